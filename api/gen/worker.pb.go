@@ -2,7 +2,7 @@
 // versions:
 // 	protoc-gen-go v1.34.2
 // 	protoc        v5.29.1
-// source: worker.proto
+// source: jobworker/v1/worker.proto
 
 package __
 
@@ -31,7 +31,7 @@ type Jobs struct {
 func (x *Jobs) Reset() {
 	*x = Jobs{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[0]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -44,7 +44,7 @@ func (x *Jobs) String() string {
 func (*Jobs) ProtoMessage() {}
 
 func (x *Jobs) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[0]
+	mi := &file_jobworker_v1_worker_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,7 +57,7 @@ func (x *Jobs) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Jobs.ProtoReflect.Descriptor instead.
 func (*Jobs) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{0}
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{0}
 }
 
 func (x *Jobs) GetJobs() []*Job {
@@ -72,22 +72,52 @@ type Job struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Command   string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	Args      []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	MaxCPU    int32    `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"`
-	MaxMemory int32    `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"`
-	MaxIOBPS  int32    `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"`
-	Status    string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	StartTime string   `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	EndTime   string   `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
-	ExitCode  int32    `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS            int32             `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"` // use ioLimitBPS
+	Status              string            `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime           string            `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime             string            `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	ExitCode            int32             `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Env                 map[string]string `protobuf:"bytes,11,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecretEnv           map[string]string `protobuf:"bytes,12,rep,name=secretEnv,proto3" json:"secretEnv,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	OutputLocation      string            `protobuf:"bytes,13,opt,name=outputLocation,proto3" json:"outputLocation,omitempty"`  // set once output has been offloaded to object storage
+	WorkspaceBytes      int64             `protobuf:"varint,14,opt,name=workspaceBytes,proto3" json:"workspaceBytes,omitempty"` // workspace disk usage, measured when the job finishes
+	RestartPolicy       string            `protobuf:"bytes,15,opt,name=restartPolicy,proto3" json:"restartPolicy,omitempty"`
+	MaxRestarts         int32             `protobuf:"varint,16,opt,name=maxRestarts,proto3" json:"maxRestarts,omitempty"`
+	Restarts            int32             `protobuf:"varint,17,opt,name=restarts,proto3" json:"restarts,omitempty"` // times the process has been relaunched
+	HealthProbe         *HealthProbe      `protobuf:"bytes,18,opt,name=healthProbe,proto3" json:"healthProbe,omitempty"`
+	Health              string            `protobuf:"bytes,19,opt,name=health,proto3" json:"health,omitempty"` // HEALTHY or UNHEALTHY once probed
+	GroupId             string            `protobuf:"bytes,20,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Labels              map[string]string `protobuf:"bytes,21,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CpuLimitMillis      int64             `protobuf:"varint,22,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes    int64             `protobuf:"varint,23,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS          int64             `protobuf:"varint,24,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"`                    // block IO bytes per second
+	Isolation           string            `protobuf:"bytes,25,opt,name=isolation,proto3" json:"isolation,omitempty"`                       // "full", "cgroups", "process", "privileged" or "sandbox"
+	CpuThrottledPercent float64           `protobuf:"fixed64,26,opt,name=cpuThrottledPercent,proto3" json:"cpuThrottledPercent,omitempty"` // share of CPU periods the CPU limit throttled the job in, measured when the job finishes
+	Qos                 string            `protobuf:"bytes,27,opt,name=qos,proto3" json:"qos,omitempty"`                                   // "guaranteed", "burstable" or "best-effort"
+	Seccomp             string            `protobuf:"bytes,28,opt,name=seccomp,proto3" json:"seccomp,omitempty"`                           // "audit" or "" for no filter
+	TimeOffsetSeconds   int64             `protobuf:"varint,29,opt,name=timeOffsetSeconds,proto3" json:"timeOffsetSeconds,omitempty"`      // shift of the job's monotonic and boottime clocks
+	CoreDump            string            `protobuf:"bytes,30,opt,name=coreDump,proto3" json:"coreDump,omitempty"`                         // workspace path of the core file of a crashed job, download it with GetJobArtifact
+	CoreDumpBytes       int64             `protobuf:"varint,31,opt,name=coreDumpBytes,proto3" json:"coreDumpBytes,omitempty"`
+	LaunchMicros        int64             `protobuf:"varint,32,opt,name=launchMicros,proto3" json:"launchMicros,omitempty"`             // time the worker took to start the job, from the request to its process running
+	CleanupMicros       int64             `protobuf:"varint,33,opt,name=cleanupMicros,proto3" json:"cleanupMicros,omitempty"`           // time from the end of the job to the removal of its cgroup, 0 while pending
+	Idle                bool              `protobuf:"varint,34,opt,name=idle,proto3" json:"idle,omitempty"`                             // no output or CPU use for the job's maxIdleSeconds
+	OutputDroppedBytes  int64             `protobuf:"varint,35,opt,name=outputDroppedBytes,proto3" json:"outputDroppedBytes,omitempty"` // output dropped over the job's output rate limit, counted when the job finishes
+	Runtime             string            `protobuf:"bytes,36,opt,name=runtime,proto3" json:"runtime,omitempty"`                        // sandbox runtime the job runs through, isolation is "sandbox"
+	StatusReason        string            `protobuf:"bytes,37,opt,name=statusReason,proto3" json:"statusReason,omitempty"`              // why the job entered its status, e.g. "exited with code 1"
 }
 
 func (x *Job) Reset() {
 	*x = Job{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[1]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -100,7 +130,7 @@ func (x *Job) String() string {
 func (*Job) ProtoMessage() {}
 
 func (x *Job) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[1]
+	mi := &file_jobworker_v1_worker_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -113,7 +143,7 @@ func (x *Job) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Job.ProtoReflect.Descriptor instead.
 func (*Job) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{1}
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Job) GetId() string {
@@ -137,6 +167,7 @@ func (x *Job) GetArgs() []string {
 	return nil
 }
 
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
 func (x *Job) GetMaxCPU() int32 {
 	if x != nil {
 		return x.MaxCPU
@@ -144,6 +175,7 @@ func (x *Job) GetMaxCPU() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
 func (x *Job) GetMaxMemory() int32 {
 	if x != nil {
 		return x.MaxMemory
@@ -151,6 +183,7 @@ func (x *Job) GetMaxMemory() int32 {
 	return 0
 }
 
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
 func (x *Job) GetMaxIOBPS() int32 {
 	if x != nil {
 		return x.MaxIOBPS
@@ -186,268 +219,218 @@ func (x *Job) GetExitCode() int32 {
 	return 0
 }
 
-type EmptyRequest struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-}
-
-func (x *EmptyRequest) Reset() {
-	*x = EmptyRequest{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[2]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Job) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
 	}
+	return nil
 }
 
-func (x *EmptyRequest) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Job) GetSecretEnv() map[string]string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return nil
 }
 
-func (*EmptyRequest) ProtoMessage() {}
-
-func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[2]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Job) GetOutputLocation() string {
+	if x != nil {
+		return x.OutputLocation
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
-func (*EmptyRequest) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{2}
+func (x *Job) GetWorkspaceBytes() int64 {
+	if x != nil {
+		return x.WorkspaceBytes
+	}
+	return 0
 }
 
-type RunJobReq struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Command   string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
-	Args      []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
-	MaxCPU    int32    `protobuf:"varint,3,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"`
-	MaxMemory int32    `protobuf:"varint,4,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"`
-	MaxIOBPS  int32    `protobuf:"varint,5,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"`
+func (x *Job) GetRestartPolicy() string {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return ""
 }
 
-func (x *RunJobReq) Reset() {
-	*x = RunJobReq{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[3]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Job) GetMaxRestarts() int32 {
+	if x != nil {
+		return x.MaxRestarts
 	}
+	return 0
 }
 
-func (x *RunJobReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Job) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
 }
 
-func (*RunJobReq) ProtoMessage() {}
-
-func (x *RunJobReq) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[3]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Job) GetHealthProbe() *HealthProbe {
+	if x != nil {
+		return x.HealthProbe
 	}
-	return mi.MessageOf(x)
+	return nil
 }
 
-// Deprecated: Use RunJobReq.ProtoReflect.Descriptor instead.
-func (*RunJobReq) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{3}
+func (x *Job) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
 }
 
-func (x *RunJobReq) GetCommand() string {
+func (x *Job) GetGroupId() string {
 	if x != nil {
-		return x.Command
+		return x.GroupId
 	}
 	return ""
 }
 
-func (x *RunJobReq) GetArgs() []string {
+func (x *Job) GetLabels() map[string]string {
 	if x != nil {
-		return x.Args
+		return x.Labels
 	}
 	return nil
 }
 
-func (x *RunJobReq) GetMaxCPU() int32 {
+func (x *Job) GetCpuLimitMillis() int64 {
 	if x != nil {
-		return x.MaxCPU
+		return x.CpuLimitMillis
 	}
 	return 0
 }
 
-func (x *RunJobReq) GetMaxMemory() int32 {
+func (x *Job) GetMemoryLimitBytes() int64 {
 	if x != nil {
-		return x.MaxMemory
+		return x.MemoryLimitBytes
 	}
 	return 0
 }
 
-func (x *RunJobReq) GetMaxIOBPS() int32 {
+func (x *Job) GetIoLimitBPS() int64 {
 	if x != nil {
-		return x.MaxIOBPS
+		return x.IoLimitBPS
 	}
 	return 0
 }
 
-type RunJobRes struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
-
-	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Command   string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	Args      []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	MaxCPU    int32    `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"`
-	MaxMemory int32    `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"`
-	MaxIOBPS  int32    `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"`
-	Status    string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	StartTime string   `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	EndTime   string   `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
-	ExitCode  int32    `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
-}
-
-func (x *RunJobRes) Reset() {
-	*x = RunJobRes{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[4]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *Job) GetIsolation() string {
+	if x != nil {
+		return x.Isolation
 	}
+	return ""
 }
 
-func (x *RunJobRes) String() string {
-	return protoimpl.X.MessageStringOf(x)
-}
-
-func (*RunJobRes) ProtoMessage() {}
-
-func (x *RunJobRes) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[4]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *Job) GetCpuThrottledPercent() float64 {
+	if x != nil {
+		return x.CpuThrottledPercent
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use RunJobRes.ProtoReflect.Descriptor instead.
-func (*RunJobRes) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{4}
+func (x *Job) GetQos() string {
+	if x != nil {
+		return x.Qos
+	}
+	return ""
 }
 
-func (x *RunJobRes) GetId() string {
+func (x *Job) GetSeccomp() string {
 	if x != nil {
-		return x.Id
+		return x.Seccomp
 	}
 	return ""
 }
 
-func (x *RunJobRes) GetCommand() string {
+func (x *Job) GetTimeOffsetSeconds() int64 {
 	if x != nil {
-		return x.Command
+		return x.TimeOffsetSeconds
 	}
-	return ""
+	return 0
 }
 
-func (x *RunJobRes) GetArgs() []string {
+func (x *Job) GetCoreDump() string {
 	if x != nil {
-		return x.Args
+		return x.CoreDump
 	}
-	return nil
+	return ""
 }
 
-func (x *RunJobRes) GetMaxCPU() int32 {
+func (x *Job) GetCoreDumpBytes() int64 {
 	if x != nil {
-		return x.MaxCPU
+		return x.CoreDumpBytes
 	}
 	return 0
 }
 
-func (x *RunJobRes) GetMaxMemory() int32 {
+func (x *Job) GetLaunchMicros() int64 {
 	if x != nil {
-		return x.MaxMemory
+		return x.LaunchMicros
 	}
 	return 0
 }
 
-func (x *RunJobRes) GetMaxIOBPS() int32 {
+func (x *Job) GetCleanupMicros() int64 {
 	if x != nil {
-		return x.MaxIOBPS
+		return x.CleanupMicros
 	}
 	return 0
 }
 
-func (x *RunJobRes) GetStatus() string {
+func (x *Job) GetIdle() bool {
 	if x != nil {
-		return x.Status
+		return x.Idle
 	}
-	return ""
+	return false
 }
 
-func (x *RunJobRes) GetStartTime() string {
+func (x *Job) GetOutputDroppedBytes() int64 {
 	if x != nil {
-		return x.StartTime
+		return x.OutputDroppedBytes
 	}
-	return ""
+	return 0
 }
 
-func (x *RunJobRes) GetEndTime() string {
+func (x *Job) GetRuntime() string {
 	if x != nil {
-		return x.EndTime
+		return x.Runtime
 	}
 	return ""
 }
 
-func (x *RunJobRes) GetExitCode() int32 {
+func (x *Job) GetStatusReason() string {
 	if x != nil {
-		return x.ExitCode
+		return x.StatusReason
 	}
-	return 0
+	return ""
 }
 
-// GetJobStatus
-type GetJobStatusReq struct {
+type EmptyRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
-
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 }
 
-func (x *GetJobStatusReq) Reset() {
-	*x = GetJobStatusReq{}
+func (x *EmptyRequest) Reset() {
+	*x = EmptyRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[5]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[2]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetJobStatusReq) String() string {
+func (x *EmptyRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobStatusReq) ProtoMessage() {}
+func (*EmptyRequest) ProtoMessage() {}
 
-func (x *GetJobStatusReq) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[5]
+func (x *EmptyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[2]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -458,52 +441,76 @@ func (x *GetJobStatusReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobStatusReq.ProtoReflect.Descriptor instead.
-func (*GetJobStatusReq) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{5}
-}
-
-func (x *GetJobStatusReq) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use EmptyRequest.ProtoReflect.Descriptor instead.
+func (*EmptyRequest) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{2}
 }
 
-type GetJobStatusRes struct {
+type RunJobReq struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id        string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Command   string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
-	Args      []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
-	MaxCPU    int32    `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"`
-	MaxMemory int32    `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"`
-	MaxIOBPS  int32    `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"`
-	Status    string   `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
-	StartTime string   `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
-	EndTime   string   `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
-	ExitCode  int32    `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Command string   `protobuf:"bytes,1,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,2,rep,name=args,proto3" json:"args,omitempty"`
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,3,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,4,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS             int32             `protobuf:"varint,5,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"` // use ioLimitBPS
+	Env                  map[string]string `protobuf:"bytes,6,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	EnvFile              []byte            `protobuf:"bytes,7,opt,name=envFile,proto3" json:"envFile,omitempty"`
+	SecretEnv            map[string]string `protobuf:"bytes,8,rep,name=secretEnv,proto3" json:"secretEnv,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // env name -> secret name
+	UploadId             string            `protobuf:"bytes,9,opt,name=uploadId,proto3" json:"uploadId,omitempty"`                                                                                           // files staged with UploadJobFiles, placed in the job workspace
+	RestartPolicy        string            `protobuf:"bytes,10,opt,name=restartPolicy,proto3" json:"restartPolicy,omitempty"`                                                                                // "never" (default), "on-failure" or "always"
+	MaxRestarts          int32             `protobuf:"varint,11,opt,name=maxRestarts,proto3" json:"maxRestarts,omitempty"`                                                                                   // 0 uses the server default
+	HealthProbe          *HealthProbe      `protobuf:"bytes,12,opt,name=healthProbe,proto3" json:"healthProbe,omitempty"`
+	Labels               map[string]string `protobuf:"bytes,13,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // used to select jobs in bulk operations
+	Stdin                bool              `protobuf:"varint,14,opt,name=stdin,proto3" json:"stdin,omitempty"`                                                                                          // connect the job's stdin to WriteJobStdin instead of /dev/null
+	RequiredCapabilities []string          `protobuf:"bytes,15,rep,name=requiredCapabilities,proto3" json:"requiredCapabilities,omitempty"`                                                             // see WorkerInfo.capabilities, missing ones fail with UNIMPLEMENTED
+	Shell                bool              `protobuf:"varint,16,opt,name=shell,proto3" json:"shell,omitempty"`                                                                                          // run command as a script with /bin/sh -c, args become $1...; needs the run_shell_job permission
+	CpuLimitMillis       int64             `protobuf:"varint,17,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"`                                                                        // CPU time per second, 1000 = one core
+	MemoryLimitBytes     int64             `protobuf:"varint,18,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS           int64             `protobuf:"varint,19,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"`                     // block IO bytes per second
+	Profile              string            `protobuf:"bytes,20,opt,name=profile,proto3" json:"profile,omitempty"`                            // named limit profile from the server config, explicit limits override its values
+	Isolation            string            `protobuf:"bytes,21,opt,name=isolation,proto3" json:"isolation,omitempty"`                        // "full", "cgroups", "process" or "privileged", "" for the server default; anything but full needs the run_unisolated_job permission, privileged also run_privileged_job
+	Qos                  string            `protobuf:"bytes,22,opt,name=qos,proto3" json:"qos,omitempty"`                                    // "guaranteed", "burstable" (default) or "best-effort": CPU and IO weights, memory protection and OOM kill order under contention
+	Seccomp              string            `protobuf:"bytes,23,opt,name=seccomp,proto3" json:"seccomp,omitempty"`                            // "audit" has the kernel log every syscall the job makes without blocking any, "" or "none" for no filter
+	TimeOffsetSeconds    int64             `protobuf:"varint,24,opt,name=timeOffsetSeconds,proto3" json:"timeOffsetSeconds,omitempty"`       // shift of the job's monotonic and boottime clocks in a time namespace, wall clock time is not shifted
+	InheritEnv           *bool             `protobuf:"varint,25,opt,name=inheritEnv,proto3,oneof" json:"inheritEnv,omitempty"`               // false starts the job with only env, secretEnv and the JOB_* variables instead of the worker's environment; unset inherits
+	CommandSha256        string            `protobuf:"bytes,26,opt,name=commandSha256,proto3" json:"commandSha256,omitempty"`                // hex SHA-256 digest the resolved executable must have, init aborts the job before exec on a mismatch
+	MaxIdleSeconds       int64             `protobuf:"varint,27,opt,name=maxIdleSeconds,proto3" json:"maxIdleSeconds,omitempty"`             // a job writing no output and using no CPU this long is idle, 0 for no limit
+	IdleAction           string            `protobuf:"bytes,28,opt,name=idleAction,proto3" json:"idleAction,omitempty"`                      // "stop" (default) stops an idle job, "mark" only reports it idle
+	OutputFlushMillis    int64             `protobuf:"varint,29,opt,name=outputFlushMillis,proto3" json:"outputFlushMillis,omitempty"`       // how long output may be held to be stored and streamed in larger chunks, 0 for the server's interval, -1 to store every write as it comes
+	OutputBytesPerSecond int64             `protobuf:"varint,30,opt,name=outputBytesPerSecond,proto3" json:"outputBytesPerSecond,omitempty"` // rate stdout and stderr together are stored at, at least 1024, up to the server's limit; 0 for the server's limit
+	OutputLimitAction    string            `protobuf:"bytes,31,opt,name=outputLimitAction,proto3" json:"outputLimitAction,omitempty"`        // "throttle" blocks the job's writes, "truncate" drops the output over the rate; "" for the server's action
+	Inputs               []*JobInput       `protobuf:"bytes,32,rep,name=inputs,proto3" json:"inputs,omitempty"`                              // object storage files streamed in as the job reads them instead of being staged upfront
+	AllowedWindows       []string          `protobuf:"bytes,33,rep,name=allowedWindows,proto3" json:"allowedWindows,omitempty"`              // "[DAYS] HH:MM-HH:MM [ZONE]", e.g. "Mon-Fri 18:00-06:00"; the job is held outside them
+	QueueTimeoutSeconds  int64             `protobuf:"varint,34,opt,name=queueTimeoutSeconds,proto3" json:"queueTimeoutSeconds,omitempty"`   // the job is marked EXPIRED instead of starting later than this after submitTime; 0 for no limit
+	SubmitTime           string            `protobuf:"bytes,35,opt,name=submitTime,proto3" json:"submitTime,omitempty"`                      // RFC 3339 time the queue timeout counts from, when the server received the request if unset
+	Sockets              []*JobSocket      `protobuf:"bytes,36,rep,name=sockets,proto3" json:"sockets,omitempty"`                            // listening sockets the worker opens and passes in as LISTEN_FDS; needs the sockets capability
+	Signature            []byte            `protobuf:"bytes,37,opt,name=signature,proto3" json:"signature,omitempty"`                        // Ed25519 signature over the request's deterministic encoding without this field, see client.SignRunJobReq; workers requiring signed specs refuse requests without a valid one
+	Runtime              string            `protobuf:"bytes,38,opt,name=runtime,proto3" json:"runtime,omitempty"`                            // sandbox runtime from the server's worker.runtimes to run the job through instead of an isolation mode; needs the sandbox capability
 }
 
-func (x *GetJobStatusRes) Reset() {
-	*x = GetJobStatusRes{}
+func (x *RunJobReq) Reset() {
+	*x = RunJobReq{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[6]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetJobStatusRes) String() string {
+func (x *RunJobReq) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobStatusRes) ProtoMessage() {}
+func (*RunJobReq) ProtoMessage() {}
 
-func (x *GetJobStatusRes) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[6]
+func (x *RunJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -514,157 +521,309 @@ func (x *GetJobStatusRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobStatusRes.ProtoReflect.Descriptor instead.
-func (*GetJobStatusRes) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{6}
-}
-
-func (x *GetJobStatusRes) GetId() string {
-	if x != nil {
-		return x.Id
-	}
-	return ""
+// Deprecated: Use RunJobReq.ProtoReflect.Descriptor instead.
+func (*RunJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *GetJobStatusRes) GetCommand() string {
+func (x *RunJobReq) GetCommand() string {
 	if x != nil {
 		return x.Command
 	}
 	return ""
 }
 
-func (x *GetJobStatusRes) GetArgs() []string {
+func (x *RunJobReq) GetArgs() []string {
 	if x != nil {
 		return x.Args
 	}
 	return nil
 }
 
-func (x *GetJobStatusRes) GetMaxCPU() int32 {
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobReq) GetMaxCPU() int32 {
 	if x != nil {
 		return x.MaxCPU
 	}
 	return 0
 }
 
-func (x *GetJobStatusRes) GetMaxMemory() int32 {
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobReq) GetMaxMemory() int32 {
 	if x != nil {
 		return x.MaxMemory
 	}
 	return 0
 }
 
-func (x *GetJobStatusRes) GetMaxIOBPS() int32 {
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobReq) GetMaxIOBPS() int32 {
 	if x != nil {
 		return x.MaxIOBPS
 	}
 	return 0
 }
 
-func (x *GetJobStatusRes) GetStatus() string {
+func (x *RunJobReq) GetEnv() map[string]string {
 	if x != nil {
-		return x.Status
+		return x.Env
 	}
-	return ""
+	return nil
 }
 
-func (x *GetJobStatusRes) GetStartTime() string {
+func (x *RunJobReq) GetEnvFile() []byte {
 	if x != nil {
-		return x.StartTime
+		return x.EnvFile
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetSecretEnv() map[string]string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
 	}
 	return ""
 }
 
-func (x *GetJobStatusRes) GetEndTime() string {
+func (x *RunJobReq) GetRestartPolicy() string {
 	if x != nil {
-		return x.EndTime
+		return x.RestartPolicy
 	}
 	return ""
 }
 
-func (x *GetJobStatusRes) GetExitCode() int32 {
+func (x *RunJobReq) GetMaxRestarts() int32 {
 	if x != nil {
-		return x.ExitCode
+		return x.MaxRestarts
 	}
 	return 0
 }
 
-// StopJob
-type StopJobReq struct {
-	state         protoimpl.MessageState
-	sizeCache     protoimpl.SizeCache
-	unknownFields protoimpl.UnknownFields
+func (x *RunJobReq) GetHealthProbe() *HealthProbe {
+	if x != nil {
+		return x.HealthProbe
+	}
+	return nil
+}
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+func (x *RunJobReq) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
 }
 
-func (x *StopJobReq) Reset() {
-	*x = StopJobReq{}
-	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[7]
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		ms.StoreMessageInfo(mi)
+func (x *RunJobReq) GetStdin() bool {
+	if x != nil {
+		return x.Stdin
 	}
+	return false
 }
 
-func (x *StopJobReq) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *RunJobReq) GetRequiredCapabilities() []string {
+	if x != nil {
+		return x.RequiredCapabilities
+	}
+	return nil
 }
 
-func (*StopJobReq) ProtoMessage() {}
+func (x *RunJobReq) GetShell() bool {
+	if x != nil {
+		return x.Shell
+	}
+	return false
+}
 
-func (x *StopJobReq) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[7]
-	if protoimpl.UnsafeEnabled && x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+func (x *RunJobReq) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use StopJobReq.ProtoReflect.Descriptor instead.
-func (*StopJobReq) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{7}
+func (x *RunJobReq) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
 }
 
-func (x *StopJobReq) GetId() string {
+func (x *RunJobReq) GetIoLimitBPS() int64 {
 	if x != nil {
-		return x.Id
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetProfile() string {
+	if x != nil {
+		return x.Profile
 	}
 	return ""
 }
 
-type StopJobRes struct {
+func (x *RunJobReq) GetIsolation() string {
+	if x != nil {
+		return x.Isolation
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetQos() string {
+	if x != nil {
+		return x.Qos
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetSeccomp() string {
+	if x != nil {
+		return x.Seccomp
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetTimeOffsetSeconds() int64 {
+	if x != nil {
+		return x.TimeOffsetSeconds
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetInheritEnv() bool {
+	if x != nil && x.InheritEnv != nil {
+		return *x.InheritEnv
+	}
+	return false
+}
+
+func (x *RunJobReq) GetCommandSha256() string {
+	if x != nil {
+		return x.CommandSha256
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetMaxIdleSeconds() int64 {
+	if x != nil {
+		return x.MaxIdleSeconds
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetIdleAction() string {
+	if x != nil {
+		return x.IdleAction
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetOutputFlushMillis() int64 {
+	if x != nil {
+		return x.OutputFlushMillis
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetOutputBytesPerSecond() int64 {
+	if x != nil {
+		return x.OutputBytesPerSecond
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetOutputLimitAction() string {
+	if x != nil {
+		return x.OutputLimitAction
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetInputs() []*JobInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetAllowedWindows() []string {
+	if x != nil {
+		return x.AllowedWindows
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetQueueTimeoutSeconds() int64 {
+	if x != nil {
+		return x.QueueTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *RunJobReq) GetSubmitTime() string {
+	if x != nil {
+		return x.SubmitTime
+	}
+	return ""
+}
+
+func (x *RunJobReq) GetSockets() []*JobSocket {
+	if x != nil {
+		return x.Sockets
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+func (x *RunJobReq) GetRuntime() string {
+	if x != nil {
+		return x.Runtime
+	}
+	return ""
+}
+
+// Listening socket passed into a job as a descriptor from 3 up, in the order
+// given, with its name in LISTEN_FDNAMES. Jobs on the same address share the
+// socket, which stays open until the last of them ends.
+type JobSocket struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Status   string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
-	EndTime  string `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`
-	ExitCode int32  `protobuf:"varint,4,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Name    string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // letters, digits, '_', '.' and '-'
+	Address string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // tcp://HOST:PORT, or unix://NAME in the worker's socket directory
 }
 
-func (x *StopJobRes) Reset() {
-	*x = StopJobRes{}
+func (x *JobSocket) Reset() {
+	*x = JobSocket{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[8]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *StopJobRes) String() string {
+func (x *JobSocket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopJobRes) ProtoMessage() {}
+func (*JobSocket) ProtoMessage() {}
 
-func (x *StopJobRes) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[8]
+func (x *JobSocket) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -675,65 +834,116 @@ func (x *StopJobRes) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopJobRes.ProtoReflect.Descriptor instead.
-func (*StopJobRes) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use JobSocket.ProtoReflect.Descriptor instead.
+func (*JobSocket) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StopJobRes) GetId() string {
+func (x *JobSocket) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
 	}
 	return ""
 }
 
-func (x *StopJobRes) GetStatus() string {
+func (x *JobSocket) GetAddress() string {
 	if x != nil {
-		return x.Status
+		return x.Address
 	}
 	return ""
 }
 
-func (x *StopJobRes) GetEndTime() string {
+// Object storage file a job reads from its workspace. The file is a named pipe
+// the worker streams the object into each time the job opens it, so it can
+// only be read sequentially.
+type JobInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Source string `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"` // s3://bucket/key or gs://bucket/key
+	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`     // workspace relative path
+}
+
+func (x *JobInput) Reset() {
+	*x = JobInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobInput) ProtoMessage() {}
+
+func (x *JobInput) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobInput.ProtoReflect.Descriptor instead.
+func (*JobInput) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *JobInput) GetSource() string {
 	if x != nil {
-		return x.EndTime
+		return x.Source
 	}
 	return ""
 }
 
-func (x *StopJobRes) GetExitCode() int32 {
+func (x *JobInput) GetPath() string {
 	if x != nil {
-		return x.ExitCode
+		return x.Path
 	}
-	return 0
+	return ""
 }
 
-// GetJobLogs
-type GetJobLogsReq struct {
+// Liveness probe, run while the job's process is alive
+type HealthProbe struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type                string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                        // "exec", "tcp" or "http"
+	Command             []string `protobuf:"bytes,2,rep,name=command,proto3" json:"command,omitempty"`                  // exec: run inside the job's namespaces
+	Port                int32    `protobuf:"varint,3,opt,name=port,proto3" json:"port,omitempty"`                       // tcp, http
+	Path                string   `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`                        // http
+	IntervalSeconds     int32    `protobuf:"varint,5,opt,name=intervalSeconds,proto3" json:"intervalSeconds,omitempty"` // default 10
+	TimeoutSeconds      int32    `protobuf:"varint,6,opt,name=timeoutSeconds,proto3" json:"timeoutSeconds,omitempty"`   // default 1
+	InitialDelaySeconds int32    `protobuf:"varint,7,opt,name=initialDelaySeconds,proto3" json:"initialDelaySeconds,omitempty"`
+	FailureThreshold    int32    `protobuf:"varint,8,opt,name=failureThreshold,proto3" json:"failureThreshold,omitempty"` // default 3
 }
 
-func (x *GetJobLogsReq) Reset() {
-	*x = GetJobLogsReq{}
+func (x *HealthProbe) Reset() {
+	*x = HealthProbe{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[9]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *GetJobLogsReq) String() string {
+func (x *HealthProbe) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetJobLogsReq) ProtoMessage() {}
+func (*HealthProbe) ProtoMessage() {}
 
-func (x *GetJobLogsReq) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[9]
+func (x *HealthProbe) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -744,43 +954,121 @@ func (x *GetJobLogsReq) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetJobLogsReq.ProtoReflect.Descriptor instead.
-func (*GetJobLogsReq) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use HealthProbe.ProtoReflect.Descriptor instead.
+func (*HealthProbe) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetJobLogsReq) GetId() string {
+func (x *HealthProbe) GetType() string {
 	if x != nil {
-		return x.Id
+		return x.Type
 	}
 	return ""
 }
 
-type DataChunk struct {
+func (x *HealthProbe) GetCommand() []string {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *HealthProbe) GetPort() int32 {
+	if x != nil {
+		return x.Port
+	}
+	return 0
+}
+
+func (x *HealthProbe) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *HealthProbe) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *HealthProbe) GetTimeoutSeconds() int32 {
+	if x != nil {
+		return x.TimeoutSeconds
+	}
+	return 0
+}
+
+func (x *HealthProbe) GetInitialDelaySeconds() int32 {
+	if x != nil {
+		return x.InitialDelaySeconds
+	}
+	return 0
+}
+
+func (x *HealthProbe) GetFailureThreshold() int32 {
+	if x != nil {
+		return x.FailureThreshold
+	}
+	return 0
+}
+
+type RunJobRes struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS         int32             `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"` // use ioLimitBPS
+	Status           string            `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime        string            `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime          string            `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	ExitCode         int32             `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Env              map[string]string `protobuf:"bytes,11,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecretEnv        map[string]string `protobuf:"bytes,12,rep,name=secretEnv,proto3" json:"secretEnv,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	OutputLocation   string            `protobuf:"bytes,13,opt,name=outputLocation,proto3" json:"outputLocation,omitempty"`  // set once output has been offloaded to object storage
+	WorkspaceBytes   int64             `protobuf:"varint,14,opt,name=workspaceBytes,proto3" json:"workspaceBytes,omitempty"` // workspace disk usage, measured when the job finishes
+	RestartPolicy    string            `protobuf:"bytes,15,opt,name=restartPolicy,proto3" json:"restartPolicy,omitempty"`
+	MaxRestarts      int32             `protobuf:"varint,16,opt,name=maxRestarts,proto3" json:"maxRestarts,omitempty"`
+	Restarts         int32             `protobuf:"varint,17,opt,name=restarts,proto3" json:"restarts,omitempty"` // times the process has been relaunched
+	HealthProbe      *HealthProbe      `protobuf:"bytes,18,opt,name=healthProbe,proto3" json:"healthProbe,omitempty"`
+	Health           string            `protobuf:"bytes,19,opt,name=health,proto3" json:"health,omitempty"` // HEALTHY or UNHEALTHY once probed
+	GroupId          string            `protobuf:"bytes,20,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Labels           map[string]string `protobuf:"bytes,21,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CpuLimitMillis   int64             `protobuf:"varint,22,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes int64             `protobuf:"varint,23,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS       int64             `protobuf:"varint,24,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"` // block IO bytes per second
+	Isolation        string            `protobuf:"bytes,25,opt,name=isolation,proto3" json:"isolation,omitempty"`    // "full", "cgroups", "process", "privileged" or "sandbox"
+	Qos              string            `protobuf:"bytes,26,opt,name=qos,proto3" json:"qos,omitempty"`                // "guaranteed", "burstable" or "best-effort"
+	Runtime          string            `protobuf:"bytes,27,opt,name=runtime,proto3" json:"runtime,omitempty"`        // sandbox runtime the job runs through, isolation is "sandbox"
 }
 
-func (x *DataChunk) Reset() {
-	*x = DataChunk{}
+func (x *RunJobRes) Reset() {
+	*x = RunJobRes{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_worker_proto_msgTypes[10]
+		mi := &file_jobworker_v1_worker_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
 }
 
-func (x *DataChunk) String() string {
+func (x *RunJobRes) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DataChunk) ProtoMessage() {}
+func (*RunJobRes) ProtoMessage() {}
 
-func (x *DataChunk) ProtoReflect() protoreflect.Message {
-	mi := &file_worker_proto_msgTypes[10]
+func (x *RunJobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -791,178 +1079,7708 @@ func (x *DataChunk) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
-func (*DataChunk) Descriptor() ([]byte, []int) {
-	return file_worker_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use RunJobRes.ProtoReflect.Descriptor instead.
+func (*RunJobRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *DataChunk) GetPayload() []byte {
+func (x *RunJobRes) GetId() string {
 	if x != nil {
-		return x.Payload
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-var File_worker_proto protoreflect.FileDescriptor
-
-var file_worker_proto_rawDesc = []byte{
-	0x0a, 0x0c, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
-	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x22, 0x27, 0x0a, 0x04, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1f,
-	0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x77,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x4a, 0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22,
-	0x81, 0x02, 0x0a, 0x03, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61,
-	0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e,
-	0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x1c, 0x0a,
-	0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d,
-	0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d,
-	0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12,
-	0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a,
-	0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43,
-	0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43,
-	0x6f, 0x64, 0x65, 0x22, 0x0e, 0x0a, 0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x22, 0x8b, 0x01, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
-	0x71, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61,
-	0x72, 0x67, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12,
-	0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
-	0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65,
-	0x6d, 0x6f, 0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50,
-	0x53, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50,
-	0x53, 0x22, 0x87, 0x02, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x16, 0x0a,
-	0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x6d,
-	0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f,
-	0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d,
-	0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18,
-	0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12,
-	0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
-	0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
-	0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65,
-	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x21, 0x0a, 0x0f, 0x47,
-	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e,
-	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x8d,
-	0x02, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
-	0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
-	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
-	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x1c, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d,
-	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x6d, 0x61, 0x78,
-	0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42,
-	0x50, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42,
-	0x50, 0x53, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74,
-	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
-	0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54,
-	0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
-	0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a,
-	0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x1c,
-	0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02,
-	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6a, 0x0a, 0x0a,
-	0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
-	0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
-	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
-	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x22, 0x1f, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4a,
-	0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x25, 0x0a, 0x09, 0x44, 0x61, 0x74,
-	0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
-	0x32, 0xa3, 0x02, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x30, 0x0a, 0x06, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b,
-	0x65, 0x72, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x11, 0x2e, 0x77,
-	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x22,
-	0x00, 0x12, 0x42, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
-	0x73, 0x12, 0x17, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f,
-	0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x77, 0x6f, 0x72,
-	0x6b, 0x65, 0x72, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
-	0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x33, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62,
-	0x12, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f,
-	0x62, 0x52, 0x65, 0x71, 0x1a, 0x12, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x53, 0x74,
-	0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x38, 0x0a, 0x0a, 0x47, 0x65,
-	0x74, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x73, 0x12, 0x15, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65,
-	0x72, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a,
-	0x11, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x75,
-	0x6e, 0x6b, 0x30, 0x01, 0x12, 0x30, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73,
-	0x12, 0x14, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0c, 0x2e, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
-	0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x42, 0x04, 0x5a, 0x02, 0x2e, 0x2f, 0x62, 0x06, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x33,
+func (x *RunJobRes) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
 }
 
-var (
-	file_worker_proto_rawDescOnce sync.Once
-	file_worker_proto_rawDescData = file_worker_proto_rawDesc
-)
+func (x *RunJobRes) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
 
-func file_worker_proto_rawDescGZIP() []byte {
-	file_worker_proto_rawDescOnce.Do(func() {
-		file_worker_proto_rawDescData = protoimpl.X.CompressGZIP(file_worker_proto_rawDescData)
-	})
-	return file_worker_proto_rawDescData
-}
-
-var file_worker_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
-var file_worker_proto_goTypes = []any{
-	(*Jobs)(nil),            // 0: worker.Jobs
-	(*Job)(nil),             // 1: worker.Job
-	(*EmptyRequest)(nil),    // 2: worker.EmptyRequest
-	(*RunJobReq)(nil),       // 3: worker.RunJobReq
-	(*RunJobRes)(nil),       // 4: worker.RunJobRes
-	(*GetJobStatusReq)(nil), // 5: worker.GetJobStatusReq
-	(*GetJobStatusRes)(nil), // 6: worker.GetJobStatusRes
-	(*StopJobReq)(nil),      // 7: worker.StopJobReq
-	(*StopJobRes)(nil),      // 8: worker.StopJobRes
-	(*GetJobLogsReq)(nil),   // 9: worker.GetJobLogsReq
-	(*DataChunk)(nil),       // 10: worker.DataChunk
-}
-var file_worker_proto_depIdxs = []int32{
-	1,  // 0: worker.Jobs.jobs:type_name -> worker.Job
-	3,  // 1: worker.JobService.RunJob:input_type -> worker.RunJobReq
-	5,  // 2: worker.JobService.GetJobStatus:input_type -> worker.GetJobStatusReq
-	7,  // 3: worker.JobService.StopJob:input_type -> worker.StopJobReq
-	9,  // 4: worker.JobService.GetJobLogs:input_type -> worker.GetJobLogsReq
-	2,  // 5: worker.JobService.ListJobs:input_type -> worker.EmptyRequest
-	4,  // 6: worker.JobService.RunJob:output_type -> worker.RunJobRes
-	6,  // 7: worker.JobService.GetJobStatus:output_type -> worker.GetJobStatusRes
-	8,  // 8: worker.JobService.StopJob:output_type -> worker.StopJobRes
-	10, // 9: worker.JobService.GetJobLogs:output_type -> worker.DataChunk
-	0,  // 10: worker.JobService.ListJobs:output_type -> worker.Jobs
-	6,  // [6:11] is the sub-list for method output_type
-	1,  // [1:6] is the sub-list for method input_type
-	1,  // [1:1] is the sub-list for extension type_name
-	1,  // [1:1] is the sub-list for extension extendee
-	0,  // [0:1] is the sub-list for field type_name
-}
-
-func init() { file_worker_proto_init() }
-func file_worker_proto_init() {
-	if File_worker_proto != nil {
-		return
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobRes) GetMaxCPU() int32 {
+	if x != nil {
+		return x.MaxCPU
 	}
-	if !protoimpl.UnsafeEnabled {
-		file_worker_proto_msgTypes[0].Exporter = func(v any, i int) any {
-			switch v := v.(*Jobs); i {
-			case 0:
-				return &v.state
-			case 1:
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobRes) GetMaxMemory() int32 {
+	if x != nil {
+		return x.MaxMemory
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *RunJobRes) GetMaxIOBPS() int32 {
+	if x != nil {
+		return x.MaxIOBPS
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *RunJobRes) GetSecretEnv() map[string]string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return nil
+}
+
+func (x *RunJobRes) GetOutputLocation() string {
+	if x != nil {
+		return x.OutputLocation
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetWorkspaceBytes() int64 {
+	if x != nil {
+		return x.WorkspaceBytes
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetRestartPolicy() string {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetMaxRestarts() int32 {
+	if x != nil {
+		return x.MaxRestarts
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetHealthProbe() *HealthProbe {
+	if x != nil {
+		return x.HealthProbe
+	}
+	return nil
+}
+
+func (x *RunJobRes) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *RunJobRes) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+func (x *RunJobRes) GetIsolation() string {
+	if x != nil {
+		return x.Isolation
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetQos() string {
+	if x != nil {
+		return x.Qos
+	}
+	return ""
+}
+
+func (x *RunJobRes) GetRuntime() string {
+	if x != nil {
+		return x.Runtime
+	}
+	return ""
+}
+
+// RunJobAttached
+// The first message carries the started job, then output chunks follow (empty
+// ones are keepalives) and the last message carries the final status
+type RunJobAttachedRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Event:
+	//	*RunJobAttachedRes_Started
+	//	*RunJobAttachedRes_Output
+	//	*RunJobAttachedRes_Exit
+	Event isRunJobAttachedRes_Event `protobuf_oneof:"event"`
+}
+
+func (x *RunJobAttachedRes) Reset() {
+	*x = RunJobAttachedRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunJobAttachedRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobAttachedRes) ProtoMessage() {}
+
+func (x *RunJobAttachedRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobAttachedRes.ProtoReflect.Descriptor instead.
+func (*RunJobAttachedRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{8}
+}
+
+func (m *RunJobAttachedRes) GetEvent() isRunJobAttachedRes_Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+func (x *RunJobAttachedRes) GetStarted() *RunJobRes {
+	if x, ok := x.GetEvent().(*RunJobAttachedRes_Started); ok {
+		return x.Started
+	}
+	return nil
+}
+
+func (x *RunJobAttachedRes) GetOutput() []byte {
+	if x, ok := x.GetEvent().(*RunJobAttachedRes_Output); ok {
+		return x.Output
+	}
+	return nil
+}
+
+func (x *RunJobAttachedRes) GetExit() *JobExit {
+	if x, ok := x.GetEvent().(*RunJobAttachedRes_Exit); ok {
+		return x.Exit
+	}
+	return nil
+}
+
+type isRunJobAttachedRes_Event interface {
+	isRunJobAttachedRes_Event()
+}
+
+type RunJobAttachedRes_Started struct {
+	Started *RunJobRes `protobuf:"bytes,1,opt,name=started,proto3,oneof"`
+}
+
+type RunJobAttachedRes_Output struct {
+	Output []byte `protobuf:"bytes,2,opt,name=output,proto3,oneof"`
+}
+
+type RunJobAttachedRes_Exit struct {
+	Exit *JobExit `protobuf:"bytes,3,opt,name=exit,proto3,oneof"`
+}
+
+func (*RunJobAttachedRes_Started) isRunJobAttachedRes_Event() {}
+
+func (*RunJobAttachedRes_Output) isRunJobAttachedRes_Event() {}
+
+func (*RunJobAttachedRes_Exit) isRunJobAttachedRes_Event() {}
+
+type JobExit struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status   string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // COMPLETED, FAILED or STOPPED
+	ExitCode int32  `protobuf:"varint,2,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	EndTime  string `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	Restarts int32  `protobuf:"varint,4,opt,name=restarts,proto3" json:"restarts,omitempty"`
+}
+
+func (x *JobExit) Reset() {
+	*x = JobExit{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobExit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobExit) ProtoMessage() {}
+
+func (x *JobExit) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobExit.ProtoReflect.Descriptor instead.
+func (*JobExit) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *JobExit) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobExit) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *JobExit) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *JobExit) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+// ValidateJob
+// Runs the checks RunJob performs without starting anything
+type ValidationError struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Field   string `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"` // e.g. "command", "environment", "secretEnv"
+	Message string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+}
+
+func (x *ValidationError) Reset() {
+	*x = ValidationError{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidationError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidationError) ProtoMessage() {}
+
+func (x *ValidationError) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidationError.ProtoReflect.Descriptor instead.
+func (*ValidationError) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ValidationError) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *ValidationError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ValidateJobRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Valid           bool               `protobuf:"varint,1,opt,name=valid,proto3" json:"valid,omitempty"`
+	Errors          []*ValidationError `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty"`
+	ResolvedCommand string             `protobuf:"bytes,3,opt,name=resolvedCommand,proto3" json:"resolvedCommand,omitempty"` // command path the job would run
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS int32 `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"` // use ioLimitBPS
+	// limits the job would run with, defaults applied
+	CpuLimitMillis   int64 `protobuf:"varint,7,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes int64 `protobuf:"varint,8,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS       int64 `protobuf:"varint,9,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"` // block IO bytes per second
+}
+
+func (x *ValidateJobRes) Reset() {
+	*x = ValidateJobRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ValidateJobRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ValidateJobRes) ProtoMessage() {}
+
+func (x *ValidateJobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ValidateJobRes.ProtoReflect.Descriptor instead.
+func (*ValidateJobRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ValidateJobRes) GetValid() bool {
+	if x != nil {
+		return x.Valid
+	}
+	return false
+}
+
+func (x *ValidateJobRes) GetErrors() []*ValidationError {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+func (x *ValidateJobRes) GetResolvedCommand() string {
+	if x != nil {
+		return x.ResolvedCommand
+	}
+	return ""
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *ValidateJobRes) GetMaxCPU() int32 {
+	if x != nil {
+		return x.MaxCPU
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *ValidateJobRes) GetMaxMemory() int32 {
+	if x != nil {
+		return x.MaxMemory
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *ValidateJobRes) GetMaxIOBPS() int32 {
+	if x != nil {
+		return x.MaxIOBPS
+	}
+	return 0
+}
+
+func (x *ValidateJobRes) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *ValidateJobRes) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *ValidateJobRes) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+// GetJobStatus
+type GetJobStatusReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobStatusReq) Reset() {
+	*x = GetJobStatusReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusReq) ProtoMessage() {}
+
+func (x *GetJobStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusReq.ProtoReflect.Descriptor instead.
+func (*GetJobStatusReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetJobStatusReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetJobStatusRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args    []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,4,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,5,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS            int32             `protobuf:"varint,6,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"` // use ioLimitBPS
+	Status              string            `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime           string            `protobuf:"bytes,8,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime             string            `protobuf:"bytes,9,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	ExitCode            int32             `protobuf:"varint,10,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Env                 map[string]string `protobuf:"bytes,11,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	SecretEnv           map[string]string `protobuf:"bytes,12,rep,name=secretEnv,proto3" json:"secretEnv,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	OutputLocation      string            `protobuf:"bytes,13,opt,name=outputLocation,proto3" json:"outputLocation,omitempty"`  // set once output has been offloaded to object storage
+	WorkspaceBytes      int64             `protobuf:"varint,14,opt,name=workspaceBytes,proto3" json:"workspaceBytes,omitempty"` // workspace disk usage, measured when the job finishes
+	RestartPolicy       string            `protobuf:"bytes,15,opt,name=restartPolicy,proto3" json:"restartPolicy,omitempty"`
+	MaxRestarts         int32             `protobuf:"varint,16,opt,name=maxRestarts,proto3" json:"maxRestarts,omitempty"`
+	Restarts            int32             `protobuf:"varint,17,opt,name=restarts,proto3" json:"restarts,omitempty"` // times the process has been relaunched
+	HealthProbe         *HealthProbe      `protobuf:"bytes,18,opt,name=healthProbe,proto3" json:"healthProbe,omitempty"`
+	Health              string            `protobuf:"bytes,19,opt,name=health,proto3" json:"health,omitempty"` // HEALTHY or UNHEALTHY once probed
+	GroupId             string            `protobuf:"bytes,20,opt,name=groupId,proto3" json:"groupId,omitempty"`
+	Labels              map[string]string `protobuf:"bytes,21,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	CpuLimitMillis      int64             `protobuf:"varint,22,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes    int64             `protobuf:"varint,23,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS          int64             `protobuf:"varint,24,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"`                    // block IO bytes per second
+	Isolation           string            `protobuf:"bytes,25,opt,name=isolation,proto3" json:"isolation,omitempty"`                       // "full", "cgroups", "process", "privileged" or "sandbox"
+	CpuThrottledPercent float64           `protobuf:"fixed64,26,opt,name=cpuThrottledPercent,proto3" json:"cpuThrottledPercent,omitempty"` // share of CPU periods the CPU limit throttled the job in, measured when the job finishes
+	Qos                 string            `protobuf:"bytes,27,opt,name=qos,proto3" json:"qos,omitempty"`                                   // "guaranteed", "burstable" or "best-effort"
+	Seccomp             string            `protobuf:"bytes,28,opt,name=seccomp,proto3" json:"seccomp,omitempty"`                           // "audit" or "" for no filter
+	TimeOffsetSeconds   int64             `protobuf:"varint,29,opt,name=timeOffsetSeconds,proto3" json:"timeOffsetSeconds,omitempty"`      // shift of the job's monotonic and boottime clocks
+	CoreDump            string            `protobuf:"bytes,30,opt,name=coreDump,proto3" json:"coreDump,omitempty"`                         // workspace path of the core file of a crashed job, download it with GetJobArtifact
+	CoreDumpBytes       int64             `protobuf:"varint,31,opt,name=coreDumpBytes,proto3" json:"coreDumpBytes,omitempty"`
+	LaunchMicros        int64             `protobuf:"varint,32,opt,name=launchMicros,proto3" json:"launchMicros,omitempty"`             // time the worker took to start the job, from the request to its process running
+	CleanupMicros       int64             `protobuf:"varint,33,opt,name=cleanupMicros,proto3" json:"cleanupMicros,omitempty"`           // time from the end of the job to the removal of its cgroup, 0 while pending
+	Idle                bool              `protobuf:"varint,34,opt,name=idle,proto3" json:"idle,omitempty"`                             // no output or CPU use for the job's maxIdleSeconds
+	OutputDroppedBytes  int64             `protobuf:"varint,35,opt,name=outputDroppedBytes,proto3" json:"outputDroppedBytes,omitempty"` // output dropped over the job's output rate limit, counted when the job finishes
+	Result              string            `protobuf:"bytes,36,opt,name=result,proto3" json:"result,omitempty"`                          // JSON document the job wrote to JOB_RESULT_PATH, read when it finishes
+	ResultError         string            `protobuf:"bytes,37,opt,name=resultError,proto3" json:"resultError,omitempty"`                // why the job's result was not kept: too large, not JSON or not a regular file
+	Sockets             []*JobSocket      `protobuf:"bytes,38,rep,name=sockets,proto3" json:"sockets,omitempty"`                        // listening sockets passed into the job
+	Runtime             string            `protobuf:"bytes,39,opt,name=runtime,proto3" json:"runtime,omitempty"`                        // sandbox runtime the job runs through, isolation is "sandbox"
+	StatusReason        string            `protobuf:"bytes,40,opt,name=statusReason,proto3" json:"statusReason,omitempty"`              // why the job entered its status, e.g. "exited with code 1"
+}
+
+func (x *GetJobStatusRes) Reset() {
+	*x = GetJobStatusRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobStatusRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobStatusRes) ProtoMessage() {}
+
+func (x *GetJobStatusRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobStatusRes.ProtoReflect.Descriptor instead.
+func (*GetJobStatusRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetJobStatusRes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *GetJobStatusRes) GetMaxCPU() int32 {
+	if x != nil {
+		return x.MaxCPU
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *GetJobStatusRes) GetMaxMemory() int32 {
+	if x != nil {
+		return x.MaxMemory
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *GetJobStatusRes) GetMaxIOBPS() int32 {
+	if x != nil {
+		return x.MaxIOBPS
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *GetJobStatusRes) GetSecretEnv() map[string]string {
+	if x != nil {
+		return x.SecretEnv
+	}
+	return nil
+}
+
+func (x *GetJobStatusRes) GetOutputLocation() string {
+	if x != nil {
+		return x.OutputLocation
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetWorkspaceBytes() int64 {
+	if x != nil {
+		return x.WorkspaceBytes
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetRestartPolicy() string {
+	if x != nil {
+		return x.RestartPolicy
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetMaxRestarts() int32 {
+	if x != nil {
+		return x.MaxRestarts
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetHealthProbe() *HealthProbe {
+	if x != nil {
+		return x.HealthProbe
+	}
+	return nil
+}
+
+func (x *GetJobStatusRes) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *GetJobStatusRes) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetIsolation() string {
+	if x != nil {
+		return x.Isolation
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetCpuThrottledPercent() float64 {
+	if x != nil {
+		return x.CpuThrottledPercent
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetQos() string {
+	if x != nil {
+		return x.Qos
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetSeccomp() string {
+	if x != nil {
+		return x.Seccomp
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetTimeOffsetSeconds() int64 {
+	if x != nil {
+		return x.TimeOffsetSeconds
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetCoreDump() string {
+	if x != nil {
+		return x.CoreDump
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetCoreDumpBytes() int64 {
+	if x != nil {
+		return x.CoreDumpBytes
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetLaunchMicros() int64 {
+	if x != nil {
+		return x.LaunchMicros
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetCleanupMicros() int64 {
+	if x != nil {
+		return x.CleanupMicros
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetIdle() bool {
+	if x != nil {
+		return x.Idle
+	}
+	return false
+}
+
+func (x *GetJobStatusRes) GetOutputDroppedBytes() int64 {
+	if x != nil {
+		return x.OutputDroppedBytes
+	}
+	return 0
+}
+
+func (x *GetJobStatusRes) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetResultError() string {
+	if x != nil {
+		return x.ResultError
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetSockets() []*JobSocket {
+	if x != nil {
+		return x.Sockets
+	}
+	return nil
+}
+
+func (x *GetJobStatusRes) GetRuntime() string {
+	if x != nil {
+		return x.Runtime
+	}
+	return ""
+}
+
+func (x *GetJobStatusRes) GetStatusReason() string {
+	if x != nil {
+		return x.StatusReason
+	}
+	return ""
+}
+
+// GetJobProvenance
+type GetJobProvenanceReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobProvenanceReq) Reset() {
+	*x = GetJobProvenanceReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobProvenanceReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobProvenanceReq) ProtoMessage() {}
+
+func (x *GetJobProvenanceReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobProvenanceReq.ProtoReflect.Descriptor instead.
+func (*GetJobProvenanceReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetJobProvenanceReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// What a job was launched with, recorded once when the worker started it
+type JobProvenance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId         string            `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	Command       string            `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`                                                                                       // path of the executable, as the job sees it
+	CommandSha256 string            `protobuf:"bytes,3,opt,name=commandSha256,proto3" json:"commandSha256,omitempty"`                                                                           // hex SHA-256 digest of the executable, empty if the worker could not read it
+	Env           map[string]string `protobuf:"bytes,4,rep,name=env,proto3" json:"env,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`       // environment the command ran with, secrets and sensitive values masked
+	KernelVersion string            `protobuf:"bytes,5,opt,name=kernelVersion,proto3" json:"kernelVersion,omitempty"`                                                                           // host kernel release
+	CgroupPath    string            `protobuf:"bytes,6,opt,name=cgroupPath,proto3" json:"cgroupPath,omitempty"`                                                                                 // empty if the job has no cgroup
+	Cgroup        map[string]string `protobuf:"bytes,7,rep,name=cgroup,proto3" json:"cgroup,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // cgroup interface file -> value in effect, e.g. "memory.max" -> "1073741824"
+	WorkerVersion string            `protobuf:"bytes,8,opt,name=workerVersion,proto3" json:"workerVersion,omitempty"`
+	RecordedAt    string            `protobuf:"bytes,9,opt,name=recordedAt,proto3" json:"recordedAt,omitempty"`
+	SignedBy      string            `protobuf:"bytes,10,opt,name=signedBy,proto3" json:"signedBy,omitempty"` // name of the configured key the job spec was signed with, empty if it was not signed
+}
+
+func (x *JobProvenance) Reset() {
+	*x = JobProvenance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobProvenance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProvenance) ProtoMessage() {}
+
+func (x *JobProvenance) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProvenance.ProtoReflect.Descriptor instead.
+func (*JobProvenance) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *JobProvenance) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetCommandSha256() string {
+	if x != nil {
+		return x.CommandSha256
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetEnv() map[string]string {
+	if x != nil {
+		return x.Env
+	}
+	return nil
+}
+
+func (x *JobProvenance) GetKernelVersion() string {
+	if x != nil {
+		return x.KernelVersion
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetCgroupPath() string {
+	if x != nil {
+		return x.CgroupPath
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetCgroup() map[string]string {
+	if x != nil {
+		return x.Cgroup
+	}
+	return nil
+}
+
+func (x *JobProvenance) GetWorkerVersion() string {
+	if x != nil {
+		return x.WorkerVersion
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetRecordedAt() string {
+	if x != nil {
+		return x.RecordedAt
+	}
+	return ""
+}
+
+func (x *JobProvenance) GetSignedBy() string {
+	if x != nil {
+		return x.SignedBy
+	}
+	return ""
+}
+
+// GetJobProcesses
+type GetJobProcessesReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // a RUNNING job
+}
+
+func (x *GetJobProcessesReq) Reset() {
+	*x = GetJobProcessesReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobProcessesReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobProcessesReq) ProtoMessage() {}
+
+func (x *GetJobProcessesReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobProcessesReq.ProtoReflect.Descriptor instead.
+func (*GetJobProcessesReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GetJobProcessesReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// The processes of a running job, read from its cgroup and /proc
+type JobProcesses struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	JobId     string        `protobuf:"bytes,1,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	Processes []*JobProcess `protobuf:"bytes,2,rep,name=processes,proto3" json:"processes,omitempty"` // depth first, each process after its parent
+	SampledAt string        `protobuf:"bytes,3,opt,name=sampledAt,proto3" json:"sampledAt,omitempty"`
+}
+
+func (x *JobProcesses) Reset() {
+	*x = JobProcesses{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobProcesses) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProcesses) ProtoMessage() {}
+
+func (x *JobProcesses) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProcesses.ProtoReflect.Descriptor instead.
+func (*JobProcesses) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *JobProcesses) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobProcesses) GetProcesses() []*JobProcess {
+	if x != nil {
+		return x.Processes
+	}
+	return nil
+}
+
+func (x *JobProcesses) GetSampledAt() string {
+	if x != nil {
+		return x.SampledAt
+	}
+	return ""
+}
+
+type JobProcess struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Pid        int32   `protobuf:"varint,1,opt,name=pid,proto3" json:"pid,omitempty"`
+	Ppid       int32   `protobuf:"varint,2,opt,name=ppid,proto3" json:"ppid,omitempty"`
+	Depth      int32   `protobuf:"varint,3,opt,name=depth,proto3" json:"depth,omitempty"`    // in the job's process tree, 0 for a process whose parent is not in the job
+	Command    string  `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"` // command line, the process name in brackets when it has none
+	State      string  `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`     // R running, S sleeping, D disk wait, Z zombie, T stopped
+	Threads    int32   `protobuf:"varint,6,opt,name=threads,proto3" json:"threads,omitempty"`
+	CpuMicros  int64   `protobuf:"varint,7,opt,name=cpuMicros,proto3" json:"cpuMicros,omitempty"`    // user and system time the process used
+	CpuPercent float64 `protobuf:"fixed64,8,opt,name=cpuPercent,proto3" json:"cpuPercent,omitempty"` // CPU time over the time since the process started, as ps reports it
+	RssBytes   int64   `protobuf:"varint,9,opt,name=rssBytes,proto3" json:"rssBytes,omitempty"`      // resident memory
+	StartTime  string  `protobuf:"bytes,10,opt,name=startTime,proto3" json:"startTime,omitempty"`
+}
+
+func (x *JobProcess) Reset() {
+	*x = JobProcess{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobProcess) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobProcess) ProtoMessage() {}
+
+func (x *JobProcess) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobProcess.ProtoReflect.Descriptor instead.
+func (*JobProcess) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *JobProcess) GetPid() int32 {
+	if x != nil {
+		return x.Pid
+	}
+	return 0
+}
+
+func (x *JobProcess) GetPpid() int32 {
+	if x != nil {
+		return x.Ppid
+	}
+	return 0
+}
+
+func (x *JobProcess) GetDepth() int32 {
+	if x != nil {
+		return x.Depth
+	}
+	return 0
+}
+
+func (x *JobProcess) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobProcess) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *JobProcess) GetThreads() int32 {
+	if x != nil {
+		return x.Threads
+	}
+	return 0
+}
+
+func (x *JobProcess) GetCpuMicros() int64 {
+	if x != nil {
+		return x.CpuMicros
+	}
+	return 0
+}
+
+func (x *JobProcess) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *JobProcess) GetRssBytes() int64 {
+	if x != nil {
+		return x.RssBytes
+	}
+	return 0
+}
+
+func (x *JobProcess) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+// StopJob
+// The job is sent signal (TERM if unset) and killed with SIGKILL when it is
+// still running after gracePeriodMillis (the worker's cleanup timeout if unset)
+type StopJobReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	GracePeriodMillis int64  `protobuf:"varint,2,opt,name=gracePeriodMillis,proto3" json:"gracePeriodMillis,omitempty"`
+	Signal            string `protobuf:"bytes,3,opt,name=signal,proto3" json:"signal,omitempty"` // TERM, INT, HUP, QUIT, USR1, USR2 or KILL, with or without SIG
+}
+
+func (x *StopJobReq) Reset() {
+	*x = StopJobReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopJobReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobReq) ProtoMessage() {}
+
+func (x *StopJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobReq.ProtoReflect.Descriptor instead.
+func (*StopJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *StopJobReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StopJobReq) GetGracePeriodMillis() int64 {
+	if x != nil {
+		return x.GracePeriodMillis
+	}
+	return 0
+}
+
+func (x *StopJobReq) GetSignal() string {
+	if x != nil {
+		return x.Signal
+	}
+	return ""
+}
+
+type StopJobRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status   string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	EndTime  string `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`
+	ExitCode int32  `protobuf:"varint,4,opt,name=exitCode,proto3" json:"exitCode,omitempty"`
+	Method   string `protobuf:"bytes,5,opt,name=method,proto3" json:"method,omitempty"` // graceful, forced, or exited if the job had no process left
+}
+
+func (x *StopJobRes) Reset() {
+	*x = StopJobRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopJobRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobRes) ProtoMessage() {}
+
+func (x *StopJobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobRes.ProtoReflect.Descriptor instead.
+func (*StopJobRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *StopJobRes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StopJobRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *StopJobRes) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *StopJobRes) GetExitCode() int32 {
+	if x != nil {
+		return x.ExitCode
+	}
+	return 0
+}
+
+func (x *StopJobRes) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+// ResumeJob
+type ResumeJobReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // a job held in CRASH_LOOP
+}
+
+func (x *ResumeJobReq) Reset() {
+	*x = ResumeJobReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeJobReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeJobReq) ProtoMessage() {}
+
+func (x *ResumeJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeJobReq.ProtoReflect.Descriptor instead.
+func (*ResumeJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ResumeJobReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ResumeJobRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *ResumeJobRes) Reset() {
+	*x = ResumeJobRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ResumeJobRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeJobRes) ProtoMessage() {}
+
+func (x *ResumeJobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeJobRes.ProtoReflect.Descriptor instead.
+func (*ResumeJobRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ResumeJobRes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ResumeJobRes) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+// RerunJob
+// A new job is started from the spec the job was started with
+type RerunJobReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *RerunJobReq) Reset() {
+	*x = RerunJobReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RerunJobReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RerunJobReq) ProtoMessage() {}
+
+func (x *RerunJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RerunJobReq.ProtoReflect.Descriptor instead.
+func (*RerunJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *RerunJobReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// DeleteJob
+// Removes a finished job's record, buffered output, captured stdout and workspace
+type DeleteJobReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteJobReq) Reset() {
+	*x = DeleteJobReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobReq) ProtoMessage() {}
+
+func (x *DeleteJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobReq.ProtoReflect.Descriptor instead.
+func (*DeleteJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *DeleteJobReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteJobRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteJobRes) Reset() {
+	*x = DeleteJobRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteJobRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteJobRes) ProtoMessage() {}
+
+func (x *DeleteJobRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteJobRes.ProtoReflect.Descriptor instead.
+func (*DeleteJobRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *DeleteJobRes) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// ExportJob
+// Streams a gzipped tarball of the job: its spec and latest status, the
+// statuses it went through, every change recorded to it and its output
+type ExportJobReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *ExportJobReq) Reset() {
+	*x = ExportJobReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ExportJobReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportJobReq) ProtoMessage() {}
+
+func (x *ExportJobReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportJobReq.ProtoReflect.Descriptor instead.
+func (*ExportJobReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ExportJobReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// GetJobArtifact
+type GetJobArtifactReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Path string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"` // file in the job workspace, relative to it
+}
+
+func (x *GetJobArtifactReq) Reset() {
+	*x = GetJobArtifactReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobArtifactReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobArtifactReq) ProtoMessage() {}
+
+func (x *GetJobArtifactReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobArtifactReq.ProtoReflect.Descriptor instead.
+func (*GetJobArtifactReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetJobArtifactReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetJobArtifactReq) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// GetJobLogs
+type GetJobLogsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Offset int64  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // skip this many bytes of output, to resume an interrupted stream
+}
+
+func (x *GetJobLogsReq) Reset() {
+	*x = GetJobLogsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobLogsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobLogsReq) ProtoMessage() {}
+
+func (x *GetJobLogsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobLogsReq.ProtoReflect.Descriptor instead.
+func (*GetJobLogsReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetJobLogsReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetJobLogsReq) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type DataChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Payload []byte `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *DataChunk) Reset() {
+	*x = DataChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DataChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DataChunk) ProtoMessage() {}
+
+func (x *DataChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DataChunk.ProtoReflect.Descriptor instead.
+func (*DataChunk) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *DataChunk) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+// Secrets
+type CreateSecretReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name  string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value []byte `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+}
+
+func (x *CreateSecretReq) Reset() {
+	*x = CreateSecretReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSecretReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretReq) ProtoMessage() {}
+
+func (x *CreateSecretReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretReq.ProtoReflect.Descriptor instead.
+func (*CreateSecretReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *CreateSecretReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSecretReq) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+type CreateSecretRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	CreatedAt string `protobuf:"bytes,2,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *CreateSecretRes) Reset() {
+	*x = CreateSecretRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateSecretRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSecretRes) ProtoMessage() {}
+
+func (x *CreateSecretRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSecretRes.ProtoReflect.Descriptor instead.
+func (*CreateSecretRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *CreateSecretRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSecretRes) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type DeleteSecretReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteSecretReq) Reset() {
+	*x = DeleteSecretReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSecretReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretReq) ProtoMessage() {}
+
+func (x *DeleteSecretReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretReq.ProtoReflect.Descriptor instead.
+func (*DeleteSecretReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *DeleteSecretReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteSecretRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *DeleteSecretRes) Reset() {
+	*x = DeleteSecretRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteSecretRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSecretRes) ProtoMessage() {}
+
+func (x *DeleteSecretRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSecretRes.ProtoReflect.Descriptor instead.
+func (*DeleteSecretRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *DeleteSecretRes) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// UploadJobFiles
+// Consecutive chunks with the same path are appended to the same file
+type FileChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Data []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	Mode uint32 `protobuf:"varint,3,opt,name=mode,proto3" json:"mode,omitempty"`
+}
+
+func (x *FileChunk) Reset() {
+	*x = FileChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FileChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileChunk) ProtoMessage() {}
+
+func (x *FileChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileChunk.ProtoReflect.Descriptor instead.
+func (*FileChunk) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *FileChunk) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *FileChunk) GetMode() uint32 {
+	if x != nil {
+		return x.Mode
+	}
+	return 0
+}
+
+type UploadJobFilesRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UploadId   string `protobuf:"bytes,1,opt,name=uploadId,proto3" json:"uploadId,omitempty"`
+	Files      int32  `protobuf:"varint,2,opt,name=files,proto3" json:"files,omitempty"`
+	TotalBytes int64  `protobuf:"varint,3,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"`
+}
+
+func (x *UploadJobFilesRes) Reset() {
+	*x = UploadJobFilesRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadJobFilesRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadJobFilesRes) ProtoMessage() {}
+
+func (x *UploadJobFilesRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadJobFilesRes.ProtoReflect.Descriptor instead.
+func (*UploadJobFilesRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UploadJobFilesRes) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadJobFilesRes) GetFiles() int32 {
+	if x != nil {
+		return x.Files
+	}
+	return 0
+}
+
+func (x *UploadJobFilesRes) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+// SubmitJob
+// A RunJobReq too large for one message, such as one with a big inline
+// environment, sent as its encoding split in chunks. The first chunk is the
+// header giving the encoding's size, which the server refuses up front when
+// over grpc.maxSubmitSize. The server acknowledges the bytes it has taken as
+// chunks arrive and, once the client closes its side, starts the job with the
+// checks of RunJob and sends it in the last response.
+type SubmitJobChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TotalBytes int64  `protobuf:"varint,1,opt,name=totalBytes,proto3" json:"totalBytes,omitempty"` // size of the encoded RunJobReq, on the first chunk only
+	Data       []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`              // next bytes of the encoded RunJobReq
+}
+
+func (x *SubmitJobChunk) Reset() {
+	*x = SubmitJobChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitJobChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobChunk) ProtoMessage() {}
+
+func (x *SubmitJobChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobChunk.ProtoReflect.Descriptor instead.
+func (*SubmitJobChunk) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *SubmitJobChunk) GetTotalBytes() int64 {
+	if x != nil {
+		return x.TotalBytes
+	}
+	return 0
+}
+
+func (x *SubmitJobChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type SubmitJobProgress struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ReceivedBytes int64      `protobuf:"varint,1,opt,name=receivedBytes,proto3" json:"receivedBytes,omitempty"` // bytes of the request taken so far
+	Job           *RunJobRes `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`                      // the started job, on the last response only
+}
+
+func (x *SubmitJobProgress) Reset() {
+	*x = SubmitJobProgress{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubmitJobProgress) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubmitJobProgress) ProtoMessage() {}
+
+func (x *SubmitJobProgress) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubmitJobProgress.ProtoReflect.Descriptor instead.
+func (*SubmitJobProgress) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SubmitJobProgress) GetReceivedBytes() int64 {
+	if x != nil {
+		return x.ReceivedBytes
+	}
+	return 0
+}
+
+func (x *SubmitJobProgress) GetJob() *RunJobRes {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+// Pipelines
+// A step starts once every step in dependsOn and inputs has completed
+type PipelineInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Step   string `protobuf:"bytes,1,opt,name=step,proto3" json:"step,omitempty"`     // upstream step name
+	Source string `protobuf:"bytes,2,opt,name=source,proto3" json:"source,omitempty"` // "stdout" or "artifacts"
+	Path   string `protobuf:"bytes,3,opt,name=path,proto3" json:"path,omitempty"`     // destination in the workspace, defaults to inputs/<step>[.stdout]
+}
+
+func (x *PipelineInput) Reset() {
+	*x = PipelineInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PipelineInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineInput) ProtoMessage() {}
+
+func (x *PipelineInput) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineInput.ProtoReflect.Descriptor instead.
+func (*PipelineInput) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PipelineInput) GetStep() string {
+	if x != nil {
+		return x.Step
+	}
+	return ""
+}
+
+func (x *PipelineInput) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *PipelineInput) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type PipelineStep struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name      string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Job       *RunJobReq       `protobuf:"bytes,2,opt,name=job,proto3" json:"job,omitempty"`
+	DependsOn []string         `protobuf:"bytes,3,rep,name=dependsOn,proto3" json:"dependsOn,omitempty"`
+	Inputs    []*PipelineInput `protobuf:"bytes,4,rep,name=inputs,proto3" json:"inputs,omitempty"`
+}
+
+func (x *PipelineStep) Reset() {
+	*x = PipelineStep{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PipelineStep) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineStep) ProtoMessage() {}
+
+func (x *PipelineStep) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineStep.ProtoReflect.Descriptor instead.
+func (*PipelineStep) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PipelineStep) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PipelineStep) GetJob() *RunJobReq {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+func (x *PipelineStep) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *PipelineStep) GetInputs() []*PipelineInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+type RunPipelineReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Steps []*PipelineStep `protobuf:"bytes,1,rep,name=steps,proto3" json:"steps,omitempty"`
+}
+
+func (x *RunPipelineReq) Reset() {
+	*x = RunPipelineReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunPipelineReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunPipelineReq) ProtoMessage() {}
+
+func (x *RunPipelineReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunPipelineReq.ProtoReflect.Descriptor instead.
+func (*RunPipelineReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RunPipelineReq) GetSteps() []*PipelineStep {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+type GetPipelineStatusReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetPipelineStatusReq) Reset() {
+	*x = GetPipelineStatusReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetPipelineStatusReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPipelineStatusReq) ProtoMessage() {}
+
+func (x *GetPipelineStatusReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPipelineStatusReq.ProtoReflect.Descriptor instead.
+func (*GetPipelineStatusReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GetPipelineStatusReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type PipelineStepStatus struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	JobId  string `protobuf:"bytes,2,opt,name=jobId,proto3" json:"jobId,omitempty"`
+	Status string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	Error  string `protobuf:"bytes,4,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *PipelineStepStatus) Reset() {
+	*x = PipelineStepStatus{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PipelineStepStatus) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PipelineStepStatus) ProtoMessage() {}
+
+func (x *PipelineStepStatus) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PipelineStepStatus.ProtoReflect.Descriptor instead.
+func (*PipelineStepStatus) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *PipelineStepStatus) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PipelineStepStatus) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *PipelineStepStatus) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PipelineStepStatus) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type Pipeline struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string                `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Status    string                `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Steps     []*PipelineStepStatus `protobuf:"bytes,3,rep,name=steps,proto3" json:"steps,omitempty"`
+	StartTime string                `protobuf:"bytes,4,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime   string                `protobuf:"bytes,5,opt,name=endTime,proto3" json:"endTime,omitempty"`
+}
+
+func (x *Pipeline) Reset() {
+	*x = Pipeline{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[43]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Pipeline) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pipeline) ProtoMessage() {}
+
+func (x *Pipeline) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[43]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pipeline.ProtoReflect.Descriptor instead.
+func (*Pipeline) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *Pipeline) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Pipeline) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Pipeline) GetSteps() []*PipelineStepStatus {
+	if x != nil {
+		return x.Steps
+	}
+	return nil
+}
+
+func (x *Pipeline) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *Pipeline) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+// Job groups
+// Jobs of a group are started together; if one fails to start, the others are stopped
+type RunJobGroupReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name string       `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Jobs []*RunJobReq `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	// Limits the jobs of the group share, through a parent cgroup of theirs; 0 for none. Each job's own limits still apply within them.
+	CpuLimitMillis   int64 `protobuf:"varint,3,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes int64 `protobuf:"varint,4,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS       int64 `protobuf:"varint,5,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"` // block IO bytes per second
+}
+
+func (x *RunJobGroupReq) Reset() {
+	*x = RunJobGroupReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[44]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunJobGroupReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobGroupReq) ProtoMessage() {}
+
+func (x *RunJobGroupReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[44]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobGroupReq.ProtoReflect.Descriptor instead.
+func (*RunJobGroupReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *RunJobGroupReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RunJobGroupReq) GetJobs() []*RunJobReq {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *RunJobGroupReq) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *RunJobGroupReq) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *RunJobGroupReq) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+type GetJobGroupReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *GetJobGroupReq) Reset() {
+	*x = GetJobGroupReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[45]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetJobGroupReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetJobGroupReq) ProtoMessage() {}
+
+func (x *GetJobGroupReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[45]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetJobGroupReq.ProtoReflect.Descriptor instead.
+func (*GetJobGroupReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *GetJobGroupReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type StopJobGroupReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *StopJobGroupReq) Reset() {
+	*x = StopJobGroupReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[46]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopJobGroupReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobGroupReq) ProtoMessage() {}
+
+func (x *StopJobGroupReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[46]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobGroupReq.ProtoReflect.Descriptor instead.
+func (*StopJobGroupReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *StopJobGroupReq) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type JobGroup struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name             string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Status           string `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // RUNNING, COMPLETED, FAILED or STOPPED
+	CreatedAt        string `protobuf:"bytes,4,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+	Jobs             []*Job `protobuf:"bytes,5,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Running          int32  `protobuf:"varint,6,opt,name=running,proto3" json:"running,omitempty"`
+	Succeeded        int32  `protobuf:"varint,7,opt,name=succeeded,proto3" json:"succeeded,omitempty"`
+	Failed           int32  `protobuf:"varint,8,opt,name=failed,proto3" json:"failed,omitempty"`
+	Stopped          int32  `protobuf:"varint,9,opt,name=stopped,proto3" json:"stopped,omitempty"`
+	CpuLimitMillis   int64  `protobuf:"varint,10,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // shared limits of the group, 0 for none
+	MemoryLimitBytes int64  `protobuf:"varint,11,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS       int64  `protobuf:"varint,12,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"`
+}
+
+func (x *JobGroup) Reset() {
+	*x = JobGroup{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[47]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobGroup) ProtoMessage() {}
+
+func (x *JobGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[47]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobGroup.ProtoReflect.Descriptor instead.
+func (*JobGroup) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *JobGroup) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobGroup) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobGroup) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+func (x *JobGroup) GetJobs() []*Job {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *JobGroup) GetRunning() int32 {
+	if x != nil {
+		return x.Running
+	}
+	return 0
+}
+
+func (x *JobGroup) GetSucceeded() int32 {
+	if x != nil {
+		return x.Succeeded
+	}
+	return 0
+}
+
+func (x *JobGroup) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+func (x *JobGroup) GetStopped() int32 {
+	if x != nil {
+		return x.Stopped
+	}
+	return 0
+}
+
+func (x *JobGroup) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *JobGroup) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *JobGroup) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+type JobGroups struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Groups []*JobGroup `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+}
+
+func (x *JobGroups) Reset() {
+	*x = JobGroups{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[48]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobGroups) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobGroups) ProtoMessage() {}
+
+func (x *JobGroups) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[48]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobGroups.ProtoReflect.Descriptor instead.
+func (*JobGroups) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *JobGroups) GetGroups() []*JobGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type StopJobGroupRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group  *JobGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Errors map[string]string `protobuf:"bytes,2,rep,name=errors,proto3" json:"errors,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // job id -> why it could not be stopped
+}
+
+func (x *StopJobGroupRes) Reset() {
+	*x = StopJobGroupRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[49]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StopJobGroupRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopJobGroupRes) ProtoMessage() {}
+
+func (x *StopJobGroupRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[49]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopJobGroupRes.ProtoReflect.Descriptor instead.
+func (*StopJobGroupRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *StopJobGroupRes) GetGroup() *JobGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *StopJobGroupRes) GetErrors() map[string]string {
+	if x != nil {
+		return x.Errors
+	}
+	return nil
+}
+
+// Job templates
+// The command, args and the values of env, secretEnv and labels of a template's
+// spec may hold {{param}} placeholders of its declared parameters
+type JobTemplate struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name        string           `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description string           `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Params      []*TemplateParam `protobuf:"bytes,3,rep,name=params,proto3" json:"params,omitempty"`
+	Spec        *RunJobReq       `protobuf:"bytes,4,opt,name=spec,proto3" json:"spec,omitempty"` // uploadId is not allowed, an upload is used by a single job
+	CreatedAt   string           `protobuf:"bytes,5,opt,name=createdAt,proto3" json:"createdAt,omitempty"`
+}
+
+func (x *JobTemplate) Reset() {
+	*x = JobTemplate{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[50]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobTemplate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTemplate) ProtoMessage() {}
+
+func (x *JobTemplate) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[50]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTemplate.ProtoReflect.Descriptor instead.
+func (*JobTemplate) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *JobTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *JobTemplate) GetParams() []*TemplateParam {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+func (x *JobTemplate) GetSpec() *RunJobReq {
+	if x != nil {
+		return x.Spec
+	}
+	return nil
+}
+
+func (x *JobTemplate) GetCreatedAt() string {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return ""
+}
+
+type TemplateParam struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description  string `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	DefaultValue string `protobuf:"bytes,3,opt,name=defaultValue,proto3" json:"defaultValue,omitempty"` // used when a run does not set the parameter
+	Required     bool   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"`        // a run must set the parameter
+}
+
+func (x *TemplateParam) Reset() {
+	*x = TemplateParam{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[51]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TemplateParam) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TemplateParam) ProtoMessage() {}
+
+func (x *TemplateParam) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[51]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TemplateParam.ProtoReflect.Descriptor instead.
+func (*TemplateParam) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *TemplateParam) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TemplateParam) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TemplateParam) GetDefaultValue() string {
+	if x != nil {
+		return x.DefaultValue
+	}
+	return ""
+}
+
+func (x *TemplateParam) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+type CreateJobTemplateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Template *JobTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	Replace  bool         `protobuf:"varint,2,opt,name=replace,proto3" json:"replace,omitempty"` // replace a template of the same name instead of failing with ALREADY_EXISTS
+}
+
+func (x *CreateJobTemplateReq) Reset() {
+	*x = CreateJobTemplateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[52]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CreateJobTemplateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateJobTemplateReq) ProtoMessage() {}
+
+func (x *CreateJobTemplateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[52]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateJobTemplateReq.ProtoReflect.Descriptor instead.
+func (*CreateJobTemplateReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CreateJobTemplateReq) GetTemplate() *JobTemplate {
+	if x != nil {
+		return x.Template
+	}
+	return nil
+}
+
+func (x *CreateJobTemplateReq) GetReplace() bool {
+	if x != nil {
+		return x.Replace
+	}
+	return false
+}
+
+type JobTemplates struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Templates []*JobTemplate `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
+}
+
+func (x *JobTemplates) Reset() {
+	*x = JobTemplates{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[53]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobTemplates) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobTemplates) ProtoMessage() {}
+
+func (x *JobTemplates) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[53]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobTemplates.ProtoReflect.Descriptor instead.
+func (*JobTemplates) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *JobTemplates) GetTemplates() []*JobTemplate {
+	if x != nil {
+		return x.Templates
+	}
+	return nil
+}
+
+type RunJobFromTemplateReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Template string            `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
+	Params   map[string]string `protobuf:"bytes,2,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // parameter name -> value, unknown names fail with INVALID_ARGUMENT
+}
+
+func (x *RunJobFromTemplateReq) Reset() {
+	*x = RunJobFromTemplateReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[54]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RunJobFromTemplateReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RunJobFromTemplateReq) ProtoMessage() {}
+
+func (x *RunJobFromTemplateReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[54]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RunJobFromTemplateReq.ProtoReflect.Descriptor instead.
+func (*RunJobFromTemplateReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RunJobFromTemplateReq) GetTemplate() string {
+	if x != nil {
+		return x.Template
+	}
+	return ""
+}
+
+func (x *RunJobFromTemplateReq) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
+// Bulk operations
+// Jobs are selected either by id or by filter, not both
+type BulkJobsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids    []string   `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Filter *JobFilter `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+}
+
+func (x *BulkJobsReq) Reset() {
+	*x = BulkJobsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[55]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkJobsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobsReq) ProtoMessage() {}
+
+func (x *BulkJobsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[55]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobsReq.ProtoReflect.Descriptor instead.
+func (*BulkJobsReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *BulkJobsReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *BulkJobsReq) GetFilter() *JobFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+// A job matches when it has every label, one of the statuses (any if empty)
+// and, if olderThanSeconds is set, ended (or started, if still active) at least
+// that long ago
+type JobFilter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Labels           map[string]string `protobuf:"bytes,1,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Statuses         []string          `protobuf:"bytes,2,rep,name=statuses,proto3" json:"statuses,omitempty"`
+	OlderThanSeconds int64             `protobuf:"varint,3,opt,name=olderThanSeconds,proto3" json:"olderThanSeconds,omitempty"`
+}
+
+func (x *JobFilter) Reset() {
+	*x = JobFilter{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[56]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobFilter) ProtoMessage() {}
+
+func (x *JobFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[56]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobFilter.ProtoReflect.Descriptor instead.
+func (*JobFilter) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *JobFilter) GetLabels() map[string]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+func (x *JobFilter) GetStatuses() []string {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *JobFilter) GetOlderThanSeconds() int64 {
+	if x != nil {
+		return x.OlderThanSeconds
+	}
+	return 0
+}
+
+type BulkJobResult struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Ok    bool   `protobuf:"varint,2,opt,name=ok,proto3" json:"ok,omitempty"`
+	Error string `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *BulkJobResult) Reset() {
+	*x = BulkJobResult{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[57]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkJobResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobResult) ProtoMessage() {}
+
+func (x *BulkJobResult) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[57]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobResult.ProtoReflect.Descriptor instead.
+func (*BulkJobResult) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *BulkJobResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkJobResult) GetOk() bool {
+	if x != nil {
+		return x.Ok
+	}
+	return false
+}
+
+func (x *BulkJobResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkJobsRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Results []*BulkJobResult `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+}
+
+func (x *BulkJobsRes) Reset() {
+	*x = BulkJobsRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[58]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BulkJobsRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkJobsRes) ProtoMessage() {}
+
+func (x *BulkJobsRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[58]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkJobsRes.ProtoReflect.Descriptor instead.
+func (*BulkJobsRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *BulkJobsRes) GetResults() []*BulkJobResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+// Job events
+// Events are sent as they happen until the client cancels. Without ids events
+// of every job are sent; without types every type is.
+type SubscribeJobEventsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids   []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	Types []string `protobuf:"bytes,2,rep,name=types,proto3" json:"types,omitempty"` // e.g. job.created, job.updated, job.cleaned_up
+}
+
+func (x *SubscribeJobEventsReq) Reset() {
+	*x = SubscribeJobEventsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[59]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeJobEventsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeJobEventsReq) ProtoMessage() {}
+
+func (x *SubscribeJobEventsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[59]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeJobEventsReq.ProtoReflect.Descriptor instead.
+func (*SubscribeJobEventsReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *SubscribeJobEventsReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *SubscribeJobEventsReq) GetTypes() []string {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+type JobEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type                string  `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	JobId               string  `protobuf:"bytes,2,opt,name=jobId,proto3" json:"jobId,omitempty"`   // unset for worker.* events
+	Status              string  `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"` // job status after the event, unset if the event did not change the job
+	Timestamp           string  `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Error               string  `protobuf:"bytes,5,opt,name=error,proto3" json:"error,omitempty"`                               // what went wrong, for failure events
+	CpuThrottledPercent float64 `protobuf:"fixed64,6,opt,name=cpuThrottledPercent,proto3" json:"cpuThrottledPercent,omitempty"` // of a job that finished, see Job.cpuThrottledPercent
+}
+
+func (x *JobEvent) Reset() {
+	*x = JobEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[60]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobEvent) ProtoMessage() {}
+
+func (x *JobEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[60]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobEvent.ProtoReflect.Descriptor instead.
+func (*JobEvent) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *JobEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *JobEvent) GetJobId() string {
+	if x != nil {
+		return x.JobId
+	}
+	return ""
+}
+
+func (x *JobEvent) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobEvent) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *JobEvent) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *JobEvent) GetCpuThrottledPercent() float64 {
+	if x != nil {
+		return x.CpuThrottledPercent
+	}
+	return 0
+}
+
+// Resource usage
+// Snapshots are sent every intervalSeconds (2 if unset) until the client cancels.
+// Without ids every job is included.
+type StreamJobMetricsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ids             []string `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	IntervalSeconds int32    `protobuf:"varint,2,opt,name=intervalSeconds,proto3" json:"intervalSeconds,omitempty"`
+}
+
+func (x *StreamJobMetricsReq) Reset() {
+	*x = StreamJobMetricsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[61]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StreamJobMetricsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StreamJobMetricsReq) ProtoMessage() {}
+
+func (x *StreamJobMetricsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[61]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StreamJobMetricsReq.ProtoReflect.Descriptor instead.
+func (*StreamJobMetricsReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *StreamJobMetricsReq) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+func (x *StreamJobMetricsReq) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+// Usage fields are only set for running jobs. cpuPercent is the share of one
+// core used since the previous snapshot. A high throttledPercent means the
+// job is held back by its CPU limit rather than slow on its own.
+type JobMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id           string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Command      string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
+	Args         []string `protobuf:"bytes,3,rep,name=args,proto3" json:"args,omitempty"`
+	Status       string   `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime    string   `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	Restarts     int32    `protobuf:"varint,6,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	Health       string   `protobuf:"bytes,7,opt,name=health,proto3" json:"health,omitempty"`
+	CpuPercent   float64  `protobuf:"fixed64,8,opt,name=cpuPercent,proto3" json:"cpuPercent,omitempty"`
+	MemoryBytes  int64    `protobuf:"varint,9,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	IoReadBytes  int64    `protobuf:"varint,10,opt,name=ioReadBytes,proto3" json:"ioReadBytes,omitempty"`
+	IoWriteBytes int64    `protobuf:"varint,11,opt,name=ioWriteBytes,proto3" json:"ioWriteBytes,omitempty"`
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxCPU int32 `protobuf:"varint,12,opt,name=maxCPU,proto3" json:"maxCPU,omitempty"` // percent of one core, use cpuLimitMillis
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxMemory int32 `protobuf:"varint,13,opt,name=maxMemory,proto3" json:"maxMemory,omitempty"` // MiB, use memoryLimitBytes
+	// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+	MaxIOBPS         int32   `protobuf:"varint,14,opt,name=maxIOBPS,proto3" json:"maxIOBPS,omitempty"`             // use ioLimitBPS
+	CpuLimitMillis   int64   `protobuf:"varint,15,opt,name=cpuLimitMillis,proto3" json:"cpuLimitMillis,omitempty"` // CPU time per second, 1000 = one core
+	MemoryLimitBytes int64   `protobuf:"varint,16,opt,name=memoryLimitBytes,proto3" json:"memoryLimitBytes,omitempty"`
+	IoLimitBPS       int64   `protobuf:"varint,17,opt,name=ioLimitBPS,proto3" json:"ioLimitBPS,omitempty"`              // block IO bytes per second
+	ThrottledPercent float64 `protobuf:"fixed64,18,opt,name=throttledPercent,proto3" json:"throttledPercent,omitempty"` // share of CPU periods the CPU limit throttled the job in, since the previous snapshot
+	ThrottledUsec    int64   `protobuf:"varint,19,opt,name=throttledUsec,proto3" json:"throttledUsec,omitempty"`        // time the job spent throttled since it started
+}
+
+func (x *JobMetrics) Reset() {
+	*x = JobMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[62]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobMetrics) ProtoMessage() {}
+
+func (x *JobMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[62]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobMetrics.ProtoReflect.Descriptor instead.
+func (*JobMetrics) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *JobMetrics) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobMetrics) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobMetrics) GetArgs() []string {
+	if x != nil {
+		return x.Args
+	}
+	return nil
+}
+
+func (x *JobMetrics) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobMetrics) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *JobMetrics) GetRestarts() int32 {
+	if x != nil {
+		return x.Restarts
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetHealth() string {
+	if x != nil {
+		return x.Health
+	}
+	return ""
+}
+
+func (x *JobMetrics) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetIoReadBytes() int64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetIoWriteBytes() int64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *JobMetrics) GetMaxCPU() int32 {
+	if x != nil {
+		return x.MaxCPU
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *JobMetrics) GetMaxMemory() int32 {
+	if x != nil {
+		return x.MaxMemory
+	}
+	return 0
+}
+
+// Deprecated: Marked as deprecated in jobworker/v1/worker.proto.
+func (x *JobMetrics) GetMaxIOBPS() int32 {
+	if x != nil {
+		return x.MaxIOBPS
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetCpuLimitMillis() int64 {
+	if x != nil {
+		return x.CpuLimitMillis
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetMemoryLimitBytes() int64 {
+	if x != nil {
+		return x.MemoryLimitBytes
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetIoLimitBPS() int64 {
+	if x != nil {
+		return x.IoLimitBPS
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetThrottledPercent() float64 {
+	if x != nil {
+		return x.ThrottledPercent
+	}
+	return 0
+}
+
+func (x *JobMetrics) GetThrottledUsec() int64 {
+	if x != nil {
+		return x.ThrottledUsec
+	}
+	return 0
+}
+
+// Usage summed over the running jobs of a job group or a tenant, from their
+// cgroups. Only jobs with a reading are counted.
+type AggregateMetrics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name         string  `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // group id, or tenant ("" for jobs without the tenant label)
+	Jobs         int32   `protobuf:"varint,2,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	CpuPercent   float64 `protobuf:"fixed64,3,opt,name=cpuPercent,proto3" json:"cpuPercent,omitempty"` // may exceed 100 when the jobs use several cores
+	MemoryBytes  int64   `protobuf:"varint,4,opt,name=memoryBytes,proto3" json:"memoryBytes,omitempty"`
+	IoReadBytes  int64   `protobuf:"varint,5,opt,name=ioReadBytes,proto3" json:"ioReadBytes,omitempty"`
+	IoWriteBytes int64   `protobuf:"varint,6,opt,name=ioWriteBytes,proto3" json:"ioWriteBytes,omitempty"`
+}
+
+func (x *AggregateMetrics) Reset() {
+	*x = AggregateMetrics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[63]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AggregateMetrics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregateMetrics) ProtoMessage() {}
+
+func (x *AggregateMetrics) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[63]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregateMetrics.ProtoReflect.Descriptor instead.
+func (*AggregateMetrics) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *AggregateMetrics) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AggregateMetrics) GetJobs() int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+func (x *AggregateMetrics) GetCpuPercent() float64 {
+	if x != nil {
+		return x.CpuPercent
+	}
+	return 0
+}
+
+func (x *AggregateMetrics) GetMemoryBytes() int64 {
+	if x != nil {
+		return x.MemoryBytes
+	}
+	return 0
+}
+
+func (x *AggregateMetrics) GetIoReadBytes() int64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *AggregateMetrics) GetIoWriteBytes() int64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+type JobMetricsSnapshot struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Timestamp string              `protobuf:"bytes,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Jobs      []*JobMetrics       `protobuf:"bytes,2,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	Groups    []*AggregateMetrics `protobuf:"bytes,3,rep,name=groups,proto3" json:"groups,omitempty"`   // of the jobs above, ordered by name
+	Tenants   []*AggregateMetrics `protobuf:"bytes,4,rep,name=tenants,proto3" json:"tenants,omitempty"` // of the jobs above, ordered by name
+}
+
+func (x *JobMetricsSnapshot) Reset() {
+	*x = JobMetricsSnapshot{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[64]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobMetricsSnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobMetricsSnapshot) ProtoMessage() {}
+
+func (x *JobMetricsSnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[64]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobMetricsSnapshot.ProtoReflect.Descriptor instead.
+func (*JobMetricsSnapshot) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *JobMetricsSnapshot) GetTimestamp() string {
+	if x != nil {
+		return x.Timestamp
+	}
+	return ""
+}
+
+func (x *JobMetricsSnapshot) GetJobs() []*JobMetrics {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *JobMetricsSnapshot) GetGroups() []*AggregateMetrics {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *JobMetricsSnapshot) GetTenants() []*AggregateMetrics {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+// Job stdin
+// The first chunk names the job; the job's stdin is closed when the client
+// closes the stream. Only jobs started with stdin set accept input, and only
+// their first process receives it.
+type StdinChunk struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payload []byte `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *StdinChunk) Reset() {
+	*x = StdinChunk{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[65]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StdinChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StdinChunk) ProtoMessage() {}
+
+func (x *StdinChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[65]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StdinChunk.ProtoReflect.Descriptor instead.
+func (*StdinChunk) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *StdinChunk) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *StdinChunk) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type WriteJobStdinRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Bytes int64 `protobuf:"varint,1,opt,name=bytes,proto3" json:"bytes,omitempty"` // bytes written to the job
+}
+
+func (x *WriteJobStdinRes) Reset() {
+	*x = WriteJobStdinRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[66]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WriteJobStdinRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteJobStdinRes) ProtoMessage() {}
+
+func (x *WriteJobStdinRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[66]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteJobStdinRes.ProtoReflect.Descriptor instead.
+func (*WriteJobStdinRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *WriteJobStdinRes) GetBytes() int64 {
+	if x != nil {
+		return x.Bytes
+	}
+	return 0
+}
+
+// Worker capabilities
+// Capabilities depend on the platform the worker was built for. A job whose
+// requiredCapabilities (or settings, e.g. stdin or healthProbe) need a missing
+// capability is refused with UNIMPLEMENTED and a google.rpc.ErrorInfo detail
+// with reason CAPABILITY_UNAVAILABLE and the missing names in
+// metadata["capabilities"], comma separated.
+type WorkerInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ApiVersion    string        `protobuf:"bytes,1,opt,name=apiVersion,proto3" json:"apiVersion,omitempty"` // proto package served, e.g. "jobworker.v1"
+	Os            string        `protobuf:"bytes,2,opt,name=os,proto3" json:"os,omitempty"`
+	Arch          string        `protobuf:"bytes,3,opt,name=arch,proto3" json:"arch,omitempty"`
+	Capabilities  []string      `protobuf:"bytes,4,rep,name=capabilities,proto3" json:"capabilities,omitempty"`   // e.g. "cgroups", "namespaces", "stdin"
+	LimitProfiles []string      `protobuf:"bytes,5,rep,name=limitProfiles,proto3" json:"limitProfiles,omitempty"` // profile names accepted by RunJobReq.profile
+	MaxJobs       int32         `protobuf:"varint,6,opt,name=maxJobs,proto3" json:"maxJobs,omitempty"`            // configured job capacity, 0 when unlimited
+	RunningJobs   int32         `protobuf:"varint,7,opt,name=runningJobs,proto3" json:"runningJobs,omitempty"`    // jobs initializing or running
+	Disk          *DiskUsage    `protobuf:"bytes,8,opt,name=disk,proto3" json:"disk,omitempty"`                   // unset when the janitor is disabled
+	OutputBuffer  *OutputBuffer `protobuf:"bytes,9,opt,name=outputBuffer,proto3" json:"outputBuffer,omitempty"`   // memory taken by jobs' buffered output
+	Store         *StoreStats   `protobuf:"bytes,10,opt,name=store,proto3" json:"store,omitempty"`                // jobs held in memory and the worker's memory, without a heap profile
+}
+
+func (x *WorkerInfo) Reset() {
+	*x = WorkerInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[67]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkerInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkerInfo) ProtoMessage() {}
+
+func (x *WorkerInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[67]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkerInfo.ProtoReflect.Descriptor instead.
+func (*WorkerInfo) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *WorkerInfo) GetApiVersion() string {
+	if x != nil {
+		return x.ApiVersion
+	}
+	return ""
+}
+
+func (x *WorkerInfo) GetOs() string {
+	if x != nil {
+		return x.Os
+	}
+	return ""
+}
+
+func (x *WorkerInfo) GetArch() string {
+	if x != nil {
+		return x.Arch
+	}
+	return ""
+}
+
+func (x *WorkerInfo) GetCapabilities() []string {
+	if x != nil {
+		return x.Capabilities
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetLimitProfiles() []string {
+	if x != nil {
+		return x.LimitProfiles
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetMaxJobs() int32 {
+	if x != nil {
+		return x.MaxJobs
+	}
+	return 0
+}
+
+func (x *WorkerInfo) GetRunningJobs() int32 {
+	if x != nil {
+		return x.RunningJobs
+	}
+	return 0
+}
+
+func (x *WorkerInfo) GetDisk() *DiskUsage {
+	if x != nil {
+		return x.Disk
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetOutputBuffer() *OutputBuffer {
+	if x != nil {
+		return x.OutputBuffer
+	}
+	return nil
+}
+
+func (x *WorkerInfo) GetStore() *StoreStats {
+	if x != nil {
+		return x.Store
+	}
+	return nil
+}
+
+// The checks of the subsystems the worker needs to run jobs
+type Diagnostics struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ready  bool               `protobuf:"varint,1,opt,name=ready,proto3" json:"ready,omitempty"` // no check failed
+	Checks []*DiagnosticCheck `protobuf:"bytes,2,rep,name=checks,proto3" json:"checks,omitempty"`
+}
+
+func (x *Diagnostics) Reset() {
+	*x = Diagnostics{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[68]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Diagnostics) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Diagnostics) ProtoMessage() {}
+
+func (x *Diagnostics) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[68]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Diagnostics.ProtoReflect.Descriptor instead.
+func (*Diagnostics) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *Diagnostics) GetReady() bool {
+	if x != nil {
+		return x.Ready
+	}
+	return false
+}
+
+func (x *Diagnostics) GetChecks() []*DiagnosticCheck {
+	if x != nil {
+		return x.Checks
+	}
+	return nil
+}
+
+type DiagnosticCheck struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`     // subsystem checked, e.g. "cgroup.controllers"
+	Status string `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // OK, WARN (some jobs or features will not work) or FAIL (jobs cannot run)
+	Detail string `protobuf:"bytes,3,opt,name=detail,proto3" json:"detail,omitempty"` // what was found
+	Fix    string `protobuf:"bytes,4,opt,name=fix,proto3" json:"fix,omitempty"`       // how to fix it, empty when OK
+}
+
+func (x *DiagnosticCheck) Reset() {
+	*x = DiagnosticCheck{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[69]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiagnosticCheck) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiagnosticCheck) ProtoMessage() {}
+
+func (x *DiagnosticCheck) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[69]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiagnosticCheck.ProtoReflect.Descriptor instead.
+func (*DiagnosticCheck) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *DiagnosticCheck) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *DiagnosticCheck) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DiagnosticCheck) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+func (x *DiagnosticCheck) GetFix() string {
+	if x != nil {
+		return x.Fix
+	}
+	return ""
+}
+
+// The output the worker holds in memory for all jobs, and what it moved to
+// disk since it started to stay within the budget
+type OutputBuffer struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UsedBytes    int64 `protobuf:"varint,1,opt,name=usedBytes,proto3" json:"usedBytes,omitempty"`
+	BudgetBytes  int64 `protobuf:"varint,2,opt,name=budgetBytes,proto3" json:"budgetBytes,omitempty"` // 0 when unlimited
+	SpilledBytes int64 `protobuf:"varint,3,opt,name=spilledBytes,proto3" json:"spilledBytes,omitempty"`
+	SpilledJobs  int64 `protobuf:"varint,4,opt,name=spilledJobs,proto3" json:"spilledJobs,omitempty"` // jobs whose output was moved to disk
+}
+
+func (x *OutputBuffer) Reset() {
+	*x = OutputBuffer{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[70]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *OutputBuffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OutputBuffer) ProtoMessage() {}
+
+func (x *OutputBuffer) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[70]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OutputBuffer.ProtoReflect.Descriptor instead.
+func (*OutputBuffer) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *OutputBuffer) GetUsedBytes() int64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+func (x *OutputBuffer) GetBudgetBytes() int64 {
+	if x != nil {
+		return x.BudgetBytes
+	}
+	return 0
+}
+
+func (x *OutputBuffer) GetSpilledBytes() int64 {
+	if x != nil {
+		return x.SpilledBytes
+	}
+	return 0
+}
+
+func (x *OutputBuffer) GetSpilledJobs() int64 {
+	if x != nil {
+		return x.SpilledJobs
+	}
+	return 0
+}
+
+// Job store memory
+// The jobs the worker holds in memory, what their output takes and what
+// compaction did since the worker started, with the memory of the worker
+// process. compact runs a compaction with the configured policy first, and
+// heapProfile returns a pprof heap profile of the worker. Admin only.
+type GetStoreStatsReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Compact     bool `protobuf:"varint,1,opt,name=compact,proto3" json:"compact,omitempty"`
+	HeapProfile bool `protobuf:"varint,2,opt,name=heapProfile,proto3" json:"heapProfile,omitempty"`
+}
+
+func (x *GetStoreStatsReq) Reset() {
+	*x = GetStoreStatsReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[71]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetStoreStatsReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStoreStatsReq) ProtoMessage() {}
+
+func (x *GetStoreStatsReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[71]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStoreStatsReq.ProtoReflect.Descriptor instead.
+func (*GetStoreStatsReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *GetStoreStatsReq) GetCompact() bool {
+	if x != nil {
+		return x.Compact
+	}
+	return false
+}
+
+func (x *GetStoreStatsReq) GetHeapProfile() bool {
+	if x != nil {
+		return x.HeapProfile
+	}
+	return false
+}
+
+type StoreStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs              int64         `protobuf:"varint,1,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	FinishedJobs      int64         `protobuf:"varint,2,opt,name=finishedJobs,proto3" json:"finishedJobs,omitempty"`
+	HistoryEntries    int64         `protobuf:"varint,3,opt,name=historyEntries,proto3" json:"historyEntries,omitempty"` // job changes recorded, up to 256 per job
+	OutputBuffer      *OutputBuffer `protobuf:"bytes,4,opt,name=outputBuffer,proto3" json:"outputBuffer,omitempty"`
+	CompressedJobs    int64         `protobuf:"varint,5,opt,name=compressedJobs,proto3" json:"compressedJobs,omitempty"`       // finished jobs whose output is gzipped in memory
+	CompressedBytes   int64         `protobuf:"varint,6,opt,name=compressedBytes,proto3" json:"compressedBytes,omitempty"`     // memory the compressed output takes, part of outputBuffer.usedBytes
+	UncompressedBytes int64         `protobuf:"varint,7,opt,name=uncompressedBytes,proto3" json:"uncompressedBytes,omitempty"` // what the compressed output takes once decompressed
+	Compactions       int64         `protobuf:"varint,8,opt,name=compactions,proto3" json:"compactions,omitempty"`
+	LastCompaction    string        `protobuf:"bytes,9,opt,name=lastCompaction,proto3" json:"lastCompaction,omitempty"` // empty when there was none
+	SavedBytes        int64         `protobuf:"varint,10,opt,name=savedBytes,proto3" json:"savedBytes,omitempty"`       // memory compression freed
+	ExpiredJobs       int64         `protobuf:"varint,11,opt,name=expiredJobs,proto3" json:"expiredJobs,omitempty"`     // jobs whose output was dropped after the retention
+	ExpiredBytes      int64         `protobuf:"varint,12,opt,name=expiredBytes,proto3" json:"expiredBytes,omitempty"`
+	Memory            *MemoryStats  `protobuf:"bytes,13,opt,name=memory,proto3" json:"memory,omitempty"`
+	HeapProfile       []byte        `protobuf:"bytes,14,opt,name=heapProfile,proto3" json:"heapProfile,omitempty"` // pprof format, when asked for
+}
+
+func (x *StoreStats) Reset() {
+	*x = StoreStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[72]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *StoreStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StoreStats) ProtoMessage() {}
+
+func (x *StoreStats) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[72]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StoreStats.ProtoReflect.Descriptor instead.
+func (*StoreStats) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *StoreStats) GetJobs() int64 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+func (x *StoreStats) GetFinishedJobs() int64 {
+	if x != nil {
+		return x.FinishedJobs
+	}
+	return 0
+}
+
+func (x *StoreStats) GetHistoryEntries() int64 {
+	if x != nil {
+		return x.HistoryEntries
+	}
+	return 0
+}
+
+func (x *StoreStats) GetOutputBuffer() *OutputBuffer {
+	if x != nil {
+		return x.OutputBuffer
+	}
+	return nil
+}
+
+func (x *StoreStats) GetCompressedJobs() int64 {
+	if x != nil {
+		return x.CompressedJobs
+	}
+	return 0
+}
+
+func (x *StoreStats) GetCompressedBytes() int64 {
+	if x != nil {
+		return x.CompressedBytes
+	}
+	return 0
+}
+
+func (x *StoreStats) GetUncompressedBytes() int64 {
+	if x != nil {
+		return x.UncompressedBytes
+	}
+	return 0
+}
+
+func (x *StoreStats) GetCompactions() int64 {
+	if x != nil {
+		return x.Compactions
+	}
+	return 0
+}
+
+func (x *StoreStats) GetLastCompaction() string {
+	if x != nil {
+		return x.LastCompaction
+	}
+	return ""
+}
+
+func (x *StoreStats) GetSavedBytes() int64 {
+	if x != nil {
+		return x.SavedBytes
+	}
+	return 0
+}
+
+func (x *StoreStats) GetExpiredJobs() int64 {
+	if x != nil {
+		return x.ExpiredJobs
+	}
+	return 0
+}
+
+func (x *StoreStats) GetExpiredBytes() int64 {
+	if x != nil {
+		return x.ExpiredBytes
+	}
+	return 0
+}
+
+func (x *StoreStats) GetMemory() *MemoryStats {
+	if x != nil {
+		return x.Memory
+	}
+	return nil
+}
+
+func (x *StoreStats) GetHeapProfile() []byte {
+	if x != nil {
+		return x.HeapProfile
+	}
+	return nil
+}
+
+// The Go runtime's memory of the worker process
+type MemoryStats struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	HeapAllocBytes    int64 `protobuf:"varint,1,opt,name=heapAllocBytes,proto3" json:"heapAllocBytes,omitempty"` // live and not yet collected heap objects
+	HeapInuseBytes    int64 `protobuf:"varint,2,opt,name=heapInuseBytes,proto3" json:"heapInuseBytes,omitempty"`
+	HeapReleasedBytes int64 `protobuf:"varint,3,opt,name=heapReleasedBytes,proto3" json:"heapReleasedBytes,omitempty"` // returned to the OS
+	SysBytes          int64 `protobuf:"varint,4,opt,name=sysBytes,proto3" json:"sysBytes,omitempty"`                   // obtained from the OS in total
+	NumGC             int64 `protobuf:"varint,5,opt,name=numGC,proto3" json:"numGC,omitempty"`
+	Goroutines        int32 `protobuf:"varint,6,opt,name=goroutines,proto3" json:"goroutines,omitempty"`
+}
+
+func (x *MemoryStats) Reset() {
+	*x = MemoryStats{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[73]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MemoryStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemoryStats) ProtoMessage() {}
+
+func (x *MemoryStats) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[73]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemoryStats.ProtoReflect.Descriptor instead.
+func (*MemoryStats) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *MemoryStats) GetHeapAllocBytes() int64 {
+	if x != nil {
+		return x.HeapAllocBytes
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetHeapInuseBytes() int64 {
+	if x != nil {
+		return x.HeapInuseBytes
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetHeapReleasedBytes() int64 {
+	if x != nil {
+		return x.HeapReleasedBytes
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetSysBytes() int64 {
+	if x != nil {
+		return x.SysBytes
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetNumGC() int64 {
+	if x != nil {
+		return x.NumGC
+	}
+	return 0
+}
+
+func (x *MemoryStats) GetGoroutines() int32 {
+	if x != nil {
+		return x.Goroutines
+	}
+	return 0
+}
+
+// What the janitor measured at its last check, and removed since the worker
+// started to keep the disk use within the budget
+type DiskUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UsedBytes      int64  `protobuf:"varint,1,opt,name=usedBytes,proto3" json:"usedBytes,omitempty"` // workspaces, captured output, staged uploads and log files
+	BudgetBytes    int64  `protobuf:"varint,2,opt,name=budgetBytes,proto3" json:"budgetBytes,omitempty"`
+	ReclaimedBytes int64  `protobuf:"varint,3,opt,name=reclaimedBytes,proto3" json:"reclaimedBytes,omitempty"`
+	Evicted        int64  `protobuf:"varint,4,opt,name=evicted,proto3" json:"evicted,omitempty"` // finished jobs' files and rotated log files removed
+	LastCheck      string `protobuf:"bytes,5,opt,name=lastCheck,proto3" json:"lastCheck,omitempty"`
+}
+
+func (x *DiskUsage) Reset() {
+	*x = DiskUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[74]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DiskUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiskUsage) ProtoMessage() {}
+
+func (x *DiskUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[74]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiskUsage.ProtoReflect.Descriptor instead.
+func (*DiskUsage) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *DiskUsage) GetUsedBytes() int64 {
+	if x != nil {
+		return x.UsedBytes
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetBudgetBytes() int64 {
+	if x != nil {
+		return x.BudgetBytes
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetReclaimedBytes() int64 {
+	if x != nil {
+		return x.ReclaimedBytes
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetEvicted() int64 {
+	if x != nil {
+		return x.Evicted
+	}
+	return 0
+}
+
+func (x *DiskUsage) GetLastCheck() string {
+	if x != nil {
+		return x.LastCheck
+	}
+	return ""
+}
+
+// Usage accounting
+// A job counts in the range its end time falls in. Running jobs are included,
+// with their usage so far, when the range reaches the present. Times are
+// RFC 3339; an empty startTime or endTime leaves that side of the range open.
+// Fails with FAILED_PRECONDITION when usage accounting is disabled.
+type GetUsageReportReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tenant    string `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"` // every tenant if empty
+	StartTime string `protobuf:"bytes,2,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime   string `protobuf:"bytes,3,opt,name=endTime,proto3" json:"endTime,omitempty"`
+}
+
+func (x *GetUsageReportReq) Reset() {
+	*x = GetUsageReportReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[75]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetUsageReportReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUsageReportReq) ProtoMessage() {}
+
+func (x *GetUsageReportReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[75]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUsageReportReq.ProtoReflect.Descriptor instead.
+func (*GetUsageReportReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *GetUsageReportReq) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *GetUsageReportReq) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *GetUsageReportReq) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+type JobUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                string  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Tenant            string  `protobuf:"bytes,2,opt,name=tenant,proto3" json:"tenant,omitempty"` // value of the configured tenant label, empty if unset
+	Command           string  `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Status            string  `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	StartTime         string  `protobuf:"bytes,5,opt,name=startTime,proto3" json:"startTime,omitempty"`
+	EndTime           string  `protobuf:"bytes,6,opt,name=endTime,proto3" json:"endTime,omitempty"` // empty while running
+	CpuSeconds        float64 `protobuf:"fixed64,7,opt,name=cpuSeconds,proto3" json:"cpuSeconds,omitempty"`
+	MemoryByteSeconds float64 `protobuf:"fixed64,8,opt,name=memoryByteSeconds,proto3" json:"memoryByteSeconds,omitempty"` // memory usage integrated over the job's run time
+	IoReadBytes       int64   `protobuf:"varint,9,opt,name=ioReadBytes,proto3" json:"ioReadBytes,omitempty"`
+	IoWriteBytes      int64   `protobuf:"varint,10,opt,name=ioWriteBytes,proto3" json:"ioWriteBytes,omitempty"`
+	WallSeconds       float64 `protobuf:"fixed64,11,opt,name=wallSeconds,proto3" json:"wallSeconds,omitempty"`
+}
+
+func (x *JobUsage) Reset() {
+	*x = JobUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[76]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *JobUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JobUsage) ProtoMessage() {}
+
+func (x *JobUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[76]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JobUsage.ProtoReflect.Descriptor instead.
+func (*JobUsage) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *JobUsage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *JobUsage) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *JobUsage) GetCommand() string {
+	if x != nil {
+		return x.Command
+	}
+	return ""
+}
+
+func (x *JobUsage) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *JobUsage) GetStartTime() string {
+	if x != nil {
+		return x.StartTime
+	}
+	return ""
+}
+
+func (x *JobUsage) GetEndTime() string {
+	if x != nil {
+		return x.EndTime
+	}
+	return ""
+}
+
+func (x *JobUsage) GetCpuSeconds() float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
+}
+
+func (x *JobUsage) GetMemoryByteSeconds() float64 {
+	if x != nil {
+		return x.MemoryByteSeconds
+	}
+	return 0
+}
+
+func (x *JobUsage) GetIoReadBytes() int64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *JobUsage) GetIoWriteBytes() int64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+func (x *JobUsage) GetWallSeconds() float64 {
+	if x != nil {
+		return x.WallSeconds
+	}
+	return 0
+}
+
+type TenantUsage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Tenant            string  `protobuf:"bytes,1,opt,name=tenant,proto3" json:"tenant,omitempty"`
+	Jobs              int32   `protobuf:"varint,2,opt,name=jobs,proto3" json:"jobs,omitempty"`
+	CpuSeconds        float64 `protobuf:"fixed64,3,opt,name=cpuSeconds,proto3" json:"cpuSeconds,omitempty"`
+	MemoryByteSeconds float64 `protobuf:"fixed64,4,opt,name=memoryByteSeconds,proto3" json:"memoryByteSeconds,omitempty"`
+	IoReadBytes       int64   `protobuf:"varint,5,opt,name=ioReadBytes,proto3" json:"ioReadBytes,omitempty"`
+	IoWriteBytes      int64   `protobuf:"varint,6,opt,name=ioWriteBytes,proto3" json:"ioWriteBytes,omitempty"`
+	WallSeconds       float64 `protobuf:"fixed64,7,opt,name=wallSeconds,proto3" json:"wallSeconds,omitempty"`
+}
+
+func (x *TenantUsage) Reset() {
+	*x = TenantUsage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[77]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TenantUsage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TenantUsage) ProtoMessage() {}
+
+func (x *TenantUsage) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[77]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TenantUsage.ProtoReflect.Descriptor instead.
+func (*TenantUsage) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *TenantUsage) GetTenant() string {
+	if x != nil {
+		return x.Tenant
+	}
+	return ""
+}
+
+func (x *TenantUsage) GetJobs() int32 {
+	if x != nil {
+		return x.Jobs
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetCpuSeconds() float64 {
+	if x != nil {
+		return x.CpuSeconds
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetMemoryByteSeconds() float64 {
+	if x != nil {
+		return x.MemoryByteSeconds
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetIoReadBytes() int64 {
+	if x != nil {
+		return x.IoReadBytes
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetIoWriteBytes() int64 {
+	if x != nil {
+		return x.IoWriteBytes
+	}
+	return 0
+}
+
+func (x *TenantUsage) GetWallSeconds() float64 {
+	if x != nil {
+		return x.WallSeconds
+	}
+	return 0
+}
+
+type UsageReport struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Jobs    []*JobUsage    `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"`       // ordered by start time
+	Tenants []*TenantUsage `protobuf:"bytes,2,rep,name=tenants,proto3" json:"tenants,omitempty"` // totals of the jobs, ordered by tenant
+}
+
+func (x *UsageReport) Reset() {
+	*x = UsageReport{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[78]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UsageReport) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UsageReport) ProtoMessage() {}
+
+func (x *UsageReport) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[78]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UsageReport.ProtoReflect.Descriptor instead.
+func (*UsageReport) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *UsageReport) GetJobs() []*JobUsage {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+func (x *UsageReport) GetTenants() []*TenantUsage {
+	if x != nil {
+		return x.Tenants
+	}
+	return nil
+}
+
+// Fleet membership
+type RegisterWorkerReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name    string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`       // unique in the fleet, prefixes the ids of the worker's jobs
+	Address string      `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // where the coordinator reaches the worker's JobService
+	Info    *WorkerInfo `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *RegisterWorkerReq) Reset() {
+	*x = RegisterWorkerReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[79]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterWorkerReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerReq) ProtoMessage() {}
+
+func (x *RegisterWorkerReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[79]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerReq.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RegisterWorkerReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterWorkerReq) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *RegisterWorkerReq) GetInfo() *WorkerInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+type RegisterWorkerRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *RegisterWorkerRes) Reset() {
+	*x = RegisterWorkerRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[80]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RegisterWorkerRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterWorkerRes) ProtoMessage() {}
+
+func (x *RegisterWorkerRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[80]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterWorkerRes.ProtoReflect.Descriptor instead.
+func (*RegisterWorkerRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{80}
+}
+
+// A heartbeat from a worker the coordinator does not know, for instance after
+// the coordinator restarted, fails with NOT_FOUND; the worker registers again.
+type HeartbeatReq struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name     string      `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Info     *WorkerInfo `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+	Draining bool        `protobuf:"varint,3,opt,name=draining,proto3" json:"draining,omitempty"` // the worker is shutting down and takes no new jobs
+}
+
+func (x *HeartbeatReq) Reset() {
+	*x = HeartbeatReq{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[81]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatReq) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatReq) ProtoMessage() {}
+
+func (x *HeartbeatReq) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[81]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatReq.ProtoReflect.Descriptor instead.
+func (*HeartbeatReq) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *HeartbeatReq) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *HeartbeatReq) GetInfo() *WorkerInfo {
+	if x != nil {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *HeartbeatReq) GetDraining() bool {
+	if x != nil {
+		return x.Draining
+	}
+	return false
+}
+
+type HeartbeatRes struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *HeartbeatRes) Reset() {
+	*x = HeartbeatRes{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_jobworker_v1_worker_proto_msgTypes[82]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HeartbeatRes) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeartbeatRes) ProtoMessage() {}
+
+func (x *HeartbeatRes) ProtoReflect() protoreflect.Message {
+	mi := &file_jobworker_v1_worker_proto_msgTypes[82]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeartbeatRes.ProtoReflect.Descriptor instead.
+func (*HeartbeatRes) Descriptor() ([]byte, []int) {
+	return file_jobworker_v1_worker_proto_rawDescGZIP(), []int{82}
+}
+
+var File_jobworker_v1_worker_proto protoreflect.FileDescriptor
+
+var file_jobworker_v1_worker_proto_rawDesc = []byte{
+	0x0a, 0x19, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2f, 0x76, 0x31, 0x2f, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x22, 0x2d, 0x0a, 0x04, 0x4a, 0x6f, 0x62,
+	0x73, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x22, 0xb2, 0x0b, 0x0a, 0x03, 0x4a, 0x6f, 0x62,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72,
+	0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1a,
+	0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61,
+	0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18,
+	0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x08,
+	0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02,
+	0x18, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08,
+	0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x2c, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18,
+	0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x3e, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x53, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x73, 0x65, 0x63,
+	0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26,
+	0x0a, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63,
+	0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x68, 0x65,
+	0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x18, 0x13, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12,
+	0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x6c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x50, 0x53, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x50, 0x53, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x13, 0x63, 0x70, 0x75, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c,
+	0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x13, 0x63, 0x70, 0x75, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65, 0x72,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d,
+	0x70, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70,
+	0x12, 0x2c, 0x0a, 0x11, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x74, 0x69, 0x6d,
+	0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1a,
+	0x0a, 0x08, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x75, 0x6d, 0x70, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x08, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x75, 0x6d, 0x70, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f,
+	0x72, 0x65, 0x44, 0x75, 0x6d, 0x70, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x75, 0x6d, 0x70, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73,
+	0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x4d, 0x69,
+	0x63, 0x72, 0x6f, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4d,
+	0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x21, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6c, 0x65,
+	0x61, 0x6e, 0x75, 0x70, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64,
+	0x6c, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x2e,
+	0x0a, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x23, 0x20, 0x01, 0x28, 0x03, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x22, 0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x25, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x1a, 0x36, 0x0a, 0x08,
+	0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x0e, 0x0a,
+	0x0c, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xe7, 0x0c,
+	0x0a, 0x09, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x02, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x61, 0x78,
+	0x43, 0x50, 0x55, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x6d,
+	0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6d, 0x61,
+	0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f,
+	0x42, 0x50, 0x53, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x6d,
+	0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12, 0x32, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x06,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x2e, 0x45, 0x6e,
+	0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x18, 0x0a, 0x07, 0x65,
+	0x6e, 0x76, 0x46, 0x69, 0x6c, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x65, 0x6e,
+	0x76, 0x46, 0x69, 0x6c, 0x65, 0x12, 0x44, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45,
+	0x6e, 0x76, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x12, 0x1a, 0x0a, 0x08, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x75,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a,
+	0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12,
+	0x3b, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52,
+	0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x3b, 0x0a, 0x06,
+	0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x74, 0x64,
+	0x69, 0x6e, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x73, 0x74, 0x64, 0x69, 0x6e, 0x12,
+	0x32, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x43, 0x61, 0x70, 0x61, 0x62,
+	0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x14, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
+	0x69, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x05, 0x73, 0x68, 0x65, 0x6c, 0x6c, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x12, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x12, 0x18, 0x0a,
+	0x07, 0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x70, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x18, 0x15, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x73, 0x6f, 0x6c,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x16, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f,
+	0x6d, 0x70, 0x18, 0x17, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d,
+	0x70, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53,
+	0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x74, 0x69,
+	0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x23, 0x0a, 0x0a, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x45, 0x6e, 0x76, 0x18, 0x19, 0x20,
+	0x01, 0x28, 0x08, 0x48, 0x00, 0x52, 0x0a, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x45, 0x6e,
+	0x76, 0x88, 0x01, 0x01, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53,
+	0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d,
+	0x6d, 0x61, 0x6e, 0x64, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x12, 0x26, 0x0a, 0x0e, 0x6d, 0x61,
+	0x78, 0x49, 0x64, 0x6c, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x1b, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x6d, 0x61, 0x78, 0x49, 0x64, 0x6c, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x64, 0x6c, 0x65, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x1c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x64, 0x6c, 0x65, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2c, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6c, 0x75, 0x73,
+	0x68, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x46, 0x6c, 0x75, 0x73, 0x68, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73,
+	0x12, 0x32, 0x0a, 0x14, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50,
+	0x65, 0x72, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x03, 0x52, 0x14,
+	0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x50, 0x65, 0x72, 0x53, 0x65,
+	0x63, 0x6f, 0x6e, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x11, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x41, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x20, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75,
+	0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x57, 0x69, 0x6e,
+	0x64, 0x6f, 0x77, 0x73, 0x18, 0x21, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x6c, 0x6c, 0x6f,
+	0x77, 0x65, 0x64, 0x57, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x71, 0x75,
+	0x65, 0x75, 0x65, 0x54, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x22, 0x20, 0x01, 0x28, 0x03, 0x52, 0x13, 0x71, 0x75, 0x65, 0x75, 0x65, 0x54, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x23, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x73, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x31, 0x0a, 0x07,
+	0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x24, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
+	0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x12,
+	0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x25, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x18, 0x0a,
+	0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x26, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a,
+	0x3c, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a,
+	0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x0d, 0x0a, 0x0b, 0x5f, 0x69, 0x6e, 0x68,
+	0x65, 0x72, 0x69, 0x74, 0x45, 0x6e, 0x76, 0x22, 0x39, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x53, 0x6f,
+	0x63, 0x6b, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x22, 0x36, 0x0a, 0x08, 0x4a, 0x6f, 0x62, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x93, 0x02, 0x0a, 0x0b, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x6f, 0x72, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x6f, 0x72, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x70, 0x61, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68,
+	0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x74, 0x69,
+	0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0e, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x30, 0x0a, 0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c,
+	0x61, 0x79, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x13, 0x69, 0x6e, 0x69, 0x74, 0x69, 0x61, 0x6c, 0x44, 0x65, 0x6c, 0x61, 0x79, 0x53, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x66, 0x61, 0x69, 0x6c, 0x75, 0x72, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x22, 0xdc, 0x08, 0x0a, 0x09, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x06,
+	0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61,
+	0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78,
+	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x32, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x0b, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x2e, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x44, 0x0a, 0x09, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76,
+	0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x77, 0x6f, 0x72, 0x6b,
+	0x73, 0x70, 0x61, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63,
+	0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x52, 0x65, 0x73,
+	0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72,
+	0x6f, 0x62, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50, 0x72, 0x6f, 0x62,
+	0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x13, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x49, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x49, 0x64, 0x12, 0x3b, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x15, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x2e, 0x4c, 0x61, 0x62,
+	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x17, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x50, 0x53, 0x18, 0x18, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x50, 0x53, 0x12, 0x1c, 0x0a, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x10, 0x0a, 0x03, 0x71, 0x6f, 0x73, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x03, 0x71, 0x6f, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x1a, 0x36,
+	0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x98, 0x01, 0x0a, 0x11, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68,
+	0x65, 0x64, 0x52, 0x65, 0x73, 0x12, 0x33, 0x0a, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x48,
+	0x00, 0x52, 0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x06, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x12, 0x2b, 0x0a, 0x04, 0x65, 0x78, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x15, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x78, 0x69, 0x74, 0x48, 0x00, 0x52, 0x04, 0x65, 0x78, 0x69,
+	0x74, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x22, 0x73, 0x0a, 0x07, 0x4a, 0x6f,
+	0x62, 0x45, 0x78, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1a, 0x0a,
+	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x22,
+	0x41, 0x0a, 0x0f, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72,
+	0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x22, 0xd9, 0x02, 0x0a, 0x0e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x12, 0x35, 0x0a, 0x06, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x6a, 0x6f,
+	0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x06, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x72, 0x65, 0x73, 0x6f, 0x6c, 0x76, 0x65, 0x64, 0x43, 0x6f,
+	0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72, 0x65, 0x73,
+	0x6f, 0x6c, 0x76, 0x65, 0x64, 0x43, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x1a, 0x0a, 0x06,
+	0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d,
+	0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61,
+	0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01,
+	0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70,
+	0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x22, 0x21,
+	0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0xcf, 0x0c, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12,
+	0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61,
+	0x72, 0x67, 0x73, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12,
+	0x20, 0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72,
+	0x79, 0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50,
+	0x53, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78, 0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x38, 0x0a,
+	0x03, 0x65, 0x6e, 0x76, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x26, 0x2e, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x2e, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x4a, 0x0a, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65,
+	0x74, 0x45, 0x6e, 0x76, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2c, 0x2e, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x2e, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x73, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x45, 0x6e, 0x76, 0x12, 0x26, 0x0a, 0x0e, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x4c, 0x6f, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6f, 0x75, 0x74,
+	0x70, 0x75, 0x74, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x26, 0x0a, 0x0e, 0x77,
+	0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0e, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x77, 0x6f, 0x72, 0x6b, 0x73, 0x70, 0x61, 0x63, 0x65, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x50, 0x6f,
+	0x6c, 0x69, 0x63, 0x79, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x72, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x50, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x61, 0x78,
+	0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x6d, 0x61, 0x78, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72,
+	0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12, 0x3b, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74,
+	0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x18, 0x12, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x50, 0x72, 0x6f, 0x62, 0x65, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x50,
+	0x72, 0x6f, 0x62, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x13,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x18, 0x14, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x12, 0x41, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x15, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x52, 0x65, 0x73, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a,
+	0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x18, 0x18, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x12, 0x1c, 0x0a,
+	0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x19, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x69, 0x73, 0x6f, 0x6c, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x30, 0x0a, 0x13, 0x63,
+	0x70, 0x75, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65,
+	0x6e, 0x74, 0x18, 0x1a, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x63, 0x70, 0x75, 0x54, 0x68, 0x72,
+	0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x10, 0x0a,
+	0x03, 0x71, 0x6f, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x71, 0x6f, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x73, 0x65, 0x63, 0x63, 0x6f, 0x6d, 0x70, 0x12, 0x2c, 0x0a, 0x11, 0x74, 0x69, 0x6d,
+	0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x1d,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x11, 0x74, 0x69, 0x6d, 0x65, 0x4f, 0x66, 0x66, 0x73, 0x65, 0x74,
+	0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x6f, 0x72, 0x65, 0x44,
+	0x75, 0x6d, 0x70, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x6f, 0x72, 0x65, 0x44,
+	0x75, 0x6d, 0x70, 0x12, 0x24, 0x0a, 0x0d, 0x63, 0x6f, 0x72, 0x65, 0x44, 0x75, 0x6d, 0x70, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x1f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6f, 0x72, 0x65,
+	0x44, 0x75, 0x6d, 0x70, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x6c, 0x61, 0x75,
+	0x6e, 0x63, 0x68, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x20, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0c, 0x6c, 0x61, 0x75, 0x6e, 0x63, 0x68, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x24, 0x0a,
+	0x0d, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x21,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x63, 0x6c, 0x65, 0x61, 0x6e, 0x75, 0x70, 0x4d, 0x69, 0x63,
+	0x72, 0x6f, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x18, 0x22, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x69, 0x64, 0x6c, 0x65, 0x12, 0x2e, 0x0a, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x23, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x12, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x44, 0x72, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x18, 0x24, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12,
+	0x20, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x25,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x31, 0x0a, 0x07, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x73, 0x18, 0x26, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x52, 0x07, 0x73, 0x6f, 0x63,
+	0x6b, 0x65, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0x27, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x18, 0x28,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x61, 0x73,
+	0x6f, 0x6e, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a, 0x0e, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14,
+	0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0x25, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f,
+	0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xf9, 0x03, 0x0a, 0x0d, 0x4a,
+	0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x24, 0x0a, 0x0d,
+	0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x68, 0x61, 0x32, 0x35, 0x36, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x53, 0x68, 0x61, 0x32,
+	0x35, 0x36, 0x12, 0x36, 0x0a, 0x03, 0x65, 0x6e, 0x76, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x24, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x45, 0x6e, 0x76,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x03, 0x65, 0x6e, 0x76, 0x12, 0x24, 0x0a, 0x0d, 0x6b, 0x65,
+	0x72, 0x6e, 0x65, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x6b, 0x65, 0x72, 0x6e, 0x65, 0x6c, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x50, 0x61, 0x74, 0x68,
+	0x12, 0x3f, 0x0a, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x27, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x2e, 0x43, 0x67,
+	0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x12, 0x24, 0x0a, 0x0d, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a, 0x0a, 0x72, 0x65, 0x63, 0x6f, 0x72,
+	0x64, 0x65, 0x64, 0x41, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x63,
+	0x6f, 0x72, 0x64, 0x65, 0x64, 0x41, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x42, 0x79, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x69, 0x67, 0x6e, 0x65,
+	0x64, 0x42, 0x79, 0x1a, 0x36, 0x0a, 0x08, 0x45, 0x6e, 0x76, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x39, 0x0a, 0x0b, 0x43,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x24, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x7a, 0x0a, 0x0c,
+	0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x36, 0x0a, 0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x09, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x61,
+	0x6d, 0x70, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x61, 0x6d, 0x70, 0x6c, 0x65, 0x64, 0x41, 0x74, 0x22, 0x8a, 0x02, 0x0a, 0x0a, 0x4a, 0x6f, 0x62,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x70, 0x69,
+	0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x70, 0x70, 0x69, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x64, 0x65, 0x70, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x64, 0x65,
+	0x70, 0x74, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x74, 0x68, 0x72, 0x65, 0x61, 0x64, 0x73, 0x12, 0x1c, 0x0a,
+	0x09, 0x63, 0x70, 0x75, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x09, 0x63, 0x70, 0x75, 0x4d, 0x69, 0x63, 0x72, 0x6f, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63,
+	0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52,
+	0x0a, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x72,
+	0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x72,
+	0x73, 0x73, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74,
+	0x54, 0x69, 0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x22, 0x62, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x2c, 0x0a, 0x11, 0x67, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69,
+	0x6f, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x11,
+	0x67, 0x72, 0x61, 0x63, 0x65, 0x50, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x4d, 0x69, 0x6c, 0x6c, 0x69,
+	0x73, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x6c, 0x22, 0x82, 0x01, 0x0a, 0x0a, 0x53, 0x74,
+	0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x78,
+	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x65, 0x78,
+	0x69, 0x74, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x22, 0x1e,
+	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x36,
+	0x0a, 0x0c, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16,
+	0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
+	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x1d, 0x0a, 0x0b, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1e, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1e, 0x0a, 0x0c, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x1e, 0x0a, 0x0c, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x37, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41,
+	0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x37,
+	0x0a, 0x0d, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x16, 0x0a, 0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x06, 0x6f, 0x66, 0x66, 0x73, 0x65, 0x74, 0x22, 0x25, 0x0a, 0x09, 0x44, 0x61, 0x74, 0x61, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x3b,
+	0x0a, 0x0f, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22, 0x43, 0x0a, 0x0f, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x22, 0x25, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x25, 0x0a, 0x0f, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0x47,
+	0x0a, 0x09, 0x46, 0x69, 0x6c, 0x65, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x70,
+	0x61, 0x74, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x0d, 0x52, 0x04, 0x6d, 0x6f, 0x64, 0x65, 0x22, 0x65, 0x0a, 0x11, 0x55, 0x70, 0x6c, 0x6f, 0x61,
+	0x64, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x52, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x75, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x66, 0x69, 0x6c, 0x65,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x1e,
+	0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0x44,
+	0x0a, 0x0e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x43, 0x68, 0x75, 0x6e, 0x6b,
+	0x12, 0x1e, 0x0a, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04,
+	0x64, 0x61, 0x74, 0x61, 0x22, 0x64, 0x0a, 0x11, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f,
+	0x62, 0x50, 0x72, 0x6f, 0x67, 0x72, 0x65, 0x73, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x72, 0x65, 0x63,
+	0x65, 0x69, 0x76, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0d, 0x72, 0x65, 0x63, 0x65, 0x69, 0x76, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12,
+	0x29, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x4f, 0x0a, 0x0d, 0x50, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73,
+	0x74, 0x65, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x73, 0x74, 0x65, 0x70, 0x12,
+	0x16, 0x0a, 0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x06, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0xa0, 0x01, 0x0a, 0x0c,
+	0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x65, 0x70, 0x12, 0x12, 0x0a, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x12, 0x29, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e,
+	0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x12, 0x1c, 0x0a, 0x09, 0x64,
+	0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09,
+	0x64, 0x65, 0x70, 0x65, 0x6e, 0x64, 0x73, 0x4f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x69, 0x6e, 0x70,
+	0x75, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x22, 0x42,
+	0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x52, 0x65, 0x71,
+	0x12, 0x30, 0x0a, 0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x65, 0x70, 0x52, 0x05, 0x73, 0x74, 0x65,
+	0x70, 0x73, 0x22, 0x26, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x6c, 0x0a, 0x12, 0x50, 0x69,
+	0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0xa2, 0x01, 0x0a, 0x08, 0x50, 0x69, 0x70,
+	0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x36, 0x0a,
+	0x05, 0x73, 0x74, 0x65, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65,
+	0x6c, 0x69, 0x6e, 0x65, 0x53, 0x74, 0x65, 0x70, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05,
+	0x73, 0x74, 0x65, 0x70, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69,
+	0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54,
+	0x69, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xc5, 0x01,
+	0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x52, 0x04, 0x6a, 0x6f, 0x62,
+	0x73, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x42, 0x50, 0x53, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x42, 0x50, 0x53, 0x22, 0x20, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0x21, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x4a,
+	0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x22, 0xe9, 0x02, 0x0a, 0x08, 0x4a,
+	0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41,
+	0x74, 0x12, 0x25, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x11, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x75, 0x6e, 0x6e,
+	0x69, 0x6e, 0x67, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x6e, 0x69,
+	0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x73, 0x75, 0x63, 0x63, 0x65, 0x65, 0x64, 0x65, 0x64,
+	0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x06, 0x66, 0x61, 0x69, 0x6c, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x74, 0x6f, 0x70,
+	0x70, 0x65, 0x64, 0x18, 0x09, 0x20, 0x01, 0x28, 0x05, 0x52, 0x07, 0x73, 0x74, 0x6f, 0x70, 0x70,
+	0x65, 0x64, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69,
+	0x6c, 0x6c, 0x69, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c,
+	0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x50, 0x53, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x42, 0x50, 0x53, 0x22, 0x3b, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x12, 0x2e, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x06, 0x67, 0x72, 0x6f,
+	0x75, 0x70, 0x73, 0x22, 0xbd, 0x01, 0x0a, 0x0f, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x05,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x41, 0x0a, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x52, 0x65, 0x73, 0x2e, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x52, 0x06, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x45, 0x72, 0x72, 0x6f,
+	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a,
+	0x02, 0x38, 0x01, 0x22, 0xc3, 0x01, 0x0a, 0x0b, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x33, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74,
+	0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x12, 0x2b,
+	0x0a, 0x04, 0x73, 0x70, 0x65, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x52, 0x04, 0x73, 0x70, 0x65, 0x63, 0x12, 0x1c, 0x0a, 0x09, 0x63,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x22, 0x85, 0x01, 0x0a, 0x0d, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x22, 0x0a, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74, 0x56, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x64, 0x65, 0x66, 0x61, 0x75, 0x6c, 0x74,
+	0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x22, 0x67, 0x0a, 0x14, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x35, 0x0a, 0x08, 0x74, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f,
+	0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x07, 0x72, 0x65, 0x70, 0x6c, 0x61, 0x63, 0x65, 0x22, 0x47, 0x0a, 0x0c, 0x4a, 0x6f,
+	0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x09, 0x74, 0x65,
+	0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
+	0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x09, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x22, 0xb7, 0x01, 0x0a, 0x15, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x46, 0x72,
+	0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x12, 0x1a, 0x0a,
+	0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x74, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x47, 0x0a, 0x06, 0x70, 0x61, 0x72,
+	0x61, 0x6d, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2f, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x46,
+	0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x2e, 0x50,
+	0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61,
+	0x6d, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x50, 0x0a,
+	0x0b, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03,
+	0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x2f,
+	0x0a, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x06, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x22,
+	0xcb, 0x01, 0x0a, 0x09, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x3b, 0x0a,
+	0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x54,
+	0x68, 0x61, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x10, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x54, 0x68, 0x61, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x45, 0x0a,
+	0x0d, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x0e,
+	0x0a, 0x02, 0x6f, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x02, 0x6f, 0x6b, 0x12, 0x14,
+	0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x22, 0x44, 0x0a, 0x0b, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x73,
+	0x52, 0x65, 0x73, 0x12, 0x35, 0x0a, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x75, 0x6c,
+	0x74, 0x52, 0x07, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x73, 0x22, 0x3f, 0x0a, 0x15, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x02,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x74, 0x79, 0x70, 0x65, 0x73, 0x22, 0xb2, 0x01, 0x0a, 0x08,
+	0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x05,
+	0x6a, 0x6f, 0x62, 0x49, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6a, 0x6f, 0x62,
+	0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x30,
+	0x0a, 0x13, 0x63, 0x70, 0x75, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65,
+	0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x13, 0x63, 0x70, 0x75,
+	0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x22, 0x51, 0x0a, 0x13, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74,
+	0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x12, 0x10, 0x0a, 0x03, 0x69, 0x64, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x03, 0x69, 0x64, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f,
+	0x6e, 0x64, 0x73, 0x22, 0xe0, 0x04, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x72, 0x69,
+	0x63, 0x73, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x12, 0x0a, 0x04,
+	0x61, 0x72, 0x67, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73,
+	0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72,
+	0x74, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x07, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x70,
+	0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x1a, 0x0a, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x06, 0x6d, 0x61, 0x78, 0x43, 0x50, 0x55, 0x12, 0x20,
+	0x0a, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0d, 0x20, 0x01, 0x28,
+	0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x12, 0x1e, 0x0a, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x05, 0x42, 0x02, 0x18, 0x01, 0x52, 0x08, 0x6d, 0x61, 0x78, 0x49, 0x4f, 0x42, 0x50, 0x53,
+	0x12, 0x26, 0x0a, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c,
+	0x69, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x70, 0x75, 0x4c, 0x69, 0x6d,
+	0x69, 0x74, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x12, 0x2a, 0x0a, 0x10, 0x6d, 0x65, 0x6d, 0x6f,
+	0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x10, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x10, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42,
+	0x50, 0x53, 0x18, 0x11, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x69, 0x6f, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x42, 0x50, 0x53, 0x12, 0x2a, 0x0a, 0x10, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65,
+	0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x01, 0x52, 0x10,
+	0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74,
+	0x12, 0x24, 0x0a, 0x0d, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x64, 0x55, 0x73, 0x65,
+	0x63, 0x18, 0x13, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0d, 0x74, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c,
+	0x65, 0x64, 0x55, 0x73, 0x65, 0x63, 0x22, 0xc2, 0x01, 0x0a, 0x10, 0x41, 0x67, 0x67, 0x72, 0x65,
+	0x67, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6e,
+	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
+	0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6a,
+	0x6f, 0x62, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a, 0x63, 0x70, 0x75, 0x50, 0x65, 0x72, 0x63,
+	0x65, 0x6e, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69, 0x6f, 0x52, 0x65,
+	0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6f, 0x57, 0x72, 0x69,
+	0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x69,
+	0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xd2, 0x01, 0x0a, 0x12,
+	0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x6e, 0x61, 0x70, 0x73, 0x68,
+	0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x2c, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x62, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x36,
+	0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67,
+	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x38, 0x0a, 0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x65,
+	0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73,
+	0x22, 0x36, 0x0a, 0x0a, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x12, 0x0e,
+	0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18,
+	0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x28, 0x0a, 0x10, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x52, 0x65, 0x73, 0x12, 0x14, 0x0a, 0x05,
+	0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x62, 0x79, 0x74,
+	0x65, 0x73, 0x22, 0xf3, 0x02, 0x0a, 0x0a, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66,
+	0x6f, 0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x61, 0x70, 0x69, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x6f, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x6f,
+	0x73, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x72, 0x63, 0x68, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c,
+	0x69, 0x74, 0x69, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x69, 0x6d,
+	0x69, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0d, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x73, 0x12,
+	0x18, 0x0a, 0x07, 0x6d, 0x61, 0x78, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x07, 0x6d, 0x61, 0x78, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x72, 0x75, 0x6e,
+	0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b,
+	0x72, 0x75, 0x6e, 0x6e, 0x69, 0x6e, 0x67, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x2b, 0x0a, 0x04, 0x64,
+	0x69, 0x73, 0x6b, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x73, 0x6b, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x04, 0x64, 0x69, 0x73, 0x6b, 0x12, 0x3e, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70,
+	0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74,
+	0x73, 0x52, 0x05, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x22, 0x5a, 0x0a, 0x0b, 0x44, 0x69, 0x61, 0x67,
+	0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x72, 0x65, 0x61, 0x64, 0x79, 0x12, 0x35, 0x0a,
+	0x06, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1d, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69, 0x61,
+	0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x52, 0x06, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x73, 0x22, 0x67, 0x0a, 0x0f, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74,
+	0x69, 0x63, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61,
+	0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x65, 0x74, 0x61, 0x69, 0x6c, 0x12, 0x10, 0x0a, 0x03, 0x66,
+	0x69, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x66, 0x69, 0x78, 0x22, 0x94, 0x01,
+	0x0a, 0x0c, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1c,
+	0x0a, 0x09, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x09, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b,
+	0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0b, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22,
+	0x0a, 0x0c, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64, 0x4a, 0x6f, 0x62,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x73, 0x70, 0x69, 0x6c, 0x6c, 0x65, 0x64,
+	0x4a, 0x6f, 0x62, 0x73, 0x22, 0x4e, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x70,
+	0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x70, 0x61,
+	0x63, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x70, 0x50, 0x72, 0x6f,
+	0x66, 0x69, 0x6c, 0x65, 0x22, 0xb1, 0x04, 0x0a, 0x0a, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x66, 0x69, 0x6e, 0x69, 0x73,
+	0x68, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x66,
+	0x69, 0x6e, 0x69, 0x73, 0x68, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x68,
+	0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72, 0x69, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x0e, 0x68, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x45, 0x6e, 0x74, 0x72,
+	0x69, 0x65, 0x73, 0x12, 0x3e, 0x0a, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42,
+	0x75, 0x66, 0x66, 0x65, 0x72, 0x52, 0x0c, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x75, 0x66,
+	0x66, 0x65, 0x72, 0x12, 0x26, 0x0a, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65,
+	0x64, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x63, 0x6f, 0x6d,
+	0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x28, 0x0a, 0x0f, 0x63,
+	0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
+	0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72,
+	0x65, 0x73, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x11, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x6f, 0x6d, 0x70, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d,
+	0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c,
+	0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1e, 0x0a,
+	0x0a, 0x73, 0x61, 0x76, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x0a, 0x73, 0x61, 0x76, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a,
+	0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x18, 0x0b, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0b, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x4a, 0x6f, 0x62, 0x73, 0x12,
+	0x22, 0x0a, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18,
+	0x0c, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0c, 0x65, 0x78, 0x70, 0x69, 0x72, 0x65, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x12, 0x31, 0x0a, 0x06, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x06,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x12, 0x20, 0x0a, 0x0b, 0x68, 0x65, 0x61, 0x70, 0x50, 0x72,
+	0x6f, 0x66, 0x69, 0x6c, 0x65, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0b, 0x68, 0x65, 0x61,
+	0x70, 0x50, 0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x22, 0xdd, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x6d,
+	0x6f, 0x72, 0x79, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x68, 0x65, 0x61, 0x70,
+	0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x68, 0x65, 0x61, 0x70, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x26, 0x0a, 0x0e, 0x68, 0x65, 0x61, 0x70, 0x49, 0x6e, 0x75, 0x73, 0x65, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x68, 0x65, 0x61, 0x70, 0x49, 0x6e,
+	0x75, 0x73, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x68, 0x65, 0x61, 0x70,
+	0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x11, 0x68, 0x65, 0x61, 0x70, 0x52, 0x65, 0x6c, 0x65, 0x61, 0x73, 0x65,
+	0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x73, 0x79, 0x73, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x08, 0x73, 0x79, 0x73, 0x42, 0x79, 0x74,
+	0x65, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x75, 0x6d, 0x47, 0x43, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x05, 0x6e, 0x75, 0x6d, 0x47, 0x43, 0x12, 0x1e, 0x0a, 0x0a, 0x67, 0x6f, 0x72, 0x6f,
+	0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x67, 0x6f,
+	0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x73, 0x22, 0xab, 0x01, 0x0a, 0x09, 0x44, 0x69, 0x73,
+	0x6b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x75, 0x73, 0x65, 0x64, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x75, 0x73, 0x65, 0x64, 0x42,
+	0x79, 0x74, 0x65, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x62, 0x75, 0x64, 0x67, 0x65, 0x74, 0x42, 0x79,
+	0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x62, 0x75, 0x64, 0x67, 0x65,
+	0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x63, 0x6c, 0x61, 0x69,
+	0x6d, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e,
+	0x72, 0x65, 0x63, 0x6c, 0x61, 0x69, 0x6d, 0x65, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x18,
+	0x0a, 0x07, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x07, 0x65, 0x76, 0x69, 0x63, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74,
+	0x43, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6c, 0x61, 0x73,
+	0x74, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x22, 0x63, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71, 0x12, 0x16, 0x0a, 0x06, 0x74,
+	0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e,
+	0x61, 0x6e, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d,
+	0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x22, 0xd2, 0x02, 0x0a, 0x08,
+	0x4a, 0x6f, 0x62, 0x55, 0x73, 0x61, 0x67, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74,
+	0x12, 0x18, 0x0a, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x69, 0x6d, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x54, 0x69, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x70,
+	0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0a,
+	0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x11, 0x6d, 0x65,
+	0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74,
+	0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6f, 0x52, 0x65,
+	0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x69,
+	0x6f, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c, 0x69, 0x6f,
+	0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0c, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x0b, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x22, 0xef, 0x01, 0x0a, 0x0b, 0x54, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x1e, 0x0a, 0x0a,
+	0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x63, 0x70, 0x75, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x2c, 0x0a, 0x11,
+	0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42, 0x79, 0x74, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64,
+	0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x11, 0x6d, 0x65, 0x6d, 0x6f, 0x72, 0x79, 0x42,
+	0x79, 0x74, 0x65, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12, 0x20, 0x0a, 0x0b, 0x69, 0x6f,
+	0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x0b, 0x69, 0x6f, 0x52, 0x65, 0x61, 0x64, 0x42, 0x79, 0x74, 0x65, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x0c, 0x69, 0x6f, 0x57, 0x72, 0x69, 0x74, 0x65, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x12, 0x20, 0x0a, 0x0b, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x0b, 0x77, 0x61, 0x6c, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x22, 0x6e, 0x0a, 0x0b, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72,
+	0x74, 0x12, 0x2a, 0x0a, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x04, 0x6a, 0x6f, 0x62, 0x73, 0x12, 0x33, 0x0a,
+	0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x19,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x65,
+	0x6e, 0x61, 0x6e, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x74, 0x65, 0x6e, 0x61, 0x6e,
+	0x74, 0x73, 0x22, 0x6f, 0x0a, 0x11, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2c, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x22, 0x13, 0x0a, 0x11, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65, 0x73, 0x22, 0x6c, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x72,
+	0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2c, 0x0a, 0x04,
+	0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x72,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x72,
+	0x61, 0x69, 0x6e, 0x69, 0x6e, 0x67, 0x22, 0x0e, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62,
+	0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x32, 0xb4, 0x15, 0x0a, 0x0a, 0x4a, 0x6f, 0x62, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x06, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x12,
+	0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52,
+	0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x4c, 0x0a, 0x0e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x12, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x1f,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75,
+	0x6e, 0x4a, 0x6f, 0x62, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x65, 0x64, 0x52, 0x65, 0x73, 0x30,
+	0x01, 0x12, 0x4e, 0x0a, 0x0c, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x12, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x73, 0x22,
+	0x00, 0x12, 0x54, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x21, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f, 0x76, 0x65,
+	0x6e, 0x61, 0x6e, 0x63, 0x65, 0x22, 0x00, 0x12, 0x51, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4a, 0x6f,
+	0x62, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x20, 0x2e, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x50,
+	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x00, 0x12, 0x46, 0x0a, 0x0b, 0x56, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x1a, 0x1c, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73,
+	0x22, 0x00, 0x12, 0x3f, 0x0a, 0x07, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x12, 0x18, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f,
+	0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x09, 0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62,
+	0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x75, 0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x75,
+	0x6d, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x40, 0x0a, 0x08, 0x52, 0x65,
+	0x72, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x12, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x72, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x1a, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x09,
+	0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67,
+	0x73, 0x12, 0x1b, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x4c, 0x6f, 0x67, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x17,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x61,
+	0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x42, 0x0a, 0x09, 0x45, 0x78, 0x70,
+	0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x78, 0x70, 0x6f, 0x72, 0x74, 0x4a, 0x6f, 0x62, 0x52,
+	0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x4c, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x12,
+	0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x4a, 0x6f, 0x62, 0x41, 0x72, 0x74, 0x69, 0x66, 0x61, 0x63, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x61, 0x74, 0x61, 0x43, 0x68, 0x75, 0x6e, 0x6b, 0x30, 0x01, 0x12, 0x3c, 0x0a, 0x08, 0x4c,
+	0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x12, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x73, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x53, 0x65, 0x63, 0x72, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53,
+	0x65, 0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x53, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c, 0x65, 0x73, 0x12, 0x17, 0x2e, 0x6a, 0x6f,
+	0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x65, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x4a, 0x6f, 0x62, 0x46, 0x69, 0x6c,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x28, 0x01, 0x12, 0x4e, 0x0a, 0x09, 0x53, 0x75, 0x62,
+	0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x12, 0x1c, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x43,
+	0x68, 0x75, 0x6e, 0x6b, 0x1a, 0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62, 0x6d, 0x69, 0x74, 0x4a, 0x6f, 0x62, 0x50, 0x72, 0x6f,
+	0x67, 0x72, 0x65, 0x73, 0x73, 0x28, 0x01, 0x30, 0x01, 0x12, 0x45, 0x0a, 0x0b, 0x52, 0x75, 0x6e,
+	0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x12, 0x1c, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x50, 0x69, 0x70, 0x65, 0x6c,
+	0x69, 0x6e, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b,
+	0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x22, 0x00,
+	0x12, 0x51, 0x0a, 0x11, 0x47, 0x65, 0x74, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x22, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e, 0x65,
+	0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x69, 0x70, 0x65, 0x6c, 0x69, 0x6e,
+	0x65, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f,
+	0x75, 0x70, 0x12, 0x1c, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71,
+	0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x0b, 0x47, 0x65,
+	0x74, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x4a, 0x6f, 0x62, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72,
+	0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x22,
+	0x00, 0x12, 0x46, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f,
+	0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x22, 0x00, 0x12, 0x4e, 0x0a, 0x0c, 0x53, 0x74, 0x6f,
+	0x70, 0x4a, 0x6f, 0x62, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x71, 0x1a, 0x1d, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x54, 0x0a, 0x11, 0x43, 0x72, 0x65,
+	0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x12, 0x22,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72,
+	0x65, 0x61, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x52,
+	0x65, 0x71, 0x1a, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x22, 0x00, 0x12,
+	0x4c, 0x0a, 0x10, 0x4c, 0x69, 0x73, 0x74, 0x4a, 0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61,
+	0x74, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x4a,
+	0x6f, 0x62, 0x54, 0x65, 0x6d, 0x70, 0x6c, 0x61, 0x74, 0x65, 0x73, 0x22, 0x00, 0x12, 0x54, 0x0a,
+	0x12, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d, 0x70, 0x6c,
+	0x61, 0x74, 0x65, 0x12, 0x23, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x46, 0x72, 0x6f, 0x6d, 0x54, 0x65, 0x6d,
+	0x70, 0x6c, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x1a, 0x17, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x75, 0x6e, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x73, 0x22, 0x00, 0x12, 0x42, 0x0a, 0x08, 0x53, 0x74, 0x6f, 0x70, 0x4a, 0x6f, 0x62, 0x73, 0x12,
+	0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x19, 0x2e, 0x6a, 0x6f, 0x62,
+	0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f,
+	0x62, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x44, 0x0a, 0x0a, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x73, 0x12, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65,
+	0x72, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x42, 0x75, 0x6c, 0x6b, 0x4a, 0x6f, 0x62, 0x73, 0x52, 0x65, 0x73, 0x22, 0x00, 0x12, 0x59, 0x0a,
+	0x10, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x12, 0x21, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
+	0x73, 0x52, 0x65, 0x71, 0x1a, 0x20, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x53, 0x6e,
+	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x30, 0x01, 0x12, 0x4d, 0x0a, 0x0d, 0x57, 0x72, 0x69, 0x74,
+	0x65, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x12, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77,
+	0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x64, 0x69, 0x6e, 0x43, 0x68,
+	0x75, 0x6e, 0x6b, 0x1a, 0x1e, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x57, 0x72, 0x69, 0x74, 0x65, 0x4a, 0x6f, 0x62, 0x53, 0x74, 0x64, 0x69, 0x6e,
+	0x52, 0x65, 0x73, 0x22, 0x00, 0x28, 0x01, 0x12, 0x47, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x57, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f,
+	0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x18, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x2e, 0x76, 0x31, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x22, 0x00,
+	0x12, 0x49, 0x0a, 0x0e, 0x47, 0x65, 0x74, 0x44, 0x69, 0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69,
+	0x63, 0x73, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x69,
+	0x61, 0x67, 0x6e, 0x6f, 0x73, 0x74, 0x69, 0x63, 0x73, 0x22, 0x00, 0x12, 0x4b, 0x0a, 0x0d, 0x47,
+	0x65, 0x74, 0x53, 0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x12, 0x1e, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x53,
+	0x74, 0x6f, 0x72, 0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65, 0x71, 0x1a, 0x18, 0x2e, 0x6a,
+	0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x74, 0x6f, 0x72,
+	0x65, 0x53, 0x74, 0x61, 0x74, 0x73, 0x22, 0x00, 0x12, 0x53, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73,
+	0x63, 0x72, 0x69, 0x62, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x23,
+	0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x52, 0x65, 0x71, 0x1a, 0x16, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e,
+	0x76, 0x31, 0x2e, 0x4a, 0x6f, 0x62, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x30, 0x01, 0x12, 0x4e, 0x0a,
+	0x0e, 0x47, 0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x12,
+	0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x47,
+	0x65, 0x74, 0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x65, 0x71,
+	0x1a, 0x19, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x55, 0x73, 0x61, 0x67, 0x65, 0x52, 0x65, 0x70, 0x6f, 0x72, 0x74, 0x22, 0x00, 0x32, 0xab, 0x01,
+	0x0a, 0x0c, 0x46, 0x6c, 0x65, 0x65, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x54,
+	0x0a, 0x0e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72,
+	0x12, 0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52, 0x65,
+	0x71, 0x1a, 0x1f, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x57, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x52,
+	0x65, 0x73, 0x22, 0x00, 0x12, 0x45, 0x0a, 0x09, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61,
+	0x74, 0x12, 0x1a, 0x2e, 0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31,
+	0x2e, 0x48, 0x65, 0x61, 0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x1a, 0x1a, 0x2e,
+	0x6a, 0x6f, 0x62, 0x77, 0x6f, 0x72, 0x6b, 0x65, 0x72, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61,
+	0x72, 0x74, 0x62, 0x65, 0x61, 0x74, 0x52, 0x65, 0x73, 0x22, 0x00, 0x42, 0x04, 0x5a, 0x02, 0x2e,
+	0x2f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_jobworker_v1_worker_proto_rawDescOnce sync.Once
+	file_jobworker_v1_worker_proto_rawDescData = file_jobworker_v1_worker_proto_rawDesc
+)
+
+func file_jobworker_v1_worker_proto_rawDescGZIP() []byte {
+	file_jobworker_v1_worker_proto_rawDescOnce.Do(func() {
+		file_jobworker_v1_worker_proto_rawDescData = protoimpl.X.CompressGZIP(file_jobworker_v1_worker_proto_rawDescData)
+	})
+	return file_jobworker_v1_worker_proto_rawDescData
+}
+
+var file_jobworker_v1_worker_proto_msgTypes = make([]protoimpl.MessageInfo, 100)
+var file_jobworker_v1_worker_proto_goTypes = []any{
+	(*Jobs)(nil),                  // 0: jobworker.v1.Jobs
+	(*Job)(nil),                   // 1: jobworker.v1.Job
+	(*EmptyRequest)(nil),          // 2: jobworker.v1.EmptyRequest
+	(*RunJobReq)(nil),             // 3: jobworker.v1.RunJobReq
+	(*JobSocket)(nil),             // 4: jobworker.v1.JobSocket
+	(*JobInput)(nil),              // 5: jobworker.v1.JobInput
+	(*HealthProbe)(nil),           // 6: jobworker.v1.HealthProbe
+	(*RunJobRes)(nil),             // 7: jobworker.v1.RunJobRes
+	(*RunJobAttachedRes)(nil),     // 8: jobworker.v1.RunJobAttachedRes
+	(*JobExit)(nil),               // 9: jobworker.v1.JobExit
+	(*ValidationError)(nil),       // 10: jobworker.v1.ValidationError
+	(*ValidateJobRes)(nil),        // 11: jobworker.v1.ValidateJobRes
+	(*GetJobStatusReq)(nil),       // 12: jobworker.v1.GetJobStatusReq
+	(*GetJobStatusRes)(nil),       // 13: jobworker.v1.GetJobStatusRes
+	(*GetJobProvenanceReq)(nil),   // 14: jobworker.v1.GetJobProvenanceReq
+	(*JobProvenance)(nil),         // 15: jobworker.v1.JobProvenance
+	(*GetJobProcessesReq)(nil),    // 16: jobworker.v1.GetJobProcessesReq
+	(*JobProcesses)(nil),          // 17: jobworker.v1.JobProcesses
+	(*JobProcess)(nil),            // 18: jobworker.v1.JobProcess
+	(*StopJobReq)(nil),            // 19: jobworker.v1.StopJobReq
+	(*StopJobRes)(nil),            // 20: jobworker.v1.StopJobRes
+	(*ResumeJobReq)(nil),          // 21: jobworker.v1.ResumeJobReq
+	(*ResumeJobRes)(nil),          // 22: jobworker.v1.ResumeJobRes
+	(*RerunJobReq)(nil),           // 23: jobworker.v1.RerunJobReq
+	(*DeleteJobReq)(nil),          // 24: jobworker.v1.DeleteJobReq
+	(*DeleteJobRes)(nil),          // 25: jobworker.v1.DeleteJobRes
+	(*ExportJobReq)(nil),          // 26: jobworker.v1.ExportJobReq
+	(*GetJobArtifactReq)(nil),     // 27: jobworker.v1.GetJobArtifactReq
+	(*GetJobLogsReq)(nil),         // 28: jobworker.v1.GetJobLogsReq
+	(*DataChunk)(nil),             // 29: jobworker.v1.DataChunk
+	(*CreateSecretReq)(nil),       // 30: jobworker.v1.CreateSecretReq
+	(*CreateSecretRes)(nil),       // 31: jobworker.v1.CreateSecretRes
+	(*DeleteSecretReq)(nil),       // 32: jobworker.v1.DeleteSecretReq
+	(*DeleteSecretRes)(nil),       // 33: jobworker.v1.DeleteSecretRes
+	(*FileChunk)(nil),             // 34: jobworker.v1.FileChunk
+	(*UploadJobFilesRes)(nil),     // 35: jobworker.v1.UploadJobFilesRes
+	(*SubmitJobChunk)(nil),        // 36: jobworker.v1.SubmitJobChunk
+	(*SubmitJobProgress)(nil),     // 37: jobworker.v1.SubmitJobProgress
+	(*PipelineInput)(nil),         // 38: jobworker.v1.PipelineInput
+	(*PipelineStep)(nil),          // 39: jobworker.v1.PipelineStep
+	(*RunPipelineReq)(nil),        // 40: jobworker.v1.RunPipelineReq
+	(*GetPipelineStatusReq)(nil),  // 41: jobworker.v1.GetPipelineStatusReq
+	(*PipelineStepStatus)(nil),    // 42: jobworker.v1.PipelineStepStatus
+	(*Pipeline)(nil),              // 43: jobworker.v1.Pipeline
+	(*RunJobGroupReq)(nil),        // 44: jobworker.v1.RunJobGroupReq
+	(*GetJobGroupReq)(nil),        // 45: jobworker.v1.GetJobGroupReq
+	(*StopJobGroupReq)(nil),       // 46: jobworker.v1.StopJobGroupReq
+	(*JobGroup)(nil),              // 47: jobworker.v1.JobGroup
+	(*JobGroups)(nil),             // 48: jobworker.v1.JobGroups
+	(*StopJobGroupRes)(nil),       // 49: jobworker.v1.StopJobGroupRes
+	(*JobTemplate)(nil),           // 50: jobworker.v1.JobTemplate
+	(*TemplateParam)(nil),         // 51: jobworker.v1.TemplateParam
+	(*CreateJobTemplateReq)(nil),  // 52: jobworker.v1.CreateJobTemplateReq
+	(*JobTemplates)(nil),          // 53: jobworker.v1.JobTemplates
+	(*RunJobFromTemplateReq)(nil), // 54: jobworker.v1.RunJobFromTemplateReq
+	(*BulkJobsReq)(nil),           // 55: jobworker.v1.BulkJobsReq
+	(*JobFilter)(nil),             // 56: jobworker.v1.JobFilter
+	(*BulkJobResult)(nil),         // 57: jobworker.v1.BulkJobResult
+	(*BulkJobsRes)(nil),           // 58: jobworker.v1.BulkJobsRes
+	(*SubscribeJobEventsReq)(nil), // 59: jobworker.v1.SubscribeJobEventsReq
+	(*JobEvent)(nil),              // 60: jobworker.v1.JobEvent
+	(*StreamJobMetricsReq)(nil),   // 61: jobworker.v1.StreamJobMetricsReq
+	(*JobMetrics)(nil),            // 62: jobworker.v1.JobMetrics
+	(*AggregateMetrics)(nil),      // 63: jobworker.v1.AggregateMetrics
+	(*JobMetricsSnapshot)(nil),    // 64: jobworker.v1.JobMetricsSnapshot
+	(*StdinChunk)(nil),            // 65: jobworker.v1.StdinChunk
+	(*WriteJobStdinRes)(nil),      // 66: jobworker.v1.WriteJobStdinRes
+	(*WorkerInfo)(nil),            // 67: jobworker.v1.WorkerInfo
+	(*Diagnostics)(nil),           // 68: jobworker.v1.Diagnostics
+	(*DiagnosticCheck)(nil),       // 69: jobworker.v1.DiagnosticCheck
+	(*OutputBuffer)(nil),          // 70: jobworker.v1.OutputBuffer
+	(*GetStoreStatsReq)(nil),      // 71: jobworker.v1.GetStoreStatsReq
+	(*StoreStats)(nil),            // 72: jobworker.v1.StoreStats
+	(*MemoryStats)(nil),           // 73: jobworker.v1.MemoryStats
+	(*DiskUsage)(nil),             // 74: jobworker.v1.DiskUsage
+	(*GetUsageReportReq)(nil),     // 75: jobworker.v1.GetUsageReportReq
+	(*JobUsage)(nil),              // 76: jobworker.v1.JobUsage
+	(*TenantUsage)(nil),           // 77: jobworker.v1.TenantUsage
+	(*UsageReport)(nil),           // 78: jobworker.v1.UsageReport
+	(*RegisterWorkerReq)(nil),     // 79: jobworker.v1.RegisterWorkerReq
+	(*RegisterWorkerRes)(nil),     // 80: jobworker.v1.RegisterWorkerRes
+	(*HeartbeatReq)(nil),          // 81: jobworker.v1.HeartbeatReq
+	(*HeartbeatRes)(nil),          // 82: jobworker.v1.HeartbeatRes
+	nil,                           // 83: jobworker.v1.Job.EnvEntry
+	nil,                           // 84: jobworker.v1.Job.SecretEnvEntry
+	nil,                           // 85: jobworker.v1.Job.LabelsEntry
+	nil,                           // 86: jobworker.v1.RunJobReq.EnvEntry
+	nil,                           // 87: jobworker.v1.RunJobReq.SecretEnvEntry
+	nil,                           // 88: jobworker.v1.RunJobReq.LabelsEntry
+	nil,                           // 89: jobworker.v1.RunJobRes.EnvEntry
+	nil,                           // 90: jobworker.v1.RunJobRes.SecretEnvEntry
+	nil,                           // 91: jobworker.v1.RunJobRes.LabelsEntry
+	nil,                           // 92: jobworker.v1.GetJobStatusRes.EnvEntry
+	nil,                           // 93: jobworker.v1.GetJobStatusRes.SecretEnvEntry
+	nil,                           // 94: jobworker.v1.GetJobStatusRes.LabelsEntry
+	nil,                           // 95: jobworker.v1.JobProvenance.EnvEntry
+	nil,                           // 96: jobworker.v1.JobProvenance.CgroupEntry
+	nil,                           // 97: jobworker.v1.StopJobGroupRes.ErrorsEntry
+	nil,                           // 98: jobworker.v1.RunJobFromTemplateReq.ParamsEntry
+	nil,                           // 99: jobworker.v1.JobFilter.LabelsEntry
+}
+var file_jobworker_v1_worker_proto_depIdxs = []int32{
+	1,  // 0: jobworker.v1.Jobs.jobs:type_name -> jobworker.v1.Job
+	83, // 1: jobworker.v1.Job.env:type_name -> jobworker.v1.Job.EnvEntry
+	84, // 2: jobworker.v1.Job.secretEnv:type_name -> jobworker.v1.Job.SecretEnvEntry
+	6,  // 3: jobworker.v1.Job.healthProbe:type_name -> jobworker.v1.HealthProbe
+	85, // 4: jobworker.v1.Job.labels:type_name -> jobworker.v1.Job.LabelsEntry
+	86, // 5: jobworker.v1.RunJobReq.env:type_name -> jobworker.v1.RunJobReq.EnvEntry
+	87, // 6: jobworker.v1.RunJobReq.secretEnv:type_name -> jobworker.v1.RunJobReq.SecretEnvEntry
+	6,  // 7: jobworker.v1.RunJobReq.healthProbe:type_name -> jobworker.v1.HealthProbe
+	88, // 8: jobworker.v1.RunJobReq.labels:type_name -> jobworker.v1.RunJobReq.LabelsEntry
+	5,  // 9: jobworker.v1.RunJobReq.inputs:type_name -> jobworker.v1.JobInput
+	4,  // 10: jobworker.v1.RunJobReq.sockets:type_name -> jobworker.v1.JobSocket
+	89, // 11: jobworker.v1.RunJobRes.env:type_name -> jobworker.v1.RunJobRes.EnvEntry
+	90, // 12: jobworker.v1.RunJobRes.secretEnv:type_name -> jobworker.v1.RunJobRes.SecretEnvEntry
+	6,  // 13: jobworker.v1.RunJobRes.healthProbe:type_name -> jobworker.v1.HealthProbe
+	91, // 14: jobworker.v1.RunJobRes.labels:type_name -> jobworker.v1.RunJobRes.LabelsEntry
+	7,  // 15: jobworker.v1.RunJobAttachedRes.started:type_name -> jobworker.v1.RunJobRes
+	9,  // 16: jobworker.v1.RunJobAttachedRes.exit:type_name -> jobworker.v1.JobExit
+	10, // 17: jobworker.v1.ValidateJobRes.errors:type_name -> jobworker.v1.ValidationError
+	92, // 18: jobworker.v1.GetJobStatusRes.env:type_name -> jobworker.v1.GetJobStatusRes.EnvEntry
+	93, // 19: jobworker.v1.GetJobStatusRes.secretEnv:type_name -> jobworker.v1.GetJobStatusRes.SecretEnvEntry
+	6,  // 20: jobworker.v1.GetJobStatusRes.healthProbe:type_name -> jobworker.v1.HealthProbe
+	94, // 21: jobworker.v1.GetJobStatusRes.labels:type_name -> jobworker.v1.GetJobStatusRes.LabelsEntry
+	4,  // 22: jobworker.v1.GetJobStatusRes.sockets:type_name -> jobworker.v1.JobSocket
+	95, // 23: jobworker.v1.JobProvenance.env:type_name -> jobworker.v1.JobProvenance.EnvEntry
+	96, // 24: jobworker.v1.JobProvenance.cgroup:type_name -> jobworker.v1.JobProvenance.CgroupEntry
+	18, // 25: jobworker.v1.JobProcesses.processes:type_name -> jobworker.v1.JobProcess
+	7,  // 26: jobworker.v1.SubmitJobProgress.job:type_name -> jobworker.v1.RunJobRes
+	3,  // 27: jobworker.v1.PipelineStep.job:type_name -> jobworker.v1.RunJobReq
+	38, // 28: jobworker.v1.PipelineStep.inputs:type_name -> jobworker.v1.PipelineInput
+	39, // 29: jobworker.v1.RunPipelineReq.steps:type_name -> jobworker.v1.PipelineStep
+	42, // 30: jobworker.v1.Pipeline.steps:type_name -> jobworker.v1.PipelineStepStatus
+	3,  // 31: jobworker.v1.RunJobGroupReq.jobs:type_name -> jobworker.v1.RunJobReq
+	1,  // 32: jobworker.v1.JobGroup.jobs:type_name -> jobworker.v1.Job
+	47, // 33: jobworker.v1.JobGroups.groups:type_name -> jobworker.v1.JobGroup
+	47, // 34: jobworker.v1.StopJobGroupRes.group:type_name -> jobworker.v1.JobGroup
+	97, // 35: jobworker.v1.StopJobGroupRes.errors:type_name -> jobworker.v1.StopJobGroupRes.ErrorsEntry
+	51, // 36: jobworker.v1.JobTemplate.params:type_name -> jobworker.v1.TemplateParam
+	3,  // 37: jobworker.v1.JobTemplate.spec:type_name -> jobworker.v1.RunJobReq
+	50, // 38: jobworker.v1.CreateJobTemplateReq.template:type_name -> jobworker.v1.JobTemplate
+	50, // 39: jobworker.v1.JobTemplates.templates:type_name -> jobworker.v1.JobTemplate
+	98, // 40: jobworker.v1.RunJobFromTemplateReq.params:type_name -> jobworker.v1.RunJobFromTemplateReq.ParamsEntry
+	56, // 41: jobworker.v1.BulkJobsReq.filter:type_name -> jobworker.v1.JobFilter
+	99, // 42: jobworker.v1.JobFilter.labels:type_name -> jobworker.v1.JobFilter.LabelsEntry
+	57, // 43: jobworker.v1.BulkJobsRes.results:type_name -> jobworker.v1.BulkJobResult
+	62, // 44: jobworker.v1.JobMetricsSnapshot.jobs:type_name -> jobworker.v1.JobMetrics
+	63, // 45: jobworker.v1.JobMetricsSnapshot.groups:type_name -> jobworker.v1.AggregateMetrics
+	63, // 46: jobworker.v1.JobMetricsSnapshot.tenants:type_name -> jobworker.v1.AggregateMetrics
+	74, // 47: jobworker.v1.WorkerInfo.disk:type_name -> jobworker.v1.DiskUsage
+	70, // 48: jobworker.v1.WorkerInfo.outputBuffer:type_name -> jobworker.v1.OutputBuffer
+	72, // 49: jobworker.v1.WorkerInfo.store:type_name -> jobworker.v1.StoreStats
+	69, // 50: jobworker.v1.Diagnostics.checks:type_name -> jobworker.v1.DiagnosticCheck
+	70, // 51: jobworker.v1.StoreStats.outputBuffer:type_name -> jobworker.v1.OutputBuffer
+	73, // 52: jobworker.v1.StoreStats.memory:type_name -> jobworker.v1.MemoryStats
+	76, // 53: jobworker.v1.UsageReport.jobs:type_name -> jobworker.v1.JobUsage
+	77, // 54: jobworker.v1.UsageReport.tenants:type_name -> jobworker.v1.TenantUsage
+	67, // 55: jobworker.v1.RegisterWorkerReq.info:type_name -> jobworker.v1.WorkerInfo
+	67, // 56: jobworker.v1.HeartbeatReq.info:type_name -> jobworker.v1.WorkerInfo
+	3,  // 57: jobworker.v1.JobService.RunJob:input_type -> jobworker.v1.RunJobReq
+	3,  // 58: jobworker.v1.JobService.RunJobAttached:input_type -> jobworker.v1.RunJobReq
+	12, // 59: jobworker.v1.JobService.GetJobStatus:input_type -> jobworker.v1.GetJobStatusReq
+	14, // 60: jobworker.v1.JobService.GetJobProvenance:input_type -> jobworker.v1.GetJobProvenanceReq
+	16, // 61: jobworker.v1.JobService.GetJobProcesses:input_type -> jobworker.v1.GetJobProcessesReq
+	3,  // 62: jobworker.v1.JobService.ValidateJob:input_type -> jobworker.v1.RunJobReq
+	19, // 63: jobworker.v1.JobService.StopJob:input_type -> jobworker.v1.StopJobReq
+	21, // 64: jobworker.v1.JobService.ResumeJob:input_type -> jobworker.v1.ResumeJobReq
+	23, // 65: jobworker.v1.JobService.RerunJob:input_type -> jobworker.v1.RerunJobReq
+	24, // 66: jobworker.v1.JobService.DeleteJob:input_type -> jobworker.v1.DeleteJobReq
+	28, // 67: jobworker.v1.JobService.GetJobLogs:input_type -> jobworker.v1.GetJobLogsReq
+	26, // 68: jobworker.v1.JobService.ExportJob:input_type -> jobworker.v1.ExportJobReq
+	27, // 69: jobworker.v1.JobService.GetJobArtifact:input_type -> jobworker.v1.GetJobArtifactReq
+	2,  // 70: jobworker.v1.JobService.ListJobs:input_type -> jobworker.v1.EmptyRequest
+	30, // 71: jobworker.v1.JobService.CreateSecret:input_type -> jobworker.v1.CreateSecretReq
+	32, // 72: jobworker.v1.JobService.DeleteSecret:input_type -> jobworker.v1.DeleteSecretReq
+	34, // 73: jobworker.v1.JobService.UploadJobFiles:input_type -> jobworker.v1.FileChunk
+	36, // 74: jobworker.v1.JobService.SubmitJob:input_type -> jobworker.v1.SubmitJobChunk
+	40, // 75: jobworker.v1.JobService.RunPipeline:input_type -> jobworker.v1.RunPipelineReq
+	41, // 76: jobworker.v1.JobService.GetPipelineStatus:input_type -> jobworker.v1.GetPipelineStatusReq
+	44, // 77: jobworker.v1.JobService.RunJobGroup:input_type -> jobworker.v1.RunJobGroupReq
+	45, // 78: jobworker.v1.JobService.GetJobGroup:input_type -> jobworker.v1.GetJobGroupReq
+	2,  // 79: jobworker.v1.JobService.ListJobGroups:input_type -> jobworker.v1.EmptyRequest
+	46, // 80: jobworker.v1.JobService.StopJobGroup:input_type -> jobworker.v1.StopJobGroupReq
+	52, // 81: jobworker.v1.JobService.CreateJobTemplate:input_type -> jobworker.v1.CreateJobTemplateReq
+	2,  // 82: jobworker.v1.JobService.ListJobTemplates:input_type -> jobworker.v1.EmptyRequest
+	54, // 83: jobworker.v1.JobService.RunJobFromTemplate:input_type -> jobworker.v1.RunJobFromTemplateReq
+	55, // 84: jobworker.v1.JobService.StopJobs:input_type -> jobworker.v1.BulkJobsReq
+	55, // 85: jobworker.v1.JobService.DeleteJobs:input_type -> jobworker.v1.BulkJobsReq
+	61, // 86: jobworker.v1.JobService.StreamJobMetrics:input_type -> jobworker.v1.StreamJobMetricsReq
+	65, // 87: jobworker.v1.JobService.WriteJobStdin:input_type -> jobworker.v1.StdinChunk
+	2,  // 88: jobworker.v1.JobService.GetWorkerInfo:input_type -> jobworker.v1.EmptyRequest
+	2,  // 89: jobworker.v1.JobService.GetDiagnostics:input_type -> jobworker.v1.EmptyRequest
+	71, // 90: jobworker.v1.JobService.GetStoreStats:input_type -> jobworker.v1.GetStoreStatsReq
+	59, // 91: jobworker.v1.JobService.SubscribeJobEvents:input_type -> jobworker.v1.SubscribeJobEventsReq
+	75, // 92: jobworker.v1.JobService.GetUsageReport:input_type -> jobworker.v1.GetUsageReportReq
+	79, // 93: jobworker.v1.FleetService.RegisterWorker:input_type -> jobworker.v1.RegisterWorkerReq
+	81, // 94: jobworker.v1.FleetService.Heartbeat:input_type -> jobworker.v1.HeartbeatReq
+	7,  // 95: jobworker.v1.JobService.RunJob:output_type -> jobworker.v1.RunJobRes
+	8,  // 96: jobworker.v1.JobService.RunJobAttached:output_type -> jobworker.v1.RunJobAttachedRes
+	13, // 97: jobworker.v1.JobService.GetJobStatus:output_type -> jobworker.v1.GetJobStatusRes
+	15, // 98: jobworker.v1.JobService.GetJobProvenance:output_type -> jobworker.v1.JobProvenance
+	17, // 99: jobworker.v1.JobService.GetJobProcesses:output_type -> jobworker.v1.JobProcesses
+	11, // 100: jobworker.v1.JobService.ValidateJob:output_type -> jobworker.v1.ValidateJobRes
+	20, // 101: jobworker.v1.JobService.StopJob:output_type -> jobworker.v1.StopJobRes
+	22, // 102: jobworker.v1.JobService.ResumeJob:output_type -> jobworker.v1.ResumeJobRes
+	7,  // 103: jobworker.v1.JobService.RerunJob:output_type -> jobworker.v1.RunJobRes
+	25, // 104: jobworker.v1.JobService.DeleteJob:output_type -> jobworker.v1.DeleteJobRes
+	29, // 105: jobworker.v1.JobService.GetJobLogs:output_type -> jobworker.v1.DataChunk
+	29, // 106: jobworker.v1.JobService.ExportJob:output_type -> jobworker.v1.DataChunk
+	29, // 107: jobworker.v1.JobService.GetJobArtifact:output_type -> jobworker.v1.DataChunk
+	0,  // 108: jobworker.v1.JobService.ListJobs:output_type -> jobworker.v1.Jobs
+	31, // 109: jobworker.v1.JobService.CreateSecret:output_type -> jobworker.v1.CreateSecretRes
+	33, // 110: jobworker.v1.JobService.DeleteSecret:output_type -> jobworker.v1.DeleteSecretRes
+	35, // 111: jobworker.v1.JobService.UploadJobFiles:output_type -> jobworker.v1.UploadJobFilesRes
+	37, // 112: jobworker.v1.JobService.SubmitJob:output_type -> jobworker.v1.SubmitJobProgress
+	43, // 113: jobworker.v1.JobService.RunPipeline:output_type -> jobworker.v1.Pipeline
+	43, // 114: jobworker.v1.JobService.GetPipelineStatus:output_type -> jobworker.v1.Pipeline
+	47, // 115: jobworker.v1.JobService.RunJobGroup:output_type -> jobworker.v1.JobGroup
+	47, // 116: jobworker.v1.JobService.GetJobGroup:output_type -> jobworker.v1.JobGroup
+	48, // 117: jobworker.v1.JobService.ListJobGroups:output_type -> jobworker.v1.JobGroups
+	49, // 118: jobworker.v1.JobService.StopJobGroup:output_type -> jobworker.v1.StopJobGroupRes
+	50, // 119: jobworker.v1.JobService.CreateJobTemplate:output_type -> jobworker.v1.JobTemplate
+	53, // 120: jobworker.v1.JobService.ListJobTemplates:output_type -> jobworker.v1.JobTemplates
+	7,  // 121: jobworker.v1.JobService.RunJobFromTemplate:output_type -> jobworker.v1.RunJobRes
+	58, // 122: jobworker.v1.JobService.StopJobs:output_type -> jobworker.v1.BulkJobsRes
+	58, // 123: jobworker.v1.JobService.DeleteJobs:output_type -> jobworker.v1.BulkJobsRes
+	64, // 124: jobworker.v1.JobService.StreamJobMetrics:output_type -> jobworker.v1.JobMetricsSnapshot
+	66, // 125: jobworker.v1.JobService.WriteJobStdin:output_type -> jobworker.v1.WriteJobStdinRes
+	67, // 126: jobworker.v1.JobService.GetWorkerInfo:output_type -> jobworker.v1.WorkerInfo
+	68, // 127: jobworker.v1.JobService.GetDiagnostics:output_type -> jobworker.v1.Diagnostics
+	72, // 128: jobworker.v1.JobService.GetStoreStats:output_type -> jobworker.v1.StoreStats
+	60, // 129: jobworker.v1.JobService.SubscribeJobEvents:output_type -> jobworker.v1.JobEvent
+	78, // 130: jobworker.v1.JobService.GetUsageReport:output_type -> jobworker.v1.UsageReport
+	80, // 131: jobworker.v1.FleetService.RegisterWorker:output_type -> jobworker.v1.RegisterWorkerRes
+	82, // 132: jobworker.v1.FleetService.Heartbeat:output_type -> jobworker.v1.HeartbeatRes
+	95, // [95:133] is the sub-list for method output_type
+	57, // [57:95] is the sub-list for method input_type
+	57, // [57:57] is the sub-list for extension type_name
+	57, // [57:57] is the sub-list for extension extendee
+	0,  // [0:57] is the sub-list for field type_name
+}
+
+func init() { file_jobworker_v1_worker_proto_init() }
+func file_jobworker_v1_worker_proto_init() {
+	if File_jobworker_v1_worker_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_jobworker_v1_worker_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Jobs); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Job); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*EmptyRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*RunJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*JobSocket); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*JobInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*HealthProbe); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*RunJobRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*RunJobAttachedRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*JobExit); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[10].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidationError); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[11].Exporter = func(v any, i int) any {
+			switch v := v.(*ValidateJobRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[12].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobStatusReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[13].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobStatusRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[14].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobProvenanceReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[15].Exporter = func(v any, i int) any {
+			switch v := v.(*JobProvenance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[16].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobProcessesReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[17].Exporter = func(v any, i int) any {
+			switch v := v.(*JobProcesses); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[18].Exporter = func(v any, i int) any {
+			switch v := v.(*JobProcess); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[19].Exporter = func(v any, i int) any {
+			switch v := v.(*StopJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[20].Exporter = func(v any, i int) any {
+			switch v := v.(*StopJobRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[21].Exporter = func(v any, i int) any {
+			switch v := v.(*ResumeJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[22].Exporter = func(v any, i int) any {
+			switch v := v.(*ResumeJobRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[23].Exporter = func(v any, i int) any {
+			switch v := v.(*RerunJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[24].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[25].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteJobRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[26].Exporter = func(v any, i int) any {
+			switch v := v.(*ExportJobReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[27].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobArtifactReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[28].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobLogsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[29].Exporter = func(v any, i int) any {
+			switch v := v.(*DataChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[30].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateSecretReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[31].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateSecretRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[32].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteSecretReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[33].Exporter = func(v any, i int) any {
+			switch v := v.(*DeleteSecretRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[34].Exporter = func(v any, i int) any {
+			switch v := v.(*FileChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[35].Exporter = func(v any, i int) any {
+			switch v := v.(*UploadJobFilesRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[36].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitJobChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[37].Exporter = func(v any, i int) any {
+			switch v := v.(*SubmitJobProgress); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[38].Exporter = func(v any, i int) any {
+			switch v := v.(*PipelineInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[39].Exporter = func(v any, i int) any {
+			switch v := v.(*PipelineStep); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[40].Exporter = func(v any, i int) any {
+			switch v := v.(*RunPipelineReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[41].Exporter = func(v any, i int) any {
+			switch v := v.(*GetPipelineStatusReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[42].Exporter = func(v any, i int) any {
+			switch v := v.(*PipelineStepStatus); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[43].Exporter = func(v any, i int) any {
+			switch v := v.(*Pipeline); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[44].Exporter = func(v any, i int) any {
+			switch v := v.(*RunJobGroupReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[45].Exporter = func(v any, i int) any {
+			switch v := v.(*GetJobGroupReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[46].Exporter = func(v any, i int) any {
+			switch v := v.(*StopJobGroupReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[47].Exporter = func(v any, i int) any {
+			switch v := v.(*JobGroup); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[48].Exporter = func(v any, i int) any {
+			switch v := v.(*JobGroups); i {
+			case 0:
+				return &v.state
+			case 1:
 				return &v.sizeCache
 			case 2:
 				return &v.unknownFields
@@ -970,8 +8788,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[1].Exporter = func(v any, i int) any {
-			switch v := v.(*Job); i {
+		file_jobworker_v1_worker_proto_msgTypes[49].Exporter = func(v any, i int) any {
+			switch v := v.(*StopJobGroupRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -982,8 +8800,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*EmptyRequest); i {
+		file_jobworker_v1_worker_proto_msgTypes[50].Exporter = func(v any, i int) any {
+			switch v := v.(*JobTemplate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -994,8 +8812,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*RunJobReq); i {
+		file_jobworker_v1_worker_proto_msgTypes[51].Exporter = func(v any, i int) any {
+			switch v := v.(*TemplateParam); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1006,8 +8824,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*RunJobRes); i {
+		file_jobworker_v1_worker_proto_msgTypes[52].Exporter = func(v any, i int) any {
+			switch v := v.(*CreateJobTemplateReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1018,8 +8836,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[5].Exporter = func(v any, i int) any {
-			switch v := v.(*GetJobStatusReq); i {
+		file_jobworker_v1_worker_proto_msgTypes[53].Exporter = func(v any, i int) any {
+			switch v := v.(*JobTemplates); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1030,8 +8848,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[6].Exporter = func(v any, i int) any {
-			switch v := v.(*GetJobStatusRes); i {
+		file_jobworker_v1_worker_proto_msgTypes[54].Exporter = func(v any, i int) any {
+			switch v := v.(*RunJobFromTemplateReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1042,8 +8860,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[7].Exporter = func(v any, i int) any {
-			switch v := v.(*StopJobReq); i {
+		file_jobworker_v1_worker_proto_msgTypes[55].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkJobsReq); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1054,8 +8872,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[8].Exporter = func(v any, i int) any {
-			switch v := v.(*StopJobRes); i {
+		file_jobworker_v1_worker_proto_msgTypes[56].Exporter = func(v any, i int) any {
+			switch v := v.(*JobFilter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1066,8 +8884,8 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[9].Exporter = func(v any, i int) any {
-			switch v := v.(*GetJobLogsReq); i {
+		file_jobworker_v1_worker_proto_msgTypes[57].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkJobResult); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1078,8 +8896,296 @@ func file_worker_proto_init() {
 				return nil
 			}
 		}
-		file_worker_proto_msgTypes[10].Exporter = func(v any, i int) any {
-			switch v := v.(*DataChunk); i {
+		file_jobworker_v1_worker_proto_msgTypes[58].Exporter = func(v any, i int) any {
+			switch v := v.(*BulkJobsRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[59].Exporter = func(v any, i int) any {
+			switch v := v.(*SubscribeJobEventsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[60].Exporter = func(v any, i int) any {
+			switch v := v.(*JobEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[61].Exporter = func(v any, i int) any {
+			switch v := v.(*StreamJobMetricsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[62].Exporter = func(v any, i int) any {
+			switch v := v.(*JobMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[63].Exporter = func(v any, i int) any {
+			switch v := v.(*AggregateMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[64].Exporter = func(v any, i int) any {
+			switch v := v.(*JobMetricsSnapshot); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[65].Exporter = func(v any, i int) any {
+			switch v := v.(*StdinChunk); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[66].Exporter = func(v any, i int) any {
+			switch v := v.(*WriteJobStdinRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[67].Exporter = func(v any, i int) any {
+			switch v := v.(*WorkerInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[68].Exporter = func(v any, i int) any {
+			switch v := v.(*Diagnostics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[69].Exporter = func(v any, i int) any {
+			switch v := v.(*DiagnosticCheck); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[70].Exporter = func(v any, i int) any {
+			switch v := v.(*OutputBuffer); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[71].Exporter = func(v any, i int) any {
+			switch v := v.(*GetStoreStatsReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[72].Exporter = func(v any, i int) any {
+			switch v := v.(*StoreStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[73].Exporter = func(v any, i int) any {
+			switch v := v.(*MemoryStats); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[74].Exporter = func(v any, i int) any {
+			switch v := v.(*DiskUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[75].Exporter = func(v any, i int) any {
+			switch v := v.(*GetUsageReportReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[76].Exporter = func(v any, i int) any {
+			switch v := v.(*JobUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[77].Exporter = func(v any, i int) any {
+			switch v := v.(*TenantUsage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[78].Exporter = func(v any, i int) any {
+			switch v := v.(*UsageReport); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[79].Exporter = func(v any, i int) any {
+			switch v := v.(*RegisterWorkerReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[80].Exporter = func(v any, i int) any {
+			switch v := v.(*RegisterWorkerRes); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[81].Exporter = func(v any, i int) any {
+			switch v := v.(*HeartbeatReq); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_jobworker_v1_worker_proto_msgTypes[82].Exporter = func(v any, i int) any {
+			switch v := v.(*HeartbeatRes); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -1091,22 +9197,28 @@ func file_worker_proto_init() {
 			}
 		}
 	}
+	file_jobworker_v1_worker_proto_msgTypes[3].OneofWrappers = []any{}
+	file_jobworker_v1_worker_proto_msgTypes[8].OneofWrappers = []any{
+		(*RunJobAttachedRes_Started)(nil),
+		(*RunJobAttachedRes_Output)(nil),
+		(*RunJobAttachedRes_Exit)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
-			RawDescriptor: file_worker_proto_rawDesc,
+			RawDescriptor: file_jobworker_v1_worker_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   100,
 			NumExtensions: 0,
-			NumServices:   1,
+			NumServices:   2,
 		},
-		GoTypes:           file_worker_proto_goTypes,
-		DependencyIndexes: file_worker_proto_depIdxs,
-		MessageInfos:      file_worker_proto_msgTypes,
+		GoTypes:           file_jobworker_v1_worker_proto_goTypes,
+		DependencyIndexes: file_jobworker_v1_worker_proto_depIdxs,
+		MessageInfos:      file_jobworker_v1_worker_proto_msgTypes,
 	}.Build()
-	File_worker_proto = out.File
-	file_worker_proto_rawDesc = nil
-	file_worker_proto_goTypes = nil
-	file_worker_proto_depIdxs = nil
+	File_jobworker_v1_worker_proto = out.File
+	file_jobworker_v1_worker_proto_rawDesc = nil
+	file_jobworker_v1_worker_proto_goTypes = nil
+	file_jobworker_v1_worker_proto_depIdxs = nil
 }
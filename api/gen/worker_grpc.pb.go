@@ -2,7 +2,7 @@
 // versions:
 // - protoc-gen-go-grpc v1.3.0
 // - protoc             v5.29.1
-// source: worker.proto
+// source: jobworker/v1/worker.proto
 
 package __
 
@@ -19,11 +19,42 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	JobService_RunJob_FullMethodName       = "/worker.JobService/RunJob"
-	JobService_GetJobStatus_FullMethodName = "/worker.JobService/GetJobStatus"
-	JobService_StopJob_FullMethodName      = "/worker.JobService/StopJob"
-	JobService_GetJobLogs_FullMethodName   = "/worker.JobService/GetJobLogs"
-	JobService_ListJobs_FullMethodName     = "/worker.JobService/ListJobs"
+	JobService_RunJob_FullMethodName             = "/jobworker.v1.JobService/RunJob"
+	JobService_RunJobAttached_FullMethodName     = "/jobworker.v1.JobService/RunJobAttached"
+	JobService_GetJobStatus_FullMethodName       = "/jobworker.v1.JobService/GetJobStatus"
+	JobService_GetJobProvenance_FullMethodName   = "/jobworker.v1.JobService/GetJobProvenance"
+	JobService_GetJobProcesses_FullMethodName    = "/jobworker.v1.JobService/GetJobProcesses"
+	JobService_ValidateJob_FullMethodName        = "/jobworker.v1.JobService/ValidateJob"
+	JobService_StopJob_FullMethodName            = "/jobworker.v1.JobService/StopJob"
+	JobService_ResumeJob_FullMethodName          = "/jobworker.v1.JobService/ResumeJob"
+	JobService_RerunJob_FullMethodName           = "/jobworker.v1.JobService/RerunJob"
+	JobService_DeleteJob_FullMethodName          = "/jobworker.v1.JobService/DeleteJob"
+	JobService_GetJobLogs_FullMethodName         = "/jobworker.v1.JobService/GetJobLogs"
+	JobService_ExportJob_FullMethodName          = "/jobworker.v1.JobService/ExportJob"
+	JobService_GetJobArtifact_FullMethodName     = "/jobworker.v1.JobService/GetJobArtifact"
+	JobService_ListJobs_FullMethodName           = "/jobworker.v1.JobService/ListJobs"
+	JobService_CreateSecret_FullMethodName       = "/jobworker.v1.JobService/CreateSecret"
+	JobService_DeleteSecret_FullMethodName       = "/jobworker.v1.JobService/DeleteSecret"
+	JobService_UploadJobFiles_FullMethodName     = "/jobworker.v1.JobService/UploadJobFiles"
+	JobService_SubmitJob_FullMethodName          = "/jobworker.v1.JobService/SubmitJob"
+	JobService_RunPipeline_FullMethodName        = "/jobworker.v1.JobService/RunPipeline"
+	JobService_GetPipelineStatus_FullMethodName  = "/jobworker.v1.JobService/GetPipelineStatus"
+	JobService_RunJobGroup_FullMethodName        = "/jobworker.v1.JobService/RunJobGroup"
+	JobService_GetJobGroup_FullMethodName        = "/jobworker.v1.JobService/GetJobGroup"
+	JobService_ListJobGroups_FullMethodName      = "/jobworker.v1.JobService/ListJobGroups"
+	JobService_StopJobGroup_FullMethodName       = "/jobworker.v1.JobService/StopJobGroup"
+	JobService_CreateJobTemplate_FullMethodName  = "/jobworker.v1.JobService/CreateJobTemplate"
+	JobService_ListJobTemplates_FullMethodName   = "/jobworker.v1.JobService/ListJobTemplates"
+	JobService_RunJobFromTemplate_FullMethodName = "/jobworker.v1.JobService/RunJobFromTemplate"
+	JobService_StopJobs_FullMethodName           = "/jobworker.v1.JobService/StopJobs"
+	JobService_DeleteJobs_FullMethodName         = "/jobworker.v1.JobService/DeleteJobs"
+	JobService_StreamJobMetrics_FullMethodName   = "/jobworker.v1.JobService/StreamJobMetrics"
+	JobService_WriteJobStdin_FullMethodName      = "/jobworker.v1.JobService/WriteJobStdin"
+	JobService_GetWorkerInfo_FullMethodName      = "/jobworker.v1.JobService/GetWorkerInfo"
+	JobService_GetDiagnostics_FullMethodName     = "/jobworker.v1.JobService/GetDiagnostics"
+	JobService_GetStoreStats_FullMethodName      = "/jobworker.v1.JobService/GetStoreStats"
+	JobService_SubscribeJobEvents_FullMethodName = "/jobworker.v1.JobService/SubscribeJobEvents"
+	JobService_GetUsageReport_FullMethodName     = "/jobworker.v1.JobService/GetUsageReport"
 )
 
 // JobServiceClient is the client API for JobService service.
@@ -31,10 +62,41 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type JobServiceClient interface {
 	RunJob(ctx context.Context, in *RunJobReq, opts ...grpc.CallOption) (*RunJobRes, error)
+	RunJobAttached(ctx context.Context, in *RunJobReq, opts ...grpc.CallOption) (JobService_RunJobAttachedClient, error)
 	GetJobStatus(ctx context.Context, in *GetJobStatusReq, opts ...grpc.CallOption) (*GetJobStatusRes, error)
+	GetJobProvenance(ctx context.Context, in *GetJobProvenanceReq, opts ...grpc.CallOption) (*JobProvenance, error)
+	GetJobProcesses(ctx context.Context, in *GetJobProcessesReq, opts ...grpc.CallOption) (*JobProcesses, error)
+	ValidateJob(ctx context.Context, in *RunJobReq, opts ...grpc.CallOption) (*ValidateJobRes, error)
 	StopJob(ctx context.Context, in *StopJobReq, opts ...grpc.CallOption) (*StopJobRes, error)
+	ResumeJob(ctx context.Context, in *ResumeJobReq, opts ...grpc.CallOption) (*ResumeJobRes, error)
+	RerunJob(ctx context.Context, in *RerunJobReq, opts ...grpc.CallOption) (*RunJobRes, error)
+	DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error)
 	GetJobLogs(ctx context.Context, in *GetJobLogsReq, opts ...grpc.CallOption) (JobService_GetJobLogsClient, error)
+	ExportJob(ctx context.Context, in *ExportJobReq, opts ...grpc.CallOption) (JobService_ExportJobClient, error)
+	GetJobArtifact(ctx context.Context, in *GetJobArtifactReq, opts ...grpc.CallOption) (JobService_GetJobArtifactClient, error)
 	ListJobs(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Jobs, error)
+	CreateSecret(ctx context.Context, in *CreateSecretReq, opts ...grpc.CallOption) (*CreateSecretRes, error)
+	DeleteSecret(ctx context.Context, in *DeleteSecretReq, opts ...grpc.CallOption) (*DeleteSecretRes, error)
+	UploadJobFiles(ctx context.Context, opts ...grpc.CallOption) (JobService_UploadJobFilesClient, error)
+	SubmitJob(ctx context.Context, opts ...grpc.CallOption) (JobService_SubmitJobClient, error)
+	RunPipeline(ctx context.Context, in *RunPipelineReq, opts ...grpc.CallOption) (*Pipeline, error)
+	GetPipelineStatus(ctx context.Context, in *GetPipelineStatusReq, opts ...grpc.CallOption) (*Pipeline, error)
+	RunJobGroup(ctx context.Context, in *RunJobGroupReq, opts ...grpc.CallOption) (*JobGroup, error)
+	GetJobGroup(ctx context.Context, in *GetJobGroupReq, opts ...grpc.CallOption) (*JobGroup, error)
+	ListJobGroups(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*JobGroups, error)
+	StopJobGroup(ctx context.Context, in *StopJobGroupReq, opts ...grpc.CallOption) (*StopJobGroupRes, error)
+	CreateJobTemplate(ctx context.Context, in *CreateJobTemplateReq, opts ...grpc.CallOption) (*JobTemplate, error)
+	ListJobTemplates(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*JobTemplates, error)
+	RunJobFromTemplate(ctx context.Context, in *RunJobFromTemplateReq, opts ...grpc.CallOption) (*RunJobRes, error)
+	StopJobs(ctx context.Context, in *BulkJobsReq, opts ...grpc.CallOption) (*BulkJobsRes, error)
+	DeleteJobs(ctx context.Context, in *BulkJobsReq, opts ...grpc.CallOption) (*BulkJobsRes, error)
+	StreamJobMetrics(ctx context.Context, in *StreamJobMetricsReq, opts ...grpc.CallOption) (JobService_StreamJobMetricsClient, error)
+	WriteJobStdin(ctx context.Context, opts ...grpc.CallOption) (JobService_WriteJobStdinClient, error)
+	GetWorkerInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*WorkerInfo, error)
+	GetDiagnostics(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Diagnostics, error)
+	GetStoreStats(ctx context.Context, in *GetStoreStatsReq, opts ...grpc.CallOption) (*StoreStats, error)
+	SubscribeJobEvents(ctx context.Context, in *SubscribeJobEventsReq, opts ...grpc.CallOption) (JobService_SubscribeJobEventsClient, error)
+	GetUsageReport(ctx context.Context, in *GetUsageReportReq, opts ...grpc.CallOption) (*UsageReport, error)
 }
 
 type jobServiceClient struct {
@@ -54,6 +116,38 @@ func (c *jobServiceClient) RunJob(ctx context.Context, in *RunJobReq, opts ...gr
 	return out, nil
 }
 
+func (c *jobServiceClient) RunJobAttached(ctx context.Context, in *RunJobReq, opts ...grpc.CallOption) (JobService_RunJobAttachedClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[0], JobService_RunJobAttached_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceRunJobAttachedClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_RunJobAttachedClient interface {
+	Recv() (*RunJobAttachedRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceRunJobAttachedClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceRunJobAttachedClient) Recv() (*RunJobAttachedRes, error) {
+	m := new(RunJobAttachedRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *jobServiceClient) GetJobStatus(ctx context.Context, in *GetJobStatusReq, opts ...grpc.CallOption) (*GetJobStatusRes, error) {
 	out := new(GetJobStatusRes)
 	err := c.cc.Invoke(ctx, JobService_GetJobStatus_FullMethodName, in, out, opts...)
@@ -63,189 +157,1344 @@ func (c *jobServiceClient) GetJobStatus(ctx context.Context, in *GetJobStatusReq
 	return out, nil
 }
 
+func (c *jobServiceClient) GetJobProvenance(ctx context.Context, in *GetJobProvenanceReq, opts ...grpc.CallOption) (*JobProvenance, error) {
+	out := new(JobProvenance)
+	err := c.cc.Invoke(ctx, JobService_GetJobProvenance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetJobProcesses(ctx context.Context, in *GetJobProcessesReq, opts ...grpc.CallOption) (*JobProcesses, error) {
+	out := new(JobProcesses)
+	err := c.cc.Invoke(ctx, JobService_GetJobProcesses_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ValidateJob(ctx context.Context, in *RunJobReq, opts ...grpc.CallOption) (*ValidateJobRes, error) {
+	out := new(ValidateJobRes)
+	err := c.cc.Invoke(ctx, JobService_ValidateJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *jobServiceClient) StopJob(ctx context.Context, in *StopJobReq, opts ...grpc.CallOption) (*StopJobRes, error) {
 	out := new(StopJobRes)
 	err := c.cc.Invoke(ctx, JobService_StopJob_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *jobServiceClient) ResumeJob(ctx context.Context, in *ResumeJobReq, opts ...grpc.CallOption) (*ResumeJobRes, error) {
+	out := new(ResumeJobRes)
+	err := c.cc.Invoke(ctx, JobService_ResumeJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RerunJob(ctx context.Context, in *RerunJobReq, opts ...grpc.CallOption) (*RunJobRes, error) {
+	out := new(RunJobRes)
+	err := c.cc.Invoke(ctx, JobService_RerunJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteJob(ctx context.Context, in *DeleteJobReq, opts ...grpc.CallOption) (*DeleteJobRes, error) {
+	out := new(DeleteJobRes)
+	err := c.cc.Invoke(ctx, JobService_DeleteJob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetJobLogs(ctx context.Context, in *GetJobLogsReq, opts ...grpc.CallOption) (JobService_GetJobLogsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[1], JobService_GetJobLogs_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceGetJobLogsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_GetJobLogsClient interface {
+	Recv() (*DataChunk, error)
+	grpc.ClientStream
+}
+
+type jobServiceGetJobLogsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceGetJobLogsClient) Recv() (*DataChunk, error) {
+	m := new(DataChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) ExportJob(ctx context.Context, in *ExportJobReq, opts ...grpc.CallOption) (JobService_ExportJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[2], JobService_ExportJob_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceExportJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_ExportJobClient interface {
+	Recv() (*DataChunk, error)
+	grpc.ClientStream
+}
+
+type jobServiceExportJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceExportJobClient) Recv() (*DataChunk, error) {
+	m := new(DataChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) GetJobArtifact(ctx context.Context, in *GetJobArtifactReq, opts ...grpc.CallOption) (JobService_GetJobArtifactClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[3], JobService_GetJobArtifact_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceGetJobArtifactClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_GetJobArtifactClient interface {
+	Recv() (*DataChunk, error)
+	grpc.ClientStream
+}
+
+type jobServiceGetJobArtifactClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceGetJobArtifactClient) Recv() (*DataChunk, error) {
+	m := new(DataChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) ListJobs(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Jobs, error) {
+	out := new(Jobs)
+	err := c.cc.Invoke(ctx, JobService_ListJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CreateSecret(ctx context.Context, in *CreateSecretReq, opts ...grpc.CallOption) (*CreateSecretRes, error) {
+	out := new(CreateSecretRes)
+	err := c.cc.Invoke(ctx, JobService_CreateSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteSecret(ctx context.Context, in *DeleteSecretReq, opts ...grpc.CallOption) (*DeleteSecretRes, error) {
+	out := new(DeleteSecretRes)
+	err := c.cc.Invoke(ctx, JobService_DeleteSecret_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) UploadJobFiles(ctx context.Context, opts ...grpc.CallOption) (JobService_UploadJobFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[4], JobService_UploadJobFiles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceUploadJobFilesClient{stream}
+	return x, nil
+}
+
+type JobService_UploadJobFilesClient interface {
+	Send(*FileChunk) error
+	CloseAndRecv() (*UploadJobFilesRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceUploadJobFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceUploadJobFilesClient) Send(m *FileChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *jobServiceUploadJobFilesClient) CloseAndRecv() (*UploadJobFilesRes, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadJobFilesRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) SubmitJob(ctx context.Context, opts ...grpc.CallOption) (JobService_SubmitJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[5], JobService_SubmitJob_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceSubmitJobClient{stream}
+	return x, nil
+}
+
+type JobService_SubmitJobClient interface {
+	Send(*SubmitJobChunk) error
+	Recv() (*SubmitJobProgress, error)
+	grpc.ClientStream
+}
+
+type jobServiceSubmitJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceSubmitJobClient) Send(m *SubmitJobChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *jobServiceSubmitJobClient) Recv() (*SubmitJobProgress, error) {
+	m := new(SubmitJobProgress)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) RunPipeline(ctx context.Context, in *RunPipelineReq, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, JobService_RunPipeline_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetPipelineStatus(ctx context.Context, in *GetPipelineStatusReq, opts ...grpc.CallOption) (*Pipeline, error) {
+	out := new(Pipeline)
+	err := c.cc.Invoke(ctx, JobService_GetPipelineStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RunJobGroup(ctx context.Context, in *RunJobGroupReq, opts ...grpc.CallOption) (*JobGroup, error) {
+	out := new(JobGroup)
+	err := c.cc.Invoke(ctx, JobService_RunJobGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetJobGroup(ctx context.Context, in *GetJobGroupReq, opts ...grpc.CallOption) (*JobGroup, error) {
+	out := new(JobGroup)
+	err := c.cc.Invoke(ctx, JobService_GetJobGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobGroups(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*JobGroups, error) {
+	out := new(JobGroups)
+	err := c.cc.Invoke(ctx, JobService_ListJobGroups_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) StopJobGroup(ctx context.Context, in *StopJobGroupReq, opts ...grpc.CallOption) (*StopJobGroupRes, error) {
+	out := new(StopJobGroupRes)
+	err := c.cc.Invoke(ctx, JobService_StopJobGroup_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) CreateJobTemplate(ctx context.Context, in *CreateJobTemplateReq, opts ...grpc.CallOption) (*JobTemplate, error) {
+	out := new(JobTemplate)
+	err := c.cc.Invoke(ctx, JobService_CreateJobTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) ListJobTemplates(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*JobTemplates, error) {
+	out := new(JobTemplates)
+	err := c.cc.Invoke(ctx, JobService_ListJobTemplates_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) RunJobFromTemplate(ctx context.Context, in *RunJobFromTemplateReq, opts ...grpc.CallOption) (*RunJobRes, error) {
+	out := new(RunJobRes)
+	err := c.cc.Invoke(ctx, JobService_RunJobFromTemplate_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) StopJobs(ctx context.Context, in *BulkJobsReq, opts ...grpc.CallOption) (*BulkJobsRes, error) {
+	out := new(BulkJobsRes)
+	err := c.cc.Invoke(ctx, JobService_StopJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) DeleteJobs(ctx context.Context, in *BulkJobsReq, opts ...grpc.CallOption) (*BulkJobsRes, error) {
+	out := new(BulkJobsRes)
+	err := c.cc.Invoke(ctx, JobService_DeleteJobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) StreamJobMetrics(ctx context.Context, in *StreamJobMetricsReq, opts ...grpc.CallOption) (JobService_StreamJobMetricsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[6], JobService_StreamJobMetrics_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceStreamJobMetricsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_StreamJobMetricsClient interface {
+	Recv() (*JobMetricsSnapshot, error)
+	grpc.ClientStream
+}
+
+type jobServiceStreamJobMetricsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceStreamJobMetricsClient) Recv() (*JobMetricsSnapshot, error) {
+	m := new(JobMetricsSnapshot)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) WriteJobStdin(ctx context.Context, opts ...grpc.CallOption) (JobService_WriteJobStdinClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[7], JobService_WriteJobStdin_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceWriteJobStdinClient{stream}
+	return x, nil
+}
+
+type JobService_WriteJobStdinClient interface {
+	Send(*StdinChunk) error
+	CloseAndRecv() (*WriteJobStdinRes, error)
+	grpc.ClientStream
+}
+
+type jobServiceWriteJobStdinClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceWriteJobStdinClient) Send(m *StdinChunk) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *jobServiceWriteJobStdinClient) CloseAndRecv() (*WriteJobStdinRes, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteJobStdinRes)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) GetWorkerInfo(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*WorkerInfo, error) {
+	out := new(WorkerInfo)
+	err := c.cc.Invoke(ctx, JobService_GetWorkerInfo_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetDiagnostics(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Diagnostics, error) {
+	out := new(Diagnostics)
+	err := c.cc.Invoke(ctx, JobService_GetDiagnostics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) GetStoreStats(ctx context.Context, in *GetStoreStatsReq, opts ...grpc.CallOption) (*StoreStats, error) {
+	out := new(StoreStats)
+	err := c.cc.Invoke(ctx, JobService_GetStoreStats_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *jobServiceClient) SubscribeJobEvents(ctx context.Context, in *SubscribeJobEventsReq, opts ...grpc.CallOption) (JobService_SubscribeJobEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[8], JobService_SubscribeJobEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &jobServiceSubscribeJobEventsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type JobService_SubscribeJobEventsClient interface {
+	Recv() (*JobEvent, error)
+	grpc.ClientStream
+}
+
+type jobServiceSubscribeJobEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *jobServiceSubscribeJobEventsClient) Recv() (*JobEvent, error) {
+	m := new(JobEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *jobServiceClient) GetUsageReport(ctx context.Context, in *GetUsageReportReq, opts ...grpc.CallOption) (*UsageReport, error) {
+	out := new(UsageReport)
+	err := c.cc.Invoke(ctx, JobService_GetUsageReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// JobServiceServer is the server API for JobService service.
+// All implementations must embed UnimplementedJobServiceServer
+// for forward compatibility
+type JobServiceServer interface {
+	RunJob(context.Context, *RunJobReq) (*RunJobRes, error)
+	RunJobAttached(*RunJobReq, JobService_RunJobAttachedServer) error
+	GetJobStatus(context.Context, *GetJobStatusReq) (*GetJobStatusRes, error)
+	GetJobProvenance(context.Context, *GetJobProvenanceReq) (*JobProvenance, error)
+	GetJobProcesses(context.Context, *GetJobProcessesReq) (*JobProcesses, error)
+	ValidateJob(context.Context, *RunJobReq) (*ValidateJobRes, error)
+	StopJob(context.Context, *StopJobReq) (*StopJobRes, error)
+	ResumeJob(context.Context, *ResumeJobReq) (*ResumeJobRes, error)
+	RerunJob(context.Context, *RerunJobReq) (*RunJobRes, error)
+	DeleteJob(context.Context, *DeleteJobReq) (*DeleteJobRes, error)
+	GetJobLogs(*GetJobLogsReq, JobService_GetJobLogsServer) error
+	ExportJob(*ExportJobReq, JobService_ExportJobServer) error
+	GetJobArtifact(*GetJobArtifactReq, JobService_GetJobArtifactServer) error
+	ListJobs(context.Context, *EmptyRequest) (*Jobs, error)
+	CreateSecret(context.Context, *CreateSecretReq) (*CreateSecretRes, error)
+	DeleteSecret(context.Context, *DeleteSecretReq) (*DeleteSecretRes, error)
+	UploadJobFiles(JobService_UploadJobFilesServer) error
+	SubmitJob(JobService_SubmitJobServer) error
+	RunPipeline(context.Context, *RunPipelineReq) (*Pipeline, error)
+	GetPipelineStatus(context.Context, *GetPipelineStatusReq) (*Pipeline, error)
+	RunJobGroup(context.Context, *RunJobGroupReq) (*JobGroup, error)
+	GetJobGroup(context.Context, *GetJobGroupReq) (*JobGroup, error)
+	ListJobGroups(context.Context, *EmptyRequest) (*JobGroups, error)
+	StopJobGroup(context.Context, *StopJobGroupReq) (*StopJobGroupRes, error)
+	CreateJobTemplate(context.Context, *CreateJobTemplateReq) (*JobTemplate, error)
+	ListJobTemplates(context.Context, *EmptyRequest) (*JobTemplates, error)
+	RunJobFromTemplate(context.Context, *RunJobFromTemplateReq) (*RunJobRes, error)
+	StopJobs(context.Context, *BulkJobsReq) (*BulkJobsRes, error)
+	DeleteJobs(context.Context, *BulkJobsReq) (*BulkJobsRes, error)
+	StreamJobMetrics(*StreamJobMetricsReq, JobService_StreamJobMetricsServer) error
+	WriteJobStdin(JobService_WriteJobStdinServer) error
+	GetWorkerInfo(context.Context, *EmptyRequest) (*WorkerInfo, error)
+	GetDiagnostics(context.Context, *EmptyRequest) (*Diagnostics, error)
+	GetStoreStats(context.Context, *GetStoreStatsReq) (*StoreStats, error)
+	SubscribeJobEvents(*SubscribeJobEventsReq, JobService_SubscribeJobEventsServer) error
+	GetUsageReport(context.Context, *GetUsageReportReq) (*UsageReport, error)
+	mustEmbedUnimplementedJobServiceServer()
+}
+
+// UnimplementedJobServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedJobServiceServer struct {
+}
+
+func (UnimplementedJobServiceServer) RunJob(context.Context, *RunJobReq) (*RunJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
+}
+func (UnimplementedJobServiceServer) RunJobAttached(*RunJobReq, JobService_RunJobAttachedServer) error {
+	return status.Errorf(codes.Unimplemented, "method RunJobAttached not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobStatus(context.Context, *GetJobStatusReq) (*GetJobStatusRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobProvenance(context.Context, *GetJobProvenanceReq) (*JobProvenance, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobProvenance not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobProcesses(context.Context, *GetJobProcessesReq) (*JobProcesses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobProcesses not implemented")
+}
+func (UnimplementedJobServiceServer) ValidateJob(context.Context, *RunJobReq) (*ValidateJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ValidateJob not implemented")
+}
+func (UnimplementedJobServiceServer) StopJob(context.Context, *StopJobReq) (*StopJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopJob not implemented")
+}
+func (UnimplementedJobServiceServer) ResumeJob(context.Context, *ResumeJobReq) (*ResumeJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeJob not implemented")
+}
+func (UnimplementedJobServiceServer) RerunJob(context.Context, *RerunJobReq) (*RunJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RerunJob not implemented")
+}
+func (UnimplementedJobServiceServer) DeleteJob(context.Context, *DeleteJobReq) (*DeleteJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJob not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobLogs(*GetJobLogsReq, JobService_GetJobLogsServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetJobLogs not implemented")
+}
+func (UnimplementedJobServiceServer) ExportJob(*ExportJobReq, JobService_ExportJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportJob not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobArtifact(*GetJobArtifactReq, JobService_GetJobArtifactServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetJobArtifact not implemented")
+}
+func (UnimplementedJobServiceServer) ListJobs(context.Context, *EmptyRequest) (*Jobs, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (UnimplementedJobServiceServer) CreateSecret(context.Context, *CreateSecretReq) (*CreateSecretRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSecret not implemented")
+}
+func (UnimplementedJobServiceServer) DeleteSecret(context.Context, *DeleteSecretReq) (*DeleteSecretRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteSecret not implemented")
+}
+func (UnimplementedJobServiceServer) UploadJobFiles(JobService_UploadJobFilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method UploadJobFiles not implemented")
+}
+func (UnimplementedJobServiceServer) SubmitJob(JobService_SubmitJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubmitJob not implemented")
+}
+func (UnimplementedJobServiceServer) RunPipeline(context.Context, *RunPipelineReq) (*Pipeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunPipeline not implemented")
+}
+func (UnimplementedJobServiceServer) GetPipelineStatus(context.Context, *GetPipelineStatusReq) (*Pipeline, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPipelineStatus not implemented")
+}
+func (UnimplementedJobServiceServer) RunJobGroup(context.Context, *RunJobGroupReq) (*JobGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJobGroup not implemented")
+}
+func (UnimplementedJobServiceServer) GetJobGroup(context.Context, *GetJobGroupReq) (*JobGroup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJobGroup not implemented")
+}
+func (UnimplementedJobServiceServer) ListJobGroups(context.Context, *EmptyRequest) (*JobGroups, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobGroups not implemented")
+}
+func (UnimplementedJobServiceServer) StopJobGroup(context.Context, *StopJobGroupReq) (*StopJobGroupRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopJobGroup not implemented")
+}
+func (UnimplementedJobServiceServer) CreateJobTemplate(context.Context, *CreateJobTemplateReq) (*JobTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateJobTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) ListJobTemplates(context.Context, *EmptyRequest) (*JobTemplates, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobTemplates not implemented")
+}
+func (UnimplementedJobServiceServer) RunJobFromTemplate(context.Context, *RunJobFromTemplateReq) (*RunJobRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunJobFromTemplate not implemented")
+}
+func (UnimplementedJobServiceServer) StopJobs(context.Context, *BulkJobsReq) (*BulkJobsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopJobs not implemented")
+}
+func (UnimplementedJobServiceServer) DeleteJobs(context.Context, *BulkJobsReq) (*BulkJobsRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteJobs not implemented")
+}
+func (UnimplementedJobServiceServer) StreamJobMetrics(*StreamJobMetricsReq, JobService_StreamJobMetricsServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamJobMetrics not implemented")
+}
+func (UnimplementedJobServiceServer) WriteJobStdin(JobService_WriteJobStdinServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteJobStdin not implemented")
+}
+func (UnimplementedJobServiceServer) GetWorkerInfo(context.Context, *EmptyRequest) (*WorkerInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkerInfo not implemented")
+}
+func (UnimplementedJobServiceServer) GetDiagnostics(context.Context, *EmptyRequest) (*Diagnostics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDiagnostics not implemented")
+}
+func (UnimplementedJobServiceServer) GetStoreStats(context.Context, *GetStoreStatsReq) (*StoreStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStoreStats not implemented")
+}
+func (UnimplementedJobServiceServer) SubscribeJobEvents(*SubscribeJobEventsReq, JobService_SubscribeJobEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeJobEvents not implemented")
+}
+func (UnimplementedJobServiceServer) GetUsageReport(context.Context, *GetUsageReportReq) (*UsageReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUsageReport not implemented")
+}
+func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
+
+// UnsafeJobServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to JobServiceServer will
+// result in compilation errors.
+type UnsafeJobServiceServer interface {
+	mustEmbedUnimplementedJobServiceServer()
+}
+
+func RegisterJobServiceServer(s grpc.ServiceRegistrar, srv JobServiceServer) {
+	s.RegisterService(&JobService_ServiceDesc, srv)
+}
+
+func _JobService_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RunJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RunJob(ctx, req.(*RunJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RunJobAttached_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RunJobReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).RunJobAttached(m, &jobServiceRunJobAttachedServer{stream})
+}
+
+type JobService_RunJobAttachedServer interface {
+	Send(*RunJobAttachedRes) error
+	grpc.ServerStream
+}
+
+type jobServiceRunJobAttachedServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceRunJobAttachedServer) Send(m *RunJobAttachedRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetJobStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobStatus(ctx, req.(*GetJobStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJobProvenance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobProvenanceReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobProvenance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetJobProvenance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobProvenance(ctx, req.(*GetJobProvenanceReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJobProcesses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobProcessesReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobProcesses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetJobProcesses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobProcesses(ctx, req.(*GetJobProcessesReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ValidateJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ValidateJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ValidateJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ValidateJob(ctx, req.(*RunJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_StopJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).StopJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_StopJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).StopJob(ctx, req.(*StopJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ResumeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ResumeJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ResumeJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ResumeJob(ctx, req.(*ResumeJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RerunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RerunJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RerunJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RerunJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RerunJob(ctx, req.(*RerunJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DeleteJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteJobReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_DeleteJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteJob(ctx, req.(*DeleteJobReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobLogsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).GetJobLogs(m, &jobServiceGetJobLogsServer{stream})
+}
+
+type JobService_GetJobLogsServer interface {
+	Send(*DataChunk) error
+	grpc.ServerStream
+}
+
+type jobServiceGetJobLogsServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceGetJobLogsServer) Send(m *DataChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_ExportJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportJobReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).ExportJob(m, &jobServiceExportJobServer{stream})
+}
+
+type JobService_ExportJobServer interface {
+	Send(*DataChunk) error
+	grpc.ServerStream
+}
+
+type jobServiceExportJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceExportJobServer) Send(m *DataChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_GetJobArtifact_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobArtifactReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).GetJobArtifact(m, &jobServiceGetJobArtifactServer{stream})
+}
+
+type JobService_GetJobArtifactServer interface {
+	Send(*DataChunk) error
+	grpc.ServerStream
+}
+
+type jobServiceGetJobArtifactServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceGetJobArtifactServer) Send(m *DataChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _JobService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobs(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_CreateSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CreateSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_CreateSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CreateSecret(ctx, req.(*CreateSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_DeleteSecret_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteSecretReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteSecret(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_DeleteSecret_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteSecret(ctx, req.(*DeleteSecretReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_UploadJobFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).UploadJobFiles(&jobServiceUploadJobFilesServer{stream})
+}
+
+type JobService_UploadJobFilesServer interface {
+	SendAndClose(*UploadJobFilesRes) error
+	Recv() (*FileChunk, error)
+	grpc.ServerStream
+}
+
+type jobServiceUploadJobFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceUploadJobFilesServer) SendAndClose(m *UploadJobFilesRes) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *jobServiceUploadJobFilesServer) Recv() (*FileChunk, error) {
+	m := new(FileChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _JobService_SubmitJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).SubmitJob(&jobServiceSubmitJobServer{stream})
+}
+
+type JobService_SubmitJobServer interface {
+	Send(*SubmitJobProgress) error
+	Recv() (*SubmitJobChunk, error)
+	grpc.ServerStream
+}
+
+type jobServiceSubmitJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *jobServiceSubmitJobServer) Send(m *SubmitJobProgress) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *jobServiceSubmitJobServer) Recv() (*SubmitJobChunk, error) {
+	m := new(SubmitJobChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _JobService_RunPipeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunPipelineReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RunPipeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RunPipeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RunPipeline(ctx, req.(*RunPipelineReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetPipelineStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPipelineStatusReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetPipelineStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetPipelineStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetPipelineStatus(ctx, req.(*GetPipelineStatusReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RunJobGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobGroupReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RunJobGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RunJobGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RunJobGroup(ctx, req.(*RunJobGroupReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_GetJobGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobGroupReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).GetJobGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_GetJobGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).GetJobGroup(ctx, req.(*GetJobGroupReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobGroups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobGroups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListJobGroups_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobGroups(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_StopJobGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StopJobGroupReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).StopJobGroup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_StopJobGroup_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).StopJobGroup(ctx, req.(*StopJobGroupReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_CreateJobTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateJobTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).CreateJobTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_CreateJobTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).CreateJobTemplate(ctx, req.(*CreateJobTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_ListJobTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).ListJobTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_ListJobTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).ListJobTemplates(ctx, req.(*EmptyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_RunJobFromTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunJobFromTemplateReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).RunJobFromTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_RunJobFromTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).RunJobFromTemplate(ctx, req.(*RunJobFromTemplateReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _JobService_StopJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkJobsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(JobServiceServer).StopJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_StopJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).StopJobs(ctx, req.(*BulkJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *jobServiceClient) GetJobLogs(ctx context.Context, in *GetJobLogsReq, opts ...grpc.CallOption) (JobService_GetJobLogsClient, error) {
-	stream, err := c.cc.NewStream(ctx, &JobService_ServiceDesc.Streams[0], JobService_GetJobLogs_FullMethodName, opts...)
-	if err != nil {
+func _JobService_DeleteJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BulkJobsReq)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	x := &jobServiceGetJobLogsClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
+	if interceptor == nil {
+		return srv.(JobServiceServer).DeleteJobs(ctx, in)
 	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: JobService_DeleteJobs_FullMethodName,
 	}
-	return x, nil
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(JobServiceServer).DeleteJobs(ctx, req.(*BulkJobsReq))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-type JobService_GetJobLogsClient interface {
-	Recv() (*DataChunk, error)
-	grpc.ClientStream
+func _JobService_StreamJobMetrics_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamJobMetricsReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(JobServiceServer).StreamJobMetrics(m, &jobServiceStreamJobMetricsServer{stream})
 }
 
-type jobServiceGetJobLogsClient struct {
-	grpc.ClientStream
+type JobService_StreamJobMetricsServer interface {
+	Send(*JobMetricsSnapshot) error
+	grpc.ServerStream
 }
 
-func (x *jobServiceGetJobLogsClient) Recv() (*DataChunk, error) {
-	m := new(DataChunk)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+type jobServiceStreamJobMetricsServer struct {
+	grpc.ServerStream
 }
 
-func (c *jobServiceClient) ListJobs(ctx context.Context, in *EmptyRequest, opts ...grpc.CallOption) (*Jobs, error) {
-	out := new(Jobs)
-	err := c.cc.Invoke(ctx, JobService_ListJobs_FullMethodName, in, out, opts...)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+func (x *jobServiceStreamJobMetricsServer) Send(m *JobMetricsSnapshot) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-// JobServiceServer is the server API for JobService service.
-// All implementations must embed UnimplementedJobServiceServer
-// for forward compatibility
-type JobServiceServer interface {
-	RunJob(context.Context, *RunJobReq) (*RunJobRes, error)
-	GetJobStatus(context.Context, *GetJobStatusReq) (*GetJobStatusRes, error)
-	StopJob(context.Context, *StopJobReq) (*StopJobRes, error)
-	GetJobLogs(*GetJobLogsReq, JobService_GetJobLogsServer) error
-	ListJobs(context.Context, *EmptyRequest) (*Jobs, error)
-	mustEmbedUnimplementedJobServiceServer()
+func _JobService_WriteJobStdin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(JobServiceServer).WriteJobStdin(&jobServiceWriteJobStdinServer{stream})
 }
 
-// UnimplementedJobServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedJobServiceServer struct {
+type JobService_WriteJobStdinServer interface {
+	SendAndClose(*WriteJobStdinRes) error
+	Recv() (*StdinChunk, error)
+	grpc.ServerStream
 }
 
-func (UnimplementedJobServiceServer) RunJob(context.Context, *RunJobReq) (*RunJobRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method RunJob not implemented")
-}
-func (UnimplementedJobServiceServer) GetJobStatus(context.Context, *GetJobStatusReq) (*GetJobStatusRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetJobStatus not implemented")
-}
-func (UnimplementedJobServiceServer) StopJob(context.Context, *StopJobReq) (*StopJobRes, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method StopJob not implemented")
-}
-func (UnimplementedJobServiceServer) GetJobLogs(*GetJobLogsReq, JobService_GetJobLogsServer) error {
-	return status.Errorf(codes.Unimplemented, "method GetJobLogs not implemented")
-}
-func (UnimplementedJobServiceServer) ListJobs(context.Context, *EmptyRequest) (*Jobs, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+type jobServiceWriteJobStdinServer struct {
+	grpc.ServerStream
 }
-func (UnimplementedJobServiceServer) mustEmbedUnimplementedJobServiceServer() {}
 
-// UnsafeJobServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to JobServiceServer will
-// result in compilation errors.
-type UnsafeJobServiceServer interface {
-	mustEmbedUnimplementedJobServiceServer()
+func (x *jobServiceWriteJobStdinServer) SendAndClose(m *WriteJobStdinRes) error {
+	return x.ServerStream.SendMsg(m)
 }
 
-func RegisterJobServiceServer(s grpc.ServiceRegistrar, srv JobServiceServer) {
-	s.RegisterService(&JobService_ServiceDesc, srv)
+func (x *jobServiceWriteJobStdinServer) Recv() (*StdinChunk, error) {
+	m := new(StdinChunk)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
 }
 
-func _JobService_RunJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RunJobReq)
+func _JobService_GetWorkerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).RunJob(ctx, in)
+		return srv.(JobServiceServer).GetWorkerInfo(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: JobService_RunJob_FullMethodName,
+		FullMethod: JobService_GetWorkerInfo_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).RunJob(ctx, req.(*RunJobReq))
+		return srv.(JobServiceServer).GetWorkerInfo(ctx, req.(*EmptyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_GetJobStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetJobStatusReq)
+func _JobService_GetDiagnostics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmptyRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).GetJobStatus(ctx, in)
+		return srv.(JobServiceServer).GetDiagnostics(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: JobService_GetJobStatus_FullMethodName,
+		FullMethod: JobService_GetDiagnostics_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).GetJobStatus(ctx, req.(*GetJobStatusReq))
+		return srv.(JobServiceServer).GetDiagnostics(ctx, req.(*EmptyRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_StopJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(StopJobReq)
+func _JobService_GetStoreStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStoreStatsReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).StopJob(ctx, in)
+		return srv.(JobServiceServer).GetStoreStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: JobService_StopJob_FullMethodName,
+		FullMethod: JobService_GetStoreStats_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).StopJob(ctx, req.(*StopJobReq))
+		return srv.(JobServiceServer).GetStoreStats(ctx, req.(*GetStoreStatsReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _JobService_GetJobLogs_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(GetJobLogsReq)
+func _JobService_SubscribeJobEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeJobEventsReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
-	return srv.(JobServiceServer).GetJobLogs(m, &jobServiceGetJobLogsServer{stream})
+	return srv.(JobServiceServer).SubscribeJobEvents(m, &jobServiceSubscribeJobEventsServer{stream})
 }
 
-type JobService_GetJobLogsServer interface {
-	Send(*DataChunk) error
+type JobService_SubscribeJobEventsServer interface {
+	Send(*JobEvent) error
 	grpc.ServerStream
 }
 
-type jobServiceGetJobLogsServer struct {
+type jobServiceSubscribeJobEventsServer struct {
 	grpc.ServerStream
 }
 
-func (x *jobServiceGetJobLogsServer) Send(m *DataChunk) error {
+func (x *jobServiceSubscribeJobEventsServer) Send(m *JobEvent) error {
 	return x.ServerStream.SendMsg(m)
 }
 
-func _JobService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(EmptyRequest)
+func _JobService_GetUsageReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUsageReportReq)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(JobServiceServer).ListJobs(ctx, in)
+		return srv.(JobServiceServer).GetUsageReport(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: JobService_ListJobs_FullMethodName,
+		FullMethod: JobService_GetUsageReport_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(JobServiceServer).ListJobs(ctx, req.(*EmptyRequest))
+		return srv.(JobServiceServer).GetUsageReport(ctx, req.(*GetUsageReportReq))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -254,7 +1503,7 @@ func _JobService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
 var JobService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "worker.JobService",
+	ServiceName: "jobworker.v1.JobService",
 	HandlerType: (*JobServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
@@ -265,21 +1514,281 @@ var JobService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetJobStatus",
 			Handler:    _JobService_GetJobStatus_Handler,
 		},
+		{
+			MethodName: "GetJobProvenance",
+			Handler:    _JobService_GetJobProvenance_Handler,
+		},
+		{
+			MethodName: "GetJobProcesses",
+			Handler:    _JobService_GetJobProcesses_Handler,
+		},
+		{
+			MethodName: "ValidateJob",
+			Handler:    _JobService_ValidateJob_Handler,
+		},
 		{
 			MethodName: "StopJob",
 			Handler:    _JobService_StopJob_Handler,
 		},
+		{
+			MethodName: "ResumeJob",
+			Handler:    _JobService_ResumeJob_Handler,
+		},
+		{
+			MethodName: "RerunJob",
+			Handler:    _JobService_RerunJob_Handler,
+		},
+		{
+			MethodName: "DeleteJob",
+			Handler:    _JobService_DeleteJob_Handler,
+		},
 		{
 			MethodName: "ListJobs",
 			Handler:    _JobService_ListJobs_Handler,
 		},
+		{
+			MethodName: "CreateSecret",
+			Handler:    _JobService_CreateSecret_Handler,
+		},
+		{
+			MethodName: "DeleteSecret",
+			Handler:    _JobService_DeleteSecret_Handler,
+		},
+		{
+			MethodName: "RunPipeline",
+			Handler:    _JobService_RunPipeline_Handler,
+		},
+		{
+			MethodName: "GetPipelineStatus",
+			Handler:    _JobService_GetPipelineStatus_Handler,
+		},
+		{
+			MethodName: "RunJobGroup",
+			Handler:    _JobService_RunJobGroup_Handler,
+		},
+		{
+			MethodName: "GetJobGroup",
+			Handler:    _JobService_GetJobGroup_Handler,
+		},
+		{
+			MethodName: "ListJobGroups",
+			Handler:    _JobService_ListJobGroups_Handler,
+		},
+		{
+			MethodName: "StopJobGroup",
+			Handler:    _JobService_StopJobGroup_Handler,
+		},
+		{
+			MethodName: "CreateJobTemplate",
+			Handler:    _JobService_CreateJobTemplate_Handler,
+		},
+		{
+			MethodName: "ListJobTemplates",
+			Handler:    _JobService_ListJobTemplates_Handler,
+		},
+		{
+			MethodName: "RunJobFromTemplate",
+			Handler:    _JobService_RunJobFromTemplate_Handler,
+		},
+		{
+			MethodName: "StopJobs",
+			Handler:    _JobService_StopJobs_Handler,
+		},
+		{
+			MethodName: "DeleteJobs",
+			Handler:    _JobService_DeleteJobs_Handler,
+		},
+		{
+			MethodName: "GetWorkerInfo",
+			Handler:    _JobService_GetWorkerInfo_Handler,
+		},
+		{
+			MethodName: "GetDiagnostics",
+			Handler:    _JobService_GetDiagnostics_Handler,
+		},
+		{
+			MethodName: "GetStoreStats",
+			Handler:    _JobService_GetStoreStats_Handler,
+		},
+		{
+			MethodName: "GetUsageReport",
+			Handler:    _JobService_GetUsageReport_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "RunJobAttached",
+			Handler:       _JobService_RunJobAttached_Handler,
+			ServerStreams: true,
+		},
 		{
 			StreamName:    "GetJobLogs",
 			Handler:       _JobService_GetJobLogs_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "ExportJob",
+			Handler:       _JobService_ExportJob_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetJobArtifact",
+			Handler:       _JobService_GetJobArtifact_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "UploadJobFiles",
+			Handler:       _JobService_UploadJobFiles_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubmitJob",
+			Handler:       _JobService_SubmitJob_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "StreamJobMetrics",
+			Handler:       _JobService_StreamJobMetrics_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WriteJobStdin",
+			Handler:       _JobService_WriteJobStdin_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "SubscribeJobEvents",
+			Handler:       _JobService_SubscribeJobEvents_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "jobworker/v1/worker.proto",
+}
+
+const (
+	FleetService_RegisterWorker_FullMethodName = "/jobworker.v1.FleetService/RegisterWorker"
+	FleetService_Heartbeat_FullMethodName      = "/jobworker.v1.FleetService/Heartbeat"
+)
+
+// FleetServiceClient is the client API for FleetService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FleetServiceClient interface {
+	RegisterWorker(ctx context.Context, in *RegisterWorkerReq, opts ...grpc.CallOption) (*RegisterWorkerRes, error)
+	Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error)
+}
+
+type fleetServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFleetServiceClient(cc grpc.ClientConnInterface) FleetServiceClient {
+	return &fleetServiceClient{cc}
+}
+
+func (c *fleetServiceClient) RegisterWorker(ctx context.Context, in *RegisterWorkerReq, opts ...grpc.CallOption) (*RegisterWorkerRes, error) {
+	out := new(RegisterWorkerRes)
+	err := c.cc.Invoke(ctx, FleetService_RegisterWorker_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fleetServiceClient) Heartbeat(ctx context.Context, in *HeartbeatReq, opts ...grpc.CallOption) (*HeartbeatRes, error) {
+	out := new(HeartbeatRes)
+	err := c.cc.Invoke(ctx, FleetService_Heartbeat_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FleetServiceServer is the server API for FleetService service.
+// All implementations must embed UnimplementedFleetServiceServer
+// for forward compatibility
+type FleetServiceServer interface {
+	RegisterWorker(context.Context, *RegisterWorkerReq) (*RegisterWorkerRes, error)
+	Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatRes, error)
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+// UnimplementedFleetServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedFleetServiceServer struct {
+}
+
+func (UnimplementedFleetServiceServer) RegisterWorker(context.Context, *RegisterWorkerReq) (*RegisterWorkerRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegisterWorker not implemented")
+}
+func (UnimplementedFleetServiceServer) Heartbeat(context.Context, *HeartbeatReq) (*HeartbeatRes, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
+}
+func (UnimplementedFleetServiceServer) mustEmbedUnimplementedFleetServiceServer() {}
+
+// UnsafeFleetServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FleetServiceServer will
+// result in compilation errors.
+type UnsafeFleetServiceServer interface {
+	mustEmbedUnimplementedFleetServiceServer()
+}
+
+func RegisterFleetServiceServer(s grpc.ServiceRegistrar, srv FleetServiceServer) {
+	s.RegisterService(&FleetService_ServiceDesc, srv)
+}
+
+func _FleetService_RegisterWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterWorkerReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).RegisterWorker(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_RegisterWorker_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).RegisterWorker(ctx, req.(*RegisterWorkerReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FleetService_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeartbeatReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FleetServiceServer).Heartbeat(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FleetService_Heartbeat_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FleetServiceServer).Heartbeat(ctx, req.(*HeartbeatReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// FleetService_ServiceDesc is the grpc.ServiceDesc for FleetService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var FleetService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "jobworker.v1.FleetService",
+	HandlerType: (*FleetServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RegisterWorker",
+			Handler:    _FleetService_RegisterWorker_Handler,
+		},
+		{
+			MethodName: "Heartbeat",
+			Handler:    _FleetService_Heartbeat_Handler,
+		},
 	},
-	Metadata: "worker.proto",
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "jobworker/v1/worker.proto",
 }
@@ -4,12 +4,18 @@ import (
 	"fmt"
 	"log"
 	"os"
-	"path/filepath"
+	"runtime"
 	"worker/internal/modes"
 	"worker/pkg/config"
 	"worker/pkg/logger"
 )
 
+func init() {
+	// A job's init creates its time namespace from the main thread, the only
+	// one whose namespace /proc/self/timens_offsets configures
+	runtime.LockOSThread()
+}
+
 func main() {
 	// Load configuration first
 	cfg, path, err := config.LoadConfig()
@@ -30,6 +36,10 @@ func main() {
 		"address", cfg.GetServerAddress(),
 		"logLevel", cfg.Logging.Level)
 
+	if cfg.Server.Mode != "init" {
+		runtime.UnlockOSThread()
+	}
+
 	// Run based on configured mode
 	var runErr error
 	switch cfg.Server.Mode {
@@ -37,6 +47,10 @@ func main() {
 		runErr = modes.RunServer(cfg)
 	case "init":
 		runErr = modes.RunJobInit(cfg)
+	case "agent":
+		runErr = modes.RunAgent(cfg)
+	case "coordinator":
+		runErr = modes.RunCoordinator(cfg)
 	default:
 		runErr = fmt.Errorf("unknown mode: %s (check WORKER_MODE or config file)", cfg.Server.Mode)
 	}
@@ -48,20 +62,34 @@ func main() {
 }
 
 func initializeLogging(cfg *config.Config) {
+	opts := logger.Options{Level: logger.INFO, Components: make(map[string]logger.LogLevel)}
+
 	// Parse and set log level
 	if level, err := logger.ParseLevel(cfg.Logging.Level); err == nil {
-		logger.SetLevel(level)
+		opts.Level = level
 	} else {
 		log.Printf("Invalid log level '%s', using INFO", cfg.Logging.Level)
-		logger.SetLevel(logger.INFO)
+	}
+	for component, name := range cfg.Logging.Components {
+		if level, err := logger.ParseLevel(name); err == nil {
+			opts.Components[component] = level
+		}
 	}
 
-	// Configure output if needed (for file logging)
-	if cfg.Logging.Output != "stdout" && cfg.Logging.Output != "" {
-		// Ensure log directory exists
-		logDir := filepath.Dir(cfg.Logging.Output)
-		if err := os.MkdirAll(logDir, 0755); err != nil {
-			log.Printf("Failed to setup log file, using stdout: %v", err)
+	if format, err := logger.ParseFormat(cfg.Logging.Format); err == nil {
+		opts.Format = format
+	} else {
+		log.Printf("Invalid log format '%s', using text", cfg.Logging.Format)
+	}
+
+	// Job init processes log to the job's output, only the server uses the configured sink
+	if cfg.Server.Mode != "init" {
+		sink, err := logger.OpenSink(cfg.Logging.Output, cfg.Logging.MaxSize, cfg.Logging.MaxBackups)
+		if err != nil {
+			log.Printf("Failed to open log output '%s', using stdout: %v", cfg.Logging.Output, err)
 		}
+		opts.Sink = sink
 	}
+
+	logger.Configure(opts)
 }
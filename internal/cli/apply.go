@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"time"
+	"worker/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+type applyCmdParams struct {
+	path    string
+	dryRun  bool
+	signKey string
+}
+
+func newApplyCmd() *cobra.Command {
+	params := &applyCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "apply -f <file|dir>",
+		Short: "Run jobs described in YAML spec files",
+		Long: `Run every job described in a YAML spec file, or in each .yaml/.yml file of a
+directory. A file may hold several specs separated by "---".
+
+Example job.yaml:
+  command: python3
+  args: ["app.py"]
+  cpu: "0.5"                 # or maxCPU: 50 (percent)
+  memory: 512Mi              # or maxMemory: 512 (MB)
+  io: 50MB/s                 # or maxIOBPS: 50000000
+  profile: medium            # server limit profile, the limits above override it
+  env:
+    APP_ENV: prod
+  envFile: .env              # relative to the spec file
+  secretEnv:
+    API_KEY: my-api-key
+  files:                     # uploaded into the job workspace
+    - local: app.py
+    - local: data/input.csv
+      path: input/data.csv
+  restartPolicy: on-failure
+  maxRestarts: 3
+  labels:
+    team: ci
+  shell: false               # true runs command as a script with sh -c
+  isolation: cgroups         # full, cgroups, process or privileged (default: server setting)
+  healthProbe:
+    type: http
+    port: 8080
+    path: /healthz
+    interval: 10s`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(params)
+		},
+	}
+
+	cmd.Flags().StringVarP(&params.path, "file", "f", "", "Job spec file or directory of spec files")
+	cmd.Flags().BoolVar(&params.dryRun, "dry-run", false, "Validate the jobs on the server without starting them")
+	cmd.Flags().StringVar(&params.signKey, "sign-key", "", "Sign each job request with an Ed25519 private key in PEM")
+	_ = cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func runApply(params *applyCmdParams) error {
+	specs, err := loadJobFiles(params.path)
+	if err != nil {
+		return err
+	}
+	signKey, err := loadSigningKey(params.signKey)
+	if err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	failed := 0
+	for i, spec := range specs {
+		fmt.Printf("[%d/%d] %s\n", i+1, len(specs), spec.Command)
+		if err := applySpec(jobClient, spec, params.dryRun, signKey); err != nil {
+			failed++
+			fmt.Printf("  %v\n", err)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d jobs failed", failed, len(specs))
+	}
+	return nil
+}
+
+func applySpec(jobClient *client.JobClient, spec *jobFile, dryRun bool, signKey ed25519.PrivateKey) error {
+	job, files, err := spec.request()
+	if err != nil {
+		return err
+	}
+
+	if dryRun {
+		if err := signJob(job, signKey); err != nil {
+			return err
+		}
+		return runValidate(jobClient, job, files)
+	}
+
+	if len(files) > 0 {
+		uploadCtx, uploadCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		upload, e := jobClient.UploadJobFiles(uploadCtx, files)
+		uploadCancel()
+		if e != nil {
+			return fmt.Errorf("failed to upload job files: %v", e)
+		}
+		job.UploadId = upload.UploadId
+	}
+	if err := signJob(job, signKey); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.RunJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to run job: %v", err)
+	}
+
+	fmt.Printf("  ID: %s Status: %s\n", response.Id, response.Status)
+	return nil
+}
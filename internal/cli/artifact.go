@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type artifactCmdParams struct {
+	file string
+}
+
+func newArtifactCmd() *cobra.Command {
+	params := &artifactCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "artifact <job-id> <path>",
+		Short: "Download a file from a job's workspace",
+		Long: `Download a file a job left in its workspace, such as the core file of a
+crashed job shown by 'cli status'. The path is relative to the workspace, and
+files can be downloaded for as long as the worker keeps the workspace.
+
+Examples:
+  cli artifact 42 core.1234
+  cli artifact 42 results/report.json -f report.json
+  cli artifact 42 results/report.json -f - | jq .`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runArtifact(args[0], args[1], params)
+		},
+	}
+
+	cmd.Flags().StringVarP(&params.file, "file", "f", "", "Write the file here, - for stdout (default its name in the current directory)")
+
+	return cmd
+}
+
+func runArtifact(jobID, artifact string, params *artifactCmdParams) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
+	defer cancel()
+
+	stream, err := jobClient.GetJobArtifact(ctx, jobID, artifact)
+	if err != nil {
+		return err
+	}
+
+	// receive the first chunk before creating the file, so a failed download leaves none behind
+	chunk, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to download %s: %v", artifact, err)
+	}
+
+	path := params.file
+	if path == "" {
+		path = filepath.Base(artifact)
+	}
+	out := os.Stdout
+	if path != "-" {
+		if out, err = os.Create(path); err != nil {
+			return fmt.Errorf("failed to create %s: %v", path, err)
+		}
+		defer out.Close()
+	}
+
+	var written int64
+	for chunk != nil {
+		n, err := out.Write(chunk.Payload)
+		written += int64(n)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		if chunk, err = stream.Recv(); err != nil && err != io.EOF {
+			return fmt.Errorf("download interrupted: %v", err)
+		}
+	}
+
+	if path != "-" {
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		fmt.Printf("Downloaded %s of job %s to %s (%d bytes)\n", artifact, jobID, path, written)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"worker/pkg/client"
+
+	"google.golang.org/grpc/status"
+	pb "worker/api/gen"
+)
+
+// ExitCodeError makes the CLI exit with the code of an attached job
+type ExitCodeError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitCodeError) Error() string {
+	return e.Err.Error()
+}
+
+// runAttached starts the job and copies its output to stdout until it finishes.
+// If stdin is set it is copied to the job's stdin once the job has started.
+// Ctrl+C detaches; the job keeps running on the server.
+func runAttached(jobClient *client.JobClient, job *pb.RunJobReq, stdin io.Reader) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+
+	var jobID string
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	stream, err := jobClient.RunJobAttached(ctx, job)
+	if err != nil {
+		return err
+	}
+
+	for {
+		res, e := stream.Recv()
+		if e == io.EOF {
+			return fmt.Errorf("stream ended before job %s finished", jobID)
+		}
+		if e != nil {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				fmt.Fprintf(os.Stderr, "\nDetached, job %s keeps running\n", jobID)
+				return nil
+			}
+			if s, ok := status.FromError(e); ok {
+				return fmt.Errorf("failed to run job: %v", s.Message())
+			}
+			return fmt.Errorf("error receiving job stream: %v", e)
+		}
+
+		switch event := res.Event.(type) {
+		case *pb.RunJobAttachedRes_Started:
+			jobID = event.Started.Id
+			fmt.Fprintf(os.Stderr, "Job %s started\n", jobID)
+			if stdin != nil {
+				go writeStdin(ctx, jobClient, jobID, stdin)
+			}
+		case *pb.RunJobAttachedRes_Output:
+			if _, err := os.Stdout.Write(event.Output); err != nil {
+				return fmt.Errorf("failed to write output: %v", err)
+			}
+		case *pb.RunJobAttachedRes_Exit:
+			fmt.Fprintf(os.Stderr, "Job %s %s with exit code %d\n", jobID, event.Exit.Status, event.Exit.ExitCode)
+			if event.Exit.Status == "COMPLETED" {
+				return nil
+			}
+			code := int(event.Exit.ExitCode)
+			if code <= 0 {
+				code = 1
+			}
+			return &ExitCodeError{Code: code, Err: fmt.Errorf("job %s %s", jobID, event.Exit.Status)}
+		}
+	}
+}
+
+func writeStdin(ctx context.Context, jobClient *client.JobClient, jobID string, stdin io.Reader) {
+	if _, err := jobClient.WriteJobStdin(ctx, jobID, stdin); err != nil && ctx.Err() == nil {
+		if s, ok := status.FromError(err); ok {
+			err = errors.New(s.Message())
+		}
+		fmt.Fprintf(os.Stderr, "Failed to write stdin of job %s: %v\n", jobID, err)
+	}
+}
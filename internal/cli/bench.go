@@ -0,0 +1,238 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+	"worker/pkg/client"
+)
+
+type benchCmdParams struct {
+	jobs        int
+	concurrency int
+	isolation   string
+	timeout     time.Duration
+	keep        bool
+}
+
+func newBenchCmd() *cobra.Command {
+	params := &benchCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "bench [flags] [-- command [args...]]",
+		Short: "Load-test a worker with short jobs",
+		Long: `Start many short jobs, a given number at a time, and report how fast the
+worker launches and cleans them up: the latency of each request, the launch
+time the worker measured from the request to the process running, the time
+from the end of each job to the removal of its cgroup, and the throughput.
+The jobs run 'true' unless a command is given, and are deleted afterwards.
+
+Examples:
+  cli bench
+  cli bench -n 1000 -c 50
+  cli bench -n 200 --isolation=cgroups -- sleep 0.1`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runBench(params, args)
+		},
+	}
+
+	cmd.Flags().IntVarP(&params.jobs, "jobs", "n", 100, "Number of jobs to start")
+	cmd.Flags().IntVarP(&params.concurrency, "concurrency", "c", 10, "Jobs started at a time")
+	cmd.Flags().StringVar(&params.isolation, "isolation", "", "Isolation of the jobs: full, cgroups or process (default the worker's)")
+	cmd.Flags().DurationVar(&params.timeout, "timeout", 5*time.Minute, "Give up waiting for the jobs after this long")
+	cmd.Flags().BoolVar(&params.keep, "keep", false, "Keep the jobs instead of deleting them")
+
+	return cmd
+}
+
+// latency summarizes a set of measured durations
+type latency struct {
+	Count              int
+	P50, P90, P99, Max time.Duration
+}
+
+// summarizeLatency computes nearest-rank percentiles of samples
+func summarizeLatency(samples []time.Duration) latency {
+	if len(samples) == 0 {
+		return latency{}
+	}
+
+	sorted := append([]time.Duration(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := func(p int) time.Duration {
+		i := (p*len(sorted)+99)/100 - 1
+		return sorted[max(i, 0)]
+	}
+	return latency{Count: len(sorted), P50: rank(50), P90: rank(90), P99: rank(99), Max: sorted[len(sorted)-1]}
+}
+
+// benchResult is what the benchmark measured
+type benchResult struct {
+	started    []string // ids of the jobs started
+	failed     int      // requests the worker refused or failed
+	incomplete int      // jobs that did not complete, or were not cleaned up in time
+	elapsed    time.Duration
+	requests   []time.Duration
+	launches   []time.Duration
+	cleanups   []time.Duration
+}
+
+func runBench(params *benchCmdParams, args []string) error {
+	if params.jobs < 1 || params.concurrency < 1 {
+		return fmt.Errorf("--jobs and --concurrency must be at least 1")
+	}
+	command, commandArgs := "true", []string(nil)
+	if len(args) > 0 {
+		command, commandArgs = args[0], args[1:]
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), params.timeout)
+	defer cancel()
+
+	// subscribe before starting anything, so no cleanup goes unseen
+	stream, err := jobClient.SubscribeJobEvents(ctx, nil, []string{"job.cleaned_up"})
+	if err != nil {
+		return err
+	}
+	cleanedUp := make(chan string)
+	go func() {
+		for {
+			event, err := stream.Recv()
+			if err != nil {
+				close(cleanedUp)
+				return
+			}
+			select {
+			case cleanedUp <- event.JobId:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	fmt.Printf("Starting %d jobs, %d at a time: %s\n", params.jobs, params.concurrency, command)
+
+	result := &benchResult{}
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, params.concurrency)
+	start := time.Now()
+
+	for i := 0; i < params.jobs; i++ {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+
+			requested := time.Now()
+			res, err := jobClient.RunJob(ctx, &pb.RunJobReq{Command: command, Args: commandArgs, Isolation: params.isolation})
+			took := time.Since(requested)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				result.failed++
+				return
+			}
+			result.started = append(result.started, res.Id)
+			result.requests = append(result.requests, took)
+		}()
+	}
+	wg.Wait()
+	result.elapsed = time.Since(start)
+
+	waitForCleanups(ctx, cleanedUp, result.started)
+	collectJobTimes(jobClient, result)
+
+	if !params.keep {
+		deleteBenchJobs(jobClient, result.started)
+	}
+
+	printBench(os.Stdout, result)
+	return nil
+}
+
+// waitForCleanups waits until every started job was cleaned up, or ctx is done
+func waitForCleanups(ctx context.Context, cleanedUp <-chan string, ids []string) {
+	pending := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		pending[id] = true
+	}
+
+	for len(pending) > 0 {
+		select {
+		case id, ok := <-cleanedUp:
+			if !ok {
+				return
+			}
+			delete(pending, id)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// collectJobTimes reads the launch and cleanup times the worker recorded
+func collectJobTimes(jobClient *client.JobClient, result *benchResult) {
+	for _, id := range result.started {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		job, err := jobClient.GetJobStatus(ctx, id)
+		cancel()
+		if err != nil {
+			result.incomplete++
+			continue
+		}
+
+		result.launches = append(result.launches, time.Duration(job.LaunchMicros)*time.Microsecond)
+		if job.Status != "COMPLETED" || job.CleanupMicros == 0 {
+			result.incomplete++
+			continue
+		}
+		result.cleanups = append(result.cleanups, time.Duration(job.CleanupMicros)*time.Microsecond)
+	}
+}
+
+// deleteBenchJobs removes the jobs the benchmark started, leaving any that
+// are still running
+func deleteBenchJobs(jobClient *client.JobClient, ids []string) {
+	for _, id := range ids {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		_, _ = jobClient.DeleteJob(ctx, id)
+		cancel()
+	}
+}
+
+func printBench(out io.Writer, result *benchResult) {
+	fmt.Fprintf(out, "\nJobs: %d started, %d failed to start, %d did not complete\n",
+		len(result.started), result.failed, result.incomplete)
+	if seconds := result.elapsed.Seconds(); seconds > 0 {
+		fmt.Fprintf(out, "Duration: %v (%.1f jobs/s)\n", result.elapsed.Round(time.Millisecond), float64(len(result.started))/seconds)
+	}
+
+	fmt.Fprintf(out, "\n%-10s %8s %12s %12s %12s %12s\n", "", "COUNT", "P50", "P90", "P99", "MAX")
+	for _, row := range []struct {
+		name    string
+		samples []time.Duration
+	}{
+		{"Request", result.requests},
+		{"Launch", result.launches},
+		{"Cleanup", result.cleanups},
+	} {
+		l := summarizeLatency(row.samples)
+		fmt.Fprintf(out, "%-10s %8d %12v %12v %12v %12v\n", row.name, l.Count,
+			l.P50.Round(time.Microsecond), l.P90.Round(time.Microsecond), l.P99.Round(time.Microsecond), l.Max.Round(time.Microsecond))
+	}
+}
@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSummarizeLatency(t *testing.T) {
+	if got := summarizeLatency(nil); got != (latency{}) {
+		t.Errorf("summarizeLatency(nil) = %+v, want zero", got)
+	}
+
+	var samples []time.Duration
+	for i := 100; i >= 1; i-- {
+		samples = append(samples, time.Duration(i)*time.Millisecond)
+	}
+	got := summarizeLatency(samples)
+	want := latency{Count: 100, P50: 50 * time.Millisecond, P90: 90 * time.Millisecond, P99: 99 * time.Millisecond, Max: 100 * time.Millisecond}
+	if got != want {
+		t.Errorf("summarizeLatency() = %+v, want %+v", got, want)
+	}
+	if samples[0] != 100*time.Millisecond {
+		t.Error("summarizeLatency() reordered the samples")
+	}
+
+	got = summarizeLatency([]time.Duration{3 * time.Second})
+	if got.P50 != 3*time.Second || got.P99 != 3*time.Second {
+		t.Errorf("summarizeLatency() of one sample = %+v", got)
+	}
+}
+
+func TestPrintBench(t *testing.T) {
+	var out bytes.Buffer
+	printBench(&out, &benchResult{
+		started:    []string{"1", "2"},
+		failed:     1,
+		incomplete: 1,
+		elapsed:    time.Second,
+		requests:   []time.Duration{2 * time.Millisecond, 4 * time.Millisecond},
+		launches:   []time.Duration{time.Millisecond, 3 * time.Millisecond},
+		cleanups:   []time.Duration{5 * time.Millisecond},
+	})
+
+	for _, want := range []string{
+		"2 started, 1 failed to start, 1 did not complete",
+		"(2.0 jobs/s)",
+		"Launch            2          1ms          3ms          3ms          3ms",
+		"Cleanup           1          5ms",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("printBench() output lacks %q:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+// jobSelector holds the flags that select jobs for bulk stop and delete
+type jobSelector struct {
+	labels    []string
+	statuses  []string
+	olderThan time.Duration
+}
+
+func (s *jobSelector) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(&s.labels, "label", nil, "Select jobs with label KEY=VALUE (repeatable)")
+	cmd.Flags().StringArrayVar(&s.statuses, "status", nil, "Select jobs with this status (repeatable)")
+	cmd.Flags().DurationVar(&s.olderThan, "older-than", 0, "Select jobs that ended (or started, if active) at least this long ago, e.g. 168h")
+}
+
+func (s *jobSelector) isSet() bool {
+	return len(s.labels) > 0 || len(s.statuses) > 0 || s.olderThan > 0
+}
+
+// request builds a bulk request for the given job IDs or, if there are none, the selector flags
+func (s *jobSelector) request(ids []string) (*pb.BulkJobsReq, error) {
+	if len(ids) > 0 && s.isSet() {
+		return nil, fmt.Errorf("select jobs by id or by --label/--status/--older-than, not both")
+	}
+	if len(ids) > 0 {
+		return &pb.BulkJobsReq{Ids: ids}, nil
+	}
+	if !s.isSet() {
+		return nil, fmt.Errorf("specify job ids or at least one of --label, --status, --older-than")
+	}
+
+	filter := &pb.JobFilter{OlderThanSeconds: int64(s.olderThan / time.Second)}
+	for _, label := range s.labels {
+		key, value, found := strings.Cut(label, "=")
+		if !found || key == "" {
+			return nil, fmt.Errorf("invalid --label value %q, expected KEY=VALUE", label)
+		}
+		if filter.Labels == nil {
+			filter.Labels = make(map[string]string)
+		}
+		filter.Labels[key] = value
+	}
+	for _, status := range s.statuses {
+		filter.Statuses = append(filter.Statuses, strings.ToUpper(status))
+	}
+
+	return &pb.BulkJobsReq{Filter: filter}, nil
+}
+
+func printBulkResults(action string, res *pb.BulkJobsRes) {
+	if len(res.Results) == 0 {
+		fmt.Println("No matching jobs")
+		return
+	}
+
+	failed := 0
+	for _, r := range res.Results {
+		if r.Ok {
+			fmt.Printf("%s %s\n", r.Id, action)
+		} else {
+			failed++
+			fmt.Printf("%s failed: %s\n", r.Id, r.Error)
+		}
+	}
+	fmt.Printf("%d %s, %d failed\n", len(res.Results)-failed, action, failed)
+}
@@ -8,4 +8,29 @@ func NewConfig() *Config {
 
 type Config struct {
 	ServerAddr string
+	CertPath   string
+	KeyPath    string
+	CAPath     string
+	Context    string // Name of the context to use instead of the current one
+
+	Compression string // Compressor of the output streams, "gzip", "zstd" or "" for none
+}
+
+// Apply fills the settings that were not given on the command line from ctx
+func (c *Config) Apply(ctx *Context, changed func(flag string) bool) {
+	if ctx.Server != "" && !changed("server") {
+		c.ServerAddr = ctx.Server
+	}
+	if ctx.Cert != "" && !changed("cert") {
+		c.CertPath = ctx.Cert
+	}
+	if ctx.Key != "" && !changed("key") {
+		c.KeyPath = ctx.Key
+	}
+	if ctx.CA != "" && !changed("ca") {
+		c.CAPath = ctx.CA
+	}
+	if ctx.Compression != "" && !changed("compress") {
+		c.Compression = ctx.Compression
+	}
 }
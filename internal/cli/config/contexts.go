@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Context is a named set of connection settings for one worker server
+type Context struct {
+	Name   string `yaml:"name"`
+	Server string `yaml:"server"`
+	CA     string `yaml:"ca,omitempty"`
+	Cert   string `yaml:"cert,omitempty"`
+	Key    string `yaml:"key,omitempty"`
+
+	Compression string `yaml:"compression,omitempty"` // compressor of the output streams, for slow links
+}
+
+// File is the client config file holding the known contexts
+type File struct {
+	CurrentContext string    `yaml:"currentContext,omitempty"`
+	Contexts       []Context `yaml:"contexts,omitempty"`
+}
+
+// DefaultPath is $WORKER_CONFIG, or ~/.worker/config.yaml
+func DefaultPath() string {
+	if path := os.Getenv("WORKER_CONFIG"); path != "" {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(".worker", "config.yaml")
+	}
+	return filepath.Join(home, ".worker", "config.yaml")
+}
+
+// LoadFile reads the config file at path. A missing file is an empty config.
+func LoadFile(path string) (*File, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &File{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var f File
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&f); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &f, nil
+}
+
+// Save writes the config file, readable by its owner only
+func (f *File) Save(path string) error {
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(f); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return os.WriteFile(path, data.Bytes(), 0600)
+}
+
+// Find returns the context with the given name
+func (f *File) Find(name string) (*Context, error) {
+	for i := range f.Contexts {
+		if f.Contexts[i].Name == name {
+			return &f.Contexts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("context %q not found", name)
+}
+
+// Current returns the current context, nil if none is set
+func (f *File) Current() (*Context, error) {
+	if f.CurrentContext == "" {
+		return nil, nil
+	}
+	return f.Find(f.CurrentContext)
+}
+
+// Set adds the context, or replaces the one with the same name
+func (f *File) Set(ctx Context) {
+	for i := range f.Contexts {
+		if f.Contexts[i].Name == ctx.Name {
+			f.Contexts[i] = ctx
+			return
+		}
+	}
+	f.Contexts = append(f.Contexts, ctx)
+}
+
+// Delete removes the context, unsetting it if it was the current one
+func (f *File) Delete(name string) error {
+	for i := range f.Contexts {
+		if f.Contexts[i].Name == name {
+			f.Contexts = append(f.Contexts[:i], f.Contexts[i+1:]...)
+			if f.CurrentContext == name {
+				f.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("context %q not found", name)
+}
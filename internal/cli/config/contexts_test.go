@@ -0,0 +1,90 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "worker", "config.yaml")
+
+	file, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() of a missing file error = %v", err)
+	}
+	if len(file.Contexts) != 0 {
+		t.Fatalf("expected no contexts, got %v", file.Contexts)
+	}
+
+	file.Set(Context{Name: "prod", Server: "10.0.0.5:50051", Cert: "prod.pem"})
+	file.Set(Context{Name: "dev", Server: "localhost:50051"})
+	file.Set(Context{Name: "prod", Server: "10.0.0.6:50051"})
+	file.CurrentContext = "prod"
+	if err := file.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected mode 0600, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if len(loaded.Contexts) != 2 {
+		t.Fatalf("expected 2 contexts, got %v", loaded.Contexts)
+	}
+	current, err := loaded.Current()
+	if err != nil || current == nil {
+		t.Fatalf("Current() = %v, %v", current, err)
+	}
+	if current.Server != "10.0.0.6:50051" || current.Cert != "" {
+		t.Errorf("expected the replaced prod context, got %+v", current)
+	}
+
+	if err := loaded.Delete("prod"); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	if loaded.CurrentContext != "" {
+		t.Errorf("expected the current context to be unset, got %q", loaded.CurrentContext)
+	}
+	if err := loaded.Delete("prod"); err == nil {
+		t.Error("expected an error deleting a missing context")
+	}
+}
+
+func TestLoadFileRejectsUnknownFields(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("contexts:\n  - name: prod\n    address: x\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadFile(path); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
+
+func TestConfigApply(t *testing.T) {
+	cfg := &Config{ServerAddr: "flag:1", CertPath: "default.pem", KeyPath: "default-key.pem", CAPath: "ca.pem"}
+	ctx := &Context{Name: "prod", Server: "prod:50051", Cert: "prod.pem", Compression: "zstd"}
+
+	cfg.Apply(ctx, func(flag string) bool { return flag == "server" })
+
+	if cfg.ServerAddr != "flag:1" {
+		t.Errorf("expected the --server flag to win, got %q", cfg.ServerAddr)
+	}
+	if cfg.CertPath != "prod.pem" {
+		t.Errorf("expected the context cert, got %q", cfg.CertPath)
+	}
+	if cfg.KeyPath != "default-key.pem" {
+		t.Errorf("expected the default key to stay, got %q", cfg.KeyPath)
+	}
+	if cfg.Compression != "zstd" {
+		t.Errorf("expected the context compression, got %q", cfg.Compression)
+	}
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"worker/internal/cli/config"
+	"worker/pkg/client"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage connection contexts",
+		Long: `Manage named contexts holding a server address and the certificates to
+use with it. Commands use the current context unless --context is given;
+--server, --cert, --key, --ca and --compress override single settings.
+
+The config file is ~/.worker/config.yaml, or $WORKER_CONFIG if set.
+
+Examples:
+  cli config set-context prod --server=10.0.0.5:50051 --cert=prod/client-cert.pem --key=prod/client-key.pem --ca=prod/ca-cert.pem
+  cli config set-context remote --server=203.0.113.7:50051 --compress=zstd
+  cli config use-context prod
+  cli --context=staging list`,
+		// the config file is edited here, not used to connect
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			return nil
+		},
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the contexts, marking the current one",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := config.LoadFile(config.DefaultPath())
+			if err != nil {
+				return err
+			}
+			if len(file.Contexts) == 0 {
+				fmt.Println("No contexts found")
+				return nil
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "CURRENT\tNAME\tSERVER\tCERT")
+			for _, ctx := range file.Contexts {
+				current := ""
+				if ctx.Name == file.CurrentContext {
+					current = "*"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", current, ctx.Name, orDash(ctx.Server), orDash(ctx.Cert))
+			}
+			return w.Flush()
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "current-context",
+		Short: "Print the name of the current context",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			file, err := config.LoadFile(config.DefaultPath())
+			if err != nil {
+				return err
+			}
+			if file.CurrentContext == "" {
+				return fmt.Errorf("no current context is set")
+			}
+			fmt.Println(file.CurrentContext)
+			return nil
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "use-context <name>",
+		Short: "Make a context the current one",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateConfigFile(func(file *config.File) error {
+				if _, err := file.Find(args[0]); err != nil {
+					return err
+				}
+				file.CurrentContext = args[0]
+				fmt.Printf("Switched to context %q\n", args[0])
+				return nil
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "set-context <name>",
+		Short: "Create or update a context from --server, --cert, --key, --ca and --compress",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateConfigFile(func(file *config.File) error {
+				ctx := config.Context{Name: args[0]}
+				if existing, err := file.Find(args[0]); err == nil {
+					ctx = *existing
+				}
+
+				// only the flags given change the context
+				flags := cmd.Flags()
+				if flags.Changed("server") {
+					ctx.Server = cfg.ServerAddr
+				}
+				if flags.Changed("cert") {
+					ctx.Cert = cfg.CertPath
+				}
+				if flags.Changed("key") {
+					ctx.Key = cfg.KeyPath
+				}
+				if flags.Changed("ca") {
+					ctx.CA = cfg.CAPath
+				}
+				if flags.Changed("compress") {
+					compression, err := client.ParseCompression(cfg.Compression)
+					if err != nil {
+						return err
+					}
+					ctx.Compression = compression
+				}
+				if ctx.Server == "" {
+					return fmt.Errorf("context %q needs a server, use --server", ctx.Name)
+				}
+
+				file.Set(ctx)
+				if file.CurrentContext == "" {
+					file.CurrentContext = ctx.Name
+				}
+				fmt.Printf("Context %q saved\n", ctx.Name)
+				return nil
+			})
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "delete-context <name>",
+		Short: "Delete a context",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return updateConfigFile(func(file *config.File) error {
+				if err := file.Delete(args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("Context %q deleted\n", args[0])
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
+
+// updateConfigFile loads the config file, applies update and saves the result
+func updateConfigFile(update func(file *config.File) error) error {
+	path := config.DefaultPath()
+	file, err := config.LoadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := update(file); err != nil {
+		return err
+	}
+	if err := file.Save(path); err != nil {
+		return fmt.Errorf("failed to save config file: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newDeleteCmd() *cobra.Command {
+	selector := &jobSelector{}
+
+	cmd := &cobra.Command{
+		Use:   "delete [job-id...]",
+		Short: "Delete finished jobs with their output and workspace",
+		Long: `Delete finished jobs, given by ID or selected with filters.
+Running jobs are not deleted; stop them first.
+
+Examples:
+  cli delete 42 43
+  cli delete --status=FAILED --older-than=168h
+  cli delete --label=team=ci --status=COMPLETED`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !selector.isSet() {
+				return runDeleteOne(args[0])
+			}
+			return runDelete(selector, args)
+		},
+	}
+	selector.addFlags(cmd)
+
+	return cmd
+}
+
+func runDeleteOne(jobID string) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := jobClient.DeleteJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to delete job: %v", err)
+	}
+
+	fmt.Printf("Job %s deleted\n", response.Id)
+
+	return nil
+}
+
+func runDelete(selector *jobSelector, ids []string) error {
+	req, err := selector.request(ids)
+	if err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := jobClient.DeleteJobs(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to delete jobs: %v", err)
+	}
+
+	printBulkResults("deleted", response)
+
+	return nil
+}
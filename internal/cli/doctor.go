@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+func newDoctorCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Check the worker can run jobs and print how to fix what it cannot",
+		Long: `Ask the worker to check the subsystems it needs to run jobs: the cgroup
+tree and its controllers, the binary jobs are launched from, the workspace
+and spill dirs, the disk space left and the job store. Each check prints as
+OK, WARN or FAIL, with how to fix it when it is not OK. The command fails
+when any check fails.
+
+Examples:
+  cli doctor
+  cli doctor --context staging`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor()
+		},
+	}
+
+	return cmd
+}
+
+func runDoctor() error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	diagnostics, err := jobClient.GetDiagnostics(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get diagnostics: %v", err)
+	}
+
+	printDiagnostics(os.Stdout, diagnostics)
+	if !diagnostics.Ready {
+		return fmt.Errorf("the worker cannot run jobs until the failed checks are fixed")
+	}
+	return nil
+}
+
+// printDiagnostics prints each check with its status, and how to fix it
+// when it is not OK
+func printDiagnostics(out io.Writer, diagnostics *pb.Diagnostics) {
+	width := 0
+	for _, check := range diagnostics.Checks {
+		width = max(width, len(check.Name))
+	}
+
+	for _, check := range diagnostics.Checks {
+		fmt.Fprintf(out, "[%-4s] %-*s  %s\n", check.Status, width, check.Name, check.Detail)
+		if check.Fix != "" {
+			fmt.Fprintf(out, "       %-*s  fix: %s\n", width, "", check.Fix)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestPrintDiagnostics(t *testing.T) {
+	diagnostics := &pb.Diagnostics{Checks: []*pb.DiagnosticCheck{
+		{Name: "disk", Status: "OK", Detail: "40% used"},
+		{Name: "cgroup.base", Status: "FAIL", Detail: "permission denied", Fix: "Run the worker as root"},
+	}}
+
+	var out bytes.Buffer
+	printDiagnostics(&out, diagnostics)
+
+	want := "[OK  ] disk         40% used\n" +
+		"[FAIL] cgroup.base  permission denied\n" +
+		"                    fix: Run the worker as root\n"
+	if out.String() != want {
+		t.Errorf("unexpected output:\n%s", out.String())
+	}
+}
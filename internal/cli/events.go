@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+func newEventsCmd() *cobra.Command {
+	var types []string
+
+	cmd := &cobra.Command{
+		Use:   "events [job-id...]",
+		Short: "Watch job events as they happen",
+		Long: `Print job events as the worker publishes them, for the given jobs or for
+every job, until interrupted with Ctrl+C.
+
+Event types: job.created, job.updated, job.cleaned_up, job.stuck, job.crash_loop, job.idle,
+job.expired, job.privileged, job.limit_not_applied, job.output_limited, job.input_failed,
+worker.output_pressure
+
+Examples:
+  cli events
+  cli events 42 43
+  cli events --type=job.cleaned_up`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runEvents(args, types)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&types, "type", nil, "Only print events of this type (repeatable)")
+
+	return cmd
+}
+
+func runEvents(ids []string, types []string) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sigCh)
+	go func() {
+		<-sigCh
+		cancel()
+	}()
+
+	stream, err := jobClient.SubscribeJobEvents(ctx, ids, types)
+	if err != nil {
+		return err
+	}
+
+	for {
+		event, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) || ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("event stream failed: %v", err)
+		}
+		fmt.Println(formatEvent(event))
+	}
+}
+
+// formatEvent renders an event as one line: time, type, job and what changed
+func formatEvent(e *pb.JobEvent) string {
+	line := fmt.Sprintf("%s  %-15s job %s", e.Timestamp, e.Type, e.JobId)
+	if e.Status != "" {
+		line += "  " + e.Status
+	}
+	if e.Error != "" {
+		line += "  error: " + e.Error
+	}
+	return line
+}
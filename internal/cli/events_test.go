@@ -0,0 +1,28 @@
+package cli
+
+import (
+	"testing"
+	pb "worker/api/gen"
+)
+
+func TestFormatEvent(t *testing.T) {
+	tests := []struct {
+		event *pb.JobEvent
+		want  string
+	}{
+		{
+			&pb.JobEvent{Type: "job.updated", JobId: "42", Status: "RUNNING", Timestamp: "2026-01-02T03:04:05Z"},
+			"2026-01-02T03:04:05Z  job.updated     job 42  RUNNING",
+		},
+		{
+			&pb.JobEvent{Type: "job.cleaned_up", JobId: "42", Error: "busy", Timestamp: "2026-01-02T03:04:05Z"},
+			"2026-01-02T03:04:05Z  job.cleaned_up  job 42  error: busy",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := formatEvent(tt.event); got != tt.want {
+			t.Errorf("formatEvent() = %q, want %q", got, tt.want)
+		}
+	}
+}
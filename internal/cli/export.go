@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+type exportCmdParams struct {
+	file string
+}
+
+func newExportCmd() *cobra.Command {
+	params := &exportCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "export <job-id>",
+		Short: "Download a job's spec, history and output as a tarball",
+		Long: `Download a gzipped tarball of a job: its spec and latest status, the statuses
+it went through, every change recorded to it and its output. Attach it to an
+incident ticket or share it as a reproduction. Running jobs are exported as
+they are so far.
+
+Examples:
+  cli export 42
+  cli export 42 -f incident-1234.tar.gz
+  cli export 42 -f - | tar -tz`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runExport(args[0], params)
+		},
+	}
+
+	cmd.Flags().StringVarP(&params.file, "file", "f", "", "Write the tarball to this file, - for stdout (default job-<id>.tar.gz)")
+
+	return cmd
+}
+
+func runExport(jobID string, params *exportCmdParams) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	stream, err := jobClient.ExportJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+
+	// receive the first chunk before creating the file, so a failed export leaves none behind
+	chunk, err := stream.Recv()
+	if err != nil && err != io.EOF {
+		return fmt.Errorf("failed to export job: %v", err)
+	}
+
+	path := params.file
+	if path == "" {
+		path = fmt.Sprintf("job-%s.tar.gz", jobID)
+	}
+	out := os.Stdout
+	if path != "-" {
+		if out, err = os.Create(path); err != nil {
+			return fmt.Errorf("failed to create %s: %v", path, err)
+		}
+		defer out.Close()
+	}
+
+	var written int64
+	for chunk != nil {
+		n, err := out.Write(chunk.Payload)
+		written += int64(n)
+		if err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		if chunk, err = stream.Recv(); err != nil && err != io.EOF {
+			return fmt.Errorf("export interrupted: %v", err)
+		}
+	}
+
+	if path != "-" {
+		if err := out.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %v", path, err)
+		}
+		fmt.Printf("Exported job %s to %s (%d bytes)\n", jobID, path, written)
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"worker/pkg/client"
+	"worker/pkg/units"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+// groupFile is the YAML layout accepted by "group run"
+type groupFile struct {
+	Name   string     `yaml:"name"`
+	CPU    string     `yaml:"cpu"`    // shared by all the jobs, e.g. "2"
+	Memory string     `yaml:"memory"` // shared by all the jobs, e.g. "4Gi"
+	IO     string     `yaml:"io"`     // shared by all the jobs, e.g. "100MB/s"
+	Jobs   []jobEntry `yaml:"jobs"`
+}
+
+// toRequest converts the file to a request, without its jobs
+func (f groupFile) toRequest() (*pb.RunJobGroupReq, error) {
+	req := &pb.RunJobGroupReq{Name: f.Name}
+	var err error
+	if f.CPU != "" {
+		if req.CpuLimitMillis, err = units.ParseCPU(f.CPU); err != nil {
+			return nil, err
+		}
+	}
+	if f.Memory != "" {
+		if req.MemoryLimitBytes, err = units.ParseMemory(f.Memory); err != nil {
+			return nil, err
+		}
+	}
+	if f.IO != "" {
+		if req.IoLimitBPS, err = units.ParseIO(f.IO); err != nil {
+			return nil, err
+		}
+	}
+	return req, nil
+}
+
+func newGroupCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "group",
+		Short: "Start, inspect and stop sets of jobs as a unit",
+		Long: `Start related jobs together and manage them as one group.
+
+The group's cpu, memory and io limits are shared by its jobs, which run in
+one parent cgroup, on top of any limits of their own.
+
+Example group.yaml:
+  name: web-stack
+  cpu: "2"
+  memory: 4Gi
+  jobs:
+    - command: redis-server
+      restartPolicy: always
+    - command: python3
+      args: ["app.py"]
+      env:
+        REDIS_URL: "redis://127.0.0.1:6379"`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "run <file>",
+		Short: "Start the jobs described in a YAML file as a group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runGroup(args[0])
+		},
+	})
+	statusOutput := &outputFlags{}
+	statusCmd := &cobra.Command{
+		Use:   "status <group-id>",
+		Short: "Get the status of a group and its jobs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := statusOutput.validate(); err != nil {
+				return err
+			}
+			return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+				group, err := c.GetJobGroup(ctx, args[0])
+				if err != nil {
+					return fmt.Errorf("failed to get job group: %v", err)
+				}
+				if statusOutput.structured() {
+					return statusOutput.printMessage(group)
+				}
+				printGroup(group, true)
+				return nil
+			})
+		},
+	}
+	statusOutput.addFlags(statusCmd, false)
+	cmd.AddCommand(statusCmd)
+
+	listOutput := &outputFlags{}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List job groups",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := listOutput.validate(); err != nil {
+				return err
+			}
+			return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+				response, err := c.ListJobGroups(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to list job groups: %v", err)
+				}
+				switch {
+				case listOutput.structured():
+					return listOutput.printMessage(response)
+				case listOutput.quiet:
+					for _, group := range response.Groups {
+						fmt.Println(group.Id)
+					}
+					return nil
+				}
+				if len(response.Groups) == 0 {
+					fmt.Println("No job groups found")
+					return nil
+				}
+				for _, group := range response.Groups {
+					printGroup(group, listOutput.format == outputWide)
+				}
+				return nil
+			})
+		},
+	}
+	listOutput.addFlags(listCmd, true)
+	cmd.AddCommand(listCmd)
+	cmd.AddCommand(&cobra.Command{
+		Use:   "stop <group-id>",
+		Short: "Stop every running job of a group",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+				response, err := c.StopJobGroup(ctx, args[0])
+				if err != nil {
+					return fmt.Errorf("failed to stop job group: %v", err)
+				}
+				printGroup(response.Group, true)
+				printJobErrors(response.Errors)
+				return nil
+			})
+		},
+	})
+
+	return cmd
+}
+
+func runGroup(path string) error {
+	var file groupFile
+	if err := readYAMLFile(path, &file); err != nil {
+		return err
+	}
+
+	req, err := file.toRequest()
+	if err != nil {
+		return err
+	}
+	for i, job := range file.Jobs {
+		jobReq, err := job.toRequest()
+		if err != nil {
+			return fmt.Errorf("job %d: %v", i+1, err)
+		}
+		req.Jobs = append(req.Jobs, jobReq)
+	}
+
+	return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+		group, err := c.RunJobGroup(ctx, req)
+		if err != nil {
+			return fmt.Errorf("failed to run job group: %v", err)
+		}
+		fmt.Printf("Job group started:\n")
+		printGroup(group, true)
+		return nil
+	})
+}
+
+func withGroupClient(fn func(ctx context.Context, c *client.JobClient) error) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return fn(ctx, jobClient)
+}
+
+func printGroup(group *pb.JobGroup, withJobs bool) {
+	fmt.Printf("%s %s %s running=%d succeeded=%d failed=%d stopped=%d\n",
+		group.Id, group.Name, group.Status, group.Running, group.Succeeded, group.Failed, group.Stopped)
+	if limits := formatGroupLimits(group); limits != "" {
+		fmt.Printf("  Shared limits: %s\n", limits)
+	}
+
+	if !withJobs {
+		return
+	}
+	for _, job := range group.Jobs {
+		fmt.Printf("  %s %s Command: %s %s\n", job.Id, job.Status, job.Command, strings.Join(job.Args, " "))
+	}
+}
+
+// formatGroupLimits describes the limits shared by the jobs of a group, "" when it has none
+func formatGroupLimits(group *pb.JobGroup) string {
+	var limits []string
+	if group.CpuLimitMillis > 0 {
+		limits = append(limits, "cpu="+units.FormatCPU(group.CpuLimitMillis))
+	}
+	if group.MemoryLimitBytes > 0 {
+		limits = append(limits, "memory="+units.FormatBytes(group.MemoryLimitBytes))
+	}
+	if group.IoLimitBPS > 0 {
+		limits = append(limits, "io="+units.FormatBytes(group.IoLimitBPS)+"/s")
+	}
+	return strings.Join(limits, " ")
+}
+
+// printJobErrors prints per-job failures in job ID order
+func printJobErrors(errors map[string]string) {
+	if len(errors) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(errors))
+	for id := range errors {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	fmt.Printf("Errors:\n")
+	for _, id := range ids {
+		fmt.Printf("  %s: %s\n", id, errors[id])
+	}
+}
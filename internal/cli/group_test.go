@@ -0,0 +1,32 @@
+package cli
+
+import (
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestGroupFileToRequest(t *testing.T) {
+	req, err := groupFile{Name: "web", CPU: "2", Memory: "4Gi", IO: "100MB/s"}.toRequest()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.CpuLimitMillis != 2000 || req.MemoryLimitBytes != 4<<30 || req.IoLimitBPS != 100_000_000 {
+		t.Errorf("unexpected limits cpu=%d memory=%d io=%d", req.CpuLimitMillis, req.MemoryLimitBytes, req.IoLimitBPS)
+	}
+
+	if _, err := (groupFile{Name: "web", Memory: "lots"}).toRequest(); err == nil {
+		t.Error("expected an error for an invalid memory limit")
+	}
+}
+
+func TestFormatGroupLimits(t *testing.T) {
+	if got := formatGroupLimits(&pb.JobGroup{}); got != "" {
+		t.Errorf("expected no limits, got %q", got)
+	}
+
+	got := formatGroupLimits(&pb.JobGroup{CpuLimitMillis: 1500, MemoryLimitBytes: 1 << 30})
+	if want := "cpu=1.5 memory=1.0Gi"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
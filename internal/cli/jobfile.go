@@ -0,0 +1,329 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"time"
+	"worker/pkg/client"
+	"worker/pkg/units"
+
+	"gopkg.in/yaml.v3"
+	pb "worker/api/gen"
+)
+
+// jobEntry is the YAML layout of a single job in job, pipeline and group files
+type jobEntry struct {
+	Command       string            `yaml:"command"`
+	Args          []string          `yaml:"args"`
+	MaxCPU        int64             `yaml:"maxCPU"`    // percent of one core
+	MaxMemory     int64             `yaml:"maxMemory"` // MiB
+	MaxIOBPS      int64             `yaml:"maxIOBPS"`
+	CPU           string            `yaml:"cpu"`     // e.g. "1.5", overrides maxCPU
+	Memory        string            `yaml:"memory"`  // e.g. "2Gi", overrides maxMemory
+	IO            string            `yaml:"io"`      // e.g. "50MB/s", overrides maxIOBPS
+	Profile       string            `yaml:"profile"` // limit profile from the server config, limits above override it
+	Env           map[string]string `yaml:"env"`
+	SecretEnv     map[string]string `yaml:"secretEnv"`
+	RestartPolicy string            `yaml:"restartPolicy"`
+	MaxRestarts   int32             `yaml:"maxRestarts"`
+	Labels        map[string]string `yaml:"labels"`
+	HealthProbe   *probeEntry       `yaml:"healthProbe"`
+	Shell         bool              `yaml:"shell"`      // run command as a script with sh -c
+	Isolation     string            `yaml:"isolation"`  // "full", "cgroups", "process" or "privileged", "" for the server default
+	QoS           string            `yaml:"qos"`        // "guaranteed", "burstable" or "best-effort", "" for burstable
+	Seccomp       string            `yaml:"seccomp"`    // "audit" to log the job's syscalls, "" for no filter
+	Runtime       string            `yaml:"runtime"`    // sandbox runtime from the server config to run the job through
+	TimeOffset    time.Duration     `yaml:"timeOffset"` // shift of the job's monotonic and boottime clocks
+	InheritEnv    *bool             `yaml:"inheritEnv"` // false for only env, secretEnv and the JOB_* variables, unset inherits
+
+	CommandSha256 string        `yaml:"commandSha256"` // hex SHA-256 digest the command's executable must have
+	MaxIdle       time.Duration `yaml:"maxIdle"`       // no output and no CPU use this long makes the job idle
+	IdleAction    string        `yaml:"idleAction"`    // "stop" (default) or "mark"
+
+	OutputFlush       *time.Duration `yaml:"outputFlush"`       // how long output may be held to be sent in larger chunks, 0s for none, unset for the server's
+	OutputRate        string         `yaml:"outputRate"`        // e.g. "64KB/s", the rate stdout and stderr together are kept at
+	OutputLimitAction string         `yaml:"outputLimitAction"` // "throttle" or "truncate", "" for the server's
+
+	Inputs  []inputEntry  `yaml:"inputs"`  // object storage files streamed in as the job reads them
+	Sockets []socketEntry `yaml:"sockets"` // listening sockets the worker passes in, in descriptor order
+
+	AllowedWindows []string      `yaml:"allowedWindows"` // e.g. "Mon-Fri 18:00-06:00", times the job may start in
+	QueueTimeout   time.Duration `yaml:"queueTimeout"`   // the job expires instead of starting later than this after submission
+}
+
+// socketEntry is the YAML layout of a job socket
+type socketEntry struct {
+	Name    string `yaml:"name"`    // name in LISTEN_FDNAMES
+	Address string `yaml:"address"` // tcp://HOST:PORT or unix://NAME
+}
+
+// inputEntry is the YAML layout of a job input
+type inputEntry struct {
+	Source string `yaml:"source"` // s3://bucket/key or gs://bucket/key
+	Path   string `yaml:"path"`   // destination in the workspace, defaults to the object name
+}
+
+// probeEntry is the YAML layout of a liveness probe
+type probeEntry struct {
+	Type             string        `yaml:"type"`
+	Command          []string      `yaml:"command"`
+	Port             int32         `yaml:"port"`
+	Path             string        `yaml:"path"`
+	Interval         time.Duration `yaml:"interval"`
+	Timeout          time.Duration `yaml:"timeout"`
+	InitialDelay     time.Duration `yaml:"initialDelay"`
+	FailureThreshold int32         `yaml:"failureThreshold"`
+}
+
+func (e jobEntry) toRequest() (*pb.RunJobReq, error) {
+	req := &pb.RunJobReq{
+		Command:           e.Command,
+		Args:              e.Args,
+		Env:               e.Env,
+		SecretEnv:         e.SecretEnv,
+		RestartPolicy:     e.RestartPolicy,
+		MaxRestarts:       e.MaxRestarts,
+		Labels:            e.Labels,
+		Shell:             e.Shell,
+		Profile:           e.Profile,
+		Isolation:         e.Isolation,
+		Qos:               e.QoS,
+		Seccomp:           e.Seccomp,
+		Runtime:           e.Runtime,
+		TimeOffsetSeconds: int64(e.TimeOffset / time.Second),
+		InheritEnv:        e.InheritEnv,
+		CommandSha256:     e.CommandSha256,
+		MaxIdleSeconds:    int64(e.MaxIdle / time.Second),
+		IdleAction:        e.IdleAction,
+		OutputLimitAction: e.OutputLimitAction,
+		AllowedWindows:    e.AllowedWindows,
+	}
+	if e.OutputFlush != nil {
+		req.OutputFlushMillis = outputFlushMillis(*e.OutputFlush)
+	}
+	req.QueueTimeoutSeconds = int64(e.QueueTimeout / time.Second)
+	for _, input := range e.Inputs {
+		req.Inputs = append(req.Inputs, jobInput(input.Source, input.Path))
+	}
+	for _, socket := range e.Sockets {
+		req.Sockets = append(req.Sockets, &pb.JobSocket{Name: socket.Name, Address: socket.Address})
+	}
+
+	if p := e.HealthProbe; p != nil {
+		req.HealthProbe = &pb.HealthProbe{
+			Type:                p.Type,
+			Command:             p.Command,
+			Port:                p.Port,
+			Path:                p.Path,
+			IntervalSeconds:     int32(p.Interval / time.Second),
+			TimeoutSeconds:      int32(p.Timeout / time.Second),
+			InitialDelaySeconds: int32(p.InitialDelay / time.Second),
+			FailureThreshold:    p.FailureThreshold,
+		}
+	}
+
+	cpuMillis, memoryBytes, ioBPS := e.MaxCPU*10, e.MaxMemory<<20, e.MaxIOBPS
+	var err error
+	if e.CPU != "" {
+		if cpuMillis, err = units.ParseCPU(e.CPU); err != nil {
+			return nil, err
+		}
+	}
+	if e.Memory != "" {
+		if memoryBytes, err = units.ParseMemory(e.Memory); err != nil {
+			return nil, err
+		}
+	}
+	if e.IO != "" {
+		if ioBPS, err = units.ParseIO(e.IO); err != nil {
+			return nil, err
+		}
+	}
+	if e.OutputRate != "" {
+		if req.OutputBytesPerSecond, err = units.ParseIO(e.OutputRate); err != nil {
+			return nil, fmt.Errorf("outputRate: %v", err)
+		}
+	}
+	setLimits(req, cpuMillis, memoryBytes, ioBPS)
+
+	return req, nil
+}
+
+// setLimits sets the non-zero limits on req. The deprecated int32 fields are
+// filled in too, so servers that predate the int64 ones still apply them.
+func setLimits(req *pb.RunJobReq, cpuMillis, memoryBytes, ioBPS int64) {
+	if cpuMillis > 0 {
+		req.CpuLimitMillis = cpuMillis
+		req.MaxCPU = legacyLimit((cpuMillis + 9) / 10)
+	}
+	if memoryBytes > 0 {
+		req.MemoryLimitBytes = memoryBytes
+		req.MaxMemory = legacyLimit((memoryBytes + 1<<20 - 1) >> 20)
+	}
+	if ioBPS > 0 {
+		req.IoLimitBPS = ioBPS
+		req.MaxIOBPS = legacyLimit(ioBPS)
+	}
+}
+
+func legacyLimit(n int64) int32 {
+	return int32(min(n, math.MaxInt32))
+}
+
+// jobFile is the YAML layout accepted by "run -f" and "apply -f". Relative
+// paths are resolved against the directory of the file.
+//
+//	command: python3
+//	args: ["app.py"]
+//	memory: 512Mi
+//	envFile: .env
+//	files:
+//	  - local: app.py
+//	  - local: data/input.csv
+//	    path: input/data.csv
+//	restartPolicy: on-failure
+//	maxRestarts: 3
+type jobFile struct {
+	jobEntry `yaml:",inline"`
+
+	EnvFile string `yaml:"envFile"`
+	Files   []struct {
+		Local string `yaml:"local"`
+		Path  string `yaml:"path"` // destination in the workspace, defaults to the file name
+	} `yaml:"files"`
+
+	dir string // directory of the file the spec was read from
+}
+
+// request builds the run request and the files to upload for the spec
+func (f *jobFile) request() (*pb.RunJobReq, []client.UploadFile, error) {
+	req, err := f.toRequest()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if f.EnvFile != "" {
+		data, err := os.ReadFile(f.resolve(f.EnvFile))
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read env file: %v", err)
+		}
+		req.EnvFile = data
+	}
+
+	var files []client.UploadFile
+	for _, file := range f.Files {
+		if file.Local == "" {
+			return nil, nil, fmt.Errorf("file entry without local path")
+		}
+		dest := file.Path
+		if dest == "" {
+			dest = filepath.Base(file.Local)
+		}
+		files = append(files, client.UploadFile{LocalPath: f.resolve(file.Local), Path: dest})
+	}
+
+	return req, files, nil
+}
+
+func (f *jobFile) resolve(path string) string {
+	if filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(f.dir, path)
+}
+
+// loadJobFiles reads every job spec in path. A directory contributes each of
+// its .yaml and .yml files in name order; a file may hold several specs
+// separated by "---".
+func loadJobFiles(path string) ([]*jobFile, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := []string{path}
+	if info.IsDir() {
+		paths = nil
+		for _, pattern := range []string{"*.yaml", "*.yml"} {
+			matches, err := filepath.Glob(filepath.Join(path, pattern))
+			if err != nil {
+				return nil, err
+			}
+			paths = append(paths, matches...)
+		}
+		sort.Strings(paths)
+	}
+
+	var specs []*jobFile
+	for _, p := range paths {
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %v", p, err)
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		for i := 1; ; i++ {
+			spec := &jobFile{dir: filepath.Dir(p)}
+			if err := decoder.Decode(spec); err != nil {
+				if errors.Is(err, io.EOF) {
+					break
+				}
+				return nil, fmt.Errorf("failed to parse %s (spec %d): %v", p, i, err)
+			}
+			if spec.Command == "" {
+				return nil, fmt.Errorf("%s (spec %d): command is required", p, i)
+			}
+			// catch malformed values before any job of the set is started
+			if _, err := spec.toRequest(); err != nil {
+				return nil, fmt.Errorf("%s (spec %d): %v", p, i, err)
+			}
+			specs = append(specs, spec)
+		}
+	}
+
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("no job specs found in %s", path)
+	}
+	return specs, nil
+}
+
+// readYAMLFile reads and parses a YAML file into out, rejecting unknown fields
+func readYAMLFile(path string, out interface{}) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %v", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil {
+		return fmt.Errorf("failed to parse %s: %v", path, err)
+	}
+	return nil
+}
+
+// outputFlushMillis converts an output flush interval to RunJobReq's
+// outputFlushMillis, where -1 stores every write as it comes
+func outputFlushMillis(interval time.Duration) int64 {
+	if interval <= 0 {
+		return -1
+	}
+	return max(int64(interval/time.Millisecond), 1)
+}
+
+// jobInput builds a job input, placing the object at its name in the
+// workspace when no path is given
+func jobInput(source, dest string) *pb.JobInput {
+	if dest == "" {
+		dest = path.Base(source)
+	}
+	return &pb.JobInput{Source: source, Path: dest}
+}
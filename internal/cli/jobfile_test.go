@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadJobFiles(t *testing.T) {
+	dir := t.TempDir()
+
+	writeFile := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writeFile("a.yaml", `
+command: python3
+args: ["app.py"]
+maxMemory: 512
+envFile: app.env
+files:
+  - local: app.py
+  - local: data/in.csv
+    path: input/in.csv
+inputs:
+  - source: s3://datasets/train.csv
+  - source: s3://datasets/2024/test.csv
+    path: input/test.csv
+sockets:
+  - name: http
+    address: tcp://:8080
+healthProbe:
+  type: http
+  port: 8080
+  interval: 30s
+---
+command: echo "$1" | tr a-z A-Z
+args: ["second"]
+shell: true
+profile: small
+isolation: process
+outputFlush: 0s
+allowedWindows: ["weekends 00:00-24:00", "Mon-Fri 18:00-06:00 UTC"]
+queueTimeout: 4h
+`)
+	writeFile("b.yml", "command: make\nrestartPolicy: on-failure\ncpu: \"1.5\"\nmemory: 2Gi\noutputRate: 64KB/s\n")
+	writeFile("app.env", "APP_ENV=prod\n")
+	writeFile("notes.txt", "not a spec")
+
+	specs, err := loadJobFiles(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(specs) != 3 {
+		t.Fatalf("expected 3 specs, got %d", len(specs))
+	}
+
+	req, files, err := specs[0].request()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Command != "python3" || req.MemoryLimitBytes != 512<<20 || string(req.EnvFile) != "APP_ENV=prod\n" {
+		t.Errorf("unexpected request %+v", req)
+	}
+	if req.HealthProbe == nil || req.HealthProbe.IntervalSeconds != int32(30*time.Second/time.Second) {
+		t.Errorf("unexpected health probe %+v", req.HealthProbe)
+	}
+	if len(files) != 2 || files[0].LocalPath != filepath.Join(dir, "app.py") || files[0].Path != "app.py" || files[1].Path != "input/in.csv" {
+		t.Errorf("unexpected files %+v", files)
+	}
+	if len(req.Inputs) != 2 || req.Inputs[0].Path != "train.csv" || req.Inputs[1].Source != "s3://datasets/2024/test.csv" || req.Inputs[1].Path != "input/test.csv" {
+		t.Errorf("unexpected inputs %+v", req.Inputs)
+	}
+	if len(req.Sockets) != 1 || req.Sockets[0].Name != "http" || req.Sockets[0].Address != "tcp://:8080" {
+		t.Errorf("unexpected sockets %+v", req.Sockets)
+	}
+
+	if second, _, err := specs[1].request(); err != nil || len(second.AllowedWindows) != 2 || second.AllowedWindows[1] != "Mon-Fri 18:00-06:00 UTC" || second.QueueTimeoutSeconds != 4*3600 {
+		t.Errorf("unexpected allowed windows %v and queue timeout %d, %v", second.GetAllowedWindows(), second.GetQueueTimeoutSeconds(), err)
+	}
+
+	if specs[1].Command != `echo "$1" | tr a-z A-Z` || specs[2].RestartPolicy != "on-failure" {
+		t.Errorf("unexpected spec order: %s, %s", specs[1].Command, specs[2].Command)
+	}
+
+	if req, _, err = specs[1].request(); err != nil || !req.Shell || req.Profile != "small" || req.Isolation != "process" {
+		t.Errorf("expected shell mode, profile and isolation to be requested, got %+v, %v", req, err)
+	}
+	if req.OutputFlushMillis != -1 {
+		t.Errorf("expected outputFlush 0s to store every write, got %d", req.OutputFlushMillis)
+	}
+
+	req, _, err = specs[2].request()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.CpuLimitMillis != 1500 || req.MemoryLimitBytes != 2<<30 || req.MaxCPU != 150 || req.MaxMemory != 2048 {
+		t.Errorf("expected resource units to be converted, got cpu=%d memory=%d", req.CpuLimitMillis, req.MemoryLimitBytes)
+	}
+	if req.OutputFlushMillis != 0 {
+		t.Errorf("expected no outputFlush to use the server's interval, got %d", req.OutputFlushMillis)
+	}
+	if req.OutputBytesPerSecond != 64000 {
+		t.Errorf("expected outputRate 64KB/s to be 64000 bytes per second, got %d", req.OutputBytesPerSecond)
+	}
+}
+
+func TestLoadJobFilesRejectsInvalidSpecs(t *testing.T) {
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		"unknown.yaml":   "command: echo\nretries: 3\n",
+		"nocommand.yaml": "args: [\"x\"]\n",
+		"badunits.yaml":  "command: echo\nmemory: lots\n",
+	} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadJobFiles(path); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+
+	if _, err := loadJobFiles(t.TempDir()); err == nil {
+		t.Error("expected error for directory without specs")
+	}
+}
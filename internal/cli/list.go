@@ -3,25 +3,44 @@ package cli
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
+	"text/tabwriter"
 	"time"
-	"worker/pkg/client"
+	"worker/pkg/units"
 
 	"github.com/spf13/cobra"
+	pb "worker/api/gen"
 )
 
 func newListCmd() *cobra.Command {
+	output := &outputFlags{}
+
 	cmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all jobs",
-		RunE:  runList,
+		Long: `List all jobs.
+
+Examples:
+  cli list
+  cli list -o wide
+  cli list -o json
+  cli list -q | xargs cli stop`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(output)
+		},
 	}
+	output.addFlags(cmd, true)
 
 	return cmd
 }
 
-func runList(cmd *cobra.Command, args []string) error {
-	jobClient, err := client.NewJobClient(cfg.ServerAddr)
+func runList(output *outputFlags) error {
+	if err := output.validate(); err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
 	if err != nil {
 		return err
 	}
@@ -35,11 +54,26 @@ func runList(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to list jobs: %v", err)
 	}
 
+	switch {
+	case output.structured():
+		return output.printMessage(response)
+	case output.quiet:
+		for _, job := range response.Jobs {
+			fmt.Println(job.Id)
+		}
+		return nil
+	}
+
 	if len(response.Jobs) == 0 {
 		fmt.Println("No jobs found")
 		return nil
 	}
 
+	if output.format == outputWide {
+		printJobTable(response.Jobs)
+		return nil
+	}
+
 	for _, job := range response.Jobs {
 		fmt.Printf("%s %s StartTime: %s Command: %s %s\n",
 			job.Id, job.Status, job.StartTime, job.Command, strings.Join(job.Args, " "))
@@ -47,3 +81,24 @@ func runList(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func printJobTable(jobs []*pb.Job) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tSTATUS\tEXIT\tSTART\tEND\tCPU\tMEMORY\tIO/S\tRESTARTS\tHEALTH\tGROUP\tLABELS\tCOMMAND")
+	for _, job := range jobs {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\t%s\t%s\t%s\t%d\t%s\t%s\t%s\t%s\n",
+			job.Id, job.Status, job.ExitCode, job.StartTime, orDash(job.EndTime),
+			formatLimit(job.CpuLimitMillis, units.FormatCPU), formatLimit(job.MemoryLimitBytes, units.FormatBytes),
+			formatLimit(job.IoLimitBPS, units.FormatBytes), job.Restarts, orDash(job.Health),
+			orDash(job.GroupId), formatLabels(job.Labels),
+			strings.Join(append([]string{job.Command}, job.Args...), " "))
+	}
+	w.Flush()
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
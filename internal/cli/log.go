@@ -5,35 +5,70 @@ import (
 	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
-	"google.golang.org/grpc/status"
 	"io"
 	"os"
 	"os/signal"
+	"sort"
+	"strings"
+	"sync"
 	"syscall"
+	"time"
 	"worker/pkg/client"
 )
 
 func newLogCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "log <job-id>",
-		Short: "Stream job logs",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runLog,
+		Use:     "log [job-id...]",
+		Aliases: []string{"logs"},
+		Short:   "Stream job logs",
+		Long: `Stream the logs of one or more jobs. With several jobs, or jobs selected by
+label, group or pipeline, lines are interleaved and prefixed with the job
+(or pipeline step) they came from.
+
+Examples:
+  cli log 42
+  cli logs 42 43 44
+  cli logs --label=team=ci
+  cli logs --group=3
+  cli logs --pipeline=7`,
+		RunE: runLog,
 	}
 
 	cmd.Flags().BoolVarP(&logParams.follow, "follow", "f", true, "Follow the log stream (can be terminated with Ctrl+C)")
+	cmd.Flags().StringArrayVar(&logParams.labels, "label", nil, "Stream jobs with label KEY=VALUE (repeatable)")
+	cmd.Flags().StringVar(&logParams.group, "group", "", "Stream the jobs of a job group")
+	cmd.Flags().StringVar(&logParams.pipeline, "pipeline", "", "Stream the steps of a pipeline")
+	cmd.Flags().BoolVar(&logParams.noColor, "no-color", false, "Do not color the line prefixes")
 
 	return cmd
 }
 
 type logCmdParams struct {
-	follow bool
+	follow   bool
+	labels   []string
+	group    string
+	pipeline string
+	noColor  bool
 }
 
 var logParams = &logCmdParams{}
 
+// logSource is one job whose logs are streamed, with the prefix for its lines
+type logSource struct {
+	jobID string
+	name  string
+}
+
 func runLog(cmd *cobra.Command, args []string) error {
-	jobID := args[0]
+	selectors := 0
+	for _, set := range []bool{len(args) > 0, len(logParams.labels) > 0, logParams.group != "", logParams.pipeline != ""} {
+		if set {
+			selectors++
+		}
+	}
+	if selectors != 1 {
+		return fmt.Errorf("specify job ids or one of --label, --group, --pipeline")
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -47,37 +82,217 @@ func runLog(cmd *cobra.Command, args []string) error {
 		cancel()
 	}()
 
-	jobClient, err := client.NewJobClient(cfg.ServerAddr)
+	jobClient, err := newJobClient()
 	if err != nil {
 		return err
 	}
 	defer jobClient.Close()
 
-	stream, err := jobClient.GetJobLogs(ctx, jobID)
+	if len(args) == 1 {
+		return streamSingleLog(ctx, jobClient, args[0])
+	}
+
+	sources, err := resolveLogSources(jobClient, args)
 	if err != nil {
-		return fmt.Errorf("failed to start log stream: %v", err)
+		return err
+	}
+	if len(sources) == 0 {
+		return fmt.Errorf("no matching jobs")
 	}
 
+	return streamMultipleLogs(ctx, jobClient, sources, !logParams.noColor && isTerminal(os.Stdout))
+}
+
+func streamSingleLog(ctx context.Context, jobClient *client.JobClient, jobID string) error {
 	fmt.Printf("Logs for job %s (Press Ctrl+C to exit if streaming):\n", jobID)
 
-	for {
-		chunk, e := stream.Recv()
-		if e == io.EOF {
-			return nil // Clean exit at end of stream
+	if err := jobClient.FollowJobLogs(ctx, jobID, os.Stdout); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// This is an expected error due to our cancellation
+			return nil
+		}
+		return fmt.Errorf("log stream error: %v", err)
+	}
+	return nil
+}
+
+// resolveLogSources turns the job IDs or selector flags into the jobs to stream
+func resolveLogSources(jobClient *client.JobClient, ids []string) ([]logSource, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var sources []logSource
+	switch {
+	case len(ids) > 0:
+		for _, id := range ids {
+			sources = append(sources, logSource{jobID: id, name: id})
+		}
+
+	case logParams.group != "":
+		group, err := jobClient.GetJobGroup(ctx, logParams.group)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get job group: %v", err)
+		}
+		for _, job := range group.Jobs {
+			sources = append(sources, logSource{jobID: job.Id, name: job.Id})
+		}
+
+	case logParams.pipeline != "":
+		p, err := jobClient.GetPipelineStatus(ctx, logParams.pipeline)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pipeline status: %v", err)
+		}
+		// steps that have not started yet have no logs to stream
+		for _, step := range p.Steps {
+			if step.JobId != "" {
+				sources = append(sources, logSource{jobID: step.JobId, name: step.Name})
+			}
+		}
+
+	default:
+		labels := make(map[string]string)
+		for _, label := range logParams.labels {
+			key, value, found := strings.Cut(label, "=")
+			if !found || key == "" {
+				return nil, fmt.Errorf("invalid --label value %q, expected KEY=VALUE", label)
+			}
+			labels[key] = value
 		}
-		if e != nil {
-			if errors.Is(ctx.Err(), context.Canceled) {
-				// This is an expected error due to our cancellation
-				return nil
+
+		response, err := jobClient.ListJobs(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list jobs: %v", err)
+		}
+		for _, job := range response.Jobs {
+			if hasLabels(job.Labels, labels) {
+				sources = append(sources, logSource{jobID: job.Id, name: job.Id})
 			}
+		}
+		// job IDs are sequence numbers, shorter ones are older
+		sort.Slice(sources, func(i, j int) bool {
+			a, b := sources[i].jobID, sources[j].jobID
+			return len(a) < len(b) || len(a) == len(b) && a < b
+		})
+	}
 
-			if s, ok := status.FromError(e); ok {
-				return fmt.Errorf("log stream error: %v", s.Message())
+	return sources, nil
+}
+
+func hasLabels(labels, want map[string]string) bool {
+	for k, v := range want {
+		if value, ok := labels[k]; !ok || value != v {
+			return false
+		}
+	}
+	return true
+}
+
+// streamMultipleLogs streams every source at once, writing whole lines with
+// the source's prefix so lines of different jobs never mix
+func streamMultipleLogs(ctx context.Context, jobClient *client.JobClient, sources []logSource, color bool) error {
+	width := 0
+	for _, source := range sources {
+		width = max(width, len(source.name))
+	}
+
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	failed := make(chan error, len(sources))
+
+	for i, source := range sources {
+		prefix := fmt.Sprintf("%-*s | ", width, source.name)
+		if color {
+			prefix = logColors[i%len(logColors)] + prefix + resetVideo
+		}
+		out := newPrefixWriter(os.Stdout, &mutex, prefix)
+
+		wg.Add(1)
+		go func(source logSource) {
+			defer wg.Done()
+			defer out.Flush()
+
+			if err := jobClient.FollowJobLogs(ctx, source.jobID, out); err != nil && ctx.Err() == nil {
+				out.Flush()
+				mutex.Lock()
+				fmt.Fprintf(os.Stderr, "%s: %v\n", source.name, err)
+				mutex.Unlock()
+				failed <- err
 			}
+		}(source)
+	}
+
+	wg.Wait()
+	close(failed)
+
+	if n := len(failed); n > 0 {
+		return fmt.Errorf("%d of %d log streams failed", n, len(sources))
+	}
+	return nil
+}
+
+// logColors are the ANSI colors cycled through for line prefixes
+var logColors = []string{
+	"\x1b[36m", // cyan
+	"\x1b[33m", // yellow
+	"\x1b[32m", // green
+	"\x1b[35m", // magenta
+	"\x1b[34m", // blue
+	"\x1b[31m", // red
+}
+
+// prefixWriter writes complete lines to out, each starting with prefix. A
+// partial line is held back until it is completed or Flush is called. Writers
+// sharing a mutex never interleave within a line.
+type prefixWriter struct {
+	out     io.Writer
+	mutex   *sync.Mutex
+	prefix  string
+	partial []byte
+}
+
+func newPrefixWriter(out io.Writer, mutex *sync.Mutex, prefix string) *prefixWriter {
+	return &prefixWriter{out: out, mutex: mutex, prefix: prefix}
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	data := append(w.partial, p...)
+	end := strings.LastIndexByte(string(data), '\n')
+	if end < 0 {
+		w.partial = data
+		return len(p), nil
+	}
 
-			return fmt.Errorf("error receiving log stream: %v", e)
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(string(data[:end+1]), "\n") {
+		if line != "" {
+			b.WriteString(w.prefix)
+			b.WriteString(line)
 		}
+	}
+	w.partial = append([]byte(nil), data[end+1:]...)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	if _, err := io.WriteString(w.out, b.String()); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
 
-		fmt.Printf("%s", chunk.Payload)
+// Flush writes a pending partial line, ending it with a newline
+func (w *prefixWriter) Flush() {
+	if len(w.partial) == 0 {
+		return
 	}
+	line := w.prefix + string(w.partial) + "\n"
+	w.partial = nil
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	_, _ = io.WriteString(w.out, line)
+}
+
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
 }
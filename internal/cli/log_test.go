@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+)
+
+func TestPrefixWriter(t *testing.T) {
+	var out bytes.Buffer
+	var mutex sync.Mutex
+	a := newPrefixWriter(&out, &mutex, "a | ")
+	b := newPrefixWriter(&out, &mutex, "b | ")
+
+	a.Write([]byte("one\ntw"))
+	b.Write([]byte("first\n"))
+	a.Write([]byte("o\nthree\n"))
+	b.Write([]byte("no newline"))
+	a.Flush()
+	b.Flush()
+
+	want := "a | one\nb | first\na | two\na | three\nb | no newline\n"
+	if out.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out.String(), want)
+	}
+}
+
+func TestHasLabels(t *testing.T) {
+	labels := map[string]string{"team": "ci", "build": "42"}
+
+	if !hasLabels(labels, map[string]string{"team": "ci"}) {
+		t.Error("expected a match on a subset of labels")
+	}
+	if hasLabels(labels, map[string]string{"team": "web"}) {
+		t.Error("expected no match on a different value")
+	}
+	if hasLabels(nil, map[string]string{"team": "ci"}) {
+		t.Error("expected no match without labels")
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputWide = "wide"
+)
+
+// outputFlags holds the -o and -q flags of commands that print jobs
+type outputFlags struct {
+	format string
+	quiet  bool
+}
+
+func (o *outputFlags) addFlags(cmd *cobra.Command, quiet bool) {
+	cmd.Flags().StringVarP(&o.format, "output", "o", "", "Output format: json, yaml or wide")
+	if quiet {
+		cmd.Flags().BoolVarP(&o.quiet, "quiet", "q", false, "Only print IDs")
+	}
+}
+
+func (o *outputFlags) validate() error {
+	switch o.format {
+	case "", outputJSON, outputYAML, outputWide:
+	default:
+		return fmt.Errorf("invalid output format %q, expected json, yaml or wide", o.format)
+	}
+	if o.quiet && o.format != "" {
+		return fmt.Errorf("--quiet cannot be combined with --output")
+	}
+	return nil
+}
+
+// structured reports whether the output is JSON or YAML
+func (o *outputFlags) structured() bool {
+	return o.format == outputJSON || o.format == outputYAML
+}
+
+// printMessage writes msg as JSON or YAML. Field names follow the API, and
+// unset fields are included so scripts can rely on every key being present.
+func (o *outputFlags) printMessage(msg proto.Message) error {
+	data, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to encode output: %v", err)
+	}
+
+	if o.format == outputJSON {
+		fmt.Println(string(data))
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("failed to encode output: %v", err)
+	}
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	defer encoder.Close()
+	return encoder.Encode(value)
+}
+
+// formatLabels renders labels as k=v pairs sorted by key, "-" if there are none
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+
+	pairs := make([]string, 0, len(labels))
+	for k, v := range labels {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,37 @@
+package cli
+
+import "testing"
+
+func TestOutputFlagsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		flags   outputFlags
+		wantErr bool
+	}{
+		{"default", outputFlags{}, false},
+		{"json", outputFlags{format: "json"}, false},
+		{"yaml", outputFlags{format: "yaml"}, false},
+		{"wide", outputFlags{format: "wide"}, false},
+		{"quiet", outputFlags{quiet: true}, false},
+		{"unknown format", outputFlags{format: "xml"}, true},
+		{"quiet with output", outputFlags{format: "json", quiet: true}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.flags.validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	if got := formatLabels(nil); got != "-" {
+		t.Errorf("formatLabels(nil) = %q, want -", got)
+	}
+	if got := formatLabels(map[string]string{"team": "ci", "env": "prod"}); got != "env=prod,team=ci" {
+		t.Errorf("formatLabels() = %q", got)
+	}
+}
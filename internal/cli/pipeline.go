@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+// pipelineFile is the YAML layout accepted by "pipeline run"
+type pipelineFile struct {
+	Steps []struct {
+		Name     string `yaml:"name"`
+		jobEntry `yaml:",inline"`
+
+		DependsOn []string `yaml:"dependsOn"`
+		Inputs    []struct {
+			Step   string `yaml:"step"`
+			Source string `yaml:"source"`
+			Path   string `yaml:"path"`
+		} `yaml:"inputs"`
+	} `yaml:"steps"`
+}
+
+func newPipelineCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pipeline",
+		Short: "Run jobs chained by their output",
+		Long: `Run a set of jobs in dependency order, feeding the stdout or workspace
+files of upstream steps into downstream workspaces.
+
+Example pipeline.yaml:
+  steps:
+    - name: build
+      command: make
+      args: ["dist"]
+    - name: test
+      command: sh
+      args: ["-c", "ls inputs/build && wc -l inputs/build.stdout"]
+      inputs:
+        - step: build
+          source: artifacts   # files under inputs/build/
+        - step: build
+          source: stdout      # inputs/build.stdout
+    - name: notify
+      command: echo
+      args: ["done"]
+      dependsOn: ["test"]`,
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "run <file>",
+		Short: "Start a pipeline described in a YAML file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPipeline(args[0])
+		},
+	})
+	output := &outputFlags{}
+	statusCmd := &cobra.Command{
+		Use:   "status <pipeline-id>",
+		Short: "Get the status of a pipeline and its steps",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPipelineStatus(args[0], output)
+		},
+	}
+	output.addFlags(statusCmd, false)
+	cmd.AddCommand(statusCmd)
+
+	return cmd
+}
+
+func runPipeline(path string) error {
+	var file pipelineFile
+	if err := readYAMLFile(path, &file); err != nil {
+		return err
+	}
+
+	req := &pb.RunPipelineReq{}
+	for _, step := range file.Steps {
+		job, err := step.toRequest()
+		if err != nil {
+			return fmt.Errorf("step %s: %v", step.Name, err)
+		}
+		pbStep := &pb.PipelineStep{
+			Name:      step.Name,
+			Job:       job,
+			DependsOn: step.DependsOn,
+		}
+		for _, in := range step.Inputs {
+			pbStep.Inputs = append(pbStep.Inputs, &pb.PipelineInput{Step: in.Step, Source: in.Source, Path: in.Path})
+		}
+		req.Steps = append(req.Steps, pbStep)
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.RunPipeline(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to run pipeline: %v", err)
+	}
+
+	fmt.Printf("Pipeline started:\n")
+	printPipeline(response)
+
+	return nil
+}
+
+func runPipelineStatus(id string, output *outputFlags) error {
+	if err := output.validate(); err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.GetPipelineStatus(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get pipeline status: %v", err)
+	}
+
+	if output.structured() {
+		return output.printMessage(response)
+	}
+
+	printPipeline(response)
+
+	return nil
+}
+
+func printPipeline(p *pb.Pipeline) {
+	fmt.Printf("ID: %s\n", p.Id)
+	fmt.Printf("Status: %s\n", p.Status)
+	fmt.Printf("Started At: %s\n", p.StartTime)
+	if p.EndTime != "" {
+		fmt.Printf("Ended At: %s\n", p.EndTime)
+	}
+
+	fmt.Printf("Steps:\n")
+	for _, step := range p.Steps {
+		jobID := step.JobId
+		if jobID == "" {
+			jobID = "-"
+		}
+		fmt.Printf("  %-20s %-10s job=%s\n", step.Name, step.Status, jobID)
+		if step.Error != "" {
+			fmt.Printf("    %s\n", step.Error)
+		}
+	}
+}
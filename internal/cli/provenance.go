@@ -0,0 +1,77 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+func newProvenanceCmd() *cobra.Command {
+	output := &outputFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "provenance <job-id>",
+		Short: "Show what a job was launched with",
+		Long: `Show the record the worker kept of what a job was launched with: the
+executable and its SHA-256 digest, the environment with secrets masked, the
+cgroup settings in effect, and the kernel and worker versions.
+
+Examples:
+  cli provenance <job-id>
+  cli provenance <job-id> -o json > provenance.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runProvenance(args[0], output)
+		},
+	}
+	output.addFlags(cmd, false)
+
+	return cmd
+}
+
+func runProvenance(jobID string, output *outputFlags) error {
+	if err := output.validate(); err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.GetJobProvenance(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job provenance: %v", err)
+	}
+
+	if output.structured() {
+		return output.printMessage(response)
+	}
+	printProvenance(response)
+	return nil
+}
+
+// printProvenance prints a provenance record, "-" for what the worker could not record
+func printProvenance(p *pb.JobProvenance) {
+	fmt.Printf("Job: %s\n", p.JobId)
+	fmt.Printf("Command: %s\n", p.Command)
+	fmt.Printf("SHA-256: %s\n", orDash(p.CommandSha256))
+	fmt.Printf("Kernel: %s\n", orDash(p.KernelVersion))
+	fmt.Printf("Worker Version: %s\n", p.WorkerVersion)
+	if p.SignedBy != "" {
+		fmt.Printf("Signed By: %s\n", p.SignedBy)
+	}
+	fmt.Printf("Recorded At: %s\n", p.RecordedAt)
+	if p.CgroupPath != "" {
+		fmt.Printf("Cgroup: %s\n", p.CgroupPath)
+		printKeyValues("Cgroup Settings", p.Cgroup)
+	}
+	printEnv(p.Env)
+}
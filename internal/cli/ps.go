@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+	"worker/pkg/units"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+func newPsCmd() *cobra.Command {
+	output := &outputFlags{}
+
+	cmd := &cobra.Command{
+		Use:   "ps <job-id>",
+		Short: "List the processes of a running job",
+		Long: `List the processes of a running job as a tree, each one under the process
+that started it, with its state, threads, CPU time, resident memory and
+command line. The processes are those in the job's cgroup, or for a job
+without one, its first process and the processes it started. CPU% is the
+CPU time over the time since the process started, as ps reports it.
+
+Examples:
+  cli ps <job-id>
+  cli ps <job-id> -o json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPs(args[0], output)
+		},
+	}
+	output.addFlags(cmd, false)
+
+	return cmd
+}
+
+func runPs(jobID string, output *outputFlags) error {
+	if err := output.validate(); err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.GetJobProcesses(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to get job processes: %v", err)
+	}
+
+	if output.structured() {
+		return output.printMessage(response)
+	}
+	return printProcesses(os.Stdout, response)
+}
+
+// printProcesses prints a line per process, the command indented under its
+// parent's
+func printProcesses(out io.Writer, res *pb.JobProcesses) error {
+	sampled, _ := time.Parse(time.RFC3339, res.SampledAt)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PID\tPPID\tSTATE\tTHREADS\tCPU\tCPU%\tRSS\tELAPSED\tCOMMAND")
+	for _, p := range res.Processes {
+		elapsed := "-"
+		if started, err := time.Parse(time.RFC3339, p.StartTime); err == nil && !sampled.IsZero() {
+			elapsed = sampled.Sub(started).Round(time.Second).String()
+		}
+		command := p.Command
+		if p.Depth > 0 {
+			command = strings.Repeat("  ", int(p.Depth-1)) + "\\_ " + command
+		}
+		fmt.Fprintf(w, "%d\t%d\t%s\t%d\t%s\t%.1f\t%s\t%s\t%s\n",
+			p.Pid, p.Ppid, p.State, p.Threads, (time.Duration(p.CpuMicros) * time.Microsecond).Round(10*time.Millisecond),
+			p.CpuPercent, units.FormatBytes(p.RssBytes), elapsed, command)
+	}
+	return w.Flush()
+}
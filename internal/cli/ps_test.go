@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestPrintProcesses(t *testing.T) {
+	res := &pb.JobProcesses{
+		JobId:     "1",
+		SampledAt: "2024-05-01T12:01:00Z",
+		Processes: []*pb.JobProcess{
+			{Pid: 10, Ppid: 1, Command: "make -j2", State: "S", Threads: 1, CpuMicros: 1500000, CpuPercent: 2.5, RssBytes: 4 << 20, StartTime: "2024-05-01T12:00:00Z"},
+			{Pid: 11, Ppid: 10, Depth: 1, Command: "cc main.c", State: "R", Threads: 1, StartTime: "2024-05-01T12:00:30Z"},
+			{Pid: 12, Ppid: 11, Depth: 2, Command: "as", State: "R", Threads: 1},
+		},
+	}
+
+	var out bytes.Buffer
+	if err := printProcesses(&out, res); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "PID") {
+		t.Fatalf("expected a header and 3 processes, got:\n%s", out.String())
+	}
+	for i, want := range []string{
+		"10   1     S      1        1.5s  2.5   4.0Mi  1m0s     make -j2",
+		"11   10    R      1        0s    0.0   0B     30s      \\_ cc main.c",
+		"12   11    R      1        0s    0.0   0B     -          \\_ as",
+	} {
+		if lines[i+1] != want {
+			t.Errorf("line %d: expected %q, got %q", i+1, want, lines[i+1])
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newRerunCmd() *cobra.Command {
+	var quiet bool
+
+	cmd := &cobra.Command{
+		Use:   "rerun <job-id>",
+		Short: "Start a new job with the same spec as an earlier one",
+		Long: `Start a new job with exactly the spec an earlier job was started with:
+the same command, environment, limits, restart policy, labels and group.
+Secrets are resolved again by name. Jobs started with uploaded files cannot be
+rerun, as their upload went into the first job's workspace.
+
+Examples:
+  cli rerun 42
+  cli rerun 42 -q`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRerun(args[0], quiet)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print the job ID")
+
+	return cmd
+}
+
+func runRerun(jobID string, quiet bool) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.RerunJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to rerun job: %v", err)
+	}
+
+	if quiet {
+		fmt.Println(response.Id)
+		return nil
+	}
+
+	fmt.Printf("Job %s rerun:\n", jobID)
+	fmt.Printf("ID: %s\n", response.Id)
+	fmt.Printf("Command: %s\n", strings.Join(append([]string{response.Command}, response.Args...), " "))
+	fmt.Printf("Status: %s\n", response.Status)
+	fmt.Printf("StartTime: %s\n", response.StartTime)
+	printEnv(response.Env)
+	printSecretEnv(response.SecretEnv)
+
+	return nil
+}
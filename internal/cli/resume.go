@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newResumeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "resume <job-id>",
+		Short: "Restart a job held in a crash loop",
+		Long: `Restart a job the worker stopped restarting because it kept failing
+(status CRASH_LOOP). The job restarts right away and its failure count starts
+over. Stop the job instead to give up on it.
+
+Examples:
+  cli resume 42`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResume(args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runResume(jobID string) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := jobClient.ResumeJob(ctx, jobID)
+	if err != nil {
+		return fmt.Errorf("failed to resume job: %v", err)
+	}
+
+	fmt.Printf("Job %s resumed\n", response.Id)
+
+	return nil
+}
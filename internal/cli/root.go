@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 	"worker/internal/cli/config"
+	"worker/pkg/client"
 )
 
 var (
@@ -13,6 +16,9 @@ var rootCmd = &cobra.Command{
 	Use:   "cli",
 	Short: "Worker CLI client",
 	Long:  "Command Line Interface to interact with the Worker gRPC service running in host machines",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return applyContext(cmd)
+	},
 }
 
 func Execute() error {
@@ -20,11 +26,72 @@ func Execute() error {
 }
 
 func init() {
+	tlsFiles := client.DefaultTLSFiles()
 	rootCmd.PersistentFlags().StringVarP(&cfg.ServerAddr, "server", "s", "192.168.1.161:50051", "Address format host:port")
+	rootCmd.PersistentFlags().StringVar(&cfg.CertPath, "cert", tlsFiles.CertPath, "Client certificate path")
+	rootCmd.PersistentFlags().StringVar(&cfg.KeyPath, "key", tlsFiles.KeyPath, "Client private key path")
+	rootCmd.PersistentFlags().StringVar(&cfg.CAPath, "ca", tlsFiles.CAPath, "CA certificate path")
+	rootCmd.PersistentFlags().StringVar(&cfg.Context, "context", "", "Context from the config file to use instead of the current one")
+	rootCmd.PersistentFlags().StringVar(&cfg.Compression, "compress", "", "Compress streamed job output with gzip or zstd, for slow links")
 
 	rootCmd.AddCommand(newRunCmd())
+	rootCmd.AddCommand(newRerunCmd())
+	rootCmd.AddCommand(newApplyCmd())
 	rootCmd.AddCommand(newStatusCmd())
+	rootCmd.AddCommand(newProvenanceCmd())
+	rootCmd.AddCommand(newPsCmd())
 	rootCmd.AddCommand(newStopCmd())
+	rootCmd.AddCommand(newResumeCmd())
+	rootCmd.AddCommand(newDeleteCmd())
+	rootCmd.AddCommand(newExportCmd())
+	rootCmd.AddCommand(newArtifactCmd())
 	rootCmd.AddCommand(newLogCmd())
 	rootCmd.AddCommand(newListCmd())
+	rootCmd.AddCommand(newTopCmd())
+	rootCmd.AddCommand(newBenchCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+	rootCmd.AddCommand(newStoreCmd())
+	rootCmd.AddCommand(newUsageCmd())
+	rootCmd.AddCommand(newEventsCmd())
+	rootCmd.AddCommand(newSecretCmd())
+	rootCmd.AddCommand(newPipelineCmd())
+	rootCmd.AddCommand(newGroupCmd())
+	rootCmd.AddCommand(newTemplateCmd())
+	rootCmd.AddCommand(newConfigCmd())
+}
+
+// applyContext takes the connection settings not given as flags from the
+// selected or current context of the config file
+func applyContext(cmd *cobra.Command) error {
+	file, err := config.LoadFile(config.DefaultPath())
+	if err != nil {
+		return err
+	}
+
+	var ctx *config.Context
+	if cfg.Context != "" {
+		ctx, err = file.Find(cfg.Context)
+	} else {
+		ctx, err = file.Current()
+	}
+	if err != nil || ctx == nil {
+		return err
+	}
+
+	cfg.Apply(ctx, func(flag string) bool {
+		return cmd.Flags().Changed(flag)
+	})
+	return nil
+}
+
+func newJobClient() (*client.JobClient, error) {
+	compression, err := client.ParseCompression(cfg.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --compress: %v", err)
+	}
+	return client.NewJobClientWithTLS(cfg.ServerAddr, client.TLSFiles{
+		CertPath: cfg.CertPath,
+		KeyPath:  cfg.KeyPath,
+		CAPath:   cfg.CAPath,
+	}, client.WithLogCompression(compression))
 }
@@ -2,11 +2,16 @@ package cli
 
 import (
 	"context"
+	"crypto/ed25519"
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 	"worker/pkg/client"
+	"worker/pkg/units"
 
 	"github.com/spf13/cobra"
 	pb "worker/api/gen"
@@ -14,7 +19,7 @@ import (
 
 func newRunCmd() *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "run <command> [args...]",
+		Use:   "run [-f job.yaml] <command> [args...]",
 		Short: "Run a new job",
 		Long: `Run a new job with the specified command and arguments.
 
@@ -25,11 +30,87 @@ Examples:
   cli run mysql
   cli run python3 script.py
   cli run bash -c "curl http://example.com"
+  cli run --shell 'ps aux | grep "$1" | wc -l' python3
+  cli run --env=APP_ENV=prod --env-file=.env python3 app.py
+  cli run --no-inherit-env --env=PATH=/usr/bin:/bin python3 app.py
+  cli run --sha256=$(sha256sum /usr/local/bin/etl | cut -d' ' -f1) /usr/local/bin/etl
+  cli run --secret-env=API_KEY=my-api-key python3 app.py
+  cli run --file=script.py --file=data.csv:input/data.csv python3 script.py
+  cli run --input=data/train.csv=s3://datasets/train.csv python3 train.py data/train.csv
+  cli run --restart=on-failure:5 ./server
+  cli run --cpu 1.5 --memory 2Gi --io 50MB/s python3 train.py
+  cli run --profile=large python3 train.py
+  cli run --restart=always --health-http=8080/healthz python3 -m http.server 8080
+  cli run --max-idle=30m --idle-action=mark ./crawler
+  cli run --output-flush=0 --attach ./interactive-tool
+  cli run --output-rate=64KB/s --output-limit-action=truncate ./noisy-daemon
+  cli run --label=team=ci --label=build=1234 make test
+  cli run --dry-run --max-memory=512 python3 app.py
+  cli run --attach make test
+  cat data.csv | cli run --stdin -- python3 process.py
+  cli run -f job.yaml
+  cli run -f job.yaml --max-memory=1024 python3 other.py
 
 Flags:
+  -f PATH             Read the job from a YAML spec file (see "cli apply --help");
+                      a command and flags given on the command line take precedence
+  --cpu=CPUS          CPU limit as CPUs (1.5), percent (150%) or millicores (500m)
+  --memory=SIZE       Memory limit, e.g. 512M, 512Mi or 2Gi (plain numbers are MiB)
+  --io=RATE           IO limit, e.g. 50MB/s or 10Mi (plain numbers are bytes/s)
+  --profile=NAME      Use a named limit profile from the server config, limits
+                      given with other flags override the profile's
   --max-cpu=N         Max CPU percentage
   --max-memory=N      Max Memory in MB  
   --max-iobps=N       Max IO BPS
+  --env=KEY=VALUE     Set an environment variable (repeatable)
+  --env-file=PATH     Read environment variables from a dotenv file
+  --no-inherit-env    Start the job with only the given environment and the JOB_*
+                      variables instead of the worker's environment
+  --sha256=DIGEST     Pin the command to the SHA-256 digest of its executable; the job
+                      fails before running it if the executable it resolves to differs
+  --secret-env=KEY=SECRET  Inject a stored secret as an environment variable (repeatable)
+  --file=LOCAL[:DEST] Upload a file into the job workspace (repeatable)
+  --input=[DEST=]SOURCE  Stream an s3:// or gs:// object into the workspace as the job
+                      reads it, at DEST or its object name (repeatable)
+  --allowed-window=WINDOW  Only start the job within "[DAYS] HH:MM-HH:MM [ZONE]", e.g.
+                      "Mon-Fri 18:00-06:00 UTC"; it is held until then otherwise (repeatable)
+  --queue-timeout=DUR Mark the job EXPIRED instead of starting it if it cannot start
+                      within DUR of being submitted, e.g. while held by a blackout
+  --label=KEY=VALUE   Attach a label used to select jobs in bulk (repeatable)
+  --socket=NAME=ADDR  Have the worker listen on ADDR, tcp://HOST:PORT or unix://NAME, and
+                      pass the socket in as LISTEN_FDS/LISTEN_FDNAMES (repeatable)
+  --sign-key=PATH     Sign the job request with an Ed25519 private key in PEM, for
+                      workers that only run signed job specs
+  --dry-run           Validate the job on the server without starting it
+  --attach            Stream the job output and exit with the job's exit code
+  --stdin             Pipe stdin into the job, closing its stdin on EOF (implies --attach)
+  --shell             Run the command as a script with /bin/sh -c, the remaining
+                      arguments become $1, $2, ... (needs the run_shell_job permission)
+  --isolation=MODE    full (namespaces and cgroup), cgroups (limits only), process
+                      (no isolation) or privileged (host maintenance, admins only);
+                      anything but full needs the run_unisolated_job permission
+  --qos=CLASS         guaranteed, burstable (default) or best-effort: how the job fares
+                      against other jobs for CPU, IO and memory, and when the OOM killer picks it
+  --seccomp=MODE      audit: allow every syscall but have the kernel log each one, to build
+                      a seccomp profile from what the job uses
+  --runtime=NAME      Run the job through a sandbox runtime configured on the server, such
+                      as gVisor, for untrusted code; replaces --isolation
+  --time-offset=DUR   Run the job in a time namespace with its monotonic and boottime clocks
+                      shifted by DUR (e.g. 720h or -1h); the wall clock is not shifted
+  --max-idle=DUR      Treat the job as hung once it writes no output and uses no CPU for DUR
+  --idle-action=ACT   stop (default) stops an idle job, mark only reports it idle
+  --output-flush=DUR  Send output in chunks gathered for at most DUR instead of the
+                      server's interval; 0 sends every write as it comes
+  --output-rate=RATE  Keep stdout and stderr together to RATE, e.g. 64KB/s (at least 1KB/s)
+  --output-limit-action=ACT  throttle blocks the job's writes over the rate, truncate drops
+                      the output over it; the server's action by default
+  -q, --quiet         Only print the job ID
+  --restart=POLICY[:N] Restart policy: never, on-failure or always, with at most N restarts
+  --health-cmd=CMD    Liveness probe: run CMD with sh -c inside the job
+  --health-tcp=PORT   Liveness probe: connect to PORT
+  --health-http=PORT[/PATH]  Liveness probe: GET PATH on PORT
+  --health-interval=D, --health-timeout=D, --health-start-delay=D, --health-retries=N
+                      Probe timing (e.g. 10s) and failures before the job is unhealthy
 
 All jobs share the host network interface and can communicate
 with each other and external services directly.`,
@@ -43,25 +124,263 @@ with each other and external services directly.`,
 
 func runRun(cmd *cobra.Command, args []string) error {
 	var (
-		maxCPU    int32
-		maxMemory int32
-		maxIOBPS  int32
+		cpuMillis   int64
+		memoryBytes int64
+		ioBPS       int64
+		profile     string
+		isolation   string
+		qos         string
+		seccomp     string
+		runtime     string
+		digest      string
+		timeOffset  time.Duration
+		maxIdle     time.Duration
+		queueWait   time.Duration
+		idleAction  string
+		outputFlush *time.Duration
+		outputRate  int64
+		outputLimit string
+		env         map[string]string
+		envFile     []byte
+		secretEnv   map[string]string
+		files       []client.UploadFile
+		inputs      []*pb.JobInput
+		sockets     []*pb.JobSocket
+		windows     []string
+		restart     string
+		maxRestarts int32
+		probe       *pb.HealthProbe
+		labels      map[string]string
+		specPath    string
+		signKeyPath string
+		dryRun      bool
+		attach      bool
+		stdin       bool
+		shell       bool
+		cleanEnv    bool
+		quiet       bool
 	)
 
-	commandStartIndex := 0
-	for i, arg := range args {
-		if strings.HasPrefix(arg, "--max-cpu=") {
-			if val, err := parseIntFlag(arg, "--max-cpu="); err == nil {
-				maxCPU = int32(val)
+	commandStartIndex := len(args)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if value, ok, err := flagValue(args, &i, "-f"); ok {
+			if err != nil {
+				return err
 			}
+			specPath = value
+		} else if value, ok, err := flagValue(args, &i, "--sign-key"); ok {
+			if err != nil {
+				return err
+			}
+			signKeyPath = value
+		} else if value, ok, err := flagValue(args, &i, "--cpu"); ok {
+			if err == nil {
+				cpuMillis, err = units.ParseCPU(value)
+			}
+			if err != nil {
+				return err
+			}
+		} else if value, ok, err := flagValue(args, &i, "--memory"); ok {
+			if err == nil {
+				memoryBytes, err = units.ParseMemory(value)
+			}
+			if err != nil {
+				return err
+			}
+		} else if value, ok, err := flagValue(args, &i, "--io"); ok {
+			if err == nil {
+				ioBPS, err = units.ParseIO(value)
+			}
+			if err != nil {
+				return err
+			}
+		} else if value, ok, err := flagValue(args, &i, "--profile"); ok {
+			if err != nil {
+				return err
+			}
+			profile = value
+		} else if value, ok, err := flagValue(args, &i, "--isolation"); ok {
+			if err != nil {
+				return err
+			}
+			isolation = value
+		} else if value, ok, err := flagValue(args, &i, "--qos"); ok {
+			if err != nil {
+				return err
+			}
+			qos = value
+		} else if value, ok, err := flagValue(args, &i, "--seccomp"); ok {
+			if err != nil {
+				return err
+			}
+			seccomp = value
+		} else if value, ok, err := flagValue(args, &i, "--runtime"); ok {
+			if err != nil {
+				return err
+			}
+			runtime = value
+		} else if value, ok, err := flagValue(args, &i, "--sha256"); ok {
+			if err != nil {
+				return err
+			}
+			digest = value
+		} else if value, ok, err := flagValue(args, &i, "--time-offset"); ok {
+			if err != nil {
+				return err
+			}
+			if timeOffset, err = time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid --time-offset: %v", err)
+			}
+		} else if value, ok, err := flagValue(args, &i, "--max-idle"); ok {
+			if err != nil {
+				return err
+			}
+			if maxIdle, err = time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid --max-idle: %v", err)
+			}
+		} else if value, ok, err := flagValue(args, &i, "--queue-timeout"); ok {
+			if err != nil {
+				return err
+			}
+			if queueWait, err = time.ParseDuration(value); err != nil {
+				return fmt.Errorf("invalid --queue-timeout: %v", err)
+			}
+		} else if value, ok, err := flagValue(args, &i, "--idle-action"); ok {
+			if err != nil {
+				return err
+			}
+			idleAction = value
+		} else if value, ok, err := flagValue(args, &i, "--output-flush"); ok {
+			if err != nil {
+				return err
+			}
+			interval, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid --output-flush: %v", err)
+			}
+			outputFlush = &interval
+		} else if value, ok, err := flagValue(args, &i, "--output-rate"); ok {
+			if err == nil {
+				outputRate, err = units.ParseIO(value)
+			}
+			if err != nil {
+				return fmt.Errorf("invalid --output-rate: %v", err)
+			}
+		} else if value, ok, err := flagValue(args, &i, "--output-limit-action"); ok {
+			if err != nil {
+				return err
+			}
+			outputLimit = value
+		} else if strings.HasPrefix(arg, "--max-cpu=") {
+			val, err := parseIntFlag(arg, "--max-cpu=")
+			if err != nil {
+				return fmt.Errorf("invalid --max-cpu value %q, expected a percentage", arg)
+			}
+			cpuMillis = int64(val) * 10
 		} else if strings.HasPrefix(arg, "--max-memory=") {
-			if val, err := parseIntFlag(arg, "--max-memory="); err == nil {
-				maxMemory = int32(val)
+			val, err := parseIntFlag(arg, "--max-memory=")
+			if err != nil {
+				return fmt.Errorf("invalid --max-memory value %q, expected MB", arg)
 			}
+			memoryBytes = int64(val) << 20
 		} else if strings.HasPrefix(arg, "--max-iobps=") {
-			if val, err := parseIntFlag(arg, "--max-iobps="); err == nil {
-				maxIOBPS = int32(val)
+			val, err := parseIntFlag(arg, "--max-iobps=")
+			if err != nil {
+				return fmt.Errorf("invalid --max-iobps value %q, expected bytes per second", arg)
+			}
+			ioBPS = int64(val)
+		} else if strings.HasPrefix(arg, "--env=") {
+			key, value, found := strings.Cut(strings.TrimPrefix(arg, "--env="), "=")
+			if !found || key == "" {
+				return fmt.Errorf("invalid --env value %q, expected KEY=VALUE", arg)
+			}
+			if env == nil {
+				env = make(map[string]string)
+			}
+			env[key] = value
+		} else if strings.HasPrefix(arg, "--secret-env=") {
+			key, secretName, found := strings.Cut(strings.TrimPrefix(arg, "--secret-env="), "=")
+			if !found || key == "" || secretName == "" {
+				return fmt.Errorf("invalid --secret-env value %q, expected KEY=SECRET_NAME", arg)
+			}
+			if secretEnv == nil {
+				secretEnv = make(map[string]string)
+			}
+			secretEnv[key] = secretName
+		} else if arg == "--dry-run" {
+			dryRun = true
+		} else if arg == "--attach" {
+			attach = true
+		} else if arg == "--stdin" {
+			stdin = true
+		} else if arg == "--shell" {
+			shell = true
+		} else if arg == "--no-inherit-env" {
+			cleanEnv = true
+		} else if arg == "-q" || arg == "--quiet" {
+			quiet = true
+		} else if strings.HasPrefix(arg, "--label=") {
+			key, value, found := strings.Cut(strings.TrimPrefix(arg, "--label="), "=")
+			if !found || key == "" {
+				return fmt.Errorf("invalid --label value %q, expected KEY=VALUE", arg)
+			}
+			if labels == nil {
+				labels = make(map[string]string)
+			}
+			labels[key] = value
+		} else if strings.HasPrefix(arg, "--input=") {
+			value := strings.TrimPrefix(arg, "--input=")
+			dest, source, found := strings.Cut(value, "=")
+			// object keys may hold "=", e.g. date=2024-01-01/part.parquet
+			if !found || strings.Contains(dest, "://") {
+				dest, source = "", value
+			}
+			if source == "" {
+				return fmt.Errorf("invalid --input value %q, expected [DEST=]SOURCE", arg)
+			}
+			inputs = append(inputs, jobInput(source, dest))
+		} else if strings.HasPrefix(arg, "--socket=") {
+			name, address, found := strings.Cut(strings.TrimPrefix(arg, "--socket="), "=")
+			if !found || name == "" || address == "" {
+				return fmt.Errorf("invalid --socket value %q, expected NAME=ADDRESS", arg)
+			}
+			sockets = append(sockets, &pb.JobSocket{Name: name, Address: address})
+		} else if strings.HasPrefix(arg, "--allowed-window=") {
+			windows = append(windows, strings.TrimPrefix(arg, "--allowed-window="))
+		} else if strings.HasPrefix(arg, "--file=") {
+			local, dest, _ := strings.Cut(strings.TrimPrefix(arg, "--file="), ":")
+			if local == "" {
+				return fmt.Errorf("invalid --file value %q, expected LOCAL[:DEST]", arg)
+			}
+			if dest == "" {
+				dest = filepath.Base(local)
+			}
+			files = append(files, client.UploadFile{LocalPath: local, Path: dest})
+		} else if strings.HasPrefix(arg, "--restart=") {
+			policy, limit, hasLimit := strings.Cut(strings.TrimPrefix(arg, "--restart="), ":")
+			restart = policy
+			if hasLimit {
+				val, err := strconv.ParseInt(limit, 10, 32)
+				if err != nil || val < 1 {
+					return fmt.Errorf("invalid --restart value %q, expected POLICY[:N]", arg)
+				}
+				maxRestarts = int32(val)
+			}
+		} else if strings.HasPrefix(arg, "--health-") {
+			var err error
+			if probe, err = parseHealthFlag(arg, probe); err != nil {
+				return err
+			}
+		} else if strings.HasPrefix(arg, "--env-file=") {
+			data, err := os.ReadFile(strings.TrimPrefix(arg, "--env-file="))
+			if err != nil {
+				return fmt.Errorf("failed to read env file: %v", err)
 			}
+			envFile = data
+		} else if arg == "--" {
+			commandStartIndex = i + 1
+			break
 		} else if !strings.HasPrefix(arg, "--") {
 			commandStartIndex = i
 			break
@@ -70,47 +389,366 @@ func runRun(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	if commandStartIndex >= len(args) {
+	job := &pb.RunJobReq{}
+	if specPath != "" {
+		specs, err := loadJobFiles(specPath)
+		if err != nil {
+			return err
+		}
+		if len(specs) != 1 {
+			return fmt.Errorf("%s holds %d job specs, use apply to run several", specPath, len(specs))
+		}
+
+		var specFiles []client.UploadFile
+		if job, specFiles, err = specs[0].request(); err != nil {
+			return err
+		}
+		files = append(specFiles, files...)
+	}
+
+	// the command line takes precedence over the spec file
+	if commandStartIndex < len(args) {
+		job.Command = args[commandStartIndex]
+		job.Args = args[commandStartIndex+1:]
+	}
+	if job.Command == "" {
 		return fmt.Errorf("must specify a command")
 	}
+	setLimits(job, cpuMillis, memoryBytes, ioBPS)
+	if profile != "" {
+		job.Profile = profile
+	}
+	if envFile != nil {
+		job.EnvFile = envFile
+	}
+	if restart != "" {
+		job.RestartPolicy = restart
+	}
+	if maxRestarts > 0 {
+		job.MaxRestarts = maxRestarts
+	}
+	if probe != nil {
+		job.HealthProbe = probe
+	}
+	if shell {
+		job.Shell = true
+	}
+	if cleanEnv {
+		inherit := false
+		job.InheritEnv = &inherit
+	}
+	if digest != "" {
+		job.CommandSha256 = digest
+	}
+	if isolation != "" {
+		job.Isolation = isolation
+	}
+	if qos != "" {
+		job.Qos = qos
+	}
+	if seccomp != "" {
+		job.Seccomp = seccomp
+	}
+	if runtime != "" {
+		job.Runtime = runtime
+	}
+	if timeOffset != 0 {
+		job.TimeOffsetSeconds = int64(timeOffset / time.Second)
+	}
+	if maxIdle != 0 {
+		job.MaxIdleSeconds = int64(maxIdle / time.Second)
+	}
+	if idleAction != "" {
+		job.IdleAction = idleAction
+	}
+	if queueWait != 0 {
+		job.QueueTimeoutSeconds = int64(queueWait / time.Second)
+	}
+	if outputFlush != nil {
+		job.OutputFlushMillis = outputFlushMillis(*outputFlush)
+	}
+	if outputRate != 0 {
+		job.OutputBytesPerSecond = outputRate
+	}
+	if outputLimit != "" {
+		job.OutputLimitAction = outputLimit
+	}
+	job.Env = mergeStringMaps(job.Env, env)
+	job.SecretEnv = mergeStringMaps(job.SecretEnv, secretEnv)
+	job.Labels = mergeStringMaps(job.Labels, labels)
+	job.Inputs = append(job.Inputs, inputs...)
+	job.Sockets = append(job.Sockets, sockets...)
+	job.AllowedWindows = append(job.AllowedWindows, windows...)
 
-	commandArgs := args[commandStartIndex:]
-	command := commandArgs[0]
-	cmdArgs := commandArgs[1:]
+	signKey, err := loadSigningKey(signKeyPath)
+	if err != nil {
+		return err
+	}
 
-	jobClient, err := client.NewJobClient(cfg.ServerAddr)
+	jobClient, err := newJobClient()
 	if err != nil {
 		return err
 	}
 	defer jobClient.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+	if dryRun {
+		if err := signJob(job, signKey); err != nil {
+			return err
+		}
+		return runValidate(jobClient, job, files)
+	}
+
+	if len(files) > 0 {
+		uploadCtx, uploadCancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		upload, e := jobClient.UploadJobFiles(uploadCtx, files)
+		uploadCancel()
+		if e != nil {
+			return fmt.Errorf("failed to upload job files: %v", e)
+		}
+		job.UploadId = upload.UploadId
+		if !quiet {
+			fmt.Printf("Uploaded %d file(s), %d bytes\n", upload.Files, upload.TotalBytes)
+		}
+	}
+
+	// the signature covers the upload, so the job is signed last
+	if err := signJob(job, signKey); err != nil {
+		return err
+	}
 
-	job := &pb.RunJobReq{
-		Command:   command,
-		Args:      cmdArgs,
-		MaxCPU:    maxCPU,
-		MaxMemory: maxMemory,
-		MaxIOBPS:  maxIOBPS,
+	if stdin {
+		job.Stdin = true
+		return runAttached(jobClient, job, os.Stdin)
+	}
+	if attach {
+		return runAttached(jobClient, job, nil)
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
 	response, err := jobClient.RunJob(ctx, job)
 	if err != nil {
 		return fmt.Errorf("failed to run job: %v", err)
 	}
 
+	if quiet {
+		fmt.Println(response.Id)
+		return nil
+	}
+
 	fmt.Printf("Job started:\n")
 	fmt.Printf("ID: %s\n", response.Id)
-	fmt.Printf("Command: %s\n", strings.Join(commandArgs, " "))
+	fmt.Printf("Command: %s\n", strings.Join(append([]string{job.Command}, job.Args...), " "))
 	fmt.Printf("Status: %s\n", response.Status)
 	fmt.Printf("StartTime: %s\n", response.StartTime)
 	fmt.Printf("Network: host (shared with system)\n")
+	printEnv(response.Env)
+	printSecretEnv(response.SecretEnv)
+
+	return nil
+}
+
+// loadSigningKey reads the key jobs are signed with, nil when path is empty
+func loadSigningKey(path string) (ed25519.PrivateKey, error) {
+	if path == "" {
+		return nil, nil
+	}
+	return client.LoadSigningKey(path)
+}
+
+// signJob signs the completed job request with key, if any
+func signJob(job *pb.RunJobReq, key ed25519.PrivateKey) error {
+	if key == nil {
+		return nil
+	}
+	if err := client.SignRunJobReq(job, key); err != nil {
+		return fmt.Errorf("failed to sign job: %v", err)
+	}
+	return nil
+}
+
+// runValidate checks the job on the server without starting it. Files are only
+// checked locally; they are not uploaded.
+func runValidate(jobClient *client.JobClient, job *pb.RunJobReq, files []client.UploadFile) error {
+	var problems []string
+	for _, f := range files {
+		if _, err := os.Stat(f.LocalPath); err != nil {
+			problems = append(problems, fmt.Sprintf("file: %v", err))
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.ValidateJob(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to validate job: %v", err)
+	}
+	for _, e := range response.Errors {
+		problems = append(problems, fmt.Sprintf("%s: %s", e.Field, e.Message))
+	}
+
+	if response.ResolvedCommand != "" {
+		fmt.Printf("Command: %s\n", response.ResolvedCommand)
+	}
+	printLimits(response.CpuLimitMillis, response.MemoryLimitBytes, response.IoLimitBPS)
 
+	if len(problems) > 0 {
+		fmt.Printf("Problems:\n")
+		for _, p := range problems {
+			fmt.Printf("  %s\n", p)
+		}
+		return fmt.Errorf("job is not valid (%d problems)", len(problems))
+	}
+
+	fmt.Printf("Job is valid\n")
 	return nil
 }
 
+// parseHealthFlag applies one --health-* flag to the probe, creating it on first use
+func parseHealthFlag(arg string, probe *pb.HealthProbe) (*pb.HealthProbe, error) {
+	name, value, _ := strings.Cut(strings.TrimPrefix(arg, "--health-"), "=")
+	if value == "" {
+		return nil, fmt.Errorf("missing value for %s", arg)
+	}
+	if probe == nil {
+		probe = &pb.HealthProbe{}
+	}
+
+	switch name {
+	case "cmd":
+		probe.Type = "exec"
+		probe.Command = []string{"/bin/sh", "-c", value}
+	case "tcp", "http":
+		portStr, path, _ := strings.Cut(value, "/")
+		port, err := strconv.ParseInt(portStr, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid port in %s", arg)
+		}
+		probe.Type = name
+		probe.Port = int32(port)
+		if name == "http" {
+			probe.Path = "/" + path
+		}
+	case "interval", "timeout", "start-delay":
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration in %s: %v", arg, err)
+		}
+		seconds := int32(d / time.Second)
+		switch name {
+		case "interval":
+			probe.IntervalSeconds = seconds
+		case "timeout":
+			probe.TimeoutSeconds = seconds
+		default:
+			probe.InitialDelaySeconds = seconds
+		}
+	case "retries":
+		n, err := strconv.ParseInt(value, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value in %s", arg)
+		}
+		probe.FailureThreshold = int32(n)
+	default:
+		return nil, fmt.Errorf("unknown flag: %s", arg)
+	}
+
+	return probe, nil
+}
+
+// mergeStringMaps returns base with the entries of overrides applied on top
+func mergeStringMaps(base, overrides map[string]string) map[string]string {
+	if len(overrides) == 0 {
+		return base
+	}
+	if base == nil {
+		base = make(map[string]string, len(overrides))
+	}
+	for k, v := range overrides {
+		base[k] = v
+	}
+	return base
+}
+
+// flagValue matches "name=value" or "name value" at args[*i], advancing *i past
+// a separate value. ok reports whether the argument is the flag.
+func flagValue(args []string, i *int, name string) (value string, ok bool, err error) {
+	arg := args[*i]
+	if v, found := strings.CutPrefix(arg, name+"="); found {
+		return v, true, nil
+	}
+	if arg != name {
+		return "", false, nil
+	}
+	if *i+1 >= len(args) {
+		return "", true, fmt.Errorf("missing value for %s", name)
+	}
+	*i++
+	return args[*i], true, nil
+}
+
 func parseIntFlag(arg, prefix string) (int64, error) {
 	valueStr := strings.TrimPrefix(arg, prefix)
 	return strconv.ParseInt(valueStr, 10, 32)
 }
+
+// printLimits prints the resource limits of a job, "-" for unlimited
+func printLimits(cpuMillis, memoryBytes, ioBPS int64) {
+	fmt.Printf("CPU: %s\n", formatLimit(cpuMillis, units.FormatCPU))
+	fmt.Printf("Memory: %s\n", formatLimit(memoryBytes, units.FormatBytes))
+	fmt.Printf("IO: %s\n", formatLimit(ioBPS, func(n int64) string { return units.FormatBytes(n) + "/s" }))
+}
+
+func formatLimit(n int64, format func(int64) string) string {
+	if n <= 0 {
+		return "-"
+	}
+	return format(n)
+}
+
+// printEnv prints job environment variables in a stable order
+func printEnv(env map[string]string) {
+	printKeyValues("Env", env)
+}
+
+func printLabels(labels map[string]string) {
+	printKeyValues("Labels", labels)
+}
+
+// printKeyValues prints a titled map sorted by key, nothing if it is empty
+func printKeyValues(title string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("%s:\n", title)
+	for _, k := range keys {
+		fmt.Printf("  %s=%s\n", k, values[k])
+	}
+}
+
+// printSecretEnv prints secret references; values never leave the worker
+func printSecretEnv(secretEnv map[string]string) {
+	if len(secretEnv) == 0 {
+		return
+	}
+
+	keys := make([]string, 0, len(secretEnv))
+	for k := range secretEnv {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	fmt.Printf("Secret Env:\n")
+	for _, k := range keys {
+		fmt.Printf("  %s=<secret:%s>\n", k, secretEnv[k])
+	}
+}
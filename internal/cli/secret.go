@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func newSecretCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "secret",
+		Short: "Manage secrets stored on the worker",
+		Long: `Manage encrypted secrets stored on the worker.
+
+Secrets are referenced by name when running a job and injected as
+environment variables at exec time:
+  cli run --secret-env=API_KEY=my-api-key python3 app.py`,
+	}
+
+	cmd.AddCommand(newSecretCreateCmd())
+	cmd.AddCommand(newSecretDeleteCmd())
+
+	return cmd
+}
+
+func newSecretCreateCmd() *cobra.Command {
+	var fromFile string
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create a secret, reading the value from stdin or --from-file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSecretCreate(args[0], fromFile)
+		},
+	}
+
+	cmd.Flags().StringVar(&fromFile, "from-file", "", "Read the secret value from a file")
+
+	return cmd
+}
+
+func newSecretDeleteCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a secret",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSecretDelete(args[0])
+		},
+	}
+}
+
+func runSecretCreate(name, fromFile string) error {
+	var (
+		value []byte
+		err   error
+	)
+	if fromFile != "" {
+		value, err = os.ReadFile(fromFile)
+	} else {
+		value, err = io.ReadAll(os.Stdin)
+		// a value piped with echo should not keep its trailing newline
+		value = bytes.TrimRight(value, "\r\n")
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read secret value: %v", err)
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.CreateSecret(ctx, name, value)
+	if err != nil {
+		return fmt.Errorf("failed to create secret: %v", err)
+	}
+
+	fmt.Printf("Secret created: %s (%s)\n", response.Name, response.CreatedAt)
+	return nil
+}
+
+func runSecretDelete(name string) error {
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	response, err := jobClient.DeleteSecret(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to delete secret: %v", err)
+	}
+
+	fmt.Printf("Secret deleted: %s\n", response.Name)
+	return nil
+}
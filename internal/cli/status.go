@@ -5,26 +5,43 @@ import (
 	"fmt"
 	"strings"
 	"time"
-	"worker/pkg/client"
+	"worker/pkg/units"
 
 	"github.com/spf13/cobra"
 )
 
 func newStatusCmd() *cobra.Command {
+	output := &outputFlags{}
+	var result bool
+
 	cmd := &cobra.Command{
 		Use:   "status <job-id>",
 		Short: "Get the status of a job by ID",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runStatus,
+		Long: `Get the status of a job by ID.
+
+A job can leave a JSON result by writing it to the file named by its
+JOB_RESULT_PATH variable; it is read when the job finishes.
+
+Examples:
+  cli status 42
+  cli status --result 42 | jq .rows`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatus(args[0], output, result)
+		},
 	}
+	output.addFlags(cmd, false)
+	cmd.Flags().BoolVar(&result, "result", false, "Print only the job's JSON result, failing if it left none")
 
 	return cmd
 }
 
-func runStatus(cmd *cobra.Command, args []string) error {
-	jobID := args[0]
+func runStatus(jobID string, output *outputFlags, result bool) error {
+	if err := output.validate(); err != nil {
+		return err
+	}
 
-	jobClient, err := client.NewJobClient(cfg.ServerAddr)
+	jobClient, err := newJobClient()
 	if err != nil {
 		return err
 	}
@@ -38,6 +55,24 @@ func runStatus(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get job status: %v", err)
 	}
 
+	if result {
+		switch {
+		case response.Result != "":
+			fmt.Println(response.Result)
+			return nil
+		case response.ResultError != "":
+			return fmt.Errorf("job %s left a result that was not kept: %s", jobID, response.ResultError)
+		case response.EndTime == "":
+			return fmt.Errorf("job %s has not finished", jobID)
+		default:
+			return fmt.Errorf("job %s left no result", jobID)
+		}
+	}
+
+	if output.structured() {
+		return output.printMessage(response)
+	}
+
 	fmt.Printf("Id: %s\n", response.Id)
 	fmt.Printf("Command: %s %s\n", response.Command, strings.Join(response.Args, " "))
 	if response.Status != "RUNNING" {
@@ -45,10 +80,75 @@ func runStatus(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Printf("Started At: %s\n", response.StartTime)
 	fmt.Printf("Ended At: %s\n", response.EndTime)
-	fmt.Printf("Status: %s\n", response.Status)
-	fmt.Printf("MaxCPU: %d\n", response.MaxCPU)
-	fmt.Printf("MaxMemory: %d\n", response.MaxMemory)
-	fmt.Printf("MaxIOBPS: %d\n", response.MaxIOBPS)
+	if response.StatusReason != "" {
+		fmt.Printf("Status: %s (%s)\n", response.Status, response.StatusReason)
+	} else {
+		fmt.Printf("Status: %s\n", response.Status)
+	}
+	printLimits(response.CpuLimitMillis, response.MemoryLimitBytes, response.IoLimitBPS)
+	switch response.Isolation {
+	case "", "full":
+	case "privileged":
+		fmt.Printf("Isolation: PRIVILEGED, runs on the host without namespaces or cgroup limits\n")
+	case "sandbox":
+		fmt.Printf("Isolation: sandbox (runtime %s)\n", response.Runtime)
+	default:
+		fmt.Printf("Isolation: %s\n", response.Isolation)
+	}
+	if response.Qos != "" && response.Qos != "burstable" {
+		fmt.Printf("QoS: %s\n", response.Qos)
+	}
+	if response.Seccomp != "" {
+		fmt.Printf("Seccomp: %s\n", response.Seccomp)
+	}
+	if response.CoreDump != "" {
+		fmt.Printf("Core Dump: %s (%s), download with: cli artifact %s %s\n",
+			response.CoreDump, units.FormatBytes(response.CoreDumpBytes), response.Id, response.CoreDump)
+	}
+	if response.Idle {
+		fmt.Printf("Idle: no output or CPU use for its max idle time\n")
+	}
+	if response.OutputDroppedBytes > 0 {
+		fmt.Printf("Output Dropped: %s over its output rate limit\n", units.FormatBytes(response.OutputDroppedBytes))
+	}
+	if response.TimeOffsetSeconds != 0 {
+		fmt.Printf("Time Offset: %v\n", time.Duration(response.TimeOffsetSeconds)*time.Second)
+	}
+	if response.RestartPolicy != "" && response.RestartPolicy != "never" {
+		fmt.Printf("Restart Policy: %s (max %d restarts)\n", response.RestartPolicy, response.MaxRestarts)
+		fmt.Printf("Restarts: %d\n", response.Restarts)
+	}
+	if response.HealthProbe != nil {
+		health := response.Health
+		if health == "" {
+			health = "unknown"
+		}
+		fmt.Printf("Health: %s (%s probe)\n", health, response.HealthProbe.Type)
+	}
+	if response.GroupId != "" {
+		fmt.Printf("Group: %s\n", response.GroupId)
+	}
+	printLabels(response.Labels)
+	if response.WorkspaceBytes > 0 {
+		fmt.Printf("Workspace Usage: %d bytes\n", response.WorkspaceBytes)
+	}
+	if response.CpuThrottledPercent > 0 {
+		fmt.Printf("CPU Throttled: %.1f%% of periods\n", response.CpuThrottledPercent)
+	}
+	if response.OutputLocation != "" {
+		fmt.Printf("Output Location: %s\n", response.OutputLocation)
+	}
+	for _, socket := range response.Sockets {
+		fmt.Printf("Socket: %s=%s\n", socket.Name, socket.Address)
+	}
+	if response.Result != "" {
+		fmt.Printf("Result: %s\n", response.Result)
+	}
+	if response.ResultError != "" {
+		fmt.Printf("Result Error: %s\n", response.ResultError)
+	}
+	printEnv(response.Env)
+	printSecretEnv(response.SecretEnv)
 
 	return nil
 }
@@ -4,35 +4,68 @@ import (
 	"context"
 	"fmt"
 	"time"
-	"worker/pkg/client"
 
 	"github.com/spf13/cobra"
+	pb "worker/api/gen"
 )
 
+type stopCmdParams struct {
+	grace  time.Duration
+	signal string
+}
+
 func newStopCmd() *cobra.Command {
+	selector := &jobSelector{}
+	params := &stopCmdParams{}
+
 	cmd := &cobra.Command{
-		Use:   "stop <job-id>",
-		Short: "Stop a running job",
-		Args:  cobra.ExactArgs(1),
-		RunE:  runStop,
+		Use:   "stop [job-id...]",
+		Short: "Stop running jobs",
+		Long: `Stop one or more running jobs, given by ID or selected with filters.
+
+A job is sent SIGTERM, or the signal given with --signal, and killed with
+SIGKILL when it is still running after the grace period. The grace period
+defaults to the worker's cleanup timeout. --grace and --signal apply when
+stopping a single job.
+
+Examples:
+  cli stop 42
+  cli stop 42 --grace 30s
+  cli stop 42 --signal INT --grace 1m
+  cli stop 42 43 44
+  cli stop --label=team=ci`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 && !selector.isSet() {
+				return runStop(args[0], params)
+			}
+			if params.grace != 0 || params.signal != "" {
+				return fmt.Errorf("--grace and --signal apply when stopping a single job")
+			}
+			return runBulkStop(selector, args)
+		},
 	}
+	selector.addFlags(cmd)
+	cmd.Flags().DurationVar(&params.grace, "grace", 0, "How long the job may take to exit before it is killed")
+	cmd.Flags().StringVar(&params.signal, "signal", "", "Signal asking the job to exit: TERM, INT, HUP, QUIT, USR1, USR2 or KILL (default TERM)")
 
 	return cmd
 }
 
-func runStop(cmd *cobra.Command, args []string) error {
-	jobID := args[0]
-
-	jobClient, err := client.NewJobClient(cfg.ServerAddr)
+func runStop(jobID string, params *stopCmdParams) error {
+	jobClient, err := newJobClient()
 	if err != nil {
 		return err
 	}
 	defer jobClient.Close()
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second+params.grace)
 	defer cancel()
 
-	response, err := jobClient.StopJob(ctx, jobID)
+	response, err := jobClient.StopJob(ctx, &pb.StopJobReq{
+		Id:                jobID,
+		GracePeriodMillis: params.grace.Milliseconds(),
+		Signal:            params.signal,
+	})
 	if err != nil {
 		return fmt.Errorf("failed to stop job: %v", err)
 	}
@@ -40,6 +73,35 @@ func runStop(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Job stopped successfully:\n")
 	fmt.Printf("ID: %s\n", response.Id)
 	fmt.Printf("Status: %s\n", response.Status)
+	if response.Method != "" {
+		fmt.Printf("Method: %s\n", response.Method)
+	}
+	fmt.Printf("Exit Code: %d\n", response.ExitCode)
+
+	return nil
+}
+
+func runBulkStop(selector *jobSelector, ids []string) error {
+	req, err := selector.request(ids)
+	if err != nil {
+		return err
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	response, err := jobClient.StopJobs(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to stop jobs: %v", err)
+	}
+
+	printBulkResults("stopped", response)
 
 	return nil
 }
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+	"worker/pkg/units"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+type storeCmdParams struct {
+	compact     bool
+	heapProfile string
+	format      string
+}
+
+func newStoreCmd() *cobra.Command {
+	params := &storeCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "store",
+		Short: "Show the memory the worker's job store takes",
+		Long: `Show the jobs the worker holds in memory, the memory their output takes,
+what compaction compressed and dropped since the worker started, and the
+memory of the worker process. Finished jobs' output is compressed and dropped
+by the worker's outputBuffer.compressAfter and retention settings; --compact
+runs a compaction with them now instead of waiting for the next one.
+--heap-profile writes a heap profile of the worker, to read with go tool
+pprof. Admin only.
+
+Examples:
+  cli store
+  cli store --compact
+  cli store --heap-profile heap.pprof`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStore(params)
+		},
+	}
+
+	cmd.Flags().BoolVar(&params.compact, "compact", false, "Compact the store before reporting")
+	cmd.Flags().StringVar(&params.heapProfile, "heap-profile", "", "Write a heap profile of the worker to this file")
+	cmd.Flags().StringVarP(&params.format, "output", "o", "", "Output format: json or yaml")
+
+	return cmd
+}
+
+func runStore(params *storeCmdParams) error {
+	switch params.format {
+	case "", outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("invalid output format %q, expected json or yaml", params.format)
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stats, err := jobClient.GetStoreStats(ctx, &pb.GetStoreStatsReq{Compact: params.compact, HeapProfile: params.heapProfile != ""})
+	if err != nil {
+		return fmt.Errorf("failed to get store stats: %v", err)
+	}
+
+	if params.heapProfile != "" {
+		if err := os.WriteFile(params.heapProfile, stats.HeapProfile, 0644); err != nil {
+			return fmt.Errorf("failed to write heap profile: %v", err)
+		}
+		stats.HeapProfile = nil
+	}
+
+	if params.format != "" {
+		return (&outputFlags{format: params.format}).printMessage(stats)
+	}
+	return printStoreStats(os.Stdout, stats)
+}
+
+// printStoreStats prints the stats a line per value
+func printStoreStats(out io.Writer, stats *pb.StoreStats) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	buffer := stats.GetOutputBuffer()
+	memory := stats.GetMemory()
+
+	fmt.Fprintf(w, "Jobs:\t%d (%d finished)\n", stats.Jobs, stats.FinishedJobs)
+	fmt.Fprintf(w, "History entries:\t%d\n", stats.HistoryEntries)
+	if buffer.GetBudgetBytes() > 0 {
+		fmt.Fprintf(w, "Buffered output:\t%s of %s\n", units.FormatBytes(buffer.GetUsedBytes()), units.FormatBytes(buffer.GetBudgetBytes()))
+	} else {
+		fmt.Fprintf(w, "Buffered output:\t%s\n", units.FormatBytes(buffer.GetUsedBytes()))
+	}
+	fmt.Fprintf(w, "Spilled to disk:\t%s in %d jobs\n", units.FormatBytes(buffer.GetSpilledBytes()), buffer.GetSpilledJobs())
+	fmt.Fprintf(w, "Compressed:\t%s, %s uncompressed, in %d jobs\n",
+		units.FormatBytes(stats.CompressedBytes), units.FormatBytes(stats.UncompressedBytes), stats.CompressedJobs)
+	fmt.Fprintf(w, "Compactions:\t%d, last %s\n", stats.Compactions, orDash(stats.LastCompaction))
+	fmt.Fprintf(w, "Saved by compression:\t%s\n", units.FormatBytes(stats.SavedBytes))
+	fmt.Fprintf(w, "Expired output:\t%s in %d jobs\n", units.FormatBytes(stats.ExpiredBytes), stats.ExpiredJobs)
+	fmt.Fprintf(w, "Heap:\t%s allocated, %s in use, %s released\n",
+		units.FormatBytes(memory.GetHeapAllocBytes()), units.FormatBytes(memory.GetHeapInuseBytes()), units.FormatBytes(memory.GetHeapReleasedBytes()))
+	fmt.Fprintf(w, "Process memory:\t%s from the OS, %d GCs, %d goroutines\n",
+		units.FormatBytes(memory.GetSysBytes()), memory.GetNumGC(), memory.GetGoroutines())
+	return w.Flush()
+}
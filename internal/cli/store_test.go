@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestPrintStoreStats(t *testing.T) {
+	stats := &pb.StoreStats{
+		Jobs:              12,
+		FinishedJobs:      10,
+		OutputBuffer:      &pb.OutputBuffer{UsedBytes: 3 << 20, BudgetBytes: 1 << 30},
+		CompressedJobs:    4,
+		CompressedBytes:   1 << 20,
+		UncompressedBytes: 8 << 20,
+		Compactions:       3,
+		Memory:            &pb.MemoryStats{Goroutines: 42},
+	}
+
+	var out bytes.Buffer
+	if err := printStoreStats(&out, stats); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{
+		"Jobs:                  12 (10 finished)\n",
+		"Buffered output:       3.0Mi of 1.0Gi\n",
+		"Compressed:            1.0Mi, 8.0Mi uncompressed, in 4 jobs\n",
+		"Compactions:           3, last -\n",
+		"42 goroutines",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"worker/pkg/client"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+// templateFile is the YAML layout accepted by "template create"
+type templateFile struct {
+	Name        string       `yaml:"name"`
+	Description string       `yaml:"description"`
+	Params      []paramEntry `yaml:"params"`
+	Job         jobEntry     `yaml:"job"`
+}
+
+// paramEntry is the YAML layout of a template parameter
+type paramEntry struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Default     string `yaml:"default"`
+	Required    bool   `yaml:"required"`
+}
+
+func (f templateFile) toTemplate() (*pb.JobTemplate, error) {
+	spec, err := f.Job.toRequest()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := &pb.JobTemplate{Name: f.Name, Description: f.Description, Spec: spec}
+	for _, param := range f.Params {
+		tmpl.Params = append(tmpl.Params, &pb.TemplateParam{
+			Name:         param.Name,
+			Description:  param.Description,
+			DefaultValue: param.Default,
+			Required:     param.Required,
+		})
+	}
+	return tmpl, nil
+}
+
+func newTemplateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Store job specs with parameters and run jobs from them",
+		Long: `Store standardized job specs on the worker and run jobs from them by name,
+passing only the values of their parameters.
+
+The command, args, input sources and the values of env, secretEnv and
+labels may hold {{param}} placeholders of the template's parameters.
+
+Example template.yaml:
+  name: train
+  description: Train a model on a dataset
+  params:
+    - name: dataset
+      required: true
+    - name: epochs
+      default: "10"
+  job:
+    command: python3
+    args: ["train.py", "--data=/data/{{dataset}}", "--epochs={{epochs}}"]
+    memory: 4Gi
+    labels:
+      dataset: "{{dataset}}"
+
+Examples:
+  cli template create template.yaml
+  cli template run train -p dataset=mnist -p epochs=20`,
+	}
+
+	var replace bool
+	createCmd := &cobra.Command{
+		Use:   "create <file>",
+		Short: "Store the job template described in a YAML file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTemplateCreate(args[0], replace)
+		},
+	}
+	createCmd.Flags().BoolVar(&replace, "replace", false, "Replace a template of the same name")
+	cmd.AddCommand(createCmd)
+
+	listOutput := &outputFlags{}
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List job templates",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := listOutput.validate(); err != nil {
+				return err
+			}
+			return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+				response, err := c.ListJobTemplates(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to list job templates: %v", err)
+				}
+				switch {
+				case listOutput.structured():
+					return listOutput.printMessage(response)
+				case listOutput.quiet:
+					for _, tmpl := range response.Templates {
+						fmt.Println(tmpl.Name)
+					}
+					return nil
+				}
+				if len(response.Templates) == 0 {
+					fmt.Println("No job templates found")
+					return nil
+				}
+				for _, tmpl := range response.Templates {
+					printTemplate(tmpl)
+				}
+				return nil
+			})
+		},
+	}
+	listOutput.addFlags(listCmd, true)
+	cmd.AddCommand(listCmd)
+
+	var params map[string]string
+	var quiet bool
+	runCmd := &cobra.Command{
+		Use:   "run <template>",
+		Short: "Run a job from a template",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+				response, err := c.RunJobFromTemplate(ctx, args[0], params)
+				if err != nil {
+					return fmt.Errorf("failed to run job from template: %v", err)
+				}
+				if quiet {
+					fmt.Println(response.Id)
+					return nil
+				}
+				fmt.Printf("Job started from template %s:\n", args[0])
+				fmt.Printf("ID: %s\n", response.Id)
+				fmt.Printf("Command: %s\n", strings.Join(append([]string{response.Command}, response.Args...), " "))
+				fmt.Printf("Status: %s\n", response.Status)
+				fmt.Printf("StartTime: %s\n", response.StartTime)
+				printEnv(response.Env)
+				printSecretEnv(response.SecretEnv)
+				return nil
+			})
+		},
+	}
+	runCmd.Flags().StringToStringVarP(&params, "param", "p", nil, "Parameter value as name=value, may be repeated")
+	runCmd.Flags().BoolVarP(&quiet, "quiet", "q", false, "Only print the job ID")
+	cmd.AddCommand(runCmd)
+
+	return cmd
+}
+
+func runTemplateCreate(path string, replace bool) error {
+	var file templateFile
+	if err := readYAMLFile(path, &file); err != nil {
+		return err
+	}
+
+	tmpl, err := file.toTemplate()
+	if err != nil {
+		return fmt.Errorf("job: %v", err)
+	}
+
+	return withGroupClient(func(ctx context.Context, c *client.JobClient) error {
+		stored, err := c.CreateJobTemplate(ctx, tmpl, replace)
+		if err != nil {
+			return fmt.Errorf("failed to create job template: %v", err)
+		}
+		fmt.Printf("Job template stored:\n")
+		printTemplate(stored)
+		return nil
+	})
+}
+
+// printTemplate prints a template with its parameters
+func printTemplate(tmpl *pb.JobTemplate) {
+	command := strings.Join(append([]string{tmpl.GetSpec().GetCommand()}, tmpl.GetSpec().GetArgs()...), " ")
+	fmt.Printf("%s Command: %s\n", tmpl.Name, command)
+	if tmpl.Description != "" {
+		fmt.Printf("  %s\n", tmpl.Description)
+	}
+	for _, param := range tmpl.Params {
+		fmt.Printf("  %s\n", formatTemplateParam(param))
+	}
+}
+
+// formatTemplateParam describes a parameter, e.g. "epochs (default 10)"
+func formatTemplateParam(param *pb.TemplateParam) string {
+	desc := param.Name
+	switch {
+	case param.Required:
+		desc += " (required)"
+	case param.DefaultValue != "":
+		desc += fmt.Sprintf(" (default %s)", param.DefaultValue)
+	}
+	if param.Description != "" {
+		desc += ": " + param.Description
+	}
+	return desc
+}
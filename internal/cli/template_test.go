@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestTemplateFileToTemplate(t *testing.T) {
+	file := templateFile{
+		Name:   "train",
+		Params: []paramEntry{{Name: "dataset", Required: true}, {Name: "epochs", Default: "10"}},
+		Job:    jobEntry{Command: "python3", Args: []string{"train.py", "{{dataset}}"}, Memory: "4Gi"},
+	}
+
+	tmpl, err := file.toTemplate()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tmpl.Spec.Args[1] != "{{dataset}}" || tmpl.Spec.MemoryLimitBytes != 4<<30 {
+		t.Errorf("unexpected spec %+v", tmpl.Spec)
+	}
+	if len(tmpl.Params) != 2 || !tmpl.Params[0].Required || tmpl.Params[1].DefaultValue != "10" {
+		t.Errorf("unexpected params %v", tmpl.Params)
+	}
+
+	file.Job.Memory = "lots"
+	if _, err := file.toTemplate(); err == nil {
+		t.Error("expected an error for an invalid memory limit")
+	}
+}
+
+func TestFormatTemplateParam(t *testing.T) {
+	tests := []struct {
+		param    *pb.TemplateParam
+		expected string
+	}{
+		{&pb.TemplateParam{Name: "dataset", Required: true, Description: "dataset to train on"}, "dataset (required): dataset to train on"},
+		{&pb.TemplateParam{Name: "epochs", DefaultValue: "10"}, "epochs (default 10)"},
+		{&pb.TemplateParam{Name: "tag"}, "tag"},
+	}
+
+	for _, tt := range tests {
+		if got := formatTemplateParam(tt.param); got != tt.expected {
+			t.Errorf("expected %q, got %q", tt.expected, got)
+		}
+	}
+}
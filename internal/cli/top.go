@@ -0,0 +1,579 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"worker/pkg/client"
+	"worker/pkg/units"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc/status"
+	pb "worker/api/gen"
+)
+
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	leaveAltScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+	reverseVideo   = "\x1b[7m"
+	resetVideo     = "\x1b[0m"
+
+	// lines of output kept for the log view
+	maxTopLogLines = 1000
+)
+
+func newTopCmd() *cobra.Command {
+	var interval int32
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "Show jobs and their resource usage in a live dashboard",
+		Long: `Show jobs with their live CPU, memory and IO usage.
+
+Keys:
+  up/down, k/j   Select a job
+  l, enter       View the logs of the selected job (esc to go back)
+  s              Stop the selected job
+  g, t           View usage summed per job group or tenant (esc to go back)
+  q              Quit`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTop(interval)
+		},
+	}
+
+	cmd.Flags().Int32VarP(&interval, "interval", "n", 2, "Seconds between updates")
+
+	return cmd
+}
+
+type topView int
+
+const (
+	topTable topView = iota
+	topLogs
+	topGroups
+	topTenants
+)
+
+type topAction int
+
+const (
+	topNone topAction = iota
+	topQuit
+	topOpenLogs
+	topCloseLogs
+	topStop
+)
+
+// topModel is the state of the dashboard. Events change it and render draws it.
+type topModel struct {
+	server   string
+	snapshot *pb.JobMetricsSnapshot
+	selected string // ID of the selected job, kept across snapshots
+	view     topView
+	logJob   string
+	logs     []string // the last line may still be incomplete
+	confirm  bool     // waiting for the stop confirmation
+	message  string
+	width    int
+	height   int
+}
+
+type logChunk struct {
+	jobID string
+	data  []byte
+}
+
+func runTop(interval int32) error {
+	if interval < 1 {
+		return fmt.Errorf("invalid interval: %d", interval)
+	}
+
+	restore, err := enterRawTerminal()
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream, err := jobClient.StreamJobMetrics(ctx, nil, interval)
+	if err != nil {
+		return err
+	}
+
+	snapshots := make(chan *pb.JobMetricsSnapshot)
+	streamErr := make(chan error, 1)
+	go func() {
+		for {
+			snapshot, e := stream.Recv()
+			if e != nil {
+				streamErr <- e
+				return
+			}
+			select {
+			case snapshots <- snapshot:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	keys := make(chan string)
+	go readKeys(os.Stdin, keys)
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM, syscall.SIGWINCH)
+	defer signal.Stop(sigCh)
+
+	logChunks := make(chan logChunk)
+	messages := make(chan string)
+	stopLogs := context.CancelFunc(func() {})
+	defer func() { stopLogs() }()
+
+	m := &topModel{server: cfg.ServerAddr}
+	m.width, m.height = terminalSize()
+
+	fmt.Print(enterAltScreen)
+	defer fmt.Print(leaveAltScreen)
+
+	for {
+		fmt.Print(m.render())
+
+		select {
+		case snapshot := <-snapshots:
+			m.setSnapshot(snapshot)
+		case e := <-streamErr:
+			if s, ok := status.FromError(e); ok {
+				return fmt.Errorf("metrics stream error: %v", s.Message())
+			}
+			return fmt.Errorf("metrics stream error: %v", e)
+		case chunk := <-logChunks:
+			if m.view == topLogs && chunk.jobID == m.logJob {
+				m.appendLogs(chunk.data)
+			}
+		case msg := <-messages:
+			m.message = msg
+		case sig := <-sigCh:
+			if sig != syscall.SIGWINCH {
+				return nil
+			}
+			m.width, m.height = terminalSize()
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+			switch m.handleKey(key) {
+			case topQuit:
+				return nil
+			case topOpenLogs:
+				stopLogs = streamTopLogs(ctx, jobClient, m.logJob, logChunks)
+			case topCloseLogs:
+				stopLogs()
+			case topStop:
+				go stopTopJob(ctx, jobClient, m.selected, messages)
+			}
+		}
+	}
+}
+
+// handleKey updates the model for a key press and returns what the caller
+// has to do about it
+func (m *topModel) handleKey(key string) topAction {
+	m.message = ""
+
+	if m.view == topLogs {
+		switch key {
+		case "esc", "q":
+			m.view = topTable
+			m.logJob = ""
+			m.logs = nil
+			return topCloseLogs
+		}
+		return topNone
+	}
+
+	if m.view == topGroups || m.view == topTenants {
+		switch key {
+		case "esc", "q":
+			m.view = topTable
+		case "g":
+			m.view = topGroups
+		case "t":
+			m.view = topTenants
+		}
+		return topNone
+	}
+
+	if m.confirm {
+		m.confirm = false
+		if key == "y" || key == "Y" {
+			return topStop
+		}
+		return topNone
+	}
+
+	switch key {
+	case "q":
+		return topQuit
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "l", "enter":
+		if m.selectedJob() != nil {
+			m.view = topLogs
+			m.logJob = m.selected
+			m.logs = nil
+			return topOpenLogs
+		}
+	case "g":
+		m.view = topGroups
+	case "t":
+		m.view = topTenants
+	case "s":
+		if job := m.selectedJob(); job != nil && job.Status == "RUNNING" {
+			m.confirm = true
+		} else if job != nil {
+			m.message = fmt.Sprintf("Job %s is not running", job.Id)
+		}
+	}
+	return topNone
+}
+
+// setSnapshot replaces the jobs shown. The selection follows the job ID, or
+// stays at the same row if the selected job is gone.
+func (m *topModel) setSnapshot(snapshot *pb.JobMetricsSnapshot) {
+	index := m.selectedIndex()
+	m.snapshot = snapshot
+
+	if m.selectedIndex() >= 0 || len(snapshot.Jobs) == 0 {
+		return
+	}
+	index = max(0, min(index, len(snapshot.Jobs)-1))
+	m.selected = snapshot.Jobs[index].Id
+}
+
+func (m *topModel) move(delta int) {
+	if m.snapshot == nil || len(m.snapshot.Jobs) == 0 {
+		return
+	}
+	index := max(0, min(m.selectedIndex()+delta, len(m.snapshot.Jobs)-1))
+	m.selected = m.snapshot.Jobs[index].Id
+}
+
+func (m *topModel) selectedIndex() int {
+	if m.snapshot == nil {
+		return -1
+	}
+	for i, job := range m.snapshot.Jobs {
+		if job.Id == m.selected {
+			return i
+		}
+	}
+	return -1
+}
+
+func (m *topModel) selectedJob() *pb.JobMetrics {
+	if i := m.selectedIndex(); i >= 0 {
+		return m.snapshot.Jobs[i]
+	}
+	return nil
+}
+
+func (m *topModel) appendLogs(data []byte) {
+	lines := strings.Split(string(data), "\n")
+	if n := len(m.logs); n > 0 {
+		m.logs[n-1] += lines[0]
+		lines = lines[1:]
+	}
+	m.logs = append(m.logs, lines...)
+	if len(m.logs) > maxTopLogLines {
+		m.logs = m.logs[len(m.logs)-maxTopLogLines:]
+	}
+}
+
+func (m *topModel) render() string {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+
+	// every line but the header, the table heading and the footer
+	rows := max(1, m.height-3)
+
+	if m.view == topLogs {
+		fmt.Fprintf(&b, "%s\n", m.fit(fmt.Sprintf("Logs for job %s", m.logJob)))
+		logs := m.logs
+		if len(logs) > rows+1 {
+			logs = logs[len(logs)-rows-1:]
+		}
+		for _, line := range logs {
+			fmt.Fprintf(&b, "%s\n", m.fit(line))
+		}
+		for i := len(logs); i < rows+1; i++ {
+			b.WriteString("\n")
+		}
+		b.WriteString(m.fit("esc/q back"))
+		return b.String()
+	}
+
+	if m.view == topGroups || m.view == topTenants {
+		return m.renderTotals(&b, rows)
+	}
+
+	total, running := 0, 0
+	timestamp := "waiting for data..."
+	if m.snapshot != nil {
+		timestamp = m.snapshot.Timestamp
+		total = len(m.snapshot.Jobs)
+		for _, job := range m.snapshot.Jobs {
+			if job.Status == "RUNNING" {
+				running++
+			}
+		}
+	}
+	fmt.Fprintf(&b, "%s\n", m.fit(fmt.Sprintf("worker top - %s - %s - %d jobs, %d running", m.server, timestamp, total, running)))
+	fmt.Fprintf(&b, "%s\n", m.fit(fmt.Sprintf("%-8s %-12s %7s %6s %18s %10s %10s %8s %-9s %s",
+		"ID", "STATUS", "CPU%", "THR%", "MEMORY", "IO READ", "IO WRITE", "RESTARTS", "HEALTH", "COMMAND")))
+
+	var jobs []*pb.JobMetrics
+	if m.snapshot != nil {
+		jobs = m.snapshot.Jobs
+	}
+	offset := max(0, m.selectedIndex()-rows+1)
+	for i := offset; i < len(jobs) && i < offset+rows; i++ {
+		line := m.fit(formatTopRow(jobs[i]))
+		if jobs[i].Id == m.selected {
+			line = reverseVideo + line + strings.Repeat(" ", max(0, m.width-len([]rune(line)))) + resetVideo
+		}
+		fmt.Fprintf(&b, "%s\n", line)
+	}
+	for i := len(jobs) - offset; i < rows; i++ {
+		b.WriteString("\n")
+	}
+
+	switch {
+	case m.confirm:
+		b.WriteString(m.fit(fmt.Sprintf("Stop job %s? [y/N]", m.selected)))
+	case m.message != "":
+		b.WriteString(m.fit(m.message))
+	default:
+		b.WriteString(m.fit("up/down select  l logs  s stop  g groups  t tenants  q quit"))
+	}
+
+	return b.String()
+}
+
+// renderTotals draws the usage summed per job group or tenant
+func (m *topModel) renderTotals(b *strings.Builder, rows int) string {
+	title, heading, totals := "Usage per job group", "GROUP", m.snapshot.GetGroups()
+	if m.view == topTenants {
+		title, heading, totals = "Usage per tenant", "TENANT", m.snapshot.GetTenants()
+	}
+
+	fmt.Fprintf(b, "%s\n", m.fit(fmt.Sprintf("worker top - %s - %s", m.server, title)))
+	fmt.Fprintf(b, "%s\n", m.fit(fmt.Sprintf("%-16s %6s %7s %10s %10s %10s", heading, "JOBS", "CPU%", "MEMORY", "IO READ", "IO WRITE")))
+	for i := 0; i < rows; i++ {
+		if i < len(totals) {
+			t := totals[i]
+			fmt.Fprintf(b, "%s", m.fit(fmt.Sprintf("%-16s %6d %7.1f %10s %10s %10s", orDash(t.Name), t.Jobs, t.CpuPercent,
+				units.FormatBytes(t.MemoryBytes), units.FormatBytes(t.IoReadBytes), units.FormatBytes(t.IoWriteBytes))))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString(m.fit("g groups  t tenants  esc/q back"))
+	return b.String()
+}
+
+func formatTopRow(job *pb.JobMetrics) string {
+	cpu, throttled, memory, ioRead, ioWrite := "-", "-", "-", "-", "-"
+	if job.Status == "RUNNING" {
+		cpu = fmt.Sprintf("%.1f", job.CpuPercent)
+		if job.CpuLimitMillis > 0 {
+			throttled = fmt.Sprintf("%.0f", job.ThrottledPercent)
+		}
+		memory = units.FormatBytes(job.MemoryBytes)
+		if job.MemoryLimitBytes > 0 {
+			memory += "/" + units.FormatBytes(job.MemoryLimitBytes)
+		}
+		ioRead = units.FormatBytes(job.IoReadBytes)
+		ioWrite = units.FormatBytes(job.IoWriteBytes)
+	}
+
+	return fmt.Sprintf("%-8s %-12s %7s %6s %18s %10s %10s %8d %-9s %s",
+		job.Id, job.Status, cpu, throttled, memory, ioRead, ioWrite, job.Restarts, orDash(job.Health),
+		strings.Join(append([]string{job.Command}, job.Args...), " "))
+}
+
+// fit cuts s to the terminal width
+func (m *topModel) fit(s string) string {
+	if r := []rune(s); m.width > 0 && len(r) > m.width {
+		return string(r[:m.width])
+	}
+	return s
+}
+
+// streamTopLogs sends the job's output to chunks in the background until the
+// returned function is called
+func streamTopLogs(parent context.Context, jobClient *client.JobClient, jobID string, chunks chan<- logChunk) context.CancelFunc {
+	ctx, cancel := context.WithCancel(parent)
+	go forwardTopLogs(ctx, jobClient, jobID, chunks)
+	return cancel
+}
+
+func forwardTopLogs(ctx context.Context, jobClient *client.JobClient, jobID string, chunks chan<- logChunk) {
+	send := func(data []byte) bool {
+		select {
+		case chunks <- logChunk{jobID: jobID, data: data}:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	stream, err := jobClient.GetJobLogs(ctx, jobID)
+	if err != nil {
+		send([]byte(fmt.Sprintf("[%v]\n", err)))
+		return
+	}
+
+	for {
+		chunk, e := stream.Recv()
+		if e == io.EOF {
+			send([]byte("[end of output]\n"))
+			return
+		}
+		if e != nil {
+			if ctx.Err() == nil {
+				if s, ok := status.FromError(e); ok {
+					e = fmt.Errorf("%s", s.Message())
+				}
+				send([]byte(fmt.Sprintf("[log stream error: %v]\n", e)))
+			}
+			return
+		}
+		if !send(chunk.Payload) {
+			return
+		}
+	}
+}
+
+func stopTopJob(ctx context.Context, jobClient *client.JobClient, jobID string, messages chan<- string) {
+	msg := fmt.Sprintf("Job %s stopped", jobID)
+	if _, err := jobClient.StopJob(ctx, &pb.StopJobReq{Id: jobID}); err != nil {
+		msg = fmt.Sprintf("Failed to stop job %s: %v", jobID, err)
+	}
+
+	select {
+	case messages <- msg:
+	case <-ctx.Done():
+	}
+}
+
+// readKeys sends the keys read from r until it fails
+func readKeys(r io.Reader, keys chan<- string) {
+	defer close(keys)
+
+	buf := make([]byte, 32)
+	for {
+		n, err := r.Read(buf)
+		if err != nil {
+			return
+		}
+		for _, key := range decodeKeys(buf[:n]) {
+			keys <- key
+		}
+	}
+}
+
+// decodeKeys turns terminal input into key names: arrow keys become "up" and
+// "down", escape "esc", return "enter" and anything else the character itself
+func decodeKeys(input []byte) []string {
+	sequences := []struct {
+		seq string
+		key string
+	}{
+		{"\x1b[A", "up"}, {"\x1bOA", "up"},
+		{"\x1b[B", "down"}, {"\x1bOB", "down"},
+	}
+
+	var keys []string
+	for len(input) > 0 {
+		matched := false
+		for _, s := range sequences {
+			if bytes.HasPrefix(input, []byte(s.seq)) {
+				keys = append(keys, s.key)
+				input = input[len(s.seq):]
+				matched = true
+				break
+			}
+		}
+		if matched {
+			continue
+		}
+
+		switch input[0] {
+		case 0x1b:
+			keys = append(keys, "esc")
+		case '\r', '\n':
+			keys = append(keys, "enter")
+		default:
+			keys = append(keys, string(input[0]))
+		}
+		input = input[1:]
+	}
+	return keys
+}
+
+// enterRawTerminal switches the terminal to unbuffered input without echo and
+// returns a function restoring the previous settings. Signals such as Ctrl+C
+// keep working.
+func enterRawTerminal() (func(), error) {
+	saved, err := stty("-g")
+	if err != nil {
+		return nil, fmt.Errorf("top needs an interactive terminal: %v", err)
+	}
+	if _, err := stty("-icanon", "-echo", "min", "1"); err != nil {
+		return nil, fmt.Errorf("failed to configure terminal: %v", err)
+	}
+	return func() {
+		stty(strings.TrimSpace(saved))
+	}, nil
+}
+
+// terminalSize returns the terminal width and height, 80x24 if unknown
+func terminalSize() (int, int) {
+	out, err := stty("size")
+	if err == nil {
+		if fields := strings.Fields(out); len(fields) == 2 {
+			rows, errRows := strconv.Atoi(fields[0])
+			cols, errCols := strconv.Atoi(fields[1])
+			if errRows == nil && errCols == nil && rows > 0 && cols > 0 {
+				return cols, rows
+			}
+		}
+	}
+	return 80, 24
+}
+
+func stty(args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = os.Stdin
+	out, err := cmd.Output()
+	return string(out), err
+}
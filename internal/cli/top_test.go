@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestDecodeKeys(t *testing.T) {
+	got := decodeKeys([]byte("\x1b[Aj\x1b[B\x1bOAl\r\x1bq"))
+	want := []string{"up", "j", "down", "up", "l", "enter", "esc", "q"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decodeKeys() = %v, want %v", got, want)
+	}
+}
+
+func topSnapshot(ids ...string) *pb.JobMetricsSnapshot {
+	snapshot := &pb.JobMetricsSnapshot{Timestamp: "2025-01-01T00:00:00Z"}
+	for _, id := range ids {
+		snapshot.Jobs = append(snapshot.Jobs, &pb.JobMetrics{Id: id, Command: "sleep", Status: "RUNNING"})
+	}
+	return snapshot
+}
+
+func TestTopModelSelection(t *testing.T) {
+	m := &topModel{width: 120, height: 24}
+
+	m.setSnapshot(topSnapshot("1", "2", "3"))
+	if m.selected != "1" {
+		t.Fatalf("expected first job selected, got %q", m.selected)
+	}
+
+	m.handleKey("down")
+	m.handleKey("j")
+	m.handleKey("down")
+	if m.selected != "3" {
+		t.Errorf("expected selection to stop at the last job, got %q", m.selected)
+	}
+
+	// the selection follows the job when the order changes
+	m.setSnapshot(topSnapshot("3", "1", "2"))
+	if m.selected != "3" {
+		t.Errorf("expected job 3 to stay selected, got %q", m.selected)
+	}
+
+	// and stays on the same row when the job is gone
+	m.handleKey("down")
+	m.setSnapshot(topSnapshot("3", "2"))
+	if m.selected != "2" {
+		t.Errorf("expected job 2 selected, got %q", m.selected)
+	}
+}
+
+func TestTopModelKeys(t *testing.T) {
+	m := &topModel{width: 120, height: 24}
+	m.setSnapshot(topSnapshot("1"))
+
+	if action := m.handleKey("s"); action != topNone || !m.confirm {
+		t.Fatalf("expected stop confirmation, got action %v confirm %v", action, m.confirm)
+	}
+	if action := m.handleKey("y"); action != topStop {
+		t.Errorf("expected stop, got %v", action)
+	}
+
+	m.handleKey("s")
+	if action := m.handleKey("n"); action != topNone || m.confirm {
+		t.Errorf("expected the stop to be cancelled, got %v", action)
+	}
+
+	if action := m.handleKey("l"); action != topOpenLogs || m.view != topLogs || m.logJob != "1" {
+		t.Fatalf("expected logs of job 1, got action %v view %v job %q", action, m.view, m.logJob)
+	}
+	if action := m.handleKey("q"); action != topCloseLogs || m.view != topTable {
+		t.Errorf("expected q to leave the log view, got %v", action)
+	}
+	if action := m.handleKey("q"); action != topQuit {
+		t.Errorf("expected quit, got %v", action)
+	}
+}
+
+func TestTopModelStopFinishedJob(t *testing.T) {
+	m := &topModel{width: 120, height: 24}
+	snapshot := topSnapshot("1")
+	snapshot.Jobs[0].Status = "COMPLETED"
+	m.setSnapshot(snapshot)
+
+	m.handleKey("s")
+	if m.confirm || !strings.Contains(m.message, "not running") {
+		t.Errorf("expected a message instead of a confirmation, got confirm %v message %q", m.confirm, m.message)
+	}
+}
+
+func TestTopModelAppendLogs(t *testing.T) {
+	m := &topModel{}
+	m.appendLogs([]byte("one\ntw"))
+	m.appendLogs([]byte("o\nthree\n"))
+
+	want := []string{"one", "two", "three", ""}
+	if !reflect.DeepEqual(m.logs, want) {
+		t.Errorf("logs = %q, want %q", m.logs, want)
+	}
+}
+
+func TestTopModelRender(t *testing.T) {
+	m := &topModel{server: "localhost:50051", width: 120, height: 10}
+	snapshot := topSnapshot("1", "2")
+	snapshot.Jobs[0].CpuPercent = 12.5
+	snapshot.Jobs[0].MemoryBytes = 10 * 1024 * 1024
+	snapshot.Jobs[0].MemoryLimitBytes = 512 << 20
+	snapshot.Jobs[1].Status = "COMPLETED"
+	m.setSnapshot(snapshot)
+
+	out := m.render()
+	for _, want := range []string{"2 jobs, 1 running", "12.5", "10.0Mi/512.0Mi", reverseVideo + "1 "} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() missing %q:\n%s", want, out)
+		}
+	}
+	if lines := strings.Count(out, "\n"); lines != m.height-1 {
+		t.Errorf("render() wrote %d lines, want %d", lines, m.height-1)
+	}
+}
+
+func TestTopModelTotals(t *testing.T) {
+	m := &topModel{server: "localhost:50051", width: 120, height: 10}
+	snapshot := topSnapshot("1", "2")
+	snapshot.Tenants = []*pb.AggregateMetrics{{Name: "", Jobs: 1}, {Name: "web", Jobs: 2, CpuPercent: 150, MemoryBytes: 1 << 30}}
+	m.setSnapshot(snapshot)
+
+	m.handleKey("t")
+	out := m.render()
+	for _, want := range []string{"Usage per tenant", "web", "150.0", "1.0Gi"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render() missing %q:\n%s", want, out)
+		}
+	}
+	if lines := strings.Count(out, "\n"); lines != m.height-1 {
+		t.Errorf("render() wrote %d lines, want %d", lines, m.height-1)
+	}
+
+	m.handleKey("g")
+	if out := m.render(); !strings.Contains(out, "Usage per job group") || strings.Contains(out, "web") {
+		t.Errorf("expected the empty group view:\n%s", out)
+	}
+
+	if m.handleKey("q") != topNone || m.view != topTable {
+		t.Error("expected q to go back to the jobs")
+	}
+}
@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+	"worker/pkg/units"
+
+	"github.com/spf13/cobra"
+	pb "worker/api/gen"
+)
+
+const outputCSV = "csv"
+
+type usageCmdParams struct {
+	tenant string
+	since  time.Duration
+	from   string
+	until  string
+	jobs   bool
+	format string
+}
+
+func newUsageCmd() *cobra.Command {
+	params := &usageCmdParams{}
+
+	cmd := &cobra.Command{
+		Use:   "usage",
+		Short: "Report the resources jobs consumed, per tenant",
+		Long: `Report the CPU time, memory, IO and wall time jobs consumed, totalled per
+tenant, for chargeback. A job counts in the range it ended in; running jobs
+are included with their usage so far. The worker must have usage accounting
+enabled; the tenant of a job is the value of its tenant label.
+
+Examples:
+  cli usage --since 24h
+  cli usage --tenant web --from 2024-05-01T00:00:00Z --until 2024-06-01T00:00:00Z
+  cli usage --since 720h --jobs -o csv > usage.csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runUsage(params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.tenant, "tenant", "", "Only report this tenant")
+	cmd.Flags().DurationVar(&params.since, "since", 0, "Report jobs that ended within this duration")
+	cmd.Flags().StringVar(&params.from, "from", "", "Report jobs that ended at or after this RFC 3339 time")
+	cmd.Flags().StringVar(&params.until, "until", "", "Report jobs that ended before this RFC 3339 time")
+	cmd.Flags().BoolVar(&params.jobs, "jobs", false, "List each job instead of the tenant totals")
+	cmd.Flags().StringVarP(&params.format, "output", "o", "", "Output format: csv, json or yaml")
+
+	return cmd
+}
+
+func runUsage(params *usageCmdParams) error {
+	switch params.format {
+	case "", outputCSV, outputJSON, outputYAML:
+	default:
+		return fmt.Errorf("invalid output format %q, expected csv, json or yaml", params.format)
+	}
+	if params.since > 0 && params.from != "" {
+		return fmt.Errorf("--since cannot be combined with --from")
+	}
+
+	req := &pb.GetUsageReportReq{Tenant: params.tenant, StartTime: params.from, EndTime: params.until}
+	if params.since > 0 {
+		req.StartTime = time.Now().Add(-params.since).Format(time.RFC3339)
+	}
+
+	jobClient, err := newJobClient()
+	if err != nil {
+		return err
+	}
+	defer jobClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	report, err := jobClient.GetUsageReport(ctx, req)
+	if err != nil {
+		return fmt.Errorf("failed to get usage report: %v", err)
+	}
+
+	switch params.format {
+	case outputJSON, outputYAML:
+		return (&outputFlags{format: params.format}).printMessage(report)
+	case outputCSV:
+		return writeUsageCSV(os.Stdout, report, params.jobs)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if params.jobs {
+		fmt.Fprintln(w, "ID\tTENANT\tSTATUS\tSTART\tEND\tCPU-SECONDS\tMEMORY-GIB-HOURS\tIO-READ\tIO-WRITE\tWALL")
+		for _, job := range report.Jobs {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%.1f\t%.3f\t%s\t%s\t%s\n",
+				job.Id, orDash(job.Tenant), job.Status, job.StartTime, orDash(job.EndTime),
+				job.CpuSeconds, gibHours(job.MemoryByteSeconds), units.FormatBytes(job.IoReadBytes),
+				units.FormatBytes(job.IoWriteBytes), formatSeconds(job.WallSeconds))
+		}
+	} else {
+		fmt.Fprintln(w, "TENANT\tJOBS\tCPU-SECONDS\tMEMORY-GIB-HOURS\tIO-READ\tIO-WRITE\tWALL")
+		for _, t := range report.Tenants {
+			fmt.Fprintf(w, "%s\t%d\t%.1f\t%.3f\t%s\t%s\t%s\n",
+				orDash(t.Tenant), t.Jobs, t.CpuSeconds, gibHours(t.MemoryByteSeconds),
+				units.FormatBytes(t.IoReadBytes), units.FormatBytes(t.IoWriteBytes), formatSeconds(t.WallSeconds))
+		}
+	}
+	return w.Flush()
+}
+
+// writeUsageCSV writes the tenant totals, or each job, with a header row.
+// Values are in base units so spreadsheets can price them.
+func writeUsageCSV(out io.Writer, report *pb.UsageReport, jobs bool) error {
+	w := csv.NewWriter(out)
+	float := func(f float64) string { return strconv.FormatFloat(f, 'f', -1, 64) }
+	integer := func(n int64) string { return strconv.FormatInt(n, 10) }
+
+	if jobs {
+		_ = w.Write([]string{"id", "tenant", "command", "status", "start_time", "end_time", "cpu_seconds", "memory_byte_seconds", "io_read_bytes", "io_write_bytes", "wall_seconds"})
+		for _, job := range report.Jobs {
+			_ = w.Write([]string{job.Id, job.Tenant, job.Command, job.Status, job.StartTime, job.EndTime,
+				float(job.CpuSeconds), float(job.MemoryByteSeconds), integer(job.IoReadBytes), integer(job.IoWriteBytes), float(job.WallSeconds)})
+		}
+	} else {
+		_ = w.Write([]string{"tenant", "jobs", "cpu_seconds", "memory_byte_seconds", "io_read_bytes", "io_write_bytes", "wall_seconds"})
+		for _, t := range report.Tenants {
+			_ = w.Write([]string{t.Tenant, integer(int64(t.Jobs)),
+				float(t.CpuSeconds), float(t.MemoryByteSeconds), integer(t.IoReadBytes), integer(t.IoWriteBytes), float(t.WallSeconds)})
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func gibHours(byteSeconds float64) float64 {
+	return byteSeconds / (1 << 30) / 3600
+}
+
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
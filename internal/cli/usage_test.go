@@ -0,0 +1,35 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestWriteUsageCSV(t *testing.T) {
+	report := &pb.UsageReport{
+		Jobs:    []*pb.JobUsage{{Id: "1", Tenant: "web", Command: "echo, twice", Status: "COMPLETED", CpuSeconds: 1.5, IoReadBytes: 10, WallSeconds: 2}},
+		Tenants: []*pb.TenantUsage{{Tenant: "web", Jobs: 1, CpuSeconds: 1.5, MemoryByteSeconds: 1e12, IoReadBytes: 10, WallSeconds: 2}},
+	}
+
+	var out bytes.Buffer
+	if err := writeUsageCSV(&out, report, false); err != nil {
+		t.Fatal(err)
+	}
+	want := "tenant,jobs,cpu_seconds,memory_byte_seconds,io_read_bytes,io_write_bytes,wall_seconds\n" +
+		"web,1,1.5,1000000000000,10,0,2\n"
+	if out.String() != want {
+		t.Errorf("unexpected totals:\n%s", out.String())
+	}
+
+	out.Reset()
+	if err := writeUsageCSV(&out, report, true); err != nil {
+		t.Fatal(err)
+	}
+	want = "id,tenant,command,status,start_time,end_time,cpu_seconds,memory_byte_seconds,io_read_bytes,io_write_bytes,wall_seconds\n" +
+		"1,web,\"echo, twice\",COMPLETED,,,1.5,0,10,0,2\n"
+	if out.String() != want {
+		t.Errorf("unexpected jobs:\n%s", out.String())
+	}
+}
@@ -0,0 +1,8 @@
+//go:build !linux
+
+package modes
+
+// onCgroup2 is false, cgroups being Linux only
+func onCgroup2(path string) bool {
+	return false
+}
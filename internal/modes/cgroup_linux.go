@@ -0,0 +1,13 @@
+//go:build linux
+
+package modes
+
+import "golang.org/x/sys/unix"
+
+// onCgroup2 reports whether path is on a cgroup v2 filesystem, rather than a
+// directory made on the tmpfs of a cgroup v1 host, whose cgroup.procs takes
+// any write without moving the process anywhere
+func onCgroup2(path string) bool {
+	var fs unix.Statfs_t
+	return unix.Statfs(path, &fs) == nil && fs.Type == unix.CGROUP2_SUPER_MAGIC
+}
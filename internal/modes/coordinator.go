@@ -0,0 +1,65 @@
+package modes
+
+import (
+	"context"
+	"fmt"
+	"worker/internal/worker/auth"
+	"worker/internal/worker/federation"
+	"worker/internal/worker/server"
+	"worker/internal/worker/tracing"
+	"worker/pkg/client"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// RunCoordinator serves the job API for the configured fleet of workers,
+// dispatching jobs to them instead of running any itself
+func RunCoordinator(cfg *config.Config) error {
+	log := logger.WithField("mode", "coordinator")
+
+	log.Info("starting coordinator",
+		"address", cfg.GetServerAddress(),
+		"workers", len(cfg.Coordinator.Workers))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	// The coordinator calls the workers as an admin client
+	files := client.TLSFiles{
+		CertPath: cfg.Security.ClientCertPath,
+		KeyPath:  cfg.Security.ClientKeyPath,
+		CAPath:   cfg.Security.CACertPath,
+	}
+	fleet, err := federation.NewFleet(cfg.Coordinator, federation.TLSDialer(files))
+	if err != nil {
+		return fmt.Errorf("failed to connect to the fleet: %w", err)
+	}
+	defer fleet.Close()
+
+	authorization := auth.NewGrpcAuthorization()
+	coordinator := federation.NewCoordinator(authorization, fleet, cfg.Coordinator.DispatchTimeout)
+	registry := federation.NewRegistry(authorization, fleet)
+	grpcServer, err := server.StartCoordinatorServer(coordinator, registry, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+
+	log.Info("coordinator started successfully", "address", cfg.GetServerAddress())
+
+	awaitShutdown()
+	log.Info("received shutdown signal, stopping coordinator...")
+
+	grpcServer.GracefulStop()
+
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer flushCancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		log.Warn("failed to flush traces", "error", err)
+	}
+
+	log.Info("coordinator stopped gracefully")
+
+	return nil
+}
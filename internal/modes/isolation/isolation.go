@@ -2,6 +2,7 @@ package isolation
 
 import (
 	"fmt"
+	"os"
 	"runtime"
 	"strconv"
 	"strings"
@@ -43,6 +44,49 @@ func (i *Isolator) Setup() error {
 	}
 }
 
+// MountWorkspace makes the job workspace the working directory of the job
+func MountWorkspace(logger *logger.Logger, hostDir, mountPath string) error {
+	p := platform.NewPlatform()
+	isolator := NewIsolator(p, logger)
+	return isolator.MountWorkspace(hostDir, mountPath)
+}
+
+// MountWorkspace bind-mounts hostDir at mountPath inside the job's mount namespace
+// and changes into it. Without a mount path, or off Linux, the job runs from hostDir.
+func (i *Isolator) MountWorkspace(hostDir, mountPath string) error {
+	workDir := hostDir
+
+	if runtime.GOOS == "linux" && mountPath != "" {
+		if err := i.bindWorkspace(hostDir, mountPath); err != nil {
+			// the job still gets its own directory, just under the host path
+			i.logger.Warn("workspace bind mount failed, using host path", "mountPath", mountPath, "error", err)
+		} else {
+			workDir = mountPath
+		}
+	}
+
+	if err := os.Chdir(workDir); err != nil {
+		return fmt.Errorf("failed to enter workspace %s: %w", workDir, err)
+	}
+
+	i.logger.Debug("job workspace ready", "hostDir", hostDir, "workDir", workDir)
+	return nil
+}
+
+func (i *Isolator) bindWorkspace(hostDir, mountPath string) error {
+	if err := i.platform.MkdirAll(mountPath, 0755); err != nil {
+		return fmt.Errorf("failed to create mount point: %w", err)
+	}
+
+	// mounts were made private during Setup, so the bind stays inside the job namespace
+	err := i.platform.Mount(hostDir, mountPath, "", 0x1000, "") // 0x1000 for platform.MountBind
+	if err != nil {
+		return fmt.Errorf("bind mount failed: %w", err)
+	}
+
+	return nil
+}
+
 // setupLinux sets up Linux-specific isolation using platform abstraction
 func (i *Isolator) setupLinux() error {
 	pid := i.platform.Getpid()
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package isolation
+
+import (
+	"fmt"
+	"runtime"
+
+	"worker/internal/worker/domain"
+	"worker/pkg/logger"
+)
+
+// InstallSeccomp fails for any mode but none, seccomp being Linux only
+func InstallSeccomp(logger *logger.Logger, mode domain.SeccompMode) error {
+	if mode == domain.SeccompNone {
+		return nil
+	}
+	return fmt.Errorf("seccomp is not available on %s", runtime.GOOS)
+}
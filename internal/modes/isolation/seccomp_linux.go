@@ -0,0 +1,71 @@
+//go:build linux
+
+package isolation
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+	"worker/internal/worker/domain"
+	"worker/pkg/logger"
+)
+
+// auditFilter is a seccomp program allowing every syscall with
+// SECCOMP_RET_LOG, so the kernel writes a SECCOMP audit record for each one
+var auditFilter = []unix.SockFilter{
+	{Code: unix.BPF_RET | unix.BPF_K, K: unix.SECCOMP_RET_LOG},
+}
+
+// InstallSeccomp loads the seccomp filter of mode into the calling process,
+// where it stays across the exec of the job and is inherited by everything
+// the job starts. It runs last before the exec, since the filter also covers
+// what init does from then on.
+func InstallSeccomp(logger *logger.Logger, mode domain.SeccompMode) error {
+	switch mode {
+	case domain.SeccompNone:
+		return nil
+	case domain.SeccompAudit:
+		if err := loadFilter(auditFilter); err != nil {
+			return fmt.Errorf("failed to load seccomp audit filter: %w", err)
+		}
+		logger.Debug("seccomp audit filter loaded, syscalls are logged as SECCOMP audit records")
+		return nil
+	default:
+		return fmt.Errorf("unknown seccomp mode %q", mode)
+	}
+}
+
+// loadFilter installs a filter on every thread of the process. Without
+// CAP_SYS_ADMIN the kernel only accepts a filter once the process can no
+// longer gain privileges, so no_new_privs is set only when it is needed and
+// setuid binaries keep working for a privileged init.
+func loadFilter(filter []unix.SockFilter) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+
+	prog := unix.SockFprog{Len: uint16(len(filter)), Filter: &filter[0]}
+	err := seccompSetFilter(&prog)
+	if errors.Is(err, unix.EACCES) {
+		if err := unix.Prctl(unix.PR_SET_NO_NEW_PRIVS, 1, 0, 0, 0); err != nil {
+			return fmt.Errorf("failed to set no_new_privs: %w", err)
+		}
+		err = seccompSetFilter(&prog)
+	}
+	return err
+}
+
+func seccompSetFilter(prog *unix.SockFprog) error {
+	tid, _, errno := unix.RawSyscall(unix.SYS_SECCOMP, unix.SECCOMP_SET_MODE_FILTER,
+		unix.SECCOMP_FILTER_FLAG_TSYNC, uintptr(unsafe.Pointer(prog)))
+	if errno != 0 {
+		return errno
+	}
+	// with TSYNC a positive result is a thread the filter could not be applied to
+	if tid != 0 {
+		return fmt.Errorf("thread %d could not take the filter", tid)
+	}
+	return nil
+}
@@ -0,0 +1,19 @@
+//go:build !linux
+
+package isolation
+
+import (
+	"fmt"
+	"runtime"
+	"time"
+
+	"worker/pkg/logger"
+)
+
+// SetupTimeNamespace fails for any offset but 0, time namespaces being Linux only
+func SetupTimeNamespace(logger *logger.Logger, offset time.Duration) error {
+	if offset == 0 {
+		return nil
+	}
+	return fmt.Errorf("time namespaces are not available on %s", runtime.GOOS)
+}
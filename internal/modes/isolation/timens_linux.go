@@ -0,0 +1,43 @@
+//go:build linux
+
+package isolation
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"time"
+
+	"golang.org/x/sys/unix"
+	"worker/pkg/logger"
+)
+
+// SetupTimeNamespace creates a time namespace whose monotonic and boottime
+// clocks run offset ahead of the host's, behind it when negative. The calling
+// process stays in the host namespace and the exec of the job moves it into
+// the new one. /proc/self/timens_offsets only configures the namespace of the
+// main thread, so both this and the exec have to run on it. Wall clock time is
+// not namespaced.
+func SetupTimeNamespace(logger *logger.Logger, offset time.Duration) error {
+	if offset == 0 {
+		return nil
+	}
+
+	runtime.LockOSThread()
+	if unix.Gettid() != unix.Getpid() {
+		return fmt.Errorf("time namespace has to be created from the main thread")
+	}
+	if err := unix.Unshare(unix.CLONE_NEWTIME); err != nil {
+		return fmt.Errorf("failed to create time namespace: %w", err)
+	}
+
+	// the offsets can only be written until a process enters the namespace
+	seconds := int64(offset / time.Second)
+	offsets := fmt.Sprintf("monotonic %d 0\nboottime %d 0\n", seconds, seconds)
+	if err := os.WriteFile("/proc/self/timens_offsets", []byte(offsets), 0644); err != nil {
+		return fmt.Errorf("failed to set clock offsets: %w", err)
+	}
+
+	logger.Debug("time namespace created", "offset", offset)
+	return nil
+}
@@ -5,9 +5,13 @@ import (
 	"path/filepath"
 	"runtime"
 	"strconv"
+	"strings"
 
+	"worker/internal/worker/execarch"
 	"worker/pkg/logger"
 	"worker/pkg/platform"
+
+	"golang.org/x/sys/unix"
 )
 
 // JobConfig represents job configuration
@@ -16,6 +20,11 @@ type JobConfig struct {
 	Command    string
 	Args       []string
 	CgroupPath string
+	CleanEnv   bool   // exec the command without the worker's settings, see jobEnvironment
+	SHA256     string // hex digest the resolved executable must have, "" for none
+
+	Runtime     string   // sandbox runtime binary the command is run through, "" to exec it directly
+	RuntimeArgs []string // runtime arguments before the command
 }
 
 // JobExecutor handles job execution using platform abstraction
@@ -44,41 +53,63 @@ func (je *JobExecutor) LoadConfigFromEnv() (*JobConfig, error) {
 	jobID := je.platform.Getenv("JOB_ID")
 	command := je.platform.Getenv("JOB_COMMAND")
 	cgroupPath := je.platform.Getenv("JOB_CGROUP_PATH")
-	argsCountStr := je.platform.Getenv("JOB_ARGS_COUNT")
+	cleanEnv := je.platform.Getenv("JOB_CLEAN_ENV") == "true"
+	digest := je.platform.Getenv("JOB_COMMAND_SHA256")
+	runtimePath := je.platform.Getenv("JOB_RUNTIME_PATH")
 
 	if jobID == "" || command == "" {
 		return nil, fmt.Errorf("missing required environment variables (JOB_ID=%s, JOB_COMMAND=%s)",
 			jobID, command)
 	}
 
-	var args []string
-	if argsCountStr != "" {
-		argsCount, err := strconv.Atoi(argsCountStr)
-		if err != nil {
-			return nil, fmt.Errorf("invalid JOB_ARGS_COUNT: %v", err)
-		}
-
-		args = make([]string, argsCount)
-		for i := 0; i < argsCount; i++ {
-			argKey := fmt.Sprintf("JOB_ARG_%d", i)
-			args[i] = je.platform.Getenv(argKey)
-		}
+	args, err := je.loadArgs("JOB_ARGS_COUNT", "JOB_ARG_%d")
+	if err != nil {
+		return nil, err
+	}
+	runtimeArgs, err := je.loadArgs("JOB_RUNTIME_ARGS_COUNT", "JOB_RUNTIME_ARG_%d")
+	if err != nil {
+		return nil, err
 	}
 
 	je.logger.Debug("loaded job configuration",
 		"jobId", jobID,
 		"command", command,
 		"argsCount", len(args),
-		"cgroupPath", cgroupPath)
+		"cgroupPath", cgroupPath,
+		"runtime", runtimePath)
 
 	return &JobConfig{
 		JobID:      jobID,
 		Command:    command,
 		Args:       args,
 		CgroupPath: cgroupPath,
+		CleanEnv:   cleanEnv,
+		SHA256:     digest,
+
+		Runtime:     runtimePath,
+		RuntimeArgs: runtimeArgs,
 	}, nil
 }
 
+// loadArgs reads the arguments counted in the countKey variable from the
+// variables named by argKey and their index
+func (je *JobExecutor) loadArgs(countKey, argKey string) ([]string, error) {
+	countStr := je.platform.Getenv(countKey)
+	if countStr == "" {
+		return nil, nil
+	}
+	count, err := strconv.Atoi(countStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", countKey, err)
+	}
+
+	args := make([]string, count)
+	for i := 0; i < count; i++ {
+		args[i] = je.platform.Getenv(fmt.Sprintf(argKey, i))
+	}
+	return args, nil
+}
+
 // Execute executes the job based on platform
 func Execute(config *JobConfig, logger *logger.Logger) error {
 	p := platform.NewPlatform()
@@ -107,10 +138,31 @@ func (je *JobExecutor) executeLinux(config *JobConfig) error {
 	if err != nil {
 		return fmt.Errorf("command resolution failed: %w", err)
 	}
+	// the runtime resolves the command again in its own process, where the
+	// descriptor of a pinned command does not exist
+	if config.SHA256 != "" && config.Runtime != "" {
+		return fmt.Errorf("a pinned command cannot run through a sandbox runtime")
+	}
+	// a pinned command is exec'd from the copy its digest was read from
+	commandPath, err = je.openPinnedCommand(commandPath, config.SHA256)
+	if err != nil {
+		return err
+	}
+	// fail with the reason rather than the exec's "exec format error"
+	if err := execarch.Check(commandPath); err != nil {
+		return err
+	}
 
 	// Prepare arguments and environment using platform abstraction
 	execArgs := append([]string{config.Command}, config.Args...)
-	envVars := je.platform.Environ()
+	envVars := jobEnvironment(je.platform.Environ(), config.CleanEnv)
+
+	// A sandbox runtime is exec'd in the command's place and runs the
+	// resolved command itself, the runtime's processes staying in the cgroup
+	if config.Runtime != "" {
+		execArgs = append(append([]string{config.Runtime}, config.RuntimeArgs...), append([]string{commandPath}, config.Args...)...)
+		commandPath = config.Runtime
+	}
 
 	je.logger.Debug("executing command with platform exec",
 		"commandPath", commandPath, "args", execArgs)
@@ -133,10 +185,14 @@ func (je *JobExecutor) executeDarwin(config *JobConfig) error {
 	if err != nil {
 		return fmt.Errorf("command resolution failed: %w", err)
 	}
+	commandPath, err = je.openPinnedCommand(commandPath, config.SHA256)
+	if err != nil {
+		return err
+	}
 
 	// Use platform abstraction to create and run command
 	cmd := je.platform.CreateCommand(commandPath, config.Args...)
-	cmd.SetEnv(je.platform.Environ())
+	cmd.SetEnv(jobEnvironment(je.platform.Environ(), config.CleanEnv))
 
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("command start failed: %w", err)
@@ -150,16 +206,40 @@ func (je *JobExecutor) executeDarwin(config *JobConfig) error {
 	return nil
 }
 
-// resolveCommandPath resolves a command to its full path using platform abstraction
+// jobEnvironment is the environment the command runs with. A job that does
+// not inherit the worker's environment only got the WORKER_* settings init
+// needed from it, those and the worker's markers are dropped here.
+func jobEnvironment(environ []string, cleanEnv bool) []string {
+	if !cleanEnv {
+		return environ
+	}
+
+	env := make([]string, 0, len(environ))
+	for _, entry := range environ {
+		if strings.HasPrefix(entry, "WORKER_") || strings.HasPrefix(entry, "HOST_NETWORKING=") {
+			continue
+		}
+		env = append(env, entry)
+	}
+	return env
+}
+
+// resolveCommandPath resolves a command to its full path using platform
+// abstraction. It runs inside the job's mount namespace and working directory,
+// so commands the worker left to it, in the job's own filesystem, resolve here.
 func (je *JobExecutor) resolveCommandPath(command string) (string, error) {
 	if command == "" {
 		return "", fmt.Errorf("command cannot be empty")
 	}
 
-	// If already absolute path, verify it exists using platform abstraction
-	if filepath.IsAbs(command) {
-		if _, err := je.platform.Stat(command); err != nil {
-			return "", fmt.Errorf("command %s not found: %w", command, err)
+	// A path, absolute or relative to the workspace, must exist in the job's filesystem
+	if strings.Contains(command, "/") {
+		info, err := je.platform.Stat(command)
+		if err != nil {
+			return "", fmt.Errorf("command %s not found in the job's filesystem: %w", command, err)
+		}
+		if info.IsDir() || info.Mode()&0111 == 0 {
+			return "", fmt.Errorf("command %s in the job's filesystem is not an executable file", command)
 		}
 		return command, nil
 	}
@@ -183,6 +263,51 @@ func (je *JobExecutor) resolveCommandPath(command string) (string, error) {
 	return "", fmt.Errorf("command %s not found in PATH or common locations", command)
 }
 
+// openPinnedCommand checks the executable about to run has the SHA-256
+// digest the job pinned its command to, so a binary replaced on the host is
+// never run in its place. It returns the path of a descriptor of the verified
+// copy, left open across the exec: running that path runs what was hashed
+// even if commandPath is replaced or written to meanwhile.
+func (je *JobExecutor) openPinnedCommand(commandPath, want string) (string, error) {
+	if want == "" {
+		return commandPath, nil
+	}
+
+	file, err := je.platform.Open(commandPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open command %s to verify its digest: %w", commandPath, err)
+	}
+	defer file.Close()
+
+	fd, got, err := pinCommand(file, commandPath)
+	if err != nil {
+		return "", err
+	}
+	if got != want {
+		_ = unix.Close(fd)
+		return "", fmt.Errorf("command %s does not match its pinned digest: sha256 %s, expected %s", commandPath, got, want)
+	}
+
+	// an interpreter reopens the path of a script, so the descriptor must
+	// outlive the exec
+	if _, err := unix.FcntlInt(uintptr(fd), unix.F_SETFD, 0); err != nil {
+		_ = unix.Close(fd)
+		return "", fmt.Errorf("failed to keep command %s open across exec: %w", commandPath, err)
+	}
+
+	je.logger.Debug("verified command digest", "commandPath", commandPath, "sha256", want, "fd", fd)
+	return descriptorPath(fd), nil
+}
+
+// descriptorPath is a path that opens, or executes, the file behind an open
+// descriptor of this process
+func descriptorPath(fd int) string {
+	if runtime.GOOS == "linux" {
+		return fmt.Sprintf("/proc/self/fd/%d", fd)
+	}
+	return fmt.Sprintf("/dev/fd/%d", fd)
+}
+
 // getCommonPaths returns platform-specific common command paths
 func (je *JobExecutor) getCommonPaths(command string) []string {
 	commonPaths := []string{
@@ -0,0 +1,71 @@
+//go:build linux
+
+package jobexec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"worker/pkg/logger"
+	"worker/pkg/platform"
+
+	"golang.org/x/sys/unix"
+)
+
+func TestOpenPinnedCommandRunsASealedCopy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "etl")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho pinned\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("#!/bin/sh\necho pinned\n"))
+
+	je := NewJobExecutor(platform.NewPlatform(), logger.New())
+	pinned, err := je.openPinnedCommand(path, hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fd, err := strconv.Atoi(strings.TrimPrefix(pinned, "/proc/self/fd/"))
+	if err != nil {
+		t.Fatalf("expected a descriptor path, got %s", pinned)
+	}
+	defer unix.Close(fd)
+
+	// a write to the very file that was hashed does not reach the copy
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho written\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(pinned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != "#!/bin/sh\necho pinned\n" {
+		t.Errorf("expected the hashed contents behind %s, got %q", pinned, got)
+	}
+
+	seals, err := unix.FcntlInt(uintptr(fd), unix.F_GET_SEALS, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seals&pinnedSeals != pinnedSeals {
+		t.Errorf("expected the copy sealed, seals %#x", seals)
+	}
+	if _, err := unix.Pwrite(fd, []byte("x"), 0); err == nil {
+		t.Error("expected the sealed copy not to be writable")
+	}
+	if fdFlags, err := unix.FcntlInt(uintptr(fd), unix.F_GETFD, 0); err != nil || fdFlags&unix.FD_CLOEXEC != 0 {
+		t.Errorf("expected the copy kept open across exec, flags %#x, %v", fdFlags, err)
+	}
+}
+
+func TestExecuteRejectsPinnedCommandWithRuntime(t *testing.T) {
+	je := NewJobExecutor(platform.NewPlatform(), logger.New())
+	err := je.executeLinux(&JobConfig{Command: "/bin/sh", SHA256: strings.Repeat("0", 64), Runtime: "/usr/bin/runsc"})
+	if err == nil || !strings.Contains(err.Error(), "sandbox runtime") {
+		t.Errorf("expected a pinned command with a runtime to be refused, got %v", err)
+	}
+}
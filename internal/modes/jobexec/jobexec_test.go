@@ -0,0 +1,62 @@
+package jobexec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"worker/pkg/logger"
+	"worker/pkg/platform"
+)
+
+func TestOpenPinnedCommandRunsTheHashedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "etl")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\necho pinned\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	sum := sha256.Sum256([]byte("#!/bin/sh\necho pinned\n"))
+
+	je := NewJobExecutor(platform.NewPlatform(), logger.New())
+	pinned, err := je.openPinnedCommand(path, hex.EncodeToString(sum[:]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pinned == path {
+		t.Fatalf("expected the command to be run from its descriptor, got %s", pinned)
+	}
+
+	// a binary moved over the path after the check is not the one pinned
+	swapped := path + ".new"
+	if err := os.WriteFile(swapped, []byte("#!/bin/sh\necho swapped\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(swapped, path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(pinned)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "pinned") {
+		t.Errorf("expected the hashed file behind %s, got %q", pinned, got)
+	}
+}
+
+func TestOpenPinnedCommandRejectsMismatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "etl")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	je := NewJobExecutor(platform.NewPlatform(), logger.New())
+	if _, err := je.openPinnedCommand(path, strings.Repeat("0", 64)); err == nil || !strings.Contains(err.Error(), "pinned digest") {
+		t.Errorf("expected a digest mismatch, got %v", err)
+	}
+	if got, err := je.openPinnedCommand(path, ""); err != nil || got != path {
+		t.Errorf("expected an unpinned command to keep its path, got %s, %v", got, err)
+	}
+}
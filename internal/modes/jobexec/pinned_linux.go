@@ -0,0 +1,65 @@
+//go:build linux
+
+package jobexec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"golang.org/x/sys/unix"
+)
+
+// pinnedSeals keep the copy of a pinned command from ever changing
+const pinnedSeals = unix.F_SEAL_SEAL | unix.F_SEAL_SHRINK | unix.F_SEAL_GROW | unix.F_SEAL_WRITE
+
+// pinCommand copies the command into a sealed in-memory file and returns its
+// descriptor and the digest of the sealed copy. What runs from it is what was
+// hashed, even if the file on disk is written to meanwhile.
+func pinCommand(file io.Reader, commandPath string) (int, string, error) {
+	fd, err := unix.MemfdCreate("pinned-command", unix.MFD_ALLOW_SEALING)
+	if err != nil {
+		return -1, "", fmt.Errorf("failed to create the pinned copy of command %s: %w", commandPath, err)
+	}
+
+	buf := make([]byte, 64*1024)
+	for {
+		n, readErr := file.Read(buf)
+		for written := 0; written < n; {
+			w, err := unix.Write(fd, buf[written:n])
+			if err != nil {
+				_ = unix.Close(fd)
+				return -1, "", fmt.Errorf("failed to copy command %s to verify its digest: %w", commandPath, err)
+			}
+			written += w
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			_ = unix.Close(fd)
+			return -1, "", fmt.Errorf("failed to read command %s to verify its digest: %w", commandPath, readErr)
+		}
+	}
+	if _, err := unix.FcntlInt(uintptr(fd), unix.F_ADD_SEALS, pinnedSeals); err != nil {
+		_ = unix.Close(fd)
+		return -1, "", fmt.Errorf("failed to seal the pinned copy of command %s: %w", commandPath, err)
+	}
+
+	// the copy is hashed once sealed, not as it was written
+	hash := sha256.New()
+	for off := int64(0); ; {
+		n, err := unix.Pread(fd, buf, off)
+		if err != nil {
+			_ = unix.Close(fd)
+			return -1, "", fmt.Errorf("failed to read the pinned copy of command %s: %w", commandPath, err)
+		}
+		if n == 0 {
+			break
+		}
+		hash.Write(buf[:n])
+		off += int64(n)
+	}
+	return fd, hex.EncodeToString(hash.Sum(nil)), nil
+}
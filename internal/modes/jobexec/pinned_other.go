@@ -0,0 +1,34 @@
+//go:build !linux
+
+package jobexec
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+
+	"golang.org/x/sys/unix"
+)
+
+// pinCommand returns a descriptor of the command and its digest. Without
+// sealed in-memory files the command runs from the hashed file itself, which
+// pins the file but not its contents.
+func pinCommand(file io.Reader, commandPath string) (int, string, error) {
+	f, ok := file.(interface{ Fd() uintptr })
+	if !ok {
+		return -1, "", fmt.Errorf("failed to open command %s to verify its digest: no file descriptor", commandPath)
+	}
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return -1, "", fmt.Errorf("failed to read command %s to verify its digest: %w", commandPath, err)
+	}
+
+	// a duplicate outlives the file, which the caller closes
+	fd, err := unix.Dup(int(f.Fd()))
+	if err != nil {
+		return -1, "", fmt.Errorf("failed to keep command %s open: %w", commandPath, err)
+	}
+	return fd, hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -2,23 +2,37 @@ package modes
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/signal"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"syscall"
+	"time"
 	"worker/internal/modes/isolation"
 	"worker/internal/modes/jobexec"
 
 	"worker/internal/worker"
-	"worker/internal/worker/server"
-	"worker/internal/worker/state"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/intake"
+	"worker/internal/worker/reconcile"
+	"worker/internal/worker/tracing"
+	"worker/internal/worker/upgrade"
 	"worker/pkg/config"
 	"worker/pkg/logger"
+
+	oteltrace "go.opentelemetry.io/otel/trace"
 )
 
+// tracingFlushTimeout bounds how long exporting the last spans may delay the
+// exec of a job
+const tracingFlushTimeout = 2 * time.Second
+
 func RunServer(cfg *config.Config) error {
 	log := logger.WithField("mode", "server")
 
@@ -26,86 +40,221 @@ func RunServer(cfg *config.Config) error {
 		"address", cfg.GetServerAddress(),
 		"maxJobs", cfg.Worker.MaxConcurrentJobs)
 
-	// Create state store
-	store := state.New()
-
-	// Create worker with configuration
-	workerInstance := worker.NewWorker(store, cfg)
-	if workerInstance == nil {
-		return fmt.Errorf("failed to create worker for current platform")
+	// A worker binary replaced by this one in an upgrade hands over its jobs
+	handoff, err := upgrade.Inherited()
+	if err != nil {
+		log.Error("failed to take over from the previous worker binary, its jobs are left unsupervised", "error", err)
 	}
 
-	// Start gRPC server with configuration
-	grpcServer, err := server.StartGRPCServer(store, workerInstance, cfg)
+	jobWorker, err := worker.New(worker.WithConfig(cfg), worker.WithHandoff(handoff))
 	if err != nil {
-		return fmt.Errorf("failed to start gRPC server: %w", err)
+		return err
 	}
 
-	// Setup graceful shutdown
-	_, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	sigChan := make(chan os.Signal, 1)
-	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	// Start gRPC server with configuration
+	if err := jobWorker.Serve(); err != nil {
+		jobWorker.Close()
+		return err
+	}
 
 	log.Info("server started successfully", "address", cfg.GetServerAddress())
 
-	// Wait for shutdown signal
-	<-sigChan
+	// Wait for shutdown signal, upgrading on SIGUSR2 in the meantime
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM, syscall.SIGUSR2)
+	for sig := <-sigChan; sig == syscall.SIGUSR2; sig = <-sigChan {
+		log.Info("received upgrade signal, handing off to the worker binary on disk")
+		if err := jobWorker.Upgrade(); err != nil {
+			log.Error("worker upgrade failed, carrying on", "error", err)
+		}
+	}
+	signal.Stop(sigChan)
 	log.Info("received shutdown signal, stopping server...")
 
 	// Graceful shutdown
-	grpcServer.GracefulStop()
+	jobWorker.Close()
+
 	log.Info("server stopped gracefully")
 
 	return nil
 }
 
+// RunAgent runs the worker without accepting connections. It takes its jobs
+// from the intake queue, pulling them over an outbound connection, and from
+// the reconciled spec directory, so it can run behind NAT or a firewall; its
+// jobs are observed through the CloudEvents, log shipping and offload
+// integrations.
+func RunAgent(cfg *config.Config) error {
+	log := logger.WithField("mode", "agent")
+
+	log.Info("starting worker agent",
+		"intake", cfg.Intake.Enabled,
+		"reconcile", cfg.Reconcile.Enabled,
+		"maxJobs", cfg.Worker.MaxConcurrentJobs)
+
+	jobWorker, err := worker.New(worker.WithConfig(cfg))
+	if err != nil {
+		return err
+	}
+
+	// Submissions get the checks of RunJob, as in server mode
+	consumer, err := intake.New(cfg.Intake, jobWorker.Service)
+	if err != nil {
+		jobWorker.Close()
+		return fmt.Errorf("failed to start intake: %w", err)
+	}
+	reconciler, err := reconcile.New(cfg.Reconcile, jobWorker.Service)
+	if err != nil {
+		consumer.Close()
+		jobWorker.Close()
+		return fmt.Errorf("failed to start reconcile: %w", err)
+	}
+
+	log.Info("agent started successfully")
+
+	awaitShutdown()
+	log.Info("received shutdown signal, stopping agent...")
+
+	reconciler.Close()
+	consumer.Close()
+	jobWorker.Close()
+
+	log.Info("agent stopped gracefully")
+
+	return nil
+}
+
+// awaitShutdown blocks until SIGINT or SIGTERM
+func awaitShutdown() {
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	<-sigChan
+}
+
 // RunJobInit runs the worker in job initialization mode
-func RunJobInit(cfg *config.Config) error {
+func RunJobInit(cfg *config.Config) (err error) {
 	initLogger := logger.WithField("mode", "init")
 
+	// A warm pool process prepares its namespaces first, then waits for a job
+	standby := os.Getenv("JOB_STANDBY") == "true"
+	if standby {
+		if err := isolation.Setup(initLogger); err != nil {
+			return fmt.Errorf("job isolation setup failed: %w", err)
+		}
+
+		claimed, err := awaitJob(os.Stdin)
+		if err != nil {
+			return fmt.Errorf("failed to receive job: %w", err)
+		}
+		if !claimed {
+			initLogger.Debug("warm init process released without a job")
+			return nil
+		}
+	}
+
 	jobID := os.Getenv("JOB_ID")
 	if jobID != "" {
 		initLogger = initLogger.WithField("jobId", jobID)
 	}
 
+	isolationMode := domain.IsolationMode(os.Getenv("JOB_ISOLATION"))
+
+	// Continue the trace of the job launch up to the exec of the job command
+	trace := startInitTrace(cfg.Tracing, jobID, initLogger)
+	defer func() { trace.end(err) }()
+
 	initLogger.Debug("worker starting in INIT mode",
 		"platform", runtime.GOOS,
 		"mode", "init",
-		"jobId", jobID)
-
-	// Validate required environment
-	cgroupPath := os.Getenv("JOB_CGROUP_PATH")
-	if cgroupPath == "" {
-		return fmt.Errorf("JOB_CGROUP_PATH environment variable is required")
-	}
-
-	// Assign to cgroup immediately
-	if err := assignToCgroup(cgroupPath, initLogger); err != nil {
-		return fmt.Errorf("failed to assign to cgroup: %w", err)
-	}
-
-	// Verify cgroup assignment
-	if err := verifyCgroupAssignment(cgroupPath, initLogger); err != nil {
-		return fmt.Errorf("cgroup assignment verification failed: %w", err)
+		"jobId", jobID,
+		"isolation", isolationMode)
+
+	// Plain process and privileged jobs have no cgroup to join
+	if isolationMode.UsesCgroup() {
+		// Validate required environment
+		cgroupPath := os.Getenv("JOB_CGROUP_PATH")
+		if cgroupPath == "" {
+			return fmt.Errorf("JOB_CGROUP_PATH environment variable is required")
+		}
+
+		// Assign to cgroup immediately
+		if err := assignToCgroup(cgroupPath, initLogger); err != nil {
+			return fmt.Errorf("failed to assign to cgroup: %w", err)
+		}
+
+		// Verify cgroup assignment
+		if err := verifyCgroupAssignment(cgroupPath, initLogger); err != nil {
+			return fmt.Errorf("cgroup assignment verification failed: %w", err)
+		}
 	}
 
 	// Log resource limits for transparency
 	logResourceLimits(initLogger)
 
+	// Let a crashing job dump core up to the configured size
+	if value := os.Getenv("JOB_CORE_LIMIT_BYTES"); value != "" {
+		if err := setCoreLimit(value); err != nil {
+			return fmt.Errorf("failed to set core size limit: %w", err)
+		}
+	}
+
 	// Load job configuration
 	jobConfig, err := jobexec.LoadConfigFromEnv(initLogger)
 	if err != nil {
 		return fmt.Errorf("failed to load job config: %w", err)
 	}
 
-	// Set up isolation
-	if err := isolation.Setup(initLogger); err != nil {
-		return fmt.Errorf("job isolation setup failed: %w", err)
+	// Set up isolation, which remounts /proc and so needs the job's own namespaces
+	if isolationMode == domain.IsolationFull && !standby {
+		_, span := tracing.Start(trace.ctx, "job.namespace.setup")
+		err := isolation.Setup(initLogger)
+		tracing.End(span, err)
+		if err != nil {
+			return fmt.Errorf("job isolation setup failed: %w", err)
+		}
+	}
+
+	// Run the job from its workspace when one was prepared
+	if workspace := os.Getenv("JOB_WORKSPACE"); workspace != "" {
+		if err := isolation.MountWorkspace(initLogger, workspace, os.Getenv("JOB_WORKSPACE_MOUNT")); err != nil {
+			return fmt.Errorf("job workspace setup failed: %w", err)
+		}
+		// the result file is in the workspace, wherever the job sees it
+		if dir, err := os.Getwd(); err == nil {
+			_ = os.Setenv("JOB_RESULT_PATH", filepath.Join(dir, domain.JobResultFile))
+		}
+	}
+
+	// Shift the job's clocks, if asked to
+	if value := os.Getenv("JOB_TIME_OFFSET"); value != "" {
+		seconds, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid JOB_TIME_OFFSET: %w", err)
+		}
+		if err := isolation.SetupTimeNamespace(initLogger, time.Duration(seconds)*time.Second); err != nil {
+			return fmt.Errorf("job time namespace setup failed: %w", err)
+		}
 	}
 
-	// Execute the job
+	// The job's sockets were inherited as descriptors 3 and up and stay open
+	// through the exec, which keeps this process ID
+	if count := os.Getenv("JOB_LISTEN_FDS"); count != "" {
+		_ = os.Setenv("LISTEN_FDS", count)
+		_ = os.Setenv("LISTEN_FDNAMES", os.Getenv("JOB_LISTEN_FDNAMES"))
+		_ = os.Setenv("LISTEN_PID", strconv.Itoa(os.Getpid()))
+		_ = os.Unsetenv("JOB_LISTEN_FDS")
+		_ = os.Unsetenv("JOB_LISTEN_FDNAMES")
+	}
+
+	trace.end(nil)
+
+	// The seccomp filter goes in last, after the trace is flushed, so it
+	// covers little of init besides the exec
+	if err := isolation.InstallSeccomp(initLogger, domain.SeccompMode(os.Getenv("JOB_SECCOMP"))); err != nil {
+		return fmt.Errorf("job seccomp setup failed: %w", err)
+	}
+
+	// Execute the job, which replaces this process
 	if err := jobexec.Execute(jobConfig, initLogger); err != nil {
 		return fmt.Errorf("job execution failed: %w", err)
 	}
@@ -115,6 +264,73 @@ func RunJobInit(cfg *config.Config) error {
 	return nil
 }
 
+// initTrace is the span of the init process, a child of the launch span
+// passed in JOB_TRACEPARENT
+type initTrace struct {
+	ctx   context.Context
+	span  oteltrace.Span
+	flush func(context.Context) error
+	ended bool
+}
+
+// startInitTrace starts the init span. Without a traceparent, or with tracing
+// disabled, the span is a no-op.
+func startInitTrace(cfg config.TracingConfig, jobID string, logger *logger.Logger) *initTrace {
+	ctx := context.Background()
+	flush := func(context.Context) error { return nil }
+
+	if traceparent := os.Getenv("JOB_TRACEPARENT"); traceparent != "" && cfg.Enabled {
+		shutdown, err := tracing.Setup(ctx, cfg)
+		if err != nil {
+			logger.Warn("tracing setup failed, continuing without it", "error", err)
+		} else {
+			ctx = tracing.Extract(ctx, traceparent)
+			flush = shutdown
+		}
+	}
+
+	ctx, span := tracing.Start(ctx, "job.init", tracing.JobID(jobID))
+	return &initTrace{ctx: ctx, span: span, flush: flush}
+}
+
+// end ends the span and exports it, the process either execs or exits next
+func (t *initTrace) end(err error) {
+	if t.ended {
+		return
+	}
+	t.ended = true
+	tracing.End(t.span, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer cancel()
+	_ = t.flush(ctx)
+}
+
+// awaitJob reads the environment of the job handed to a warm init process:
+// NUL-separated KEY=VALUE entries up to EOF. It replaces the process
+// environment and reports false when the worker closed the pipe without a job.
+func awaitJob(r io.Reader) (bool, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return false, err
+	}
+	if len(data) == 0 {
+		return false, nil
+	}
+
+	os.Clearenv()
+	for _, entry := range strings.Split(strings.TrimSuffix(string(data), "\x00"), "\x00") {
+		key, value, ok := strings.Cut(entry, "=")
+		if !ok {
+			return false, fmt.Errorf("malformed environment entry %q", key)
+		}
+		if err := os.Setenv(key, value); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
 // assignToCgroup assigns the current process to the specified cgroup
 func assignToCgroup(cgroupPath string, logger *logger.Logger) error {
 	if cgroupPath == "" {
@@ -138,7 +354,16 @@ func assignToCgroup(cgroupPath string, logger *logger.Logger) error {
 	return nil
 }
 
-// verifyCgroupAssignment verifies that the current process is in a cgroup namespace
+// errNotInJobCgroup is returned by init when joining the job's cgroup did not
+// take, which would leave the job running without its limits
+var errNotInJobCgroup = errors.New("process is not in the job's cgroup, refusing to run the job without its limits")
+
+// verifyCgroupAssignment checks, before the job command execs, that this
+// process is in the job's cgroup. The cgroup v2 path /proc/self/cgroup
+// reports is relative to the cgroup namespace root, "/../job-1" in a
+// namespace rooted at a sibling cgroup, so it must be the tail of the job's
+// cgroup path. In a namespace rooted at the job's cgroup, which reports "/",
+// the job cgroup's cgroup.procs must list the process instead.
 func verifyCgroupAssignment(expectedCgroupPath string, logger *logger.Logger) error {
 	const cgroupFile = "/proc/self/cgroup"
 
@@ -156,22 +381,93 @@ func verifyCgroupAssignment(expectedCgroupPath string, logger *logger.Logger) er
 		"cgroupView", cgroupContent,
 		"expectedHostPath", expectedCgroupPath)
 
-	// In cgroup namespace, expect "0::/" format
-	if !strings.HasPrefix(cgroupContent, "0::") {
-		return fmt.Errorf("process not in cgroup namespace (expected format '0::/...', got: %q)", cgroupContent)
+	if !onCgroup2(expectedCgroupPath) {
+		return fmt.Errorf("%w: %s is not on a cgroup v2 filesystem", errNotInJobCgroup, expectedCgroupPath)
+	}
+	current, err := checkCgroupView(cgroupContent, expectedCgroupPath, pid)
+	if err != nil {
+		return err
 	}
 
-	logger.Debug("cgroup assignment verified successfully", "pid", pid)
+	logger.Debug("cgroup assignment verified successfully", "pid", pid, "cgroup", current)
 	return nil
 }
 
+// checkCgroupView checks the content of /proc/self/cgroup puts pid in the
+// cgroup at expectedCgroupPath, and returns the cgroup v2 path it reports
+func checkCgroupView(content, expectedCgroupPath string, pid int) (string, error) {
+	current, ok := unifiedCgroup(content)
+	if !ok {
+		return "", fmt.Errorf("%w: no cgroup v2 entry in /proc/self/cgroup, got %q", errNotInJobCgroup, content)
+	}
+
+	relative := current
+	for strings.HasPrefix(relative, "/..") {
+		relative = strings.TrimPrefix(relative, "/..")
+	}
+	if relative == "" || relative == "/" {
+		if !cgroupLists(expectedCgroupPath, pid) {
+			return "", fmt.Errorf("%w: in cgroup %s, which is not %s", errNotInJobCgroup, current, expectedCgroupPath)
+		}
+	} else if !pathEndsWith(expectedCgroupPath, relative) {
+		return "", fmt.Errorf("%w: in cgroup %s, expected %s", errNotInJobCgroup, current, expectedCgroupPath)
+	}
+	return current, nil
+}
+
+// pathEndsWith reports whether the last components of path are those of
+// tail, so /a/job-1 ends with job-1 but /a/xjob-1 does not
+func pathEndsWith(path, tail string) bool {
+	components := strings.Split(strings.Trim(filepath.Clean(path), "/"), "/")
+	tailComponents := strings.Split(strings.Trim(filepath.Clean(tail), "/"), "/")
+	if len(tailComponents) > len(components) {
+		return false
+	}
+	return slices.Equal(components[len(components)-len(tailComponents):], tailComponents)
+}
+
+// unifiedCgroup returns the cgroup v2 path of a /proc/self/cgroup, the
+// "0::" line, which hosts with cgroup v1 hierarchies list after theirs
+func unifiedCgroup(content string) (string, bool) {
+	for _, line := range strings.Split(content, "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// cgroupLists reports whether the cgroup.procs of a cgroup lists pid, as
+// seen from this process's pid namespace
+func cgroupLists(cgroupPath string, pid int) bool {
+	data, err := os.ReadFile(filepath.Join(cgroupPath, "cgroup.procs"))
+	if err != nil {
+		return false
+	}
+	for _, listed := range strings.Fields(string(data)) {
+		if listed == strconv.Itoa(pid) {
+			return true
+		}
+	}
+	return false
+}
+
 // logResourceLimits logs the applied resource limits for transparency
 func logResourceLimits(logger *logger.Logger) {
 	limits := map[string]string{
-		"maxCPU":    os.Getenv("JOB_MAX_CPU"),
-		"maxMemory": os.Getenv("JOB_MAX_MEMORY"),
-		"maxIOBPS":  os.Getenv("JOB_MAX_IOBPS"),
+		"cpuLimitMillis":   os.Getenv("JOB_CPU_LIMIT_MILLIS"),
+		"memoryLimitBytes": os.Getenv("JOB_MEMORY_LIMIT_BYTES"),
+		"ioLimitBPS":       os.Getenv("JOB_IO_LIMIT_BPS"),
 	}
 
 	logger.Debug("resource limits applied", "limits", limits)
 }
+
+// setCoreLimit sets RLIMIT_CORE, which the job inherits across the exec
+func setCoreLimit(value string) error {
+	size, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid JOB_CORE_LIMIT_BYTES: %w", err)
+	}
+	return syscall.Setrlimit(syscall.RLIMIT_CORE, &syscall.Rlimit{Cur: size, Max: size})
+}
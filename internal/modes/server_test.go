@@ -0,0 +1,85 @@
+package modes
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckCgroupView(t *testing.T) {
+	const expected = "/sys/fs/cgroup/worker.slice/worker.service/job-1"
+
+	tests := []struct {
+		name    string
+		content string
+		want    string
+		wantErr bool
+	}{
+		{name: "v2", content: "0::/worker.slice/worker.service/job-1", want: "/worker.slice/worker.service/job-1"},
+		{name: "v2 in a namespace rooted at a sibling", content: "0::/../job-1", want: "/../job-1"},
+		{name: "hybrid", content: "12:memory:/user.slice\n11:cpu,cpuacct:/user.slice\n1:name=systemd:/user.slice\n0::/worker.slice/worker.service/job-1", want: "/worker.slice/worker.service/job-1"},
+		{name: "v1 only", content: "12:memory:/worker.slice/worker.service/job-1\n11:cpu,cpuacct:/worker.slice/worker.service/job-1", wantErr: true},
+		{name: "other job", content: "0::/worker.slice/worker.service/job-2", wantErr: true},
+		{name: "prefix sibling", content: "0::/worker.slice/worker.service/xjob-1", wantErr: true},
+		{name: "worker cgroup", content: "0::/worker.slice/worker.service", wantErr: true},
+		{name: "longer than the job path", content: "0::/sys/fs/cgroup/sys/fs/cgroup/worker.slice/worker.service/job-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := checkCgroupView(tt.content, expected, 42)
+			if tt.wantErr {
+				if !errors.Is(err, errNotInJobCgroup) {
+					t.Errorf("expected the cgroup refused, got %q, %v", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("checkCgroupView() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCgroupViewAtNamespaceRoot(t *testing.T) {
+	// in a namespace rooted at the job's cgroup, the cgroup must list the process
+	cgroupPath := filepath.Join(t.TempDir(), "job-1")
+	if err := os.MkdirAll(cgroupPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cgroupPath, "cgroup.procs"), []byte("7\n42\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := checkCgroupView("0::/", cgroupPath, 42); err != nil {
+		t.Errorf("expected a listed process accepted, got %v", err)
+	}
+	if _, err := checkCgroupView("0::/", cgroupPath, 4); !errors.Is(err, errNotInJobCgroup) {
+		t.Errorf("expected an unlisted process refused, got %v", err)
+	}
+	if _, err := checkCgroupView("0::/..", filepath.Join(t.TempDir(), "gone"), 42); !errors.Is(err, errNotInJobCgroup) {
+		t.Errorf("expected a missing cgroup refused, got %v", err)
+	}
+}
+
+func TestPathEndsWith(t *testing.T) {
+	tests := []struct {
+		path, tail string
+		want       bool
+	}{
+		{"/a/job-1", "/job-1", true},
+		{"/a/job-1", "job-1", true},
+		{"/a/job-1", "/a/job-1", true},
+		{"/a/job-1/", "/job-1", true},
+		{"/a/xjob-1", "/job-1", false},
+		{"/a/job-1", "/b/job-1", false},
+		{"/a/job-10", "/job-1", false},
+		{"/job-1", "/a/job-1", false},
+	}
+	for _, tt := range tests {
+		if got := pathEndsWith(tt.path, tt.tail); got != tt.want {
+			t.Errorf("pathEndsWith(%q, %q) = %v, want %v", tt.path, tt.tail, got, tt.want)
+		}
+	}
+}
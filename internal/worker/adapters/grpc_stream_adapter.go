@@ -26,3 +26,25 @@ func (a *GrpcStreamAdapter) SendKeepalive() error {
 func (a *GrpcStreamAdapter) Context() context.Context {
 	return a.stream.Context()
 }
+
+// AttachedStreamAdapter adapts the RunJobAttached stream to the domain interface,
+// sending output as output events
+type AttachedStreamAdapter struct {
+	stream pb.JobService_RunJobAttachedServer
+}
+
+func NewAttachedStreamAdapter(stream pb.JobService_RunJobAttachedServer) state.DomainStreamer {
+	return &AttachedStreamAdapter{stream: stream}
+}
+
+func (a *AttachedStreamAdapter) SendData(data []byte) error {
+	return a.stream.Send(&pb.RunJobAttachedRes{Event: &pb.RunJobAttachedRes_Output{Output: data}})
+}
+
+func (a *AttachedStreamAdapter) SendKeepalive() error {
+	return a.SendData([]byte{})
+}
+
+func (a *AttachedStreamAdapter) Context() context.Context {
+	return a.stream.Context()
+}
@@ -20,6 +20,18 @@ type FakeGrpcAuthorization struct {
 	authorizedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AuthorizedRolesStub        func(context.Context, []auth.ClientRole) error
+	authorizedRolesMutex       sync.RWMutex
+	authorizedRolesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []auth.ClientRole
+	}
+	authorizedRolesReturns struct {
+		result1 error
+	}
+	authorizedRolesReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -86,11 +98,80 @@ func (fake *FakeGrpcAuthorization) AuthorizedReturnsOnCall(i int, result1 error)
 	}{result1}
 }
 
+func (fake *FakeGrpcAuthorization) AuthorizedRoles(arg1 context.Context, arg2 []auth.ClientRole) error {
+	var arg2Copy []auth.ClientRole
+	if arg2 != nil {
+		arg2Copy = make([]auth.ClientRole, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.authorizedRolesMutex.Lock()
+	ret, specificReturn := fake.authorizedRolesReturnsOnCall[len(fake.authorizedRolesArgsForCall)]
+	fake.authorizedRolesArgsForCall = append(fake.authorizedRolesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []auth.ClientRole
+	}{arg1, arg2Copy})
+	stub := fake.AuthorizedRolesStub
+	fakeReturns := fake.authorizedRolesReturns
+	fake.recordInvocation("AuthorizedRoles", []interface{}{arg1, arg2Copy})
+	fake.authorizedRolesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeGrpcAuthorization) AuthorizedRolesCallCount() int {
+	fake.authorizedRolesMutex.RLock()
+	defer fake.authorizedRolesMutex.RUnlock()
+	return len(fake.authorizedRolesArgsForCall)
+}
+
+func (fake *FakeGrpcAuthorization) AuthorizedRolesCalls(stub func(context.Context, []auth.ClientRole) error) {
+	fake.authorizedRolesMutex.Lock()
+	defer fake.authorizedRolesMutex.Unlock()
+	fake.AuthorizedRolesStub = stub
+}
+
+func (fake *FakeGrpcAuthorization) AuthorizedRolesArgsForCall(i int) (context.Context, []auth.ClientRole) {
+	fake.authorizedRolesMutex.RLock()
+	defer fake.authorizedRolesMutex.RUnlock()
+	argsForCall := fake.authorizedRolesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeGrpcAuthorization) AuthorizedRolesReturns(result1 error) {
+	fake.authorizedRolesMutex.Lock()
+	defer fake.authorizedRolesMutex.Unlock()
+	fake.AuthorizedRolesStub = nil
+	fake.authorizedRolesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeGrpcAuthorization) AuthorizedRolesReturnsOnCall(i int, result1 error) {
+	fake.authorizedRolesMutex.Lock()
+	defer fake.authorizedRolesMutex.Unlock()
+	fake.AuthorizedRolesStub = nil
+	if fake.authorizedRolesReturnsOnCall == nil {
+		fake.authorizedRolesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.authorizedRolesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeGrpcAuthorization) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.authorizedMutex.RLock()
 	defer fake.authorizedMutex.RUnlock()
+	fake.authorizedRolesMutex.RLock()
+	defer fake.authorizedRolesMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -15,34 +15,64 @@ import (
 type ClientRole string
 
 const (
-	AdminRole   ClientRole = "admin"
-	ViewerRole  ClientRole = "viewer"
-	UnknownRole ClientRole = "unknown"
+	AdminRole     ClientRole = "admin"
+	ViewerRole    ClientRole = "viewer"
+	SubmitterRole ClientRole = "submitter" // runs fully isolated jobs without shell and reads them, the default for queue intake
+	UnknownRole   ClientRole = "unknown"
 )
 
 type Operation string
 
 const (
-	RunJobOp     Operation = "run_job"
-	GetJobOp     Operation = "get_job"
-	StopJobOp    Operation = "stop_job"
-	ListJobsOp   Operation = "list_jobs"
-	StreamJobsOp Operation = "stream_jobs"
+	RunJobOp           Operation = "run_job"
+	RunShellJobOp      Operation = "run_shell_job"      // in addition to run_job, for jobs run with sh -c
+	RunUnisolatedJobOp Operation = "run_unisolated_job" // in addition to run_job, for jobs asking for weaker isolation than full
+	RunPrivilegedJobOp Operation = "run_privileged_job" // in addition to run_unisolated_job, for privileged jobs
+	GetJobOp           Operation = "get_job"
+	GetJobArtifactOp   Operation = "get_job_artifact" // download files of a job's workspace, such as its core dump holding its secrets
+	StopJobOp          Operation = "stop_job"
+	DeleteJobOp        Operation = "delete_job"
+	ListJobsOp         Operation = "list_jobs"
+	StreamJobsOp       Operation = "stream_jobs"
+	CreateSecretOp     Operation = "create_secret"
+	DeleteSecretOp     Operation = "delete_secret"
+	JoinFleetOp        Operation = "join_fleet"     // register with a coordinator and send it heartbeats
+	InspectWorkerOp    Operation = "inspect_worker" // read the worker's memory and heap profile, and compact its store
 )
 
 //counterfeiter:generate . GrpcAuthorization
 type GrpcAuthorization interface {
 	Authorized(ctx context.Context, operation Operation) error
+	AuthorizedRoles(ctx context.Context, roles []ClientRole) error
 }
 
 type grpcAuthorization struct {
 }
 
+type roleKey struct{}
+
+// WithRole returns a context trusted with role, for requests entering the
+// worker other than over mTLS gRPC, such as queue intake. gRPC clients cannot
+// set it, their role always comes from their certificate.
+func WithRole(ctx context.Context, role ClientRole) context.Context {
+	return context.WithValue(ctx, roleKey{}, role)
+}
+
 func NewGrpcAuthorization() GrpcAuthorization {
 	return &grpcAuthorization{}
 }
 
 func (s *grpcAuthorization) extractClientRole(ctx context.Context) (ClientRole, error) {
+	return clientRole(ctx)
+}
+
+// clientRole is the role of the caller: the one its context was trusted
+// with, or else the organizational unit of its client certificate
+func clientRole(ctx context.Context) (ClientRole, error) {
+	if role, ok := ctx.Value(roleKey{}).(ClientRole); ok {
+		return role, nil
+	}
+
 	p, ok := peer.FromContext(ctx)
 	if !ok {
 		return UnknownRole, fmt.Errorf("no peer information found")
@@ -64,7 +94,7 @@ func (s *grpcAuthorization) extractClientRole(ctx context.Context) (ClientRole,
 		switch strings.ToLower(ou) {
 		case "admin":
 			return AdminRole, nil
-		case "viewer":
+		case "viewer", "observer": // observer is an alias of viewer
 			return ViewerRole, nil
 		}
 	}
@@ -73,6 +103,21 @@ func (s *grpcAuthorization) extractClientRole(ctx context.Context) (ClientRole,
 	return UnknownRole, nil
 }
 
+// ClientName names the caller for audit logs: the common name of its client
+// certificate, or its role for requests that entered the worker otherwise
+func ClientName(ctx context.Context) string {
+	if role, ok := ctx.Value(roleKey{}).(ClientRole); ok {
+		return "role:" + string(role)
+	}
+
+	if p, ok := peer.FromContext(ctx); ok {
+		if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok && len(tlsInfo.State.PeerCertificates) > 0 {
+			return tlsInfo.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+	return "unknown"
+}
+
 func (s *grpcAuthorization) isOperationAllowed(role ClientRole, operation Operation) bool {
 	switch role {
 	case AdminRole:
@@ -81,7 +126,16 @@ func (s *grpcAuthorization) isOperationAllowed(role ClientRole, operation Operat
 		switch operation {
 		case GetJobOp, ListJobsOp, StreamJobsOp:
 			return true
-		case RunJobOp, StopJobOp:
+		case RunJobOp, RunShellJobOp, RunUnisolatedJobOp, RunPrivilegedJobOp, GetJobArtifactOp, StopJobOp, DeleteJobOp, CreateSecretOp, DeleteSecretOp, JoinFleetOp, InspectWorkerOp:
+			return false
+		default:
+			return false
+		}
+	case SubmitterRole:
+		switch operation {
+		case RunJobOp, GetJobOp, ListJobsOp, StreamJobsOp:
+			return true
+		case RunShellJobOp, RunUnisolatedJobOp, RunPrivilegedJobOp, GetJobArtifactOp, StopJobOp, DeleteJobOp, CreateSecretOp, DeleteSecretOp, JoinFleetOp, InspectWorkerOp:
 			return false
 		default:
 			return false
@@ -103,3 +157,20 @@ func (s *grpcAuthorization) Authorized(ctx context.Context, operation Operation)
 
 	return nil
 }
+
+// AuthorizedRoles checks that the caller holds one of roles, for resources
+// restricted beyond the per-operation rules
+func (s *grpcAuthorization) AuthorizedRoles(ctx context.Context, roles []ClientRole) error {
+	role, err := s.extractClientRole(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "failed to extract client role: %v", err)
+	}
+
+	for _, r := range roles {
+		if r == role {
+			return nil
+		}
+	}
+
+	return status.Errorf(codes.PermissionDenied, "role %s is not one of %v", role, roles)
+}
@@ -82,6 +82,12 @@ func TestGrpcAuthorization_ExtractClientRole(t *testing.T) {
 			expectedRole: ViewerRole,
 			expectError:  false,
 		},
+		{
+			name:         "Observer is a viewer",
+			context:      createMockContext([]string{"observer"}),
+			expectedRole: ViewerRole,
+			expectError:  false,
+		},
 		{
 			name:         "Admin role (case insensitive)",
 			context:      createMockContext([]string{"ADMIN"}),
@@ -162,24 +168,61 @@ func TestGrpcAuthorization_IsOperationAllowed(t *testing.T) {
 	}{
 		// Admin role - should allow all operations
 		{AdminRole, RunJobOp, true},
+		{AdminRole, RunShellJobOp, true},
+		{AdminRole, RunUnisolatedJobOp, true},
+		{AdminRole, RunPrivilegedJobOp, true},
+		{AdminRole, GetJobArtifactOp, true},
 		{AdminRole, GetJobOp, true},
 		{AdminRole, StopJobOp, true},
 		{AdminRole, ListJobsOp, true},
 		{AdminRole, StreamJobsOp, true},
+		{AdminRole, CreateSecretOp, true},
+		{AdminRole, DeleteSecretOp, true},
+		{AdminRole, InspectWorkerOp, true},
 
 		// Viewer role - should allow only read operations
 		{ViewerRole, RunJobOp, false},
+		{ViewerRole, RunShellJobOp, false},
+		{ViewerRole, RunUnisolatedJobOp, false},
+		{ViewerRole, RunPrivilegedJobOp, false},
+		{ViewerRole, GetJobArtifactOp, false},
 		{ViewerRole, GetJobOp, true},
 		{ViewerRole, StopJobOp, false},
 		{ViewerRole, ListJobsOp, true},
 		{ViewerRole, StreamJobsOp, true},
+		{ViewerRole, CreateSecretOp, false},
+		{ViewerRole, DeleteSecretOp, false},
+		{ViewerRole, InspectWorkerOp, false},
+
+		// Submitter role - should allow fully isolated runs and reads only
+		{SubmitterRole, RunJobOp, true},
+		{SubmitterRole, RunShellJobOp, false},
+		{SubmitterRole, RunUnisolatedJobOp, false},
+		{SubmitterRole, RunPrivilegedJobOp, false},
+		{SubmitterRole, GetJobArtifactOp, false},
+		{SubmitterRole, GetJobOp, true},
+		{SubmitterRole, StopJobOp, false},
+		{SubmitterRole, DeleteJobOp, false},
+		{SubmitterRole, ListJobsOp, true},
+		{SubmitterRole, StreamJobsOp, true},
+		{SubmitterRole, CreateSecretOp, false},
+		{SubmitterRole, DeleteSecretOp, false},
+		{SubmitterRole, JoinFleetOp, false},
+		{SubmitterRole, InspectWorkerOp, false},
 
 		// Unknown role - should not allow any operations
 		{UnknownRole, RunJobOp, false},
+		{UnknownRole, RunShellJobOp, false},
+		{UnknownRole, RunUnisolatedJobOp, false},
+		{UnknownRole, RunPrivilegedJobOp, false},
+		{UnknownRole, GetJobArtifactOp, false},
 		{UnknownRole, GetJobOp, false},
 		{UnknownRole, StopJobOp, false},
 		{UnknownRole, ListJobsOp, false},
 		{UnknownRole, StreamJobsOp, false},
+		{UnknownRole, CreateSecretOp, false},
+		{UnknownRole, DeleteSecretOp, false},
+		{UnknownRole, InspectWorkerOp, false},
 	}
 
 	for _, tt := range tests {
@@ -322,6 +365,48 @@ func TestGrpcAuthorization_Authorized(t *testing.T) {
 	}
 }
 
+func TestGrpcAuthorization_AuthorizedRoles(t *testing.T) {
+	auth := NewGrpcAuthorization()
+	adminOnly := []ClientRole{AdminRole}
+
+	if err := auth.AuthorizedRoles(createMockContext([]string{"admin"}), adminOnly); err != nil {
+		t.Errorf("Expected admin to be allowed, got: %v", err)
+	}
+	if err := auth.AuthorizedRoles(createMockContext([]string{"viewer"}), adminOnly); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected PermissionDenied for viewer, got: %v", err)
+	}
+	if err := auth.AuthorizedRoles(createMockContextNoPeer(), adminOnly); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Expected Unauthenticated without peer, got: %v", err)
+	}
+}
+
+func TestGrpcAuthorization_WithRole(t *testing.T) {
+	auth := NewGrpcAuthorization()
+
+	if err := auth.Authorized(WithRole(context.Background(), AdminRole), RunShellJobOp); err != nil {
+		t.Errorf("Expected the context role to be trusted, got: %v", err)
+	}
+	if err := auth.Authorized(WithRole(createMockContext([]string{"admin"}), ViewerRole), RunJobOp); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected the context role to take precedence, got: %v", err)
+	}
+}
+
+func TestClientName(t *testing.T) {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{
+		State: tls.ConnectionState{PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: "ops-laptop"}}}},
+	}})
+
+	if name := ClientName(ctx); name != "ops-laptop" {
+		t.Errorf("Expected the certificate common name, got %q", name)
+	}
+	if name := ClientName(WithRole(context.Background(), AdminRole)); name != "role:admin" {
+		t.Errorf("Expected the context role, got %q", name)
+	}
+	if name := ClientName(createMockContextNoPeer()); name != "unknown" {
+		t.Errorf("Expected unknown without a peer, got %q", name)
+	}
+}
+
 func TestClientRole_String(t *testing.T) {
 	tests := []struct {
 		role     ClientRole
@@ -345,10 +430,16 @@ func TestOperation_String(t *testing.T) {
 		expected  string
 	}{
 		{RunJobOp, "run_job"},
+		{RunShellJobOp, "run_shell_job"},
+		{RunUnisolatedJobOp, "run_unisolated_job"},
+		{RunPrivilegedJobOp, "run_privileged_job"},
 		{GetJobOp, "get_job"},
 		{StopJobOp, "stop_job"},
 		{ListJobsOp, "list_jobs"},
 		{StreamJobsOp, "stream_jobs"},
+		{CreateSecretOp, "create_secret"},
+		{DeleteSecretOp, "delete_secret"},
+		{JoinFleetOp, "join_fleet"},
 	}
 
 	for _, tt := range tests {
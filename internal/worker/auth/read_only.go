@@ -0,0 +1,69 @@
+package auth
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"strings"
+)
+
+// readOnlyMethods are the methods of the job service, under its current and
+// legacy names, that only read jobs, their logs and metrics, or the worker.
+// They are all a viewer may call; methods added later are closed to viewers
+// until listed here.
+var readOnlyMethods = map[string]bool{
+	"GetJobStatus":       true,
+	"GetJobProvenance":   true,
+	"GetJobProcesses":    true,
+	"GetJobLogs":         true,
+	"ExportJob":          true,
+	"ListJobs":           true,
+	"GetPipelineStatus":  true,
+	"GetJobGroup":        true,
+	"ListJobGroups":      true,
+	"ListJobTemplates":   true,
+	"StreamJobMetrics":   true,
+	"GetWorkerInfo":      true,
+	"GetDiagnostics":     true,
+	"SubscribeJobEvents": true,
+	"GetUsageReport":     true,
+}
+
+// ReadOnly reports whether the gRPC method, named in full as
+// /package.Service/Method, changes nothing on the worker
+func ReadOnly(fullMethod string) bool {
+	service, method, ok := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	if !ok || !strings.HasSuffix(service, ".JobService") {
+		return false
+	}
+	return readOnlyMethods[method]
+}
+
+// UnaryServerInterceptor refuses viewers every method that is not ReadOnly,
+// before the handler's own per-operation checks. Any front end relaying to
+// the gRPC server, a REST or WebSocket gateway included, goes through it.
+func UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := authorizeMethod(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods
+func StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authorizeMethod(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+// authorizeMethod refuses a viewer the methods that are not ReadOnly. Other
+// callers, and those without a role, are left to the handler.
+func authorizeMethod(ctx context.Context, fullMethod string) error {
+	role, err := clientRole(ctx)
+	if err != nil || role != ViewerRole || ReadOnly(fullMethod) {
+		return nil
+	}
+	return status.Errorf(codes.PermissionDenied, "role %s has read-only access, %s is not allowed", role, fullMethod)
+}
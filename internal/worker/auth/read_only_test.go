@@ -0,0 +1,114 @@
+package auth
+
+import (
+	"context"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"testing"
+	pb "worker/api/gen"
+)
+
+func TestReadOnly(t *testing.T) {
+	tests := []struct {
+		method   string
+		readOnly bool
+	}{
+		{pb.JobService_ListJobs_FullMethodName, true},
+		{pb.JobService_GetJobLogs_FullMethodName, true},
+		{pb.JobService_StreamJobMetrics_FullMethodName, true},
+		{pb.JobService_GetJobProcesses_FullMethodName, true},
+		{"/worker.JobService/GetJobStatus", true}, // legacy service name
+		{pb.JobService_RunJob_FullMethodName, false},
+		{pb.JobService_GetJobArtifact_FullMethodName, false}, // core dumps hold the job's secrets
+		{pb.JobService_StopJob_FullMethodName, false},
+		{pb.JobService_WriteJobStdin_FullMethodName, false},
+		{pb.FleetService_Heartbeat_FullMethodName, false},
+		{"/other.Service/ListJobs", false},
+		{"ListJobs", false},
+	}
+
+	for _, tt := range tests {
+		if got := ReadOnly(tt.method); got != tt.readOnly {
+			t.Errorf("ReadOnly(%q) = %v, want %v", tt.method, got, tt.readOnly)
+		}
+	}
+}
+
+func TestReadOnly_ListsJobServiceMethods(t *testing.T) {
+	methods := make(map[string]bool)
+	for _, m := range pb.JobService_ServiceDesc.Methods {
+		methods[m.MethodName] = true
+	}
+	for _, s := range pb.JobService_ServiceDesc.Streams {
+		methods[s.StreamName] = true
+	}
+
+	for name := range readOnlyMethods {
+		if !methods[name] {
+			t.Errorf("read-only method %s is not a method of the job service", name)
+		}
+	}
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	called := false
+	handler := func(ctx context.Context, req any) (any, error) {
+		called = true
+		return "ok", nil
+	}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		method  string
+		code    codes.Code
+		reached bool
+	}{
+		{"viewer reads", createMockContext([]string{"viewer"}), pb.JobService_GetJobStatus_FullMethodName, codes.OK, true},
+		{"viewer runs", createMockContext([]string{"viewer"}), pb.JobService_RunJob_FullMethodName, codes.PermissionDenied, false},
+		{"observer stops", createMockContext([]string{"observer"}), pb.JobService_StopJob_FullMethodName, codes.PermissionDenied, false},
+		{"viewer joins fleet", createMockContext([]string{"viewer"}), pb.FleetService_RegisterWorker_FullMethodName, codes.PermissionDenied, false},
+		{"admin runs", createMockContext([]string{"admin"}), pb.JobService_RunJob_FullMethodName, codes.OK, true},
+		{"no peer left to the handler", createMockContextNoPeer(), pb.JobService_RunJob_FullMethodName, codes.OK, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+			_, err := UnaryServerInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+			if called != tt.reached {
+				t.Errorf("handler called = %v, want %v", called, tt.reached)
+			}
+		})
+	}
+}
+
+type fakeServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *fakeServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	handler := func(srv any, ss grpc.ServerStream) error { return nil }
+	viewer := &fakeServerStream{ctx: createMockContext([]string{"viewer"})}
+
+	if err := StreamServerInterceptor(nil, viewer, &grpc.StreamServerInfo{FullMethod: pb.JobService_GetJobLogs_FullMethodName}, handler); err != nil {
+		t.Errorf("expected the viewer to follow logs, got %v", err)
+	}
+	err := StreamServerInterceptor(nil, viewer, &grpc.StreamServerInfo{FullMethod: pb.JobService_UploadJobFiles_FullMethodName}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied uploading files, got %v", err)
+	}
+	err = StreamServerInterceptor(nil, viewer, &grpc.StreamServerInfo{FullMethod: pb.JobService_GetJobArtifact_FullMethodName}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied downloading an artifact, got %v", err)
+	}
+}
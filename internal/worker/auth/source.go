@@ -0,0 +1,155 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"net/netip"
+	"strings"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// SourcePolicy restricts the addresses clients call from, on top of mTLS:
+// connections from outside the allowed networks are closed as they are
+// accepted, and clients with source restrictions may only call from their
+// own networks. A nil policy restricts nothing.
+type SourcePolicy struct {
+	allowed []netip.Prefix
+	clients map[string][]netip.Prefix // by certificate common name
+	logger  *logger.Logger
+}
+
+// NewSourcePolicy creates the policy of the security config, nil when it
+// restricts nothing
+func NewSourcePolicy(cfg config.SecurityConfig) (*SourcePolicy, error) {
+	if len(cfg.AllowedClientCIDRs) == 0 && len(cfg.SourceRestrictions) == 0 {
+		return nil, nil
+	}
+
+	allowed, err := parsePrefixes(cfg.AllowedClientCIDRs)
+	if err != nil {
+		return nil, fmt.Errorf("invalid allowed client CIDRs: %w", err)
+	}
+
+	clients := make(map[string][]netip.Prefix, len(cfg.SourceRestrictions))
+	for _, r := range cfg.SourceRestrictions {
+		prefixes, err := parsePrefixes(r.CIDRs)
+		if err != nil {
+			return nil, fmt.Errorf("invalid source restriction for client %q: %w", r.Client, err)
+		}
+		clients[r.Client] = prefixes
+	}
+
+	return &SourcePolicy{
+		allowed: allowed,
+		clients: clients,
+		logger:  logger.WithField("component", "source-policy"),
+	}, nil
+}
+
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+		if err != nil {
+			return nil, err
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// contains reports whether addr is in one of prefixes, false for addresses
+// other than IP ones
+func contains(prefixes []netip.Prefix, addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	if !ok {
+		return false
+	}
+	ip, ok := netip.AddrFromSlice(tcp.IP)
+	if !ok {
+		return false
+	}
+	ip = ip.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// Listener closes the connections lis accepts from outside the allowed
+// networks before any TLS handshake
+func (p *SourcePolicy) Listener(lis net.Listener) net.Listener {
+	if p == nil || len(p.allowed) == 0 {
+		return lis
+	}
+	return &allowListener{Listener: lis, policy: p}
+}
+
+type allowListener struct {
+	net.Listener
+	policy *SourcePolicy
+}
+
+func (l *allowListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if contains(l.policy.allowed, conn.RemoteAddr()) {
+			return conn, nil
+		}
+		l.policy.logger.Debug("connection refused, source not allowed", "remote", conn.RemoteAddr().String())
+		_ = conn.Close()
+	}
+}
+
+// UnaryServerInterceptor refuses the calls of clients with source
+// restrictions made from outside their networks
+func (p *SourcePolicy) UnaryServerInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if err := p.authorizeSource(ctx, info.FullMethod); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// StreamServerInterceptor is UnaryServerInterceptor for streaming methods
+func (p *SourcePolicy) StreamServerInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := p.authorizeSource(ss.Context(), info.FullMethod); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
+
+func (p *SourcePolicy) authorizeSource(ctx context.Context, fullMethod string) error {
+	if p == nil || len(p.clients) == 0 {
+		return nil
+	}
+	pr, ok := peer.FromContext(ctx)
+	if !ok {
+		return nil
+	}
+	tlsInfo, ok := pr.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return nil
+	}
+
+	client := tlsInfo.State.PeerCertificates[0].Subject.CommonName
+	prefixes, restricted := p.clients[client]
+	if !restricted || contains(prefixes, pr.Addr) {
+		return nil
+	}
+
+	p.logger.Warn("call refused, client calls from outside its networks",
+		"client", client, "remote", pr.Addr.String(), "method", fullMethod)
+	return status.Errorf(codes.PermissionDenied, "client %s may not call from %s", client, pr.Addr)
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"net"
+	"testing"
+	"time"
+	"worker/pkg/config"
+)
+
+func clientContext(commonName string, addr string) context.Context {
+	tcp, _ := net.ResolveTCPAddr("tcp", addr)
+	return peer.NewContext(context.Background(), &peer.Peer{
+		Addr: tcp,
+		AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+			PeerCertificates: []*x509.Certificate{{Subject: pkix.Name{CommonName: commonName}}},
+		}},
+	})
+}
+
+func TestNewSourcePolicy(t *testing.T) {
+	if p, err := NewSourcePolicy(config.SecurityConfig{}); p != nil || err != nil {
+		t.Errorf("expected no policy without restrictions, got %v, %v", p, err)
+	}
+	if _, err := NewSourcePolicy(config.SecurityConfig{AllowedClientCIDRs: []string{"10.0.0.1"}}); err == nil {
+		t.Error("expected an error for an address without prefix length")
+	}
+	bad := config.SecurityConfig{SourceRestrictions: []config.SourceRestriction{{Client: "ci", CIDRs: []string{"nope"}}}}
+	if _, err := NewSourcePolicy(bad); err == nil {
+		t.Error("expected an error for an invalid restriction")
+	}
+}
+
+func TestSourcePolicy_Listener(t *testing.T) {
+	accept := func(cidrs ...string) bool {
+		t.Helper()
+		p, err := NewSourcePolicy(config.SecurityConfig{AllowedClientCIDRs: cidrs})
+		if err != nil {
+			t.Fatal(err)
+		}
+		inner, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		lis := p.Listener(inner)
+		defer lis.Close()
+
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			if conn, err := lis.Accept(); err == nil {
+				accepted <- conn
+			}
+		}()
+
+		conn, err := net.Dial("tcp", inner.Addr().String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		select {
+		case c := <-accepted:
+			c.Close()
+			return true
+		case <-time.After(200 * time.Millisecond):
+			// refused connections are closed by the server
+			_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+			if _, err := conn.Read(make([]byte, 1)); err == nil {
+				t.Error("expected the refused connection to be closed")
+			}
+			return false
+		}
+	}
+
+	if !accept("127.0.0.0/8") {
+		t.Error("expected a connection from an allowed network to be accepted")
+	}
+	if accept("10.0.0.0/8", "192.168.0.0/16") {
+		t.Error("expected a connection from elsewhere to be refused")
+	}
+}
+
+func TestSourcePolicy_Interceptors(t *testing.T) {
+	p, err := NewSourcePolicy(config.SecurityConfig{SourceRestrictions: []config.SourceRestriction{
+		{Client: "ci-runner", CIDRs: []string{"10.1.0.0/16", "fd00::/8"}},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		code codes.Code
+	}{
+		{"restricted client from its network", clientContext("ci-runner", "10.1.2.3:5000"), codes.OK},
+		{"restricted client over IPv6", clientContext("ci-runner", "[fd00::1]:5000"), codes.OK},
+		{"restricted client from elsewhere", clientContext("ci-runner", "10.2.0.1:5000"), codes.PermissionDenied},
+		{"unrestricted client", clientContext("ops-laptop", "192.0.2.1:5000"), codes.OK},
+		{"trusted context", WithRole(context.Background(), AdminRole), codes.OK},
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) { return nil, nil }
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.UnaryServerInterceptor(tt.ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/jobworker.v1.JobService/ListJobs"}, handler)
+			if status.Code(err) != tt.code {
+				t.Errorf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+
+	var none *SourcePolicy
+	stream := &fakeServerStream{ctx: clientContext("ci-runner", "10.2.0.1:5000")}
+	if err := none.StreamServerInterceptor(nil, stream, &grpc.StreamServerInfo{}, func(any, grpc.ServerStream) error { return nil }); err != nil {
+		t.Errorf("expected a nil policy to allow any call, got %v", err)
+	}
+}
@@ -0,0 +1,224 @@
+package cloudevents
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// SpecVersion is the version of the CloudEvents specification emitted
+const SpecVersion = "1.0"
+
+// typePrefix namespaces the event types, "job.created" is emitted as
+// "io.jobworker.job.created"
+const typePrefix = "io.jobworker."
+
+// Event is a CloudEvent in the JSON event format, the structured content mode
+// of both the HTTP and the NATS protocol bindings
+type Event struct {
+	SpecVersion     string  `json:"specversion"`
+	ID              string  `json:"id"`
+	Source          string  `json:"source"`
+	Type            string  `json:"type"`
+	Subject         string  `json:"subject"` // the job id
+	Time            string  `json:"time"`
+	DataContentType string  `json:"datacontenttype"`
+	Data            JobData `json:"data"`
+}
+
+// JobData is the data of a job event. Environment variables are left out
+// since they may carry credentials.
+type JobData struct {
+	JobID        string            `json:"jobId"`
+	Command      string            `json:"command,omitempty"`
+	Status       string            `json:"status,omitempty"`
+	ExitCode     int32             `json:"exitCode"`
+	Restarts     int32             `json:"restarts"`
+	Health       string            `json:"health,omitempty"`
+	Labels       map[string]string `json:"labels,omitempty"`
+	GroupID      string            `json:"groupId,omitempty"`
+	StartTime    string            `json:"startTime,omitempty"`
+	EndTime      string            `json:"endTime,omitempty"`
+	CPUThrottled float64           `json:"cpuThrottledPercent,omitempty"` // finished jobs only
+	CleanupError string            `json:"cleanupError,omitempty"`        // job.cleaned_up only
+	Error        string            `json:"error,omitempty"`               // job.stuck only
+}
+
+// Emitter publishes the job events of the bus as CloudEvents. Events are
+// queued and sent in order by one goroutine, so a slow endpoint never holds up
+// the worker; events arriving while the queue is full are dropped.
+type Emitter struct {
+	transport Transport
+	source    string
+	types     map[events.Type]bool // empty for all
+	idPrefix  string
+	sequence  atomic.Uint64
+
+	mutex  sync.RWMutex // guards sending to the queue against Close
+	closed bool
+	queue  chan Event
+	done   chan struct{}
+	logger *logger.Logger // sampled, an overloaded endpoint fails many sends
+}
+
+// New creates an emitter from configuration. It returns nil when CloudEvents
+// are disabled.
+func New(cfg config.CloudEventsConfig) (*Emitter, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithTransport(transport, cfg)
+}
+
+// NewWithTransport creates an emitter sending through an already constructed transport
+func NewWithTransport(transport Transport, cfg config.CloudEventsConfig) (*Emitter, error) {
+	types := make(map[events.Type]bool, len(cfg.Types))
+	for _, name := range cfg.Types {
+		t := events.Type(name)
+		if !t.Known() {
+			return nil, fmt.Errorf("unknown job event type: %s", name)
+		}
+		types[t] = true
+	}
+
+	source := cfg.Source
+	if source == "" {
+		hostname, _ := os.Hostname()
+		source = "/worker/" + hostname
+	}
+
+	queueSize := cfg.QueueSize
+	if queueSize < 1 {
+		queueSize = 1
+	}
+
+	log := logger.WithField("component", "cloudevents")
+	e := &Emitter{
+		transport: transport,
+		source:    source,
+		types:     types,
+		idPrefix:  newIDPrefix(),
+		queue:     make(chan Event, queueSize),
+		done:      make(chan struct{}),
+		logger:    log.Sampled(1),
+	}
+	go e.run()
+
+	log.Info("cloudevents enabled", "transport", transport.Name(), "source", source)
+	return e, nil
+}
+
+// Handler returns the bus handler that queues the events to emit
+func (e *Emitter) Handler() events.Handler {
+	return func(ev events.Event) {
+		if len(e.types) > 0 && !e.types[ev.Type] {
+			return
+		}
+
+		e.mutex.RLock()
+		defer e.mutex.RUnlock()
+		if e.closed {
+			return
+		}
+
+		select {
+		case e.queue <- e.convert(ev):
+		default:
+			e.logger.Warn("cloudevents queue full, dropping event", "eventType", string(ev.Type), "jobId", ev.JobID)
+		}
+	}
+}
+
+// Close sends the queued events and closes the transport. Events published
+// afterwards are ignored.
+func (e *Emitter) Close() error {
+	e.mutex.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.queue)
+	}
+	e.mutex.Unlock()
+
+	<-e.done
+	return e.transport.Close()
+}
+
+func (e *Emitter) run() {
+	defer close(e.done)
+
+	for ev := range e.queue {
+		if err := e.transport.Send(&ev); err != nil {
+			e.logger.Warn("failed to send cloudevent", "type", ev.Type, "jobId", ev.Subject, "error", err)
+		}
+	}
+}
+
+// convert builds the CloudEvent of a job event
+func (e *Emitter) convert(ev events.Event) Event {
+	data := JobData{JobID: ev.JobID}
+	if job := ev.Job; job != nil {
+		data = jobData(job)
+	}
+	switch {
+	case ev.Err == nil:
+	case ev.Type == events.JobCleanedUp:
+		data.CleanupError = ev.Err.Error()
+	default:
+		data.Error = ev.Err.Error()
+	}
+
+	return Event{
+		SpecVersion:     SpecVersion,
+		ID:              e.idPrefix + "-" + strconv.FormatUint(e.sequence.Add(1), 10),
+		Source:          e.source,
+		Type:            typePrefix + string(ev.Type),
+		Subject:         ev.JobID,
+		Time:            ev.Time.UTC().Format(time.RFC3339Nano),
+		DataContentType: "application/json",
+		Data:            data,
+	}
+}
+
+func jobData(job *domain.Job) JobData {
+	data := JobData{
+		JobID:        job.Id,
+		Command:      job.Command,
+		Status:       string(job.Status),
+		ExitCode:     job.ExitCode,
+		Restarts:     job.Restarts,
+		Health:       string(job.Health),
+		Labels:       job.Labels,
+		GroupID:      job.GroupId,
+		StartTime:    job.StartTime.UTC().Format(time.RFC3339Nano),
+		CPUThrottled: job.CPUThrottled,
+	}
+	if job.EndTime != nil {
+		data.EndTime = job.EndTime.UTC().Format(time.RFC3339Nano)
+	}
+	return data
+}
+
+// newIDPrefix makes event ids unique across worker restarts, the sequence
+// number makes them unique within one
+func newIDPrefix() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 36)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,167 @@
+package cloudevents_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/cloudevents"
+	"worker/internal/worker/cloudevents/cloudeventsfakes"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/pkg/config"
+)
+
+func publishJobEvents(bus *events.Bus) {
+	job := &domain.Job{
+		Id:        "7",
+		Command:   "/bin/true",
+		Env:       map[string]string{"TOKEN": "secret"},
+		Labels:    map[string]string{"team": "infra"},
+		Status:    domain.StatusRunning,
+		StartTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+	bus.Publish(events.Event{Type: events.JobCreated, Job: job})
+	bus.Publish(events.Event{Type: events.JobCleanedUp, JobID: "7", Err: errors.New("device busy")})
+}
+
+func TestEmitterSendsEvents(t *testing.T) {
+	transport := &cloudeventsfakes.FakeTransport{}
+	emitter, err := cloudevents.NewWithTransport(transport, config.CloudEventsConfig{Source: "/worker/test", QueueSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	bus.Handle(emitter.Handler())
+	publishJobEvents(bus)
+
+	if err := emitter.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if transport.SendCallCount() != 2 || transport.CloseCallCount() != 1 {
+		t.Fatalf("expected 2 sends and a close, got %d and %d", transport.SendCallCount(), transport.CloseCallCount())
+	}
+
+	created := transport.SendArgsForCall(0)
+	if created.SpecVersion != "1.0" || created.Type != "io.jobworker.job.created" || created.Source != "/worker/test" || created.Subject != "7" {
+		t.Errorf("unexpected event %+v", created)
+	}
+	if created.Data.Status != "RUNNING" || created.Data.Labels["team"] != "infra" {
+		t.Errorf("unexpected data %+v", created.Data)
+	}
+
+	cleaned := transport.SendArgsForCall(1)
+	if cleaned.Type != "io.jobworker.job.cleaned_up" || cleaned.Data.CleanupError != "device busy" {
+		t.Errorf("unexpected event %+v", cleaned)
+	}
+	if cleaned.ID == created.ID {
+		t.Error("expected unique event ids")
+	}
+}
+
+func TestEmitterReportsStuckOperations(t *testing.T) {
+	transport := &cloudeventsfakes.FakeTransport{}
+	emitter, err := cloudevents.NewWithTransport(transport, config.CloudEventsConfig{QueueSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	bus.Handle(emitter.Handler())
+	bus.Publish(events.Event{Type: events.JobStuck, JobID: "7", Err: errors.New("launch took longer than 1m0s")})
+	_ = emitter.Close()
+
+	stuck := transport.SendArgsForCall(0)
+	if stuck.Type != "io.jobworker.job.stuck" || stuck.Data.Error == "" || stuck.Data.CleanupError != "" {
+		t.Errorf("unexpected event %+v", stuck)
+	}
+}
+
+func TestEmitterFiltersTypes(t *testing.T) {
+	transport := &cloudeventsfakes.FakeTransport{}
+	emitter, err := cloudevents.NewWithTransport(transport, config.CloudEventsConfig{Types: []string{"job.cleaned_up"}, QueueSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	bus.Handle(emitter.Handler())
+	publishJobEvents(bus)
+	_ = emitter.Close()
+
+	if transport.SendCallCount() != 1 || transport.SendArgsForCall(0).Type != "io.jobworker.job.cleaned_up" {
+		t.Errorf("expected only the cleanup event, got %d sends", transport.SendCallCount())
+	}
+
+	if _, err := cloudevents.NewWithTransport(transport, config.CloudEventsConfig{Types: []string{"job.deleted"}}); err == nil {
+		t.Error("expected an unknown event type to be rejected")
+	}
+}
+
+func TestEmitterIgnoresEventsAfterClose(t *testing.T) {
+	transport := &cloudeventsfakes.FakeTransport{}
+	emitter, err := cloudevents.NewWithTransport(transport, config.CloudEventsConfig{QueueSize: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bus := events.NewBus()
+	bus.Handle(emitter.Handler())
+	_ = emitter.Close()
+	publishJobEvents(bus)
+
+	if transport.SendCallCount() != 0 {
+		t.Errorf("expected no sends after close, got %d", transport.SendCallCount())
+	}
+}
+
+func TestHTTPTransportStructuredMode(t *testing.T) {
+	var contentType string
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		contentType = req.Header.Get("Content-Type")
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	transport, err := cloudevents.NewTransport(config.CloudEventsConfig{Transport: "http", URL: server.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer transport.Close()
+
+	event := &cloudevents.Event{SpecVersion: "1.0", ID: "1", Source: "/worker/test", Type: "io.jobworker.job.created", Subject: "7"}
+	if err := transport.Send(event); err != nil {
+		t.Fatalf("Send failed: %v", err)
+	}
+
+	if !strings.HasPrefix(contentType, "application/cloudevents+json") {
+		t.Errorf("unexpected content type %q", contentType)
+	}
+	if body["specversion"] != "1.0" || body["type"] != "io.jobworker.job.created" || body["subject"] != "7" {
+		t.Errorf("unexpected body %v", body)
+	}
+}
+
+func TestHTTPTransportRejectedEvent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	transport, _ := cloudevents.NewTransport(config.CloudEventsConfig{Transport: "http", URL: server.URL})
+	if err := transport.Send(&cloudevents.Event{}); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("expected the rejection, got %v", err)
+	}
+}
+
+func TestNewTransportUnsupported(t *testing.T) {
+	if _, err := cloudevents.NewTransport(config.CloudEventsConfig{Transport: "kafka"}); err == nil {
+		t.Error("expected an unsupported transport to be rejected")
+	}
+}
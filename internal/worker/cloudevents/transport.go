@@ -0,0 +1,154 @@
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+	"worker/internal/worker/nats"
+	"worker/pkg/config"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// contentType is the structured content mode media type of the JSON event format
+const contentType = "application/cloudevents+json; charset=utf-8"
+
+// Transport delivers CloudEvents to an event platform
+//
+//counterfeiter:generate . Transport
+type Transport interface {
+	Name() string
+	Send(event *Event) error
+	Close() error
+}
+
+// NewTransport creates the transport for the given configuration
+func NewTransport(cfg config.CloudEventsConfig) (Transport, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	switch cfg.Transport {
+	case "http":
+		return newHTTPTransport(cfg), nil
+	case "nats":
+		return newNATSTransport(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported cloudevents transport: %s", cfg.Transport)
+	}
+}
+
+// httpTransport POSTs each event in structured content mode, as a Knative
+// broker or an Argo Events webhook source expects
+type httpTransport struct {
+	url    string
+	client *http.Client
+}
+
+func newHTTPTransport(cfg config.CloudEventsConfig) Transport {
+	return &httpTransport{
+		url:    cfg.URL,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+func (h *httpTransport) Name() string {
+	return "http"
+}
+
+func (h *httpTransport) Send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode cloudevent: %w", err)
+	}
+
+	resp, err := h.client.Post(h.url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("cloudevent post failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("cloudevent post returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (h *httpTransport) Close() error {
+	h.client.CloseIdleConnections()
+	return nil
+}
+
+// natsTransport publishes each event as a JSON message on a subject, the
+// structured content mode of the NATS binding
+type natsTransport struct {
+	url     string
+	subject string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn *nats.Conn
+}
+
+func newNATSTransport(cfg config.CloudEventsConfig) Transport {
+	return &natsTransport{
+		url:     cfg.URL,
+		subject: cfg.Subject,
+		timeout: cfg.Timeout,
+	}
+}
+
+func (n *natsTransport) Name() string {
+	return "nats"
+}
+
+// Send publishes the event, reconnecting once if the connection was lost
+func (n *natsTransport) Send(event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to encode cloudevent: %w", err)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		if n.conn == nil {
+			conn, err := nats.Dial(n.url, n.timeout)
+			if err != nil {
+				return err
+			}
+			n.conn = conn
+		}
+
+		if err := n.conn.Publish(n.subject, body); err != nil {
+			lastErr = err
+			_ = n.conn.Close()
+			n.conn = nil
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("cloudevent publish failed: %w", lastErr)
+}
+
+// Close waits until the server received the published events
+func (n *natsTransport) Close() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.conn == nil {
+		return nil
+	}
+	err := n.conn.Flush()
+	_ = n.conn.Close()
+	n.conn = nil
+	return err
+}
@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"worker/internal/worker/domain"
 )
 
@@ -9,6 +10,12 @@ import (
 
 //counterfeiter:generate . Worker
 type Worker interface {
-	StartJob(ctx context.Context, command string, args []string, maxCPU, maxMemory, maxIOBPS int32) (*domain.Job, error)
-	StopJob(ctx context.Context, jobId string) error
+	StartJob(ctx context.Context, spec *domain.JobSpec) (*domain.Job, error)
+	ExpireJob(ctx context.Context, spec *domain.JobSpec, reason error) (*domain.Job, error)
+	StopJob(ctx context.Context, jobId string, opts domain.StopOptions) (*domain.StopResult, error)
+	ResumeJob(ctx context.Context, jobId string) error
+	ValidateJob(ctx context.Context, spec *domain.JobSpec) *domain.JobValidation
+	OpenStdin(jobId string) (io.WriteCloser, error)
+	Capabilities() []domain.Capability
+	Diagnostics(ctx context.Context) domain.Diagnostics
 }
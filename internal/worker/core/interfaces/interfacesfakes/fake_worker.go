@@ -3,21 +3,79 @@ package interfacesfakes
 
 import (
 	"context"
+	"io"
 	"sync"
 	"worker/internal/worker/core/interfaces"
 	"worker/internal/worker/domain"
 )
 
 type FakeWorker struct {
-	StartJobStub        func(context.Context, string, []string, int32, int32, int32) (*domain.Job, error)
+	CapabilitiesStub        func() []domain.Capability
+	capabilitiesMutex       sync.RWMutex
+	capabilitiesArgsForCall []struct {
+	}
+	capabilitiesReturns struct {
+		result1 []domain.Capability
+	}
+	capabilitiesReturnsOnCall map[int]struct {
+		result1 []domain.Capability
+	}
+	DiagnosticsStub        func(context.Context) domain.Diagnostics
+	diagnosticsMutex       sync.RWMutex
+	diagnosticsArgsForCall []struct {
+		arg1 context.Context
+	}
+	diagnosticsReturns struct {
+		result1 domain.Diagnostics
+	}
+	diagnosticsReturnsOnCall map[int]struct {
+		result1 domain.Diagnostics
+	}
+	ExpireJobStub        func(context.Context, *domain.JobSpec, error) (*domain.Job, error)
+	expireJobMutex       sync.RWMutex
+	expireJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 *domain.JobSpec
+		arg3 error
+	}
+	expireJobReturns struct {
+		result1 *domain.Job
+		result2 error
+	}
+	expireJobReturnsOnCall map[int]struct {
+		result1 *domain.Job
+		result2 error
+	}
+	OpenStdinStub        func(string) (io.WriteCloser, error)
+	openStdinMutex       sync.RWMutex
+	openStdinArgsForCall []struct {
+		arg1 string
+	}
+	openStdinReturns struct {
+		result1 io.WriteCloser
+		result2 error
+	}
+	openStdinReturnsOnCall map[int]struct {
+		result1 io.WriteCloser
+		result2 error
+	}
+	ResumeJobStub        func(context.Context, string) error
+	resumeJobMutex       sync.RWMutex
+	resumeJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	resumeJobReturns struct {
+		result1 error
+	}
+	resumeJobReturnsOnCall map[int]struct {
+		result1 error
+	}
+	StartJobStub        func(context.Context, *domain.JobSpec) (*domain.Job, error)
 	startJobMutex       sync.RWMutex
 	startJobArgsForCall []struct {
 		arg1 context.Context
-		arg2 string
-		arg3 []string
-		arg4 int32
-		arg5 int32
-		arg6 int32
+		arg2 *domain.JobSpec
 	}
 	startJobReturns struct {
 		result1 *domain.Job
@@ -27,44 +85,356 @@ type FakeWorker struct {
 		result1 *domain.Job
 		result2 error
 	}
-	StopJobStub        func(context.Context, string) error
+	StopJobStub        func(context.Context, string, domain.StopOptions) (*domain.StopResult, error)
 	stopJobMutex       sync.RWMutex
 	stopJobArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
+		arg3 domain.StopOptions
 	}
 	stopJobReturns struct {
-		result1 error
+		result1 *domain.StopResult
+		result2 error
 	}
 	stopJobReturnsOnCall map[int]struct {
-		result1 error
+		result1 *domain.StopResult
+		result2 error
+	}
+	ValidateJobStub        func(context.Context, *domain.JobSpec) *domain.JobValidation
+	validateJobMutex       sync.RWMutex
+	validateJobArgsForCall []struct {
+		arg1 context.Context
+		arg2 *domain.JobSpec
+	}
+	validateJobReturns struct {
+		result1 *domain.JobValidation
+	}
+	validateJobReturnsOnCall map[int]struct {
+		result1 *domain.JobValidation
 	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeWorker) StartJob(arg1 context.Context, arg2 string, arg3 []string, arg4 int32, arg5 int32, arg6 int32) (*domain.Job, error) {
-	var arg3Copy []string
-	if arg3 != nil {
-		arg3Copy = make([]string, len(arg3))
-		copy(arg3Copy, arg3)
+func (fake *FakeWorker) Capabilities() []domain.Capability {
+	fake.capabilitiesMutex.Lock()
+	ret, specificReturn := fake.capabilitiesReturnsOnCall[len(fake.capabilitiesArgsForCall)]
+	fake.capabilitiesArgsForCall = append(fake.capabilitiesArgsForCall, struct {
+	}{})
+	stub := fake.CapabilitiesStub
+	fakeReturns := fake.capabilitiesReturns
+	fake.recordInvocation("Capabilities", []interface{}{})
+	fake.capabilitiesMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) CapabilitiesCallCount() int {
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	return len(fake.capabilitiesArgsForCall)
+}
+
+func (fake *FakeWorker) CapabilitiesCalls(stub func() []domain.Capability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = stub
+}
+
+func (fake *FakeWorker) CapabilitiesReturns(result1 []domain.Capability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	fake.capabilitiesReturns = struct {
+		result1 []domain.Capability
+	}{result1}
+}
+
+func (fake *FakeWorker) CapabilitiesReturnsOnCall(i int, result1 []domain.Capability) {
+	fake.capabilitiesMutex.Lock()
+	defer fake.capabilitiesMutex.Unlock()
+	fake.CapabilitiesStub = nil
+	if fake.capabilitiesReturnsOnCall == nil {
+		fake.capabilitiesReturnsOnCall = make(map[int]struct {
+			result1 []domain.Capability
+		})
+	}
+	fake.capabilitiesReturnsOnCall[i] = struct {
+		result1 []domain.Capability
+	}{result1}
+}
+
+func (fake *FakeWorker) Diagnostics(arg1 context.Context) domain.Diagnostics {
+	fake.diagnosticsMutex.Lock()
+	ret, specificReturn := fake.diagnosticsReturnsOnCall[len(fake.diagnosticsArgsForCall)]
+	fake.diagnosticsArgsForCall = append(fake.diagnosticsArgsForCall, struct {
+		arg1 context.Context
+	}{arg1})
+	stub := fake.DiagnosticsStub
+	fakeReturns := fake.diagnosticsReturns
+	fake.recordInvocation("Diagnostics", []interface{}{arg1})
+	fake.diagnosticsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) DiagnosticsCallCount() int {
+	fake.diagnosticsMutex.RLock()
+	defer fake.diagnosticsMutex.RUnlock()
+	return len(fake.diagnosticsArgsForCall)
+}
+
+func (fake *FakeWorker) DiagnosticsCalls(stub func(context.Context) domain.Diagnostics) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = stub
+}
+
+func (fake *FakeWorker) DiagnosticsArgsForCall(i int) context.Context {
+	fake.diagnosticsMutex.RLock()
+	defer fake.diagnosticsMutex.RUnlock()
+	argsForCall := fake.diagnosticsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) DiagnosticsReturns(result1 domain.Diagnostics) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = nil
+	fake.diagnosticsReturns = struct {
+		result1 domain.Diagnostics
+	}{result1}
+}
+
+func (fake *FakeWorker) DiagnosticsReturnsOnCall(i int, result1 domain.Diagnostics) {
+	fake.diagnosticsMutex.Lock()
+	defer fake.diagnosticsMutex.Unlock()
+	fake.DiagnosticsStub = nil
+	if fake.diagnosticsReturnsOnCall == nil {
+		fake.diagnosticsReturnsOnCall = make(map[int]struct {
+			result1 domain.Diagnostics
+		})
+	}
+	fake.diagnosticsReturnsOnCall[i] = struct {
+		result1 domain.Diagnostics
+	}{result1}
+}
+
+func (fake *FakeWorker) ExpireJob(arg1 context.Context, arg2 *domain.JobSpec, arg3 error) (*domain.Job, error) {
+	fake.expireJobMutex.Lock()
+	ret, specificReturn := fake.expireJobReturnsOnCall[len(fake.expireJobArgsForCall)]
+	fake.expireJobArgsForCall = append(fake.expireJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 *domain.JobSpec
+		arg3 error
+	}{arg1, arg2, arg3})
+	stub := fake.ExpireJobStub
+	fakeReturns := fake.expireJobReturns
+	fake.recordInvocation("ExpireJob", []interface{}{arg1, arg2, arg3})
+	fake.expireJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) ExpireJobCallCount() int {
+	fake.expireJobMutex.RLock()
+	defer fake.expireJobMutex.RUnlock()
+	return len(fake.expireJobArgsForCall)
+}
+
+func (fake *FakeWorker) ExpireJobCalls(stub func(context.Context, *domain.JobSpec, error) (*domain.Job, error)) {
+	fake.expireJobMutex.Lock()
+	defer fake.expireJobMutex.Unlock()
+	fake.ExpireJobStub = stub
+}
+
+func (fake *FakeWorker) ExpireJobArgsForCall(i int) (context.Context, *domain.JobSpec, error) {
+	fake.expireJobMutex.RLock()
+	defer fake.expireJobMutex.RUnlock()
+	argsForCall := fake.expireJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeWorker) ExpireJobReturns(result1 *domain.Job, result2 error) {
+	fake.expireJobMutex.Lock()
+	defer fake.expireJobMutex.Unlock()
+	fake.ExpireJobStub = nil
+	fake.expireJobReturns = struct {
+		result1 *domain.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ExpireJobReturnsOnCall(i int, result1 *domain.Job, result2 error) {
+	fake.expireJobMutex.Lock()
+	defer fake.expireJobMutex.Unlock()
+	fake.ExpireJobStub = nil
+	if fake.expireJobReturnsOnCall == nil {
+		fake.expireJobReturnsOnCall = make(map[int]struct {
+			result1 *domain.Job
+			result2 error
+		})
 	}
+	fake.expireJobReturnsOnCall[i] = struct {
+		result1 *domain.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) OpenStdin(arg1 string) (io.WriteCloser, error) {
+	fake.openStdinMutex.Lock()
+	ret, specificReturn := fake.openStdinReturnsOnCall[len(fake.openStdinArgsForCall)]
+	fake.openStdinArgsForCall = append(fake.openStdinArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.OpenStdinStub
+	fakeReturns := fake.openStdinReturns
+	fake.recordInvocation("OpenStdin", []interface{}{arg1})
+	fake.openStdinMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWorker) OpenStdinCallCount() int {
+	fake.openStdinMutex.RLock()
+	defer fake.openStdinMutex.RUnlock()
+	return len(fake.openStdinArgsForCall)
+}
+
+func (fake *FakeWorker) OpenStdinCalls(stub func(string) (io.WriteCloser, error)) {
+	fake.openStdinMutex.Lock()
+	defer fake.openStdinMutex.Unlock()
+	fake.OpenStdinStub = stub
+}
+
+func (fake *FakeWorker) OpenStdinArgsForCall(i int) string {
+	fake.openStdinMutex.RLock()
+	defer fake.openStdinMutex.RUnlock()
+	argsForCall := fake.openStdinArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWorker) OpenStdinReturns(result1 io.WriteCloser, result2 error) {
+	fake.openStdinMutex.Lock()
+	defer fake.openStdinMutex.Unlock()
+	fake.OpenStdinStub = nil
+	fake.openStdinReturns = struct {
+		result1 io.WriteCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) OpenStdinReturnsOnCall(i int, result1 io.WriteCloser, result2 error) {
+	fake.openStdinMutex.Lock()
+	defer fake.openStdinMutex.Unlock()
+	fake.OpenStdinStub = nil
+	if fake.openStdinReturnsOnCall == nil {
+		fake.openStdinReturnsOnCall = make(map[int]struct {
+			result1 io.WriteCloser
+			result2 error
+		})
+	}
+	fake.openStdinReturnsOnCall[i] = struct {
+		result1 io.WriteCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ResumeJob(arg1 context.Context, arg2 string) error {
+	fake.resumeJobMutex.Lock()
+	ret, specificReturn := fake.resumeJobReturnsOnCall[len(fake.resumeJobArgsForCall)]
+	fake.resumeJobArgsForCall = append(fake.resumeJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.ResumeJobStub
+	fakeReturns := fake.resumeJobReturns
+	fake.recordInvocation("ResumeJob", []interface{}{arg1, arg2})
+	fake.resumeJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ResumeJobCallCount() int {
+	fake.resumeJobMutex.RLock()
+	defer fake.resumeJobMutex.RUnlock()
+	return len(fake.resumeJobArgsForCall)
+}
+
+func (fake *FakeWorker) ResumeJobCalls(stub func(context.Context, string) error) {
+	fake.resumeJobMutex.Lock()
+	defer fake.resumeJobMutex.Unlock()
+	fake.ResumeJobStub = stub
+}
+
+func (fake *FakeWorker) ResumeJobArgsForCall(i int) (context.Context, string) {
+	fake.resumeJobMutex.RLock()
+	defer fake.resumeJobMutex.RUnlock()
+	argsForCall := fake.resumeJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) ResumeJobReturns(result1 error) {
+	fake.resumeJobMutex.Lock()
+	defer fake.resumeJobMutex.Unlock()
+	fake.ResumeJobStub = nil
+	fake.resumeJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) ResumeJobReturnsOnCall(i int, result1 error) {
+	fake.resumeJobMutex.Lock()
+	defer fake.resumeJobMutex.Unlock()
+	fake.ResumeJobStub = nil
+	if fake.resumeJobReturnsOnCall == nil {
+		fake.resumeJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.resumeJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWorker) StartJob(arg1 context.Context, arg2 *domain.JobSpec) (*domain.Job, error) {
 	fake.startJobMutex.Lock()
 	ret, specificReturn := fake.startJobReturnsOnCall[len(fake.startJobArgsForCall)]
 	fake.startJobArgsForCall = append(fake.startJobArgsForCall, struct {
 		arg1 context.Context
-		arg2 string
-		arg3 []string
-		arg4 int32
-		arg5 int32
-		arg6 int32
-	}{arg1, arg2, arg3Copy, arg4, arg5, arg6})
+		arg2 *domain.JobSpec
+	}{arg1, arg2})
 	stub := fake.StartJobStub
 	fakeReturns := fake.startJobReturns
-	fake.recordInvocation("StartJob", []interface{}{arg1, arg2, arg3Copy, arg4, arg5, arg6})
+	fake.recordInvocation("StartJob", []interface{}{arg1, arg2})
 	fake.startJobMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2, arg3, arg4, arg5, arg6)
+		return stub(arg1, arg2)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2
@@ -78,17 +448,17 @@ func (fake *FakeWorker) StartJobCallCount() int {
 	return len(fake.startJobArgsForCall)
 }
 
-func (fake *FakeWorker) StartJobCalls(stub func(context.Context, string, []string, int32, int32, int32) (*domain.Job, error)) {
+func (fake *FakeWorker) StartJobCalls(stub func(context.Context, *domain.JobSpec) (*domain.Job, error)) {
 	fake.startJobMutex.Lock()
 	defer fake.startJobMutex.Unlock()
 	fake.StartJobStub = stub
 }
 
-func (fake *FakeWorker) StartJobArgsForCall(i int) (context.Context, string, []string, int32, int32, int32) {
+func (fake *FakeWorker) StartJobArgsForCall(i int) (context.Context, *domain.JobSpec) {
 	fake.startJobMutex.RLock()
 	defer fake.startJobMutex.RUnlock()
 	argsForCall := fake.startJobArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+	return argsForCall.arg1, argsForCall.arg2
 }
 
 func (fake *FakeWorker) StartJobReturns(result1 *domain.Job, result2 error) {
@@ -117,24 +487,25 @@ func (fake *FakeWorker) StartJobReturnsOnCall(i int, result1 *domain.Job, result
 	}{result1, result2}
 }
 
-func (fake *FakeWorker) StopJob(arg1 context.Context, arg2 string) error {
+func (fake *FakeWorker) StopJob(arg1 context.Context, arg2 string, arg3 domain.StopOptions) (*domain.StopResult, error) {
 	fake.stopJobMutex.Lock()
 	ret, specificReturn := fake.stopJobReturnsOnCall[len(fake.stopJobArgsForCall)]
 	fake.stopJobArgsForCall = append(fake.stopJobArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
-	}{arg1, arg2})
+		arg3 domain.StopOptions
+	}{arg1, arg2, arg3})
 	stub := fake.StopJobStub
 	fakeReturns := fake.stopJobReturns
-	fake.recordInvocation("StopJob", []interface{}{arg1, arg2})
+	fake.recordInvocation("StopJob", []interface{}{arg1, arg2, arg3})
 	fake.stopJobMutex.Unlock()
 	if stub != nil {
-		return stub(arg1, arg2)
+		return stub(arg1, arg2, arg3)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeWorker) StopJobCallCount() int {
@@ -143,49 +514,126 @@ func (fake *FakeWorker) StopJobCallCount() int {
 	return len(fake.stopJobArgsForCall)
 }
 
-func (fake *FakeWorker) StopJobCalls(stub func(context.Context, string) error) {
+func (fake *FakeWorker) StopJobCalls(stub func(context.Context, string, domain.StopOptions) (*domain.StopResult, error)) {
 	fake.stopJobMutex.Lock()
 	defer fake.stopJobMutex.Unlock()
 	fake.StopJobStub = stub
 }
 
-func (fake *FakeWorker) StopJobArgsForCall(i int) (context.Context, string) {
+func (fake *FakeWorker) StopJobArgsForCall(i int) (context.Context, string, domain.StopOptions) {
 	fake.stopJobMutex.RLock()
 	defer fake.stopJobMutex.RUnlock()
 	argsForCall := fake.stopJobArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeWorker) StopJobReturns(result1 error) {
+func (fake *FakeWorker) StopJobReturns(result1 *domain.StopResult, result2 error) {
 	fake.stopJobMutex.Lock()
 	defer fake.stopJobMutex.Unlock()
 	fake.StopJobStub = nil
 	fake.stopJobReturns = struct {
-		result1 error
-	}{result1}
+		result1 *domain.StopResult
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeWorker) StopJobReturnsOnCall(i int, result1 error) {
+func (fake *FakeWorker) StopJobReturnsOnCall(i int, result1 *domain.StopResult, result2 error) {
 	fake.stopJobMutex.Lock()
 	defer fake.stopJobMutex.Unlock()
 	fake.StopJobStub = nil
 	if fake.stopJobReturnsOnCall == nil {
 		fake.stopJobReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 *domain.StopResult
+			result2 error
 		})
 	}
 	fake.stopJobReturnsOnCall[i] = struct {
-		result1 error
+		result1 *domain.StopResult
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWorker) ValidateJob(arg1 context.Context, arg2 *domain.JobSpec) *domain.JobValidation {
+	fake.validateJobMutex.Lock()
+	ret, specificReturn := fake.validateJobReturnsOnCall[len(fake.validateJobArgsForCall)]
+	fake.validateJobArgsForCall = append(fake.validateJobArgsForCall, struct {
+		arg1 context.Context
+		arg2 *domain.JobSpec
+	}{arg1, arg2})
+	stub := fake.ValidateJobStub
+	fakeReturns := fake.validateJobReturns
+	fake.recordInvocation("ValidateJob", []interface{}{arg1, arg2})
+	fake.validateJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ValidateJobCallCount() int {
+	fake.validateJobMutex.RLock()
+	defer fake.validateJobMutex.RUnlock()
+	return len(fake.validateJobArgsForCall)
+}
+
+func (fake *FakeWorker) ValidateJobCalls(stub func(context.Context, *domain.JobSpec) *domain.JobValidation) {
+	fake.validateJobMutex.Lock()
+	defer fake.validateJobMutex.Unlock()
+	fake.ValidateJobStub = stub
+}
+
+func (fake *FakeWorker) ValidateJobArgsForCall(i int) (context.Context, *domain.JobSpec) {
+	fake.validateJobMutex.RLock()
+	defer fake.validateJobMutex.RUnlock()
+	argsForCall := fake.validateJobArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWorker) ValidateJobReturns(result1 *domain.JobValidation) {
+	fake.validateJobMutex.Lock()
+	defer fake.validateJobMutex.Unlock()
+	fake.ValidateJobStub = nil
+	fake.validateJobReturns = struct {
+		result1 *domain.JobValidation
+	}{result1}
+}
+
+func (fake *FakeWorker) ValidateJobReturnsOnCall(i int, result1 *domain.JobValidation) {
+	fake.validateJobMutex.Lock()
+	defer fake.validateJobMutex.Unlock()
+	fake.ValidateJobStub = nil
+	if fake.validateJobReturnsOnCall == nil {
+		fake.validateJobReturnsOnCall = make(map[int]struct {
+			result1 *domain.JobValidation
+		})
+	}
+	fake.validateJobReturnsOnCall[i] = struct {
+		result1 *domain.JobValidation
 	}{result1}
 }
 
 func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.capabilitiesMutex.RLock()
+	defer fake.capabilitiesMutex.RUnlock()
+	fake.diagnosticsMutex.RLock()
+	defer fake.diagnosticsMutex.RUnlock()
+	fake.expireJobMutex.RLock()
+	defer fake.expireJobMutex.RUnlock()
+	fake.openStdinMutex.RLock()
+	defer fake.openStdinMutex.RUnlock()
+	fake.resumeJobMutex.RLock()
+	defer fake.resumeJobMutex.RUnlock()
 	fake.startJobMutex.RLock()
 	defer fake.startJobMutex.RUnlock()
 	fake.stopJobMutex.RLock()
 	defer fake.stopJobMutex.RUnlock()
+	fake.validateJobMutex.RLock()
+	defer fake.validateJobMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
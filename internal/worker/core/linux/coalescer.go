@@ -0,0 +1,62 @@
+package linux
+
+import (
+	"sync"
+	"time"
+	"worker/internal/worker/state"
+)
+
+// outputCoalescer gathers a job's output into larger chunks before it is
+// stored, so the followers of a chatty job get fewer, larger messages. A chunk
+// is stored once it holds maxBytes or interval after its first write.
+type outputCoalescer struct {
+	store    state.Store
+	jobId    string
+	interval time.Duration
+	maxBytes int
+
+	mutex sync.Mutex
+	buf   []byte
+	timer *time.Timer // pending flush, nil while buf is empty
+}
+
+func newOutputCoalescer(store state.Store, jobId string, interval time.Duration, maxBytes int) *outputCoalescer {
+	return &outputCoalescer{store: store, jobId: jobId, interval: interval, maxBytes: maxBytes}
+}
+
+// Write adds a chunk the caller no longer modifies
+func (c *outputCoalescer) Write(chunk []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.buf = append(c.buf, chunk...)
+	if len(c.buf) >= c.maxBytes {
+		c.flushLocked()
+		return
+	}
+	if c.timer == nil {
+		c.timer = time.AfterFunc(c.interval, c.Flush)
+	}
+}
+
+// Flush stores what has been gathered; a nil coalescer holds nothing
+func (c *outputCoalescer) Flush() {
+	if c == nil {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.flushLocked()
+}
+
+func (c *outputCoalescer) flushLocked() {
+	if c.timer != nil {
+		c.timer.Stop()
+		c.timer = nil
+	}
+	if len(c.buf) == 0 {
+		return
+	}
+	c.store.WriteToBuffer(c.jobId, c.buf)
+	c.buf = nil
+}
@@ -0,0 +1,182 @@
+//go:build linux
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"syscall"
+	"worker/internal/worker/core/linux/process"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/execarch"
+)
+
+// Use of the filesystem holding job workspaces the disk check warns and fails at
+const (
+	diskWarnPercent = 90
+	diskFailPercent = 98
+)
+
+// Diagnostics checks what the worker needs to run jobs: the kernel features
+// of the preflight checks, the delegated cgroup tree and its controllers, the
+// binary jobs are launched from, the dirs it writes to and the disk space
+// they have left
+func (w *Worker) Diagnostics(ctx context.Context) domain.Diagnostics {
+	d := kernelChecks(w.config)
+	w.checkCgroupBase(&d)
+	w.checkCgroupControllers(&d)
+	w.checkInitBinary(&d)
+	checkWritableDir(&d, "workspace.dir", w.config.Workspace.BaseDir, "workspace.baseDir")
+	if w.config.OutputBuffer.MaxBytes > 0 {
+		checkWritableDir(&d, "output.spill_dir", w.config.OutputBuffer.SpillDir, "outputBuffer.spillDir")
+	}
+	checkDiskSpace(&d, w.config.Workspace.BaseDir)
+	d.Add("store", domain.CheckOK,
+		fmt.Sprintf("%d jobs kept in memory, lost when the worker restarts", len(w.store.ListJobs())), "")
+	return d
+}
+
+// checkCgroupBase checks the worker can create job cgroups
+func (w *Worker) checkCgroupBase(d *domain.Diagnostics) {
+	const name = "cgroup.base"
+	baseDir := w.config.Cgroup.BaseDir
+
+	if _, err := os.Stat(filepath.Join(baseDir, "cgroup.procs")); err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("%s is not a cgroup v2 directory: %v", baseDir, err),
+			"Mount cgroup v2 (the unified hierarchy) and set cgroup.baseDir to the cgroup delegated to the worker")
+		return
+	}
+
+	probe := filepath.Join(baseDir, "diagnostics-probe")
+	if err := os.Mkdir(probe, 0755); err != nil && !os.IsExist(err) {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot create job cgroups in %s: %v", baseDir, err),
+			fmt.Sprintf("Run the worker as root, or delegate %s to its user (Delegate=yes in the systemd unit)", baseDir))
+		return
+	}
+	_ = os.Remove(probe)
+
+	d.Add(name, domain.CheckOK, fmt.Sprintf("job cgroups are created in %s", baseDir), "")
+}
+
+// checkCgroupControllers checks the configured controllers are enabled for
+// job cgroups; jobs run without the limits of those that are not
+func (w *Worker) checkCgroupControllers(d *domain.Diagnostics) {
+	const name = "cgroup.controllers"
+	baseDir := w.config.Cgroup.BaseDir
+
+	data, err := os.ReadFile(filepath.Join(baseDir, "cgroup.subtree_control"))
+	if err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot read the enabled controllers: %v", err),
+			fmt.Sprintf("Check %s is a cgroup v2 directory the worker can read", baseDir))
+		return
+	}
+
+	enabled := strings.Fields(string(data))
+	var missing []string
+	for _, controller := range w.config.Cgroup.EnableControllers {
+		if !slices.Contains(enabled, controller) {
+			missing = append(missing, controller)
+		}
+	}
+	if len(missing) > 0 {
+		d.Add(name, domain.CheckWarn,
+			fmt.Sprintf("%s not enabled, jobs run without these limits", strings.Join(missing, ", ")),
+			fmt.Sprintf("Enable them with: echo '+%s' > %s (a controller missing from the parent's cgroup.controllers must be enabled there first)",
+				strings.Join(missing, " +"), filepath.Join(baseDir, "cgroup.subtree_control")))
+		return
+	}
+
+	d.Add(name, domain.CheckOK, "enabled: "+strings.Join(enabled, " "), "")
+}
+
+// checkInitBinary checks the binary jobs are launched from: the init binary
+// configured for the host's architecture, or the running worker itself
+func (w *Worker) checkInitBinary(d *domain.Diagnostics) {
+	const name = "init.binary"
+
+	if w.initPath != "" {
+		if _, err := os.Stat(w.initPath); err != nil {
+			d.Add(name, domain.CheckFail, fmt.Sprintf("the init binary cannot be executed: %v", err),
+				fmt.Sprintf("Install a worker build for %s at %s", execarch.Host(), w.initPath))
+			return
+		}
+		if err := execarch.Check(w.initPath); err != nil {
+			d.Add(name, domain.CheckFail, err.Error(),
+				fmt.Sprintf("Set worker.initBinaries.%s to a worker build for this host", execarch.Host()))
+			return
+		}
+		d.Add(name, domain.CheckOK, fmt.Sprintf("jobs are launched from %s, the init binary for %s", w.initPath, execarch.Host()), "")
+		return
+	}
+
+	info, err := os.Stat(process.SelfExe)
+	if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("the running binary cannot be executed: %v", err),
+			"Check /proc is mounted and the worker binary is executable")
+		return
+	}
+
+	// a deploy that replaced the binary does not break launches, they use the
+	// running one, but the worker no longer runs what is on disk
+	if target, err := os.Readlink(process.SelfExe); err == nil && strings.HasSuffix(target, " (deleted)") {
+		d.Add(name, domain.CheckWarn,
+			fmt.Sprintf("%s was replaced on disk since the worker started", w.binaryPath),
+			"Restart the worker to run the installed version")
+		return
+	}
+
+	d.Add(name, domain.CheckOK, fmt.Sprintf("jobs are launched from %s", w.binaryPath), "")
+}
+
+// checkWritableDir checks the worker can create files in dir, set by the
+// configKey setting
+func checkWritableDir(d *domain.Diagnostics, name, dir, configKey string) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot create %s: %v", dir, err),
+			fmt.Sprintf("Create %s writable by the worker's user, or point %s elsewhere", dir, configKey))
+		return
+	}
+
+	probe, err := os.CreateTemp(dir, ".diagnostics-*")
+	if err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot write to %s: %v", dir, err),
+			fmt.Sprintf("Make %s writable by the worker's user, or point %s elsewhere", dir, configKey))
+		return
+	}
+	_ = probe.Close()
+	_ = os.Remove(probe.Name())
+
+	d.Add(name, domain.CheckOK, dir+" is writable", "")
+}
+
+// checkDiskSpace checks the space left on the filesystem of dir
+func checkDiskSpace(d *domain.Diagnostics, dir string) {
+	const name = "disk"
+
+	var fs syscall.Statfs_t
+	if err := syscall.Statfs(dir, &fs); err != nil {
+		d.Add(name, domain.CheckWarn, fmt.Sprintf("cannot read the disk use of %s: %v", dir, err), "Check "+dir+" exists")
+		return
+	}
+	if fs.Blocks == 0 {
+		d.Add(name, domain.CheckOK, fmt.Sprintf("%s has no block limit", dir), "")
+		return
+	}
+
+	usedPercent := int(100 - fs.Bavail*100/fs.Blocks)
+	free := int64(fs.Bavail) * fs.Bsize
+	detail := fmt.Sprintf("%d%% of the filesystem of %s used, %d MB free", usedPercent, dir, free/(1024*1024))
+	fix := fmt.Sprintf("Free space on the filesystem of %s, or enable the janitor (janitor.enabled) to remove finished jobs' files", dir)
+	switch {
+	case usedPercent >= diskFailPercent:
+		d.Add(name, domain.CheckFail, detail, fix)
+	case usedPercent >= diskWarnPercent:
+		d.Add(name, domain.CheckWarn, detail, fix)
+	default:
+		d.Add(name, domain.CheckOK, detail, "")
+	}
+}
@@ -0,0 +1,114 @@
+//go:build linux
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/metrics"
+)
+
+// watchIdle applies the job's idle policy to the current process until the
+// returned function is called. The function waits for the watcher to finish.
+func (w *Worker) watchIdle(run *jobRun) func() {
+	if run.job.Spec == nil || run.job.Spec.Idle.MaxIdle <= 0 {
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		w.runIdleWatch(ctx, run, run.job.Spec.Idle)
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// runIdleWatch checks the job's output and CPU use every CheckInterval. A job
+// without a cgroup is only checked for output.
+func (w *Worker) runIdleWatch(ctx context.Context, run *jobRun, policy domain.IdlePolicy) {
+	log := w.logger.WithFields("jobID", run.job.Id, "maxIdle", policy.MaxIdle, "action", policy.Action)
+	interval := policy.CheckInterval()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	activeAt := time.Now()
+	lastOutput := run.lastOutput.Load()
+	cpuTime := time.Duration(-1)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			active := false
+			if output := run.lastOutput.Load(); output != lastOutput {
+				lastOutput = output
+				active = true
+			}
+			if run.job.CgroupPath != "" {
+				if usage, err := metrics.ReadCgroup(run.job.CgroupPath); err == nil {
+					if cpuTime >= 0 && usage.CPUTime-cpuTime > time.Duration(float64(interval)*domain.IdleCPUShare) {
+						active = true
+					}
+					cpuTime = usage.CPUTime
+				}
+			}
+
+			if active {
+				activeAt = now
+				if run.idle {
+					run.idle = false
+					log.Debug("idle job is active again")
+					w.setJobIdle(run.job.Id, false)
+				}
+				continue
+			}
+			if run.idle || now.Sub(activeAt) < policy.MaxIdle {
+				continue
+			}
+
+			run.idle = true
+			w.setJobIdle(run.job.Id, true)
+			if policy.Action == domain.IdleMark {
+				log.Warn("job is idle")
+				w.events.Publish(events.Event{Type: events.JobIdle, JobID: run.job.Id,
+					Err: fmt.Errorf("no output or CPU use for %v, marked idle", policy.MaxIdle)})
+				continue
+			}
+
+			log.Warn("job is idle, stopping it")
+			w.events.Publish(events.Event{Type: events.JobIdle, JobID: run.job.Id,
+				Err: fmt.Errorf("no output or CPU use for %v, stopping it", policy.MaxIdle)})
+			// StopJob waits for the monitor, which waits for this watcher
+			go w.stopIdleJob(run)
+			return
+		}
+	}
+}
+
+// stopIdleJob stops a job the way StopJob does by default
+func (w *Worker) stopIdleJob(run *jobRun) {
+	opts, _ := domain.NewStopOptions("", 0)
+	if _, err := w.StopJob(run.trace, run.job.Id, opts); err != nil {
+		w.logger.Warn("failed to stop idle job", "jobID", run.job.Id, "error", err)
+	}
+}
+
+// setJobIdle records whether a running job is idle
+func (w *Worker) setJobIdle(jobID string, idle bool) {
+	job, exists := w.store.GetJob(jobID)
+	if !exists || !job.IsRunning() || job.Idle == idle {
+		return
+	}
+	job.Idle = idle
+	w.publishJob(job)
+}
@@ -0,0 +1,135 @@
+//go:build linux
+
+package isolation
+
+import (
+	"fmt"
+	"strconv"
+	"syscall"
+	"worker/internal/worker/core/linux/unprivileged"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Backend sets up how a job's processes are separated from the host
+//
+//counterfeiter:generate . Backend
+type Backend interface {
+	Mode() domain.IsolationMode
+	// SysProcAttr returns the attributes the job's init process is started with
+	SysProcAttr() *syscall.SysProcAttr
+	// UsesCgroup reports whether the job runs in its own cgroup with its limits
+	UsesCgroup() bool
+	// InitEnv returns the variables telling the init process what to set up
+	InitEnv(job *domain.Job) []string
+}
+
+// NewBackend creates the backend for mode
+func NewBackend(mode domain.IsolationMode) (Backend, error) {
+	switch mode {
+	case domain.IsolationFull:
+		return &namespaceBackend{isolation: unprivileged.NewJobIsolation()}, nil
+	case domain.IsolationCgroups:
+		return &hostBackend{mode: mode, cgroup: true}, nil
+	case domain.IsolationProcess, domain.IsolationPrivileged:
+		return &hostBackend{mode: mode}, nil
+	default:
+		return nil, fmt.Errorf("unsupported isolation mode: %q", mode)
+	}
+}
+
+// namespaceBackend runs jobs in new PID, mount, IPC and UTS namespaces and
+// in their own cgroup
+type namespaceBackend struct {
+	isolation *unprivileged.JobIsolation
+}
+
+func (b *namespaceBackend) Mode() domain.IsolationMode {
+	return domain.IsolationFull
+}
+
+func (b *namespaceBackend) SysProcAttr() *syscall.SysProcAttr {
+	return b.isolation.CreateIsolatedSysProcAttr()
+}
+
+func (b *namespaceBackend) UsesCgroup() bool {
+	return true
+}
+
+func (b *namespaceBackend) InitEnv(job *domain.Job) []string {
+	return []string{
+		"JOB_ISOLATION=" + string(domain.IsolationFull),
+		"JOB_CGROUP_PATH=/sys/fs/cgroup", // Namespace path
+		"CGROUP_NAMESPACE=true",
+		"USER_NAMESPACE=true",
+	}
+}
+
+// hostBackend runs jobs in the worker's namespaces, skipping the namespace and
+// mount setup for trusted jobs. The job still gets its own process group, and
+// its own cgroup when cgroup is set.
+type hostBackend struct {
+	mode   domain.IsolationMode
+	cgroup bool
+}
+
+func (b *hostBackend) Mode() domain.IsolationMode {
+	return b.mode
+}
+
+func (b *hostBackend) SysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+func (b *hostBackend) UsesCgroup() bool {
+	return b.cgroup
+}
+
+func (b *hostBackend) InitEnv(job *domain.Job) []string {
+	env := []string{"JOB_ISOLATION=" + string(b.mode)}
+	if b.cgroup {
+		// without a cgroup namespace the init process sees the host hierarchy
+		env = append(env, "JOB_CGROUP_PATH="+job.CgroupPath)
+	}
+	return env
+}
+
+// NewRuntimeBackend creates the backend of jobs run through a sandbox runtime
+func NewRuntimeBackend(runtime config.RuntimeConfig) Backend {
+	return &runtimeBackend{runtime: runtime}
+}
+
+// runtimeBackend runs jobs through an external sandbox runtime, which sets up
+// the job's isolation in place of the worker's namespaces. Init joins the
+// job's cgroup before it execs the runtime, so the limits cover the whole
+// sandbox.
+type runtimeBackend struct {
+	runtime config.RuntimeConfig
+}
+
+func (b *runtimeBackend) Mode() domain.IsolationMode {
+	return domain.IsolationSandbox
+}
+
+func (b *runtimeBackend) SysProcAttr() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+func (b *runtimeBackend) UsesCgroup() bool {
+	return true
+}
+
+func (b *runtimeBackend) InitEnv(job *domain.Job) []string {
+	env := []string{
+		"JOB_ISOLATION=" + string(domain.IsolationSandbox),
+		"JOB_CGROUP_PATH=" + job.CgroupPath,
+		"JOB_RUNTIME_PATH=" + b.runtime.Path,
+		"JOB_RUNTIME_ARGS_COUNT=" + strconv.Itoa(len(b.runtime.Args)),
+	}
+	for i, arg := range b.runtime.Args {
+		env = append(env, fmt.Sprintf("JOB_RUNTIME_ARG_%d=%s", i, arg))
+	}
+	return env
+}
@@ -0,0 +1,310 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+
+//go:build linux
+
+package isolationfakes
+
+import (
+	"sync"
+	"syscall"
+	"worker/internal/worker/core/linux/isolation"
+	"worker/internal/worker/domain"
+)
+
+type FakeBackend struct {
+	InitEnvStub        func(*domain.Job) []string
+	initEnvMutex       sync.RWMutex
+	initEnvArgsForCall []struct {
+		arg1 *domain.Job
+	}
+	initEnvReturns struct {
+		result1 []string
+	}
+	initEnvReturnsOnCall map[int]struct {
+		result1 []string
+	}
+	ModeStub        func() domain.IsolationMode
+	modeMutex       sync.RWMutex
+	modeArgsForCall []struct {
+	}
+	modeReturns struct {
+		result1 domain.IsolationMode
+	}
+	modeReturnsOnCall map[int]struct {
+		result1 domain.IsolationMode
+	}
+	SysProcAttrStub        func() *syscall.SysProcAttr
+	sysProcAttrMutex       sync.RWMutex
+	sysProcAttrArgsForCall []struct {
+	}
+	sysProcAttrReturns struct {
+		result1 *syscall.SysProcAttr
+	}
+	sysProcAttrReturnsOnCall map[int]struct {
+		result1 *syscall.SysProcAttr
+	}
+	UsesCgroupStub        func() bool
+	usesCgroupMutex       sync.RWMutex
+	usesCgroupArgsForCall []struct {
+	}
+	usesCgroupReturns struct {
+		result1 bool
+	}
+	usesCgroupReturnsOnCall map[int]struct {
+		result1 bool
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBackend) InitEnv(arg1 *domain.Job) []string {
+	fake.initEnvMutex.Lock()
+	ret, specificReturn := fake.initEnvReturnsOnCall[len(fake.initEnvArgsForCall)]
+	fake.initEnvArgsForCall = append(fake.initEnvArgsForCall, struct {
+		arg1 *domain.Job
+	}{arg1})
+	stub := fake.InitEnvStub
+	fakeReturns := fake.initEnvReturns
+	fake.recordInvocation("InitEnv", []interface{}{arg1})
+	fake.initEnvMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) InitEnvCallCount() int {
+	fake.initEnvMutex.RLock()
+	defer fake.initEnvMutex.RUnlock()
+	return len(fake.initEnvArgsForCall)
+}
+
+func (fake *FakeBackend) InitEnvCalls(stub func(*domain.Job) []string) {
+	fake.initEnvMutex.Lock()
+	defer fake.initEnvMutex.Unlock()
+	fake.InitEnvStub = stub
+}
+
+func (fake *FakeBackend) InitEnvArgsForCall(i int) *domain.Job {
+	fake.initEnvMutex.RLock()
+	defer fake.initEnvMutex.RUnlock()
+	argsForCall := fake.initEnvArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackend) InitEnvReturns(result1 []string) {
+	fake.initEnvMutex.Lock()
+	defer fake.initEnvMutex.Unlock()
+	fake.InitEnvStub = nil
+	fake.initEnvReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeBackend) InitEnvReturnsOnCall(i int, result1 []string) {
+	fake.initEnvMutex.Lock()
+	defer fake.initEnvMutex.Unlock()
+	fake.InitEnvStub = nil
+	if fake.initEnvReturnsOnCall == nil {
+		fake.initEnvReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.initEnvReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeBackend) Mode() domain.IsolationMode {
+	fake.modeMutex.Lock()
+	ret, specificReturn := fake.modeReturnsOnCall[len(fake.modeArgsForCall)]
+	fake.modeArgsForCall = append(fake.modeArgsForCall, struct {
+	}{})
+	stub := fake.ModeStub
+	fakeReturns := fake.modeReturns
+	fake.recordInvocation("Mode", []interface{}{})
+	fake.modeMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) ModeCallCount() int {
+	fake.modeMutex.RLock()
+	defer fake.modeMutex.RUnlock()
+	return len(fake.modeArgsForCall)
+}
+
+func (fake *FakeBackend) ModeCalls(stub func() domain.IsolationMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = stub
+}
+
+func (fake *FakeBackend) ModeReturns(result1 domain.IsolationMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = nil
+	fake.modeReturns = struct {
+		result1 domain.IsolationMode
+	}{result1}
+}
+
+func (fake *FakeBackend) ModeReturnsOnCall(i int, result1 domain.IsolationMode) {
+	fake.modeMutex.Lock()
+	defer fake.modeMutex.Unlock()
+	fake.ModeStub = nil
+	if fake.modeReturnsOnCall == nil {
+		fake.modeReturnsOnCall = make(map[int]struct {
+			result1 domain.IsolationMode
+		})
+	}
+	fake.modeReturnsOnCall[i] = struct {
+		result1 domain.IsolationMode
+	}{result1}
+}
+
+func (fake *FakeBackend) SysProcAttr() *syscall.SysProcAttr {
+	fake.sysProcAttrMutex.Lock()
+	ret, specificReturn := fake.sysProcAttrReturnsOnCall[len(fake.sysProcAttrArgsForCall)]
+	fake.sysProcAttrArgsForCall = append(fake.sysProcAttrArgsForCall, struct {
+	}{})
+	stub := fake.SysProcAttrStub
+	fakeReturns := fake.sysProcAttrReturns
+	fake.recordInvocation("SysProcAttr", []interface{}{})
+	fake.sysProcAttrMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) SysProcAttrCallCount() int {
+	fake.sysProcAttrMutex.RLock()
+	defer fake.sysProcAttrMutex.RUnlock()
+	return len(fake.sysProcAttrArgsForCall)
+}
+
+func (fake *FakeBackend) SysProcAttrCalls(stub func() *syscall.SysProcAttr) {
+	fake.sysProcAttrMutex.Lock()
+	defer fake.sysProcAttrMutex.Unlock()
+	fake.SysProcAttrStub = stub
+}
+
+func (fake *FakeBackend) SysProcAttrReturns(result1 *syscall.SysProcAttr) {
+	fake.sysProcAttrMutex.Lock()
+	defer fake.sysProcAttrMutex.Unlock()
+	fake.SysProcAttrStub = nil
+	fake.sysProcAttrReturns = struct {
+		result1 *syscall.SysProcAttr
+	}{result1}
+}
+
+func (fake *FakeBackend) SysProcAttrReturnsOnCall(i int, result1 *syscall.SysProcAttr) {
+	fake.sysProcAttrMutex.Lock()
+	defer fake.sysProcAttrMutex.Unlock()
+	fake.SysProcAttrStub = nil
+	if fake.sysProcAttrReturnsOnCall == nil {
+		fake.sysProcAttrReturnsOnCall = make(map[int]struct {
+			result1 *syscall.SysProcAttr
+		})
+	}
+	fake.sysProcAttrReturnsOnCall[i] = struct {
+		result1 *syscall.SysProcAttr
+	}{result1}
+}
+
+func (fake *FakeBackend) UsesCgroup() bool {
+	fake.usesCgroupMutex.Lock()
+	ret, specificReturn := fake.usesCgroupReturnsOnCall[len(fake.usesCgroupArgsForCall)]
+	fake.usesCgroupArgsForCall = append(fake.usesCgroupArgsForCall, struct {
+	}{})
+	stub := fake.UsesCgroupStub
+	fakeReturns := fake.usesCgroupReturns
+	fake.recordInvocation("UsesCgroup", []interface{}{})
+	fake.usesCgroupMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) UsesCgroupCallCount() int {
+	fake.usesCgroupMutex.RLock()
+	defer fake.usesCgroupMutex.RUnlock()
+	return len(fake.usesCgroupArgsForCall)
+}
+
+func (fake *FakeBackend) UsesCgroupCalls(stub func() bool) {
+	fake.usesCgroupMutex.Lock()
+	defer fake.usesCgroupMutex.Unlock()
+	fake.UsesCgroupStub = stub
+}
+
+func (fake *FakeBackend) UsesCgroupReturns(result1 bool) {
+	fake.usesCgroupMutex.Lock()
+	defer fake.usesCgroupMutex.Unlock()
+	fake.UsesCgroupStub = nil
+	fake.usesCgroupReturns = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBackend) UsesCgroupReturnsOnCall(i int, result1 bool) {
+	fake.usesCgroupMutex.Lock()
+	defer fake.usesCgroupMutex.Unlock()
+	fake.UsesCgroupStub = nil
+	if fake.usesCgroupReturnsOnCall == nil {
+		fake.usesCgroupReturnsOnCall = make(map[int]struct {
+			result1 bool
+		})
+	}
+	fake.usesCgroupReturnsOnCall[i] = struct {
+		result1 bool
+	}{result1}
+}
+
+func (fake *FakeBackend) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.initEnvMutex.RLock()
+	defer fake.initEnvMutex.RUnlock()
+	fake.modeMutex.RLock()
+	defer fake.modeMutex.RUnlock()
+	fake.sysProcAttrMutex.RLock()
+	defer fake.sysProcAttrMutex.RUnlock()
+	fake.usesCgroupMutex.RLock()
+	defer fake.usesCgroupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBackend) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ isolation.Backend = new(FakeBackend)
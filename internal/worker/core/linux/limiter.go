@@ -0,0 +1,88 @@
+package linux
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+// outputLimiter holds a job's stdout and stderr together to its output rate
+// limit, allowing bursts of one second's worth
+type outputLimiter struct {
+	rate    float64 // bytes per second
+	action  domain.OutputLimitAction
+	stopped <-chan struct{} // closed once the job is being stopped, its remaining output is let through
+	onDrop  func()          // called on the first output dropped
+
+	mutex  sync.Mutex
+	tokens float64 // bytes that may be written now, negative while throttled writers wait
+	last   time.Time
+
+	dropped atomic.Int64
+}
+
+func newOutputLimiter(limit domain.OutputLimit, stopped <-chan struct{}, onDrop func()) *outputLimiter {
+	return &outputLimiter{
+		rate:    float64(limit.BytesPerSecond),
+		action:  limit.Action,
+		stopped: stopped,
+		onDrop:  onDrop,
+		tokens:  float64(limit.BytesPerSecond),
+		last:    time.Now(),
+	}
+}
+
+// Limit returns the part of p to store. Throttling blocks until the rate
+// allows all of p, truncation drops what the rate does not allow.
+func (l *outputLimiter) Limit(p []byte) []byte {
+	select {
+	case <-l.stopped:
+		return p
+	default:
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.rate, l.rate)
+	l.last = now
+
+	if l.action == domain.OutputTruncate {
+		allowed := len(p)
+		if float64(allowed) > l.tokens {
+			allowed = max(int(l.tokens), 0)
+		}
+		l.tokens -= float64(allowed)
+		l.mutex.Unlock()
+
+		if dropped := len(p) - allowed; dropped > 0 {
+			if l.dropped.Add(int64(dropped)) == int64(dropped) && l.onDrop != nil {
+				l.onDrop()
+			}
+		}
+		return p[:allowed]
+	}
+
+	// writers take the bytes on credit and wait until the rate has paid them
+	l.tokens -= float64(len(p))
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mutex.Unlock()
+
+	if wait > 0 {
+		timer := time.NewTimer(wait)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+		case <-l.stopped:
+		}
+	}
+	return p
+}
+
+// Dropped is how many bytes of output were dropped; a nil limiter drops nothing
+func (l *outputLimiter) Dropped() int64 {
+	if l == nil {
+		return 0
+	}
+	return l.dropped.Load()
+}
@@ -0,0 +1,168 @@
+//go:build linux
+
+package linux
+
+import (
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// outputPipe copies what a process writes to its end of a pipe into the job
+// output. The worker holds the read end itself, rather than leaving it to
+// exec, so copying can be paused and the read end handed to a new binary.
+type outputPipe struct {
+	file *os.File // read end, closed once the process closed its end
+	w    io.Writer
+	eof  chan struct{} // closed with file
+
+	mutex  sync.Mutex
+	done   chan struct{} // closed when the current copy stops
+	closed bool
+}
+
+// openOutputPipe returns a pipe copying into w and the write end to give the
+// process, which the caller closes once the process started
+func openOutputPipe(w io.Writer) (*outputPipe, *os.File, error) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return nil, nil, err
+	}
+	p := &outputPipe{file: reader, w: w, eof: make(chan struct{})}
+	p.copy()
+	return p, writer, nil
+}
+
+// adoptOutputPipe copies into w from the read end of a pipe handed over by
+// the worker binary this one replaced
+func adoptOutputPipe(file *os.File, w io.Writer) *outputPipe {
+	p := &outputPipe{file: file, w: w, eof: make(chan struct{})}
+	p.copy()
+	return p
+}
+
+// flusher is a pipe destination holding back output until it is flushed
+type flusher interface {
+	Flush()
+}
+
+func (p *outputPipe) copy() {
+	done := make(chan struct{})
+	p.done = done
+
+	go func() {
+		defer close(done)
+
+		// like exec, close the read end once the writer fails, so the process
+		// gets EPIPE rather than blocking on a full pipe
+		_, err := io.Copy(p.w, p.file)
+		// the output redaction held back is released once copying stops,
+		// the pipe may be handed to a new binary
+		if f, ok := p.w.(flusher); ok {
+			f.Flush()
+		}
+		if errors.Is(err, os.ErrDeadlineExceeded) {
+			return
+		}
+		p.mutex.Lock()
+		p.closed = true
+		_ = p.file.Close()
+		close(p.eof)
+		p.mutex.Unlock()
+	}()
+}
+
+// wait returns once the process and every process it left behind closed
+// their end of the pipe, and all they wrote was copied
+func (p *outputPipe) wait() {
+	if p != nil {
+		<-p.eof
+	}
+}
+
+// pause stops copying, leaving what is not yet copied in the pipe. It
+// returns the read end, nil if the pipe is closed.
+func (p *outputPipe) pause() *os.File {
+	if p == nil {
+		return nil
+	}
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	_ = p.file.SetReadDeadline(time.Now())
+	done := p.done
+	p.mutex.Unlock()
+
+	<-done
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.closed {
+		return nil
+	}
+	return p.file
+}
+
+// resume continues copying after pause
+func (p *outputPipe) resume() {
+	if p == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		return
+	}
+	_ = p.file.SetReadDeadline(time.Time{})
+	p.copy()
+}
+
+// processOutput is the stdout and stderr of a process
+type processOutput struct {
+	stdout *outputPipe
+	stderr *outputPipe
+}
+
+// openProcessOutput returns the output of a process copying into stdout and
+// stderr, and the write ends to start it with
+func openProcessOutput(stdout, stderr io.Writer) (*processOutput, *os.File, *os.File, error) {
+	out, childStdout, err := openOutputPipe(stdout)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	errPipe, childStderr, err := openOutputPipe(stderr)
+	if err != nil {
+		_ = childStdout.Close()
+		return nil, nil, nil, err
+	}
+	return &processOutput{stdout: out, stderr: errPipe}, childStdout, childStderr, nil
+}
+
+// wait returns once all output of the process was copied
+func (o *processOutput) wait() {
+	if o == nil {
+		return
+	}
+	o.stdout.wait()
+	o.stderr.wait()
+}
+
+// pause stops copying and returns the read ends still open
+func (o *processOutput) pause() (stdout, stderr *os.File) {
+	if o == nil {
+		return nil, nil
+	}
+	return o.stdout.pause(), o.stderr.pause()
+}
+
+func (o *processOutput) resume() {
+	if o == nil {
+		return
+	}
+	o.stdout.resume()
+	o.stderr.resume()
+}
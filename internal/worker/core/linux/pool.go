@@ -0,0 +1,200 @@
+//go:build linux
+
+package linux
+
+import (
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"worker/pkg/logger"
+	"worker/pkg/platform"
+)
+
+// warmInit is an init process started ahead of time in fresh namespaces. It
+// waits on its stdin for the environment of the job it is handed.
+type warmInit struct {
+	cmd     platform.Command
+	control *os.File      // write end of the process's stdin
+	stdout  *switchWriter // job stdout once claimed
+	stderr  *switchWriter // job stderr once claimed
+	pipes   *processOutput
+	created time.Time
+}
+
+func (wi *warmInit) pid() int32 {
+	if proc := wi.cmd.Process(); proc != nil {
+		return int32(proc.Pid())
+	}
+	return 0
+}
+
+// handOff connects the job output and sends the job environment, which starts
+// the job. The environment must not contain NUL bytes.
+func (wi *warmInit) handOff(env []string, stdout, stderr io.Writer) error {
+	wi.stdout.set(stdout)
+	wi.stderr.set(stderr)
+
+	_, err := io.WriteString(wi.control, strings.Join(env, "\x00"))
+	if closeErr := wi.control.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// release stops an unclaimed process: closing its stdin without a job makes it
+// exit, and the kill covers a process that is stuck
+func (wi *warmInit) release() {
+	_ = wi.control.Close()
+	if proc := wi.cmd.Process(); proc != nil {
+		_ = proc.Kill()
+	}
+	_ = wi.cmd.Wait()
+}
+
+// switchWriter forwards to a writer set after the process started, dropping
+// what is written before
+type switchWriter struct {
+	mutex sync.Mutex
+	w     io.Writer
+}
+
+func (s *switchWriter) set(w io.Writer) {
+	s.mutex.Lock()
+	s.w = w
+	s.mutex.Unlock()
+}
+
+func (s *switchWriter) Write(p []byte) (int, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.w == nil {
+		return len(p), nil
+	}
+	return s.w.Write(p)
+}
+
+// initPool keeps up to size warm init processes, replacing those idle for
+// longer than ttl
+type initPool struct {
+	size   int
+	ttl    time.Duration
+	launch func() (*warmInit, error)
+	logger *logger.Logger
+
+	mutex  sync.Mutex
+	idle   []*warmInit
+	refill chan struct{}
+}
+
+func newInitPool(size int, ttl time.Duration, launch func() (*warmInit, error)) *initPool {
+	return &initPool{
+		size:   size,
+		ttl:    ttl,
+		launch: launch,
+		logger: logger.New().WithField("component", "init-pool"),
+		refill: make(chan struct{}, 1),
+	}
+}
+
+// run keeps the pool filled until the process exits
+func (p *initPool) run() {
+	ticker := time.NewTicker(p.ttl / 2)
+	defer ticker.Stop()
+
+	for {
+		p.expire(time.Now())
+		p.fill()
+
+		select {
+		case <-p.refill:
+		case <-ticker.C:
+		}
+	}
+}
+
+// claim takes an idle process, nil if there is none
+func (p *initPool) claim() *warmInit {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for len(p.idle) > 0 {
+		wi := p.idle[len(p.idle)-1]
+		p.idle = p.idle[:len(p.idle)-1]
+
+		if time.Since(wi.created) < p.ttl {
+			p.requestRefill()
+			return wi
+		}
+		go wi.release()
+	}
+
+	p.requestRefill()
+	return nil
+}
+
+func (p *initPool) requestRefill() {
+	select {
+	case p.refill <- struct{}{}:
+	default:
+	}
+}
+
+// fill launches processes until the pool is full, giving up until the next
+// round on the first failure
+func (p *initPool) fill() {
+	for p.idleCount() < p.size {
+		wi, err := p.launch()
+		if err != nil {
+			p.logger.Warn("failed to start warm init process", "error", err)
+			return
+		}
+
+		p.mutex.Lock()
+		p.idle = append(p.idle, wi)
+		p.mutex.Unlock()
+	}
+}
+
+func (p *initPool) idleCount() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return len(p.idle)
+}
+
+// expire releases the processes idle for longer than the TTL
+func (p *initPool) expire(now time.Time) {
+	p.mutex.Lock()
+	var expired []*warmInit
+	fresh := p.idle[:0]
+	for _, wi := range p.idle {
+		if now.Sub(wi.created) >= p.ttl {
+			expired = append(expired, wi)
+		} else {
+			fresh = append(fresh, wi)
+		}
+	}
+	p.idle = fresh
+	p.mutex.Unlock()
+
+	for _, wi := range expired {
+		wi.release()
+	}
+	if len(expired) > 0 {
+		p.logger.Debug("released expired warm init processes", "count", len(expired))
+	}
+}
+
+// drain releases every idle process, the pool starts new ones on its next round
+func (p *initPool) drain() {
+	p.mutex.Lock()
+	idle := p.idle
+	p.idle = nil
+	p.mutex.Unlock()
+
+	for _, wi := range idle {
+		wi.release()
+	}
+}
@@ -0,0 +1,223 @@
+//go:build linux
+
+package linux
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"golang.org/x/sys/unix"
+)
+
+// jobNamespaces are the namespaces full isolation creates for each job, with
+// the kernel option they need
+var jobNamespaces = []struct{ name, option string }{
+	{"pid", "CONFIG_PID_NS"},
+	{"mnt", ""},
+	{"ipc", "CONFIG_IPC_NS"},
+	{"uts", "CONFIG_UTS_NS"},
+	{"cgroup", ""},
+}
+
+// kernelCheckModes are the isolation modes a failed kernel check rules out
+var kernelCheckModes = map[string][]domain.IsolationMode{
+	"kernel.cgroup2":     {domain.IsolationFull, domain.IsolationCgroups},
+	"kernel.controllers": {domain.IsolationFull, domain.IsolationCgroups},
+	"kernel.namespaces":  {domain.IsolationFull},
+	"kernel.setns":       {domain.IsolationFull},
+}
+
+// preflight checks the kernel has the features jobs need before the worker
+// sets anything up. In strict mode a failed check fails it; in degraded mode
+// it returns the isolation modes the failed checks rule out, with why.
+func preflight(cfg *config.Config, log *logger.Logger) (map[domain.IsolationMode]string, error) {
+	if cfg.Worker.Preflight == config.PreflightOff {
+		return nil, nil
+	}
+
+	unavailable := make(map[domain.IsolationMode]string)
+	var failed []string
+	for _, check := range kernelChecks(cfg) {
+		switch check.Status {
+		case domain.CheckOK:
+			log.Debug("preflight check passed", "check", check.Name, "detail", check.Detail)
+			continue
+		case domain.CheckWarn:
+			log.Warn("preflight check found a problem", "check", check.Name, "detail", check.Detail, "fix", check.Fix)
+			continue
+		}
+
+		log.Error("preflight check failed", "check", check.Name, "detail", check.Detail, "fix", check.Fix)
+		failed = append(failed, fmt.Sprintf("%s: %s (fix: %s)", check.Name, check.Detail, check.Fix))
+		for _, mode := range kernelCheckModes[check.Name] {
+			if _, ok := unavailable[mode]; !ok {
+				unavailable[mode] = check.Detail
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return nil, nil
+	}
+
+	if cfg.Worker.Preflight == config.PreflightStrict {
+		return nil, fmt.Errorf("preflight checks failed, fix them or set worker.preflight to %q to start without the isolation modes they rule out:\n  %s",
+			config.PreflightDegraded, strings.Join(failed, "\n  "))
+	}
+
+	for mode, reason := range unavailable {
+		log.Warn("starting degraded, jobs with this isolation mode are refused", "isolation", mode, "reason", reason)
+	}
+	if _, ok := unavailable[domain.IsolationMode(cfg.Worker.Isolation)]; ok {
+		log.Warn("the default isolation mode is unavailable, jobs must ask for one that works", "isolation", cfg.Worker.Isolation)
+	}
+	return unavailable, nil
+}
+
+// kernelChecks checks the kernel features the isolation modes need
+func kernelChecks(cfg *config.Config) domain.Diagnostics {
+	var d domain.Diagnostics
+	checkCgroup2(&d, cfg.Cgroup.BaseDir)
+	checkControllersAvailable(&d, cfg.Cgroup)
+	checkNamespaces(&d)
+	checkSetns(&d)
+	return d
+}
+
+// existingDir is dir, or its closest ancestor that exists when the worker
+// has yet to create it
+func existingDir(dir string) string {
+	for dir != "/" {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		dir = filepath.Dir(dir)
+	}
+	return dir
+}
+
+// checkCgroup2 checks the cgroup tree is cgroup v2, the unified hierarchy
+func checkCgroup2(d *domain.Diagnostics, baseDir string) {
+	const name = "kernel.cgroup2"
+	const fix = "Boot with systemd.unified_cgroup_hierarchy=1 (or cgroup_no_v1=all) so /sys/fs/cgroup is cgroup v2"
+
+	dir := existingDir(baseDir)
+	var fs unix.Statfs_t
+	if err := unix.Statfs(dir, &fs); err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot read the filesystem of %s: %v", dir, err), fix)
+		return
+	}
+
+	switch fs.Type {
+	case unix.CGROUP2_SUPER_MAGIC:
+		d.Add(name, domain.CheckOK, dir+" is cgroup v2", "")
+	case unix.CGROUP_SUPER_MAGIC, unix.TMPFS_MAGIC:
+		d.Add(name, domain.CheckFail, dir+" is a cgroup v1 (legacy or hybrid) hierarchy", fix)
+	default:
+		d.Add(name, domain.CheckFail, fmt.Sprintf("%s is not on a cgroup filesystem (type 0x%x)", dir, fs.Type),
+			"Mount cgroup v2 at /sys/fs/cgroup and point cgroup.baseDir into it")
+	}
+}
+
+// checkControllersAvailable checks the configured controllers can be enabled
+// for job cgroups, which they can only when the parent cgroup delegates them
+func checkControllersAvailable(d *domain.Diagnostics, cfg config.CgroupConfig) {
+	const name = "kernel.controllers"
+
+	dir := existingDir(cfg.BaseDir)
+	data, err := os.ReadFile(filepath.Join(dir, "cgroup.controllers"))
+	if err != nil {
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot read the available controllers of %s: %v", dir, err),
+			"Point cgroup.baseDir into the cgroup v2 tree")
+		return
+	}
+
+	available := strings.Fields(string(data))
+	var missing []string
+	for _, controller := range cfg.EnableControllers {
+		if !slices.Contains(available, controller) {
+			missing = append(missing, controller)
+		}
+	}
+	if len(missing) > 0 {
+		d.Add(name, domain.CheckFail,
+			fmt.Sprintf("%s not available in %s", strings.Join(missing, ", "), dir),
+			fmt.Sprintf("Delegate them to the worker's cgroup (Delegate=%s in the systemd unit), or remove them from cgroup.enableControllers",
+				strings.Join(missing, " ")))
+		return
+	}
+
+	d.Add(name, domain.CheckOK, "available: "+strings.Join(available, " "), "")
+}
+
+// checkNamespaces checks the kernel supports the namespaces of full
+// isolation, and the time namespace of jobs with a clock offset
+func checkNamespaces(d *domain.Diagnostics) {
+	var missing, options []string
+	for _, ns := range jobNamespaces {
+		if _, err := os.Stat("/proc/self/ns/" + ns.name); err != nil {
+			missing = append(missing, ns.name)
+			if ns.option != "" {
+				options = append(options, ns.option)
+			}
+		}
+	}
+	if len(missing) > 0 {
+		fix := "Run Linux 4.6 or later"
+		if len(options) > 0 {
+			fix += " built with " + strings.Join(options, ", ")
+		}
+		d.Add("kernel.namespaces", domain.CheckFail,
+			fmt.Sprintf("the kernel lacks the %s namespaces", strings.Join(missing, ", ")),
+			fix+", or run jobs with cgroups isolation")
+	} else {
+		d.Add("kernel.namespaces", domain.CheckOK, "pid, mnt, ipc, uts and cgroup namespaces are supported", "")
+	}
+
+	if _, err := os.Stat("/proc/self/ns/time"); err != nil {
+		d.Add("kernel.timens", domain.CheckWarn, "the kernel lacks time namespaces, jobs with a time offset fail",
+			"Run Linux 5.6 or later built with CONFIG_TIME_NS")
+	}
+}
+
+// checkSetns checks namespaces can be joined on this architecture by having
+// the calling thread join its own UTS namespace, which changes nothing but
+// needs the same privileges as creating one
+func checkSetns(d *domain.Diagnostics) {
+	const name = "kernel.setns"
+
+	err := joinOwnNamespace("uts", unix.CLONE_NEWUTS)
+	switch {
+	case err == nil:
+		d.Add(name, domain.CheckOK, "namespaces can be joined on "+runtime.GOARCH, "")
+	case errors.Is(err, unix.ENOSYS):
+		d.Add(name, domain.CheckFail, fmt.Sprintf("the kernel does not implement setns on %s", runtime.GOARCH),
+			"Run a kernel with namespace support for this architecture, or run jobs with cgroups isolation")
+	case errors.Is(err, unix.EPERM):
+		d.Add(name, domain.CheckFail, fmt.Sprintf("the worker is not permitted to enter namespaces: %v", err),
+			"Run the worker as root with CAP_SYS_ADMIN, and without a seccomp profile blocking setns (in a container, run it privileged)")
+	default:
+		d.Add(name, domain.CheckFail, fmt.Sprintf("cannot enter namespaces: %v", err),
+			"Check /proc is mounted and the worker runs as root")
+	}
+}
+
+// joinOwnNamespace joins the namespace of the given type the thread is already in
+func joinOwnNamespace(ns string, nstype int) error {
+	fd, err := unix.Open("/proc/self/ns/"+ns, unix.O_RDONLY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return err
+	}
+	defer unix.Close(fd)
+
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	return unix.Setns(fd, nstype)
+}
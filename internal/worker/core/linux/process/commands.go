@@ -0,0 +1,106 @@
+//go:build linux
+
+package process
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// maxCachedCommands bounds the command cache, which is cleared of expired
+// entries, then emptied, when it fills up
+const maxCachedCommands = 1024
+
+// commandCache remembers the paths command names resolved to, so launches of
+// hot commands stat the file they resolved to instead of looking through PATH
+// and the common locations again. An entry holds for the TTL while that file
+// is unchanged; a command installed earlier in PATH is found once it expires.
+// A nil cache caches nothing.
+type commandCache struct {
+	ttl     time.Duration
+	mu      sync.Mutex
+	entries map[commandKey]cachedCommand
+}
+
+// commandKey is a command name and the job PATH it is looked up in, "" for
+// the worker's
+type commandKey struct {
+	command    string
+	searchPath string
+}
+
+type cachedCommand struct {
+	path    string
+	modTime time.Time
+	size    int64
+	mode    os.FileMode
+	expires time.Time
+}
+
+func newCommandCache(ttl time.Duration) *commandCache {
+	if ttl <= 0 {
+		return nil
+	}
+	return &commandCache{ttl: ttl, entries: make(map[commandKey]cachedCommand)}
+}
+
+// get returns the path key resolved to, unless it expired or the file there
+// changed since
+func (c *commandCache) get(key commandKey, stat func(string) (os.FileInfo, error)) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if !ok {
+		return "", false
+	}
+
+	info, err := stat(entry.path)
+	if err != nil || time.Now().After(entry.expires) || !entry.matches(info) {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return "", false
+	}
+	return entry.path, true
+}
+
+// put remembers the path key resolved to, with the state of the file there
+func (c *commandCache) put(key commandKey, path string, stat func(string) (os.FileInfo, error)) {
+	if c == nil {
+		return
+	}
+	info, err := stat(path)
+	if err != nil {
+		return
+	}
+
+	now := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= maxCachedCommands {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= maxCachedCommands {
+			clear(c.entries)
+		}
+	}
+	c.entries[key] = cachedCommand{
+		path:    path,
+		modTime: info.ModTime(),
+		size:    info.Size(),
+		mode:    info.Mode(),
+		expires: now.Add(c.ttl),
+	}
+}
+
+func (e cachedCommand) matches(info os.FileInfo) bool {
+	return info.ModTime().Equal(e.modTime) && info.Size() == e.size && info.Mode() == e.mode
+}
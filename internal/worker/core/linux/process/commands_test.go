@@ -0,0 +1,148 @@
+//go:build linux
+
+package process
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+)
+
+// fakeInfo is the state of a file as the command cache sees it
+type fakeInfo struct {
+	size    int64
+	mode    os.FileMode
+	modTime time.Time
+}
+
+func (f fakeInfo) Name() string       { return "cmd" }
+func (f fakeInfo) Size() int64        { return f.size }
+func (f fakeInfo) Mode() os.FileMode  { return f.mode }
+func (f fakeInfo) ModTime() time.Time { return f.modTime }
+func (f fakeInfo) IsDir() bool        { return false }
+func (f fakeInfo) Sys() any           { return nil }
+
+// fakeStat stats the files of a map, missing ones do not exist
+func fakeStat(files map[string]fakeInfo) func(string) (os.FileInfo, error) {
+	return func(path string) (os.FileInfo, error) {
+		info, ok := files[path]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return info, nil
+	}
+}
+
+func TestCommandCacheInvalidation(t *testing.T) {
+	built := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	original := fakeInfo{size: 1024, mode: 0755, modTime: built}
+
+	tests := []struct {
+		name    string
+		changed *fakeInfo // state of the file at lookup, nil once removed
+		expire  bool
+		hit     bool
+	}{
+		{name: "unchanged", changed: &original, hit: true},
+		{name: "size changed", changed: &fakeInfo{size: 2048, mode: 0755, modTime: built}},
+		{name: "mode changed", changed: &fakeInfo{size: 1024, mode: 0644, modTime: built}},
+		{name: "mtime changed", changed: &fakeInfo{size: 1024, mode: 0755, modTime: built.Add(time.Second)}},
+		{name: "removed"},
+		{name: "expired", changed: &original, expire: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := map[string]fakeInfo{"/usr/bin/python3": original}
+			cache := newCommandCache(time.Minute)
+			key := commandKey{command: "python3"}
+			cache.put(key, "/usr/bin/python3", fakeStat(files))
+
+			if tt.changed != nil {
+				files["/usr/bin/python3"] = *tt.changed
+			} else {
+				delete(files, "/usr/bin/python3")
+			}
+			if tt.expire {
+				entry := cache.entries[key]
+				entry.expires = time.Now().Add(-time.Second)
+				cache.entries[key] = entry
+			}
+
+			path, ok := cache.get(key, fakeStat(files))
+			if ok != tt.hit || (tt.hit && path != "/usr/bin/python3") {
+				t.Errorf("get() = %q, %v, want hit %v", path, ok, tt.hit)
+			}
+			if _, cached := cache.entries[key]; cached != tt.hit {
+				t.Errorf("expected a stale entry to be dropped, cached %v", cached)
+			}
+		})
+	}
+}
+
+func TestCommandCacheKeysBySearchPath(t *testing.T) {
+	files := map[string]fakeInfo{"/usr/bin/tool": {size: 1}, "/opt/venv/bin/tool": {size: 2}}
+	cache := newCommandCache(time.Minute)
+	cache.put(commandKey{command: "tool"}, "/usr/bin/tool", fakeStat(files))
+	cache.put(commandKey{command: "tool", searchPath: "/opt/venv/bin"}, "/opt/venv/bin/tool", fakeStat(files))
+
+	if path, _ := cache.get(commandKey{command: "tool"}, fakeStat(files)); path != "/usr/bin/tool" {
+		t.Errorf("worker PATH resolved to %q", path)
+	}
+	if path, _ := cache.get(commandKey{command: "tool", searchPath: "/opt/venv/bin"}, fakeStat(files)); path != "/opt/venv/bin/tool" {
+		t.Errorf("job PATH resolved to %q", path)
+	}
+}
+
+func TestCommandCacheEviction(t *testing.T) {
+	files := map[string]fakeInfo{"/usr/bin/cmd": {size: 1}}
+	stat := fakeStat(files)
+
+	fill := func(cache *commandCache, expired int) {
+		for i := 0; i < maxCachedCommands; i++ {
+			key := commandKey{command: fmt.Sprintf("cmd-%d", i)}
+			cache.put(key, "/usr/bin/cmd", stat)
+			if i < expired {
+				entry := cache.entries[key]
+				entry.expires = time.Now().Add(-time.Second)
+				cache.entries[key] = entry
+			}
+		}
+	}
+
+	// a full cache first drops its expired entries
+	cache := newCommandCache(time.Minute)
+	fill(cache, 10)
+	cache.put(commandKey{command: "new"}, "/usr/bin/cmd", stat)
+	if len(cache.entries) != maxCachedCommands-10+1 {
+		t.Errorf("expected the expired entries evicted, %d cached", len(cache.entries))
+	}
+	if _, ok := cache.entries[commandKey{command: "cmd-10"}]; !ok {
+		t.Error("expected live entries kept")
+	}
+
+	// and is emptied when none expired
+	cache = newCommandCache(time.Minute)
+	fill(cache, 0)
+	cache.put(commandKey{command: "new"}, "/usr/bin/cmd", stat)
+	if len(cache.entries) != 1 {
+		t.Errorf("expected a full cache emptied, %d cached", len(cache.entries))
+	}
+	if _, ok := cache.get(commandKey{command: "new"}, stat); !ok {
+		t.Error("expected the new entry cached")
+	}
+}
+
+func TestCommandCacheDisabled(t *testing.T) {
+	cache := newCommandCache(0)
+	if cache != nil {
+		t.Fatal("expected no cache without a TTL")
+	}
+
+	files := map[string]fakeInfo{"/usr/bin/cmd": {size: 1}}
+	cache.put(commandKey{command: "cmd"}, "/usr/bin/cmd", fakeStat(files))
+	if _, ok := cache.get(commandKey{command: "cmd"}, fakeStat(files)); ok {
+		t.Error("a nil cache must cache nothing")
+	}
+}
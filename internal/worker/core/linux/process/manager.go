@@ -7,8 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
 	"strings"
 	"syscall"
@@ -20,30 +22,55 @@ import (
 
 const (
 	GracefulShutdownTimeout = 100 * time.Millisecond
+	shutdownPollInterval    = 50 * time.Millisecond
 	ProcessStartTimeout     = 10 * time.Second
 	MaxJobArgs              = 100
 	MaxJobArgLength         = 1024
+	MaxJobEnvVars           = 256
+	MaxJobEnvValueLength    = 32 * 1024
+
+	// SelfExe is the running worker binary. Launching it stays valid when the
+	// file on disk is replaced or removed by a deploy.
+	SelfExe = "/proc/self/exe"
 )
 
+// reservedEnvPrefixes are owned by the worker and the job init process
+var reservedEnvPrefixes = []string{"JOB_", "WORKER_"}
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
 // Manager handles all process-related operations including launching, cleanup, and validation
 type Manager struct {
-	platform platform.Platform
-	logger   *logger.Logger
+	platform      platform.Platform
+	logger        *logger.Logger
+	cleanupLogger *logger.Logger // sampled, for entries logged per cleaned up process
+	commands      *commandCache  // resolved command names, nil when not cached
 }
 
-// NewProcessManager creates a new unified process manager
-func NewProcessManager(platform platform.Platform) *Manager {
+// cleanupLogsPerSecond limits the entries logged per cleaned up process, so
+// stopping many jobs at once does not flood the log
+const cleanupLogsPerSecond = 10
+
+// NewProcessManager creates a new unified process manager. Command names
+// resolve to the same path for commandCacheTTL while the file is unchanged,
+// 0 resolves them on every launch.
+func NewProcessManager(platform platform.Platform, commandCacheTTL time.Duration) *Manager {
+	log := logger.New().WithField("component", "process-manager")
 	return &Manager{
-		platform: platform,
-		logger:   logger.New().WithField("component", "process-manager"),
+		platform:      platform,
+		logger:        log,
+		cleanupLogger: log.Sampled(cleanupLogsPerSecond),
+		commands:      newCommandCache(commandCacheTTL),
 	}
 }
 
 // LaunchConfig contains all configuration for launching a process
 type LaunchConfig struct {
-	InitPath    string
+	InitPath    string // defaults to the running worker binary
 	Environment []string
 	SysProcAttr *syscall.SysProcAttr
+	ExtraFiles  []*os.File // passed as descriptors 3 and up
+	Stdin       io.Reader
 	Stdout      io.Writer
 	Stderr      io.Writer
 	JobID       string
@@ -87,13 +114,30 @@ func (pm *Manager) LaunchProcess(ctx context.Context, config *LaunchConfig) (*La
 		return result, nil
 	case <-ctx.Done():
 		log.Warn("context cancelled while starting process")
+		go pm.reapAbandoned(config.JobID, resultChan)
 		return nil, ctx.Err()
 	case <-time.After(ProcessStartTimeout):
 		log.Error("timeout waiting for process to start")
+		go pm.reapAbandoned(config.JobID, resultChan)
 		return nil, fmt.Errorf("timeout waiting for process to start")
 	}
 }
 
+// reapAbandoned kills a process whose launch was given up, should it still
+// start, so it does not run without a job
+func (pm *Manager) reapAbandoned(jobID string, resultChan <-chan *LaunchResult) {
+	result := <-resultChan
+	if result.Error != nil {
+		return
+	}
+
+	pm.logger.Warn("abandoned launch started a process, killing it", "jobID", jobID, "pid", result.PID)
+	if proc := result.Command.Process(); proc != nil {
+		_ = proc.Kill()
+	}
+	_ = result.Command.Wait()
+}
+
 // launchInGoroutine launches the process in a separate goroutine with proper namespace handling
 func (pm *Manager) launchInGoroutine(config *LaunchConfig, resultChan chan<- *LaunchResult) {
 	defer func() {
@@ -141,14 +185,21 @@ func (pm *Manager) launchInGoroutine(config *LaunchConfig, resultChan chan<- *La
 // createAndStartCommand creates and starts the command with proper configuration
 func (pm *Manager) createAndStartCommand(config *LaunchConfig) (platform.Command, error) {
 	// Create command
-	cmd := pm.platform.CreateCommand(config.InitPath)
+	initPath := config.InitPath
+	if initPath == "" {
+		initPath = SelfExe
+	}
+	cmd := pm.platform.CreateCommand(initPath)
 
 	// Set environment
 	if config.Environment != nil {
 		cmd.SetEnv(config.Environment)
 	}
 
-	// Set stdout/stderr
+	// Set stdin/stdout/stderr
+	if config.Stdin != nil {
+		cmd.SetStdin(config.Stdin)
+	}
 	if config.Stdout != nil {
 		cmd.SetStdout(config.Stdout)
 	}
@@ -156,6 +207,10 @@ func (pm *Manager) createAndStartCommand(config *LaunchConfig) (platform.Command
 		cmd.SetStderr(config.Stderr)
 	}
 
+	if len(config.ExtraFiles) > 0 {
+		cmd.SetExtraFiles(config.ExtraFiles)
+	}
+
 	// Set system process attributes (namespaces, process group, etc.)
 	if config.SysProcAttr != nil {
 		cmd.SetSysProcAttr(config.SysProcAttr)
@@ -177,6 +232,7 @@ type CleanupRequest struct {
 	NetworkGroupID  string
 	NamespacePath   string
 	ForceKill       bool
+	Signal          syscall.Signal // asks the process to exit, SIGTERM when 0
 	GracefulTimeout time.Duration
 }
 
@@ -201,7 +257,7 @@ func (pm *Manager) CleanupProcess(ctx context.Context, req *CleanupRequest) (*Cl
 		return nil, fmt.Errorf("invalid cleanup request: %w", err)
 	}
 
-	log := pm.logger.WithFields("jobID", req.JobID, "pid", req.PID)
+	log := pm.cleanupLogger.WithFields("jobID", req.JobID, "pid", req.PID)
 	log.Debug("starting process cleanup", "forceKill", req.ForceKill, "gracefulTimeout", req.GracefulTimeout)
 
 	startTime := time.Now()
@@ -250,7 +306,7 @@ type processCleanupResult struct {
 
 // cleanupProcessAndGroup handles process and process group cleanup
 func (pm *Manager) cleanupProcessAndGroup(ctx context.Context, req *CleanupRequest) *processCleanupResult {
-	log := pm.logger.WithFields("jobID", req.JobID, "pid", req.PID)
+	log := pm.cleanupLogger.WithFields("jobID", req.JobID, "pid", req.PID)
 
 	// Check if process is still alive
 	if !pm.isProcessAlive(req.PID) {
@@ -263,12 +319,12 @@ func (pm *Manager) cleanupProcessAndGroup(ctx context.Context, req *CleanupReque
 	}
 
 	// If force kill is requested, skip graceful shutdown
-	if req.ForceKill {
+	if req.ForceKill || req.Signal == syscall.SIGKILL {
 		return pm.forceKillProcess(req.PID, req.JobID)
 	}
 
 	// Try graceful shutdown first
-	gracefulResult := pm.attemptGracefulShutdown(req.PID, req.GracefulTimeout, req.JobID)
+	gracefulResult := pm.attemptGracefulShutdown(req.PID, req.Signal, req.GracefulTimeout, req.JobID)
 	if gracefulResult.Killed {
 		return gracefulResult
 	}
@@ -278,55 +334,59 @@ func (pm *Manager) cleanupProcessAndGroup(ctx context.Context, req *CleanupReque
 	return pm.forceKillProcess(req.PID, req.JobID)
 }
 
-// attemptGracefulShutdown attempts to gracefully shut down a process
-func (pm *Manager) attemptGracefulShutdown(pid int32, timeout time.Duration, jobID string) *processCleanupResult {
-	log := pm.logger.WithFields("jobID", jobID, "pid", pid)
+// attemptGracefulShutdown sends signal to a process and waits up to timeout for it to exit
+func (pm *Manager) attemptGracefulShutdown(pid int32, signal syscall.Signal, timeout time.Duration, jobID string) *processCleanupResult {
+	log := pm.cleanupLogger.WithFields("jobID", jobID, "pid", pid)
 
+	if signal == 0 {
+		signal = syscall.SIGTERM
+	}
 	if timeout <= 0 {
 		timeout = GracefulShutdownTimeout
 	}
 
-	log.Debug("attempting graceful shutdown", "timeout", timeout)
+	log.Debug("attempting graceful shutdown", "signal", signal, "timeout", timeout)
 
-	// Send SIGTERM to process group first
-	if err := pm.platform.Kill(-int(pid), syscall.SIGTERM); err != nil {
-		log.Warn("failed to send SIGTERM to process group", "error", err)
-		// If killing the group failed, try killing just the main process
-		if err := pm.platform.Kill(int(pid), syscall.SIGTERM); err != nil {
-			log.Warn("failed to send SIGTERM to main process", "error", err)
+	// Signal the process group first
+	if err := pm.platform.Kill(-int(pid), signal); err != nil {
+		log.Warn("failed to signal process group", "signal", signal, "error", err)
+		// If signalling the group failed, try just the main process
+		if err := pm.platform.Kill(int(pid), signal); err != nil {
+			log.Warn("failed to signal main process", "signal", signal, "error", err)
 			return &processCleanupResult{
 				Killed: false,
 				Method: "graceful_failed",
-				Error:  fmt.Errorf("failed to send SIGTERM: %w", err),
+				Error:  fmt.Errorf("failed to send %v: %w", signal, err),
 			}
 		}
 	}
 
-	// Wait for graceful shutdown
+	// Wait for the process to exit, checking every poll interval
 	log.Debug("waiting for graceful shutdown", "timeout", timeout)
-	time.Sleep(timeout)
-
-	// Check if process is still alive
-	if !pm.isProcessAlive(pid) {
-		log.Debug("process terminated gracefully")
-		return &processCleanupResult{
-			Killed: true,
-			Method: "graceful",
-			Error:  nil,
+	deadline := time.Now().Add(timeout)
+	for pm.isProcessAlive(pid) {
+		if !time.Now().Before(deadline) {
+			log.Debug("process still alive after graceful shutdown attempt")
+			return &processCleanupResult{
+				Killed: false,
+				Method: "graceful_timeout",
+				Error:  nil,
+			}
 		}
+		time.Sleep(min(shutdownPollInterval, time.Until(deadline)))
 	}
 
-	log.Debug("process still alive after graceful shutdown attempt")
+	log.Debug("process terminated gracefully")
 	return &processCleanupResult{
-		Killed: false,
-		Method: "graceful_timeout",
+		Killed: true,
+		Method: "graceful",
 		Error:  nil,
 	}
 }
 
 // forceKillProcess force kills a process and its group
 func (pm *Manager) forceKillProcess(pid int32, jobID string) *processCleanupResult {
-	log := pm.logger.WithFields("jobID", jobID, "pid", pid)
+	log := pm.cleanupLogger.WithFields("jobID", jobID, "pid", pid)
 	log.Warn("force killing process")
 
 	// Send SIGKILL to process group
@@ -381,19 +441,69 @@ func (pm *Manager) ValidateCommand(command string) error {
 	return pm.validateCommand(command)
 }
 
+// ValidateShellScript validates a script for shell mode
+func (pm *Manager) ValidateShellScript(script string) error {
+	return pm.validateShellScript(script)
+}
+
 // ValidateArguments validates command arguments
 func (pm *Manager) ValidateArguments(args []string) error {
 	return pm.validateArguments(args)
 }
 
-// ResolveCommand resolves a command to its full path
-func (pm *Manager) ResolveCommand(command string) (string, error) {
+// ValidateJobEnvironment validates client supplied environment variables against
+// naming rules, size limits, reserved prefixes and the configured denylist
+func (pm *Manager) ValidateJobEnvironment(env map[string]string, denylist []string) error {
+	return pm.validateJobEnvironment(env, denylist)
+}
+
+// ResolveOptions describe the job a command is resolved for
+type ResolveOptions struct {
+	SearchPath string   // the job's own PATH, "" for the worker's
+	JobDirs    []string // directories only the job's mount namespace has, e.g. its workspace mount
+}
+
+// ResolveCommand resolves a command to its full path. A job with its own
+// PATH in SearchPath has the command looked up only there, as in the worker's
+// PATH and common locations it could find a binary the job would not.
+//
+// Commands in the job's own filesystem, relative to its working directory or
+// in one of JobDirs, cannot be seen from the host. They are returned as they
+// are, and the job's init process resolves them inside its mount namespace.
+func (pm *Manager) ResolveCommand(command string, opts ResolveOptions) (string, error) {
 	if command == "" {
 		return "", fmt.Errorf("command cannot be empty")
 	}
 
 	log := pm.logger.WithField("command", command)
 
+	if InJobFilesystem(command, opts.JobDirs) {
+		log.Debug("command is in the job's filesystem, resolved by its init process")
+		return command, nil
+	}
+	if filepath.IsAbs(command) {
+		return pm.resolveCommand(command, opts, log)
+	}
+
+	key := commandKey{command: command, searchPath: opts.SearchPath}
+	if path, ok := pm.commands.get(key, pm.platform.Stat); ok {
+		log.Debug("using cached command path", "resolved", path)
+		return path, nil
+	}
+	path, err := pm.resolveCommand(command, opts, log)
+	if err == nil {
+		pm.commands.put(key, path, pm.platform.Stat)
+	}
+	return path, err
+}
+
+// resolveCommand is ResolveCommand for commands outside the job's filesystem,
+// without the cache
+func (pm *Manager) resolveCommand(command string, opts ResolveOptions, log *logger.Logger) (string, error) {
+	if opts.SearchPath != "" && !filepath.IsAbs(command) {
+		return pm.lookPathIn(command, opts.SearchPath, log)
+	}
+
 	// If command is already absolute, validate it exists
 	if filepath.IsAbs(command) {
 		if _, err := pm.platform.Stat(command); err != nil {
@@ -432,6 +542,38 @@ func (pm *Manager) ResolveCommand(command string) (string, error) {
 	return "", fmt.Errorf("command %s not found in PATH or common locations", command)
 }
 
+// InJobFilesystem reports whether a command path is one only the job can
+// resolve: relative to its working directory, or under one of jobDirs
+func InJobFilesystem(command string, jobDirs []string) bool {
+	if !filepath.IsAbs(command) {
+		return strings.Contains(command, "/")
+	}
+	for _, dir := range jobDirs {
+		if rel, err := filepath.Rel(dir, filepath.Clean(command)); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			return true
+		}
+	}
+	return false
+}
+
+// lookPathIn finds an executable command in the directories of searchPath
+func (pm *Manager) lookPathIn(command, searchPath string, log *logger.Logger) (string, error) {
+	if strings.Contains(command, "/") {
+		return "", fmt.Errorf("command %s must be a name or an absolute path", command)
+	}
+
+	for _, dir := range filepath.SplitList(searchPath) {
+		path := filepath.Join(dir, command)
+		if info, err := pm.platform.Stat(path); err == nil && info.Mode().IsRegular() && info.Mode()&0111 != 0 {
+			log.Debug("resolved command via the job's PATH", "resolved", path)
+			return path, nil
+		}
+	}
+
+	log.Error("command not found in the job's PATH", "path", searchPath)
+	return "", fmt.Errorf("command %s not found in PATH %s", command, searchPath)
+}
+
 // CreateSysProcAttr creates syscall process attributes for namespace isolation
 func (pm *Manager) CreateSysProcAttr(enableNetworkNS bool) *syscall.SysProcAttr {
 	sysProcAttr := pm.platform.CreateProcessGroup()
@@ -633,6 +775,21 @@ func (pm *Manager) validateCommand(command string) error {
 	return nil
 }
 
+// validateShellScript checks a script run with sh -c. Shell syntax is the point
+// of shell mode, so only the size and null bytes are checked.
+func (pm *Manager) validateShellScript(script string) error {
+	if strings.TrimSpace(script) == "" {
+		return ValidationError{Field: "command", Value: script, Message: "shell script cannot be empty"}
+	}
+	if len(script) > MaxJobArgLength {
+		return ValidationError{Field: "command", Value: "shell script", Message: fmt.Sprintf("shell script too long (max %d characters)", MaxJobArgLength)}
+	}
+	if strings.Contains(script, "\x00") {
+		return ValidationError{Field: "command", Value: "shell script", Message: "shell script contains null bytes"}
+	}
+	return nil
+}
+
 func (pm *Manager) validateArguments(args []string) error {
 	if len(args) > MaxJobArgs {
 		return ValidationError{Field: "args", Value: len(args), Message: fmt.Sprintf("too many arguments (max %d)", MaxJobArgs)}
@@ -648,6 +805,55 @@ func (pm *Manager) validateArguments(args []string) error {
 	return nil
 }
 
+func (pm *Manager) validateJobEnvironment(env map[string]string, denylist []string) error {
+	if len(env) > MaxJobEnvVars {
+		return ValidationError{Field: "env", Value: len(env), Message: fmt.Sprintf("too many environment variables (max %d)", MaxJobEnvVars)}
+	}
+	for name, value := range env {
+		if !envNamePattern.MatchString(name) {
+			return ValidationError{Field: "env", Value: name, Message: "invalid environment variable name"}
+		}
+		for _, prefix := range reservedEnvPrefixes {
+			if strings.HasPrefix(name, prefix) {
+				return ValidationError{Field: "env", Value: name, Message: fmt.Sprintf("prefix %s is reserved", prefix)}
+			}
+		}
+		if isDeniedEnvName(name, denylist) {
+			return ValidationError{Field: "env", Value: name, Message: "environment variable is not allowed"}
+		}
+		if len(value) > MaxJobEnvValueLength {
+			return ValidationError{Field: "env", Value: name, Message: fmt.Sprintf("value too long (max %d characters)", MaxJobEnvValueLength)}
+		}
+		if strings.Contains(value, "\x00") {
+			return ValidationError{Field: "env", Value: name, Message: "value contains null bytes"}
+		}
+		if name == "PATH" {
+			for _, dir := range filepath.SplitList(value) {
+				if !filepath.IsAbs(dir) {
+					return ValidationError{Field: "env", Value: name, Message: fmt.Sprintf("PATH entry %q is not an absolute directory", dir)}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isDeniedEnvName matches a name against denylist entries, where a trailing * matches any suffix
+func isDeniedEnvName(name string, denylist []string) bool {
+	for _, entry := range denylist {
+		if prefix, ok := strings.CutSuffix(entry, "*"); ok {
+			if strings.HasPrefix(name, prefix) {
+				return true
+			}
+			continue
+		}
+		if name == entry {
+			return true
+		}
+	}
+	return false
+}
+
 func (pm *Manager) validatePID(pid int32) error {
 	if pid <= 0 {
 		return ValidationError{Field: "pid", Value: pid, Message: "PID must be positive"}
@@ -659,14 +865,13 @@ func (pm *Manager) validatePID(pid int32) error {
 }
 
 func (pm *Manager) validateLaunchConfig(config *LaunchConfig) error {
-	if config.InitPath == "" {
-		return fmt.Errorf("init path cannot be empty")
-	}
 	if config.JobID == "" {
 		return fmt.Errorf("job ID cannot be empty")
 	}
-	if err := pm.validateInitPath(config.InitPath); err != nil {
-		return fmt.Errorf("invalid init path: %w", err)
+	if config.InitPath != "" {
+		if err := pm.validateInitPath(config.InitPath); err != nil {
+			return fmt.Errorf("invalid init path: %w", err)
+		}
 	}
 	if config.Environment != nil {
 		if err := pm.validateEnvironment(config.Environment); err != nil {
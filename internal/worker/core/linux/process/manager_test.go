@@ -0,0 +1,158 @@
+//go:build linux
+
+package process
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+	"worker/pkg/platform/platformfakes"
+)
+
+func TestResolveCommandInJobPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   map[string]fakeInfo
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "only in the job PATH",
+			files: map[string]fakeInfo{"/opt/venv/bin/tool": {mode: 0755}},
+			want:  "/opt/venv/bin/tool",
+		},
+		{
+			name:    "only in the worker PATH",
+			files:   map[string]fakeInfo{"/usr/bin/tool": {mode: 0755}},
+			wantErr: true,
+		},
+		{
+			name:    "not executable",
+			files:   map[string]fakeInfo{"/opt/venv/bin/tool": {mode: 0644}},
+			wantErr: true,
+		},
+		{
+			name:  "not executable before an executable",
+			files: map[string]fakeInfo{"/opt/venv/bin/tool": {mode: 0644}, "/opt/tools/tool": {mode: 0755}},
+			want:  "/opt/tools/tool",
+		},
+		{
+			name:    "directory",
+			files:   map[string]fakeInfo{"/opt/venv/bin/tool": {mode: os.ModeDir | 0755}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &platformfakes.FakePlatform{}
+			fake.StatStub = fakeStat(tt.files)
+			// the worker's PATH would find the command
+			fake.LookPathReturns("/usr/bin/tool", nil)
+			pm := NewProcessManager(fake, 0)
+
+			got, err := pm.ResolveCommand("tool", ResolveOptions{SearchPath: "/opt/venv/bin:/opt/tools"})
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ResolveCommand() = %q, %v, want %q", got, err, tt.want)
+			}
+			if fake.LookPathCallCount() != 0 {
+				t.Error("expected the worker's PATH not searched")
+			}
+		})
+	}
+}
+
+func TestInJobFilesystem(t *testing.T) {
+	jobDirs := []string{"/ws", "/data/"}
+
+	tests := []struct {
+		command string
+		want    bool
+	}{
+		{"/ws/run.sh", true},
+		{"/ws/bin/run", true},
+		{"/ws", true},
+		{"/data/tool", true},
+		{"/ws/..hidden", true},
+		{"./run.sh", true},
+		{"bin/run", true},
+		{"python3", false},
+		{"/ws2/run.sh", false},
+		{"/wsx", false},
+		{"/ws/../etc/passwd", false},
+		{"/ws/bin/../../usr/bin/env", false},
+		{"/usr/bin/python3", false},
+		{"/", false},
+	}
+
+	for _, tt := range tests {
+		if got := InJobFilesystem(tt.command, jobDirs); got != tt.want {
+			t.Errorf("InJobFilesystem(%q) = %v, want %v", tt.command, got, tt.want)
+		}
+	}
+
+	if InJobFilesystem("/ws/run.sh", nil) {
+		t.Error("expected an absolute path outside any job directory to be the host's")
+	}
+}
+
+func TestLaunchProcessReapsAbandonedChildren(t *testing.T) {
+	tests := []struct {
+		name     string
+		startErr error
+		reaped   bool
+	}{
+		{name: "started after the launch was abandoned", reaped: true},
+		{name: "failed after the launch was abandoned", startErr: errors.New("exec failed")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proc := &platformfakes.FakeProcess{}
+			proc.PidReturns(4242)
+			cmd := &platformfakes.FakeCommand{}
+			cmd.ProcessReturns(proc)
+			waited := make(chan struct{})
+			cmd.WaitStub = func() error {
+				close(waited)
+				return nil
+			}
+
+			// the process starts only once the caller gave up on it
+			release := make(chan struct{})
+			cmd.StartStub = func() error {
+				<-release
+				return tt.startErr
+			}
+			fake := &platformfakes.FakePlatform{}
+			fake.CreateCommandReturns(cmd)
+			pm := NewProcessManager(fake, 0)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if _, err := pm.LaunchProcess(ctx, &LaunchConfig{JobID: "1"}); !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected the launch abandoned, got %v", err)
+			}
+			close(release)
+
+			select {
+			case <-waited:
+				if !tt.reaped {
+					t.Fatal("expected a process that never started not to be waited on")
+				}
+			case <-time.After(time.Second):
+				if tt.reaped {
+					t.Fatal("expected the abandoned child waited on")
+				}
+			}
+			if tt.reaped && proc.KillCallCount() != 1 {
+				t.Errorf("expected the abandoned child killed once, got %d kills", proc.KillCallCount())
+			}
+			if !tt.reaped && proc.KillCallCount() != 0 {
+				t.Errorf("expected nothing killed, got %d kills", proc.KillCallCount())
+			}
+		})
+	}
+}
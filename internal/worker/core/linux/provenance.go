@@ -0,0 +1,113 @@
+//go:build linux
+
+package linux
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+	"worker/internal/worker/core/linux/isolation"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/version"
+)
+
+// recordProvenance captures what a job is launched with. It runs once the
+// job's cgroup is set up, so the settings read back are those in effect.
+func (w *Worker) recordProvenance(job *domain.Job, backend isolation.Backend) *domain.Provenance {
+	log := w.logger.WithFields("jobID", job.Id, "operation", "record-provenance")
+
+	p := &domain.Provenance{
+		Command:       job.Command,
+		Env:           w.provenanceEnv(job, backend),
+		KernelVersion: w.kernelRelease(),
+		CgroupPath:    job.CgroupPath,
+		WorkerVersion: version.String(),
+		RecordedAt:    time.Now(),
+	}
+	if job.CgroupPath != "" {
+		p.Cgroup = w.cgroup.Settings(job.CgroupPath)
+	}
+	if job.Spec != nil {
+		p.SignedBy = job.Spec.SignedBy
+	}
+
+	if path := w.hostCommandPath(job, backend); path != "" {
+		digest, err := w.fileSHA256(path)
+		if err != nil {
+			log.Debug("could not hash the job's executable", "path", path, "error", err)
+		}
+		p.CommandSHA256 = digest
+	}
+	return p
+}
+
+// provenanceEnv is the environment the job's command runs with, as init execs
+// it, with secret values and those of sensitive names masked
+func (w *Worker) provenanceEnv(job *domain.Job, backend isolation.Backend) map[string]string {
+	masked := make(map[string]string, len(job.SecretEnv))
+	for name := range job.SecretEnv {
+		masked[name] = redact.DefaultMask
+	}
+
+	env := make(map[string]string)
+	for _, entry := range w.buildJobEnvironmentSingleBinary(job, w.binaryPath, masked, backend) {
+		name, value, _ := strings.Cut(entry, "=")
+		// init drops the worker's settings before the exec of a job that does
+		// not inherit the worker's environment, see jobexec.jobEnvironment
+		if job.CleanEnv && (strings.HasPrefix(name, "WORKER_") || name == "HOST_NETWORKING") {
+			continue
+		}
+		env[name] = value // the last entry wins, as it does in the process started
+	}
+	return w.redactor.RedactEnv(env)
+}
+
+// kernelRelease returns the release of the running kernel, "" if unknown
+func (w *Worker) kernelRelease() string {
+	data, err := w.platform.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// hostCommandPath returns where the host sees the job's executable. A command
+// in the job's own filesystem is in its workspace; "" if it cannot be found.
+func (w *Worker) hostCommandPath(job *domain.Job, backend isolation.Backend) string {
+	command := job.Command
+	if !filepath.IsAbs(command) {
+		if job.Workspace == "" {
+			return ""
+		}
+		return filepath.Join(job.Workspace, command)
+	}
+
+	for _, dir := range w.jobDirs(backend) {
+		if rel, err := filepath.Rel(dir, command); err == nil && rel != ".." && !strings.HasPrefix(rel, "../") {
+			if job.Workspace == "" {
+				return ""
+			}
+			return filepath.Join(job.Workspace, rel)
+		}
+	}
+	return command
+}
+
+// fileSHA256 returns the hex SHA-256 digest of a file
+func (w *Worker) fileSHA256(path string) (string, error) {
+	file, err := w.platform.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -2,6 +2,7 @@ package resource
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,21 +10,56 @@ import (
 	"strings"
 	"syscall"
 	"time"
+	"worker/internal/worker/domain"
 	"worker/pkg/config"
 	"worker/pkg/logger"
+	"worker/pkg/platform"
+
+	"golang.org/x/sys/unix"
 )
 
 type cgroup struct {
-	logger      *logger.Logger
-	initialized bool
-	config      config.CgroupConfig
+	logger        *logger.Logger
+	cleanupLogger *logger.Logger // sampled, for entries logged per job cleanup
+	initialized   bool
+	config        config.CgroupConfig
+	cleanups      chan cleanupRequest
+	faults        *platform.Faults // fail job cgroup writes, nil unless fault injection is enabled
+
+	// write writes job cgroup files, os.WriteFile but in tests
+	write func(path string, data []byte, perm os.FileMode) error
+}
+
+// cleanupLogsPerSecond limits the entries logged per job cgroup cleanup, so
+// removing many cgroups at once does not flood the log
+const cleanupLogsPerSecond = 10
+
+// cleanupRequest is a job cgroup waiting to be removed by the cleanup pool
+type cleanupRequest struct {
+	jobID      string
+	cgroupPath string
+	done       func(error)
 }
 
-func New(cfg config.CgroupConfig) Resource {
-	return &cgroup{
-		logger: logger.New().WithField("component", "resource-manager"),
-		config: cfg,
+func New(cfg config.CgroupConfig, faults *platform.Faults) Resource {
+	c := &cgroup{
+		logger:   logger.New().WithField("component", "resource-manager"),
+		config:   cfg,
+		cleanups: make(chan cleanupRequest, cfg.CleanupQueueSize),
+		faults:   faults,
+		write:    os.WriteFile,
+	}
+	c.cleanupLogger = c.logger.Sampled(cleanupLogsPerSecond)
+
+	workers := cfg.CleanupWorkers
+	if workers < 1 {
+		workers = 1
 	}
+	for i := 0; i < workers; i++ {
+		go c.cleanupWorker()
+	}
+
+	return c
 }
 
 func (c *cgroup) EnsureControllers() error {
@@ -57,21 +93,37 @@ func (c *cgroup) EnsureControllers() error {
 
 //counterfeiter:generate . Resource
 type Resource interface {
-	Create(cgroupJobDir string, maxCPU int32, maxMemory int32, maxIOBPS int32) error
-	SetIOLimit(cgroupPath string, ioBPS int) error
-	SetCPULimit(cgroupPath string, cpuLimit int) error
-	SetMemoryLimit(cgroupPath string, memoryLimitMB int) error
-	CleanupCgroup(jobID string)
+	// Create creates a job cgroup with its limits. The cgroup is created even
+	// when the error, wrapping LimitNotAppliedError, says the kernel did not
+	// apply some of them; CreateGroup does the same.
+	Create(cgroupJobDir string, cpuMillis int64, memoryBytes int64, ioBPS int64) error
+	// CreateGroup creates the parent cgroup of a job group with the limits its jobs share
+	CreateGroup(groupDir string, cpuMillis int64, memoryBytes int64, ioBPS int64) error
+	// RemoveGroup removes a group cgroup once no job cgroup is left in it
+	RemoveGroup(groupDir string) error
+	SetIOLimit(cgroupPath string, ioBPS int64) error
+	SetCPULimit(cgroupPath string, cpuMillis int64) error
+	SetMemoryLimit(cgroupPath string, memoryBytes int64) error
+	SetQoS(cgroupPath string, qos domain.QoSSettings) error
+	// Settings reads back the limits and QoS settings in effect in a cgroup
+	Settings(cgroupPath string) map[string]string
+	CleanupCgroup(jobID string, cgroupPath string, done func(error))
 	EnsureControllers() error
 }
 
 func (c *cgroup) enableControllersFromConfig() error {
-	log := c.logger.WithField("operation", "enable-controllers")
+	return c.enableControllers(c.config.BaseDir)
+}
 
-	subtreeControlFile := filepath.Join(c.config.BaseDir, "cgroup.subtree_control")
+// enableControllers enables the configured controllers that are available
+// for the cgroups created in dir
+func (c *cgroup) enableControllers(dir string) error {
+	log := c.logger.WithFields("operation", "enable-controllers", "cgroupPath", dir)
+
+	subtreeControlFile := filepath.Join(dir, "cgroup.subtree_control")
 
 	// Check available controllers
-	controllersFile := filepath.Join(c.config.BaseDir, "cgroup.controllers")
+	controllersFile := filepath.Join(dir, "cgroup.controllers")
 	availableBytes, err := os.ReadFile(controllersFile)
 	if err != nil {
 		return fmt.Errorf("failed to read available controllers: %w", err)
@@ -99,7 +151,7 @@ func (c *cgroup) enableControllersFromConfig() error {
 	// Write enabled controllers
 	controllersToEnable := strings.Join(enabledControllers, " ")
 	if err := os.WriteFile(subtreeControlFile, []byte(controllersToEnable), 0644); err != nil {
-		return fmt.Errorf("failed to enable controllers: %w", err)
+		return fmt.Errorf("failed to enable controllers: %w", domain.NewCgroupError(subtreeControlFile, controllersToEnable, err))
 	}
 
 	log.Info("controllers enabled from configuration",
@@ -133,6 +185,30 @@ func (c *cgroup) moveWorkerProcessToSubgroup() error {
 	return nil
 }
 
+// writeFile writes a job cgroup file, failing with a domain.CgroupError
+func (c *cgroup) writeFile(path string, data []byte) error {
+	err := c.faults.CgroupWrite(path)
+	if err == nil {
+		err = c.write(path, data, 0644)
+	}
+	if err != nil {
+		return domain.NewCgroupError(path, string(data), err)
+	}
+	return nil
+}
+
+// mkdirAll creates a job cgroup, failing with a domain.CgroupError
+func (c *cgroup) mkdirAll(path string) error {
+	err := c.faults.CgroupWrite(path)
+	if err == nil {
+		err = os.MkdirAll(path, 0755)
+	}
+	if err != nil {
+		return domain.NewCgroupError(path, "", err)
+	}
+	return nil
+}
+
 // contains checks if a slice contains a string
 func contains(slice []string, item string) bool {
 	for _, s := range slice {
@@ -143,12 +219,12 @@ func contains(slice []string, item string) bool {
 	return false
 }
 
-func (c *cgroup) Create(cgroupJobDir string, maxCPU int32, maxMemory int32, maxIOBPS int32) error {
+func (c *cgroup) Create(cgroupJobDir string, cpuMillis int64, memoryBytes int64, ioBPS int64) error {
 	log := c.logger.WithFields(
 		"cgroupPath", cgroupJobDir,
-		"maxCPU", maxCPU,
-		"maxMemory", maxMemory,
-		"maxIOBPS", maxIOBPS)
+		"cpuMillis", cpuMillis,
+		"memoryBytes", memoryBytes,
+		"ioBPS", ioBPS)
 
 	log.Info("creating cgroup")
 
@@ -163,45 +239,135 @@ func (c *cgroup) Create(cgroupJobDir string, maxCPU int32, maxMemory int32, maxI
 	}
 
 	// Create the cgroup directory
-	if err := os.MkdirAll(cgroupJobDir, 0755); err != nil {
+	if err := c.mkdirAll(cgroupJobDir); err != nil {
 		log.Error("failed to create cgroup directory", "error", err)
-		return fmt.Errorf("failed to create cgroup directory: %v", err)
+		return err
 	}
 
 	// Wait a moment for controller files to appear
 	time.Sleep(100 * time.Millisecond)
 
-	// Set CPU limit (with better error handling)
-	if maxCPU > 0 {
-		if err := c.SetCPULimit(cgroupJobDir, int(maxCPU)); err != nil {
-			log.Warn("failed to set CPU limit", "error", err)
-			// Don't fail the job creation - just log the warning
-		}
+	if err := c.setLimits(cgroupJobDir, cpuMillis, memoryBytes, ioBPS, log); err != nil {
+		log.Warn("cgroup limits not set", "error", err)
+		return err
 	}
 
-	// Set memory limit (with better error handling)
-	if maxMemory > 0 {
-		if err := c.SetMemoryLimit(cgroupJobDir, int(maxMemory)); err != nil {
-			log.Warn("failed to set memory limit", "error", err)
-			// Don't fail the job creation - just log the warning
+	log.Info("cgroup created successfully")
+	return nil
+}
+
+// setLimits sets the non-zero limits of a cgroup. Limits of controllers that
+// are not enabled are logged and skipped rather than failing the cgroup, but
+// writes the kernel refused fail it with a domain.CgroupError. Otherwise the
+// error lists the limits the kernel ignored or clamped, as LimitNotAppliedError.
+func (c *cgroup) setLimits(cgroupPath string, cpuMillis int64, memoryBytes int64, ioBPS int64, log *logger.Logger) error {
+	var refused, notApplied []error
+	failed := func(limit string, err error) {
+		var limitErr *LimitNotAppliedError
+		var cgroupErr *domain.CgroupError
+		switch {
+		case errors.As(err, &limitErr):
+			notApplied = append(notApplied, err)
+		case errors.As(err, &cgroupErr) && cgroupErr.Kind != domain.CgroupControllerMissing:
+			refused = append(refused, err)
+		default:
+			log.Warn("failed to set "+limit+" limit", "error", err)
 		}
 	}
 
-	// Set IO limit (with better error handling)
-	if maxIOBPS > 0 {
-		if err := c.SetIOLimit(cgroupJobDir, int(maxIOBPS)); err != nil {
-			log.Warn("failed to set IO limit", "error", err)
-			// Don't fail the job creation - just log the warning
+	if cpuMillis > 0 {
+		if err := c.SetCPULimit(cgroupPath, cpuMillis); err != nil {
+			failed("CPU", err)
+		}
+	}
+	if memoryBytes > 0 {
+		if err := c.SetMemoryLimit(cgroupPath, memoryBytes); err != nil {
+			failed("memory", err)
+		}
+	}
+	if ioBPS > 0 {
+		if err := c.SetIOLimit(cgroupPath, ioBPS); err != nil {
+			failed("IO", err)
 		}
 	}
+	if len(refused) > 0 {
+		return errors.Join(refused...)
+	}
+	return errors.Join(notApplied...)
+}
 
-	log.Info("cgroup created successfully")
+// CreateGroup creates the parent cgroup of a job group, with the limits its
+// jobs share, and enables the controllers for the job cgroups created in it.
+// Creating a group cgroup that exists sets its limits again.
+func (c *cgroup) CreateGroup(groupDir string, cpuMillis int64, memoryBytes int64, ioBPS int64) error {
+	log := c.logger.WithFields(
+		"cgroupPath", groupDir,
+		"cpuMillis", cpuMillis,
+		"memoryBytes", memoryBytes,
+		"ioBPS", ioBPS)
+
+	if !c.isGroupCgroup(groupDir) {
+		return fmt.Errorf("security violation: not a group cgroup in the delegated subtree: %s", groupDir)
+	}
+
+	if err := c.EnsureControllers(); err != nil {
+		log.Warn("controller setup failed", "error", err)
+	}
+
+	if err := c.mkdirAll(groupDir); err != nil {
+		return err
+	}
+
+	// without them the job cgroups would be created without their limits
+	if err := c.enableControllers(groupDir); err != nil {
+		return fmt.Errorf("failed to enable controllers for the group's jobs: %w", err)
+	}
+
+	if err := c.setLimits(groupDir, cpuMillis, memoryBytes, ioBPS, log); err != nil {
+		log.Warn("group cgroup limits not set", "error", err)
+		return err
+	}
+
+	log.Debug("group cgroup created")
 	return nil
 }
 
-// SetIOLimit sets IO limits for a cgroup
-func (c *cgroup) SetIOLimit(cgroupPath string, ioBPS int) error {
-	log := c.logger.WithFields("cgroupPath", cgroupPath, "ioBPS", ioBPS)
+// RemoveGroup removes a group cgroup, unless job cgroups are still in it
+func (c *cgroup) RemoveGroup(groupDir string) error {
+	if !c.isGroupCgroup(groupDir) {
+		return fmt.Errorf("refusing to remove non-group cgroup %s", groupDir)
+	}
+
+	err := os.Remove(groupDir)
+	if err == nil {
+		c.logger.Debug("removed group cgroup", "cgroupPath", groupDir)
+		return nil
+	}
+	if os.IsNotExist(err) || errors.Is(err, syscall.EBUSY) || errors.Is(err, syscall.ENOTEMPTY) {
+		return nil
+	}
+	return fmt.Errorf("failed to remove group cgroup directory: %w", err)
+}
+
+// isGroupCgroup reports whether path is a group cgroup of the delegated subtree
+func (c *cgroup) isGroupCgroup(path string) bool {
+	return filepath.Dir(path) == c.config.BaseDir && strings.HasPrefix(filepath.Base(path), "group-")
+}
+
+// isJobCgroup reports whether path is a job cgroup of the delegated subtree,
+// directly in it or in a group cgroup
+func (c *cgroup) isJobCgroup(path string) bool {
+	if !strings.HasPrefix(filepath.Base(path), "job-") {
+		return false
+	}
+	parent := filepath.Dir(path)
+	return parent == c.config.BaseDir || c.isGroupCgroup(parent)
+}
+
+// SetIOLimit limits the read and write rate of a cgroup on the disk of the
+// job workspaces, or the configured IO device
+func (c *cgroup) SetIOLimit(cgroupPath string, ioBPS int64) error {
+	log := c.logger.WithFields("cgroupPath", cgroupPath, "ioBPS", ioBPS, "device", c.config.IODevice)
 
 	// Check if io.max exists to confirm cgroup v2
 	ioMaxPath := filepath.Join(cgroupPath, "io.max")
@@ -209,44 +375,78 @@ func (c *cgroup) SetIOLimit(cgroupPath string, ioBPS int) error {
 		log.Debug("io.max not found, IO limiting not available")
 		return fmt.Errorf("io.max not found, cgroup v2 IO limiting not available")
 	}
-
-	// Check current device format by reading io.max
-	if currentConfig, err := os.ReadFile(ioMaxPath); err == nil {
-		log.Debug("current io.max content", "content", string(currentConfig))
+	if c.config.IODevice == "" {
+		return fmt.Errorf("no disk to limit IO on, set the cgroup ioDevice")
 	}
 
-	// Try different formats with valid device identification
-	formats := []string{
-		// Device with just rbps (more likely to work)
-		fmt.Sprintf("8:0 rbps=%d", ioBPS),
-		// Device with just wbps
-		fmt.Sprintf("8:0 wbps=%d", ioBPS),
-		// With "max" device syntax
-		fmt.Sprintf("max rbps=%d", ioBPS),
-		// With riops and wiops, operations per second instead of bytes
-		"8:0 riops=1000 wiops=1000",
+	limit := strconv.FormatInt(ioBPS, 10)
+	setting := fmt.Sprintf("%s rbps=%s wbps=%s", c.config.IODevice, limit, limit)
+	if err := c.writeFile(ioMaxPath, []byte(setting)); err != nil {
+		// a device the kernel does not limit means IO limits are not
+		// available on this host rather than that the limit is wrong
+		log.Debug("IO limit refused", "setting", setting, "error", err)
+		return fmt.Errorf("io.max refused %q: %v", setting, err)
+	}
+	if err := verifySetting(ioMaxPath, setting, func(got string) bool {
+		return ioMaxValue(got, c.config.IODevice, "rbps") == limit && ioMaxValue(got, c.config.IODevice, "wbps") == limit
+	}); err != nil {
+		log.Debug("IO limit not applied", "setting", setting, "error", err)
+		return err
 	}
 
-	var lastErr error
-	for _, format := range formats {
-		log.Debug("trying IO limit format", "format", format)
+	log.Info("successfully set IO limit", "setting", setting)
+	return nil
+}
 
-		if e := os.WriteFile(ioMaxPath, []byte(format), 0644); e != nil {
-			log.Debug("IO limit format failed", "format", format, "error", e)
-			lastErr = e
-		} else {
-			log.Info("successfully set IO limit", "format", format)
-			return nil
+// DiskDevice returns the major:minor of the disk holding path, or the nearest
+// of its parents that exists. For a partition it is the whole disk, io.max
+// only limits those.
+func DiskDevice(path string) (string, error) {
+	var st unix.Stat_t
+	for {
+		err := unix.Stat(path, &st)
+		if err == nil {
+			break
 		}
+		if !errors.Is(err, unix.ENOENT) || path == filepath.Dir(path) {
+			return "", fmt.Errorf("failed to stat %s: %w", path, err)
+		}
+		path = filepath.Dir(path)
+	}
+	return diskDevice(sysDevBlock, uint64(unix.Major(uint64(st.Dev))), uint64(unix.Minor(uint64(st.Dev))))
+}
+
+// sysDevBlock links each block device by major:minor to its sysfs directory
+const sysDevBlock = "/sys/dev/block"
+
+// diskDevice returns the whole disk of the block device major:minor, as
+// listed in sysBlock
+func diskDevice(sysBlock string, major, minor uint64) (string, error) {
+	device := fmt.Sprintf("%d:%d", major, minor)
+	dir := filepath.Join(sysBlock, device)
+	if _, err := os.Stat(dir); err != nil {
+		// tmpfs, overlayfs and other filesystems without a disk have no entry
+		return "", fmt.Errorf("device %s is not a block device: %w", device, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "partition")); err != nil {
+		return device, nil
 	}
 
-	log.Debug("all IO limit formats failed", "lastError", lastErr, "triedFormats", len(formats))
-	return fmt.Errorf("all IO limit formats failed, last error: %w", lastErr)
+	// a partition's sysfs directory is in the one of its disk
+	partition, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the disk of partition %s: %w", device, err)
+	}
+	disk, err := os.ReadFile(filepath.Join(filepath.Dir(partition), "dev"))
+	if err != nil {
+		return "", fmt.Errorf("failed to find the disk of partition %s: %w", device, err)
+	}
+	return strings.TrimSpace(string(disk)), nil
 }
 
 // SetCPULimit sets CPU limits for the cgroup
-func (c *cgroup) SetCPULimit(cgroupPath string, cpuLimit int) error {
-	log := c.logger.WithFields("cgroupPath", cgroupPath, "cpuLimit", cpuLimit)
+func (c *cgroup) SetCPULimit(cgroupPath string, cpuMillis int64) error {
+	log := c.logger.WithFields("cgroupPath", cgroupPath, "cpuMillis", cpuMillis)
 
 	// CPU controller files
 	cpuMaxPath := filepath.Join(cgroupPath, "cpu.max")
@@ -255,13 +455,16 @@ func (c *cgroup) SetCPULimit(cgroupPath string, cpuLimit int) error {
 	// Try cpu.max (cgroup v2)
 	if _, err := os.Stat(cpuMaxPath); err == nil {
 		// Format: $MAX $PERIOD
-		// Convert percentage to microseconds: 100% = 100000/100000, 50% = 50000/100000
-		quota := (cpuLimit * 100000) / 100
+		// Convert millicores to microseconds: 1000m = 100000/100000, 500m = 50000/100000
+		quota := cpuMillis * 100
 		limit := fmt.Sprintf("%d 100000", quota)
 
-		if e := os.WriteFile(cpuMaxPath, []byte(limit), 0644); e != nil {
+		if e := c.writeFile(cpuMaxPath, []byte(limit)); e != nil {
 			log.Error("failed to write to cpu.max", "limit", limit, "error", e)
-			return fmt.Errorf("failed to write to cpu.max: %w", e)
+			return e
+		}
+		if e := verifySetting(cpuMaxPath, limit, func(got string) bool { return got == limit }); e != nil {
+			return e
 		}
 		log.Info("set CPU limit with cpu.max", "limit", limit)
 		return nil
@@ -272,9 +475,9 @@ func (c *cgroup) SetCPULimit(cgroupPath string, cpuLimit int) error {
 		// Convert CPU limit to weight (1-10000)
 		// Default weight is 100, so scale accordingly
 		weight := 100 // Default
-		if cpuLimit > 0 {
-			// Scale from typical CPU limit (e.g. 100 = 1 core) to weight range
-			weight = int(100 * (float64(cpuLimit) / 100.0))
+		if cpuMillis > 0 {
+			// Scale from millicores (1000 = 1 core) to weight range
+			weight = int(cpuMillis / 10)
 			if weight < 1 {
 				weight = 1
 			} else if weight > 10000 {
@@ -282,9 +485,13 @@ func (c *cgroup) SetCPULimit(cgroupPath string, cpuLimit int) error {
 			}
 		}
 
-		if e := os.WriteFile(cpuWeightPath, []byte(fmt.Sprintf("%d", weight)), 0644); e != nil {
+		value := strconv.Itoa(weight)
+		if e := c.writeFile(cpuWeightPath, []byte(value)); e != nil {
 			log.Error("failed to write to cpu.weight", "weight", weight, "error", e)
-			return fmt.Errorf("failed to write to cpu.weight: %w", e)
+			return e
+		}
+		if e := verifySetting(cpuWeightPath, value, func(got string) bool { return got == value }); e != nil {
+			return e
 		}
 
 		log.Info("set CPU weight", "weight", weight)
@@ -296,22 +503,23 @@ func (c *cgroup) SetCPULimit(cgroupPath string, cpuLimit int) error {
 }
 
 // SetMemoryLimit sets memory limits for the cgroup
-func (c *cgroup) SetMemoryLimit(cgroupPath string, memoryLimitMB int) error {
-	log := c.logger.WithFields("cgroupPath", cgroupPath, "memoryLimitMB", memoryLimitMB)
-
-	// Convert MB to bytes
-	memoryLimitBytes := int64(memoryLimitMB) * 1024 * 1024
+func (c *cgroup) SetMemoryLimit(cgroupPath string, memoryLimitBytes int64) error {
+	log := c.logger.WithFields("cgroupPath", cgroupPath, "memoryLimitBytes", memoryLimitBytes)
 
 	// Cgroup v2
 	memoryMaxPath := filepath.Join(cgroupPath, "memory.max")
 	memoryHighPath := filepath.Join(cgroupPath, "memory.high")
 
 	var setMax, setHigh bool
+	var refused, notApplied []error
 
 	// Set memory.max hard limit
 	if _, err := os.Stat(memoryMaxPath); err == nil {
-		if e := os.WriteFile(memoryMaxPath, []byte(fmt.Sprintf("%d", memoryLimitBytes)), 0644); e != nil {
+		if e := c.writeFile(memoryMaxPath, []byte(fmt.Sprintf("%d", memoryLimitBytes))); e != nil {
 			log.Warn("failed to write to memory.max", "memoryLimitBytes", memoryLimitBytes, "error", e)
+			refused = append(refused, e)
+		} else if e := verifyMemory(memoryMaxPath, memoryLimitBytes); e != nil {
+			notApplied = append(notApplied, e)
 		} else {
 			setMax = true
 			log.Info("set memory.max limit", "memoryLimitBytes", memoryLimitBytes)
@@ -321,14 +529,23 @@ func (c *cgroup) SetMemoryLimit(cgroupPath string, memoryLimitMB int) error {
 	// Set memory.high soft limit (90% of hard limit)
 	if _, err := os.Stat(memoryHighPath); err == nil {
 		softLimit := int64(float64(memoryLimitBytes) * 0.9)
-		if e := os.WriteFile(memoryHighPath, []byte(fmt.Sprintf("%d", softLimit)), 0644); e != nil {
+		if e := c.writeFile(memoryHighPath, []byte(fmt.Sprintf("%d", softLimit))); e != nil {
 			log.Warn("failed to write to memory.high", "softLimit", softLimit, "error", e)
+			refused = append(refused, e)
+		} else if e := verifyMemory(memoryHighPath, softLimit); e != nil {
+			notApplied = append(notApplied, e)
 		} else {
 			setHigh = true
 			log.Info("set memory.high limit", "softLimit", softLimit)
 		}
 	}
 
+	if len(refused) > 0 {
+		return errors.Join(refused...)
+	}
+	if len(notApplied) > 0 {
+		return errors.Join(notApplied...)
+	}
 	if !setMax && !setHigh {
 		log.Debug("neither memory.max nor memory.high found")
 		return fmt.Errorf("neither memory.max nor memory.high found")
@@ -337,92 +554,260 @@ func (c *cgroup) SetMemoryLimit(cgroupPath string, memoryLimitMB int) error {
 	return nil
 }
 
-// CleanupCgroup deletes a cgroup after removing job processes
-func (c *cgroup) CleanupCgroup(jobID string) {
-	cleanupLogger := c.logger.WithField("jobId", jobID)
-	cleanupLogger.Debug("starting cgroup cleanup with configured timeout",
-		"timeout", c.config.CleanupTimeout)
+// LimitNotAppliedError is returned for a limit the kernel accepted the write
+// of, but reads back as something else: ignored, or clamped to its range
+type LimitNotAppliedError struct {
+	File  string // interface file of the limit, such as cpu.max
+	Wrote string
+	Got   string
+}
+
+func (e *LimitNotAppliedError) Error() string {
+	return fmt.Sprintf("%s reads %q after writing %q", e.File, e.Got, e.Wrote)
+}
+
+// verifySetting reads back the setting written to path, which applied
+// compares to what was written
+func verifySetting(path, wrote string, applied func(got string) bool) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read back %s: %w", filepath.Base(path), err)
+	}
+	got := strings.TrimSpace(string(data))
+	if !applied(got) {
+		return &LimitNotAppliedError{File: filepath.Base(path), Wrote: wrote, Got: got}
+	}
+	return nil
+}
+
+// verifyMemory verifies a memory limit, which the kernel keeps in pages
+func verifyMemory(path string, bytes int64) error {
+	pageSize := int64(os.Getpagesize())
+	want := strconv.FormatInt(bytes/pageSize*pageSize, 10)
+	return verifySetting(path, strconv.FormatInt(bytes, 10), func(got string) bool { return got == want })
+}
+
+// ioMaxValue returns the value of key on the line of device in the content
+// of io.max, "" when the device has no line: io.max lists the devices with
+// a limit, as "8:0 rbps=1048576 wbps=max riops=max wiops=max"
+func ioMaxValue(content, device, key string) string {
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 0 || fields[0] != device {
+			continue
+		}
+		for _, field := range fields[1:] {
+			if value, ok := strings.CutPrefix(field, key+"="); ok {
+				return value
+			}
+		}
+	}
+	return ""
+}
+
+// SetQoS sets the CPU and IO weights and the memory protection of a QoS class.
+// Settings of controllers that are not enabled are skipped; the error lists
+// the settings the kernel rejected.
+func (c *cgroup) SetQoS(cgroupPath string, qos domain.QoSSettings) error {
+	log := c.logger.WithFields("cgroupPath", cgroupPath)
+
+	type setting struct{ file, value string }
+	settings := []setting{
+		{"cpu.weight", strconv.FormatInt(qos.CPUWeight, 10)},
+		// io.weight takes effect with the BFQ scheduler or io.cost, both optional
+		{"io.weight", fmt.Sprintf("default %d", qos.IOWeight)},
+	}
+	if qos.MemoryMin > 0 {
+		settings = append(settings, setting{"memory.min", strconv.FormatInt(qos.MemoryMin, 10)})
+	}
+	if qos.MemoryLow > 0 {
+		settings = append(settings, setting{"memory.low", strconv.FormatInt(qos.MemoryLow, 10)})
+	}
+
+	var failed []string
+	for _, s := range settings {
+		path := filepath.Join(cgroupPath, s.file)
+		if _, err := os.Stat(path); err != nil {
+			log.Debug("QoS setting not available", "file", s.file)
+			continue
+		}
+		if err := c.writeFile(path, []byte(s.value)); err != nil {
+			log.Debug("failed to write QoS setting", "file", s.file, "value", s.value, "error", err)
+			failed = append(failed, s.file)
+			continue
+		}
+		log.Debug("set QoS setting", "file", s.file, "value", s.value)
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("QoS settings not applied: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// settingFiles are the interface files Settings reads, those the worker
+// writes limits and QoS settings to
+var settingFiles = []string{
+	"cpu.max", "cpu.weight",
+	"memory.max", "memory.high", "memory.min", "memory.low",
+	"io.max", "io.weight",
+}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(context.Background(), c.config.CleanupTimeout)
-		defer cancel()
+// Settings returns the content of the setting files of a cgroup, skipping
+// those of controllers not enabled in it
+func (c *cgroup) Settings(cgroupPath string) map[string]string {
+	settings := make(map[string]string, len(settingFiles))
+	for _, file := range settingFiles {
+		data, err := os.ReadFile(filepath.Join(cgroupPath, file))
+		if err != nil {
+			continue
+		}
+		settings[file] = strings.TrimSpace(string(data))
+	}
+	return settings
+}
 
-		done := make(chan bool)
-		go func() {
-			cleanupJobCgroup(jobID, cleanupLogger, &c.config)
-			done <- true
-		}()
+// CleanupCgroup queues a job cgroup for removal by the cleanup pool, waiting
+// while the queue is full. done, when not nil, receives the result.
+func (c *cgroup) CleanupCgroup(jobID string, cgroupPath string, done func(error)) {
+	c.cleanupLogger.Debug("queueing cgroup cleanup", "jobId", jobID, "queued", len(c.cleanups))
+	c.cleanups <- cleanupRequest{jobID: jobID, cgroupPath: cgroupPath, done: done}
+}
 
-		select {
-		case <-done:
-			cleanupLogger.Debug("cgroup cleanup completed within configured timeout")
-		case <-ctx.Done():
-			cleanupLogger.Warn("cgroup cleanup timed out",
-				"configuredTimeout", c.config.CleanupTimeout)
+// cleanupWorker removes queued job cgroups one at a time
+func (c *cgroup) cleanupWorker() {
+	for req := range c.cleanups {
+		cleanupLogger := c.cleanupLogger.WithField("jobId", req.jobID)
+
+		ctx, cancel := context.WithTimeout(context.Background(), c.config.CleanupTimeout)
+		err := c.cleanupJobCgroup(ctx, req.cgroupPath, cleanupLogger)
+		cancel()
+
+		if err != nil {
+			cleanupLogger.Warn("cgroup cleanup failed", "error", err)
+		} else {
+			cleanupLogger.Debug("cgroup cleanup completed")
 		}
-	}()
+		if req.done != nil {
+			req.done(err)
+		}
+	}
 }
 
-// cleanupJobCgroup clean process first SIGTERM and SIGKILL then remove the cgroupPath items
-func cleanupJobCgroup(jobID string, logger *logger.Logger, cfg *config.CgroupConfig) {
-	// Use the delegated cgroup path
-	cgroupPath := filepath.Join(cfg.BaseDir, "job-"+jobID)
+// cleanupJobCgroup kills the processes left in the job cgroup and removes it.
+// Kernels with cgroup.kill kill them all at once; older ones get SIGTERM, then
+// SIGKILL once ctx is done or after a short grace period.
+func (c *cgroup) cleanupJobCgroup(ctx context.Context, cgroupPath string, logger *logger.Logger) error {
+	// Jobs run without a cgroup have none to remove
+	if cgroupPath == "" {
+		return nil
+	}
 	cleanupLogger := logger.WithField("cgroupPath", cgroupPath)
 
 	// Security check: ensure we're only cleaning up within our delegated subtree
-	if !strings.HasPrefix(cgroupPath, cfg.BaseDir+"/job-") {
-		cleanupLogger.Error("security violation: attempted to clean up non-job cgroup", "path", cgroupPath)
-		return
+	if !c.isJobCgroup(cgroupPath) {
+		return fmt.Errorf("refusing to clean up non-job cgroup %s", cgroupPath)
 	}
 
 	// Check if the cgroup exists
 	if _, err := os.Stat(cgroupPath); os.IsNotExist(err) {
 		cleanupLogger.Debug("cgroup directory does not exist, skipping cleanup")
-		return
+		return nil
+	}
+
+	err := c.writeFile(filepath.Join(cgroupPath, "cgroup.kill"), []byte("1"))
+	if err == nil {
+		cleanupLogger.Debug("killed cgroup processes with cgroup.kill")
+		return c.removeCgroupDir(ctx, cgroupPath, cleanupLogger)
+	}
+	if !os.IsNotExist(err) {
+		cleanupLogger.Warn("cgroup.kill failed, signalling processes", "error", err)
 	}
 
 	// Try to kill any processes still in the cgroup
-	procsPath := filepath.Join(cgroupPath, "cgroup.procs")
-	if procsData, err := os.ReadFile(procsPath); err == nil {
-		pids := strings.Split(string(procsData), "\n")
-		activePids := []string{}
+	if pids := cgroupPids(cgroupPath); len(pids) > 0 {
+		cleanupLogger.Debug("terminating processes in cgroup", "pids", pids)
+		signalPids(pids, syscall.SIGTERM)
 
-		for _, pidStr := range pids {
-			if pidStr == "" {
-				continue
-			}
-			activePids = append(activePids, pidStr)
-
-			if pid, e1 := strconv.Atoi(pidStr); e1 == nil {
-				cleanupLogger.Debug("terminating process in cgroup", "pid", pid)
-
-				// Try to terminate the process
-				proc, e2 := os.FindProcess(pid)
-				if e2 == nil {
-					// Try SIGTERM first
-					_ = proc.Signal(syscall.SIGTERM)
-
-					// Wait a moment
-					time.Sleep(100 * time.Millisecond)
-
-					// Then SIGKILL if needed
-					e := proc.Signal(syscall.SIGKILL)
-					if e != nil {
-						return
-					}
-				}
-			}
+		select {
+		case <-ctx.Done():
+		case <-time.After(100 * time.Millisecond):
 		}
 
-		if len(activePids) > 0 {
-			cleanupLogger.Debug("terminated processes in cgroup", "pids", activePids)
+		signalPids(cgroupPids(cgroupPath), syscall.SIGKILL)
+	}
+
+	return cgroupPathRemoveAll(cgroupPath, cleanupLogger)
+}
+
+// removeCgroupDir removes an emptied cgroup, retrying with backoff while the
+// kernel still reports it busy because killed processes have not exited yet.
+// A cgroup stays busy while it has child cgroups, such as those a job
+// delegating its cgroup created, so they are killed and removed first,
+// depth-first: a process moved into a child after its parent was killed
+// would keep it busy.
+func (c *cgroup) removeCgroupDir(ctx context.Context, cgroupPath string, logger *logger.Logger) error {
+	entries, err := os.ReadDir(cgroupPath)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to list child cgroups: %w", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		child := filepath.Join(cgroupPath, entry.Name())
+		if err := c.writeFile(filepath.Join(child, "cgroup.kill"), []byte("1")); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.Warn("cgroup.kill failed for child cgroup", "path", child, "error", err)
+		}
+		if err := c.removeCgroupDir(ctx, child, logger); err != nil {
+			return err
 		}
 	}
 
-	cgroupPathRemoveAll(cgroupPath, cleanupLogger)
+	backoff := 5 * time.Millisecond
+	for attempt := 1; ; attempt++ {
+		err := os.Remove(cgroupPath)
+		if err == nil || os.IsNotExist(err) {
+			logger.Debug("removed cgroup directory", "path", cgroupPath, "attempts", attempt)
+			return nil
+		}
+		if !errors.Is(err, syscall.EBUSY) {
+			return fmt.Errorf("failed to remove cgroup directory: %w", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("cgroup still busy after %d attempts: %w", attempt, err)
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, 500*time.Millisecond)
+	}
+}
+
+// cgroupPids lists the processes in a cgroup, nil if it cannot be read
+func cgroupPids(cgroupPath string) []int {
+	procsData, err := os.ReadFile(filepath.Join(cgroupPath, "cgroup.procs"))
+	if err != nil {
+		return nil
+	}
+
+	var pids []int
+	for _, pidStr := range strings.Fields(string(procsData)) {
+		if pid, err := strconv.Atoi(pidStr); err == nil {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
 }
 
-func cgroupPathRemoveAll(cgroupPath string, logger *logger.Logger) {
+func signalPids(pids []int, sig syscall.Signal) {
+	for _, pid := range pids {
+		// processes that already exited are simply gone
+		_ = syscall.Kill(pid, sig)
+	}
+}
+
+func cgroupPathRemoveAll(cgroupPath string, logger *logger.Logger) error {
 	if err := os.RemoveAll(cgroupPath); err != nil {
 		logger.Warn("failed to remove cgroup directory", "error", err)
 
@@ -448,11 +833,11 @@ func cgroupPathRemoveAll(cgroupPath string, logger *logger.Logger) {
 
 		// Try to remove the directory again
 		if e := os.Remove(cgroupPath); e != nil {
-			logger.Debug("could not remove cgroup directory completely, will be cleaned up later", "error", e)
-		} else {
-			logger.Debug("successfully removed cgroup directory on retry")
+			return fmt.Errorf("failed to remove cgroup directory: %w", e)
 		}
+		logger.Debug("successfully removed cgroup directory on retry")
 	} else {
 		logger.Debug("successfully removed cgroup directory")
 	}
+	return nil
 }
@@ -0,0 +1,237 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+	"worker/pkg/platform"
+)
+
+func TestRemoveCgroupDirRemovesChildCgroups(t *testing.T) {
+	cgroupPath := filepath.Join(t.TempDir(), "job-1")
+	for _, child := range []string{"app/worker-a", "app/worker-b", "sidecar"} {
+		if err := os.MkdirAll(filepath.Join(cgroupPath, child), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c := &cgroup{write: func(string, []byte, os.FileMode) error { return nil }}
+	if err := c.removeCgroupDir(ctx, cgroupPath, logger.New()); err != nil {
+		t.Fatalf("removeCgroupDir() error = %v", err)
+	}
+	if _, err := os.Stat(cgroupPath); !os.IsNotExist(err) {
+		t.Errorf("expected the cgroup and its children removed, got %v", err)
+	}
+}
+
+func TestCleanupJobCgroupKillsBeforeRemoving(t *testing.T) {
+	baseDir := t.TempDir()
+	cgroupPath := filepath.Join(baseDir, "job-1")
+	if err := os.MkdirAll(filepath.Join(cgroupPath, "app", "worker-a"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// a kill file is not written to the directory, which could not be
+	// removed with it, but recorded with whether its cgroup still existed
+	var kills []string
+	c := &cgroup{config: config.CgroupConfig{BaseDir: baseDir}, write: func(path string, data []byte, _ os.FileMode) error {
+		if filepath.Base(path) != "cgroup.kill" || string(data) != "1" {
+			t.Errorf("unexpected write of %q to %s", data, path)
+		}
+		if _, err := os.Stat(filepath.Dir(path)); err != nil {
+			t.Errorf("%s killed after its cgroup was removed", path)
+		}
+		rel, _ := filepath.Rel(baseDir, filepath.Dir(path))
+		kills = append(kills, rel)
+		return nil
+	}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := c.cleanupJobCgroup(ctx, cgroupPath, logger.New()); err != nil {
+		t.Fatalf("cleanupJobCgroup() error = %v", err)
+	}
+
+	want := []string{"job-1", "job-1/app", "job-1/app/worker-a"}
+	if !slices.Equal(kills, want) {
+		t.Errorf("killed %v, want %v", kills, want)
+	}
+	if _, err := os.Stat(cgroupPath); !os.IsNotExist(err) {
+		t.Errorf("expected the cgroup and its children removed, got %v", err)
+	}
+}
+
+func TestRemoveCgroupDirMissing(t *testing.T) {
+	c := &cgroup{write: os.WriteFile}
+	if err := c.removeCgroupDir(context.Background(), filepath.Join(t.TempDir(), "gone"), logger.New()); err != nil {
+		t.Errorf("expected a missing cgroup to be removed already, got %v", err)
+	}
+}
+
+func TestIOMaxValue(t *testing.T) {
+	content := "8:0 rbps=1048576 wbps=max riops=max wiops=max\n259:0 rbps=max wbps=2097152 riops=max wiops=max\n"
+
+	tests := []struct {
+		name    string
+		content string
+		device  string
+		key     string
+		want    string
+	}{
+		{name: "limit", content: content, device: "8:0", key: "rbps", want: "1048576"},
+		{name: "unlimited", content: content, device: "8:0", key: "wbps", want: "max"},
+		{name: "second device", content: content, device: "259:0", key: "wbps", want: "2097152"},
+		{name: "device without a line", content: content, device: "8:16", key: "rbps"},
+		{name: "device prefix", content: content, device: "8:", key: "rbps"},
+		{name: "key prefix", content: content, device: "8:0", key: "bps"},
+		{name: "empty", device: "8:0", key: "rbps"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ioMaxValue(tt.content, tt.device, tt.key); got != tt.want {
+				t.Errorf("ioMaxValue() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerifySetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cpu.max")
+	if err := os.WriteFile(path, []byte("max 100000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := verifySetting(path, "max 100000", func(got string) bool { return got == "max 100000" }); err != nil {
+		t.Errorf("expected the setting applied, got %v", err)
+	}
+
+	err := verifySetting(path, "50000 100000", func(got string) bool { return got == "50000 100000" })
+	var notApplied *LimitNotAppliedError
+	if !errors.As(err, &notApplied) || notApplied.File != "cpu.max" || notApplied.Wrote != "50000 100000" || notApplied.Got != "max 100000" {
+		t.Errorf("expected a limit not applied error, got %v", err)
+	}
+
+	err = verifySetting(filepath.Join(t.TempDir(), "gone"), "1", func(string) bool { return true })
+	if err == nil || errors.As(err, &notApplied) {
+		t.Errorf("expected a read error, got %v", err)
+	}
+}
+
+func TestVerifyMemory(t *testing.T) {
+	pageSize := int64(os.Getpagesize())
+	tests := []struct {
+		name    string
+		bytes   int64
+		content string
+		applied bool
+	}{
+		{name: "page aligned", bytes: 256 * pageSize, content: strconv.FormatInt(256*pageSize, 10), applied: true},
+		{name: "rounded down to a page", bytes: 256*pageSize + 1, content: strconv.FormatInt(256*pageSize, 10), applied: true},
+		{name: "clamped", bytes: 256 * pageSize, content: strconv.FormatInt(128*pageSize, 10)},
+		{name: "ignored", bytes: 256 * pageSize, content: "max"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "memory.max")
+			if err := os.WriteFile(path, []byte(tt.content+"\n"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			err := verifyMemory(path, tt.bytes)
+			var notApplied *LimitNotAppliedError
+			if tt.applied && err != nil || !tt.applied && !errors.As(err, &notApplied) {
+				t.Errorf("verifyMemory() = %v, applied %v", err, tt.applied)
+			}
+		})
+	}
+}
+
+func TestSetIOLimit(t *testing.T) {
+	newCgroup := func(t *testing.T, device string, faults *platform.Faults) (*cgroup, string) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "io.max"), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return &cgroup{logger: logger.New(), config: config.CgroupConfig{IODevice: device}, faults: faults, write: os.WriteFile}, dir
+	}
+
+	// reads and writes are both limited, on the configured device
+	c, dir := newCgroup(t, "259:0", nil)
+	if err := c.SetIOLimit(dir, 1048576); err != nil {
+		t.Fatalf("SetIOLimit() error = %v", err)
+	}
+	if got, _ := os.ReadFile(filepath.Join(dir, "io.max")); string(got) != "259:0 rbps=1048576 wbps=1048576" {
+		t.Errorf("unexpected io.max %q", got)
+	}
+
+	c, dir = newCgroup(t, "", nil)
+	if err := c.SetIOLimit(dir, 1048576); err == nil {
+		t.Error("expected an error without a device to limit")
+	}
+
+	// a write the kernel refuses is not a limit it ignored
+	c, dir = newCgroup(t, "259:0", platform.NewFaults(config.FaultInjectionConfig{Enabled: true, Seed: 1, CgroupWriteFailRate: 1}))
+	err := c.SetIOLimit(dir, 1048576)
+	var notApplied *LimitNotAppliedError
+	if err == nil || errors.As(err, &notApplied) {
+		t.Errorf("expected the refused write reported, got %v", err)
+	}
+}
+
+func TestDiskDevice(t *testing.T) {
+	sys := t.TempDir()
+	disk := filepath.Join(sys, "block", "nvme0n1")
+	partition := filepath.Join(disk, "nvme0n1p1")
+	if err := os.MkdirAll(partition, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for path, content := range map[string]string{
+		filepath.Join(disk, "dev"):            "259:0\n",
+		filepath.Join(partition, "dev"):       "259:1\n",
+		filepath.Join(partition, "partition"): "1\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	devBlock := filepath.Join(sys, "dev", "block")
+	if err := os.MkdirAll(devBlock, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(disk, filepath.Join(devBlock, "259:0")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(partition, filepath.Join(devBlock, "259:1")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		major, minor uint64
+		want         string
+		wantErr      bool
+	}{
+		{name: "disk", major: 259, minor: 0, want: "259:0"},
+		{name: "partition", major: 259, minor: 1, want: "259:0"},
+		{name: "no block device", major: 0, minor: 42, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := diskDevice(devBlock, tt.major, tt.minor)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("diskDevice() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -4,21 +4,24 @@ package resourcefakes
 import (
 	"sync"
 	"worker/internal/worker/core/linux/resource"
+	"worker/internal/worker/domain"
 )
 
 type FakeResource struct {
-	CleanupCgroupStub        func(string)
+	CleanupCgroupStub        func(string, string, func(error))
 	cleanupCgroupMutex       sync.RWMutex
 	cleanupCgroupArgsForCall []struct {
 		arg1 string
+		arg2 string
+		arg3 func(error)
 	}
-	CreateStub        func(string, int32, int32, int32) error
+	CreateStub        func(string, int64, int64, int64) error
 	createMutex       sync.RWMutex
 	createArgsForCall []struct {
 		arg1 string
-		arg2 int32
-		arg3 int32
-		arg4 int32
+		arg2 int64
+		arg3 int64
+		arg4 int64
 	}
 	createReturns struct {
 		result1 error
@@ -26,6 +29,20 @@ type FakeResource struct {
 	createReturnsOnCall map[int]struct {
 		result1 error
 	}
+	CreateGroupStub        func(string, int64, int64, int64) error
+	createGroupMutex       sync.RWMutex
+	createGroupArgsForCall []struct {
+		arg1 string
+		arg2 int64
+		arg3 int64
+		arg4 int64
+	}
+	createGroupReturns struct {
+		result1 error
+	}
+	createGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
 	EnsureControllersStub        func() error
 	ensureControllersMutex       sync.RWMutex
 	ensureControllersArgsForCall []struct {
@@ -36,11 +53,22 @@ type FakeResource struct {
 	ensureControllersReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetCPULimitStub        func(string, int) error
+	RemoveGroupStub        func(string) error
+	removeGroupMutex       sync.RWMutex
+	removeGroupArgsForCall []struct {
+		arg1 string
+	}
+	removeGroupReturns struct {
+		result1 error
+	}
+	removeGroupReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SetCPULimitStub        func(string, int64) error
 	setCPULimitMutex       sync.RWMutex
 	setCPULimitArgsForCall []struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}
 	setCPULimitReturns struct {
 		result1 error
@@ -48,11 +76,11 @@ type FakeResource struct {
 	setCPULimitReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetIOLimitStub        func(string, int) error
+	SetIOLimitStub        func(string, int64) error
 	setIOLimitMutex       sync.RWMutex
 	setIOLimitArgsForCall []struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}
 	setIOLimitReturns struct {
 		result1 error
@@ -60,11 +88,11 @@ type FakeResource struct {
 	setIOLimitReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SetMemoryLimitStub        func(string, int) error
+	SetMemoryLimitStub        func(string, int64) error
 	setMemoryLimitMutex       sync.RWMutex
 	setMemoryLimitArgsForCall []struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}
 	setMemoryLimitReturns struct {
 		result1 error
@@ -72,20 +100,45 @@ type FakeResource struct {
 	setMemoryLimitReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetQoSStub        func(string, domain.QoSSettings) error
+	setQoSMutex       sync.RWMutex
+	setQoSArgsForCall []struct {
+		arg1 string
+		arg2 domain.QoSSettings
+	}
+	setQoSReturns struct {
+		result1 error
+	}
+	setQoSReturnsOnCall map[int]struct {
+		result1 error
+	}
+	SettingsStub        func(string) map[string]string
+	settingsMutex       sync.RWMutex
+	settingsArgsForCall []struct {
+		arg1 string
+	}
+	settingsReturns struct {
+		result1 map[string]string
+	}
+	settingsReturnsOnCall map[int]struct {
+		result1 map[string]string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeResource) CleanupCgroup(arg1 string) {
+func (fake *FakeResource) CleanupCgroup(arg1 string, arg2 string, arg3 func(error)) {
 	fake.cleanupCgroupMutex.Lock()
 	fake.cleanupCgroupArgsForCall = append(fake.cleanupCgroupArgsForCall, struct {
 		arg1 string
-	}{arg1})
+		arg2 string
+		arg3 func(error)
+	}{arg1, arg2, arg3})
 	stub := fake.CleanupCgroupStub
-	fake.recordInvocation("CleanupCgroup", []interface{}{arg1})
+	fake.recordInvocation("CleanupCgroup", []interface{}{arg1, arg2, arg3})
 	fake.cleanupCgroupMutex.Unlock()
 	if stub != nil {
-		fake.CleanupCgroupStub(arg1)
+		fake.CleanupCgroupStub(arg1, arg2, arg3)
 	}
 }
 
@@ -95,27 +148,27 @@ func (fake *FakeResource) CleanupCgroupCallCount() int {
 	return len(fake.cleanupCgroupArgsForCall)
 }
 
-func (fake *FakeResource) CleanupCgroupCalls(stub func(string)) {
+func (fake *FakeResource) CleanupCgroupCalls(stub func(string, string, func(error))) {
 	fake.cleanupCgroupMutex.Lock()
 	defer fake.cleanupCgroupMutex.Unlock()
 	fake.CleanupCgroupStub = stub
 }
 
-func (fake *FakeResource) CleanupCgroupArgsForCall(i int) string {
+func (fake *FakeResource) CleanupCgroupArgsForCall(i int) (string, string, func(error)) {
 	fake.cleanupCgroupMutex.RLock()
 	defer fake.cleanupCgroupMutex.RUnlock()
 	argsForCall := fake.cleanupCgroupArgsForCall[i]
-	return argsForCall.arg1
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakeResource) Create(arg1 string, arg2 int32, arg3 int32, arg4 int32) error {
+func (fake *FakeResource) Create(arg1 string, arg2 int64, arg3 int64, arg4 int64) error {
 	fake.createMutex.Lock()
 	ret, specificReturn := fake.createReturnsOnCall[len(fake.createArgsForCall)]
 	fake.createArgsForCall = append(fake.createArgsForCall, struct {
 		arg1 string
-		arg2 int32
-		arg3 int32
-		arg4 int32
+		arg2 int64
+		arg3 int64
+		arg4 int64
 	}{arg1, arg2, arg3, arg4})
 	stub := fake.CreateStub
 	fakeReturns := fake.createReturns
@@ -136,13 +189,13 @@ func (fake *FakeResource) CreateCallCount() int {
 	return len(fake.createArgsForCall)
 }
 
-func (fake *FakeResource) CreateCalls(stub func(string, int32, int32, int32) error) {
+func (fake *FakeResource) CreateCalls(stub func(string, int64, int64, int64) error) {
 	fake.createMutex.Lock()
 	defer fake.createMutex.Unlock()
 	fake.CreateStub = stub
 }
 
-func (fake *FakeResource) CreateArgsForCall(i int) (string, int32, int32, int32) {
+func (fake *FakeResource) CreateArgsForCall(i int) (string, int64, int64, int64) {
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
 	argsForCall := fake.createArgsForCall[i]
@@ -172,6 +225,70 @@ func (fake *FakeResource) CreateReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) CreateGroup(arg1 string, arg2 int64, arg3 int64, arg4 int64) error {
+	fake.createGroupMutex.Lock()
+	ret, specificReturn := fake.createGroupReturnsOnCall[len(fake.createGroupArgsForCall)]
+	fake.createGroupArgsForCall = append(fake.createGroupArgsForCall, struct {
+		arg1 string
+		arg2 int64
+		arg3 int64
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.CreateGroupStub
+	fakeReturns := fake.createGroupReturns
+	fake.recordInvocation("CreateGroup", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) CreateGroupCallCount() int {
+	fake.createGroupMutex.RLock()
+	defer fake.createGroupMutex.RUnlock()
+	return len(fake.createGroupArgsForCall)
+}
+
+func (fake *FakeResource) CreateGroupCalls(stub func(string, int64, int64, int64) error) {
+	fake.createGroupMutex.Lock()
+	defer fake.createGroupMutex.Unlock()
+	fake.CreateGroupStub = stub
+}
+
+func (fake *FakeResource) CreateGroupArgsForCall(i int) (string, int64, int64, int64) {
+	fake.createGroupMutex.RLock()
+	defer fake.createGroupMutex.RUnlock()
+	argsForCall := fake.createGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeResource) CreateGroupReturns(result1 error) {
+	fake.createGroupMutex.Lock()
+	defer fake.createGroupMutex.Unlock()
+	fake.CreateGroupStub = nil
+	fake.createGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) CreateGroupReturnsOnCall(i int, result1 error) {
+	fake.createGroupMutex.Lock()
+	defer fake.createGroupMutex.Unlock()
+	fake.CreateGroupStub = nil
+	if fake.createGroupReturnsOnCall == nil {
+		fake.createGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeResource) EnsureControllers() error {
 	fake.ensureControllersMutex.Lock()
 	ret, specificReturn := fake.ensureControllersReturnsOnCall[len(fake.ensureControllersArgsForCall)]
@@ -225,12 +342,73 @@ func (fake *FakeResource) EnsureControllersReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) SetCPULimit(arg1 string, arg2 int) error {
+func (fake *FakeResource) RemoveGroup(arg1 string) error {
+	fake.removeGroupMutex.Lock()
+	ret, specificReturn := fake.removeGroupReturnsOnCall[len(fake.removeGroupArgsForCall)]
+	fake.removeGroupArgsForCall = append(fake.removeGroupArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.RemoveGroupStub
+	fakeReturns := fake.removeGroupReturns
+	fake.recordInvocation("RemoveGroup", []interface{}{arg1})
+	fake.removeGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) RemoveGroupCallCount() int {
+	fake.removeGroupMutex.RLock()
+	defer fake.removeGroupMutex.RUnlock()
+	return len(fake.removeGroupArgsForCall)
+}
+
+func (fake *FakeResource) RemoveGroupCalls(stub func(string) error) {
+	fake.removeGroupMutex.Lock()
+	defer fake.removeGroupMutex.Unlock()
+	fake.RemoveGroupStub = stub
+}
+
+func (fake *FakeResource) RemoveGroupArgsForCall(i int) string {
+	fake.removeGroupMutex.RLock()
+	defer fake.removeGroupMutex.RUnlock()
+	argsForCall := fake.removeGroupArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) RemoveGroupReturns(result1 error) {
+	fake.removeGroupMutex.Lock()
+	defer fake.removeGroupMutex.Unlock()
+	fake.RemoveGroupStub = nil
+	fake.removeGroupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) RemoveGroupReturnsOnCall(i int, result1 error) {
+	fake.removeGroupMutex.Lock()
+	defer fake.removeGroupMutex.Unlock()
+	fake.RemoveGroupStub = nil
+	if fake.removeGroupReturnsOnCall == nil {
+		fake.removeGroupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeGroupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) SetCPULimit(arg1 string, arg2 int64) error {
 	fake.setCPULimitMutex.Lock()
 	ret, specificReturn := fake.setCPULimitReturnsOnCall[len(fake.setCPULimitArgsForCall)]
 	fake.setCPULimitArgsForCall = append(fake.setCPULimitArgsForCall, struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}{arg1, arg2})
 	stub := fake.SetCPULimitStub
 	fakeReturns := fake.setCPULimitReturns
@@ -251,13 +429,13 @@ func (fake *FakeResource) SetCPULimitCallCount() int {
 	return len(fake.setCPULimitArgsForCall)
 }
 
-func (fake *FakeResource) SetCPULimitCalls(stub func(string, int) error) {
+func (fake *FakeResource) SetCPULimitCalls(stub func(string, int64) error) {
 	fake.setCPULimitMutex.Lock()
 	defer fake.setCPULimitMutex.Unlock()
 	fake.SetCPULimitStub = stub
 }
 
-func (fake *FakeResource) SetCPULimitArgsForCall(i int) (string, int) {
+func (fake *FakeResource) SetCPULimitArgsForCall(i int) (string, int64) {
 	fake.setCPULimitMutex.RLock()
 	defer fake.setCPULimitMutex.RUnlock()
 	argsForCall := fake.setCPULimitArgsForCall[i]
@@ -287,12 +465,12 @@ func (fake *FakeResource) SetCPULimitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) SetIOLimit(arg1 string, arg2 int) error {
+func (fake *FakeResource) SetIOLimit(arg1 string, arg2 int64) error {
 	fake.setIOLimitMutex.Lock()
 	ret, specificReturn := fake.setIOLimitReturnsOnCall[len(fake.setIOLimitArgsForCall)]
 	fake.setIOLimitArgsForCall = append(fake.setIOLimitArgsForCall, struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}{arg1, arg2})
 	stub := fake.SetIOLimitStub
 	fakeReturns := fake.setIOLimitReturns
@@ -313,13 +491,13 @@ func (fake *FakeResource) SetIOLimitCallCount() int {
 	return len(fake.setIOLimitArgsForCall)
 }
 
-func (fake *FakeResource) SetIOLimitCalls(stub func(string, int) error) {
+func (fake *FakeResource) SetIOLimitCalls(stub func(string, int64) error) {
 	fake.setIOLimitMutex.Lock()
 	defer fake.setIOLimitMutex.Unlock()
 	fake.SetIOLimitStub = stub
 }
 
-func (fake *FakeResource) SetIOLimitArgsForCall(i int) (string, int) {
+func (fake *FakeResource) SetIOLimitArgsForCall(i int) (string, int64) {
 	fake.setIOLimitMutex.RLock()
 	defer fake.setIOLimitMutex.RUnlock()
 	argsForCall := fake.setIOLimitArgsForCall[i]
@@ -349,12 +527,12 @@ func (fake *FakeResource) SetIOLimitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeResource) SetMemoryLimit(arg1 string, arg2 int) error {
+func (fake *FakeResource) SetMemoryLimit(arg1 string, arg2 int64) error {
 	fake.setMemoryLimitMutex.Lock()
 	ret, specificReturn := fake.setMemoryLimitReturnsOnCall[len(fake.setMemoryLimitArgsForCall)]
 	fake.setMemoryLimitArgsForCall = append(fake.setMemoryLimitArgsForCall, struct {
 		arg1 string
-		arg2 int
+		arg2 int64
 	}{arg1, arg2})
 	stub := fake.SetMemoryLimitStub
 	fakeReturns := fake.setMemoryLimitReturns
@@ -375,13 +553,13 @@ func (fake *FakeResource) SetMemoryLimitCallCount() int {
 	return len(fake.setMemoryLimitArgsForCall)
 }
 
-func (fake *FakeResource) SetMemoryLimitCalls(stub func(string, int) error) {
+func (fake *FakeResource) SetMemoryLimitCalls(stub func(string, int64) error) {
 	fake.setMemoryLimitMutex.Lock()
 	defer fake.setMemoryLimitMutex.Unlock()
 	fake.SetMemoryLimitStub = stub
 }
 
-func (fake *FakeResource) SetMemoryLimitArgsForCall(i int) (string, int) {
+func (fake *FakeResource) SetMemoryLimitArgsForCall(i int) (string, int64) {
 	fake.setMemoryLimitMutex.RLock()
 	defer fake.setMemoryLimitMutex.RUnlock()
 	argsForCall := fake.setMemoryLimitArgsForCall[i]
@@ -411,6 +589,129 @@ func (fake *FakeResource) SetMemoryLimitReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeResource) SetQoS(arg1 string, arg2 domain.QoSSettings) error {
+	fake.setQoSMutex.Lock()
+	ret, specificReturn := fake.setQoSReturnsOnCall[len(fake.setQoSArgsForCall)]
+	fake.setQoSArgsForCall = append(fake.setQoSArgsForCall, struct {
+		arg1 string
+		arg2 domain.QoSSettings
+	}{arg1, arg2})
+	stub := fake.SetQoSStub
+	fakeReturns := fake.setQoSReturns
+	fake.recordInvocation("SetQoS", []interface{}{arg1, arg2})
+	fake.setQoSMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) SetQoSCallCount() int {
+	fake.setQoSMutex.RLock()
+	defer fake.setQoSMutex.RUnlock()
+	return len(fake.setQoSArgsForCall)
+}
+
+func (fake *FakeResource) SetQoSCalls(stub func(string, domain.QoSSettings) error) {
+	fake.setQoSMutex.Lock()
+	defer fake.setQoSMutex.Unlock()
+	fake.SetQoSStub = stub
+}
+
+func (fake *FakeResource) SetQoSArgsForCall(i int) (string, domain.QoSSettings) {
+	fake.setQoSMutex.RLock()
+	defer fake.setQoSMutex.RUnlock()
+	argsForCall := fake.setQoSArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeResource) SetQoSReturns(result1 error) {
+	fake.setQoSMutex.Lock()
+	defer fake.setQoSMutex.Unlock()
+	fake.SetQoSStub = nil
+	fake.setQoSReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) SetQoSReturnsOnCall(i int, result1 error) {
+	fake.setQoSMutex.Lock()
+	defer fake.setQoSMutex.Unlock()
+	fake.SetQoSStub = nil
+	if fake.setQoSReturnsOnCall == nil {
+		fake.setQoSReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setQoSReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeResource) Settings(arg1 string) map[string]string {
+	fake.settingsMutex.Lock()
+	ret, specificReturn := fake.settingsReturnsOnCall[len(fake.settingsArgsForCall)]
+	fake.settingsArgsForCall = append(fake.settingsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.SettingsStub
+	fakeReturns := fake.settingsReturns
+	fake.recordInvocation("Settings", []interface{}{arg1})
+	fake.settingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeResource) SettingsCallCount() int {
+	fake.settingsMutex.RLock()
+	defer fake.settingsMutex.RUnlock()
+	return len(fake.settingsArgsForCall)
+}
+
+func (fake *FakeResource) SettingsCalls(stub func(string) map[string]string) {
+	fake.settingsMutex.Lock()
+	defer fake.settingsMutex.Unlock()
+	fake.SettingsStub = stub
+}
+
+func (fake *FakeResource) SettingsArgsForCall(i int) string {
+	fake.settingsMutex.RLock()
+	defer fake.settingsMutex.RUnlock()
+	argsForCall := fake.settingsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeResource) SettingsReturns(result1 map[string]string) {
+	fake.settingsMutex.Lock()
+	defer fake.settingsMutex.Unlock()
+	fake.SettingsStub = nil
+	fake.settingsReturns = struct {
+		result1 map[string]string
+	}{result1}
+}
+
+func (fake *FakeResource) SettingsReturnsOnCall(i int, result1 map[string]string) {
+	fake.settingsMutex.Lock()
+	defer fake.settingsMutex.Unlock()
+	fake.SettingsStub = nil
+	if fake.settingsReturnsOnCall == nil {
+		fake.settingsReturnsOnCall = make(map[int]struct {
+			result1 map[string]string
+		})
+	}
+	fake.settingsReturnsOnCall[i] = struct {
+		result1 map[string]string
+	}{result1}
+}
+
 func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -418,14 +719,22 @@ func (fake *FakeResource) Invocations() map[string][][]interface{} {
 	defer fake.cleanupCgroupMutex.RUnlock()
 	fake.createMutex.RLock()
 	defer fake.createMutex.RUnlock()
+	fake.createGroupMutex.RLock()
+	defer fake.createGroupMutex.RUnlock()
 	fake.ensureControllersMutex.RLock()
 	defer fake.ensureControllersMutex.RUnlock()
+	fake.removeGroupMutex.RLock()
+	defer fake.removeGroupMutex.RUnlock()
 	fake.setCPULimitMutex.RLock()
 	defer fake.setCPULimitMutex.RUnlock()
 	fake.setIOLimitMutex.RLock()
 	defer fake.setIOLimitMutex.RUnlock()
 	fake.setMemoryLimitMutex.RLock()
 	defer fake.setMemoryLimitMutex.RUnlock()
+	fake.setQoSMutex.RLock()
+	defer fake.setQoSMutex.RUnlock()
+	fake.settingsMutex.RLock()
+	defer fake.settingsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
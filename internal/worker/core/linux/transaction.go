@@ -0,0 +1,67 @@
+//go:build linux
+
+package linux
+
+import (
+	"context"
+	"fmt"
+	"worker/pkg/logger"
+)
+
+// launchStep is a resource a launch created, and how to remove it again
+type launchStep struct {
+	name string
+	undo func()
+}
+
+// launchTransaction records the resources a job launch creates, so a launch
+// failing or abandoned part way removes every one of them, last first, and
+// no failure path leaks a cgroup, workspace or file. Commit hands them to the
+// job, whose monitor removes them when it ends.
+type launchTransaction struct {
+	jobID     string
+	steps     []launchStep
+	committed bool
+	logger    *logger.Logger
+}
+
+func (w *Worker) beginLaunch(jobID string) *launchTransaction {
+	return &launchTransaction{
+		jobID:  jobID,
+		logger: w.logger.WithFields("jobID", jobID, "component", "launch"),
+	}
+}
+
+// Created records a resource the launch created, with how to remove it
+func (tx *launchTransaction) Created(name string, undo func()) {
+	tx.steps = append(tx.steps, launchStep{name: name, undo: undo})
+}
+
+// Commit keeps everything the launch created
+func (tx *launchTransaction) Commit() {
+	tx.committed = true
+}
+
+// Rollback removes what the launch created, unless it was committed. It is
+// deferred by the launch, so it runs on every return.
+func (tx *launchTransaction) Rollback(cause error) {
+	if tx.committed || len(tx.steps) == 0 {
+		return
+	}
+
+	tx.logger.Debug("rolling back job launch", "steps", len(tx.steps), "error", cause)
+	for i := len(tx.steps) - 1; i >= 0; i-- {
+		tx.logger.Debug("undoing launch step", "step", tx.steps[i].name)
+		tx.steps[i].undo()
+	}
+	tx.steps = nil
+}
+
+// launchWanted fails a launch whose request was cancelled or ran past its
+// deadline before the given step
+func launchWanted(ctx context.Context, step string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("job launch abandoned before %s: %w", step, err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+//go:build linux
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"worker/pkg/logger"
+)
+
+func TestLaunchTransaction(t *testing.T) {
+	tests := []struct {
+		name   string
+		steps  []string
+		commit bool
+		want   []string // undone, in order
+	}{
+		{name: "rollback undoes last first", steps: []string{"cgroup", "workspace", "capture"}, want: []string{"capture", "workspace", "cgroup"}},
+		{name: "commit keeps everything", steps: []string{"cgroup", "workspace"}, commit: true},
+		{name: "nothing created", steps: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Worker{logger: logger.New()}
+			tx := w.beginLaunch("1")
+
+			var undone []string
+			for _, step := range tt.steps {
+				tx.Created(step, func() { undone = append(undone, step) })
+			}
+			if tt.commit {
+				tx.Commit()
+			}
+
+			// deferred rollbacks may run more than once, undoing once
+			tx.Rollback(errors.New("launch failed"))
+			tx.Rollback(errors.New("launch failed"))
+
+			if !reflect.DeepEqual(undone, tt.want) {
+				t.Errorf("undone %v, want %v", undone, tt.want)
+			}
+		})
+	}
+}
+
+func TestLaunchWanted(t *testing.T) {
+	if err := launchWanted(context.Background(), "cgroup"); err != nil {
+		t.Errorf("expected a live launch to go on, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := launchWanted(ctx, "cgroup"); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected an abandoned launch to fail, got %v", err)
+	}
+}
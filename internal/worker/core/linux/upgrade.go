@@ -0,0 +1,339 @@
+//go:build linux
+
+package linux
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/upgrade"
+	"worker/pkg/platform"
+)
+
+// HandOff prepares the running jobs to be handed to a new worker binary: it
+// stops launches and exit handling, pauses copying the jobs' output and
+// returns their processes by job ID. Unless the exec that follows replaces
+// the worker, release must be called to carry on.
+//
+// A job waiting for a restart, held in a crash loop or streaming inputs in
+// cannot be handed off, and fails the hand-off.
+func (w *Worker) HandOff() (map[string]*upgrade.Process, func(), error) {
+	w.handoffMu.Lock()
+	if w.initPool != nil {
+		// the exec would leave them behind
+		w.initPool.drain()
+	}
+
+	w.runsMu.Lock()
+	runs := slices.Collect(maps.Values(w.runs))
+	w.runsMu.Unlock()
+
+	processes := make(map[string]*upgrade.Process, len(runs))
+	var paused []*jobRun
+	release := func() {
+		for _, p := range processes {
+			if p.Pidfd >= 0 {
+				_ = unix.Close(p.Pidfd)
+			}
+		}
+		for _, run := range paused {
+			run.pipes.resume()
+		}
+		w.handoffMu.Unlock()
+		if w.initPool != nil {
+			w.initPool.requestRefill()
+		}
+	}
+
+	for _, run := range runs {
+		select {
+		case <-run.finished:
+			continue
+		default:
+		}
+
+		if err := run.checkHandOff(); err != nil {
+			release()
+			return nil, nil, fmt.Errorf("job %s %w", run.job.Id, err)
+		}
+
+		pid := run.cmd.Process().Pid()
+		pidfd, err := unix.PidfdOpen(pid, 0)
+		switch {
+		case errors.Is(err, unix.ESRCH):
+			// exited and reaped, its monitor waits for the hand-off to end
+			pidfd = -1
+		case err != nil:
+			release()
+			return nil, nil, fmt.Errorf("failed to open a pidfd for job %s: %w", run.job.Id, err)
+		}
+
+		stdout, stderr := run.pipes.pause()
+		paused = append(paused, run)
+		run.output.Flush()
+
+		processes[run.job.Id] = &upgrade.Process{
+			Pid:     pid,
+			Pidfd:   pidfd,
+			Stdout:  descriptor(stdout),
+			Stderr:  descriptor(stderr),
+			Sockets: descriptors(run.sockets),
+			Secrets: run.secrets,
+		}
+	}
+
+	w.logger.Info("jobs ready to be handed off", "running", len(processes))
+	return processes, release, nil
+}
+
+// checkHandOff reports why the job cannot be handed off, nil if it can
+func (r *jobRun) checkHandOff() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch {
+	case r.held:
+		return fmt.Errorf("is held in a crash loop, resume or stop it first")
+	case r.waiting:
+		return fmt.Errorf("is waiting to be restarted, retry once it runs")
+	case r.cmd == nil:
+		return fmt.Errorf("has no process")
+	case r.job.Spec != nil && len(r.job.Spec.Inputs) > 0:
+		return fmt.Errorf("streams inputs, which cannot be handed off")
+	}
+	return nil
+}
+
+// Adopt takes over the jobs handed off by the worker binary this one
+// replaced, once they are in the store: job IDs continue after theirs, the
+// running jobs are monitored, restarted and stopped as if this binary had
+// started them, and the workspaces of the finished ones are removed after the
+// retention period, counted anew. The stdin of running jobs, if any, was
+// closed by the exec.
+func (w *Worker) Adopt(jobs []upgrade.Job) {
+	// the same socket is handed over once for all the jobs sharing it
+	files := make(map[int]*os.File)
+
+	for _, handed := range jobs {
+		if id, err := strconv.ParseInt(handed.Job.Id, 10, 64); err == nil {
+			for counter := atomic.LoadInt64(&jobCounter); id > counter; counter = atomic.LoadInt64(&jobCounter) {
+				if atomic.CompareAndSwapInt64(&jobCounter, counter, id) {
+					break
+				}
+			}
+		}
+
+		switch job := handed.Job; {
+		case handed.Process != nil:
+			w.adopt(job, handed.Process, files)
+		case job.IsCompleted() && job.Workspace != "" && (job.Spec == nil || !job.Spec.RetainWorkspace):
+			w.scheduleWorkspaceCleanup(job.Id)
+		}
+	}
+}
+
+func (w *Worker) adopt(job *domain.Job, p *upgrade.Process, files map[int]*os.File) {
+	log := w.logger.WithFields("jobID", job.Id, "pid", p.Pid)
+	spec := job.Spec
+	if spec == nil {
+		spec = &domain.JobSpec{}
+	}
+
+	var stdoutCapture *os.File
+	if spec.CaptureStdout {
+		var err error
+		stdoutCapture, err = os.OpenFile(w.workspaces.StdoutPath(job.Id), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+		if err != nil {
+			log.Warn("failed to reopen the stdout capture, raw stdout is no longer captured", "error", err)
+		}
+	}
+
+	run := newJobRun(job, spec, stdoutCapture)
+	run.secrets = p.Secrets
+	run.trace = context.Background()
+	run.output = w.outputCoalescer(job.Id, spec)
+	run.limiter = w.outputLimiter(run, spec)
+	w.registerSecrets(run.secrets)
+
+	sockets := make([]*os.File, len(p.Sockets))
+	for i, fd := range p.Sockets {
+		if files[fd] == nil {
+			files[fd] = os.NewFile(uintptr(fd), spec.Sockets[i].Address)
+		}
+		sockets[i] = files[fd]
+	}
+	run.sockets, run.releaseSockets = w.sockets.Adopt(spec.Sockets, sockets)
+
+	run.pipes = &processOutput{
+		stdout: adoptPipe(p.Stdout, "stdout", w.stdoutWriter(run)),
+		stderr: adoptPipe(p.Stderr, "stderr", w.stderrWriter(run)),
+	}
+
+	cmd := &adoptedCommand{pid: p.Pid, pidfd: p.Pidfd}
+	run.cmd = cmd
+	w.trackRun(run)
+	go w.monitorJob(cmd, run)
+
+	log.Info("job adopted from the previous worker binary", "restarts", job.Restarts)
+}
+
+func adoptPipe(fd int, name string, w io.Writer) *outputPipe {
+	if fd < 0 {
+		return nil
+	}
+	return adoptOutputPipe(os.NewFile(uintptr(fd), name), w)
+}
+
+// descriptor returns the descriptor of file without making it blocking, as
+// Fd would, -1 for nil
+func descriptor(file *os.File) int {
+	if file == nil {
+		return -1
+	}
+	raw, err := file.SyscallConn()
+	if err != nil {
+		return -1
+	}
+	fd := -1
+	_ = raw.Control(func(f uintptr) { fd = int(f) })
+	return fd
+}
+
+func descriptors(files []*os.File) []int {
+	fds := make([]int, len(files))
+	for i, file := range files {
+		fds[i] = descriptor(file)
+	}
+	return fds
+}
+
+// adoptedCommand is a job process started by the worker binary this one
+// replaced. It is still a child of the worker, so it is waited for like one.
+type adoptedCommand struct {
+	pid int
+
+	mutex sync.Mutex
+	pidfd int // -1 when the process was reaped, by Wait or before the hand-off
+}
+
+func (c *adoptedCommand) Start() error {
+	return fmt.Errorf("process %d was already started", c.pid)
+}
+
+// Wait waits on the pidfd for the process to exit without reaping it, so
+// its pid cannot be reused before it is reaped by pid
+func (c *adoptedCommand) Wait() error {
+	c.mutex.Lock()
+	pidfd := c.pidfd
+	c.mutex.Unlock()
+	if pidfd < 0 {
+		return &processExit{lost: true}
+	}
+	defer func() {
+		c.mutex.Lock()
+		_ = unix.Close(c.pidfd)
+		c.pidfd = -1
+		c.mutex.Unlock()
+	}()
+
+	err := retryEINTR(func() error {
+		return unix.Waitid(unix.P_PIDFD, pidfd, nil, unix.WEXITED|unix.WNOWAIT, nil)
+	})
+	if err != nil {
+		return &processExit{lost: true}
+	}
+
+	var status unix.WaitStatus
+	err = retryEINTR(func() error {
+		_, err := unix.Wait4(c.pid, &status, 0, nil)
+		return err
+	})
+	if err != nil {
+		return &processExit{lost: true}
+	}
+	if status.Exited() && status.ExitStatus() == 0 {
+		return nil
+	}
+	return &processExit{status: syscall.WaitStatus(status)}
+}
+
+func (c *adoptedCommand) Process() platform.Process {
+	return &adoptedProcess{c}
+}
+
+// The process is already running, there is nothing left to set up
+func (c *adoptedCommand) SetStdin(r interface{})                   {}
+func (c *adoptedCommand) SetStdout(w interface{})                  {}
+func (c *adoptedCommand) SetStderr(w interface{})                  {}
+func (c *adoptedCommand) SetSysProcAttr(attr *syscall.SysProcAttr) {}
+func (c *adoptedCommand) SetEnv(env []string)                      {}
+func (c *adoptedCommand) SetExtraFiles(files []*os.File)           {}
+
+type adoptedProcess struct {
+	cmd *adoptedCommand
+}
+
+func (p *adoptedProcess) Pid() int {
+	return p.cmd.pid
+}
+
+// Kill signals through the pidfd, which cannot reach another process reusing the pid
+func (p *adoptedProcess) Kill() error {
+	p.cmd.mutex.Lock()
+	defer p.cmd.mutex.Unlock()
+
+	if p.cmd.pidfd < 0 {
+		return os.ErrProcessDone
+	}
+	return unix.PidfdSendSignal(p.cmd.pidfd, unix.SIGKILL, nil, 0)
+}
+
+// processExit is how an adopted process ended, in the terms of the
+// exec.ExitError the processes the worker started end with
+type processExit struct {
+	status syscall.WaitStatus
+	lost   bool // reaped by the previous binary, how it ended is unknown
+}
+
+func (e *processExit) Error() string {
+	switch {
+	case e.lost:
+		return "exit status lost in the worker upgrade"
+	case e.status.Signaled():
+		return "signal: " + e.status.Signal().String()
+	default:
+		return "exit status " + strconv.Itoa(e.status.ExitStatus())
+	}
+}
+
+// ExitCode returns the exit code, -1 when the process was killed by a signal
+// or its status was lost
+func (e *processExit) ExitCode() int {
+	if e.lost || !e.status.Exited() {
+		return -1
+	}
+	return e.status.ExitStatus()
+}
+
+func (e *processExit) Sys() any {
+	return e.status
+}
+
+func retryEINTR(f func() error) error {
+	for {
+		if err := f(); !errors.Is(err, unix.EINTR) {
+			return err
+		}
+	}
+}
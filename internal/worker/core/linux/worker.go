@@ -6,144 +6,594 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
+	"maps"
+	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 	"sync/atomic"
+	"syscall"
 	"time"
 	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/core/linux/isolation"
 	"worker/internal/worker/core/linux/process"
 	"worker/internal/worker/core/linux/resource"
-	"worker/internal/worker/core/linux/unprivileged"
+	"worker/internal/worker/coredump"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/execarch"
+	"worker/internal/worker/health"
+	"worker/internal/worker/hooks"
+	"worker/internal/worker/inputs"
+	"worker/internal/worker/logsink"
+	"worker/internal/worker/metrics"
+	"worker/internal/worker/offload"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/sockets"
 	"worker/internal/worker/state"
+	"worker/internal/worker/tracing"
+	"worker/internal/worker/upgrade"
+	"worker/internal/worker/utils"
+	"worker/internal/worker/watchdog"
+	"worker/internal/worker/workspace"
 	"worker/pkg/config"
 	"worker/pkg/logger"
 	"worker/pkg/platform"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var jobCounter int64
 
+// shellPath runs the command of jobs started in shell mode
+const shellPath = "/bin/sh"
+
+// stopSettleTimeout is how long StopJob waits for the monitor to record the
+// exit of a process it signalled
+const stopSettleTimeout = 5 * time.Second
+
 // Worker handles job execution with configuration
 type Worker struct {
 	store          state.Store
+	events         *events.Bus // job records change through events, the store reads them back
 	cgroup         resource.Resource
 	processManager *process.Manager
-	jobIsolation   *unprivileged.JobIsolation
+	logShipper     *logsink.Shipper
+	workspaces     *workspace.Manager
+	offloader      *offload.Offloader
+	offloads       sync.Map      // job ID -> chan struct{} closed once the job's offload ended
+	hooks          *hooks.Runner // pre-start and post-stop hooks, nil when none are configured
+	redactor       *redact.Redactor
+	initPool       *initPool          // warm init processes, nil when disabled
+	inputs         *inputs.Streamer   // streams job inputs from object storage, nil when disabled
+	sockets        *sockets.Registry  // listening sockets passed into jobs, nil when disabled
+	watchdog       *watchdog.Watchdog // fails stuck launches and cleanups, nil when disabled
+	corePattern    coredump.Pattern   // how the kernel names the core files of crashed jobs
 	platform       platform.Platform
+	binaryPath     string // worker binary on disk, for reference only
+	initPath       string // worker binary jobs are launched from, "" for the running one
 	config         *config.Config
 	logger         *logger.Logger
+
+	unavailable map[domain.IsolationMode]string // isolation modes the preflight checks ruled out, with why
+
+	runs   map[string]*jobRun // started jobs still being monitored
+	runsMu sync.Mutex
+
+	// read-held by launches and by monitors handling an exit, write-held while
+	// the jobs are handed to a new worker binary, which ends with the exec
+	handoffMu sync.RWMutex
+
+	// held while a job cgroup is created in a group cgroup, so the cleanup of
+	// the group's last job cannot remove the group cgroup in between
+	groupCgroupsMu sync.Mutex
 }
 
-// NewPlatformWorker creates a new Linux platform worker
-func NewPlatformWorker(store state.Store, cfg *config.Config) interfaces.Worker {
-	platformInterface := platform.NewPlatform()
-	processManager := process.NewProcessManager(platformInterface)
-	cgroupResource := resource.New(cfg.Cgroup)
-	jobIsolation := unprivileged.NewJobIsolation()
+// NewPlatformWorker creates a new Linux platform worker, limiting jobs through
+// cgroup, or through the cgroup v2 tree of the config when it is nil. It fails
+// when the kernel lacks features jobs need, unless the preflight mode of the
+// config lets it start without the isolation modes needing them.
+func NewPlatformWorker(store state.Store, bus *events.Bus, redactor *redact.Redactor, cgroup resource.Resource, cfg *config.Config) (interfaces.Worker, error) {
+	log := logger.New().WithField("component", "linux-worker")
+	unavailable, err := preflight(cfg, log)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fault injection, when enabled, fails the worker's own cgroup writes,
+	// process starts and kills at random
+	faults := platform.NewFaults(cfg.FaultInjection)
+	platformInterface := platform.WithFaults(platform.NewPlatform(), faults)
+	processManager := process.NewProcessManager(platformInterface, cfg.Worker.CommandCacheTTL)
+	cgroupResource := cgroup
+	if cgroupResource == nil {
+		cgroupConfig := cfg.Cgroup
+		// IO limits apply to the disk the jobs' workspaces are on
+		if cgroupConfig.IODevice == "" {
+			if cgroupConfig.IODevice, err = resource.DiskDevice(cfg.Workspace.BaseDir); err != nil {
+				log.Warn("no disk found for job IO limits, set cgroup.ioDevice", "workspaces", cfg.Workspace.BaseDir, "error", err)
+			}
+		}
+		cgroupResource = resource.New(cgroupConfig, faults)
+	}
+
+	// Jobs are launched from the running binary, so its path on disk is only
+	// informational and may go stale when a deploy replaces it
+	binaryPath, err := platformInterface.Executable()
+	if err != nil {
+		binaryPath = process.SelfExe
+	}
+	initPath, err := initBinary(cfg.Worker.InitBinaries)
+	if err != nil {
+		return nil, err
+	}
 
 	worker := &Worker{
 		store:          store,
+		events:         bus,
 		cgroup:         cgroupResource,
 		processManager: processManager,
-		jobIsolation:   jobIsolation,
+		redactor:       redactor,
+		workspaces:     workspace.NewManager(cfg.Workspace),
+		hooks:          hooks.New(cfg.Hooks),
+		watchdog:       watchdog.New(cfg.Worker.WatchdogDeadline),
 		platform:       platformInterface,
+		binaryPath:     binaryPath,
+		initPath:       initPath,
 		config:         cfg,
-		logger:         logger.New().WithField("component", "linux-worker"),
+		logger:         log,
+		unavailable:    unavailable,
+		runs:           make(map[string]*jobRun),
+	}
+
+	if _, ok := unavailable[domain.IsolationCgroups]; !ok {
+		if err := worker.setupCgroupControllers(); err != nil {
+			return nil, fmt.Errorf("cgroup controller setup failed: %w", err)
+		}
+	}
+
+	logShipper, err := logsink.NewShipper(cfg.LogShipping)
+	if err != nil {
+		// job output is still buffered locally, so shipping failures are not fatal
+		worker.logger.Error("log shipping setup failed, continuing without it", "error", err)
+	}
+	worker.logShipper = logShipper
+
+	offloader, err := offload.New(cfg.Offload)
+	if err != nil {
+		// finished jobs then simply keep their output locally
+		worker.logger.Error("job offload setup failed, continuing without it", "error", err)
+	}
+	worker.offloader = offloader
+
+	streamer, err := inputs.New(cfg.Inputs)
+	if err != nil {
+		// jobs with inputs are then rejected, all others run as usual
+		worker.logger.Error("job inputs setup failed, continuing without them", "error", err)
+	}
+	worker.inputs = streamer
+	worker.sockets = sockets.New(cfg.Sockets)
+
+	// Nothing is running yet, so job workspaces on disk belong to a previous
+	// run, unless jobs are handed over by the binary this one replaced
+	if !upgrade.Upgraded() {
+		worker.workspaces.PruneJobs(cfg.Workspace.Retention)
+	}
+	go worker.pruneUploadsLoop()
+
+	if cfg.CoreDumps.Enabled {
+		worker.corePattern, err = coredump.LoadPattern()
+		if err != nil {
+			worker.logger.Warn("failed to read the core pattern", "error", err)
+		} else if !worker.corePattern.Relative() {
+			worker.logger.Warn("kernel.core_pattern sends cores outside the job workspace, crashed jobs' cores are not kept",
+				"corePattern", worker.corePattern.Raw)
+		}
 	}
 
-	if err := worker.setupCgroupControllers(); err != nil {
-		worker.logger.Fatal("cgroup controller setup failed", "error", err)
+	if _, ok := unavailable[domain.IsolationFull]; cfg.WarmPool.Enabled && !ok {
+		worker.initPool = newInitPool(cfg.WarmPool.Size, cfg.WarmPool.TTL, worker.launchWarmInit)
+		go worker.initPool.run()
 	}
 
+	defaultCPU, defaultMemory, _ := cfg.Worker.DefaultLimits()
 	worker.logger.Debug("Linux worker initialized",
 		"maxConcurrentJobs", cfg.Worker.MaxConcurrentJobs,
-		"defaultCPU", cfg.Worker.DefaultCPULimit,
-		"defaultMemory", cfg.Worker.DefaultMemoryLimit,
+		"defaultCPUMillis", defaultCPU,
+		"defaultMemoryBytes", defaultMemory,
+		"isolation", cfg.Worker.Isolation,
 		"cgroupPath", cfg.Cgroup.BaseDir)
 
-	return worker
+	return worker, nil
+}
+
+// Close ships the job output still queued for the log sinks and closes them.
+// Jobs still running are left to run; their further output is only stored.
+func (w *Worker) Close() {
+	w.logShipper.Close()
 }
 
-func (w *Worker) StartJob(ctx context.Context, command string, args []string, maxCPU, maxMemory, maxIOBPS int32) (*domain.Job, error) {
+func (w *Worker) StartJob(ctx context.Context, spec *domain.JobSpec) (*domain.Job, error) {
+	w.handoffMu.RLock()
+	defer w.handoffMu.RUnlock()
+
 	jobID := w.getNextJobID()
+
+	ctx, span := tracing.Start(ctx, "job.start", tracing.JobID(jobID))
+	job, err := w.startJob(ctx, jobID, spec, time.Now())
+	tracing.End(span, err)
+	return job, err
+}
+
+// ExpireJob records a job of the spec that could not start within its queue
+// timeout as EXPIRED, without creating any of its resources or running hooks,
+// so it is listed and waited for like a job that ran
+func (w *Worker) ExpireJob(ctx context.Context, spec *domain.JobSpec, reason error) (*domain.Job, error) {
+	w.handoffMu.RLock()
+	defer w.handoffMu.RUnlock()
+
+	job := &domain.Job{
+		Id:        w.getNextJobID(),
+		Command:   spec.Command,
+		Args:      utils.CopyStringSlice(spec.Args),
+		Env:       utils.CopyStringMap(spec.Env),
+		SecretEnv: utils.CopyStringMap(spec.SecretEnv),
+		Limits:    w.resolveLimits(spec.Limits),
+		GroupId:   spec.GroupID,
+		Labels:    utils.CopyStringMap(spec.Labels),
+		Status:    domain.StatusInitializing,
+		Spec:      spec.Copy(),
+		StartTime: time.Now(),
+	}
+	w.events.Publish(events.Event{Type: events.JobCreated, Job: job})
+
+	expired := job.DeepCopy()
+	expired.Expire(fmt.Sprintf("not started within its queue timeout: %v", reason))
+	w.publishJob(expired)
+	w.events.Publish(events.Event{Type: events.JobExpired, JobID: job.Id, Err: reason})
+
+	w.logger.Info("job expired before it could start", "jobID", job.Id, "command", spec.Command, "reason", reason)
+	return expired, nil
+}
+
+// startJob validates the spec, creates the job resources and launches it,
+// recording each step as a span of the trace in ctx. Every resource is created
+// in a launch transaction: when a step fails, or ctx is done before the next
+// one, the launch is rolled back and leaves no cgroup or workspace behind. A
+// job registered before the failure is recorded as FAILED.
+func (w *Worker) startJob(ctx context.Context, jobID string, spec *domain.JobSpec, requested time.Time) (job *domain.Job, err error) {
+	command := spec.Command
 	log := w.logger.WithFields("jobID", jobID, "command", command)
 
 	log.Debug("starting job with configuration",
-		"requestedCPU", maxCPU,
-		"requestedMemory", maxMemory,
-		"requestedIO", maxIOBPS,
+		"requestedCPUMillis", spec.Limits.CPUMillis,
+		"requestedMemoryBytes", spec.Limits.MemoryBytes,
+		"requestedIOBPS", spec.Limits.IOBPS,
+		"envVars", len(spec.Env),
+		"secretEnvVars", len(spec.SecretEnv),
 		"validateCommands", w.config.Worker.ValidateCommands)
 
-	// Early context check
-	select {
-	case <-ctx.Done():
-		return nil, ctx.Err()
-	default:
-	}
+	tx := w.beginLaunch(jobID)
+	defer func() { tx.Rollback(err) }()
 
-	// Validate command and arguments
-	if err := w.processManager.ValidateCommand(command); err != nil {
-		return nil, fmt.Errorf("invalid command: %w", err)
+	if err := launchWanted(ctx, "validation"); err != nil {
+		return nil, err
 	}
 
-	if err := w.processManager.ValidateArguments(args); err != nil {
-		return nil, fmt.Errorf("invalid arguments: %w", err)
+	// Validate the spec and resolve the command path
+	_, validateSpan := tracing.Start(ctx, "job.validate")
+	validation := w.ValidateJob(ctx, spec)
+	tracing.End(validateSpan, validation.Err())
+	if err := validation.Err(); err != nil {
+		return nil, err
 	}
 
-	// Resolve command path
-	resolvedCommand, err := w.processManager.ResolveCommand(command)
+	backend, err := w.isolationBackend(spec.Isolation, spec.Runtime)
 	if err != nil {
-		return nil, fmt.Errorf("command resolution failed: %w", err)
+		return nil, err
 	}
 
 	// Create job domain object
-	job := w.createJobDomain(jobID, resolvedCommand, args, maxCPU, maxMemory, maxIOBPS)
+	job = w.createJobDomain(jobID, validation.ResolvedCommand, spec, backend)
+	trace := tracing.Detach(ctx)
+
+	// Pre-start hooks run before anything is created, so a rejection leaves nothing to clean up
+	hookCtx, hookSpan := tracing.Start(ctx, "job.hooks.pre_start")
+	err = w.hooks.PreStart(hookCtx, job)
+	tracing.End(hookSpan, err)
+	if err != nil {
+		return nil, err
+	}
+
+	// Setup cgroup resources, unless the backend runs the job without one
+	var limitsNotApplied error
+	if backend.UsesCgroup() {
+		if err := launchWanted(ctx, "cgroup setup"); err != nil {
+			return nil, err
+		}
+
+		log.Debug("creating cgroup for job with resource limits",
+			"limits", fmt.Sprintf("CPU:%dm, Memory:%d bytes, IO:%d bytes/s",
+				job.Limits.CPUMillis, job.Limits.MemoryBytes, job.Limits.IOBPS))
 
-	log.Debug("creating cgroup for job with resource limits",
-		"limits", fmt.Sprintf("CPU:%d, Memory:%dMB, IO:%d",
-			job.Limits.MaxCPU, job.Limits.MaxMemory, job.Limits.MaxIOBPS))
+		_, cgroupSpan := tracing.Start(ctx, "job.cgroup.create", attribute.String("cgroup.path", job.CgroupPath))
+		e := w.createCgroup(job)
+		tracing.End(cgroupSpan, e)
+		var notApplied *resource.LimitNotAppliedError
+		if e != nil && !errors.As(e, &notApplied) {
+			return nil, fmt.Errorf("cgroup setup failed: %w", e)
+		}
+		tx.Created("cgroup", func() { w.cleanupCgroup(trace, jobID, job.CgroupPath) })
+
+		// the kernel ignored or clamped a limit it accepted the write of
+		if e != nil {
+			if w.config.Cgroup.RequireLimits {
+				return nil, fmt.Errorf("cgroup limits not applied: %w", e)
+			}
+			log.Warn("job starts with limits the kernel did not apply", "error", e)
+			limitsNotApplied = e
+		}
 
-	// Setup cgroup resources
-	if e := w.cgroup.Create(
-		job.CgroupPath,
-		job.Limits.MaxCPU,
-		job.Limits.MaxMemory,
-		job.Limits.MaxIOBPS,
-	); e != nil {
-		return nil, fmt.Errorf("cgroup setup failed: %w", e)
+		// like the limits, QoS is best effort on hosts missing a controller
+		if e := w.cgroup.SetQoS(job.CgroupPath, job.QoS.Settings(job.Limits)); e != nil {
+			log.Warn("QoS class only partly applied", "qos", job.QoS, "error", e)
+		}
 	}
 
-	// Register job in store
-	w.store.CreateNewJob(job)
+	if err := launchWanted(ctx, "workspace setup"); err != nil {
+		return nil, err
+	}
+
+	// Create the job workspace, seeded with staged input files if any
+	_, workspaceSpan := tracing.Start(ctx, "job.workspace.setup")
+	workspaceDir, err := w.setupWorkspace(jobID, spec.UploadID)
+	tracing.End(workspaceSpan, err)
+	if err != nil {
+		return nil, fmt.Errorf("workspace setup failed: %w", err)
+	}
+	job.Workspace = workspaceDir
+
+	// the workspace of a job that never got registered goes right away, a
+	// failed job's is kept for inspection like any other
+	registered := false
+	tx.Created("workspace", func() {
+		switch {
+		case !registered:
+			w.cleanupWorkspace(jobID)
+		case !spec.RetainWorkspace:
+			w.scheduleWorkspaceCleanup(jobID)
+		}
+	})
+
+	var stdoutCapture *os.File
+	if spec.CaptureStdout {
+		stdoutCapture, err = os.OpenFile(w.workspaces.StdoutPath(jobID), os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("stdout capture setup failed: %w", err)
+		}
+	}
+
+	run := newJobRun(job, spec, stdoutCapture)
+	run.trace = trace
+	run.output = w.outputCoalescer(jobID, spec)
+	run.limiter = w.outputLimiter(run, spec)
+	tx.Created("stdout capture", run.closeCapture)
+
+	if spec.Stdin {
+		if err := run.openStdinPipe(); err != nil {
+			return nil, fmt.Errorf("stdin setup failed: %w", err)
+		}
+		tx.Created("stdin pipe", run.closeStdin)
+	}
+
+	// Inputs are fetched as the job reads them, so the launch does not wait for them
+	run.stopInputs, err = w.inputs.Serve(jobID, workspaceDir, spec.Inputs, func(input domain.JobInput, err error) {
+		w.events.Publish(events.Event{Type: events.JobInputFailed, JobID: jobID,
+			Err: fmt.Errorf("input %s from %s: %w", input.Path, input.Source, err)})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("input setup failed: %w", err)
+	}
+	tx.Created("inputs", run.stopInputs)
 
-	// Start the process using single binary approach
-	cmd, err := w.startProcessSingleBinary(ctx, job)
+	// Sockets stay open across restarts, and for as long as another job holds them
+	run.sockets, run.releaseSockets, err = w.sockets.Acquire(spec.Sockets)
+	if err != nil {
+		return nil, fmt.Errorf("socket setup failed: %w", err)
+	}
+	tx.Created("sockets", run.releaseSockets)
+
+	if err := launchWanted(ctx, "process start"); err != nil {
+		return nil, err
+	}
+
+	// Recorded before the job is registered, so every copy of it carries the record
+	job.Provenance = w.recordProvenance(job, backend)
+
+	// Register job in store
+	w.events.Publish(events.Event{Type: events.JobCreated, Job: job})
+	registered = true
+	tx.Created("job record", func() {
+		failedJob := run.job.DeepCopy()
+		failedJob.Fail(-1, "failed to start")
+		w.publishJob(failedJob)
+		go w.hooks.PostStop(run.trace, failedJob)
+	})
+
+	// Secret values are masked in job output for as long as the job runs
+	w.registerSecrets(spec.Secrets)
+	tx.Created("secret masking", func() { w.releaseSecrets(spec.Secrets) })
+
+	// Start the process using single binary approach; a launch cancelled by ctx
+	// kills a process that starts anyway
+	cmd, err := w.launchWatched(ctx, run)
 	if err != nil {
-		w.cleanupFailedJob(job)
 		return nil, fmt.Errorf("process start failed: %w", err)
 	}
+	tx.Commit()
 
 	// Update job with process info
+	run.cmd = cmd
+	job.LaunchTime = time.Since(requested)
 	w.updateJobAsRunning(job, cmd)
+	if job.Isolation == domain.IsolationPrivileged {
+		log.Warn("privileged job started on the host without isolation or limits", "pid", job.Pid, "command", job.Command)
+		w.events.Publish(events.Event{Type: events.JobPrivileged, JobID: job.Id})
+	}
+	if limitsNotApplied != nil {
+		w.events.Publish(events.Event{Type: events.JobLimitNotApplied, JobID: job.Id, Err: limitsNotApplied})
+	}
 
-	// Start monitoring
-	go w.monitorJob(ctx, cmd, job)
+	// Start monitoring, which outlives the request
+	w.trackRun(run)
+	go w.monitorJob(cmd, run)
 
 	log.Debug("job started successfully", "pid", job.Pid)
 	return job, nil
 }
 
-func (w *Worker) StopJob(ctx context.Context, jobID string) error {
+// ValidateJob runs every check StartJob performs before creating any resources
+// and reports all problems at once. Nothing is created or started.
+func (w *Worker) ValidateJob(ctx context.Context, spec *domain.JobSpec) *domain.JobValidation {
+	validation := &domain.JobValidation{Limits: w.resolveLimits(spec.Limits)}
+
+	command := spec.Command
+	var commandErr error
+	if spec.Shell {
+		commandErr = w.processManager.ValidateShellScript(spec.Command)
+		command = shellPath
+	} else {
+		commandErr = w.processManager.ValidateCommand(spec.Command)
+	}
+	validation.Add("command", commandErr)
+	validation.Add("arguments", w.processManager.ValidateArguments(spec.Args))
+	validation.Add("environment", w.processManager.ValidateJobEnvironment(spec.Env, w.config.Worker.EnvDenylist))
+	backend, err := w.isolationBackend(spec.Isolation, spec.Runtime)
+	if err != nil {
+		validation.Add("isolation", err)
+	} else {
+		validation.Add("group limits", checkGroupLimits(spec, backend))
+	}
+	validation.Add("secret environment", w.processManager.ValidateJobEnvironment(spec.Secrets, w.config.Worker.EnvDenylist))
+	validation.Add("inputs", w.inputs.Check(spec.Inputs))
+	validation.Add("sockets", w.sockets.Check(spec.Sockets))
+
+	if spec.Probe != nil && spec.Probe.Type == domain.ProbeExec {
+		if _, err := exec.LookPath("nsenter"); err != nil {
+			validation.Add("health probe", fmt.Errorf("exec health probes require nsenter: %w", err))
+		}
+	}
+
+	// resolving a command that failed validation would only repeat the problem
+	if commandErr == nil {
+		resolvedCommand, err := w.processManager.ResolveCommand(command, process.ResolveOptions{
+			SearchPath: w.searchPath(spec),
+			JobDirs:    w.jobDirs(backend),
+		})
+		if err != nil {
+			validation.Add("command", fmt.Errorf("command resolution failed: %w", err))
+		}
+		validation.ResolvedCommand = resolvedCommand
+
+		// a command in the job's own filesystem is checked by init before the exec
+		if err == nil && !process.InJobFilesystem(resolvedCommand, w.jobDirs(backend)) {
+			validation.Add("command", execarch.Check(resolvedCommand))
+		}
+	}
+
+	return validation
+}
+
+// searchPath is the PATH a job's command is looked up in: the job's own, or
+// the configured base PATH, "" for the worker's
+func (w *Worker) searchPath(spec *domain.JobSpec) string {
+	if path, ok := spec.Env["PATH"]; ok {
+		return path
+	}
+	return w.config.Worker.BaseEnv["PATH"]
+}
+
+// jobDirs are the directories a job of the backend sees that the host does
+// not: the workspace mount of its own mount namespace
+func (w *Worker) jobDirs(backend isolation.Backend) []string {
+	if backend == nil || backend.Mode() != domain.IsolationFull || w.config.Workspace.MountPath == "" {
+		return nil
+	}
+	return []string{w.config.Workspace.MountPath}
+}
+
+// OpenStdin returns the stdin of a job started with Stdin. Only one client can
+// hold it; closing it closes the job's stdin.
+func (w *Worker) OpenStdin(jobID string) (io.WriteCloser, error) {
+	run := w.lookupRun(jobID)
+	if run == nil {
+		return nil, fmt.Errorf("job is not running: %s", jobID)
+	}
+	return run.claimStdin()
+}
+
+// Capabilities lists what this worker provides. Jobs share the host network,
+// so there is no network isolation.
+func (w *Worker) Capabilities() []domain.Capability {
+	var capabilities []domain.Capability
+	if _, ok := w.unavailable[domain.IsolationCgroups]; !ok {
+		capabilities = append(capabilities, domain.CapabilityCgroups)
+	}
+	if _, ok := w.unavailable[domain.IsolationFull]; !ok {
+		capabilities = append(capabilities, domain.CapabilityNamespaces)
+	}
+	if w.sockets != nil {
+		capabilities = append(capabilities, domain.CapabilitySockets)
+	}
+	if _, ok := w.unavailable[domain.IsolationCgroups]; !ok && len(w.config.Worker.Runtimes) > 0 {
+		capabilities = append(capabilities, domain.CapabilitySandbox)
+	}
+	return append(capabilities,
+		domain.CapabilityStdin,
+		domain.CapabilityHealthProbes,
+		domain.CapabilityRestarts,
+		domain.CapabilityUploads,
+	)
+}
+
+func (w *Worker) StopJob(ctx context.Context, jobID string, opts domain.StopOptions) (*domain.StopResult, error) {
 	log := w.logger.WithField("jobID", jobID)
-	log.Debug("stopping job")
+	log.Debug("stopping job", "signal", opts.Signal, "gracePeriod", opts.GracePeriod)
 
 	job, exists := w.store.GetJob(jobID)
 	if !exists {
-		return fmt.Errorf("job not found: %s", jobID)
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+
+	if !job.IsRunning() && !job.IsCrashLooping() {
+		return nil, fmt.Errorf("job is not running: %s (status: %s)", jobID, job.Status)
+	}
+
+	// A job waiting to be restarted or held in a crash loop has no process to
+	// signal, cancelling the restart is enough
+	run := w.lookupRun(jobID)
+	if run != nil && run.requestStop() {
+		stoppedJob := job.DeepCopy()
+		if job.IsCrashLooping() {
+			stoppedJob.Stop("stopped by a user while held in a crash loop")
+		} else {
+			stoppedJob.Stop("stopped by a user while waiting to restart")
+		}
+		w.publishJob(stoppedJob)
+		w.cleanupCgroup(ctx, jobID, job.CgroupPath)
+		log.Debug("job stopped while waiting to restart")
+		return &domain.StopResult{Method: domain.StopExited, ExitCode: stoppedJob.ExitCode}, nil
 	}
 
-	if !job.IsRunning() {
-		return fmt.Errorf("job is not running: %s (status: %s)", jobID, job.Status)
+	gracePeriod := opts.GracePeriod
+	if gracePeriod <= 0 {
+		gracePeriod = w.config.Cgroup.CleanupTimeout
 	}
 
 	// Create cleanup request
@@ -152,238 +602,1437 @@ func (w *Worker) StopJob(ctx context.Context, jobID string) error {
 		PID:             job.Pid,
 		CgroupPath:      job.CgroupPath,
 		ForceKill:       false,
-		GracefulTimeout: w.config.Cgroup.CleanupTimeout,
+		Signal:          opts.Signal,
+		GracefulTimeout: gracePeriod,
 	}
 
 	// Perform process cleanup
-	result, err := w.processManager.CleanupProcess(ctx, cleanupReq)
+	cleanupCtx, cleanupSpan := tracing.Start(ctx, "job.process.cleanup", tracing.JobID(jobID))
+	result, err := w.processManager.CleanupProcess(cleanupCtx, cleanupReq)
+	tracing.End(cleanupSpan, err)
 	if err != nil {
-		return fmt.Errorf("process cleanup failed: %w", err)
+		return nil, fmt.Errorf("process cleanup failed: %w", err)
 	}
 
-	// Update job status
-	w.updateJobStatus(job, result)
-
-	// Cleanup cgroup
-	w.cgroup.CleanupCgroup(jobID)
+	// The monitor records how the process exited; without one, or when it
+	// does not see the exit, the status follows from the cleanup
+	if run == nil || !run.awaitFinish(ctx, stopSettleTimeout) {
+		w.updateJobStatus(job, result)
+		w.cleanupCgroup(ctx, jobID, job.CgroupPath)
+	}
 
-	log.Debug("job stopped successfully", "method", result.Method)
-	return nil
-}
+	stopped := &domain.StopResult{Method: stopMethod(result.Method), ExitCode: -1}
+	if final, exists := w.store.GetJob(jobID); exists {
+		stopped.ExitCode = final.ExitCode
+	}
 
-// Helper methods (keeping existing implementations)
-func (w *Worker) getNextJobID() string {
-	nextID := atomic.AddInt64(&jobCounter, 1)
-	return fmt.Sprintf("%d", nextID)
+	log.Debug("job stopped successfully", "method", stopped.Method, "exitCode", stopped.ExitCode)
+	return stopped, nil
 }
 
-func (w *Worker) createJobDomain(jobID, resolvedCommand string, args []string, maxCPU, maxMemory, maxIOBPS int32) *domain.Job {
-	// Apply defaults from configuration
-	if maxCPU <= 0 {
-		maxCPU = w.config.Worker.DefaultCPULimit
-	}
-	if maxMemory <= 0 {
-		maxMemory = w.config.Worker.DefaultMemoryLimit
+// ResumeJob restarts a job held in a crash loop, with its failure count reset
+func (w *Worker) ResumeJob(ctx context.Context, jobID string) error {
+	job, exists := w.store.GetJob(jobID)
+	if !exists {
+		return fmt.Errorf("job not found: %s", jobID)
 	}
-	if maxIOBPS <= 0 {
-		maxIOBPS = w.config.Worker.DefaultIOLimit
+
+	run := w.lookupRun(jobID)
+	if run == nil || !run.requestResume() {
+		return fmt.Errorf("job is not crash looping: %s (status: %s)", jobID, job.Status)
 	}
 
-	w.logger.Debug("job resource limits applied",
-		"jobID", jobID,
-		"maxCPU", maxCPU,
-		"maxMemory", maxMemory,
-		"maxIOBPS", maxIOBPS,
-		"source", "client-specified or defaults")
+	w.logger.Info("job resumed from crash loop", "jobID", jobID)
+	return nil
+}
 
-	return &domain.Job{
-		Id:      jobID,
-		Command: resolvedCommand,
-		Args:    append([]string(nil), args...),
-		Limits: domain.ResourceLimits{
-			MaxCPU:    maxCPU,
-			MaxMemory: maxMemory,
-			MaxIOBPS:  maxIOBPS,
-		},
-		Status:     domain.StatusInitializing,
-		CgroupPath: filepath.Join(w.config.Cgroup.BaseDir, "job-"+jobID),
-		StartTime:  time.Now(),
+// stopMethod maps a process cleanup method to how the stop ended the job
+func stopMethod(method string) string {
+	switch method {
+	case "graceful":
+		return domain.StopGraceful
+	case "already_dead":
+		return domain.StopExited
+	default:
+		return domain.StopForced
 	}
 }
 
-func (w *Worker) setupCgroupControllers() error {
-	w.logger.Debug("setting up cgroup controllers for job isolation")
+// jobRun holds the per-run state of a started job that is not part of the job record
+type jobRun struct {
+	job             *domain.Job
+	secrets         map[string]string // injected at exec time, never stored
+	stdoutCapture   *os.File          // raw stdout copy, nil unless requested
+	retainWorkspace bool              // the caller removes the workspace
 
-	if err := w.cgroup.EnsureControllers(); err != nil {
-		return fmt.Errorf("failed to ensure controllers: %w", err)
-	}
+	// stdin pipe for jobs started with Stdin. The read end goes to the first
+	// process only; restarted processes get no stdin.
+	stdinReader  *os.File
+	stdinWriter  *os.File
+	stdinClaimed bool
 
-	w.logger.Debug("cgroup controllers setup completed successfully")
-	return nil
-}
+	health domain.HealthState // last probe result, owned by the probe goroutine of the current process
 
-// startProcessSingleBinary starts a job using the same binary in init mode
-func (w *Worker) startProcessSingleBinary(ctx context.Context, job *domain.Job) (platform.Command, error) {
-	// Get the current executable path (this same binary)
-	execPath, err := w.platform.Executable()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get current executable path: %w", err)
-	}
+	idle       bool         // the idle policy found the job idle, owned by the idle watcher of the current process
+	lastOutput atomic.Int64 // time the job last wrote output in Unix nanoseconds, for the idle policy
 
-	// Prepare environment with job information and mode indicator
-	env := w.buildJobEnvironmentSingleBinary(job, execPath)
+	output  *outputCoalescer // shared by stdout and stderr to keep their order, nil to store every write
+	limiter *outputLimiter   // shared by stdout and stderr, nil without an output rate limit
 
-	// Create isolation attributes
-	sysProcAttr := w.jobIsolation.CreateIsolatedSysProcAttr()
+	stopInputs func() // stops streaming the job's inputs and removes their pipes
 
-	// Create launch configuration
-	launchConfig := &process.LaunchConfig{
-		InitPath:    execPath, // Use same binary
-		Environment: env,
-		SysProcAttr: sysProcAttr,
-		Stdout:      New(w.store, job.Id),
-		Stderr:      New(w.store, job.Id),
-		JobID:       job.Id,
-		Command:     job.Command,
-		Args:        job.Args,
+	sockets        []*os.File // listening sockets of the spec, passed to every process of the job
+	releaseSockets func()     // drops the job's hold on its sockets
+
+	// current process and its output, changed under the read lock of handoffMu
+	cmd   platform.Command
+	pipes *processOutput
+
+	trace context.Context // carries the span of StartJob, for restarts and cleanup
+
+	mutex    sync.Mutex
+	stopping bool          // StopJob was called, the job must not be restarted
+	waiting  bool          // the process exited and a restart is pending
+	held     bool          // the job is held in a crash loop until resumed
+	wake     chan struct{} // closed when a stop is requested
+	resume   chan struct{} // receives the resume of a held job
+	finished chan struct{} // closed once the final status is published
+}
+
+func newJobRun(job *domain.Job, spec *domain.JobSpec, stdoutCapture *os.File) *jobRun {
+	return &jobRun{
+		job:             job,
+		secrets:         spec.Secrets,
+		stdoutCapture:   stdoutCapture,
+		retainWorkspace: spec.RetainWorkspace,
+		stopInputs:      func() {},
+		releaseSockets:  func() {},
+		wake:            make(chan struct{}),
+		resume:          make(chan struct{}, 1),
+		finished:        make(chan struct{}),
 	}
+}
 
-	// Launch the process
-	result, err := w.processManager.LaunchProcess(ctx, launchConfig)
-	if err != nil {
-		return nil, err
+func (r *jobRun) closeCapture() {
+	if r.stdoutCapture != nil {
+		_ = r.stdoutCapture.Close()
 	}
+}
 
-	// Move process to cgroup
-	if e := w.addProcessToCgroup(job.CgroupPath, result.PID); e != nil {
-		w.logger.Warn("failed to add process to cgroup", "error", e)
+func (r *jobRun) openStdinPipe() error {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		return err
 	}
+	r.stdinReader, r.stdinWriter = reader, writer
+	return nil
+}
 
-	w.logger.Debug("process launched using single binary", "jobID", job.Id, "pid", result.PID)
-	return result.Command, nil
+// takeStdin returns the stdin of the next process, nil once the first process
+// has it
+func (r *jobRun) takeStdin() *os.File {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.stdinReader == nil {
+		return nil
+	}
+	reader := r.stdinReader
+	r.stdinReader = nil
+	return reader
 }
 
-// buildJobEnvironmentSingleBinary builds environment for single binary mode
-func (w *Worker) buildJobEnvironmentSingleBinary(job *domain.Job, execPath string) []string {
-	baseEnv := w.platform.Environ()
+// claimStdin hands the write end of the stdin pipe to a single client
+func (r *jobRun) claimStdin() (*os.File, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
-	// Job-specific environment with mode indicator
-	jobEnv := []string{
-		"WORKER_MODE=init", // This tells the binary to run in init mode
-		fmt.Sprintf("JOB_ID=%s", job.Id),
-		fmt.Sprintf("JOB_COMMAND=%s", job.Command),
-		fmt.Sprintf("JOB_CGROUP_PATH=%s", "/sys/fs/cgroup"),    // Namespace path
-		fmt.Sprintf("JOB_CGROUP_HOST_PATH=%s", job.CgroupPath), // Host path for debugging
-		fmt.Sprintf("JOB_ARGS_COUNT=%d", len(job.Args)),
-		"HOST_NETWORKING=true",
-		"CGROUP_NAMESPACE=true",
-		"JOB_ISOLATION=enabled",
-		"USER_NAMESPACE=true",
-		fmt.Sprintf("WORKER_BINARY_PATH=%s", execPath), // For reference
-		fmt.Sprintf("JOB_MAX_CPU=%d", job.Limits.MaxCPU),
-		fmt.Sprintf("JOB_MAX_MEMORY=%d", job.Limits.MaxMemory),
-		fmt.Sprintf("JOB_MAX_IOBPS=%d", job.Limits.MaxIOBPS),
+	if r.stdinWriter == nil {
+		return nil, fmt.Errorf("job was not started with stdin")
 	}
+	if r.stdinClaimed {
+		return nil, fmt.Errorf("job stdin is already being written")
+	}
+	r.stdinClaimed = true
+	return r.stdinWriter, nil
+}
 
-	// Add job arguments
-	for i, arg := range job.Args {
-		jobEnv = append(jobEnv, fmt.Sprintf("JOB_ARG_%d=%s", i, arg))
+// closeStdin closes both ends of the stdin pipe, so a client still writing
+// gets an error once the job is gone
+func (r *jobRun) closeStdin() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.stdinReader != nil {
+		_ = r.stdinReader.Close()
+		r.stdinReader = nil
 	}
+	if r.stdinWriter != nil {
+		_ = r.stdinWriter.Close()
+	}
+}
 
-	return append(baseEnv, jobEnv...)
+// requestStop prevents further restarts and reports whether the job was
+// between an exit and its restart, i.e. has no live process
+func (r *jobRun) requestStop() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.stopping {
+		r.stopping = true
+		close(r.wake)
+	}
+	return r.waiting
 }
 
-// addProcessToCgroup moves a process to the specified cgroup
-func (w *Worker) addProcessToCgroup(cgroupPath string, pid int32) error {
-	procsFile := filepath.Join(cgroupPath, "cgroup.procs")
-	pidBytes := []byte(fmt.Sprintf("%d", pid))
-	return w.platform.WriteFile(procsFile, pidBytes, 0644)
+func (r *jobRun) stopRequested() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	return r.stopping
 }
 
-func (w *Worker) updateJobAsRunning(job *domain.Job, processCmd platform.Command) {
-	cmd := processCmd.Process()
-	if cmd == nil {
-		w.logger.Warn("process is nil after start", "jobID", job.Id)
-		return
+// waitForRestart sleeps for the backoff delay and reports whether the job
+// should still be restarted afterwards
+func (r *jobRun) waitForRestart(delay time.Duration) bool {
+	r.mutex.Lock()
+	if r.stopping {
+		r.mutex.Unlock()
+		return false
 	}
+	r.waiting = true
+	r.mutex.Unlock()
 
-	runningJob := job.DeepCopy()
-	runningJob.Pid = int32(cmd.Pid())
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
 
-	if err := runningJob.MarkAsRunning(runningJob.Pid); err != nil {
-		w.logger.Warn("domain validation failed for running status", "error", err)
-		runningJob.Status = domain.StatusRunning
-		runningJob.Pid = int32(cmd.Pid())
+	select {
+	case <-timer.C:
+	case <-r.wake:
 	}
 
-	job.Status = domain.StatusRunning
-	runningJob.StartTime = time.Now()
-	w.store.UpdateJob(runningJob)
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.waiting = false
+	return !r.stopping
 }
 
-func (w *Worker) monitorJob(ctx context.Context, cmd platform.Command, job *domain.Job) {
-	log := w.logger.WithField("jobID", job.Id)
-	startTime := time.Now()
+// hold marks the job as held in a crash loop, so a stop or resume finds it
+// without a process. It returns false when the job is being stopped.
+func (r *jobRun) hold() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
 
-	// Wait for process completion
-	err := cmd.Wait()
-	duration := time.Since(startTime)
+	if r.stopping {
+		return false
+	}
+	r.waiting, r.held = true, true
+	return true
+}
 
-	// Determine final status and exit code
-	var finalStatus domain.JobStatus
-	var exitCode int32
+// awaitResume waits until a held job is resumed or stopped, and reports
+// whether it should be restarted
+func (r *jobRun) awaitResume() bool {
+	select {
+	case <-r.resume:
+	case <-r.wake:
+	}
 
-	if err != nil {
-		var exitErr *exec.ExitError
-		if errors.As(err, &exitErr) {
-			exitCode = int32(exitErr.ExitCode())
-			finalStatus = domain.StatusFailed
-		}
-	} else {
-		exitCode = 0
-		finalStatus = domain.StatusCompleted
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.waiting, r.held = false, false
+	return !r.stopping
+}
+
+// requestResume releases a held job, and reports false when it is not held
+func (r *jobRun) requestResume() bool {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.held || r.stopping {
+		return false
 	}
+	r.held = false
+	r.resume <- struct{}{}
+	return true
+}
 
-	// Update job status
-	completedJob := job.DeepCopy()
-	switch finalStatus {
-	case domain.StatusCompleted:
-		completedJob.Complete(exitCode)
-	case domain.StatusFailed:
-		completedJob.Fail(exitCode)
+// awaitFinish waits until the final status of the job is published, and
+// reports whether it was within timeout
+func (r *jobRun) awaitFinish(ctx context.Context, timeout time.Duration) bool {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-r.finished:
+		return true
+	case <-timer.C:
+		return false
+	case <-ctx.Done():
+		return false
 	}
+}
 
-	w.store.UpdateJob(completedJob)
+func (w *Worker) trackRun(run *jobRun) {
+	w.runsMu.Lock()
+	w.runs[run.job.Id] = run
+	w.runsMu.Unlock()
+}
 
-	// Cleanup cgroup
-	w.cgroup.CleanupCgroup(job.Id)
+func (w *Worker) lookupRun(jobID string) *jobRun {
+	w.runsMu.Lock()
+	defer w.runsMu.Unlock()
+	return w.runs[jobID]
+}
 
-	log.Debug("job monitoring completed",
-		"finalStatus", finalStatus,
-		"exitCode", exitCode,
-		"duration", duration)
+func (w *Worker) forgetRun(jobID string) {
+	w.runsMu.Lock()
+	delete(w.runs, jobID)
+	w.runsMu.Unlock()
 }
 
-func (w *Worker) cleanupFailedJob(job *domain.Job) {
-	failedJob := job.DeepCopy()
-	failedJob.Fail(-1)
-	w.store.UpdateJob(failedJob)
-	w.cgroup.CleanupCgroup(job.Id)
+// Helper methods (keeping existing implementations)
+func (w *Worker) getNextJobID() string {
+	nextID := atomic.AddInt64(&jobCounter, 1)
+	return fmt.Sprintf("%d", nextID)
+}
+
+// resolveLimits applies the configured defaults to unset limits
+func (w *Worker) resolveLimits(limits domain.ResourceLimits) domain.ResourceLimits {
+	cpuMillis, memoryBytes, ioBPS := w.config.Worker.DefaultLimits()
+	if limits.CPUMillis <= 0 {
+		limits.CPUMillis = cpuMillis
+	}
+	if limits.MemoryBytes <= 0 {
+		limits.MemoryBytes = memoryBytes
+	}
+	if limits.IOBPS <= 0 {
+		limits.IOBPS = ioBPS
+	}
+	return limits
+}
+
+// groupCgroupPath is the cgroup the jobs of a group sharing limits are created in
+func (w *Worker) groupCgroupPath(groupID string) string {
+	return filepath.Join(w.config.Cgroup.BaseDir, "group-"+groupID)
+}
+
+// createCgroup creates the cgroup of a job with its limits, within the cgroup
+// of its group, created first, when the group shares limits
+func (w *Worker) createCgroup(job *domain.Job) error {
+	groupDir := filepath.Dir(job.CgroupPath)
+	if groupDir == w.config.Cgroup.BaseDir {
+		return w.cgroup.Create(job.CgroupPath, job.Limits.CPUMillis, job.Limits.MemoryBytes, job.Limits.IOBPS)
+	}
+
+	w.groupCgroupsMu.Lock()
+	defer w.groupCgroupsMu.Unlock()
+
+	// limits of the group the kernel did not apply are reported with the job's
+	groupLimits := job.Spec.GroupLimits
+	groupErr := w.cgroup.CreateGroup(groupDir, groupLimits.CPUMillis, groupLimits.MemoryBytes, groupLimits.IOBPS)
+	var notApplied *resource.LimitNotAppliedError
+	if groupErr != nil {
+		if !errors.As(groupErr, &notApplied) {
+			return fmt.Errorf("group cgroup setup failed: %w", groupErr)
+		}
+		groupErr = fmt.Errorf("group cgroup: %w", groupErr)
+	}
+	err := w.cgroup.Create(job.CgroupPath, job.Limits.CPUMillis, job.Limits.MemoryBytes, job.Limits.IOBPS)
+	if err != nil && !errors.As(err, &notApplied) {
+		return err
+	}
+	return errors.Join(groupErr, err)
+}
+
+// removeGroupCgroup removes the group cgroup a removed job cgroup was in, if
+// it was the group's last
+func (w *Worker) removeGroupCgroup(cgroupPath string) {
+	groupDir := filepath.Dir(cgroupPath)
+	if cgroupPath == "" || groupDir == w.config.Cgroup.BaseDir {
+		return
+	}
+
+	w.groupCgroupsMu.Lock()
+	defer w.groupCgroupsMu.Unlock()
+
+	if err := w.cgroup.RemoveGroup(groupDir); err != nil {
+		w.logger.Warn("failed to remove group cgroup", "cgroupPath", groupDir, "error", err)
+	}
+}
+
+// checkGroupLimits checks a job of a group sharing limits runs in a cgroup,
+// the limits are set on its parent
+func checkGroupLimits(spec *domain.JobSpec, backend isolation.Backend) error {
+	if spec.GroupID == "" || spec.GroupLimits.IsZero() || backend.UsesCgroup() {
+		return nil
+	}
+	return fmt.Errorf("the job group shares limits, which %s isolation runs jobs without", backend.Mode())
+}
+
+// isolationBackend returns the backend for an isolation mode, "" meaning the
+// configured default, or for the sandbox runtime of that name when it is set
+func (w *Worker) isolationBackend(mode domain.IsolationMode, runtime string) (isolation.Backend, error) {
+	if runtime != "" {
+		return w.runtimeBackend(mode, runtime)
+	}
+	if mode == "" {
+		mode = domain.IsolationMode(w.config.Worker.Isolation)
+	}
+	if reason, ok := w.unavailable[mode]; ok {
+		return nil, fmt.Errorf("isolation mode %q is unavailable on this host: %s", mode, reason)
+	}
+	return isolation.NewBackend(mode)
+}
+
+// runtimeBackend returns the backend of a job run through a sandbox runtime.
+// The runtime isolates the job in place of an isolation mode, so the job may
+// not ask for one too.
+func (w *Worker) runtimeBackend(mode domain.IsolationMode, name string) (isolation.Backend, error) {
+	if mode != "" && mode != domain.IsolationSandbox {
+		return nil, fmt.Errorf("runtime %q isolates the job, it cannot also ask for %s isolation", name, mode)
+	}
+	runtime, ok := w.config.Worker.Runtimes[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown runtime %q", name)
+	}
+	// the sandbox runs in the job's cgroup
+	if reason, ok := w.unavailable[domain.IsolationCgroups]; ok {
+		return nil, fmt.Errorf("runtime %q is unavailable on this host: %s", name, reason)
+	}
+	if _, err := exec.LookPath(runtime.Path); err != nil {
+		return nil, fmt.Errorf("runtime %q: %w", name, err)
+	}
+	return isolation.NewRuntimeBackend(runtime), nil
+}
+
+func (w *Worker) createJobDomain(jobID, resolvedCommand string, spec *domain.JobSpec, backend isolation.Backend) *domain.Job {
+	limits := w.resolveLimits(spec.Limits)
+
+	w.logger.Debug("job resource limits applied",
+		"jobID", jobID,
+		"cpuMillis", limits.CPUMillis,
+		"memoryBytes", limits.MemoryBytes,
+		"ioBPS", limits.IOBPS,
+		"source", "client-specified or defaults")
+
+	restart := spec.Restart
+	if restart.Mode == "" {
+		restart.Mode = domain.RestartNever
+	}
+	if restart.MaxRestarts <= 0 {
+		restart.MaxRestarts = w.config.Worker.MaxRestarts
+	}
+
+	args := append([]string(nil), spec.Args...)
+	if spec.Shell {
+		args = spec.ShellArgs()
+	}
+
+	var cgroupPath string
+	if backend.UsesCgroup() {
+		cgroupPath = filepath.Join(w.config.Cgroup.BaseDir, "job-"+jobID)
+		if spec.GroupID != "" && !spec.GroupLimits.IsZero() {
+			cgroupPath = filepath.Join(w.groupCgroupPath(spec.GroupID), "job-"+jobID)
+		}
+	}
+
+	qos := spec.QoS
+	if qos == "" {
+		qos = domain.QoSBurstable
+	}
+
+	return &domain.Job{
+		Id:            jobID,
+		Command:       resolvedCommand,
+		Args:          args,
+		Env:           utils.CopyStringMap(spec.Env),
+		SecretEnv:     utils.CopyStringMap(spec.SecretEnv),
+		Limits:        limits,
+		Restart:       restart,
+		Probe:         probeWithDefaults(spec.Probe),
+		GroupId:       spec.GroupID,
+		Labels:        utils.CopyStringMap(spec.Labels),
+		Status:        domain.StatusInitializing,
+		CgroupPath:    cgroupPath,
+		Isolation:     backend.Mode(),
+		QoS:           qos,
+		Seccomp:       spec.Seccomp,
+		Runtime:       spec.Runtime,
+		TimeOffset:    spec.TimeOffset,
+		CleanEnv:      spec.CleanEnv,
+		CommandDigest: spec.CommandDigest,
+		Spec:          spec.Copy(),
+		StartTime:     time.Now(),
+	}
+}
+
+func probeWithDefaults(probe *domain.HealthProbe) *domain.HealthProbe {
+	if probe == nil {
+		return nil
+	}
+	withDefaults := probe.WithDefaults()
+	return withDefaults.Copy()
+}
+
+// initBinary returns the init binary the config names for the host's
+// architecture, "" to launch jobs from the running worker. Either must run on
+// the host, which is checked once here instead of failing every launch with
+// "exec format error".
+func initBinary(binaries map[string]string) (string, error) {
+	path := binaries[execarch.Host()]
+	if path == "" {
+		if err := execarch.Check(process.SelfExe); err != nil {
+			return "", fmt.Errorf("jobs cannot be launched from the running worker: %w", err)
+		}
+		return "", nil
+	}
+
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("invalid init binary for %s: %w", execarch.Host(), err)
+	}
+	if err := execarch.Check(path); err != nil {
+		return "", fmt.Errorf("invalid init binary for %s: %w", execarch.Host(), err)
+	}
+	return path, nil
+}
+
+func (w *Worker) setupCgroupControllers() error {
+	w.logger.Debug("setting up cgroup controllers for job isolation")
+
+	if err := w.cgroup.EnsureControllers(); err != nil {
+		return fmt.Errorf("failed to ensure controllers: %w", err)
+	}
+
+	w.logger.Debug("cgroup controllers setup completed successfully")
+	return nil
+}
+
+// startProcessSingleBinary starts a job using the same binary in init mode
+func (w *Worker) startProcessSingleBinary(ctx context.Context, run *jobRun) (platform.Command, error) {
+	ctx, span := tracing.Start(ctx, "job.launch",
+		tracing.JobID(run.job.Id),
+		attribute.String("job.isolation", string(run.job.Isolation)))
+	cmd, err := w.launchJobProcess(ctx, run)
+	tracing.End(span, err)
+	return cmd, err
+}
+
+// launchWatched starts the job's process under the watchdog. A launch running
+// past the deadline fails the job; a process it still starts later is killed.
+func (w *Worker) launchWatched(ctx context.Context, run *jobRun) (platform.Command, error) {
+	type launched struct {
+		cmd platform.Command
+		err error
+	}
+	result := make(chan launched, 1)
+	stuck := make(chan error, 1)
+	op := w.watchdog.Begin(watchdog.Launch, run.job.Id, func(err error) { stuck <- err })
+
+	go func() {
+		cmd, err := w.startProcessSingleBinary(ctx, run)
+		result <- launched{cmd: cmd, err: err}
+	}()
+
+	var err error
+	select {
+	case r := <-result:
+		if op.Finish() {
+			return r.cmd, r.err
+		}
+		// the watchdog fired as the launch returned
+		err = <-stuck
+		result <- r
+	case err = <-stuck:
+	}
+
+	w.events.Publish(events.Event{Type: events.JobStuck, JobID: run.job.Id, Err: err})
+	go func() {
+		r := <-result
+		if r.err != nil {
+			return
+		}
+		w.logger.Warn("stuck launch finished after the job failed, killing its process", "jobID", run.job.Id)
+		if proc := r.cmd.Process(); proc != nil {
+			_ = proc.Kill()
+		}
+		_ = r.cmd.Wait()
+	}()
+	return nil, err
+}
+
+// launchJobProcess starts the init process of a job, from the warm pool when
+// possible. The init process continues the trace in ctx.
+func (w *Worker) launchJobProcess(ctx context.Context, run *jobRun) (platform.Command, error) {
+	job := run.job
+
+	backend, err := w.isolationBackend(job.Isolation, job.Runtime)
+	if err != nil {
+		return nil, err
+	}
+
+	// Prepare environment with job information and mode indicator
+	env := w.buildJobEnvironmentSingleBinary(job, w.binaryPath, run.secrets, backend)
+	if traceparent := tracing.Inject(ctx); traceparent != "" {
+		env = append(env, "JOB_TRACEPARENT="+traceparent)
+	}
+
+	// Create isolation attributes
+	sysProcAttr := backend.SysProcAttr()
+
+	stdout := w.stdoutWriter(run)
+	stderr := w.stderrWriter(run)
+	stdin := run.takeStdin()
+
+	// Sockets are passed as descriptors 3 and up, init tells the job about
+	// them the way systemd does
+	if len(run.sockets) > 0 {
+		names := make([]string, len(run.sockets))
+		for i, socket := range job.Spec.Sockets {
+			names[i] = socket.Name
+		}
+		env = append(env, fmt.Sprintf("JOB_LISTEN_FDS=%d", len(run.sockets)), "JOB_LISTEN_FDNAMES="+strings.Join(names, ":"))
+	}
+
+	// A warm init process has its namespaces ready, but its stdin carries the
+	// job environment, so jobs reading stdin always start cold. It was started
+	// without the job's sockets, so jobs with sockets do too.
+	if stdin == nil && len(run.sockets) == 0 && w.initPool != nil && backend.Mode() == domain.IsolationFull {
+		if cmd := w.startWarm(run, env, stdout, stderr); cmd != nil {
+			trace.SpanFromContext(ctx).SetAttributes(attribute.Bool("job.warm", true))
+			return cmd, nil
+		}
+	}
+
+	output, childStdout, childStderr, err := openProcessOutput(stdout, stderr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output pipes: %w", err)
+	}
+	// the child has its own copies once started, and the pipes of a failed
+	// launch end right away
+	defer childStdout.Close()
+	defer childStderr.Close()
+
+	// Create launch configuration
+	launchConfig := &process.LaunchConfig{
+		Environment: env,
+		InitPath:    w.initPath,
+		SysProcAttr: sysProcAttr,
+		ExtraFiles:  run.sockets,
+		Stdout:      childStdout,
+		Stderr:      childStderr,
+		JobID:       job.Id,
+		Command:     job.Command,
+		Args:        job.Args,
+	}
+	if stdin != nil {
+		launchConfig.Stdin = stdin
+		// the child has its own copy once started
+		defer stdin.Close()
+	}
+
+	// Launch the process
+	result, err := w.processManager.LaunchProcess(ctx, launchConfig)
+	if err != nil {
+		return nil, err
+	}
+	run.pipes = output
+
+	// Move process to cgroup
+	if job.CgroupPath != "" {
+		if e := w.addProcessToCgroup(job.CgroupPath, result.PID); e != nil {
+			w.logger.Warn("failed to add process to cgroup", "error", e)
+		}
+	}
+	w.setOOMScoreAdj(job, result.PID)
+
+	w.logger.Debug("process launched using single binary", "jobID", job.Id, "pid", result.PID, "isolation", backend.Mode())
+	return result.Command, nil
+}
+
+// startWarm hands the job to a warm init process from the pool. It returns nil
+// when none is available or the hand-off failed, and the caller starts the job cold.
+func (w *Worker) startWarm(run *jobRun, env []string, stdout, stderr io.Writer) platform.Command {
+	job := run.job
+	wi := w.initPool.claim()
+	if wi == nil {
+		return nil
+	}
+
+	pid := wi.pid()
+	if job.CgroupPath != "" {
+		// the job must not start before it is in its cgroup
+		if err := w.addProcessToCgroup(job.CgroupPath, pid); err != nil {
+			w.logger.Warn("failed to add warm init process to cgroup, starting cold", "jobID", job.Id, "error", err)
+			go wi.release()
+			return nil
+		}
+	}
+
+	w.setOOMScoreAdj(job, pid)
+
+	if err := wi.handOff(env, stdout, stderr); err != nil {
+		w.logger.Warn("failed to hand job to warm init process, starting cold", "jobID", job.Id, "error", err)
+		go wi.release()
+		return nil
+	}
+
+	run.pipes = wi.pipes
+	w.logger.Debug("job started from warm pool", "jobID", job.Id, "pid", pid)
+	return wi.cmd
+}
+
+// launchWarmInit starts an init process in fresh namespaces that waits for a
+// job on its stdin
+func (w *Worker) launchWarmInit() (*warmInit, error) {
+	// one started during a hand-off would be left behind by the exec
+	w.handoffMu.RLock()
+	defer w.handoffMu.RUnlock()
+
+	backend, err := isolation.NewBackend(domain.IsolationFull)
+	if err != nil {
+		return nil, err
+	}
+
+	childStdin, control, err := os.Pipe()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create control pipe: %w", err)
+	}
+	// the child has its own copy once started
+	defer childStdin.Close()
+
+	wi := &warmInit{
+		control: control,
+		stdout:  &switchWriter{},
+		stderr:  &switchWriter{},
+		created: time.Now(),
+	}
+
+	output, childStdout, childStderr, err := openProcessOutput(wi.stdout, wi.stderr)
+	if err != nil {
+		control.Close()
+		return nil, fmt.Errorf("failed to create output pipes: %w", err)
+	}
+	defer childStdout.Close()
+	defer childStderr.Close()
+
+	result, err := w.processManager.LaunchProcess(context.Background(), &process.LaunchConfig{
+		Environment: append(w.platform.Environ(), "WORKER_MODE=init", "JOB_STANDBY=true"),
+		InitPath:    w.initPath,
+		SysProcAttr: backend.SysProcAttr(),
+		Stdin:       childStdin,
+		Stdout:      childStdout,
+		Stderr:      childStderr,
+		JobID:       "warm-init",
+	})
+	if err != nil {
+		control.Close()
+		return nil, err
+	}
+	wi.pipes = output
+
+	wi.cmd = result.Command
+	return wi, nil
+}
+
+// stdoutWriter returns the job's stdout destination, teeing raw output to the
+// capture file when one was requested
+func (w *Worker) stdoutWriter(run *jobRun) io.Writer {
+	output := New(w.store, run.job.Id).WithRedactor(w.redactor).WithShipper(w.logShipper, jobLogLabels(run.job, "stdout")).WithActivity(&run.lastOutput).WithCoalescer(run.output).WithLimiter(run.limiter)
+	if run.stdoutCapture == nil {
+		return output
+	}
+	return capturedOutput{Writer: io.MultiWriter(run.stdoutCapture, output), output: output}
+}
+
+// capturedOutput is job output teed to a capture file, flushed as its
+// OutputWriter
+type capturedOutput struct {
+	io.Writer
+	output *OutputWriter
+}
+
+func (c capturedOutput) Flush() {
+	c.output.Flush()
+}
+
+// stderrWriter returns the job's stderr destination
+func (w *Worker) stderrWriter(run *jobRun) io.Writer {
+	return New(w.store, run.job.Id).WithRedactor(w.redactor).WithShipper(w.logShipper, jobLogLabels(run.job, "stderr")).WithActivity(&run.lastOutput).WithCoalescer(run.output).WithLimiter(run.limiter)
+}
+
+// outputCoalescer returns what gathers the job's output into larger chunks,
+// nil when the job or the server store every write as it comes
+func (w *Worker) outputCoalescer(jobID string, spec *domain.JobSpec) *outputCoalescer {
+	interval := spec.OutputFlush
+	if interval == 0 {
+		interval = w.config.OutputBuffer.FlushInterval
+	}
+	if interval <= 0 {
+		return nil
+	}
+	return newOutputCoalescer(w.store, jobID, interval, w.config.OutputBuffer.FlushBytes)
+}
+
+// outputLimiter returns what holds the job's output to its rate limit within
+// the server's, nil when neither sets one
+func (w *Worker) outputLimiter(run *jobRun, spec *domain.JobSpec) *outputLimiter {
+	server := domain.OutputLimit{
+		BytesPerSecond: w.config.OutputBuffer.RateLimit,
+		Action:         domain.OutputLimitAction(w.config.OutputBuffer.RateLimitAction),
+	}
+	limit := spec.OutputLimit.Within(server)
+	if limit.BytesPerSecond == 0 {
+		return nil
+	}
+
+	return newOutputLimiter(limit, run.wake, func() {
+		w.logger.Warn("job output over its rate limit, dropping it", "jobID", run.job.Id, "bytesPerSecond", limit.BytesPerSecond)
+		w.events.Publish(events.Event{Type: events.JobOutputLimited, JobID: run.job.Id,
+			Err: fmt.Errorf("output over %d bytes per second, dropping what is over", limit.BytesPerSecond)})
+	})
+}
+
+// jobLogLabels returns the job metadata attached to shipped log entries
+func jobLogLabels(job *domain.Job, stream string) map[string]string {
+	return map[string]string{
+		"command": filepath.Base(job.Command),
+		"stream":  stream,
+	}
+}
+
+// buildJobEnvironmentSingleBinary builds environment for single binary mode
+func (w *Worker) buildJobEnvironmentSingleBinary(job *domain.Job, execPath string, secrets map[string]string, backend isolation.Backend) []string {
+	baseEnv := w.platform.Environ()
+	if job.CleanEnv {
+		// init still needs the worker's own settings, it drops them before the exec
+		baseEnv = workerSettings(baseEnv)
+	}
+
+	// Job-specific environment with mode indicator
+	jobEnv := []string{
+		"WORKER_MODE=init", // This tells the binary to run in init mode
+		fmt.Sprintf("JOB_ID=%s", job.Id),
+		fmt.Sprintf("JOB_COMMAND=%s", job.Command),
+		fmt.Sprintf("JOB_CGROUP_HOST_PATH=%s", job.CgroupPath), // Host path for debugging
+		fmt.Sprintf("JOB_ARGS_COUNT=%d", len(job.Args)),
+		"HOST_NETWORKING=true",
+		fmt.Sprintf("WORKER_BINARY_PATH=%s", execPath), // For reference
+		fmt.Sprintf("JOB_CPU_LIMIT_MILLIS=%d", job.Limits.CPUMillis),
+		fmt.Sprintf("JOB_MEMORY_LIMIT_BYTES=%d", job.Limits.MemoryBytes),
+		fmt.Sprintf("JOB_IO_LIMIT_BPS=%d", job.Limits.IOBPS),
+	}
+
+	jobEnv = append(jobEnv, backend.InitEnv(job)...)
+
+	if job.Seccomp != domain.SeccompNone {
+		jobEnv = append(jobEnv, fmt.Sprintf("JOB_SECCOMP=%s", job.Seccomp))
+	}
+	if w.config.CoreDumps.Enabled {
+		jobEnv = append(jobEnv, fmt.Sprintf("JOB_CORE_LIMIT_BYTES=%d", w.config.CoreDumps.MaxBytes))
+	}
+	if job.TimeOffset != 0 {
+		jobEnv = append(jobEnv, fmt.Sprintf("JOB_TIME_OFFSET=%d", int64(job.TimeOffset/time.Second)))
+	}
+	if job.CleanEnv {
+		jobEnv = append(jobEnv, "JOB_CLEAN_ENV=true")
+	}
+	if job.CommandDigest != "" {
+		jobEnv = append(jobEnv, fmt.Sprintf("JOB_COMMAND_SHA256=%s", job.CommandDigest))
+	}
+
+	if job.Workspace != "" {
+		// a bind mount outside a private mount namespace would show up on the host
+		mountPath := w.config.Workspace.MountPath
+		if backend.Mode() != domain.IsolationFull {
+			mountPath = ""
+		}
+		jobEnv = append(jobEnv,
+			fmt.Sprintf("JOB_WORKSPACE=%s", job.Workspace),
+			fmt.Sprintf("JOB_WORKSPACE_MOUNT=%s", mountPath))
+	}
+
+	// Add job arguments
+	for i, arg := range job.Args {
+		jobEnv = append(jobEnv, fmt.Sprintf("JOB_ARG_%d=%s", i, arg))
+	}
+
+	// The configured base variables override the host's, for every job
+	for _, name := range slices.Sorted(maps.Keys(w.config.Worker.BaseEnv)) {
+		baseEnv = append(baseEnv, fmt.Sprintf("%s=%s", name, w.config.Worker.BaseEnv[name]))
+	}
+
+	// Client environment goes between the host environment and the job variables,
+	// so it can override inherited values but never the worker's own JOB_* settings
+	userEnv := make([]string, 0, len(job.Env)+len(secrets))
+	for name, value := range job.Env {
+		userEnv = append(userEnv, fmt.Sprintf("%s=%s", name, value))
+	}
+	for name, value := range secrets {
+		userEnv = append(userEnv, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(userEnv)
+
+	env := append(baseEnv, userEnv...)
+	return append(env, jobEnv...)
+}
+
+// workerSettings keeps the WORKER_* variables of an environment, which
+// configure the init process
+func workerSettings(environ []string) []string {
+	var settings []string
+	for _, entry := range environ {
+		if strings.HasPrefix(entry, "WORKER_") {
+			settings = append(settings, entry)
+		}
+	}
+	return settings
+}
+
+// registerSecrets adds secret values to the redactor so they are masked in job output
+func (w *Worker) registerSecrets(secrets map[string]string) {
+	for _, value := range secrets {
+		w.redactor.AddValue(value)
+	}
+}
+
+// releaseSecrets drops the redactor registrations made by registerSecrets
+func (w *Worker) releaseSecrets(secrets map[string]string) {
+	for _, value := range secrets {
+		w.redactor.RemoveValue(value)
+	}
+}
+
+// addProcessToCgroup moves a process to the specified cgroup
+func (w *Worker) addProcessToCgroup(cgroupPath string, pid int32) error {
+	procsFile := filepath.Join(cgroupPath, "cgroup.procs")
+	pidBytes := []byte(fmt.Sprintf("%d", pid))
+	return w.platform.WriteFile(procsFile, pidBytes, 0644)
+}
+
+// setOOMScoreAdj applies the OOM killer adjustment of the job's QoS class to
+// its process, which the processes it starts inherit
+func (w *Worker) setOOMScoreAdj(job *domain.Job, pid int32) {
+	adj := job.QoS.Settings(job.Limits).OOMScoreAdj
+	if adj == 0 {
+		return
+	}
+	path := fmt.Sprintf("/proc/%d/oom_score_adj", pid)
+	if err := w.platform.WriteFile(path, []byte(strconv.Itoa(adj)), 0644); err != nil {
+		w.logger.Warn("failed to set OOM score adjustment", "jobID", job.Id, "qos", job.QoS, "error", err)
+	}
+}
+
+func (w *Worker) updateJobAsRunning(job *domain.Job, processCmd platform.Command) {
+	cmd := processCmd.Process()
+	if cmd == nil {
+		w.logger.Warn("process is nil after start", "jobID", job.Id)
+		return
+	}
+
+	runningJob := job.DeepCopy()
+	runningJob.Pid = int32(cmd.Pid())
+
+	if err := runningJob.MarkAsRunning(runningJob.Pid); err != nil {
+		w.logger.Warn("domain validation failed for running status", "jobID", job.Id, "error", err)
+		return
+	}
+
+	job.Status, job.StatusReason = runningJob.Status, runningJob.StatusReason
+	runningJob.StartTime = time.Now()
+	w.publishJob(runningJob)
+}
+
+func (w *Worker) monitorJob(cmd platform.Command, run *jobRun) {
+	job := run.job
+	log := w.logger.WithField("jobID", job.Id)
+	startTime := time.Now()
+	defer w.releaseSecrets(run.secrets)
+	defer w.forgetRun(job.Id)
+
+	var finalStatus domain.JobStatus
+	var exitCode int32
+	var failure string    // why the job failed other than by exiting
+	var coreRun time.Time // start of the last run that dumped core
+	crashes := 0
+	crashLoop := domain.CrashLoop{Threshold: w.config.Worker.CrashLoopThreshold, Window: w.config.Worker.CrashLoopWindow}
+
+	for {
+		// Wait for process completion
+		runStart := time.Now()
+		stopProbe := w.startProbe(run, cmd)
+		stopIdleWatch := w.watchIdle(run)
+		err := cmd.Wait()
+		run.pipes.wait()
+		w.handoffMu.RLock()
+		finalStatus, exitCode = exitStatus(err)
+		stopIdleWatch()
+		stopProbe()
+		run.output.Flush()
+		if dumpedCore(err) {
+			coreRun = runStart
+		}
+
+		if run.stopRequested() || !job.Restart.ShouldRestart(finalStatus, job.Restarts, job.Restart.MaxRestarts) {
+			break
+		}
+
+		restartReason := fmt.Sprintf("restarted (%s)", exitReason(exitCode))
+		if finalStatus != domain.StatusCompleted && crashLoop.Failed(time.Now()) {
+			// held jobs restart right away once resumed, with a clean slate
+			w.handoffMu.RUnlock()
+			resumed := w.holdInCrashLoop(run, exitCode)
+			w.handoffMu.RLock()
+			if !resumed {
+				break
+			}
+			crashLoop.Reset()
+			crashes = 0
+			restartReason = "resumed from a crash loop by a user"
+		} else {
+			// a run that stayed up long enough is not part of a crash loop
+			if time.Since(runStart) >= w.config.Worker.RestartResetAfter {
+				crashes = 0
+			}
+			delay := domain.RestartBackoff(w.config.Worker.RestartBackoff, w.config.Worker.RestartMaxBackoff, crashes)
+			crashes++
+
+			log.Info("job exited, scheduling restart",
+				"exitCode", exitCode,
+				"restart", job.Restarts+1,
+				"maxRestarts", job.Restart.MaxRestarts,
+				"delay", delay)
+
+			w.handoffMu.RUnlock()
+			restart := run.waitForRestart(delay)
+			w.handoffMu.RLock()
+			if !restart {
+				break
+			}
+		}
+
+		// the request context is long gone, restarts only continue its trace
+		next, err := w.startProcessSingleBinary(run.trace, run)
+		if err != nil {
+			log.Error("job restart failed", "error", err)
+			finalStatus, exitCode = domain.StatusFailed, -1
+			failure = fmt.Sprintf("restart failed: %v", err)
+			break
+		}
+
+		cmd = next
+		run.cmd = next
+		run.health = domain.HealthUnknown
+		run.idle = false
+		w.updateJobAsRestarted(job, cmd, restartReason)
+		w.handoffMu.RUnlock()
+	}
+	defer w.handoffMu.RUnlock()
+
+	duration := time.Since(startTime)
+	run.closeCapture()
+	run.closeStdin()
+	run.stopInputs()
+	run.releaseSockets()
+
+	// Update job status
+	completedJob := job.DeepCopy()
+	switch {
+	case run.stopRequested():
+		completedJob.Stop("stopped by a user")
+		// a process that exited on its own after the stop signal keeps its code
+		if finalStatus != "" {
+			completedJob.ExitCode = exitCode
+		}
+	case finalStatus == domain.StatusCompleted:
+		completedJob.Complete(exitCode, exitReason(exitCode))
+	case finalStatus == domain.StatusFailed && failure != "":
+		completedJob.Fail(exitCode, failure)
+	case finalStatus == domain.StatusFailed:
+		completedJob.Fail(exitCode, exitReason(exitCode))
+	}
+	completedJob.Health = run.health
+	completedJob.Idle = run.idle
+	completedJob.OutputDropped = run.limiter.Dropped()
+	completedJob.WorkspaceBytes = w.workspaceUsage(job)
+	completedJob.CPUThrottled = w.cpuThrottling(job)
+	if !coreRun.IsZero() {
+		completedJob.CoreDump, completedJob.CoreDumpBytes = w.findCoreDump(job, coreRun)
+	}
+	completedJob.Result, completedJob.ResultError = w.readResult(job)
+
+	w.publishJob(completedJob)
+	close(run.finished)
+
+	// Cleanup cgroup
+	w.cleanupCgroup(run.trace, job.Id, job.CgroupPath)
+
+	if w.offloader != nil {
+		done := make(chan struct{})
+		w.offloads.Store(job.Id, done)
+		go func() {
+			defer close(done)
+			defer w.offloads.Delete(job.Id)
+			w.offloadJob(completedJob, run.retainWorkspace)
+		}()
+	}
+	if !run.retainWorkspace {
+		w.scheduleWorkspaceCleanup(job.Id)
+	}
+	go w.hooks.PostStop(run.trace, completedJob)
+
+	log.Debug("job monitoring completed",
+		"finalStatus", completedJob.Status,
+		"exitCode", exitCode,
+		"restarts", job.Restarts,
+		"duration", duration)
+}
+
+// holdInCrashLoop publishes a job that kept failing as CRASH_LOOP and waits
+// until a user resumes it. It returns false when the job is stopped instead.
+func (w *Worker) holdInCrashLoop(run *jobRun, exitCode int32) bool {
+	if !run.hold() {
+		return false
+	}
+
+	threshold, window := w.config.Worker.CrashLoopThreshold, w.config.Worker.CrashLoopWindow
+	held := run.job.DeepCopy()
+	held.HoldInCrashLoop(exitCode, fmt.Sprintf("failed %d times within %v, held until resumed (%s)", threshold, window, exitReason(exitCode)))
+	w.publishJob(held)
+
+	w.events.Publish(events.Event{
+		Type:  events.JobCrashLoop,
+		JobID: held.Id,
+		Err:   fmt.Errorf("failed %d times within %v", threshold, window),
+	})
+	w.logger.Warn("job is crash looping, holding it until resumed",
+		"jobID", held.Id, "exitCode", exitCode, "failures", threshold, "window", window)
+
+	return run.awaitResume()
+}
+
+// exitStatus maps the result of waiting on a job process to a final status.
+// Processes the worker started report an exec.ExitError, adopted ones a
+// processExit.
+func exitStatus(err error) (domain.JobStatus, int32) {
+	if err == nil {
+		return domain.StatusCompleted, 0
+	}
+
+	var exitErr interface{ ExitCode() int }
+	if errors.As(err, &exitErr) {
+		return domain.StatusFailed, int32(exitErr.ExitCode())
+	}
+	return "", 0
+}
+
+// exitReason says how a job's process ended, by the exit code exitStatus returned
+func exitReason(exitCode int32) string {
+	if exitCode < 0 {
+		return "killed by a signal"
+	}
+	return fmt.Sprintf("exited with code %d", exitCode)
+}
+
+// dumpedCore reports whether a job process was killed by a signal that dumped core
+func dumpedCore(err error) bool {
+	var exitErr interface{ Sys() any }
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	return ok && status.CoreDump()
+}
+
+// findCoreDump looks up the core file a crashed job left in its workspace
+func (w *Worker) findCoreDump(job *domain.Job, since time.Time) (string, int64) {
+	if !w.config.CoreDumps.Enabled || job.Workspace == "" {
+		return "", 0
+	}
+
+	path, size := coredump.Find(job.Workspace, w.corePattern, since)
+	if path == "" {
+		w.logger.Warn("job dumped core but no core file is in its workspace", "jobID", job.Id, "corePattern", w.corePattern.Raw)
+		return "", 0
+	}
+	w.logger.Info("job dumped core", "jobID", job.Id, "path", path, "bytes", size)
+	return path, size
+}
+
+// readResult reads the result the job left in its workspace, with the job's
+// secrets still masked, or why it was not kept
+func (w *Worker) readResult(job *domain.Job) ([]byte, string) {
+	if job.Workspace == "" {
+		return nil, ""
+	}
+
+	data, err := workspace.ReadResult(job.Workspace, domain.JobResultFile, domain.MaxJobResultBytes)
+	if err == nil && data != nil {
+		err = domain.ValidateJobResult(data)
+	}
+	if err != nil {
+		w.logger.Warn("job result not kept", "jobID", job.Id, "error", err)
+		return nil, err.Error()
+	}
+	return w.redactor.Redact(data), ""
+}
+
+// startProbe runs the job's liveness probe against the current process until the
+// returned function is called. The function waits for the probe to finish.
+func (w *Worker) startProbe(run *jobRun, cmd platform.Command) func() {
+	job := run.job
+	proc := cmd.Process()
+	if job.Probe == nil || proc == nil {
+		return func() {}
+	}
+
+	checker, err := health.NewChecker(job.Probe)
+	if err != nil {
+		w.logger.Warn("health probe disabled", "jobID", job.Id, "error", err)
+		return func() {}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		health.Run(ctx, job.Probe, checker, int32(proc.Pid()), func(failures int32, err error) {
+			w.handleProbeResult(run, proc, failures, err)
+		})
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// handleProbeResult records health transitions. An unhealthy job is killed when
+// its restart policy can relaunch it, otherwise it is only marked UNHEALTHY.
+func (w *Worker) handleProbeResult(run *jobRun, proc platform.Process, failures int32, err error) {
+	log := w.logger.WithField("jobID", run.job.Id)
+
+	state := domain.HealthHealthy
+	if failures > 0 {
+		log.Debug("health probe failed", "failures", failures, "threshold", run.job.Probe.FailureThreshold, "error", err)
+		if failures < run.job.Probe.FailureThreshold {
+			return
+		}
+		state = domain.HealthUnhealthy
+	}
+
+	if state == run.health {
+		return
+	}
+	run.health = state
+	w.setJobHealth(run.job.Id, state)
+
+	if state != domain.HealthUnhealthy {
+		return
+	}
+
+	if run.job.Restart.Mode == domain.RestartNever {
+		log.Warn("job is unhealthy", "failures", failures, "error", err)
+		return
+	}
+
+	log.Warn("job is unhealthy, killing it to apply the restart policy", "failures", failures, "error", err)
+	if e := proc.Kill(); e != nil {
+		log.Warn("failed to kill unhealthy job", "error", e)
+	}
+}
+
+// setJobHealth updates the health of a running job in the store
+func (w *Worker) setJobHealth(jobID string, state domain.HealthState) {
+	job, exists := w.store.GetJob(jobID)
+	if !exists || !job.IsRunning() {
+		return
+	}
+	job.Health = state
+	w.publishJob(job)
+}
+
+// updateJobAsRestarted records the process of a relaunched job
+func (w *Worker) updateJobAsRestarted(job *domain.Job, processCmd platform.Command, reason string) {
+	job.Restarts++
+	pid := job.Pid
+	if proc := processCmd.Process(); proc != nil {
+		pid = int32(proc.Pid())
+	}
+	job.MarkAsRestarted(pid, reason)
+	w.publishJob(job.DeepCopy())
+}
+
+// offloadJob uploads a finished job's output and artifacts to object storage and,
+// when configured, frees the local copies
+func (w *Worker) offloadJob(job *domain.Job, retainWorkspace bool) {
+	log := w.logger.WithField("jobID", job.Id)
+
+	output, _, err := w.store.GetOutput(job.Id)
+	if err != nil {
+		log.Warn("cannot offload job output", "error", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.config.Offload.Timeout)
+	defer cancel()
+
+	location, err := w.offloader.OffloadJob(ctx, job, output)
+	if err != nil {
+		log.Error("job offload failed, keeping output locally", "error", err)
+		return
+	}
+
+	if !w.offloader.EvictLocal() {
+		return
+	}
+
+	if e := w.store.EvictOutput(job.Id, location); e != nil {
+		log.Warn("failed to evict offloaded output", "error", e)
+	}
+	if job.Workspace != "" && !retainWorkspace {
+		if e := os.RemoveAll(job.Workspace); e != nil {
+			log.Warn("failed to remove offloaded workspace", "workspace", job.Workspace, "error", e)
+		}
+	}
+
+	log.Debug("job output offloaded and evicted locally", "location", location)
+}
+
+// DeleteOffloaded removes what was offloaded of a finished job from object
+// storage, once an offload in progress ended. It fails when the job's output
+// was offloaded but offloading is no longer enabled.
+func (w *Worker) DeleteOffloaded(ctx context.Context, job *domain.Job) error {
+	if w.offloader == nil {
+		if job.OutputLocation != "" {
+			return fmt.Errorf("output was offloaded to %s, but offloading is disabled", job.OutputLocation)
+		}
+		return nil
+	}
+
+	if done, ok := w.offloads.Load(job.Id); ok {
+		select {
+		case <-done.(chan struct{}):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.config.Offload.Timeout)
+	defer cancel()
+	return w.offloader.DeleteJob(ctx, job.Id)
+}
+
+// cleanupCgroup queues removal of the job's cgroup and publishes the result.
+// The removal is a span of the trace in ctx, ending once the cgroup is gone.
+// A removal running past the watchdog deadline is published as failed.
+func (w *Worker) cleanupCgroup(ctx context.Context, jobID, cgroupPath string) {
+	_, span := tracing.Start(ctx, "job.cgroup.cleanup", tracing.JobID(jobID))
+	cleanedUp := func(err error) {
+		tracing.End(span, err)
+		w.events.Publish(events.Event{Type: events.JobCleanedUp, JobID: jobID, Err: err})
+	}
+
+	op := w.watchdog.Begin(watchdog.Cleanup, jobID, func(err error) {
+		w.events.Publish(events.Event{Type: events.JobStuck, JobID: jobID, Err: err})
+		cleanedUp(err)
+	})
+	w.cgroup.CleanupCgroup(jobID, cgroupPath, func(err error) {
+		if err == nil {
+			w.removeGroupCgroup(cgroupPath)
+		}
+		if !op.Finish() {
+			w.logger.Warn("stuck cgroup cleanup finished", "jobID", jobID, "error", err)
+			return
+		}
+		cleanedUp(err)
+	})
+}
+
+// publishJob announces a change to the job record
+func (w *Worker) publishJob(job *domain.Job) {
+	w.events.Publish(events.Event{Type: events.JobUpdated, Job: job})
+}
+
+// setupWorkspace claims a staged upload as the job workspace, or creates an empty one
+func (w *Worker) setupWorkspace(jobID, uploadID string) (string, error) {
+	if uploadID != "" {
+		return w.workspaces.Claim(uploadID, jobID)
+	}
+	return w.workspaces.Create(jobID)
+}
+
+// workspaceUsage returns the disk usage of a job workspace, logging failures
+func (w *Worker) workspaceUsage(job *domain.Job) int64 {
+	if job.Workspace == "" {
+		return 0
+	}
+
+	usage, err := workspace.Usage(job.Workspace)
+	if err != nil {
+		w.logger.Warn("failed to measure workspace usage", "jobID", job.Id, "error", err)
+	}
+	return usage
+}
+
+// cpuThrottling reads how much the CPU limit held the job back over its run,
+// before its cgroup is removed
+func (w *Worker) cpuThrottling(job *domain.Job) float64 {
+	if job.CgroupPath == "" {
+		return 0
+	}
+
+	usage, err := metrics.ReadCgroup(job.CgroupPath)
+	if err != nil {
+		w.logger.Debug("failed to read CPU throttling", "jobID", job.Id, "error", err)
+		return 0
+	}
+	if share := usage.ThrottledShare(); share > 0 {
+		w.logger.Info("job was throttled by its CPU limit", "jobID", job.Id,
+			"throttledPercent", share, "throttledTime", usage.CPUThrottledTime)
+		return share
+	}
+	return 0
+}
+
+// cleanupWorkspace removes the workspace of a job that never started
+func (w *Worker) cleanupWorkspace(jobID string) {
+	if err := w.workspaces.Remove(jobID); err != nil {
+		w.logger.Warn("workspace cleanup failed", "jobID", jobID, "error", err)
+	}
+}
+
+// scheduleWorkspaceCleanup removes a finished job's workspace once the retention period has passed
+func (w *Worker) scheduleWorkspaceCleanup(jobID string) {
+	time.AfterFunc(w.config.Workspace.Retention, func() {
+		w.cleanupWorkspace(jobID)
+	})
+}
+
+// pruneUploadsLoop periodically removes uploads that no job claimed in time
+func (w *Worker) pruneUploadsLoop() {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		w.workspaces.PruneUploads(w.config.Workspace.UploadTTL)
+	}
 }
 
 func (w *Worker) updateJobStatus(job *domain.Job, result *process.CleanupResult) {
 	stoppedJob := job.DeepCopy()
 
+	// a job the user stopped is STOPPED however it had to be killed
 	switch result.Method {
 	case "graceful":
-		stoppedJob.Stop()
-	case "forced", "force_failed":
-		stoppedJob.Fail(-1)
+		stoppedJob.Stop("stopped by a user")
+	case "forced":
+		stoppedJob.Stop("killed by a user after the grace period")
 	case "already_dead":
-		stoppedJob.Complete(0)
+		stoppedJob.Complete(0, "exited before it was stopped")
 	default:
-		stoppedJob.Fail(-1)
+		stoppedJob.Fail(-1, fmt.Sprintf("could not be stopped (%s)", result.Method))
 	}
 
-	w.store.UpdateJob(stoppedJob)
+	w.publishJob(stoppedJob)
 }
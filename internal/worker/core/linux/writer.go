@@ -1,31 +1,145 @@
 package linux
 
 import (
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/internal/worker/logsink"
+	"worker/internal/worker/redact"
 	"worker/internal/worker/state"
 )
 
+// redactIdle is how long output the redactor held back waits for the rest of
+// a secret. Once the job is quiet that long, a prompt or progress line without
+// a newline is written as it is.
+const redactIdle = 200 * time.Millisecond
+
 type OutputWriter struct {
-	jobId string
-	store state.Store
+	jobId    string
+	store    state.Store
+	shipper  *logsink.Shipper
+	labels   map[string]string
+	redactor *redact.Stream
+	activity *atomic.Int64 // time of the last write in Unix nanoseconds, nil if not tracked
+	coalesce *outputCoalescer
+	limiter  *outputLimiter
+
+	mutex     sync.Mutex  // keeps idle flushes in order with writes
+	idle      *time.Timer // flushes the redactor's held back output, nil until it holds some
+	lastWrite time.Time
 }
 
 func New(store state.Store, jobId string) *OutputWriter {
 	return &OutputWriter{store: store, jobId: jobId}
 }
 
+// WithRedactor masks secrets in the output before it is stored or shipped.
+// The end of a write that may start a secret is held until the next write,
+// until the stream is quiet for redactIdle, or until Flush.
+func (w *OutputWriter) WithRedactor(redactor *redact.Redactor) *OutputWriter {
+	w.redactor = redactor.Stream()
+	return w
+}
+
+// WithShipper forwards everything written to the given shipper, tagged with the labels
+func (w *OutputWriter) WithShipper(shipper *logsink.Shipper, labels map[string]string) *OutputWriter {
+	w.shipper = shipper
+	w.labels = labels
+	return w
+}
+
+// WithActivity stores the time of every write in last, for the idle policy
+func (w *OutputWriter) WithActivity(last *atomic.Int64) *OutputWriter {
+	w.activity = last
+	return w
+}
+
+// WithCoalescer stores the output through the given coalescer, nil to store
+// every write as it comes. The shipper still gets every write.
+func (w *OutputWriter) WithCoalescer(coalescer *outputCoalescer) *OutputWriter {
+	w.coalesce = coalescer
+	return w
+}
+
+// WithLimiter holds the output to the job's rate limit, nil for none. Output
+// the limiter drops is neither stored nor shipped.
+func (w *OutputWriter) WithLimiter(limiter *outputLimiter) *OutputWriter {
+	w.limiter = limiter
+	return w
+}
+
 // Write implements the io.Writer interface
 func (w *OutputWriter) Write(p []byte) (n int, err error) {
 	if len(p) == 0 {
 		return 0, nil
 	}
+	if w.activity != nil {
+		w.activity.Store(time.Now().UnixNano())
+	}
 
 	// Create a copy of the data to prevent races
 	// The underlying buffer p might be reused by the caller
 	chunk := make([]byte, len(p))
 	copy(chunk, p)
 
-	w.store.WriteToBuffer(w.jobId, chunk)
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// secrets are masked before the limiter may cut the chunk, a secret cut
+	// in two would no longer match and its first part would be kept
+	w.write(w.redactor.Redact(chunk))
+	w.lastWrite = time.Now()
+	if w.redactor.Held() > 0 {
+		if w.idle == nil {
+			w.idle = time.AfterFunc(redactIdle, w.flushIdle)
+		} else {
+			w.idle.Reset(redactIdle)
+		}
+	}
 
 	// Return the number of bytes written (always successful)
 	return len(p), nil
 }
+
+// Flush stores and ships the output the redactor held back, once the stream
+// ended
+func (w *OutputWriter) Flush() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.idle != nil {
+		w.idle.Stop()
+	}
+	w.write(w.redactor.Flush())
+}
+
+// flushIdle writes the output the redactor held back once no write came for
+// redactIdle. A write that raced the timer has reset it and is waited for.
+func (w *OutputWriter) flushIdle() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if time.Since(w.lastWrite) < redactIdle {
+		return
+	}
+	w.write(w.redactor.Flush())
+}
+
+// write stores and ships redacted output, within the rate limit
+func (w *OutputWriter) write(chunk []byte) {
+	if len(chunk) == 0 {
+		return
+	}
+	if w.limiter != nil {
+		if chunk = w.limiter.Limit(chunk); len(chunk) == 0 {
+			return
+		}
+	}
+
+	if w.coalesce != nil {
+		w.coalesce.Write(chunk)
+	} else {
+		w.store.WriteToBuffer(w.jobId, chunk)
+	}
+	w.shipper.Ship(w.jobId, w.labels, chunk)
+}
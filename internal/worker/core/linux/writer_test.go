@@ -0,0 +1,97 @@
+package linux
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/state/statefakes"
+	"worker/pkg/config"
+)
+
+func TestOutputWriterRedactsBeforeTruncating(t *testing.T) {
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	redactor.AddValue("hunter2-secret")
+
+	store := &statefakes.FakeStore{}
+	limiter := newOutputLimiter(domain.OutputLimit{BytesPerSecond: 16, Action: domain.OutputTruncate}, nil, nil)
+	w := New(store, "1").WithRedactor(redactor).WithLimiter(limiter)
+
+	// the rate allows 16 bytes, which would cut the secret after "hunter2"
+	if n, err := w.Write([]byte("password=hunter2-secret\n")); err != nil || n != 24 {
+		t.Fatalf("unexpected write result %d, %v", n, err)
+	}
+
+	if store.WriteToBufferCallCount() != 1 {
+		t.Fatalf("expected the allowed output to be stored, got %d writes", store.WriteToBufferCallCount())
+	}
+	_, chunk := store.WriteToBufferArgsForCall(0)
+	if got := string(chunk); got != "password=***REDA" || strings.Contains(got, "hunter") {
+		t.Errorf("expected the truncated output to be redacted, got %q", got)
+	}
+}
+
+func TestOutputWriterRedactsSecretSplitAcrossWrites(t *testing.T) {
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true, Mask: "***"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	redactor.AddValue("hunter2-secret")
+
+	store := &statefakes.FakeStore{}
+	w := New(store, "1").WithRedactor(redactor)
+
+	for _, p := range []string{"password=hunter2", "-secret done\npw=hunt"} {
+		if _, err := w.Write([]byte(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	w.Flush()
+
+	var stored []byte
+	for i := 0; i < store.WriteToBufferCallCount(); i++ {
+		_, chunk := store.WriteToBufferArgsForCall(i)
+		stored = append(stored, chunk...)
+	}
+	if string(stored) != "password=*** done\npw=hunt" {
+		t.Errorf("expected the split secret to be masked and the rest flushed, got %q", stored)
+	}
+}
+
+func TestOutputWriterFlushesHeldOutputWhenIdle(t *testing.T) {
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true, Mask: "***", Patterns: []string{`ghp_[A-Za-z0-9]+`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	store := &statefakes.FakeStore{}
+	w := New(store, "1").WithRedactor(redactor)
+
+	// with patterns the unfinished line is held back, a prompt has no newline
+	if _, err := w.Write([]byte("Continue? [y/N] ")); err != nil {
+		t.Fatal(err)
+	}
+	if store.WriteToBufferCallCount() != 0 {
+		t.Fatalf("expected the unfinished line held back, got %d writes", store.WriteToBufferCallCount())
+	}
+
+	deadline := time.Now().Add(5 * redactIdle)
+	for store.WriteToBufferCallCount() == 0 && time.Now().Before(deadline) {
+		time.Sleep(redactIdle / 10)
+	}
+	if store.WriteToBufferCallCount() != 1 {
+		t.Fatalf("expected the prompt written once the job is quiet, got %d writes", store.WriteToBufferCallCount())
+	}
+	if _, chunk := store.WriteToBufferArgsForCall(0); string(chunk) != "Continue? [y/N] " {
+		t.Errorf("unexpected prompt %q", chunk)
+	}
+
+	w.Flush()
+	if store.WriteToBufferCallCount() != 1 {
+		t.Errorf("expected nothing left to flush, got %d writes", store.WriteToBufferCallCount())
+	}
+}
@@ -5,8 +5,12 @@ package core
 import (
 	"context"
 	"fmt"
+	"io"
 	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/core/linux/resource"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/redact"
 	"worker/internal/worker/state"
 	"worker/pkg/config"
 	"worker/pkg/logger"
@@ -19,24 +23,58 @@ type darwinWorker struct {
 }
 
 // NewWorker creates a Darwin worker for development (SAME FUNCTION NAME as Linux)
-func NewWorker(store state.Store, cfg *config.Config) interfaces.Worker {
+func NewWorker(store state.Store, bus *events.Bus, redactor *redact.Redactor, cgroup resource.Resource, cfg *config.Config) (interfaces.Worker, error) {
 	return &darwinWorker{
 		logger: logger.New().WithField("component", "darwin-worker"),
 		config: cfg,
-	}
+	}, nil
 }
 
 // StartJob provides basic job execution on macOS (for development/testing)
-func (w *darwinWorker) StartJob(ctx context.Context, command string, args []string, maxCPU, maxMemory, maxIOBPS int32) (*domain.Job, error) {
+func (w *darwinWorker) StartJob(ctx context.Context, spec *domain.JobSpec) (*domain.Job, error) {
 	w.logger.Warn("Darwin worker has limited functionality - jobs will not be isolated")
 	return nil, fmt.Errorf("Darwin worker not fully implemented - use Linux for production")
 }
 
+// ExpireJob is not supported on macOS, jobs need the Linux worker
+func (w *darwinWorker) ExpireJob(ctx context.Context, spec *domain.JobSpec, reason error) (*domain.Job, error) {
+	return nil, fmt.Errorf("Darwin worker not fully implemented")
+}
+
 // StopJob stops a job on macOS (basic implementation)
-func (w *darwinWorker) StopJob(ctx context.Context, jobId string) error {
+func (w *darwinWorker) StopJob(ctx context.Context, jobId string, opts domain.StopOptions) (*domain.StopResult, error) {
 	w.logger.Warn("Darwin worker stop job called")
+	return nil, fmt.Errorf("Darwin worker not fully implemented")
+}
+
+// ResumeJob is not supported on macOS, jobs need the Linux worker
+func (w *darwinWorker) ResumeJob(ctx context.Context, jobId string) error {
 	return fmt.Errorf("Darwin worker not fully implemented")
 }
 
+// ValidateJob reports every spec as unrunnable, jobs need the Linux worker
+func (w *darwinWorker) ValidateJob(ctx context.Context, spec *domain.JobSpec) *domain.JobValidation {
+	validation := &domain.JobValidation{Limits: spec.Limits}
+	validation.Add("platform", fmt.Errorf("jobs can only run on the Linux worker"))
+	return validation
+}
+
+// OpenStdin is not supported on macOS, jobs need the Linux worker
+func (w *darwinWorker) OpenStdin(jobId string) (io.WriteCloser, error) {
+	return nil, fmt.Errorf("Darwin worker not fully implemented")
+}
+
+// Capabilities is empty on macOS, jobs need the Linux worker
+func (w *darwinWorker) Capabilities() []domain.Capability {
+	return nil
+}
+
+// Diagnostics reports the platform as unable to run jobs, they need the Linux worker
+func (w *darwinWorker) Diagnostics(ctx context.Context) domain.Diagnostics {
+	var d domain.Diagnostics
+	d.Add("platform", domain.CheckFail, "jobs can only run on the Linux worker", "Run the worker on Linux")
+	return d
+}
+
 // Ensure darwinWorker implements interfaces
 var _ interfaces.Worker = (*darwinWorker)(nil)
@@ -4,33 +4,80 @@ package core
 
 import (
 	"context"
+	"io"
 	"worker/internal/worker/core/interfaces"
 	"worker/internal/worker/core/linux"
+	"worker/internal/worker/core/linux/resource"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/redact"
 	"worker/internal/worker/state"
+	"worker/internal/worker/upgrade"
 	"worker/pkg/config"
 )
 
 // linuxWorker is a thin wrapper around the Linux worker
 type linuxWorker struct {
-	platformWorker interfaces.Worker
+	platformWorker *linux.Worker
 }
 
-// NewWorker creates a Linux worker
-func NewWorker(store state.Store, cfg *config.Config) interfaces.Worker {
-	return &linuxWorker{
-		platformWorker: linux.NewPlatformWorker(store, cfg),
+// NewWorker creates a Linux worker, failing when the preflight checks do
+func NewWorker(store state.Store, bus *events.Bus, redactor *redact.Redactor, cgroup resource.Resource, cfg *config.Config) (interfaces.Worker, error) {
+	platformWorker, err := linux.NewPlatformWorker(store, bus, redactor, cgroup, cfg)
+	if err != nil {
+		return nil, err
 	}
+	return &linuxWorker{platformWorker: platformWorker.(*linux.Worker)}, nil
 }
 
 // StartJob delegates to the platform worker
-func (w *linuxWorker) StartJob(ctx context.Context, command string, args []string, maxCPU, maxMemory, maxIOBPS int32) (*domain.Job, error) {
-	return w.platformWorker.StartJob(ctx, command, args, maxCPU, maxMemory, maxIOBPS)
+func (w *linuxWorker) StartJob(ctx context.Context, spec *domain.JobSpec) (*domain.Job, error) {
+	return w.platformWorker.StartJob(ctx, spec)
+}
+
+// ExpireJob delegates to the platform worker
+func (w *linuxWorker) ExpireJob(ctx context.Context, spec *domain.JobSpec, reason error) (*domain.Job, error) {
+	return w.platformWorker.ExpireJob(ctx, spec, reason)
 }
 
 // StopJob delegates to the platform worker
-func (w *linuxWorker) StopJob(ctx context.Context, jobId string) error {
-	return w.platformWorker.StopJob(ctx, jobId)
+func (w *linuxWorker) StopJob(ctx context.Context, jobId string, opts domain.StopOptions) (*domain.StopResult, error) {
+	return w.platformWorker.StopJob(ctx, jobId, opts)
+}
+
+// ResumeJob delegates to the platform worker
+func (w *linuxWorker) ResumeJob(ctx context.Context, jobId string) error {
+	return w.platformWorker.ResumeJob(ctx, jobId)
+}
+
+// ValidateJob delegates to the platform worker
+func (w *linuxWorker) ValidateJob(ctx context.Context, spec *domain.JobSpec) *domain.JobValidation {
+	return w.platformWorker.ValidateJob(ctx, spec)
+}
+
+// Diagnostics delegates to the platform worker
+func (w *linuxWorker) Diagnostics(ctx context.Context) domain.Diagnostics {
+	return w.platformWorker.Diagnostics(ctx)
+}
+
+// OpenStdin delegates to the platform worker
+func (w *linuxWorker) OpenStdin(jobId string) (io.WriteCloser, error) {
+	return w.platformWorker.OpenStdin(jobId)
+}
+
+// Capabilities delegates to the platform worker
+func (w *linuxWorker) Capabilities() []domain.Capability {
+	return w.platformWorker.Capabilities()
+}
+
+// HandOff delegates to the platform worker
+func (w *linuxWorker) HandOff() (map[string]*upgrade.Process, func(), error) {
+	return w.platformWorker.HandOff()
+}
+
+// Adopt delegates to the platform worker
+func (w *linuxWorker) Adopt(jobs []upgrade.Job) {
+	w.platformWorker.Adopt(jobs)
 }
 
 // Ensure linuxWorker implements interfaces
@@ -0,0 +1,100 @@
+package coredump
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	patternFile = "/proc/sys/kernel/core_pattern"
+	usesPIDFile = "/proc/sys/kernel/core_uses_pid"
+)
+
+// Pattern is how the kernel names core files, from kernel.core_pattern and
+// kernel.core_uses_pid
+type Pattern struct {
+	Raw     string
+	UsesPID bool
+}
+
+// LoadPattern reads the host's core pattern
+func LoadPattern() (Pattern, error) {
+	raw, err := os.ReadFile(patternFile)
+	if err != nil {
+		return Pattern{}, err
+	}
+	usesPID, _ := os.ReadFile(usesPIDFile)
+	return Pattern{
+		Raw:     strings.TrimSpace(string(raw)),
+		UsesPID: strings.TrimSpace(string(usesPID)) == "1",
+	}, nil
+}
+
+// Relative reports whether cores are written to the working directory of the
+// crashing process, which for a job is its workspace. Cores piped to a handler
+// such as systemd-coredump, or written to an absolute path, never reach it.
+func (p Pattern) Relative() bool {
+	return p.Raw != "" && !strings.HasPrefix(p.Raw, "|") && !filepath.IsAbs(p.Raw)
+}
+
+// Glob returns a glob matching the names of the core files the pattern makes.
+// Every %-specifier matches anything, and without a %p in the pattern
+// core_uses_pid appends the PID after a dot.
+func (p Pattern) Glob() string {
+	var glob strings.Builder
+	for i := 0; i < len(p.Raw); i++ {
+		c := p.Raw[i]
+		switch {
+		case c == '%' && i+1 < len(p.Raw) && p.Raw[i+1] == '%':
+			glob.WriteByte('%')
+			i++
+		case c == '%':
+			glob.WriteByte('*')
+			i++
+		case strings.IndexByte(`*?[\`, c) >= 0:
+			glob.WriteByte('\\')
+			glob.WriteByte(c)
+		default:
+			glob.WriteByte(c)
+		}
+	}
+	if p.UsesPID && !strings.Contains(p.Raw, "%p") {
+		glob.WriteString(".[0-9]*")
+	}
+	return glob.String()
+}
+
+// Find returns the newest core file in dir written since a process started,
+// as a path relative to dir, and its size. It returns "" when there is none.
+func Find(dir string, p Pattern, since time.Time) (string, int64) {
+	if !p.Relative() {
+		return "", 0
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(dir, p.Glob()))
+
+	// file times may be coarser than the clock the start was taken from
+	since = since.Truncate(time.Second)
+	var newest string
+	var newestInfo os.FileInfo
+	for _, path := range matches {
+		info, err := os.Lstat(path)
+		if err != nil || !info.Mode().IsRegular() || info.ModTime().Before(since) {
+			continue
+		}
+		if newestInfo == nil || info.ModTime().After(newestInfo.ModTime()) {
+			newest, newestInfo = path, info
+		}
+	}
+	if newestInfo == nil {
+		return "", 0
+	}
+
+	rel, err := filepath.Rel(dir, newest)
+	if err != nil {
+		return "", 0
+	}
+	return rel, newestInfo.Size()
+}
@@ -0,0 +1,68 @@
+package coredump
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPatternGlob(t *testing.T) {
+	for p, want := range map[Pattern]string{
+		{Raw: "core"}:                   "core",
+		{Raw: "core", UsesPID: true}:    "core.[0-9]*",
+		{Raw: "core.%p", UsesPID: true}: "core.*",
+		{Raw: "%e-%s.core"}:             "*-*.core",
+		{Raw: "cores/100%%-%t"}:         "cores/100%-*",
+		{Raw: "core[1]*"}:               `core\[1]\*`,
+	} {
+		if got := p.Glob(); got != want {
+			t.Errorf("%+v: got glob %q, want %q", p, got, want)
+		}
+	}
+}
+
+func TestPatternRelative(t *testing.T) {
+	for raw, want := range map[string]bool{
+		"core":                                  true,
+		"cores/core.%p":                         true,
+		"/var/crash/core.%e":                    false,
+		"|/usr/lib/systemd/systemd-coredump %P": false,
+		"":                                      false,
+	} {
+		if got := (Pattern{Raw: raw}).Relative(); got != want {
+			t.Errorf("%q: got relative %v, want %v", raw, got, want)
+		}
+	}
+}
+
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Now()
+	write := func(name string, size int, modified time.Time) {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write("core.10", 100, start.Add(-time.Hour)) // left by an earlier run
+	write("core.20", 200, start.Add(time.Second))
+	write("core.30", 300, start.Add(2*time.Second))
+	write("core.txt", 400, start.Add(3*time.Second)) // not a PID
+
+	path, size := Find(dir, Pattern{Raw: "core", UsesPID: true}, start)
+	if path != "core.30" || size != 300 {
+		t.Errorf("expected the newest core core.30, got %q (%d bytes)", path, size)
+	}
+
+	if path, _ := Find(dir, Pattern{Raw: "core"}, start); path != "" {
+		t.Errorf("expected no core named exactly core, got %q", path)
+	}
+	if path, _ := Find(dir, Pattern{Raw: "|/bin/handler"}, start); path != "" {
+		t.Errorf("expected no core for a piped pattern, got %q", path)
+	}
+}
@@ -0,0 +1,59 @@
+package domain
+
+// Capability names a feature a worker build may or may not provide
+type Capability string
+
+const (
+	CapabilityCgroups          Capability = "cgroups"           // CPU, memory and IO limits
+	CapabilityNamespaces       Capability = "namespaces"        // PID, mount, IPC and UTS isolation
+	CapabilityNetworkIsolation Capability = "network-isolation" // private network namespace per job
+	CapabilityStdin            Capability = "stdin"             // input written with WriteJobStdin
+	CapabilityHealthProbes     Capability = "health-probes"     // liveness probes
+	CapabilityRestarts         Capability = "restarts"          // on-failure and always restart policies
+	CapabilityUploads          Capability = "uploads"           // files staged into the job workspace
+	CapabilitySockets          Capability = "sockets"           // listening sockets passed into jobs
+	CapabilitySandbox          Capability = "sandbox"           // jobs run through a configured sandbox runtime
+)
+
+// RequiredCapabilities lists what the spec needs: the capabilities the client
+// asked for plus those implied by its settings
+func (s *JobSpec) RequiredCapabilities() []Capability {
+	required := append([]Capability(nil), s.Capabilities...)
+	if s.Stdin {
+		required = append(required, CapabilityStdin)
+	}
+	if s.Probe != nil {
+		required = append(required, CapabilityHealthProbes)
+	}
+	if s.Restart.Mode == RestartOnFailure || s.Restart.Mode == RestartAlways {
+		required = append(required, CapabilityRestarts)
+	}
+	if s.UploadID != "" {
+		required = append(required, CapabilityUploads)
+	}
+	if len(s.Sockets) > 0 {
+		required = append(required, CapabilitySockets)
+	}
+	if s.Runtime != "" {
+		required = append(required, CapabilitySandbox)
+	}
+	return required
+}
+
+// MissingCapabilities returns the capabilities in want that are not in have,
+// without duplicates and in the order first wanted
+func MissingCapabilities(want, have []Capability) []Capability {
+	seen := make(map[Capability]bool, len(have)+len(want))
+	for _, c := range have {
+		seen[c] = true
+	}
+
+	var missing []Capability
+	for _, c := range want {
+		if !seen[c] {
+			seen[c] = true
+			missing = append(missing, c)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestJobSpecRequiredCapabilities(t *testing.T) {
+	spec := &JobSpec{
+		Capabilities: []Capability{CapabilityNetworkIsolation},
+		Stdin:        true,
+		Probe:        &HealthProbe{},
+		Restart:      RestartPolicy{Mode: RestartOnFailure},
+		UploadID:     "upload-1",
+		Sockets:      []JobSocket{{Name: "http", Address: "tcp://:8080"}},
+		Runtime:      "gvisor",
+	}
+	want := []Capability{CapabilityNetworkIsolation, CapabilityStdin, CapabilityHealthProbes, CapabilityRestarts, CapabilityUploads, CapabilitySockets, CapabilitySandbox}
+	if got := spec.RequiredCapabilities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	plain := &JobSpec{Restart: RestartPolicy{Mode: RestartNever}}
+	if got := plain.RequiredCapabilities(); len(got) != 0 {
+		t.Errorf("expected no capabilities, got %v", got)
+	}
+}
+
+func TestMissingCapabilities(t *testing.T) {
+	have := []Capability{CapabilityCgroups, CapabilityStdin}
+	want := []Capability{CapabilityStdin, CapabilityNetworkIsolation, CapabilityUploads, CapabilityNetworkIsolation}
+
+	got := MissingCapabilities(want, have)
+	if !reflect.DeepEqual(got, []Capability{CapabilityNetworkIsolation, CapabilityUploads}) {
+		t.Errorf("expected network-isolation and uploads once each, got %v", got)
+	}
+	if got := MissingCapabilities(have, have); got != nil {
+		t.Errorf("expected nothing missing, got %v", got)
+	}
+}
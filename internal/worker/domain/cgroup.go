@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+)
+
+// CgroupErrorKind classifies a failed cgroup write by what fixes it
+type CgroupErrorKind string
+
+const (
+	CgroupPermissionDenied  CgroupErrorKind = "permission_denied"  // the worker may not write its cgroup subtree
+	CgroupControllerMissing CgroupErrorKind = "controller_missing" // the file of a limit or the parent cgroup does not exist
+	CgroupInvalidValue      CgroupErrorKind = "invalid_value"      // the kernel rejected the value of a limit
+	CgroupLimitReached      CgroupErrorKind = "limit_reached"      // the hierarchy has no room for another cgroup
+	CgroupWriteFailed       CgroupErrorKind = "write_failed"       // anything else, such as EIO
+)
+
+// CgroupError is a write to a job or group cgroup the kernel refused. Value
+// is what was written, "" for creating the cgroup directory.
+type CgroupError struct {
+	Path  string
+	Value string
+	Kind  CgroupErrorKind
+	Err   error
+}
+
+// NewCgroupError classifies the error of a write of value to path
+func NewCgroupError(path, value string, err error) *CgroupError {
+	kind := CgroupWriteFailed
+	switch {
+	case errors.Is(err, syscall.EPERM), errors.Is(err, syscall.EACCES), errors.Is(err, syscall.EROFS):
+		kind = CgroupPermissionDenied
+	case errors.Is(err, syscall.ENOENT):
+		kind = CgroupControllerMissing
+	case errors.Is(err, syscall.EINVAL), errors.Is(err, syscall.ERANGE):
+		kind = CgroupInvalidValue
+	case errors.Is(err, syscall.ENOSPC), errors.Is(err, syscall.EAGAIN):
+		kind = CgroupLimitReached
+	}
+	return &CgroupError{Path: path, Value: value, Kind: kind, Err: err}
+}
+
+func (e *CgroupError) Error() string {
+	if e.Value == "" {
+		return fmt.Sprintf("failed to create cgroup %s: %v", e.Path, e.Err)
+	}
+	return fmt.Sprintf("failed to write %q to %s: %v", e.Value, e.Path, e.Err)
+}
+
+func (e *CgroupError) Unwrap() error {
+	return e.Err
+}
+
+// Hint says what the operator, or for invalid values the client, can do
+// about the error
+func (e *CgroupError) Hint() string {
+	switch e.Kind {
+	case CgroupPermissionDenied:
+		return "the worker needs a delegated cgroup subtree: run it as root or under systemd with Delegate=yes, and check cgroup.baseDir"
+	case CgroupControllerMissing:
+		return "enable the controller in cgroup.subtree_control above cgroup.baseDir and list it in cgroup.enableControllers, or run the job without that limit"
+	case CgroupInvalidValue:
+		return "the limit is outside the range the kernel takes, such as a CPU limit under 10 millicores; change the job's limits"
+	case CgroupLimitReached:
+		return "the cgroup hierarchy is full: raise cgroup.max.descendants or cgroup.max.depth, or retry once running jobs finish"
+	}
+	return "check the worker log and the kernel log for the failing cgroup write"
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"syscall"
+	"testing"
+)
+
+func TestNewCgroupError(t *testing.T) {
+	for errno, want := range map[syscall.Errno]CgroupErrorKind{
+		syscall.EPERM:  CgroupPermissionDenied,
+		syscall.EACCES: CgroupPermissionDenied,
+		syscall.ENOENT: CgroupControllerMissing,
+		syscall.EINVAL: CgroupInvalidValue,
+		syscall.ENOSPC: CgroupLimitReached,
+		syscall.EIO:    CgroupWriteFailed,
+	} {
+		err := NewCgroupError("/sys/fs/cgroup/job-1/cpu.max", "500 100000", fmt.Errorf("write: %w", errno))
+		if err.Kind != want {
+			t.Errorf("%v: expected %s, got %s", errno, want, err.Kind)
+		}
+		if !errors.Is(err, errno) {
+			t.Errorf("%v: expected the error to unwrap to its errno", errno)
+		}
+		if err.Hint() == "" {
+			t.Errorf("%v: expected a hint", errno)
+		}
+	}
+}
+
+func TestCgroupErrorMessage(t *testing.T) {
+	err := NewCgroupError("/sys/fs/cgroup/job-1/cpu.max", "500 100000", syscall.EINVAL)
+	if got := err.Error(); got != `failed to write "500 100000" to /sys/fs/cgroup/job-1/cpu.max: invalid argument` {
+		t.Errorf("unexpected message %q", got)
+	}
+
+	created := NewCgroupError("/sys/fs/cgroup/job-1", "", syscall.ENOSPC)
+	if got := created.Error(); !strings.HasPrefix(got, "failed to create cgroup /sys/fs/cgroup/job-1") {
+		t.Errorf("unexpected message %q", got)
+	}
+}
@@ -0,0 +1,36 @@
+package domain
+
+// CheckStatus is the outcome of a self-diagnostic check
+type CheckStatus string
+
+const (
+	CheckOK   CheckStatus = "OK"
+	CheckWarn CheckStatus = "WARN" // the worker runs, but some jobs or features will not work as configured
+	CheckFail CheckStatus = "FAIL" // jobs cannot run until it is fixed
+)
+
+// Check is the outcome of checking one subsystem the worker needs
+type Check struct {
+	Name   string // Subsystem checked, e.g. "cgroup.controllers"
+	Status CheckStatus
+	Detail string // What was found
+	Fix    string // How to fix it ("" when OK)
+}
+
+// Diagnostics are the checks of the worker's subsystems
+type Diagnostics []Check
+
+// Add records a check
+func (d *Diagnostics) Add(name string, status CheckStatus, detail, fix string) {
+	*d = append(*d, Check{Name: name, Status: status, Detail: detail, Fix: fix})
+}
+
+// Ready reports whether no check failed
+func (d Diagnostics) Ready() bool {
+	for _, c := range d {
+		if c.Status == CheckFail {
+			return false
+		}
+	}
+	return true
+}
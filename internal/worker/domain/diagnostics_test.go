@@ -0,0 +1,20 @@
+package domain
+
+import "testing"
+
+func TestDiagnosticsReady(t *testing.T) {
+	var d Diagnostics
+	if !d.Ready() {
+		t.Error("no checks are not ready")
+	}
+
+	d.Add("disk", CheckWarn, "92% used", "free some space")
+	if !d.Ready() {
+		t.Error("a warning made the worker not ready")
+	}
+
+	d.Add("cgroup.base", CheckFail, "not writable", "delegate it")
+	if d.Ready() || len(d) != 2 || d[1].Fix != "delegate it" {
+		t.Errorf("Diagnostics = %+v, ready %v", d, d.Ready())
+	}
+}
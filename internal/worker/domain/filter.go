@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// JobFilter selects jobs for bulk operations. The zero filter matches every job.
+type JobFilter struct {
+	Labels    map[string]string // Every label must be present with the same value
+	Statuses  []JobStatus       // Any of these statuses, empty for any status
+	OlderThan time.Duration     // Minimum age, see Job.Age; 0 for any age
+}
+
+// IsEmpty reports whether the filter would match every job
+func (f JobFilter) IsEmpty() bool {
+	return len(f.Labels) == 0 && len(f.Statuses) == 0 && f.OlderThan <= 0
+}
+
+// Validate rejects unknown statuses and negative ages
+func (f JobFilter) Validate() error {
+	for _, s := range f.Statuses {
+		switch s {
+		case StatusInitializing, StatusRunning, StatusCompleted, StatusFailed, StatusStopped, StatusCrashLoop, StatusExpired:
+		default:
+			return fmt.Errorf("unknown job status %q", s)
+		}
+	}
+	if f.OlderThan < 0 {
+		return fmt.Errorf("invalid age: %s", f.OlderThan)
+	}
+	return nil
+}
+
+// Matches reports whether the job satisfies every condition of the filter
+func (f JobFilter) Matches(job *Job, now time.Time) bool {
+	for k, v := range f.Labels {
+		if label, exists := job.Labels[k]; !exists || label != v {
+			return false
+		}
+	}
+
+	if len(f.Statuses) > 0 {
+		matched := false
+		for _, s := range f.Statuses {
+			if job.Status == s {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return f.OlderThan <= 0 || job.Age(now) >= f.OlderThan
+}
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestJobFilterMatches(t *testing.T) {
+	now := time.Now()
+	ended := now.Add(-8 * 24 * time.Hour)
+
+	oldFailed := &Job{Status: StatusFailed, StartTime: ended.Add(-time.Hour), EndTime: &ended, Labels: map[string]string{"team": "ci"}}
+	running := &Job{Status: StatusRunning, StartTime: now.Add(-time.Minute), Labels: map[string]string{"team": "ci", "env": "dev"}}
+
+	tests := []struct {
+		name     string
+		filter   JobFilter
+		job      *Job
+		expected bool
+	}{
+		{"empty filter", JobFilter{}, running, true},
+		{"label match", JobFilter{Labels: map[string]string{"team": "ci"}}, running, true},
+		{"all labels required", JobFilter{Labels: map[string]string{"team": "ci", "env": "dev"}}, oldFailed, false},
+		{"label value differs", JobFilter{Labels: map[string]string{"team": "web"}}, running, false},
+		{"status match", JobFilter{Statuses: []JobStatus{StatusCompleted, StatusFailed}}, oldFailed, true},
+		{"status mismatch", JobFilter{Statuses: []JobStatus{StatusFailed}}, running, false},
+		{"old enough by end time", JobFilter{Statuses: []JobStatus{StatusFailed}, OlderThan: 7 * 24 * time.Hour}, oldFailed, true},
+		{"too young by start time", JobFilter{OlderThan: time.Hour}, running, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.Matches(tt.job, now); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestJobFilterValidate(t *testing.T) {
+	if err := (JobFilter{Statuses: []JobStatus{StatusFailed}}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := (JobFilter{Statuses: []JobStatus{"BROKEN"}}).Validate(); err == nil {
+		t.Error("expected error for unknown status")
+	}
+	if err := (JobFilter{OlderThan: -time.Second}).Validate(); err == nil {
+		t.Error("expected error for negative age")
+	}
+	if !(JobFilter{}).IsEmpty() {
+		t.Error("expected zero filter to be empty")
+	}
+}
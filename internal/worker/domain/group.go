@@ -0,0 +1,72 @@
+package domain
+
+import "time"
+
+type GroupStatus string
+
+const (
+	GroupRunning   GroupStatus = "RUNNING"   // at least one job is still running
+	GroupCompleted GroupStatus = "COMPLETED" // every job completed successfully
+	GroupFailed    GroupStatus = "FAILED"    // at least one job failed
+	GroupStopped   GroupStatus = "STOPPED"   // jobs were stopped, none failed
+)
+
+// JobGroup is a set of jobs started together and managed as a unit
+type JobGroup struct {
+	Id        string
+	Name      string
+	JobIds    []string
+	Limits    ResourceLimits // shared by the group's jobs, zero for none
+	CreatedAt time.Time
+}
+
+// DeepCopy creates independent copy to prevent concurrent modification issues
+func (g *JobGroup) DeepCopy() *JobGroup {
+	return &JobGroup{
+		Id:        g.Id,
+		Name:      g.Name,
+		JobIds:    append([]string(nil), g.JobIds...),
+		Limits:    g.Limits,
+		CreatedAt: g.CreatedAt,
+	}
+}
+
+// GroupCounts aggregates the status of a group's jobs
+type GroupCounts struct {
+	Running   int32
+	Succeeded int32
+	Failed    int32
+	Stopped   int32
+}
+
+// CountJobs tallies the jobs by status
+func CountJobs(jobs []*Job) GroupCounts {
+	var counts GroupCounts
+	for _, job := range jobs {
+		switch job.Status {
+		case StatusCompleted:
+			counts.Succeeded++
+		case StatusFailed, StatusExpired:
+			counts.Failed++
+		case StatusStopped:
+			counts.Stopped++
+		default:
+			counts.Running++
+		}
+	}
+	return counts
+}
+
+// Status derives the group status from the counts
+func (c GroupCounts) Status() GroupStatus {
+	switch {
+	case c.Running > 0:
+		return GroupRunning
+	case c.Failed > 0:
+		return GroupFailed
+	case c.Stopped > 0:
+		return GroupStopped
+	default:
+		return GroupCompleted
+	}
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestGroupCountsStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []JobStatus
+		expected GroupStatus
+	}{
+		{"all completed", []JobStatus{StatusCompleted, StatusCompleted}, GroupCompleted},
+		{"one running", []JobStatus{StatusCompleted, StatusRunning, StatusFailed}, GroupRunning},
+		{"initializing counts as running", []JobStatus{StatusInitializing}, GroupRunning},
+		{"failure wins over stop", []JobStatus{StatusStopped, StatusFailed}, GroupFailed},
+		{"stopped", []JobStatus{StatusStopped, StatusCompleted}, GroupStopped},
+		{"expired counts as failed", []JobStatus{StatusExpired, StatusStopped}, GroupFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var jobs []*Job
+			for _, s := range tt.statuses {
+				jobs = append(jobs, &Job{Status: s})
+			}
+
+			if got := CountJobs(jobs).Status(); got != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestCountJobs(t *testing.T) {
+	counts := CountJobs([]*Job{
+		{Status: StatusRunning},
+		{Status: StatusCompleted},
+		{Status: StatusCompleted},
+		{Status: StatusFailed},
+		{Status: StatusStopped},
+	})
+
+	expected := GroupCounts{Running: 1, Succeeded: 2, Failed: 1, Stopped: 1}
+	if counts != expected {
+		t.Errorf("expected %+v, got %+v", expected, counts)
+	}
+}
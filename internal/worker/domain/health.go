@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type HealthState string
+
+const (
+	HealthUnknown   HealthState = ""          // no probe, or no result yet
+	HealthHealthy   HealthState = "HEALTHY"   // the last probe succeeded
+	HealthUnhealthy HealthState = "UNHEALTHY" // the failure threshold was reached
+)
+
+type ProbeType string
+
+const (
+	ProbeExec ProbeType = "exec" // run a command inside the job's namespaces
+	ProbeTCP  ProbeType = "tcp"  // connect to a port
+	ProbeHTTP ProbeType = "http" // GET a path, 2xx and 3xx are healthy
+)
+
+const (
+	DefaultProbeInterval         = 10 * time.Second
+	DefaultProbeTimeout          = time.Second
+	DefaultProbeFailureThreshold = 3
+	MinProbeInterval             = time.Second
+)
+
+// HealthProbe is a liveness check run periodically while a job's process is alive
+type HealthProbe struct {
+	Type             ProbeType
+	Command          []string // exec: command and arguments
+	Port             int32    // tcp, http: port on the job's network (the host network)
+	Path             string   // http: request path
+	Interval         time.Duration
+	Timeout          time.Duration
+	InitialDelay     time.Duration // grace period after each process start
+	FailureThreshold int32         // consecutive failures before the job is unhealthy
+}
+
+// WithDefaults returns a copy of the probe with unset timing fields filled in
+func (p HealthProbe) WithDefaults() HealthProbe {
+	if p.Interval == 0 {
+		p.Interval = DefaultProbeInterval
+	}
+	if p.Timeout == 0 {
+		p.Timeout = DefaultProbeTimeout
+	}
+	if p.FailureThreshold == 0 {
+		p.FailureThreshold = DefaultProbeFailureThreshold
+	}
+	return p
+}
+
+// Validate checks that the probe has what its type needs and sane timings
+func (p HealthProbe) Validate() error {
+	switch p.Type {
+	case ProbeExec:
+		if len(p.Command) == 0 {
+			return errors.New("exec probe requires a command")
+		}
+	case ProbeTCP, ProbeHTTP:
+		if p.Port < 1 || p.Port > 65535 {
+			return fmt.Errorf("%s probe requires a valid port, got %d", p.Type, p.Port)
+		}
+	default:
+		return fmt.Errorf("unknown probe type %q (expected exec, tcp or http)", p.Type)
+	}
+
+	if p.Interval != 0 && p.Interval < MinProbeInterval {
+		return fmt.Errorf("probe interval must be at least %v", MinProbeInterval)
+	}
+	if p.Timeout < 0 || p.InitialDelay < 0 || p.FailureThreshold < 0 {
+		return errors.New("probe timeout, initial delay and failure threshold must not be negative")
+	}
+	return nil
+}
+
+// Copy returns an independent copy of the probe, nil stays nil
+func (p *HealthProbe) Copy() *HealthProbe {
+	if p == nil {
+		return nil
+	}
+	cp := *p
+	cp.Command = append([]string(nil), p.Command...)
+	return &cp
+}
@@ -0,0 +1,43 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHealthProbeValidate(t *testing.T) {
+	valid := []HealthProbe{
+		{Type: ProbeExec, Command: []string{"true"}},
+		{Type: ProbeTCP, Port: 5432},
+		{Type: ProbeHTTP, Port: 8080, Path: "/healthz", Interval: 5 * time.Second},
+	}
+	for _, p := range valid {
+		if err := p.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", p, err)
+		}
+	}
+
+	invalid := []HealthProbe{
+		{Type: ProbeExec},
+		{Type: ProbeTCP},
+		{Type: ProbeHTTP, Port: 70000},
+		{Type: "grpc", Port: 80},
+		{Type: ProbeTCP, Port: 80, Interval: 100 * time.Millisecond},
+		{Type: ProbeTCP, Port: 80, FailureThreshold: -1},
+	}
+	for _, p := range invalid {
+		if err := p.Validate(); err == nil {
+			t.Errorf("expected %+v to be rejected", p)
+		}
+	}
+}
+
+func TestHealthProbeWithDefaults(t *testing.T) {
+	p := HealthProbe{Type: ProbeTCP, Port: 80, Timeout: 3 * time.Second}.WithDefaults()
+	if p.Interval != DefaultProbeInterval || p.FailureThreshold != DefaultProbeFailureThreshold {
+		t.Errorf("expected defaults to be applied, got %+v", p)
+	}
+	if p.Timeout != 3*time.Second {
+		t.Errorf("expected explicit timeout to be kept, got %v", p.Timeout)
+	}
+}
@@ -0,0 +1,37 @@
+package domain
+
+import "time"
+
+// HistoryChange is the kind of change a history entry records
+type HistoryChange string
+
+const (
+	HistoryCreated   HistoryChange = "created"    // the job was stored
+	HistoryUpdated   HistoryChange = "updated"    // its status, restart count or health changed
+	HistoryCleanedUp HistoryChange = "cleaned_up" // its cgroup was removed, or failed to be
+)
+
+// HistoryEntry is one change the worker recorded to a job
+type HistoryEntry struct {
+	Time     time.Time
+	Change   HistoryChange
+	Status   JobStatus
+	Reason   string // why the job entered Status, set when it did or restarted
+	Restarts int32
+	Health   HealthState
+	Error    string // why the cgroup cleanup failed, for HistoryCleanedUp
+}
+
+// StatusChanges returns the entries at which the job entered a new status
+func StatusChanges(history []HistoryEntry) []HistoryEntry {
+	var changes []HistoryEntry
+	for _, entry := range history {
+		if entry.Change == HistoryCleanedUp {
+			continue
+		}
+		if len(changes) == 0 || changes[len(changes)-1].Status != entry.Status {
+			changes = append(changes, entry)
+		}
+	}
+	return changes
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// IdleAction is what happens to a job once it has been idle for its MaxIdle
+type IdleAction string
+
+const (
+	IdleStop IdleAction = "stop" // stop the job as StopJob does, with SIGTERM and then SIGKILL
+	IdleMark IdleAction = "mark" // only mark the job idle, until it is active again
+)
+
+const (
+	// MaxIdleTime bounds MaxIdle
+	MaxIdleTime = 100 * 365 * 24 * time.Hour
+
+	// IdleCPUShare is the share of one core a job must use between two checks
+	// for its CPU use to count as activity, so runtime housekeeping of a
+	// hung process does not keep it alive
+	IdleCPUShare = 0.01
+
+	minIdleCheckInterval = time.Second
+	maxIdleCheckInterval = 30 * time.Second
+)
+
+// IdlePolicy catches jobs that neither write output nor use CPU for MaxIdle
+type IdlePolicy struct {
+	MaxIdle time.Duration // 0 for no policy
+	Action  IdleAction
+}
+
+// ParseIdlePolicy validates an idle policy given in whole seconds, the action
+// defaulting to stop
+func ParseIdlePolicy(maxIdleSeconds int64, action string) (IdlePolicy, error) {
+	if maxIdleSeconds < 0 || maxIdleSeconds > int64(MaxIdleTime/time.Second) {
+		return IdlePolicy{}, fmt.Errorf("max idle time %ds out of range (0 to %v)", maxIdleSeconds, MaxIdleTime)
+	}
+	if maxIdleSeconds == 0 {
+		if action != "" {
+			return IdlePolicy{}, fmt.Errorf("idle action %q given without a max idle time", action)
+		}
+		return IdlePolicy{}, nil
+	}
+
+	policy := IdlePolicy{MaxIdle: time.Duration(maxIdleSeconds) * time.Second}
+	switch IdleAction(action) {
+	case "", IdleStop:
+		policy.Action = IdleStop
+	case IdleMark:
+		policy.Action = IdleMark
+	default:
+		return IdlePolicy{}, fmt.Errorf("unknown idle action %q (expected stop or mark)", action)
+	}
+	return policy, nil
+}
+
+// CheckInterval is how often the job's activity is checked, a tenth of
+// MaxIdle within 1s to 30s
+func (p IdlePolicy) CheckInterval() time.Duration {
+	return min(max(p.MaxIdle/10, minIdleCheckInterval), maxIdleCheckInterval)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseIdlePolicy(t *testing.T) {
+	tests := []struct {
+		seconds int64
+		action  string
+		want    IdlePolicy
+	}{
+		{0, "", IdlePolicy{}},
+		{600, "", IdlePolicy{MaxIdle: 10 * time.Minute, Action: IdleStop}},
+		{60, "mark", IdlePolicy{MaxIdle: time.Minute, Action: IdleMark}},
+	}
+	for _, tt := range tests {
+		got, err := ParseIdlePolicy(tt.seconds, tt.action)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseIdlePolicy(%d, %q) = %+v, %v; want %+v", tt.seconds, tt.action, got, err, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		seconds int64
+		action  string
+	}{{-1, ""}, {0, "mark"}, {60, "kill"}, {1 << 40, ""}} {
+		if _, err := ParseIdlePolicy(tt.seconds, tt.action); err == nil {
+			t.Errorf("expected ParseIdlePolicy(%d, %q) to be rejected", tt.seconds, tt.action)
+		}
+	}
+}
+
+func TestIdlePolicyCheckInterval(t *testing.T) {
+	for maxIdle, want := range map[time.Duration]time.Duration{
+		5 * time.Second: time.Second,
+		time.Minute:     6 * time.Second,
+		24 * time.Hour:  30 * time.Second,
+	} {
+		if got := (IdlePolicy{MaxIdle: maxIdle}).CheckInterval(); got != want {
+			t.Errorf("CheckInterval for %v = %v, want %v", maxIdle, got, want)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// MaxJobInputs bounds the number of input files a job may stream in
+const MaxJobInputs = 64
+
+// JobInput is an object storage file a job reads from its workspace. It is
+// fetched as the job reads it rather than staged before the job starts.
+type JobInput struct {
+	Source string // Object location, s3://bucket/key or gs://bucket/key
+	Path   string // Workspace relative path the job reads the file from
+}
+
+// InputObject is the object a job input is read from
+type InputObject struct {
+	Scheme string // "s3" or "gs"
+	Bucket string
+	Key    string
+}
+
+// ParseInputSource splits an input source into the object it names
+func ParseInputSource(source string) (InputObject, error) {
+	u, err := url.Parse(source)
+	if err != nil {
+		return InputObject{}, fmt.Errorf("invalid input source %q: %v", source, err)
+	}
+	if u.Scheme != "s3" && u.Scheme != "gs" {
+		return InputObject{}, fmt.Errorf("input source %q must be an s3:// or gs:// location", source)
+	}
+	key := strings.TrimPrefix(u.Path, "/")
+	if u.Host == "" || key == "" || strings.HasSuffix(key, "/") {
+		return InputObject{}, fmt.Errorf("input source %q must name a bucket and an object", source)
+	}
+	// a store or proxy that resolves dot segments would read another object
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "." || segment == ".." {
+			return InputObject{}, fmt.Errorf("input source %q has a . or .. path segment", source)
+		}
+	}
+	return InputObject{Scheme: u.Scheme, Bucket: u.Host, Key: key}, nil
+}
+
+// ValidateJobInputs checks a job's inputs name objects and distinct files
+// within the workspace
+func ValidateJobInputs(inputs []JobInput) error {
+	if len(inputs) > MaxJobInputs {
+		return fmt.Errorf("%d inputs exceed the maximum of %d", len(inputs), MaxJobInputs)
+	}
+
+	paths := make(map[string]bool, len(inputs))
+	for _, input := range inputs {
+		if _, err := ParseInputSource(input.Source); err != nil {
+			return err
+		}
+		path := filepath.Clean(input.Path)
+		if input.Path == "" || !filepath.IsLocal(path) {
+			return fmt.Errorf("input path %q must be relative to the workspace", input.Path)
+		}
+		if paths[path] {
+			return fmt.Errorf("input path %q is used twice", input.Path)
+		}
+		paths[path] = true
+	}
+	return nil
+}
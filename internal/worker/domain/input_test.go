@@ -0,0 +1,46 @@
+package domain
+
+import "testing"
+
+func TestParseInputSource(t *testing.T) {
+	tests := map[string]InputObject{
+		"s3://data/train.csv":         {Scheme: "s3", Bucket: "data", Key: "train.csv"},
+		"gs://models/v2/weights.bin":  {Scheme: "gs", Bucket: "models", Key: "v2/weights.bin"},
+		"s3://data/dir/a%20b.parquet": {Scheme: "s3", Bucket: "data", Key: "dir/a b.parquet"},
+	}
+	for source, want := range tests {
+		got, err := ParseInputSource(source)
+		if err != nil || got != want {
+			t.Errorf("ParseInputSource(%q) = %+v, %v; want %+v", source, got, err, want)
+		}
+	}
+
+	for _, source := range []string{"", "data/train.csv", "https://example.com/train.csv", "s3://data", "s3://data/", "s3:///train.csv", "s3://data/dir/", "s3://data/../other/key", "gs://data/dir/./a"} {
+		if _, err := ParseInputSource(source); err == nil {
+			t.Errorf("expected ParseInputSource(%q) to be rejected", source)
+		}
+	}
+}
+
+func TestValidateJobInputs(t *testing.T) {
+	valid := []JobInput{
+		{Source: "s3://data/train.csv", Path: "train.csv"},
+		{Source: "s3://data/test.csv", Path: "data/test.csv"},
+	}
+	if err := ValidateJobInputs(valid); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := map[string][]JobInput{
+		"empty path":     {{Source: "s3://data/a", Path: ""}},
+		"absolute path":  {{Source: "s3://data/a", Path: "/etc/passwd"}},
+		"escaping path":  {{Source: "s3://data/a", Path: "../a"}},
+		"bad source":     {{Source: "file:///etc/passwd", Path: "a"}},
+		"duplicate path": {{Source: "s3://data/a", Path: "a"}, {Source: "s3://data/b", Path: "./a"}},
+	}
+	for name, inputs := range tests {
+		if err := ValidateJobInputs(inputs); err == nil {
+			t.Errorf("%s: expected the inputs to be rejected", name)
+		}
+	}
+}
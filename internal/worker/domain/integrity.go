@@ -0,0 +1,23 @@
+package domain
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// ParseCommandDigest validates a hex SHA-256 digest a job's command is pinned
+// to, returning it in lower case; "" pins nothing
+func ParseCommandDigest(digest string) (string, error) {
+	if digest == "" {
+		return "", nil
+	}
+	digest = strings.ToLower(strings.TrimPrefix(digest, "sha256:"))
+	if len(digest) != 64 {
+		return "", fmt.Errorf("invalid command digest: expected 64 hex characters of a SHA-256 digest, got %d", len(digest))
+	}
+	if _, err := hex.DecodeString(digest); err != nil {
+		return "", fmt.Errorf("invalid command digest: %v", err)
+	}
+	return digest, nil
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseCommandDigest(t *testing.T) {
+	const digest = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	for input, want := range map[string]string{
+		"":                      "",
+		digest:                  digest,
+		strings.ToUpper(digest): digest,
+		"sha256:" + digest:      digest,
+	} {
+		got, err := ParseCommandDigest(input)
+		if err != nil || got != want {
+			t.Errorf("ParseCommandDigest(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"abc", digest + "00", strings.Replace(digest, "e", "g", 1)} {
+		if _, err := ParseCommandDigest(input); err == nil {
+			t.Errorf("expected command digest %q to be rejected", input)
+		}
+	}
+}
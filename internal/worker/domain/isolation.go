@@ -0,0 +1,43 @@
+package domain
+
+import "fmt"
+
+// IsolationMode selects how a job's processes are separated from the host
+type IsolationMode string
+
+const (
+	IsolationFull    IsolationMode = "full"    // PID, mount, IPC and UTS namespaces plus a cgroup with the job's limits
+	IsolationCgroups IsolationMode = "cgroups" // a cgroup with the job's limits, host namespaces
+	IsolationProcess IsolationMode = "process" // a plain child process without namespaces or limits
+
+	// IsolationPrivileged runs like process, for admin host-maintenance jobs
+	// such as firmware updates. It is never a server default and is flagged
+	// wherever the job is reported.
+	IsolationPrivileged IsolationMode = "privileged"
+
+	// IsolationSandbox is the mode of jobs run through a sandbox runtime,
+	// which isolates them in its place. Jobs get it by naming a runtime, it
+	// is not asked for directly.
+	IsolationSandbox IsolationMode = "sandbox"
+)
+
+// ParseIsolationMode validates an isolation mode, keeping "" to mean the
+// server default
+func ParseIsolationMode(mode string) (IsolationMode, error) {
+	switch IsolationMode(mode) {
+	case "", IsolationFull, IsolationCgroups, IsolationProcess, IsolationPrivileged:
+		return IsolationMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown isolation mode %q (expected full, cgroups, process or privileged)", mode)
+	}
+}
+
+// Weaker reports whether the mode gives up any of the isolation of full mode
+func (m IsolationMode) Weaker() bool {
+	return m == IsolationCgroups || m == IsolationProcess || m == IsolationPrivileged
+}
+
+// UsesCgroup reports whether jobs in the mode run in a cgroup of their own
+func (m IsolationMode) UsesCgroup() bool {
+	return m != IsolationProcess && m != IsolationPrivileged
+}
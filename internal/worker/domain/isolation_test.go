@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestParseIsolationMode(t *testing.T) {
+	for _, input := range []string{"", "full", "cgroups", "process", "privileged"} {
+		got, err := ParseIsolationMode(input)
+		if err != nil || string(got) != input {
+			t.Errorf("ParseIsolationMode(%q) = %q, %v; want %q", input, got, err, input)
+		}
+	}
+
+	if _, err := ParseIsolationMode("vm"); err == nil {
+		t.Error("expected unknown isolation mode to be rejected")
+	}
+}
+
+func TestIsolationModeWeaker(t *testing.T) {
+	for mode, want := range map[IsolationMode]bool{
+		"":                  false,
+		IsolationFull:       false,
+		IsolationCgroups:    true,
+		IsolationProcess:    true,
+		IsolationPrivileged: true,
+	} {
+		if got := mode.Weaker(); got != want {
+			t.Errorf("%q.Weaker() = %v, want %v", mode, got, want)
+		}
+	}
+}
+
+func TestIsolationModeUsesCgroup(t *testing.T) {
+	for mode, want := range map[IsolationMode]bool{
+		IsolationFull:       true,
+		IsolationCgroups:    true,
+		IsolationProcess:    false,
+		IsolationPrivileged: false,
+	} {
+		if got := mode.UsesCgroup(); got != want {
+			t.Errorf("%q.UsesCgroup() = %v, want %v", mode, got, want)
+		}
+	}
+}
@@ -1,8 +1,10 @@
 package domain
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"math"
 	"time"
 	"worker/internal/worker/utils"
 )
@@ -15,25 +17,176 @@ const (
 	StatusCompleted    JobStatus = "COMPLETED"
 	StatusFailed       JobStatus = "FAILED"
 	StatusStopped      JobStatus = "STOPPED"
+	StatusCrashLoop    JobStatus = "CRASH_LOOP" // kept failing, no longer restarted until a user resumes or stops it
+	StatusExpired      JobStatus = "EXPIRED"    // could not start within its queue timeout, never ran
 )
 
+// ResourceLimits are in the units written to the job's cgroup. Zero means the
+// server default.
 type ResourceLimits struct {
-	MaxCPU    int32
-	MaxMemory int32
-	MaxIOBPS  int32
+	CPUMillis   int64 // CPU time per second of wall time, 1000 = one core
+	MemoryBytes int64 // Hard memory limit
+	IOBPS       int64 // Block IO bytes per second
+}
+
+// LegacyLimits converts limits in the units of the deprecated API fields: CPU
+// percent of one core and memory in MiB
+func LegacyLimits(cpuPercent, memoryMB, ioBPS int32) ResourceLimits {
+	return ResourceLimits{
+		CPUMillis:   int64(cpuPercent) * 10,
+		MemoryBytes: int64(memoryMB) << 20,
+		IOBPS:       int64(ioBPS),
+	}
+}
+
+// IsZero reports whether no limit is set
+func (l ResourceLimits) IsZero() bool {
+	return l == ResourceLimits{}
+}
+
+// Legacy returns the limits in the units of the deprecated API fields, capped
+// at the largest int32
+func (l ResourceLimits) Legacy() (cpuPercent, memoryMB, ioBPS int32) {
+	return clampInt32(l.CPUMillis / 10), clampInt32(l.MemoryBytes >> 20), clampInt32(l.IOBPS)
+}
+
+func clampInt32(n int64) int32 {
+	return int32(min(max(n, math.MinInt32), math.MaxInt32))
+}
+
+// JobSpec describes a job as requested by a client
+type JobSpec struct {
+	Command string            // Command as given by the client (resolved by the worker)
+	Args    []string          // Command line arguments
+	Limits  ResourceLimits    // Requested limits, zero values mean the profile's or server defaults
+	Profile string            // Named limit profile from the server config ("" if none)
+	Env     map[string]string // Explicit environment variables for the job
+
+	SecretEnv map[string]string // Env name -> secret name references
+	Secrets   map[string]string // Resolved secret values by env name, injected at exec time and never stored
+
+	UploadID string // Staged upload to place in the job workspace ("" if none)
+
+	Restart RestartPolicy     // Whether the worker relaunches the job when it exits
+	Probe   *HealthProbe      // Liveness probe, nil for none
+	GroupID string            // Job group the job is started in ("" if none)
+	Labels  map[string]string // Client-defined key/value labels used to select jobs
+
+	GroupLimits ResourceLimits // Limits the jobs of GroupID share through a parent cgroup, zero for none
+
+	CaptureStdout   bool // Also write raw stdout to a file next to the workspace
+	RetainWorkspace bool // Leave workspace removal to the caller instead of the retention timer
+	Stdin           bool // Keep the job's stdin open for a client to write to
+	Shell           bool // Command is a script run with sh -c, Args are its positional parameters
+
+	Isolation IsolationMode // How the job is isolated, "" for the server default
+	QoS       QoSClass      // How the job fares under contention, "" for burstable
+	Seccomp   SeccompMode   // Seccomp filter the job runs under, "" for none
+	Runtime   string        // Configured sandbox runtime the job runs through instead of the worker's isolation, "" for none
+
+	TimeOffset time.Duration // Shift of the job's monotonic and boottime clocks, 0 for the host's
+
+	Capabilities []Capability // Worker capabilities the client requires explicitly
+
+	CleanEnv bool // Start from only Env, the secrets and the JOB_* variables instead of the worker's environment
+
+	CommandDigest string // Lower case hex SHA-256 digest the resolved executable must have, "" for none
+
+	Idle IdlePolicy // What happens to the job when it writes no output and uses no CPU for a while
+
+	OutputFlush time.Duration // How long output may be held to be stored in larger chunks, 0 for the server's interval, negative to store every write
+	OutputLimit OutputLimit   // Rate stdout and stderr together are stored at, within the server's limit
+
+	Inputs []JobInput // Object storage files streamed into the workspace as the job reads them
+
+	Sockets []JobSocket // Listening sockets the worker opens and passes in, held until the job ends
+
+	AllowedWindows []TimeWindow // Times of the week the job may start in, any time when empty
+
+	QueueTimeout time.Duration // How long after Submitted the job may still start, 0 for no limit
+	Submitted    time.Time     // When the job was submitted, zero for when the server received it
+
+	SignedBy string // Name of the configured key the request was signed with, "" if it was not signed
 }
 
 type Job struct {
-	Id         string         // Unique identifier for job tracking
-	Command    string         // Executable command path
-	Args       []string       // Command line arguments
-	Limits     ResourceLimits // CPU/memory/IO constraints
-	Status     JobStatus      // Current execution state
-	Pid        int32          // Process ID when running
-	CgroupPath string         // Filesystem path for resource limits
-	StartTime  time.Time      // Job creation timestamp
-	EndTime    *time.Time     // Completion timestamp (nil if running)
-	ExitCode   int32          // Process exit status
+	Id             string            // Unique identifier for job tracking
+	Command        string            // Executable command path
+	Args           []string          // Command line arguments
+	Limits         ResourceLimits    // CPU/memory/IO constraints
+	Env            map[string]string // Explicit environment variables passed by the client
+	SecretEnv      map[string]string // Env name -> secret name references (values are never stored)
+	Workspace      string            // Host path of the job working directory ("" if none)
+	WorkspaceBytes int64             // Disk usage of the workspace, measured when the job finishes
+	CPUThrottled   float64           // Percentage of CPU periods the CPU limit held the job back in, measured when the job finishes
+	CoreDump       string            // Workspace path of the core file the job dumped when it crashed ("" if none)
+	CoreDumpBytes  int64             // Size of the core file
+	OutputLocation string            // Object storage location of offloaded output ("" while held locally)
+	OutputDropped  int64             // Bytes of output dropped over the output rate limit, counted when the job finishes
+	OutputExpired  bool              // Buffered output was removed by store compaction once the output retention passed
+	Result         []byte            // JSON document the job left at JOB_RESULT_PATH, read when it finishes (nil if none)
+	ResultError    string            // Why the result the job left was not kept ("" if it was, or there is none)
+	Restart        RestartPolicy     // Restart policy with the limit resolved against server defaults
+	Restarts       int32             // Number of times the process has been relaunched
+	Probe          *HealthProbe      // Liveness probe with defaults applied (nil for none)
+	Health         HealthState       // Result of the liveness probe
+	Idle           bool              // No output or CPU use for the idle policy's MaxIdle, until the job is active again
+	GroupId        string            // Job group the job belongs to ("" if none)
+	Labels         map[string]string // Client-defined key/value labels
+	Status         JobStatus         // Current execution state
+	StatusReason   string            // Why the job entered its status, e.g. "exited with code 1"
+	Pid            int32             // Process ID when running
+	CgroupPath     string            // Filesystem path for resource limits ("" if the job has no cgroup)
+	Isolation      IsolationMode     // How the job's processes are isolated
+	QoS            QoSClass          // How the job fares against others under contention
+	Seccomp        SeccompMode       // Seccomp filter the job runs under ("" for none)
+	Runtime        string            // Sandbox runtime the job runs through ("" for none)
+	TimeOffset     time.Duration     // Shift of the job's monotonic and boottime clocks (0 for none)
+	CleanEnv       bool              // The job does not inherit the worker's environment
+	CommandDigest  string            // SHA-256 digest init verifies the executable against ("" for none)
+	Spec           *JobSpec          // Spec the job was started from, without secret values; never modified
+	Provenance     *Provenance       // What the job was launched with (nil until it is launched); never modified
+	CleanupError   string            // Why removing the job's cgroup failed ("" if it succeeded or is pending)
+	LaunchTime     time.Duration     // How long the worker took to start the job, from the request to its process running
+	CleanupTime    time.Duration     // How long after the job ended its cgroup was removed (0 while pending)
+	StartTime      time.Time         // Job creation timestamp
+	EndTime        *time.Time        // Completion timestamp (nil if running)
+	ExitCode       int32             // Process exit status
+}
+
+// ShellArgs are the sh arguments that run a Shell spec's command as a script,
+// with the spec's Args as $1, $2 and so on
+func (s *JobSpec) ShellArgs() []string {
+	return append([]string{"-c", s.Command, "sh"}, s.Args...)
+}
+
+// Copy returns a deep copy of the spec without its resolved secrets, which
+// are never stored
+func (s *JobSpec) Copy() *JobSpec {
+	if s == nil {
+		return nil
+	}
+
+	cp := *s
+	cp.Args = utils.CopyStringSlice(s.Args)
+	cp.Env = utils.CopyStringMap(s.Env)
+	cp.SecretEnv = utils.CopyStringMap(s.SecretEnv)
+	cp.Secrets = nil
+	cp.Probe = s.Probe.Copy()
+	cp.Labels = utils.CopyStringMap(s.Labels)
+	cp.Capabilities = append([]Capability(nil), s.Capabilities...)
+	cp.Inputs = append([]JobInput(nil), s.Inputs...)
+	cp.Sockets = append([]JobSocket(nil), s.Sockets...)
+	cp.AllowedWindows = append([]TimeWindow(nil), s.AllowedWindows...)
+	return &cp
+}
+
+// StartDeadline is the last time the job may start, zero if it has no queue timeout
+func (s *JobSpec) StartDeadline() time.Time {
+	if s.QueueTimeout <= 0 || s.Submitted.IsZero() {
+		return time.Time{}
+	}
+	return s.Submitted.Add(s.QueueTimeout)
 }
 
 func (j *Job) IsRunning() bool {
@@ -41,7 +194,20 @@ func (j *Job) IsRunning() bool {
 }
 
 func (j *Job) IsCompleted() bool {
-	return j.Status == StatusCompleted || j.Status == StatusFailed || j.Status == StatusStopped
+	return j.Status == StatusCompleted || j.Status == StatusFailed || j.Status == StatusStopped || j.Status == StatusExpired
+}
+
+// IsCrashLooping reports whether the job is held after failing too often
+func (j *Job) IsCrashLooping() bool {
+	return j.Status == StatusCrashLoop
+}
+
+// Age is the time since the job ended, or since it started while it is still active
+func (j *Job) Age(now time.Time) time.Duration {
+	if j.EndTime != nil {
+		return now.Sub(*j.EndTime)
+	}
+	return now.Sub(j.StartTime)
 }
 
 // MarkAsRunning transitions job from INITIALIZING to RUNNING state with given PID
@@ -55,35 +221,64 @@ func (j *Job) MarkAsRunning(pid int32) error {
 		return errors.New("PID must be positive")
 	}
 
-	j.Status = StatusRunning
+	j.setStatus(StatusRunning, fmt.Sprintf("started with pid %d", pid))
 	j.Pid = pid
 	return nil
 }
 
+// MarkAsRestarted marks a job whose process was relaunched, after it exited or was
+// resumed from a crash loop, as running again
+func (j *Job) MarkAsRestarted(pid int32, reason string) {
+	j.setStatus(StatusRunning, reason)
+	j.Pid = pid
+}
+
 // Complete marks job as successfully finished with given exit code
-func (j *Job) Complete(exitCode int32) {
-	j.Status = StatusCompleted
+func (j *Job) Complete(exitCode int32, reason string) {
+	j.setStatus(StatusCompleted, reason)
 	j.ExitCode = exitCode
 	now := time.Now()
 	j.EndTime = &now
 }
 
 // Fail marks job as failed with given exit code
-func (j *Job) Fail(exitCode int32) {
-	j.Status = StatusFailed
+func (j *Job) Fail(exitCode int32, reason string) {
+	j.setStatus(StatusFailed, reason)
 	j.ExitCode = exitCode
 	now := time.Now()
 	j.EndTime = &now
 }
 
+// HoldInCrashLoop marks a job that kept failing as held, with the exit code of
+// its last run. It is not finished: resuming restarts it.
+func (j *Job) HoldInCrashLoop(exitCode int32, reason string) {
+	j.setStatus(StatusCrashLoop, reason)
+	j.ExitCode = exitCode
+}
+
 // Stop forcefully terminates a running job
-func (j *Job) Stop() {
-	j.Status = StatusStopped
+func (j *Job) Stop(reason string) {
+	j.setStatus(StatusStopped, reason)
 	j.ExitCode = -1
 	now := time.Now()
 	j.EndTime = &now
 }
 
+// Expire marks a job that was not started in time as finished without running
+func (j *Job) Expire(reason string) {
+	j.setStatus(StatusExpired, reason)
+	j.ExitCode = -1
+	now := time.Now()
+	j.EndTime = &now
+}
+
+// setStatus moves the job to status for reason. Whether the move is allowed
+// is checked by the store, which knows the status it moves from.
+func (j *Job) setStatus(status JobStatus, reason string) {
+	j.Status = status
+	j.StatusReason = reason
+}
+
 // DeepCopy creates independent copy to prevent concurrent modification issues
 func (j *Job) DeepCopy() *Job {
 	var endTimeCopy *time.Time
@@ -93,16 +288,48 @@ func (j *Job) DeepCopy() *Job {
 	}
 
 	return &Job{
-		Id:         j.Id,
-		Command:    j.Command,
-		Args:       utils.CopyStringSlice(j.Args),
-		Limits:     j.Limits,
-		Status:     j.Status,
-		Pid:        j.Pid,
-		CgroupPath: j.CgroupPath,
-		StartTime:  j.StartTime,
-		EndTime:    endTimeCopy,
-		ExitCode:   j.ExitCode,
+		Id:             j.Id,
+		Command:        j.Command,
+		Args:           utils.CopyStringSlice(j.Args),
+		Limits:         j.Limits,
+		Env:            utils.CopyStringMap(j.Env),
+		SecretEnv:      utils.CopyStringMap(j.SecretEnv),
+		Workspace:      j.Workspace,
+		WorkspaceBytes: j.WorkspaceBytes,
+		CPUThrottled:   j.CPUThrottled,
+		OutputLocation: j.OutputLocation,
+		OutputDropped:  j.OutputDropped,
+		OutputExpired:  j.OutputExpired,
+		Result:         bytes.Clone(j.Result),
+		ResultError:    j.ResultError,
+		Restart:        j.Restart,
+		Restarts:       j.Restarts,
+		Probe:          j.Probe.Copy(),
+		Health:         j.Health,
+		Idle:           j.Idle,
+		GroupId:        j.GroupId,
+		Labels:         utils.CopyStringMap(j.Labels),
+		Status:         j.Status,
+		StatusReason:   j.StatusReason,
+		Pid:            j.Pid,
+		CgroupPath:     j.CgroupPath,
+		Isolation:      j.Isolation,
+		QoS:            j.QoS,
+		Seccomp:        j.Seccomp,
+		Runtime:        j.Runtime,
+		TimeOffset:     j.TimeOffset,
+		CleanEnv:       j.CleanEnv,
+		CommandDigest:  j.CommandDigest,
+		Spec:           j.Spec, // immutable, shared by all copies
+		Provenance:     j.Provenance,
+		CoreDump:       j.CoreDump,
+		CoreDumpBytes:  j.CoreDumpBytes,
+		CleanupError:   j.CleanupError,
+		LaunchTime:     j.LaunchTime,
+		CleanupTime:    j.CleanupTime,
+		StartTime:      j.StartTime,
+		EndTime:        endTimeCopy,
+		ExitCode:       j.ExitCode,
 	}
 }
 
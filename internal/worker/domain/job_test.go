@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"math"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -32,7 +34,7 @@ func TestJobStateTransitions(t *testing.T) {
 	}
 
 	// test valid transition: RUNNING -> COMPLETED
-	job.Complete(0)
+	job.Complete(0, "exited with code 0")
 	if job.Status != StatusCompleted {
 		t.Errorf("Expected status COMPLETED, got %v", job.Status)
 	}
@@ -81,7 +83,7 @@ func TestJobFailTransitions(t *testing.T) {
 				Status: tt.initialStatus,
 			}
 
-			job.Fail(tt.exitCode)
+			job.Fail(tt.exitCode, "exited")
 
 			if !tt.expectError {
 				if job.Status != tt.expectedStatus {
@@ -105,7 +107,7 @@ func TestJobStopTransition(t *testing.T) {
 		Pid:    1234,
 	}
 
-	job.Stop()
+	job.Stop("stopped by a user")
 
 	if job.Status != StatusStopped {
 		t.Errorf("Expected status STOPPED, got %v", job.Status)
@@ -118,6 +120,28 @@ func TestJobStopTransition(t *testing.T) {
 	}
 }
 
+func TestJobExpire(t *testing.T) {
+	job := &Job{Id: "test-expire", Status: StatusInitializing}
+
+	job.Expire("not started within its queue timeout")
+
+	if job.Status != StatusExpired || job.ExitCode != -1 || job.EndTime == nil {
+		t.Errorf("unexpected expired job %+v", job)
+	}
+}
+
+func TestJobSpecStartDeadline(t *testing.T) {
+	submitted := time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)
+
+	spec := &JobSpec{QueueTimeout: time.Hour, Submitted: submitted}
+	if got := spec.StartDeadline(); !got.Equal(submitted.Add(time.Hour)) {
+		t.Errorf("expected the deadline an hour after submission, got %v", got)
+	}
+	if got := (&JobSpec{Submitted: submitted}).StartDeadline(); !got.IsZero() {
+		t.Errorf("expected no deadline without a queue timeout, got %v", got)
+	}
+}
+
 func TestJobMarkAsRunningValidation(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -157,10 +181,11 @@ func TestJobDeepCopy(t *testing.T) {
 		Status:  StatusRunning,
 		Pid:     1234,
 		Limits: ResourceLimits{
-			MaxCPU:    100,
-			MaxMemory: 512,
-			MaxIOBPS:  1000,
+			CPUMillis:   1000,
+			MemoryBytes: 512 << 20,
+			IOBPS:       1000,
 		},
+		Env:        map[string]string{"APP_ENV": "prod"},
 		CgroupPath: "/sys/fs/cgroup/job-test-1",
 		StartTime:  time.Now(),
 		EndTime:    &endTime,
@@ -192,6 +217,12 @@ func TestJobDeepCopy(t *testing.T) {
 		t.Error("Deep copy failed: args slice was not properly copied")
 	}
 
+	// Test env map independence
+	original.Env["APP_ENV"] = "dev"
+	if cp.Env["APP_ENV"] != "prod" {
+		t.Error("Deep copy failed: env map was not properly copied")
+	}
+
 	// Test status independence
 	original.Status = StatusCompleted
 	if cp.Status != StatusRunning {
@@ -241,6 +272,7 @@ func TestJobIsCompleted(t *testing.T) {
 		{StatusCompleted, true},
 		{StatusFailed, true},
 		{StatusStopped, true},
+		{StatusExpired, true},
 	}
 
 	for _, tt := range tests {
@@ -287,3 +319,62 @@ func TestJobDuration(t *testing.T) {
 		t.Errorf("Expected duration 0, got %v", duration)
 	}
 }
+
+func TestJobSpecShellArgs(t *testing.T) {
+	spec := &JobSpec{Command: `grep -c "$1" "$2" | tee count`, Args: []string{"ERROR", "app.log"}, Shell: true}
+
+	want := []string{"-c", `grep -c "$1" "$2" | tee count`, "sh", "ERROR", "app.log"}
+	if got := spec.ShellArgs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
+
+func TestJobSpecCopy(t *testing.T) {
+	spec := &JobSpec{
+		Command:   "make",
+		Args:      []string{"test"},
+		Env:       map[string]string{"CI": "1"},
+		SecretEnv: map[string]string{"TOKEN": "ci-token"},
+		Secrets:   map[string]string{"TOKEN": "s3cr3t"},
+		Labels:    map[string]string{"team": "ci"},
+		Probe:     &HealthProbe{Command: []string{"true"}},
+	}
+
+	cp := spec.Copy()
+	if cp.Secrets != nil {
+		t.Errorf("expected secret values to be dropped, got %v", cp.Secrets)
+	}
+	if cp.SecretEnv["TOKEN"] != "ci-token" {
+		t.Errorf("expected secret references to be kept, got %v", cp.SecretEnv)
+	}
+
+	spec.Args[0] = "clean"
+	spec.Env["CI"] = "0"
+	spec.Labels["team"] = "ops"
+	spec.Probe.Command[0] = "false"
+	if cp.Args[0] != "test" || cp.Env["CI"] != "1" || cp.Labels["team"] != "ci" || cp.Probe.Command[0] != "true" {
+		t.Errorf("copy shares state with the original: %+v", cp)
+	}
+
+	if (*JobSpec)(nil).Copy() != nil {
+		t.Error("expected the copy of a nil spec to be nil")
+	}
+}
+
+func TestResourceLimitsLegacy(t *testing.T) {
+	limits := LegacyLimits(150, 512, 1000)
+	if want := (ResourceLimits{CPUMillis: 1500, MemoryBytes: 512 << 20, IOBPS: 1000}); limits != want {
+		t.Errorf("expected %+v, got %+v", want, limits)
+	}
+
+	cpu, memory, io := limits.Legacy()
+	if cpu != 150 || memory != 512 || io != 1000 {
+		t.Errorf("expected 150/512/1000, got %d/%d/%d", cpu, memory, io)
+	}
+
+	limits = ResourceLimits{CPUMillis: math.MaxInt64, MemoryBytes: 1 << 60, IOBPS: 1 << 40}
+	cpu, memory, io = limits.Legacy()
+	if cpu != math.MaxInt32 || memory != math.MaxInt32 || io != math.MaxInt32 {
+		t.Errorf("expected limits capped at int32 max, got %d/%d/%d", cpu, memory, io)
+	}
+}
@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxOutputFlush bounds how long a job's output may be held back to be stored
+// in larger chunks
+const MaxOutputFlush = 10 * time.Second
+
+// OutputFlushFromMillis validates a job's output flush interval in
+// milliseconds: 0 leaves the server's interval and -1 stores every write as it
+// comes, returned as a negative duration
+func OutputFlushFromMillis(millis int64) (time.Duration, error) {
+	if millis < -1 || millis > int64(MaxOutputFlush/time.Millisecond) {
+		return 0, fmt.Errorf("output flush interval %dms out of range (-1 to %v)", millis, MaxOutputFlush)
+	}
+	return time.Duration(millis) * time.Millisecond, nil
+}
+
+// OutputLimitAction is what happens to a job's output over its rate limit
+type OutputLimitAction string
+
+const (
+	OutputThrottle OutputLimitAction = "throttle" // block the job's writes until the rate allows them
+	OutputTruncate OutputLimitAction = "truncate" // drop the output over the rate
+)
+
+// MinOutputRate bounds output rate limits from below, so the output a job
+// leaves in its pipes when it exits drains within a minute
+const MinOutputRate = 1024
+
+// OutputLimit caps the rate a job's stdout and stderr together are stored at
+type OutputLimit struct {
+	BytesPerSecond int64             // 0 for the server's limit
+	Action         OutputLimitAction // "" for the server's action
+}
+
+// ParseOutputLimit validates a job's output rate limit. An action without a
+// rate applies to the server's rate.
+func ParseOutputLimit(bytesPerSecond int64, action string) (OutputLimit, error) {
+	if bytesPerSecond != 0 && bytesPerSecond < MinOutputRate {
+		return OutputLimit{}, fmt.Errorf("output rate limit %d B/s out of range (0 or at least %d)", bytesPerSecond, MinOutputRate)
+	}
+
+	limit := OutputLimit{BytesPerSecond: bytesPerSecond}
+	switch OutputLimitAction(action) {
+	case "", OutputThrottle, OutputTruncate:
+		limit.Action = OutputLimitAction(action)
+	default:
+		return OutputLimit{}, fmt.Errorf("unknown output limit action %q (expected throttle or truncate)", action)
+	}
+	return limit, nil
+}
+
+// Within returns the limit in effect for a job under the server's limit: the
+// job's own rate up to the server's, and the job's action, else the server's,
+// else throttle. A rate of 0 is no limit.
+func (l OutputLimit) Within(server OutputLimit) OutputLimit {
+	limit := l
+	if limit.BytesPerSecond == 0 || server.BytesPerSecond > 0 && limit.BytesPerSecond > server.BytesPerSecond {
+		limit.BytesPerSecond = server.BytesPerSecond
+	}
+	if limit.Action == "" {
+		limit.Action = server.Action
+	}
+	if limit.Action == "" {
+		limit.Action = OutputThrottle
+	}
+	return limit
+}
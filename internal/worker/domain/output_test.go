@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutputFlushFromMillis(t *testing.T) {
+	for millis, want := range map[int64]time.Duration{
+		0:     0,
+		-1:    -time.Millisecond,
+		250:   250 * time.Millisecond,
+		10000: MaxOutputFlush,
+	} {
+		got, err := OutputFlushFromMillis(millis)
+		if err != nil || got != want {
+			t.Errorf("OutputFlushFromMillis(%d) = %v, %v; want %v", millis, got, err, want)
+		}
+	}
+
+	for _, millis := range []int64{-2, 10001} {
+		if _, err := OutputFlushFromMillis(millis); err == nil {
+			t.Errorf("expected output flush interval %dms to be rejected", millis)
+		}
+	}
+}
+
+func TestParseOutputLimit(t *testing.T) {
+	limit, err := ParseOutputLimit(4096, "truncate")
+	if err != nil || limit != (OutputLimit{BytesPerSecond: 4096, Action: OutputTruncate}) {
+		t.Errorf("expected a 4096 B/s truncate limit, got %+v, %v", limit, err)
+	}
+	if limit, err = ParseOutputLimit(0, "truncate"); err != nil || limit.Action != OutputTruncate {
+		t.Errorf("expected an action without a rate to be kept, got %+v, %v", limit, err)
+	}
+
+	for _, bad := range []struct {
+		rate   int64
+		action string
+	}{{100, ""}, {-1, ""}, {4096, "drop"}} {
+		if _, err := ParseOutputLimit(bad.rate, bad.action); err == nil {
+			t.Errorf("expected %d B/s %q to be rejected", bad.rate, bad.action)
+		}
+	}
+}
+
+func TestOutputLimitWithin(t *testing.T) {
+	server := OutputLimit{BytesPerSecond: 1 << 20, Action: OutputTruncate}
+	for _, tc := range []struct {
+		job, want OutputLimit
+	}{
+		{OutputLimit{}, server},
+		{OutputLimit{BytesPerSecond: 4096}, OutputLimit{BytesPerSecond: 4096, Action: OutputTruncate}},
+		{OutputLimit{BytesPerSecond: 1 << 30, Action: OutputThrottle}, OutputLimit{BytesPerSecond: 1 << 20, Action: OutputThrottle}},
+	} {
+		if got := tc.job.Within(server); got != tc.want {
+			t.Errorf("%+v within %+v = %+v, want %+v", tc.job, server, got, tc.want)
+		}
+	}
+
+	if got := (OutputLimit{BytesPerSecond: 4096}).Within(OutputLimit{}); got != (OutputLimit{BytesPerSecond: 4096, Action: OutputThrottle}) {
+		t.Errorf("expected a job limit without a server limit to throttle, got %+v", got)
+	}
+}
@@ -0,0 +1,211 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+type PipelineStatus string
+
+const (
+	PipelineRunning   PipelineStatus = "RUNNING"
+	PipelineCompleted PipelineStatus = "COMPLETED"
+	PipelineFailed    PipelineStatus = "FAILED"
+)
+
+type StepStatus string
+
+const (
+	StepPending   StepStatus = "PENDING"
+	StepRunning   StepStatus = "RUNNING"
+	StepCompleted StepStatus = "COMPLETED"
+	StepFailed    StepStatus = "FAILED"
+	StepStopped   StepStatus = "STOPPED"
+	StepSkipped   StepStatus = "SKIPPED" // an upstream step did not complete
+)
+
+type InputSource string
+
+const (
+	InputStdout    InputSource = "stdout"    // upstream job's standard output as a single file
+	InputArtifacts InputSource = "artifacts" // upstream job's workspace files
+)
+
+// PipelineInput wires the output of an upstream step into a step's workspace
+type PipelineInput struct {
+	Step   string      // Upstream step name
+	Source InputSource // What to take from the upstream step
+	Path   string      // Destination relative to the workspace ("" for inputs/<step>...)
+}
+
+// Destination returns where the input is placed in the downstream workspace
+func (in PipelineInput) Destination() string {
+	if in.Path != "" {
+		return filepath.Clean(in.Path)
+	}
+	if in.Source == InputStdout {
+		return filepath.Join("inputs", in.Step+".stdout")
+	}
+	return filepath.Join("inputs", in.Step)
+}
+
+// PipelineStep is one job of a pipeline together with what it waits for
+type PipelineStep struct {
+	Name      string
+	Job       *JobSpec
+	DependsOn []string        // Steps that must complete first without passing data
+	Inputs    []PipelineInput // Steps whose output is staged in this step's workspace
+}
+
+// Upstream returns every step this step waits for, without duplicates
+func (s *PipelineStep) Upstream() []string {
+	seen := make(map[string]bool)
+	var upstream []string
+	add := func(name string) {
+		if !seen[name] {
+			seen[name] = true
+			upstream = append(upstream, name)
+		}
+	}
+
+	for _, name := range s.DependsOn {
+		add(name)
+	}
+	for _, in := range s.Inputs {
+		add(in.Step)
+	}
+	return upstream
+}
+
+// PipelineSpec describes a set of jobs run in dependency order
+type PipelineSpec struct {
+	Steps []PipelineStep
+}
+
+// Validate checks step names, references and inputs, and rejects dependency cycles
+func (p *PipelineSpec) Validate() error {
+	if len(p.Steps) == 0 {
+		return errors.New("pipeline has no steps")
+	}
+
+	steps := make(map[string]*PipelineStep, len(p.Steps))
+	for i := range p.Steps {
+		step := &p.Steps[i]
+		if step.Name == "" {
+			return fmt.Errorf("step %d has no name", i)
+		}
+		if _, dup := steps[step.Name]; dup {
+			return fmt.Errorf("duplicate step name %q", step.Name)
+		}
+		if step.Job == nil {
+			return fmt.Errorf("step %q has no job", step.Name)
+		}
+		steps[step.Name] = step
+	}
+
+	for _, step := range p.Steps {
+		for _, name := range step.Upstream() {
+			if name == step.Name {
+				return fmt.Errorf("step %q depends on itself", step.Name)
+			}
+			if _, ok := steps[name]; !ok {
+				return fmt.Errorf("step %q depends on unknown step %q", step.Name, name)
+			}
+		}
+
+		for _, in := range step.Inputs {
+			if in.Source != InputStdout && in.Source != InputArtifacts {
+				return fmt.Errorf("step %q: unknown input source %q", step.Name, in.Source)
+			}
+			if !filepath.IsLocal(in.Destination()) {
+				return fmt.Errorf("step %q: input path %q must be relative to the workspace", step.Name, in.Path)
+			}
+		}
+
+		if len(step.Inputs) > 0 && step.Job.UploadID != "" {
+			return fmt.Errorf("step %q: inputs cannot be combined with an upload", step.Name)
+		}
+	}
+
+	// depth-first search, a step seen again while still on the stack closes a cycle
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(steps))
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visiting:
+			return fmt.Errorf("dependency cycle through step %q", name)
+		case visited:
+			return nil
+		}
+		state[name] = visiting
+		for _, upstream := range steps[name].Upstream() {
+			if err := visit(upstream); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		return nil
+	}
+
+	for _, step := range p.Steps {
+		if err := visit(step.Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StepState is the progress of a single pipeline step
+type StepState struct {
+	Name   string
+	JobId  string // Set once the step's job has been started
+	Status StepStatus
+	Error  string // Why the step failed to start or was skipped
+}
+
+type Pipeline struct {
+	Id        string
+	Status    PipelineStatus
+	Steps     []StepState
+	StartTime time.Time
+	EndTime   *time.Time
+}
+
+// DeepCopy creates independent copy to prevent concurrent modification issues
+func (p *Pipeline) DeepCopy() *Pipeline {
+	var endTimeCopy *time.Time
+	if p.EndTime != nil {
+		cp := *p.EndTime
+		endTimeCopy = &cp
+	}
+
+	return &Pipeline{
+		Id:        p.Id,
+		Status:    p.Status,
+		Steps:     append([]StepState(nil), p.Steps...),
+		StartTime: p.StartTime,
+		EndTime:   endTimeCopy,
+	}
+}
+
+// StepStatusFromJob maps the final status of a step's job to the step status
+func StepStatusFromJob(status JobStatus) StepStatus {
+	switch status {
+	case StatusCompleted:
+		return StepCompleted
+	case StatusStopped:
+		return StepStopped
+	case StatusRunning, StatusInitializing, StatusCrashLoop:
+		return StepRunning
+	default:
+		return StepFailed
+	}
+}
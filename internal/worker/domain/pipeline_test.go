@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPipelineSpecValidate(t *testing.T) {
+	job := &JobSpec{Command: "echo"}
+
+	tests := []struct {
+		name    string
+		steps   []PipelineStep
+		wantErr string
+	}{
+		{
+			name: "valid chain",
+			steps: []PipelineStep{
+				{Name: "build", Job: job},
+				{Name: "test", Job: job, Inputs: []PipelineInput{{Step: "build", Source: InputArtifacts}}},
+				{Name: "notify", Job: job, DependsOn: []string{"test"}},
+			},
+		},
+		{name: "empty", wantErr: "no steps"},
+		{
+			name:    "duplicate name",
+			steps:   []PipelineStep{{Name: "a", Job: job}, {Name: "a", Job: job}},
+			wantErr: "duplicate",
+		},
+		{
+			name:    "unknown dependency",
+			steps:   []PipelineStep{{Name: "a", Job: job, DependsOn: []string{"b"}}},
+			wantErr: "unknown step",
+		},
+		{
+			name: "cycle",
+			steps: []PipelineStep{
+				{Name: "a", Job: job, DependsOn: []string{"c"}},
+				{Name: "b", Job: job, DependsOn: []string{"a"}},
+				{Name: "c", Job: job, Inputs: []PipelineInput{{Step: "b", Source: InputStdout}}},
+			},
+			wantErr: "cycle",
+		},
+		{
+			name: "unknown source",
+			steps: []PipelineStep{
+				{Name: "a", Job: job},
+				{Name: "b", Job: job, Inputs: []PipelineInput{{Step: "a", Source: "stderr"}}},
+			},
+			wantErr: "input source",
+		},
+		{
+			name: "escaping path",
+			steps: []PipelineStep{
+				{Name: "a", Job: job},
+				{Name: "b", Job: job, Inputs: []PipelineInput{{Step: "a", Source: InputStdout, Path: "../out"}}},
+			},
+			wantErr: "relative",
+		},
+		{
+			name: "inputs with upload",
+			steps: []PipelineStep{
+				{Name: "a", Job: job},
+				{Name: "b", Job: &JobSpec{Command: "cat", UploadID: "x"}, Inputs: []PipelineInput{{Step: "a", Source: InputStdout}}},
+			},
+			wantErr: "upload",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := (&PipelineSpec{Steps: tt.steps}).Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("expected valid pipeline, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPipelineInputDestination(t *testing.T) {
+	if got := (PipelineInput{Step: "build", Source: InputStdout}).Destination(); got != "inputs/build.stdout" {
+		t.Errorf("unexpected stdout destination %s", got)
+	}
+	if got := (PipelineInput{Step: "build", Source: InputArtifacts}).Destination(); got != "inputs/build" {
+		t.Errorf("unexpected artifacts destination %s", got)
+	}
+	if got := (PipelineInput{Step: "build", Source: InputArtifacts, Path: "src/"}).Destination(); got != "src" {
+		t.Errorf("unexpected custom destination %s", got)
+	}
+}
+
+func TestPipelineStepUpstream(t *testing.T) {
+	step := PipelineStep{
+		DependsOn: []string{"a", "b"},
+		Inputs:    []PipelineInput{{Step: "b"}, {Step: "c"}},
+	}
+	if got := strings.Join(step.Upstream(), ","); got != "a,b,c" {
+		t.Errorf("expected a,b,c, got %s", got)
+	}
+}
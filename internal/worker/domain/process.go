@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// JobProcess is one process of a running job, as /proc reports it
+type JobProcess struct {
+	Pid        int32
+	ParentPid  int32
+	Depth      int32  // In the job's process tree, 0 for a process whose parent is not in the job
+	Command    string // Command line, the process name in brackets when it has none
+	State      string // R running, S sleeping, D disk wait, Z zombie, T stopped
+	Threads    int32
+	CPUTime    time.Duration // User and system time the process used
+	CPUPercent float64       // CPU time over the time since the process started, as ps reports it
+	RSSBytes   int64         // Resident memory
+	StartTime  time.Time
+}
+
+// ProcessTree orders the processes of a job depth first, each one after its
+// parent, and sets their depth. Processes whose parent is not in the job,
+// usually just the job's first process, are the roots.
+func ProcessTree(processes []JobProcess) []JobProcess {
+	byPid := make(map[int32]bool, len(processes))
+	for _, p := range processes {
+		byPid[p.Pid] = true
+	}
+
+	children := make(map[int32][]JobProcess)
+	var roots []JobProcess
+	for _, p := range processes {
+		if p.ParentPid != p.Pid && byPid[p.ParentPid] {
+			children[p.ParentPid] = append(children[p.ParentPid], p)
+		} else {
+			roots = append(roots, p)
+		}
+	}
+
+	tree := make([]JobProcess, 0, len(processes))
+	var walk func(level []JobProcess, depth int32)
+	walk = func(level []JobProcess, depth int32) {
+		sort.Slice(level, func(i, j int) bool { return level[i].Pid < level[j].Pid })
+		for _, p := range level {
+			p.Depth = depth
+			tree = append(tree, p)
+			walk(children[p.Pid], depth+1)
+		}
+	}
+	walk(roots, 0)
+	return tree
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "testing"
+
+func TestProcessTree(t *testing.T) {
+	tree := ProcessTree([]JobProcess{
+		{Pid: 12, ParentPid: 10},
+		{Pid: 30, ParentPid: 11},
+		{Pid: 10, ParentPid: 1},
+		{Pid: 11, ParentPid: 10},
+		{Pid: 40, ParentPid: 2}, // reparented outside the job
+	})
+
+	want := []struct{ pid, depth int32 }{{10, 0}, {11, 1}, {30, 2}, {12, 1}, {40, 0}}
+	if len(tree) != len(want) {
+		t.Fatalf("expected %d processes, got %+v", len(want), tree)
+	}
+	for i, w := range want {
+		if tree[i].Pid != w.pid || tree[i].Depth != w.depth {
+			t.Errorf("process %d: expected pid %d at depth %d, got pid %d at depth %d", i, w.pid, w.depth, tree[i].Pid, tree[i].Depth)
+		}
+	}
+}
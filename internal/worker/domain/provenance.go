@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Provenance records what a job actually ran with, captured once when it is
+// launched, for reproducing the run and for audits. It is never modified.
+type Provenance struct {
+	Command       string            // Path of the executable the job ran, as the job sees it
+	CommandSHA256 string            // Hex SHA-256 digest of the executable ("" if the worker could not read it)
+	Env           map[string]string // Environment the command ran with, secrets and sensitive values masked
+	KernelVersion string            // Release of the host kernel, e.g. "6.8.0-45-generic"
+	CgroupPath    string            // Job cgroup ("" if the job has none)
+	Cgroup        map[string]string // Cgroup interface file -> value read back after setup, e.g. "memory.max"
+	WorkerVersion string            // Version of the worker that launched the job
+	SignedBy      string            // Signing key the job spec was verified with ("" if it was not signed)
+	RecordedAt    time.Time
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "fmt"
+
+// QoSClass is how a job fares against other jobs when the host is contended
+type QoSClass string
+
+const (
+	QoSGuaranteed QoSClass = "guaranteed"  // favoured for CPU and IO, its memory never reclaimed, OOM-killed last
+	QoSBurstable  QoSClass = "burstable"   // kernel default shares, half its memory limit protected from reclaim
+	QoSBestEffort QoSClass = "best-effort" // runs on what other jobs leave, reclaimed and OOM-killed first
+)
+
+// ParseQoSClass validates a QoS class, treating "" as burstable
+func ParseQoSClass(class string) (QoSClass, error) {
+	switch QoSClass(class) {
+	case "", QoSBurstable:
+		return QoSBurstable, nil
+	case QoSGuaranteed, QoSBestEffort:
+		return QoSClass(class), nil
+	default:
+		return "", fmt.Errorf("unknown QoS class %q (expected guaranteed, burstable or best-effort)", class)
+	}
+}
+
+// QoSSettings are the cgroup and OOM killer settings a QoS class maps to
+type QoSSettings struct {
+	CPUWeight   int64 // cpu.weight, 1 to 10000, the kernel default is 100
+	IOWeight    int64 // io.weight, 1 to 10000, the kernel default is 100
+	MemoryMin   int64 // memory.min, bytes never reclaimed, 0 for none
+	MemoryLow   int64 // memory.low, bytes reclaimed only when unprotected memory runs out, 0 for none
+	OOMScoreAdj int   // oom_score_adj of the job's processes, -1000 to 1000
+}
+
+// Settings returns the settings of the class for a job with the given limits.
+// Memory protection is sized by the memory limit, jobs without one get none.
+func (c QoSClass) Settings(limits ResourceLimits) QoSSettings {
+	switch c {
+	case QoSGuaranteed:
+		return QoSSettings{CPUWeight: 1000, IOWeight: 1000, MemoryMin: limits.MemoryBytes, OOMScoreAdj: -900}
+	case QoSBestEffort:
+		return QoSSettings{CPUWeight: 1, IOWeight: 1, OOMScoreAdj: 1000}
+	default:
+		return QoSSettings{CPUWeight: 100, IOWeight: 100, MemoryLow: limits.MemoryBytes / 2}
+	}
+}
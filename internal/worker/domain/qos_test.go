@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestParseQoSClass(t *testing.T) {
+	for input, want := range map[string]QoSClass{
+		"":            QoSBurstable,
+		"burstable":   QoSBurstable,
+		"guaranteed":  QoSGuaranteed,
+		"best-effort": QoSBestEffort,
+	} {
+		got, err := ParseQoSClass(input)
+		if err != nil || got != want {
+			t.Errorf("ParseQoSClass(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	if _, err := ParseQoSClass("premium"); err == nil {
+		t.Error("expected unknown QoS class to be rejected")
+	}
+}
+
+func TestQoSSettings(t *testing.T) {
+	limits := ResourceLimits{CPUMillis: 1000, MemoryBytes: 1 << 30}
+
+	guaranteed := QoSGuaranteed.Settings(limits)
+	burstable := QoSBurstable.Settings(limits)
+	bestEffort := QoSBestEffort.Settings(limits)
+
+	if !(guaranteed.CPUWeight > burstable.CPUWeight && burstable.CPUWeight > bestEffort.CPUWeight) {
+		t.Errorf("expected CPU weights to decrease by class, got %d, %d, %d", guaranteed.CPUWeight, burstable.CPUWeight, bestEffort.CPUWeight)
+	}
+	if !(guaranteed.OOMScoreAdj < burstable.OOMScoreAdj && burstable.OOMScoreAdj < bestEffort.OOMScoreAdj) {
+		t.Errorf("expected best-effort jobs to be OOM-killed first, got %d, %d, %d", guaranteed.OOMScoreAdj, burstable.OOMScoreAdj, bestEffort.OOMScoreAdj)
+	}
+	if guaranteed.MemoryMin != 1<<30 || burstable.MemoryLow != 1<<29 || bestEffort.MemoryMin != 0 || bestEffort.MemoryLow != 0 {
+		t.Errorf("unexpected memory protection %+v, %+v, %+v", guaranteed, burstable, bestEffort)
+	}
+
+	if unlimited := QoSGuaranteed.Settings(ResourceLimits{}); unlimited.MemoryMin != 0 {
+		t.Errorf("expected no memory protection without a memory limit, got %d", unlimited.MemoryMin)
+	}
+}
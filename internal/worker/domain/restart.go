@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+type RestartMode string
+
+const (
+	RestartNever     RestartMode = "never"
+	RestartOnFailure RestartMode = "on-failure"
+	RestartAlways    RestartMode = "always"
+)
+
+// ParseRestartMode validates a restart mode, treating "" as never
+func ParseRestartMode(mode string) (RestartMode, error) {
+	switch RestartMode(mode) {
+	case "", RestartNever:
+		return RestartNever, nil
+	case RestartOnFailure, RestartAlways:
+		return RestartMode(mode), nil
+	default:
+		return "", fmt.Errorf("unknown restart policy %q (expected never, on-failure or always)", mode)
+	}
+}
+
+// RestartPolicy decides whether the worker relaunches a job when its process exits
+type RestartPolicy struct {
+	Mode        RestartMode
+	MaxRestarts int32 // Restarts allowed before the job is left failed, 0 means the server default
+}
+
+// ShouldRestart reports whether a process that finished with the given status is
+// relaunched after restarts previous restarts, given the restart limit
+func (p RestartPolicy) ShouldRestart(status JobStatus, restarts, maxRestarts int32) bool {
+	if restarts >= maxRestarts {
+		return false
+	}
+
+	switch p.Mode {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return status != StatusCompleted
+	default:
+		return false
+	}
+}
+
+// RestartBackoff returns the delay before the next restart: initial doubled for each
+// consecutive crash, capped at max
+func RestartBackoff(initial, max time.Duration, consecutiveCrashes int) time.Duration {
+	delay := initial
+	for i := 0; i < consecutiveCrashes && delay < max; i++ {
+		delay *= 2
+	}
+	if delay > max {
+		return max
+	}
+	return delay
+}
+
+// CrashLoop detects a job failing Threshold times within Window, after which
+// the worker stops restarting it until a user resumes it
+type CrashLoop struct {
+	Threshold int // failures that make a crash loop, 0 never detects one
+	Window    time.Duration
+	failures  []time.Time
+}
+
+// Failed records a failed run that ended at t and reports whether the job is
+// now crash looping
+func (c *CrashLoop) Failed(t time.Time) bool {
+	if c.Threshold <= 0 {
+		return false
+	}
+
+	recent := c.failures[:0]
+	for _, f := range c.failures {
+		if t.Sub(f) < c.Window {
+			recent = append(recent, f)
+		}
+	}
+	c.failures = append(recent, t)
+	return len(c.failures) >= c.Threshold
+}
+
+// Reset forgets the recorded failures
+func (c *CrashLoop) Reset() {
+	c.failures = nil
+}
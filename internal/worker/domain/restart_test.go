@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRestartMode(t *testing.T) {
+	for input, want := range map[string]RestartMode{
+		"":           RestartNever,
+		"never":      RestartNever,
+		"on-failure": RestartOnFailure,
+		"always":     RestartAlways,
+	} {
+		got, err := ParseRestartMode(input)
+		if err != nil || got != want {
+			t.Errorf("ParseRestartMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	if _, err := ParseRestartMode("sometimes"); err == nil {
+		t.Error("expected unknown restart mode to be rejected")
+	}
+}
+
+func TestRestartPolicyShouldRestart(t *testing.T) {
+	tests := []struct {
+		mode     RestartMode
+		status   JobStatus
+		restarts int32
+		want     bool
+	}{
+		{RestartNever, StatusFailed, 0, false},
+		{RestartOnFailure, StatusFailed, 0, true},
+		{RestartOnFailure, StatusCompleted, 0, false},
+		{RestartAlways, StatusCompleted, 0, true},
+		{RestartAlways, StatusFailed, 2, true},
+		{RestartAlways, StatusFailed, 3, false}, // circuit breaker
+	}
+
+	for _, tt := range tests {
+		policy := RestartPolicy{Mode: tt.mode}
+		if got := policy.ShouldRestart(tt.status, tt.restarts, 3); got != tt.want {
+			t.Errorf("%s after %s with %d restarts: got %v, want %v", tt.mode, tt.status, tt.restarts, got, tt.want)
+		}
+	}
+}
+
+func TestRestartBackoff(t *testing.T) {
+	tests := []struct {
+		crashes int
+		want    time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{10, 30 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := RestartBackoff(time.Second, 30*time.Second, tt.crashes); got != tt.want {
+			t.Errorf("RestartBackoff after %d crashes = %v, want %v", tt.crashes, got, tt.want)
+		}
+	}
+}
+
+func TestCrashLoop(t *testing.T) {
+	loop := CrashLoop{Threshold: 3, Window: time.Minute}
+	start := time.Now()
+
+	// failures spread wider than the window never add up
+	for i := 0; i < 5; i++ {
+		if loop.Failed(start.Add(time.Duration(i) * 40 * time.Second)) {
+			t.Fatalf("failure %d 40s apart detected as a crash loop", i)
+		}
+	}
+
+	loop.Reset()
+	if loop.Failed(start) || loop.Failed(start.Add(10*time.Second)) {
+		t.Fatal("crash loop detected below the threshold")
+	}
+	if !loop.Failed(start.Add(20 * time.Second)) {
+		t.Error("expected 3 failures within a minute to be a crash loop")
+	}
+
+	disabled := CrashLoop{Window: time.Minute}
+	for i := 0; i < 10; i++ {
+		if disabled.Failed(start) {
+			t.Fatal("expected no crash loop without a threshold")
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JobResultFile is the file in its workspace a job writes its result to. The
+// job finds its path in JOB_RESULT_PATH.
+const JobResultFile = ".job-result.json"
+
+// MaxJobResultBytes bounds the result a job may leave, it is kept with the job record
+const MaxJobResultBytes = 64 << 10
+
+// ValidateJobResult checks a result a job left is a JSON document within
+// MaxJobResultBytes
+func ValidateJobResult(data []byte) error {
+	if len(data) > MaxJobResultBytes {
+		return fmt.Errorf("result exceeds %d bytes", MaxJobResultBytes)
+	}
+	if !json.Valid(data) {
+		return fmt.Errorf("result is not valid JSON")
+	}
+	return nil
+}
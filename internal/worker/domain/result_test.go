@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateJobResult(t *testing.T) {
+	if err := ValidateJobResult([]byte(`{"rows": 42, "path": "out/report.csv"}`)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := ValidateJobResult([]byte(`rows=42`)); err == nil {
+		t.Error("expected an error for a result that is not JSON")
+	}
+	if err := ValidateJobResult([]byte(`"` + strings.Repeat("x", MaxJobResultBytes) + `"`)); err == nil {
+		t.Error("expected an error for a result over the limit")
+	}
+}
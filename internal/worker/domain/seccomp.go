@@ -0,0 +1,23 @@
+package domain
+
+import "fmt"
+
+// SeccompMode is the seccomp filter a job runs under
+type SeccompMode string
+
+const (
+	SeccompNone  SeccompMode = ""      // no filter
+	SeccompAudit SeccompMode = "audit" // every syscall is allowed and logged by the kernel audit subsystem
+)
+
+// ParseSeccompMode validates a seccomp mode, treating "" and "none" as no filter
+func ParseSeccompMode(mode string) (SeccompMode, error) {
+	switch mode {
+	case "", "none":
+		return SeccompNone, nil
+	case string(SeccompAudit):
+		return SeccompAudit, nil
+	default:
+		return "", fmt.Errorf("unknown seccomp mode %q (expected none or audit)", mode)
+	}
+}
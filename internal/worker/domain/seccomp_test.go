@@ -0,0 +1,22 @@
+package domain
+
+import "testing"
+
+func TestParseSeccompMode(t *testing.T) {
+	for input, want := range map[string]SeccompMode{
+		"":      SeccompNone,
+		"none":  SeccompNone,
+		"audit": SeccompAudit,
+	} {
+		got, err := ParseSeccompMode(input)
+		if err != nil || got != want {
+			t.Errorf("ParseSeccompMode(%q) = %q, %v; want %q", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"enforce", "AUDIT"} {
+		if _, err := ParseSeccompMode(input); err == nil {
+			t.Errorf("expected seccomp mode %q to be rejected", input)
+		}
+	}
+}
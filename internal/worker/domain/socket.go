@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaxJobSockets bounds the number of listening sockets passed into a job
+const MaxJobSockets = 16
+
+// socketName matches the names jobs find their sockets under in
+// LISTEN_FDNAMES, which separates them with colons
+var socketName = regexp.MustCompile(`^[A-Za-z0-9_.-]{1,64}$`)
+
+// JobSocket is a listening socket the worker opens and passes into the job,
+// the way systemd passes sockets to socket-activated services
+type JobSocket struct {
+	Name    string // Name the job finds the socket under in LISTEN_FDNAMES
+	Address string // tcp://HOST:PORT, or unix://NAME for a socket in the worker's socket directory
+}
+
+// SocketAddress is the address a job socket listens on
+type SocketAddress struct {
+	Network string // "tcp" or "unix"
+	Address string // HOST:PORT for tcp, the socket file name for unix
+}
+
+// ParseSocketAddress splits a socket address into its network and address
+func ParseSocketAddress(address string) (SocketAddress, error) {
+	network, rest, ok := strings.Cut(address, "://")
+	switch {
+	case !ok:
+		return SocketAddress{}, fmt.Errorf("socket address %q must be tcp://HOST:PORT or unix://NAME", address)
+	case network == "tcp":
+		if _, port, err := net.SplitHostPort(rest); err != nil || port == "" {
+			return SocketAddress{}, fmt.Errorf("socket address %q must be tcp://HOST:PORT", address)
+		}
+	case network == "unix":
+		if rest == "" || rest != filepath.Base(rest) || rest == "." || rest == ".." {
+			return SocketAddress{}, fmt.Errorf("socket address %q must name a file in the socket directory", address)
+		}
+	default:
+		return SocketAddress{}, fmt.Errorf("socket address %q must be tcp://HOST:PORT or unix://NAME", address)
+	}
+	return SocketAddress{Network: network, Address: rest}, nil
+}
+
+// ValidateJobSockets checks a job's sockets have distinct names and valid
+// addresses, each used once
+func ValidateJobSockets(sockets []JobSocket) error {
+	if len(sockets) > MaxJobSockets {
+		return fmt.Errorf("%d sockets exceed the maximum of %d", len(sockets), MaxJobSockets)
+	}
+
+	names := make(map[string]bool, len(sockets))
+	addresses := make(map[string]bool, len(sockets))
+	for _, socket := range sockets {
+		if !socketName.MatchString(socket.Name) {
+			return fmt.Errorf("socket name %q must be 1 to 64 letters, digits, '_', '.' or '-'", socket.Name)
+		}
+		if names[socket.Name] {
+			return fmt.Errorf("socket name %q is used twice", socket.Name)
+		}
+		names[socket.Name] = true
+
+		if _, err := ParseSocketAddress(socket.Address); err != nil {
+			return err
+		}
+		if addresses[socket.Address] {
+			return fmt.Errorf("socket address %q is used twice", socket.Address)
+		}
+		addresses[socket.Address] = true
+	}
+	return nil
+}
+
+// String returns the socket as NAME=ADDRESS
+func (s JobSocket) String() string {
+	return s.Name + "=" + s.Address
+}
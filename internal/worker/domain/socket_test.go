@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestParseSocketAddress(t *testing.T) {
+	valid := map[string]SocketAddress{
+		"tcp://:8080":         {Network: "tcp", Address: ":8080"},
+		"tcp://127.0.0.1:80":  {Network: "tcp", Address: "127.0.0.1:80"},
+		"tcp://[::1]:443":     {Network: "tcp", Address: "[::1]:443"},
+		"unix://app.sock":     {Network: "unix", Address: "app.sock"},
+		"unix://metrics-1.sk": {Network: "unix", Address: "metrics-1.sk"},
+	}
+	for input, want := range valid {
+		got, err := ParseSocketAddress(input)
+		if err != nil || got != want {
+			t.Errorf("ParseSocketAddress(%q) = %+v, %v; want %+v", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"", ":8080", "tcp://localhost", "tcp://host:", "udp://:53", "unix://", "unix://../etc/x.sock", "unix://run/app.sock", "unix://.."} {
+		if _, err := ParseSocketAddress(input); err == nil {
+			t.Errorf("ParseSocketAddress(%q): expected an error", input)
+		}
+	}
+}
+
+func TestValidateJobSockets(t *testing.T) {
+	if err := ValidateJobSockets([]JobSocket{{Name: "http", Address: "tcp://:8080"}, {Name: "admin", Address: "unix://admin.sock"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tests := map[string][]JobSocket{
+		"empty name":     {{Name: "", Address: "tcp://:8080"}},
+		"colon in name":  {{Name: "a:b", Address: "tcp://:8080"}},
+		"same name":      {{Name: "http", Address: "tcp://:8080"}, {Name: "http", Address: "tcp://:8081"}},
+		"same address":   {{Name: "a", Address: "tcp://:8080"}, {Name: "b", Address: "tcp://:8080"}},
+		"bad address":    {{Name: "http", Address: "http://:8080"}},
+		"too many names": make([]JobSocket, MaxJobSockets+1),
+	}
+	for name, sockets := range tests {
+		if err := ValidateJobSockets(sockets); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
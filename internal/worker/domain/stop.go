@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// MaxStopGracePeriod bounds how long a stop waits for a job to exit on its own
+const MaxStopGracePeriod = 10 * time.Minute
+
+// How a stop ended a job
+const (
+	StopGraceful = "graceful" // the job exited within the grace period
+	StopForced   = "forced"   // the job was killed with SIGKILL once the grace period ran out
+	StopExited   = "exited"   // the job had no process left to signal
+)
+
+// StopOptions is how a job is asked to exit: Signal goes to its process group,
+// and SIGKILL follows when it is still running after GracePeriod
+type StopOptions struct {
+	Signal      syscall.Signal // SIGTERM when 0
+	GracePeriod time.Duration  // the worker's cleanup timeout when 0
+}
+
+// StopResult is how a stopped job ended
+type StopResult struct {
+	Method   string
+	ExitCode int32
+}
+
+var stopSignals = map[string]syscall.Signal{
+	"TERM": syscall.SIGTERM,
+	"INT":  syscall.SIGINT,
+	"HUP":  syscall.SIGHUP,
+	"QUIT": syscall.SIGQUIT,
+	"USR1": syscall.SIGUSR1,
+	"USR2": syscall.SIGUSR2,
+	"KILL": syscall.SIGKILL,
+}
+
+// ParseStopSignal parses a signal name with or without the SIG prefix, in any
+// case. An empty name is SIGTERM.
+func ParseStopSignal(name string) (syscall.Signal, error) {
+	if name == "" {
+		return syscall.SIGTERM, nil
+	}
+	if sig, ok := stopSignals[strings.TrimPrefix(strings.ToUpper(name), "SIG")]; ok {
+		return sig, nil
+	}
+	return 0, fmt.Errorf("unsupported stop signal %q (expected TERM, INT, HUP, QUIT, USR1, USR2 or KILL)", name)
+}
+
+// NewStopOptions validates a requested signal and grace period
+func NewStopOptions(signal string, gracePeriod time.Duration) (StopOptions, error) {
+	sig, err := ParseStopSignal(signal)
+	if err != nil {
+		return StopOptions{}, err
+	}
+	if gracePeriod < 0 || gracePeriod > MaxStopGracePeriod {
+		return StopOptions{}, fmt.Errorf("grace period %v out of range (0 to %v)", gracePeriod, MaxStopGracePeriod)
+	}
+	return StopOptions{Signal: sig, GracePeriod: gracePeriod}, nil
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestParseStopSignal(t *testing.T) {
+	for input, want := range map[string]syscall.Signal{
+		"":        syscall.SIGTERM,
+		"TERM":    syscall.SIGTERM,
+		"SIGINT":  syscall.SIGINT,
+		"hup":     syscall.SIGHUP,
+		"sigusr1": syscall.SIGUSR1,
+		"KILL":    syscall.SIGKILL,
+	} {
+		got, err := ParseStopSignal(input)
+		if err != nil || got != want {
+			t.Errorf("ParseStopSignal(%q) = %v, %v; want %v", input, got, err, want)
+		}
+	}
+
+	for _, input := range []string{"STOP", "SIGSEGV", "15"} {
+		if _, err := ParseStopSignal(input); err == nil {
+			t.Errorf("expected %q to be rejected", input)
+		}
+	}
+}
+
+func TestNewStopOptions(t *testing.T) {
+	opts, err := NewStopOptions("INT", 30*time.Second)
+	if err != nil || opts.Signal != syscall.SIGINT || opts.GracePeriod != 30*time.Second {
+		t.Errorf("unexpected options %+v, %v", opts, err)
+	}
+
+	for _, grace := range []time.Duration{-time.Second, MaxStopGracePeriod + time.Second} {
+		if _, err := NewStopOptions("", grace); err == nil {
+			t.Errorf("expected grace period %v to be rejected", grace)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+// templatePlaceholder matches {{param}}, spaces inside the braces allowed
+var templatePlaceholder = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_.-]*)\s*\}\}`)
+
+var templateParamName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// JobTemplate is a stored job spec whose command, args, env, secret env and
+// label values may hold {{param}} placeholders, filled in when a job is run
+// from it
+type JobTemplate struct {
+	Name        string
+	Description string
+	Params      []TemplateParam
+	Spec        *JobSpec
+	CreatedAt   time.Time
+}
+
+// TemplateParam is a parameter of a job template
+type TemplateParam struct {
+	Name        string
+	Description string
+	Default     string // used when the run does not set the parameter
+	Required    bool   // the run must set the parameter, Default is ignored
+}
+
+// Validate checks the template is complete and every placeholder is a declared parameter
+func (t *JobTemplate) Validate() error {
+	if t.Name == "" {
+		return errors.New("template has no name")
+	}
+	if t.Spec == nil {
+		return errors.New("template has no job spec")
+	}
+	if t.Spec.UploadID != "" {
+		return errors.New("template job specs cannot reference an upload, uploads are used by a single job")
+	}
+
+	declared := make(map[string]bool, len(t.Params))
+	for _, param := range t.Params {
+		if !templateParamName.MatchString(param.Name) {
+			return fmt.Errorf("invalid parameter name %q", param.Name)
+		}
+		if declared[param.Name] {
+			return fmt.Errorf("parameter %s is declared twice", param.Name)
+		}
+		declared[param.Name] = true
+	}
+
+	var undeclared []string
+	t.Spec.templateStrings(func(s string) string {
+		for _, match := range templatePlaceholder.FindAllStringSubmatch(s, -1) {
+			if !declared[match[1]] && !slices.Contains(undeclared, match[1]) {
+				undeclared = append(undeclared, match[1])
+			}
+		}
+		return s
+	})
+	if len(undeclared) > 0 {
+		sort.Strings(undeclared)
+		return fmt.Errorf("placeholders of undeclared parameters: %s", strings.Join(undeclared, ", "))
+	}
+
+	// jobs run from a signed template count as signed, so what they run must
+	// be what was signed
+	if t.Spec.SignedBy != "" {
+		for _, s := range append([]string{t.Spec.Command}, t.Spec.Args...) {
+			if templatePlaceholder.MatchString(s) {
+				return errors.New("signed templates cannot have placeholders in the command or args")
+			}
+		}
+	}
+	return nil
+}
+
+// Instantiate returns the template's spec with its placeholders replaced by
+// the given parameter values, or the defaults of the parameters not given
+func (t *JobTemplate) Instantiate(params map[string]string) (*JobSpec, error) {
+	values := make(map[string]string, len(t.Params))
+	for _, param := range t.Params {
+		value, ok := params[param.Name]
+		if !ok {
+			if param.Required {
+				return nil, fmt.Errorf("parameter %s is required", param.Name)
+			}
+			value = param.Default
+		}
+		values[param.Name] = value
+	}
+
+	var unknown []string
+	for name := range params {
+		if _, ok := values[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("template %s has no parameters %s", t.Name, strings.Join(unknown, ", "))
+	}
+
+	// each job from the template is a new submission
+	spec := t.Spec.Copy()
+	spec.Submitted = time.Time{}
+	spec.templateStrings(func(s string) string {
+		return templatePlaceholder.ReplaceAllStringFunc(s, func(placeholder string) string {
+			return values[templatePlaceholder.FindStringSubmatch(placeholder)[1]]
+		})
+	})
+
+	// the spec was checked with its placeholders, the values are the caller's
+	if err := ValidateJobInputs(spec.Inputs); err != nil {
+		return nil, fmt.Errorf("invalid job spec: %w", err)
+	}
+	return spec, nil
+}
+
+// DeepCopy creates independent copy to prevent concurrent modification issues
+func (t *JobTemplate) DeepCopy() *JobTemplate {
+	cp := *t
+	cp.Params = append([]TemplateParam(nil), t.Params...)
+	cp.Spec = t.Spec.Copy()
+	return &cp
+}
+
+// templateStrings replaces the strings of the spec placeholders may be in
+// with what fn returns for them
+func (s *JobSpec) templateStrings(fn func(string) string) {
+	s.Command = fn(s.Command)
+	for i, arg := range s.Args {
+		s.Args[i] = fn(arg)
+	}
+	for _, m := range []map[string]string{s.Env, s.SecretEnv, s.Labels} {
+		for k, v := range m {
+			m[k] = fn(v)
+		}
+	}
+	for i := range s.Inputs {
+		s.Inputs[i].Source = fn(s.Inputs[i].Source)
+	}
+}
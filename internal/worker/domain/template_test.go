@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+)
+
+func newTestTemplate() *JobTemplate {
+	return &JobTemplate{
+		Name: "train",
+		Params: []TemplateParam{
+			{Name: "dataset", Required: true},
+			{Name: "epochs", Default: "10"},
+		},
+		Spec: &JobSpec{
+			Command: "python3",
+			Args:    []string{"train.py", "--data={{dataset}}", "--epochs={{ epochs }}"},
+			Limits:  ResourceLimits{MemoryBytes: 1 << 30},
+			Env:     map[string]string{"DATASET": "/data/{{dataset}}"},
+			Labels:  map[string]string{"dataset": "{{dataset}}"},
+		},
+	}
+}
+
+func TestJobTemplateInstantiate(t *testing.T) {
+	tmpl := newTestTemplate()
+	if err := tmpl.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	spec, err := tmpl.Instantiate(map[string]string{"dataset": "mnist"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := strings.Join(spec.Args, " "); got != "train.py --data=mnist --epochs=10" {
+		t.Errorf("unexpected args %q", got)
+	}
+	if spec.Env["DATASET"] != "/data/mnist" || spec.Labels["dataset"] != "mnist" {
+		t.Errorf("unexpected env %v and labels %v", spec.Env, spec.Labels)
+	}
+	if spec.Limits != tmpl.Spec.Limits {
+		t.Errorf("expected the template limits, got %+v", spec.Limits)
+	}
+	if tmpl.Spec.Args[1] != "--data={{dataset}}" {
+		t.Errorf("instantiating modified the template: %q", tmpl.Spec.Args[1])
+	}
+}
+
+func TestJobTemplateInstantiateErrors(t *testing.T) {
+	tmpl := newTestTemplate()
+
+	if _, err := tmpl.Instantiate(nil); err == nil || !strings.Contains(err.Error(), "dataset is required") {
+		t.Errorf("expected a missing parameter error, got %v", err)
+	}
+	if _, err := tmpl.Instantiate(map[string]string{"dataset": "a", "batch": "32"}); err == nil || !strings.Contains(err.Error(), "batch") {
+		t.Errorf("expected an unknown parameter error, got %v", err)
+	}
+}
+
+func TestJobTemplateInstantiateValidatesValues(t *testing.T) {
+	tmpl := newTestTemplate()
+	tmpl.Spec.Inputs = []JobInput{{Source: "s3://datasets/{{dataset}}.csv", Path: "train.csv"}}
+	if err := tmpl.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := tmpl.Instantiate(map[string]string{"dataset": "mnist"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, dataset := range []string{"../private/keys", "a/./../../b"} {
+		if _, err := tmpl.Instantiate(map[string]string{"dataset": dataset}); err == nil || !strings.Contains(err.Error(), "invalid job spec") {
+			t.Errorf("expected dataset %q to be rejected, got %v", dataset, err)
+		}
+	}
+}
+
+func TestJobTemplateValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(*JobTemplate)
+		errMsg string
+	}{
+		{"undeclared placeholder", func(tmpl *JobTemplate) { tmpl.Spec.Command = "{{tool}}" }, "undeclared parameters: tool"},
+		{"duplicate parameter", func(tmpl *JobTemplate) { tmpl.Params = append(tmpl.Params, TemplateParam{Name: "epochs"}) }, "declared twice"},
+		{"invalid parameter name", func(tmpl *JobTemplate) { tmpl.Params[0].Name = "data set" }, "invalid parameter name"},
+		{"upload", func(tmpl *JobTemplate) { tmpl.Spec.UploadID = "1" }, "upload"},
+		{"no name", func(tmpl *JobTemplate) { tmpl.Name = "" }, "no name"},
+		{"signed with args placeholder", func(tmpl *JobTemplate) { tmpl.Spec.SignedBy = "release" }, "signed templates"},
+		{"signed with command placeholder", func(tmpl *JobTemplate) {
+			tmpl.Spec.SignedBy = "release"
+			tmpl.Spec.Command = "{{dataset}}"
+			tmpl.Spec.Args = nil
+		}, "signed templates"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl := newTestTemplate()
+			tt.modify(tmpl)
+			if err := tmpl.Validate(); err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errMsg, err)
+			}
+		})
+	}
+}
+
+func TestJobTemplateValidateSigned(t *testing.T) {
+	// the parameters of a signed template may only fill in env, labels and inputs
+	tmpl := newTestTemplate()
+	tmpl.Spec.SignedBy = "release"
+	tmpl.Spec.Args = []string{"train.py"}
+	if err := tmpl.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
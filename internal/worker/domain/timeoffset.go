@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// MaxTimeOffset bounds how far a job's clocks may be shifted from the host's
+const MaxTimeOffset = 100 * 365 * 24 * time.Hour
+
+// TimeOffsetFromSeconds validates a shift of a job's monotonic and boottime
+// clocks, in whole seconds. A negative offset must also be smaller than the
+// host's uptime, which the kernel checks when the job starts.
+func TimeOffsetFromSeconds(seconds int64) (time.Duration, error) {
+	if seconds > int64(MaxTimeOffset/time.Second) || seconds < -int64(MaxTimeOffset/time.Second) {
+		return 0, fmt.Errorf("time offset %ds out of range (at most %v either way)", seconds, MaxTimeOffset)
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
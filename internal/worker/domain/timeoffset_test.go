@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeOffsetFromSeconds(t *testing.T) {
+	for seconds, want := range map[int64]time.Duration{
+		0:       0,
+		86400:   24 * time.Hour,
+		-3600:   -time.Hour,
+		3155760: 3155760 * time.Second,
+	} {
+		got, err := TimeOffsetFromSeconds(seconds)
+		if err != nil || got != want {
+			t.Errorf("TimeOffsetFromSeconds(%d) = %v, %v; want %v", seconds, got, err, want)
+		}
+	}
+
+	limit := int64(MaxTimeOffset / time.Second)
+	for _, seconds := range []int64{limit + 1, -limit - 1} {
+		if _, err := TimeOffsetFromSeconds(seconds); err == nil {
+			t.Errorf("expected time offset %ds to be rejected", seconds)
+		}
+	}
+}
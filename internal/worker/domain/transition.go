@@ -0,0 +1,99 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// ReasonCreated is the reason of a job that is still in the status it was created with
+const ReasonCreated = "created"
+
+// transitions are the statuses a job may move to from each status. A job that
+// ended moves nowhere: once stopped, it stays stopped.
+var transitions = map[JobStatus][]JobStatus{
+	// a process that exits before the worker learned its pid never showed RUNNING
+	StatusInitializing: {StatusRunning, StatusCompleted, StatusFailed, StatusExpired},
+	StatusRunning:      {StatusCompleted, StatusFailed, StatusStopped, StatusCrashLoop},
+	// a resumed job whose restart fails ends without having run again
+	StatusCrashLoop: {StatusRunning, StatusFailed, StatusStopped},
+}
+
+// CanTransition reports whether a job may move from the status from to to.
+// Staying in a status, to restart or update other fields, is not a transition
+// and always allowed.
+func CanTransition(from, to JobStatus) bool {
+	if from == to {
+		return true
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// InvalidTransitionError is a status change the job state machine does not
+// allow, or one made without a reason of its own
+type InvalidTransitionError struct {
+	JobID  string
+	From   JobStatus
+	To     JobStatus
+	Reason string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	if CanTransition(e.From, e.To) {
+		return fmt.Sprintf("job %s cannot move from %s to %s without a reason", e.JobID, e.From, e.To)
+	}
+	return fmt.Sprintf("job %s cannot move from %s to %s (%s)", e.JobID, e.From, e.To, e.Reason)
+}
+
+// CheckTransition returns an *InvalidTransitionError when next, a newer record
+// of the job prev, changes its status in a way the state machine does not
+// allow. A status change has to come with a reason, one that is not left over
+// from the previous status.
+func CheckTransition(prev, next *Job) error {
+	if prev.Status == next.Status {
+		return nil
+	}
+	if !CanTransition(prev.Status, next.Status) || next.StatusReason == "" || next.StatusReason == prev.StatusReason {
+		return &InvalidTransitionError{JobID: next.Id, From: prev.Status, To: next.Status, Reason: next.StatusReason}
+	}
+	return nil
+}
+
+// StatusTransition is a change of a job's status as the store applied it
+type StatusTransition struct {
+	JobID  string
+	From   JobStatus // "" when the job was just stored
+	To     JobStatus
+	Reason string // what caused the change, e.g. "exited with code 1"
+	Time   time.Time
+	Job    *Job // the job after the change, a copy hooks may keep but not modify
+}
+
+// StatusHook is called for each status transition of each job
+type StatusHook func(StatusTransition)
+
+// NewStatusTransition describes the change of a job from the status from to
+// its current one
+func NewStatusTransition(from JobStatus, job *Job, at time.Time) StatusTransition {
+	reason := job.StatusReason
+	if reason == "" {
+		reason = ReasonCreated
+	}
+	return StatusTransition{
+		JobID:  job.Id,
+		From:   from,
+		To:     job.Status,
+		Reason: reason,
+		Time:   at,
+		Job:    job,
+	}
+}
+
+// IsFinal reports whether the transition ended the job
+func (t StatusTransition) IsFinal() bool {
+	return t.Job.IsCompleted()
+}
@@ -0,0 +1,89 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewStatusTransition(t *testing.T) {
+	for _, tc := range []struct {
+		from   JobStatus
+		job    Job
+		reason string
+	}{
+		{"", Job{Status: StatusInitializing}, "created"},
+		{"", Job{Status: StatusRunning, StatusReason: "started with pid 7"}, "started with pid 7"},
+		{StatusInitializing, Job{Status: StatusRunning, StatusReason: "started with pid 7"}, "started with pid 7"},
+		{StatusRunning, Job{Status: StatusFailed, StatusReason: "exited with code 2"}, "exited with code 2"},
+	} {
+		job := tc.job
+		job.Id = "1"
+		tr := NewStatusTransition(tc.from, &job, time.Now())
+		if tr.Reason != tc.reason || tr.From != tc.from || tr.To != job.Status || tr.JobID != "1" {
+			t.Errorf("%s -> %s: expected %q, got %+v", tc.from, job.Status, tc.reason, tr)
+		}
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	for _, tc := range []struct {
+		from, to JobStatus
+		want     bool
+	}{
+		{StatusInitializing, StatusRunning, true},
+		{StatusInitializing, StatusExpired, true},
+		{StatusInitializing, StatusCrashLoop, false},
+		{StatusRunning, StatusRunning, true},
+		{StatusRunning, StatusStopped, true},
+		{StatusRunning, StatusCrashLoop, true},
+		{StatusRunning, StatusInitializing, false},
+		{StatusCrashLoop, StatusRunning, true},
+		{StatusCrashLoop, StatusCompleted, false},
+		{StatusStopped, StatusFailed, false},
+		{StatusCompleted, StatusRunning, false},
+		{StatusFailed, StatusStopped, false},
+		{StatusExpired, StatusRunning, false},
+		{StatusStopped, StatusStopped, true},
+	} {
+		if got := CanTransition(tc.from, tc.to); got != tc.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tc.from, tc.to, got, tc.want)
+		}
+	}
+}
+
+func TestCheckTransition(t *testing.T) {
+	running := &Job{Id: "1", Status: StatusInitializing}
+	if err := running.MarkAsRunning(7); err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := running.DeepCopy()
+	stopped.Stop("stopped by a user")
+	if err := CheckTransition(running, stopped); err != nil {
+		t.Errorf("expected a running job to stop, got %v", err)
+	}
+
+	// a stop that raced the process exit does not turn into a failure
+	failed := running.DeepCopy()
+	failed.Fail(-1, "killed by a signal")
+	var invalid *InvalidTransitionError
+	if err := CheckTransition(stopped, failed); !errors.As(err, &invalid) || invalid.From != StatusStopped || invalid.To != StatusFailed {
+		t.Errorf("expected STOPPED -> FAILED to be rejected, got %v", err)
+	}
+
+	// a stale copy whose status was set by hand has no reason of its own
+	unreasoned := running.DeepCopy()
+	unreasoned.Status = StatusCompleted
+	if err := CheckTransition(running, unreasoned); !errors.As(err, &invalid) {
+		t.Errorf("expected a status change without a reason to be rejected, got %v", err)
+	} else if want := "job 1 cannot move from RUNNING to COMPLETED without a reason"; err.Error() != want {
+		t.Errorf("expected %q, got %q", want, err)
+	}
+
+	healthy := running.DeepCopy()
+	healthy.Health = HealthHealthy
+	if err := CheckTransition(running, healthy); err != nil {
+		t.Errorf("expected an update that keeps the status to be allowed, got %v", err)
+	}
+}
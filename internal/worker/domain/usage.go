@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"sort"
+	"time"
+)
+
+// JobUsage is a reading of a job's resource consumption, taken from its cgroup
+type JobUsage struct {
+	CPUTime      time.Duration // CPU time used since the job started
+	CPUPercent   float64       // Share of one core since the previous reading, 0 for the first one
+	MemoryBytes  int64         // Current memory usage, including page cache
+	IOReadBytes  int64         // Bytes read from block devices since the job started
+	IOWriteBytes int64         // Bytes written to block devices since the job started
+
+	CPUPeriods          int64         // CPU limit enforcement periods since the job started
+	CPUThrottledPeriods int64         // Periods in which the job used up its CPU limit and was held back
+	CPUThrottledTime    time.Duration // Time the job was held back by its CPU limit
+	ThrottledPercent    float64       // Share of periods throttled since the previous reading, 0 for the first one
+
+	SampledAt time.Time
+}
+
+// ThrottledShare is the percentage of CPU periods the job was throttled in
+// since it started, 0 without a CPU limit
+func (u *JobUsage) ThrottledShare() float64 {
+	if u.CPUPeriods <= 0 {
+		return 0
+	}
+	return float64(u.CPUThrottledPeriods) / float64(u.CPUPeriods) * 100
+}
+
+// UsageRecord is what a job consumed over its run, for chargeback. A running
+// job has no EndTime and its usage so far.
+type UsageRecord struct {
+	JobID             string
+	Tenant            string // Value of the configured tenant label ("" if unset)
+	Command           string
+	Status            JobStatus
+	StartTime         time.Time
+	EndTime           *time.Time
+	CPUTime           time.Duration
+	MemoryByteSeconds float64 // Memory usage integrated over the run time
+	IOReadBytes       int64
+	IOWriteBytes      int64
+}
+
+// WallTime is how long the job ran, up to now while it is running
+func (r *UsageRecord) WallTime(now time.Time) time.Duration {
+	if r.EndTime != nil {
+		return r.EndTime.Sub(r.StartTime)
+	}
+	return now.Sub(r.StartTime)
+}
+
+// TenantUsage is the usage of all jobs of a tenant
+type TenantUsage struct {
+	Tenant            string
+	Jobs              int
+	CPUTime           time.Duration
+	MemoryByteSeconds float64
+	IOReadBytes       int64
+	IOWriteBytes      int64
+	WallTime          time.Duration
+}
+
+// SumByTenant totals the records per tenant, ordered by tenant
+func SumByTenant(records []*UsageRecord, now time.Time) []*TenantUsage {
+	byTenant := make(map[string]*TenantUsage)
+	var totals []*TenantUsage
+	for _, r := range records {
+		t, ok := byTenant[r.Tenant]
+		if !ok {
+			t = &TenantUsage{Tenant: r.Tenant}
+			byTenant[r.Tenant] = t
+			totals = append(totals, t)
+		}
+		t.Jobs++
+		t.CPUTime += r.CPUTime
+		t.MemoryByteSeconds += r.MemoryByteSeconds
+		t.IOReadBytes += r.IOReadBytes
+		t.IOWriteBytes += r.IOWriteBytes
+		t.WallTime += r.WallTime(now)
+	}
+
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Tenant < totals[j].Tenant })
+	return totals
+}
+
+// UsageTotal is the usage summed over the running jobs of a job group or a
+// tenant
+type UsageTotal struct {
+	Name         string
+	Jobs         int
+	CPUPercent   float64
+	MemoryBytes  int64
+	IOReadBytes  int64
+	IOWriteBytes int64
+}
+
+// UsageTotals sums job readings by group or tenant name
+type UsageTotals map[string]*UsageTotal
+
+// Add counts a job's reading towards name
+func (t UsageTotals) Add(name string, usage *JobUsage) {
+	total, ok := t[name]
+	if !ok {
+		total = &UsageTotal{Name: name}
+		t[name] = total
+	}
+	total.Jobs++
+	total.CPUPercent += usage.CPUPercent
+	total.MemoryBytes += usage.MemoryBytes
+	total.IOReadBytes += usage.IOReadBytes
+	total.IOWriteBytes += usage.IOWriteBytes
+}
+
+// Sorted returns the totals ordered by name
+func (t UsageTotals) Sorted() []*UsageTotal {
+	totals := make([]*UsageTotal, 0, len(t))
+	for _, total := range t {
+		totals = append(totals, total)
+	}
+	sort.Slice(totals, func(i, j int) bool { return totals[i].Name < totals[j].Name })
+	return totals
+}
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSumByTenant(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	end := now.Add(-time.Minute)
+	records := []*UsageRecord{
+		{Tenant: "web", StartTime: now.Add(-time.Hour), EndTime: &end, CPUTime: time.Second, IOReadBytes: 10},
+		{Tenant: "ci", StartTime: now.Add(-time.Minute), CPUTime: 2 * time.Second, MemoryByteSeconds: 100},
+		{Tenant: "web", StartTime: now.Add(-2 * time.Minute), EndTime: &end, CPUTime: 3 * time.Second, IOWriteBytes: 5},
+	}
+
+	totals := SumByTenant(records, now)
+	if len(totals) != 2 || totals[0].Tenant != "ci" || totals[1].Tenant != "web" {
+		t.Fatalf("expected ci and web totals, got %v", totals)
+	}
+	if totals[0].WallTime != time.Minute || totals[0].MemoryByteSeconds != 100 {
+		t.Errorf("expected a running job to count up to now, got %+v", totals[0])
+	}
+	web := totals[1]
+	if web.Jobs != 2 || web.CPUTime != 4*time.Second || web.IOReadBytes != 10 || web.IOWriteBytes != 5 || web.WallTime != 60*time.Minute {
+		t.Errorf("unexpected web totals %+v", web)
+	}
+}
+
+func TestUsageTotals(t *testing.T) {
+	totals := UsageTotals{}
+	totals.Add("web", &JobUsage{CPUPercent: 50, MemoryBytes: 100})
+	totals.Add("ci", &JobUsage{CPUPercent: 10, IOWriteBytes: 7})
+	totals.Add("web", &JobUsage{CPUPercent: 75, MemoryBytes: 50, IOReadBytes: 3})
+
+	sorted := totals.Sorted()
+	if len(sorted) != 2 || sorted[0].Name != "ci" || sorted[1].Name != "web" {
+		t.Fatalf("expected ci and web, got %v", sorted)
+	}
+	web := sorted[1]
+	if web.Jobs != 2 || web.CPUPercent != 125 || web.MemoryBytes != 150 || web.IOReadBytes != 3 {
+		t.Errorf("unexpected web total %+v", web)
+	}
+}
@@ -0,0 +1,50 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SpecError is one problem found in a job spec
+type SpecError struct {
+	Field   string // Spec field the problem is about, e.g. "command" or "env"
+	Message string
+}
+
+func (e SpecError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Message)
+}
+
+// JobValidation is the outcome of checking a job spec without starting it
+type JobValidation struct {
+	ResolvedCommand string         // Command path the job would run ("" if it could not be resolved)
+	Limits          ResourceLimits // Limits the job would run with, server defaults applied
+	Errors          []SpecError
+}
+
+// Add records err against field; nil errors are ignored
+func (v *JobValidation) Add(field string, err error) {
+	if err != nil {
+		v.Errors = append(v.Errors, SpecError{Field: field, Message: err.Error()})
+	}
+}
+
+func (v *JobValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// Err combines every error into one, nil if the spec is valid
+func (v *JobValidation) Err() error {
+	if v.Valid() {
+		return nil
+	}
+	if len(v.Errors) == 1 {
+		return v.Errors[0]
+	}
+
+	messages := make([]string, len(v.Errors))
+	for i, e := range v.Errors {
+		messages[i] = e.Error()
+	}
+	return fmt.Errorf("%d problems: %s", len(v.Errors), strings.Join(messages, "; "))
+}
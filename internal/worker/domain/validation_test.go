@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestJobValidation(t *testing.T) {
+	v := &JobValidation{}
+	v.Add("command", nil)
+
+	if !v.Valid() || v.Err() != nil {
+		t.Fatal("expected validation without errors to be valid")
+	}
+
+	v.Add("command", errors.New("command cannot be empty"))
+	if v.Valid() {
+		t.Fatal("expected validation to be invalid")
+	}
+	if got := v.Err().Error(); got != "invalid command: command cannot be empty" {
+		t.Errorf("unexpected single error %q", got)
+	}
+
+	v.Add("environment", errors.New("prefix JOB_ is reserved"))
+	got := v.Err().Error()
+	if !strings.HasPrefix(got, "2 problems:") || !strings.Contains(got, "invalid environment: prefix JOB_ is reserved") {
+		t.Errorf("unexpected combined error %q", got)
+	}
+}
@@ -0,0 +1,145 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TimeWindow is a period recurring every week, e.g. "Mon-Fri 09:00-17:00".
+// A window ending at or before its start runs past midnight into the next day.
+type TimeWindow struct {
+	Days     [7]bool       // Days the window starts on, indexed by time.Weekday
+	Start    time.Duration // Time of day the window opens
+	End      time.Duration // Time of day the window closes
+	Location *time.Location
+
+	text string
+}
+
+var weekdays = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseTimeWindow parses "[DAYS] HH:MM-HH:MM [ZONE]". DAYS is a comma
+// separated list of days and day ranges such as "Mon-Fri,Sun", or "weekdays"
+// or "weekends", every day when left out. ZONE is an IANA time zone name, loc
+// when left out.
+func ParseTimeWindow(text string, loc *time.Location) (TimeWindow, error) {
+	w := TimeWindow{Location: loc, text: text}
+	fields := strings.Fields(text)
+	if len(fields) == 0 || len(fields) > 3 {
+		return TimeWindow{}, fmt.Errorf("invalid time window %q, expected [DAYS] HH:MM-HH:MM [ZONE]", text)
+	}
+
+	if !strings.Contains(fields[0], ":") {
+		days, err := parseDays(fields[0])
+		if err != nil {
+			return TimeWindow{}, fmt.Errorf("invalid time window %q: %v", text, err)
+		}
+		w.Days = days
+		fields = fields[1:]
+	} else {
+		w.Days = [7]bool{true, true, true, true, true, true, true}
+	}
+	if len(fields) == 0 || len(fields) > 2 {
+		return TimeWindow{}, fmt.Errorf("invalid time window %q, expected [DAYS] HH:MM-HH:MM [ZONE]", text)
+	}
+
+	start, end, found := strings.Cut(fields[0], "-")
+	var err error
+	if w.Start, err = parseTimeOfDay(start); found && err == nil {
+		w.End, err = parseTimeOfDay(end)
+	}
+	if !found || err != nil || w.Start >= 24*time.Hour {
+		return TimeWindow{}, fmt.Errorf("invalid time range %q in time window %q", fields[0], text)
+	}
+
+	if len(fields) == 2 {
+		if w.Location, err = time.LoadLocation(fields[1]); err != nil {
+			return TimeWindow{}, fmt.Errorf("invalid time zone in time window %q: %v", text, err)
+		}
+	}
+	if w.Location == nil {
+		w.Location = time.Local
+	}
+	return w, nil
+}
+
+func parseDays(text string) ([7]bool, error) {
+	var days [7]bool
+	switch strings.ToLower(text) {
+	case "weekdays":
+		text = "mon-fri"
+	case "weekends":
+		text = "sat,sun"
+	}
+
+	for _, part := range strings.Split(text, ",") {
+		first, last, isRange := strings.Cut(part, "-")
+		from, ok := weekdays[strings.ToLower(first)]
+		if !ok {
+			return days, fmt.Errorf("unknown day %q", first)
+		}
+		to := from
+		if isRange {
+			if to, ok = weekdays[strings.ToLower(last)]; !ok {
+				return days, fmt.Errorf("unknown day %q", last)
+			}
+		}
+		// ranges may wrap around the week, e.g. Fri-Mon
+		for day := from; ; day = (day + 1) % 7 {
+			days[day] = true
+			if day == to {
+				break
+			}
+		}
+	}
+	return days, nil
+}
+
+// parseTimeOfDay parses HH:MM, up to 24:00
+func parseTimeOfDay(text string) (time.Duration, error) {
+	var hours, minutes int
+	if _, err := fmt.Sscanf(text, "%d:%d", &hours, &minutes); err != nil || len(text) != 5 {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	d := time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute
+	if hours < 0 || minutes < 0 || minutes > 59 || d > 24*time.Hour {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	return d, nil
+}
+
+// Contains reports whether t falls in the window
+func (w TimeWindow) Contains(t time.Time) bool {
+	t = t.In(w.Location)
+	day := t.Weekday()
+	offset := time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second
+
+	if w.Start < w.End {
+		return w.Days[day] && offset >= w.Start && offset < w.End
+	}
+	// past midnight: the part after the start on the day itself, and the part
+	// before the end on the day after
+	return (w.Days[day] && offset >= w.Start) || (w.Days[(day+6)%7] && offset < w.End)
+}
+
+// String returns the window as it was parsed
+func (w TimeWindow) String() string {
+	return w.text
+}
+
+// InWindows reports whether t falls in any of the windows, true when there are none
+func InWindows(windows []TimeWindow, t time.Time) bool {
+	if len(windows) == 0 {
+		return true
+	}
+	for _, w := range windows {
+		if w.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
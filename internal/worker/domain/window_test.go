@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeWindow(t *testing.T) {
+	w, err := ParseTimeWindow("Mon-Fri 09:00-17:00", time.UTC)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Days != [7]bool{false, true, true, true, true, true, false} || w.Start != 9*time.Hour || w.End != 17*time.Hour {
+		t.Errorf("unexpected window %+v", w)
+	}
+	if w.String() != "Mon-Fri 09:00-17:00" {
+		t.Errorf("unexpected string %q", w.String())
+	}
+
+	w, err = ParseTimeWindow("fri-mon,wed 22:00-24:00 UTC", time.Local)
+	if err != nil || w.Days != [7]bool{true, true, false, true, false, true, true} || w.End != 24*time.Hour || w.Location != time.UTC {
+		t.Errorf("unexpected window %+v, %v", w, err)
+	}
+
+	for _, text := range []string{"", "09:00", "Mon", "Mon-Fri 9:00-17:00", "Mon-Fri 09:00-25:00", "Funday 09:00-17:00",
+		"Mon 24:00-01:00", "09:00-10:00 Mars/Olympus", "Mon 09:00-10:00 UTC extra"} {
+		if _, err := ParseTimeWindow(text, time.UTC); err == nil {
+			t.Errorf("expected %q to be rejected", text)
+		}
+	}
+}
+
+func TestTimeWindowContains(t *testing.T) {
+	// 2025-01-06 is a Monday
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2025, 1, 6+day, hour, minute, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		window string
+		t      time.Time
+		want   bool
+	}{
+		{"Mon-Fri 09:00-17:00", at(0, 9, 0), true},
+		{"Mon-Fri 09:00-17:00", at(0, 17, 0), false},
+		{"Mon-Fri 09:00-17:00", at(5, 12, 0), false},
+		{"weekends 00:00-00:00", at(6, 23, 59), true},
+		{"weekends 00:00-00:00", at(0, 0, 0), false},
+		{"Fri 22:00-06:00", at(4, 23, 0), true},
+		{"Fri 22:00-06:00", at(5, 5, 59), true},
+		{"Fri 22:00-06:00", at(5, 22, 30), false},
+		{"Fri 22:00-06:00", at(3, 5, 0), false},
+		{"09:00-17:00 Asia/Tokyo", at(0, 1, 0), true}, // 10:00 in Tokyo
+	}
+	for _, tt := range tests {
+		w, err := ParseTimeWindow(tt.window, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.window, err)
+		}
+		if got := w.Contains(tt.t); got != tt.want {
+			t.Errorf("%q contains %v = %v, want %v", tt.window, tt.t, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package events
+
+import (
+	"sync"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/logger"
+)
+
+// Type names what happened to a job, or to the worker for events without one
+type Type string
+
+const (
+	JobCreated   Type = "job.created"    // the job was accepted and is starting
+	JobUpdated   Type = "job.updated"    // the job record changed: status, pid, restarts or health
+	JobCleanedUp Type = "job.cleaned_up" // the job's cgroup was removed, or Err says why not
+	JobStuck     Type = "job.stuck"      // a launch or cleanup of the job ran past the watchdog deadline, Err says which
+	JobCrashLoop Type = "job.crash_loop" // the job kept failing and is held until resumed, Err says how often
+	JobIdle      Type = "job.idle"       // the job wrote no output and used no CPU for its max idle time, Err says what is done about it
+	JobExpired   Type = "job.expired"    // the job could not start within its queue timeout and never ran, Err says why
+
+	JobPrivileged      Type = "job.privileged"        // the job was started with privileged isolation, on the host without limits
+	JobLimitNotApplied Type = "job.limit_not_applied" // the kernel ignored or clamped limits of the job's cgroup, Err says which
+
+	JobOutputLimited Type = "job.output_limited" // the job's output went over its rate limit and is being dropped, Err says the limit
+	JobInputFailed   Type = "job.input_failed"   // an input file of the job could not be streamed in, Err says which and why
+
+	OutputPressure Type = "worker.output_pressure" // jobs' buffered output nears the worker's budget, Err says how full
+)
+
+// Known reports whether t is one of the event types above
+func (t Type) Known() bool {
+	switch t {
+	case JobCreated, JobUpdated, JobCleanedUp, JobStuck, JobCrashLoop, JobIdle, JobExpired, JobPrivileged, JobLimitNotApplied, JobOutputLimited, JobInputFailed, OutputPressure:
+		return true
+	}
+	return false
+}
+
+// Event is published by the worker core. Job is a snapshot that consumers
+// must not modify, nil for events that do not change the job record.
+type Event struct {
+	Type  Type
+	JobID string
+	Job   *domain.Job
+	Err   error
+	Time  time.Time
+}
+
+// Handler consumes events synchronously, in publish order
+type Handler func(Event)
+
+// subscriberBuffer is how many events an asynchronous subscriber can fall
+// behind before it is dropped
+const subscriberBuffer = 64
+
+// Bus delivers every published event to the registered handlers and then to
+// the subscribers. Handlers run before Publish returns, so the store can
+// apply an update before the publisher reads it back. Subscribers that fall
+// behind are dropped, by closing their channel, rather than slowing publishers.
+type Bus struct {
+	mutex       sync.RWMutex
+	handlers    []Handler
+	subscribers map[chan Event]struct{}
+	logger      *logger.Logger
+}
+
+func NewBus() *Bus {
+	return &Bus{
+		subscribers: make(map[chan Event]struct{}),
+		logger:      logger.WithField("component", "event-bus"),
+	}
+}
+
+// Handle registers a synchronous handler for every event
+func (b *Bus) Handle(h Handler) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.handlers = append(b.handlers, h)
+}
+
+// Subscribe returns a channel of the events published from now on and a
+// function that ends the subscription
+func (b *Bus) Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	b.mutex.Lock()
+	b.subscribers[ch] = struct{}{}
+	b.mutex.Unlock()
+
+	return ch, func() { b.drop(ch) }
+}
+
+func (b *Bus) drop(ch chan Event) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if _, exists := b.subscribers[ch]; exists {
+		delete(b.subscribers, ch)
+		close(ch)
+	}
+}
+
+// Publish delivers the event, stamping it with the current time if unset
+func (b *Bus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	if e.JobID == "" && e.Job != nil {
+		e.JobID = e.Job.Id
+	}
+
+	b.mutex.RLock()
+	handlers := b.handlers
+	b.mutex.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+
+	b.mutex.RLock()
+	var slow []chan Event
+	for ch := range b.subscribers {
+		select {
+		case ch <- e:
+		default:
+			slow = append(slow, ch)
+		}
+	}
+	b.mutex.RUnlock()
+
+	for _, ch := range slow {
+		b.logger.Warn("dropping slow event subscriber", "eventType", string(e.Type), "jobId", e.JobID)
+		b.drop(ch)
+	}
+}
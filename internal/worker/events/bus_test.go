@@ -0,0 +1,60 @@
+package events
+
+import (
+	"testing"
+	"worker/internal/worker/domain"
+)
+
+func TestBus_HandlersRunBeforeSubscribers(t *testing.T) {
+	bus := NewBus()
+
+	var handled []Type
+	bus.Handle(func(e Event) { handled = append(handled, e.Type) })
+
+	events, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	bus.Publish(Event{Type: JobCreated, Job: &domain.Job{Id: "1"}})
+
+	if len(handled) != 1 || handled[0] != JobCreated {
+		t.Fatalf("expected the handler to see the event before Publish returned, got %v", handled)
+	}
+
+	e := <-events
+	if e.Type != JobCreated || e.JobID != "1" {
+		t.Errorf("unexpected event %+v", e)
+	}
+	if e.Time.IsZero() {
+		t.Error("expected the event to be timestamped")
+	}
+}
+
+func TestBus_DropsSlowSubscriber(t *testing.T) {
+	bus := NewBus()
+	events, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		bus.Publish(Event{Type: JobUpdated, JobID: "1"})
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("expected %d buffered events before the channel closed, got %d", subscriberBuffer, received)
+	}
+}
+
+func TestBus_Unsubscribe(t *testing.T) {
+	bus := NewBus()
+	events, unsubscribe := bus.Subscribe()
+	unsubscribe()
+	unsubscribe() // a second call is harmless
+
+	bus.Publish(Event{Type: JobUpdated, JobID: "1"})
+	if _, ok := <-events; ok {
+		t.Error("expected no events after unsubscribing")
+	}
+}
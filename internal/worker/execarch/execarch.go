@@ -0,0 +1,154 @@
+package execarch
+
+import (
+	"bytes"
+	"debug/elf"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// binfmtDir is where binfmt_misc lists the handlers of formats the kernel
+// does not exec itself, such as qemu-user for other architectures
+var binfmtDir = "/proc/sys/fs/binfmt_misc"
+
+// MismatchError is returned for an executable the kernel cannot exec on this
+// host, which would otherwise only fail at launch with "exec format error"
+type MismatchError struct {
+	Path   string
+	Arch   string // GOARCH name of the executable's architecture, "" when it is not an executable
+	Host   string
+	Reason string
+}
+
+func (e *MismatchError) Error() string {
+	if e.Arch != "" {
+		return fmt.Sprintf("%s is built for %s, this host is %s and has no emulator registered for it", e.Path, e.Arch, e.Host)
+	}
+	return fmt.Sprintf("%s cannot be executed on this %s host: %s", e.Path, e.Host, e.Reason)
+}
+
+// Of returns the GOARCH name of the architecture an ELF binary is built for
+func Of(path string) (string, error) {
+	f, err := elf.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	return elfArch(f), nil
+}
+
+// check is Check for a host of the given architecture
+func check(path, host string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	magic := make([]byte, 4)
+	if _, err := io.ReadFull(file, magic); err != nil {
+		return mismatch(path, "", host, "it is not an ELF executable or a script")
+	}
+	if bytes.HasPrefix(magic, []byte("#!")) {
+		return nil
+	}
+	if !bytes.Equal(magic, []byte(elf.ELFMAG)) {
+		// a binfmt_misc handler may take the format, the kernel can tell
+		if len(binfmtHandlers()) > 0 {
+			return nil
+		}
+		return mismatch(path, "", host, "it is not an ELF executable or a script")
+	}
+
+	f, err := elf.NewFile(file)
+	if err != nil {
+		return mismatch(path, "", host, fmt.Sprintf("invalid ELF header: %v", err))
+	}
+	if f.Type != elf.ET_EXEC && f.Type != elf.ET_DYN {
+		return mismatch(path, "", host, fmt.Sprintf("it is an ELF %s file, not an executable", strings.TrimPrefix(f.Type.String(), "ET_")))
+	}
+	if arch := elfArch(f); !native(arch, host) && !emulated(arch) {
+		return mismatch(path, arch, host, "")
+	}
+	return nil
+}
+
+// native reports whether a host of the architecture runs executables of arch
+// itself, as x86-64 kernels do 32-bit x86 ones
+func native(arch, host string) bool {
+	return arch == host || host == "amd64" && arch == "386"
+}
+
+func mismatch(path, arch, host, reason string) error {
+	return &MismatchError{Path: path, Arch: arch, Host: host, Reason: reason}
+}
+
+// elfArch maps the machine of an ELF file to its GOARCH name
+func elfArch(f *elf.File) string {
+	switch f.Machine {
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_386:
+		return "386"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_RISCV:
+		if f.Class == elf.ELFCLASS64 {
+			return "riscv64"
+		}
+	case elf.EM_PPC64:
+		if f.Data == elf.ELFDATA2LSB {
+			return "ppc64le"
+		}
+		return "ppc64"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	}
+	return strings.ToLower(strings.TrimPrefix(f.Machine.String(), "EM_"))
+}
+
+// qemuNames are the names qemu-user registers its binfmt_misc handlers under
+var qemuNames = map[string]string{
+	"amd64":   "x86_64",
+	"386":     "i386",
+	"arm64":   "aarch64",
+	"arm":     "arm",
+	"riscv64": "riscv64",
+	"ppc64le": "ppc64le",
+	"ppc64":   "ppc64",
+	"s390x":   "s390x",
+	"loong64": "loongarch64",
+}
+
+// emulated reports whether qemu-user runs executables of arch on this host
+func emulated(arch string) bool {
+	name, ok := qemuNames[arch]
+	return ok && enabled(filepath.Join(binfmtDir, "qemu-"+name))
+}
+
+// binfmtHandlers lists the enabled binfmt_misc handlers
+func binfmtHandlers() []string {
+	entries, err := os.ReadDir(binfmtDir)
+	if err != nil {
+		return nil
+	}
+	var handlers []string
+	for _, entry := range entries {
+		if name := entry.Name(); name != "register" && name != "status" && enabled(filepath.Join(binfmtDir, name)) {
+			handlers = append(handlers, name)
+		}
+	}
+	return handlers
+}
+
+func enabled(handler string) bool {
+	data, err := os.ReadFile(handler)
+	return err == nil && strings.HasPrefix(string(data), "enabled")
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package execarch
+
+import "runtime"
+
+// Host returns the worker's own architecture
+func Host() string {
+	return runtime.GOARCH
+}
+
+// Check accepts any file, executables other than ELF ones being left to the
+// exec to report
+func Check(path string) error {
+	return nil
+}
@@ -0,0 +1,44 @@
+//go:build linux
+
+package execarch
+
+import (
+	"runtime"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Host returns the GOARCH name of the machine the kernel runs on, which is
+// not the worker's own architecture when the worker runs under emulation
+func Host() string {
+	var uts unix.Utsname
+	if err := unix.Uname(&uts); err != nil {
+		return runtime.GOARCH
+	}
+	machine := unix.ByteSliceToString(uts.Machine[:])
+	switch {
+	case machine == "x86_64":
+		return "amd64"
+	case machine == "aarch64" || machine == "arm64":
+		return "arm64"
+	case len(machine) == 4 && strings.HasPrefix(machine, "i") && strings.HasSuffix(machine, "86"):
+		return "386"
+	case strings.HasPrefix(machine, "armv"):
+		return "arm"
+	case machine == "loongarch64":
+		return "loong64"
+	case machine == "riscv64", machine == "ppc64le", machine == "ppc64", machine == "s390x":
+		return machine
+	default:
+		return runtime.GOARCH
+	}
+}
+
+// Check returns a MismatchError when the kernel cannot exec path on this
+// host: an ELF binary of another architecture without an emulator registered
+// for it, or a file that is neither an executable nor a script. Files it
+// cannot read are left to the exec to report.
+func Check(path string) error {
+	return check(path, Host())
+}
@@ -0,0 +1,108 @@
+package execarch
+
+import (
+	"debug/elf"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeELF writes the header of a 64-bit little-endian ELF file
+func writeELF(t *testing.T, typ elf.Type, machine elf.Machine) string {
+	t.Helper()
+	header := make([]byte, 64)
+	copy(header, elf.ELFMAG)
+	header[elf.EI_CLASS] = byte(elf.ELFCLASS64)
+	header[elf.EI_DATA] = byte(elf.ELFDATA2LSB)
+	header[elf.EI_VERSION] = byte(elf.EV_CURRENT)
+	binary.LittleEndian.PutUint16(header[16:], uint16(typ))
+	binary.LittleEndian.PutUint16(header[18:], uint16(machine))
+	binary.LittleEndian.PutUint32(header[20:], uint32(elf.EV_CURRENT))
+	binary.LittleEndian.PutUint16(header[52:], 64)
+
+	path := filepath.Join(t.TempDir(), "binary")
+	if err := os.WriteFile(path, header, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func useBinfmtDir(t *testing.T, handlers ...string) {
+	t.Helper()
+	dir := t.TempDir()
+	for _, name := range append(handlers, "register", "status") {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("enabled\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	old := binfmtDir
+	binfmtDir = dir
+	t.Cleanup(func() { binfmtDir = old })
+}
+
+func TestCheck(t *testing.T) {
+	useBinfmtDir(t)
+
+	arm64 := writeELF(t, elf.ET_EXEC, elf.EM_AARCH64)
+	if err := check(arm64, "arm64"); err != nil {
+		t.Errorf("expected an arm64 binary to run on arm64, got %v", err)
+	}
+	err := check(arm64, "amd64")
+	if err == nil || !strings.Contains(err.Error(), "is built for arm64, this host is amd64") {
+		t.Errorf("expected an architecture mismatch, got %v", err)
+	}
+	if err := check(writeELF(t, elf.ET_DYN, elf.EM_386), "amd64"); err != nil {
+		t.Errorf("expected a 386 binary to run on amd64, got %v", err)
+	}
+	if err := check(writeELF(t, elf.ET_REL, elf.EM_X86_64), "amd64"); err == nil || !strings.Contains(err.Error(), "not an executable") {
+		t.Errorf("expected an object file to be refused, got %v", err)
+	}
+
+	script := filepath.Join(t.TempDir(), "script")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(script, "amd64"); err != nil {
+		t.Errorf("expected a script to pass, got %v", err)
+	}
+
+	data := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(data, []byte("PK\x03\x04 not a binary"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(data, "amd64"); err == nil {
+		t.Error("expected a file in an unknown format to be refused")
+	}
+
+	if err := check(filepath.Join(t.TempDir(), "missing"), "amd64"); err != nil {
+		t.Errorf("expected files that cannot be read to be left to the exec, got %v", err)
+	}
+}
+
+func TestCheckEmulated(t *testing.T) {
+	useBinfmtDir(t, "qemu-aarch64", "jar")
+
+	if err := check(writeELF(t, elf.ET_EXEC, elf.EM_AARCH64), "amd64"); err != nil {
+		t.Errorf("expected an arm64 binary to run under qemu, got %v", err)
+	}
+	if err := check(writeELF(t, elf.ET_EXEC, elf.EM_RISCV), "amd64"); err == nil {
+		t.Error("expected a binary without an emulator to be refused")
+	}
+
+	data := filepath.Join(t.TempDir(), "app.jar")
+	if err := os.WriteFile(data, []byte("PK\x03\x04"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := check(data, "amd64"); err != nil {
+		t.Errorf("expected formats binfmt_misc handlers may take to be left to the kernel, got %v", err)
+	}
+}
+
+func TestOf(t *testing.T) {
+	arch, err := Of(writeELF(t, elf.ET_EXEC, elf.EM_AARCH64))
+	if err != nil || arch != "arm64" {
+		t.Errorf("expected arm64, got %q, %v", arch, err)
+	}
+}
@@ -0,0 +1,121 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path"
+	"time"
+	"worker/internal/worker/domain"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	pb "worker/api/gen"
+)
+
+// Bundle is what an export of a job holds
+type Bundle struct {
+	Job     *pb.Job // spec and latest status, as the API reports them
+	History []domain.HistoryEntry
+	Output  []byte
+}
+
+// entry is the exported layout of a history entry
+type entry struct {
+	Time     time.Time `json:"time"`
+	Change   string    `json:"change"`
+	Status   string    `json:"status"`
+	Reason   string    `json:"reason,omitempty"`
+	Restarts int32     `json:"restarts,omitempty"`
+	Health   string    `json:"health,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Write writes the bundle as a gzipped tarball, into a directory named after
+// the job:
+//
+//	job.json             the job's spec and latest status
+//	status-history.json  each status the job entered and when
+//	events.jsonl         every change recorded to the job, one per line
+//	output.log           the buffered output
+//	output-location.txt  where the output went, instead of output.log once offloaded
+func Write(w io.Writer, b *Bundle, now time.Time) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	dir := "job-" + b.Job.Id
+
+	add := func(name string, data []byte) error {
+		header := &tar.Header{
+			Name:    path.Join(dir, name),
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: now,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			return fmt.Errorf("failed to write %s: %w", name, err)
+		}
+		return nil
+	}
+
+	job, err := protojson.MarshalOptions{Multiline: true, Indent: "  ", EmitUnpopulated: true}.Marshal(b.Job)
+	if err != nil {
+		return fmt.Errorf("failed to encode job: %w", err)
+	}
+	if err := add("job.json", append(job, '\n')); err != nil {
+		return err
+	}
+
+	statuses, err := json.MarshalIndent(entries(domain.StatusChanges(b.History)), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode status history: %w", err)
+	}
+	if err := add("status-history.json", append(statuses, '\n')); err != nil {
+		return err
+	}
+
+	var events bytes.Buffer
+	encoder := json.NewEncoder(&events)
+	for _, e := range entries(b.History) {
+		if err := encoder.Encode(e); err != nil {
+			return fmt.Errorf("failed to encode events: %w", err)
+		}
+	}
+	if err := add("events.jsonl", events.Bytes()); err != nil {
+		return err
+	}
+
+	if len(b.Output) == 0 && b.Job.OutputLocation != "" {
+		err = add("output-location.txt", []byte(b.Job.OutputLocation+"\n"))
+	} else {
+		err = add("output.log", b.Output)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return gz.Close()
+}
+
+func entries(history []domain.HistoryEntry) []entry {
+	out := make([]entry, 0, len(history))
+	for _, h := range history {
+		out = append(out, entry{
+			Time:     h.Time,
+			Change:   string(h.Change),
+			Status:   string(h.Status),
+			Reason:   h.Reason,
+			Restarts: h.Restarts,
+			Health:   string(h.Health),
+			Error:    h.Error,
+		})
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	pb "worker/api/gen"
+)
+
+// readArchive returns the files of a gzipped tarball by name
+func readArchive(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string]string)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[header.Name] = string(content)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	bundle := &Bundle{
+		Job: &pb.Job{Id: "7", Command: "make", Status: "FAILED", ExitCode: 2},
+		History: []domain.HistoryEntry{
+			{Time: start, Change: domain.HistoryCreated, Status: domain.StatusInitializing},
+			{Time: start.Add(time.Second), Change: domain.HistoryUpdated, Status: domain.StatusRunning},
+			{Time: start.Add(2 * time.Second), Change: domain.HistoryUpdated, Status: domain.StatusRunning, Health: domain.HealthHealthy},
+			{Time: start.Add(time.Minute), Change: domain.HistoryUpdated, Status: domain.StatusFailed, Reason: "exited with code 2"},
+			{Time: start.Add(time.Minute), Change: domain.HistoryCleanedUp, Status: domain.StatusFailed, Error: "device busy"},
+		},
+		Output: []byte("compiling\nerror\n"),
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, bundle, start); err != nil {
+		t.Fatal(err)
+	}
+	files := readArchive(t, buf.Bytes())
+
+	var job pb.Job
+	if err := protojson.Unmarshal([]byte(files["job-7/job.json"]), &job); err != nil || job.Command != "make" || job.ExitCode != 2 {
+		t.Errorf("unexpected job.json %q, %v", files["job-7/job.json"], err)
+	}
+	if files["job-7/output.log"] != "compiling\nerror\n" {
+		t.Errorf("unexpected output %q", files["job-7/output.log"])
+	}
+
+	var statuses []entry
+	if err := json.Unmarshal([]byte(files["job-7/status-history.json"]), &statuses); err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 3 || statuses[0].Status != "INITIALIZING" || statuses[2].Status != "FAILED" || statuses[2].Reason != "exited with code 2" {
+		t.Errorf("unexpected status history %+v", statuses)
+	}
+
+	events := strings.Split(strings.TrimSpace(files["job-7/events.jsonl"]), "\n")
+	if len(events) != 5 || !strings.Contains(events[4], `"error":"device busy"`) {
+		t.Errorf("unexpected events %q", events)
+	}
+}
+
+func TestWriteOffloadedOutput(t *testing.T) {
+	bundle := &Bundle{Job: &pb.Job{Id: "8", Status: "COMPLETED", OutputLocation: "s3://jobs/8.log"}}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, bundle, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	files := readArchive(t, buf.Bytes())
+
+	if _, ok := files["job-8/output.log"]; ok {
+		t.Error("expected no output.log for offloaded output")
+	}
+	if files["job-8/output-location.txt"] != "s3://jobs/8.log\n" {
+		t.Errorf("unexpected output location %q", files["job-8/output-location.txt"])
+	}
+}
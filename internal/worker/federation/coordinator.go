@@ -0,0 +1,417 @@
+package federation
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+	pb "worker/api/gen"
+	auth2 "worker/internal/worker/auth"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Coordinator serves the job API for a fleet of workers. RunJob dispatches to
+// the least loaded healthy worker, failing over to the next one when a worker
+// cannot be reached; the calls naming a job are forwarded to the worker that
+// runs it. Clients are authorized by the coordinator, which calls the workers
+// with its own admin certificate. Calls the coordinator does not federate
+// answer Unimplemented.
+type Coordinator struct {
+	pb.UnimplementedJobServiceServer
+	auth            auth2.GrpcAuthorization
+	fleet           *Fleet
+	dispatchTimeout time.Duration
+	logger          *logger.Logger
+}
+
+func NewCoordinator(auth auth2.GrpcAuthorization, fleet *Fleet, dispatchTimeout time.Duration) *Coordinator {
+	return &Coordinator{
+		auth:            auth,
+		fleet:           fleet,
+		dispatchTimeout: dispatchTimeout,
+		logger:          logger.WithField("component", "coordinator"),
+	}
+}
+
+func (c *Coordinator) RunJob(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+	log := c.logger.WithFields("operation", "RunJob", "command", req.Command)
+
+	if err := c.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	candidates := c.fleet.candidates()
+	if len(candidates) == 0 {
+		log.Warn("no worker available for job")
+		return nil, status.Error(codes.ResourceExhausted, "no worker has capacity for the job")
+	}
+
+	var lastErr error
+	for _, m := range candidates {
+		dispatchCtx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+		res, err := m.client.RunJob(dispatchCtx, req, grpc.WaitForReady(false))
+		cancel()
+
+		if err == nil {
+			c.fleet.markDispatched(m)
+			res.Id = fleetJobID(m, res.Id)
+			log.Info("job dispatched", "jobId", res.Id, "worker", m.name)
+			return res, nil
+		}
+		if !failover(err) || ctx.Err() != nil {
+			return nil, err
+		}
+
+		log.Warn("dispatch failed, trying the next worker", "worker", m.name, "error", err)
+		if status.Code(err) == codes.Unavailable {
+			c.fleet.markFailed(m)
+		}
+		lastErr = err
+	}
+
+	return nil, status.Errorf(codes.Unavailable, "no worker accepted the job: %v", lastErr)
+}
+
+func (c *Coordinator) GetJobStatus(ctx context.Context, req *pb.GetJobStatusReq) (*pb.GetJobStatusRes, error) {
+	if err := c.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+	defer cancel()
+
+	res, err := m.client.GetJobStatus(ctx, &pb.GetJobStatusReq{Id: workerID})
+	if err != nil {
+		return nil, err
+	}
+	res.Id = req.Id
+	return res, nil
+}
+
+func (c *Coordinator) GetJobProvenance(ctx context.Context, req *pb.GetJobProvenanceReq) (*pb.JobProvenance, error) {
+	if err := c.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+	defer cancel()
+
+	res, err := m.client.GetJobProvenance(ctx, &pb.GetJobProvenanceReq{Id: workerID})
+	if err != nil {
+		return nil, err
+	}
+	res.JobId = req.Id
+	return res, nil
+}
+
+func (c *Coordinator) StopJob(ctx context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error) {
+	if err := c.auth.Authorized(ctx, auth2.StopJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	// the worker may wait out the grace period before answering
+	grace := time.Duration(req.GracePeriodMillis) * time.Millisecond
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout+grace)
+	defer cancel()
+
+	res, err := m.client.StopJob(ctx, &pb.StopJobReq{Id: workerID, GracePeriodMillis: req.GracePeriodMillis, Signal: req.Signal})
+	if err != nil {
+		return nil, err
+	}
+	res.Id = req.Id
+	return res, nil
+}
+
+// RerunJob reruns a job on the worker that ran it, which holds its spec
+func (c *Coordinator) RerunJob(ctx context.Context, req *pb.RerunJobReq) (*pb.RunJobRes, error) {
+	if err := c.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+	defer cancel()
+
+	res, err := m.client.RerunJob(ctx, &pb.RerunJobReq{Id: workerID})
+	if err != nil {
+		return nil, err
+	}
+	c.fleet.markDispatched(m)
+	res.Id = fleetJobID(m, res.Id)
+	return res, nil
+}
+
+// ResumeJob relays the resume of a crash-looping job to the worker running it
+func (c *Coordinator) ResumeJob(ctx context.Context, req *pb.ResumeJobReq) (*pb.ResumeJobRes, error) {
+	if err := c.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+	defer cancel()
+
+	res, err := m.client.ResumeJob(ctx, &pb.ResumeJobReq{Id: workerID})
+	if err != nil {
+		return nil, err
+	}
+	res.Id = req.Id
+	return res, nil
+}
+
+func (c *Coordinator) DeleteJob(ctx context.Context, req *pb.DeleteJobReq) (*pb.DeleteJobRes, error) {
+	if err := c.auth.Authorized(ctx, auth2.DeleteJobOp); err != nil {
+		return nil, err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+	defer cancel()
+
+	res, err := m.client.DeleteJob(ctx, &pb.DeleteJobReq{Id: workerID})
+	if err != nil {
+		return nil, err
+	}
+	res.Id = req.Id
+	return res, nil
+}
+
+// GetJobLogs relays the log stream of the worker running the job
+func (c *Coordinator) GetJobLogs(req *pb.GetJobLogsReq, stream pb.JobService_GetJobLogsServer) error {
+	if err := c.auth.Authorized(stream.Context(), auth2.GetJobOp); err != nil {
+		return err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return err
+	}
+
+	logs, err := m.client.GetJobLogs(stream.Context(), &pb.GetJobLogsReq{Id: workerID, Offset: req.Offset})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := logs.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// ExportJob relays the export of the worker running the job
+func (c *Coordinator) ExportJob(req *pb.ExportJobReq, stream pb.JobService_ExportJobServer) error {
+	if err := c.auth.Authorized(stream.Context(), auth2.GetJobOp); err != nil {
+		return err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return err
+	}
+
+	archive, err := m.client.ExportJob(stream.Context(), &pb.ExportJobReq{Id: workerID})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := archive.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// GetJobArtifact relays a file from the workspace of the job on its worker
+func (c *Coordinator) GetJobArtifact(req *pb.GetJobArtifactReq, stream pb.JobService_GetJobArtifactServer) error {
+	if err := c.auth.Authorized(stream.Context(), auth2.GetJobOp); err != nil {
+		return err
+	}
+
+	m, workerID, err := c.owner(req.Id)
+	if err != nil {
+		return err
+	}
+
+	artifact, err := m.client.GetJobArtifact(stream.Context(), &pb.GetJobArtifactReq{Id: workerID, Path: req.Path})
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := artifact.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+// ListJobs lists the jobs of every healthy worker. Workers failing to answer
+// are left out rather than failing the call.
+func (c *Coordinator) ListJobs(ctx context.Context, _ *pb.EmptyRequest) (*pb.Jobs, error) {
+	log := c.logger.WithField("operation", "ListJobs")
+
+	if err := c.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+		return nil, err
+	}
+
+	members := c.fleet.healthyMembers()
+	lists := make([]*pb.Jobs, len(members))
+
+	var wg sync.WaitGroup
+	for i, m := range members {
+		wg.Add(1)
+		go func(i int, m *member) {
+			defer wg.Done()
+
+			listCtx, cancel := context.WithTimeout(ctx, c.dispatchTimeout)
+			defer cancel()
+
+			jobs, err := m.client.ListJobs(listCtx, &pb.EmptyRequest{})
+			if err != nil {
+				log.Warn("failed to list jobs of worker", "worker", m.name, "error", err)
+				return
+			}
+			for _, job := range jobs.Jobs {
+				job.Id = fleetJobID(m, job.Id)
+			}
+			lists[i] = jobs
+		}(i, m)
+	}
+	wg.Wait()
+
+	all := &pb.Jobs{}
+	for _, jobs := range lists {
+		all.Jobs = append(all.Jobs, jobs.GetJobs()...)
+	}
+	return all, nil
+}
+
+// GetWorkerInfo describes the fleet as one worker: the capabilities and limit
+// profiles every healthy worker offers, and their summed capacity and load
+func (c *Coordinator) GetWorkerInfo(ctx context.Context, _ *pb.EmptyRequest) (*pb.WorkerInfo, error) {
+	if err := c.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		return nil, err
+	}
+
+	info := &pb.WorkerInfo{
+		ApiVersion: string(pb.File_jobworker_v1_worker_proto.Package()),
+		Os:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+	}
+
+	var capabilities, profiles map[string]int
+	members := c.fleet.healthyMembers()
+	unlimited := false
+	for _, m := range members {
+		m.mutex.Lock()
+		workerInfo := m.info
+		m.mutex.Unlock()
+
+		capabilities = count(capabilities, workerInfo.GetCapabilities())
+		profiles = count(profiles, workerInfo.GetLimitProfiles())
+		info.RunningJobs += workerInfo.GetRunningJobs()
+		info.MaxJobs += workerInfo.GetMaxJobs()
+		unlimited = unlimited || workerInfo.GetMaxJobs() <= 0
+	}
+	if unlimited {
+		info.MaxJobs = 0
+	}
+	info.Capabilities = common(capabilities, len(members))
+	info.LimitProfiles = common(profiles, len(members))
+	return info, nil
+}
+
+// owner returns the worker running the job with the given fleet id
+func (c *Coordinator) owner(id string) (*member, string, error) {
+	m, workerID, ok := c.fleet.lookup(id)
+	if !ok {
+		return nil, "", status.Errorf(codes.NotFound, "job not found: %s", id)
+	}
+	return m, workerID, nil
+}
+
+// failover tells whether a dispatch error means the job may start on another
+// worker: the worker was unreachable, full or failed to start it, rather than
+// refusing the request. A timeout is not retried, the job may have started.
+func failover(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Internal:
+		return true
+	default:
+		return false
+	}
+}
+
+func count(counts map[string]int, names []string) map[string]int {
+	if counts == nil {
+		counts = make(map[string]int)
+	}
+	for _, name := range names {
+		counts[name]++
+	}
+	return counts
+}
+
+// common returns the names counted n times, sorted
+func common(counts map[string]int, n int) []string {
+	var names []string
+	for name, c := range counts {
+		if c == n {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
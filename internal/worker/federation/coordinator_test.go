@@ -0,0 +1,187 @@
+package federation_test
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/auth/authfakes"
+	"worker/internal/worker/federation"
+	"worker/pkg/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// stubWorker answers the calls a coordinator makes to a worker
+type stubWorker struct {
+	pb.JobServiceClient
+
+	mutex   sync.Mutex
+	info    *pb.WorkerInfo
+	infoErr error
+	runErr  error
+	started int
+}
+
+func (s *stubWorker) GetWorkerInfo(context.Context, *pb.EmptyRequest, ...grpc.CallOption) (*pb.WorkerInfo, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.info, s.infoErr
+}
+
+func (s *stubWorker) RunJob(_ context.Context, req *pb.RunJobReq, _ ...grpc.CallOption) (*pb.RunJobRes, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.runErr != nil {
+		return nil, s.runErr
+	}
+	s.started++
+	return &pb.RunJobRes{Id: strconv.Itoa(s.started), Command: req.Command, Status: "RUNNING"}, nil
+}
+
+func (s *stubWorker) GetJobStatus(_ context.Context, req *pb.GetJobStatusReq, _ ...grpc.CallOption) (*pb.GetJobStatusRes, error) {
+	if req.Id != "1" {
+		return nil, status.Error(codes.NotFound, "job not found")
+	}
+	return &pb.GetJobStatusRes{Id: req.Id, Status: "COMPLETED"}, nil
+}
+
+func (s *stubWorker) GetJobProvenance(_ context.Context, req *pb.GetJobProvenanceReq, _ ...grpc.CallOption) (*pb.JobProvenance, error) {
+	return &pb.JobProvenance{JobId: req.Id, Command: "/bin/true"}, nil
+}
+
+func (s *stubWorker) ListJobs(context.Context, *pb.EmptyRequest, ...grpc.CallOption) (*pb.Jobs, error) {
+	return &pb.Jobs{Jobs: []*pb.Job{{Id: "1"}}}, nil
+}
+
+func (s *stubWorker) Close() error {
+	return nil
+}
+
+func newFleet(t *testing.T, workers map[string]*stubWorker) *federation.Fleet {
+	t.Helper()
+
+	cfg := config.CoordinatorConfig{HeartbeatInterval: time.Hour, HeartbeatTimeout: 2 * time.Hour}
+	for _, name := range []string{"node-1", "node-2"} {
+		if _, ok := workers[name]; ok {
+			cfg.Workers = append(cfg.Workers, config.FleetWorker{Name: name, Address: name + ":50051"})
+		}
+	}
+
+	fleet, err := federation.NewFleet(cfg, func(w config.FleetWorker) (pb.JobServiceClient, io.Closer, error) {
+		return workers[w.Name], workers[w.Name], nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(fleet.Close)
+	return fleet
+}
+
+func TestRunJobDispatchesToLeastLoadedWorker(t *testing.T) {
+	busy := &stubWorker{info: &pb.WorkerInfo{MaxJobs: 4, RunningJobs: 3}}
+	idle := &stubWorker{info: &pb.WorkerInfo{MaxJobs: 4, RunningJobs: 1}}
+	coordinator := federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, map[string]*stubWorker{"node-1": busy, "node-2": idle}), time.Second)
+
+	res, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Id != "node-2/1" || idle.started != 1 {
+		t.Errorf("expected the idle worker to get the job, got %s", res.Id)
+	}
+
+	// each dispatch counts against the worker until the next heartbeat
+	for i := 0; i < 3; i++ {
+		if _, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if busy.started != 1 || idle.started != 3 {
+		t.Errorf("expected the load to even out, got %d and %d", busy.started, idle.started)
+	}
+
+	_, err = coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"})
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected a full fleet to refuse jobs, got %v", err)
+	}
+}
+
+func TestRunJobFailsOver(t *testing.T) {
+	down := &stubWorker{info: &pb.WorkerInfo{}, runErr: status.Error(codes.Unavailable, "connection refused")}
+	up := &stubWorker{info: &pb.WorkerInfo{MaxJobs: 1}}
+	coordinator := federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, map[string]*stubWorker{"node-1": down, "node-2": up}), time.Second)
+
+	res, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"})
+	if err != nil || res.Id != "node-2/1" {
+		t.Fatalf("expected the job to fail over to node-2, got %v %v", res, err)
+	}
+
+	refused := &stubWorker{info: &pb.WorkerInfo{}, runErr: status.Error(codes.InvalidArgument, "bad command")}
+	coordinator = federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, map[string]*stubWorker{"node-1": refused, "node-2": up}), time.Second)
+	if _, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a refused job not to fail over, got %v", err)
+	}
+}
+
+func TestUnhealthyWorkerGetsNoJobs(t *testing.T) {
+	silent := &stubWorker{infoErr: status.Error(codes.Unavailable, "connection refused")}
+	coordinator := federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, map[string]*stubWorker{"node-1": silent}), time.Second)
+
+	if _, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected no worker to be available, got %v", err)
+	}
+	if silent.started != 0 {
+		t.Error("expected no dispatch to a worker without heartbeats")
+	}
+}
+
+func TestJobCallsRouteToOwner(t *testing.T) {
+	workers := map[string]*stubWorker{"node-1": {info: &pb.WorkerInfo{}}, "node-2": {info: &pb.WorkerInfo{}}}
+	coordinator := federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, workers), time.Second)
+
+	res, err := coordinator.GetJobStatus(context.Background(), &pb.GetJobStatusReq{Id: "node-2/1"})
+	if err != nil || res.Id != "node-2/1" || res.Status != "COMPLETED" {
+		t.Errorf("unexpected status %v %v", res, err)
+	}
+
+	provenance, err := coordinator.GetJobProvenance(context.Background(), &pb.GetJobProvenanceReq{Id: "node-2/1"})
+	if err != nil || provenance.JobId != "node-2/1" || provenance.Command != "/bin/true" {
+		t.Errorf("unexpected provenance %v %v", provenance, err)
+	}
+
+	for _, id := range []string{"node-3/1", "1", "node-1/"} {
+		if _, err := coordinator.GetJobStatus(context.Background(), &pb.GetJobStatusReq{Id: id}); status.Code(err) != codes.NotFound {
+			t.Errorf("expected %q to be unknown, got %v", id, err)
+		}
+	}
+
+	jobs, err := coordinator.ListJobs(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs.Jobs) != 2 || jobs.Jobs[0].Id != "node-1/1" || jobs.Jobs[1].Id != "node-2/1" {
+		t.Errorf("unexpected jobs %v", jobs.Jobs)
+	}
+}
+
+func TestGetWorkerInfoDescribesFleet(t *testing.T) {
+	workers := map[string]*stubWorker{
+		"node-1": {info: &pb.WorkerInfo{MaxJobs: 4, RunningJobs: 1, Capabilities: []string{"cgroups", "stdin"}}},
+		"node-2": {info: &pb.WorkerInfo{MaxJobs: 2, RunningJobs: 2, Capabilities: []string{"stdin"}}},
+	}
+	coordinator := federation.NewCoordinator(&authfakes.FakeGrpcAuthorization{}, newFleet(t, workers), time.Second)
+
+	info, err := coordinator.GetWorkerInfo(context.Background(), &pb.EmptyRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.MaxJobs != 6 || info.RunningJobs != 3 || len(info.Capabilities) != 1 || info.Capabilities[0] != "stdin" {
+		t.Errorf("unexpected fleet info %v", info)
+	}
+}
@@ -0,0 +1,342 @@
+package federation
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	pb "worker/api/gen"
+	"worker/pkg/client"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc"
+)
+
+// member is a worker of the fleet and what its last heartbeat reported
+type member struct {
+	name    string
+	address string
+	client  pb.JobServiceClient
+	closer  io.Closer
+
+	mutex      sync.Mutex
+	lastSeen   time.Time // zero until the first heartbeat answered
+	info       *pb.WorkerInfo
+	dispatched int32 // jobs started since the last heartbeat, not yet in info
+	failing    bool  // the last heartbeat failed
+	pushed     bool  // registered itself and sends its own heartbeats
+	draining   bool  // shutting down, takes no new jobs
+}
+
+// free returns how many more jobs the member takes, math.MaxInt32 less its
+// load when its capacity is unlimited
+func (m *member) free() int32 {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	running := m.info.GetRunningJobs() + m.dispatched
+	if m.info.GetMaxJobs() <= 0 {
+		return math.MaxInt32 - running
+	}
+	return m.info.GetMaxJobs() - running
+}
+
+func (m *member) isDraining() bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.draining
+}
+
+func (m *member) healthy(now time.Time, timeout time.Duration) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return !m.lastSeen.IsZero() && now.Sub(m.lastSeen) < timeout
+}
+
+// Dialer connects to a worker of the fleet
+type Dialer func(worker config.FleetWorker) (pb.JobServiceClient, io.Closer, error)
+
+// TLSDialer connects with the given client certificate, without retrying
+// calls, so heartbeats and dispatches fail over instead of waiting
+func TLSDialer(files client.TLSFiles) Dialer {
+	return func(worker config.FleetWorker) (pb.JobServiceClient, io.Closer, error) {
+		c, err := client.NewJobClientWithTLS(worker.Address, files, client.WithRetryPolicy(client.RetryPolicy{MaxAttempts: 1}))
+		if err != nil {
+			return nil, nil, err
+		}
+		return c.Service(), c, nil
+	}
+}
+
+// Fleet tracks the workers a coordinator dispatches to. Configured workers are
+// sent a GetWorkerInfo heartbeat every interval, workers that registered
+// themselves send their own. A worker without a heartbeat within the heartbeat
+// timeout gets no new jobs until it is heard from again.
+type Fleet struct {
+	mutex   sync.RWMutex
+	members []*member
+	byName  map[string]*member
+	dial    Dialer
+
+	interval time.Duration
+	timeout  time.Duration
+	now      func() time.Time
+	logger   *logger.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewFleet connects to the configured workers and starts sending heartbeats
+func NewFleet(cfg config.CoordinatorConfig, dial Dialer) (*Fleet, error) {
+	f := &Fleet{
+		byName:   make(map[string]*member, len(cfg.Workers)),
+		dial:     dial,
+		interval: cfg.HeartbeatInterval,
+		timeout:  cfg.HeartbeatTimeout,
+		now:      time.Now,
+		logger:   logger.WithField("component", "fleet"),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	for _, worker := range cfg.Workers {
+		c, closer, err := dial(worker)
+		if err != nil {
+			f.closeMembers()
+			return nil, fmt.Errorf("failed to connect to worker %s: %w", worker.Name, err)
+		}
+		m := &member{name: worker.Name, address: worker.Address, client: c, closer: closer}
+		f.members = append(f.members, m)
+		f.byName[m.name] = m
+	}
+
+	f.heartbeat()
+	go f.run()
+	return f, nil
+}
+
+// Close stops the heartbeats and closes the worker connections
+func (f *Fleet) Close() {
+	close(f.stop)
+	<-f.done
+	f.closeMembers()
+}
+
+func (f *Fleet) closeMembers() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for _, m := range f.members {
+		if m.closer != nil {
+			_ = m.closer.Close()
+		}
+	}
+}
+
+func (f *Fleet) run() {
+	defer close(f.done)
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			f.heartbeat()
+		case <-f.stop:
+			return
+		}
+	}
+}
+
+// heartbeat polls every configured worker at once and records what they report
+func (f *Fleet) heartbeat() {
+	var wg sync.WaitGroup
+	for _, m := range f.snapshot() {
+		m.mutex.Lock()
+		pushed := m.pushed
+		m.mutex.Unlock()
+		if pushed {
+			continue
+		}
+
+		wg.Add(1)
+		go func(m *member) {
+			defer wg.Done()
+
+			ctx, cancel := context.WithTimeout(context.Background(), f.interval)
+			defer cancel()
+
+			info, err := m.client.GetWorkerInfo(ctx, &pb.EmptyRequest{}, grpc.WaitForReady(false))
+
+			m.mutex.Lock()
+			defer m.mutex.Unlock()
+
+			if err != nil {
+				if !m.failing {
+					f.logger.Warn("worker heartbeat failed", "worker", m.name, "address", m.address, "error", err)
+				}
+				m.failing = true
+				return
+			}
+
+			if m.failing || m.lastSeen.IsZero() {
+				f.logger.Info("worker available", "worker", m.name, "address", m.address,
+					"maxJobs", info.MaxJobs, "runningJobs", info.RunningJobs)
+			}
+			m.lastSeen = f.now()
+			m.info = info
+			m.dispatched = 0
+			m.failing = false
+		}(m)
+	}
+	wg.Wait()
+}
+
+// register adds a worker that joined the fleet itself, or updates it when it
+// registers again, for instance after a restart
+func (f *Fleet) register(name, address string, info *pb.WorkerInfo) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	m, exists := f.byName[name]
+	if !exists || m.address != address {
+		c, closer, err := f.dial(config.FleetWorker{Name: name, Address: address})
+		if err != nil {
+			return fmt.Errorf("failed to connect to worker %s: %w", name, err)
+		}
+		replacement := &member{name: name, address: address, client: c, closer: closer}
+
+		if exists {
+			if m.closer != nil {
+				_ = m.closer.Close()
+			}
+			f.members[slices.Index(f.members, m)] = replacement
+		} else {
+			f.members = append(f.members, replacement)
+		}
+		f.byName[name] = replacement
+		m = replacement
+	}
+
+	m.mutex.Lock()
+	m.pushed = true
+	m.mutex.Unlock()
+	f.logger.Info("worker registered", "worker", name, "address", address)
+	f.report(m, info, false)
+	return nil
+}
+
+// reportHeartbeat records a heartbeat a registered worker sent
+func (f *Fleet) reportHeartbeat(name string, info *pb.WorkerInfo, draining bool) error {
+	f.mutex.RLock()
+	m, exists := f.byName[name]
+	f.mutex.RUnlock()
+
+	if !exists {
+		return fmt.Errorf("worker %s is not registered", name)
+	}
+	f.report(m, info, draining)
+	return nil
+}
+
+func (f *Fleet) report(m *member, info *pb.WorkerInfo, draining bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if draining && !m.draining {
+		f.logger.Info("worker draining", "worker", m.name)
+	}
+	m.lastSeen = f.now()
+	m.info = info
+	m.dispatched = 0
+	m.draining = draining
+}
+
+func (f *Fleet) snapshot() []*member {
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+	return slices.Clone(f.members)
+}
+
+// candidates returns the healthy workers with room for another job, the
+// least loaded first
+func (f *Fleet) candidates() []*member {
+	now := f.now()
+
+	var available []*member
+	free := make(map[*member]int32)
+	for _, m := range f.snapshot() {
+		if !m.healthy(now, f.timeout) || m.isDraining() {
+			continue
+		}
+		if n := m.free(); n > 0 {
+			available = append(available, m)
+			free[m] = n
+		}
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return free[available[i]] > free[available[j]]
+	})
+	return available
+}
+
+// markDispatched counts a job started on m until the next heartbeat reports it
+func (f *Fleet) markDispatched(m *member) {
+	m.mutex.Lock()
+	m.dispatched++
+	m.mutex.Unlock()
+}
+
+// markFailed takes m out of dispatching until it answers a heartbeat again
+func (f *Fleet) markFailed(m *member) {
+	m.mutex.Lock()
+	m.lastSeen = time.Time{}
+	m.mutex.Unlock()
+}
+
+// healthyMembers returns the workers that answered heartbeats recently
+func (f *Fleet) healthyMembers() []*member {
+	now := f.now()
+
+	var healthy []*member
+	for _, m := range f.snapshot() {
+		if m.healthy(now, f.timeout) {
+			healthy = append(healthy, m)
+		}
+	}
+	return healthy
+}
+
+// lookup returns the worker named in a fleet job id and the worker's own id
+// of the job
+func (f *Fleet) lookup(id string) (*member, string, bool) {
+	name, workerID, ok := splitJobID(id)
+	if !ok {
+		return nil, "", false
+	}
+	f.mutex.RLock()
+	defer f.mutex.RUnlock()
+
+	m, exists := f.byName[name]
+	return m, workerID, exists
+}
+
+// fleetJobID prefixes the id a worker gave a job with the worker's name
+func fleetJobID(m *member, workerID string) string {
+	return m.name + "/" + workerID
+}
+
+// splitJobID splits a fleet job id at the first slash, worker names have none
+func splitJobID(id string) (name, workerID string, ok bool) {
+	name, workerID, ok = strings.Cut(id, "/")
+	return name, workerID, ok && name != "" && workerID != ""
+}
@@ -0,0 +1,169 @@
+package federation
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"time"
+	pb "worker/api/gen"
+	"worker/pkg/client"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InfoFunc reports the info a worker sends in its heartbeats
+type InfoFunc func(ctx context.Context) (*pb.WorkerInfo, error)
+
+// CoordinatorDialer connects to the FleetService of a coordinator
+type CoordinatorDialer func(address string) (pb.FleetServiceClient, io.Closer, error)
+
+// TLSCoordinatorDialer connects with the given client certificate
+func TLSCoordinatorDialer(files client.TLSFiles) CoordinatorDialer {
+	return func(address string) (pb.FleetServiceClient, io.Closer, error) {
+		c, err := client.NewJobClientWithTLS(address, files)
+		if err != nil {
+			return nil, nil, err
+		}
+		return c.FleetService(), c, nil
+	}
+}
+
+// Registrar makes a worker a member of a coordinator's fleet. It registers
+// the worker and sends a heartbeat with the worker's load every interval,
+// registering again whenever the coordinator no longer knows the worker.
+// On Close it sends a last heartbeat asking for no new jobs.
+type Registrar struct {
+	name    string
+	address string
+	info    InfoFunc
+
+	coordinator pb.FleetServiceClient
+	closer      io.Closer
+	interval    time.Duration
+	logger      *logger.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewRegistrar starts joining the fleet. It returns nil when the worker is
+// not configured to join one.
+func NewRegistrar(cfg config.FleetConfig, serverPort int, info InfoFunc, dial CoordinatorDialer) (*Registrar, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	hostname, _ := os.Hostname()
+	name := cfg.Name
+	if name == "" {
+		name = hostname
+	}
+	address := cfg.Address
+	if address == "" {
+		address = net.JoinHostPort(hostname, strconv.Itoa(serverPort))
+	}
+
+	coordinator, closer, err := dial(cfg.Coordinator)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Registrar{
+		name:        name,
+		address:     address,
+		info:        info,
+		coordinator: coordinator,
+		closer:      closer,
+		interval:    cfg.Interval,
+		logger:      logger.WithFields("component", "fleet-registrar", "coordinator", cfg.Coordinator),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go r.run()
+
+	r.logger.Info("joining fleet", "name", name, "address", address)
+	return r, nil
+}
+
+// Close tells the coordinator the worker is draining and stops the heartbeats
+func (r *Registrar) Close() {
+	if r == nil {
+		return
+	}
+
+	close(r.stop)
+	<-r.done
+
+	ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+	defer cancel()
+	if err := r.heartbeat(ctx, true); err != nil {
+		r.logger.Warn("failed to announce draining", "error", err)
+	}
+	_ = r.closer.Close()
+}
+
+func (r *Registrar) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	registered := false
+	failing := false
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), r.interval)
+		var err error
+		if registered {
+			err = r.heartbeat(ctx, false)
+			if status.Code(err) == codes.NotFound {
+				r.logger.Info("coordinator lost the registration, registering again")
+				registered = false
+			}
+		}
+		if !registered {
+			if err = r.register(ctx); err == nil {
+				registered = true
+			}
+		}
+		cancel()
+
+		switch {
+		case err != nil && !failing:
+			r.logger.Warn("fleet heartbeat failed", "error", err)
+			failing = true
+		case err == nil && failing:
+			r.logger.Info("fleet heartbeats resumed")
+			failing = false
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.stop:
+			return
+		}
+	}
+}
+
+func (r *Registrar) register(ctx context.Context) error {
+	info, err := r.info(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = r.coordinator.RegisterWorker(ctx, &pb.RegisterWorkerReq{Name: r.name, Address: r.address, Info: info}, grpc.WaitForReady(false))
+	return err
+}
+
+func (r *Registrar) heartbeat(ctx context.Context, draining bool) error {
+	info, err := r.info(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = r.coordinator.Heartbeat(ctx, &pb.HeartbeatReq{Name: r.name, Info: info, Draining: draining}, grpc.WaitForReady(false))
+	return err
+}
@@ -0,0 +1,59 @@
+package federation
+
+import (
+	"context"
+	"strings"
+	pb "worker/api/gen"
+	auth2 "worker/internal/worker/auth"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Registry serves the FleetService of a coordinator, through which workers
+// join its fleet and report their load
+type Registry struct {
+	pb.UnimplementedFleetServiceServer
+	auth   auth2.GrpcAuthorization
+	fleet  *Fleet
+	logger *logger.Logger
+}
+
+func NewRegistry(auth auth2.GrpcAuthorization, fleet *Fleet) *Registry {
+	return &Registry{
+		auth:   auth,
+		fleet:  fleet,
+		logger: logger.WithField("component", "fleet-registry"),
+	}
+}
+
+func (r *Registry) RegisterWorker(ctx context.Context, req *pb.RegisterWorkerReq) (*pb.RegisterWorkerRes, error) {
+	log := r.logger.WithFields("operation", "RegisterWorker", "worker", req.Name, "address", req.Address)
+
+	if err := r.auth.Authorized(ctx, auth2.JoinFleetOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	if req.Name == "" || strings.Contains(req.Name, "/") || req.Address == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid worker %q at %q", req.Name, req.Address)
+	}
+
+	if err := r.fleet.register(req.Name, req.Address, req.Info); err != nil {
+		log.Error("failed to register worker", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to register worker: %v", err)
+	}
+	return &pb.RegisterWorkerRes{}, nil
+}
+
+func (r *Registry) Heartbeat(ctx context.Context, req *pb.HeartbeatReq) (*pb.HeartbeatRes, error) {
+	if err := r.auth.Authorized(ctx, auth2.JoinFleetOp); err != nil {
+		return nil, err
+	}
+
+	if err := r.fleet.reportHeartbeat(req.Name, req.Info, req.Draining); err != nil {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	return &pb.HeartbeatRes{}, nil
+}
@@ -0,0 +1,149 @@
+package federation_test
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/auth/authfakes"
+	"worker/internal/worker/federation"
+	"worker/pkg/config"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRegisteredWorkerGetsJobs(t *testing.T) {
+	worker := &stubWorker{}
+	cfg := config.CoordinatorConfig{HeartbeatInterval: time.Hour, HeartbeatTimeout: 2 * time.Hour}
+	fleet, err := federation.NewFleet(cfg, func(w config.FleetWorker) (pb.JobServiceClient, io.Closer, error) {
+		if w.Address != "10.0.0.11:50051" {
+			t.Errorf("unexpected address %s", w.Address)
+		}
+		return worker, worker, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fleet.Close()
+
+	auth := &authfakes.FakeGrpcAuthorization{}
+	registry := federation.NewRegistry(auth, fleet)
+	coordinator := federation.NewCoordinator(auth, fleet, time.Second)
+
+	if _, err := registry.Heartbeat(context.Background(), &pb.HeartbeatReq{Name: "node-1"}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected a heartbeat before registering to be refused, got %v", err)
+	}
+	if _, err := registry.RegisterWorker(context.Background(), &pb.RegisterWorkerReq{Name: "a/b", Address: "10.0.0.11:50051"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected a name with a slash to be refused, got %v", err)
+	}
+
+	_, err = registry.RegisterWorker(context.Background(), &pb.RegisterWorkerReq{Name: "node-1", Address: "10.0.0.11:50051", Info: &pb.WorkerInfo{MaxJobs: 2}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"})
+	if err != nil || res.Id != "node-1/1" {
+		t.Fatalf("expected the registered worker to get the job, got %v %v", res, err)
+	}
+
+	if _, err := registry.Heartbeat(context.Background(), &pb.HeartbeatReq{Name: "node-1", Info: &pb.WorkerInfo{MaxJobs: 2}, Draining: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := coordinator.RunJob(context.Background(), &pb.RunJobReq{Command: "echo"}); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("expected a draining worker to get no jobs, got %v", err)
+	}
+	if _, err := coordinator.GetJobStatus(context.Background(), &pb.GetJobStatusReq{Id: "node-1/1"}); err != nil {
+		t.Errorf("expected the jobs of a draining worker to stay reachable, got %v", err)
+	}
+}
+
+// stubCoordinator records the fleet calls of a registrar
+type stubCoordinator struct {
+	pb.FleetServiceClient
+
+	mutex      sync.Mutex
+	registered int
+	heartbeats []*pb.HeartbeatReq
+	forget     bool // answer the next heartbeat with NOT_FOUND
+}
+
+func (s *stubCoordinator) RegisterWorker(_ context.Context, req *pb.RegisterWorkerReq, _ ...grpc.CallOption) (*pb.RegisterWorkerRes, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.registered++
+	return &pb.RegisterWorkerRes{}, nil
+}
+
+func (s *stubCoordinator) Heartbeat(_ context.Context, req *pb.HeartbeatReq, _ ...grpc.CallOption) (*pb.HeartbeatRes, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.forget {
+		s.forget = false
+		return nil, status.Error(codes.NotFound, "worker node-1 is not registered")
+	}
+	s.heartbeats = append(s.heartbeats, req)
+	return &pb.HeartbeatRes{}, nil
+}
+
+func (s *stubCoordinator) Close() error {
+	return nil
+}
+
+func (s *stubCoordinator) counts() (int, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.registered, len(s.heartbeats)
+}
+
+func TestRegistrarSendsHeartbeats(t *testing.T) {
+	coordinator := &stubCoordinator{}
+	info := func(context.Context) (*pb.WorkerInfo, error) {
+		return &pb.WorkerInfo{MaxJobs: 4, RunningJobs: 1}, nil
+	}
+	cfg := config.FleetConfig{Enabled: true, Coordinator: "coordinator:50051", Name: "node-1", Address: "10.0.0.11:50051", Interval: 10 * time.Millisecond}
+
+	registrar, err := federation.NewRegistrar(cfg, 50051, info, func(address string) (pb.FleetServiceClient, io.Closer, error) {
+		return coordinator, coordinator, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	waitFor(t, func() bool { _, heartbeats := coordinator.counts(); return heartbeats >= 2 })
+
+	coordinator.mutex.Lock()
+	coordinator.forget = true
+	coordinator.mutex.Unlock()
+	waitFor(t, func() bool { registered, _ := coordinator.counts(); return registered == 2 })
+
+	registrar.Close()
+	coordinator.mutex.Lock()
+	defer coordinator.mutex.Unlock()
+	last := coordinator.heartbeats[len(coordinator.heartbeats)-1]
+	if !last.Draining || last.Name != "node-1" || last.Info.RunningJobs != 1 {
+		t.Errorf("expected a last draining heartbeat, got %v", last)
+	}
+}
+
+func TestRegistrarDisabled(t *testing.T) {
+	registrar, err := federation.NewRegistrar(config.FleetConfig{}, 50051, nil, nil)
+	if registrar != nil || err != nil {
+		t.Errorf("expected no registrar, got %v %v", registrar, err)
+	}
+	registrar.Close()
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
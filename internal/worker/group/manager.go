@@ -0,0 +1,151 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/state"
+	"worker/pkg/logger"
+)
+
+var ErrNotFound = errors.New("job group not found")
+
+// Manager starts job groups and answers questions about them. Group membership
+// is also recorded on each job, so jobs keep their group after the fact.
+type Manager struct {
+	worker interfaces.Worker
+	store  state.Store
+
+	counter int64
+	groups  map[string]*domain.JobGroup
+	mutex   sync.RWMutex
+
+	logger *logger.Logger
+}
+
+func NewManager(worker interfaces.Worker, store state.Store) *Manager {
+	return &Manager{
+		worker: worker,
+		store:  store,
+		groups: make(map[string]*domain.JobGroup),
+		logger: logger.WithField("component", "job-groups"),
+	}
+}
+
+// Start starts every job of the group, sharing limits unless they are zero.
+// If any job fails to start, the jobs already started are stopped and the
+// group is not created.
+func (m *Manager) Start(ctx context.Context, name string, limits domain.ResourceLimits, specs []*domain.JobSpec) (*domain.JobGroup, error) {
+	if len(specs) == 0 {
+		return nil, errors.New("job group has no jobs")
+	}
+
+	group := &domain.JobGroup{
+		Id:        fmt.Sprintf("%d", atomic.AddInt64(&m.counter, 1)),
+		Name:      name,
+		Limits:    limits,
+		CreatedAt: time.Now(),
+	}
+	log := m.logger.WithFields("groupId", group.Id, "name", name)
+
+	for i, spec := range specs {
+		groupSpec := *spec
+		groupSpec.GroupID = group.Id
+		groupSpec.GroupLimits = limits
+
+		job, err := m.worker.StartJob(ctx, &groupSpec)
+		if err != nil {
+			log.Warn("job group start failed, stopping started jobs", "failedJob", i, "error", err)
+			m.stopJobs(context.Background(), group.JobIds)
+			return nil, fmt.Errorf("job %d: %w", i, err)
+		}
+		group.JobIds = append(group.JobIds, job.Id)
+	}
+
+	m.mutex.Lock()
+	m.groups[group.Id] = group
+	m.mutex.Unlock()
+
+	log.Debug("job group started", "jobs", len(group.JobIds))
+
+	return group.DeepCopy(), nil
+}
+
+// Get returns the group with the current state of its jobs
+func (m *Manager) Get(id string) (*domain.JobGroup, []*domain.Job, error) {
+	m.mutex.RLock()
+	group, exists := m.groups[id]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	return group.DeepCopy(), m.Jobs(group), nil
+}
+
+// List returns every group, oldest first
+func (m *Manager) List() []*domain.JobGroup {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	groups := make([]*domain.JobGroup, 0, len(m.groups))
+	for _, group := range m.groups {
+		groups = append(groups, group.DeepCopy())
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].CreatedAt.Before(groups[j].CreatedAt)
+	})
+	return groups
+}
+
+// Stop stops every running job of the group. Errors for individual jobs are
+// returned by job ID; jobs that already finished are not an error.
+func (m *Manager) Stop(ctx context.Context, id string) (map[string]error, error) {
+	m.mutex.RLock()
+	group, exists := m.groups[id]
+	m.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	var running []string
+	for _, job := range m.Jobs(group) {
+		if job.IsRunning() || job.IsCrashLooping() {
+			running = append(running, job.Id)
+		}
+	}
+
+	failures := m.stopJobs(ctx, running)
+	m.logger.Debug("job group stopped", "groupId", id, "stopped", len(running)-len(failures), "failed", len(failures))
+
+	return failures, nil
+}
+
+func (m *Manager) stopJobs(ctx context.Context, jobIDs []string) map[string]error {
+	failures := make(map[string]error)
+	for _, jobID := range jobIDs {
+		if _, err := m.worker.StopJob(ctx, jobID, domain.StopOptions{}); err != nil {
+			failures[jobID] = err
+		}
+	}
+	return failures
+}
+
+// Jobs returns the current state of a group's jobs
+func (m *Manager) Jobs(group *domain.JobGroup) []*domain.Job {
+	jobs := make([]*domain.Job, 0, len(group.JobIds))
+	for _, jobID := range group.JobIds {
+		if job, exists := m.store.GetJob(jobID); exists {
+			jobs = append(jobs, job)
+		}
+	}
+	return jobs
+}
@@ -0,0 +1,149 @@
+package group
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"worker/internal/worker/core/interfaces/interfacesfakes"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/state"
+)
+
+// newTestManager returns a manager whose fake worker records started jobs in
+// the store; jobs whose command is "fail" fail to start
+func newTestManager() (*Manager, *interfacesfakes.FakeWorker, state.Store) {
+	store := state.New()
+	worker := &interfacesfakes.FakeWorker{}
+
+	var counter int
+	worker.StartJobStub = func(_ context.Context, spec *domain.JobSpec) (*domain.Job, error) {
+		if spec.Command == "fail" {
+			return nil, errors.New("start failed")
+		}
+		counter++
+		job := &domain.Job{Id: fmt.Sprintf("%d", counter), Command: spec.Command, GroupId: spec.GroupID, Status: domain.StatusRunning}
+		store.CreateNewJob(job)
+		return job, nil
+	}
+
+	return NewManager(worker, store), worker, store
+}
+
+func TestManagerStart(t *testing.T) {
+	m, _, store := newTestManager()
+
+	group, err := m.Start(context.Background(), "web", domain.ResourceLimits{}, []*domain.JobSpec{{Command: "redis"}, {Command: "app"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(group.JobIds) != 2 {
+		t.Fatalf("expected 2 jobs, got %v", group.JobIds)
+	}
+	for _, id := range group.JobIds {
+		job, _ := store.GetJob(id)
+		if job.GroupId != group.Id {
+			t.Errorf("expected job %s to belong to group %s, got %q", id, group.Id, job.GroupId)
+		}
+	}
+
+	got, jobs, err := m.Get(group.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Name != "web" || len(jobs) != 2 {
+		t.Errorf("unexpected group %+v with %d jobs", got, len(jobs))
+	}
+}
+
+func TestManagerStartSharesLimits(t *testing.T) {
+	m, worker, _ := newTestManager()
+	limits := domain.ResourceLimits{CPUMillis: 2000, MemoryBytes: 1 << 30}
+
+	group, err := m.Start(context.Background(), "web", limits, []*domain.JobSpec{{Command: "redis"}, {Command: "app"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if group.Limits != limits {
+		t.Errorf("expected group limits %+v, got %+v", limits, group.Limits)
+	}
+	for i := 0; i < worker.StartJobCallCount(); i++ {
+		_, spec := worker.StartJobArgsForCall(i)
+		if spec.GroupLimits != limits || spec.Limits != (domain.ResourceLimits{}) {
+			t.Errorf("job %d started with limits %+v and group limits %+v", i, spec.Limits, spec.GroupLimits)
+		}
+	}
+}
+
+func TestManagerStartRollsBack(t *testing.T) {
+	m, worker, _ := newTestManager()
+
+	_, err := m.Start(context.Background(), "broken", domain.ResourceLimits{}, []*domain.JobSpec{{Command: "a"}, {Command: "b"}, {Command: "fail"}})
+	if err == nil {
+		t.Fatal("expected error when a job fails to start")
+	}
+
+	if worker.StopJobCallCount() != 2 {
+		t.Errorf("expected the 2 started jobs to be stopped, got %d stops", worker.StopJobCallCount())
+	}
+	if len(m.List()) != 0 {
+		t.Error("expected no group to be recorded")
+	}
+}
+
+func TestManagerStop(t *testing.T) {
+	m, worker, store := newTestManager()
+
+	group, err := m.Start(context.Background(), "batch", domain.ResourceLimits{}, []*domain.JobSpec{{Command: "a"}, {Command: "b"}, {Command: "c"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the first job already finished and must not be stopped
+	finished, _ := store.GetJob(group.JobIds[0])
+	finished.Complete(0, "exited with code 0")
+	store.UpdateJob(finished)
+
+	worker.StopJobStub = func(_ context.Context, id string, _ domain.StopOptions) (*domain.StopResult, error) {
+		if id == group.JobIds[2] {
+			return nil, errors.New("stop failed")
+		}
+		return &domain.StopResult{Method: domain.StopGraceful}, nil
+	}
+
+	failures, err := m.Stop(context.Background(), group.Id)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if worker.StopJobCallCount() != 2 {
+		t.Errorf("expected 2 running jobs to be stopped, got %d", worker.StopJobCallCount())
+	}
+	if len(failures) != 1 || failures[group.JobIds[2]] == nil {
+		t.Errorf("expected a failure for job %s, got %v", group.JobIds[2], failures)
+	}
+
+	if _, err := m.Stop(context.Background(), "missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestManagerList(t *testing.T) {
+	m, _, _ := newTestManager()
+
+	for _, name := range []string{"first", "second", "third"} {
+		if _, err := m.Start(context.Background(), name, domain.ResourceLimits{}, []*domain.JobSpec{{Command: name}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	groups := m.List()
+	if len(groups) != 3 {
+		t.Fatalf("expected 3 groups, got %d", len(groups))
+	}
+	if groups[0].Name != "first" || groups[2].Name != "third" {
+		t.Errorf("expected groups oldest first, got %s..%s", groups[0].Name, groups[2].Name)
+	}
+}
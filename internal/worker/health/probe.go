@@ -0,0 +1,153 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+// Checker runs a single probe attempt against the process with the given host PID
+type Checker interface {
+	Check(ctx context.Context, pid int32) error
+}
+
+// NewChecker returns the checker for the probe's type
+func NewChecker(probe *domain.HealthProbe) (Checker, error) {
+	switch probe.Type {
+	case domain.ProbeExec:
+		return &execChecker{command: probe.Command}, nil
+	case domain.ProbeTCP:
+		return &tcpChecker{address: localAddress(probe.Port)}, nil
+	case domain.ProbeHTTP:
+		path := probe.Path
+		if path == "" || path[0] != '/' {
+			path = "/" + path
+		}
+		return &httpChecker{
+			url:    "http://" + localAddress(probe.Port) + path,
+			client: &http.Client{CheckRedirect: noRedirects},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown probe type %q", probe.Type)
+	}
+}
+
+// jobs share the host network, so their ports are reachable on loopback
+func localAddress(port int32) string {
+	return net.JoinHostPort("127.0.0.1", strconv.Itoa(int(port)))
+}
+
+// execChecker runs the command in the job's mount, PID, UTS and IPC namespaces
+// through nsenter, so it sees the job's filesystem view and processes
+type execChecker struct {
+	command []string
+}
+
+func (c *execChecker) Check(ctx context.Context, pid int32) error {
+	cmd := exec.CommandContext(ctx, "nsenter", nsenterArgs(pid, c.command)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("probe command failed: %w (output: %q)", err, truncate(output, 256))
+	}
+	return nil
+}
+
+func nsenterArgs(pid int32, command []string) []string {
+	args := []string{
+		"--target", strconv.Itoa(int(pid)),
+		"--mount", "--pid", "--uts", "--ipc",
+		"--",
+	}
+	return append(args, command...)
+}
+
+type tcpChecker struct {
+	address string
+}
+
+func (c *tcpChecker) Check(ctx context.Context, _ int32) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
+type httpChecker struct {
+	url    string
+	client *http.Client
+}
+
+func (c *httpChecker) Check(ctx context.Context, _ int32) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return fmt.Errorf("unhealthy HTTP status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// a redirect response already shows the server is alive
+func noRedirects(*http.Request, []*http.Request) error {
+	return http.ErrUseLastResponse
+}
+
+func truncate(b []byte, n int) []byte {
+	if len(b) > n {
+		return b[:n]
+	}
+	return b
+}
+
+// Run probes the process until ctx is done. onResult is called after every
+// attempt with the number of consecutive failures (0 on success).
+func Run(ctx context.Context, probe *domain.HealthProbe, checker Checker, pid int32, onResult func(failures int32, err error)) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(probe.InitialDelay):
+	}
+
+	ticker := time.NewTicker(probe.Interval)
+	defer ticker.Stop()
+
+	var failures int32
+	for {
+		attemptCtx, cancel := context.WithTimeout(ctx, probe.Timeout)
+		err := checker.Check(attemptCtx, pid)
+		cancel()
+
+		// a probe cut short by shutdown says nothing about the job
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err != nil {
+			failures++
+		} else {
+			failures = 0
+		}
+		onResult(failures, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func listenerPort(t *testing.T, addr net.Addr) int32 {
+	t.Helper()
+	return int32(addr.(*net.TCPAddr).Port)
+}
+
+func TestTCPChecker(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := listenerPort(t, ln.Addr())
+
+	checker, _ := NewChecker(&domain.HealthProbe{Type: domain.ProbeTCP, Port: port})
+	if err := checker.Check(context.Background(), 1); err != nil {
+		t.Errorf("expected open port to be healthy, got %v", err)
+	}
+
+	ln.Close()
+	if err := checker.Check(context.Background(), 1); err == nil {
+		t.Error("expected closed port to be unhealthy")
+	}
+}
+
+func TestHTTPChecker(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			w.WriteHeader(http.StatusOK)
+		case "/moved":
+			http.Redirect(w, r, "/elsewhere", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	port := listenerPort(t, srv.Listener.Addr())
+
+	for path, healthy := range map[string]bool{"/healthz": true, "moved": true, "/down": false} {
+		checker, _ := NewChecker(&domain.HealthProbe{Type: domain.ProbeHTTP, Port: port, Path: path})
+		err := checker.Check(context.Background(), 1)
+		if healthy && err != nil {
+			t.Errorf("%s: expected healthy, got %v", path, err)
+		}
+		if !healthy && err == nil {
+			t.Errorf("%s: expected unhealthy", path)
+		}
+	}
+}
+
+func TestNsenterArgs(t *testing.T) {
+	got := strings.Join(nsenterArgs(42, []string{"cat", "/tmp/ready"}), " ")
+	want := "--target 42 --mount --pid --uts --ipc -- cat /tmp/ready"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+type scriptedChecker struct {
+	mutex   sync.Mutex
+	results []error
+}
+
+func (c *scriptedChecker) Check(context.Context, int32) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if len(c.results) == 0 {
+		return nil
+	}
+	err := c.results[0]
+	c.results = c.results[1:]
+	return err
+}
+
+func TestRunCountsConsecutiveFailures(t *testing.T) {
+	fail := errors.New("down")
+	checker := &scriptedChecker{results: []error{fail, fail, nil, fail}}
+	probe := &domain.HealthProbe{Interval: time.Millisecond, Timeout: time.Second}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var got []int32
+	Run(ctx, probe, checker, 1, func(failures int32, err error) {
+		got = append(got, failures)
+		if len(got) == 4 {
+			cancel()
+		}
+	})
+
+	want := []int32{1, 2, 0, 1}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected failure counts %v, got %v", want, got)
+		}
+	}
+}
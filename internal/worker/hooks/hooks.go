@@ -0,0 +1,216 @@
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Event names when a hook runs
+type Event string
+
+const (
+	PreStart Event = "pre-start" // the job passed validation, nothing is created yet
+	PostStop Event = "post-stop" // the job ended and will not be restarted
+)
+
+// defaultTimeout applies to hooks configured without a timeout
+const defaultTimeout = 10 * time.Second
+
+// Payload is the JSON a hook receives. Environment variables are left out
+// since they may carry credentials.
+type Payload struct {
+	Event Event      `json:"event"`
+	Job   jobPayload `json:"job"`
+}
+
+type jobPayload struct {
+	ID               string            `json:"id"`
+	Command          string            `json:"command"`
+	Args             []string          `json:"args"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	GroupID          string            `json:"groupId,omitempty"`
+	Isolation        string            `json:"isolation"`
+	QoS              string            `json:"qos"`
+	Workspace        string            `json:"workspace,omitempty"`
+	CPULimitMillis   int64             `json:"cpuLimitMillis"`
+	MemoryLimitBytes int64             `json:"memoryLimitBytes"`
+	IOLimitBPS       int64             `json:"ioLimitBPS"`
+	StartTime        string            `json:"startTime"`
+	Result           *resultPayload    `json:"result,omitempty"` // post-stop only
+}
+
+type resultPayload struct {
+	Status   string `json:"status"`
+	ExitCode int32  `json:"exitCode"`
+	Restarts int32  `json:"restarts"`
+	EndTime  string `json:"endTime,omitempty"`
+}
+
+// Runner runs the configured hooks. All Runner methods are safe to call on
+// nil, which runs nothing.
+type Runner struct {
+	preStart []config.HookConfig
+	postStop []config.HookConfig
+	client   *http.Client
+	logger   *logger.Logger
+}
+
+// New creates a runner from configuration. It returns nil when no hooks are
+// configured.
+func New(cfg config.HooksConfig) *Runner {
+	if len(cfg.PreStart) == 0 && len(cfg.PostStop) == 0 {
+		return nil
+	}
+
+	return &Runner{
+		preStart: cfg.PreStart,
+		postStop: cfg.PostStop,
+		client:   &http.Client{},
+		logger:   logger.WithField("component", "hooks"),
+	}
+}
+
+// PreStart runs the pre-start hooks in order. It returns the error of the
+// first required hook that fails; failures of other hooks are only logged.
+func (r *Runner) PreStart(ctx context.Context, job *domain.Job) error {
+	if r == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(NewPayload(PreStart, job))
+	if err != nil {
+		return fmt.Errorf("failed to encode hook payload: %w", err)
+	}
+
+	for _, hook := range r.preStart {
+		if err := r.run(ctx, hook, PreStart, job.Id, payload); err != nil {
+			if hook.Required {
+				return fmt.Errorf("pre-start hook %q failed: %w", hook.Name, err)
+			}
+			r.logger.Warn("pre-start hook failed", "hook", hook.Name, "jobId", job.Id, "error", err)
+		}
+	}
+	return nil
+}
+
+// PostStop runs the post-stop hooks in order, logging failures
+func (r *Runner) PostStop(ctx context.Context, job *domain.Job) {
+	if r == nil {
+		return
+	}
+
+	payload, err := json.Marshal(NewPayload(PostStop, job))
+	if err != nil {
+		r.logger.Error("failed to encode hook payload", "jobId", job.Id, "error", err)
+		return
+	}
+
+	for _, hook := range r.postStop {
+		if err := r.run(ctx, hook, PostStop, job.Id, payload); err != nil {
+			r.logger.Warn("post-stop hook failed", "hook", hook.Name, "jobId", job.Id, "error", err)
+		}
+	}
+}
+
+func (r *Runner) run(ctx context.Context, hook config.HookConfig, event Event, jobID string, payload []byte) error {
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = defaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	if hook.URL != "" {
+		err = r.post(ctx, hook.URL, event, payload)
+	} else {
+		err = runCommand(ctx, hook.Command, event, jobID, payload)
+	}
+
+	r.logger.Debug("hook completed", "hook", hook.Name, "event", string(event), "jobId", jobID, "duration", time.Since(start), "failed", err != nil)
+	return err
+}
+
+func (r *Runner) post(ctx context.Context, url string, event Event, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Worker-Hook-Event", string(event))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("hook endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// runCommand runs the hook command on the host with the payload on stdin and
+// the event and job id in its environment
+func runCommand(ctx context.Context, command []string, event Event, jobID string, payload []byte) error {
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "WORKER_HOOK_EVENT="+string(event), "WORKER_HOOK_JOB_ID="+jobID)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("%w: %s", err, bytes.TrimSpace(output))
+		}
+		return err
+	}
+	return nil
+}
+
+// NewPayload describes the job for a hook
+func NewPayload(event Event, job *domain.Job) Payload {
+	payload := Payload{
+		Event: event,
+		Job: jobPayload{
+			ID:               job.Id,
+			Command:          job.Command,
+			Args:             job.Args,
+			Labels:           job.Labels,
+			GroupID:          job.GroupId,
+			Isolation:        string(job.Isolation),
+			QoS:              string(job.QoS),
+			Workspace:        job.Workspace,
+			CPULimitMillis:   job.Limits.CPUMillis,
+			MemoryLimitBytes: job.Limits.MemoryBytes,
+			IOLimitBPS:       job.Limits.IOBPS,
+			StartTime:        job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		},
+	}
+
+	if event == PostStop {
+		result := &resultPayload{
+			Status:   string(job.Status),
+			ExitCode: job.ExitCode,
+			Restarts: job.Restarts,
+		}
+		if job.EndTime != nil {
+			result.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
+		}
+		payload.Job.Result = result
+	}
+
+	return payload
+}
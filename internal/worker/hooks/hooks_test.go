@@ -0,0 +1,135 @@
+package hooks
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+)
+
+func testJob() *domain.Job {
+	end := time.Date(2025, 1, 2, 3, 4, 6, 0, time.UTC)
+	return &domain.Job{
+		Id:        "7",
+		Command:   "/bin/echo",
+		Args:      []string{"hi"},
+		Env:       map[string]string{"TOKEN": "secret"},
+		Labels:    map[string]string{"team": "infra"},
+		Isolation: domain.IsolationFull,
+		Status:    domain.StatusFailed,
+		ExitCode:  3,
+		StartTime: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		EndTime:   &end,
+	}
+}
+
+func TestNewWithoutHooks(t *testing.T) {
+	r := New(config.HooksConfig{})
+	if r != nil {
+		t.Fatal("expected a nil runner without hooks")
+	}
+	if err := r.PreStart(context.Background(), testJob()); err != nil {
+		t.Errorf("expected a nil runner to run nothing, got %v", err)
+	}
+	r.PostStop(context.Background(), testJob())
+}
+
+func TestHTTPHookReceivesPayload(t *testing.T) {
+	var got Payload
+	var event string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		event = req.Header.Get("X-Worker-Hook-Event")
+		if err := json.NewDecoder(req.Body).Decode(&got); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	r := New(config.HooksConfig{PostStop: []config.HookConfig{{Name: "notify", URL: server.URL}}})
+	r.PostStop(context.Background(), testJob())
+
+	if event != "post-stop" || got.Event != PostStop {
+		t.Errorf("unexpected event %q / %q", event, got.Event)
+	}
+	if got.Job.ID != "7" || got.Job.Labels["team"] != "infra" {
+		t.Errorf("unexpected job %+v", got.Job)
+	}
+	if got.Job.Result == nil || got.Job.Result.Status != "FAILED" || got.Job.Result.ExitCode != 3 {
+		t.Errorf("unexpected result %+v", got.Job.Result)
+	}
+}
+
+func TestPayloadOmitsEnvironment(t *testing.T) {
+	data, err := json.Marshal(NewPayload(PreStart, testJob()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "secret") {
+		t.Errorf("payload leaks the environment: %s", data)
+	}
+	if strings.Contains(string(data), "result") {
+		t.Errorf("pre-start payload has a result: %s", data)
+	}
+}
+
+func TestCommandHookReceivesPayload(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "payload.json")
+	r := New(config.HooksConfig{PreStart: []config.HookConfig{{
+		Name:    "record",
+		Command: []string{"/bin/sh", "-c", `cat > "$0"; echo "$WORKER_HOOK_EVENT $WORKER_HOOK_JOB_ID" >> "$0"`, out},
+	}}})
+
+	if err := r.PreStart(context.Background(), testJob()); err != nil {
+		t.Fatalf("PreStart failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"event":"pre-start"`) || !strings.HasSuffix(string(data), "pre-start 7\n") {
+		t.Errorf("unexpected hook input %q", data)
+	}
+}
+
+func TestRequiredPreStartHookRejectsJob(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	optional := New(config.HooksConfig{PreStart: []config.HookConfig{{Name: "warm", URL: server.URL}}})
+	if err := optional.PreStart(context.Background(), testJob()); err != nil {
+		t.Errorf("expected an optional hook failure to be ignored, got %v", err)
+	}
+
+	required := New(config.HooksConfig{PreStart: []config.HookConfig{{Name: "register", URL: server.URL, Required: true}}})
+	err := required.PreStart(context.Background(), testJob())
+	if err == nil || !strings.Contains(err.Error(), `"register"`) || !strings.Contains(err.Error(), "503") {
+		t.Errorf("expected the required hook to reject the job, got %v", err)
+	}
+}
+
+func TestHookTimeout(t *testing.T) {
+	r := New(config.HooksConfig{PreStart: []config.HookConfig{{
+		Name:     "slow",
+		Command:  []string{"/bin/sleep", "5"},
+		Timeout:  50 * time.Millisecond,
+		Required: true,
+	}}})
+
+	start := time.Now()
+	if err := r.PreStart(context.Background(), testJob()); err == nil {
+		t.Error("expected the hook to time out")
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Error("expected the timeout to stop the hook")
+	}
+}
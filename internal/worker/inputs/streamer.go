@@ -0,0 +1,246 @@
+package inputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/offload"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// A reader that keeps a pipe open after the end of an object lets the next
+// open for writing succeed right away, so fetches of an input are spaced out
+// by at least streamInterval, and by retryInterval after a failed one.
+const (
+	streamInterval = 100 * time.Millisecond
+	retryInterval  = time.Second
+)
+
+// Streamer serves job inputs as named pipes in the job workspace. Each time
+// the job opens an input, its object is fetched and written to the pipe as the
+// job reads it, so a job starts without waiting for its data to be staged.
+type Streamer struct {
+	reader       offload.ObjectReader
+	scheme       string
+	buckets      map[string]bool
+	maxInputs    int
+	fetchTimeout time.Duration
+	logger       *logger.Logger
+}
+
+// New creates a streamer from configuration. It returns nil when job inputs
+// are disabled; all Streamer methods are safe to call on nil.
+func New(cfg config.InputsConfig) (*Streamer, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	// no overall timeout: objects are read at the pace of the job
+	reader, err := offload.NewObjectReader(config.OffloadConfig{
+		Type:            cfg.Type,
+		Endpoint:        cfg.Endpoint,
+		Region:          cfg.Region,
+		AccessKeyID:     cfg.AccessKeyID,
+		SecretAccessKey: cfg.SecretAccessKey,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithReader(reader, cfg), nil
+}
+
+// NewWithReader creates a streamer for an already constructed object reader
+func NewWithReader(reader offload.ObjectReader, cfg config.InputsConfig) *Streamer {
+	s := &Streamer{
+		reader:       reader,
+		scheme:       "s3",
+		maxInputs:    cfg.MaxInputs,
+		fetchTimeout: cfg.FetchTimeout,
+		logger:       logger.WithField("component", "inputs"),
+	}
+	if cfg.Type == "gcs" {
+		s.scheme = "gs"
+	}
+	if len(cfg.Buckets) > 0 {
+		s.buckets = make(map[string]bool, len(cfg.Buckets))
+		for _, bucket := range cfg.Buckets {
+			s.buckets[bucket] = true
+		}
+	}
+
+	s.logger.Info("job inputs enabled", "backend", cfg.Type, "buckets", cfg.Buckets)
+	return s
+}
+
+// Check reports whether the worker can stream the given inputs
+func (s *Streamer) Check(inputs []domain.JobInput) error {
+	if len(inputs) == 0 {
+		return nil
+	}
+	if s == nil {
+		return fmt.Errorf("job inputs are not enabled on this worker")
+	}
+	if len(inputs) > s.maxInputs {
+		return fmt.Errorf("%d inputs exceed the worker's maximum of %d", len(inputs), s.maxInputs)
+	}
+
+	for _, input := range inputs {
+		object, err := domain.ParseInputSource(input.Source)
+		if err != nil {
+			return err
+		}
+		if object.Scheme != s.scheme {
+			return fmt.Errorf("input %s: this worker reads inputs from %s:// locations", input.Source, s.scheme)
+		}
+		if s.buckets != nil && !s.buckets[object.Bucket] {
+			return fmt.Errorf("input %s: bucket %s is not allowed", input.Source, object.Bucket)
+		}
+	}
+	return nil
+}
+
+// Serve creates a named pipe for each input under dir and streams its object
+// into the pipe every time the pipe is opened for reading. onError is called
+// when an object cannot be fetched. The returned function stops serving and
+// removes the pipes; it waits for transfers in progress to end.
+func (s *Streamer) Serve(jobID, dir string, inputs []domain.JobInput, onError func(domain.JobInput, error)) (func(), error) {
+	if s == nil || len(inputs) == 0 {
+		return func() {}, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	var pipes []string
+
+	stop := func() {
+		cancel()
+		// a pipe opened for reading releases a server blocked in opening it
+		// for writing; it sees ctx is done and returns
+		var readers []*os.File
+		for _, pipe := range pipes {
+			if reader, err := os.OpenFile(pipe, os.O_RDONLY|syscall.O_NONBLOCK, 0); err == nil {
+				readers = append(readers, reader)
+			}
+		}
+		wg.Wait()
+		for _, reader := range readers {
+			_ = reader.Close()
+		}
+		for _, pipe := range pipes {
+			_ = os.Remove(pipe)
+		}
+	}
+
+	for _, input := range inputs {
+		object, err := domain.ParseInputSource(input.Source)
+		if err != nil {
+			stop()
+			return nil, err
+		}
+
+		pipe := filepath.Join(dir, filepath.Clean(input.Path))
+		if err := os.MkdirAll(filepath.Dir(pipe), 0755); err != nil {
+			stop()
+			return nil, fmt.Errorf("input %s: %w", input.Path, err)
+		}
+		if err := syscall.Mkfifo(pipe, 0644); err != nil {
+			stop()
+			if errors.Is(err, syscall.EEXIST) {
+				return nil, fmt.Errorf("input %s: the path already exists in the workspace", input.Path)
+			}
+			return nil, fmt.Errorf("input %s: failed to create the pipe: %w", input.Path, err)
+		}
+		pipes = append(pipes, pipe)
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serve(ctx, jobID, pipe, input, object, onError)
+		}()
+	}
+	return stop, nil
+}
+
+// serve streams the object into the pipe each time the job opens it, until ctx is done
+func (s *Streamer) serve(ctx context.Context, jobID, pipe string, input domain.JobInput, object domain.InputObject, onError func(domain.JobInput, error)) {
+	log := s.logger.WithFields("jobID", jobID, "source", input.Source, "path", input.Path)
+
+	for {
+		// blocks until the job opens the pipe for reading
+		file, err := os.OpenFile(pipe, os.O_WRONLY, 0)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Warn("failed to open input pipe", "error", err)
+			}
+			return
+		}
+		if ctx.Err() != nil {
+			_ = file.Close()
+			return
+		}
+
+		start := time.Now()
+		n, err := s.stream(ctx, file, object)
+		_ = file.Close()
+		wait := streamInterval
+		switch {
+		case ctx.Err() != nil:
+			return
+		case err != nil:
+			log.Warn("failed to stream input", "bytes", n, "error", err)
+			if onError != nil {
+				onError(input, err)
+			}
+			wait = retryInterval
+		default:
+			log.Debug("input streamed", "bytes", n, "duration", time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// stream copies the object into the pipe. A job that closes the pipe before
+// the end of the object is not an error.
+func (s *Streamer) stream(ctx context.Context, file *os.File, object domain.InputObject) (int64, error) {
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the timeout only covers the store's response, the body is read as
+	// slowly as the job reads the pipe
+	timer := time.AfterFunc(s.fetchTimeout, cancel)
+	body, err := s.reader.Get(fetchCtx, object.Bucket, object.Key)
+	if !timer.Stop() && ctx.Err() == nil {
+		if err == nil {
+			_ = body.Close()
+		}
+		return 0, fmt.Errorf("no response within %v", s.fetchTimeout)
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	// a write to a job that keeps the pipe open without reading ends with ctx
+	release := context.AfterFunc(ctx, func() { _ = file.Close() })
+	defer release()
+
+	n, err := io.Copy(file, body)
+	if errors.Is(err, syscall.EPIPE) {
+		return n, nil
+	}
+	return n, err
+}
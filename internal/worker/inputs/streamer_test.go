@@ -0,0 +1,184 @@
+package inputs_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/inputs"
+	"worker/internal/worker/offload/offloadfakes"
+	"worker/pkg/config"
+)
+
+func testConfig() config.InputsConfig {
+	return config.InputsConfig{Enabled: true, Type: "s3", MaxInputs: 4, FetchTimeout: time.Second}
+}
+
+func TestCheck(t *testing.T) {
+	var disabled *inputs.Streamer
+	if err := disabled.Check(nil); err != nil {
+		t.Errorf("a job without inputs should pass on a worker without them: %v", err)
+	}
+	if err := disabled.Check([]domain.JobInput{{Source: "s3://data/a", Path: "a"}}); err == nil {
+		t.Error("expected inputs to be rejected when disabled")
+	}
+
+	cfg := testConfig()
+	cfg.Buckets = []string{"data"}
+	streamer := inputs.NewWithReader(&offloadfakes.FakeObjectReader{}, cfg)
+
+	if err := streamer.Check([]domain.JobInput{{Source: "s3://data/a", Path: "a"}}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	tests := map[string][]domain.JobInput{
+		"other bucket": {{Source: "s3://secrets/a", Path: "a"}},
+		"other scheme": {{Source: "gs://data/a", Path: "a"}},
+		"too many": {
+			{Source: "s3://data/a", Path: "a"}, {Source: "s3://data/b", Path: "b"}, {Source: "s3://data/c", Path: "c"},
+			{Source: "s3://data/d", Path: "d"}, {Source: "s3://data/e", Path: "e"},
+		},
+	}
+	for name, in := range tests {
+		if err := streamer.Check(in); err == nil {
+			t.Errorf("%s: expected the inputs to be rejected", name)
+		}
+	}
+}
+
+func TestServe_StreamsOnEveryOpen(t *testing.T) {
+	reader := &offloadfakes.FakeObjectReader{}
+	reader.GetStub = func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(bucket + "/" + key)), nil
+	}
+	streamer := inputs.NewWithReader(reader, testConfig())
+
+	dir := t.TempDir()
+	stop, err := streamer.Serve("1", dir, []domain.JobInput{{Source: "s3://data/sets/train.csv", Path: "in/train.csv"}}, nil)
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	pipe := filepath.Join(dir, "in", "train.csv")
+	for i := 0; i < 2; i++ {
+		data, err := os.ReadFile(pipe)
+		if err != nil || string(data) != "data/sets/train.csv" {
+			t.Errorf("read %d: got %q, %v", i, data, err)
+		}
+	}
+
+	stop()
+	if reader.GetCallCount() != 2 {
+		t.Errorf("expected the object to be fetched for each read, got %d fetches", reader.GetCallCount())
+	}
+	if _, err := os.Stat(pipe); !os.IsNotExist(err) {
+		t.Errorf("expected the pipe to be removed, got %v", err)
+	}
+}
+
+func TestServe_ReportsFetchErrors(t *testing.T) {
+	reader := &offloadfakes.FakeObjectReader{}
+	reader.GetReturns(nil, errors.New("access denied"))
+	streamer := inputs.NewWithReader(reader, testConfig())
+
+	var mutex sync.Mutex
+	var failed []string
+	dir := t.TempDir()
+	stop, err := streamer.Serve("1", dir, []domain.JobInput{{Source: "s3://data/a", Path: "a"}}, func(input domain.JobInput, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		failed = append(failed, input.Path+": "+err.Error())
+	})
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	// the job sees an empty file
+	if data, err := os.ReadFile(filepath.Join(dir, "a")); err != nil || len(data) != 0 {
+		t.Errorf("got %q, %v", data, err)
+	}
+	stop()
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	if len(failed) != 1 || failed[0] != "a: access denied" {
+		t.Errorf("unexpected errors reported: %v", failed)
+	}
+}
+
+func TestServe_StopsUnopenedPipes(t *testing.T) {
+	streamer := inputs.NewWithReader(&offloadfakes.FakeObjectReader{}, testConfig())
+
+	stop, err := streamer.Serve("1", t.TempDir(), []domain.JobInput{{Source: "s3://data/a", Path: "a"}}, nil)
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop did not return for a pipe the job never opened")
+	}
+}
+
+func TestServe_StopsBlockedTransfers(t *testing.T) {
+	reader := &offloadfakes.FakeObjectReader{}
+	reader.GetStub = func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+		return io.NopCloser(strings.NewReader(strings.Repeat("x", 1<<20))), nil
+	}
+	streamer := inputs.NewWithReader(reader, testConfig())
+
+	dir := t.TempDir()
+	stop, err := streamer.Serve("1", dir, []domain.JobInput{{Source: "s3://data/a", Path: "a"}}, nil)
+	if err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+
+	// the job opens the pipe but never reads it
+	file, err := os.Open(filepath.Join(dir, "a"))
+	if err != nil {
+		t.Fatalf("open failed: %v", err)
+	}
+	defer file.Close()
+	for reader.GetCallCount() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		stop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("stop did not return for a transfer blocked on the job")
+	}
+}
+
+func TestServe_RejectsExistingPath(t *testing.T) {
+	streamer := inputs.NewWithReader(&offloadfakes.FakeObjectReader{}, testConfig())
+
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "b"), []byte("staged"), 0644)
+	_, err := streamer.Serve("1", dir, []domain.JobInput{
+		{Source: "s3://data/a", Path: "a"},
+		{Source: "s3://data/b", Path: "b"},
+	}, nil)
+	if err == nil {
+		t.Fatal("expected an error for an input over a staged file")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "a")); !os.IsNotExist(err) {
+		t.Errorf("expected the pipes created before the error to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,300 @@
+package intake
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	pb "worker/api/gen"
+	auth2 "worker/internal/worker/auth"
+	"worker/internal/worker/nats"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// pullWait is how long a JetStream pull request waits for a submission
+	pullWait = 30 * time.Second
+
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// JetStream acknowledgements: +ACK removes the message, -NAK redelivers it and
+// +TERM stops redelivering it
+const (
+	ackAccept = "+ACK"
+	ackRetry  = "-NAK"
+	ackReject = "+TERM"
+)
+
+// Submitter starts the jobs of accepted submissions
+type Submitter interface {
+	RunJob(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error)
+}
+
+// Reply is sent to the reply subject of a core NATS submission
+type Reply struct {
+	JobID  string `json:"jobId,omitempty"`
+	Status string `json:"status,omitempty"`
+	Error  string `json:"error,omitempty"`
+	Code   string `json:"code,omitempty"` // gRPC status code name of the rejection
+}
+
+// Consumer takes job submissions from a message queue. A submission is a
+// RunJobReq in protobuf JSON and goes through the same checks as the RunJob
+// RPC, with the rights of the configured role: submitter by default, which
+// cannot run shell, unisolated or privileged jobs. It is acknowledged once the job
+// started: core NATS submissions get a Reply, JetStream messages are acked,
+// or left for redelivery when the worker failed to start the job.
+type Consumer struct {
+	cfg       config.IntakeConfig
+	submitter Submitter
+	logger    *logger.Logger // sampled, a flood of bad submissions logs many rejections
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	mutex sync.Mutex
+	conn  *nats.Conn
+}
+
+// New starts consuming submissions. It returns nil when intake is disabled.
+func New(cfg config.IntakeConfig, submitter Submitter) (*Consumer, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if cfg.Transport != "nats" && cfg.Transport != "jetstream" {
+		return nil, fmt.Errorf("unsupported intake transport: %s", cfg.Transport)
+	}
+
+	role := auth2.ClientRole(cfg.Role)
+	if role == "" {
+		role = auth2.SubmitterRole
+	}
+
+	ctx, cancel := context.WithCancel(auth2.WithRole(context.Background(), role))
+	c := &Consumer{
+		cfg:       cfg,
+		submitter: submitter,
+		logger:    logger.WithField("component", "intake").Sampled(5),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+	go c.run()
+
+	logger.WithField("component", "intake").Info("job intake enabled", "transport", cfg.Transport, "subject", cfg.Subject, "stream", cfg.Stream, "consumer", cfg.Consumer, "role", role)
+	return c, nil
+}
+
+// Close stops consuming and waits for the submission being handled
+func (c *Consumer) Close() {
+	if c == nil {
+		return
+	}
+
+	c.cancel()
+	c.mutex.Lock()
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+	c.mutex.Unlock()
+	<-c.done
+}
+
+// run keeps a connection up, reconnecting with backoff when it is lost
+func (c *Consumer) run() {
+	defer close(c.done)
+
+	delay := minReconnectDelay
+	for c.ctx.Err() == nil {
+		conn, err := nats.Dial(c.cfg.URL, c.cfg.Timeout)
+		if err == nil {
+			c.mutex.Lock()
+			c.conn = conn
+			c.mutex.Unlock()
+			if c.ctx.Err() != nil {
+				_ = conn.Close()
+				return
+			}
+
+			delay = minReconnectDelay
+			if c.cfg.Transport == "jetstream" {
+				err = c.pull(conn)
+			} else {
+				err = c.subscribe(conn)
+			}
+			_ = conn.Close()
+		}
+		if c.ctx.Err() != nil {
+			return
+		}
+
+		c.logger.Warn("intake connection failed, reconnecting", "error", err, "retryIn", delay)
+		select {
+		case <-time.After(delay):
+		case <-c.ctx.Done():
+			return
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// subscribe takes submissions published to the subject, sharing them with
+// the other workers of the queue group
+func (c *Consumer) subscribe(conn *nats.Conn) error {
+	sub, err := conn.Subscribe(c.cfg.Subject, c.cfg.QueueGroup)
+	if err != nil {
+		return err
+	}
+
+	for msg := range sub.C {
+		reply, _ := c.submit(msg.Data, time.Time{})
+		if msg.Reply == "" {
+			continue
+		}
+		body, err := json.Marshal(reply)
+		if err != nil {
+			return err
+		}
+		if err := conn.Publish(msg.Reply, body); err != nil {
+			return err
+		}
+	}
+	return conn.Err()
+}
+
+// pull fetches submissions one at a time from a durable JetStream consumer,
+// so a message is only taken off the stream by the worker that starts it
+func (c *Consumer) pull(conn *nats.Conn) error {
+	inbox := nats.NewInbox()
+	sub, err := conn.Subscribe(inbox, "")
+	if err != nil {
+		return err
+	}
+
+	next := "$JS.API.CONSUMER.MSG.NEXT." + c.cfg.Stream + "." + c.cfg.Consumer
+	request := []byte(`{"batch":1,"expires":` + strconv.FormatInt(pullWait.Nanoseconds(), 10) + `}`)
+
+	for {
+		if err := conn.PublishRequest(next, inbox, request); err != nil {
+			return err
+		}
+
+		var msg *nats.Msg
+		select {
+		case m, ok := <-sub.C:
+			if !ok {
+				return conn.Err()
+			}
+			msg = m
+		case <-time.After(pullWait + c.cfg.Timeout):
+			// the server ends each request with a status, a missing
+			// one means the connection stalled
+			return fmt.Errorf("no response to jetstream pull request within %v", pullWait+c.cfg.Timeout)
+		}
+
+		// 404 and 408 end a request that found no submission
+		if code := msg.Status(); code != 0 {
+			if code != 404 && code != 408 {
+				c.logger.Warn("jetstream pull request failed", "status", code, "stream", c.cfg.Stream, "consumer", c.cfg.Consumer)
+				select {
+				case <-time.After(minReconnectDelay):
+				case <-c.ctx.Done():
+					return nil
+				}
+			}
+			continue
+		}
+
+		// redelivered submissions keep the time they were first published
+		published, _ := publishedAt(msg.Reply)
+		_, err := c.submit(msg.Data, published)
+		if err := conn.Publish(msg.Reply, []byte(ack(err))); err != nil {
+			return err
+		}
+	}
+}
+
+// submit decodes a submission and starts its job. A submission without a
+// submit time gets published, when it is known, for its queue timeout.
+func (c *Consumer) submit(data []byte, published time.Time) (Reply, error) {
+	req := &pb.RunJobReq{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, req); err != nil {
+		c.logger.Warn("rejected malformed job submission", "error", err)
+		err = status.Errorf(codes.InvalidArgument, "invalid job submission: %v", err)
+		return rejection(err), err
+	}
+	if req.SubmitTime == "" && !published.IsZero() {
+		req.SubmitTime = published.UTC().Format(time.RFC3339)
+	}
+
+	res, err := c.submitter.RunJob(c.ctx, req)
+	if err != nil {
+		c.logger.Warn("job submission rejected", "command", req.Command, "error", err)
+		return rejection(err), err
+	}
+
+	c.logger.Info("job submission accepted", "jobId", res.Id, "command", req.Command)
+	return Reply{JobID: res.Id, Status: res.Status}, nil
+}
+
+// publishedAt returns when a JetStream message was stored, from the nanosecond
+// timestamp in its ack subject:
+// $JS.ACK.<stream>.<consumer>.<delivered>.<sseq>.<cseq>.<timestamp>.<pending>,
+// with a domain and account hash after ACK and a random token at the end on
+// newer servers
+func publishedAt(reply string) (time.Time, bool) {
+	tokens := strings.Split(reply, ".")
+	if len(tokens) < 9 || tokens[0] != "$JS" || tokens[1] != "ACK" {
+		return time.Time{}, false
+	}
+	pos := 7
+	if len(tokens) > 9 {
+		pos = 9
+	}
+	if len(tokens) <= pos+1 {
+		return time.Time{}, false
+	}
+	ns, err := strconv.ParseInt(tokens[pos], 10, 64)
+	if err != nil || ns <= 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, ns), true
+}
+
+func rejection(err error) Reply {
+	st := status.Convert(err)
+	return Reply{Error: st.Message(), Code: st.Code().String()}
+}
+
+// ack returns the JetStream acknowledgement of a submission. Failures of the
+// worker are retried, possibly by another worker; submissions the worker will
+// never accept are dropped. A refusal saying when to retry, such as a job held
+// by the schedule, is redelivered after that delay.
+func ack(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return fmt.Sprintf(`%s {"delay": %d}`, ackRetry, info.GetRetryDelay().AsDuration().Nanoseconds())
+		}
+	}
+
+	switch status.Code(err) {
+	case codes.OK:
+		return ackAccept
+	case codes.Internal, codes.Unavailable, codes.ResourceExhausted, codes.Aborted, codes.DeadlineExceeded, codes.Unknown:
+		return ackRetry
+	default:
+		return ackReject
+	}
+}
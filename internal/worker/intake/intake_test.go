@@ -0,0 +1,147 @@
+package intake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	auth2 "worker/internal/worker/auth"
+	"worker/pkg/logger"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+type submitterFunc func(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error)
+
+func (f submitterFunc) RunJob(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+	return f(ctx, req)
+}
+
+func newTestConsumer(submitter Submitter) *Consumer {
+	return &Consumer{
+		submitter: submitter,
+		logger:    logger.New(),
+		ctx:       auth2.WithRole(context.Background(), auth2.SubmitterRole),
+	}
+}
+
+func TestSubmitStartsJob(t *testing.T) {
+	var got *pb.RunJobReq
+	c := newTestConsumer(submitterFunc(func(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+		got = req
+		return &pb.RunJobRes{Id: "42", Status: "RUNNING"}, nil
+	}))
+
+	reply, err := c.submit([]byte(`{"command":"echo","args":["hi"],"maxMemory":64,"producer":"ignored"}`), time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.JobID != "42" || reply.Status != "RUNNING" || reply.Error != "" {
+		t.Errorf("unexpected reply %+v", reply)
+	}
+	if got.Command != "echo" || len(got.Args) != 1 || got.MaxMemory != 64 {
+		t.Errorf("unexpected request %v", got)
+	}
+	if ack(err) != ackAccept {
+		t.Errorf("expected an accepted submission to be acked, got %s", ack(err))
+	}
+}
+
+func TestSubmissionsRunWithoutShellOrWeakerIsolation(t *testing.T) {
+	authorization := auth2.NewGrpcAuthorization()
+	c := newTestConsumer(submitterFunc(func(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+		if err := authorization.Authorized(ctx, auth2.RunJobOp); err != nil {
+			t.Errorf("expected submissions to run jobs, got %v", err)
+		}
+		for _, op := range []auth2.Operation{auth2.RunShellJobOp, auth2.RunUnisolatedJobOp, auth2.RunPrivilegedJobOp} {
+			if err := authorization.Authorized(ctx, op); status.Code(err) != codes.PermissionDenied {
+				t.Errorf("expected submissions to be refused %s, got %v", op, err)
+			}
+		}
+		return &pb.RunJobRes{Id: "42", Status: "RUNNING"}, nil
+	}))
+
+	if _, err := c.submit([]byte(`{"command":"echo"}`), time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSubmitRejections(t *testing.T) {
+	c := newTestConsumer(submitterFunc(func(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+		return nil, status.Error(codes.PermissionDenied, "shell jobs are not allowed")
+	}))
+
+	reply, err := c.submit([]byte(`{"command":`), time.Time{})
+	if reply.Code != "InvalidArgument" || ack(err) != ackReject {
+		t.Errorf("expected a malformed submission to be rejected, got %+v", reply)
+	}
+
+	reply, err = c.submit([]byte(`{"command":"sh"}`), time.Time{})
+	if reply.Code != "PermissionDenied" || reply.Error != "shell jobs are not allowed" || ack(err) != ackReject {
+		t.Errorf("expected the refusal to be passed on, got %+v", reply)
+	}
+}
+
+func TestAckRetriesWorkerFailures(t *testing.T) {
+	for _, err := range []error{
+		status.Error(codes.Internal, "failed to start job"),
+		status.Error(codes.ResourceExhausted, "too many jobs"),
+		errors.New("unexpected"),
+	} {
+		if got := ack(err); got != ackRetry {
+			t.Errorf("expected %v to be redelivered, got %s", err, got)
+		}
+	}
+}
+
+func TestAckDelaysHeldJobs(t *testing.T) {
+	st, err := status.New(codes.FailedPrecondition, "job held (blackout nightly) until 06:00").
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(90 * time.Second)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ack(st.Err()); got != `-NAK {"delay": 90000000000}` {
+		t.Errorf("expected the held job to be redelivered after the delay, got %s", got)
+	}
+
+	if got := ack(status.Error(codes.FailedPrecondition, "job can never start")); got != ackReject {
+		t.Errorf("expected a job that never may start to be dropped, got %s", got)
+	}
+}
+
+func TestSubmitKeepsPublishTime(t *testing.T) {
+	var got *pb.RunJobReq
+	c := newTestConsumer(submitterFunc(func(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+		got = req
+		return &pb.RunJobRes{Id: "42", Status: "EXPIRED"}, nil
+	}))
+
+	published, ok := publishedAt("$JS.ACK.jobs.workers.3.17.12.1736157600000000000.0")
+	if !ok || !published.Equal(time.Date(2025, 1, 6, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected publish time %v, %v", published, ok)
+	}
+	if _, ok := publishedAt("$JS.ACK.hub.ACCHASH.jobs.workers.3.17.12.1736157600000000000.0.TOKEN"); !ok {
+		t.Error("expected the publish time in a subject with a domain")
+	}
+	if _, ok := publishedAt("_INBOX.abc"); ok {
+		t.Error("expected no publish time in a plain reply subject")
+	}
+
+	if _, err := c.submit([]byte(`{"command":"report","queueTimeoutSeconds":3600}`), published); err != nil {
+		t.Fatal(err)
+	}
+	if got.SubmitTime != "2025-01-06T10:00:00Z" {
+		t.Errorf("expected the publish time as submit time, got %q", got.SubmitTime)
+	}
+
+	if _, err := c.submit([]byte(`{"command":"report","submitTime":"2025-01-06T09:00:00Z"}`), published); err != nil {
+		t.Fatal(err)
+	}
+	if got.SubmitTime != "2025-01-06T09:00:00Z" {
+		t.Errorf("expected the producer's submit time to be kept, got %q", got.SubmitTime)
+	}
+}
@@ -0,0 +1,234 @@
+package janitor
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/workspace"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Jobs is where the janitor learns whether a job is still running
+type Jobs interface {
+	GetJob(id string) (*domain.Job, bool)
+}
+
+// Stats is the disk use the janitor last measured and what it removed since
+// the worker started
+type Stats struct {
+	UsedBytes      int64
+	BudgetBytes    int64
+	ReclaimedBytes int64
+	Evicted        int64 // jobs' files and log files removed
+	LastRun        time.Time
+}
+
+// candidate is something the janitor may remove: the files of a finished
+// job, or a rotated log file
+type candidate struct {
+	jobID    string // "" for a log file
+	path     string
+	bytes    int64
+	modified time.Time
+}
+
+// Janitor keeps what jobs leave on disk within a budget. Every interval it
+// adds up the job workspaces and captured output, the staged uploads and the
+// worker's log files; once that is over the budget it removes the files of
+// finished jobs and rotated logs, oldest first, until the use is down to the
+// low watermark. Running jobs, staged uploads and the current log file are
+// never removed, and neither are the files of jobs that finished within
+// minAge, which offloading and pipelines may still read.
+type Janitor struct {
+	workspaces   *workspace.Manager
+	jobs         Jobs
+	logFile      string
+	maxBytes     int64
+	lowWatermark int64
+	minAge       time.Duration
+	now          func() time.Time
+	logger       *logger.Logger
+
+	mutex sync.Mutex
+	stats Stats
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New starts the janitor. It returns nil when the janitor is disabled.
+// logFile is the worker's log file, "" when it does not log to a file.
+func New(cfg config.JanitorConfig, logFile string, workspaces *workspace.Manager, jobs Jobs) *Janitor {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	j := &Janitor{
+		workspaces:   workspaces,
+		jobs:         jobs,
+		logFile:      logFile,
+		maxBytes:     cfg.MaxBytes,
+		lowWatermark: cfg.MaxBytes * int64(cfg.LowWatermark) / 100,
+		minAge:       cfg.MinAge,
+		now:          time.Now,
+		logger:       logger.WithField("component", "janitor"),
+		stats:        Stats{BudgetBytes: cfg.MaxBytes},
+		stop:         make(chan struct{}),
+		done:         make(chan struct{}),
+	}
+	go j.run(cfg.Interval)
+
+	j.logger.Info("janitor enabled", "maxBytes", cfg.MaxBytes, "lowWatermark", j.lowWatermark, "interval", cfg.Interval)
+	return j
+}
+
+// Stats returns the latest measurements, nil when the janitor is disabled
+func (j *Janitor) Stats() *Stats {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	stats := j.stats
+	return &stats
+}
+
+// Close stops the janitor
+func (j *Janitor) Close() {
+	if j == nil {
+		return
+	}
+
+	close(j.stop)
+	<-j.done
+}
+
+func (j *Janitor) run(interval time.Duration) {
+	defer close(j.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		j.collect()
+
+		select {
+		case <-ticker.C:
+		case <-j.stop:
+			return
+		}
+	}
+}
+
+// collect makes one pass: it measures the disk use and, over budget, removes
+// the oldest candidates until the use is down to the low watermark
+func (j *Janitor) collect() {
+	used, candidates := j.measure()
+
+	var reclaimed, evicted int64
+	if used > j.maxBytes {
+		sort.Slice(candidates, func(a, b int) bool { return candidates[a].modified.Before(candidates[b].modified) })
+		for _, c := range candidates {
+			if used <= j.lowWatermark {
+				break
+			}
+			if err := j.remove(c); err != nil {
+				j.logger.Warn("failed to remove files", "jobId", c.jobID, "path", c.path, "error", err)
+				continue
+			}
+			used -= c.bytes
+			reclaimed += c.bytes
+			evicted++
+		}
+
+		if evicted > 0 {
+			j.logger.Info("disk budget exceeded, removed the oldest files",
+				"removed", evicted, "reclaimedBytes", reclaimed, "usedBytes", used, "maxBytes", j.maxBytes)
+		}
+		if used > j.maxBytes {
+			j.logger.Warn("disk use stays over budget, nothing left to remove", "usedBytes", used, "maxBytes", j.maxBytes)
+		}
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+	j.stats.UsedBytes = used
+	j.stats.ReclaimedBytes += reclaimed
+	j.stats.Evicted += evicted
+	j.stats.LastRun = j.now()
+}
+
+// measure adds up the disk use and lists what may be removed
+func (j *Janitor) measure() (int64, []candidate) {
+	cutoff := j.now().Add(-j.minAge)
+	var used int64
+	var candidates []candidate
+
+	artifacts, err := j.workspaces.Artifacts()
+	if err != nil {
+		j.logger.Warn("failed to measure workspaces", "error", err)
+	}
+	for _, a := range artifacts {
+		used += a.Bytes
+		job, known := j.jobs.GetJob(a.JobID)
+		if known && !job.IsCompleted() {
+			continue
+		}
+		if a.Modified.After(cutoff) || known && job.EndTime != nil && job.EndTime.After(cutoff) {
+			continue
+		}
+		candidates = append(candidates, candidate{jobID: a.JobID, bytes: a.Bytes, modified: a.Modified})
+	}
+
+	uploads, err := j.workspaces.UploadsUsage()
+	if err != nil {
+		j.logger.Warn("failed to measure staged uploads", "error", err)
+	}
+	used += uploads
+
+	if j.logFile != "" {
+		logs, backups := j.logFiles()
+		used += logs
+		candidates = append(candidates, backups...)
+	}
+
+	return used, candidates
+}
+
+// logFiles returns the size of the log file and its rotated backups, and the
+// backups as candidates
+func (j *Janitor) logFiles() (int64, []candidate) {
+	var used int64
+	if info, err := os.Stat(j.logFile); err == nil {
+		used += info.Size()
+	}
+
+	matches, _ := filepath.Glob(j.logFile + ".*")
+	var backups []candidate
+	for _, path := range matches {
+		if _, err := strconv.Atoi(strings.TrimPrefix(path, j.logFile+".")); err != nil {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		used += info.Size()
+		backups = append(backups, candidate{path: path, bytes: info.Size(), modified: info.ModTime()})
+	}
+	return used, backups
+}
+
+func (j *Janitor) remove(c candidate) error {
+	if c.jobID != "" {
+		return j.workspaces.Remove(c.jobID)
+	}
+	return os.Remove(c.path)
+}
@@ -0,0 +1,147 @@
+package janitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/workspace"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+type stubJobs map[string]*domain.Job
+
+func (s stubJobs) GetJob(id string) (*domain.Job, bool) {
+	job, ok := s[id]
+	return job, ok
+}
+
+// writeJob leaves a workspace of size bytes for a job, last changed at modified
+func writeJob(t *testing.T, m *workspace.Manager, id string, size int, modified time.Time) {
+	t.Helper()
+	dir, err := m.Create(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "data")
+	if err := os.WriteFile(file, make([]byte, size), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{file, dir} {
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func newJanitor(m *workspace.Manager, jobs Jobs, logFile string, maxBytes int64, now time.Time) *Janitor {
+	return &Janitor{
+		workspaces:   m,
+		jobs:         jobs,
+		logFile:      logFile,
+		maxBytes:     maxBytes,
+		lowWatermark: maxBytes / 2,
+		minAge:       10 * time.Minute,
+		now:          func() time.Time { return now },
+		logger:       logger.WithField("component", "janitor"),
+		stats:        Stats{BudgetBytes: maxBytes},
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+func TestCollectEvictsOldestFinishedJobs(t *testing.T) {
+	m := workspace.NewManager(config.WorkspaceConfig{BaseDir: t.TempDir()})
+	now := time.Now()
+	hourAgo := now.Add(-time.Hour)
+
+	writeJob(t, m, "1", 400, now.Add(-3*time.Hour)) // finished, oldest
+	writeJob(t, m, "2", 400, now.Add(-2*time.Hour)) // finished
+	writeJob(t, m, "3", 400, now.Add(-4*time.Hour)) // still running
+	writeJob(t, m, "4", 400, now.Add(-time.Minute)) // finished within minAge
+	writeJob(t, m, "5", 400, hourAgo)               // left by a previous run
+
+	jobs := stubJobs{
+		"1": {Id: "1", Status: domain.StatusCompleted, EndTime: &hourAgo},
+		"2": {Id: "2", Status: domain.StatusFailed, EndTime: &hourAgo},
+		"3": {Id: "3", Status: domain.StatusRunning},
+		"4": {Id: "4", Status: domain.StatusCompleted, EndTime: &now},
+	}
+
+	// 2000 bytes used, down to 500 would need everything removed
+	j := newJanitor(m, jobs, "", 1000, now)
+	j.collect()
+
+	for id, kept := range map[string]bool{"1": false, "2": false, "3": true, "4": true, "5": false} {
+		if exists(m.JobDir(id)) != kept {
+			t.Errorf("job %s: expected kept=%v", id, kept)
+		}
+	}
+
+	stats := j.Stats()
+	if stats.Evicted != 3 || stats.ReclaimedBytes != 1200 || stats.UsedBytes != 800 || stats.BudgetBytes != 1000 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+}
+
+func TestCollectStopsAtLowWatermark(t *testing.T) {
+	m := workspace.NewManager(config.WorkspaceConfig{BaseDir: t.TempDir()})
+	now := time.Now()
+	for i, id := range []string{"1", "2", "3"} {
+		writeJob(t, m, id, 300, now.Add(-time.Duration(3-i)*time.Hour))
+	}
+
+	// under budget nothing is removed
+	j := newJanitor(m, stubJobs{}, "", 1000, now)
+	j.collect()
+	if !exists(m.JobDir("1")) || j.Stats().UsedBytes != 900 {
+		t.Fatalf("expected nothing removed under budget, stats %+v", j.Stats())
+	}
+
+	// 900 over a budget of 800, down to 400 takes the two oldest
+	j = newJanitor(m, stubJobs{}, "", 800, now)
+	j.collect()
+	if exists(m.JobDir("1")) || exists(m.JobDir("2")) || !exists(m.JobDir("3")) {
+		t.Errorf("expected the two oldest jobs removed, stats %+v", j.Stats())
+	}
+}
+
+func TestCollectRemovesRotatedLogs(t *testing.T) {
+	m := workspace.NewManager(config.WorkspaceConfig{BaseDir: t.TempDir()})
+	logFile := filepath.Join(t.TempDir(), "worker.log")
+	now := time.Now()
+	for i, path := range []string{logFile, logFile + ".1", logFile + ".2", logFile + ".old"} {
+		if err := os.WriteFile(path, make([]byte, 300), 0644); err != nil {
+			t.Fatal(err)
+		}
+		modified := now.Add(-time.Duration(i) * time.Hour)
+		if err := os.Chtimes(path, modified, modified); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// 900 over a budget of 800, down to 700 takes the oldest backup
+	j := newJanitor(m, stubJobs{}, logFile, 800, now)
+	j.lowWatermark = 700
+	j.collect()
+
+	if !exists(logFile) || !exists(logFile+".1") || exists(logFile+".2") || !exists(logFile+".old") {
+		t.Errorf("expected only the oldest backup removed, stats %+v", j.Stats())
+	}
+}
+
+func TestDisabledJanitor(t *testing.T) {
+	j := New(config.JanitorConfig{}, "", nil, nil)
+	if j != nil {
+		t.Fatal("expected no janitor when disabled")
+	}
+	if j.Stats() != nil {
+		t.Error("expected no stats from a disabled janitor")
+	}
+	j.Close()
+}
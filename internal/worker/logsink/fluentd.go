@@ -0,0 +1,165 @@
+package logsink
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"sort"
+	"sync"
+	"time"
+	"worker/pkg/config"
+)
+
+// fluentdSink speaks the Fluentd forward protocol (Forward mode) over TCP
+type fluentdSink struct {
+	address string
+	tag     string
+	timeout time.Duration
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func newFluentdSink(cfg config.LogSinkConfig) Sink {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "worker.job"
+	}
+
+	return &fluentdSink{
+		address: cfg.Address,
+		tag:     tag,
+		timeout: cfg.Timeout,
+	}
+}
+
+func (f *fluentdSink) Name() string {
+	return "fluentd"
+}
+
+// Send encodes every output line as a Fluentd event and writes them in a single
+// forward message, reconnecting once if the connection was dropped
+func (f *fluentdSink) Send(entry *Entry) error {
+	lines := splitLines(entry.Data)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	msg := encodeForwardMessage(f.tag, entry.Timestamp, entry.Labels, lines)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var lastErr error
+	for attempt := 0; attempt < 2; attempt++ {
+		if f.conn == nil {
+			conn, err := net.DialTimeout("tcp", f.address, f.timeout)
+			if err != nil {
+				return fmt.Errorf("failed to connect to fluentd: %w", err)
+			}
+			f.conn = conn
+		}
+
+		_ = f.conn.SetWriteDeadline(time.Now().Add(f.timeout))
+		if _, err := f.conn.Write(msg); err != nil {
+			lastErr = err
+			_ = f.conn.Close()
+			f.conn = nil
+			continue
+		}
+		return nil
+	}
+
+	return fmt.Errorf("fluentd write failed: %w", lastErr)
+}
+
+func (f *fluentdSink) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.conn == nil {
+		return nil
+	}
+	err := f.conn.Close()
+	f.conn = nil
+	return err
+}
+
+// encodeForwardMessage builds [tag, [[time, record], ...]] in msgpack where each
+// record holds the line under "log" plus the entry labels
+func encodeForwardMessage(tag string, ts time.Time, labels map[string]string, lines []string) []byte {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	writeArrayHeader(&buf, 2)
+	writeString(&buf, tag)
+	writeArrayHeader(&buf, len(lines))
+
+	for _, line := range lines {
+		writeArrayHeader(&buf, 2)
+		writeUint32(&buf, uint32(ts.Unix()))
+		writeMapHeader(&buf, len(keys)+1)
+		writeString(&buf, "log")
+		writeString(&buf, line)
+		for _, k := range keys {
+			writeString(&buf, k)
+			writeString(&buf, labels[k])
+		}
+	}
+
+	return buf.Bytes()
+}
+
+func writeArrayHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x90 | byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xdc)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdd)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func writeMapHeader(buf *bytes.Buffer, n int) {
+	switch {
+	case n < 16:
+		buf.WriteByte(0x80 | byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xde)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdf)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	n := len(s)
+	switch {
+	case n < 32:
+		buf.WriteByte(0xa0 | byte(n))
+	case n <= 0xff:
+		buf.WriteByte(0xd9)
+		buf.WriteByte(byte(n))
+	case n <= 0xffff:
+		buf.WriteByte(0xda)
+		_ = binary.Write(buf, binary.BigEndian, uint16(n))
+	default:
+		buf.WriteByte(0xdb)
+		_ = binary.Write(buf, binary.BigEndian, uint32(n))
+	}
+	buf.WriteString(s)
+}
+
+func writeUint32(buf *bytes.Buffer, v uint32) {
+	buf.WriteByte(0xce)
+	_ = binary.Write(buf, binary.BigEndian, v)
+}
@@ -0,0 +1,37 @@
+package logsink
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEncodeForwardMessage(t *testing.T) {
+	ts := time.Unix(1700000000, 0)
+	msg := encodeForwardMessage("worker.job", ts, map[string]string{"job_id": "7"}, []string{"line one"})
+
+	expected := []byte{0x92} // [tag, entries]
+	expected = append(expected, 0xaa)
+	expected = append(expected, "worker.job"...)
+	expected = append(expected, 0x91, 0x92) // one entry: [time, record]
+	expected = append(expected, 0xce, 0x65, 0x53, 0xf1, 0x00)
+	expected = append(expected, 0x82) // record with 2 keys
+	expected = append(expected, 0xa3)
+	expected = append(expected, "log"...)
+	expected = append(expected, 0xa8)
+	expected = append(expected, "line one"...)
+	expected = append(expected, 0xa6)
+	expected = append(expected, "job_id"...)
+	expected = append(expected, 0xa1, '7')
+
+	if !bytes.Equal(msg, expected) {
+		t.Errorf("unexpected encoding\n got: %x\nwant: %x", msg, expected)
+	}
+}
+
+func TestSplitLines(t *testing.T) {
+	lines := splitLines([]byte("a\r\n\nb\n"))
+	if len(lines) != 2 || lines[0] != "a" || lines[1] != "b" {
+		t.Errorf("unexpected lines: %q", lines)
+	}
+}
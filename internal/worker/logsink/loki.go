@@ -0,0 +1,78 @@
+package logsink
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"worker/pkg/config"
+)
+
+const lokiPushPath = "/loki/api/v1/push"
+
+type lokiSink struct {
+	url    string
+	client *http.Client
+}
+
+func newLokiSink(cfg config.LogSinkConfig) Sink {
+	return &lokiSink{
+		url:    strings.TrimRight(cfg.Address, "/") + lokiPushPath,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+type lokiPushRequest struct {
+	Streams []lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (l *lokiSink) Name() string {
+	return "loki"
+}
+
+// Send pushes the entry lines as a single Loki stream labelled with the job metadata
+func (l *lokiSink) Send(entry *Entry) error {
+	lines := splitLines(entry.Data)
+	if len(lines) == 0 {
+		return nil
+	}
+
+	ts := strconv.FormatInt(entry.Timestamp.UnixNano(), 10)
+	values := make([][2]string, 0, len(lines))
+	for _, line := range lines {
+		values = append(values, [2]string{ts, line})
+	}
+
+	body, err := json.Marshal(lokiPushRequest{
+		Streams: []lokiStream{{Stream: entry.Labels, Values: values}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode loki push request: %w", err)
+	}
+
+	resp, err := l.client.Post(l.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("loki push failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("loki push returned status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func (l *lokiSink) Close() error {
+	l.client.CloseIdleConnections()
+	return nil
+}
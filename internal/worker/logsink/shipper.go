@@ -0,0 +1,149 @@
+package logsink
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Shipper fans job output out to the configured sinks from a background goroutine
+// so that slow or unreachable log systems never block the job's output path
+type Shipper struct {
+	sinks   []Sink
+	labels  map[string]string
+	queue   chan *Entry
+	dropped atomic.Uint64
+
+	closeOnce sync.Once
+	closeMux  sync.RWMutex // held to queue, so the queue is not closed under a sender
+	closed    bool
+	done      chan struct{}
+	logger    *logger.Logger
+}
+
+// NewShipper creates a shipper for the configured sinks. It returns nil when
+// log shipping is disabled; all Shipper methods are safe to call on nil.
+func NewShipper(cfg config.LogShippingConfig) (*Shipper, error) {
+	if !cfg.Enabled || len(cfg.Sinks) == 0 {
+		return nil, nil
+	}
+
+	sinks := make([]Sink, 0, len(cfg.Sinks))
+	for _, sinkCfg := range cfg.Sinks {
+		sink, err := NewSink(sinkCfg)
+		if err != nil {
+			for _, s := range sinks {
+				_ = s.Close()
+			}
+			return nil, fmt.Errorf("failed to create %s log sink: %w", sinkCfg.Type, err)
+		}
+		sinks = append(sinks, sink)
+	}
+
+	return NewShipperWithSinks(sinks, cfg.Labels, cfg.QueueSize), nil
+}
+
+// NewShipperWithSinks creates a shipper for already constructed sinks
+func NewShipperWithSinks(sinks []Sink, labels map[string]string, queueSize int) *Shipper {
+	if queueSize <= 0 {
+		queueSize = 1024
+	}
+
+	staticLabels := make(map[string]string, len(labels)+1)
+	if host, err := os.Hostname(); err == nil {
+		staticLabels["host"] = host
+	}
+	for k, v := range labels {
+		staticLabels[k] = v
+	}
+
+	s := &Shipper{
+		sinks:  sinks,
+		labels: staticLabels,
+		queue:  make(chan *Entry, queueSize),
+		done:   make(chan struct{}),
+		logger: logger.WithField("component", "log-shipper"),
+	}
+
+	go s.run()
+
+	s.logger.Info("log shipping enabled", "sinks", len(sinks), "queueSize", queueSize)
+	return s
+}
+
+// Ship queues a chunk of job output for forwarding. Job labels are merged over the
+// static labels from configuration. When the queue is full, or the shipper is
+// closed, the chunk is dropped.
+func (s *Shipper) Ship(jobID string, jobLabels map[string]string, data []byte) {
+	if s == nil || len(data) == 0 {
+		return
+	}
+
+	labels := make(map[string]string, len(s.labels)+len(jobLabels)+1)
+	for k, v := range s.labels {
+		labels[k] = v
+	}
+	for k, v := range jobLabels {
+		labels[k] = v
+	}
+	labels["job_id"] = jobID
+
+	entry := &Entry{
+		JobID:     jobID,
+		Timestamp: time.Now(),
+		Labels:    labels,
+		Data:      data,
+	}
+
+	s.closeMux.RLock()
+	defer s.closeMux.RUnlock()
+	if s.closed {
+		return
+	}
+
+	select {
+	case s.queue <- entry:
+	default:
+		if dropped := s.dropped.Add(1); dropped%100 == 1 {
+			s.logger.Warn("log shipping queue full, dropping output", "jobId", jobID, "totalDropped", dropped)
+		}
+	}
+}
+
+// Close drains the queue and closes all sinks. Output shipped afterwards is
+// dropped.
+func (s *Shipper) Close() {
+	if s == nil {
+		return
+	}
+
+	s.closeOnce.Do(func() {
+		s.closeMux.Lock()
+		s.closed = true
+		close(s.queue)
+		s.closeMux.Unlock()
+		<-s.done
+
+		for _, sink := range s.sinks {
+			if err := sink.Close(); err != nil {
+				s.logger.Warn("failed to close log sink", "sink", sink.Name(), "error", err)
+			}
+		}
+	})
+}
+
+func (s *Shipper) run() {
+	defer close(s.done)
+
+	for entry := range s.queue {
+		for _, sink := range s.sinks {
+			if err := sink.Send(entry); err != nil {
+				s.logger.Debug("failed to ship job output", "sink", sink.Name(), "jobId", entry.JobID, "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package logsink_test
+
+import (
+	"testing"
+	"worker/internal/worker/logsink"
+	"worker/internal/worker/logsink/logsinkfakes"
+)
+
+func TestShipper_ForwardsToAllSinks(t *testing.T) {
+	sinkA := &logsinkfakes.FakeSink{}
+	sinkB := &logsinkfakes.FakeSink{}
+
+	shipper := logsink.NewShipperWithSinks([]logsink.Sink{sinkA, sinkB}, map[string]string{"env": "test"}, 10)
+	shipper.Ship("42", map[string]string{"stream": "stdout"}, []byte("hello\n"))
+	shipper.Close()
+
+	for name, sink := range map[string]*logsinkfakes.FakeSink{"A": sinkA, "B": sinkB} {
+		if sink.SendCallCount() != 1 {
+			t.Fatalf("sink %s: expected 1 send, got %d", name, sink.SendCallCount())
+		}
+
+		entry := sink.SendArgsForCall(0)
+		if entry.JobID != "42" {
+			t.Errorf("sink %s: expected job ID 42, got %s", name, entry.JobID)
+		}
+		if entry.Labels["job_id"] != "42" || entry.Labels["env"] != "test" || entry.Labels["stream"] != "stdout" {
+			t.Errorf("sink %s: unexpected labels %v", name, entry.Labels)
+		}
+		if string(entry.Data) != "hello\n" {
+			t.Errorf("sink %s: unexpected data %q", name, entry.Data)
+		}
+		if sink.CloseCallCount() != 1 {
+			t.Errorf("sink %s: expected sink to be closed", name)
+		}
+	}
+}
+
+func TestShipper_NilIsNoop(t *testing.T) {
+	var shipper *logsink.Shipper
+
+	// must not panic
+	shipper.Ship("1", nil, []byte("data"))
+	shipper.Close()
+}
+
+func TestShipper_DropsOutputAfterClose(t *testing.T) {
+	sink := &logsinkfakes.FakeSink{}
+	shipper := logsink.NewShipperWithSinks([]logsink.Sink{sink}, nil, 10)
+	shipper.Close()
+
+	// jobs outlive the shipper, their output must not panic
+	shipper.Ship("1", nil, []byte("late\n"))
+	shipper.Close()
+
+	if sink.SendCallCount() != 0 || sink.CloseCallCount() != 1 {
+		t.Errorf("expected late output dropped and the sink closed once, got %d sends and %d closes", sink.SendCallCount(), sink.CloseCallCount())
+	}
+}
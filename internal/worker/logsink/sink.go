@@ -0,0 +1,45 @@
+package logsink
+
+import (
+	"fmt"
+	"time"
+	"worker/pkg/config"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Entry is a single chunk of job output together with the job metadata
+// that sinks attach as labels
+type Entry struct {
+	JobID     string
+	Timestamp time.Time
+	Labels    map[string]string
+	Data      []byte
+}
+
+// Sink forwards job output entries to an external log system
+//
+//counterfeiter:generate . Sink
+type Sink interface {
+	Name() string
+	Send(entry *Entry) error
+	Close() error
+}
+
+// NewSink creates a sink for the given configuration
+func NewSink(cfg config.LogSinkConfig) (Sink, error) {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 5 * time.Second
+	}
+
+	switch cfg.Type {
+	case "syslog":
+		return newSyslogSink(cfg)
+	case "loki":
+		return newLokiSink(cfg), nil
+	case "fluentd":
+		return newFluentdSink(cfg), nil
+	default:
+		return nil, fmt.Errorf("unsupported log sink type: %s", cfg.Type)
+	}
+}
@@ -0,0 +1,77 @@
+package logsink
+
+import (
+	"fmt"
+	"log/syslog"
+	"sort"
+	"strings"
+	"worker/pkg/config"
+)
+
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+func newSyslogSink(cfg config.LogSinkConfig) (Sink, error) {
+	tag := cfg.Tag
+	if tag == "" {
+		tag = "worker"
+	}
+
+	// an empty network/address connects to the local syslog daemon
+	w, err := syslog.Dial(cfg.Network, cfg.Address, syslog.LOG_INFO|syslog.LOG_USER, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+
+	return &syslogSink{writer: w}, nil
+}
+
+func (s *syslogSink) Name() string {
+	return "syslog"
+}
+
+// Send writes one syslog message per output line, prefixed with the job labels
+func (s *syslogSink) Send(entry *Entry) error {
+	prefix := formatLabels(entry.Labels)
+
+	for _, line := range splitLines(entry.Data) {
+		if err := s.writer.Info(prefix + " " + line); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
+
+// formatLabels renders labels as sorted key=value pairs so messages are stable
+func formatLabels(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, labels[k]))
+	}
+
+	return "[" + strings.Join(parts, " ") + "]"
+}
+
+// splitLines splits a chunk into non-empty lines
+func splitLines(data []byte) []string {
+	var lines []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimRight(line, "\r")
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// DomainToDiagnostics converts the checks of the worker's subsystems to
+// protobuf Diagnostics, ready when none of them failed
+func DomainToDiagnostics(d domain.Diagnostics) *pb.Diagnostics {
+	res := &pb.Diagnostics{Ready: d.Ready()}
+	for _, c := range d {
+		res.Checks = append(res.Checks, &pb.DiagnosticCheck{
+			Name:   c.Name,
+			Status: string(c.Status),
+			Detail: c.Detail,
+			Fix:    c.Fix,
+		})
+	}
+	return res
+}
@@ -0,0 +1,21 @@
+package mappers
+
+import (
+	"testing"
+	"worker/internal/worker/domain"
+)
+
+func TestDomainToDiagnostics(t *testing.T) {
+	var d domain.Diagnostics
+	d.Add("cgroup.base", domain.CheckOK, "job cgroups are created in /sys/fs/cgroup", "")
+	d.Add("disk", domain.CheckFail, "99% used", "Free space")
+
+	res := DomainToDiagnostics(d)
+
+	if res.Ready {
+		t.Error("expected not ready with a failed check")
+	}
+	if len(res.Checks) != 2 || res.Checks[1].Status != "FAIL" || res.Checks[1].Fix != "Free space" {
+		t.Errorf("unexpected checks %v", res.Checks)
+	}
+}
@@ -0,0 +1,37 @@
+package mappers
+
+import (
+	"fmt"
+	pb "worker/api/gen"
+	"worker/internal/worker/events"
+)
+
+// EventToProtobuf converts a bus event to the protobuf JobEvent sent to subscribers
+func EventToProtobuf(e events.Event) *pb.JobEvent {
+	event := &pb.JobEvent{
+		Type:      string(e.Type),
+		JobId:     e.JobID,
+		Timestamp: e.Time.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	if e.Job != nil {
+		event.Status = string(e.Job.Status)
+		event.CpuThrottledPercent = e.Job.CPUThrottled
+	}
+	if e.Err != nil {
+		event.Error = e.Err.Error()
+	}
+	return event
+}
+
+// EventTypesToDomain converts and validates the event types of a subscription
+func EventTypesToDomain(types []string) ([]events.Type, error) {
+	var result []events.Type
+	for _, t := range types {
+		eventType := events.Type(t)
+		if !eventType.Known() {
+			return nil, fmt.Errorf("unknown event type %q", t)
+		}
+		result = append(result, eventType)
+	}
+	return result, nil
+}
@@ -0,0 +1,55 @@
+package mappers
+
+import (
+	"errors"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+)
+
+func TestEventToProtobuf(t *testing.T) {
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	updated := EventToProtobuf(events.Event{
+		Type:  events.JobUpdated,
+		JobID: "7",
+		Job:   &domain.Job{Id: "7", Status: domain.StatusRunning},
+		Time:  at,
+	})
+	if updated.Type != "job.updated" || updated.JobId != "7" || updated.Status != "RUNNING" {
+		t.Errorf("unexpected event %+v", updated)
+	}
+	if updated.Timestamp != "2026-01-02T03:04:05Z" {
+		t.Errorf("unexpected timestamp %q", updated.Timestamp)
+	}
+
+	finished := EventToProtobuf(events.Event{
+		Type:  events.JobUpdated,
+		JobID: "7",
+		Job:   &domain.Job{Id: "7", Status: domain.StatusCompleted, CPUThrottled: 12.5},
+		Time:  at,
+	})
+	if finished.CpuThrottledPercent != 12.5 {
+		t.Errorf("throttling not mapped: %+v", finished)
+	}
+
+	cleaned := EventToProtobuf(events.Event{Type: events.JobCleanedUp, JobID: "7", Err: errors.New("busy"), Time: at})
+	if cleaned.Status != "" || cleaned.Error != "busy" {
+		t.Errorf("unexpected event %+v", cleaned)
+	}
+}
+
+func TestEventTypesToDomain(t *testing.T) {
+	types, err := EventTypesToDomain([]string{"job.created", "job.cleaned_up"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(types) != 2 || types[0] != events.JobCreated || types[1] != events.JobCleanedUp {
+		t.Errorf("unexpected types %v", types)
+	}
+
+	if _, err := EventTypesToDomain([]string{"job.exploded"}); err == nil {
+		t.Error("expected error for unknown event type")
+	}
+}
@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/utils"
+)
+
+// JobFilterToDomain converts a protobuf JobFilter to a validated domain JobFilter.
+// A nil filter converts to the empty filter.
+func JobFilterToDomain(f *pb.JobFilter) (domain.JobFilter, error) {
+	if f == nil {
+		return domain.JobFilter{}, nil
+	}
+
+	filter := domain.JobFilter{
+		Labels:    utils.CopyStringMap(f.Labels),
+		OlderThan: time.Duration(f.OlderThanSeconds) * time.Second,
+	}
+	for _, s := range f.Statuses {
+		filter.Statuses = append(filter.Statuses, domain.JobStatus(s))
+	}
+
+	return filter, filter.Validate()
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+func TestJobFilterToDomain(t *testing.T) {
+	filter, err := JobFilterToDomain(&pb.JobFilter{
+		Labels:           map[string]string{"team": "ci"},
+		Statuses:         []string{"FAILED"},
+		OlderThanSeconds: 3600,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if filter.Labels["team"] != "ci" || filter.OlderThan != time.Hour {
+		t.Errorf("unexpected filter %+v", filter)
+	}
+	if len(filter.Statuses) != 1 || filter.Statuses[0] != domain.StatusFailed {
+		t.Errorf("expected FAILED status, got %v", filter.Statuses)
+	}
+
+	if f, err := JobFilterToDomain(nil); err != nil || !f.IsEmpty() {
+		t.Errorf("expected empty filter for nil, got %+v, %v", f, err)
+	}
+	if _, err := JobFilterToDomain(&pb.JobFilter{Statuses: []string{"failed"}}); err == nil {
+		t.Error("expected error for unknown status")
+	}
+}
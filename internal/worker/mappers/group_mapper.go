@@ -0,0 +1,32 @@
+package mappers
+
+import (
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// DomainToJobGroupResponse converts a job group and the current state of its
+// jobs to protobuf JobGroup, aggregating the job statuses
+func DomainToJobGroupResponse(group *domain.JobGroup, jobs []*domain.Job) *pb.JobGroup {
+	counts := domain.CountJobs(jobs)
+
+	res := &pb.JobGroup{
+		Id:               group.Id,
+		Name:             group.Name,
+		Status:           string(counts.Status()),
+		CreatedAt:        group.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		Running:          counts.Running,
+		Succeeded:        counts.Succeeded,
+		Failed:           counts.Failed,
+		Stopped:          counts.Stopped,
+		CpuLimitMillis:   group.Limits.CPUMillis,
+		MemoryLimitBytes: group.Limits.MemoryBytes,
+		IoLimitBPS:       group.Limits.IOBPS,
+	}
+
+	for _, job := range jobs {
+		res.Jobs = append(res.Jobs, DomainToProtobuf(job))
+	}
+
+	return res
+}
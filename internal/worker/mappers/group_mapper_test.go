@@ -0,0 +1,30 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func TestDomainToJobGroupResponse(t *testing.T) {
+	group := &domain.JobGroup{Id: "1", Name: "web", JobIds: []string{"1", "2"}, Limits: domain.ResourceLimits{MemoryBytes: 1 << 30}, CreatedAt: time.Now()}
+	jobs := []*domain.Job{
+		{Id: "1", Command: "redis", GroupId: "1", Status: domain.StatusRunning},
+		{Id: "2", Command: "app", GroupId: "1", Status: domain.StatusFailed},
+	}
+
+	res := DomainToJobGroupResponse(group, jobs)
+
+	if res.Status != string(domain.GroupRunning) {
+		t.Errorf("expected RUNNING, got %s", res.Status)
+	}
+	if res.Running != 1 || res.Failed != 1 {
+		t.Errorf("unexpected counts running=%d failed=%d", res.Running, res.Failed)
+	}
+	if res.MemoryLimitBytes != 1<<30 || res.CpuLimitMillis != 0 {
+		t.Errorf("unexpected group limits cpu=%d memory=%d", res.CpuLimitMillis, res.MemoryLimitBytes)
+	}
+	if len(res.Jobs) != 2 || res.Jobs[1].GroupId != "1" {
+		t.Errorf("expected jobs with group ID, got %v", res.Jobs)
+	}
+}
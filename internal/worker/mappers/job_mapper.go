@@ -1,25 +1,217 @@
 package mappers
 
 import (
+	"fmt"
+	"time"
 	pb "worker/api/gen"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/utils"
 )
 
+// RunJobRequestToSpec converts a RunJobReq to a domain JobSpec. Variables from the
+// env file are applied first so that explicit env entries take precedence.
+// Secret references are copied as-is and resolved by the caller.
+func RunJobRequestToSpec(req *pb.RunJobReq) (*domain.JobSpec, error) {
+	spec := &domain.JobSpec{
+		Command:   req.Command,
+		Args:      req.Args,
+		Limits:    requestLimits(req),
+		SecretEnv: utils.CopyStringMap(req.SecretEnv),
+		UploadID:  req.UploadId,
+		Labels:    utils.CopyStringMap(req.Labels),
+		Stdin:     req.Stdin,
+		Shell:     req.Shell,
+		Profile:   req.Profile,
+		Runtime:   req.Runtime,
+		CleanEnv:  req.InheritEnv != nil && !*req.InheritEnv,
+	}
+
+	if spec.Limits.CPUMillis < 0 || spec.Limits.MemoryBytes < 0 || spec.Limits.IOBPS < 0 {
+		return nil, fmt.Errorf("invalid resource limits: %+v", spec.Limits)
+	}
+
+	mode, err := domain.ParseRestartMode(req.RestartPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	spec.Isolation, err = domain.ParseIsolationMode(req.Isolation)
+	if err != nil {
+		return nil, err
+	}
+	spec.QoS, err = domain.ParseQoSClass(req.Qos)
+	if err != nil {
+		return nil, err
+	}
+	spec.Seccomp, err = domain.ParseSeccompMode(req.Seccomp)
+	if err != nil {
+		return nil, err
+	}
+	spec.TimeOffset, err = domain.TimeOffsetFromSeconds(req.TimeOffsetSeconds)
+	if err != nil {
+		return nil, err
+	}
+	spec.CommandDigest, err = domain.ParseCommandDigest(req.CommandSha256)
+	if err != nil {
+		return nil, err
+	}
+	// init runs a pinned command from the copy it verified, which a runtime
+	// resolving the command in its own process cannot see
+	if spec.CommandDigest != "" && spec.Runtime != "" {
+		return nil, fmt.Errorf("a pinned command cannot run through a sandbox runtime")
+	}
+	spec.Idle, err = domain.ParseIdlePolicy(req.MaxIdleSeconds, req.IdleAction)
+	if err != nil {
+		return nil, err
+	}
+	spec.OutputFlush, err = domain.OutputFlushFromMillis(req.OutputFlushMillis)
+	if err != nil {
+		return nil, err
+	}
+	spec.OutputLimit, err = domain.ParseOutputLimit(req.OutputBytesPerSecond, req.OutputLimitAction)
+	if err != nil {
+		return nil, err
+	}
+	if req.MaxRestarts < 0 {
+		return nil, fmt.Errorf("invalid max restarts: %d", req.MaxRestarts)
+	}
+	spec.Restart = domain.RestartPolicy{Mode: mode, MaxRestarts: req.MaxRestarts}
+
+	for _, name := range req.RequiredCapabilities {
+		spec.Capabilities = append(spec.Capabilities, domain.Capability(name))
+	}
+
+	for _, input := range req.Inputs {
+		spec.Inputs = append(spec.Inputs, domain.JobInput{Source: input.Source, Path: input.Path})
+	}
+	if err := domain.ValidateJobInputs(spec.Inputs); err != nil {
+		return nil, err
+	}
+
+	for _, socket := range req.Sockets {
+		spec.Sockets = append(spec.Sockets, domain.JobSocket{Name: socket.Name, Address: socket.Address})
+	}
+	if err := domain.ValidateJobSockets(spec.Sockets); err != nil {
+		return nil, err
+	}
+
+	// windows without a zone are in the worker's local time
+	for _, text := range req.AllowedWindows {
+		window, err := domain.ParseTimeWindow(text, time.Local)
+		if err != nil {
+			return nil, err
+		}
+		spec.AllowedWindows = append(spec.AllowedWindows, window)
+	}
+
+	if req.QueueTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("invalid queue timeout: %ds", req.QueueTimeoutSeconds)
+	}
+	spec.QueueTimeout = time.Duration(req.QueueTimeoutSeconds) * time.Second
+	if req.SubmitTime != "" {
+		if spec.Submitted, err = time.Parse(time.RFC3339, req.SubmitTime); err != nil {
+			return nil, fmt.Errorf("invalid submit time %q, expected RFC 3339", req.SubmitTime)
+		}
+	}
+
+	if req.HealthProbe != nil {
+		probe := HealthProbeToDomain(req.HealthProbe)
+		if err := probe.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid health probe: %w", err)
+		}
+		spec.Probe = probe
+	}
+
+	if len(req.EnvFile) == 0 && len(req.Env) == 0 {
+		return spec, nil
+	}
+
+	env := make(map[string]string, len(req.Env))
+	if len(req.EnvFile) > 0 {
+		fileEnv, err := utils.ParseEnvFile(req.EnvFile)
+		if err != nil {
+			return nil, fmt.Errorf("invalid env file: %w", err)
+		}
+		for k, v := range fileEnv {
+			env[k] = v
+		}
+	}
+	for k, v := range req.Env {
+		env[k] = v
+	}
+	for k := range spec.SecretEnv {
+		if _, exists := env[k]; exists {
+			return nil, fmt.Errorf("variable %s is set both as env and secret env", k)
+		}
+	}
+	spec.Env = env
+
+	return spec, nil
+}
+
+// requestLimits reads the int64 limit fields, falling back to the deprecated
+// int32 ones for clients that do not set them
+func requestLimits(req *pb.RunJobReq) domain.ResourceLimits {
+	limits := domain.ResourceLimits{
+		CPUMillis:   req.CpuLimitMillis,
+		MemoryBytes: req.MemoryLimitBytes,
+		IOBPS:       req.IoLimitBPS,
+	}
+
+	legacy := domain.LegacyLimits(req.MaxCPU, req.MaxMemory, req.MaxIOBPS)
+	if limits.CPUMillis == 0 {
+		limits.CPUMillis = legacy.CPUMillis
+	}
+	if limits.MemoryBytes == 0 {
+		limits.MemoryBytes = legacy.MemoryBytes
+	}
+	if limits.IOBPS == 0 {
+		limits.IOBPS = legacy.IOBPS
+	}
+	return limits
+}
+
 // DomainToProtobuf converts domain Job to protobuf Job
 func DomainToProtobuf(job *domain.Job) *pb.Job {
 	pbJob := &pb.Job{
-		Id:        job.Id,
-		Command:   job.Command,
-		Args:      job.Args,
-		MaxCPU:    job.Limits.MaxCPU,
-		MaxMemory: job.Limits.MaxMemory,
-		MaxIOBPS:  job.Limits.MaxIOBPS,
-		Status:    string(job.Status),
-		StartTime: job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
-		ExitCode:  job.ExitCode,
+		Id:                  job.Id,
+		Command:             job.Command,
+		Args:                job.Args,
+		CpuLimitMillis:      job.Limits.CPUMillis,
+		MemoryLimitBytes:    job.Limits.MemoryBytes,
+		IoLimitBPS:          job.Limits.IOBPS,
+		Status:              string(job.Status),
+		StartTime:           job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		ExitCode:            job.ExitCode,
+		Env:                 job.Env,
+		SecretEnv:           job.SecretEnv,
+		OutputLocation:      job.OutputLocation,
+		WorkspaceBytes:      job.WorkspaceBytes,
+		CpuThrottledPercent: job.CPUThrottled,
+		RestartPolicy:       string(job.Restart.Mode),
+		MaxRestarts:         job.Restart.MaxRestarts,
+		Restarts:            job.Restarts,
+		HealthProbe:         HealthProbeToProtobuf(job.Probe),
+		Health:              string(job.Health),
+		GroupId:             job.GroupId,
+		Labels:              job.Labels,
+		Isolation:           string(job.Isolation),
+		Qos:                 string(job.QoS),
+		Seccomp:             string(job.Seccomp),
+		Runtime:             job.Runtime,
+		TimeOffsetSeconds:   int64(job.TimeOffset / time.Second),
+		CoreDump:            job.CoreDump,
+		CoreDumpBytes:       job.CoreDumpBytes,
+		LaunchMicros:        job.LaunchTime.Microseconds(),
+		CleanupMicros:       job.CleanupTime.Microseconds(),
+		Idle:                job.Idle,
+		OutputDroppedBytes:  job.OutputDropped,
+		StatusReason:        job.StatusReason,
 		// Removed network fields
 	}
 
+	pbJob.MaxCPU, pbJob.MaxMemory, pbJob.MaxIOBPS = job.Limits.Legacy()
+
 	if job.EndTime != nil {
 		pbJob.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
 	}
@@ -30,18 +222,34 @@ func DomainToProtobuf(job *domain.Job) *pb.Job {
 // DomainToRunJobResponse converts domain Job to RunJobRes
 func DomainToRunJobResponse(job *domain.Job) *pb.RunJobRes {
 	response := &pb.RunJobRes{
-		Id:        job.Id,
-		Command:   job.Command,
-		Args:      job.Args,
-		MaxCPU:    job.Limits.MaxCPU,
-		MaxMemory: job.Limits.MaxMemory,
-		MaxIOBPS:  job.Limits.MaxIOBPS,
-		Status:    string(job.Status),
-		StartTime: job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
-		ExitCode:  job.ExitCode,
+		Id:               job.Id,
+		Command:          job.Command,
+		Args:             job.Args,
+		CpuLimitMillis:   job.Limits.CPUMillis,
+		MemoryLimitBytes: job.Limits.MemoryBytes,
+		IoLimitBPS:       job.Limits.IOBPS,
+		Status:           string(job.Status),
+		StartTime:        job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		ExitCode:         job.ExitCode,
+		Env:              job.Env,
+		SecretEnv:        job.SecretEnv,
+		OutputLocation:   job.OutputLocation,
+		WorkspaceBytes:   job.WorkspaceBytes,
+		RestartPolicy:    string(job.Restart.Mode),
+		MaxRestarts:      job.Restart.MaxRestarts,
+		Restarts:         job.Restarts,
+		HealthProbe:      HealthProbeToProtobuf(job.Probe),
+		Health:           string(job.Health),
+		GroupId:          job.GroupId,
+		Labels:           job.Labels,
+		Isolation:        string(job.Isolation),
+		Qos:              string(job.QoS),
+		Runtime:          job.Runtime,
 		// Removed network fields
 	}
 
+	response.MaxCPU, response.MaxMemory, response.MaxIOBPS = job.Limits.Legacy()
+
 	if job.EndTime != nil {
 		response.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
 	}
@@ -52,18 +260,49 @@ func DomainToRunJobResponse(job *domain.Job) *pb.RunJobRes {
 // DomainToGetJobStatusResponse converts domain Job to GetJobStatusRes
 func DomainToGetJobStatusResponse(job *domain.Job) *pb.GetJobStatusRes {
 	response := &pb.GetJobStatusRes{
-		Id:        job.Id,
-		Command:   job.Command,
-		Args:      job.Args,
-		MaxCPU:    job.Limits.MaxCPU,
-		MaxMemory: job.Limits.MaxMemory,
-		MaxIOBPS:  job.Limits.MaxIOBPS,
-		Status:    string(job.Status),
-		StartTime: job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
-		ExitCode:  job.ExitCode,
+		Id:                  job.Id,
+		Command:             job.Command,
+		Args:                job.Args,
+		CpuLimitMillis:      job.Limits.CPUMillis,
+		MemoryLimitBytes:    job.Limits.MemoryBytes,
+		IoLimitBPS:          job.Limits.IOBPS,
+		Status:              string(job.Status),
+		StartTime:           job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		ExitCode:            job.ExitCode,
+		Env:                 job.Env,
+		SecretEnv:           job.SecretEnv,
+		OutputLocation:      job.OutputLocation,
+		WorkspaceBytes:      job.WorkspaceBytes,
+		CpuThrottledPercent: job.CPUThrottled,
+		RestartPolicy:       string(job.Restart.Mode),
+		MaxRestarts:         job.Restart.MaxRestarts,
+		Restarts:            job.Restarts,
+		HealthProbe:         HealthProbeToProtobuf(job.Probe),
+		Health:              string(job.Health),
+		GroupId:             job.GroupId,
+		Labels:              job.Labels,
+		Isolation:           string(job.Isolation),
+		Qos:                 string(job.QoS),
+		Seccomp:             string(job.Seccomp),
+		Runtime:             job.Runtime,
+		TimeOffsetSeconds:   int64(job.TimeOffset / time.Second),
+		CoreDump:            job.CoreDump,
+		CoreDumpBytes:       job.CoreDumpBytes,
+		LaunchMicros:        job.LaunchTime.Microseconds(),
+		CleanupMicros:       job.CleanupTime.Microseconds(),
+		Idle:                job.Idle,
+		OutputDroppedBytes:  job.OutputDropped,
+		Result:              string(job.Result),
+		ResultError:         job.ResultError,
+		StatusReason:        job.StatusReason,
 		// Removed network fields
 	}
 
+	response.MaxCPU, response.MaxMemory, response.MaxIOBPS = job.Limits.Legacy()
+	if job.Spec != nil {
+		response.Sockets = JobSocketsToProtobuf(job.Spec.Sockets)
+	}
+
 	if job.EndTime != nil {
 		response.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
 	}
@@ -71,12 +310,22 @@ func DomainToGetJobStatusResponse(job *domain.Job) *pb.GetJobStatusRes {
 	return response
 }
 
-// DomainToStopJobResponse converts domain Job to StopJobRes
-func DomainToStopJobResponse(job *domain.Job) *pb.StopJobRes {
+// JobSocketsToProtobuf converts a job's sockets, nil for none
+func JobSocketsToProtobuf(sockets []domain.JobSocket) []*pb.JobSocket {
+	var out []*pb.JobSocket
+	for _, socket := range sockets {
+		out = append(out, &pb.JobSocket{Name: socket.Name, Address: socket.Address})
+	}
+	return out
+}
+
+// DomainToStopJobResponse converts domain Job and how it was stopped to StopJobRes
+func DomainToStopJobResponse(job *domain.Job, result *domain.StopResult) *pb.StopJobRes {
 	response := &pb.StopJobRes{
 		Id:       job.Id,
 		Status:   string(job.Status),
 		ExitCode: job.ExitCode,
+		Method:   result.Method,
 	}
 
 	if job.EndTime != nil {
@@ -85,3 +334,65 @@ func DomainToStopJobResponse(job *domain.Job) *pb.StopJobRes {
 
 	return response
 }
+
+// HealthProbeToDomain converts a protobuf HealthProbe, leaving unset timings at zero
+func HealthProbeToDomain(probe *pb.HealthProbe) *domain.HealthProbe {
+	return &domain.HealthProbe{
+		Type:             domain.ProbeType(probe.Type),
+		Command:          append([]string(nil), probe.Command...),
+		Port:             probe.Port,
+		Path:             probe.Path,
+		Interval:         time.Duration(probe.IntervalSeconds) * time.Second,
+		Timeout:          time.Duration(probe.TimeoutSeconds) * time.Second,
+		InitialDelay:     time.Duration(probe.InitialDelaySeconds) * time.Second,
+		FailureThreshold: probe.FailureThreshold,
+	}
+}
+
+// HealthProbeToProtobuf converts a domain HealthProbe, nil stays nil
+func HealthProbeToProtobuf(probe *domain.HealthProbe) *pb.HealthProbe {
+	if probe == nil {
+		return nil
+	}
+	return &pb.HealthProbe{
+		Type:                string(probe.Type),
+		Command:             probe.Command,
+		Port:                probe.Port,
+		Path:                probe.Path,
+		IntervalSeconds:     int32(probe.Interval / time.Second),
+		TimeoutSeconds:      int32(probe.Timeout / time.Second),
+		InitialDelaySeconds: int32(probe.InitialDelay / time.Second),
+		FailureThreshold:    probe.FailureThreshold,
+	}
+}
+
+// DomainToValidateJobResponse converts a domain JobValidation to protobuf ValidateJobRes
+func DomainToValidateJobResponse(v *domain.JobValidation) *pb.ValidateJobRes {
+	res := &pb.ValidateJobRes{
+		Valid:            v.Valid(),
+		ResolvedCommand:  v.ResolvedCommand,
+		CpuLimitMillis:   v.Limits.CPUMillis,
+		MemoryLimitBytes: v.Limits.MemoryBytes,
+		IoLimitBPS:       v.Limits.IOBPS,
+	}
+	res.MaxCPU, res.MaxMemory, res.MaxIOBPS = v.Limits.Legacy()
+
+	for _, e := range v.Errors {
+		res.Errors = append(res.Errors, &pb.ValidationError{Field: e.Field, Message: e.Message})
+	}
+
+	return res
+}
+
+// DomainToJobExit converts the final state of a domain Job to protobuf JobExit
+func DomainToJobExit(job *domain.Job) *pb.JobExit {
+	exit := &pb.JobExit{
+		Status:   string(job.Status),
+		ExitCode: job.ExitCode,
+		Restarts: job.Restarts,
+	}
+	if job.EndTime != nil {
+		exit.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return exit
+}
@@ -1,8 +1,11 @@
 package mappers
 
 import (
+	"math"
+	"strings"
 	"testing"
 	"time"
+	pb "worker/api/gen"
 	"worker/internal/worker/domain"
 )
 
@@ -15,9 +18,9 @@ func TestDomainToProtobuf(t *testing.T) {
 		Command: "echo",
 		Args:    []string{"hello", "world"},
 		Limits: domain.ResourceLimits{
-			MaxCPU:    100,
-			MaxMemory: 512,
-			MaxIOBPS:  1000,
+			CPUMillis:   1000,
+			MemoryBytes: 512 << 20,
+			IOBPS:       1000,
 		},
 		Status:    domain.StatusCompleted,
 		StartTime: startTime,
@@ -42,14 +45,17 @@ func TestDomainToProtobuf(t *testing.T) {
 			t.Errorf("Expected arg[%d] %v, got %v", i, arg, pbJob.Args[i])
 		}
 	}
-	if pbJob.MaxCPU != job.Limits.MaxCPU {
-		t.Errorf("Expected MaxCPU %v, got %v", job.Limits.MaxCPU, pbJob.MaxCPU)
+	if pbJob.CpuLimitMillis != job.Limits.CPUMillis {
+		t.Errorf("Expected CpuLimitMillis %v, got %v", job.Limits.CPUMillis, pbJob.CpuLimitMillis)
 	}
-	if pbJob.MaxMemory != job.Limits.MaxMemory {
-		t.Errorf("Expected MaxMemory %v, got %v", job.Limits.MaxMemory, pbJob.MaxMemory)
+	if pbJob.MemoryLimitBytes != job.Limits.MemoryBytes {
+		t.Errorf("Expected MemoryLimitBytes %v, got %v", job.Limits.MemoryBytes, pbJob.MemoryLimitBytes)
 	}
-	if pbJob.MaxIOBPS != job.Limits.MaxIOBPS {
-		t.Errorf("Expected MaxIOBPS %v, got %v", job.Limits.MaxIOBPS, pbJob.MaxIOBPS)
+	if pbJob.IoLimitBPS != job.Limits.IOBPS {
+		t.Errorf("Expected IoLimitBPS %v, got %v", job.Limits.IOBPS, pbJob.IoLimitBPS)
+	}
+	if pbJob.MaxCPU != 100 || pbJob.MaxMemory != 512 || pbJob.MaxIOBPS != 1000 {
+		t.Errorf("Expected legacy limits 100/512/1000, got %v/%v/%v", pbJob.MaxCPU, pbJob.MaxMemory, pbJob.MaxIOBPS)
 	}
 	if pbJob.Status != string(job.Status) {
 		t.Errorf("Expected status %v, got %v", string(job.Status), pbJob.Status)
@@ -109,9 +115,9 @@ func TestDomainToRunJobResponse(t *testing.T) {
 		Command: "echo",
 		Args:    []string{"test"},
 		Limits: domain.ResourceLimits{
-			MaxCPU:    50,
-			MaxMemory: 256,
-			MaxIOBPS:  500,
+			CPUMillis:   500,
+			MemoryBytes: 256 << 20,
+			IOBPS:       500,
 		},
 		Status:    domain.StatusRunning,
 		StartTime: time.Now(),
@@ -170,7 +176,7 @@ func TestDomainToStopJobResponse(t *testing.T) {
 		ExitCode: -1,
 	}
 
-	response := DomainToStopJobResponse(job)
+	response := DomainToStopJobResponse(job, &domain.StopResult{Method: domain.StopForced, ExitCode: -1})
 
 	// Verify it's a proper StopJobRes
 	if response.Id != job.Id {
@@ -185,6 +191,9 @@ func TestDomainToStopJobResponse(t *testing.T) {
 	if response.EndTime == "" {
 		t.Error("Expected end time to be set for stopped job")
 	}
+	if response.Method != domain.StopForced {
+		t.Errorf("Expected method %v, got %v", domain.StopForced, response.Method)
+	}
 }
 
 func TestDomainToStopJobResponse_NoEndTime(t *testing.T) {
@@ -195,7 +204,7 @@ func TestDomainToStopJobResponse_NoEndTime(t *testing.T) {
 		ExitCode: -1,
 	}
 
-	response := DomainToStopJobResponse(job)
+	response := DomainToStopJobResponse(job, &domain.StopResult{Method: domain.StopGraceful})
 
 	if response.EndTime != "" {
 		t.Errorf("Expected empty end time, got %v", response.EndTime)
@@ -224,7 +233,7 @@ func TestStatusMapping(t *testing.T) {
 		pbJob := DomainToProtobuf(job)
 		runJobRes := DomainToRunJobResponse(job)
 		statusRes := DomainToGetJobStatusResponse(job)
-		stopRes := DomainToStopJobResponse(job)
+		stopRes := DomainToStopJobResponse(job, &domain.StopResult{})
 
 		expectedStatus := string(status)
 
@@ -252,25 +261,25 @@ func TestResourceLimitsMapping(t *testing.T) {
 		{
 			name: "zero limits",
 			limits: domain.ResourceLimits{
-				MaxCPU:    0,
-				MaxMemory: 0,
-				MaxIOBPS:  0,
+				CPUMillis:   0,
+				MemoryBytes: 0,
+				IOBPS:       0,
 			},
 		},
 		{
 			name: "negative limits",
 			limits: domain.ResourceLimits{
-				MaxCPU:    -1,
-				MaxMemory: -1,
-				MaxIOBPS:  -1,
+				CPUMillis:   -1,
+				MemoryBytes: -1,
+				IOBPS:       -1,
 			},
 		},
 		{
 			name: "max values",
 			limits: domain.ResourceLimits{
-				MaxCPU:    2147483647, // int32 max
-				MaxMemory: 2147483647,
-				MaxIOBPS:  2147483647,
+				CPUMillis:   math.MaxInt64,
+				MemoryBytes: math.MaxInt64,
+				IOBPS:       math.MaxInt64,
 			},
 		},
 	}
@@ -287,14 +296,19 @@ func TestResourceLimitsMapping(t *testing.T) {
 
 			pbJob := DomainToProtobuf(job)
 
-			if pbJob.MaxCPU != tt.limits.MaxCPU {
-				t.Errorf("Expected MaxCPU %v, got %v", tt.limits.MaxCPU, pbJob.MaxCPU)
+			if pbJob.CpuLimitMillis != tt.limits.CPUMillis {
+				t.Errorf("Expected CpuLimitMillis %v, got %v", tt.limits.CPUMillis, pbJob.CpuLimitMillis)
+			}
+			if pbJob.MemoryLimitBytes != tt.limits.MemoryBytes {
+				t.Errorf("Expected MemoryLimitBytes %v, got %v", tt.limits.MemoryBytes, pbJob.MemoryLimitBytes)
 			}
-			if pbJob.MaxMemory != tt.limits.MaxMemory {
-				t.Errorf("Expected MaxMemory %v, got %v", tt.limits.MaxMemory, pbJob.MaxMemory)
+			if pbJob.IoLimitBPS != tt.limits.IOBPS {
+				t.Errorf("Expected IoLimitBPS %v, got %v", tt.limits.IOBPS, pbJob.IoLimitBPS)
 			}
-			if pbJob.MaxIOBPS != tt.limits.MaxIOBPS {
-				t.Errorf("Expected MaxIOBPS %v, got %v", tt.limits.MaxIOBPS, pbJob.MaxIOBPS)
+
+			cpu, memory, io := tt.limits.Legacy()
+			if pbJob.MaxCPU != cpu || pbJob.MaxMemory != memory || pbJob.MaxIOBPS != io {
+				t.Errorf("Expected legacy limits %v/%v/%v, got %v/%v/%v", cpu, memory, io, pbJob.MaxCPU, pbJob.MaxMemory, pbJob.MaxIOBPS)
 			}
 		})
 	}
@@ -382,9 +396,9 @@ func BenchmarkDomainToProtobuf(b *testing.B) {
 		Command: "echo",
 		Args:    []string{"hello", "world", "from", "benchmark"},
 		Limits: domain.ResourceLimits{
-			MaxCPU:    100,
-			MaxMemory: 512,
-			MaxIOBPS:  1000,
+			CPUMillis:   1000,
+			MemoryBytes: 512 << 20,
+			IOBPS:       1000,
 		},
 		Status:    domain.StatusCompleted,
 		StartTime: time.Now(),
@@ -411,3 +425,396 @@ func BenchmarkDomainToRunJobResponse(b *testing.B) {
 		DomainToRunJobResponse(job)
 	}
 }
+
+func TestRunJobRequestToSpec(t *testing.T) {
+	req := &pb.RunJobReq{
+		Command:   "python3",
+		Args:      []string{"app.py"},
+		MaxCPU:    50,
+		MaxMemory: 256,
+		Env:       map[string]string{"APP_ENV": "prod"},
+		EnvFile:   []byte("# defaults\nAPP_ENV=dev\nexport LOG_LEVEL=\"debug\"\n"),
+		Stdin:     true,
+		Shell:     true,
+		Profile:   "medium",
+		Isolation: "cgroups",
+		Runtime:   "gvisor",
+
+		RequiredCapabilities: []string{"network-isolation"},
+	}
+
+	spec, err := RunJobRequestToSpec(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	if spec.Command != "python3" || len(spec.Args) != 1 {
+		t.Errorf("Command/args not mapped correctly: %v %v", spec.Command, spec.Args)
+	}
+	if spec.Limits.CPUMillis != 500 || spec.Limits.MemoryBytes != 256<<20 || spec.Profile != "medium" || spec.Isolation != domain.IsolationCgroups {
+		t.Errorf("Limits not mapped correctly: %+v", spec.Limits)
+	}
+	if spec.Env["APP_ENV"] != "prod" {
+		t.Errorf("Expected explicit env to override env file, got %v", spec.Env["APP_ENV"])
+	}
+	if spec.Env["LOG_LEVEL"] != "debug" {
+		t.Errorf("Expected LOG_LEVEL from env file, got %v", spec.Env["LOG_LEVEL"])
+	}
+	if !spec.Stdin || !spec.Shell {
+		t.Error("Expected stdin and shell mode to be mapped")
+	}
+	if spec.Runtime != "gvisor" {
+		t.Errorf("Expected runtime to be mapped, got %q", spec.Runtime)
+	}
+	if len(spec.Capabilities) != 1 || spec.Capabilities[0] != domain.CapabilityNetworkIsolation {
+		t.Errorf("Expected required capabilities to be mapped, got %v", spec.Capabilities)
+	}
+}
+
+func TestRunJobRequestToSpec_InvalidEnvFile(t *testing.T) {
+	req := &pb.RunJobReq{
+		Command: "echo",
+		EnvFile: []byte("NOT_AN_ASSIGNMENT\n"),
+	}
+
+	if _, err := RunJobRequestToSpec(req); err == nil {
+		t.Error("Expected error for malformed env file")
+	}
+}
+
+func TestRunJobRequestToSpec_InvalidIsolation(t *testing.T) {
+	req := &pb.RunJobReq{Command: "echo", Isolation: "vm"}
+
+	if _, err := RunJobRequestToSpec(req); err == nil {
+		t.Error("Expected error for unknown isolation mode")
+	}
+}
+
+func TestRunJobRequestToSpec_QoS(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", Qos: "best-effort"})
+	if err != nil || spec.QoS != domain.QoSBestEffort {
+		t.Errorf("Expected best-effort QoS, got %q, %v", spec.QoS, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", Qos: "premium"}); err == nil {
+		t.Error("Expected error for unknown QoS class")
+	}
+}
+
+func TestRunJobRequestToSpec_Seccomp(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", Seccomp: "audit"})
+	if err != nil || spec.Seccomp != domain.SeccompAudit {
+		t.Errorf("Expected audit seccomp mode, got %q, %v", spec.Seccomp, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", Seccomp: "enforce"}); err == nil {
+		t.Error("Expected error for unknown seccomp mode")
+	}
+}
+
+func TestRunJobRequestToSpec_TimeOffset(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", TimeOffsetSeconds: -3600})
+	if err != nil || spec.TimeOffset != -time.Hour {
+		t.Errorf("Expected a time offset of -1h, got %v, %v", spec.TimeOffset, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", TimeOffsetSeconds: 1 << 40}); err == nil {
+		t.Error("Expected error for an out of range time offset")
+	}
+}
+
+func TestRunJobRequestToSpec_InheritEnv(t *testing.T) {
+	inherit, noInherit := true, false
+	tests := []struct {
+		inheritEnv *bool
+		cleanEnv   bool
+	}{
+		{nil, false},
+		{&inherit, false},
+		{&noInherit, true},
+	}
+
+	for _, tt := range tests {
+		spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", InheritEnv: tt.inheritEnv})
+		if err != nil || spec.CleanEnv != tt.cleanEnv {
+			t.Errorf("Expected clean env %v, got %v, %v", tt.cleanEnv, spec.CleanEnv, err)
+		}
+	}
+}
+
+func TestRunJobRequestToSpec_CommandDigest(t *testing.T) {
+	digest := strings.Repeat("ab", 32)
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", CommandSha256: strings.ToUpper(digest)})
+	if err != nil || spec.CommandDigest != digest {
+		t.Errorf("Expected command digest %s, got %q, %v", digest, spec.CommandDigest, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", CommandSha256: "abc"}); err == nil {
+		t.Error("Expected error for a truncated command digest")
+	}
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", CommandSha256: digest, Runtime: "gvisor"}); err == nil {
+		t.Error("Expected error for a pinned command run through a runtime")
+	}
+}
+
+func TestRunJobRequestToSpec_IdlePolicy(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", MaxIdleSeconds: 300, IdleAction: "mark"})
+	if err != nil || spec.Idle != (domain.IdlePolicy{MaxIdle: 5 * time.Minute, Action: domain.IdleMark}) {
+		t.Errorf("Expected a 5m mark idle policy, got %+v, %v", spec.Idle, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", IdleAction: "mark"}); err == nil {
+		t.Error("Expected error for an idle action without a max idle time")
+	}
+}
+
+func TestRunJobRequestToSpec_OutputFlush(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", OutputFlushMillis: -1})
+	if err != nil || spec.OutputFlush >= 0 {
+		t.Errorf("Expected output stored as it is written, got %v, %v", spec.OutputFlush, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", OutputFlushMillis: -5}); err == nil {
+		t.Error("Expected error for a negative output flush interval other than -1")
+	}
+}
+
+func TestRunJobRequestToSpec_OutputLimit(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", OutputBytesPerSecond: 65536, OutputLimitAction: "truncate"})
+	if err != nil || spec.OutputLimit != (domain.OutputLimit{BytesPerSecond: 65536, Action: domain.OutputTruncate}) {
+		t.Errorf("Expected a 64KiB/s truncate output limit, got %+v, %v", spec.OutputLimit, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "echo", OutputBytesPerSecond: 10}); err == nil {
+		t.Error("Expected error for an output rate limit below the minimum")
+	}
+}
+
+func TestRunJobRequestToSpec_Inputs(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "cat", Inputs: []*pb.JobInput{
+		{Source: "s3://data/train.csv", Path: "data/train.csv"},
+	}})
+	want := domain.JobInput{Source: "s3://data/train.csv", Path: "data/train.csv"}
+	if err != nil || len(spec.Inputs) != 1 || spec.Inputs[0] != want {
+		t.Errorf("Expected the input %+v, got %+v, %v", want, spec.Inputs, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "cat", Inputs: []*pb.JobInput{
+		{Source: "s3://data/train.csv", Path: "../train.csv"},
+	}}); err == nil {
+		t.Error("Expected error for an input outside the workspace")
+	}
+}
+
+func TestRunJobRequestToSpec_Sockets(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", Sockets: []*pb.JobSocket{
+		{Name: "http", Address: "tcp://:8080"},
+	}})
+	want := domain.JobSocket{Name: "http", Address: "tcp://:8080"}
+	if err != nil || len(spec.Sockets) != 1 || spec.Sockets[0] != want {
+		t.Errorf("Expected the socket %+v, got %+v, %v", want, spec.Sockets, err)
+	}
+	if sockets := DomainToGetJobStatusResponse(&domain.Job{Spec: spec}).Sockets; len(sockets) != 1 || sockets[0].Name != "http" {
+		t.Errorf("Expected the socket in the job status, got %+v", sockets)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", Sockets: []*pb.JobSocket{
+		{Name: "http", Address: "udp://:53"},
+	}}); err == nil {
+		t.Error("Expected error for an unsupported socket address")
+	}
+}
+
+func TestRunJobRequestToSpec_AllowedWindows(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "make", AllowedWindows: []string{"Mon-Fri 18:00-06:00", "weekends 00:00-24:00 UTC"}})
+	if err != nil || len(spec.AllowedWindows) != 2 || spec.AllowedWindows[1].Location != time.UTC {
+		t.Errorf("Expected two allowed windows, got %+v, %v", spec.AllowedWindows, err)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "make", AllowedWindows: []string{"after lunch"}}); err == nil {
+		t.Error("Expected error for an invalid allowed window")
+	}
+}
+
+func TestRunJobRequestToSpec_QueueTimeout(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "report", QueueTimeoutSeconds: 3600, SubmitTime: "2025-01-06T10:00:00Z"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if spec.QueueTimeout != time.Hour || !spec.StartDeadline().Equal(time.Date(2025, 1, 6, 11, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected an 11:00 start deadline, got %v, %v", spec.QueueTimeout, spec.StartDeadline())
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "report", QueueTimeoutSeconds: -1}); err == nil {
+		t.Error("Expected error for a negative queue timeout")
+	}
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "report", SubmitTime: "10:00"}); err == nil {
+		t.Error("Expected error for an invalid submit time")
+	}
+}
+
+func TestRunJobRequestToSpec_Limits(t *testing.T) {
+	req := &pb.RunJobReq{
+		Command:          "echo",
+		MaxCPU:           50,
+		MaxMemory:        256,
+		MaxIOBPS:         1000,
+		CpuLimitMillis:   2500,
+		MemoryLimitBytes: 8 << 30,
+	}
+
+	spec, err := RunJobRequestToSpec(req)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	want := domain.ResourceLimits{CPUMillis: 2500, MemoryBytes: 8 << 30, IOBPS: 1000}
+	if spec.Limits != want {
+		t.Errorf("Expected new fields to win over legacy ones, got %+v", spec.Limits)
+	}
+
+	req.MemoryLimitBytes = -1
+	if _, err := RunJobRequestToSpec(req); err == nil {
+		t.Error("Expected error for negative limits")
+	}
+}
+
+func TestDomainToGetJobStatusResponse_Env(t *testing.T) {
+	job := &domain.Job{
+		Id:        "env-job",
+		Command:   "env",
+		Env:       map[string]string{"APP_ENV": "prod"},
+		Status:    domain.StatusRunning,
+		StartTime: time.Now(),
+	}
+
+	response := DomainToGetJobStatusResponse(job)
+	if response.Env["APP_ENV"] != "prod" {
+		t.Errorf("Expected env to be mapped, got %v", response.Env)
+	}
+}
+
+func TestRunJobRequestToSpec_SecretEnvConflict(t *testing.T) {
+	req := &pb.RunJobReq{
+		Command:   "env",
+		Env:       map[string]string{"API_KEY": "plain"},
+		SecretEnv: map[string]string{"API_KEY": "api-key"},
+	}
+
+	if _, err := RunJobRequestToSpec(req); err == nil {
+		t.Error("Expected error when a variable is set both as env and secret env")
+	}
+}
+
+func TestRunJobRequestToSpec_RestartPolicy(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", RestartPolicy: "on-failure", MaxRestarts: 3})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec.Restart.Mode != domain.RestartOnFailure || spec.Restart.MaxRestarts != 3 {
+		t.Errorf("Restart policy not mapped correctly: %+v", spec.Restart)
+	}
+
+	spec, _ = RunJobRequestToSpec(&pb.RunJobReq{Command: "echo"})
+	if spec.Restart.Mode != domain.RestartNever {
+		t.Errorf("Expected default restart policy never, got %q", spec.Restart.Mode)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", RestartPolicy: "sometimes"}); err == nil {
+		t.Error("Expected error for unknown restart policy")
+	}
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", RestartPolicy: "always", MaxRestarts: -1}); err == nil {
+		t.Error("Expected error for negative max restarts")
+	}
+}
+
+func TestDomainToGetJobStatusResponse_Restarts(t *testing.T) {
+	job := &domain.Job{
+		Id:        "svc",
+		Command:   "server",
+		Restart:   domain.RestartPolicy{Mode: domain.RestartAlways, MaxRestarts: 10},
+		Restarts:  2,
+		Status:    domain.StatusRunning,
+		StartTime: time.Now(),
+	}
+
+	response := DomainToGetJobStatusResponse(job)
+	if response.RestartPolicy != "always" || response.MaxRestarts != 10 || response.Restarts != 2 {
+		t.Errorf("Restart tracking not mapped: %s/%d/%d", response.RestartPolicy, response.MaxRestarts, response.Restarts)
+	}
+}
+
+func TestRunJobRequestToSpec_HealthProbe(t *testing.T) {
+	spec, err := RunJobRequestToSpec(&pb.RunJobReq{
+		Command:     "server",
+		HealthProbe: &pb.HealthProbe{Type: "http", Port: 8080, Path: "/healthz", IntervalSeconds: 5, FailureThreshold: 2},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if spec.Probe == nil || spec.Probe.Type != domain.ProbeHTTP || spec.Probe.Interval != 5*time.Second || spec.Probe.FailureThreshold != 2 {
+		t.Errorf("Health probe not mapped correctly: %+v", spec.Probe)
+	}
+
+	if _, err := RunJobRequestToSpec(&pb.RunJobReq{Command: "server", HealthProbe: &pb.HealthProbe{Type: "tcp"}}); err == nil {
+		t.Error("Expected error for tcp probe without port")
+	}
+
+	job := &domain.Job{Id: "1", Probe: spec.Probe, Health: domain.HealthUnhealthy, StartTime: time.Now()}
+	response := DomainToGetJobStatusResponse(job)
+	if response.Health != "UNHEALTHY" || response.HealthProbe.GetPath() != "/healthz" {
+		t.Errorf("Health not mapped: %s %+v", response.Health, response.HealthProbe)
+	}
+}
+
+func TestDomainToValidateJobResponse(t *testing.T) {
+	v := &domain.JobValidation{
+		ResolvedCommand: "/usr/bin/python3",
+		Limits:          domain.ResourceLimits{CPUMillis: 1000, MemoryBytes: 512 << 20},
+	}
+
+	res := DomainToValidateJobResponse(v)
+	if !res.Valid || res.ResolvedCommand != "/usr/bin/python3" || res.MemoryLimitBytes != 512<<20 || res.MaxMemory != 512 {
+		t.Errorf("unexpected response %+v", res)
+	}
+
+	v.Errors = append(v.Errors, domain.SpecError{Field: "environment", Message: "prefix JOB_ is reserved"})
+	res = DomainToValidateJobResponse(v)
+	if res.Valid || len(res.Errors) != 1 || res.Errors[0].Field != "environment" {
+		t.Errorf("expected one environment error, got %+v", res)
+	}
+}
+
+func TestDomainToJobExit(t *testing.T) {
+	job := &domain.Job{Id: "1", Status: domain.StatusRunning, Restarts: 2}
+	job.Fail(3, "exited with code 3")
+
+	exit := DomainToJobExit(job)
+	if exit.Status != "FAILED" || exit.ExitCode != 3 || exit.Restarts != 2 {
+		t.Errorf("unexpected exit %+v", exit)
+	}
+	if exit.EndTime == "" {
+		t.Error("expected end time to be set")
+	}
+}
+
+func TestDomainToGetJobStatusResponse_Result(t *testing.T) {
+	job := &domain.Job{
+		Id:        "report",
+		Command:   "report",
+		Status:    domain.StatusCompleted,
+		StartTime: time.Now(),
+		Result:    []byte(`{"rows":42}`),
+	}
+
+	response := DomainToGetJobStatusResponse(job)
+	if response.Result != `{"rows":42}` || response.ResultError != "" {
+		t.Errorf("Result not mapped: %q/%q", response.Result, response.ResultError)
+	}
+
+	job.Result, job.ResultError = nil, "result is not valid JSON"
+	response = DomainToGetJobStatusResponse(job)
+	if response.Result != "" || response.ResultError != job.ResultError {
+		t.Errorf("Result error not mapped: %q/%q", response.Result, response.ResultError)
+	}
+}
@@ -0,0 +1,51 @@
+package mappers
+
+import (
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// DomainToJobMetrics converts a job and its usage to protobuf JobMetrics.
+// usage is nil for jobs that are not running.
+func DomainToJobMetrics(job *domain.Job, usage *domain.JobUsage) *pb.JobMetrics {
+	res := &pb.JobMetrics{
+		Id:               job.Id,
+		Command:          job.Command,
+		Args:             job.Args,
+		Status:           string(job.Status),
+		StartTime:        job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		Restarts:         job.Restarts,
+		Health:           string(job.Health),
+		CpuLimitMillis:   job.Limits.CPUMillis,
+		MemoryLimitBytes: job.Limits.MemoryBytes,
+		IoLimitBPS:       job.Limits.IOBPS,
+	}
+	res.MaxCPU, res.MaxMemory, res.MaxIOBPS = job.Limits.Legacy()
+
+	if usage != nil {
+		res.CpuPercent = usage.CPUPercent
+		res.MemoryBytes = usage.MemoryBytes
+		res.IoReadBytes = usage.IOReadBytes
+		res.IoWriteBytes = usage.IOWriteBytes
+		res.ThrottledPercent = usage.ThrottledPercent
+		res.ThrottledUsec = usage.CPUThrottledTime.Microseconds()
+	}
+
+	return res
+}
+
+// UsageTotalsToAggregateMetrics converts group or tenant totals to protobuf
+func UsageTotalsToAggregateMetrics(totals domain.UsageTotals) []*pb.AggregateMetrics {
+	var res []*pb.AggregateMetrics
+	for _, t := range totals.Sorted() {
+		res = append(res, &pb.AggregateMetrics{
+			Name:         t.Name,
+			Jobs:         int32(t.Jobs),
+			CpuPercent:   t.CPUPercent,
+			MemoryBytes:  t.MemoryBytes,
+			IoReadBytes:  t.IOReadBytes,
+			IoWriteBytes: t.IOWriteBytes,
+		})
+	}
+	return res
+}
@@ -0,0 +1,45 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func TestDomainToJobMetrics(t *testing.T) {
+	job := &domain.Job{
+		Id:        "1",
+		Command:   "stress",
+		Status:    domain.StatusRunning,
+		StartTime: time.Now(),
+		Limits:    domain.ResourceLimits{CPUMillis: 500, MemoryBytes: 256 << 20},
+	}
+
+	res := DomainToJobMetrics(job, &domain.JobUsage{CPUPercent: 42.5, MemoryBytes: 1024, IOWriteBytes: 10,
+		ThrottledPercent: 30, CPUThrottledTime: 2 * time.Second})
+	if res.CpuPercent != 42.5 || res.MemoryBytes != 1024 || res.IoWriteBytes != 10 {
+		t.Errorf("usage not mapped: %v", res)
+	}
+	if res.ThrottledPercent != 30 || res.ThrottledUsec != 2000000 {
+		t.Errorf("throttling not mapped: %v", res)
+	}
+	if res.CpuLimitMillis != 500 || res.MemoryLimitBytes != 256<<20 || res.MaxCPU != 50 || res.MaxMemory != 256 {
+		t.Errorf("limits not mapped: %v", res)
+	}
+
+	res = DomainToJobMetrics(job, nil)
+	if res.CpuPercent != 0 || res.MemoryBytes != 0 {
+		t.Errorf("expected no usage without a reading, got %v", res)
+	}
+}
+
+func TestUsageTotalsToAggregateMetrics(t *testing.T) {
+	totals := domain.UsageTotals{}
+	totals.Add("web", &domain.JobUsage{CPUPercent: 20, MemoryBytes: 1024})
+	totals.Add("", &domain.JobUsage{IOReadBytes: 5})
+
+	res := UsageTotalsToAggregateMetrics(totals)
+	if len(res) != 2 || res[0].Name != "" || res[0].IoReadBytes != 5 || res[1].Name != "web" || res[1].Jobs != 1 || res[1].MemoryBytes != 1024 {
+		t.Errorf("totals not mapped: %v", res)
+	}
+}
@@ -0,0 +1,66 @@
+package mappers
+
+import (
+	"fmt"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// RunPipelineRequestToSpec converts a RunPipelineReq to a domain PipelineSpec.
+// The spec is not validated here, see PipelineSpec.Validate.
+func RunPipelineRequestToSpec(req *pb.RunPipelineReq) (*domain.PipelineSpec, error) {
+	spec := &domain.PipelineSpec{Steps: make([]domain.PipelineStep, 0, len(req.Steps))}
+
+	for i, step := range req.Steps {
+		if step.Job == nil {
+			return nil, fmt.Errorf("step %d (%s) has no job", i, step.Name)
+		}
+
+		jobSpec, err := RunJobRequestToSpec(step.Job)
+		if err != nil {
+			return nil, fmt.Errorf("step %s: %w", step.Name, err)
+		}
+
+		inputs := make([]domain.PipelineInput, 0, len(step.Inputs))
+		for _, in := range step.Inputs {
+			inputs = append(inputs, domain.PipelineInput{
+				Step:   in.Step,
+				Source: domain.InputSource(in.Source),
+				Path:   in.Path,
+			})
+		}
+
+		spec.Steps = append(spec.Steps, domain.PipelineStep{
+			Name:      step.Name,
+			Job:       jobSpec,
+			DependsOn: append([]string(nil), step.DependsOn...),
+			Inputs:    inputs,
+		})
+	}
+
+	return spec, nil
+}
+
+// DomainToPipelineResponse converts a domain Pipeline to protobuf Pipeline
+func DomainToPipelineResponse(p *domain.Pipeline) *pb.Pipeline {
+	res := &pb.Pipeline{
+		Id:        p.Id,
+		Status:    string(p.Status),
+		StartTime: p.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	for _, step := range p.Steps {
+		res.Steps = append(res.Steps, &pb.PipelineStepStatus{
+			Name:   step.Name,
+			JobId:  step.JobId,
+			Status: string(step.Status),
+			Error:  step.Error,
+		})
+	}
+
+	if p.EndTime != nil {
+		res.EndTime = p.EndTime.Format("2006-01-02T15:04:05Z07:00")
+	}
+
+	return res
+}
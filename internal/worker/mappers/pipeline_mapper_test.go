@@ -0,0 +1,63 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+func TestRunPipelineRequestToSpec(t *testing.T) {
+	req := &pb.RunPipelineReq{Steps: []*pb.PipelineStep{
+		{Name: "build", Job: &pb.RunJobReq{Command: "make", Env: map[string]string{"A": "1"}}},
+		{
+			Name:      "test",
+			Job:       &pb.RunJobReq{Command: "go", Args: []string{"test"}},
+			DependsOn: []string{"build"},
+			Inputs:    []*pb.PipelineInput{{Step: "build", Source: "artifacts", Path: "bin"}},
+		},
+	}}
+
+	spec, err := RunPipelineRequestToSpec(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(spec.Steps) != 2 {
+		t.Fatalf("expected 2 steps, got %d", len(spec.Steps))
+	}
+	if spec.Steps[0].Job.Env["A"] != "1" {
+		t.Errorf("expected job env to be mapped, got %v", spec.Steps[0].Job.Env)
+	}
+
+	in := spec.Steps[1].Inputs[0]
+	if in.Step != "build" || in.Source != domain.InputArtifacts || in.Path != "bin" {
+		t.Errorf("unexpected input %+v", in)
+	}
+
+	if _, err := RunPipelineRequestToSpec(&pb.RunPipelineReq{Steps: []*pb.PipelineStep{{Name: "empty"}}}); err == nil {
+		t.Error("expected error for step without job")
+	}
+}
+
+func TestDomainToPipelineResponse(t *testing.T) {
+	end := time.Now()
+	p := &domain.Pipeline{
+		Id:     "3",
+		Status: domain.PipelineFailed,
+		Steps: []domain.StepState{
+			{Name: "build", JobId: "7", Status: domain.StepFailed},
+			{Name: "test", Status: domain.StepSkipped, Error: `upstream step "build" did not complete`},
+		},
+		StartTime: end.Add(-time.Second),
+		EndTime:   &end,
+	}
+
+	res := DomainToPipelineResponse(p)
+	if res.Id != "3" || res.Status != "FAILED" || res.EndTime == "" {
+		t.Errorf("unexpected pipeline response %+v", res)
+	}
+	if len(res.Steps) != 2 || res.Steps[0].JobId != "7" || res.Steps[1].Status != "SKIPPED" || res.Steps[1].Error == "" {
+		t.Errorf("unexpected steps %+v", res.Steps)
+	}
+}
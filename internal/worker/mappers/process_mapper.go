@@ -0,0 +1,31 @@
+package mappers
+
+import (
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// DomainToJobProcesses converts the process tree of a job to protobuf
+func DomainToJobProcesses(jobID string, processes []domain.JobProcess, sampledAt time.Time) *pb.JobProcesses {
+	res := &pb.JobProcesses{
+		JobId:     jobID,
+		Processes: make([]*pb.JobProcess, 0, len(processes)),
+		SampledAt: sampledAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	for _, p := range processes {
+		res.Processes = append(res.Processes, &pb.JobProcess{
+			Pid:        p.Pid,
+			Ppid:       p.ParentPid,
+			Depth:      p.Depth,
+			Command:    p.Command,
+			State:      p.State,
+			Threads:    p.Threads,
+			CpuMicros:  p.CPUTime.Microseconds(),
+			CpuPercent: p.CPUPercent,
+			RssBytes:   p.RSSBytes,
+			StartTime:  p.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		})
+	}
+	return res
+}
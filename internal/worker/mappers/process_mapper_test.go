@@ -0,0 +1,26 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func TestDomainToJobProcesses(t *testing.T) {
+	started := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	res := DomainToJobProcesses("1", []domain.JobProcess{
+		{Pid: 10, ParentPid: 1, Command: "make -j2", State: "S", Threads: 1, CPUTime: 1500 * time.Millisecond, CPUPercent: 2.5, RSSBytes: 4096, StartTime: started},
+		{Pid: 11, ParentPid: 10, Depth: 1, Command: "cc main.c", State: "R", Threads: 1, StartTime: started},
+	}, started.Add(time.Minute))
+
+	if res.JobId != "1" || len(res.Processes) != 2 || res.SampledAt != "2024-05-01T12:01:00Z" {
+		t.Fatalf("unexpected processes %v", res)
+	}
+	top := res.Processes[0]
+	if top.Pid != 10 || top.Ppid != 1 || top.Command != "make -j2" || top.CpuMicros != 1500000 || top.CpuPercent != 2.5 || top.RssBytes != 4096 || top.StartTime != "2024-05-01T12:00:00Z" {
+		t.Errorf("unexpected process %v", top)
+	}
+	if res.Processes[1].Depth != 1 || res.Processes[1].State != "R" {
+		t.Errorf("unexpected child %v", res.Processes[1])
+	}
+}
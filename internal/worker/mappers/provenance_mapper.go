@@ -0,0 +1,23 @@
+package mappers
+
+import (
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/utils"
+)
+
+// DomainToJobProvenance converts the provenance record of a job to protobuf
+func DomainToJobProvenance(jobID string, p *domain.Provenance) *pb.JobProvenance {
+	return &pb.JobProvenance{
+		JobId:         jobID,
+		Command:       p.Command,
+		CommandSha256: p.CommandSHA256,
+		Env:           utils.CopyStringMap(p.Env),
+		KernelVersion: p.KernelVersion,
+		CgroupPath:    p.CgroupPath,
+		Cgroup:        utils.CopyStringMap(p.Cgroup),
+		WorkerVersion: p.WorkerVersion,
+		SignedBy:      p.SignedBy,
+		RecordedAt:    p.RecordedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+}
@@ -0,0 +1,33 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func TestDomainToJobProvenance(t *testing.T) {
+	p := &domain.Provenance{
+		Command:       "/usr/bin/python3",
+		CommandSHA256: "ab12",
+		Env:           map[string]string{"PATH": "/usr/bin"},
+		KernelVersion: "6.8.0",
+		CgroupPath:    "/sys/fs/cgroup/worker.slice/job-1",
+		Cgroup:        map[string]string{"memory.max": "1073741824"},
+		WorkerVersion: "v1.4.0",
+		SignedBy:      "release-team",
+		RecordedAt:    time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC),
+	}
+
+	res := DomainToJobProvenance("1", p)
+
+	if res.JobId != "1" || res.Command != p.Command || res.CommandSha256 != "ab12" || res.WorkerVersion != "v1.4.0" || res.SignedBy != "release-team" {
+		t.Errorf("unexpected provenance %v", res)
+	}
+	if res.Cgroup["memory.max"] != "1073741824" || res.Env["PATH"] != "/usr/bin" {
+		t.Errorf("unexpected env %v and cgroup settings %v", res.Env, res.Cgroup)
+	}
+	if res.RecordedAt != "2024-05-01T12:00:00Z" {
+		t.Errorf("unexpected recordedAt %q", res.RecordedAt)
+	}
+}
@@ -0,0 +1,108 @@
+package mappers
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// JobTemplateToDomain converts a protobuf JobTemplate to a domain JobTemplate,
+// converting its spec like RunJobRequestToSpec does
+func JobTemplateToDomain(tmpl *pb.JobTemplate) (*domain.JobTemplate, error) {
+	if tmpl.GetSpec() == nil {
+		return nil, errors.New("template has no job spec")
+	}
+
+	spec, err := RunJobRequestToSpec(tmpl.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid job spec: %w", err)
+	}
+
+	res := &domain.JobTemplate{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Spec:        spec,
+	}
+	for _, param := range tmpl.Params {
+		res.Params = append(res.Params, domain.TemplateParam{
+			Name:        param.Name,
+			Description: param.Description,
+			Default:     param.DefaultValue,
+			Required:    param.Required,
+		})
+	}
+	return res, nil
+}
+
+// DomainToJobTemplate converts a domain JobTemplate to protobuf JobTemplate
+func DomainToJobTemplate(tmpl *domain.JobTemplate) *pb.JobTemplate {
+	res := &pb.JobTemplate{
+		Name:        tmpl.Name,
+		Description: tmpl.Description,
+		Spec:        specToRunJobRequest(tmpl.Spec),
+		CreatedAt:   tmpl.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+	}
+	for _, param := range tmpl.Params {
+		res.Params = append(res.Params, &pb.TemplateParam{
+			Name:         param.Name,
+			Description:  param.Description,
+			DefaultValue: param.Default,
+			Required:     param.Required,
+		})
+	}
+	return res
+}
+
+// specToRunJobRequest converts a domain JobSpec back to the RunJobReq it
+// could have been converted from, the env file merged into env
+func specToRunJobRequest(spec *domain.JobSpec) *pb.RunJobReq {
+	req := &pb.RunJobReq{
+		Command:           spec.Command,
+		Args:              spec.Args,
+		CpuLimitMillis:    spec.Limits.CPUMillis,
+		MemoryLimitBytes:  spec.Limits.MemoryBytes,
+		IoLimitBPS:        spec.Limits.IOBPS,
+		Profile:           spec.Profile,
+		Env:               spec.Env,
+		SecretEnv:         spec.SecretEnv,
+		RestartPolicy:     string(spec.Restart.Mode),
+		MaxRestarts:       spec.Restart.MaxRestarts,
+		HealthProbe:       HealthProbeToProtobuf(spec.Probe),
+		Labels:            spec.Labels,
+		Stdin:             spec.Stdin,
+		Shell:             spec.Shell,
+		Isolation:         string(spec.Isolation),
+		Qos:               string(spec.QoS),
+		Seccomp:           string(spec.Seccomp),
+		Runtime:           spec.Runtime,
+		TimeOffsetSeconds: int64(spec.TimeOffset / time.Second),
+		CommandSha256:     spec.CommandDigest,
+		MaxIdleSeconds:    int64(spec.Idle.MaxIdle / time.Second),
+		IdleAction:        string(spec.Idle.Action),
+		OutputFlushMillis: int64(spec.OutputFlush / time.Millisecond),
+
+		OutputBytesPerSecond: spec.OutputLimit.BytesPerSecond,
+		OutputLimitAction:    string(spec.OutputLimit.Action),
+	}
+	req.MaxCPU, req.MaxMemory, req.MaxIOBPS = spec.Limits.Legacy()
+	if spec.CleanEnv {
+		inherit := false
+		req.InheritEnv = &inherit
+	}
+
+	for _, capability := range spec.Capabilities {
+		req.RequiredCapabilities = append(req.RequiredCapabilities, string(capability))
+	}
+	for _, input := range spec.Inputs {
+		req.Inputs = append(req.Inputs, &pb.JobInput{Source: input.Source, Path: input.Path})
+	}
+	req.Sockets = JobSocketsToProtobuf(spec.Sockets)
+	for _, window := range spec.AllowedWindows {
+		req.AllowedWindows = append(req.AllowedWindows, window.String())
+	}
+	// the submit time is the submission's, not the template's
+	req.QueueTimeoutSeconds = int64(spec.QueueTimeout / time.Second)
+	return req
+}
@@ -0,0 +1,48 @@
+package mappers
+
+import (
+	"testing"
+	pb "worker/api/gen"
+)
+
+func TestJobTemplateRoundTrip(t *testing.T) {
+	in := &pb.JobTemplate{
+		Name:   "train",
+		Params: []*pb.TemplateParam{{Name: "dataset", Required: true}, {Name: "epochs", DefaultValue: "10"}},
+		Spec: &pb.RunJobReq{
+			Command:          "python3",
+			Args:             []string{"train.py", "{{dataset}}"},
+			MemoryLimitBytes: 1 << 30,
+			Env:              map[string]string{"EPOCHS": "{{epochs}}"},
+			RestartPolicy:    "on-failure",
+			Isolation:        "cgroups",
+			Inputs:           []*pb.JobInput{{Source: "s3://data/{{dataset}}.csv", Path: "data.csv"}},
+		},
+	}
+
+	tmpl, err := JobTemplateToDomain(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tmpl.Params) != 2 || !tmpl.Params[0].Required || tmpl.Params[1].Default != "10" {
+		t.Errorf("unexpected params %+v", tmpl.Params)
+	}
+
+	out := DomainToJobTemplate(tmpl)
+	if out.Spec.Command != "python3" || out.Spec.Args[1] != "{{dataset}}" || out.Spec.Env["EPOCHS"] != "{{epochs}}" {
+		t.Errorf("unexpected spec %+v", out.Spec)
+	}
+	if out.Spec.MemoryLimitBytes != 1<<30 || out.Spec.MaxMemory != 1024 {
+		t.Errorf("unexpected memory limits %d and %d MiB", out.Spec.MemoryLimitBytes, out.Spec.MaxMemory)
+	}
+	if out.Spec.RestartPolicy != "on-failure" || out.Spec.Isolation != "cgroups" {
+		t.Errorf("unexpected restart policy %q and isolation %q", out.Spec.RestartPolicy, out.Spec.Isolation)
+	}
+	if len(out.Spec.Inputs) != 1 || out.Spec.Inputs[0].Source != "s3://data/{{dataset}}.csv" || out.Spec.Inputs[0].Path != "data.csv" {
+		t.Errorf("unexpected inputs %+v", out.Spec.Inputs)
+	}
+
+	if _, err := JobTemplateToDomain(&pb.JobTemplate{Name: "empty"}); err == nil {
+		t.Error("expected an error for a template without a spec")
+	}
+}
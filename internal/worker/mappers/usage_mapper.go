@@ -0,0 +1,44 @@
+package mappers
+
+import (
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker/domain"
+)
+
+// UsageReportToProtobuf converts usage records to a report with the job
+// records and the totals of each tenant
+func UsageReportToProtobuf(records []*domain.UsageRecord, now time.Time) *pb.UsageReport {
+	report := &pb.UsageReport{}
+	for _, r := range records {
+		usage := &pb.JobUsage{
+			Id:                r.JobID,
+			Tenant:            r.Tenant,
+			Command:           r.Command,
+			Status:            string(r.Status),
+			StartTime:         r.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+			CpuSeconds:        r.CPUTime.Seconds(),
+			MemoryByteSeconds: r.MemoryByteSeconds,
+			IoReadBytes:       r.IOReadBytes,
+			IoWriteBytes:      r.IOWriteBytes,
+			WallSeconds:       r.WallTime(now).Seconds(),
+		}
+		if r.EndTime != nil {
+			usage.EndTime = r.EndTime.Format("2006-01-02T15:04:05Z07:00")
+		}
+		report.Jobs = append(report.Jobs, usage)
+	}
+
+	for _, t := range domain.SumByTenant(records, now) {
+		report.Tenants = append(report.Tenants, &pb.TenantUsage{
+			Tenant:            t.Tenant,
+			Jobs:              int32(t.Jobs),
+			CpuSeconds:        t.CPUTime.Seconds(),
+			MemoryByteSeconds: t.MemoryByteSeconds,
+			IoReadBytes:       t.IOReadBytes,
+			IoWriteBytes:      t.IOWriteBytes,
+			WallSeconds:       t.WallTime.Seconds(),
+		})
+	}
+	return report
+}
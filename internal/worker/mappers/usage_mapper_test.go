@@ -0,0 +1,24 @@
+package mappers
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func TestUsageReportToProtobuf(t *testing.T) {
+	now := time.Now()
+	end := now.Add(-time.Minute)
+	records := []*domain.UsageRecord{
+		{JobID: "1", Tenant: "web", Status: domain.StatusCompleted, StartTime: end.Add(-time.Minute), EndTime: &end, CPUTime: 1500 * time.Millisecond},
+		{JobID: "2", Tenant: "web", Status: domain.StatusRunning, StartTime: now.Add(-time.Minute), MemoryByteSeconds: 42},
+	}
+
+	report := UsageReportToProtobuf(records, now)
+	if len(report.Jobs) != 2 || report.Jobs[0].CpuSeconds != 1.5 || report.Jobs[0].EndTime == "" || report.Jobs[1].EndTime != "" {
+		t.Errorf("jobs not mapped: %v", report.Jobs)
+	}
+	if len(report.Tenants) != 1 || report.Tenants[0].Jobs != 2 || report.Tenants[0].WallSeconds != 120 || report.Tenants[0].MemoryByteSeconds != 42 {
+		t.Errorf("totals not mapped: %v", report.Tenants)
+	}
+}
@@ -0,0 +1,104 @@
+package metrics
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+// ReadCgroup reads the current usage of a cgroup v2 directory. CPU and memory
+// are required; io.stat is optional since the io controller may not be enabled.
+func ReadCgroup(cgroupPath string) (*domain.JobUsage, error) {
+	usage := &domain.JobUsage{SampledAt: time.Now()}
+
+	cpuStat, err := readKeyValues(filepath.Join(cgroupPath, "cpu.stat"))
+	if err != nil {
+		return nil, err
+	}
+	usage.CPUTime = time.Duration(cpuStat["usage_usec"]) * time.Microsecond
+	// only present when the cgroup has a CPU limit
+	usage.CPUPeriods = cpuStat["nr_periods"]
+	usage.CPUThrottledPeriods = cpuStat["nr_throttled"]
+	usage.CPUThrottledTime = time.Duration(cpuStat["throttled_usec"]) * time.Microsecond
+
+	if usage.MemoryBytes, err = readInt(filepath.Join(cgroupPath, "memory.current")); err != nil {
+		return nil, err
+	}
+
+	if usage.IOReadBytes, usage.IOWriteBytes, err = readIOStat(filepath.Join(cgroupPath, "io.stat")); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	return usage, nil
+}
+
+// readKeyValues parses flat "key value" files such as cpu.stat
+func readKeyValues(path string) (map[string]int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]int64)
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		v, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: invalid value for %s: %v", path, fields[0], err)
+		}
+		values[fields[0]] = v
+	}
+	return values, nil
+}
+
+func readInt(path string) (int64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	v, err := strconv.ParseInt(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %v", path, err)
+	}
+	return v, nil
+}
+
+// readIOStat sums rbytes and wbytes over every device in io.stat, whose lines
+// look like "8:0 rbytes=1024 wbytes=4096 rios=1 wios=2 dbytes=0 dios=0"
+func readIOStat(path string) (read, written int64, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		for _, field := range fields[min(1, len(fields)):] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				continue
+			}
+			v, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				continue
+			}
+			switch key {
+			case "rbytes":
+				read += v
+			case "wbytes":
+				written += v
+			}
+		}
+	}
+	return read, written, scanner.Err()
+}
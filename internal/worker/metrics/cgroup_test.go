@@ -0,0 +1,110 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+func writeCgroupFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadCgroup(t *testing.T) {
+	dir := writeCgroupFiles(t, map[string]string{
+		"cpu.stat":       "usage_usec 2500000\nuser_usec 2000000\nsystem_usec 500000\nnr_periods 40\nnr_throttled 10\nthrottled_usec 750000\n",
+		"memory.current": "10485760\n",
+		"io.stat":        "8:0 rbytes=1024 wbytes=4096 rios=1 wios=2 dbytes=0 dios=0\n8:16 rbytes=1000 wbytes=0 rios=3 wios=0 dbytes=0 dios=0\n",
+	})
+
+	usage, err := ReadCgroup(dir)
+	if err != nil {
+		t.Fatalf("ReadCgroup() error = %v", err)
+	}
+
+	if usage.CPUTime != 2500*time.Millisecond {
+		t.Errorf("CPUTime = %v, want 2.5s", usage.CPUTime)
+	}
+	if usage.MemoryBytes != 10485760 {
+		t.Errorf("MemoryBytes = %d", usage.MemoryBytes)
+	}
+	if usage.IOReadBytes != 2024 || usage.IOWriteBytes != 4096 {
+		t.Errorf("IO = %d/%d, want 2024/4096", usage.IOReadBytes, usage.IOWriteBytes)
+	}
+	if usage.CPUThrottledTime != 750*time.Millisecond || usage.ThrottledShare() != 25 {
+		t.Errorf("throttling = %v in %.1f%% of periods, want 750ms in 25%%", usage.CPUThrottledTime, usage.ThrottledShare())
+	}
+}
+
+func TestReadCgroupWithoutIOStat(t *testing.T) {
+	dir := writeCgroupFiles(t, map[string]string{
+		"cpu.stat":       "usage_usec 100\n",
+		"memory.current": "4096\n",
+	})
+
+	usage, err := ReadCgroup(dir)
+	if err != nil {
+		t.Fatalf("ReadCgroup() error = %v", err)
+	}
+	if usage.IOReadBytes != 0 || usage.IOWriteBytes != 0 {
+		t.Errorf("IO = %d/%d, want 0/0", usage.IOReadBytes, usage.IOWriteBytes)
+	}
+	if usage.ThrottledShare() != 0 {
+		t.Errorf("ThrottledShare() = %v without a CPU limit, want 0", usage.ThrottledShare())
+	}
+}
+
+func TestReadCgroupMissing(t *testing.T) {
+	if _, err := ReadCgroup(filepath.Join(t.TempDir(), "gone")); err == nil {
+		t.Error("expected an error for a missing cgroup")
+	}
+}
+
+func TestSamplerCPUPercent(t *testing.T) {
+	start := time.Now()
+	readings := []*domain.JobUsage{
+		{CPUTime: time.Second, CPUPeriods: 100, CPUThrottledPeriods: 90, SampledAt: start},
+		{CPUTime: 2 * time.Second, CPUPeriods: 120, CPUThrottledPeriods: 95, SampledAt: start.Add(2 * time.Second)},
+	}
+
+	sampler := NewSampler()
+	sampler.read = func(string) (*domain.JobUsage, error) {
+		usage := readings[0]
+		readings = readings[1:]
+		return usage, nil
+	}
+
+	job := &domain.Job{Id: "1"}
+	first, err := sampler.Sample(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.CPUPercent != 0 {
+		t.Errorf("first CPUPercent = %v, want 0", first.CPUPercent)
+	}
+
+	second, err := sampler.Sample(job)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second.CPUPercent != 50 {
+		t.Errorf("second CPUPercent = %v, want 50", second.CPUPercent)
+	}
+	if second.ThrottledPercent != 25 {
+		t.Errorf("second ThrottledPercent = %v, want 25", second.ThrottledPercent)
+	}
+
+	sampler.Forget(map[string]bool{})
+	if len(sampler.previous) != 0 {
+		t.Errorf("Forget() left %d readings", len(sampler.previous))
+	}
+}
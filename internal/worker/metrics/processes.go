@@ -0,0 +1,221 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+	"worker/internal/worker/domain"
+)
+
+// procDir is where processes are read from
+var procDir = "/proc"
+
+// clockTicks is USER_HZ, the unit of the times in /proc/<pid>/stat, which
+// the kernel keeps at 100 on every architecture
+const clockTicks = 100
+
+// ReadProcesses returns the process tree of a running job: the processes in
+// its cgroup, or for a job without one, its first process and that process'
+// descendants
+func ReadProcesses(job *domain.Job) ([]domain.JobProcess, error) {
+	return readProcesses(procDir, job.CgroupPath, job.Pid, time.Now())
+}
+
+func readProcesses(proc, cgroupPath string, pid int32, now time.Time) ([]domain.JobProcess, error) {
+	var pids []int32
+	var err error
+	if cgroupPath != "" {
+		pids, err = cgroupPids(cgroupPath)
+	} else {
+		pids, err = descendants(proc, pid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	bootTime, err := readBootTime(proc)
+	if err != nil {
+		return nil, err
+	}
+
+	processes := make([]domain.JobProcess, 0, len(pids))
+	for _, p := range pids {
+		process, err := readProcess(proc, p, bootTime, now)
+		if err != nil {
+			// exited since it was listed
+			continue
+		}
+		processes = append(processes, process)
+	}
+	return domain.ProcessTree(processes), nil
+}
+
+// cgroupPids lists the processes in a cgroup and the cgroups below it
+func cgroupPids(cgroupPath string) ([]int32, error) {
+	var pids []int32
+	err := filepath.WalkDir(cgroupPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			// a child cgroup removed while walking
+			if path != cgroupPath && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		data, err := os.ReadFile(filepath.Join(path, "cgroup.procs"))
+		if err != nil {
+			return err
+		}
+		for _, field := range strings.Fields(string(data)) {
+			pid, err := strconv.ParseInt(field, 10, 32)
+			if err != nil {
+				return fmt.Errorf("%s: invalid pid %q", filepath.Join(path, "cgroup.procs"), field)
+			}
+			pids = append(pids, int32(pid))
+		}
+		return nil
+	})
+	return pids, err
+}
+
+// descendants lists pid and the processes it forked, and they forked, that
+// are still running
+func descendants(proc string, pid int32) ([]int32, error) {
+	if pid <= 0 {
+		return nil, errors.New("job has no process")
+	}
+
+	if _, err := os.Stat(filepath.Join(proc, strconv.Itoa(int(pid)))); err != nil {
+		return nil, fmt.Errorf("process %d of the job is gone", pid)
+	}
+
+	entries, err := os.ReadDir(proc)
+	if err != nil {
+		return nil, err
+	}
+	children := make(map[int32][]int32)
+	for _, entry := range entries {
+		child, err := strconv.ParseInt(entry.Name(), 10, 32)
+		if err != nil {
+			continue
+		}
+		stat, err := readStat(proc, int32(child))
+		if err != nil {
+			continue
+		}
+		children[stat.ppid] = append(children[stat.ppid], int32(child))
+	}
+
+	pids := []int32{pid}
+	for i := 0; i < len(pids); i++ {
+		pids = append(pids, children[pids[i]]...)
+	}
+	return pids, nil
+}
+
+// procStat is what the worker uses of /proc/<pid>/stat
+type procStat struct {
+	comm      string
+	state     string
+	ppid      int32
+	cpuTicks  int64 // utime + stime
+	threads   int32
+	startTick int64 // since boot
+	rssPages  int64
+}
+
+// readStat parses /proc/<pid>/stat, whose second field, the process name in
+// parentheses, may hold spaces and parentheses itself
+func readStat(proc string, pid int32) (*procStat, error) {
+	path := filepath.Join(proc, strconv.Itoa(int(pid)), "stat")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	open, end := bytes.IndexByte(data, '('), bytes.LastIndexByte(data, ')')
+	if open < 0 || end < open {
+		return nil, fmt.Errorf("%s: malformed", path)
+	}
+	fields := strings.Fields(string(data[end+1:]))
+	if len(fields) < 22 {
+		return nil, fmt.Errorf("%s: %d fields, expected at least 24", path, len(fields)+2)
+	}
+
+	numbers := make(map[int]int64)
+	for _, i := range []int{1, 11, 12, 17, 19, 21} {
+		if numbers[i], err = strconv.ParseInt(fields[i], 10, 64); err != nil {
+			return nil, fmt.Errorf("%s: field %d: %v", path, i+3, err)
+		}
+	}
+	return &procStat{
+		comm:      string(data[open+1 : end]),
+		state:     fields[0],
+		ppid:      int32(numbers[1]),
+		cpuTicks:  numbers[11] + numbers[12],
+		threads:   int32(numbers[17]),
+		startTick: numbers[19],
+		rssPages:  numbers[21],
+	}, nil
+}
+
+func readProcess(proc string, pid int32, bootTime, now time.Time) (domain.JobProcess, error) {
+	stat, err := readStat(proc, pid)
+	if err != nil {
+		return domain.JobProcess{}, err
+	}
+
+	command := "[" + stat.comm + "]"
+	if cmdline, err := os.ReadFile(filepath.Join(proc, strconv.Itoa(int(pid)), "cmdline")); err == nil && len(cmdline) > 0 {
+		command = strings.Join(strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00"), " ")
+	}
+
+	process := domain.JobProcess{
+		Pid:       pid,
+		ParentPid: stat.ppid,
+		Command:   command,
+		State:     stat.state,
+		Threads:   stat.threads,
+		CPUTime:   time.Duration(stat.cpuTicks) * time.Second / clockTicks,
+		RSSBytes:  stat.rssPages * int64(os.Getpagesize()),
+		StartTime: bootTime.Add(time.Duration(stat.startTick) * time.Second / clockTicks),
+	}
+	if elapsed := now.Sub(process.StartTime); elapsed > 0 {
+		process.CPUPercent = float64(process.CPUTime) / float64(elapsed) * 100
+	}
+	return process, nil
+}
+
+// readBootTime reads when the system booted from the btime line of /proc/stat
+func readBootTime(proc string) (time.Time, error) {
+	f, err := os.Open(filepath.Join(proc, "stat"))
+	if err != nil {
+		return time.Time{}, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "btime "); ok {
+			seconds, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+			if err != nil {
+				return time.Time{}, fmt.Errorf("%s: invalid btime: %v", f.Name(), err)
+			}
+			return time.Unix(seconds, 0), nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return time.Time{}, err
+	}
+	return time.Time{}, fmt.Errorf("%s: no btime", f.Name())
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeProc writes a /proc with the processes given as pid -> ppid, each
+// started 10s after boot and having used 2.5s of CPU
+func writeProc(t *testing.T, processes map[int]int, cmdlines map[int]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "stat"), []byte("cpu  1 2 3 4\nbtime 1700000000\nprocesses 42\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for pid, ppid := range processes {
+		procDir := filepath.Join(dir, fmt.Sprint(pid))
+		if err := os.MkdirAll(procDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		stat := fmt.Sprintf("%d (my (odd) name) S %d 1 1 0 -1 4194304 100 0 0 0 200 50 0 0 20 0 3 0 1000 1000000 25 18446744073709551615\n", pid, ppid)
+		if err := os.WriteFile(filepath.Join(procDir, "stat"), []byte(stat), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(procDir, "cmdline"), []byte(cmdlines[pid]), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestReadProcessesFromCgroup(t *testing.T) {
+	proc := writeProc(t, map[int]int{100: 1, 101: 100, 102: 101}, map[int]string{100: "sh\x00-c\x00make -j2\x00", 101: "make\x00-j2\x00"})
+	cgroup := writeCgroupFiles(t, map[string]string{"cgroup.procs": "100\n101\n"})
+	if err := os.Mkdir(filepath.Join(cgroup, "child"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cgroup, "child", "cgroup.procs"), []byte("102\n103\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	started := time.Unix(1700000010, 0)
+	processes, err := readProcesses(proc, cgroup, 100, started.Add(5*time.Second))
+	if err != nil {
+		t.Fatalf("readProcesses() error = %v", err)
+	}
+
+	// 103 exited after it was listed
+	if len(processes) != 3 {
+		t.Fatalf("expected 3 processes, got %+v", processes)
+	}
+	top, child, grandchild := processes[0], processes[1], processes[2]
+	if top.Pid != 100 || top.Command != "sh -c make -j2" || top.Depth != 0 {
+		t.Errorf("unexpected top process %+v", top)
+	}
+	if child.Pid != 101 || child.Depth != 1 || grandchild.Pid != 102 || grandchild.Depth != 2 {
+		t.Errorf("unexpected tree %+v", processes)
+	}
+	if grandchild.Command != "[my (odd) name]" {
+		t.Errorf("expected a process without a command line to show its name, got %q", grandchild.Command)
+	}
+	if top.State != "S" || top.Threads != 3 || top.CPUTime != 2500*time.Millisecond || top.CPUPercent != 50 {
+		t.Errorf("unexpected stats %+v", top)
+	}
+	if !top.StartTime.Equal(started) || top.RSSBytes != 25*int64(os.Getpagesize()) {
+		t.Errorf("unexpected start time or memory %+v", top)
+	}
+}
+
+func TestReadProcessesWithoutCgroup(t *testing.T) {
+	proc := writeProc(t, map[int]int{1: 0, 100: 1, 101: 100, 200: 1}, nil)
+
+	processes, err := readProcesses(proc, "", 100, time.Now())
+	if err != nil {
+		t.Fatalf("readProcesses() error = %v", err)
+	}
+	if len(processes) != 2 || processes[0].Pid != 100 || processes[1].Pid != 101 {
+		t.Errorf("expected the job's process and its child, got %+v", processes)
+	}
+
+	if _, err := readProcesses(proc, "", 300, time.Now()); err == nil {
+		t.Error("expected an error for a job whose process is gone")
+	}
+}
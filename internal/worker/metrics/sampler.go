@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"sync"
+	"worker/internal/worker/domain"
+)
+
+// Sampler reads job cgroups and derives CPU and throttling percentages from
+// the previous reading of the same job. Each metrics stream uses its own
+// sampler, so the percentages cover the stream's own interval.
+type Sampler struct {
+	read     func(cgroupPath string) (*domain.JobUsage, error)
+	previous map[string]*domain.JobUsage
+	mutex    sync.Mutex
+}
+
+func NewSampler() *Sampler {
+	return &Sampler{
+		read:     ReadCgroup,
+		previous: make(map[string]*domain.JobUsage),
+	}
+}
+
+// Sample returns the current usage of the job
+func (s *Sampler) Sample(job *domain.Job) (*domain.JobUsage, error) {
+	usage, err := s.read(job.CgroupPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if prev, exists := s.previous[job.Id]; exists {
+		elapsed := usage.SampledAt.Sub(prev.SampledAt)
+		if elapsed > 0 && usage.CPUTime >= prev.CPUTime {
+			usage.CPUPercent = float64(usage.CPUTime-prev.CPUTime) / float64(elapsed) * 100
+		}
+		if periods := usage.CPUPeriods - prev.CPUPeriods; periods > 0 && usage.CPUThrottledPeriods >= prev.CPUThrottledPeriods {
+			usage.ThrottledPercent = float64(usage.CPUThrottledPeriods-prev.CPUThrottledPeriods) / float64(periods) * 100
+		}
+	}
+	s.previous[job.Id] = usage
+
+	return usage, nil
+}
+
+// Forget drops the readings of jobs that are not in keep
+func (s *Sampler) Forget(keep map[string]bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for id := range s.previous {
+		if !keep[id] {
+			delete(s.previous, id)
+		}
+	}
+}
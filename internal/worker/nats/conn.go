@@ -0,0 +1,433 @@
+package nats
+
+import (
+	"bufio"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrClosed is returned for operations on a connection that was closed or lost
+var ErrClosed = errors.New("nats connection closed")
+
+// serverInfo is the part of the server's INFO message the client uses
+type serverInfo struct {
+	TLSRequired bool `json:"tls_required"`
+	MaxPayload  int  `json:"max_payload"`
+}
+
+type connectOptions struct {
+	Verbose  bool   `json:"verbose"`
+	Pedantic bool   `json:"pedantic"`
+	Name     string `json:"name"`
+	Lang     string `json:"lang"`
+	Version  string `json:"version"`
+	User     string `json:"user,omitempty"`
+	Pass     string `json:"pass,omitempty"`
+	Token    string `json:"auth_token,omitempty"`
+}
+
+// Conn is a client connection speaking the core NATS text protocol. A read
+// loop answers server pings and reports protocol errors; once it ends the
+// connection is unusable and a new one must be dialed.
+type Conn struct {
+	conn    net.Conn
+	timeout time.Duration
+	info    serverInfo
+
+	writeMu sync.Mutex
+	writer  *bufio.Writer
+
+	mutex  sync.Mutex
+	err    error // why the connection ended, nil while it is up
+	pongs  []chan struct{}
+	subs   map[int]*Subscription
+	nextID int
+	closed chan struct{}
+}
+
+// Msg is a message delivered to a subscription
+type Msg struct {
+	Subject string
+	Reply   string // subject to respond to, "" if the publisher expects no response
+	Header  string // raw NATS/1.0 header block of HMSG messages, "" for MSG
+	Data    []byte
+}
+
+// Status returns the status code of a header-only status message, such as
+// the 404 or 408 a JetStream pull request ends with, and 0 for other messages
+func (m *Msg) Status() int {
+	line, _, _ := strings.Cut(m.Header, "\r\n")
+	fields := strings.Fields(line)
+	if len(fields) < 2 || fields[0] != "NATS/1.0" {
+		return 0
+	}
+	code, _ := strconv.Atoi(fields[1])
+	return code
+}
+
+// Subscription receives the messages of a subject. Messages arriving while C
+// is full are dropped, as a NATS server drops messages for slow consumers.
+type Subscription struct {
+	C <-chan *Msg
+
+	sid     int
+	msgs    chan *Msg
+	dropped int
+}
+
+// subscriptionBuffer is how many messages a subscription holds before dropping
+const subscriptionBuffer = 256
+
+// Dial connects to a server given as nats://[user[:pass]@]host:port, or
+// tls:// to require TLS. A user without a password is sent as a token. Dial
+// returns once the server accepted the connection.
+func Dial(address string, timeout time.Duration) (*Conn, error) {
+	u, err := url.Parse(address)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid nats url: %s", address)
+	}
+	if u.Scheme != "nats" && u.Scheme != "tls" {
+		return nil, fmt.Errorf("unsupported nats url scheme: %s", u.Scheme)
+	}
+
+	host := u.Host
+	if u.Port() == "" {
+		host = net.JoinHostPort(u.Hostname(), "4222")
+	}
+
+	raw, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to nats: %w", err)
+	}
+
+	c := &Conn{conn: raw, timeout: timeout, subs: make(map[int]*Subscription), closed: make(chan struct{})}
+	if err := c.handshake(u); err != nil {
+		_ = c.conn.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// handshake reads INFO, upgrades to TLS when required, then sends CONNECT and
+// waits for the PONG of a PING, or the error rejecting the connection
+func (c *Conn) handshake(u *url.URL) error {
+	_ = c.conn.SetDeadline(time.Now().Add(c.timeout))
+
+	reader := bufio.NewReader(c.conn)
+	line, err := readLine(reader)
+	if err != nil {
+		return fmt.Errorf("failed to read nats server info: %w", err)
+	}
+	op, args, _ := strings.Cut(line, " ")
+	if !strings.EqualFold(op, "INFO") {
+		return fmt.Errorf("unexpected nats greeting: %q", line)
+	}
+	if err := json.Unmarshal([]byte(args), &c.info); err != nil {
+		return fmt.Errorf("invalid nats server info: %w", err)
+	}
+
+	if c.info.TLSRequired || u.Scheme == "tls" {
+		tlsConn := tls.Client(c.conn, &tls.Config{ServerName: u.Hostname(), MinVersion: tls.VersionTLS12})
+		if err := tlsConn.Handshake(); err != nil {
+			return fmt.Errorf("nats tls handshake failed: %w", err)
+		}
+		c.conn = tlsConn
+		reader = bufio.NewReader(c.conn)
+	}
+
+	opts := connectOptions{Name: "worker", Lang: "go", Version: "1.0"}
+	if u.User != nil {
+		if pass, ok := u.User.Password(); ok {
+			opts.User, opts.Pass = u.User.Username(), pass
+		} else {
+			opts.Token = u.User.Username()
+		}
+	}
+	connect, err := json.Marshal(opts)
+	if err != nil {
+		return err
+	}
+
+	c.writer = bufio.NewWriter(c.conn)
+	if _, err := fmt.Fprintf(c.writer, "CONNECT %s\r\nPING\r\n", connect); err != nil {
+		return err
+	}
+	if err := c.writer.Flush(); err != nil {
+		return fmt.Errorf("failed to send nats connect: %w", err)
+	}
+
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return fmt.Errorf("nats connect failed: %w", err)
+		}
+		switch {
+		case strings.EqualFold(line, "PONG"):
+			_ = c.conn.SetDeadline(time.Time{})
+			go c.readLoop(reader)
+			return nil
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("nats server rejected connection: %s", protocolError(line))
+		}
+	}
+}
+
+// Publish sends data to subject. It only fails when the data cannot be
+// written; use Flush to know the server received it.
+func (c *Conn) Publish(subject string, data []byte) error {
+	if c.info.MaxPayload > 0 && len(data) > c.info.MaxPayload {
+		return fmt.Errorf("nats payload of %d bytes exceeds the server maximum of %d", len(data), c.info.MaxPayload)
+	}
+	return c.write(fmt.Sprintf("PUB %s %d\r\n", subject, len(data)), data, []byte("\r\n"))
+}
+
+// PublishRequest sends data to subject, asking for responses on reply
+func (c *Conn) PublishRequest(subject, reply string, data []byte) error {
+	return c.write(fmt.Sprintf("PUB %s %s %d\r\n", subject, reply, len(data)), data, []byte("\r\n"))
+}
+
+// Subscribe receives the messages published to subject. With a queue group,
+// each message goes to only one of the group's subscribers. C is closed when
+// the connection ends.
+func (c *Conn) Subscribe(subject, queue string) (*Subscription, error) {
+	c.mutex.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mutex.Unlock()
+		return nil, err
+	}
+	c.nextID++
+	msgs := make(chan *Msg, subscriptionBuffer)
+	sub := &Subscription{C: msgs, sid: c.nextID, msgs: msgs}
+	c.subs[sub.sid] = sub
+	c.mutex.Unlock()
+
+	command := fmt.Sprintf("SUB %s %d\r\n", subject, sub.sid)
+	if queue != "" {
+		command = fmt.Sprintf("SUB %s %s %d\r\n", subject, queue, sub.sid)
+	}
+	if err := c.write(command); err != nil {
+		return nil, err
+	}
+	return sub, nil
+}
+
+// Dropped returns how many messages were dropped because C was full
+func (c *Conn) Dropped(sub *Subscription) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return sub.dropped
+}
+
+// inboxCounter makes inboxes created in the same nanosecond unique
+var inboxCounter atomic.Uint64
+
+// NewInbox returns a unique subject for responses
+func NewInbox() string {
+	return "_INBOX." + strconv.FormatInt(time.Now().UnixNano(), 36) + "." + strconv.FormatUint(inboxCounter.Add(1), 36)
+}
+
+// Flush waits until the server processed everything sent before it
+func (c *Conn) Flush() error {
+	pong := make(chan struct{})
+	c.mutex.Lock()
+	if c.err != nil {
+		err := c.err
+		c.mutex.Unlock()
+		return err
+	}
+	c.pongs = append(c.pongs, pong)
+	c.mutex.Unlock()
+
+	if err := c.write("PING\r\n"); err != nil {
+		return err
+	}
+
+	select {
+	case <-pong:
+		return nil
+	case <-c.closed:
+		return c.Err()
+	case <-time.After(c.timeout):
+		return fmt.Errorf("nats flush timed out after %v", c.timeout)
+	}
+}
+
+// Err returns why the connection ended, nil while it is up
+func (c *Conn) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// Close closes the connection
+func (c *Conn) Close() error {
+	c.fail(ErrClosed)
+	return nil
+}
+
+func (c *Conn) write(parts ...interface{}) error {
+	if err := c.Err(); err != nil {
+		return err
+	}
+
+	c.writeMu.Lock()
+	defer c.writeMu.Unlock()
+
+	_ = c.conn.SetWriteDeadline(time.Now().Add(c.timeout))
+	for _, part := range parts {
+		var err error
+		switch p := part.(type) {
+		case string:
+			_, err = c.writer.WriteString(p)
+		case []byte:
+			_, err = c.writer.Write(p)
+		}
+		if err != nil {
+			c.fail(err)
+			return err
+		}
+	}
+	if err := c.writer.Flush(); err != nil {
+		c.fail(err)
+		return err
+	}
+	return nil
+}
+
+// fail ends the connection with err, keeping the first reason
+func (c *Conn) fail(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err != nil {
+		return
+	}
+	c.err = err
+	close(c.closed)
+	for sid, sub := range c.subs {
+		close(sub.msgs)
+		delete(c.subs, sid)
+	}
+	_ = c.conn.Close()
+}
+
+// deliver hands a message to its subscription, dropping it when the
+// subscription is full or gone
+func (c *Conn) deliver(sid int, msg *Msg) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	sub, exists := c.subs[sid]
+	if !exists {
+		return
+	}
+	select {
+	case sub.msgs <- msg:
+	default:
+		sub.dropped++
+	}
+}
+
+func (c *Conn) readLoop(reader *bufio.Reader) {
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			c.fail(fmt.Errorf("nats connection lost: %w", err))
+			return
+		}
+
+		op, args, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(op) {
+		case "MSG", "HMSG":
+			sid, msg, err := readMsg(reader, strings.EqualFold(op, "HMSG"), args)
+			if err != nil {
+				c.fail(fmt.Errorf("nats protocol error: %w", err))
+				return
+			}
+			c.deliver(sid, msg)
+		case "PING":
+			_ = c.write("PONG\r\n")
+		case "PONG":
+			c.mutex.Lock()
+			if len(c.pongs) > 0 {
+				close(c.pongs[0])
+				c.pongs = c.pongs[1:]
+			}
+			c.mutex.Unlock()
+		case "-ERR":
+			// the server closes the connection after most errors, a
+			// rejected publish permission leaves it open
+			if strings.Contains(strings.ToLower(line), "permissions violation") {
+				continue
+			}
+			c.fail(fmt.Errorf("nats server error: %s", protocolError(line)))
+			return
+		}
+	}
+}
+
+// readMsg reads the payload of MSG <subject> <sid> [reply] <size> or
+// HMSG <subject> <sid> [reply] <header size> <total size>
+func readMsg(reader *bufio.Reader, withHeader bool, args string) (int, *Msg, error) {
+	fields := strings.Fields(args)
+	sizes := 1
+	if withHeader {
+		sizes = 2
+	}
+	if len(fields) != 2+sizes && len(fields) != 3+sizes {
+		return 0, nil, fmt.Errorf("malformed message line %q", args)
+	}
+
+	sid, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0, nil, fmt.Errorf("malformed subscription id %q", fields[1])
+	}
+	msg := &Msg{Subject: fields[0]}
+	if len(fields) == 3+sizes {
+		msg.Reply = fields[2]
+	}
+
+	total, err := strconv.Atoi(fields[len(fields)-1])
+	if err != nil || total < 0 {
+		return 0, nil, fmt.Errorf("malformed message size %q", fields[len(fields)-1])
+	}
+	headerSize := 0
+	if withHeader {
+		headerSize, err = strconv.Atoi(fields[len(fields)-2])
+		if err != nil || headerSize < 0 || headerSize > total {
+			return 0, nil, fmt.Errorf("malformed header size %q", fields[len(fields)-2])
+		}
+	}
+
+	payload := make([]byte, total+2)
+	if _, err := io.ReadFull(reader, payload); err != nil {
+		return 0, nil, err
+	}
+	msg.Header = string(payload[:headerSize])
+	msg.Data = payload[headerSize:total]
+	return sid, msg, nil
+}
+
+func readLine(reader *bufio.Reader) (string, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// protocolError extracts the message of an -ERR line
+func protocolError(line string) string {
+	return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "-ERR")), "'")
+}
@@ -0,0 +1,244 @@
+package nats
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeServer speaks enough of the NATS protocol to accept a client, record
+// what it publishes and deliver it to subscribers
+type fakeServer struct {
+	listener net.Listener
+	info     string
+	reject   string // -ERR message sent instead of accepting CONNECT
+
+	mutex       sync.Mutex
+	connects    []string
+	published   map[string][]string
+	subscribers map[string][]fakeSubscriber
+}
+
+type fakeSubscriber struct {
+	conn net.Conn
+	sid  string
+}
+
+func newFakeServer(t *testing.T) *fakeServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &fakeServer{listener: listener, info: `{"max_payload":1024}`, published: make(map[string][]string), subscribers: make(map[string][]fakeSubscriber)}
+	t.Cleanup(func() { _ = listener.Close() })
+	go s.serve()
+	return s
+}
+
+func (s *fakeServer) url() string {
+	return "nats://" + s.listener.Addr().String()
+}
+
+func (s *fakeServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *fakeServer) handle(conn net.Conn) {
+	defer conn.Close()
+	fmt.Fprintf(conn, "INFO %s\r\n", s.info)
+
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := readLine(reader)
+		if err != nil {
+			return
+		}
+		op, args, _ := strings.Cut(line, " ")
+		switch op {
+		case "CONNECT":
+			s.mutex.Lock()
+			s.connects = append(s.connects, args)
+			s.mutex.Unlock()
+			if s.reject != "" {
+				fmt.Fprintf(conn, "-ERR '%s'\r\n", s.reject)
+				return
+			}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		case "SUB":
+			fields := strings.Fields(args)
+			s.mutex.Lock()
+			s.subscribers[fields[0]] = append(s.subscribers[fields[0]], fakeSubscriber{conn: conn, sid: fields[len(fields)-1]})
+			s.mutex.Unlock()
+		case "PUB":
+			fields := strings.Fields(args)
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			reply := ""
+			if len(fields) == 3 {
+				reply = " " + fields[1]
+			}
+			s.mutex.Lock()
+			s.published[fields[0]] = append(s.published[fields[0]], string(payload[:size]))
+			for _, sub := range s.subscribers[fields[0]] {
+				fmt.Fprintf(sub.conn, "MSG %s %s%s %d\r\n%s", fields[0], sub.sid, reply, size, payload)
+			}
+			s.mutex.Unlock()
+		}
+	}
+}
+
+func (s *fakeServer) messages(subject string) []string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]string(nil), s.published[subject]...)
+}
+
+func TestPublishAndFlush(t *testing.T) {
+	server := newFakeServer(t)
+
+	conn, err := Dial(server.url(), time.Second)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.Publish("jobs.events", []byte("one")); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Publish("jobs.events", []byte("two\r\nlines")); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	got := server.messages("jobs.events")
+	if len(got) != 2 || got[0] != "one" || got[1] != "two\r\nlines" {
+		t.Errorf("unexpected messages %q", got)
+	}
+}
+
+func TestPublishRejectsOversizedPayload(t *testing.T) {
+	server := newFakeServer(t)
+
+	conn, err := Dial(server.url(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	if err := conn.Publish("jobs", make([]byte, 2048)); err == nil {
+		t.Error("expected a payload over max_payload to be rejected")
+	}
+}
+
+func TestDialSendsCredentials(t *testing.T) {
+	server := newFakeServer(t)
+	address := strings.Replace(server.url(), "nats://", "nats://worker:s3cret@", 1)
+
+	conn, err := Dial(address, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	if len(server.connects) != 1 || !strings.Contains(server.connects[0], `"user":"worker"`) || !strings.Contains(server.connects[0], `"pass":"s3cret"`) {
+		t.Errorf("unexpected CONNECT %q", server.connects)
+	}
+}
+
+func TestDialRejected(t *testing.T) {
+	server := newFakeServer(t)
+	server.reject = "Authorization Violation"
+
+	_, err := Dial(server.url(), time.Second)
+	if err == nil || !strings.Contains(err.Error(), "Authorization Violation") {
+		t.Errorf("expected the rejection, got %v", err)
+	}
+}
+
+func TestClosedConnection(t *testing.T) {
+	server := newFakeServer(t)
+
+	conn, err := Dial(server.url(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+
+	if err := conn.Publish("jobs", []byte("late")); err != ErrClosed {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+func TestSubscribeReceivesRequests(t *testing.T) {
+	server := newFakeServer(t)
+
+	conn, err := Dial(server.url(), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	sub, err := conn.Subscribe("jobs.submit", "workers")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.PublishRequest("jobs.submit", "_INBOX.1", []byte("run\r\nme")); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case msg := <-sub.C:
+		if msg.Subject != "jobs.submit" || msg.Reply != "_INBOX.1" || string(msg.Data) != "run\r\nme" {
+			t.Errorf("unexpected message %+v", msg)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the published message")
+	}
+
+	conn.Close()
+	if _, open := <-sub.C; open {
+		t.Error("expected the subscription to close with the connection")
+	}
+}
+
+func TestReadStatusMessage(t *testing.T) {
+	header := "NATS/1.0 408 Request Timeout\r\n\r\n"
+	line := fmt.Sprintf("_INBOX.1 3 %d %d", len(header), len(header))
+	reader := bufio.NewReader(strings.NewReader(header + "\r\n"))
+
+	sid, msg, err := readMsg(reader, true, line)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sid != 3 || msg.Status() != 408 || len(msg.Data) != 0 {
+		t.Errorf("unexpected status message %d %+v", sid, msg)
+	}
+
+	if _, _, err := readMsg(reader, false, "jobs 1 x"); err == nil {
+		t.Error("expected a malformed size to be rejected")
+	}
+}
@@ -0,0 +1,59 @@
+package offload
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"worker/pkg/config"
+)
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
+
+// Backend stores objects in a bucket
+//
+//counterfeiter:generate . Backend
+type Backend interface {
+	Name() string
+	// URI returns the scheme-qualified location of a key, e.g. s3://bucket/key
+	URI(key string) string
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	// DeletePrefix removes every object whose key starts with prefix
+	DeletePrefix(ctx context.Context, prefix string) error
+}
+
+// ObjectReader reads objects from the buckets of an object store
+//
+//counterfeiter:generate . ObjectReader
+type ObjectReader interface {
+	Get(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+}
+
+// NewObjectReader creates a reader for the object store selected by cfg.Type;
+// cfg.Bucket is not used. A zero cfg.Timeout leaves reads of large objects
+// unbounded, ctx is what ends them.
+func NewObjectReader(cfg config.OffloadConfig) (ObjectReader, error) {
+	backend, err := NewBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return backend.(*s3Backend), nil
+}
+
+// NewBackend creates the object storage backend selected by cfg.Type
+func NewBackend(cfg config.OffloadConfig) (Backend, error) {
+	switch cfg.Type {
+	case "s3":
+		return newS3Backend(cfg, "s3")
+	case "gcs":
+		// GCS is reached through its S3-compatible XML API using HMAC keys
+		if cfg.Endpoint == "" {
+			cfg.Endpoint = "https://storage.googleapis.com"
+		}
+		if cfg.Region == "" {
+			cfg.Region = "auto"
+		}
+		return newS3Backend(cfg, "gs")
+	default:
+		return nil, fmt.Errorf("unsupported offload backend type: %s", cfg.Type)
+	}
+}
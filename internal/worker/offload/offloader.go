@@ -0,0 +1,202 @@
+package offload
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Offloader uploads the output, metadata and artifacts of finished jobs to
+// object storage under <prefix>/<jobId>/
+type Offloader struct {
+	backend    Backend
+	prefix     string
+	artifacts  []string
+	evictLocal bool
+	logger     *logger.Logger
+}
+
+// jobRecord is the metadata stored next to the offloaded output. Environment
+// variables are deliberately left out since they may carry credentials.
+type jobRecord struct {
+	ID               string   `json:"id"`
+	Command          string   `json:"command"`
+	Args             []string `json:"args"`
+	Status           string   `json:"status"`
+	ExitCode         int32    `json:"exitCode"`
+	StartTime        string   `json:"startTime"`
+	EndTime          string   `json:"endTime,omitempty"`
+	CPULimitMillis   int64    `json:"cpuLimitMillis"`
+	MemoryLimitBytes int64    `json:"memoryLimitBytes"`
+	IOLimitBPS       int64    `json:"ioLimitBPS"`
+}
+
+// New creates an offloader from configuration. It returns nil when offloading
+// is disabled; all Offloader methods are safe to call on nil.
+func New(cfg config.OffloadConfig) (*Offloader, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	backend, err := NewBackend(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewWithBackend(backend, cfg), nil
+}
+
+// NewWithBackend creates an offloader for an already constructed backend
+func NewWithBackend(backend Backend, cfg config.OffloadConfig) *Offloader {
+	o := &Offloader{
+		backend:    backend,
+		prefix:     cfg.Prefix,
+		artifacts:  cfg.Artifacts,
+		evictLocal: cfg.EvictLocal,
+		logger:     logger.WithField("component", "offloader"),
+	}
+
+	o.logger.Info("job offloading enabled", "backend", backend.Name(), "bucket", cfg.Bucket, "prefix", cfg.Prefix)
+	return o
+}
+
+// EvictLocal reports whether local copies should be dropped after a successful upload
+func (o *Offloader) EvictLocal() bool {
+	return o != nil && o.evictLocal
+}
+
+// OffloadJob uploads the job metadata, its output and any matching workspace
+// artifacts, returning the location they were stored under
+func (o *Offloader) OffloadJob(ctx context.Context, job *domain.Job, output []byte) (string, error) {
+	if o == nil {
+		return "", nil
+	}
+
+	base := path.Join(o.prefix, job.Id)
+
+	record, err := json.MarshalIndent(newJobRecord(job), "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode job record: %w", err)
+	}
+	if err := o.backend.Put(ctx, path.Join(base, "job.json"), bytes.NewReader(record), int64(len(record)), "application/json"); err != nil {
+		return "", err
+	}
+
+	if err := o.backend.Put(ctx, path.Join(base, "output.log"), bytes.NewReader(output), int64(len(output)), "text/plain"); err != nil {
+		return "", err
+	}
+
+	if job.Workspace != "" {
+		uploaded, err := o.uploadArtifacts(ctx, base, job.Workspace)
+		if err != nil {
+			return "", err
+		}
+		o.logger.Debug("job artifacts uploaded", "jobId", job.Id, "files", uploaded)
+	}
+
+	location := o.backend.URI(base)
+	o.logger.Debug("job offloaded", "jobId", job.Id, "location", location, "outputSize", len(output))
+
+	return location, nil
+}
+
+// DeleteJob removes everything offloaded of a job: its metadata, output and
+// artifacts
+func (o *Offloader) DeleteJob(ctx context.Context, jobID string) error {
+	if o == nil {
+		return nil
+	}
+
+	base := path.Join(o.prefix, jobID) + "/"
+	if err := o.backend.DeletePrefix(ctx, base); err != nil {
+		return err
+	}
+
+	o.logger.Debug("offloaded job deleted", "jobId", jobID, "location", o.backend.URI(base))
+	return nil
+}
+
+func (o *Offloader) uploadArtifacts(ctx context.Context, base, workspace string) (int, error) {
+	uploaded := 0
+
+	err := filepath.WalkDir(workspace, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(workspace, p)
+		if err != nil {
+			return err
+		}
+		if !o.matchesArtifact(rel) {
+			return nil
+		}
+
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			return err
+		}
+
+		key := path.Join(base, "artifacts", filepath.ToSlash(rel))
+		if err := o.backend.Put(ctx, key, f, info.Size(), "application/octet-stream"); err != nil {
+			return err
+		}
+		uploaded++
+		return nil
+	})
+	if err != nil {
+		return uploaded, fmt.Errorf("artifact upload failed: %w", err)
+	}
+
+	return uploaded, nil
+}
+
+// matchesArtifact reports whether a workspace-relative path should be uploaded.
+// With no patterns configured the whole workspace is uploaded.
+func (o *Offloader) matchesArtifact(rel string) bool {
+	if len(o.artifacts) == 0 {
+		return true
+	}
+
+	for _, pattern := range o.artifacts {
+		if ok, _ := filepath.Match(pattern, rel); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func newJobRecord(job *domain.Job) jobRecord {
+	record := jobRecord{
+		ID:               job.Id,
+		Command:          job.Command,
+		Args:             job.Args,
+		Status:           string(job.Status),
+		ExitCode:         job.ExitCode,
+		StartTime:        job.StartTime.Format("2006-01-02T15:04:05Z07:00"),
+		CPULimitMillis:   job.Limits.CPUMillis,
+		MemoryLimitBytes: job.Limits.MemoryBytes,
+		IOLimitBPS:       job.Limits.IOBPS,
+	}
+	if job.EndTime != nil {
+		record.EndTime = job.EndTime.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return record
+}
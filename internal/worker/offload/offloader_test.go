@@ -0,0 +1,125 @@
+package offload_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/offload"
+	"worker/internal/worker/offload/offloadfakes"
+	"worker/pkg/config"
+)
+
+func newTestJob(workspace string) *domain.Job {
+	end := time.Now()
+	return &domain.Job{
+		Id:        "7",
+		Command:   "/usr/bin/python3",
+		Args:      []string{"train.py"},
+		Env:       map[string]string{"API_KEY": "should-not-be-uploaded"},
+		Status:    domain.StatusCompleted,
+		StartTime: end.Add(-time.Minute),
+		EndTime:   &end,
+		Workspace: workspace,
+	}
+}
+
+func TestOffloadJob_UploadsOutputAndArtifacts(t *testing.T) {
+	workspace := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(workspace, "out"), 0755)
+	_ = os.WriteFile(filepath.Join(workspace, "out", "model.bin"), []byte("weights"), 0644)
+	_ = os.WriteFile(filepath.Join(workspace, "train.py"), []byte("print(1)"), 0644)
+
+	backend := &offloadfakes.FakeBackend{}
+	backend.URIStub = func(key string) string { return "s3://bucket/" + key }
+
+	uploaded := map[string]string{}
+	backend.PutStub = func(_ context.Context, key string, body io.Reader, _ int64, _ string) error {
+		data, _ := io.ReadAll(body)
+		uploaded[key] = string(data)
+		return nil
+	}
+
+	o := offload.NewWithBackend(backend, config.OffloadConfig{Prefix: "jobs", Artifacts: []string{"out/*"}})
+
+	location, err := o.OffloadJob(context.Background(), newTestJob(workspace), []byte("epoch 1\n"))
+	if err != nil {
+		t.Fatalf("OffloadJob failed: %v", err)
+	}
+	if location != "s3://bucket/jobs/7" {
+		t.Errorf("unexpected location %s", location)
+	}
+
+	keys := make([]string, 0, len(uploaded))
+	for k := range uploaded {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	expected := []string{"jobs/7/artifacts/out/model.bin", "jobs/7/job.json", "jobs/7/output.log"}
+	if len(keys) != len(expected) {
+		t.Fatalf("expected uploads %v, got %v", expected, keys)
+	}
+	for i := range expected {
+		if keys[i] != expected[i] {
+			t.Errorf("expected upload %s, got %s", expected[i], keys[i])
+		}
+	}
+
+	if uploaded["jobs/7/output.log"] != "epoch 1\n" {
+		t.Errorf("unexpected output upload %q", uploaded["jobs/7/output.log"])
+	}
+	if strings.Contains(uploaded["jobs/7/job.json"], "should-not-be-uploaded") {
+		t.Error("job record must not include environment values")
+	}
+}
+
+func TestOffloadJob_BackendError(t *testing.T) {
+	backend := &offloadfakes.FakeBackend{}
+	backend.PutReturns(errors.New("bucket unreachable"))
+
+	o := offload.NewWithBackend(backend, config.OffloadConfig{Prefix: "jobs"})
+	if _, err := o.OffloadJob(context.Background(), newTestJob(""), []byte("x")); err == nil {
+		t.Error("expected backend error to be returned")
+	}
+}
+
+func TestDeleteJob_DeletesEverythingUnderTheJob(t *testing.T) {
+	backend := &offloadfakes.FakeBackend{}
+	o := offload.NewWithBackend(backend, config.OffloadConfig{Prefix: "jobs"})
+
+	if err := o.DeleteJob(context.Background(), "7"); err != nil {
+		t.Fatal(err)
+	}
+	// the trailing slash keeps job 70 from being deleted along with job 7
+	if backend.DeletePrefixCallCount() != 1 {
+		t.Fatalf("expected one delete, got %d", backend.DeletePrefixCallCount())
+	}
+	if _, prefix := backend.DeletePrefixArgsForCall(0); prefix != "jobs/7/" {
+		t.Errorf("unexpected prefix %q", prefix)
+	}
+
+	backend.DeletePrefixReturns(errors.New("bucket unreachable"))
+	if err := o.DeleteJob(context.Background(), "7"); err == nil {
+		t.Error("expected backend error to be returned")
+	}
+}
+
+func TestNilOffloader(t *testing.T) {
+	var o *offload.Offloader
+
+	if o.EvictLocal() {
+		t.Error("nil offloader must not evict")
+	}
+	if location, err := o.OffloadJob(context.Background(), newTestJob(""), nil); err != nil || location != "" {
+		t.Errorf("expected no-op on nil offloader, got %q, %v", location, err)
+	}
+	if err := o.DeleteJob(context.Background(), "7"); err != nil {
+		t.Errorf("expected no-op on nil offloader, got %v", err)
+	}
+}
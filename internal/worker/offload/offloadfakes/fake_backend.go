@@ -0,0 +1,335 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package offloadfakes
+
+import (
+	"context"
+	"io"
+	"sync"
+	"worker/internal/worker/offload"
+)
+
+type FakeBackend struct {
+	DeletePrefixStub        func(context.Context, string) error
+	deletePrefixMutex       sync.RWMutex
+	deletePrefixArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	deletePrefixReturns struct {
+		result1 error
+	}
+	deletePrefixReturnsOnCall map[int]struct {
+		result1 error
+	}
+	NameStub        func() string
+	nameMutex       sync.RWMutex
+	nameArgsForCall []struct {
+	}
+	nameReturns struct {
+		result1 string
+	}
+	nameReturnsOnCall map[int]struct {
+		result1 string
+	}
+	PutStub        func(context.Context, string, io.Reader, int64, string) error
+	putMutex       sync.RWMutex
+	putArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+		arg4 int64
+		arg5 string
+	}
+	putReturns struct {
+		result1 error
+	}
+	putReturnsOnCall map[int]struct {
+		result1 error
+	}
+	URIStub        func(string) string
+	uRIMutex       sync.RWMutex
+	uRIArgsForCall []struct {
+		arg1 string
+	}
+	uRIReturns struct {
+		result1 string
+	}
+	uRIReturnsOnCall map[int]struct {
+		result1 string
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBackend) DeletePrefix(arg1 context.Context, arg2 string) error {
+	fake.deletePrefixMutex.Lock()
+	ret, specificReturn := fake.deletePrefixReturnsOnCall[len(fake.deletePrefixArgsForCall)]
+	fake.deletePrefixArgsForCall = append(fake.deletePrefixArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DeletePrefixStub
+	fakeReturns := fake.deletePrefixReturns
+	fake.recordInvocation("DeletePrefix", []interface{}{arg1, arg2})
+	fake.deletePrefixMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) DeletePrefixCallCount() int {
+	fake.deletePrefixMutex.RLock()
+	defer fake.deletePrefixMutex.RUnlock()
+	return len(fake.deletePrefixArgsForCall)
+}
+
+func (fake *FakeBackend) DeletePrefixCalls(stub func(context.Context, string) error) {
+	fake.deletePrefixMutex.Lock()
+	defer fake.deletePrefixMutex.Unlock()
+	fake.DeletePrefixStub = stub
+}
+
+func (fake *FakeBackend) DeletePrefixArgsForCall(i int) (context.Context, string) {
+	fake.deletePrefixMutex.RLock()
+	defer fake.deletePrefixMutex.RUnlock()
+	argsForCall := fake.deletePrefixArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBackend) DeletePrefixReturns(result1 error) {
+	fake.deletePrefixMutex.Lock()
+	defer fake.deletePrefixMutex.Unlock()
+	fake.DeletePrefixStub = nil
+	fake.deletePrefixReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) DeletePrefixReturnsOnCall(i int, result1 error) {
+	fake.deletePrefixMutex.Lock()
+	defer fake.deletePrefixMutex.Unlock()
+	fake.DeletePrefixStub = nil
+	if fake.deletePrefixReturnsOnCall == nil {
+		fake.deletePrefixReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePrefixReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) Name() string {
+	fake.nameMutex.Lock()
+	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
+	fake.nameArgsForCall = append(fake.nameArgsForCall, struct {
+	}{})
+	stub := fake.NameStub
+	fakeReturns := fake.nameReturns
+	fake.recordInvocation("Name", []interface{}{})
+	fake.nameMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) NameCallCount() int {
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	return len(fake.nameArgsForCall)
+}
+
+func (fake *FakeBackend) NameCalls(stub func() string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = stub
+}
+
+func (fake *FakeBackend) NameReturns(result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	fake.nameReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBackend) NameReturnsOnCall(i int, result1 string) {
+	fake.nameMutex.Lock()
+	defer fake.nameMutex.Unlock()
+	fake.NameStub = nil
+	if fake.nameReturnsOnCall == nil {
+		fake.nameReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.nameReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBackend) Put(arg1 context.Context, arg2 string, arg3 io.Reader, arg4 int64, arg5 string) error {
+	fake.putMutex.Lock()
+	ret, specificReturn := fake.putReturnsOnCall[len(fake.putArgsForCall)]
+	fake.putArgsForCall = append(fake.putArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 io.Reader
+		arg4 int64
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	stub := fake.PutStub
+	fakeReturns := fake.putReturns
+	fake.recordInvocation("Put", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.putMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) PutCallCount() int {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	return len(fake.putArgsForCall)
+}
+
+func (fake *FakeBackend) PutCalls(stub func(context.Context, string, io.Reader, int64, string) error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = stub
+}
+
+func (fake *FakeBackend) PutArgsForCall(i int) (context.Context, string, io.Reader, int64, string) {
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	argsForCall := fake.putArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeBackend) PutReturns(result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	fake.putReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) PutReturnsOnCall(i int, result1 error) {
+	fake.putMutex.Lock()
+	defer fake.putMutex.Unlock()
+	fake.PutStub = nil
+	if fake.putReturnsOnCall == nil {
+		fake.putReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.putReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBackend) URI(arg1 string) string {
+	fake.uRIMutex.Lock()
+	ret, specificReturn := fake.uRIReturnsOnCall[len(fake.uRIArgsForCall)]
+	fake.uRIArgsForCall = append(fake.uRIArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.URIStub
+	fakeReturns := fake.uRIReturns
+	fake.recordInvocation("URI", []interface{}{arg1})
+	fake.uRIMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeBackend) URICallCount() int {
+	fake.uRIMutex.RLock()
+	defer fake.uRIMutex.RUnlock()
+	return len(fake.uRIArgsForCall)
+}
+
+func (fake *FakeBackend) URICalls(stub func(string) string) {
+	fake.uRIMutex.Lock()
+	defer fake.uRIMutex.Unlock()
+	fake.URIStub = stub
+}
+
+func (fake *FakeBackend) URIArgsForCall(i int) string {
+	fake.uRIMutex.RLock()
+	defer fake.uRIMutex.RUnlock()
+	argsForCall := fake.uRIArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBackend) URIReturns(result1 string) {
+	fake.uRIMutex.Lock()
+	defer fake.uRIMutex.Unlock()
+	fake.URIStub = nil
+	fake.uRIReturns = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBackend) URIReturnsOnCall(i int, result1 string) {
+	fake.uRIMutex.Lock()
+	defer fake.uRIMutex.Unlock()
+	fake.URIStub = nil
+	if fake.uRIReturnsOnCall == nil {
+		fake.uRIReturnsOnCall = make(map[int]struct {
+			result1 string
+		})
+	}
+	fake.uRIReturnsOnCall[i] = struct {
+		result1 string
+	}{result1}
+}
+
+func (fake *FakeBackend) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deletePrefixMutex.RLock()
+	defer fake.deletePrefixMutex.RUnlock()
+	fake.nameMutex.RLock()
+	defer fake.nameMutex.RUnlock()
+	fake.putMutex.RLock()
+	defer fake.putMutex.RUnlock()
+	fake.uRIMutex.RLock()
+	defer fake.uRIMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBackend) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ offload.Backend = new(FakeBackend)
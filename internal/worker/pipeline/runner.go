@@ -0,0 +1,260 @@
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/state"
+	"worker/internal/worker/workspace"
+	"worker/pkg/logger"
+)
+
+var ErrNotFound = errors.New("pipeline not found")
+
+// Runner starts pipeline steps as their upstream steps complete and stages
+// upstream output in downstream workspaces.
+//
+// Steps that feed other steps run with a retained workspace and captured stdout,
+// so their output is still on disk when the downstream step is prepared. The
+// runner removes those workspaces once the pipeline has finished and the
+// retention period has passed.
+type Runner struct {
+	worker     interfaces.Worker
+	store      state.Store
+	workspaces *workspace.Manager
+	retention  time.Duration
+
+	counter   int64
+	pipelines map[string]*run
+	mutex     sync.RWMutex
+
+	logger *logger.Logger
+}
+
+// run is the state of one started pipeline
+type run struct {
+	mutex    sync.Mutex
+	pipeline *domain.Pipeline
+}
+
+func NewRunner(worker interfaces.Worker, store state.Store, workspaces *workspace.Manager, retention time.Duration) *Runner {
+	return &Runner{
+		worker:     worker,
+		store:      store,
+		workspaces: workspaces,
+		retention:  retention,
+		pipelines:  make(map[string]*run),
+		logger:     logger.WithField("component", "pipeline-runner"),
+	}
+}
+
+// Start validates the spec and runs the pipeline in the background
+func (r *Runner) Start(spec *domain.PipelineSpec) (*domain.Pipeline, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	p := &domain.Pipeline{
+		Id:        fmt.Sprintf("%d", atomic.AddInt64(&r.counter, 1)),
+		Status:    domain.PipelineRunning,
+		Steps:     make([]domain.StepState, len(spec.Steps)),
+		StartTime: time.Now(),
+	}
+	for i, step := range spec.Steps {
+		p.Steps[i] = domain.StepState{Name: step.Name, Status: domain.StepPending}
+	}
+
+	pr := &run{pipeline: p}
+
+	r.mutex.Lock()
+	r.pipelines[p.Id] = pr
+	r.mutex.Unlock()
+
+	r.logger.Debug("pipeline started", "pipelineId", p.Id, "steps", len(spec.Steps))
+
+	go r.execute(pr, spec)
+
+	return p.DeepCopy(), nil
+}
+
+// Get returns a snapshot of a pipeline
+func (r *Runner) Get(id string) (*domain.Pipeline, error) {
+	r.mutex.RLock()
+	pr, exists := r.pipelines[id]
+	r.mutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+	return pr.pipeline.DeepCopy(), nil
+}
+
+// execute runs every step in its own goroutine, each waiting for its upstream steps
+func (r *Runner) execute(pr *run, spec *domain.PipelineSpec) {
+	log := r.logger.WithField("pipelineId", pr.pipeline.Id)
+
+	// steps read by a downstream step keep their workspace and stdout until the end
+	sources := make(map[string]bool)
+	for _, step := range spec.Steps {
+		for _, in := range step.Inputs {
+			sources[in.Step] = true
+		}
+	}
+
+	done := make(map[string]chan struct{}, len(spec.Steps))
+	for _, step := range spec.Steps {
+		done[step.Name] = make(chan struct{})
+	}
+
+	var wg sync.WaitGroup
+	for i := range spec.Steps {
+		wg.Add(1)
+		go func(index int, step domain.PipelineStep) {
+			defer wg.Done()
+			defer close(done[step.Name])
+			r.runStep(pr, index, step, sources[step.Name], done)
+		}(i, spec.Steps[i])
+	}
+	wg.Wait()
+
+	pr.mutex.Lock()
+	status := domain.PipelineCompleted
+	var retained []string
+	for _, step := range pr.pipeline.Steps {
+		if step.Status != domain.StepCompleted {
+			status = domain.PipelineFailed
+		}
+		if sources[step.Name] && step.JobId != "" {
+			retained = append(retained, step.JobId)
+		}
+	}
+	now := time.Now()
+	pr.pipeline.Status = status
+	pr.pipeline.EndTime = &now
+	pr.mutex.Unlock()
+
+	time.AfterFunc(r.retention, func() {
+		for _, jobID := range retained {
+			if err := r.workspaces.Remove(jobID); err != nil {
+				log.Warn("failed to remove retained workspace", "jobId", jobID, "error", err)
+			}
+		}
+	})
+
+	log.Debug("pipeline finished", "status", status, "duration", now.Sub(pr.pipeline.StartTime))
+}
+
+func (r *Runner) runStep(pr *run, index int, step domain.PipelineStep, isSource bool, done map[string]chan struct{}) {
+	log := r.logger.WithFields("pipelineId", pr.pipeline.Id, "step", step.Name)
+
+	for _, upstream := range step.Upstream() {
+		<-done[upstream]
+		if r.stepState(pr, upstream).Status != domain.StepCompleted {
+			r.setStep(pr, index, func(s *domain.StepState) {
+				s.Status = domain.StepSkipped
+				s.Error = fmt.Sprintf("upstream step %q did not complete", upstream)
+			})
+			log.Debug("step skipped", "upstream", upstream)
+			return
+		}
+	}
+
+	spec := *step.Job
+	spec.CaptureStdout = isSource
+	spec.RetainWorkspace = isSource
+
+	if len(step.Inputs) > 0 {
+		uploadID, err := r.stageInputs(pr, step)
+		if err != nil {
+			r.failStep(pr, index, fmt.Errorf("failed to stage inputs: %w", err))
+			return
+		}
+		spec.UploadID = uploadID
+	}
+
+	job, err := r.worker.StartJob(context.Background(), &spec)
+	if err != nil {
+		r.failStep(pr, index, err)
+		return
+	}
+
+	r.setStep(pr, index, func(s *domain.StepState) {
+		s.JobId = job.Id
+		s.Status = domain.StepRunning
+	})
+	log.Debug("step started", "jobId", job.Id)
+
+	finished, err := r.store.WaitForCompletion(context.Background(), job.Id)
+	if err != nil {
+		r.failStep(pr, index, fmt.Errorf("lost track of job %s: %w", job.Id, err))
+		return
+	}
+
+	r.setStep(pr, index, func(s *domain.StepState) {
+		s.Status = domain.StepStatusFromJob(finished.Status)
+	})
+	log.Debug("step finished", "jobId", job.Id, "status", finished.Status, "exitCode", finished.ExitCode)
+}
+
+// stageInputs copies upstream stdout and artifacts into a new upload for the step
+func (r *Runner) stageInputs(pr *run, step domain.PipelineStep) (string, error) {
+	upload, err := r.workspaces.NewUpload()
+	if err != nil {
+		return "", err
+	}
+
+	for _, in := range step.Inputs {
+		jobID := r.stepState(pr, in.Step).JobId
+
+		switch in.Source {
+		case domain.InputStdout:
+			err = upload.CopyFile(in.Destination(), r.workspaces.StdoutPath(jobID))
+		case domain.InputArtifacts:
+			err = upload.CopyDir(in.Destination(), r.workspaces.JobDir(jobID))
+		}
+		if err != nil {
+			upload.Abort()
+			return "", fmt.Errorf("%s of step %q: %w", in.Source, in.Step, err)
+		}
+	}
+
+	if err := upload.Close(); err != nil {
+		upload.Abort()
+		return "", err
+	}
+	return upload.ID, nil
+}
+
+func (r *Runner) stepState(pr *run, name string) domain.StepState {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+
+	for _, s := range pr.pipeline.Steps {
+		if s.Name == name {
+			return s
+		}
+	}
+	return domain.StepState{}
+}
+
+func (r *Runner) setStep(pr *run, index int, update func(*domain.StepState)) {
+	pr.mutex.Lock()
+	defer pr.mutex.Unlock()
+	update(&pr.pipeline.Steps[index])
+}
+
+func (r *Runner) failStep(pr *run, index int, err error) {
+	r.logger.Warn("pipeline step failed", "pipelineId", pr.pipeline.Id, "step", pr.pipeline.Steps[index].Name, "error", err)
+	r.setStep(pr, index, func(s *domain.StepState) {
+		s.Status = domain.StepFailed
+		s.Error = err.Error()
+	})
+}
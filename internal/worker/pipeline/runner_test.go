@@ -0,0 +1,190 @@
+package pipeline
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+	"worker/internal/worker/core/interfaces/interfacesfakes"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/state"
+	"worker/internal/worker/workspace"
+	"worker/pkg/config"
+)
+
+// fakeJobs stands in for the worker: it prepares the workspace the way the real
+// worker does and finishes each job with the exit code configured for its command
+type fakeJobs struct {
+	store      state.Store
+	workspaces *workspace.Manager
+	exitCodes  map[string]int32
+
+	mutex   sync.Mutex
+	counter int
+	specs   map[string]domain.JobSpec // by command
+}
+
+func (f *fakeJobs) start(_ context.Context, spec *domain.JobSpec) (*domain.Job, error) {
+	f.mutex.Lock()
+	f.counter++
+	id := fmt.Sprintf("%d", f.counter)
+	f.specs[spec.Command] = *spec
+	f.mutex.Unlock()
+
+	var dir string
+	var err error
+	if spec.UploadID != "" {
+		dir, err = f.workspaces.Claim(spec.UploadID, id)
+	} else {
+		dir, err = f.workspaces.Create(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// every job leaves an artifact and prints its command
+	if err := os.WriteFile(filepath.Join(dir, spec.Command+".out"), []byte("artifact of "+spec.Command), 0644); err != nil {
+		return nil, err
+	}
+	if spec.CaptureStdout {
+		if err := os.WriteFile(f.workspaces.StdoutPath(id), []byte("stdout of "+spec.Command), 0600); err != nil {
+			return nil, err
+		}
+	}
+
+	job := &domain.Job{Id: id, Command: spec.Command, Workspace: dir, Status: domain.StatusRunning}
+	f.store.CreateNewJob(job)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		finished := job.DeepCopy()
+		if code := f.exitCodes[spec.Command]; code != 0 {
+			finished.Fail(code, fmt.Sprintf("exited with code %d", code))
+		} else {
+			finished.Complete(0, "exited with code 0")
+		}
+		f.store.UpdateJob(finished)
+	}()
+
+	return job, nil
+}
+
+func newTestRunner(t *testing.T, exitCodes map[string]int32) (*Runner, *fakeJobs) {
+	t.Helper()
+
+	store := state.New()
+	workspaces := workspace.NewManager(config.WorkspaceConfig{BaseDir: t.TempDir()})
+	jobs := &fakeJobs{store: store, workspaces: workspaces, exitCodes: exitCodes, specs: make(map[string]domain.JobSpec)}
+
+	worker := &interfacesfakes.FakeWorker{}
+	worker.StartJobStub = jobs.start
+
+	return NewRunner(worker, store, workspaces, time.Hour), jobs
+}
+
+func waitForPipeline(t *testing.T, r *Runner, id string) *domain.Pipeline {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		p, err := r.Get(id)
+		if err != nil {
+			t.Fatalf("Get failed: %v", err)
+		}
+		if p.Status != domain.PipelineRunning {
+			return p
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("pipeline did not finish")
+	return nil
+}
+
+func TestRunner_StagesUpstreamOutput(t *testing.T) {
+	r, jobs := newTestRunner(t, nil)
+
+	p, err := r.Start(&domain.PipelineSpec{Steps: []domain.PipelineStep{
+		{Name: "consume", Job: &domain.JobSpec{Command: "consume"}, Inputs: []domain.PipelineInput{
+			{Step: "produce", Source: domain.InputStdout},
+			{Step: "produce", Source: domain.InputArtifacts, Path: "data"},
+		}},
+		{Name: "produce", Job: &domain.JobSpec{Command: "produce"}},
+	}})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	p = waitForPipeline(t, r, p.Id)
+	if p.Status != domain.PipelineCompleted {
+		t.Fatalf("expected COMPLETED pipeline, got %s: %+v", p.Status, p.Steps)
+	}
+
+	producer := jobs.specs["produce"]
+	if !producer.CaptureStdout || !producer.RetainWorkspace {
+		t.Errorf("expected source step to capture stdout and retain its workspace: %+v", producer)
+	}
+	if jobs.specs["consume"].RetainWorkspace {
+		t.Error("expected leaf step to use normal workspace cleanup")
+	}
+
+	consumerDir := r.workspaces.JobDir(p.Steps[0].JobId)
+
+	stdout, err := os.ReadFile(filepath.Join(consumerDir, "inputs", "produce.stdout"))
+	if err != nil || string(stdout) != "stdout of produce" {
+		t.Errorf("expected staged stdout, got %q (%v)", stdout, err)
+	}
+	artifact, err := os.ReadFile(filepath.Join(consumerDir, "data", "produce.out"))
+	if err != nil || string(artifact) != "artifact of produce" {
+		t.Errorf("expected staged artifact, got %q (%v)", artifact, err)
+	}
+}
+
+func TestRunner_SkipsStepsAfterFailure(t *testing.T) {
+	r, jobs := newTestRunner(t, map[string]int32{"build": 2})
+
+	p, err := r.Start(&domain.PipelineSpec{Steps: []domain.PipelineStep{
+		{Name: "build", Job: &domain.JobSpec{Command: "build"}},
+		{Name: "test", Job: &domain.JobSpec{Command: "test"}, DependsOn: []string{"build"}},
+		{Name: "lint", Job: &domain.JobSpec{Command: "lint"}},
+	}})
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+
+	p = waitForPipeline(t, r, p.Id)
+	if p.Status != domain.PipelineFailed {
+		t.Errorf("expected FAILED pipeline, got %s", p.Status)
+	}
+
+	want := map[string]domain.StepStatus{
+		"build": domain.StepFailed,
+		"test":  domain.StepSkipped,
+		"lint":  domain.StepCompleted,
+	}
+	for _, step := range p.Steps {
+		if step.Status != want[step.Name] {
+			t.Errorf("step %s: expected %s, got %s", step.Name, want[step.Name], step.Status)
+		}
+	}
+	if _, started := jobs.specs["test"]; started {
+		t.Error("expected skipped step not to be started")
+	}
+}
+
+func TestRunner_RejectsInvalidSpec(t *testing.T) {
+	r, _ := newTestRunner(t, nil)
+
+	_, err := r.Start(&domain.PipelineSpec{Steps: []domain.PipelineStep{
+		{Name: "a", Job: &domain.JobSpec{Command: "a"}, DependsOn: []string{"a"}},
+	}})
+	if err == nil {
+		t.Error("expected self-dependency to be rejected")
+	}
+
+	if _, err := r.Get("missing"); err == nil {
+		t.Error("expected unknown pipeline to return an error")
+	}
+}
@@ -3,11 +3,16 @@ package worker
 import (
 	"worker/internal/worker/core"
 	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/core/linux/resource"
+	"worker/internal/worker/events"
+	"worker/internal/worker/redact"
 	"worker/internal/worker/state"
 	"worker/pkg/config"
 )
 
-// NewWorker creates a platform-specific worker implementation
-func NewWorker(store state.Store, cfg *config.Config) interfaces.Worker {
-	return core.NewWorker(store, cfg)
+// NewWorker creates a platform-specific worker implementation, limiting jobs
+// through cgroup, or through the cgroup tree of the config when it is nil. It
+// fails when the host cannot run jobs, see config.PreflightStrict.
+func NewWorker(store state.Store, bus *events.Bus, redactor *redact.Redactor, cgroup resource.Resource, cfg *config.Config) (interfaces.Worker, error) {
+	return core.NewWorker(store, bus, redactor, cgroup, cfg)
 }
@@ -0,0 +1,317 @@
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	pb "worker/api/gen"
+	auth2 "worker/internal/worker/auth"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+)
+
+// Labels the reconciler sets on the jobs it starts: the spec file the job runs
+// and a hash of the spec, telling whether the job still matches the file
+const (
+	SpecLabel = "reconcile.spec"
+	HashLabel = "reconcile.hash"
+)
+
+// Service is the part of the job API the reconciler drives
+type Service interface {
+	RunJob(ctx context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error)
+	StopJob(ctx context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error)
+	ListJobs(ctx context.Context, req *pb.EmptyRequest) (*pb.Jobs, error)
+}
+
+// spec is a desired job read from the spec directory
+type spec struct {
+	req  *pb.RunJobReq
+	hash string
+}
+
+// Reconciler keeps the jobs of the worker in line with a directory of desired
+// job specs, one RunJobReq per .yaml, .yml or .json file. Every interval it
+// starts the jobs of new specs, starts a job again when it failed or was
+// stopped, replaces the jobs of changed specs and stops the jobs of removed
+// ones. A job that completed successfully is left alone until its spec
+// changes. Jobs go through the checks of the RunJob RPC with the rights of an
+// admin client; a spec that cannot be read leaves its job as it is.
+type Reconciler struct {
+	dir      string
+	interval time.Duration
+	service  Service
+	logger   *logger.Logger
+
+	errors map[string]string // last error of each spec, logged when it changes
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// New starts reconciling. It returns nil when reconciling is disabled.
+func New(cfg config.ReconcileConfig, service Service) (*Reconciler, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+	if info, err := os.Stat(cfg.Dir); err != nil {
+		return nil, err
+	} else if !info.IsDir() {
+		return nil, fmt.Errorf("not a directory: %s", cfg.Dir)
+	}
+
+	ctx, cancel := context.WithCancel(auth2.WithRole(context.Background(), auth2.AdminRole))
+	r := &Reconciler{
+		dir:      cfg.Dir,
+		interval: cfg.Interval,
+		service:  service,
+		logger:   logger.WithFields("component", "reconcile", "dir", cfg.Dir),
+		errors:   make(map[string]string),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+	}
+	go r.run()
+
+	r.logger.Info("reconciling desired jobs", "interval", cfg.Interval)
+	return r, nil
+}
+
+// Close stops reconciling. The jobs keep running.
+func (r *Reconciler) Close() {
+	if r == nil {
+		return
+	}
+
+	r.cancel()
+	<-r.done
+}
+
+func (r *Reconciler) run() {
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.reconcile(r.ctx); err != nil && r.ctx.Err() == nil {
+			r.logger.Warn("reconcile failed", "error", err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-r.ctx.Done():
+			return
+		}
+	}
+}
+
+// reconcile makes one pass over the specs and the jobs
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	specs, invalid, err := r.load()
+	if err != nil {
+		return err
+	}
+
+	jobs, err := r.service.ListJobs(ctx, &pb.EmptyRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	byName := make(map[string][]*pb.Job)
+	for _, job := range jobs.Jobs {
+		if name, ok := job.Labels[SpecLabel]; ok {
+			byName[name] = append(byName[name], job)
+		}
+	}
+
+	for name, jobs := range byName {
+		if _, ok := specs[name]; ok || invalid[name] {
+			continue
+		}
+		for _, job := range jobs {
+			if active(job) {
+				r.stop(ctx, name, job, "spec removed")
+			}
+		}
+	}
+
+	names := make([]string, 0, len(specs))
+	for name := range specs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return nil
+		}
+		r.sync(ctx, name, specs[name], byName[name])
+	}
+	return nil
+}
+
+// sync brings the jobs of one spec in line with it
+func (r *Reconciler) sync(ctx context.Context, name string, s spec, jobs []*pb.Job) {
+	var latest *pb.Job
+	running := false
+	for _, job := range jobs {
+		if job.Labels[HashLabel] != s.hash {
+			if active(job) {
+				r.stop(ctx, name, job, "spec changed")
+			}
+			continue
+		}
+		running = running || active(job)
+		if latest == nil || newer(job.Id, latest.Id) {
+			latest = job
+		}
+	}
+
+	if running || (latest != nil && latest.Status == "COMPLETED") {
+		return
+	}
+
+	req := proto.Clone(s.req).(*pb.RunJobReq)
+	if req.Labels == nil {
+		req.Labels = make(map[string]string)
+	}
+	req.Labels[SpecLabel] = name
+	req.Labels[HashLabel] = s.hash
+
+	res, err := r.service.RunJob(ctx, req)
+	if err != nil {
+		r.report(name, fmt.Errorf("failed to start job: %w", err))
+		return
+	}
+	r.report(name, nil)
+
+	if latest == nil {
+		r.logger.Info("job started for spec", "spec", name, "jobId", res.Id)
+	} else {
+		r.logger.Info("job started again for spec", "spec", name, "jobId", res.Id, "previousJob", latest.Id, "previousStatus", latest.Status)
+	}
+}
+
+func (r *Reconciler) stop(ctx context.Context, name string, job *pb.Job, reason string) {
+	if _, err := r.service.StopJob(ctx, &pb.StopJobReq{Id: job.Id}); err != nil {
+		r.logger.Warn("failed to stop job", "spec", name, "jobId", job.Id, "reason", reason, "error", err)
+		return
+	}
+	r.logger.Info("job stopped", "spec", name, "jobId", job.Id, "reason", reason)
+}
+
+// load reads the spec directory. Specs that cannot be read are returned in
+// invalid, so their jobs are neither started nor stopped.
+func (r *Reconciler) load() (map[string]spec, map[string]bool, error) {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read spec directory: %w", err)
+	}
+
+	specs := make(map[string]spec)
+	invalid := make(map[string]bool)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") {
+			continue
+		}
+		switch filepath.Ext(name) {
+		case ".yaml", ".yml", ".json":
+		default:
+			continue
+		}
+
+		s, err := readSpec(filepath.Join(r.dir, name))
+		if err != nil {
+			invalid[name] = true
+			r.report(name, err)
+			continue
+		}
+		specs[name] = s
+	}
+
+	// forget errors of specs that are gone
+	for name := range r.errors {
+		if _, ok := specs[name]; !ok && !invalid[name] {
+			delete(r.errors, name)
+		}
+	}
+	return specs, invalid, nil
+}
+
+// report logs the error of a spec when it differs from the last one
+func (r *Reconciler) report(name string, err error) {
+	if err == nil {
+		delete(r.errors, name)
+		return
+	}
+	if r.errors[name] == err.Error() {
+		return
+	}
+	r.errors[name] = err.Error()
+	r.logger.Warn("spec not applied", "spec", name, "error", err)
+}
+
+// readSpec reads a RunJobReq in protobuf JSON, or the same layout in YAML
+func readSpec(path string) (spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return spec{}, err
+	}
+
+	if filepath.Ext(path) != ".json" {
+		var doc interface{}
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return spec{}, err
+		}
+		if data, err = json.Marshal(doc); err != nil {
+			return spec{}, err
+		}
+	}
+
+	req := &pb.RunJobReq{}
+	if err := protojson.Unmarshal(data, req); err != nil {
+		return spec{}, err
+	}
+	if req.Command == "" {
+		return spec{}, fmt.Errorf("command is required")
+	}
+
+	canonical, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return spec{}, err
+	}
+	sum := sha256.Sum256(canonical)
+	return spec{req: req, hash: hex.EncodeToString(sum[:6])}, nil
+}
+
+func active(job *pb.Job) bool {
+	switch job.Status {
+	case "COMPLETED", "FAILED", "STOPPED", "EXPIRED":
+		return false
+	default:
+		return true
+	}
+}
+
+// newer tells whether job id a was assigned after b, ids being increasing
+// decimal numbers
+func newer(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a > b
+}
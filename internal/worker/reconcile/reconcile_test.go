@@ -0,0 +1,137 @@
+package reconcile
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	pb "worker/api/gen"
+	"worker/pkg/logger"
+)
+
+// stubService keeps jobs in memory the way the job service reports them
+type stubService struct {
+	jobs    []*pb.Job
+	stopped []string
+}
+
+func (s *stubService) RunJob(_ context.Context, req *pb.RunJobReq) (*pb.RunJobRes, error) {
+	id := strconv.Itoa(len(s.jobs) + 1)
+	s.jobs = append(s.jobs, &pb.Job{Id: id, Command: req.Command, Args: req.Args, Status: "RUNNING", Labels: req.Labels})
+	return &pb.RunJobRes{Id: id, Status: "RUNNING"}, nil
+}
+
+func (s *stubService) StopJob(_ context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error) {
+	s.stopped = append(s.stopped, req.Id)
+	s.job(req.Id).Status = "STOPPED"
+	return &pb.StopJobRes{Id: req.Id, Status: "STOPPED"}, nil
+}
+
+func (s *stubService) ListJobs(context.Context, *pb.EmptyRequest) (*pb.Jobs, error) {
+	return &pb.Jobs{Jobs: s.jobs}, nil
+}
+
+func (s *stubService) job(id string) *pb.Job {
+	n, _ := strconv.Atoi(id)
+	return s.jobs[n-1]
+}
+
+func newReconciler(t *testing.T, service Service) (*Reconciler, string) {
+	t.Helper()
+	dir := t.TempDir()
+	return &Reconciler{
+		dir:     dir,
+		service: service,
+		logger:  logger.WithField("component", "reconcile"),
+		errors:  make(map[string]string),
+	}, dir
+}
+
+func writeSpec(t *testing.T, dir, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func pass(t *testing.T, r *Reconciler) {
+	t.Helper()
+	if err := r.reconcile(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReconcileStartsAndRestartsJobs(t *testing.T) {
+	service := &stubService{}
+	r, dir := newReconciler(t, service)
+	writeSpec(t, dir, "web.yaml", "command: nginx\nargs: [\"-g\", \"daemon off;\"]\nlabels:\n  team: web\n")
+	writeSpec(t, dir, "batch.json", `{"command": "backup", "memoryLimitBytes": "1048576"}`)
+	writeSpec(t, dir, "README.md", "not a spec")
+
+	pass(t, r)
+	if len(service.jobs) != 2 {
+		t.Fatalf("expected a job per spec, got %d", len(service.jobs))
+	}
+	web := service.jobs[1]
+	if web.Command != "nginx" || web.Labels[SpecLabel] != "web.yaml" || web.Labels["team"] != "web" || web.Labels[HashLabel] == "" {
+		t.Errorf("unexpected job %v", web)
+	}
+
+	// running jobs are left alone
+	pass(t, r)
+	if len(service.jobs) != 2 {
+		t.Fatalf("expected no new jobs, got %d", len(service.jobs))
+	}
+
+	// a failed job is started again, a completed one is done
+	service.job("1").Status = "COMPLETED"
+	service.job("2").Status = "FAILED"
+	pass(t, r)
+	if len(service.jobs) != 3 || service.jobs[2].Command != "nginx" {
+		t.Fatalf("expected the failed job to be started again, got %v", service.jobs)
+	}
+}
+
+func TestReconcileFollowsSpecChanges(t *testing.T) {
+	service := &stubService{}
+	r, dir := newReconciler(t, service)
+	writeSpec(t, dir, "web.yaml", "command: nginx\n")
+	writeSpec(t, dir, "worker.yaml", "command: queue-worker\n")
+	pass(t, r)
+
+	// a changed spec replaces its job, a removed one stops it
+	writeSpec(t, dir, "web.yaml", "command: nginx\nargs: [\"-c\", \"/etc/nginx/new.conf\"]\n")
+	if err := os.Remove(filepath.Join(dir, "worker.yaml")); err != nil {
+		t.Fatal(err)
+	}
+	pass(t, r)
+
+	if len(service.stopped) != 2 {
+		t.Fatalf("expected both jobs to be stopped, got %v", service.stopped)
+	}
+	if len(service.jobs) != 3 || len(service.jobs[2].Args) != 2 {
+		t.Fatalf("expected the changed spec to get a new job, got %v", service.jobs)
+	}
+
+	// a spec that no longer parses keeps its job
+	writeSpec(t, dir, "web.yaml", "command: nginx\nunknownField: true\n")
+	pass(t, r)
+	if len(service.stopped) != 2 || r.errors["web.yaml"] == "" {
+		t.Errorf("expected the broken spec to be reported and its job kept, stopped %v", service.stopped)
+	}
+}
+
+func TestReadSpecRejectsInvalidSpecs(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"empty.yaml":  "args: [\"x\"]\n",
+		"broken.json": "{",
+		"typo.yaml":   "comand: echo\n",
+	} {
+		writeSpec(t, dir, name, content)
+		if _, err := readSpec(filepath.Join(dir, name)); err == nil {
+			t.Errorf("expected %s to be rejected", name)
+		}
+	}
+}
@@ -0,0 +1,301 @@
+package redact
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"worker/pkg/config"
+)
+
+const DefaultMask = "***REDACTED***"
+
+// minSecretLength avoids masking trivially short values (e.g. "1") everywhere in the output
+const minSecretLength = 4
+
+const (
+	// patternCarry bounds the unfinished line a Stream holds back for the
+	// patterns to match once its end arrives
+	patternCarry = 1024
+
+	// maxCarry bounds what a Stream holds back in all, a longer match in
+	// progress is redacted as far as it got
+	maxCarry = 64 * 1024
+)
+
+// Redactor masks secrets in job output and in environment variables echoed by the API.
+// Secrets are matched either by configured regex patterns or by exact values registered
+// at runtime (e.g. values injected into a job from the secrets store).
+type Redactor struct {
+	mask      []byte
+	patterns  []*regexp.Regexp
+	envKeys   []*regexp.Regexp
+	values    map[string]int // value -> number of registrations
+	sorted    [][]byte       // registered values, longest first
+	valuesMux sync.RWMutex
+}
+
+// New creates a redactor from configuration. It returns nil when redaction is
+// disabled; all Redactor methods are safe to call on nil.
+func New(cfg config.RedactionConfig) (*Redactor, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	r := &Redactor{
+		mask:   []byte(cfg.Mask),
+		values: make(map[string]int),
+	}
+	if len(r.mask) == 0 {
+		r.mask = []byte(DefaultMask)
+	}
+
+	for _, p := range cfg.Patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction pattern %q: %w", p, err)
+		}
+		r.patterns = append(r.patterns, re)
+	}
+
+	for _, p := range cfg.SensitiveEnvKeys {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid sensitive env key pattern %q: %w", p, err)
+		}
+		r.envKeys = append(r.envKeys, re)
+	}
+
+	return r, nil
+}
+
+// AddValue registers a literal secret value to be masked wherever it appears.
+// Registrations are reference counted so the same value can be used by several jobs.
+func (r *Redactor) AddValue(value string) {
+	if r == nil || len(value) < minSecretLength {
+		return
+	}
+
+	r.valuesMux.Lock()
+	defer r.valuesMux.Unlock()
+
+	r.values[value]++
+	if r.values[value] == 1 {
+		r.rebuildSorted()
+	}
+}
+
+// RemoveValue drops one registration of a secret value
+func (r *Redactor) RemoveValue(value string) {
+	if r == nil {
+		return
+	}
+
+	r.valuesMux.Lock()
+	defer r.valuesMux.Unlock()
+
+	count, ok := r.values[value]
+	if !ok {
+		return
+	}
+	if count > 1 {
+		r.values[value] = count - 1
+		return
+	}
+
+	delete(r.values, value)
+	r.rebuildSorted()
+}
+
+// rebuildSorted orders values longest first so overlapping secrets are fully masked
+func (r *Redactor) rebuildSorted() {
+	r.sorted = r.sorted[:0]
+	for v := range r.values {
+		r.sorted = append(r.sorted, []byte(v))
+	}
+	sort.Slice(r.sorted, func(i, j int) bool {
+		return len(r.sorted[i]) > len(r.sorted[j])
+	})
+}
+
+// Redact returns data with all known secrets masked. The input is not modified.
+func (r *Redactor) Redact(data []byte) []byte {
+	if r == nil || len(data) == 0 {
+		return data
+	}
+
+	r.valuesMux.RLock()
+	for _, v := range r.sorted {
+		if bytes.Contains(data, v) {
+			data = bytes.ReplaceAll(data, v, r.mask)
+		}
+	}
+	r.valuesMux.RUnlock()
+
+	for _, re := range r.patterns {
+		data = re.ReplaceAll(data, r.mask)
+	}
+
+	return data
+}
+
+// Stream returns a redactor for a stream of writes, such as one output of a
+// job, that masks secrets split across writes. It returns nil when r is nil;
+// all Stream methods are safe to call on nil.
+func (r *Redactor) Stream() *Stream {
+	if r == nil {
+		return nil
+	}
+	return &Stream{redactor: r}
+}
+
+// Stream redacts consecutive writes. The end of a write that may be the start
+// of a secret is carried over and redacted with the next write: the longest
+// part of a registered value it ends with and, with patterns, its unfinished
+// last line.
+type Stream struct {
+	redactor *Redactor
+	mutex    sync.Mutex
+	tail     []byte // raw end of the previous writes, not returned yet
+}
+
+// Redact returns the part of the stream so far that can no longer be part of
+// an unmasked secret, with all known secrets masked
+func (s *Stream) Redact(data []byte) []byte {
+	if s == nil {
+		return data
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	buffered := make([]byte, 0, len(s.tail)+len(data))
+	buffered = append(append(buffered, s.tail...), data...)
+
+	cut := s.redactor.carryCut(buffered)
+	s.tail = append(s.tail[:0], buffered[cut:]...)
+	return s.redactor.Redact(buffered[:cut])
+}
+
+// Held returns how many bytes the stream carries over to the next write
+func (s *Stream) Held() int {
+	if s == nil {
+		return 0
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return len(s.tail)
+}
+
+// Flush returns what the stream carried over, redacted, once it ended or
+// went quiet
+func (s *Stream) Flush() []byte {
+	if s == nil {
+		return nil
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	tail := s.redactor.Redact(s.tail)
+	s.tail = nil
+	return tail
+}
+
+// carryCut returns where data is split between what is redacted now and what
+// is carried over. The split never falls inside a secret found in data.
+func (r *Redactor) carryCut(data []byte) int {
+	r.valuesMux.RLock()
+	defer r.valuesMux.RUnlock()
+
+	carry := 0
+	for _, v := range r.sorted {
+		for k := min(len(v)-1, len(data)); k > carry; k-- {
+			if bytes.HasSuffix(data, v[:k]) {
+				carry = k
+				break
+			}
+		}
+	}
+	if len(r.patterns) > 0 {
+		line := len(data) - bytes.LastIndexByte(data, '\n') - 1
+		carry = max(carry, min(line, patternCarry))
+	}
+	if carry == 0 {
+		return len(data)
+	}
+
+	var matches [][]int
+	for _, v := range r.sorted {
+		for start := 0; start < len(data); {
+			i := bytes.Index(data[start:], v)
+			if i < 0 {
+				break
+			}
+			matches = append(matches, []int{start + i, start + i + len(v)})
+			start += i + 1
+		}
+	}
+	for _, re := range r.patterns {
+		matches = append(matches, re.FindAllIndex(data, -1)...)
+	}
+
+	cut := len(data) - carry
+	for moved := true; moved; {
+		moved = false
+		for _, m := range matches {
+			if m[0] < cut && cut < m[1] {
+				cut = m[0]
+				moved = true
+			}
+		}
+	}
+	if len(data)-cut > maxCarry {
+		return len(data)
+	}
+	return cut
+}
+
+// RedactString is the string form of Redact
+func (r *Redactor) RedactString(s string) string {
+	if r == nil {
+		return s
+	}
+	return string(r.Redact([]byte(s)))
+}
+
+// RedactEnv returns a copy of env in which values of sensitive keys are fully masked
+// and all other values have known secrets masked
+func (r *Redactor) RedactEnv(env map[string]string) map[string]string {
+	if r == nil || env == nil {
+		return env
+	}
+
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		if r.IsSensitiveKey(k) {
+			out[k] = string(r.mask)
+			continue
+		}
+		out[k] = r.RedactString(v)
+	}
+
+	return out
+}
+
+// IsSensitiveKey reports whether an environment variable name matches a sensitive key pattern
+func (r *Redactor) IsSensitiveKey(key string) bool {
+	if r == nil {
+		return false
+	}
+
+	for _, re := range r.envKeys {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
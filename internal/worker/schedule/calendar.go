@@ -0,0 +1,94 @@
+package schedule
+
+import (
+	"fmt"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// horizon bounds how far ahead the calendar looks for the time a held job may
+// start; windows recur every week, so a job not allowed within it never is
+const horizon = 8 * 24 * time.Hour
+
+// Calendar decides when new jobs may start, from the blackout windows of the
+// server and the allowed windows of each job
+type Calendar struct {
+	blackouts []blackout
+}
+
+type blackout struct {
+	name   string
+	window domain.TimeWindow
+	labels map[string]string
+}
+
+// Hold is why a job may not start yet and when it may
+type Hold struct {
+	Until  time.Time // first minute the job may start, zero if it never may
+	Reason string
+}
+
+// New creates a calendar from the blackouts of the config. Windows without a
+// time zone are in the worker's local time.
+func New(cfg config.ScheduleConfig) (*Calendar, error) {
+	c := &Calendar{}
+	for i, b := range cfg.Blackouts {
+		window, err := domain.ParseTimeWindow(b.Window, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("schedule blackout %d: %w", i, err)
+		}
+		name := b.Name
+		if name == "" {
+			name = b.Window
+		}
+		c.blackouts = append(c.blackouts, blackout{name: name, window: window, labels: b.Labels})
+	}
+
+	if len(c.blackouts) > 0 {
+		logger.WithField("component", "schedule").Info("blackout windows configured", "count", len(c.blackouts))
+	}
+	return c, nil
+}
+
+// Hold reports whether a job of the spec is held at now, and until when
+func (c *Calendar) Hold(spec *domain.JobSpec, now time.Time) (Hold, bool) {
+	reason := c.reason(spec, now)
+	if reason == "" {
+		return Hold{}, false
+	}
+
+	// windows are given to the minute
+	for t := now.Truncate(time.Minute).Add(time.Minute); t.Sub(now) <= horizon; t = t.Add(time.Minute) {
+		if c.reason(spec, t) == "" {
+			return Hold{Until: t, Reason: reason}, true
+		}
+	}
+	return Hold{Reason: reason}, true
+}
+
+// reason returns why a job of the spec may not start at t, "" if it may
+func (c *Calendar) reason(spec *domain.JobSpec, t time.Time) string {
+	if c != nil {
+		for _, b := range c.blackouts {
+			if b.window.Contains(t) && selects(b.labels, spec.Labels) {
+				return fmt.Sprintf("blackout %s", b.name)
+			}
+		}
+	}
+	if !domain.InWindows(spec.AllowedWindows, t) {
+		return "outside the job's allowed windows"
+	}
+	return ""
+}
+
+// selects reports whether a blackout with the given labels applies to a job with labels
+func selects(selector, labels map[string]string) bool {
+	for k, v := range selector {
+		if label, exists := labels[k]; !exists || label != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+)
+
+// monday is 2025-01-06 at the given time, in UTC
+func monday(hour, minute int) time.Time {
+	return time.Date(2025, 1, 6, hour, minute, 30, 0, time.UTC)
+}
+
+func TestCalendarHold_Blackout(t *testing.T) {
+	calendar, err := New(config.ScheduleConfig{Blackouts: []config.BlackoutConfig{
+		{Name: "business hours", Window: "Mon-Fri 09:00-17:00 UTC", Labels: map[string]string{"class": "batch"}},
+	}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	batch := &domain.JobSpec{Labels: map[string]string{"class": "batch"}}
+	hold, held := calendar.Hold(batch, monday(10, 0))
+	if !held || !hold.Until.Equal(monday(17, 0).Truncate(time.Minute)) || hold.Reason != "blackout business hours" {
+		t.Errorf("expected the batch job to be held until 17:00, got %+v, %v", hold, held)
+	}
+
+	if _, held := calendar.Hold(batch, monday(8, 59)); held {
+		t.Error("expected the batch job to start before the blackout")
+	}
+	if _, held := calendar.Hold(&domain.JobSpec{}, monday(10, 0)); held {
+		t.Error("expected a job without the label to start during the blackout")
+	}
+}
+
+func TestCalendarHold_AllowedWindows(t *testing.T) {
+	window, err := domain.ParseTimeWindow("Mon-Fri 18:00-06:00 UTC", time.Local)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	spec := &domain.JobSpec{AllowedWindows: []domain.TimeWindow{window}}
+
+	// a nil calendar still applies the job's own windows
+	var calendar *Calendar
+	hold, held := calendar.Hold(spec, monday(12, 0))
+	if !held || !hold.Until.Equal(monday(18, 0).Truncate(time.Minute)) {
+		t.Errorf("expected the job to be held until 18:00, got %+v, %v", hold, held)
+	}
+	if _, held := calendar.Hold(spec, monday(23, 0)); held {
+		t.Error("expected the job to start within its window")
+	}
+}
+
+func TestCalendarHold_Never(t *testing.T) {
+	calendar, err := New(config.ScheduleConfig{Blackouts: []config.BlackoutConfig{{Window: "00:00-00:00"}}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	hold, held := calendar.Hold(&domain.JobSpec{}, monday(12, 0))
+	if !held || !hold.Until.IsZero() || hold.Reason != "blackout 00:00-00:00" {
+		t.Errorf("expected the job to be held for good, got %+v, %v", hold, held)
+	}
+
+	if _, err := New(config.ScheduleConfig{Blackouts: []config.BlackoutConfig{{Window: "lunch"}}}); err == nil {
+		t.Error("expected an invalid window to be rejected")
+	}
+}
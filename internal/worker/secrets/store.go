@@ -0,0 +1,316 @@
+package secrets
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+const (
+	keySize        = 32 // AES-256
+	secretFileExt  = ".secret"
+	MaxSecretSize  = 64 * 1024
+	maxNameLength  = 128
+	secretFileMode = 0600
+)
+
+var (
+	ErrNotFound      = errors.New("secret not found")
+	ErrAlreadyExists = errors.New("secret already exists")
+	ErrInvalidName   = errors.New("invalid secret name")
+	ErrDisabled      = errors.New("secrets store is not enabled")
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Store keeps secrets encrypted at rest with AES-GCM, one file per secret.
+// The secret name is bound to the ciphertext as additional data so encrypted
+// files cannot be swapped between names.
+type Store struct {
+	dir    string
+	aead   cipher.AEAD
+	mu     sync.RWMutex
+	logger *logger.Logger
+}
+
+// New opens the secrets store, generating the encryption key on first use.
+// It returns nil when secrets are disabled; all Store methods are safe to call on nil.
+func New(cfg config.SecretsConfig) (*Store, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	if _, err := os.Stat(cfg.KeyFile); os.IsNotExist(err) && holdsSecrets(cfg.Dir) {
+		// a new key would make them unreadable
+		return nil, fmt.Errorf("secrets key file %s is missing but %s holds secrets: restore the key or set secrets.keyFile to where it is", cfg.KeyFile, cfg.Dir)
+	}
+
+	key, err := loadOrCreateKey(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	s := &Store{
+		dir:    cfg.Dir,
+		aead:   aead,
+		logger: logger.WithField("component", "secrets-store"),
+	}
+	s.logger.Debug("secrets store initialized", "dir", cfg.Dir)
+	if keyInDir(cfg.KeyFile, cfg.Dir) {
+		s.logger.Warn("secrets key file is inside the secrets directory, a backup or copy of the directory can decrypt the secrets; move it out and set secrets.keyFile", "keyFile", cfg.KeyFile, "dir", cfg.Dir)
+	}
+
+	return s, nil
+}
+
+// keyInDir reports whether the key file is stored within the secrets directory
+func keyInDir(keyFile, dir string) bool {
+	keyFile, err := filepath.Abs(keyFile)
+	if err != nil {
+		return false
+	}
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(dir, keyFile)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// holdsSecrets reports whether dir holds secret files
+func holdsSecrets(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*"+secretFileExt))
+	return len(matches) > 0
+}
+
+func loadOrCreateKey(path string) ([]byte, error) {
+	key, err := os.ReadFile(path)
+	if err == nil {
+		if len(key) != keySize {
+			return nil, fmt.Errorf("secrets key file %s must contain %d bytes, got %d", path, keySize, len(key))
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read secrets key file: %w", err)
+	}
+
+	key = make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate secrets key: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create secrets key directory: %w", err)
+	}
+	created, err := writeKey(path, key)
+	if err != nil {
+		return nil, err
+	}
+	if !created {
+		// another worker starting at once created the key first
+		return loadOrCreateKey(path)
+	}
+	return key, nil
+}
+
+// writeKey stores a new key at path, written in full and synced to disk
+// before it appears there, so a crash never leaves a truncated key behind.
+// It reports false, without changing path, when a key already exists there.
+func writeKey(path string, key []byte) (bool, error) {
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return false, fmt.Errorf("failed to create secrets key file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(key)
+	if err == nil {
+		err = tmp.Chmod(secretFileMode)
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to write secrets key file: %w", err)
+	}
+
+	// a link, unlike a rename, never replaces a key another worker created
+	if err := os.Link(tmp.Name(), path); err != nil {
+		if os.IsExist(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to create secrets key file: %w", err)
+	}
+	if err := syncDir(dir); err != nil {
+		return false, fmt.Errorf("failed to sync secrets key directory: %w", err)
+	}
+	return true, nil
+}
+
+// syncDir syncs a directory, so the entries created in it survive a crash
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+// ValidateName checks that a secret name is safe to use as a file name
+func ValidateName(name string) error {
+	if len(name) == 0 || len(name) > maxNameLength || !namePattern.MatchString(name) {
+		return fmt.Errorf("%w: %q", ErrInvalidName, name)
+	}
+	return nil
+}
+
+// Create encrypts and stores a new secret. Existing secrets are never overwritten;
+// rotate a secret by deleting and re-creating it.
+func (s *Store) Create(name string, value []byte) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, ErrDisabled
+	}
+	if err := ValidateName(name); err != nil {
+		return time.Time{}, err
+	}
+	if len(value) == 0 {
+		return time.Time{}, fmt.Errorf("secret value cannot be empty")
+	}
+	if len(value) > MaxSecretSize {
+		return time.Time{}, fmt.Errorf("secret value too large (max %d bytes)", MaxSecretSize)
+	}
+
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return time.Time{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := s.aead.Seal(nonce, nonce, value, []byte(name))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path(name), os.O_WRONLY|os.O_CREATE|os.O_EXCL, secretFileMode)
+	if err != nil {
+		if os.IsExist(err) {
+			return time.Time{}, fmt.Errorf("%w: %s", ErrAlreadyExists, name)
+		}
+		return time.Time{}, fmt.Errorf("failed to create secret file: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(sealed); err != nil {
+		_ = os.Remove(s.path(name))
+		return time.Time{}, fmt.Errorf("failed to write secret: %w", err)
+	}
+
+	s.logger.Info("secret created", "name", name)
+	return time.Now(), nil
+}
+
+// Delete removes a secret
+func (s *Store) Delete(name string) error {
+	if s == nil {
+		return ErrDisabled
+	}
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := os.Remove(s.path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+
+	s.logger.Info("secret deleted", "name", name)
+	return nil
+}
+
+// Get decrypts and returns a secret value
+func (s *Store) Get(name string) ([]byte, error) {
+	if s == nil {
+		return nil, ErrDisabled
+	}
+	if err := ValidateName(name); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	sealed, err := os.ReadFile(s.path(name))
+	s.mu.RUnlock()
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+		return nil, fmt.Errorf("failed to read secret: %w", err)
+	}
+
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return nil, fmt.Errorf("secret %s is corrupted", name)
+	}
+	value, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt secret %s: %w", name, err)
+	}
+
+	return value, nil
+}
+
+// Resolve maps environment variable names to secret names and returns the
+// decrypted values keyed by environment variable name
+func (s *Store) Resolve(refs map[string]string) (map[string]string, error) {
+	if len(refs) == 0 {
+		return nil, nil
+	}
+	if s == nil {
+		return nil, ErrDisabled
+	}
+
+	values := make(map[string]string, len(refs))
+	for envName, secretName := range refs {
+		value, err := s.Get(secretName)
+		if err != nil {
+			return nil, err
+		}
+		values[envName] = string(value)
+	}
+
+	return values, nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.dir, name+secretFileExt)
+}
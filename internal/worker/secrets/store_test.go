@@ -0,0 +1,216 @@
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"worker/pkg/config"
+)
+
+func newTestStore(t *testing.T, dir string) *Store {
+	t.Helper()
+
+	store, err := New(config.SecretsConfig{
+		Enabled: true,
+		Dir:     dir,
+		KeyFile: dir + ".key",
+	})
+	if err != nil {
+		t.Fatalf("Failed to create store: %v", err)
+	}
+	return store
+}
+
+func TestStoreCreateGetDelete(t *testing.T) {
+	dir := t.TempDir()
+	store := newTestStore(t, dir)
+
+	if _, err := store.Create("api-key", []byte("s3cr3t-value")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	value, err := store.Get("api-key")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if string(value) != "s3cr3t-value" {
+		t.Errorf("Expected s3cr3t-value, got %q", value)
+	}
+
+	raw, err := os.ReadFile(filepath.Join(dir, "api-key"+secretFileExt))
+	if err != nil {
+		t.Fatalf("Failed to read secret file: %v", err)
+	}
+	if bytes.Contains(raw, []byte("s3cr3t-value")) {
+		t.Error("Secret value is stored in plaintext")
+	}
+
+	if _, err := store.Create("api-key", []byte("other")); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("Expected ErrAlreadyExists, got %v", err)
+	}
+
+	if err := store.Delete("api-key"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if _, err := store.Get("api-key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if err := store.Delete("api-key"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for second delete, got %v", err)
+	}
+}
+
+func TestStorePersistsAcrossRestart(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := newTestStore(t, dir).Create("db-password", []byte("hunter22")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	value, err := newTestStore(t, dir).Get("db-password")
+	if err != nil {
+		t.Fatalf("Get after reopen failed: %v", err)
+	}
+	if string(value) != "hunter22" {
+		t.Errorf("Expected hunter22, got %q", value)
+	}
+}
+
+func TestStoreRefusesNewKeyForExistingSecrets(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := newTestStore(t, dir).Create("db-password", []byte("hunter22")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	// a key moved out of the directory by hand, but not configured yet
+	_, err := New(config.SecretsConfig{Enabled: true, Dir: dir, KeyFile: filepath.Join(t.TempDir(), "secrets.key")})
+	if err == nil {
+		t.Fatal("expected a missing key to fail while secrets exist")
+	}
+}
+
+func TestLoadOrCreateKey(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "keys")
+	path := filepath.Join(dir, "secrets.key")
+
+	key, err := loadOrCreateKey(path)
+	if err != nil {
+		t.Fatalf("loadOrCreateKey failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil || info.Size() != keySize || info.Mode().Perm() != secretFileMode {
+		t.Fatalf("expected a %d byte key with mode %o, got %v, %v", keySize, secretFileMode, info, err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("expected no temporary files left, got %d entries", len(entries))
+	}
+
+	again, err := loadOrCreateKey(path)
+	if err != nil || !bytes.Equal(again, key) {
+		t.Errorf("expected the stored key loaded, got %v", err)
+	}
+
+	// a key another worker created first is kept and used
+	created, err := writeKey(path, bytes.Repeat([]byte{1}, keySize))
+	if err != nil || created {
+		t.Errorf("expected the existing key kept, created %v, %v", created, err)
+	}
+	if stored, _ := os.ReadFile(path); !bytes.Equal(stored, key) {
+		t.Error("expected the existing key unchanged")
+	}
+}
+
+func TestLoadOrCreateKeyRejectsTruncatedKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.key")
+	if err := os.WriteFile(path, make([]byte, keySize/2), secretFileMode); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadOrCreateKey(path); err == nil {
+		t.Error("expected a truncated key to fail")
+	}
+}
+
+func TestKeyInDir(t *testing.T) {
+	tests := []struct {
+		keyFile, dir string
+		in           bool
+	}{
+		{"/opt/worker/secrets/master.key", "/opt/worker/secrets", true},
+		{"/opt/worker/secrets/keys/master.key", "/opt/worker/secrets/", true},
+		{"/etc/worker/secrets.key", "/opt/worker/secrets", false},
+		{"/opt/worker/secrets.key", "/opt/worker/secrets", false},
+		{"/opt/worker/secrets/../secrets.key", "/opt/worker/secrets", false},
+	}
+	for _, tt := range tests {
+		if got := keyInDir(tt.keyFile, tt.dir); got != tt.in {
+			t.Errorf("keyInDir(%q, %q) = %v, want %v", tt.keyFile, tt.dir, got, tt.in)
+		}
+	}
+}
+
+func TestStoreRejectsSwappedFiles(t *testing.T) {
+	dir := t.TempDir()
+	store := newTestStore(t, dir)
+
+	if _, err := store.Create("first", []byte("value-one")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if err := os.Rename(filepath.Join(dir, "first"+secretFileExt), filepath.Join(dir, "second"+secretFileExt)); err != nil {
+		t.Fatalf("Rename failed: %v", err)
+	}
+
+	if _, err := store.Get("second"); err == nil {
+		t.Error("Expected decryption to fail for a secret file moved to another name")
+	}
+}
+
+func TestValidateName(t *testing.T) {
+	valid := []string{"api-key", "DB_PASSWORD", "app.token", "a1"}
+	for _, name := range valid {
+		if err := ValidateName(name); err != nil {
+			t.Errorf("Expected %q to be valid, got %v", name, err)
+		}
+	}
+
+	invalid := []string{"", "../etc/passwd", "a/b", ".hidden", "-flag", "has space"}
+	for _, name := range invalid {
+		if err := ValidateName(name); !errors.Is(err, ErrInvalidName) {
+			t.Errorf("Expected %q to be invalid, got %v", name, err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	store := newTestStore(t, t.TempDir())
+	if _, err := store.Create("api-key", []byte("abcd1234")); err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+
+	values, err := store.Resolve(map[string]string{"API_KEY": "api-key"})
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+	if values["API_KEY"] != "abcd1234" {
+		t.Errorf("Expected API_KEY to resolve, got %v", values)
+	}
+
+	if _, err := store.Resolve(map[string]string{"MISSING": "nope"}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestNilStore(t *testing.T) {
+	var store *Store
+
+	if values, err := store.Resolve(nil); err != nil || values != nil {
+		t.Errorf("Expected empty resolve on nil store, got %v, %v", values, err)
+	}
+	if _, err := store.Resolve(map[string]string{"A": "b"}); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Expected ErrDisabled, got %v", err)
+	}
+	if _, err := store.Create("name", []byte("value")); !errors.Is(err, ErrDisabled) {
+		t.Errorf("Expected ErrDisabled, got %v", err)
+	}
+}
@@ -1,23 +1,145 @@
 package server
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
+	"golang.org/x/net/netutil"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 	"net"
 	"os"
 	pb "worker/api/gen"
 	auth2 "worker/internal/worker/auth"
 	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/events"
+	"worker/internal/worker/federation"
+	"worker/internal/worker/group"
+	"worker/internal/worker/intake"
+	"worker/internal/worker/janitor"
+	"worker/internal/worker/pipeline"
+	"worker/internal/worker/reconcile"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/schedule"
+	"worker/internal/worker/secrets"
+	"worker/internal/worker/signing"
 	"worker/internal/worker/state"
+	"worker/internal/worker/templates"
+	"worker/internal/worker/usage"
+	"worker/internal/worker/workspace"
+	"worker/pkg/client"
 	"worker/pkg/config"
 	"worker/pkg/logger"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+
+	// the compressors clients may stream output with, the server answers a
+	// compressed request in kind
+	_ "github.com/mostynb/go-grpc-compression/zstd"
+	_ "google.golang.org/grpc/encoding/gzip"
 )
 
-func StartGRPCServer(jobStore state.Store, jobWorker interfaces.Worker, cfg *config.Config) (*grpc.Server, error) {
+// StartGRPCServer serves the job service over gRPC on lis, created by Listen
+// or handed over by the worker binary this one replaced, along with the
+// intake, reconcile and fleet registration integrations the config enables.
+// Stopping the server closes lis.
+func StartGRPCServer(jobService *JobServiceServer, cfg *config.Config, lis net.Listener) (*grpc.Server, error) {
+	serverLogger := logger.WithField("component", "grpc-server")
+
+	sources, err := auth2.NewSourcePolicy(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer, err := newGRPCServer(cfg, serverLogger, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	pb.RegisterJobServiceServer(grpcServer, jobService)
+	registerLegacyJobService(grpcServer, jobService)
+
+	serverLogger.Debug("job service registered successfully")
+
+	lis = limitConnections(cfg, lis, sources)
+
+	// queue submissions go through the same checks as RunJob
+	consumer, err := intake.New(cfg.Intake, jobService)
+	if err != nil {
+		serverLogger.Warn("intake setup failed, continuing without it", "error", err)
+	}
+
+	// desired specs go through the same checks as RunJob
+	reconciler, err := reconcile.New(cfg.Reconcile, jobService)
+	if err != nil {
+		serverLogger.Warn("reconcile setup failed, continuing without it", "error", err)
+	}
+
+	// heartbeats carry what GetWorkerInfo reports
+	info := func(ctx context.Context) (*pb.WorkerInfo, error) {
+		return jobService.GetWorkerInfo(auth2.WithRole(ctx, auth2.ViewerRole), &pb.EmptyRequest{})
+	}
+	files := client.TLSFiles{CertPath: cfg.Security.ClientCertPath, KeyPath: cfg.Security.ClientKeyPath, CAPath: cfg.Security.CACertPath}
+	registrar, err := federation.NewRegistrar(cfg.Fleet, cfg.Server.Port, info, federation.TLSCoordinatorDialer(files))
+	if err != nil {
+		serverLogger.Warn("fleet registration setup failed, continuing without it", "error", err)
+	}
+
+	serve(grpcServer, lis, serverLogger, func() {
+		registrar.Close()
+		reconciler.Close()
+		consumer.Close()
+	})
+	return grpcServer, nil
+}
+
+// NewJobService creates the job service with its workspace, pipeline and
+// group managers and template store, for serving over gRPC or taking jobs from a queue
+func NewJobService(jobStore state.Store, bus *events.Bus, jobWorker interfaces.Worker, redactor *redact.Redactor, secretStore *secrets.Store, accountant *usage.Accountant, janitor *janitor.Janitor, calendar *schedule.Calendar, verifier *signing.Verifier, cfg *config.Config) *JobServiceServer {
+	auth := auth2.NewGrpcAuthorization()
+
+	workspaces := workspace.NewManager(cfg.Workspace)
+	pipelines := pipeline.NewRunner(jobWorker, jobStore, workspaces, cfg.Workspace.Retention)
+
+	groups := group.NewManager(jobWorker, jobStore)
+
+	return NewJobServiceServer(auth, jobStore, jobWorker, redactor, secretStore, accountant, janitor, workspaces, pipelines, groups, templates.NewStore(), cfg.Worker.LimitProfiles, cfg.Worker.MaxConcurrentJobs, cfg.Usage.TenantLabel, calendar, verifier, bus, cfg.GRPC.MaxSubmitSize, state.NewCompactPolicy(cfg.OutputBuffer))
+}
+
+// StartCoordinatorServer serves the job API of a coordinator, which dispatches
+// jobs to a fleet of workers, and the fleet API the workers join through, with
+// the same TLS and limits as a worker
+func StartCoordinatorServer(coordinator pb.JobServiceServer, registry pb.FleetServiceServer, cfg *config.Config) (*grpc.Server, error) {
 	serverLogger := logger.WithField("component", "grpc-server")
+
+	sources, err := auth2.NewSourcePolicy(cfg.Security)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer, err := newGRPCServer(cfg, serverLogger, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	pb.RegisterJobServiceServer(grpcServer, coordinator)
+	registerLegacyJobService(grpcServer, coordinator)
+	pb.RegisterFleetServiceServer(grpcServer, registry)
+
+	lis, err := listen(cfg, sources)
+	if err != nil {
+		return nil, err
+	}
+
+	serve(grpcServer, lis, serverLogger, func() {})
+	return grpcServer, nil
+}
+
+// newGRPCServer creates a server requiring TLS client certificates signed by
+// the configured CA, from the sources the policy allows
+func newGRPCServer(cfg *config.Config, serverLogger *logger.Logger, sources *auth2.SourcePolicy) (*grpc.Server, error) {
 	serverAddress := cfg.GetServerAddress()
 
 	serverLogger.Debug("initializing gRPC server",
@@ -67,22 +189,48 @@ func StartGRPCServer(jobStore state.Store, jobWorker interfaces.Worker, cfg *con
 		grpc.MaxRecvMsgSize(int(cfg.GRPC.MaxRecvMsgSize)),
 		grpc.MaxSendMsgSize(int(cfg.GRPC.MaxSendMsgSize)),
 		grpc.MaxHeaderListSize(uint32(cfg.GRPC.MaxHeaderListSize)),
+		// gRPC takes a connection idle time, age and grace of 0 as no limit
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:                  cfg.GRPC.KeepAliveTime,
+			Timeout:               cfg.GRPC.KeepAliveTimeout,
+			MaxConnectionIdle:     cfg.GRPC.MaxConnectionIdle,
+			MaxConnectionAge:      cfg.GRPC.MaxConnectionAge,
+			MaxConnectionAgeGrace: cfg.GRPC.MaxConnectionAgeGrace,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.GRPC.KeepAliveMinTime,
+			PermitWithoutStream: cfg.GRPC.PermitKeepAliveWithoutStream,
+		}),
+		// viewers are refused anything but the read-only methods up front,
+		// and clients calling from outside their networks anything at all
+		grpc.ChainUnaryInterceptor(sources.UnaryServerInterceptor, auth2.UnaryServerInterceptor),
+		grpc.ChainStreamInterceptor(sources.StreamServerInterceptor, auth2.StreamServerInterceptor),
+	}
+	if cfg.GRPC.MaxConcurrentStreams > 0 {
+		grpcOptions = append(grpcOptions, grpc.MaxConcurrentStreams(cfg.GRPC.MaxConcurrentStreams))
+	}
+
+	// RPC spans are the parents of the job spans the worker records
+	if cfg.Tracing.Enabled {
+		grpcOptions = append(grpcOptions, grpc.StatsHandler(otelgrpc.NewServerHandler()))
 	}
 
 	serverLogger.Debug("gRPC server options configured",
 		"maxRecvMsgSize", cfg.GRPC.MaxRecvMsgSize,
 		"maxSendMsgSize", cfg.GRPC.MaxSendMsgSize,
-		"maxHeaderListSize", cfg.GRPC.MaxHeaderListSize)
-
-	grpcServer := grpc.NewServer(grpcOptions...)
-
-	auth := auth2.NewGrpcAuthorization()
-	serverLogger.Debug("authorization module initialized")
+		"maxHeaderListSize", cfg.GRPC.MaxHeaderListSize,
+		"keepAliveTime", cfg.GRPC.KeepAliveTime,
+		"keepAliveMinTime", cfg.GRPC.KeepAliveMinTime,
+		"maxConcurrentStreams", cfg.GRPC.MaxConcurrentStreams,
+		"maxConnectionAge", cfg.GRPC.MaxConnectionAge)
 
-	jobService := NewJobServiceServer(auth, jobStore, jobWorker)
-	pb.RegisterJobServiceServer(grpcServer, jobService)
+	return grpc.NewServer(grpcOptions...), nil
+}
 
-	serverLogger.Debug("job service registered successfully")
+// Listen creates the TCP listener of the server at the address of the config
+func Listen(cfg *config.Config) (net.Listener, error) {
+	serverLogger := logger.WithField("component", "grpc-server")
+	serverAddress := cfg.GetServerAddress()
 
 	serverLogger.Debug("creating TCP listener", "address", serverAddress)
 
@@ -92,7 +240,34 @@ func StartGRPCServer(jobStore state.Store, jobWorker interfaces.Worker, cfg *con
 		return nil, fmt.Errorf("failed to listen: %w", err)
 	}
 
-	serverLogger.Debug("TCP listener created successfully", "address", serverAddress, "network", "tcp")
+	serverLogger.Debug("TCP listener created successfully", "address", serverAddress, "network", "tcp",
+		"maxConnections", cfg.GRPC.MaxConnections)
+	return lis, nil
+}
+
+func listen(cfg *config.Config, sources *auth2.SourcePolicy) (net.Listener, error) {
+	lis, err := Listen(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return limitConnections(cfg, lis, sources), nil
+}
+
+// limitConnections applies the connection limit of the config to lis, once
+// the connections from sources the policy refuses are closed, so they take
+// no part of the limit
+func limitConnections(cfg *config.Config, lis net.Listener, sources *auth2.SourcePolicy) net.Listener {
+	lis = sources.Listener(lis)
+	if cfg.GRPC.MaxConnections > 0 {
+		// connections over the limit wait in the accept queue until one closes
+		return netutil.LimitListener(lis, cfg.GRPC.MaxConnections)
+	}
+	return lis
+}
+
+// serve runs the server in the background, calling stopped once it stopped
+func serve(grpcServer *grpc.Server, lis net.Listener, serverLogger *logger.Logger, stopped func()) {
+	serverAddress := lis.Addr().String()
 
 	go func() {
 		serverLogger.Debug("starting TLS gRPC server", "address", serverAddress, "ready", true)
@@ -102,9 +277,20 @@ func StartGRPCServer(jobStore state.Store, jobWorker interfaces.Worker, cfg *con
 		} else {
 			serverLogger.Debug("gRPC server stopped gracefully")
 		}
+		stopped()
 	}()
 
 	serverLogger.Debug("gRPC server initialization completed", "address", serverAddress, "tlsEnabled", true, "authRequired", true)
+}
 
-	return grpcServer, nil
+// legacyJobServiceName is the service name used before the API moved to the
+// jobworker.v1 package. The messages are wire-identical, so older clients keep
+// working against the v1 implementation during the transition window.
+const legacyJobServiceName = "worker.JobService"
+
+// registerLegacyJobService serves srv under the pre-v1 service name as well
+func registerLegacyJobService(s grpc.ServiceRegistrar, srv pb.JobServiceServer) {
+	desc := pb.JobService_ServiceDesc
+	desc.ServiceName = legacyJobServiceName
+	s.RegisterService(&desc, srv)
 }
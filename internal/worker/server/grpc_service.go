@@ -1,34 +1,93 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"io"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sort"
+	"strings"
 	"time"
 	pb "worker/api/gen"
 	"worker/internal/worker/adapters"
 	auth2 "worker/internal/worker/auth"
 	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/export"
+	"worker/internal/worker/group"
+	"worker/internal/worker/janitor"
 	"worker/internal/worker/mappers"
+	"worker/internal/worker/metrics"
+	"worker/internal/worker/pipeline"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/schedule"
+	"worker/internal/worker/secrets"
+	"worker/internal/worker/signing"
 	"worker/internal/worker/state"
+	"worker/internal/worker/templates"
+	"worker/internal/worker/usage"
+	"worker/internal/worker/workspace"
+	"worker/pkg/config"
 	"worker/pkg/logger"
 )
 
 type JobServiceServer struct {
 	pb.UnimplementedJobServiceServer
-	auth      auth2.GrpcAuthorization
-	jobStore  state.Store
-	jobWorker interfaces.Worker
-	logger    *logger.Logger
+	auth       auth2.GrpcAuthorization
+	jobStore   state.Store
+	jobWorker  interfaces.Worker
+	redactor   *redact.Redactor
+	secrets    *secrets.Store
+	usage      *usage.Accountant
+	janitor    *janitor.Janitor
+	workspaces *workspace.Manager
+	pipelines  *pipeline.Runner
+	groups     *group.Manager
+	templates  *templates.Store
+	profiles   map[string]config.LimitProfile
+	maxJobs    int
+	tenant     string // label naming a job's tenant
+	calendar   *schedule.Calendar
+	signatures *signing.Verifier
+	events     *events.Bus
+	maxSubmit  int64 // largest job request SubmitJob takes
+	compaction state.CompactPolicy
+	logger     *logger.Logger
 }
 
-func NewJobServiceServer(auth auth2.GrpcAuthorization, jobStore state.Store, jobWorker interfaces.Worker) *JobServiceServer {
+func NewJobServiceServer(auth auth2.GrpcAuthorization, jobStore state.Store, jobWorker interfaces.Worker, redactor *redact.Redactor, secretStore *secrets.Store, accountant *usage.Accountant, janitor *janitor.Janitor, workspaces *workspace.Manager, pipelines *pipeline.Runner, groups *group.Manager, templateStore *templates.Store, profiles map[string]config.LimitProfile, maxJobs int, tenantLabel string, calendar *schedule.Calendar, verifier *signing.Verifier, bus *events.Bus, maxSubmitSize int64, compaction state.CompactPolicy) *JobServiceServer {
 	return &JobServiceServer{
-		auth:      auth,
-		jobStore:  jobStore,
-		jobWorker: jobWorker,
-		logger:    logger.WithField("component", "grpc-service"),
+		auth:       auth,
+		jobStore:   jobStore,
+		jobWorker:  jobWorker,
+		redactor:   redactor,
+		secrets:    secretStore,
+		usage:      accountant,
+		janitor:    janitor,
+		workspaces: workspaces,
+		pipelines:  pipelines,
+		groups:     groups,
+		templates:  templateStore,
+		profiles:   profiles,
+		maxJobs:    maxJobs,
+		tenant:     tenantLabel,
+		calendar:   calendar,
+		signatures: verifier,
+		events:     bus,
+		maxSubmit:  maxSubmitSize,
+		compaction: compaction,
+		logger:     logger.WithField("component", "grpc-service"),
 	}
 }
 
@@ -40,6 +99,9 @@ func (s *JobServiceServer) RunJob(ctx context.Context, runJobReq *pb.RunJobReq)
 		"maxCPU", runJobReq.MaxCPU,
 		"maxMemory", runJobReq.MaxMemory,
 		"maxIOBPS", runJobReq.MaxIOBPS,
+		"profile", runJobReq.Profile,
+		"envVars", len(runJobReq.Env),
+		"envFileSize", len(runJobReq.EnvFile),
 	)
 
 	log.Debug("run job request received")
@@ -49,151 +111,1806 @@ func (s *JobServiceServer) RunJob(ctx context.Context, runJobReq *pb.RunJobReq)
 		return nil, err
 	}
 
+	newJob, err := s.startJob(ctx, runJobReq, log)
+	if err != nil {
+		return nil, err
+	}
+
+	return mappers.DomainToRunJobResponse(s.redactJob(newJob)), nil
+}
+
+// startJob converts the request, resolves its secrets and starts the job,
+// returning errors as gRPC status errors
+func (s *JobServiceServer) startJob(ctx context.Context, runJobReq *pb.RunJobReq, log *logger.Logger) (*domain.Job, error) {
+	spec, err := mappers.RunJobRequestToSpec(runJobReq)
+	if err != nil {
+		log.Warn("invalid run job request", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job request: %v", err)
+	}
+	if err := s.verifySignature(runJobReq, spec); err != nil {
+		log.Warn("job spec signature rejected", "error", err)
+		return nil, err
+	}
+
+	return s.startSpec(ctx, spec, log)
+}
+
+// startSpec authorizes a spec, applies its profile, resolves its secrets and
+// starts the job, returning errors as gRPC status errors
+func (s *JobServiceServer) startSpec(ctx context.Context, spec *domain.JobSpec, log *logger.Logger) (*domain.Job, error) {
+	if err := s.authorizeSpec(ctx, spec); err != nil {
+		log.Warn("job settings not authorized", "error", err)
+		return nil, err
+	}
+
+	if err := s.applyProfile(ctx, spec); err != nil {
+		log.Warn("limit profile rejected", "profile", spec.Profile, "error", err)
+		return nil, err
+	}
+
+	if missing := domain.MissingCapabilities(spec.RequiredCapabilities(), s.jobWorker.Capabilities()); len(missing) > 0 {
+		log.Warn("job needs capabilities the worker lacks", "missing", missing)
+		return nil, capabilityError(missing)
+	}
+
+	now := time.Now()
+	if spec.Submitted.IsZero() {
+		spec.Submitted = now
+	}
+	hold, held := s.calendar.Hold(spec, now)
+	if reason := expiry(spec, hold, held, now); reason != nil {
+		newJob, err := s.jobWorker.ExpireJob(ctx, spec, reason)
+		if err != nil {
+			log.Error("failed to record expired job", "error", err)
+			return nil, status.Errorf(codes.Internal, "job expired but could not be recorded: %v", err)
+		}
+		log.Info("job expired before it could start", "jobId", newJob.Id, "reason", reason)
+		return newJob, nil
+	}
+	if held {
+		log.Info("job held by the schedule", "reason", hold.Reason, "until", hold.Until)
+		return nil, heldError(hold, now)
+	}
+
+	var err error
+	spec.Secrets, err = s.secrets.Resolve(spec.SecretEnv)
+	if err != nil {
+		log.Warn("secret resolution failed", "error", err)
+		return nil, secretStatusError(err)
+	}
+
 	startTime := time.Now()
-	newJob, err := s.jobWorker.StartJob(ctx, runJobReq.Command, runJobReq.Args, runJobReq.MaxCPU, runJobReq.MaxMemory, runJobReq.MaxIOBPS)
+	newJob, err := s.jobWorker.StartJob(ctx, spec)
 
 	if err != nil {
 		duration := time.Since(startTime)
+		if ctxErr := ctx.Err(); ctxErr != nil && errors.Is(err, ctxErr) {
+			log.Warn("job creation abandoned by the client", "error", err, "duration", duration)
+			return nil, status.FromContextError(err).Err()
+		}
 		log.Error("job creation failed", "error", err, "duration", duration)
+		var cgroupErr *domain.CgroupError
+		if errors.As(err, &cgroupErr) {
+			return nil, cgroupStatusError(err, cgroupErr)
+		}
 		return nil, status.Errorf(codes.Internal, "job run failed: %v", err)
 	}
 
 	duration := time.Since(startTime)
 	log.Debug("job created successfully with host networking", "jobId", newJob.Id, "duration", duration)
+	if newJob.Isolation == domain.IsolationPrivileged {
+		log.Warn("privileged job started without isolation or limits",
+			"jobId", newJob.Id, "command", spec.Command, "client", auth2.ClientName(ctx))
+	}
 
-	return mappers.DomainToRunJobResponse(newJob), nil
+	return newJob, nil
 }
 
-func (s *JobServiceServer) GetJobStatus(ctx context.Context, req *pb.GetJobStatusReq) (*pb.GetJobStatusRes, error) {
-	log := s.logger.WithFields("operation", "GetJobStatus", "jobId", req.GetId())
+// RerunJob starts a new job from the spec another job was started with, so a
+// flaky job can be retried exactly. Secrets are resolved again by name.
+func (s *JobServiceServer) RerunJob(ctx context.Context, req *pb.RerunJobReq) (*pb.RunJobRes, error) {
+	log := s.logger.WithFields("operation", "RerunJob", "jobId", req.GetId())
 
-	log.Debug("get job status request received")
+	log.Debug("rerun job request received")
 
-	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
 		log.Warn("authorization failed", "error", err)
 		return nil, err
 	}
 
 	job, exists := s.jobStore.GetJob(req.GetId())
 	if !exists {
-		log.Warn("job not found")
 		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
 	}
+	if job.Spec == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s has no stored spec to rerun", job.Id)
+	}
+	// the staged upload was moved into the first job's workspace
+	if job.Spec.UploadID != "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s was started with an upload, which cannot be reused", job.Id)
+	}
 
-	log.Debug("job retrieved successfully", "status", string(job.Status), "duration", job.Duration())
+	// the queue timeout counts from the rerun
+	spec := job.Spec.Copy()
+	spec.Submitted = time.Time{}
+	newJob, err := s.startSpec(ctx, spec, log)
+	if err != nil {
+		return nil, err
+	}
 
-	return mappers.DomainToGetJobStatusResponse(job), nil
+	log.Info("job rerun", "newJobId", newJob.Id)
+	return mappers.DomainToRunJobResponse(s.redactJob(newJob)), nil
 }
 
-func (s *JobServiceServer) StopJob(ctx context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error) {
-	log := s.logger.WithFields("operation", "StopJob", "jobId", req.GetId())
+// RunJobAttached starts a job and streams its output and final status on the
+// same stream. The job keeps running if the client goes away.
+func (s *JobServiceServer) RunJobAttached(runJobReq *pb.RunJobReq, stream pb.JobService_RunJobAttachedServer) error {
+	log := s.logger.WithFields("operation", "RunJobAttached", "command", runJobReq.Command)
 
-	log.Debug("stop job request received")
+	log.Debug("run job attached request received")
 
-	if err := s.auth.Authorized(ctx, auth2.StopJobOp); err != nil {
+	ctx := stream.Context()
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
 		log.Warn("authorization failed", "error", err)
-		return nil, err
+		return err
 	}
 
-	startTime := time.Now()
-	if err := s.jobWorker.StopJob(ctx, req.GetId()); err != nil {
-		duration := time.Since(startTime)
-		log.Error("job stop failed", "error", err, "duration", duration)
-		return nil, status.Errorf(codes.Internal, "StopJob error %v", err)
+	newJob, err := s.startJob(ctx, runJobReq, log)
+	if err != nil {
+		return err
 	}
+	log = log.WithField("jobId", newJob.Id)
 
-	job, exists := s.jobStore.GetJob(req.GetId())
-	if !exists {
-		log.Warn("job not found after stop operation")
-		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	started := &pb.RunJobAttachedRes{Event: &pb.RunJobAttachedRes_Started{Started: mappers.DomainToRunJobResponse(s.redactJob(newJob))}}
+	if err := stream.Send(started); err != nil {
+		log.Warn("client went away before the job started streaming", "error", err)
+		return err
 	}
 
-	duration := time.Since(startTime)
-	log.Debug("job stopped successfully", "finalStatus", string(job.Status), "duration", duration)
+	existingLogs, isRunning, err := s.jobStore.GetOutput(newJob.Id)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "job not found")
+	}
+
+	domainStream := adapters.NewAttachedStreamAdapter(stream)
+	if len(existingLogs) > 0 {
+		if err := domainStream.SendData(existingLogs); err != nil {
+			return err
+		}
+	}
+	if isRunning {
+		if err := s.jobStore.SendUpdatesToClient(ctx, newJob.Id, domainStream); err != nil {
+			log.Debug("attached output stream ended", "error", err)
+			return err
+		}
+	}
+
+	finished, err := s.jobStore.WaitForCompletion(ctx, newJob.Id)
+	if err != nil {
+		log.Debug("client detached before the job finished", "error", err)
+		return err
+	}
 
-	return mappers.DomainToStopJobResponse(job), nil
+	log.Debug("attached job finished", "status", string(finished.Status), "exitCode", finished.ExitCode)
+
+	return stream.Send(&pb.RunJobAttachedRes{Event: &pb.RunJobAttachedRes_Exit{Exit: mappers.DomainToJobExit(finished)}})
 }
 
-func (s *JobServiceServer) ListJobs(ctx context.Context, _ *pb.EmptyRequest) (*pb.Jobs, error) {
-	log := s.logger.WithField("operation", "ListJobs")
+// ValidateJob checks a job request the way RunJob would, without starting it.
+// Problems with the request are reported in the response rather than as an error.
+func (s *JobServiceServer) ValidateJob(ctx context.Context, req *pb.RunJobReq) (*pb.ValidateJobRes, error) {
+	log := s.logger.WithFields("operation", "ValidateJob", "command", req.Command)
 
-	log.Debug("list jobs request received")
+	log.Debug("validate job request received")
 
-	if err := s.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
 		log.Warn("authorization failed", "error", err)
 		return nil, err
 	}
 
-	startTime := time.Now()
-	jobs := s.jobStore.ListJobs()
+	spec, err := mappers.RunJobRequestToSpec(req)
+	if err != nil {
+		validation := &domain.JobValidation{}
+		validation.Add("request", err)
+		return mappers.DomainToValidateJobResponse(validation), nil
+	}
+	if err := s.verifySignature(req, spec); err != nil {
+		log.Warn("job spec signature rejected", "error", err)
+		return nil, err
+	}
 
-	rawJobs := &pb.Jobs{}
-	statusCounts := make(map[string]int)
+	if err := s.authorizeSpec(ctx, spec); err != nil {
+		log.Warn("job settings not authorized", "error", err)
+		return nil, err
+	}
 
-	for _, job := range jobs {
-		rawJobs.Jobs = append(rawJobs.Jobs, mappers.DomainToProtobuf(job))
-		statusCounts[string(job.Status)]++
+	// an unknown profile is reported like any other spec error, a forbidden one fails the call
+	var profileErr error
+	if _, ok := s.profiles[spec.Profile]; spec.Profile != "" && !ok {
+		profileErr = fmt.Errorf("unknown limit profile %q", spec.Profile)
+	} else if err := s.applyProfile(ctx, spec); err != nil {
+		log.Warn("limit profile not authorized", "profile", spec.Profile, "error", err)
+		return nil, err
 	}
 
-	duration := time.Since(startTime)
-	log.Debug("jobs listed successfully",
-		"totalJobs", len(jobs),
-		"statusBreakdown", statusCounts,
-		"duration", duration)
+	var secretErr error
+	spec.Secrets, secretErr = s.secrets.Resolve(spec.SecretEnv)
 
-	return rawJobs, nil
+	validation := s.jobWorker.ValidateJob(ctx, spec)
+	validation.Add("profile", profileErr)
+	validation.Add("secretEnv", secretErr)
+	if missing := domain.MissingCapabilities(spec.RequiredCapabilities(), s.jobWorker.Capabilities()); len(missing) > 0 {
+		validation.Add("capabilities", fmt.Errorf("worker does not support %s", joinCapabilities(missing)))
+	}
+
+	log.Debug("job validated", "valid", validation.Valid(), "errors", len(validation.Errors))
+
+	return mappers.DomainToValidateJobResponse(validation), nil
 }
 
-func (s *JobServiceServer) GetJobLogs(req *pb.GetJobLogsReq, stream pb.JobService_GetJobLogsServer) error {
-	log := s.logger.WithFields("operation", "GetJobLogs", "jobId", req.GetId())
+// GetWorkerInfo reports the API version, platform, capabilities and load of
+// the worker, so clients of mixed-version fleets can tell what a worker
+// supports and coordinators how many more jobs it takes
+func (s *JobServiceServer) GetWorkerInfo(ctx context.Context, _ *pb.EmptyRequest) (*pb.WorkerInfo, error) {
+	log := s.logger.WithField("operation", "GetWorkerInfo")
 
-	log.Debug("job logs stream request received")
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
 
-	if err := s.auth.Authorized(stream.Context(), auth2.StreamJobsOp); err != nil {
+	info := &pb.WorkerInfo{
+		ApiVersion: string(pb.File_jobworker_v1_worker_proto.Package()),
+		Os:         runtime.GOOS,
+		Arch:       runtime.GOARCH,
+		MaxJobs:    int32(s.maxJobs),
+	}
+	for _, c := range s.jobWorker.Capabilities() {
+		info.Capabilities = append(info.Capabilities, string(c))
+	}
+	for name := range s.profiles {
+		info.LimitProfiles = append(info.LimitProfiles, name)
+	}
+	sort.Strings(info.LimitProfiles)
+	for _, job := range s.jobStore.ListJobs() {
+		if !job.IsCompleted() {
+			info.RunningJobs++
+		}
+	}
+	buffers := s.jobStore.BufferStats()
+	info.OutputBuffer = &pb.OutputBuffer{
+		UsedBytes:    buffers.UsedBytes,
+		BudgetBytes:  buffers.BudgetBytes,
+		SpilledBytes: buffers.SpilledBytes,
+		SpilledJobs:  buffers.SpilledJobs,
+	}
+	if stats := s.janitor.Stats(); stats != nil {
+		info.Disk = &pb.DiskUsage{
+			UsedBytes:      stats.UsedBytes,
+			BudgetBytes:    stats.BudgetBytes,
+			ReclaimedBytes: stats.ReclaimedBytes,
+			Evicted:        stats.Evicted,
+			LastCheck:      stats.LastRun.Format("2006-01-02T15:04:05Z07:00"),
+		}
+	}
+	info.Store = storeStats(s.jobStore.Stats())
+	return info, nil
+}
+
+// GetStoreStats reports the memory the job store and the worker take, after
+// a compaction when asked, with a heap profile when asked
+func (s *JobServiceServer) GetStoreStats(ctx context.Context, req *pb.GetStoreStatsReq) (*pb.StoreStats, error) {
+	log := s.logger.WithFields("operation", "GetStoreStats", "compact", req.GetCompact(), "heapProfile", req.GetHeapProfile())
+
+	if err := s.auth.Authorized(ctx, auth2.InspectWorkerOp); err != nil {
 		log.Warn("authorization failed", "error", err)
-		return err
+		return nil, err
 	}
 
-	existingLogs, isRunning, err := s.jobStore.GetOutput(req.GetId())
+	if req.GetCompact() {
+		result := s.jobStore.Compact(s.compaction, time.Now())
+		log.Info("store compacted on request", "compressed", result.Compressed, "savedBytes", result.SavedBytes,
+			"expired", result.Expired, "expiredBytes", result.ExpiredBytes)
+	}
+
+	stats := storeStats(s.jobStore.Stats())
+	if req.GetHeapProfile() {
+		var profile bytes.Buffer
+		if err := pprof.Lookup("heap").WriteTo(&profile, 0); err != nil {
+			log.Error("failed to write heap profile", "error", err)
+			return nil, status.Errorf(codes.Internal, "failed to write heap profile: %v", err)
+		}
+		stats.HeapProfile = profile.Bytes()
+	}
+	return stats, nil
+}
+
+// storeStats converts the store's stats, adding the memory of the process
+func storeStats(stats state.StoreStats) *pb.StoreStats {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	res := &pb.StoreStats{
+		Jobs:           stats.Jobs,
+		FinishedJobs:   stats.FinishedJobs,
+		HistoryEntries: stats.HistoryEntries,
+		OutputBuffer: &pb.OutputBuffer{
+			UsedBytes:    stats.Buffer.UsedBytes,
+			BudgetBytes:  stats.Buffer.BudgetBytes,
+			SpilledBytes: stats.Buffer.SpilledBytes,
+			SpilledJobs:  stats.Buffer.SpilledJobs,
+		},
+		CompressedJobs:    stats.CompressedJobs,
+		CompressedBytes:   stats.CompressedBytes,
+		UncompressedBytes: stats.UncompressedBytes,
+		Compactions:       stats.Compactions,
+		SavedBytes:        stats.SavedBytes,
+		ExpiredJobs:       stats.ExpiredJobs,
+		ExpiredBytes:      stats.ExpiredBytes,
+		Memory: &pb.MemoryStats{
+			HeapAllocBytes:    int64(mem.HeapAlloc),
+			HeapInuseBytes:    int64(mem.HeapInuse),
+			HeapReleasedBytes: int64(mem.HeapReleased),
+			SysBytes:          int64(mem.Sys),
+			NumGC:             int64(mem.NumGC),
+			Goroutines:        int32(runtime.NumGoroutine()),
+		},
+	}
+	if !stats.LastCompaction.IsZero() {
+		res.LastCompaction = stats.LastCompaction.Format("2006-01-02T15:04:05Z07:00")
+	}
+	return res
+}
+
+// GetDiagnostics checks the subsystems the worker needs to run jobs and
+// reports, for each one that is not fine, how to fix it
+func (s *JobServiceServer) GetDiagnostics(ctx context.Context, _ *pb.EmptyRequest) (*pb.Diagnostics, error) {
+	log := s.logger.WithField("operation", "GetDiagnostics")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	diagnostics := s.jobWorker.Diagnostics(ctx)
+	if !diagnostics.Ready() {
+		log.Warn("worker diagnostics found failed checks")
+	}
+	return mappers.DomainToDiagnostics(diagnostics), nil
+}
+
+// verifySignature checks the signature of the request a spec was converted
+// from, recording the key it was signed with in the spec
+func (s *JobServiceServer) verifySignature(req *pb.RunJobReq, spec *domain.JobSpec) error {
+	signedBy, err := s.signatures.Verify(req)
 	if err != nil {
-		log.Warn("job not found for log streaming")
-		return status.Errorf(codes.NotFound, "job not found")
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	spec.SignedBy = signedBy
+	return nil
+}
+
+// authorizeSpec checks the extra permissions a job needs on top of run_job:
+// shell mode, isolation weaker than full and privileged isolation, and that
+// its spec is signed if the worker requires it
+func (s *JobServiceServer) authorizeSpec(ctx context.Context, spec *domain.JobSpec) error {
+	if s.signatures.Required() && spec.SignedBy == "" {
+		return status.Error(codes.PermissionDenied, "this worker only runs jobs with signed specs")
+	}
+	if spec.Shell {
+		if err := s.auth.Authorized(ctx, auth2.RunShellJobOp); err != nil {
+			return err
+		}
+	}
+	if spec.Isolation.Weaker() {
+		if err := s.auth.Authorized(ctx, auth2.RunUnisolatedJobOp); err != nil {
+			return err
+		}
 	}
+	if spec.Isolation == domain.IsolationPrivileged {
+		return s.auth.Authorized(ctx, auth2.RunPrivilegedJobOp)
+	}
+	return nil
+}
 
-	log.Debug("streaming job logs", "jobId", req.GetId(), "existingLogSize", len(existingLogs), "isRunning", isRunning)
+// applyProfile fills the limits a spec leaves unset from its named limit
+// profile, after checking the caller holds one of the profile's roles
+func (s *JobServiceServer) applyProfile(ctx context.Context, spec *domain.JobSpec) error {
+	if spec.Profile == "" {
+		return nil
+	}
 
-	// streaming the existing logs from the existingLogs
-	if e := stream.Send(&pb.DataChunk{Payload: existingLogs}); e != nil {
-		log.Error("failed to send existing logs", "error", e, "logSize", len(existingLogs))
-		return e
+	profile, ok := s.profiles[spec.Profile]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown limit profile %q", spec.Profile)
 	}
 
-	log.Debug("existing logs sent", "logSize", len(existingLogs))
+	if len(profile.Roles) > 0 {
+		roles := make([]auth2.ClientRole, 0, len(profile.Roles))
+		for _, r := range profile.Roles {
+			roles = append(roles, auth2.ClientRole(strings.ToLower(r)))
+		}
+		if err := s.auth.AuthorizedRoles(ctx, roles); err != nil {
+			return err
+		}
+	}
 
-	// already completed, we're done
-	if !isRunning {
-		log.Debug("job already completed, log stream ended", "jobId", req.GetId())
+	if spec.Limits.CPUMillis == 0 {
+		spec.Limits.CPUMillis = profile.CPUMillis
+	}
+	if spec.Limits.MemoryBytes == 0 {
+		spec.Limits.MemoryBytes = profile.MemoryBytes
+	}
+	if spec.Limits.IOBPS == 0 {
+		spec.Limits.IOBPS = profile.IOBPS
+	}
+	return nil
+}
+
+// capabilityReason is the ErrorInfo reason of requests refused for missing capabilities
+const capabilityReason = "CAPABILITY_UNAVAILABLE"
+
+// capabilityError is the UNIMPLEMENTED status for a job needing missing
+// capabilities, naming them in an ErrorInfo detail clients can inspect
+func capabilityError(missing []domain.Capability) error {
+	names := joinCapabilities(missing)
+	st := status.Newf(codes.Unimplemented, "worker does not support %s", names)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   capabilityReason,
+		Domain:   string(pb.File_jobworker_v1_worker_proto.Package()),
+		Metadata: map[string]string{"capabilities": names},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// expiry returns why a job of the spec must expire instead of starting or
+// being held, nil if it need not: it is past its start deadline, or held
+// until after it
+func expiry(spec *domain.JobSpec, hold schedule.Hold, held bool, now time.Time) error {
+	deadline := spec.StartDeadline()
+	switch {
+	case deadline.IsZero():
 		return nil
+	case now.After(deadline):
+		return fmt.Errorf("not started within its queue timeout of %v, submitted at %s", spec.QueueTimeout, spec.Submitted.Format(time.RFC3339))
+	case held && (hold.Until.IsZero() || hold.Until.After(deadline)):
+		return fmt.Errorf("held (%s) past its queue timeout of %v", hold.Reason, spec.QueueTimeout)
 	}
+	return nil
+}
 
-	// subscribe to new updates not the existing ones
-	domainStream := adapters.NewGrpcStreamAdapter(stream)
-	streamStartTime := time.Now()
+// cgroupReason is the ErrorInfo reason of jobs whose cgroup the kernel refused
+const cgroupReason = "CGROUP_WRITE_FAILED"
 
-	e := s.jobStore.SendUpdatesToClient(stream.Context(), req.GetId(), domainStream)
+// cgroupCodes are the codes of the kinds of cgroup errors: a limit the
+// kernel rejects is the client's to change, a full hierarchy frees up as jobs
+// finish, and the others are for the operator to fix on the host
+var cgroupCodes = map[domain.CgroupErrorKind]codes.Code{
+	domain.CgroupPermissionDenied:  codes.FailedPrecondition,
+	domain.CgroupControllerMissing: codes.Unimplemented,
+	domain.CgroupInvalidValue:      codes.InvalidArgument,
+	domain.CgroupLimitReached:      codes.ResourceExhausted,
+	domain.CgroupWriteFailed:       codes.Internal,
+}
 
-	streamDuration := time.Since(streamStartTime)
+// cgroupStatusError is the status for a job whose cgroup could not be set up,
+// with the kind of failure and what to do about it in an ErrorInfo detail
+func cgroupStatusError(err error, cgroupErr *domain.CgroupError) error {
+	code, ok := cgroupCodes[cgroupErr.Kind]
+	if !ok {
+		code = codes.Internal
+	}
+	hint := cgroupErr.Hint()
+	st := status.Newf(code, "job run failed: %v (%s)", err, hint)
+	detailed, detailErr := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   cgroupReason,
+		Domain:   string(pb.File_jobworker_v1_worker_proto.Package()),
+		Metadata: map[string]string{"kind": string(cgroupErr.Kind), "path": cgroupErr.Path, "hint": hint},
+	})
+	if detailErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
 
-	if e != nil && errors.Is(e, errors.New("stream cancelled by client")) {
-		log.Debug("log stream cancelled by client", "duration", streamDuration)
-		return status.Error(codes.Canceled, "log stream cancelled by client")
+// heldReason is the ErrorInfo reason of requests refused by the schedule
+const heldReason = "JOB_HELD"
+
+// heldError is the FAILED_PRECONDITION status for a job the schedule holds,
+// with when it may start in an ErrorInfo and a RetryInfo detail. A job that
+// never may start gets no details.
+func heldError(hold schedule.Hold, now time.Time) error {
+	if hold.Until.IsZero() {
+		return status.Errorf(codes.FailedPrecondition, "job can never start: %s", hold.Reason)
 	}
 
-	if e != nil {
-		log.Error("log stream ended with error", "error", e, "duration", streamDuration)
-	} else {
-		log.Debug("log stream completed successfully", "duration", streamDuration)
+	until := hold.Until.Format(time.RFC3339)
+	st := status.Newf(codes.FailedPrecondition, "job held (%s) until %s", hold.Reason, until)
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   heldReason,
+		Domain:   string(pb.File_jobworker_v1_worker_proto.Package()),
+		Metadata: map[string]string{"until": until},
+	}, &errdetails.RetryInfo{RetryDelay: durationpb.New(hold.Until.Sub(now))})
+	if err != nil {
+		return st.Err()
 	}
+	return detailed.Err()
+}
 
-	return e
+func joinCapabilities(capabilities []domain.Capability) string {
+	names := make([]string, len(capabilities))
+	for i, c := range capabilities {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ",")
+}
+
+func (s *JobServiceServer) GetJobStatus(ctx context.Context, req *pb.GetJobStatusReq) (*pb.GetJobStatusRes, error) {
+	log := s.logger.WithFields("operation", "GetJobStatus", "jobId", req.GetId())
+
+	log.Debug("get job status request received")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		log.Warn("job not found")
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+
+	log.Debug("job retrieved successfully", "status", string(job.Status), "duration", job.Duration())
+
+	return mappers.DomainToGetJobStatusResponse(s.redactJob(job)), nil
+}
+
+// GetJobProvenance returns the record of what a job was launched with
+func (s *JobServiceServer) GetJobProvenance(ctx context.Context, req *pb.GetJobProvenanceReq) (*pb.JobProvenance, error) {
+	log := s.logger.WithFields("operation", "GetJobProvenance", "jobId", req.GetId())
+
+	log.Debug("get job provenance request received")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		log.Warn("job not found")
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	if job.Provenance == nil {
+		return nil, status.Errorf(codes.NotFound, "job %s has no provenance record", req.GetId())
+	}
+
+	return mappers.DomainToJobProvenance(job.Id, job.Provenance), nil
+}
+
+// GetJobProcesses lists the processes of a running job with their CPU and
+// memory use, as a tree
+func (s *JobServiceServer) GetJobProcesses(ctx context.Context, req *pb.GetJobProcessesReq) (*pb.JobProcesses, error) {
+	log := s.logger.WithFields("operation", "GetJobProcesses", "jobId", req.GetId())
+
+	log.Debug("get job processes request received")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		log.Warn("job not found")
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	if !job.IsRunning() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is %s, only running jobs have processes", job.Id, job.Status)
+	}
+
+	processes, err := metrics.ReadProcesses(job)
+	if err != nil {
+		log.Warn("failed to read job processes", "error", err)
+		return nil, status.Errorf(codes.Internal, "failed to read the processes of job %s: %v", job.Id, err)
+	}
+
+	return mappers.DomainToJobProcesses(job.Id, s.redactProcesses(processes), time.Now()), nil
+}
+
+// redactProcesses masks secrets in the command lines of processes, as in the
+// job's output: tokens passed as arguments show in /proc
+func (s *JobServiceServer) redactProcesses(processes []domain.JobProcess) []domain.JobProcess {
+	for i := range processes {
+		processes[i].Command = s.redactor.RedactString(processes[i].Command)
+	}
+	return processes
+}
+
+func (s *JobServiceServer) StopJob(ctx context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error) {
+	log := s.logger.WithFields("operation", "StopJob", "jobId", req.GetId())
+
+	log.Debug("stop job request received")
+
+	if err := s.auth.Authorized(ctx, auth2.StopJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	opts, err := domain.NewStopOptions(req.GetSignal(), time.Duration(req.GetGracePeriodMillis())*time.Millisecond)
+	if err != nil {
+		log.Warn("invalid stop options", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	startTime := time.Now()
+	result, err := s.jobWorker.StopJob(ctx, req.GetId(), opts)
+	if err != nil {
+		duration := time.Since(startTime)
+		log.Error("job stop failed", "error", err, "duration", duration)
+		return nil, status.Errorf(codes.Internal, "StopJob error %v", err)
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		log.Warn("job not found after stop operation")
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+
+	duration := time.Since(startTime)
+	log.Debug("job stopped successfully", "finalStatus", string(job.Status), "method", result.Method, "duration", duration)
+
+	return mappers.DomainToStopJobResponse(job, result), nil
+}
+
+// ResumeJob restarts a job held in a crash loop
+func (s *JobServiceServer) ResumeJob(ctx context.Context, req *pb.ResumeJobReq) (*pb.ResumeJobRes, error) {
+	log := s.logger.WithFields("operation", "ResumeJob", "jobId", req.GetId())
+
+	log.Debug("resume job request received")
+
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	if !job.IsCrashLooping() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is %s, only crash-looping jobs can be resumed", job.Id, job.Status)
+	}
+
+	if err := s.jobWorker.ResumeJob(ctx, job.Id); err != nil {
+		log.Error("job resume failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "ResumeJob error %v", err)
+	}
+
+	if job, exists = s.jobStore.GetJob(req.GetId()); !exists {
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+
+	log.Info("job resumed")
+	return &pb.ResumeJobRes{Id: job.Id, Status: string(job.Status)}, nil
+}
+
+// DeleteJob removes a finished job and everything the worker keeps of it
+func (s *JobServiceServer) DeleteJob(ctx context.Context, req *pb.DeleteJobReq) (*pb.DeleteJobRes, error) {
+	log := s.logger.WithFields("operation", "DeleteJob", "jobId", req.GetId())
+
+	log.Debug("delete job request received")
+
+	if err := s.auth.Authorized(ctx, auth2.DeleteJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		return nil, status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	if !job.IsCompleted() {
+		return nil, status.Errorf(codes.FailedPrecondition, "job %s is %s, stop it before deleting", job.Id, job.Status)
+	}
+
+	if err := s.deleteJob(ctx, job.Id); err != nil {
+		log.Error("job delete failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "DeleteJob error %v", err)
+	}
+
+	log.Info("job deleted")
+	return &pb.DeleteJobRes{Id: job.Id}, nil
+}
+
+// offloadedWorker is a platform worker that may have copied finished jobs to
+// object storage
+type offloadedWorker interface {
+	DeleteOffloaded(ctx context.Context, job *domain.Job) error
+}
+
+// deleteJob removes what was offloaded of a finished job from object storage,
+// then its record and buffered output from the store, and its workspace and
+// captured stdout from disk. A job whose offloaded copy cannot be removed is
+// kept, so that the delete can be retried.
+func (s *JobServiceServer) deleteJob(ctx context.Context, jobID string) error {
+	if job, exists := s.jobStore.GetJob(jobID); exists && job.IsCompleted() {
+		if err := s.deleteOffloaded(ctx, job); err != nil {
+			return fmt.Errorf("failed to delete offloaded output, job kept: %w", err)
+		}
+	}
+	if err := s.jobStore.DeleteJob(jobID); err != nil {
+		return err
+	}
+	return s.workspaces.Remove(jobID)
+}
+
+func (s *JobServiceServer) deleteOffloaded(ctx context.Context, job *domain.Job) error {
+	worker, ok := s.jobWorker.(offloadedWorker)
+	if !ok {
+		if job.OutputLocation != "" {
+			return fmt.Errorf("output was offloaded to %s", job.OutputLocation)
+		}
+		return nil
+	}
+	return worker.DeleteOffloaded(ctx, job)
+}
+
+// exportChunkSize bounds the payload of each message of an export or artifact stream
+const exportChunkSize = 64 * 1024
+
+// ExportJob streams a tarball of a job, for attaching to incident tickets or
+// sharing a reproduction. Running jobs are exported as they are so far.
+func (s *JobServiceServer) ExportJob(req *pb.ExportJobReq, stream pb.JobService_ExportJobServer) error {
+	log := s.logger.WithFields("operation", "ExportJob", "jobId", req.GetId())
+
+	log.Debug("export job request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.StreamJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	job, exists := s.jobStore.GetJob(req.GetId())
+	if !exists {
+		return status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	// the job may be deleted meanwhile
+	history, err := s.jobStore.History(job.Id)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+	output, _, err := s.jobStore.GetOutput(job.Id)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+
+	bundle := &export.Bundle{
+		Job:     mappers.DomainToProtobuf(s.redactJob(job)),
+		History: history,
+		Output:  output,
+	}
+	w := bufio.NewWriterSize(chunkSender{stream}, exportChunkSize)
+	if err := export.Write(w, bundle, time.Now()); err != nil {
+		log.Error("job export failed", "error", err)
+		return status.Errorf(codes.Internal, "ExportJob error %v", err)
+	}
+	if err := w.Flush(); err != nil {
+		log.Warn("job export stream failed", "error", err)
+		return err
+	}
+
+	log.Debug("job exported", "outputSize", len(output), "historyEntries", len(history))
+	return nil
+}
+
+// GetJobArtifact streams a file from a job's workspace, such as the core file
+// of a crashed job. It is available for as long as the workspace is kept.
+func (s *JobServiceServer) GetJobArtifact(req *pb.GetJobArtifactReq, stream pb.JobService_GetJobArtifactServer) error {
+	log := s.logger.WithFields("operation", "GetJobArtifact", "jobId", req.GetId(), "path", req.GetPath())
+
+	log.Debug("get job artifact request received")
+
+	// core dumps hold the job's memory, secrets included, and staged pipeline
+	// output is not redacted, so artifacts are not for viewers
+	if err := s.auth.Authorized(stream.Context(), auth2.GetJobArtifactOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	if _, exists := s.jobStore.GetJob(req.GetId()); !exists {
+		return status.Errorf(codes.NotFound, "job not found %v", req.GetId())
+	}
+
+	f, err := s.workspaces.OpenArtifact(req.GetId(), req.GetPath())
+	switch {
+	case errors.Is(err, workspace.ErrInvalidPath):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, os.ErrNotExist):
+		return status.Errorf(codes.NotFound, "artifact %q not found in the workspace of job %v", req.GetPath(), req.GetId())
+	case err != nil:
+		log.Error("failed to open artifact", "error", err)
+		return status.Errorf(codes.Internal, "GetJobArtifact error %v", err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriterSize(chunkSender{stream}, exportChunkSize)
+	written, err := io.Copy(w, f)
+	if err == nil {
+		err = w.Flush()
+	}
+	if err != nil {
+		log.Warn("artifact stream failed", "error", err)
+		return err
+	}
+
+	log.Debug("artifact sent", "bytes", written)
+	return nil
+}
+
+// chunkSender sends what is written to it as DataChunk messages of at most
+// exportChunkSize bytes
+type chunkSender struct {
+	stream pb.JobService_ExportJobServer
+}
+
+func (c chunkSender) Write(p []byte) (int, error) {
+	for sent := 0; sent < len(p); {
+		n := min(len(p)-sent, exportChunkSize)
+		if err := c.stream.Send(&pb.DataChunk{Payload: p[sent : sent+n]}); err != nil {
+			return sent, err
+		}
+		sent += n
+	}
+	return len(p), nil
+}
+
+func (s *JobServiceServer) ListJobs(ctx context.Context, _ *pb.EmptyRequest) (*pb.Jobs, error) {
+	log := s.logger.WithField("operation", "ListJobs")
+
+	log.Debug("list jobs request received")
+
+	if err := s.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	startTime := time.Now()
+	jobs := s.jobStore.ListJobs()
+
+	rawJobs := &pb.Jobs{}
+	statusCounts := make(map[string]int)
+
+	for _, job := range jobs {
+		rawJobs.Jobs = append(rawJobs.Jobs, mappers.DomainToProtobuf(s.redactJob(job)))
+		statusCounts[string(job.Status)]++
+	}
+
+	duration := time.Since(startTime)
+	log.Debug("jobs listed successfully",
+		"totalJobs", len(jobs),
+		"statusBreakdown", statusCounts,
+		"duration", duration)
+
+	return rawJobs, nil
+}
+
+func (s *JobServiceServer) GetJobLogs(req *pb.GetJobLogsReq, stream pb.JobService_GetJobLogsServer) error {
+	log := s.logger.WithFields("operation", "GetJobLogs", "jobId", req.GetId())
+
+	log.Debug("job logs stream request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.StreamJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	existingLogs, isRunning, err := s.jobStore.GetOutput(req.GetId())
+	if err != nil {
+		log.Warn("job not found for log streaming")
+		return status.Errorf(codes.NotFound, "job not found")
+	}
+
+	if job, exists := s.jobStore.GetJob(req.GetId()); exists && job.OutputLocation != "" && len(existingLogs) == 0 {
+		log.Debug("job output was offloaded", "location", job.OutputLocation)
+		return status.Errorf(codes.FailedPrecondition, "job output was offloaded to %s", job.OutputLocation)
+	} else if exists && job.OutputExpired && len(existingLogs) == 0 {
+		log.Debug("job output expired")
+		return status.Errorf(codes.FailedPrecondition, "job output was removed once the output retention passed")
+	}
+
+	if req.GetOffset() < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid offset: %d", req.GetOffset())
+	}
+	// a resumed stream only needs what the client has not seen yet
+	existingLogs = existingLogs[min(int64(len(existingLogs)), req.GetOffset()):]
+
+	log.Debug("streaming job logs", "jobId", req.GetId(), "existingLogSize", len(existingLogs), "isRunning", isRunning, "offset", req.GetOffset())
+
+	// streaming the existing logs from the existingLogs
+	if e := stream.Send(&pb.DataChunk{Payload: existingLogs}); e != nil {
+		log.Error("failed to send existing logs", "error", e, "logSize", len(existingLogs))
+		return e
+	}
+
+	log.Debug("existing logs sent", "logSize", len(existingLogs))
+
+	// already completed, we're done
+	if !isRunning {
+		log.Debug("job already completed, log stream ended", "jobId", req.GetId())
+		return nil
+	}
+
+	// subscribe to new updates not the existing ones
+	domainStream := adapters.NewGrpcStreamAdapter(stream)
+	streamStartTime := time.Now()
+
+	e := s.jobStore.SendUpdatesToClient(stream.Context(), req.GetId(), domainStream)
+
+	streamDuration := time.Since(streamStartTime)
+
+	if e != nil && errors.Is(e, errors.New("stream cancelled by client")) {
+		log.Debug("log stream cancelled by client", "duration", streamDuration)
+		return status.Error(codes.Canceled, "log stream cancelled by client")
+	}
+
+	if e != nil {
+		log.Error("log stream ended with error", "error", e, "duration", streamDuration)
+	} else {
+		log.Debug("log stream completed successfully", "duration", streamDuration)
+	}
+
+	return e
+}
+
+func (s *JobServiceServer) WriteJobStdin(stream pb.JobService_WriteJobStdinServer) error {
+	log := s.logger.WithField("operation", "WriteJobStdin")
+
+	log.Debug("job stdin stream request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	chunk, err := stream.Recv()
+	if err == io.EOF {
+		return status.Error(codes.InvalidArgument, "no job id given")
+	}
+	if err != nil {
+		return err
+	}
+
+	jobID := chunk.GetId()
+	log = log.WithField("jobId", jobID)
+
+	if _, exists := s.jobStore.GetJob(jobID); !exists {
+		log.Warn("job not found for stdin")
+		return status.Errorf(codes.NotFound, "job not found %v", jobID)
+	}
+
+	stdin, err := s.jobWorker.OpenStdin(jobID)
+	if err != nil {
+		log.Warn("job stdin not available", "error", err)
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	}
+	defer stdin.Close()
+
+	var written int64
+	for {
+		if len(chunk.GetPayload()) > 0 {
+			n, e := stdin.Write(chunk.GetPayload())
+			written += int64(n)
+			if e != nil {
+				log.Debug("job stopped reading stdin", "bytes", written, "error", e)
+				return status.Errorf(codes.FailedPrecondition, "job stopped reading stdin after %d bytes", written)
+			}
+		}
+
+		chunk, err = stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			log.Debug("stdin stream ended with error", "bytes", written, "error", err)
+			return err
+		}
+	}
+
+	log.Debug("job stdin closed", "bytes", written)
+
+	return stream.SendAndClose(&pb.WriteJobStdinRes{Bytes: written})
+}
+
+// defaultMetricsInterval is used when a metrics stream does not ask for an interval
+const defaultMetricsInterval = 2 * time.Second
+
+func (s *JobServiceServer) StreamJobMetrics(req *pb.StreamJobMetricsReq, stream pb.JobService_StreamJobMetricsServer) error {
+	log := s.logger.WithFields("operation", "StreamJobMetrics", "jobIds", req.GetIds())
+
+	log.Debug("job metrics stream request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.StreamJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	if req.GetIntervalSeconds() < 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid interval: %d", req.GetIntervalSeconds())
+	}
+	interval := defaultMetricsInterval
+	if req.GetIntervalSeconds() > 0 {
+		interval = time.Duration(req.GetIntervalSeconds()) * time.Second
+	}
+
+	for _, id := range req.GetIds() {
+		if _, exists := s.jobStore.GetJob(id); !exists {
+			log.Warn("job not found for metrics streaming", "jobId", id)
+			return status.Errorf(codes.NotFound, "job not found: %s", id)
+		}
+	}
+
+	sampler := metrics.NewSampler()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := stream.Send(s.metricsSnapshot(req.GetIds(), sampler, log)); err != nil {
+			log.Debug("metrics stream ended", "error", err)
+			return err
+		}
+
+		select {
+		case <-stream.Context().Done():
+			log.Debug("metrics stream cancelled by client")
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// SubscribeJobEvents streams job events as the worker publishes them
+func (s *JobServiceServer) SubscribeJobEvents(req *pb.SubscribeJobEventsReq, stream pb.JobService_SubscribeJobEventsServer) error {
+	log := s.logger.WithFields("operation", "SubscribeJobEvents", "jobIds", req.GetIds(), "types", req.GetTypes())
+
+	log.Debug("job events subscription received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.StreamJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	types, err := mappers.EventTypesToDomain(req.GetTypes())
+	if err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	wantJob := make(map[string]bool, len(req.GetIds()))
+	for _, id := range req.GetIds() {
+		wantJob[id] = true
+	}
+	wantType := make(map[events.Type]bool, len(types))
+	for _, t := range types {
+		wantType[t] = true
+	}
+
+	updates, unsubscribe := s.events.Subscribe()
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			log.Debug("job events subscription cancelled by client")
+			return nil
+		case e, ok := <-updates:
+			if !ok {
+				log.Warn("job events subscriber fell behind")
+				return status.Error(codes.ResourceExhausted, "subscriber fell behind and events were dropped")
+			}
+			if (len(wantJob) > 0 && !wantJob[e.JobID]) || (len(wantType) > 0 && !wantType[e.Type]) {
+				continue
+			}
+			if err := stream.Send(mappers.EventToProtobuf(e)); err != nil {
+				log.Debug("job events stream ended", "error", err)
+				return err
+			}
+		}
+	}
+}
+
+// metricsSnapshot reads the usage of the selected jobs, oldest first, and sums
+// it per job group and tenant. Jobs whose cgroup cannot be read are still
+// listed, without usage.
+func (s *JobServiceServer) metricsSnapshot(ids []string, sampler *metrics.Sampler, log *logger.Logger) *pb.JobMetricsSnapshot {
+	var jobs []*domain.Job
+	if len(ids) == 0 {
+		jobs = s.jobStore.ListJobs()
+	} else {
+		for _, id := range ids {
+			if job, exists := s.jobStore.GetJob(id); exists {
+				jobs = append(jobs, job)
+			}
+		}
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartTime.Before(jobs[j].StartTime)
+	})
+
+	snapshot := &pb.JobMetricsSnapshot{Timestamp: time.Now().Format("2006-01-02T15:04:05Z07:00")}
+	running := make(map[string]bool)
+	groups, tenants := domain.UsageTotals{}, domain.UsageTotals{}
+	for _, job := range jobs {
+		var usage *domain.JobUsage
+		if job.IsRunning() {
+			running[job.Id] = true
+			var err error
+			if usage, err = sampler.Sample(job); err != nil {
+				log.Debug("failed to read job usage", "jobId", job.Id, "error", err)
+			}
+		}
+		if usage != nil {
+			if job.GroupId != "" {
+				groups.Add(job.GroupId, usage)
+			}
+			tenants.Add(job.Labels[s.tenant], usage)
+		}
+		snapshot.Jobs = append(snapshot.Jobs, mappers.DomainToJobMetrics(job, usage))
+	}
+	sampler.Forget(running)
+	snapshot.Groups = mappers.UsageTotalsToAggregateMetrics(groups)
+	snapshot.Tenants = mappers.UsageTotalsToAggregateMetrics(tenants)
+
+	return snapshot
+}
+
+// redactJob returns a copy of the job with sensitive environment values masked
+// so that secrets passed to a job are never echoed back over the API
+func (s *JobServiceServer) redactJob(job *domain.Job) *domain.Job {
+	if s.redactor == nil || len(job.Env) == 0 {
+		return job
+	}
+
+	redacted := job.DeepCopy()
+	redacted.Env = s.redactor.RedactEnv(job.Env)
+	return redacted
+}
+
+func (s *JobServiceServer) CreateSecret(ctx context.Context, req *pb.CreateSecretReq) (*pb.CreateSecretRes, error) {
+	log := s.logger.WithFields("operation", "CreateSecret", "name", req.GetName())
+
+	log.Debug("create secret request received")
+
+	if err := s.auth.Authorized(ctx, auth2.CreateSecretOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	createdAt, err := s.secrets.Create(req.GetName(), req.GetValue())
+	if err != nil {
+		log.Warn("secret creation failed", "error", err)
+		return nil, secretStatusError(err)
+	}
+
+	return &pb.CreateSecretRes{
+		Name:      req.GetName(),
+		CreatedAt: createdAt.Format("2006-01-02T15:04:05Z07:00"),
+	}, nil
+}
+
+func (s *JobServiceServer) DeleteSecret(ctx context.Context, req *pb.DeleteSecretReq) (*pb.DeleteSecretRes, error) {
+	log := s.logger.WithFields("operation", "DeleteSecret", "name", req.GetName())
+
+	log.Debug("delete secret request received")
+
+	if err := s.auth.Authorized(ctx, auth2.DeleteSecretOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	if err := s.secrets.Delete(req.GetName()); err != nil {
+		log.Warn("secret deletion failed", "error", err)
+		return nil, secretStatusError(err)
+	}
+
+	return &pb.DeleteSecretRes{Name: req.GetName()}, nil
+}
+
+// secretStatusError maps secrets store errors to gRPC status codes
+func secretStatusError(err error) error {
+	switch {
+	case errors.Is(err, secrets.ErrDisabled):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, secrets.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, secrets.ErrAlreadyExists):
+		return status.Error(codes.AlreadyExists, err.Error())
+	case errors.Is(err, secrets.ErrInvalidName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "secret operation failed: %v", err)
+	}
+}
+
+// GetUsageReport reports the resources the jobs of a tenant consumed
+func (s *JobServiceServer) GetUsageReport(ctx context.Context, req *pb.GetUsageReportReq) (*pb.UsageReport, error) {
+	log := s.logger.WithFields("operation", "GetUsageReport", "tenant", req.GetTenant())
+
+	log.Debug("get usage report request received")
+
+	if err := s.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	var from, to time.Time
+	var err error
+	if req.GetStartTime() != "" {
+		if from, err = time.Parse(time.RFC3339, req.GetStartTime()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid start time: %v", err)
+		}
+	}
+	if req.GetEndTime() != "" {
+		if to, err = time.Parse(time.RFC3339, req.GetEndTime()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid end time: %v", err)
+		}
+	}
+
+	records, err := s.usage.Report(req.GetTenant(), from, to)
+	if errors.Is(err, usage.ErrDisabled) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		log.Error("usage report failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "usage report failed: %v", err)
+	}
+
+	return mappers.UsageReportToProtobuf(records, time.Now()), nil
+}
+
+// UploadJobFiles stages files sent by the client so a following RunJob can
+// reference them by upload ID. The upload is discarded if the stream fails.
+func (s *JobServiceServer) UploadJobFiles(stream pb.JobService_UploadJobFilesServer) error {
+	log := s.logger.WithField("operation", "UploadJobFiles")
+
+	log.Debug("upload job files request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	upload, err := s.workspaces.NewUpload()
+	if err != nil {
+		log.Error("failed to create upload", "error", err)
+		return status.Errorf(codes.Internal, "failed to create upload: %v", err)
+	}
+	log = log.WithField("uploadId", upload.ID)
+
+	for {
+		chunk, e := stream.Recv()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			upload.Abort()
+			log.Warn("upload stream failed", "error", e)
+			return e
+		}
+
+		if e := upload.Write(chunk.GetPath(), chunk.GetMode(), chunk.GetData()); e != nil {
+			upload.Abort()
+			log.Warn("upload rejected", "error", e)
+			return uploadStatusError(e)
+		}
+	}
+
+	if e := upload.Close(); e != nil {
+		upload.Abort()
+		return status.Errorf(codes.Internal, "failed to finish upload: %v", e)
+	}
+
+	log.Debug("upload staged", "files", upload.Files(), "totalBytes", upload.Size())
+
+	return stream.SendAndClose(&pb.UploadJobFilesRes{
+		UploadId:   upload.ID,
+		Files:      int32(upload.Files()),
+		TotalBytes: upload.Size(),
+	})
+}
+
+// SubmitJob starts a job whose request is streamed in chunks, for requests
+// over the message size limit, acknowledging the bytes taken as chunks
+// arrive. The request then gets the checks of RunJob.
+func (s *JobServiceServer) SubmitJob(stream pb.JobService_SubmitJobServer) error {
+	log := s.logger.WithField("operation", "SubmitJob")
+
+	log.Debug("submit job request received")
+
+	if err := s.auth.Authorized(stream.Context(), auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return err
+	}
+
+	var data []byte
+	total := int64(-1)
+	for {
+		chunk, e := stream.Recv()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			log.Warn("submit stream failed", "error", e)
+			return e
+		}
+
+		// the header, the first chunk, says how much is coming
+		if total < 0 {
+			total = chunk.GetTotalBytes()
+			if total <= 0 {
+				return status.Error(codes.InvalidArgument, "the first chunk must give the size of the job request")
+			}
+			if total > s.maxSubmit {
+				log.Warn("job request too large", "totalBytes", total, "limit", s.maxSubmit)
+				return status.Errorf(codes.ResourceExhausted, "job request of %d bytes is over the %d byte limit", total, s.maxSubmit)
+			}
+			data = make([]byte, 0, total)
+		}
+		if int64(len(data)+len(chunk.GetData())) > total {
+			return status.Errorf(codes.InvalidArgument, "job request is longer than the %d bytes announced", total)
+		}
+		data = append(data, chunk.GetData()...)
+
+		if e := stream.Send(&pb.SubmitJobProgress{ReceivedBytes: int64(len(data))}); e != nil {
+			return e
+		}
+	}
+
+	if total < 0 {
+		return status.Error(codes.InvalidArgument, "no job request received")
+	}
+	if int64(len(data)) != total {
+		return status.Errorf(codes.InvalidArgument, "job request ended after %d of %d bytes", len(data), total)
+	}
+
+	runJobReq := &pb.RunJobReq{}
+	if e := proto.Unmarshal(data, runJobReq); e != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid job request encoding: %v", e)
+	}
+	log = log.WithFields("command", runJobReq.Command, "totalBytes", total,
+		"envVars", len(runJobReq.Env), "envFileSize", len(runJobReq.EnvFile))
+
+	newJob, err := s.startJob(stream.Context(), runJobReq, log)
+	if err != nil {
+		return err
+	}
+
+	return stream.Send(&pb.SubmitJobProgress{
+		ReceivedBytes: total,
+		Job:           mappers.DomainToRunJobResponse(s.redactJob(newJob)),
+	})
+}
+
+// uploadStatusError maps workspace upload errors to gRPC status codes
+func uploadStatusError(err error) error {
+	switch {
+	case errors.Is(err, workspace.ErrInvalidPath):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, workspace.ErrUploadTooLarge), errors.Is(err, workspace.ErrTooManyFiles):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "upload failed: %v", err)
+	}
+}
+
+// RunPipeline starts a set of jobs that run in dependency order, with upstream
+// output staged in downstream workspaces. It returns once the pipeline is accepted.
+func (s *JobServiceServer) RunPipeline(ctx context.Context, req *pb.RunPipelineReq) (*pb.Pipeline, error) {
+	log := s.logger.WithFields("operation", "RunPipeline", "steps", len(req.Steps))
+
+	log.Debug("run pipeline request received")
+
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	spec, err := mappers.RunPipelineRequestToSpec(req)
+	if err != nil {
+		log.Warn("invalid run pipeline request", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid pipeline request: %v", err)
+	}
+	for i, step := range spec.Steps {
+		if err := s.verifySignature(req.Steps[i].Job, step.Job); err != nil {
+			log.Warn("job spec signature rejected", "step", step.Name, "error", err)
+			return nil, err
+		}
+	}
+
+	// secrets are resolved up front so a missing secret fails the request, not a later step
+	for _, step := range spec.Steps {
+		if err := s.authorizeSpec(ctx, step.Job); err != nil {
+			log.Warn("job settings not authorized", "step", step.Name, "error", err)
+			return nil, err
+		}
+		if err := s.applyProfile(ctx, step.Job); err != nil {
+			log.Warn("limit profile rejected", "step", step.Name, "profile", step.Job.Profile, "error", err)
+			return nil, err
+		}
+		step.Job.Secrets, err = s.secrets.Resolve(step.Job.SecretEnv)
+		if err != nil {
+			log.Warn("secret resolution failed", "step", step.Name, "error", err)
+			return nil, secretStatusError(err)
+		}
+	}
+
+	p, err := s.pipelines.Start(spec)
+	if err != nil {
+		log.Warn("pipeline rejected", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid pipeline: %v", err)
+	}
+
+	log.Debug("pipeline accepted", "pipelineId", p.Id)
+
+	return mappers.DomainToPipelineResponse(p), nil
+}
+
+func (s *JobServiceServer) GetPipelineStatus(ctx context.Context, req *pb.GetPipelineStatusReq) (*pb.Pipeline, error) {
+	log := s.logger.WithFields("operation", "GetPipelineStatus", "pipelineId", req.GetId())
+
+	log.Debug("get pipeline status request received")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	p, err := s.pipelines.Get(req.GetId())
+	if err != nil {
+		log.Warn("pipeline not found")
+		return nil, status.Errorf(codes.NotFound, "pipeline not found %v", req.GetId())
+	}
+
+	return mappers.DomainToPipelineResponse(p), nil
+}
+
+// RunJobGroup starts a set of jobs that are then listed and stopped together
+func (s *JobServiceServer) RunJobGroup(ctx context.Context, req *pb.RunJobGroupReq) (*pb.JobGroup, error) {
+	log := s.logger.WithFields("operation", "RunJobGroup", "name", req.GetName(), "jobs", len(req.Jobs))
+
+	log.Debug("run job group request received")
+
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	if len(req.Jobs) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "job group has no jobs")
+	}
+	limits := domain.ResourceLimits{CPUMillis: req.CpuLimitMillis, MemoryBytes: req.MemoryLimitBytes, IOBPS: req.IoLimitBPS}
+	if limits.CPUMillis < 0 || limits.MemoryBytes < 0 || limits.IOBPS < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid group limits: %+v", limits)
+	}
+
+	// every job is checked before any of them starts
+	specs := make([]*domain.JobSpec, 0, len(req.Jobs))
+	for i, jobReq := range req.Jobs {
+		spec, err := mappers.RunJobRequestToSpec(jobReq)
+		if err != nil {
+			log.Warn("invalid job in group", "index", i, "error", err)
+			return nil, status.Errorf(codes.InvalidArgument, "invalid job %d: %v", i, err)
+		}
+		if err := s.verifySignature(jobReq, spec); err != nil {
+			log.Warn("job spec signature rejected", "index", i, "error", err)
+			return nil, err
+		}
+
+		if err := s.authorizeSpec(ctx, spec); err != nil {
+			log.Warn("job settings not authorized", "index", i, "error", err)
+			return nil, err
+		}
+		if err := s.applyProfile(ctx, spec); err != nil {
+			log.Warn("limit profile rejected", "index", i, "profile", spec.Profile, "error", err)
+			return nil, err
+		}
+
+		spec.Secrets, err = s.secrets.Resolve(spec.SecretEnv)
+		if err != nil {
+			log.Warn("secret resolution failed", "index", i, "error", err)
+			return nil, secretStatusError(err)
+		}
+		specs = append(specs, spec)
+	}
+
+	g, err := s.groups.Start(ctx, req.GetName(), limits, specs)
+	if err != nil {
+		log.Error("job group start failed", "error", err)
+		return nil, status.Errorf(codes.Internal, "job group run failed: %v", err)
+	}
+
+	log.Debug("job group started", "groupId", g.Id)
+
+	return s.jobGroupResponse(g, s.groups.Jobs(g)), nil
+}
+
+func (s *JobServiceServer) GetJobGroup(ctx context.Context, req *pb.GetJobGroupReq) (*pb.JobGroup, error) {
+	log := s.logger.WithFields("operation", "GetJobGroup", "groupId", req.GetId())
+
+	log.Debug("get job group request received")
+
+	if err := s.auth.Authorized(ctx, auth2.GetJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	g, jobs, err := s.groups.Get(req.GetId())
+	if err != nil {
+		log.Warn("job group not found")
+		return nil, status.Errorf(codes.NotFound, "job group not found %v", req.GetId())
+	}
+
+	return s.jobGroupResponse(g, jobs), nil
+}
+
+func (s *JobServiceServer) ListJobGroups(ctx context.Context, _ *pb.EmptyRequest) (*pb.JobGroups, error) {
+	log := s.logger.WithField("operation", "ListJobGroups")
+
+	log.Debug("list job groups request received")
+
+	if err := s.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	res := &pb.JobGroups{}
+	for _, g := range s.groups.List() {
+		res.Groups = append(res.Groups, s.jobGroupResponse(g, s.groups.Jobs(g)))
+	}
+
+	return res, nil
+}
+
+// StopJobGroup stops every running job of a group, reporting jobs that could not be stopped
+func (s *JobServiceServer) StopJobGroup(ctx context.Context, req *pb.StopJobGroupReq) (*pb.StopJobGroupRes, error) {
+	log := s.logger.WithFields("operation", "StopJobGroup", "groupId", req.GetId())
+
+	log.Debug("stop job group request received")
+
+	if err := s.auth.Authorized(ctx, auth2.StopJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	failures, err := s.groups.Stop(ctx, req.GetId())
+	if err != nil {
+		log.Warn("job group not found")
+		return nil, status.Errorf(codes.NotFound, "job group not found %v", req.GetId())
+	}
+
+	g, jobs, err := s.groups.Get(req.GetId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "job group not found %v", req.GetId())
+	}
+
+	res := &pb.StopJobGroupRes{Group: s.jobGroupResponse(g, jobs)}
+	if len(failures) > 0 {
+		log.Warn("some jobs of the group could not be stopped", "failed", len(failures))
+		res.Errors = make(map[string]string, len(failures))
+		for jobID, e := range failures {
+			res.Errors[jobID] = e.Error()
+		}
+	}
+
+	return res, nil
+}
+
+func (s *JobServiceServer) jobGroupResponse(g *domain.JobGroup, jobs []*domain.Job) *pb.JobGroup {
+	redacted := make([]*domain.Job, len(jobs))
+	for i, job := range jobs {
+		redacted[i] = s.redactJob(job)
+	}
+	return mappers.DomainToJobGroupResponse(g, redacted)
+}
+
+// CreateJobTemplate stores a job spec with parameters jobs can be run from by name
+func (s *JobServiceServer) CreateJobTemplate(ctx context.Context, req *pb.CreateJobTemplateReq) (*pb.JobTemplate, error) {
+	log := s.logger.WithFields("operation", "CreateJobTemplate", "name", req.GetTemplate().GetName(), "replace", req.GetReplace())
+
+	log.Debug("create job template request received")
+
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	tmpl, err := mappers.JobTemplateToDomain(req.GetTemplate())
+	if err != nil {
+		log.Warn("invalid job template", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job template: %v", err)
+	}
+	// jobs run from the template count as signed by its signer, the store
+	// refuses parameters in what a signed template runs
+	if err := s.verifySignature(req.GetTemplate().GetSpec(), tmpl.Spec); err != nil {
+		log.Warn("job template signature rejected", "error", err)
+		return nil, err
+	}
+
+	stored, err := s.templates.Create(tmpl, req.GetReplace())
+	if err != nil {
+		log.Warn("job template rejected", "error", err)
+		if errors.Is(err, templates.ErrAlreadyExists) {
+			return nil, status.Error(codes.AlreadyExists, err.Error())
+		}
+		return nil, status.Errorf(codes.InvalidArgument, "invalid job template: %v", err)
+	}
+
+	log.Info("job template stored", "params", len(stored.Params))
+
+	return mappers.DomainToJobTemplate(stored), nil
+}
+
+func (s *JobServiceServer) ListJobTemplates(ctx context.Context, _ *pb.EmptyRequest) (*pb.JobTemplates, error) {
+	log := s.logger.WithField("operation", "ListJobTemplates")
+
+	log.Debug("list job templates request received")
+
+	if err := s.auth.Authorized(ctx, auth2.ListJobsOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	res := &pb.JobTemplates{}
+	for _, tmpl := range s.templates.List() {
+		res.Templates = append(res.Templates, mappers.DomainToJobTemplate(tmpl))
+	}
+
+	return res, nil
+}
+
+// RunJobFromTemplate starts a job from a template's spec with its parameters
+// filled in. The spec is authorized for the caller like a RunJob request.
+func (s *JobServiceServer) RunJobFromTemplate(ctx context.Context, req *pb.RunJobFromTemplateReq) (*pb.RunJobRes, error) {
+	log := s.logger.WithFields("operation", "RunJobFromTemplate", "template", req.GetTemplate(), "params", len(req.GetParams()))
+
+	log.Debug("run job from template request received")
+
+	if err := s.auth.Authorized(ctx, auth2.RunJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	tmpl, err := s.templates.Get(req.GetTemplate())
+	if err != nil {
+		log.Warn("job template not found")
+		return nil, status.Errorf(codes.NotFound, "job template not found %v", req.GetTemplate())
+	}
+
+	spec, err := tmpl.Instantiate(req.GetParams())
+	if err != nil {
+		log.Warn("invalid template parameters", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template parameters: %v", err)
+	}
+	// the template was validated before its placeholders were filled in
+	if err := s.jobWorker.ValidateJob(ctx, spec).Err(); err != nil {
+		log.Warn("instantiated job spec rejected", "error", err)
+		return nil, status.Errorf(codes.InvalidArgument, "invalid template parameters: %v", err)
+	}
+
+	newJob, err := s.startSpec(ctx, spec, log)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("job run from template", "jobId", newJob.Id)
+	return mappers.DomainToRunJobResponse(s.redactJob(newJob)), nil
+}
+
+func (s *JobServiceServer) StopJobs(ctx context.Context, req *pb.BulkJobsReq) (*pb.BulkJobsRes, error) {
+	log := s.logger.WithFields("operation", "StopJobs", "ids", len(req.GetIds()))
+
+	log.Debug("bulk stop request received")
+
+	if err := s.auth.Authorized(ctx, auth2.StopJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	jobIDs, byFilter, err := s.selectJobs(req)
+	if err != nil {
+		log.Warn("invalid job selection", "error", err)
+		return nil, err
+	}
+
+	res := &pb.BulkJobsRes{}
+	for _, jobID := range jobIDs {
+		// a filter also matches finished jobs, which there is no point in stopping
+		if byFilter {
+			if job, exists := s.jobStore.GetJob(jobID); exists && job.IsCompleted() {
+				continue
+			}
+		}
+		_, err := s.jobWorker.StopJob(ctx, jobID, domain.StopOptions{})
+		res.Results = append(res.Results, bulkResult(jobID, err))
+	}
+
+	log.Debug("bulk stop finished", "selected", len(res.Results), "failed", countFailed(res))
+
+	return res, nil
+}
+
+func (s *JobServiceServer) DeleteJobs(ctx context.Context, req *pb.BulkJobsReq) (*pb.BulkJobsRes, error) {
+	log := s.logger.WithFields("operation", "DeleteJobs", "ids", len(req.GetIds()))
+
+	log.Debug("bulk delete request received")
+
+	if err := s.auth.Authorized(ctx, auth2.DeleteJobOp); err != nil {
+		log.Warn("authorization failed", "error", err)
+		return nil, err
+	}
+
+	jobIDs, _, err := s.selectJobs(req)
+	if err != nil {
+		log.Warn("invalid job selection", "error", err)
+		return nil, err
+	}
+
+	res := &pb.BulkJobsRes{}
+	for _, jobID := range jobIDs {
+		res.Results = append(res.Results, bulkResult(jobID, s.deleteJob(ctx, jobID)))
+	}
+
+	log.Debug("bulk delete finished", "selected", len(res.Results), "failed", countFailed(res))
+
+	return res, nil
+}
+
+// selectJobs resolves the IDs a bulk request applies to and reports whether they
+// were selected by filter. Requests must name jobs either by ID or by a
+// non-empty filter, so that a missing field never selects every job.
+func (s *JobServiceServer) selectJobs(req *pb.BulkJobsReq) ([]string, bool, error) {
+	filter, err := mappers.JobFilterToDomain(req.GetFilter())
+	if err != nil {
+		return nil, false, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+	}
+
+	switch {
+	case len(req.GetIds()) > 0 && !filter.IsEmpty():
+		return nil, false, status.Error(codes.InvalidArgument, "select jobs by id or by filter, not both")
+	case len(req.GetIds()) > 0:
+		return req.GetIds(), false, nil
+	case filter.IsEmpty():
+		return nil, false, status.Error(codes.InvalidArgument, "no job ids or filter given")
+	}
+
+	jobs := s.jobStore.FindJobs(filter, time.Now())
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartTime.Before(jobs[j].StartTime)
+	})
+
+	var jobIDs []string
+	for _, job := range jobs {
+		jobIDs = append(jobIDs, job.Id)
+	}
+	return jobIDs, true, nil
+}
+
+func bulkResult(jobID string, err error) *pb.BulkJobResult {
+	if err != nil {
+		return &pb.BulkJobResult{Id: jobID, Error: err.Error()}
+	}
+	return &pb.BulkJobResult{Id: jobID, Ok: true}
+}
+
+func countFailed(res *pb.BulkJobsRes) int {
+	failed := 0
+	for _, r := range res.Results {
+		if !r.Ok {
+			failed++
+		}
+	}
+	return failed
 }
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	pb "worker/api/gen"
+	"worker/internal/worker/auth/authfakes"
+	"worker/internal/worker/core/interfaces/interfacesfakes"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/templates"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRedactProcesses(t *testing.T) {
+	redactor, err := redact.New(config.RedactionConfig{Enabled: true, Mask: "***", Patterns: []string{`ghp_[A-Za-z0-9]+`}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	redactor.AddValue("s3cr3t-value")
+	s := &JobServiceServer{redactor: redactor}
+
+	processes := s.redactProcesses([]domain.JobProcess{
+		{Pid: 1, Command: "deploy --password=s3cr3t-value --token ghp_abc123"},
+		{Pid: 2, Command: "sleep 60"},
+	})
+
+	if got := processes[0].Command; got != "deploy --password=*** --token ***" || strings.Contains(got, "s3cr3t") {
+		t.Errorf("expected the secrets in the arguments masked, got %q", got)
+	}
+	if got := processes[1].Command; got != "sleep 60" {
+		t.Errorf("expected a command without secrets kept, got %q", got)
+	}
+}
+
+func TestRunJobFromTemplateValidatesValues(t *testing.T) {
+	store := templates.NewStore()
+	if _, err := store.Create(&domain.JobTemplate{
+		Name:   "report",
+		Params: []domain.TemplateParam{{Name: "month", Required: true}},
+		Spec:   &domain.JobSpec{Command: "report", Args: []string{"--month={{month}}"}},
+	}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// the worker rejects the spec once the value is in, as it would a request
+	worker := &interfacesfakes.FakeWorker{}
+	worker.ValidateJobStub = func(_ context.Context, spec *domain.JobSpec) *domain.JobValidation {
+		validation := &domain.JobValidation{}
+		if strings.Contains(spec.Args[0], "\x00") {
+			validation.Add("arguments", errors.New("argument contains null bytes"))
+		}
+		return validation
+	}
+	s := &JobServiceServer{auth: &authfakes.FakeGrpcAuthorization{}, jobWorker: worker, templates: store, logger: logger.New()}
+
+	_, err := s.RunJobFromTemplate(context.Background(), &pb.RunJobFromTemplateReq{Template: "report", Params: map[string]string{"month": "2026-01\x00--all"}})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected the instantiated spec to be rejected, got %v", err)
+	}
+	if _, spec := worker.ValidateJobArgsForCall(0); spec.Args[0] != "--month=2026-01\x00--all" {
+		t.Errorf("expected the spec validated with the values filled in, got %q", spec.Args)
+	}
+	if worker.StartJobCallCount() != 0 {
+		t.Error("expected no job started")
+	}
+}
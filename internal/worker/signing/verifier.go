@@ -0,0 +1,95 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	pb "worker/api/gen"
+	"worker/pkg/client"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// ErrInvalidSignature is returned for a request whose signature no
+// configured key verifies
+var ErrInvalidSignature = errors.New("job spec signature does not verify with any signing key")
+
+// Verifier checks the detached signatures clients put on job requests, so a
+// worker can require job definitions signed ahead of time, whatever client
+// certificate they are submitted with
+type Verifier struct {
+	keys     []key
+	required bool
+}
+
+type key struct {
+	name   string
+	public ed25519.PublicKey
+}
+
+// New loads the public keys of the config
+func New(cfg config.SpecSigningConfig) (*Verifier, error) {
+	v := &Verifier{required: cfg.Required}
+	for _, k := range cfg.Keys {
+		public, err := loadPublicKey(k.PublicKey)
+		if err != nil {
+			return nil, fmt.Errorf("spec signing key %s: %w", k.Name, err)
+		}
+		v.keys = append(v.keys, key{name: k.Name, public: public})
+	}
+
+	if len(v.keys) > 0 {
+		logger.WithField("component", "signing").Info("job spec signing keys loaded", "keys", len(v.keys), "required", v.required)
+	}
+	return v, nil
+}
+
+func loadPublicKey(path string) (ed25519.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not PEM encoded", path)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	public, ok := parsed.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("%s holds a %T, not an Ed25519 key", path, parsed)
+	}
+	return public, nil
+}
+
+// Required reports whether only jobs with signed specs may run
+func (v *Verifier) Required() bool {
+	return v != nil && v.required
+}
+
+// Verify returns the name of the key req is signed with, "" when it is not
+// signed, or ErrInvalidSignature
+func (v *Verifier) Verify(req *pb.RunJobReq) (string, error) {
+	if len(req.GetSignature()) == 0 {
+		return "", nil
+	}
+
+	payload, err := client.SigningPayload(req)
+	if err != nil {
+		return "", err
+	}
+	if v != nil {
+		for _, k := range v.keys {
+			if ed25519.Verify(k.public, payload, req.Signature) {
+				return k.name, nil
+			}
+		}
+	}
+	return "", ErrInvalidSignature
+}
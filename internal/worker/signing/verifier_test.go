@@ -0,0 +1,96 @@
+package signing
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	pb "worker/api/gen"
+	"worker/pkg/client"
+	"worker/pkg/config"
+)
+
+func writePublicKey(t *testing.T, public ed25519.PublicKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "key.pub.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestVerify(t *testing.T) {
+	releasePublic, releaseKey, _ := ed25519.GenerateKey(nil)
+	_, otherKey, _ := ed25519.GenerateKey(nil)
+
+	v, err := New(config.SpecSigningConfig{Required: true, Keys: []config.SigningKey{
+		{Name: "release-team", PublicKey: writePublicKey(t, releasePublic)},
+	}})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	if !v.Required() {
+		t.Error("expected signed specs to be required")
+	}
+
+	signed := &pb.RunJobReq{Command: "/usr/bin/make", Args: []string{"release"}, Env: map[string]string{"B": "2", "A": "1"}}
+	if err := client.SignRunJobReq(signed, releaseKey); err != nil {
+		t.Fatal(err)
+	}
+	if name, err := v.Verify(signed); err != nil || name != "release-team" {
+		t.Errorf("Verify(signed) = %q, %v; want release-team", name, err)
+	}
+
+	tampered := &pb.RunJobReq{Command: "/usr/bin/make", Args: []string{"clean"}, Env: signed.Env, Signature: signed.Signature}
+	if _, err := v.Verify(tampered); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected a tampered request to fail, got %v", err)
+	}
+
+	foreign := &pb.RunJobReq{Command: "/usr/bin/make"}
+	if err := client.SignRunJobReq(foreign, otherKey); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v.Verify(foreign); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected an unknown key to fail, got %v", err)
+	}
+
+	if name, err := v.Verify(&pb.RunJobReq{Command: "/usr/bin/make"}); name != "" || err != nil {
+		t.Errorf("Verify(unsigned) = %q, %v; want no signer", name, err)
+	}
+}
+
+func TestVerify_NoKeys(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	req := &pb.RunJobReq{Command: "/bin/true"}
+	if err := client.SignRunJobReq(req, key); err != nil {
+		t.Fatal(err)
+	}
+
+	var none *Verifier
+	if none.Required() {
+		t.Error("expected a nil verifier not to require signatures")
+	}
+	if _, err := none.Verify(req); !errors.Is(err, ErrInvalidSignature) {
+		t.Errorf("expected a signature nothing can verify to fail, got %v", err)
+	}
+}
+
+func TestNew_InvalidKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, []byte("not a key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(config.SpecSigningConfig{Keys: []config.SigningKey{{Name: "bad", PublicKey: path}}}); err == nil {
+		t.Error("expected an error for a file without a key")
+	}
+	if _, err := New(config.SpecSigningConfig{Keys: []config.SigningKey{{Name: "missing", PublicKey: path + ".missing"}}}); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
@@ -0,0 +1,260 @@
+//go:build linux
+
+package sockets
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/sys/unix"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Registry opens the listening sockets jobs ask for. A socket stays open for
+// as long as any job holds it, so a job started on the address of a running
+// job gets the very same socket and takes over its connections without any
+// being refused in between.
+type Registry struct {
+	mutex   sync.Mutex
+	unixDir string
+	open    map[string]*socket // by address
+	logger  *logger.Logger
+}
+
+// socket is an open listening socket and the number of jobs holding it
+type socket struct {
+	file    *os.File
+	path    string // socket file of a unix socket, removed when it is closed
+	holders int
+}
+
+// New creates a registry from configuration. It returns nil when sockets are
+// disabled; all Registry methods are safe to call on nil.
+func New(cfg config.SocketsConfig) *Registry {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	r := &Registry{
+		unixDir: cfg.UnixDir,
+		open:    make(map[string]*socket),
+		logger:  logger.WithField("component", "sockets"),
+	}
+	r.logger.Info("job sockets enabled", "unixDir", cfg.UnixDir)
+	return r
+}
+
+// Check reports whether the worker can open the given sockets
+func (r *Registry) Check(sockets []domain.JobSocket) error {
+	if len(sockets) == 0 {
+		return nil
+	}
+	if r == nil {
+		return fmt.Errorf("job sockets are not enabled on this worker")
+	}
+	return domain.ValidateJobSockets(sockets)
+}
+
+// Acquire holds the given sockets for a job, opening those no other job
+// holds. It returns their files in the order asked for and a function
+// releasing them; a socket is closed once no job holds it anymore.
+func (r *Registry) Acquire(sockets []domain.JobSocket) ([]*os.File, func(), error) {
+	if r == nil || len(sockets) == 0 {
+		return nil, func() {}, nil
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var held []string
+	release := func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+		r.release(held)
+	}
+
+	files := make([]*os.File, 0, len(sockets))
+	for _, s := range sockets {
+		open, ok := r.open[s.Address]
+		if !ok {
+			file, path, err := r.listen(s.Address)
+			if err != nil {
+				r.release(held)
+				return nil, nil, fmt.Errorf("socket %s: %w", s, err)
+			}
+			open = &socket{file: file, path: path}
+			r.open[s.Address] = open
+			r.logger.Debug("socket opened", "address", s.Address)
+		}
+		open.holders++
+		held = append(held, s.Address)
+		files = append(files, open.file)
+	}
+
+	var once sync.Once
+	return files, func() { once.Do(release) }, nil
+}
+
+// Adopt holds the given sockets for a job handed over by the worker binary
+// this one replaced, from the files of the sockets it had open. Like Acquire,
+// it returns the files to pass to the job and a function releasing them.
+func (r *Registry) Adopt(sockets []domain.JobSocket, files []*os.File) ([]*os.File, func()) {
+	if r == nil {
+		var once sync.Once
+		return files, func() {
+			once.Do(func() {
+				for _, file := range files {
+					_ = file.Close()
+				}
+			})
+		}
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	held := make([]string, 0, len(sockets))
+	adopted := make([]*os.File, 0, len(sockets))
+	for i, s := range sockets {
+		open, ok := r.open[s.Address]
+		if !ok {
+			open = &socket{file: files[i]}
+			if addr, err := domain.ParseSocketAddress(s.Address); err == nil && addr.Network == "unix" {
+				open.path = filepath.Join(r.unixDir, addr.Address)
+			}
+			r.open[s.Address] = open
+		} else if open.file != files[i] {
+			_ = files[i].Close()
+		}
+		open.holders++
+		held = append(held, s.Address)
+		adopted = append(adopted, open.file)
+	}
+
+	var once sync.Once
+	return adopted, func() {
+		once.Do(func() {
+			r.mutex.Lock()
+			defer r.mutex.Unlock()
+			r.release(held)
+		})
+	}
+}
+
+// release drops a hold on each address, the caller holds mutex
+func (r *Registry) release(addresses []string) {
+	for _, address := range addresses {
+		open := r.open[address]
+		if open.holders--; open.holders > 0 {
+			continue
+		}
+
+		delete(r.open, address)
+		_ = open.file.Close()
+		if open.path != "" {
+			_ = os.Remove(open.path)
+		}
+		r.logger.Debug("socket closed", "address", address)
+	}
+}
+
+// listen opens a blocking listening socket, as the jobs it is passed to
+// expect, and returns the socket file of a unix socket
+func (r *Registry) listen(address string) (*os.File, string, error) {
+	addr, err := domain.ParseSocketAddress(address)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if addr.Network == "unix" {
+		path := filepath.Join(r.unixDir, addr.Address)
+		if err := removeStale(path); err != nil {
+			return nil, "", err
+		}
+		file, err := bindListen(unix.AF_UNIX, &unix.SockaddrUnix{Name: path}, address)
+		return file, path, err
+	}
+
+	tcp, err := net.ResolveTCPAddr("tcp", addr.Address)
+	if err != nil {
+		return nil, "", err
+	}
+	switch ip := tcp.IP; {
+	case tcp.Zone != "":
+		return nil, "", fmt.Errorf("scoped IPv6 addresses are not supported")
+	case ip == nil:
+		// all interfaces, IPv6 as well where the host has it
+		sa := &unix.SockaddrInet6{Port: tcp.Port}
+		if file, err := bindListen(unix.AF_INET6, sa, address); err == nil || !errors.Is(err, unix.EAFNOSUPPORT) {
+			return file, "", err
+		}
+		return bindListenInet4(net.IPv4zero, tcp.Port, address)
+	case ip.To4() != nil:
+		return bindListenInet4(ip, tcp.Port, address)
+	default:
+		sa := &unix.SockaddrInet6{Port: tcp.Port}
+		copy(sa.Addr[:], ip.To16())
+		file, err := bindListen(unix.AF_INET6, sa, address)
+		return file, "", err
+	}
+}
+
+func bindListenInet4(ip net.IP, port int, name string) (*os.File, string, error) {
+	sa := &unix.SockaddrInet4{Port: port}
+	copy(sa.Addr[:], ip.To4())
+	file, err := bindListen(unix.AF_INET, sa, name)
+	return file, "", err
+}
+
+// bindListen creates a stream socket of family bound to sa and listening
+func bindListen(family int, sa unix.Sockaddr, name string) (*os.File, error) {
+	fd, err := unix.Socket(family, unix.SOCK_STREAM|unix.SOCK_CLOEXEC, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the socket: %w", err)
+	}
+
+	if family != unix.AF_UNIX {
+		err = unix.SetsockoptInt(fd, unix.SOL_SOCKET, unix.SO_REUSEADDR, 1)
+	}
+	if err == nil && family == unix.AF_INET6 {
+		err = unix.SetsockoptInt(fd, unix.IPPROTO_IPV6, unix.IPV6_V6ONLY, 0)
+	}
+	if err == nil {
+		if err = unix.Bind(fd, sa); err != nil {
+			err = fmt.Errorf("failed to bind: %w", err)
+		}
+	}
+	if err == nil {
+		err = unix.Listen(fd, unix.SOMAXCONN)
+	}
+	if err != nil {
+		_ = unix.Close(fd)
+		return nil, err
+	}
+	return os.NewFile(uintptr(fd), name), nil
+}
+
+// removeStale removes a unix socket file left by a worker that did not shut
+// down cleanly, and refuses to replace anything else
+func removeStale(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	info, err := os.Lstat(path)
+	switch {
+	case os.IsNotExist(err):
+		return nil
+	case err != nil:
+		return err
+	case info.Mode().Type() != os.ModeSocket:
+		return fmt.Errorf("%s exists and is not a socket", path)
+	}
+	return os.Remove(path)
+}
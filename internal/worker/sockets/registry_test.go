@@ -0,0 +1,142 @@
+//go:build linux
+
+package sockets_test
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/sockets"
+	"worker/pkg/config"
+)
+
+func TestCheck(t *testing.T) {
+	var disabled *sockets.Registry
+	if err := disabled.Check(nil); err != nil {
+		t.Errorf("a job without sockets should pass on a worker without them: %v", err)
+	}
+	if err := disabled.Check([]domain.JobSocket{{Name: "http", Address: "tcp://:8080"}}); err == nil {
+		t.Error("expected sockets to be rejected when disabled")
+	}
+
+	registry := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: t.TempDir()})
+	if err := registry.Check([]domain.JobSocket{{Name: "a:b", Address: "tcp://:8080"}}); err == nil {
+		t.Error("expected an invalid socket name to be rejected")
+	}
+}
+
+func TestAcquire_SharesSocketsUntilReleased(t *testing.T) {
+	dir := t.TempDir()
+	registry := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: dir})
+	want := []domain.JobSocket{{Name: "api", Address: "unix://api.sock"}}
+
+	first, releaseFirst, err := registry.Acquire(want)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	second, releaseSecond, err := registry.Acquire(want)
+	if err != nil {
+		t.Fatalf("second Acquire failed: %v", err)
+	}
+	if first[0] != second[0] {
+		t.Error("expected the jobs to share the socket")
+	}
+
+	path := filepath.Join(dir, "api.sock")
+	releaseFirst()
+	releaseFirst()
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("expected the socket to stay open while a job holds it: %v", err)
+	}
+	_ = conn.Close()
+
+	releaseSecond()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket file to be removed, got %v", err)
+	}
+}
+
+func TestAcquire_ListensBlocking(t *testing.T) {
+	registry := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: t.TempDir()})
+
+	files, release, err := registry.Acquire([]domain.JobSocket{{Name: "http", Address: "tcp://127.0.0.1:0"}})
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+	defer release()
+
+	// Fd would make the file blocking itself
+	raw, err := files[0].SyscallConn()
+	if err != nil {
+		t.Fatalf("SyscallConn failed: %v", err)
+	}
+	var flags int
+	var sa unix.Sockaddr
+	_ = raw.Control(func(fd uintptr) {
+		flags, _ = unix.FcntlInt(fd, unix.F_GETFL, 0)
+		sa, _ = unix.Getsockname(int(fd))
+	})
+	if flags&unix.O_NONBLOCK != 0 {
+		t.Errorf("expected a blocking socket, got flags %#x", flags)
+	}
+
+	port := sa.(*unix.SockaddrInet4).Port
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		t.Fatalf("expected the socket to accept connections: %v", err)
+	}
+	_ = conn.Close()
+}
+
+func TestAcquire_RollsBackOnError(t *testing.T) {
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "data"), []byte("not a socket"), 0644)
+	registry := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: dir})
+
+	_, _, err := registry.Acquire([]domain.JobSocket{
+		{Name: "api", Address: "unix://api.sock"},
+		{Name: "data", Address: "unix://data"},
+	})
+	if err == nil {
+		t.Fatal("expected an error for a path that is not a socket")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "api.sock")); !os.IsNotExist(err) {
+		t.Errorf("expected the socket opened before the error to be closed, got %v", err)
+	}
+}
+
+func TestAdopt_SharesHandedOverSockets(t *testing.T) {
+	dir := t.TempDir()
+	previous := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: dir})
+	want := []domain.JobSocket{{Name: "api", Address: "unix://api.sock"}}
+	handed, _, err := previous.Acquire(want)
+	if err != nil {
+		t.Fatalf("Acquire failed: %v", err)
+	}
+
+	registry := sockets.New(config.SocketsConfig{Enabled: true, UnixDir: dir})
+	adopted, releaseAdopted := registry.Adopt(want, handed)
+	if adopted[0] != handed[0] {
+		t.Error("expected the handed over socket to be passed on")
+	}
+	acquired, releaseAcquired, err := registry.Acquire(want)
+	if err != nil {
+		t.Fatalf("Acquire of an adopted socket failed: %v", err)
+	}
+	if acquired[0] != handed[0] {
+		t.Error("expected a new job to share the adopted socket")
+	}
+
+	path := filepath.Join(dir, "api.sock")
+	releaseAdopted()
+	releaseAcquired()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected the socket file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,176 @@
+package state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/pkg/config"
+)
+
+// spillLowWatermark is the percent of the budget spilling brings the buffered
+// output down to, so one spill makes room for more than a single chunk
+const spillLowWatermark = 90
+
+// BufferStats is the memory taken by the output buffered for all jobs, and
+// what was moved to disk to keep it within the budget
+type BufferStats struct {
+	UsedBytes    int64
+	BudgetBytes  int64 // 0 when unlimited
+	SpilledBytes int64 // moved to disk since the worker started
+	SpilledJobs  int64
+}
+
+// budget accounts the output buffered by all tasks. With a max, buffers are
+// spilled to files once the total goes over it, and a pressure event is sent
+// once it reaches the warning threshold.
+type budget struct {
+	maxBytes  int64 // 0 = unlimited
+	warnBytes int64
+	lowBytes  int64
+	spillDir  string
+	bus       *events.Bus
+
+	used         atomic.Int64
+	spilledBytes atomic.Int64
+	spilledJobs  atomic.Int64
+	warned       atomic.Bool // a pressure event was sent and the use has not dropped below the threshold since
+
+	spilling sync.Mutex
+}
+
+// newBudget creates the budget of cfg, clearing what a previous worker left in
+// the spill dir since the jobs it belonged to are gone
+func newBudget(cfg config.OutputBufferConfig, bus *events.Bus) (*budget, error) {
+	b := &budget{bus: bus}
+	if cfg.MaxBytes <= 0 {
+		return b, nil
+	}
+
+	if err := os.RemoveAll(cfg.SpillDir); err != nil {
+		return nil, fmt.Errorf("failed to clear output spill dir: %w", err)
+	}
+	if err := os.MkdirAll(cfg.SpillDir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create output spill dir: %w", err)
+	}
+
+	b.maxBytes = cfg.MaxBytes
+	b.warnBytes = cfg.MaxBytes * int64(cfg.WarnPercent) / 100
+	b.lowBytes = cfg.MaxBytes * spillLowWatermark / 100
+	b.spillDir = cfg.SpillDir
+	return b, nil
+}
+
+func (b *budget) add(n int) {
+	b.used.Add(int64(n))
+}
+
+func (b *budget) release(n int) {
+	if b.used.Add(-int64(n)) < b.warnBytes {
+		b.warned.Store(false)
+	}
+}
+
+// stats reports the current use
+func (b *budget) stats() BufferStats {
+	return BufferStats{
+		UsedBytes:    b.used.Load(),
+		BudgetBytes:  b.maxBytes,
+		SpilledBytes: b.spilledBytes.Load(),
+		SpilledJobs:  b.spilledJobs.Load(),
+	}
+}
+
+// spillCandidate is a task holding output in memory
+type spillCandidate struct {
+	task     *Task
+	job      *domain.Job
+	buffered int
+}
+
+// enforceBudget sends the pressure event once the buffered output reaches the
+// warning threshold, and spills buffers once it is over the budget
+func (st *store) enforceBudget() {
+	b := st.budget
+	if b.maxBytes == 0 {
+		return
+	}
+
+	used := b.used.Load()
+	if used >= b.warnBytes && b.warned.CompareAndSwap(false, true) {
+		st.logger.Warn("buffered job output is near the budget", "usedBytes", used, "budgetBytes", b.maxBytes)
+		if b.bus != nil {
+			b.bus.Publish(events.Event{
+				Type: events.OutputPressure,
+				Err:  fmt.Errorf("buffered job output takes %d of %d bytes", used, b.maxBytes),
+			})
+		}
+	}
+	if used <= b.maxBytes {
+		return
+	}
+
+	// one writer spills for all, the others carry on over the budget meanwhile
+	if !b.spilling.TryLock() {
+		return
+	}
+	defer b.spilling.Unlock()
+
+	for _, c := range st.spillCandidates() {
+		if b.used.Load() <= b.lowBytes {
+			break
+		}
+
+		freed, err := c.task.spillBuffer(b.spillDir)
+		if err != nil {
+			st.logger.Error("failed to spill job output", "jobId", c.job.Id, "bytes", c.buffered, "error", err)
+			continue
+		}
+		if freed == 0 {
+			continue
+		}
+
+		b.release(freed)
+		b.spilledBytes.Add(int64(freed))
+		b.spilledJobs.Add(1)
+		st.logger.Info("job output spilled to disk", "jobId", c.job.Id, "bytes", freed, "usedBytes", b.used.Load())
+	}
+}
+
+// spillCandidates lists the tasks holding output in memory in the order they
+// are spilled: finished jobs first, oldest first, then running jobs, largest
+// first
+func (st *store) spillCandidates() []spillCandidate {
+	var candidates []spillCandidate
+	for i := range st.shards {
+		sh := &st.shards[i]
+		sh.mutex.RLock()
+		for _, tk := range sh.tasks {
+			if n := tk.bufferedBytes(); n > 0 {
+				candidates = append(candidates, spillCandidate{task: tk, job: tk.GetJob(), buffered: n})
+			}
+		}
+		sh.mutex.RUnlock()
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i], candidates[j]
+		if a.job.IsCompleted() != b.job.IsCompleted() {
+			return a.job.IsCompleted()
+		}
+		if a.job.IsCompleted() && a.job.EndTime != nil && b.job.EndTime != nil {
+			return a.job.EndTime.Before(*b.job.EndTime)
+		}
+		return a.buffered > b.buffered
+	})
+	return candidates
+}
+
+// spillPath is the file the output of a job is spilled to
+func spillPath(dir, jobID string) string {
+	return filepath.Join(dir, jobID+".out")
+}
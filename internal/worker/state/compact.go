@@ -0,0 +1,197 @@
+package state
+
+import (
+	"sync/atomic"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// CompactPolicy is how long after a job finished its output is compressed and
+// dropped, 0 for never
+type CompactPolicy struct {
+	CompressAfter time.Duration
+	Retention     time.Duration
+}
+
+// NewCompactPolicy returns the compaction policy of cfg
+func NewCompactPolicy(cfg config.OutputBufferConfig) CompactPolicy {
+	return CompactPolicy{CompressAfter: cfg.CompressAfter, Retention: cfg.Retention}
+}
+
+// CompactResult is what one compaction did
+type CompactResult struct {
+	Compressed   int   // jobs whose output was compressed
+	SavedBytes   int64 // memory the compression freed
+	Expired      int   // jobs whose output was dropped
+	ExpiredBytes int64 // memory dropping the output freed, spilled output is not counted
+}
+
+// StoreStats is the memory the store takes, as far as it can tell, and what
+// compaction did since the worker started
+type StoreStats struct {
+	Jobs           int64
+	FinishedJobs   int64
+	HistoryEntries int64
+	Buffer         BufferStats
+
+	CompressedJobs    int64
+	CompressedBytes   int64 // memory the compressed output takes, part of Buffer.UsedBytes
+	UncompressedBytes int64 // what the compressed output takes once decompressed
+
+	Compactions    int64
+	LastCompaction time.Time // zero when there was none
+	SavedBytes     int64     // freed by compression
+	ExpiredJobs    int64
+	ExpiredBytes   int64
+}
+
+// compactions counts what compaction did, for StoreStats
+type compactions struct {
+	runs         atomic.Int64
+	last         atomic.Int64 // unix nanoseconds of the last run
+	savedBytes   atomic.Int64
+	expiredJobs  atomic.Int64
+	expiredBytes atomic.Int64
+}
+
+// Compact compresses the output of jobs that finished CompressAfter ago and
+// drops the output of those that finished Retention ago. Running jobs are
+// left alone.
+func (st *store) Compact(policy CompactPolicy, now time.Time) CompactResult {
+	var result CompactResult
+	for _, tk := range st.tasks() {
+		ended, finished := tk.finishedAt()
+		if !finished {
+			continue
+		}
+
+		age := now.Sub(ended)
+		switch {
+		case policy.Retention > 0 && age >= policy.Retention:
+			if freed, expired := tk.expireOutput(); expired {
+				result.Expired++
+				result.ExpiredBytes += int64(freed)
+			}
+		case policy.CompressAfter > 0 && age >= policy.CompressAfter:
+			if saved := tk.pack(); saved > 0 {
+				result.Compressed++
+				result.SavedBytes += int64(saved)
+			}
+		}
+	}
+
+	st.compactions.runs.Add(1)
+	st.compactions.last.Store(now.UnixNano())
+	st.compactions.savedBytes.Add(result.SavedBytes)
+	st.compactions.expiredJobs.Add(int64(result.Expired))
+	st.compactions.expiredBytes.Add(result.ExpiredBytes)
+	return result
+}
+
+// Stats reports the jobs the store holds, the memory their output takes and
+// what compaction did
+func (st *store) Stats() StoreStats {
+	stats := StoreStats{
+		Buffer:       st.budget.stats(),
+		Compactions:  st.compactions.runs.Load(),
+		SavedBytes:   st.compactions.savedBytes.Load(),
+		ExpiredJobs:  st.compactions.expiredJobs.Load(),
+		ExpiredBytes: st.compactions.expiredBytes.Load(),
+	}
+	if last := st.compactions.last.Load(); last != 0 {
+		stats.LastCompaction = time.Unix(0, last)
+	}
+
+	for _, tk := range st.tasks() {
+		stats.Jobs++
+		if _, finished := tk.finishedAt(); finished {
+			stats.FinishedJobs++
+		}
+		stats.HistoryEntries += int64(tk.historyLen())
+		if packed, unpacked := tk.packedBytes(); packed > 0 {
+			stats.CompressedJobs++
+			stats.CompressedBytes += int64(packed)
+			stats.UncompressedBytes += int64(unpacked)
+		}
+	}
+	return stats
+}
+
+// tasks lists the tasks of all jobs
+func (st *store) tasks() []*Task {
+	tasks := make([]*Task, 0, st.count.Load())
+	for i := range st.shards {
+		sh := &st.shards[i]
+		sh.mutex.RLock()
+		for _, tk := range sh.tasks {
+			tasks = append(tasks, tk)
+		}
+		sh.mutex.RUnlock()
+	}
+	return tasks
+}
+
+// Compactor compacts a store periodically
+type Compactor struct {
+	store    Store
+	policy   CompactPolicy
+	interval time.Duration
+	logger   *logger.Logger
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// NewCompactor starts compacting the store every compactInterval of cfg. It
+// returns nil when compaction is disabled.
+func NewCompactor(cfg config.OutputBufferConfig, store Store) *Compactor {
+	policy := NewCompactPolicy(cfg)
+	if cfg.CompactInterval <= 0 || policy.CompressAfter <= 0 && policy.Retention <= 0 {
+		return nil
+	}
+
+	c := &Compactor{
+		store:    store,
+		policy:   policy,
+		interval: cfg.CompactInterval,
+		logger:   logger.WithField("component", "compactor"),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go c.run()
+
+	c.logger.Info("store compaction enabled", "interval", cfg.CompactInterval, "compressAfter", policy.CompressAfter, "retention", policy.Retention)
+	return c
+}
+
+// Close stops the compactor
+func (c *Compactor) Close() {
+	if c == nil {
+		return
+	}
+
+	close(c.stop)
+	<-c.done
+}
+
+func (c *Compactor) run() {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+		case <-c.stop:
+			return
+		}
+
+		result := c.store.Compact(c.policy, time.Now())
+		if result.Compressed > 0 || result.Expired > 0 {
+			c.logger.Info("store compacted", "compressed", result.Compressed, "savedBytes", result.SavedBytes,
+				"expired", result.Expired, "expiredBytes", result.ExpiredBytes)
+		}
+	}
+}
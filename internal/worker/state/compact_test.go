@@ -0,0 +1,94 @@
+package state
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+)
+
+func TestStore_Compact(t *testing.T) {
+	st, err := NewBounded(config.OutputBufferConfig{MaxBytes: 1 << 20, WarnPercent: 90, SpillDir: t.TempDir()}, nil)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	now := time.Now()
+	hourAgo, dayAgo := now.Add(-time.Hour), now.Add(-24*time.Hour)
+	st.CreateNewJob(&domain.Job{Id: "running", Command: "echo", Status: domain.StatusRunning})
+	st.CreateNewJob(&domain.Job{Id: "recent", Command: "echo", Status: domain.StatusCompleted, EndTime: &now})
+	st.CreateNewJob(&domain.Job{Id: "old", Command: "echo", Status: domain.StatusCompleted, EndTime: &hourAgo})
+	st.CreateNewJob(&domain.Job{Id: "ancient", Command: "echo", Status: domain.StatusFailed, EndTime: &dayAgo})
+
+	output := strings.Repeat("line of output\n", 1000)
+	for _, id := range []string{"running", "recent", "old", "ancient"} {
+		st.WriteToBuffer(id, []byte(output))
+	}
+
+	result := st.Compact(CompactPolicy{CompressAfter: 10 * time.Minute, Retention: 12 * time.Hour}, now)
+	if result.Compressed != 1 || result.Expired != 1 || result.ExpiredBytes != int64(len(output)) {
+		t.Fatalf("expected one job compressed and one expired, got %+v", result)
+	}
+
+	stats := st.Stats()
+	if stats.CompressedJobs != 1 || stats.UncompressedBytes != int64(len(output)) || stats.SavedBytes != result.SavedBytes {
+		t.Errorf("unexpected compression stats %+v", stats)
+	}
+	if want := int64(2*len(output)) + stats.CompressedBytes; stats.Buffer.UsedBytes != want {
+		t.Errorf("expected %d bytes buffered, got %d", want, stats.Buffer.UsedBytes)
+	}
+	if stats.Jobs != 4 || stats.FinishedJobs != 3 || stats.Compactions != 1 || stats.ExpiredJobs != 1 || !stats.LastCompaction.Equal(now) {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+
+	if got, _, _ := st.GetOutput("old"); string(got) != output {
+		t.Errorf("expected the compressed output to be served as it was, got %d bytes", len(got))
+	}
+	if got, _, _ := st.GetOutput("ancient"); len(got) != 0 {
+		t.Errorf("expected the expired output to be dropped, got %d bytes", len(got))
+	}
+	if job, _ := st.GetJob("ancient"); !job.OutputExpired {
+		t.Error("expected the job to be marked as expired")
+	}
+
+	// nothing left to do
+	if again := st.Compact(CompactPolicy{CompressAfter: 10 * time.Minute, Retention: 12 * time.Hour}, now); again != (CompactResult{}) {
+		t.Errorf("expected a second compaction to do nothing, got %+v", again)
+	}
+}
+
+func TestStore_WriteAfterCompaction(t *testing.T) {
+	st := newStore(&budget{})
+	ended := time.Now()
+	st.CreateNewJob(&domain.Job{Id: "job", Command: "echo", Status: domain.StatusCompleted, EndTime: &ended})
+	st.WriteToBuffer("job", []byte(strings.Repeat("a", 4096)))
+
+	if st.Compact(CompactPolicy{CompressAfter: time.Nanosecond}, ended.Add(time.Second)).Compressed != 1 {
+		t.Fatal("expected the output to be compressed")
+	}
+
+	// a restarted job writes again, its output is decompressed first
+	st.WriteToBuffer("job", []byte("b"))
+	if got, _, _ := st.GetOutput("job"); string(got) != strings.Repeat("a", 4096)+"b" {
+		t.Errorf("unexpected output after writing to compressed output: %d bytes", len(got))
+	}
+	if used := st.BufferStats().UsedBytes; used != 4097 {
+		t.Errorf("expected the budget to count the decompressed output, got %d", used)
+	}
+}
+
+func TestNewCompactor(t *testing.T) {
+	if c := NewCompactor(config.OutputBufferConfig{CompactInterval: 0, CompressAfter: time.Minute}, New()); c != nil {
+		t.Error("expected no compactor without an interval")
+	}
+	if c := NewCompactor(config.OutputBufferConfig{CompactInterval: time.Minute}, New()); c != nil {
+		t.Error("expected no compactor without anything to compact")
+	}
+
+	c := NewCompactor(config.OutputBufferConfig{CompactInterval: time.Minute, Retention: time.Hour}, New())
+	if c == nil {
+		t.Fatal("expected a compactor")
+	}
+	c.Close()
+}
@@ -0,0 +1,19 @@
+package state
+
+import "worker/internal/worker/events"
+
+// ApplyEvents returns the bus handler that keeps the store up to date with the
+// job events published by the worker core
+func ApplyEvents(st Store) events.Handler {
+	return func(e events.Event) {
+		switch e.Type {
+		case events.JobCreated:
+			st.CreateNewJob(e.Job)
+		case events.JobUpdated:
+			st.UpdateJob(e.Job)
+		case events.JobCleanedUp:
+			// jobs that failed before being stored, or were deleted since, have nothing to record
+			_ = st.RecordCleanup(e.JobID, e.Err)
+		}
+	}
+}
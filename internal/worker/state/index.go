@@ -0,0 +1,172 @@
+package state
+
+import (
+	"sync"
+	"worker/internal/worker/domain"
+)
+
+// storeShards is the number of independently locked task maps. Lookups and
+// log writes for different jobs rarely contend on the same lock.
+const storeShards = 32
+
+type shard struct {
+	mutex sync.RWMutex
+	tasks map[string]*Task
+}
+
+// shardIndex hashes the job id with FNV-1a
+func shardIndex(id string) uint32 {
+	h := uint32(2166136261)
+	for i := 0; i < len(id); i++ {
+		h ^= uint32(id[i])
+		h *= 16777619
+	}
+	return h % storeShards
+}
+
+// indexEntry is what a job is currently indexed under
+type indexEntry struct {
+	status domain.JobStatus
+	labels map[string]string
+}
+
+// jobIndex maps statuses and labels to the ids of the jobs that have them, so
+// filtered listings only look at matching jobs
+type jobIndex struct {
+	mutex    sync.RWMutex
+	entries  map[string]indexEntry
+	byStatus map[domain.JobStatus]map[string]struct{}
+	byLabel  map[string]map[string]struct{} // keyed by labelKey
+}
+
+func newJobIndex() *jobIndex {
+	return &jobIndex{
+		entries:  make(map[string]indexEntry),
+		byStatus: make(map[domain.JobStatus]map[string]struct{}),
+		byLabel:  make(map[string]map[string]struct{}),
+	}
+}
+
+func labelKey(key, value string) string {
+	return key + "\x00" + value
+}
+
+// put indexes the job, replacing what it was indexed under before
+func (x *jobIndex) put(job *domain.Job) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	old, exists := x.entries[job.Id]
+	if exists && old.status == job.Status && equalLabels(old.labels, job.Labels) {
+		return
+	}
+	if exists {
+		x.unlink(job.Id, old)
+	}
+
+	entry := indexEntry{status: job.Status, labels: make(map[string]string, len(job.Labels))}
+	for k, v := range job.Labels {
+		entry.labels[k] = v
+		link(x.byLabel, labelKey(k, v), job.Id)
+	}
+	link(x.byStatus, job.Status, job.Id)
+	x.entries[job.Id] = entry
+}
+
+func (x *jobIndex) remove(id string) {
+	x.mutex.Lock()
+	defer x.mutex.Unlock()
+
+	if entry, exists := x.entries[id]; exists {
+		x.unlink(id, entry)
+		delete(x.entries, id)
+	}
+}
+
+func (x *jobIndex) unlink(id string, entry indexEntry) {
+	unlink(x.byStatus, entry.status, id)
+	for k, v := range entry.labels {
+		unlink(x.byLabel, labelKey(k, v), id)
+	}
+}
+
+// candidates returns the ids of the jobs that can match the filter, taken from
+// its most selective indexed condition. It returns false when the filter has
+// no indexed condition and every job is a candidate.
+func (x *jobIndex) candidates(filter domain.JobFilter) ([]string, bool) {
+	x.mutex.RLock()
+	defer x.mutex.RUnlock()
+
+	var best []map[string]struct{}
+	bestSize := -1
+
+	if len(filter.Statuses) > 0 {
+		var sets []map[string]struct{}
+		size := 0
+		for _, s := range uniqueStatuses(filter.Statuses) {
+			sets = append(sets, x.byStatus[s])
+			size += len(x.byStatus[s])
+		}
+		best, bestSize = sets, size
+	}
+	for k, v := range filter.Labels {
+		set := x.byLabel[labelKey(k, v)]
+		if bestSize < 0 || len(set) < bestSize {
+			best, bestSize = []map[string]struct{}{set}, len(set)
+		}
+	}
+
+	if bestSize < 0 {
+		return nil, false
+	}
+
+	ids := make([]string, 0, bestSize)
+	for _, set := range best {
+		for id := range set {
+			ids = append(ids, id)
+		}
+	}
+	return ids, true
+}
+
+func link[K comparable](index map[K]map[string]struct{}, key K, id string) {
+	set, exists := index[key]
+	if !exists {
+		set = make(map[string]struct{})
+		index[key] = set
+	}
+	set[id] = struct{}{}
+}
+
+func unlink[K comparable](index map[K]map[string]struct{}, key K, id string) {
+	if set, exists := index[key]; exists {
+		delete(set, id)
+		if len(set) == 0 {
+			delete(index, key)
+		}
+	}
+}
+
+func equalLabels(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if bv, exists := b[k]; !exists || bv != v {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueStatuses(statuses []domain.JobStatus) []domain.JobStatus {
+	seen := make(map[domain.JobStatus]bool, len(statuses))
+	var unique []domain.JobStatus
+	for _, s := range statuses {
+		if !seen[s] {
+			seen[s] = true
+			unique = append(unique, s)
+		}
+	}
+	return unique
+}
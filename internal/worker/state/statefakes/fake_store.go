@@ -4,16 +4,74 @@ package statefakes
 import (
 	"context"
 	"sync"
+	"time"
 	"worker/internal/worker/domain"
 	"worker/internal/worker/state"
 )
 
 type FakeStore struct {
+	BufferStatsStub        func() state.BufferStats
+	bufferStatsMutex       sync.RWMutex
+	bufferStatsArgsForCall []struct {
+	}
+	bufferStatsReturns struct {
+		result1 state.BufferStats
+	}
+	bufferStatsReturnsOnCall map[int]struct {
+		result1 state.BufferStats
+	}
+	CompactStub        func(state.CompactPolicy, time.Time) state.CompactResult
+	compactMutex       sync.RWMutex
+	compactArgsForCall []struct {
+		arg1 state.CompactPolicy
+		arg2 time.Time
+	}
+	compactReturns struct {
+		result1 state.CompactResult
+	}
+	compactReturnsOnCall map[int]struct {
+		result1 state.CompactResult
+	}
 	CreateNewJobStub        func(*domain.Job)
 	createNewJobMutex       sync.RWMutex
 	createNewJobArgsForCall []struct {
 		arg1 *domain.Job
 	}
+	DeleteJobStub        func(string) error
+	deleteJobMutex       sync.RWMutex
+	deleteJobArgsForCall []struct {
+		arg1 string
+	}
+	deleteJobReturns struct {
+		result1 error
+	}
+	deleteJobReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EvictOutputStub        func(string, string) error
+	evictOutputMutex       sync.RWMutex
+	evictOutputArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	evictOutputReturns struct {
+		result1 error
+	}
+	evictOutputReturnsOnCall map[int]struct {
+		result1 error
+	}
+	FindJobsStub        func(domain.JobFilter, time.Time) []*domain.Job
+	findJobsMutex       sync.RWMutex
+	findJobsArgsForCall []struct {
+		arg1 domain.JobFilter
+		arg2 time.Time
+	}
+	findJobsReturns struct {
+		result1 []*domain.Job
+	}
+	findJobsReturnsOnCall map[int]struct {
+		result1 []*domain.Job
+	}
 	GetJobStub        func(string) (*domain.Job, bool)
 	getJobMutex       sync.RWMutex
 	getJobArgsForCall []struct {
@@ -42,6 +100,19 @@ type FakeStore struct {
 		result2 bool
 		result3 error
 	}
+	HistoryStub        func(string) ([]domain.HistoryEntry, error)
+	historyMutex       sync.RWMutex
+	historyArgsForCall []struct {
+		arg1 string
+	}
+	historyReturns struct {
+		result1 []domain.HistoryEntry
+		result2 error
+	}
+	historyReturnsOnCall map[int]struct {
+		result1 []domain.HistoryEntry
+		result2 error
+	}
 	ListJobsStub        func() []*domain.Job
 	listJobsMutex       sync.RWMutex
 	listJobsArgsForCall []struct {
@@ -52,6 +123,23 @@ type FakeStore struct {
 	listJobsReturnsOnCall map[int]struct {
 		result1 []*domain.Job
 	}
+	OnStatusChangeStub        func(domain.StatusHook)
+	onStatusChangeMutex       sync.RWMutex
+	onStatusChangeArgsForCall []struct {
+		arg1 domain.StatusHook
+	}
+	RecordCleanupStub        func(string, error) error
+	recordCleanupMutex       sync.RWMutex
+	recordCleanupArgsForCall []struct {
+		arg1 string
+		arg2 error
+	}
+	recordCleanupReturns struct {
+		result1 error
+	}
+	recordCleanupReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SendUpdatesToClientStub        func(context.Context, string, state.DomainStreamer) error
 	sendUpdatesToClientMutex       sync.RWMutex
 	sendUpdatesToClientArgsForCall []struct {
@@ -65,11 +153,35 @@ type FakeStore struct {
 	sendUpdatesToClientReturnsOnCall map[int]struct {
 		result1 error
 	}
+	StatsStub        func() state.StoreStats
+	statsMutex       sync.RWMutex
+	statsArgsForCall []struct {
+	}
+	statsReturns struct {
+		result1 state.StoreStats
+	}
+	statsReturnsOnCall map[int]struct {
+		result1 state.StoreStats
+	}
 	UpdateJobStub        func(*domain.Job)
 	updateJobMutex       sync.RWMutex
 	updateJobArgsForCall []struct {
 		arg1 *domain.Job
 	}
+	WaitForCompletionStub        func(context.Context, string) (*domain.Job, error)
+	waitForCompletionMutex       sync.RWMutex
+	waitForCompletionArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	waitForCompletionReturns struct {
+		result1 *domain.Job
+		result2 error
+	}
+	waitForCompletionReturnsOnCall map[int]struct {
+		result1 *domain.Job
+		result2 error
+	}
 	WriteToBufferStub        func(string, []byte)
 	writeToBufferMutex       sync.RWMutex
 	writeToBufferArgsForCall []struct {
@@ -80,6 +192,121 @@ type FakeStore struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeStore) BufferStats() state.BufferStats {
+	fake.bufferStatsMutex.Lock()
+	ret, specificReturn := fake.bufferStatsReturnsOnCall[len(fake.bufferStatsArgsForCall)]
+	fake.bufferStatsArgsForCall = append(fake.bufferStatsArgsForCall, struct {
+	}{})
+	stub := fake.BufferStatsStub
+	fakeReturns := fake.bufferStatsReturns
+	fake.recordInvocation("BufferStats", []interface{}{})
+	fake.bufferStatsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) BufferStatsCallCount() int {
+	fake.bufferStatsMutex.RLock()
+	defer fake.bufferStatsMutex.RUnlock()
+	return len(fake.bufferStatsArgsForCall)
+}
+
+func (fake *FakeStore) BufferStatsCalls(stub func() state.BufferStats) {
+	fake.bufferStatsMutex.Lock()
+	defer fake.bufferStatsMutex.Unlock()
+	fake.BufferStatsStub = stub
+}
+
+func (fake *FakeStore) BufferStatsReturns(result1 state.BufferStats) {
+	fake.bufferStatsMutex.Lock()
+	defer fake.bufferStatsMutex.Unlock()
+	fake.BufferStatsStub = nil
+	fake.bufferStatsReturns = struct {
+		result1 state.BufferStats
+	}{result1}
+}
+
+func (fake *FakeStore) BufferStatsReturnsOnCall(i int, result1 state.BufferStats) {
+	fake.bufferStatsMutex.Lock()
+	defer fake.bufferStatsMutex.Unlock()
+	fake.BufferStatsStub = nil
+	if fake.bufferStatsReturnsOnCall == nil {
+		fake.bufferStatsReturnsOnCall = make(map[int]struct {
+			result1 state.BufferStats
+		})
+	}
+	fake.bufferStatsReturnsOnCall[i] = struct {
+		result1 state.BufferStats
+	}{result1}
+}
+
+func (fake *FakeStore) Compact(arg1 state.CompactPolicy, arg2 time.Time) state.CompactResult {
+	fake.compactMutex.Lock()
+	ret, specificReturn := fake.compactReturnsOnCall[len(fake.compactArgsForCall)]
+	fake.compactArgsForCall = append(fake.compactArgsForCall, struct {
+		arg1 state.CompactPolicy
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.CompactStub
+	fakeReturns := fake.compactReturns
+	fake.recordInvocation("Compact", []interface{}{arg1, arg2})
+	fake.compactMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) CompactCallCount() int {
+	fake.compactMutex.RLock()
+	defer fake.compactMutex.RUnlock()
+	return len(fake.compactArgsForCall)
+}
+
+func (fake *FakeStore) CompactCalls(stub func(state.CompactPolicy, time.Time) state.CompactResult) {
+	fake.compactMutex.Lock()
+	defer fake.compactMutex.Unlock()
+	fake.CompactStub = stub
+}
+
+func (fake *FakeStore) CompactArgsForCall(i int) (state.CompactPolicy, time.Time) {
+	fake.compactMutex.RLock()
+	defer fake.compactMutex.RUnlock()
+	argsForCall := fake.compactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) CompactReturns(result1 state.CompactResult) {
+	fake.compactMutex.Lock()
+	defer fake.compactMutex.Unlock()
+	fake.CompactStub = nil
+	fake.compactReturns = struct {
+		result1 state.CompactResult
+	}{result1}
+}
+
+func (fake *FakeStore) CompactReturnsOnCall(i int, result1 state.CompactResult) {
+	fake.compactMutex.Lock()
+	defer fake.compactMutex.Unlock()
+	fake.CompactStub = nil
+	if fake.compactReturnsOnCall == nil {
+		fake.compactReturnsOnCall = make(map[int]struct {
+			result1 state.CompactResult
+		})
+	}
+	fake.compactReturnsOnCall[i] = struct {
+		result1 state.CompactResult
+	}{result1}
+}
+
 func (fake *FakeStore) CreateNewJob(arg1 *domain.Job) {
 	fake.createNewJobMutex.Lock()
 	fake.createNewJobArgsForCall = append(fake.createNewJobArgsForCall, struct {
@@ -112,6 +339,191 @@ func (fake *FakeStore) CreateNewJobArgsForCall(i int) *domain.Job {
 	return argsForCall.arg1
 }
 
+func (fake *FakeStore) DeleteJob(arg1 string) error {
+	fake.deleteJobMutex.Lock()
+	ret, specificReturn := fake.deleteJobReturnsOnCall[len(fake.deleteJobArgsForCall)]
+	fake.deleteJobArgsForCall = append(fake.deleteJobArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.DeleteJobStub
+	fakeReturns := fake.deleteJobReturns
+	fake.recordInvocation("DeleteJob", []interface{}{arg1})
+	fake.deleteJobMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) DeleteJobCallCount() int {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	return len(fake.deleteJobArgsForCall)
+}
+
+func (fake *FakeStore) DeleteJobCalls(stub func(string) error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = stub
+}
+
+func (fake *FakeStore) DeleteJobArgsForCall(i int) string {
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	argsForCall := fake.deleteJobArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) DeleteJobReturns(result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	fake.deleteJobReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) DeleteJobReturnsOnCall(i int, result1 error) {
+	fake.deleteJobMutex.Lock()
+	defer fake.deleteJobMutex.Unlock()
+	fake.DeleteJobStub = nil
+	if fake.deleteJobReturnsOnCall == nil {
+		fake.deleteJobReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteJobReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) EvictOutput(arg1 string, arg2 string) error {
+	fake.evictOutputMutex.Lock()
+	ret, specificReturn := fake.evictOutputReturnsOnCall[len(fake.evictOutputArgsForCall)]
+	fake.evictOutputArgsForCall = append(fake.evictOutputArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.EvictOutputStub
+	fakeReturns := fake.evictOutputReturns
+	fake.recordInvocation("EvictOutput", []interface{}{arg1, arg2})
+	fake.evictOutputMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) EvictOutputCallCount() int {
+	fake.evictOutputMutex.RLock()
+	defer fake.evictOutputMutex.RUnlock()
+	return len(fake.evictOutputArgsForCall)
+}
+
+func (fake *FakeStore) EvictOutputCalls(stub func(string, string) error) {
+	fake.evictOutputMutex.Lock()
+	defer fake.evictOutputMutex.Unlock()
+	fake.EvictOutputStub = stub
+}
+
+func (fake *FakeStore) EvictOutputArgsForCall(i int) (string, string) {
+	fake.evictOutputMutex.RLock()
+	defer fake.evictOutputMutex.RUnlock()
+	argsForCall := fake.evictOutputArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) EvictOutputReturns(result1 error) {
+	fake.evictOutputMutex.Lock()
+	defer fake.evictOutputMutex.Unlock()
+	fake.EvictOutputStub = nil
+	fake.evictOutputReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) EvictOutputReturnsOnCall(i int, result1 error) {
+	fake.evictOutputMutex.Lock()
+	defer fake.evictOutputMutex.Unlock()
+	fake.EvictOutputStub = nil
+	if fake.evictOutputReturnsOnCall == nil {
+		fake.evictOutputReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.evictOutputReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) FindJobs(arg1 domain.JobFilter, arg2 time.Time) []*domain.Job {
+	fake.findJobsMutex.Lock()
+	ret, specificReturn := fake.findJobsReturnsOnCall[len(fake.findJobsArgsForCall)]
+	fake.findJobsArgsForCall = append(fake.findJobsArgsForCall, struct {
+		arg1 domain.JobFilter
+		arg2 time.Time
+	}{arg1, arg2})
+	stub := fake.FindJobsStub
+	fakeReturns := fake.findJobsReturns
+	fake.recordInvocation("FindJobs", []interface{}{arg1, arg2})
+	fake.findJobsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) FindJobsCallCount() int {
+	fake.findJobsMutex.RLock()
+	defer fake.findJobsMutex.RUnlock()
+	return len(fake.findJobsArgsForCall)
+}
+
+func (fake *FakeStore) FindJobsCalls(stub func(domain.JobFilter, time.Time) []*domain.Job) {
+	fake.findJobsMutex.Lock()
+	defer fake.findJobsMutex.Unlock()
+	fake.FindJobsStub = stub
+}
+
+func (fake *FakeStore) FindJobsArgsForCall(i int) (domain.JobFilter, time.Time) {
+	fake.findJobsMutex.RLock()
+	defer fake.findJobsMutex.RUnlock()
+	argsForCall := fake.findJobsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) FindJobsReturns(result1 []*domain.Job) {
+	fake.findJobsMutex.Lock()
+	defer fake.findJobsMutex.Unlock()
+	fake.FindJobsStub = nil
+	fake.findJobsReturns = struct {
+		result1 []*domain.Job
+	}{result1}
+}
+
+func (fake *FakeStore) FindJobsReturnsOnCall(i int, result1 []*domain.Job) {
+	fake.findJobsMutex.Lock()
+	defer fake.findJobsMutex.Unlock()
+	fake.FindJobsStub = nil
+	if fake.findJobsReturnsOnCall == nil {
+		fake.findJobsReturnsOnCall = make(map[int]struct {
+			result1 []*domain.Job
+		})
+	}
+	fake.findJobsReturnsOnCall[i] = struct {
+		result1 []*domain.Job
+	}{result1}
+}
+
 func (fake *FakeStore) GetJob(arg1 string) (*domain.Job, bool) {
 	fake.getJobMutex.Lock()
 	ret, specificReturn := fake.getJobReturnsOnCall[len(fake.getJobArgsForCall)]
@@ -243,6 +655,70 @@ func (fake *FakeStore) GetOutputReturnsOnCall(i int, result1 []byte, result2 boo
 	}{result1, result2, result3}
 }
 
+func (fake *FakeStore) History(arg1 string) ([]domain.HistoryEntry, error) {
+	fake.historyMutex.Lock()
+	ret, specificReturn := fake.historyReturnsOnCall[len(fake.historyArgsForCall)]
+	fake.historyArgsForCall = append(fake.historyArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	stub := fake.HistoryStub
+	fakeReturns := fake.historyReturns
+	fake.recordInvocation("History", []interface{}{arg1})
+	fake.historyMutex.Unlock()
+	if stub != nil {
+		return stub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) HistoryCallCount() int {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	return len(fake.historyArgsForCall)
+}
+
+func (fake *FakeStore) HistoryCalls(stub func(string) ([]domain.HistoryEntry, error)) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = stub
+}
+
+func (fake *FakeStore) HistoryArgsForCall(i int) string {
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
+	argsForCall := fake.historyArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) HistoryReturns(result1 []domain.HistoryEntry, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	fake.historyReturns = struct {
+		result1 []domain.HistoryEntry
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) HistoryReturnsOnCall(i int, result1 []domain.HistoryEntry, result2 error) {
+	fake.historyMutex.Lock()
+	defer fake.historyMutex.Unlock()
+	fake.HistoryStub = nil
+	if fake.historyReturnsOnCall == nil {
+		fake.historyReturnsOnCall = make(map[int]struct {
+			result1 []domain.HistoryEntry
+			result2 error
+		})
+	}
+	fake.historyReturnsOnCall[i] = struct {
+		result1 []domain.HistoryEntry
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) ListJobs() []*domain.Job {
 	fake.listJobsMutex.Lock()
 	ret, specificReturn := fake.listJobsReturnsOnCall[len(fake.listJobsArgsForCall)]
@@ -296,6 +772,100 @@ func (fake *FakeStore) ListJobsReturnsOnCall(i int, result1 []*domain.Job) {
 	}{result1}
 }
 
+func (fake *FakeStore) OnStatusChange(arg1 domain.StatusHook) {
+	fake.onStatusChangeMutex.Lock()
+	fake.onStatusChangeArgsForCall = append(fake.onStatusChangeArgsForCall, struct {
+		arg1 domain.StatusHook
+	}{arg1})
+	stub := fake.OnStatusChangeStub
+	fake.recordInvocation("OnStatusChange", []interface{}{arg1})
+	fake.onStatusChangeMutex.Unlock()
+	if stub != nil {
+		fake.OnStatusChangeStub(arg1)
+	}
+}
+
+func (fake *FakeStore) OnStatusChangeCallCount() int {
+	fake.onStatusChangeMutex.RLock()
+	defer fake.onStatusChangeMutex.RUnlock()
+	return len(fake.onStatusChangeArgsForCall)
+}
+
+func (fake *FakeStore) OnStatusChangeCalls(stub func(domain.StatusHook)) {
+	fake.onStatusChangeMutex.Lock()
+	defer fake.onStatusChangeMutex.Unlock()
+	fake.OnStatusChangeStub = stub
+}
+
+func (fake *FakeStore) OnStatusChangeArgsForCall(i int) domain.StatusHook {
+	fake.onStatusChangeMutex.RLock()
+	defer fake.onStatusChangeMutex.RUnlock()
+	argsForCall := fake.onStatusChangeArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStore) RecordCleanup(arg1 string, arg2 error) error {
+	fake.recordCleanupMutex.Lock()
+	ret, specificReturn := fake.recordCleanupReturnsOnCall[len(fake.recordCleanupArgsForCall)]
+	fake.recordCleanupArgsForCall = append(fake.recordCleanupArgsForCall, struct {
+		arg1 string
+		arg2 error
+	}{arg1, arg2})
+	stub := fake.RecordCleanupStub
+	fakeReturns := fake.recordCleanupReturns
+	fake.recordInvocation("RecordCleanup", []interface{}{arg1, arg2})
+	fake.recordCleanupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) RecordCleanupCallCount() int {
+	fake.recordCleanupMutex.RLock()
+	defer fake.recordCleanupMutex.RUnlock()
+	return len(fake.recordCleanupArgsForCall)
+}
+
+func (fake *FakeStore) RecordCleanupCalls(stub func(string, error) error) {
+	fake.recordCleanupMutex.Lock()
+	defer fake.recordCleanupMutex.Unlock()
+	fake.RecordCleanupStub = stub
+}
+
+func (fake *FakeStore) RecordCleanupArgsForCall(i int) (string, error) {
+	fake.recordCleanupMutex.RLock()
+	defer fake.recordCleanupMutex.RUnlock()
+	argsForCall := fake.recordCleanupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) RecordCleanupReturns(result1 error) {
+	fake.recordCleanupMutex.Lock()
+	defer fake.recordCleanupMutex.Unlock()
+	fake.RecordCleanupStub = nil
+	fake.recordCleanupReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStore) RecordCleanupReturnsOnCall(i int, result1 error) {
+	fake.recordCleanupMutex.Lock()
+	defer fake.recordCleanupMutex.Unlock()
+	fake.RecordCleanupStub = nil
+	if fake.recordCleanupReturnsOnCall == nil {
+		fake.recordCleanupReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.recordCleanupReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeStore) SendUpdatesToClient(arg1 context.Context, arg2 string, arg3 state.DomainStreamer) error {
 	fake.sendUpdatesToClientMutex.Lock()
 	ret, specificReturn := fake.sendUpdatesToClientReturnsOnCall[len(fake.sendUpdatesToClientArgsForCall)]
@@ -359,6 +929,59 @@ func (fake *FakeStore) SendUpdatesToClientReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeStore) Stats() state.StoreStats {
+	fake.statsMutex.Lock()
+	ret, specificReturn := fake.statsReturnsOnCall[len(fake.statsArgsForCall)]
+	fake.statsArgsForCall = append(fake.statsArgsForCall, struct {
+	}{})
+	stub := fake.StatsStub
+	fakeReturns := fake.statsReturns
+	fake.recordInvocation("Stats", []interface{}{})
+	fake.statsMutex.Unlock()
+	if stub != nil {
+		return stub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FakeStore) StatsCallCount() int {
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
+	return len(fake.statsArgsForCall)
+}
+
+func (fake *FakeStore) StatsCalls(stub func() state.StoreStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = stub
+}
+
+func (fake *FakeStore) StatsReturns(result1 state.StoreStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	fake.statsReturns = struct {
+		result1 state.StoreStats
+	}{result1}
+}
+
+func (fake *FakeStore) StatsReturnsOnCall(i int, result1 state.StoreStats) {
+	fake.statsMutex.Lock()
+	defer fake.statsMutex.Unlock()
+	fake.StatsStub = nil
+	if fake.statsReturnsOnCall == nil {
+		fake.statsReturnsOnCall = make(map[int]struct {
+			result1 state.StoreStats
+		})
+	}
+	fake.statsReturnsOnCall[i] = struct {
+		result1 state.StoreStats
+	}{result1}
+}
+
 func (fake *FakeStore) UpdateJob(arg1 *domain.Job) {
 	fake.updateJobMutex.Lock()
 	fake.updateJobArgsForCall = append(fake.updateJobArgsForCall, struct {
@@ -391,6 +1014,71 @@ func (fake *FakeStore) UpdateJobArgsForCall(i int) *domain.Job {
 	return argsForCall.arg1
 }
 
+func (fake *FakeStore) WaitForCompletion(arg1 context.Context, arg2 string) (*domain.Job, error) {
+	fake.waitForCompletionMutex.Lock()
+	ret, specificReturn := fake.waitForCompletionReturnsOnCall[len(fake.waitForCompletionArgsForCall)]
+	fake.waitForCompletionArgsForCall = append(fake.waitForCompletionArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.WaitForCompletionStub
+	fakeReturns := fake.waitForCompletionReturns
+	fake.recordInvocation("WaitForCompletion", []interface{}{arg1, arg2})
+	fake.waitForCompletionMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStore) WaitForCompletionCallCount() int {
+	fake.waitForCompletionMutex.RLock()
+	defer fake.waitForCompletionMutex.RUnlock()
+	return len(fake.waitForCompletionArgsForCall)
+}
+
+func (fake *FakeStore) WaitForCompletionCalls(stub func(context.Context, string) (*domain.Job, error)) {
+	fake.waitForCompletionMutex.Lock()
+	defer fake.waitForCompletionMutex.Unlock()
+	fake.WaitForCompletionStub = stub
+}
+
+func (fake *FakeStore) WaitForCompletionArgsForCall(i int) (context.Context, string) {
+	fake.waitForCompletionMutex.RLock()
+	defer fake.waitForCompletionMutex.RUnlock()
+	argsForCall := fake.waitForCompletionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeStore) WaitForCompletionReturns(result1 *domain.Job, result2 error) {
+	fake.waitForCompletionMutex.Lock()
+	defer fake.waitForCompletionMutex.Unlock()
+	fake.WaitForCompletionStub = nil
+	fake.waitForCompletionReturns = struct {
+		result1 *domain.Job
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStore) WaitForCompletionReturnsOnCall(i int, result1 *domain.Job, result2 error) {
+	fake.waitForCompletionMutex.Lock()
+	defer fake.waitForCompletionMutex.Unlock()
+	fake.WaitForCompletionStub = nil
+	if fake.waitForCompletionReturnsOnCall == nil {
+		fake.waitForCompletionReturnsOnCall = make(map[int]struct {
+			result1 *domain.Job
+			result2 error
+		})
+	}
+	fake.waitForCompletionReturnsOnCall[i] = struct {
+		result1 *domain.Job
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeStore) WriteToBuffer(arg1 string, arg2 []byte) {
 	var arg2Copy []byte
 	if arg2 != nil {
@@ -432,18 +1120,38 @@ func (fake *FakeStore) WriteToBufferArgsForCall(i int) (string, []byte) {
 func (fake *FakeStore) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.bufferStatsMutex.RLock()
+	defer fake.bufferStatsMutex.RUnlock()
+	fake.compactMutex.RLock()
+	defer fake.compactMutex.RUnlock()
 	fake.createNewJobMutex.RLock()
 	defer fake.createNewJobMutex.RUnlock()
+	fake.deleteJobMutex.RLock()
+	defer fake.deleteJobMutex.RUnlock()
+	fake.evictOutputMutex.RLock()
+	defer fake.evictOutputMutex.RUnlock()
+	fake.findJobsMutex.RLock()
+	defer fake.findJobsMutex.RUnlock()
 	fake.getJobMutex.RLock()
 	defer fake.getJobMutex.RUnlock()
 	fake.getOutputMutex.RLock()
 	defer fake.getOutputMutex.RUnlock()
+	fake.historyMutex.RLock()
+	defer fake.historyMutex.RUnlock()
 	fake.listJobsMutex.RLock()
 	defer fake.listJobsMutex.RUnlock()
+	fake.onStatusChangeMutex.RLock()
+	defer fake.onStatusChangeMutex.RUnlock()
+	fake.recordCleanupMutex.RLock()
+	defer fake.recordCleanupMutex.RUnlock()
 	fake.sendUpdatesToClientMutex.RLock()
 	defer fake.sendUpdatesToClientMutex.RUnlock()
+	fake.statsMutex.RLock()
+	defer fake.statsMutex.RUnlock()
 	fake.updateJobMutex.RLock()
 	defer fake.updateJobMutex.RUnlock()
+	fake.waitForCompletionMutex.RLock()
+	defer fake.waitForCompletionMutex.RUnlock()
 	fake.writeToBufferMutex.RLock()
 	defer fake.writeToBufferMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -3,9 +3,13 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/pkg/config"
 	"worker/pkg/logger"
 )
 
@@ -15,11 +19,21 @@ import (
 type Store interface {
 	CreateNewJob(job *domain.Job)
 	UpdateJob(job *domain.Job)
+	DeleteJob(id string) error
 	GetJob(id string) (*domain.Job, bool)
 	ListJobs() []*domain.Job
+	FindJobs(filter domain.JobFilter, now time.Time) []*domain.Job
 	WriteToBuffer(jobId string, chunk []byte)
 	GetOutput(id string) ([]byte, bool, error)
+	History(id string) ([]domain.HistoryEntry, error)
+	EvictOutput(id string, location string) error
+	RecordCleanup(id string, cleanupErr error) error
+	WaitForCompletion(ctx context.Context, id string) (*domain.Job, error)
 	SendUpdatesToClient(ctx context.Context, id string, stream DomainStreamer) error
+	BufferStats() BufferStats
+	Compact(policy CompactPolicy, now time.Time) CompactResult
+	Stats() StoreStats
+	OnStatusChange(hook domain.StatusHook)
 }
 
 //counterfeiter:generate . DomainStreamer
@@ -29,40 +43,84 @@ type DomainStreamer interface {
 	Context() context.Context
 }
 
+// store keeps jobs in sharded maps, with a status and label index for
+// filtered listings
 type store struct {
-	tasks  map[string]*Task
-	mutex  sync.RWMutex
+	shards [storeShards]shard
+	index  *jobIndex
+	count  atomic.Int64
+	budget *budget
 	logger *logger.Logger
+
+	compactions compactions
+
+	hooks      []domain.StatusHook
+	hooksMutex sync.RWMutex
+
+	chunkLogger *logger.Logger // sampled, for entries logged per output chunk
 }
 
+// New creates a store buffering jobs' output without a limit
 func New() Store {
+	return newStore(&budget{})
+}
+
+// NewBounded creates a store keeping jobs' buffered output within the budget
+// of cfg, publishing a pressure event on bus when it gets close
+func NewBounded(cfg config.OutputBufferConfig, bus *events.Bus) (Store, error) {
+	b, err := newBudget(cfg, bus)
+	if err != nil {
+		return nil, err
+	}
+	return newStore(b), nil
+}
+
+func newStore(b *budget) *store {
 	s := &store{
-		tasks:  make(map[string]*Task),
+		index:  newJobIndex(),
+		budget: b,
 		logger: logger.WithField("component", "store"),
 	}
+	s.chunkLogger = s.logger.Sampled(chunkLogsPerSecond)
+	for i := range s.shards {
+		s.shards[i].tasks = make(map[string]*Task)
+	}
 
-	s.logger.Debug("store initialized")
+	s.logger.Debug("store initialized", "shards", storeShards, "bufferBudget", b.maxBytes)
 	return s
 }
 
-func (st *store) WriteToBuffer(jobId string, chunk []byte) {
-	st.mutex.RLock()
-	defer st.mutex.RUnlock()
+func (st *store) shard(id string) *shard {
+	return &st.shards[shardIndex(id)]
+}
 
-	tk, exists := st.tasks[jobId]
+// task looks up the task of a job
+func (st *store) task(id string) (*Task, bool) {
+	sh := st.shard(id)
+	sh.mutex.RLock()
+	tk, exists := sh.tasks[id]
+	sh.mutex.RUnlock()
+	return tk, exists
+}
+
+func (st *store) WriteToBuffer(jobId string, chunk []byte) {
+	tk, exists := st.task(jobId)
 	if !exists {
-		st.logger.Warn("attempted to write to buffer for non-existent job", "jobId", jobId, "chunkSize", len(chunk))
+		st.chunkLogger.Warn("attempted to write to buffer for non-existent job", "jobId", jobId, "chunkSize", len(chunk))
 		return
 	}
 
 	tk.WriteToBuffer(chunk)
+	st.enforceBudget()
 }
 
-func (st *store) GetJob(id string) (*domain.Job, bool) {
-	st.mutex.RLock()
-	defer st.mutex.RUnlock()
+// BufferStats reports the memory taken by jobs' buffered output
+func (st *store) BufferStats() BufferStats {
+	return st.budget.stats()
+}
 
-	j, exists := st.tasks[id]
+func (st *store) GetJob(id string) (*domain.Job, bool) {
+	j, exists := st.task(id)
 	if !exists {
 		st.logger.Debug("job not found in store", "jobId", id)
 		return nil, false
@@ -74,33 +132,77 @@ func (st *store) GetJob(id string) (*domain.Job, bool) {
 	return job, true
 }
 
-// CreateNewJob to add new job with all fields in the job struct, used only at the time of create
-func (st *store) CreateNewJob(job *domain.Job) {
-	st.mutex.Lock()
-	defer st.mutex.Unlock()
+// OnStatusChange registers a hook called for every status transition of
+// every job, including the one from "" when a job is stored. Hooks run
+// synchronously once the store applied the change, in the order they were
+// registered, and see the transitions of each job in the order they were
+// applied. A hook must not update the job it is called for.
+func (st *store) OnStatusChange(hook domain.StatusHook) {
+	st.hooksMutex.Lock()
+	defer st.hooksMutex.Unlock()
 
-	if _, exist := st.tasks[job.Id]; exist {
-		st.logger.Warn("job already exists, not creating new task", "jobId", job.Id)
+	st.hooks = append(st.hooks, hook)
+}
+
+// notify runs the status hooks for a change of the job from the status from,
+// the caller holds the task's transitionMu
+func (st *store) notify(from domain.JobStatus, job *domain.Job) {
+	if from == job.Status {
+		return
+	}
+
+	st.hooksMutex.RLock()
+	hooks := st.hooks
+	st.hooksMutex.RUnlock()
+	if len(hooks) == 0 {
 		return
 	}
 
-	st.tasks[job.Id] = NewTask(job)
+	transition := domain.NewStatusTransition(from, job.DeepCopy(), time.Now())
+	for _, hook := range hooks {
+		hook(transition)
+	}
+}
 
-	st.logger.Debug("new task created", "jobId", job.Id, "command", job.Command, "totalTasks", len(st.tasks))
+// CreateNewJob to add new job with all fields in the job struct, used only at the time of create
+func (st *store) CreateNewJob(job *domain.Job) {
+	sh := st.shard(job.Id)
+	sh.mutex.Lock()
+	if _, exist := sh.tasks[job.Id]; exist {
+		sh.mutex.Unlock()
+		st.logger.Warn("job already exists, not creating new task", "jobId", job.Id)
+		return
+	}
+	tk := NewTask(job)
+	tk.budget = st.budget
+	tk.transitionMu.Lock()
+	defer tk.transitionMu.Unlock()
+	sh.tasks[job.Id] = tk
+	st.index.put(job)
+	sh.mutex.Unlock()
+
+	total := st.count.Add(1)
+	st.logger.Debug("new task created", "jobId", job.Id, "command", job.Command, "totalTasks", total)
+
+	st.notify("", job)
 }
 
 func (st *store) UpdateJob(job *domain.Job) {
-	st.mutex.RLock()
-	tk, exists := st.tasks[job.Id]
-	st.mutex.RUnlock()
+	tk, exists := st.task(job.Id)
+	if exists {
+		var err error
+		exists, err = st.apply(tk, job)
+		if err != nil {
+			st.logger.Warn("rejected job update", "jobId", job.Id, "error", err)
+			return
+		}
+	}
 
 	if !exists {
 		st.logger.Warn("attempted to update non-existent job", "jobId", job.Id, "status", string(job.Status))
 		return
 	}
 
-	tk.UpdateJob(job)
-
 	tk.Publish(Update{
 		JobID:  job.Id,
 		Status: string(job.Status),
@@ -113,26 +215,100 @@ func (st *store) UpdateJob(job *domain.Job) {
 	}
 }
 
+// apply updates the task's job and runs the status hooks. It returns false
+// when the job was deleted meanwhile, and a *domain.InvalidTransitionError,
+// without changing the job, when the state machine does not allow the update.
+func (st *store) apply(tk *Task, job *domain.Job) (bool, error) {
+	tk.transitionMu.Lock()
+	defer tk.transitionMu.Unlock()
+
+	// reindex under the shard lock, so a concurrent delete cannot leave the job indexed
+	sh := st.shard(job.Id)
+	sh.mutex.RLock()
+	if sh.tasks[job.Id] != tk {
+		sh.mutex.RUnlock()
+		return false, nil
+	}
+	from, err := tk.UpdateJob(job)
+	if err != nil {
+		sh.mutex.RUnlock()
+		return true, err
+	}
+	st.index.put(job)
+	sh.mutex.RUnlock()
+
+	st.notify(from, job)
+	return true, nil
+}
+
+// DeleteJob removes a finished job together with its buffered output
+func (st *store) DeleteJob(id string) error {
+	sh := st.shard(id)
+	sh.mutex.Lock()
+	defer sh.mutex.Unlock()
+
+	tk, exists := sh.tasks[id]
+	if !exists {
+		return errors.New("job not found")
+	}
+
+	if job := tk.GetJob(); !job.IsCompleted() {
+		return fmt.Errorf("job %s is %s, only finished jobs can be deleted", id, job.Status)
+	}
+
+	tk.discardOutput()
+	delete(sh.tasks, id)
+	st.index.remove(id)
+	total := st.count.Add(-1)
+	st.logger.Debug("job deleted from store", "jobId", id, "totalTasks", total)
+
+	return nil
+}
+
 func (st *store) ListJobs() []*domain.Job {
-	st.mutex.RLock()
-	defer st.mutex.RUnlock()
+	jobs := make([]*domain.Job, 0, st.count.Load())
 
-	jobs := make([]*domain.Job, 0, len(st.tasks))
-	statusCounts := make(map[string]int)
+	for i := range st.shards {
+		sh := &st.shards[i]
+		sh.mutex.RLock()
+		for _, tk := range sh.tasks {
+			jobs = append(jobs, tk.GetJob())
+		}
+		sh.mutex.RUnlock()
+	}
 
-	for _, tk := range st.tasks {
-		job := tk.GetJob()
-		jobs = append(jobs, job)
-		statusCounts[string(job.Status)]++
+	return jobs
+}
+
+// FindJobs returns the jobs matching the filter, in no particular order. Only
+// the jobs under the filter's most selective status or label are examined.
+func (st *store) FindJobs(filter domain.JobFilter, now time.Time) []*domain.Job {
+	ids, indexed := st.index.candidates(filter)
+	if !indexed {
+		var jobs []*domain.Job
+		for _, job := range st.ListJobs() {
+			if filter.Matches(job, now) {
+				jobs = append(jobs, job)
+			}
+		}
+		return jobs
 	}
 
+	var jobs []*domain.Job
+	for _, id := range ids {
+		tk, exists := st.task(id)
+		if !exists {
+			continue
+		}
+		if job := tk.GetJob(); filter.Matches(job, now) {
+			jobs = append(jobs, job)
+		}
+	}
 	return jobs
 }
 
 func (st *store) GetOutput(id string) ([]byte, bool, error) {
-	st.mutex.RLock()
-	tk, exists := st.tasks[id]
-	st.mutex.RUnlock()
+	tk, exists := st.task(id)
 
 	if !exists {
 		st.logger.Debug("output requested for non-existent job", "jobId", id)
@@ -147,11 +323,96 @@ func (st *store) GetOutput(id string) ([]byte, bool, error) {
 	return buffer, isRunning, nil
 }
 
+// History returns the changes recorded to a job, oldest first
+func (st *store) History(id string) ([]domain.HistoryEntry, error) {
+	tk, exists := st.task(id)
+
+	if !exists {
+		return nil, errors.New("job not found")
+	}
+	return tk.History(), nil
+}
+
+// EvictOutput drops the buffered output of a finished job once it has been
+// offloaded, recording where the output can now be found
+func (st *store) EvictOutput(id string, location string) error {
+	tk, exists := st.task(id)
+
+	if !exists {
+		return errors.New("job not found")
+	}
+	if tk.IsRunning() {
+		return errors.New("cannot evict output of a running job")
+	}
+
+	freed := tk.EvictBuffer(location)
+	st.logger.Debug("job output evicted", "jobId", id, "freedBytes", freed, "location", location)
+
+	return nil
+}
+
+// RecordCleanup records the outcome of removing a job's cgroup, which happens
+// in the background after the job has ended
+func (st *store) RecordCleanup(id string, cleanupErr error) error {
+	tk, exists := st.task(id)
+
+	if !exists {
+		return errors.New("job not found")
+	}
+
+	message := ""
+	if cleanupErr != nil {
+		message = cleanupErr.Error()
+	}
+	tk.SetCleanupError(message)
+
+	return nil
+}
+
+// WaitForCompletion blocks until the job reaches a final status and returns it
+func (st *store) WaitForCompletion(ctx context.Context, id string) (*domain.Job, error) {
+	tk, exists := st.task(id)
+
+	if !exists {
+		return nil, errors.New("job not found")
+	}
+
+	for {
+		// subscribe before checking, so a completion in between is not missed
+		updates, unsubscribe := tk.Subscribe()
+
+		if job := tk.GetJob(); job.IsCompleted() {
+			unsubscribe()
+			return job, nil
+		}
+
+		if err := waitForClose(ctx, updates); err != nil {
+			unsubscribe()
+			return nil, err
+		}
+		unsubscribe()
+
+		// the channel also closes when a slow subscriber is dropped, so check again
+	}
+}
+
+// waitForClose drains updates until the channel is closed or ctx is done
+func waitForClose(ctx context.Context, updates chan Update) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-updates:
+			if !ok {
+				return nil
+			}
+		}
+	}
+}
+
 // SendUpdatesToClient sends the job log updates only
 func (st *store) SendUpdatesToClient(ctx context.Context, id string, stream DomainStreamer) error {
-	st.mutex.RLock()
-	job, exists := st.tasks[id]
-	st.mutex.RUnlock()
+	job, exists := st.task(id)
 
 	if !exists {
 		st.logger.Warn("stream requested for non-existent job", "jobId", id)
@@ -210,7 +471,7 @@ func (st *store) SendUpdatesToClient(ctx context.Context, id string, stream Doma
 				updateCount++
 				logBytesSent += len(update.LogChunk)
 
-				st.logger.Debug("log chunk sent", "jobId", id, "chunkSize", len(update.LogChunk), "totalBytesSent", logBytesSent)
+				st.chunkLogger.Debug("log chunk sent", "jobId", id, "chunkSize", len(update.LogChunk), "totalBytesSent", logBytesSent)
 			}
 
 			// exit if job is not running
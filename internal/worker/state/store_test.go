@@ -2,11 +2,18 @@ package state
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/pkg/config"
 )
 
 // mockDomainStreamer for testing
@@ -52,9 +59,9 @@ func TestStore_CreateAndGetJob(t *testing.T) {
 		Args:    []string{"hello"},
 		Status:  domain.StatusInitializing,
 		Limits: domain.ResourceLimits{
-			MaxCPU:    100,
-			MaxMemory: 512,
-			MaxIOBPS:  1000,
+			CPUMillis:   1000,
+			MemoryBytes: 512 << 20,
+			IOBPS:       1000,
 		},
 		StartTime: time.Now(),
 	}
@@ -133,6 +140,35 @@ func TestStore_UpdateJob(t *testing.T) {
 	}
 }
 
+func TestStore_DeleteJob(t *testing.T) {
+	s := New()
+
+	s.CreateNewJob(&domain.Job{Id: "delete-test", Command: "echo", Status: domain.StatusRunning})
+	s.WriteToBuffer("delete-test", []byte("output"))
+
+	if err := s.DeleteJob("delete-test"); err == nil {
+		t.Error("Expected error deleting a running job")
+	}
+
+	finished, _ := s.GetJob("delete-test")
+	finished.Complete(0, "exited with code 0")
+	s.UpdateJob(finished)
+
+	if err := s.DeleteJob("delete-test"); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if _, exists := s.GetJob("delete-test"); exists {
+		t.Error("Expected job to be removed")
+	}
+	if _, _, err := s.GetOutput("delete-test"); err == nil {
+		t.Error("Expected output to be removed")
+	}
+
+	if err := s.DeleteJob("delete-test"); err == nil {
+		t.Error("Expected error deleting a missing job")
+	}
+}
+
 func TestStore_ListJobs(t *testing.T) {
 	store := New()
 
@@ -357,3 +393,406 @@ func BenchmarkStore_WriteToBuffer(b *testing.B) {
 		store.WriteToBuffer("bench-write", testData)
 	}
 }
+
+func TestStore_EvictOutput(t *testing.T) {
+	s := New()
+
+	job := &domain.Job{Id: "evict-1", Command: "echo", Status: domain.StatusRunning}
+	s.CreateNewJob(job)
+	s.WriteToBuffer("evict-1", []byte("some output"))
+
+	if err := s.EvictOutput("evict-1", "s3://bucket/jobs/evict-1"); err == nil {
+		t.Error("expected eviction of a running job to fail")
+	}
+
+	completed := job.DeepCopy()
+	completed.Complete(0, "exited with code 0")
+	s.UpdateJob(completed)
+
+	if err := s.EvictOutput("evict-1", "s3://bucket/jobs/evict-1"); err != nil {
+		t.Fatalf("EvictOutput failed: %v", err)
+	}
+
+	output, _, err := s.GetOutput("evict-1")
+	if err != nil {
+		t.Fatalf("GetOutput failed: %v", err)
+	}
+	if len(output) != 0 {
+		t.Errorf("expected output to be evicted, got %q", output)
+	}
+
+	stored, _ := s.GetJob("evict-1")
+	if stored.OutputLocation != "s3://bucket/jobs/evict-1" {
+		t.Errorf("expected output location to be recorded, got %q", stored.OutputLocation)
+	}
+
+	if err := s.EvictOutput("missing", "x"); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
+
+func TestStore_WaitForCompletion(t *testing.T) {
+	s := New()
+
+	job := &domain.Job{Id: "wait-1", Command: "sleep", Status: domain.StatusRunning}
+	s.CreateNewJob(job)
+
+	go func() {
+		time.Sleep(20 * time.Millisecond)
+		s.WriteToBuffer("wait-1", []byte("working"))
+		completed := job.DeepCopy()
+		completed.Fail(3, "exited with code 3")
+		s.UpdateJob(completed)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	finished, err := s.WaitForCompletion(ctx, "wait-1")
+	if err != nil {
+		t.Fatalf("WaitForCompletion failed: %v", err)
+	}
+	if finished.Status != domain.StatusFailed || finished.ExitCode != 3 {
+		t.Errorf("expected FAILED with exit code 3, got %s/%d", finished.Status, finished.ExitCode)
+	}
+
+	// an already finished job returns immediately
+	if _, err := s.WaitForCompletion(ctx, "wait-1"); err != nil {
+		t.Errorf("expected finished job to return immediately, got %v", err)
+	}
+
+	if _, err := s.WaitForCompletion(ctx, "missing"); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
+
+func TestStore_WaitForCompletionCancelled(t *testing.T) {
+	s := New()
+	s.CreateNewJob(&domain.Job{Id: "wait-2", Command: "sleep", Status: domain.StatusRunning})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if _, err := s.WaitForCompletion(ctx, "wait-2"); err == nil {
+		t.Error("expected context error while the job is still running")
+	}
+}
+
+func TestStore_RecordCleanup(t *testing.T) {
+	s := New()
+	ended := time.Now().Add(-time.Second)
+	s.CreateNewJob(&domain.Job{Id: "cleanup-1", Command: "echo", Status: domain.StatusCompleted, EndTime: &ended})
+
+	if err := s.RecordCleanup("cleanup-1", errors.New("device or resource busy")); err != nil {
+		t.Fatalf("RecordCleanup failed: %v", err)
+	}
+	stored, _ := s.GetJob("cleanup-1")
+	if stored.CleanupError != "device or resource busy" {
+		t.Errorf("expected cleanup error to be recorded, got %q", stored.CleanupError)
+	}
+
+	if err := s.RecordCleanup("cleanup-1", nil); err != nil {
+		t.Fatalf("RecordCleanup failed: %v", err)
+	}
+	stored, _ = s.GetJob("cleanup-1")
+	if stored.CleanupError != "" {
+		t.Errorf("expected cleanup error to be cleared, got %q", stored.CleanupError)
+	}
+	if stored.CleanupTime < time.Second {
+		t.Errorf("expected the cleanup time to count from the end of the job, got %v", stored.CleanupTime)
+	}
+
+	if err := s.RecordCleanup("missing", nil); err == nil {
+		t.Error("expected error for unknown job")
+	}
+}
+
+// jobIDs returns the sorted ids of the jobs, for comparing FindJobs results
+func jobIDs(jobs []*domain.Job) string {
+	ids := make([]string, 0, len(jobs))
+	for _, j := range jobs {
+		ids = append(ids, j.Id)
+	}
+	sort.Strings(ids)
+	return strings.Join(ids, ",")
+}
+
+func TestStore_FindJobs(t *testing.T) {
+	s := New()
+	s.CreateNewJob(&domain.Job{Id: "find-1", Status: domain.StatusRunning, Labels: map[string]string{"team": "a"}})
+	s.CreateNewJob(&domain.Job{Id: "find-2", Status: domain.StatusRunning, Labels: map[string]string{"team": "b"}})
+	s.CreateNewJob(&domain.Job{Id: "find-3", Status: domain.StatusCompleted, Labels: map[string]string{"team": "a"}})
+	now := time.Now()
+
+	tests := []struct {
+		name   string
+		filter domain.JobFilter
+		want   string
+	}{
+		{"by status", domain.JobFilter{Statuses: []domain.JobStatus{domain.StatusRunning}}, "find-1,find-2"},
+		{"by statuses", domain.JobFilter{Statuses: []domain.JobStatus{domain.StatusRunning, domain.StatusCompleted, domain.StatusRunning}}, "find-1,find-2,find-3"},
+		{"by label", domain.JobFilter{Labels: map[string]string{"team": "a"}}, "find-1,find-3"},
+		{"by status and label", domain.JobFilter{Labels: map[string]string{"team": "a"}, Statuses: []domain.JobStatus{domain.StatusCompleted}}, "find-3"},
+		{"unknown label", domain.JobFilter{Labels: map[string]string{"team": "c"}}, ""},
+		{"no filter", domain.JobFilter{}, "find-1,find-2,find-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jobIDs(s.FindJobs(tt.filter, now)); got != tt.want {
+				t.Errorf("FindJobs = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// updates and deletes move jobs between index entries
+	stopped, _ := s.GetJob("find-1")
+	stopped.Stop("stopped by a user")
+	s.UpdateJob(stopped)
+	if got := jobIDs(s.FindJobs(domain.JobFilter{Statuses: []domain.JobStatus{domain.StatusRunning}}, now)); got != "find-2" {
+		t.Errorf("running jobs after stop = %q, want %q", got, "find-2")
+	}
+
+	if err := s.DeleteJob("find-3"); err != nil {
+		t.Fatalf("DeleteJob failed: %v", err)
+	}
+	if got := jobIDs(s.FindJobs(domain.JobFilter{Labels: map[string]string{"team": "a"}}, now)); got != "find-1" {
+		t.Errorf("team a jobs after delete = %q, want %q", got, "find-1")
+	}
+}
+
+// newBenchStore returns a store holding n finished jobs spread over four teams,
+// with every hundredth job still running
+func newBenchStore(n int) Store {
+	s := New()
+	for i := 0; i < n; i++ {
+		status := domain.StatusCompleted
+		if i%100 == 0 {
+			status = domain.StatusRunning
+		}
+		s.CreateNewJob(&domain.Job{
+			Id:      fmt.Sprintf("bench-%d", i),
+			Command: "echo",
+			Status:  status,
+			Labels:  map[string]string{"team": fmt.Sprintf("team-%d", i%4)},
+		})
+	}
+	return s
+}
+
+func BenchmarkStore_GetJob50k(b *testing.B) {
+	s := newBenchStore(50000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.GetJob(fmt.Sprintf("bench-%d", i%50000))
+	}
+}
+
+func BenchmarkStore_FindRunningJobs50k(b *testing.B) {
+	s := newBenchStore(50000)
+	filter := domain.JobFilter{Statuses: []domain.JobStatus{domain.StatusRunning}}
+	now := time.Now()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.FindJobs(filter, now)
+	}
+}
+
+func BenchmarkStore_ListJobs50k(b *testing.B) {
+	s := newBenchStore(50000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		s.ListJobs()
+	}
+}
+
+func BenchmarkStore_ConcurrentWriters(b *testing.B) {
+	s := New()
+	for i := 0; i < 64; i++ {
+		s.CreateNewJob(&domain.Job{Id: fmt.Sprintf("writer-%d", i), Command: "echo", Status: domain.StatusRunning})
+	}
+	chunk := []byte("benchmark data")
+
+	var next atomic.Int64
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		id := fmt.Sprintf("writer-%d", next.Add(1)%64)
+		for pb.Next() {
+			s.WriteToBuffer(id, chunk)
+		}
+	})
+}
+
+func TestApplyEvents(t *testing.T) {
+	s := New()
+	apply := ApplyEvents(s)
+
+	job := &domain.Job{Id: "event-1", Command: "echo", Status: domain.StatusInitializing}
+	apply(events.Event{Type: events.JobCreated, JobID: job.Id, Job: job})
+
+	running := job.DeepCopy()
+	_ = running.MarkAsRunning(1234)
+	apply(events.Event{Type: events.JobUpdated, JobID: job.Id, Job: running})
+
+	apply(events.Event{Type: events.JobCleanedUp, JobID: job.Id, Err: errors.New("busy")})
+
+	stored, exists := s.GetJob("event-1")
+	if !exists {
+		t.Fatal("expected the created job to be stored")
+	}
+	if stored.Status != domain.StatusRunning {
+		t.Errorf("expected status %s, got %s", domain.StatusRunning, stored.Status)
+	}
+	if stored.CleanupError != "busy" {
+		t.Errorf("expected cleanup error to be recorded, got %q", stored.CleanupError)
+	}
+}
+
+func TestStore_OutputBudget(t *testing.T) {
+	dir := t.TempDir()
+	bus := events.NewBus()
+	pressure, unsubscribe := bus.Subscribe()
+	defer unsubscribe()
+
+	st, err := NewBounded(config.OutputBufferConfig{MaxBytes: 100, WarnPercent: 50, SpillDir: dir}, bus)
+	if err != nil {
+		t.Fatalf("NewBounded: %v", err)
+	}
+
+	ended := time.Now()
+	st.CreateNewJob(&domain.Job{Id: "finished", Command: "echo", Status: domain.StatusCompleted, EndTime: &ended})
+	st.CreateNewJob(&domain.Job{Id: "running", Command: "echo", Status: domain.StatusRunning})
+
+	finished := []byte(strings.Repeat("f", 60))
+	st.WriteToBuffer("finished", finished)
+
+	select {
+	case e := <-pressure:
+		if e.Type != events.OutputPressure {
+			t.Errorf("expected a %s event, got %s", events.OutputPressure, e.Type)
+		}
+	default:
+		t.Error("expected a pressure event once the warning threshold was reached")
+	}
+
+	// going over the budget spills the finished job first
+	running := []byte(strings.Repeat("r", 60))
+	st.WriteToBuffer("running", running)
+	if stats := st.BufferStats(); stats.UsedBytes != 60 || stats.SpilledBytes != 60 || stats.SpilledJobs != 1 {
+		t.Errorf("expected the finished job to be spilled, got %+v", stats)
+	}
+	if output, _, _ := st.GetOutput("finished"); string(output) != string(finished) {
+		t.Errorf("expected spilled output to be served, got %q", output)
+	}
+
+	// then the running job, which keeps appending to its file
+	st.WriteToBuffer("running", []byte(strings.Repeat("r", 41)))
+	st.WriteToBuffer("running", []byte("tail"))
+	if stats := st.BufferStats(); stats.UsedBytes != 0 || stats.SpilledJobs != 2 {
+		t.Errorf("expected the running job to be spilled, got %+v", stats)
+	}
+	if output, _, _ := st.GetOutput("running"); string(output) != strings.Repeat("r", 101)+"tail" {
+		t.Errorf("unexpected output of the spilled running job: %q", output)
+	}
+
+	if err := st.DeleteJob("finished"); err != nil {
+		t.Fatalf("DeleteJob: %v", err)
+	}
+	if _, err := os.Stat(spillPath(dir, "finished")); !os.IsNotExist(err) {
+		t.Errorf("expected the spill file to be removed with the job, got %v", err)
+	}
+}
+
+func TestStore_OnStatusChange(t *testing.T) {
+	s := New()
+	var transitions []domain.StatusTransition
+	s.OnStatusChange(func(tr domain.StatusTransition) {
+		if job, _ := s.GetJob(tr.JobID); job.Status != tr.To {
+			t.Errorf("expected hooks to run after the change is applied, got %s", job.Status)
+		}
+		transitions = append(transitions, tr)
+	})
+
+	job := &domain.Job{Id: "hooked", Command: "echo", Status: domain.StatusInitializing}
+	s.CreateNewJob(job)
+
+	running := job.DeepCopy()
+	if err := running.MarkAsRunning(42); err != nil {
+		t.Fatal(err)
+	}
+	s.UpdateJob(running)
+	s.UpdateJob(running) // no transition, same status
+
+	failed := running.DeepCopy()
+	failed.Fail(3, "exited with code 3")
+	s.UpdateJob(failed)
+
+	want := []struct {
+		from, to domain.JobStatus
+		reason   string
+	}{
+		{"", domain.StatusInitializing, "created"},
+		{domain.StatusInitializing, domain.StatusRunning, "started with pid 42"},
+		{domain.StatusRunning, domain.StatusFailed, "exited with code 3"},
+	}
+	if len(transitions) != len(want) {
+		t.Fatalf("expected %d transitions, got %+v", len(want), transitions)
+	}
+	for i, w := range want {
+		if tr := transitions[i]; tr.From != w.from || tr.To != w.to || tr.Reason != w.reason || tr.JobID != "hooked" {
+			t.Errorf("transition %d: expected %s -> %s (%s), got %s -> %s (%s)", i, w.from, w.to, w.reason, tr.From, tr.To, tr.Reason)
+		}
+	}
+	if !transitions[2].IsFinal() || transitions[1].IsFinal() {
+		t.Error("expected only the failure to end the job")
+	}
+}
+
+func TestStore_RejectsInvalidTransition(t *testing.T) {
+	s := New()
+	hooked := 0
+	s.OnStatusChange(func(domain.StatusTransition) { hooked++ })
+
+	job := &domain.Job{Id: "strict", Command: "echo", Status: domain.StatusInitializing}
+	s.CreateNewJob(job)
+
+	running := job.DeepCopy()
+	_ = running.MarkAsRunning(42)
+	s.UpdateJob(running)
+
+	stopped := running.DeepCopy()
+	stopped.Stop("stopped by a user")
+	s.UpdateJob(stopped)
+
+	// the process exit seen after the stop must not turn the job FAILED
+	failed := running.DeepCopy()
+	failed.Fail(-1, "killed by a signal")
+	s.UpdateJob(failed)
+
+	// later records of the stopped job keep the reason it stopped for
+	final := running.DeepCopy()
+	final.Stop("stopped, exit seen by the monitor")
+	final.OutputDropped = 10
+	s.UpdateJob(final)
+
+	if got, _ := s.GetJob("strict"); got.Status != domain.StatusStopped || got.StatusReason != "stopped by a user" || got.OutputDropped != 10 {
+		t.Errorf("expected the job to stay STOPPED, got %s (%s)", got.Status, got.StatusReason)
+	}
+	if hooked != 3 {
+		t.Errorf("expected no hook for the rejected update, got %d calls", hooked)
+	}
+
+	history, err := s.History("strict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reasons []string
+	for _, entry := range history {
+		reasons = append(reasons, entry.Reason)
+	}
+	if got, want := strings.Join(reasons, ","), "created,started with pid 42,stopped by a user"; got != want {
+		t.Errorf("expected history reasons %q, got %q", want, got)
+	}
+}
@@ -2,7 +2,10 @@ package state
 
 import (
 	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
+	"os"
 	"sync"
 	"time"
 	"worker/internal/worker/domain"
@@ -12,11 +15,22 @@ import (
 type Task struct {
 	id string
 
-	job   *domain.Job
-	jobMu sync.RWMutex
+	job     *domain.Job
+	history []domain.HistoryEntry // guarded by jobMu
+	jobMu   sync.RWMutex
 
-	buffer   bytes.Buffer
-	bufferMu sync.RWMutex
+	buffer    bytes.Buffer
+	spill     *os.File // output moved out of memory, nil while it is buffered
+	spilled   int64    // bytes written to spill
+	packed    []byte   // gzipped output of a finished job, which buffer is then empty of
+	unpacked  int      // size of the packed output once decompressed
+	packTried bool     // compaction found the output did not get smaller
+	bufferMu  sync.RWMutex
+
+	// held while an update is applied and its status hooks run, so the hooks
+	// see the transitions of a job in the order they were applied
+	transitionMu sync.Mutex
+	budget       *budget // accounts the buffer, nil for tasks outside a store
 
 	subscribers map[chan Update]bool
 	subMu       sync.RWMutex
@@ -24,9 +38,20 @@ type Task struct {
 	ctx    context.Context
 	cancel context.CancelFunc
 
-	logger *logger.Logger
+	logger      *logger.Logger
+	chunkLogger *logger.Logger // sampled, for entries logged per output chunk
 }
 
+// maxHistory bounds the changes kept per job, a job restarting forever drops its oldest ones
+const maxHistory = 256
+
+// chunkLogsPerSecond limits the entries logged per output chunk, which would
+// otherwise flood the log when jobs write a lot of output
+const chunkLogsPerSecond = 10
+
+// chunkLogger is shared by all tasks so the limit holds across jobs
+var chunkLogger = logger.New().Sampled(chunkLogsPerSecond)
+
 // Update used for pub/sub
 type Update struct {
 	JobID    string
@@ -36,6 +61,9 @@ type Update struct {
 
 func NewTask(job *domain.Job) *Task {
 	jobCopy := job.DeepCopy()
+	if jobCopy.StatusReason == "" {
+		jobCopy.StatusReason = domain.ReasonCreated
+	}
 
 	ctx, cancel := context.WithCancel(context.Background())
 
@@ -44,10 +72,12 @@ func NewTask(job *domain.Job) *Task {
 	return &Task{
 		id:          job.Id,
 		job:         jobCopy,
+		history:     []domain.HistoryEntry{historyEntry(domain.HistoryCreated, jobCopy, true)},
 		subscribers: make(map[chan Update]bool),
 		ctx:         ctx,
 		cancel:      cancel,
 		logger:      taskLogger,
+		chunkLogger: chunkLogger.WithField("taskId", job.Id),
 	}
 }
 
@@ -96,24 +126,36 @@ func (t *Task) removeSubscriber(ch chan Update) {
 	}
 }
 
-func (t *Task) UpdateJob(job *domain.Job) {
+// UpdateJob replaces the job record and returns the status it had before. A
+// change of status the job state machine does not allow is not applied, the
+// error says why.
+func (t *Task) UpdateJob(job *domain.Job) (domain.JobStatus, error) {
 	jobCopy := job.DeepCopy()
 
-	oldStatus := ""
-	t.jobMu.RLock()
+	var oldStatus domain.JobStatus
+	t.jobMu.Lock()
 	if t.job != nil {
-		oldStatus = string(t.job.Status)
+		oldStatus = t.job.Status
+		if err := domain.CheckTransition(t.job, jobCopy); err != nil {
+			t.jobMu.Unlock()
+			return oldStatus, err
+		}
+		// the reason is why the job entered its status, later records of it do not change it
+		if t.job.Status == jobCopy.Status && t.job.Restarts == jobCopy.Restarts {
+			jobCopy.StatusReason = t.job.StatusReason
+		}
+	}
+	if t.job == nil || t.job.Status != jobCopy.Status || t.job.Restarts != jobCopy.Restarts || t.job.Health != jobCopy.Health {
+		reasoned := t.job == nil || t.job.StatusReason != jobCopy.StatusReason
+		t.record(historyEntry(domain.HistoryUpdated, jobCopy, reasoned))
 	}
-	t.jobMu.RUnlock()
-
-	t.jobMu.Lock()
 	t.job = jobCopy
 	t.jobMu.Unlock()
 
-	newStatus := string(jobCopy.Status)
-	if oldStatus != newStatus {
-		t.logger.Debug("job status updated", "oldStatus", oldStatus, "newStatus", newStatus)
+	if oldStatus != jobCopy.Status {
+		t.logger.Debug("job status updated", "oldStatus", string(oldStatus), "newStatus", string(jobCopy.Status), "reason", jobCopy.StatusReason)
 	}
+	return oldStatus, nil
 }
 
 func (t *Task) Publish(update Update) {
@@ -150,7 +192,7 @@ func (t *Task) Publish(update Update) {
 	}
 
 	if len(update.LogChunk) > 0 {
-		t.logger.Debug("log chunk published", "subscribers", subscriberCount,
+		t.chunkLogger.Debug("log chunk published", "subscribers", subscriberCount,
 			"successful", successCount, "timedOut", timeoutCount, "chunkSize", len(update.LogChunk))
 	}
 
@@ -165,7 +207,20 @@ func (t *Task) WriteToBuffer(logData []byte) {
 	}
 
 	t.bufferMu.Lock()
-	t.buffer.Write(logData)
+	if t.spill != nil {
+		// a failed write loses the chunk rather than bringing it back to memory out of order
+		n, err := t.spill.Write(logData)
+		t.spilled += int64(n)
+		if err != nil {
+			t.chunkLogger.Error("failed to write spilled output", "error", err)
+		}
+	} else {
+		t.unpack()
+		t.buffer.Write(logData)
+		if t.budget != nil {
+			t.budget.add(len(logData))
+		}
+	}
 	t.bufferMu.Unlock()
 
 	t.Publish(Update{
@@ -178,6 +233,23 @@ func (t *Task) GetBuffer() []byte {
 	t.bufferMu.RLock()
 	defer t.bufferMu.RUnlock()
 
+	if t.spill != nil {
+		data := make([]byte, t.spilled)
+		n, err := t.spill.ReadAt(data, 0)
+		if err != nil && n < len(data) {
+			t.logger.Error("failed to read spilled output", "error", err)
+		}
+		return data[:n]
+	}
+
+	if t.packed != nil {
+		data, err := gunzip(t.packed, t.unpacked)
+		if err != nil {
+			t.logger.Error("failed to decompress output", "error", err)
+		}
+		return data
+	}
+
 	if t.buffer.Len() == 0 {
 		return nil
 	}
@@ -190,6 +262,228 @@ func (t *Task) GetBuffer() []byte {
 	return data
 }
 
+// EvictBuffer releases the output buffer and records the job's output location.
+// It returns the number of bytes freed.
+func (t *Task) EvictBuffer(location string) int {
+	freed := t.discardOutput()
+
+	t.jobMu.Lock()
+	t.job.OutputLocation = location
+	t.jobMu.Unlock()
+
+	return freed
+}
+
+// bufferedBytes is how much memory the task's output takes
+func (t *Task) bufferedBytes() int {
+	t.bufferMu.RLock()
+	defer t.bufferMu.RUnlock()
+
+	return t.buffer.Len() + len(t.packed)
+}
+
+// pack gzips the buffered output, which only finished jobs should have done
+// as further output has to be decompressed first. It returns the number of
+// bytes freed.
+func (t *Task) pack() int {
+	t.bufferMu.Lock()
+	defer t.bufferMu.Unlock()
+
+	if t.spill != nil || t.packed != nil || t.packTried || t.buffer.Len() == 0 {
+		return 0
+	}
+
+	var packed bytes.Buffer
+	w := gzip.NewWriter(&packed)
+	if _, err := w.Write(t.buffer.Bytes()); err != nil || w.Close() != nil {
+		return 0
+	}
+	if packed.Len() >= t.buffer.Len() {
+		t.packTried = true
+		return 0
+	}
+
+	saved := t.buffer.Len() - packed.Len()
+	t.packed, t.unpacked = bytes.Clone(packed.Bytes()), t.buffer.Len()
+	t.buffer = bytes.Buffer{}
+	if t.budget != nil {
+		t.budget.release(saved)
+	}
+	return saved
+}
+
+// unpack brings packed output back into the buffer, the caller holds bufferMu
+func (t *Task) unpack() {
+	if t.packed == nil {
+		return
+	}
+
+	data, err := gunzip(t.packed, t.unpacked)
+	if err != nil {
+		t.logger.Error("failed to decompress output", "error", err)
+	}
+	t.buffer.Write(data)
+	if t.budget != nil {
+		t.budget.add(len(data) - len(t.packed))
+	}
+	t.packed, t.unpacked, t.packTried = nil, 0, false
+}
+
+// packedBytes is the memory the packed output takes and its size once
+// decompressed, zeros when it is not packed
+func (t *Task) packedBytes() (int, int) {
+	t.bufferMu.RLock()
+	defer t.bufferMu.RUnlock()
+
+	return len(t.packed), t.unpacked
+}
+
+func gunzip(packed []byte, size int) ([]byte, error) {
+	r, err := gzip.NewReader(bytes.NewReader(packed))
+	if err != nil {
+		return nil, err
+	}
+	data := bytes.NewBuffer(make([]byte, 0, size))
+	_, err = io.Copy(data, r)
+	return data.Bytes(), err
+}
+
+// spillBuffer moves the buffered output to a file in dir, where further
+// output is appended too. It returns the number of bytes freed.
+func (t *Task) spillBuffer(dir string) (int, error) {
+	t.bufferMu.Lock()
+	defer t.bufferMu.Unlock()
+
+	if t.spill != nil || t.buffer.Len()+len(t.packed) == 0 {
+		return 0, nil
+	}
+	t.unpack()
+
+	f, err := os.OpenFile(spillPath(dir, t.id), os.O_RDWR|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0600)
+	if err != nil {
+		return 0, err
+	}
+	n, err := f.Write(t.buffer.Bytes())
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return 0, err
+	}
+
+	freed := t.buffer.Len()
+	t.spill, t.spilled = f, int64(n)
+	t.buffer = bytes.Buffer{}
+	return freed, nil
+}
+
+// discardOutput drops the buffered output and removes its spill file. It
+// returns the number of bytes freed from memory.
+func (t *Task) discardOutput() int {
+	t.bufferMu.Lock()
+	defer t.bufferMu.Unlock()
+
+	freed := t.buffer.Len() + len(t.packed)
+	t.buffer = bytes.Buffer{}
+	t.packed, t.unpacked, t.packTried = nil, 0, false
+	if t.budget != nil {
+		t.budget.release(freed)
+	}
+
+	if t.spill != nil {
+		t.spill.Close()
+		if err := os.Remove(t.spill.Name()); err != nil {
+			t.logger.Warn("failed to remove spilled output", "error", err)
+		}
+		t.spill, t.spilled = nil, 0
+	}
+	return freed
+}
+
+// expireOutput drops the output of a finished job once the retention has
+// passed, on disk too, and marks it as expired. It returns the number of
+// bytes freed from memory and false when there was no output to drop.
+func (t *Task) expireOutput() (int, bool) {
+	t.bufferMu.RLock()
+	held := t.buffer.Len() > 0 || t.packed != nil || t.spill != nil
+	t.bufferMu.RUnlock()
+	if !held {
+		return 0, false
+	}
+
+	freed := t.discardOutput()
+
+	t.jobMu.Lock()
+	t.job.OutputExpired = true
+	t.jobMu.Unlock()
+
+	return freed, true
+}
+
+// finishedAt is when the job ended, false while it has not
+func (t *Task) finishedAt() (time.Time, bool) {
+	t.jobMu.RLock()
+	defer t.jobMu.RUnlock()
+
+	if !t.job.IsCompleted() || t.job.EndTime == nil {
+		return time.Time{}, false
+	}
+	return *t.job.EndTime, true
+}
+
+// historyLen is the number of changes recorded to the job
+func (t *Task) historyLen() int {
+	t.jobMu.RLock()
+	defer t.jobMu.RUnlock()
+
+	return len(t.history)
+}
+
+// SetCleanupError records why the job's cgroup could not be removed, and how
+// long after the job ended its cleanup finished
+func (t *Task) SetCleanupError(message string) {
+	t.jobMu.Lock()
+	t.job.CleanupError = message
+	if t.job.EndTime != nil {
+		t.job.CleanupTime = time.Since(*t.job.EndTime)
+	}
+	entry := historyEntry(domain.HistoryCleanedUp, t.job, false)
+	entry.Error = message
+	t.record(entry)
+	t.jobMu.Unlock()
+}
+
+// History returns the changes recorded to the job, oldest first
+func (t *Task) History() []domain.HistoryEntry {
+	t.jobMu.RLock()
+	defer t.jobMu.RUnlock()
+
+	return append([]domain.HistoryEntry(nil), t.history...)
+}
+
+// record appends to the history, the caller holds jobMu
+func (t *Task) record(entry domain.HistoryEntry) {
+	if len(t.history) >= maxHistory {
+		t.history = append(t.history[:0], t.history[1:]...)
+	}
+	t.history = append(t.history, entry)
+}
+
+// historyEntry records the job as it is now, with the reason of its status
+// when the change is the one that gave it
+func historyEntry(change domain.HistoryChange, job *domain.Job, reasoned bool) domain.HistoryEntry {
+	entry := domain.HistoryEntry{
+		Time:     time.Now(),
+		Change:   change,
+		Status:   job.Status,
+		Restarts: job.Restarts,
+		Health:   job.Health,
+	}
+	if reasoned {
+		entry.Reason = job.StatusReason
+	}
+	return entry
+}
+
 func (t *Task) IsRunning() bool {
 	t.jobMu.RLock()
 	defer t.jobMu.RUnlock()
@@ -89,6 +89,43 @@ func TestTask_UpdateJob(t *testing.T) {
 	}
 }
 
+func TestTask_History(t *testing.T) {
+	job := &domain.Job{Id: "history-test", Command: "echo", Status: domain.StatusInitializing}
+	task := NewTask(job)
+
+	running := job.DeepCopy()
+	_ = running.MarkAsRunning(1234)
+	task.UpdateJob(running)
+
+	// an update changing neither status, restarts nor health is not recorded
+	sameStatus := running.DeepCopy()
+	sameStatus.WorkspaceBytes = 100
+	task.UpdateJob(sameStatus)
+
+	completed := running.DeepCopy()
+	completed.Complete(0, "exited with code 0")
+	task.UpdateJob(completed)
+	task.SetCleanupError("device busy")
+
+	history := task.History()
+	changes := []domain.HistoryChange{domain.HistoryCreated, domain.HistoryUpdated, domain.HistoryUpdated, domain.HistoryCleanedUp}
+	if len(history) != len(changes) {
+		t.Fatalf("expected %d history entries, got %+v", len(changes), history)
+	}
+	for i, change := range changes {
+		if history[i].Change != change {
+			t.Errorf("entry %d: expected %s, got %s", i, change, history[i].Change)
+		}
+	}
+	if history[2].Status != domain.StatusCompleted || history[3].Error != "device busy" {
+		t.Errorf("unexpected history %+v", history)
+	}
+
+	if statuses := domain.StatusChanges(history); len(statuses) != 3 {
+		t.Errorf("expected 3 status changes, got %+v", statuses)
+	}
+}
+
 func TestTask_WriteToBuffer(t *testing.T) {
 	job := &domain.Job{
 		Id:      "buffer-test",
@@ -0,0 +1,87 @@
+package templates
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/logger"
+)
+
+var (
+	ErrNotFound      = errors.New("job template not found")
+	ErrAlreadyExists = errors.New("job template already exists")
+)
+
+var namePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*$`)
+
+// Store keeps the job templates jobs can be run from by name
+type Store struct {
+	templates map[string]*domain.JobTemplate
+	mutex     sync.RWMutex
+
+	logger *logger.Logger
+}
+
+func NewStore() *Store {
+	return &Store{
+		templates: make(map[string]*domain.JobTemplate),
+		logger:    logger.WithField("component", "job-templates"),
+	}
+}
+
+// Create validates and stores a template. A template of the same name is
+// only replaced when replace is set.
+func (s *Store) Create(tmpl *domain.JobTemplate, replace bool) (*domain.JobTemplate, error) {
+	if !namePattern.MatchString(tmpl.Name) {
+		return nil, fmt.Errorf("invalid template name %q", tmpl.Name)
+	}
+	if err := tmpl.Validate(); err != nil {
+		return nil, err
+	}
+
+	stored := tmpl.DeepCopy()
+	stored.CreatedAt = time.Now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if _, exists := s.templates[tmpl.Name]; exists && !replace {
+		return nil, fmt.Errorf("%w: %s", ErrAlreadyExists, tmpl.Name)
+	}
+	s.templates[tmpl.Name] = stored
+
+	s.logger.Debug("job template stored", "name", tmpl.Name, "params", len(tmpl.Params))
+
+	return stored.DeepCopy(), nil
+}
+
+// Get returns the template of the given name
+func (s *Store) Get(name string) (*domain.JobTemplate, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	tmpl, exists := s.templates[name]
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	}
+	return tmpl.DeepCopy(), nil
+}
+
+// List returns every template by name
+func (s *Store) List() []*domain.JobTemplate {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	templates := make([]*domain.JobTemplate, 0, len(s.templates))
+	for _, tmpl := range s.templates {
+		templates = append(templates, tmpl.DeepCopy())
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates
+}
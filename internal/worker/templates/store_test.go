@@ -0,0 +1,78 @@
+package templates
+
+import (
+	"errors"
+	"testing"
+	"worker/internal/worker/domain"
+)
+
+func newTemplate(name string) *domain.JobTemplate {
+	return &domain.JobTemplate{
+		Name:   name,
+		Params: []domain.TemplateParam{{Name: "file", Required: true}},
+		Spec:   &domain.JobSpec{Command: "gzip", Args: []string{"{{file}}"}},
+	}
+}
+
+func TestStoreCreate(t *testing.T) {
+	s := NewStore()
+
+	if _, err := s.Create(newTemplate("compress"), false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := s.Create(newTemplate("compress"), false); !errors.Is(err, ErrAlreadyExists) {
+		t.Errorf("expected ErrAlreadyExists, got %v", err)
+	}
+
+	replacement := newTemplate("compress")
+	replacement.Description = "gzip a file"
+	if _, err := s.Create(replacement, true); err != nil {
+		t.Fatalf("unexpected error replacing: %v", err)
+	}
+
+	got, err := s.Get("compress")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got.Description != "gzip a file" || got.CreatedAt.IsZero() {
+		t.Errorf("unexpected template %+v", got)
+	}
+
+	if _, err := s.Create(newTemplate("../x"), false); err == nil {
+		t.Error("expected an invalid name to be rejected")
+	}
+	if _, err := s.Get("missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestStoreReturnsCopies(t *testing.T) {
+	s := NewStore()
+	tmpl := newTemplate("compress")
+	if _, err := s.Create(tmpl, false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tmpl.Spec.Args[0] = "changed"
+
+	got, _ := s.Get("compress")
+	got.Spec.Command = "changed"
+
+	again, _ := s.Get("compress")
+	if again.Spec.Command != "gzip" || again.Spec.Args[0] != "{{file}}" {
+		t.Errorf("stored template was modified: %+v", again.Spec)
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	s := NewStore()
+	for _, name := range []string{"b", "c", "a"} {
+		if _, err := s.Create(newTemplate(name), false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	templates := s.List()
+	if len(templates) != 3 || templates[0].Name != "a" || templates[2].Name != "c" {
+		t.Errorf("expected templates sorted by name, got %d", len(templates))
+	}
+}
@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// record installs a tracer provider that keeps the ended spans in memory
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestEndRecordsError(t *testing.T) {
+	recorder := record(t)
+
+	ctx, parent := Start(context.Background(), "job.start", JobID("7"))
+	_, child := Start(ctx, "job.launch")
+	End(child, errors.New("exec failed"))
+	End(parent, nil)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	launch, start := spans[0], spans[1]
+	if launch.Parent().SpanID() != start.SpanContext().SpanID() {
+		t.Error("expected job.launch to be a child of job.start")
+	}
+	if launch.Status().Code != codes.Error || launch.Status().Description != "exec failed" {
+		t.Errorf("unexpected status %+v", launch.Status())
+	}
+	if start.Status().Code == codes.Error {
+		t.Error("expected job.start to succeed")
+	}
+}
+
+func TestInjectExtract(t *testing.T) {
+	record(t)
+
+	ctx, span := Start(context.Background(), "job.launch")
+	defer span.End()
+
+	traceparent := Inject(ctx)
+	if traceparent == "" {
+		t.Fatal("expected a traceparent for a recorded span")
+	}
+
+	remote := trace.SpanContextFromContext(Extract(context.Background(), traceparent))
+	if remote.TraceID() != span.SpanContext().TraceID() || remote.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("extracted %v, want the span of %v", remote, span.SpanContext())
+	}
+
+	if Inject(context.Background()) != "" {
+		t.Error("expected no traceparent without a span")
+	}
+}
+
+func TestDetachKeepsSpanOnly(t *testing.T) {
+	record(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctx, span := Start(ctx, "job.start")
+	span.End()
+	cancel()
+
+	detached := Detach(ctx)
+	if detached.Err() != nil {
+		t.Error("expected the detached context to outlive the request")
+	}
+	if trace.SpanContextFromContext(detached).SpanID() != span.SpanContext().SpanID() {
+		t.Error("expected the detached context to carry the span")
+	}
+}
@@ -0,0 +1,172 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"time"
+	"worker/internal/worker/upgrade"
+	"worker/internal/worker/version"
+)
+
+// upgradeDrainTimeout is how long the calls in progress get to finish before
+// an upgrade cuts them off; clients following output reconnect to the new binary
+const upgradeDrainTimeout = 10 * time.Second
+
+// handoffWorker is a platform worker whose running jobs survive an upgrade
+type handoffWorker interface {
+	HandOff() (map[string]*upgrade.Process, func(), error)
+	Adopt(jobs []upgrade.Job)
+}
+
+// Upgrade replaces the running binary, in place, by the worker binary on
+// disk, which a deploy has replaced: the gRPC listener, the job records with
+// their buffered output and the running jobs are handed to it, so no job is
+// stopped and no connection refused. Upgrade only returns when the upgrade
+// failed; the worker then carries on serving and supervising its jobs.
+func (jw *JobWorker) Upgrade() error {
+	if jw.grpcServer == nil {
+		return fmt.Errorf("worker is not serving")
+	}
+	handoff, ok := jw.Worker.(handoffWorker)
+	if !ok {
+		return fmt.Errorf("the jobs of this worker cannot be handed off")
+	}
+
+	binary, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to find the worker binary: %w", err)
+	}
+	info, err := os.Stat(binary)
+	if err != nil {
+		return fmt.Errorf("failed to find the worker binary: %w", err)
+	}
+	if !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+		return fmt.Errorf("worker binary %s is not executable", binary)
+	}
+
+	// a copy of the listener keeps the socket open, and the connections in
+	// its accept queue, while the server stops and the new binary starts
+	filer, ok := jw.listener.(interface{ File() (*os.File, error) })
+	if !ok {
+		return fmt.Errorf("the %T listener cannot be handed off", jw.listener)
+	}
+	listener, err := filer.File()
+	if err != nil {
+		return fmt.Errorf("failed to hand off the listener: %w", err)
+	}
+	defer listener.Close()
+
+	jw.logger.Info("upgrading worker binary, stopping the server", "binary", binary, "version", version.String())
+	jw.stopServing(upgradeDrainTimeout)
+
+	processes, release, err := handoff.HandOff()
+	if err != nil {
+		return jw.resumeServing(listener, err)
+	}
+
+	s := &upgrade.State{Version: version.String(), Listener: int(listener.Fd())}
+	for _, job := range jw.Store.ListJobs() {
+		output, _, _ := jw.Store.GetOutput(job.Id)
+		s.Jobs = append(s.Jobs, upgrade.Job{Job: job, Output: output, Process: processes[job.Id]})
+	}
+
+	// the exec skips all deferred work: send the queued events and spans now
+	jw.flush()
+
+	jw.logger.Info("handing off to the new worker binary", "jobs", len(s.Jobs), "running", len(processes))
+	err = upgrade.Exec(binary, s)
+
+	release()
+	jw.logger.Error("upgrade failed after events and traces were flushed, they stay off until the worker is restarted")
+	return jw.resumeServing(listener, err)
+}
+
+// stopServing stops the gRPC server, cutting off the calls still in progress
+// after timeout
+func (jw *JobWorker) stopServing(timeout time.Duration) {
+	grpcServer := jw.grpcServer
+	jw.grpcServer = nil
+	jw.listener = nil
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-stopped:
+	case <-timer.C:
+		grpcServer.Stop()
+		<-stopped
+	}
+}
+
+// resumeServing serves again on the listener kept for an upgrade that failed
+// with cause, which it returns
+func (jw *JobWorker) resumeServing(listener *os.File, cause error) error {
+	lis, err := net.FileListener(listener)
+	if err == nil {
+		jw.inherited = lis
+		err = jw.Serve()
+	}
+	if err != nil {
+		jw.logger.Error("failed to serve again after the failed upgrade", "error", err)
+	}
+	return fmt.Errorf("upgrade failed: %w", cause)
+}
+
+// flush sends the queued CloudEvents and exports the spans recorded so far,
+// closing both
+func (jw *JobWorker) flush() {
+	if jw.emitter != nil {
+		if err := jw.emitter.Close(); err != nil {
+			jw.logger.Warn("failed to close cloudevents transport", "error", err)
+		}
+		jw.emitter = nil
+	}
+
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer flushCancel()
+	if err := jw.shutdownTracing(flushCtx); err != nil {
+		jw.logger.Warn("failed to flush traces", "error", err)
+	}
+	jw.shutdownTracing = func(context.Context) error { return nil }
+}
+
+// takeOver puts the jobs handed off by the binary this one replaced back in
+// the store, has the platform worker supervise those still running and
+// keeps the listener to serve on
+func (jw *JobWorker) takeOver(s *upgrade.State) {
+	running := 0
+	for _, handed := range s.Jobs {
+		jw.Store.CreateNewJob(handed.Job)
+		jw.Store.WriteToBuffer(handed.Job.Id, handed.Output)
+		if handed.Process != nil {
+			running++
+		}
+	}
+
+	if adopter, ok := jw.Worker.(handoffWorker); ok {
+		adopter.Adopt(s.Jobs)
+	} else if running > 0 {
+		jw.logger.Error("this worker cannot supervise the running jobs handed off to it", "running", running)
+	}
+
+	if s.Listener >= 0 {
+		file := os.NewFile(uintptr(s.Listener), "listener")
+		lis, err := net.FileListener(file)
+		_ = file.Close()
+		if err != nil {
+			jw.logger.Warn("failed to take over the listener, listening anew", "error", err)
+		} else {
+			jw.inherited = lis
+		}
+	}
+
+	jw.logger.Info("worker binary upgraded", "from", s.Version, "to", version.String(), "jobs", len(s.Jobs), "running", running)
+}
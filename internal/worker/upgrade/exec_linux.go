@@ -0,0 +1,111 @@
+//go:build linux
+
+package upgrade
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+)
+
+// Exec replaces the running worker by binary, in place: the process keeps its
+// pid, so running jobs stay its children, and the descriptors of s stay open
+// under the same numbers. It returns only when the exec failed, with the
+// descriptors close-on-exec again.
+func Exec(binary string, s *State) error {
+	stateFd, err := writeState(s)
+	if err != nil {
+		return err
+	}
+
+	fds := s.descriptors()
+	for _, fd := range fds {
+		if _, err := unix.FcntlInt(uintptr(fd), unix.F_SETFD, 0); err != nil {
+			closeOnExec(fds)
+			_ = unix.Close(stateFd)
+			return fmt.Errorf("failed to pass descriptor %d: %w", fd, err)
+		}
+	}
+
+	env := make([]string, 0, len(os.Environ())+1)
+	for _, kv := range os.Environ() {
+		if !strings.HasPrefix(kv, stateEnv+"=") {
+			env = append(env, kv)
+		}
+	}
+	env = append(env, stateEnv+"="+strconv.Itoa(stateFd))
+
+	err = unix.Exec(binary, os.Args, env)
+	closeOnExec(fds)
+	_ = unix.Close(stateFd)
+	return fmt.Errorf("failed to exec %s: %w", binary, err)
+}
+
+// Inherited returns the state handed over by the worker this binary
+// replaced, nil when it was started otherwise. The descriptors of the state
+// are made close-on-exec again, so the jobs started from now on do not
+// inherit them.
+func Inherited() (*State, error) {
+	value, ok := os.LookupEnv(stateEnv)
+	if !ok {
+		return nil, nil
+	}
+	_ = os.Unsetenv(stateEnv)
+
+	fd, err := strconv.Atoi(value)
+	if err != nil || fd < 0 {
+		return nil, fmt.Errorf("invalid %s %q", stateEnv, value)
+	}
+	file := os.NewFile(uintptr(fd), "upgrade-state")
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read upgrade state: %w", err)
+	}
+	s, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	closeOnExec(s.descriptors())
+	upgraded.Store(true)
+	return s, nil
+}
+
+// writeState writes s to an in-memory file the exec passes on, so secrets it
+// carries never reach a disk
+func writeState(s *State) (int, error) {
+	data, err := encode(s)
+	if err != nil {
+		return -1, err
+	}
+
+	fd, err := unix.MemfdCreate("worker-upgrade", 0)
+	if err != nil {
+		return -1, fmt.Errorf("failed to create upgrade state file: %w", err)
+	}
+	for written := 0; written < len(data); {
+		n, err := unix.Write(fd, data[written:])
+		if err != nil {
+			_ = unix.Close(fd)
+			return -1, fmt.Errorf("failed to write upgrade state: %w", err)
+		}
+		written += n
+	}
+	if _, err := unix.Seek(fd, 0, io.SeekStart); err != nil {
+		_ = unix.Close(fd)
+		return -1, fmt.Errorf("failed to write upgrade state: %w", err)
+	}
+	return fd, nil
+}
+
+func closeOnExec(fds []int) {
+	for _, fd := range fds {
+		unix.CloseOnExec(fd)
+	}
+}
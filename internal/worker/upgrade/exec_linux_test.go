@@ -0,0 +1,91 @@
+//go:build linux
+
+package upgrade
+
+import (
+	"os"
+	"strconv"
+	"testing"
+
+	"golang.org/x/sys/unix"
+	"worker/internal/worker/domain"
+)
+
+func TestInherited_NotAnUpgrade(t *testing.T) {
+	t.Setenv(stateEnv, "")
+	_ = os.Unsetenv(stateEnv)
+
+	s, err := Inherited()
+	if s != nil || err != nil {
+		t.Errorf("Inherited() = %v, %v; want nil, nil", s, err)
+	}
+}
+
+func TestInherited_ReadsHandedOffState(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Close()
+	defer writer.Close()
+
+	// as Exec leaves them: open across the exec
+	pipeFd := int(reader.Fd())
+	if _, err := unix.FcntlInt(uintptr(pipeFd), unix.F_SETFD, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	handed := &State{
+		Version:  "v1.2.3",
+		Listener: -1,
+		Jobs: []Job{
+			{Job: &domain.Job{Id: "1", Status: domain.StatusCompleted}, Output: []byte("done\n")},
+			{
+				Job:     &domain.Job{Id: "2", Status: domain.StatusRunning, Pid: 42},
+				Process: &Process{Pid: 42, Pidfd: pipeFd, Stdout: -1, Stderr: -1, Secrets: map[string]string{"TOKEN": "s3cret"}},
+			},
+		},
+	}
+	stateFd, err := writeState(handed)
+	if err != nil {
+		t.Fatalf("writeState failed: %v", err)
+	}
+	t.Setenv(stateEnv, strconv.Itoa(stateFd))
+
+	s, err := Inherited()
+	if err != nil {
+		t.Fatalf("Inherited failed: %v", err)
+	}
+	if !Upgraded() {
+		t.Error("expected the process to be upgraded")
+	}
+	if _, ok := os.LookupEnv(stateEnv); ok {
+		t.Error("expected the state to be read only once")
+	}
+
+	if s.Version != "v1.2.3" || len(s.Jobs) != 2 {
+		t.Fatalf("unexpected state %+v", s)
+	}
+	if string(s.Jobs[0].Output) != "done\n" || s.Jobs[0].Process != nil {
+		t.Errorf("unexpected finished job %+v", s.Jobs[0])
+	}
+	p := s.Jobs[1].Process
+	if p == nil || p.Pid != 42 || p.Pidfd != pipeFd || p.Secrets["TOKEN"] != "s3cret" {
+		t.Errorf("unexpected process %+v", p)
+	}
+
+	flags, err := unix.FcntlInt(uintptr(pipeFd), unix.F_GETFD, 0)
+	if err != nil || flags&unix.FD_CLOEXEC == 0 {
+		t.Errorf("expected the inherited descriptor to be close-on-exec again, got %#x, %v", flags, err)
+	}
+	if _, err := unix.FcntlInt(uintptr(stateFd), unix.F_GETFD, 0); err == nil {
+		t.Error("expected the state file to be closed")
+	}
+}
+
+func TestInherited_RejectsInvalidDescriptor(t *testing.T) {
+	t.Setenv(stateEnv, "not-a-descriptor")
+	if _, err := Inherited(); err == nil {
+		t.Error("expected an error")
+	}
+}
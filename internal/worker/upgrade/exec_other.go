@@ -0,0 +1,15 @@
+//go:build !linux
+
+package upgrade
+
+import "fmt"
+
+// Exec is only supported on Linux
+func Exec(binary string, s *State) error {
+	return fmt.Errorf("in-place upgrades are only supported on Linux")
+}
+
+// Inherited returns nil, upgrades never start a binary elsewhere
+func Inherited() (*State, error) {
+	return nil, nil
+}
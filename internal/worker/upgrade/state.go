@@ -0,0 +1,86 @@
+package upgrade
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"worker/internal/worker/domain"
+)
+
+// stateEnv names the descriptor the state is read from by the new binary
+const stateEnv = "WORKER_UPGRADE_STATE"
+
+// State is what a worker hands the binary replacing it: its listening socket
+// and its jobs, with the processes of those still running. Descriptors are
+// inherited across the exec, so they keep their numbers.
+type State struct {
+	Version  string // version of the worker that handed off
+	Listener int    // descriptor of the gRPC listening socket, -1 for none
+	Jobs     []Job
+}
+
+// Job is a job of the store as it was handed off
+type Job struct {
+	Job     *domain.Job
+	Output  []byte   // buffered output
+	Process *Process // nil unless the job is running
+}
+
+// Process is a running job process the new binary keeps supervising. It is
+// a child of the worker before and after the exec, so the pidfd both waits
+// for it and pins its pid until it is reaped.
+type Process struct {
+	Pid     int
+	Pidfd   int
+	Stdout  int               // read end of the process's stdout pipe, -1 if it is closed
+	Stderr  int               // read end of the process's stderr pipe, -1 if it is closed
+	Sockets []int             // listening sockets of the job, in the order of its spec
+	Secrets map[string]string // resolved secret values for restarts, only ever held in memory
+}
+
+// upgraded is set once this process took over from the binary it replaced
+var upgraded atomic.Bool
+
+// Upgraded reports whether this process took over from the worker binary it
+// replaced, so the job workspaces on disk may belong to running jobs
+func Upgraded() bool {
+	return upgraded.Load()
+}
+
+// descriptors lists every descriptor the state hands over
+func (s *State) descriptors() []int {
+	var fds []int
+	if s.Listener >= 0 {
+		fds = append(fds, s.Listener)
+	}
+	for _, job := range s.Jobs {
+		p := job.Process
+		if p == nil {
+			continue
+		}
+		fds = append(fds, p.Pidfd)
+		for _, fd := range []int{p.Stdout, p.Stderr} {
+			if fd >= 0 {
+				fds = append(fds, fd)
+			}
+		}
+		fds = append(fds, p.Sockets...)
+	}
+	return fds
+}
+
+func encode(s *State) ([]byte, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upgrade state: %w", err)
+	}
+	return data, nil
+}
+
+func decode(data []byte) (*State, error) {
+	s := &State{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, fmt.Errorf("failed to decode upgrade state: %w", err)
+	}
+	return s, nil
+}
@@ -0,0 +1,309 @@
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/metrics"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+var ErrDisabled = errors.New("usage accounting is disabled")
+
+// recordsFile holds one JSON record per finished job, in the order they ended
+const recordsFile = "usage.jsonl"
+
+// entry is the stored layout of a usage record
+type entry struct {
+	JobID             string     `json:"jobId"`
+	Tenant            string     `json:"tenant,omitempty"`
+	Command           string     `json:"command"`
+	Status            string     `json:"status"`
+	StartTime         time.Time  `json:"startTime"`
+	EndTime           *time.Time `json:"endTime"`
+	CPUSeconds        float64    `json:"cpuSeconds"`
+	MemoryByteSeconds float64    `json:"memoryByteSeconds"`
+	IOReadBytes       int64      `json:"ioReadBytes"`
+	IOWriteBytes      int64      `json:"ioWriteBytes"`
+}
+
+// tracked is a running job and its latest cgroup reading
+type tracked struct {
+	record  domain.UsageRecord
+	cgroup  string
+	memory  int64     // memory usage at the latest reading
+	sampled time.Time // time of the latest reading, the start time before the first
+}
+
+// Accountant records what each job consumes, from its cgroup: CPU time and
+// IO bytes as the cgroup counted them when the job ended, and memory usage
+// integrated over samples taken every interval. Records of finished jobs are
+// appended to a file, so they outlive the worker and the job store.
+type Accountant struct {
+	path        string
+	tenantLabel string
+	interval    time.Duration
+	read        func(cgroupPath string) (*domain.JobUsage, error)
+	now         func() time.Time
+	logger      *logger.Logger
+
+	mutex   sync.Mutex
+	running map[string]*tracked
+	file    *os.File
+	written int64 // size of the file, reports read no further
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// New opens the usage records and starts sampling. It returns nil when usage
+// accounting is disabled.
+func New(cfg config.UsageConfig) (*Accountant, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	if err := os.MkdirAll(cfg.Dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create usage directory: %w", err)
+	}
+	path := filepath.Join(cfg.Dir, recordsFile)
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open usage records: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("failed to open usage records: %w", err)
+	}
+
+	a := &Accountant{
+		path:        path,
+		tenantLabel: cfg.TenantLabel,
+		interval:    cfg.Interval,
+		read:        metrics.ReadCgroup,
+		now:         time.Now,
+		logger:      logger.WithField("component", "usage"),
+		running:     make(map[string]*tracked),
+		file:        file,
+		written:     info.Size(),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+	go a.run()
+
+	a.logger.Info("usage accounting enabled", "records", path, "tenantLabel", cfg.TenantLabel)
+	return a, nil
+}
+
+// OnStatusChange is the store hook tracking jobs as they start and end. The
+// final reading is taken when the store applies the job's final status,
+// before the worker removes its cgroup.
+func (a *Accountant) OnStatusChange(t domain.StatusTransition) {
+	a.observe(t.Job)
+}
+
+// Close stops sampling and closes the records. Jobs still running are not
+// recorded.
+func (a *Accountant) Close() {
+	if a == nil {
+		return
+	}
+
+	close(a.stop)
+	<-a.done
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if err := a.file.Close(); err != nil {
+		a.logger.Warn("failed to close usage records", "error", err)
+	}
+	a.file = nil
+}
+
+func (a *Accountant) run() {
+	defer close(a.done)
+
+	ticker := time.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			a.sampleAll()
+		case <-a.stop:
+			return
+		}
+	}
+}
+
+func (a *Accountant) sampleAll() {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	now := a.now()
+	for _, t := range a.running {
+		a.sample(t, now)
+	}
+}
+
+func (a *Accountant) observe(job *domain.Job) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	t, exists := a.running[job.Id]
+	if !exists {
+		t = &tracked{
+			record: domain.UsageRecord{
+				JobID:     job.Id,
+				Tenant:    job.Labels[a.tenantLabel],
+				Command:   job.Command,
+				StartTime: job.StartTime,
+			},
+			sampled: job.StartTime,
+		}
+		a.running[job.Id] = t
+	}
+	t.cgroup = job.CgroupPath
+	t.record.Status = job.Status
+
+	if !job.IsCompleted() {
+		return
+	}
+
+	now := a.now()
+	a.sample(t, now)
+	end := now
+	if job.EndTime != nil {
+		end = *job.EndTime
+	}
+	t.record.EndTime = &end
+	delete(a.running, job.Id)
+
+	if err := a.write(&t.record); err != nil {
+		a.logger.Error("failed to record job usage", "jobId", job.Id, "error", err)
+	}
+}
+
+// sample reads the job's cgroup. Memory is integrated with the trapezoidal
+// rule between readings. A cgroup that cannot be read keeps the last reading.
+func (a *Accountant) sample(t *tracked, now time.Time) {
+	if t.cgroup == "" {
+		return
+	}
+
+	usage, err := a.read(t.cgroup)
+	if err != nil {
+		return
+	}
+
+	if elapsed := now.Sub(t.sampled); elapsed > 0 {
+		t.record.MemoryByteSeconds += float64(t.memory+usage.MemoryBytes) / 2 * elapsed.Seconds()
+	}
+	t.memory = usage.MemoryBytes
+	t.sampled = now
+	t.record.CPUTime = usage.CPUTime
+	t.record.IOReadBytes = usage.IOReadBytes
+	t.record.IOWriteBytes = usage.IOWriteBytes
+}
+
+func (a *Accountant) write(r *domain.UsageRecord) error {
+	if a.file == nil {
+		return fmt.Errorf("usage records are closed")
+	}
+
+	data, err := json.Marshal(entry{
+		JobID:             r.JobID,
+		Tenant:            r.Tenant,
+		Command:           r.Command,
+		Status:            string(r.Status),
+		StartTime:         r.StartTime,
+		EndTime:           r.EndTime,
+		CPUSeconds:        r.CPUTime.Seconds(),
+		MemoryByteSeconds: r.MemoryByteSeconds,
+		IOReadBytes:       r.IOReadBytes,
+		IOWriteBytes:      r.IOWriteBytes,
+	})
+	if err != nil {
+		return err
+	}
+
+	n, err := a.file.Write(append(data, '\n'))
+	a.written += int64(n)
+	return err
+}
+
+// Report returns the records of the jobs of tenant ("" for every tenant) that
+// ended in [from, to), and those still running when to is after now. A zero
+// from or to leaves that side open. Records are ordered by start time.
+func (a *Accountant) Report(tenant string, from, to time.Time) ([]*domain.UsageRecord, error) {
+	if a == nil {
+		return nil, ErrDisabled
+	}
+
+	a.mutex.Lock()
+	size := a.written
+	now := a.now()
+	var records []*domain.UsageRecord
+	if to.IsZero() || to.After(now) {
+		for _, t := range a.running {
+			if tenant == "" || t.record.Tenant == tenant {
+				r := t.record
+				records = append(records, &r)
+			}
+		}
+	}
+	a.mutex.Unlock()
+
+	// Only the records complete when the report started are read, the file
+	// is appended to meanwhile
+	file, err := os.Open(a.path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open usage records: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(io.LimitReader(file, size))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		var e entry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			// a write cut short by a full disk leaves a broken line
+			a.logger.Warn("skipping malformed usage record", "line", line, "error", err)
+			continue
+		}
+		if tenant != "" && e.Tenant != tenant || e.EndTime == nil {
+			continue
+		}
+		if !from.IsZero() && e.EndTime.Before(from) || !to.IsZero() && !e.EndTime.Before(to) {
+			continue
+		}
+		records = append(records, &domain.UsageRecord{
+			JobID:             e.JobID,
+			Tenant:            e.Tenant,
+			Command:           e.Command,
+			Status:            domain.JobStatus(e.Status),
+			StartTime:         e.StartTime,
+			EndTime:           e.EndTime,
+			CPUTime:           time.Duration(e.CPUSeconds * float64(time.Second)),
+			MemoryByteSeconds: e.MemoryByteSeconds,
+			IOReadBytes:       e.IOReadBytes,
+			IOWriteBytes:      e.IOWriteBytes,
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read usage records: %w", err)
+	}
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].StartTime.Before(records[j].StartTime) })
+	return records, nil
+}
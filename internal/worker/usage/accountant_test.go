@@ -0,0 +1,109 @@
+package usage
+
+import (
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/config"
+)
+
+func newAccountant(t *testing.T) (*Accountant, *time.Time, map[string]*domain.JobUsage) {
+	t.Helper()
+
+	a, err := New(config.UsageConfig{Enabled: true, Dir: t.TempDir(), TenantLabel: "tenant", Interval: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(a.Close)
+
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	readings := make(map[string]*domain.JobUsage)
+	a.now = func() time.Time { return now }
+	a.read = func(cgroupPath string) (*domain.JobUsage, error) {
+		u := *readings[cgroupPath]
+		return &u, nil
+	}
+	return a, &now, readings
+}
+
+func TestAccountantRecordsFinishedJobs(t *testing.T) {
+	a, now, readings := newAccountant(t)
+
+	job := &domain.Job{Id: "1", Command: "stress", Status: domain.StatusRunning, StartTime: *now, CgroupPath: "/cg/1", Labels: map[string]string{"tenant": "web"}}
+	a.OnStatusChange(domain.NewStatusTransition("", job.DeepCopy(), *now))
+
+	// 1 GiB for 10s, then 2 GiB for 10s
+	*now = now.Add(10 * time.Second)
+	readings["/cg/1"] = &domain.JobUsage{CPUTime: 5 * time.Second, MemoryBytes: 1 << 30}
+	a.sampleAll()
+
+	running, err := a.Report("", time.Time{}, time.Time{})
+	if err != nil || len(running) != 1 || running[0].EndTime != nil || running[0].CPUTime != 5*time.Second {
+		t.Fatalf("expected the running job with its usage so far, got %v %v", running, err)
+	}
+
+	*now = now.Add(10 * time.Second)
+	readings["/cg/1"] = &domain.JobUsage{CPUTime: 8 * time.Second, MemoryBytes: 1 << 30, IOWriteBytes: 4096}
+	job.Status = domain.StatusCompleted
+	end := *now
+	job.EndTime = &end
+	a.OnStatusChange(domain.NewStatusTransition(domain.StatusRunning, job.DeepCopy(), *now))
+
+	records, err := a.Report("web", time.Time{}, time.Time{})
+	if err != nil || len(records) != 1 {
+		t.Fatalf("expected the finished job, got %v %v", records, err)
+	}
+	r := records[0]
+	if r.Status != domain.StatusCompleted || r.CPUTime != 8*time.Second || r.IOWriteBytes != 4096 || r.WallTime(*now) != 20*time.Second {
+		t.Errorf("unexpected record %+v", r)
+	}
+	// trapezoids from 0 to 1 GiB and at 1 GiB, 10s each
+	if want := float64(15 << 30); r.MemoryByteSeconds != want {
+		t.Errorf("expected %v memory byte-seconds, got %v", want, r.MemoryByteSeconds)
+	}
+
+	if records, _ := a.Report("ci", time.Time{}, time.Time{}); len(records) != 0 {
+		t.Errorf("expected no records of another tenant, got %v", records)
+	}
+	if records, _ := a.Report("", end.Add(time.Second), time.Time{}); len(records) != 0 {
+		t.Errorf("expected no records ending before the range, got %v", records)
+	}
+	if records, _ := a.Report("", end, end.Add(time.Second)); len(records) != 1 {
+		t.Errorf("expected the record ending in the range, got %v", records)
+	}
+}
+
+func TestAccountantKeepsRecordsAcrossRestarts(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.UsageConfig{Enabled: true, Dir: dir, TenantLabel: "tenant", Interval: time.Hour}
+
+	a, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	end := time.Now()
+	a.OnStatusChange(domain.NewStatusTransition(domain.StatusRunning, &domain.Job{Id: "1", Status: domain.StatusFailed, StartTime: end.Add(-time.Minute), EndTime: &end}, end))
+	a.Close()
+
+	a, err = New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer a.Close()
+
+	records, err := a.Report("", time.Time{}, time.Time{})
+	if err != nil || len(records) != 1 || records[0].JobID != "1" || records[0].Status != domain.StatusFailed {
+		t.Errorf("expected the record to be kept, got %v %v", records, err)
+	}
+}
+
+func TestDisabledAccountant(t *testing.T) {
+	a, err := New(config.UsageConfig{})
+	if err != nil || a != nil {
+		t.Fatalf("expected no accountant when disabled, got %v %v", a, err)
+	}
+	if _, err := a.Report("", time.Time{}, time.Time{}); err != ErrDisabled {
+		t.Errorf("expected ErrDisabled, got %v", err)
+	}
+	a.Close()
+}
@@ -0,0 +1,48 @@
+package utils
+
+import "testing"
+
+func TestParseEnvFile(t *testing.T) {
+	data := []byte(`
+# comment line
+APP_ENV=prod
+export LOG_LEVEL=debug
+QUOTED="hello world"
+SINGLE='a=b'
+EMPTY=
+`)
+
+	env, err := ParseEnvFile(data)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	expected := map[string]string{
+		"APP_ENV":   "prod",
+		"LOG_LEVEL": "debug",
+		"QUOTED":    "hello world",
+		"SINGLE":    "a=b",
+		"EMPTY":     "",
+	}
+	if len(env) != len(expected) {
+		t.Fatalf("Expected %d variables, got %d: %v", len(expected), len(env), env)
+	}
+	for k, v := range expected {
+		if env[k] != v {
+			t.Errorf("Expected %s=%q, got %q", k, v, env[k])
+		}
+	}
+}
+
+func TestParseEnvFile_Invalid(t *testing.T) {
+	tests := []string{
+		"MISSING_SEPARATOR",
+		"=value",
+	}
+
+	for _, input := range tests {
+		if _, err := ParseEnvFile([]byte(input)); err == nil {
+			t.Errorf("Expected error for %q", input)
+		}
+	}
+}
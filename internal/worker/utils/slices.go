@@ -14,3 +14,16 @@ func CopyStringSlice(src []string) []string {
 
 	return dst
 }
+
+func CopyStringMap(src map[string]string) map[string]string {
+	if src == nil {
+		return nil
+	}
+
+	dst := make(map[string]string, len(src))
+	for k, v := range src {
+		dst[k] = v
+	}
+
+	return dst
+}
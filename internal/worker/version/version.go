@@ -0,0 +1,42 @@
+// Package version reports the version of the worker binary
+package version
+
+import "runtime/debug"
+
+// Version is set when building a release:
+//
+//	go build -ldflags "-X worker/internal/worker/version.Version=v1.4.0" ./cmd/worker
+var Version = ""
+
+// String returns Version, or for development builds the VCS revision the
+// binary was built from, with a -dirty suffix for uncommitted changes
+func String() string {
+	if Version != "" {
+		return Version
+	}
+
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+
+	var revision, modified string
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value
+		}
+	}
+	if revision == "" {
+		return "devel"
+	}
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	if modified == "true" {
+		revision += "-dirty"
+	}
+	return revision
+}
@@ -0,0 +1,83 @@
+package watchdog
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"worker/pkg/logger"
+)
+
+// ErrDeadlineExceeded is what an operation failed by the watchdog wraps
+var ErrDeadlineExceeded = errors.New("watchdog deadline exceeded")
+
+// Kind names what an operation does
+type Kind string
+
+const (
+	Launch  Kind = "launch"  // starting a job's process, from namespace setup to joining its cgroup
+	Cleanup Kind = "cleanup" // removing a job's cgroup
+)
+
+// Operation is one tracked launch or cleanup. Exactly one of Finish and the
+// expiry callback takes effect.
+type Operation struct {
+	Kind    Kind
+	JobID   string
+	Started time.Time
+
+	timer   *time.Timer
+	settled sync.Once
+}
+
+// Watchdog fails launches and cleanups that run past a deadline, so a hung
+// setns or cgroup write cannot leave a job initializing or waiting for its
+// cleanup forever. The stuck goroutine itself cannot be interrupted; its
+// caller learns from Finish that the operation was already given up.
+type Watchdog struct {
+	deadline time.Duration
+	logger   *logger.Logger
+}
+
+// New creates a watchdog failing operations that take longer than deadline.
+// It returns nil when deadline is 0, and a nil watchdog never fails anything.
+func New(deadline time.Duration) *Watchdog {
+	if deadline <= 0 {
+		return nil
+	}
+	return &Watchdog{
+		deadline: deadline,
+		logger:   logger.WithField("component", "watchdog"),
+	}
+}
+
+// Begin starts tracking an operation. When it is still running at the
+// deadline, expire is called with an error wrapping ErrDeadlineExceeded.
+func (w *Watchdog) Begin(kind Kind, jobID string, expire func(error)) *Operation {
+	op := &Operation{Kind: kind, JobID: jobID, Started: time.Now()}
+	if w == nil {
+		return op
+	}
+
+	op.timer = time.AfterFunc(w.deadline, func() {
+		op.settled.Do(func() {
+			err := fmt.Errorf("%w: %s took longer than %v", ErrDeadlineExceeded, kind, w.deadline)
+			w.logger.Error("operation stuck, failing it", "operation", string(kind), "jobId", jobID, "deadline", w.deadline)
+			expire(err)
+		})
+	})
+	return op
+}
+
+// Finish ends the operation. It returns false when the watchdog already
+// failed it, and the caller must not act on the result anymore.
+func (op *Operation) Finish() bool {
+	finished := false
+	op.settled.Do(func() {
+		finished = true
+		if op.timer != nil {
+			op.timer.Stop()
+		}
+	})
+	return finished
+}
@@ -0,0 +1,55 @@
+package watchdog
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestOperationFinishedInTime(t *testing.T) {
+	w := New(50 * time.Millisecond)
+	expired := make(chan error, 1)
+
+	op := w.Begin(Launch, "1", func(err error) { expired <- err })
+	if !op.Finish() {
+		t.Fatal("expected an operation finished before the deadline to count")
+	}
+
+	select {
+	case err := <-expired:
+		t.Fatalf("expected no expiry after finishing, got %v", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestOperationPastDeadline(t *testing.T) {
+	w := New(10 * time.Millisecond)
+	expired := make(chan error, 1)
+
+	op := w.Begin(Cleanup, "2", func(err error) { expired <- err })
+
+	select {
+	case err := <-expired:
+		if !errors.Is(err, ErrDeadlineExceeded) {
+			t.Errorf("expected ErrDeadlineExceeded, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected the operation to expire")
+	}
+
+	if op.Finish() {
+		t.Error("expected Finish to report the operation was already failed")
+	}
+}
+
+func TestDisabledWatchdog(t *testing.T) {
+	w := New(0)
+	if w != nil {
+		t.Fatal("expected no watchdog without a deadline")
+	}
+
+	op := w.Begin(Launch, "3", func(error) { t.Error("a disabled watchdog must not expire operations") })
+	if !op.Finish() {
+		t.Error("expected operations to always finish without a watchdog")
+	}
+}
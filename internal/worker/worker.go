@@ -0,0 +1,286 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+	"worker/internal/worker/cloudevents"
+	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/core/linux/resource"
+	"worker/internal/worker/events"
+	"worker/internal/worker/janitor"
+	"worker/internal/worker/redact"
+	"worker/internal/worker/schedule"
+	"worker/internal/worker/secrets"
+	"worker/internal/worker/server"
+	"worker/internal/worker/signing"
+	"worker/internal/worker/state"
+	"worker/internal/worker/tracing"
+	"worker/internal/worker/upgrade"
+	"worker/internal/worker/usage"
+	"worker/internal/worker/workspace"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+
+	"google.golang.org/grpc"
+)
+
+// tracingFlushTimeout bounds how long exporting the last spans may delay Close
+const tracingFlushTimeout = 2 * time.Second
+
+// JobWorker is the worker as a library: the job store, the event bus it is
+// kept up to date from, the platform worker running the jobs and the job
+// service applying the checks of the API, with the integrations the config
+// enables. Programs embedding it start jobs through Service, or serve it over
+// gRPC with Serve, as the worker daemon does.
+type JobWorker struct {
+	Store   state.Store
+	Events  *events.Bus
+	Worker  interfaces.Worker
+	Service *server.JobServiceServer
+
+	config          *config.Config
+	emitter         *cloudevents.Emitter
+	usage           *usage.Accountant
+	janitor         *janitor.Janitor
+	compactor       *state.Compactor
+	grpcServer      *grpc.Server
+	listener        net.Listener // of grpcServer, handed to the new binary by an upgrade
+	inherited       net.Listener // handed over by the binary this one replaced, until served
+	shutdownTracing func(context.Context) error
+	logger          *logger.Logger
+}
+
+// closingWorker is a platform worker holding integrations to close, such as
+// its log shipping
+type closingWorker interface {
+	Close()
+}
+
+// Option customizes what New creates
+type Option func(*options)
+
+type options struct {
+	config    *config.Config
+	store     state.Store
+	resource  resource.Resource
+	newWorker func(state.Store, *events.Bus) interfaces.Worker
+	logging   *logger.Options
+	handoff   *upgrade.State
+}
+
+// WithConfig sets the config, which defaults to config.DefaultConfig
+func WithConfig(cfg *config.Config) Option {
+	return func(o *options) { o.config = cfg }
+}
+
+// WithStore sets the job store, which New keeps up to date from the events.
+// By default the store keeps jobs' output within the budget of the config.
+func WithStore(store state.Store) Option {
+	return func(o *options) { o.store = store }
+}
+
+// WithResource sets how the Linux worker creates, limits and removes job
+// cgroups, by default in the cgroup v2 tree of the config
+func WithResource(r resource.Resource) Option {
+	return func(o *options) { o.resource = r }
+}
+
+// WithWorker replaces the platform worker running the jobs, for instance by
+// the in-memory one of workertest. It is created with the store and the bus it
+// publishes the job events on.
+func WithWorker(newWorker func(state.Store, *events.Bus) interfaces.Worker) Option {
+	return func(o *options) { o.newWorker = newWorker }
+}
+
+// WithLogger sets where and how the worker logs. Logging is process-wide, so
+// this configures every logger of the program.
+func WithLogger(opts logger.Options) Option {
+	return func(o *options) { o.logging = &opts }
+}
+
+// WithHandoff takes over the jobs and the listener the worker binary this one
+// replaced handed off in an upgrade, as returned by upgrade.Inherited. A nil
+// state is ignored.
+func WithHandoff(s *upgrade.State) Option {
+	return func(o *options) { o.handoff = s }
+}
+
+// New creates a worker. Integrations that fail to set up are logged and left
+// out, as in the daemon; a config, store or worker that cannot be created
+// fails it, as do failed preflight checks of the kernel in strict mode.
+func New(opts ...Option) (*JobWorker, error) {
+	o := &options{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.config == nil {
+		defaults := config.DefaultConfig
+		o.config = &defaults
+	}
+	cfg := o.config
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if o.logging != nil {
+		logger.Configure(*o.logging)
+	}
+
+	jw := &JobWorker{
+		config: cfg,
+		logger: logger.WithField("component", "worker"),
+	}
+
+	// Export traces of job launches and cleanups, if enabled
+	var err error
+	jw.shutdownTracing, err = tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %w", err)
+	}
+
+	// Create state store, kept up to date by the job events the worker publishes
+	// and keeping their buffered output within the budget
+	jw.Events = events.NewBus()
+	jw.Store = o.store
+	if jw.Store == nil {
+		jw.Store, err = state.NewBounded(cfg.OutputBuffer, jw.Events)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create state store: %w", err)
+		}
+	}
+	jw.Events.Handle(state.ApplyEvents(jw.Store))
+
+	// Compress and drop the output of jobs that finished long ago, if enabled
+	jw.compactor = state.NewCompactor(cfg.OutputBuffer, jw.Store)
+
+	// Publish job events as CloudEvents, if enabled
+	jw.emitter, err = cloudevents.New(cfg.CloudEvents)
+	if err != nil {
+		jw.logger.Error("cloudevents setup failed, continuing without it", "error", err)
+	}
+	if jw.emitter != nil {
+		jw.Events.Handle(jw.emitter.Handler())
+	}
+
+	// Record the resources each job consumes, if enabled
+	jw.usage, err = usage.New(cfg.Usage)
+	if err != nil {
+		jw.logger.Error("usage accounting setup failed, continuing without it", "error", err)
+	}
+	if jw.usage != nil {
+		jw.Store.OnStatusChange(jw.usage.OnStatusChange)
+	}
+
+	// Keep what jobs leave on disk within the budget, if enabled
+	jw.janitor = janitor.New(cfg.Janitor, cfg.Logging.File(), workspace.NewManager(cfg.Workspace), jw.Store)
+
+	// Create the secret redactor shared by the job output path and the API
+	redactor, err := redact.New(cfg.Redaction)
+	if err != nil {
+		jw.Close()
+		return nil, fmt.Errorf("failed to create redactor: %w", err)
+	}
+
+	// Open the encrypted secrets store; jobs that reference secrets are rejected without it
+	secretStore, err := secrets.New(cfg.Secrets)
+	if err != nil {
+		jw.logger.Error("secrets store setup failed, continuing without secrets", "error", err)
+	}
+
+	// Create the worker running the jobs
+	if o.newWorker != nil {
+		jw.Worker = o.newWorker(jw.Store, jw.Events)
+	} else if jw.Worker, err = NewWorker(jw.Store, jw.Events, redactor, o.resource, cfg); err != nil {
+		jw.Close()
+		return nil, err
+	}
+	if jw.Worker == nil {
+		jw.Close()
+		return nil, fmt.Errorf("failed to create worker for current platform")
+	}
+
+	// Hold new jobs during blackouts and outside their allowed windows
+	calendar, err := schedule.New(cfg.Schedule)
+	if err != nil {
+		jw.Close()
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	// Verify the signatures of job specs, and require them if configured
+	verifier, err := signing.New(cfg.Security.SpecSigning)
+	if err != nil {
+		jw.Close()
+		return nil, fmt.Errorf("invalid spec signing keys: %w", err)
+	}
+
+	jw.Service = server.NewJobService(jw.Store, jw.Events, jw.Worker, redactor, secretStore, jw.usage, jw.janitor, calendar, verifier, cfg)
+	if o.handoff != nil {
+		jw.takeOver(o.handoff)
+	}
+	return jw, nil
+}
+
+// Serve serves the job service over gRPC at the address of the config, or on
+// the listener handed over in an upgrade, with its TLS and the intake,
+// reconcile and fleet integrations it enables. It returns once the server
+// listens; Close stops it.
+func (jw *JobWorker) Serve() error {
+	if jw.grpcServer != nil {
+		return fmt.Errorf("worker is already serving")
+	}
+
+	lis := jw.inherited
+	jw.inherited = nil
+	if lis == nil {
+		var err error
+		if lis, err = server.Listen(jw.config); err != nil {
+			return fmt.Errorf("failed to start gRPC server: %w", err)
+		}
+	}
+
+	grpcServer, err := server.StartGRPCServer(jw.Service, jw.config, lis)
+	if err != nil {
+		_ = lis.Close()
+		return fmt.Errorf("failed to start gRPC server: %w", err)
+	}
+	jw.grpcServer = grpcServer
+	jw.listener = lis
+	return nil
+}
+
+// Close stops serving, waiting for the calls in progress, sends the queued
+// events and job output, closes the usage records, stops the janitor and the
+// store compactor and flushes the traces.
+// Jobs still running are left to run.
+func (jw *JobWorker) Close() {
+	if jw.grpcServer != nil {
+		jw.grpcServer.GracefulStop()
+		jw.grpcServer = nil
+		jw.listener = nil
+	}
+	if jw.inherited != nil {
+		_ = jw.inherited.Close()
+		jw.inherited = nil
+	}
+
+	if jw.emitter != nil {
+		if err := jw.emitter.Close(); err != nil {
+			jw.logger.Warn("failed to close cloudevents transport", "error", err)
+		}
+	}
+
+	if closer, ok := jw.Worker.(closingWorker); ok {
+		closer.Close()
+	}
+
+	jw.usage.Close()
+	jw.janitor.Close()
+	jw.compactor.Close()
+
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), tracingFlushTimeout)
+	defer flushCancel()
+	if err := jw.shutdownTracing(flushCtx); err != nil {
+		jw.logger.Warn("failed to flush traces", "error", err)
+	}
+}
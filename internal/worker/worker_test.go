@@ -0,0 +1,128 @@
+package worker_test
+
+import (
+	"context"
+	"testing"
+	"time"
+	pb "worker/api/gen"
+	"worker/internal/worker"
+	"worker/internal/worker/auth"
+	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/state"
+	"worker/internal/worker/upgrade"
+	"worker/pkg/config"
+	"worker/pkg/workertest"
+)
+
+func TestNew_Embedded(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.OutputBuffer.SpillDir = t.TempDir()
+
+	jobWorker, err := worker.New(
+		worker.WithConfig(&cfg),
+		worker.WithStore(state.New()),
+		worker.WithWorker(func(store state.Store, bus *events.Bus) interfaces.Worker {
+			w := workertest.NewWorker(store, bus)
+			w.Script("echo", workertest.Result{Output: []byte("hi\n")})
+			return w
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer jobWorker.Close()
+
+	ctx, cancel := context.WithTimeout(auth.WithRole(context.Background(), auth.AdminRole), 5*time.Second)
+	defer cancel()
+
+	res, err := jobWorker.Service.RunJob(ctx, &pb.RunJobReq{Command: "echo", Args: []string{"hi"}})
+	if err != nil {
+		t.Fatalf("RunJob() error = %v", err)
+	}
+
+	job, err := jobWorker.Store.WaitForCompletion(ctx, res.Id)
+	if err != nil {
+		t.Fatalf("WaitForCompletion() error = %v", err)
+	}
+	if job.Status != domain.StatusCompleted {
+		t.Errorf("job ended %s, want COMPLETED", job.Status)
+	}
+	if output, _, _ := jobWorker.Store.GetOutput(res.Id); string(output) != "hi\n" {
+		t.Errorf("job output = %q", output)
+	}
+}
+
+func TestNew_InvalidConfig(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.OutputBuffer.MaxBytes = -1
+
+	if _, err := worker.New(worker.WithConfig(&cfg)); err == nil {
+		t.Error("New() of an invalid config succeeded")
+	}
+}
+
+func TestNew_Handoff(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.OutputBuffer.SpillDir = t.TempDir()
+
+	handoff := &upgrade.State{
+		Version:  "v1.0.0",
+		Listener: -1,
+		Jobs: []upgrade.Job{
+			{Job: &domain.Job{Id: "7", Command: "echo", Status: domain.StatusCompleted}, Output: []byte("handed over\n")},
+		},
+	}
+	jobWorker, err := worker.New(
+		worker.WithConfig(&cfg),
+		worker.WithStore(state.New()),
+		worker.WithWorker(func(store state.Store, bus *events.Bus) interfaces.Worker {
+			return workertest.NewWorker(store, bus)
+		}),
+		worker.WithHandoff(handoff),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	defer jobWorker.Close()
+
+	job, ok := jobWorker.Store.GetJob("7")
+	if !ok || job.Status != domain.StatusCompleted {
+		t.Fatalf("handed over job = %+v, %v", job, ok)
+	}
+	if output, _, _ := jobWorker.Store.GetOutput("7"); string(output) != "handed over\n" {
+		t.Errorf("handed over output = %q", output)
+	}
+}
+
+// closingWorker records being closed, like the Linux worker closing its log shipping
+type closingWorker struct {
+	*workertest.Worker
+	closed bool
+}
+
+func (w *closingWorker) Close() { w.closed = true }
+
+func TestClose_ClosesWorker(t *testing.T) {
+	cfg := config.DefaultConfig
+	cfg.OutputBuffer.SpillDir = t.TempDir()
+
+	platform := &closingWorker{}
+	jobWorker, err := worker.New(
+		worker.WithConfig(&cfg),
+		worker.WithStore(state.New()),
+		worker.WithWorker(func(store state.Store, bus *events.Bus) interfaces.Worker {
+			platform.Worker = workertest.NewWorker(store, bus)
+			return platform
+		}),
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	jobWorker.Close()
+	if !platform.closed {
+		t.Error("Close() left the platform worker open")
+	}
+}
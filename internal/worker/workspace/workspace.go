@@ -0,0 +1,474 @@
+package workspace
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+const (
+	uploadsDir = "uploads"
+	jobsDir    = "jobs"
+)
+
+var (
+	ErrUploadNotFound = errors.New("upload not found")
+	ErrUploadTooLarge = errors.New("upload exceeds size limit")
+	ErrTooManyFiles   = errors.New("upload exceeds file count limit")
+	ErrInvalidPath    = errors.New("invalid file path")
+)
+
+const copyChunkSize = 256 * 1024
+
+var uploadIDPattern = regexp.MustCompile(`^[0-9a-f]{32}$`)
+
+// Manager owns the on-disk layout of staged uploads and job workspaces:
+//
+//	<baseDir>/uploads/<uploadId>/...  files staged before a job starts
+//	<baseDir>/jobs/job-<jobId>/...    the job's working directory
+//	<baseDir>/jobs/job-<jobId>.stdout captured stdout, kept outside the job's reach
+type Manager struct {
+	baseDir        string
+	maxUploadSize  int64
+	maxUploadFiles int
+	logger         *logger.Logger
+}
+
+// NewManager creates a workspace manager for the configured base directory
+func NewManager(cfg config.WorkspaceConfig) *Manager {
+	return &Manager{
+		baseDir:        cfg.BaseDir,
+		maxUploadSize:  cfg.MaxUploadSize,
+		maxUploadFiles: cfg.MaxUploadFiles,
+		logger:         logger.WithField("component", "workspace"),
+	}
+}
+
+// JobDir returns the workspace path for a job
+func (m *Manager) JobDir(jobID string) string {
+	return filepath.Join(m.baseDir, jobsDir, "job-"+jobID)
+}
+
+// StdoutPath returns where a job's stdout is captured when requested
+func (m *Manager) StdoutPath(jobID string) string {
+	return m.JobDir(jobID) + ".stdout"
+}
+
+// Create makes an empty workspace for a job that has no staged upload
+func (m *Manager) Create(jobID string) (string, error) {
+	dir := m.JobDir(jobID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create workspace: %w", err)
+	}
+	return dir, nil
+}
+
+// Remove deletes a job's workspace. Removing a missing workspace is not an error.
+func (m *Manager) Remove(jobID string) error {
+	if err := os.RemoveAll(m.JobDir(jobID)); err != nil {
+		return fmt.Errorf("failed to remove workspace: %w", err)
+	}
+	if err := os.Remove(m.StdoutPath(jobID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove captured stdout: %w", err)
+	}
+	m.logger.Debug("workspace removed", "jobId", jobID)
+	return nil
+}
+
+// OpenArtifact opens a regular file in a job's workspace for reading. path is
+// relative to the workspace, and neither it nor a symlink on the way may
+// lead out of it.
+func (m *Manager) OpenArtifact(jobID, path string) (*os.File, error) {
+	if path == "" || !filepath.IsLocal(path) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	root, err := os.OpenRoot(m.JobDir(jobID))
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	f, err := root.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err == nil && !info.Mode().IsRegular() {
+		err = fmt.Errorf("%w: %q is not a regular file", ErrInvalidPath, path)
+	}
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// ReadResult returns the result file a job left in its workspace dir, nil if it
+// left none. A symlink or a file other than a regular one is not followed, and
+// at most limit+1 bytes are read, so a caller can tell a result over the limit.
+func ReadResult(dir, name string, limit int64) ([]byte, error) {
+	root, err := os.OpenRoot(dir)
+	if err != nil {
+		return nil, err
+	}
+	defer root.Close()
+
+	// O_NOFOLLOW: the job could point the result at any file the worker can read;
+	// O_NONBLOCK: opening a FIFO nobody writes to must not hang
+	f, err := root.OpenFile(name, os.O_RDONLY|syscall.O_NOFOLLOW|syscall.O_NONBLOCK, 0)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		return nil, fmt.Errorf("%w: %q is not a regular file", ErrInvalidPath, name)
+	}
+	return io.ReadAll(io.LimitReader(f, limit+1))
+}
+
+// Usage returns the total size in bytes of the regular files under dir
+func Usage(dir string) (int64, error) {
+	var total int64
+	err := filepath.WalkDir(dir, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		total += info.Size()
+		return nil
+	})
+	return total, err
+}
+
+// Artifact is what a job left in the jobs directory: its workspace and its
+// captured stdout
+type Artifact struct {
+	JobID    string
+	Bytes    int64
+	Modified time.Time // last change to any of its files
+}
+
+// Artifacts lists the files of each job in the jobs directory, whether the
+// job is still running or not
+func (m *Manager) Artifacts() ([]Artifact, error) {
+	root := filepath.Join(m.baseDir, jobsDir)
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	byJob := make(map[string]*Artifact)
+	var ids []string
+	for _, entry := range entries {
+		id, ok := strings.CutPrefix(strings.TrimSuffix(entry.Name(), ".stdout"), "job-")
+		if !ok {
+			continue
+		}
+		a, seen := byJob[id]
+		if !seen {
+			a = &Artifact{JobID: id}
+			byJob[id] = a
+			ids = append(ids, id)
+		}
+		// files are removed while we walk, e.g. by the retention timer
+		if err := filepath.WalkDir(filepath.Join(root, entry.Name()), a.add); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("failed to measure workspace of job %s: %w", id, err)
+		}
+	}
+
+	artifacts := make([]Artifact, 0, len(ids))
+	for _, id := range ids {
+		artifacts = append(artifacts, *byJob[id])
+	}
+	return artifacts, nil
+}
+
+func (a *Artifact) add(_ string, d fs.DirEntry, err error) error {
+	if err != nil {
+		return err
+	}
+	info, err := d.Info()
+	if err != nil {
+		return err
+	}
+	if info.Mode().IsRegular() {
+		a.Bytes += info.Size()
+	}
+	if info.ModTime().After(a.Modified) {
+		a.Modified = info.ModTime()
+	}
+	return nil
+}
+
+// UploadsUsage returns the size in bytes of the staged uploads
+func (m *Manager) UploadsUsage() (int64, error) {
+	usage, err := Usage(filepath.Join(m.baseDir, uploadsDir))
+	if errors.Is(err, fs.ErrNotExist) {
+		return usage, nil
+	}
+	return usage, err
+}
+
+// PruneJobs removes job workspaces last modified before maxAge ago. It must only
+// be used when no jobs are running, e.g. for orphans left by a previous run.
+func (m *Manager) PruneJobs(maxAge time.Duration) int {
+	return m.prune(filepath.Join(m.baseDir, jobsDir), maxAge)
+}
+
+// PruneUploads removes staged uploads that were never claimed by a job
+func (m *Manager) PruneUploads(maxAge time.Duration) int {
+	return m.prune(filepath.Join(m.baseDir, uploadsDir), maxAge)
+}
+
+func (m *Manager) prune(root string, maxAge time.Duration) int {
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			m.logger.Warn("failed to list workspaces for pruning", "dir", root, "error", err)
+		}
+		return 0
+	}
+
+	cutoff := time.Now().Add(-maxAge)
+	removed := 0
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(root, entry.Name())); err != nil {
+			m.logger.Warn("failed to prune workspace", "name", entry.Name(), "error", err)
+			continue
+		}
+		removed++
+	}
+
+	if removed > 0 {
+		m.logger.Info("pruned stale workspaces", "dir", root, "removed", removed)
+	}
+	return removed
+}
+
+// NewUpload creates an empty staging directory for files sent ahead of a job
+func (m *Manager) NewUpload() (*Upload, error) {
+	idBytes := make([]byte, 16)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate upload id: %w", err)
+	}
+	id := hex.EncodeToString(idBytes)
+
+	dir := filepath.Join(m.baseDir, uploadsDir, id)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	return &Upload{ID: id, dir: dir, manager: m}, nil
+}
+
+// Claim moves a staged upload into the job's workspace and returns its path.
+// An upload can only be claimed once.
+func (m *Manager) Claim(uploadID, jobID string) (string, error) {
+	if !uploadIDPattern.MatchString(uploadID) {
+		return "", fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+	}
+
+	src := filepath.Join(m.baseDir, uploadsDir, uploadID)
+	dst := m.JobDir(jobID)
+
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return "", fmt.Errorf("failed to create workspace root: %w", err)
+	}
+
+	if err := os.Rename(src, dst); err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %s", ErrUploadNotFound, uploadID)
+		}
+		return "", fmt.Errorf("failed to move upload into workspace: %w", err)
+	}
+
+	m.logger.Debug("upload claimed by job", "uploadId", uploadID, "jobId", jobID, "workspace", dst)
+	return dst, nil
+}
+
+// Upload stages files for a job. Consecutive writes to the same path are
+// appended, so callers can stream a file in chunks.
+type Upload struct {
+	ID string
+
+	dir         string
+	manager     *Manager
+	current     *os.File
+	currentPath string
+	files       int
+	size        int64
+}
+
+// Write appends data to the file at the given workspace-relative path
+func (u *Upload) Write(path string, mode uint32, data []byte) error {
+	if u.current == nil || path != u.currentPath {
+		if err := u.openFile(path, mode); err != nil {
+			return err
+		}
+	}
+
+	u.size += int64(len(data))
+	if u.manager.maxUploadSize > 0 && u.size > u.manager.maxUploadSize {
+		return fmt.Errorf("%w (max %d bytes)", ErrUploadTooLarge, u.manager.maxUploadSize)
+	}
+
+	if _, err := u.current.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// CopyFile adds the host file src to the upload at the given path, keeping its permissions
+func (u *Upload) CopyFile(path, src string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", src, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", src, err)
+	}
+	mode := uint32(info.Mode().Perm())
+
+	// an initial empty write creates the file even when src is empty
+	if err := u.Write(path, mode, nil); err != nil {
+		return err
+	}
+
+	buf := make([]byte, copyChunkSize)
+	for {
+		n, readErr := f.Read(buf)
+		if n > 0 {
+			if err := u.Write(path, mode, buf[:n]); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", src, readErr)
+		}
+	}
+}
+
+// CopyDir adds the regular files under the host directory src to the upload
+// below path. Symlinks and other special files are skipped.
+func (u *Upload) CopyDir(path, src string) error {
+	return filepath.WalkDir(src, func(file string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(src, file)
+		if err != nil {
+			return err
+		}
+		return u.CopyFile(filepath.Join(path, rel), file)
+	})
+}
+
+func (u *Upload) openFile(path string, mode uint32) error {
+	if err := u.closeCurrent(); err != nil {
+		return err
+	}
+
+	if path == "" || !filepath.IsLocal(path) {
+		return fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	u.files++
+	if u.manager.maxUploadFiles > 0 && u.files > u.manager.maxUploadFiles {
+		return fmt.Errorf("%w (max %d files)", ErrTooManyFiles, u.manager.maxUploadFiles)
+	}
+
+	perm := os.FileMode(mode) & os.ModePerm
+	if perm == 0 {
+		perm = 0644
+	}
+
+	target := filepath.Join(u.dir, path)
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+
+	// O_EXCL rejects the same path being sent twice non-consecutively
+	f, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path, err)
+	}
+
+	u.current = f
+	u.currentPath = path
+	return nil
+}
+
+func (u *Upload) closeCurrent() error {
+	if u.current == nil {
+		return nil
+	}
+	err := u.current.Close()
+	u.current = nil
+	return err
+}
+
+// Files returns the number of files written so far
+func (u *Upload) Files() int {
+	return u.files
+}
+
+// Size returns the number of bytes written so far
+func (u *Upload) Size() int64 {
+	return u.size
+}
+
+// Close finishes the upload, leaving it staged until a job claims it
+func (u *Upload) Close() error {
+	return u.closeCurrent()
+}
+
+// Abort discards the upload and everything written to it
+func (u *Upload) Abort() {
+	_ = u.closeCurrent()
+	if err := os.RemoveAll(u.dir); err != nil {
+		u.manager.logger.Warn("failed to remove aborted upload", "uploadId", u.ID, "error", err)
+	}
+}
@@ -0,0 +1,358 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+	"worker/pkg/config"
+)
+
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+	return NewManager(config.WorkspaceConfig{
+		BaseDir:        t.TempDir(),
+		MaxUploadSize:  1024,
+		MaxUploadFiles: 3,
+	})
+}
+
+func TestUploadAndClaim(t *testing.T) {
+	m := newTestManager(t)
+
+	upload, err := m.NewUpload()
+	if err != nil {
+		t.Fatalf("NewUpload failed: %v", err)
+	}
+
+	if err := upload.Write("script.sh", 0755, []byte("#!/bin/sh\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := upload.Write("script.sh", 0755, []byte("echo hi\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := upload.Write("data/input.csv", 0, []byte("a,b\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if err := upload.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+
+	if upload.Files() != 2 {
+		t.Errorf("Expected 2 files, got %d", upload.Files())
+	}
+
+	dir, err := m.Claim(upload.ID, "42")
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+	if dir != m.JobDir("42") {
+		t.Errorf("Expected workspace %s, got %s", m.JobDir("42"), dir)
+	}
+
+	script, err := os.ReadFile(filepath.Join(dir, "script.sh"))
+	if err != nil {
+		t.Fatalf("Failed to read staged script: %v", err)
+	}
+	if string(script) != "#!/bin/sh\necho hi\n" {
+		t.Errorf("Unexpected script content %q", script)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "script.sh"))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected mode 0755, got %v", info.Mode().Perm())
+	}
+
+	if _, err := m.Claim(upload.ID, "43"); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected second claim to fail with ErrUploadNotFound, got %v", err)
+	}
+}
+
+func TestUploadRejectsUnsafePaths(t *testing.T) {
+	m := newTestManager(t)
+
+	for _, path := range []string{"", "../escape", "/etc/passwd", "a/../../b"} {
+		upload, err := m.NewUpload()
+		if err != nil {
+			t.Fatalf("NewUpload failed: %v", err)
+		}
+		if err := upload.Write(path, 0644, []byte("x")); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("Expected ErrInvalidPath for %q, got %v", path, err)
+		}
+		upload.Abort()
+	}
+}
+
+func TestUploadLimits(t *testing.T) {
+	m := newTestManager(t)
+
+	upload, _ := m.NewUpload()
+	if err := upload.Write("big", 0644, make([]byte, 2048)); !errors.Is(err, ErrUploadTooLarge) {
+		t.Errorf("Expected ErrUploadTooLarge, got %v", err)
+	}
+	upload.Abort()
+
+	upload, _ = m.NewUpload()
+	defer upload.Abort()
+	for i, name := range []string{"a", "b", "c", "d"} {
+		err := upload.Write(name, 0644, []byte("x"))
+		if i < 3 && err != nil {
+			t.Fatalf("Unexpected error for file %d: %v", i, err)
+		}
+		if i == 3 && !errors.Is(err, ErrTooManyFiles) {
+			t.Errorf("Expected ErrTooManyFiles, got %v", err)
+		}
+	}
+}
+
+func TestClaimRejectsInvalidUploadID(t *testing.T) {
+	m := newTestManager(t)
+
+	if _, err := m.Claim("../../etc", "1"); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected ErrUploadNotFound, got %v", err)
+	}
+}
+
+func TestCreateUsageAndRemove(t *testing.T) {
+	m := newTestManager(t)
+
+	dir, err := m.Create("7")
+	if err != nil {
+		t.Fatalf("Create failed: %v", err)
+	}
+	if dir != m.JobDir("7") {
+		t.Errorf("Expected workspace %s, got %s", m.JobDir("7"), dir)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "out"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("hello"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "out", "b.bin"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	size, err := Usage(dir)
+	if err != nil {
+		t.Fatalf("Usage failed: %v", err)
+	}
+	if size != 105 {
+		t.Errorf("Expected 105 bytes, got %d", size)
+	}
+
+	if err := m.Remove("7"); err != nil {
+		t.Fatalf("Remove failed: %v", err)
+	}
+	if _, err := os.Stat(dir); !os.IsNotExist(err) {
+		t.Errorf("Expected workspace to be removed, stat returned %v", err)
+	}
+
+	// removing twice is fine
+	if err := m.Remove("7"); err != nil {
+		t.Errorf("Second Remove failed: %v", err)
+	}
+}
+
+func TestPruneRemovesOnlyStaleEntries(t *testing.T) {
+	m := newTestManager(t)
+
+	oldDir, _ := m.Create("old")
+	newDir, _ := m.Create("new")
+
+	stale := time.Now().Add(-2 * time.Hour)
+	if err := os.Chtimes(oldDir, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+
+	if removed := m.PruneJobs(time.Hour); removed != 1 {
+		t.Errorf("Expected 1 pruned workspace, got %d", removed)
+	}
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Errorf("Expected stale workspace to be removed")
+	}
+	if _, err := os.Stat(newDir); err != nil {
+		t.Errorf("Expected recent workspace to be kept: %v", err)
+	}
+
+	upload, err := m.NewUpload()
+	if err != nil {
+		t.Fatalf("NewUpload failed: %v", err)
+	}
+	upload.Close()
+
+	if removed := m.PruneUploads(time.Hour); removed != 0 {
+		t.Errorf("Expected recent upload to be kept, pruned %d", removed)
+	}
+
+	uploadDir := filepath.Join(m.baseDir, uploadsDir, upload.ID)
+	if err := os.Chtimes(uploadDir, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	if removed := m.PruneUploads(time.Hour); removed != 1 {
+		t.Errorf("Expected 1 pruned upload, got %d", removed)
+	}
+	if _, err := m.Claim(upload.ID, "9"); !errors.Is(err, ErrUploadNotFound) {
+		t.Errorf("Expected pruned upload to be unclaimable, got %v", err)
+	}
+}
+
+func TestUploadCopyFileAndDir(t *testing.T) {
+	m := NewManager(config.WorkspaceConfig{BaseDir: t.TempDir()})
+
+	src := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(src, "bin"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "bin", "tool"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "empty"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("/etc/passwd", filepath.Join(src, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	upload, err := m.NewUpload()
+	if err != nil {
+		t.Fatalf("NewUpload failed: %v", err)
+	}
+	if err := upload.CopyDir("inputs/build", src); err != nil {
+		t.Fatalf("CopyDir failed: %v", err)
+	}
+	if err := upload.CopyFile("inputs/build.stdout", filepath.Join(src, "bin", "tool")); err != nil {
+		t.Fatalf("CopyFile failed: %v", err)
+	}
+	upload.Close()
+
+	dir, err := m.Claim(upload.ID, "5")
+	if err != nil {
+		t.Fatalf("Claim failed: %v", err)
+	}
+
+	info, err := os.Stat(filepath.Join(dir, "inputs", "build", "bin", "tool"))
+	if err != nil {
+		t.Fatalf("expected copied tool: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("expected mode 0755 to be kept, got %v", info.Mode().Perm())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "inputs", "build", "empty")); err != nil {
+		t.Errorf("expected empty file to be copied: %v", err)
+	}
+	if _, err := os.Lstat(filepath.Join(dir, "inputs", "build", "link")); !os.IsNotExist(err) {
+		t.Errorf("expected symlink to be skipped, got %v", err)
+	}
+	if upload.Files() != 3 {
+		t.Errorf("expected 3 files, got %d", upload.Files())
+	}
+}
+
+func TestArtifacts(t *testing.T) {
+	m := newTestManager(t)
+
+	if artifacts, err := m.Artifacts(); err != nil || len(artifacts) != 0 {
+		t.Fatalf("expected no artifacts before any job, got %v, %v", artifacts, err)
+	}
+
+	dir, err := m.Create("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "out.bin"), make([]byte, 100), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.StdoutPath("3"), make([]byte, 20), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(m.StdoutPath("4"), make([]byte, 5), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	artifacts, err := m.Artifacts()
+	if err != nil {
+		t.Fatalf("Artifacts failed: %v", err)
+	}
+	if len(artifacts) != 2 {
+		t.Fatalf("expected an artifact per job, got %v", artifacts)
+	}
+	if artifacts[0].JobID != "3" || artifacts[0].Bytes != 120 || artifacts[0].Modified.IsZero() {
+		t.Errorf("expected the workspace and stdout of job 3 together, got %+v", artifacts[0])
+	}
+	if artifacts[1].JobID != "4" || artifacts[1].Bytes != 5 {
+		t.Errorf("expected the stdout of job 4, got %+v", artifacts[1])
+	}
+}
+
+func TestOpenArtifact(t *testing.T) {
+	m := newTestManager(t)
+	dir, err := m.Create("7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "core.12"), []byte("core"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	secret := filepath.Join(t.TempDir(), "secret")
+	if err := os.WriteFile(secret, []byte("secret"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(secret, filepath.Join(dir, "escape")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(filepath.Join(dir, "out"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := m.OpenArtifact("7", "core.12")
+	if err != nil {
+		t.Fatalf("OpenArtifact failed: %v", err)
+	}
+	f.Close()
+
+	for _, path := range []string{"", "../job-8/core", "/etc/passwd", "out"} {
+		if _, err := m.OpenArtifact("7", path); !errors.Is(err, ErrInvalidPath) {
+			t.Errorf("expected %q to be rejected as an invalid path, got %v", path, err)
+		}
+	}
+	if _, err := m.OpenArtifact("7", "escape"); err == nil {
+		t.Error("expected a symlink out of the workspace to be rejected")
+	}
+	if _, err := m.OpenArtifact("7", "missing"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected a missing file to be reported as such, got %v", err)
+	}
+}
+
+func TestReadResult(t *testing.T) {
+	dir := t.TempDir()
+
+	if data, err := ReadResult(dir, "result.json", 16); data != nil || err != nil {
+		t.Errorf("expected no result without a file, got %q, %v", data, err)
+	}
+
+	_ = os.WriteFile(filepath.Join(dir, "result.json"), []byte(`{"rows": 42}`), 0644)
+	if data, err := ReadResult(dir, "result.json", 16); string(data) != `{"rows": 42}` || err != nil {
+		t.Errorf("unexpected result %q, %v", data, err)
+	}
+	if data, _ := ReadResult(dir, "result.json", 4); len(data) != 5 {
+		t.Errorf("expected one byte past the limit to be read, got %q", data)
+	}
+
+	secret := filepath.Join(t.TempDir(), "secret")
+	_ = os.WriteFile(secret, []byte(`"host secret"`), 0600)
+	_ = os.Symlink(secret, filepath.Join(dir, "link.json"))
+	if data, err := ReadResult(dir, "link.json", 16); err == nil {
+		t.Errorf("expected a symlinked result to be refused, got %q", data)
+	}
+
+	_ = os.Mkdir(filepath.Join(dir, "dir.json"), 0755)
+	if _, err := ReadResult(dir, "dir.json", 16); !errors.Is(err, ErrInvalidPath) {
+		t.Errorf("expected ErrInvalidPath for a directory, got %v", err)
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"crypto/x509"
 	"fmt"
 	"google.golang.org/grpc/credentials"
+	"io"
 	"os"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	pb "worker/api/gen"
 )
 
@@ -20,20 +22,71 @@ const (
 	clientKeyPath  = "./certs/client-key.pem"
 
 	caCertPath = "./certs/ca-cert.pem"
+
+	// stays well below the server's default 512KB receive limit
+	uploadChunkSize = 256 * 1024
+
+	// job requests encoded larger than this are streamed with SubmitJob,
+	// in chunks of this size, as servers may take as little as 256KB
+	submitChunkSize = 128 * 1024
 )
 
+// UploadFile maps a local file to its path inside the job workspace
+type UploadFile struct {
+	LocalPath string
+	Path      string
+}
+
+// JobClient is a connection to a worker server. Failed calls return *Error,
+// which matches the Err* values with errors.Is. Calls that only read state are
+// retried on transient errors according to the client's RetryPolicy.
 type JobClient struct {
 	client pb.JobServiceClient
 	conn   *grpc.ClientConn
+	retry  RetryPolicy
+
+	logCompression string // compressor of the output streams, "" for none
+}
+
+// Option configures a JobClient
+type Option func(*JobClient)
+
+// WithRetryPolicy replaces DefaultRetryPolicy
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *JobClient) {
+		c.retry = policy
+	}
+}
+
+// TLSFiles are the PEM files the client authenticates with
+type TLSFiles struct {
+	CertPath string
+	KeyPath  string
+	CAPath   string
+}
+
+// DefaultTLSFiles are the certificates under ./certs
+func DefaultTLSFiles() TLSFiles {
+	return TLSFiles{CertPath: clientCertPath, KeyPath: clientKeyPath, CAPath: caCertPath}
+}
+
+func NewJobClient(serverAddr string, opts ...Option) (*JobClient, error) {
+	return NewJobClientWithTLS(serverAddr, DefaultTLSFiles(), opts...)
 }
 
-func NewJobClient(serverAddr string) (*JobClient, error) {
-	clientCert, err := tls.LoadX509KeyPair(clientCertPath, clientKeyPath)
+// NewJobClientWithTLS connects to serverAddr with the given certificates
+func NewJobClientWithTLS(serverAddr string, files TLSFiles, opts ...Option) (*JobClient, error) {
+	c := &JobClient{retry: DefaultRetryPolicy()}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	clientCert, err := tls.LoadX509KeyPair(files.CertPath, files.KeyPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load client cert/key: %w", err)
 	}
 
-	caCert, e := os.ReadFile(caCertPath)
+	caCert, e := os.ReadFile(files.CAPath)
 	if e != nil {
 		return nil, fmt.Errorf("failed to read CA certificate: %w", e)
 	}
@@ -56,15 +109,28 @@ func NewJobClient(serverAddr string) (*JobClient, error) {
 		serverAddr,
 		grpc.WithTransportCredentials(creds),
 		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+		grpc.WithUnaryInterceptor(c.retry.unaryInterceptor),
+		grpc.WithStreamInterceptor(streamInterceptor),
 	)
 	if er != nil {
 		return nil, fmt.Errorf("failed to connect to server: %w", er)
 	}
 
-	return &JobClient{
-		client: pb.NewJobServiceClient(conn),
-		conn:   conn,
-	}, nil
+	c.client = pb.NewJobServiceClient(conn)
+	c.conn = conn
+	return c, nil
+}
+
+// Service returns the generated client of the connection, for proxies that
+// forward requests unchanged. Calls keep the client's retry policy.
+func (c *JobClient) Service() pb.JobServiceClient {
+	return c.client
+}
+
+// FleetService returns a client of the coordinator API on the same
+// connection, for workers joining a fleet
+func (c *JobClient) FleetService() pb.FleetServiceClient {
+	return pb.NewFleetServiceClient(c.conn)
 }
 
 func (c *JobClient) Close() error {
@@ -74,23 +140,107 @@ func (c *JobClient) Close() error {
 	return nil
 }
 
+// RunJob starts a job. Requests too large for one message, such as ones
+// with a big inline environment, are streamed with SubmitJob.
 func (c *JobClient) RunJob(ctx context.Context, job *pb.RunJobReq) (*pb.RunJobRes, error) {
+	if proto.Size(job) > submitChunkSize {
+		return c.SubmitJob(ctx, job, nil)
+	}
 	return c.client.RunJob(ctx, job)
 }
 
+// SubmitJob starts a job like RunJob, streaming its request in chunks so it
+// is not bound by the server's message size limit. progress, when not nil,
+// is called with the bytes the server acknowledged taking of the total.
+// Servers without SubmitJob get the request with RunJob instead.
+func (c *JobClient) SubmitJob(ctx context.Context, job *pb.RunJobReq, progress func(received, total int64)) (*pb.RunJobRes, error) {
+	data, err := proto.Marshal(job)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job request: %w", err)
+	}
+	total := int64(len(data))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := c.client.SubmitJob(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// the server acknowledges chunks as they arrive, so they are read
+	// alongside sending the next ones
+	type result struct {
+		job   *pb.RunJobRes
+		acked bool
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		acked := false
+		for {
+			res, e := stream.Recv()
+			if e != nil {
+				done <- result{acked: acked, err: e}
+				return
+			}
+			if res.GetJob() != nil {
+				done <- result{job: res.GetJob()}
+				return
+			}
+			acked = true
+			if progress != nil {
+				progress(res.GetReceivedBytes(), total)
+			}
+		}
+	}()
+
+	for offset := int64(0); offset < total; offset += submitChunkSize {
+		chunk := &pb.SubmitJobChunk{Data: data[offset:min(offset+submitChunkSize, total)]}
+		if offset == 0 {
+			chunk.TotalBytes = total
+		}
+		// io.EOF means the server ended the stream, its status says why
+		if e := stream.Send(chunk); e == io.EOF {
+			break
+		} else if e != nil {
+			return nil, e
+		}
+	}
+	_ = stream.CloseSend()
+
+	r := <-done
+	// a server answering UNIMPLEMENTED before taking anything lacks the method
+	if r.err != nil && !r.acked && status.Code(r.err) == codes.Unimplemented {
+		return c.client.RunJob(ctx, job)
+	}
+	return r.job, r.err
+}
+
 func (c *JobClient) GetJobStatus(ctx context.Context, id string) (*pb.GetJobStatusRes, error) {
 	return c.client.GetJobStatus(ctx, &pb.GetJobStatusReq{Id: id})
 }
 
-func (c *JobClient) StopJob(ctx context.Context, id string) (*pb.StopJobRes, error) {
-	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+// GetJobProvenance returns the record of what a job was launched with
+func (c *JobClient) GetJobProvenance(ctx context.Context, id string) (*pb.JobProvenance, error) {
+	return c.client.GetJobProvenance(ctx, &pb.GetJobProvenanceReq{Id: id})
+}
+
+// GetJobProcesses returns the process tree of a running job
+func (c *JobClient) GetJobProcesses(ctx context.Context, id string) (*pb.JobProcesses, error) {
+	return c.client.GetJobProcesses(ctx, &pb.GetJobProcessesReq{Id: id})
+}
+
+// StopJob stops a job, allowing for the grace period it asks the worker to wait
+func (c *JobClient) StopJob(ctx context.Context, req *pb.StopJobReq) (*pb.StopJobRes, error) {
+	grace := time.Duration(req.GracePeriodMillis) * time.Millisecond
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second+grace)
 	defer cancel()
 
-	resp, err := c.client.StopJob(ctx, &pb.StopJobReq{Id: id})
+	resp, err := c.client.StopJob(ctx, req)
 	if err != nil {
 		if s, ok := status.FromError(err); ok {
 			if s.Code() == codes.DeadlineExceeded {
-				return nil, fmt.Errorf("timeout while stopping job %s: server may still be processing the request", id)
+				return nil, fmt.Errorf("timeout while stopping job %s: server may still be processing the request", req.Id)
 			}
 		}
 		return nil, err
@@ -102,10 +252,273 @@ func (c *JobClient) ListJobs(ctx context.Context) (*pb.Jobs, error) {
 	return c.client.ListJobs(ctx, &pb.EmptyRequest{})
 }
 
+// ExportJob returns a stream of a gzipped tarball of the job
+func (c *JobClient) ExportJob(ctx context.Context, id string) (pb.JobService_ExportJobClient, error) {
+	stream, err := c.client.ExportJob(ctx, &pb.ExportJobReq{Id: id})
+	if err != nil {
+		return nil, fmt.Errorf("failed to export job: %v", err)
+	}
+	return stream, nil
+}
+
+// GetJobArtifact streams a file from a job's workspace, path relative to it
+func (c *JobClient) GetJobArtifact(ctx context.Context, id, path string) (pb.JobService_GetJobArtifactClient, error) {
+	stream, err := c.client.GetJobArtifact(ctx, &pb.GetJobArtifactReq{Id: id, Path: path})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get job artifact: %v", err)
+	}
+	return stream, nil
+}
+
+// RunJobAttached starts a job and returns a stream of its output and final status
+func (c *JobClient) RunJobAttached(ctx context.Context, job *pb.RunJobReq) (pb.JobService_RunJobAttachedClient, error) {
+	stream, err := c.client.RunJobAttached(ctx, job, c.logCallOptions()...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start attached job: %v", err)
+	}
+	return stream, nil
+}
+
+// StreamJobMetrics returns a stream of usage snapshots for the given jobs, or
+// every job if ids is empty, sent every intervalSeconds
+func (c *JobClient) StreamJobMetrics(ctx context.Context, ids []string, intervalSeconds int32) (pb.JobService_StreamJobMetricsClient, error) {
+	stream, err := c.client.StreamJobMetrics(ctx, &pb.StreamJobMetricsReq{Ids: ids, IntervalSeconds: intervalSeconds})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start metrics stream: %v", err)
+	}
+	return stream, nil
+}
+
+// SubscribeJobEvents returns a stream of the events of the given jobs, or of
+// every job if ids is empty, limited to the given types if any
+func (c *JobClient) SubscribeJobEvents(ctx context.Context, ids []string, types []string) (pb.JobService_SubscribeJobEventsClient, error) {
+	stream, err := c.client.SubscribeJobEvents(ctx, &pb.SubscribeJobEventsReq{Ids: ids, Types: types})
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to job events: %v", err)
+	}
+	return stream, nil
+}
+
 func (c *JobClient) GetJobLogs(ctx context.Context, id string) (pb.JobService_GetJobLogsClient, error) {
-	stream, err := c.client.GetJobLogs(ctx, &pb.GetJobLogsReq{Id: id})
+	stream, err := c.client.GetJobLogs(ctx, &pb.GetJobLogsReq{Id: id}, c.logCallOptions()...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to start log stream: %v", err)
 	}
 	return stream, nil
 }
+
+// FollowJobLogs copies the job's output to w until the job finishes. If the
+// stream fails with a transient error it is reopened where it left off, with
+// the same backoff as retried calls.
+func (c *JobClient) FollowJobLogs(ctx context.Context, id string, w io.Writer) error {
+	var offset int64
+	failures := 0
+
+	for {
+		stream, err := c.client.GetJobLogs(ctx, &pb.GetJobLogsReq{Id: id, Offset: offset}, c.logCallOptions()...)
+		for err == nil {
+			var chunk *pb.DataChunk
+			if chunk, err = stream.Recv(); err == io.EOF {
+				return nil
+			}
+			if err == nil && len(chunk.Payload) > 0 {
+				failures = 0
+				n, e := w.Write(chunk.Payload)
+				offset += int64(n)
+				if e != nil {
+					return e
+				}
+			}
+		}
+
+		failures++
+		if !isTransient(err) || failures >= c.retry.MaxAttempts || !sleep(ctx, c.retry.backoff(failures)) {
+			return err
+		}
+	}
+}
+
+func (c *JobClient) CreateSecret(ctx context.Context, name string, value []byte) (*pb.CreateSecretRes, error) {
+	return c.client.CreateSecret(ctx, &pb.CreateSecretReq{Name: name, Value: value})
+}
+
+func (c *JobClient) DeleteSecret(ctx context.Context, name string) (*pb.DeleteSecretRes, error) {
+	return c.client.DeleteSecret(ctx, &pb.DeleteSecretReq{Name: name})
+}
+
+func (c *JobClient) RunPipeline(ctx context.Context, req *pb.RunPipelineReq) (*pb.Pipeline, error) {
+	return c.client.RunPipeline(ctx, req)
+}
+
+func (c *JobClient) GetPipelineStatus(ctx context.Context, id string) (*pb.Pipeline, error) {
+	return c.client.GetPipelineStatus(ctx, &pb.GetPipelineStatusReq{Id: id})
+}
+
+func (c *JobClient) RunJobGroup(ctx context.Context, req *pb.RunJobGroupReq) (*pb.JobGroup, error) {
+	return c.client.RunJobGroup(ctx, req)
+}
+
+func (c *JobClient) GetJobGroup(ctx context.Context, id string) (*pb.JobGroup, error) {
+	return c.client.GetJobGroup(ctx, &pb.GetJobGroupReq{Id: id})
+}
+
+// RerunJob starts a new job from the spec of an earlier one
+func (c *JobClient) RerunJob(ctx context.Context, id string) (*pb.RunJobRes, error) {
+	return c.client.RerunJob(ctx, &pb.RerunJobReq{Id: id})
+}
+
+// ResumeJob restarts a job held in a crash loop
+func (c *JobClient) ResumeJob(ctx context.Context, id string) (*pb.ResumeJobRes, error) {
+	return c.client.ResumeJob(ctx, &pb.ResumeJobReq{Id: id})
+}
+
+func (c *JobClient) ListJobGroups(ctx context.Context) (*pb.JobGroups, error) {
+	return c.client.ListJobGroups(ctx, &pb.EmptyRequest{})
+}
+
+func (c *JobClient) StopJobGroup(ctx context.Context, id string) (*pb.StopJobGroupRes, error) {
+	return c.client.StopJobGroup(ctx, &pb.StopJobGroupReq{Id: id})
+}
+
+// CreateJobTemplate stores a job template, replacing one of the same name
+// only when replace is set
+func (c *JobClient) CreateJobTemplate(ctx context.Context, template *pb.JobTemplate, replace bool) (*pb.JobTemplate, error) {
+	return c.client.CreateJobTemplate(ctx, &pb.CreateJobTemplateReq{Template: template, Replace: replace})
+}
+
+func (c *JobClient) ListJobTemplates(ctx context.Context) (*pb.JobTemplates, error) {
+	return c.client.ListJobTemplates(ctx, &pb.EmptyRequest{})
+}
+
+// RunJobFromTemplate starts a job from a stored template with the given parameter values
+func (c *JobClient) RunJobFromTemplate(ctx context.Context, template string, params map[string]string) (*pb.RunJobRes, error) {
+	return c.client.RunJobFromTemplate(ctx, &pb.RunJobFromTemplateReq{Template: template, Params: params})
+}
+
+func (c *JobClient) StopJobs(ctx context.Context, req *pb.BulkJobsReq) (*pb.BulkJobsRes, error) {
+	return c.client.StopJobs(ctx, req)
+}
+
+func (c *JobClient) DeleteJob(ctx context.Context, id string) (*pb.DeleteJobRes, error) {
+	return c.client.DeleteJob(ctx, &pb.DeleteJobReq{Id: id})
+}
+
+func (c *JobClient) DeleteJobs(ctx context.Context, req *pb.BulkJobsReq) (*pb.BulkJobsRes, error) {
+	return c.client.DeleteJobs(ctx, req)
+}
+
+func (c *JobClient) ValidateJob(ctx context.Context, job *pb.RunJobReq) (*pb.ValidateJobRes, error) {
+	return c.client.ValidateJob(ctx, job)
+}
+
+// GetWorkerInfo returns the platform and capabilities of the worker, so a
+// client can avoid requesting features it was built without
+func (c *JobClient) GetWorkerInfo(ctx context.Context) (*pb.WorkerInfo, error) {
+	return c.client.GetWorkerInfo(ctx, &pb.EmptyRequest{})
+}
+
+// GetDiagnostics returns the checks of the worker's subsystems, with how to
+// fix those that are not fine
+func (c *JobClient) GetDiagnostics(ctx context.Context) (*pb.Diagnostics, error) {
+	return c.client.GetDiagnostics(ctx, &pb.EmptyRequest{})
+}
+
+// GetStoreStats returns the memory the worker's job store and process take,
+// compacting the store first or adding a heap profile as req asks
+func (c *JobClient) GetStoreStats(ctx context.Context, req *pb.GetStoreStatsReq) (*pb.StoreStats, error) {
+	return c.client.GetStoreStats(ctx, req)
+}
+
+// GetUsageReport returns the resources the jobs of a tenant consumed in a
+// time range, for chargeback
+func (c *JobClient) GetUsageReport(ctx context.Context, req *pb.GetUsageReportReq) (*pb.UsageReport, error) {
+	return c.client.GetUsageReport(ctx, req)
+}
+
+// WriteJobStdin copies r to the stdin of a job started with stdin set and
+// closes the job's stdin once r is exhausted
+func (c *JobClient) WriteJobStdin(ctx context.Context, id string, r io.Reader) (*pb.WriteJobStdinRes, error) {
+	stream, err := c.client.WriteJobStdin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open job stdin: %v", err)
+	}
+
+	// the first chunk names the job, even if there is no input at all
+	chunk := &pb.StdinChunk{Id: id}
+	buf := make([]byte, uploadChunkSize)
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			chunk.Payload = buf[:n]
+		}
+		if n > 0 || chunk.Id != "" {
+			// io.EOF means the server ended the stream, its status says why
+			if e := stream.Send(chunk); e == io.EOF {
+				return stream.CloseAndRecv()
+			} else if e != nil {
+				return nil, e
+			}
+			chunk = &pb.StdinChunk{}
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			_ = stream.CloseSend()
+			return nil, fmt.Errorf("failed to read input: %v", readErr)
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+// UploadJobFiles streams local files to the worker and returns the upload ID to
+// pass in RunJobReq.UploadId
+func (c *JobClient) UploadJobFiles(ctx context.Context, files []UploadFile) (*pb.UploadJobFilesRes, error) {
+	stream, err := c.client.UploadJobFiles(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start upload: %v", err)
+	}
+
+	for _, file := range files {
+		if e := sendFile(stream, file); e != nil {
+			_ = stream.CloseSend()
+			return nil, e
+		}
+	}
+
+	return stream.CloseAndRecv()
+}
+
+func sendFile(stream pb.JobService_UploadJobFilesClient, file UploadFile) error {
+	f, err := os.Open(file.LocalPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", file.LocalPath, err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to stat %s: %w", file.LocalPath, err)
+	}
+	mode := uint32(info.Mode().Perm())
+
+	buf := make([]byte, uploadChunkSize)
+	sent := false
+	for {
+		n, readErr := f.Read(buf)
+		// always send at least one chunk so empty files are created too
+		if n > 0 || !sent && readErr == io.EOF {
+			if e := stream.Send(&pb.FileChunk{Path: file.Path, Data: buf[:n], Mode: mode}); e != nil {
+				return fmt.Errorf("failed to send %s: %w", file.LocalPath, e)
+			}
+			sent = true
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read %s: %w", file.LocalPath, readErr)
+		}
+	}
+}
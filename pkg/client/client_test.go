@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	pb "worker/api/gen"
+)
+
+// submitServer answers SubmitJob like the worker: it acknowledges each chunk
+// and returns the job once the client closes its side
+type submitServer struct {
+	pb.JobServiceClient
+	unimplemented bool
+	received      []byte
+	ran           *pb.RunJobReq
+}
+
+func (s *submitServer) SubmitJob(ctx context.Context, opts ...grpc.CallOption) (pb.JobService_SubmitJobClient, error) {
+	return &submitStream{server: s, responses: make(chan *pb.SubmitJobProgress, 1024)}, nil
+}
+
+func (s *submitServer) RunJob(ctx context.Context, req *pb.RunJobReq, opts ...grpc.CallOption) (*pb.RunJobRes, error) {
+	s.ran = req
+	return &pb.RunJobRes{Id: "2"}, nil
+}
+
+type submitStream struct {
+	grpc.ClientStream
+	server    *submitServer
+	responses chan *pb.SubmitJobProgress
+}
+
+func (s *submitStream) Send(chunk *pb.SubmitJobChunk) error {
+	if s.server.unimplemented {
+		close(s.responses)
+		return io.EOF
+	}
+	s.server.received = append(s.server.received, chunk.GetData()...)
+	s.responses <- &pb.SubmitJobProgress{ReceivedBytes: int64(len(s.server.received))}
+	return nil
+}
+
+func (s *submitStream) CloseSend() error {
+	if !s.server.unimplemented {
+		s.responses <- &pb.SubmitJobProgress{Job: &pb.RunJobRes{Id: "1"}}
+	}
+	return nil
+}
+
+func (s *submitStream) Recv() (*pb.SubmitJobProgress, error) {
+	res, ok := <-s.responses
+	if !ok {
+		return nil, status.Error(codes.Unimplemented, "unknown method SubmitJob")
+	}
+	return res, nil
+}
+
+func largeJob() *pb.RunJobReq {
+	return &pb.RunJobReq{Command: "env", Env: map[string]string{"BIG": strings.Repeat("x", 3*submitChunkSize)}}
+}
+
+func TestRunJobSubmitsLargeRequests(t *testing.T) {
+	server := &submitServer{}
+	c := &JobClient{client: server}
+
+	job := largeJob()
+	res, err := c.RunJob(context.Background(), job)
+	if err != nil || res.GetId() != "1" {
+		t.Fatalf("expected the job to be submitted, got %v, %v", res, err)
+	}
+
+	got := &pb.RunJobReq{}
+	if err := proto.Unmarshal(server.received, got); err != nil || !proto.Equal(got, job) {
+		t.Errorf("expected the server to receive the request, got %v", err)
+	}
+}
+
+func TestSubmitJobProgress(t *testing.T) {
+	c := &JobClient{client: &submitServer{}}
+
+	var acks []int64
+	var total int64
+	if _, err := c.SubmitJob(context.Background(), largeJob(), func(received, size int64) {
+		acks = append(acks, received)
+		total = size
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(acks) != 4 || acks[len(acks)-1] != total {
+		t.Errorf("expected an ack per chunk up to %d bytes, got %v", total, acks)
+	}
+}
+
+func TestSubmitJobFallsBackToRunJob(t *testing.T) {
+	server := &submitServer{unimplemented: true}
+	c := &JobClient{client: server}
+
+	res, err := c.SubmitJob(context.Background(), largeJob(), nil)
+	if err != nil || res.GetId() != "2" || server.ran == nil {
+		t.Errorf("expected a server without SubmitJob to get RunJob, got %v, %v", res, err)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"fmt"
+
+	"github.com/mostynb/go-grpc-compression/zstd"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+// Compressors the output streams can use. Importing this package registers
+// both with gRPC; the server imports them itself to answer in kind.
+const (
+	CompressionGzip = gzip.Name
+	CompressionZstd = zstd.Name
+)
+
+// ParseCompression validates the name of a compressor, "" for none
+func ParseCompression(name string) (string, error) {
+	switch name {
+	case "", "none":
+		return "", nil
+	case CompressionGzip, CompressionZstd:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unknown compression %q (expected none, gzip or zstd)", name)
+	}
+}
+
+// WithLogCompression has the server compress the output it streams to
+// GetJobLogs, FollowJobLogs and RunJobAttached with the named compressor,
+// "" for none. A server without the compressor fails the call.
+func WithLogCompression(name string) Option {
+	return func(c *JobClient) {
+		c.logCompression = name
+	}
+}
+
+// logCallOptions are the call options of the output streams. gRPC servers
+// compress their responses as the request was compressed.
+func (c *JobClient) logCallOptions() []grpc.CallOption {
+	if c.logCompression == "" {
+		return nil
+	}
+	return []grpc.CallOption{grpc.UseCompressor(c.logCompression)}
+}
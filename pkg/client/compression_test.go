@@ -0,0 +1,21 @@
+package client
+
+import "testing"
+
+func TestParseCompression(t *testing.T) {
+	for name, want := range map[string]string{
+		"":     "",
+		"none": "",
+		"gzip": CompressionGzip,
+		"zstd": CompressionZstd,
+	} {
+		got, err := ParseCompression(name)
+		if err != nil || got != want {
+			t.Errorf("ParseCompression(%q) = %q, %v; want %q", name, got, err, want)
+		}
+	}
+
+	if _, err := ParseCompression("brotli"); err == nil {
+		t.Error("expected an unknown compressor to be rejected")
+	}
+}
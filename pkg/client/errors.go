@@ -0,0 +1,154 @@
+package client
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Errors returned by JobClient methods match one of these with errors.Is,
+// according to the gRPC code the server answered with
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrPermissionDenied   = errors.New("permission denied")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrUnavailable        = errors.New("server unavailable")
+	ErrTimeout            = errors.New("deadline exceeded")
+	ErrCanceled           = errors.New("canceled")
+	ErrInternal           = errors.New("internal server error")
+	ErrUnimplemented      = errors.New("not supported by the server")
+)
+
+var codeErrors = map[codes.Code]error{
+	codes.NotFound:           ErrNotFound,
+	codes.AlreadyExists:      ErrAlreadyExists,
+	codes.InvalidArgument:    ErrInvalidArgument,
+	codes.FailedPrecondition: ErrFailedPrecondition,
+	codes.PermissionDenied:   ErrPermissionDenied,
+	codes.Unauthenticated:    ErrUnauthenticated,
+	codes.Unavailable:        ErrUnavailable,
+	codes.DeadlineExceeded:   ErrTimeout,
+	codes.Canceled:           ErrCanceled,
+	codes.Internal:           ErrInternal,
+	codes.Unimplemented:      ErrUnimplemented,
+	codes.Unknown:            ErrInternal,
+}
+
+// Error is a failed call. Its message is the server's description, and it
+// keeps the gRPC status so status.FromError and status.Code still work.
+type Error struct {
+	Code    codes.Code
+	Message string
+
+	status *status.Status // as received, with its details
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Is reports whether target is the Err* value for the error's code
+func (e *Error) Is(target error) bool {
+	return target != nil && codeErrors[e.Code] == target
+}
+
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status != nil {
+		return e.status
+	}
+	return status.New(e.Code, e.Message)
+}
+
+// wrapError converts gRPC status errors to *Error and leaves others alone
+func wrapError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var typed *Error
+	if errors.As(err, &typed) {
+		return err
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &Error{Code: s.Code(), Message: s.Message(), status: s}
+}
+
+// capabilityReason is the ErrorInfo reason the server gives for jobs that need
+// capabilities it lacks
+const capabilityReason = "CAPABILITY_UNAVAILABLE"
+
+// MissingCapabilities returns the worker capabilities a refused job needed but
+// the worker lacks, or nil if err is not such a refusal. GetWorkerInfo lists
+// the capabilities a worker has.
+func MissingCapabilities(err error) []string {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.Unimplemented {
+		return nil
+	}
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.GetReason() == capabilityReason {
+			return strings.Split(info.GetMetadata()["capabilities"], ",")
+		}
+	}
+	return nil
+}
+
+// heldReason is the ErrorInfo reason the server gives for jobs its schedule holds
+const heldReason = "JOB_HELD"
+
+// HeldUntil returns when a job refused because the worker's schedule holds it
+// may be submitted again, and false if err is not such a refusal
+func HeldUntil(err error) (time.Time, bool) {
+	s, ok := status.FromError(err)
+	if !ok || s.Code() != codes.FailedPrecondition {
+		return time.Time{}, false
+	}
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.GetReason() == heldReason {
+			until, err := time.Parse(time.RFC3339, info.GetMetadata()["until"])
+			return until, err == nil
+		}
+	}
+	return time.Time{}, false
+}
+
+// cgroupReason is the ErrorInfo reason the server gives for jobs whose cgroup
+// the kernel refused to set up
+const cgroupReason = "CGROUP_WRITE_FAILED"
+
+// CgroupFailure returns the kind of cgroup failure a job could not start
+// with, such as "invalid_value" or "permission_denied", and what fixes it,
+// and false if err is not such a failure
+func CgroupFailure(err error) (kind, hint string, ok bool) {
+	s, ok := status.FromError(err)
+	if !ok {
+		return "", "", false
+	}
+	for _, detail := range s.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if ok && info.GetReason() == cgroupReason {
+			return info.GetMetadata()["kind"], info.GetMetadata()["hint"], true
+		}
+	}
+	return "", "", false
+}
+
+// isTransient reports whether a call failing with err may succeed if retried
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
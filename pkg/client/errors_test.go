@@ -0,0 +1,106 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestWrapError(t *testing.T) {
+	err := wrapError(status.Error(codes.NotFound, "job not found"))
+
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	if errors.Is(err, ErrUnavailable) {
+		t.Error("did not expect ErrUnavailable")
+	}
+	if err.Error() != "job not found" {
+		t.Errorf("expected the server's message, got %q", err.Error())
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected the gRPC code to be kept, got %v", status.Code(err))
+	}
+
+	// wrapped further by callers, the code still matches
+	if !errors.Is(fmt.Errorf("stop: %w", err), ErrNotFound) {
+		t.Error("expected ErrNotFound through fmt.Errorf")
+	}
+}
+
+func TestWrapErrorLeavesOtherErrors(t *testing.T) {
+	if wrapError(nil) != nil {
+		t.Error("expected nil")
+	}
+	if wrapError(io.EOF) != io.EOF {
+		t.Error("expected io.EOF to be returned as is")
+	}
+}
+
+func TestHeldUntil(t *testing.T) {
+	st, err := status.New(codes.FailedPrecondition, "job held (blackout nightly) until 2025-01-07T06:00:00Z").WithDetails(&errdetails.ErrorInfo{
+		Reason:   heldReason,
+		Metadata: map[string]string{"until": "2025-01-07T06:00:00Z"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	until, held := HeldUntil(wrapError(st.Err()))
+	if !held || !until.Equal(time.Date(2025, 1, 7, 6, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected the job to be held until 06:00, got %v, %v", until, held)
+	}
+	if _, held := HeldUntil(wrapError(status.Error(codes.FailedPrecondition, "job can never start"))); held {
+		t.Error("expected no hold without details")
+	}
+}
+
+func TestCgroupFailure(t *testing.T) {
+	st, err := status.New(codes.InvalidArgument, "job run failed: cgroup setup failed").WithDetails(&errdetails.ErrorInfo{
+		Reason:   cgroupReason,
+		Metadata: map[string]string{"kind": "invalid_value", "hint": "change the job's limits"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	kind, hint, ok := CgroupFailure(wrapError(st.Err()))
+	if !ok || kind != "invalid_value" || hint != "change the job's limits" {
+		t.Errorf("expected an invalid value failure with its hint, got %q, %q, %v", kind, hint, ok)
+	}
+	if _, _, ok := CgroupFailure(wrapError(status.Error(codes.Internal, "job run failed"))); ok {
+		t.Error("expected no cgroup failure without details")
+	}
+}
+
+func TestMissingCapabilities(t *testing.T) {
+	st, err := status.New(codes.Unimplemented, "worker does not support stdin,uploads").WithDetails(&errdetails.ErrorInfo{
+		Reason:   capabilityReason,
+		Metadata: map[string]string{"capabilities": "stdin,uploads"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	refused := wrapError(st.Err())
+
+	if !errors.Is(refused, ErrUnimplemented) {
+		t.Errorf("expected ErrUnimplemented, got %v", refused)
+	}
+	if got := MissingCapabilities(refused); !reflect.DeepEqual(got, []string{"stdin", "uploads"}) {
+		t.Errorf("expected [stdin uploads], got %v", got)
+	}
+
+	if got := MissingCapabilities(wrapError(status.Error(codes.Unimplemented, "unknown method"))); got != nil {
+		t.Errorf("expected nil without details, got %v", got)
+	}
+	if got := MissingCapabilities(io.EOF); got != nil {
+		t.Errorf("expected nil for non-status errors, got %v", got)
+	}
+}
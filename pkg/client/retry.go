@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+	pb "worker/api/gen"
+)
+
+// RetryPolicy controls how calls failing with a transient error are retried.
+// Only calls that are safe to repeat are retried; starting or stopping jobs is not.
+type RetryPolicy struct {
+	MaxAttempts    int           // Total attempts including the first, 1 disables retries
+	InitialBackoff time.Duration // Wait before the first retry
+	MaxBackoff     time.Duration // Upper bound for the doubling wait
+}
+
+// DefaultRetryPolicy retries up to 4 times, waiting 200ms, 400ms, 800ms and 1.6s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 5, InitialBackoff: 200 * time.Millisecond, MaxBackoff: 5 * time.Second}
+}
+
+// backoff returns the wait before retry number attempt (starting at 1)
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.InitialBackoff
+	for i := 1; i < attempt && wait < p.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, p.MaxBackoff)
+}
+
+// idempotentMethods are the calls that are retried on transient errors
+var idempotentMethods = map[string]bool{
+	pb.JobService_GetJobStatus_FullMethodName:      true,
+	pb.JobService_GetJobProvenance_FullMethodName:  true,
+	pb.JobService_ListJobs_FullMethodName:          true,
+	pb.JobService_ValidateJob_FullMethodName:       true,
+	pb.JobService_GetPipelineStatus_FullMethodName: true,
+	pb.JobService_GetJobGroup_FullMethodName:       true,
+	pb.JobService_ListJobGroups_FullMethodName:     true,
+	pb.JobService_ListJobTemplates_FullMethodName:  true,
+	pb.JobService_GetWorkerInfo_FullMethodName:     true,
+	pb.JobService_GetDiagnostics_FullMethodName:    true,
+}
+
+// unaryInterceptor retries idempotent calls and converts errors to *Error
+func (p RetryPolicy) unaryInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	attempts := 1
+	if idempotentMethods[method] {
+		attempts = max(1, p.MaxAttempts)
+	}
+
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = invoker(ctx, method, req, reply, cc, opts...)
+		if err == nil || attempt >= attempts || !isTransient(err) {
+			break
+		}
+		if !sleep(ctx, p.backoff(attempt)) {
+			break
+		}
+	}
+	return wrapError(err)
+}
+
+// streamInterceptor converts stream errors to *Error
+func streamInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	return &errorStream{ClientStream: stream}, nil
+}
+
+type errorStream struct {
+	grpc.ClientStream
+}
+
+func (s *errorStream) SendMsg(m interface{}) error {
+	// io.EOF tells the caller to look at RecvMsg for the real error
+	if err := s.ClientStream.SendMsg(m); err != io.EOF {
+		return wrapError(err)
+	}
+	return io.EOF
+}
+
+func (s *errorStream) RecvMsg(m interface{}) error {
+	if err := s.ClientStream.RecvMsg(m); err != io.EOF {
+		return wrapError(err)
+	}
+	return io.EOF
+}
+
+// sleep waits for d and reports false if ctx ended first
+func sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
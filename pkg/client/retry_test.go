@@ -0,0 +1,70 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	pb "worker/api/gen"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 10, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	expected := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want*time.Millisecond {
+			t.Errorf("backoff(%d) = %v, want %v", i+1, got, want*time.Millisecond)
+		}
+	}
+}
+
+// invoker fails with the given errors in turn, then succeeds
+func invoker(calls *int, errs ...error) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(errs) {
+			return errs[*calls-1]
+		}
+		return nil
+	}
+}
+
+func TestUnaryInterceptorRetriesIdempotentCalls(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	unavailable := status.Error(codes.Unavailable, "connection reset")
+
+	calls := 0
+	err := policy.unaryInterceptor(context.Background(), pb.JobService_ListJobs_FullMethodName, nil, nil, nil, invoker(&calls, unavailable, unavailable))
+	if err != nil || calls != 3 {
+		t.Errorf("expected success on the third attempt, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = policy.unaryInterceptor(context.Background(), pb.JobService_ListJobs_FullMethodName, nil, nil, nil, invoker(&calls, unavailable, unavailable, unavailable))
+	if !errors.Is(err, ErrUnavailable) || calls != 3 {
+		t.Errorf("expected ErrUnavailable after 3 calls, got %v after %d calls", err, calls)
+	}
+}
+
+func TestUnaryInterceptorDoesNotRetry(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+
+	// starting a job twice is not safe
+	calls := 0
+	err := policy.unaryInterceptor(context.Background(), pb.JobService_RunJob_FullMethodName, nil, nil, nil, invoker(&calls, status.Error(codes.Unavailable, "down")))
+	if !errors.Is(err, ErrUnavailable) || calls != 1 {
+		t.Errorf("expected a single RunJob call, got %v after %d calls", err, calls)
+	}
+
+	// and a missing job stays missing
+	calls = 0
+	err = policy.unaryInterceptor(context.Background(), pb.JobService_GetJobStatus_FullMethodName, nil, nil, nil, invoker(&calls, status.Error(codes.NotFound, "job not found")))
+	if !errors.Is(err, ErrNotFound) || calls != 1 {
+		t.Errorf("expected a single call for NotFound, got %v after %d calls", err, calls)
+	}
+}
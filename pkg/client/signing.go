@@ -0,0 +1,59 @@
+package client
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/proto"
+	pb "worker/api/gen"
+)
+
+// SigningPayload is what the signature of a job request covers: the request
+// encoded deterministically without its signature. Sign the request once it
+// is complete, with its upload ID set, as any later change invalidates it.
+func SigningPayload(req *pb.RunJobReq) ([]byte, error) {
+	unsigned := proto.Clone(req).(*pb.RunJobReq)
+	unsigned.Signature = nil
+
+	payload, err := proto.MarshalOptions{Deterministic: true}.Marshal(unsigned)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode job request for signing: %w", err)
+	}
+	return payload, nil
+}
+
+// SignRunJobReq signs req with key, for workers that require signed job specs
+func SignRunJobReq(req *pb.RunJobReq, key ed25519.PrivateKey) error {
+	payload, err := SigningPayload(req)
+	if err != nil {
+		return err
+	}
+	req.Signature = ed25519.Sign(key, payload)
+	return nil
+}
+
+// LoadSigningKey reads an Ed25519 private key from a PKCS #8 PEM file, as
+// written by "openssl genpkey -algorithm ed25519"
+func LoadSigningKey(path string) (ed25519.PrivateKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signing key: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("signing key %s is not PEM encoded", path)
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse signing key %s: %w", path, err)
+	}
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("signing key %s is a %T, not an Ed25519 key", path, key)
+	}
+	return signingKey, nil
+}
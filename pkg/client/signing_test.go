@@ -0,0 +1,67 @@
+package client
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "worker/api/gen"
+)
+
+func TestSignRunJobReq(t *testing.T) {
+	public, key, _ := ed25519.GenerateKey(nil)
+	req := &pb.RunJobReq{Command: "/bin/echo", Args: []string{"hi"}, Env: map[string]string{"A": "1", "B": "2", "C": "3"}}
+
+	if err := SignRunJobReq(req, key); err != nil {
+		t.Fatalf("SignRunJobReq failed: %v", err)
+	}
+	if len(req.Signature) != ed25519.SignatureSize {
+		t.Fatalf("unexpected signature %x", req.Signature)
+	}
+
+	// the payload leaves the signature out and does not depend on map order
+	payload, err := SigningPayload(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ed25519.Verify(public, payload, req.Signature) {
+		t.Error("expected the signature to verify over the payload")
+	}
+	again, _ := SigningPayload(&pb.RunJobReq{Command: "/bin/echo", Args: []string{"hi"}, Env: map[string]string{"C": "3", "B": "2", "A": "1"}})
+	if !bytes.Equal(payload, again) {
+		t.Error("expected the same request to give the same payload")
+	}
+}
+
+func TestLoadSigningKey(t *testing.T) {
+	_, key, _ := ed25519.GenerateKey(nil)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadSigningKey(path)
+	if err != nil {
+		t.Fatalf("LoadSigningKey failed: %v", err)
+	}
+	if !loaded.Equal(key) {
+		t.Error("expected the key written to be loaded")
+	}
+
+	garbage := filepath.Join(dir, "garbage.pem")
+	if err := os.WriteFile(garbage, []byte("not pem"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadSigningKey(garbage); err == nil {
+		t.Error("expected an error for a file that is not PEM")
+	}
+}
@@ -2,8 +2,10 @@ package config
 
 import (
 	"fmt"
+	"net/netip"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -19,6 +21,30 @@ type Config struct {
 	Cgroup   CgroupConfig   `yaml:"cgroup" json:"cgroup"`
 	GRPC     GRPCConfig     `yaml:"grpc" json:"grpc"`
 	Logging  LoggingConfig  `yaml:"logging" json:"logging"`
+
+	LogShipping LogShippingConfig `yaml:"logShipping" json:"logShipping"`
+	Redaction   RedactionConfig   `yaml:"redaction" json:"redaction"`
+	Secrets     SecretsConfig     `yaml:"secrets" json:"secrets"`
+	Workspace   WorkspaceConfig   `yaml:"workspace" json:"workspace"`
+	Offload     OffloadConfig     `yaml:"offload" json:"offload"`
+	Inputs      InputsConfig      `yaml:"inputs" json:"inputs"`
+	WarmPool    WarmPoolConfig    `yaml:"warmPool" json:"warmPool"`
+	Tracing     TracingConfig     `yaml:"tracing" json:"tracing"`
+	Hooks       HooksConfig       `yaml:"hooks" json:"hooks"`
+	CloudEvents CloudEventsConfig `yaml:"cloudEvents" json:"cloudEvents"`
+	Intake      IntakeConfig      `yaml:"intake" json:"intake"`
+	Coordinator CoordinatorConfig `yaml:"coordinator" json:"coordinator"`
+	Fleet       FleetConfig       `yaml:"fleet" json:"fleet"`
+	Reconcile   ReconcileConfig   `yaml:"reconcile" json:"reconcile"`
+	Usage       UsageConfig       `yaml:"usage" json:"usage"`
+	Janitor     JanitorConfig     `yaml:"janitor" json:"janitor"`
+	CoreDumps   CoreDumpConfig    `yaml:"coreDumps" json:"coreDumps"`
+
+	OutputBuffer   OutputBufferConfig   `yaml:"outputBuffer" json:"outputBuffer"`
+	FaultInjection FaultInjectionConfig `yaml:"faultInjection" json:"faultInjection"`
+
+	Schedule ScheduleConfig `yaml:"schedule" json:"schedule"`
+	Sockets  SocketsConfig  `yaml:"sockets" json:"sockets"`
 }
 
 // ServerConfig holds server-specific configuration
@@ -31,13 +57,74 @@ type ServerConfig struct {
 
 // WorkerConfig holds worker-specific configuration
 type WorkerConfig struct {
-	DefaultCPULimit    int32         `yaml:"defaultCpuLimit" json:"defaultCpuLimit"`
-	DefaultMemoryLimit int32         `yaml:"defaultMemoryLimit" json:"defaultMemoryLimit"`
-	DefaultIOLimit     int32         `yaml:"defaultIoLimit" json:"defaultIoLimit"`
+	DefaultCPUMillis   int64         `yaml:"defaultCpuMillis" json:"defaultCpuMillis"` // 1000 = one core
+	DefaultMemoryBytes int64         `yaml:"defaultMemoryBytes" json:"defaultMemoryBytes"`
+	DefaultIOBPS       int64         `yaml:"defaultIoBps" json:"defaultIoBps"`             // block IO bytes per second
+	DefaultCPULimit    int32         `yaml:"defaultCpuLimit" json:"defaultCpuLimit"`       // Deprecated: percent of one core, used when defaultCpuMillis is unset
+	DefaultMemoryLimit int32         `yaml:"defaultMemoryLimit" json:"defaultMemoryLimit"` // Deprecated: MiB, used when defaultMemoryBytes is unset
+	DefaultIOLimit     int32         `yaml:"defaultIoLimit" json:"defaultIoLimit"`         // Deprecated: used when defaultIoBps is unset
 	MaxConcurrentJobs  int           `yaml:"maxConcurrentJobs" json:"maxConcurrentJobs"`
 	JobTimeout         time.Duration `yaml:"jobTimeout" json:"jobTimeout"`
 	CleanupTimeout     time.Duration `yaml:"cleanupTimeout" json:"cleanupTimeout"`
 	ValidateCommands   bool          `yaml:"validateCommands" json:"validateCommands"`
+	EnvDenylist        []string      `yaml:"envDenylist" json:"envDenylist"`               // env names jobs may not set, trailing * matches a prefix
+	RestartBackoff     time.Duration `yaml:"restartBackoff" json:"restartBackoff"`         // delay before restarting a job, doubled per consecutive crash
+	RestartMaxBackoff  time.Duration `yaml:"restartMaxBackoff" json:"restartMaxBackoff"`   // upper bound of the restart delay
+	RestartResetAfter  time.Duration `yaml:"restartResetAfter" json:"restartResetAfter"`   // a run lasting this long resets the backoff
+	MaxRestarts        int32         `yaml:"maxRestarts" json:"maxRestarts"`               // restart limit for jobs that do not set one
+	CrashLoopThreshold int           `yaml:"crashLoopThreshold" json:"crashLoopThreshold"` // failures within crashLoopWindow that hold a job in CRASH_LOOP, 0 never
+	CrashLoopWindow    time.Duration `yaml:"crashLoopWindow" json:"crashLoopWindow"`
+	WatchdogDeadline   time.Duration `yaml:"watchdogDeadline" json:"watchdogDeadline"` // launches and cgroup cleanups taking longer are failed, 0 never
+	CommandCacheTTL    time.Duration `yaml:"commandCacheTTL" json:"commandCacheTTL"`   // how long a command name resolves to the same path while the file is unchanged, 0 never caches
+
+	LimitProfiles map[string]LimitProfile  `yaml:"limitProfiles" json:"limitProfiles"` // named limits clients can request instead of raw numbers
+	Isolation     string                   `yaml:"isolation" json:"isolation"`         // "full", "cgroups" or "process" for jobs that do not ask for one
+	Preflight     string                   `yaml:"preflight" json:"preflight"`         // "strict", "degraded" or "off", see PreflightStrict
+	BaseEnv       map[string]string        `yaml:"baseEnv" json:"baseEnv"`             // PATH, HOME, LANG and the like jobs start with over the worker's own, their env overrides them
+	Runtimes      map[string]RuntimeConfig `yaml:"runtimes" json:"runtimes"`           // sandbox runtimes jobs can ask for by name to run with stronger isolation than namespaces
+	InitBinaries  map[string]string        `yaml:"initBinaries" json:"initBinaries"`   // worker binary jobs are launched from per host architecture (GOARCH name), the running worker for others
+}
+
+// What the worker does when the kernel lacks a feature jobs need, checked at
+// startup
+const (
+	PreflightStrict   = "strict"   // refuse to start
+	PreflightDegraded = "degraded" // start, refusing jobs of the isolation modes that cannot work
+	PreflightOff      = "off"      // skip the checks, jobs fail when they run into the missing feature
+)
+
+// LimitProfile is a named set of job resource limits. Zero values leave the
+// limit to the worker defaults.
+type LimitProfile struct {
+	CPUMillis   int64    `yaml:"cpuMillis" json:"cpuMillis"`
+	MemoryBytes int64    `yaml:"memoryBytes" json:"memoryBytes"`
+	IOBPS       int64    `yaml:"ioBps" json:"ioBps"`
+	Roles       []string `yaml:"roles" json:"roles"` // client roles allowed to use the profile, empty allows any role that may run jobs
+}
+
+// RuntimeConfig is an external sandbox runtime, such as gVisor's runsc, jobs
+// are run through instead of the worker's namespaces. The runtime is started
+// with Args followed by the job's command and arguments, so it must take the
+// command to run last, as "runsc do" does.
+type RuntimeConfig struct {
+	Path string   `yaml:"path" json:"path"` // absolute path of the runtime binary
+	Args []string `yaml:"args" json:"args"` // arguments before the job's command, e.g. ["--network=none", "do"]
+}
+
+// DefaultLimits returns the limits for jobs that do not set their own, taking
+// the deprecated MiB and percent settings when the newer ones are unset
+func (w WorkerConfig) DefaultLimits() (cpuMillis, memoryBytes, ioBPS int64) {
+	cpuMillis, memoryBytes, ioBPS = w.DefaultCPUMillis, w.DefaultMemoryBytes, w.DefaultIOBPS
+	if cpuMillis == 0 {
+		cpuMillis = int64(w.DefaultCPULimit) * 10
+	}
+	if memoryBytes == 0 {
+		memoryBytes = int64(w.DefaultMemoryLimit) << 20
+	}
+	if ioBPS == 0 {
+		ioBPS = int64(w.DefaultIOLimit)
+	}
+	return cpuMillis, memoryBytes, ioBPS
 }
 
 // SecurityConfig holds security-related configuration
@@ -48,6 +135,31 @@ type SecurityConfig struct {
 	ClientCertPath string `yaml:"clientCertPath" json:"clientCertPath"`
 	ClientKeyPath  string `yaml:"clientKeyPath" json:"clientKeyPath"`
 	MinTLSVersion  string `yaml:"minTlsVersion" json:"minTlsVersion"`
+
+	AllowedClientCIDRs []string            `yaml:"allowedClientCidrs" json:"allowedClientCidrs"` // connections from elsewhere are closed on accept, empty allows any
+	SourceRestrictions []SourceRestriction `yaml:"sourceRestrictions" json:"sourceRestrictions"`
+
+	SpecSigning SpecSigningConfig `yaml:"specSigning" json:"specSigning"`
+}
+
+// SpecSigningConfig holds the keys job specs are signed with, as a check
+// independent of the client certificate
+type SpecSigningConfig struct {
+	Required bool         `yaml:"required" json:"required"` // refuse jobs whose spec is not signed with one of the keys
+	Keys     []SigningKey `yaml:"keys" json:"keys"`
+}
+
+// SigningKey is a public key job spec signatures are verified with
+type SigningKey struct {
+	Name      string `yaml:"name" json:"name"`           // recorded as the signer of the jobs whose spec it verifies
+	PublicKey string `yaml:"publicKey" json:"publicKey"` // path of an Ed25519 public key in PEM
+}
+
+// SourceRestriction limits the networks a client may call from, on top of
+// the allowed client CIDRs
+type SourceRestriction struct {
+	Client string   `yaml:"client" json:"client"` // common name of the client certificate
+	CIDRs  []string `yaml:"cidrs" json:"cidrs"`
 }
 
 // CgroupConfig holds cgroup-related configuration
@@ -56,6 +168,14 @@ type CgroupConfig struct {
 	NamespaceMount    string        `yaml:"namespaceMount" json:"namespaceMount"`
 	EnableControllers []string      `yaml:"enableControllers" json:"enableControllers"`
 	CleanupTimeout    time.Duration `yaml:"cleanupTimeout" json:"cleanupTimeout"`
+	CleanupWorkers    int           `yaml:"cleanupWorkers" json:"cleanupWorkers"`     // cgroups removed concurrently
+	CleanupQueueSize  int           `yaml:"cleanupQueueSize" json:"cleanupQueueSize"` // pending removals before callers wait
+	// RequireLimits fails the start of jobs whose limits the kernel ignored
+	// or clamped, which otherwise run with a job.limit_not_applied event
+	RequireLimits bool `yaml:"requireLimits" json:"requireLimits"`
+	// IODevice is the major:minor of the disk job IO limits apply to, the
+	// disk of the job workspaces when empty
+	IODevice string `yaml:"ioDevice" json:"ioDevice"`
 }
 
 // GRPCConfig holds gRPC-specific configuration
@@ -65,13 +185,305 @@ type GRPCConfig struct {
 	MaxHeaderListSize int32         `yaml:"maxHeaderListSize" json:"maxHeaderListSize"`
 	KeepAliveTime     time.Duration `yaml:"keepAliveTime" json:"keepAliveTime"`
 	KeepAliveTimeout  time.Duration `yaml:"keepAliveTimeout" json:"keepAliveTimeout"`
+
+	// Clients pinging more often than keepAliveMinTime are disconnected, and
+	// may only ping without an open RPC when permitKeepAliveWithoutStream
+	KeepAliveMinTime             time.Duration `yaml:"keepAliveMinTime" json:"keepAliveMinTime"`
+	PermitKeepAliveWithoutStream bool          `yaml:"permitKeepAliveWithoutStream" json:"permitKeepAliveWithoutStream"`
+
+	MaxConcurrentStreams  uint32        `yaml:"maxConcurrentStreams" json:"maxConcurrentStreams"`   // RPCs open at once per connection, 0 for no limit
+	MaxConnections        int           `yaml:"maxConnections" json:"maxConnections"`               // connections accepted at once, 0 for no limit
+	MaxConnectionIdle     time.Duration `yaml:"maxConnectionIdle" json:"maxConnectionIdle"`         // close connections without RPCs for this long, 0 never
+	MaxConnectionAge      time.Duration `yaml:"maxConnectionAge" json:"maxConnectionAge"`           // ask clients to reconnect after this long, 0 never
+	MaxConnectionAgeGrace time.Duration `yaml:"maxConnectionAgeGrace" json:"maxConnectionAgeGrace"` // RPCs still open this long after the age are cut, 0 never
+
+	MaxSubmitSize int64 `yaml:"maxSubmitSize" json:"maxSubmitSize"` // largest job request SubmitJob takes in chunks, for requests over maxRecvMsgSize
 }
 
 // LoggingConfig holds logging configuration
 type LoggingConfig struct {
-	Level  string `yaml:"level" json:"level"`
-	Format string `yaml:"format" json:"format"`
-	Output string `yaml:"output" json:"output"`
+	Level      string            `yaml:"level" json:"level"`
+	Format     string            `yaml:"format" json:"format"`         // "text" (or "console") or "json"
+	Output     string            `yaml:"output" json:"output"`         // "stdout", "stderr", "syslog" or a file path
+	Components map[string]string `yaml:"components" json:"components"` // level per component, overriding level
+	MaxSize    int64             `yaml:"maxSize" json:"maxSize"`       // rotate the log file at this many bytes, 0 never
+	MaxBackups int               `yaml:"maxBackups" json:"maxBackups"` // rotated log files kept
+}
+
+// File returns the log file path, or "" when logging to a stream or syslog
+func (l LoggingConfig) File() string {
+	switch l.Output {
+	case "", "stdout", "stderr", "syslog":
+		return ""
+	}
+	return l.Output
+}
+
+// LogShippingConfig holds configuration for forwarding job output to external log systems
+type LogShippingConfig struct {
+	Enabled   bool              `yaml:"enabled" json:"enabled"`
+	QueueSize int               `yaml:"queueSize" json:"queueSize"`
+	Labels    map[string]string `yaml:"labels" json:"labels"`
+	Sinks     []LogSinkConfig   `yaml:"sinks" json:"sinks"`
+}
+
+// LogSinkConfig describes a single log shipping destination
+type LogSinkConfig struct {
+	Type    string        `yaml:"type" json:"type"`       // "syslog", "loki" or "fluentd"
+	Network string        `yaml:"network" json:"network"` // syslog only: "udp", "tcp" or "" for the local daemon
+	Address string        `yaml:"address" json:"address"`
+	Tag     string        `yaml:"tag" json:"tag"`
+	Timeout time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// RedactionConfig holds configuration for masking secrets in job output and API responses
+type RedactionConfig struct {
+	Enabled          bool     `yaml:"enabled" json:"enabled"`
+	Mask             string   `yaml:"mask" json:"mask"`
+	Patterns         []string `yaml:"patterns" json:"patterns"`                 // regexes masked in job output
+	SensitiveEnvKeys []string `yaml:"sensitiveEnvKeys" json:"sensitiveEnvKeys"` // regexes for env names whose values are always masked
+}
+
+// SecretsConfig holds configuration for the encrypted secrets store
+type SecretsConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	Dir     string `yaml:"dir" json:"dir"`         // directory holding encrypted secret files
+	KeyFile string `yaml:"keyFile" json:"keyFile"` // 32-byte AES key, generated on first start if missing; keep it out of Dir
+}
+
+// WorkspaceConfig holds configuration for job workspaces and staged file uploads
+type WorkspaceConfig struct {
+	BaseDir        string        `yaml:"baseDir" json:"baseDir"`
+	MountPath      string        `yaml:"mountPath" json:"mountPath"`         // where the workspace is bind-mounted inside the job, "" runs from the host path
+	Retention      time.Duration `yaml:"retention" json:"retention"`         // how long a finished job's workspace is kept, 0 = remove immediately
+	UploadTTL      time.Duration `yaml:"uploadTtl" json:"uploadTtl"`         // unclaimed uploads older than this are removed
+	MaxUploadSize  int64         `yaml:"maxUploadSize" json:"maxUploadSize"` // bytes per upload, 0 = unlimited
+	MaxUploadFiles int           `yaml:"maxUploadFiles" json:"maxUploadFiles"`
+}
+
+// OffloadConfig holds configuration for uploading finished jobs' output and
+// artifacts to object storage
+type OffloadConfig struct {
+	Enabled         bool          `yaml:"enabled" json:"enabled"`
+	Type            string        `yaml:"type" json:"type"`         // "s3" or "gcs"
+	Endpoint        string        `yaml:"endpoint" json:"endpoint"` // override for S3-compatible stores, e.g. MinIO
+	Region          string        `yaml:"region" json:"region"`
+	Bucket          string        `yaml:"bucket" json:"bucket"`
+	Prefix          string        `yaml:"prefix" json:"prefix"` // objects are stored under <prefix>/<jobId>/
+	AccessKeyID     string        `yaml:"accessKeyId" json:"accessKeyId"`
+	SecretAccessKey string        `yaml:"secretAccessKey" json:"-"`
+	Artifacts       []string      `yaml:"artifacts" json:"artifacts"` // workspace globs to upload, empty uploads everything
+	EvictLocal      bool          `yaml:"evictLocal" json:"evictLocal"`
+	Timeout         time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// InputsConfig holds configuration for job input files streamed from object
+// storage as the job reads them, instead of being staged before it starts
+type InputsConfig struct {
+	Enabled         bool          `yaml:"enabled" json:"enabled"`
+	Type            string        `yaml:"type" json:"type"`         // "s3" or "gcs"
+	Endpoint        string        `yaml:"endpoint" json:"endpoint"` // override for S3-compatible stores, e.g. MinIO
+	Region          string        `yaml:"region" json:"region"`
+	AccessKeyID     string        `yaml:"accessKeyId" json:"accessKeyId"`
+	SecretAccessKey string        `yaml:"secretAccessKey" json:"-"`
+	Buckets         []string      `yaml:"buckets" json:"buckets"`           // buckets jobs may read from, empty allows any
+	MaxInputs       int           `yaml:"maxInputs" json:"maxInputs"`       // per job
+	FetchTimeout    time.Duration `yaml:"fetchTimeout" json:"fetchTimeout"` // until the store starts sending an object
+}
+
+// SocketsConfig holds configuration for listening sockets the worker opens
+// and passes into jobs, which keep them open across restarts and hand-offs
+type SocketsConfig struct {
+	Enabled bool   `yaml:"enabled" json:"enabled"`
+	UnixDir string `yaml:"unixDir" json:"unixDir"` // where unix://NAME sockets are created
+}
+
+// ScheduleConfig holds configuration for when new jobs may start
+type ScheduleConfig struct {
+	Blackouts []BlackoutConfig `yaml:"blackouts" json:"blackouts"`
+}
+
+// BlackoutConfig is a recurring period in which the jobs it selects are held
+// instead of started
+type BlackoutConfig struct {
+	Name   string            `yaml:"name" json:"name"`
+	Window string            `yaml:"window" json:"window"` // "[DAYS] HH:MM-HH:MM [ZONE]", e.g. "Mon-Fri 09:00-17:00"
+	Labels map[string]string `yaml:"labels" json:"labels"` // labels the held jobs have, empty holds every job
+}
+
+// WarmPoolConfig holds configuration for init processes started in fresh
+// namespaces ahead of jobs, so full isolation jobs skip that setup
+type WarmPoolConfig struct {
+	Enabled bool          `yaml:"enabled" json:"enabled"`
+	Size    int           `yaml:"size" json:"size"` // idle init processes kept ready
+	TTL     time.Duration `yaml:"ttl" json:"ttl"`   // idle processes older than this are replaced
+}
+
+// TracingConfig holds configuration for OpenTelemetry traces of job launches
+// and cleanups, exported over OTLP/gRPC
+type TracingConfig struct {
+	Enabled     bool    `yaml:"enabled" json:"enabled"`
+	Endpoint    string  `yaml:"endpoint" json:"endpoint"` // host:port of the OTLP collector
+	Insecure    bool    `yaml:"insecure" json:"insecure"` // connect without TLS
+	ServiceName string  `yaml:"serviceName" json:"serviceName"`
+	SampleRatio float64 `yaml:"sampleRatio" json:"sampleRatio"` // share of new traces recorded, 0 to 1
+}
+
+// HooksConfig holds the hooks run before each job starts and after it ends.
+// Hooks receive the job as JSON.
+type HooksConfig struct {
+	PreStart []HookConfig `yaml:"preStart" json:"preStart"`
+	PostStop []HookConfig `yaml:"postStop" json:"postStop"`
+}
+
+// HookConfig is a host command or an HTTP endpoint, exactly one of the two
+type HookConfig struct {
+	Name     string        `yaml:"name" json:"name"`
+	Command  []string      `yaml:"command" json:"command"`   // run on the host with the job JSON on stdin
+	URL      string        `yaml:"url" json:"url"`           // the job JSON is POSTed here
+	Timeout  time.Duration `yaml:"timeout" json:"timeout"`   // 0 for the default of 10s
+	Required bool          `yaml:"required" json:"required"` // a failing pre-start hook rejects the job
+}
+
+// CloudEventsConfig holds configuration for publishing job lifecycle events
+// as CloudEvents, over HTTP or NATS
+type CloudEventsConfig struct {
+	Enabled   bool          `yaml:"enabled" json:"enabled"`
+	Transport string        `yaml:"transport" json:"transport"` // "http" or "nats"
+	URL       string        `yaml:"url" json:"url"`             // HTTP endpoint, or nats://host:port
+	Subject   string        `yaml:"subject" json:"subject"`     // NATS subject
+	Source    string        `yaml:"source" json:"source"`       // CloudEvents source, defaults to /worker/<hostname>
+	Types     []string      `yaml:"types" json:"types"`         // event types to emit, empty for all
+	Timeout   time.Duration `yaml:"timeout" json:"timeout"`
+	QueueSize int           `yaml:"queueSize" json:"queueSize"` // events waiting to be sent before new ones are dropped
+}
+
+// CoordinatorConfig holds configuration for coordinator mode, where the server
+// dispatches jobs to a fleet of workers instead of running them. It connects
+// to the workers with the client certificate of the security section, which
+// needs the admin role.
+type CoordinatorConfig struct {
+	Workers           []FleetWorker `yaml:"workers" json:"workers"`                     // workers polled for heartbeats, in addition to those registering themselves
+	HeartbeatInterval time.Duration `yaml:"heartbeatInterval" json:"heartbeatInterval"` // how often the capacity of each worker is polled
+	HeartbeatTimeout  time.Duration `yaml:"heartbeatTimeout" json:"heartbeatTimeout"`   // a worker not answering for this long gets no new jobs
+	DispatchTimeout   time.Duration `yaml:"dispatchTimeout" json:"dispatchTimeout"`     // bound on forwarding one call to a worker
+}
+
+// FleetWorker is a worker a coordinator dispatches jobs to. Its name prefixes
+// the ids of its jobs, "node-1/42" is job 42 of worker node-1.
+type FleetWorker struct {
+	Name    string `yaml:"name" json:"name"`
+	Address string `yaml:"address" json:"address"`
+}
+
+// FleetConfig holds configuration for a worker joining a coordinator's fleet.
+// The worker registers with the coordinator and sends it heartbeats with its
+// load, using the client certificate of the security section, which needs the
+// admin role.
+type FleetConfig struct {
+	Enabled     bool          `yaml:"enabled" json:"enabled"`
+	Coordinator string        `yaml:"coordinator" json:"coordinator"` // host:port of the coordinator
+	Name        string        `yaml:"name" json:"name"`               // defaults to the hostname
+	Address     string        `yaml:"address" json:"address"`         // where the coordinator reaches this worker, defaults to <hostname>:<server port>
+	Interval    time.Duration `yaml:"interval" json:"interval"`       // between heartbeats, below the coordinator's heartbeat timeout
+}
+
+// IntakeConfig holds configuration for consuming job submissions from a
+// message queue in addition to gRPC
+type IntakeConfig struct {
+	Enabled    bool          `yaml:"enabled" json:"enabled"`
+	Transport  string        `yaml:"transport" json:"transport"`   // "nats" or "jetstream"
+	URL        string        `yaml:"url" json:"url"`               // nats://host:port
+	Subject    string        `yaml:"subject" json:"subject"`       // NATS subject submissions are published to
+	QueueGroup string        `yaml:"queueGroup" json:"queueGroup"` // NATS queue group shared by the workers
+	Stream     string        `yaml:"stream" json:"stream"`         // JetStream stream
+	Consumer   string        `yaml:"consumer" json:"consumer"`     // durable JetStream pull consumer
+	Role       string        `yaml:"role" json:"role"`             // "submitter", or "admin" to allow shell, unisolated and privileged jobs
+	Timeout    time.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// ReconcileConfig holds configuration for running the jobs described in a
+// directory of desired job specs
+type ReconcileConfig struct {
+	Enabled  bool          `yaml:"enabled" json:"enabled"`
+	Dir      string        `yaml:"dir" json:"dir"`           // one RunJobReq per .yaml, .yml or .json file
+	Interval time.Duration `yaml:"interval" json:"interval"` // between passes over the specs and the jobs
+}
+
+// UsageConfig holds configuration for recording the resources each job
+// consumed, for chargeback
+type UsageConfig struct {
+	Enabled     bool          `yaml:"enabled" json:"enabled"`
+	Dir         string        `yaml:"dir" json:"dir"`                 // where the usage records of finished jobs are kept
+	TenantLabel string        `yaml:"tenantLabel" json:"tenantLabel"` // job label naming a job's tenant, also used by the metrics stream
+	Interval    time.Duration `yaml:"interval" json:"interval"`       // between memory samples of running jobs
+}
+
+// JanitorConfig holds configuration for keeping what jobs leave on disk within
+// a budget: workspaces, captured output, staged uploads and log files. Once
+// over maxBytes, the files of finished jobs and rotated logs are removed,
+// oldest first, until the use is down to lowWatermark percent of the budget.
+type JanitorConfig struct {
+	Enabled      bool          `yaml:"enabled" json:"enabled"`
+	Interval     time.Duration `yaml:"interval" json:"interval"`         // between checks of the disk use
+	MaxBytes     int64         `yaml:"maxBytes" json:"maxBytes"`         // disk budget
+	LowWatermark int           `yaml:"lowWatermark" json:"lowWatermark"` // percent of maxBytes removals bring the use down to
+	MinAge       time.Duration `yaml:"minAge" json:"minAge"`             // files of jobs that finished more recently are kept, even over budget
+}
+
+// CoreDumpConfig holds configuration for keeping the core files of jobs that
+// crash. Jobs get a core size limit of maxBytes, larger cores are truncated,
+// and a core the kernel writes to a job's workspace is recorded on the job and
+// can be downloaded as an artifact. Only a relative kernel.core_pattern puts
+// cores in the workspace.
+type CoreDumpConfig struct {
+	Enabled  bool  `yaml:"enabled" json:"enabled"`
+	MaxBytes int64 `yaml:"maxBytes" json:"maxBytes"` // RLIMIT_CORE of jobs
+}
+
+// OutputBufferConfig holds configuration for the memory the output the worker
+// buffers for all jobs may take. Once over maxBytes, buffers are moved to files
+// in spillDir, finished jobs' oldest first and then the largest running ones,
+// until the buffered output is down to 90% of maxBytes. Spilled output is
+// still served as before; jobs keep appending to their file.
+type OutputBufferConfig struct {
+	MaxBytes    int64  `yaml:"maxBytes" json:"maxBytes"`       // 0 = unlimited
+	WarnPercent int    `yaml:"warnPercent" json:"warnPercent"` // percent of maxBytes that sends a worker.output_pressure event
+	SpillDir    string `yaml:"spillDir" json:"spillDir"`
+
+	// A job's output is stored, and sent to clients following it, in chunks of
+	// up to flushBytes at most flushInterval after it was written; a flush
+	// interval of 0 stores every write as it comes
+	FlushInterval time.Duration `yaml:"flushInterval" json:"flushInterval"`
+	FlushBytes    int           `yaml:"flushBytes" json:"flushBytes"`
+
+	// Rate limit of the output of jobs that set none, and the highest a job
+	// may set, in bytes per second (0 for none); the action is "throttle" to
+	// block the jobs' writes or "truncate" to drop the output over the rate
+	RateLimit       int64  `yaml:"rateLimit" json:"rateLimit"`
+	RateLimitAction string `yaml:"rateLimitAction" json:"rateLimitAction"`
+
+	// Every compactInterval (0 never) the output of jobs that finished over
+	// compressAfter ago (0 never) is gzipped in memory, and the output of jobs
+	// that finished over retention ago (0 never) is dropped; the jobs' records
+	// are kept until deleted
+	CompactInterval time.Duration `yaml:"compactInterval" json:"compactInterval"`
+	CompressAfter   time.Duration `yaml:"compressAfter" json:"compressAfter"`
+	Retention       time.Duration `yaml:"retention" json:"retention"`
+}
+
+// FaultInjectionConfig holds configuration for making the worker's cgroup
+// writes, process starts and kills fail or stall at random, so tests and
+// staging can exercise its error handling and cleanup paths under the failures
+// a loaded host produces. Rates are the share of calls that fail, from 0 to 1.
+// Never enable it in production.
+type FaultInjectionConfig struct {
+	Enabled             bool          `yaml:"enabled" json:"enabled"`
+	Seed                int64         `yaml:"seed" json:"seed"`                               // makes the injected faults repeatable, 0 seeds from the clock
+	CgroupWriteFailRate float64       `yaml:"cgroupWriteFailRate" json:"cgroupWriteFailRate"` // cgroup file writes failing with EIO
+	StartFailRate       float64       `yaml:"startFailRate" json:"startFailRate"`             // job process starts failing with EAGAIN
+	StartDelay          time.Duration `yaml:"startDelay" json:"startDelay"`                   // most a process start is held back, as by a slow namespace setup
+	KillFailRate        float64       `yaml:"killFailRate" json:"killFailRate"`               // signals to jobs failing with EPERM
 }
 
 // DefaultConfig Default configuration values
@@ -90,6 +502,22 @@ var DefaultConfig = Config{
 		JobTimeout:         1 * time.Hour,
 		CleanupTimeout:     5 * time.Second,
 		ValidateCommands:   true,
+		EnvDenylist: []string{
+			"LD_*",
+			"GCONV_PATH",
+			"MALLOC_*",
+			"BASH_ENV",
+		},
+		RestartBackoff:     1 * time.Second,
+		RestartMaxBackoff:  5 * time.Minute,
+		RestartResetAfter:  10 * time.Minute,
+		MaxRestarts:        10,
+		CrashLoopThreshold: 5,
+		CrashLoopWindow:    10 * time.Minute,
+		WatchdogDeadline:   1 * time.Minute,
+		CommandCacheTTL:    30 * time.Second,
+		Isolation:          "full",
+		Preflight:          PreflightStrict,
 	},
 	Security: SecurityConfig{
 		ServerCertPath: "./certs/server-cert.pem",
@@ -104,18 +532,145 @@ var DefaultConfig = Config{
 		NamespaceMount:    "/sys/fs/cgroup",
 		EnableControllers: []string{"cpu", "memory", "io", "pids"},
 		CleanupTimeout:    5 * time.Second,
+		CleanupWorkers:    4,
+		CleanupQueueSize:  1024,
 	},
 	GRPC: GRPCConfig{
-		MaxRecvMsgSize:    512 * 1024,      // 512KB
-		MaxSendMsgSize:    4 * 1024 * 1024, // 4MB
-		MaxHeaderListSize: 1 * 1024 * 1024, // 1MB
+		MaxRecvMsgSize:    512 * 1024,       // 512KB
+		MaxSendMsgSize:    4 * 1024 * 1024,  // 4MB
+		MaxHeaderListSize: 1 * 1024 * 1024,  // 1MB
+		MaxSubmitSize:     64 * 1024 * 1024, // 64MB
 		KeepAliveTime:     30 * time.Second,
 		KeepAliveTimeout:  5 * time.Second,
+
+		KeepAliveMinTime:             10 * time.Second,
+		PermitKeepAliveWithoutStream: true,
 	},
 	Logging: LoggingConfig{
-		Level:  "INFO",
-		Format: "text",
-		Output: "stdout",
+		Level:      "INFO",
+		Format:     "text",
+		Output:     "stdout",
+		MaxSize:    100 * 1024 * 1024,
+		MaxBackups: 5,
+	},
+	LogShipping: LogShippingConfig{
+		Enabled:   false,
+		QueueSize: 1024,
+	},
+	Redaction: RedactionConfig{
+		Enabled: true,
+		Mask:    "***REDACTED***",
+		Patterns: []string{
+			`AKIA[0-9A-Z]{16}`, // AWS access key IDs
+			`(?i)bearer\s+[a-z0-9\-._~+/]+=*`,
+			`-----BEGIN [A-Z ]*PRIVATE KEY-----`,
+		},
+		SensitiveEnvKeys: []string{
+			`(?i)(secret|passw(or)?d|token|api_?key|private_?key|credential)`,
+		},
+	},
+	Secrets: SecretsConfig{
+		Enabled: true,
+		Dir:     "/opt/worker/secrets",
+		KeyFile: "/etc/worker/secrets.key",
+	},
+	Workspace: WorkspaceConfig{
+		BaseDir:        "/opt/worker/workspaces",
+		MountPath:      "/work",
+		Retention:      1 * time.Hour,
+		UploadTTL:      1 * time.Hour,
+		MaxUploadSize:  100 * 1024 * 1024, // 100MB
+		MaxUploadFiles: 1000,
+	},
+	Offload: OffloadConfig{
+		Enabled:    false,
+		Type:       "s3",
+		Prefix:     "jobs",
+		EvictLocal: true,
+		Timeout:    5 * time.Minute,
+	},
+	Inputs: InputsConfig{
+		Enabled:      false,
+		Type:         "s3",
+		MaxInputs:    16,
+		FetchTimeout: 30 * time.Second,
+	},
+	Sockets: SocketsConfig{
+		Enabled: false,
+		UnixDir: "/run/job-worker/sockets",
+	},
+	WarmPool: WarmPoolConfig{
+		Enabled: false,
+		Size:    4,
+		TTL:     10 * time.Minute,
+	},
+	CloudEvents: CloudEventsConfig{
+		Enabled:   false,
+		Transport: "http",
+		Subject:   "worker.jobs",
+		Timeout:   5 * time.Second,
+		QueueSize: 1024,
+	},
+	Coordinator: CoordinatorConfig{
+		HeartbeatInterval: 5 * time.Second,
+		HeartbeatTimeout:  15 * time.Second,
+		DispatchTimeout:   10 * time.Second,
+	},
+	Fleet: FleetConfig{
+		Enabled:  false,
+		Interval: 5 * time.Second,
+	},
+	Intake: IntakeConfig{
+		Enabled:    false,
+		Transport:  "nats",
+		Subject:    "worker.jobs.submit",
+		QueueGroup: "worker",
+		Role:       "submitter",
+		Timeout:    5 * time.Second,
+	},
+	Reconcile: ReconcileConfig{
+		Enabled:  false,
+		Dir:      "/etc/worker/jobs.d",
+		Interval: 10 * time.Second,
+	},
+	Usage: UsageConfig{
+		Enabled:     false,
+		Dir:         "/opt/worker/usage",
+		TenantLabel: "tenant",
+		Interval:    10 * time.Second,
+	},
+	Janitor: JanitorConfig{
+		Enabled:      false,
+		Interval:     5 * time.Minute,
+		MaxBytes:     10 * 1024 * 1024 * 1024, // 10GB
+		LowWatermark: 80,
+		MinAge:       10 * time.Minute,
+	},
+	CoreDumps: CoreDumpConfig{
+		Enabled:  false,
+		MaxBytes: 1024 * 1024 * 1024, // 1GB
+	},
+	OutputBuffer: OutputBufferConfig{
+		MaxBytes:    1024 * 1024 * 1024, // 1GB
+		WarnPercent: 80,
+		SpillDir:    "/opt/worker/spill",
+
+		FlushInterval: 50 * time.Millisecond,
+		FlushBytes:    64 * 1024,
+
+		RateLimitAction: "throttle",
+
+		CompactInterval: 5 * time.Minute,
+		CompressAfter:   10 * time.Minute,
+	},
+	FaultInjection: FaultInjectionConfig{
+		Enabled: false,
+	},
+	Tracing: TracingConfig{
+		Enabled:     false,
+		Endpoint:    "localhost:4317",
+		ServiceName: "worker",
+		SampleRatio: 1,
 	},
 }
 
@@ -232,9 +787,35 @@ func loadFromEnv(config *Config) error {
 			config.Worker.CleanupTimeout = timeout
 		}
 	}
+	if val := os.Getenv("WORKER_COMMAND_CACHE_TTL"); val != "" {
+		if ttl, err := time.ParseDuration(val); err == nil {
+			config.Worker.CommandCacheTTL = ttl
+		}
+	}
 	if val := os.Getenv("WORKER_VALIDATE_COMMANDS"); val != "" {
 		config.Worker.ValidateCommands = val == "true" || val == "1"
 	}
+	if val := os.Getenv("WORKER_RESTART_BACKOFF"); val != "" {
+		if backoff, err := time.ParseDuration(val); err == nil {
+			config.Worker.RestartBackoff = backoff
+		}
+	}
+	if val := os.Getenv("WORKER_MAX_RESTARTS"); val != "" {
+		if restarts, err := strconv.ParseInt(val, 10, 32); err == nil {
+			config.Worker.MaxRestarts = int32(restarts)
+		}
+	}
+	if val := os.Getenv("WORKER_CRASH_LOOP_THRESHOLD"); val != "" {
+		if threshold, err := strconv.Atoi(val); err == nil {
+			config.Worker.CrashLoopThreshold = threshold
+		}
+	}
+	if val := os.Getenv("WORKER_ISOLATION"); val != "" {
+		config.Worker.Isolation = val
+	}
+	if val := os.Getenv("WORKER_PREFLIGHT"); val != "" {
+		config.Worker.Preflight = val
+	}
 
 	// Security config
 	if val := os.Getenv("WORKER_SERVER_CERT_PATH"); val != "" {
@@ -255,6 +836,12 @@ func loadFromEnv(config *Config) error {
 	if val := os.Getenv("WORKER_MIN_TLS_VERSION"); val != "" {
 		config.Security.MinTLSVersion = val
 	}
+	if val := os.Getenv("WORKER_ALLOWED_CLIENT_CIDRS"); val != "" {
+		config.Security.AllowedClientCIDRs = strings.Split(val, ",")
+	}
+	if val := os.Getenv("WORKER_REQUIRE_SIGNED_SPECS"); val != "" {
+		config.Security.SpecSigning.Required = val == "true" || val == "1"
+	}
 
 	// Cgroup config
 	if val := os.Getenv("WORKER_CGROUP_BASE_DIR"); val != "" {
@@ -266,11 +853,22 @@ func loadFromEnv(config *Config) error {
 	if val := os.Getenv("WORKER_CGROUP_CONTROLLERS"); val != "" {
 		config.Cgroup.EnableControllers = strings.Split(val, ",")
 	}
+	if val := os.Getenv("WORKER_CGROUP_IO_DEVICE"); val != "" {
+		config.Cgroup.IODevice = val
+	}
 	if val := os.Getenv("WORKER_CGROUP_CLEANUP_TIMEOUT"); val != "" {
 		if timeout, err := time.ParseDuration(val); err == nil {
 			config.Cgroup.CleanupTimeout = timeout
 		}
 	}
+	if val := os.Getenv("WORKER_CGROUP_CLEANUP_WORKERS"); val != "" {
+		if workers, err := strconv.Atoi(val); err == nil {
+			config.Cgroup.CleanupWorkers = workers
+		}
+	}
+	if val := os.Getenv("WORKER_CGROUP_REQUIRE_LIMITS"); val != "" {
+		config.Cgroup.RequireLimits = val == "true" || val == "1"
+	}
 
 	// GRPC config
 	if val := os.Getenv("WORKER_GRPC_MAX_RECV_MSG_SIZE"); val != "" {
@@ -278,6 +876,11 @@ func loadFromEnv(config *Config) error {
 			config.GRPC.MaxRecvMsgSize = int32(size)
 		}
 	}
+	if val := os.Getenv("WORKER_GRPC_MAX_SUBMIT_SIZE"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.GRPC.MaxSubmitSize = size
+		}
+	}
 	if val := os.Getenv("WORKER_GRPC_MAX_SEND_MSG_SIZE"); val != "" {
 		if size, err := strconv.ParseInt(val, 10, 32); err == nil {
 			config.GRPC.MaxSendMsgSize = int32(size)
@@ -298,6 +901,39 @@ func loadFromEnv(config *Config) error {
 			config.GRPC.KeepAliveTimeout = timeout
 		}
 	}
+	if val := os.Getenv("WORKER_GRPC_KEEPALIVE_MIN_TIME"); val != "" {
+		if minTime, err := time.ParseDuration(val); err == nil {
+			config.GRPC.KeepAliveMinTime = minTime
+		}
+	}
+	if val := os.Getenv("WORKER_GRPC_PERMIT_KEEPALIVE_WITHOUT_STREAM"); val != "" {
+		config.GRPC.PermitKeepAliveWithoutStream = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_GRPC_MAX_CONCURRENT_STREAMS"); val != "" {
+		if streams, err := strconv.ParseUint(val, 10, 32); err == nil {
+			config.GRPC.MaxConcurrentStreams = uint32(streams)
+		}
+	}
+	if val := os.Getenv("WORKER_GRPC_MAX_CONNECTIONS"); val != "" {
+		if connections, err := strconv.Atoi(val); err == nil {
+			config.GRPC.MaxConnections = connections
+		}
+	}
+	if val := os.Getenv("WORKER_GRPC_MAX_CONNECTION_IDLE"); val != "" {
+		if idle, err := time.ParseDuration(val); err == nil {
+			config.GRPC.MaxConnectionIdle = idle
+		}
+	}
+	if val := os.Getenv("WORKER_GRPC_MAX_CONNECTION_AGE"); val != "" {
+		if age, err := time.ParseDuration(val); err == nil {
+			config.GRPC.MaxConnectionAge = age
+		}
+	}
+	if val := os.Getenv("WORKER_GRPC_MAX_CONNECTION_AGE_GRACE"); val != "" {
+		if grace, err := time.ParseDuration(val); err == nil {
+			config.GRPC.MaxConnectionAgeGrace = grace
+		}
+	}
 
 	// Logging config
 	if val := os.Getenv("LOG_LEVEL"); val != "" {
@@ -310,6 +946,215 @@ func loadFromEnv(config *Config) error {
 		config.Logging.Output = val
 	}
 
+	// Log shipping config
+	if val := os.Getenv("WORKER_LOG_SHIPPING_ENABLED"); val != "" {
+		config.LogShipping.Enabled = val == "true" || val == "1"
+	}
+
+	// Redaction config
+	if val := os.Getenv("WORKER_REDACTION_ENABLED"); val != "" {
+		config.Redaction.Enabled = val == "true" || val == "1"
+	}
+
+	// Secrets config
+	if val := os.Getenv("WORKER_SECRETS_ENABLED"); val != "" {
+		config.Secrets.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_SECRETS_DIR"); val != "" {
+		config.Secrets.Dir = val
+	}
+	if val := os.Getenv("WORKER_SECRETS_KEY_FILE"); val != "" {
+		config.Secrets.KeyFile = val
+	}
+
+	// Offload config
+	if val := os.Getenv("WORKER_OFFLOAD_ENABLED"); val != "" {
+		config.Offload.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_OFFLOAD_BUCKET"); val != "" {
+		config.Offload.Bucket = val
+	}
+	if val := os.Getenv("WORKER_OFFLOAD_ACCESS_KEY_ID"); val != "" {
+		config.Offload.AccessKeyID = val
+	}
+	if val := os.Getenv("WORKER_OFFLOAD_SECRET_ACCESS_KEY"); val != "" {
+		config.Offload.SecretAccessKey = val
+	}
+
+	// Inputs config
+	if val := os.Getenv("WORKER_INPUTS_ENABLED"); val != "" {
+		config.Inputs.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_INPUTS_ACCESS_KEY_ID"); val != "" {
+		config.Inputs.AccessKeyID = val
+	}
+	if val := os.Getenv("WORKER_INPUTS_SECRET_ACCESS_KEY"); val != "" {
+		config.Inputs.SecretAccessKey = val
+	}
+	if val := os.Getenv("WORKER_INPUTS_BUCKETS"); val != "" {
+		config.Inputs.Buckets = strings.Split(val, ",")
+	}
+
+	// Sockets config
+	if val := os.Getenv("WORKER_SOCKETS_ENABLED"); val != "" {
+		config.Sockets.Enabled = val == "true" || val == "1"
+	}
+
+	// Warm pool config
+	if val := os.Getenv("WORKER_WARM_POOL_ENABLED"); val != "" {
+		config.WarmPool.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_WARM_POOL_SIZE"); val != "" {
+		if size, err := strconv.Atoi(val); err == nil {
+			config.WarmPool.Size = size
+		}
+	}
+
+	// CloudEvents config
+	if val := os.Getenv("WORKER_CLOUDEVENTS_ENABLED"); val != "" {
+		config.CloudEvents.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_CLOUDEVENTS_URL"); val != "" {
+		config.CloudEvents.URL = val
+	}
+
+	// Fleet config
+	if val := os.Getenv("WORKER_FLEET_ENABLED"); val != "" {
+		config.Fleet.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_FLEET_COORDINATOR"); val != "" {
+		config.Fleet.Coordinator = val
+	}
+	if val := os.Getenv("WORKER_FLEET_NAME"); val != "" {
+		config.Fleet.Name = val
+	}
+	if val := os.Getenv("WORKER_FLEET_ADDRESS"); val != "" {
+		config.Fleet.Address = val
+	}
+
+	// Intake config
+	if val := os.Getenv("WORKER_INTAKE_ENABLED"); val != "" {
+		config.Intake.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_INTAKE_URL"); val != "" {
+		config.Intake.URL = val
+	}
+	if val := os.Getenv("WORKER_INTAKE_ROLE"); val != "" {
+		config.Intake.Role = val
+	}
+
+	// Reconcile config
+	if val := os.Getenv("WORKER_RECONCILE_ENABLED"); val != "" {
+		config.Reconcile.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_RECONCILE_DIR"); val != "" {
+		config.Reconcile.Dir = val
+	}
+
+	// Usage config
+	if val := os.Getenv("WORKER_USAGE_ENABLED"); val != "" {
+		config.Usage.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_USAGE_DIR"); val != "" {
+		config.Usage.Dir = val
+	}
+
+	// Janitor config
+	if val := os.Getenv("WORKER_JANITOR_ENABLED"); val != "" {
+		config.Janitor.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_JANITOR_MAX_BYTES"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Janitor.MaxBytes = size
+		}
+	}
+
+	// Core dump config
+	if val := os.Getenv("WORKER_CORE_DUMPS_ENABLED"); val != "" {
+		config.CoreDumps.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_CORE_DUMPS_MAX_BYTES"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.CoreDumps.MaxBytes = size
+		}
+	}
+
+	// Output buffer config
+	if val := os.Getenv("WORKER_OUTPUT_BUFFER_MAX_BYTES"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.OutputBuffer.MaxBytes = size
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_BUFFER_SPILL_DIR"); val != "" {
+		config.OutputBuffer.SpillDir = val
+	}
+	if val := os.Getenv("WORKER_OUTPUT_FLUSH_INTERVAL"); val != "" {
+		if interval, err := time.ParseDuration(val); err == nil {
+			config.OutputBuffer.FlushInterval = interval
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_FLUSH_BYTES"); val != "" {
+		if size, err := strconv.Atoi(val); err == nil {
+			config.OutputBuffer.FlushBytes = size
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_RATE_LIMIT"); val != "" {
+		if rate, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.OutputBuffer.RateLimit = rate
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_RATE_LIMIT_ACTION"); val != "" {
+		config.OutputBuffer.RateLimitAction = val
+	}
+	if val := os.Getenv("WORKER_OUTPUT_COMPACT_INTERVAL"); val != "" {
+		if interval, err := time.ParseDuration(val); err == nil {
+			config.OutputBuffer.CompactInterval = interval
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_COMPRESS_AFTER"); val != "" {
+		if after, err := time.ParseDuration(val); err == nil {
+			config.OutputBuffer.CompressAfter = after
+		}
+	}
+	if val := os.Getenv("WORKER_OUTPUT_RETENTION"); val != "" {
+		if retention, err := time.ParseDuration(val); err == nil {
+			config.OutputBuffer.Retention = retention
+		}
+	}
+
+	// Fault injection config
+	if val := os.Getenv("WORKER_FAULT_INJECTION_ENABLED"); val != "" {
+		config.FaultInjection.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_FAULT_INJECTION_SEED"); val != "" {
+		if seed, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.FaultInjection.Seed = seed
+		}
+	}
+
+	// Tracing config
+	if val := os.Getenv("WORKER_TRACING_ENABLED"); val != "" {
+		config.Tracing.Enabled = val == "true" || val == "1"
+	}
+	if val := os.Getenv("WORKER_TRACING_ENDPOINT"); val != "" {
+		config.Tracing.Endpoint = val
+	}
+
+	// Workspace config
+	if val := os.Getenv("WORKER_WORKSPACE_BASE_DIR"); val != "" {
+		config.Workspace.BaseDir = val
+	}
+	if val := os.Getenv("WORKER_WORKSPACE_RETENTION"); val != "" {
+		if retention, err := time.ParseDuration(val); err == nil {
+			config.Workspace.Retention = retention
+		}
+	}
+	if val := os.Getenv("WORKER_WORKSPACE_MAX_UPLOAD_SIZE"); val != "" {
+		if size, err := strconv.ParseInt(val, 10, 64); err == nil {
+			config.Workspace.MaxUploadSize = size
+		}
+	}
+
 	return nil
 }
 
@@ -319,10 +1164,30 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid server port: %d", c.Server.Port)
 	}
 
-	if c.Server.Mode != "server" && c.Server.Mode != "init" {
+	switch c.Server.Mode {
+	case "server", "init", "coordinator":
+	case "agent":
+		if !c.Intake.Enabled && !c.Reconcile.Enabled {
+			return fmt.Errorf("agent mode requires intake or reconcile to be enabled")
+		}
+	default:
 		return fmt.Errorf("invalid server mode: %s", c.Server.Mode)
 	}
 
+	if c.Server.Mode == "coordinator" {
+		if err := c.Coordinator.validate(); err != nil {
+			return err
+		}
+	}
+
+	if err := c.GRPC.validate(); err != nil {
+		return err
+	}
+
+	if err := c.Security.validate(); err != nil {
+		return err
+	}
+
 	if c.Worker.DefaultCPULimit < 0 {
 		return fmt.Errorf("invalid default CPU limit: %d", c.Worker.DefaultCPULimit)
 	}
@@ -331,10 +1196,77 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("invalid default memory limit: %d", c.Worker.DefaultMemoryLimit)
 	}
 
+	if c.Worker.CommandCacheTTL < 0 {
+		return fmt.Errorf("invalid command cache TTL: %v", c.Worker.CommandCacheTTL)
+	}
+	if c.Worker.WatchdogDeadline < 0 {
+		return fmt.Errorf("invalid watchdog deadline: %v", c.Worker.WatchdogDeadline)
+	}
+
+	if c.Worker.DefaultCPUMillis < 0 || c.Worker.DefaultMemoryBytes < 0 || c.Worker.DefaultIOBPS < 0 {
+		return fmt.Errorf("invalid default limits: cpu %dm, memory %d bytes, io %d bytes/s",
+			c.Worker.DefaultCPUMillis, c.Worker.DefaultMemoryBytes, c.Worker.DefaultIOBPS)
+	}
+
+	for name, value := range c.Worker.BaseEnv {
+		if name == "" || strings.ContainsAny(name, "=\x00") || strings.HasPrefix(name, "JOB_") || strings.HasPrefix(name, "WORKER_") {
+			return fmt.Errorf("invalid base environment variable %q", name)
+		}
+		if name == "PATH" {
+			for _, dir := range filepath.SplitList(value) {
+				if !filepath.IsAbs(dir) {
+					return fmt.Errorf("invalid base PATH %q: %q is not an absolute directory", value, dir)
+				}
+			}
+		}
+	}
+
+	for name, profile := range c.Worker.LimitProfiles {
+		if name == "" || profile.CPUMillis < 0 || profile.MemoryBytes < 0 || profile.IOBPS < 0 {
+			return fmt.Errorf("invalid limit profile %q: cpu %dm, memory %d bytes, io %d bytes/s",
+				name, profile.CPUMillis, profile.MemoryBytes, profile.IOBPS)
+		}
+	}
+
+	for arch, path := range c.Worker.InitBinaries {
+		if arch == "" || !filepath.IsAbs(path) {
+			return fmt.Errorf("invalid init binary for architecture %q: path %q is not absolute", arch, path)
+		}
+	}
+
+	for name, runtime := range c.Worker.Runtimes {
+		if name == "" || !filepath.IsAbs(runtime.Path) {
+			return fmt.Errorf("invalid runtime %q: path %q is not absolute", name, runtime.Path)
+		}
+	}
+
+	switch c.Worker.Isolation {
+	case "full", "cgroups", "process":
+	default:
+		return fmt.Errorf("invalid isolation mode: %q", c.Worker.Isolation)
+	}
+	switch c.Worker.Preflight {
+	case PreflightStrict, PreflightDegraded, PreflightOff:
+	default:
+		return fmt.Errorf("invalid preflight mode: %q", c.Worker.Preflight)
+	}
+
 	if c.Worker.MaxConcurrentJobs < 1 {
 		return fmt.Errorf("invalid max concurrent jobs: %d", c.Worker.MaxConcurrentJobs)
 	}
 
+	if c.Worker.RestartBackoff <= 0 || c.Worker.RestartMaxBackoff < c.Worker.RestartBackoff {
+		return fmt.Errorf("invalid restart backoff: %v (max %v)", c.Worker.RestartBackoff, c.Worker.RestartMaxBackoff)
+	}
+
+	if c.Worker.MaxRestarts < 1 {
+		return fmt.Errorf("invalid max restarts: %d", c.Worker.MaxRestarts)
+	}
+
+	if c.Worker.CrashLoopThreshold < 0 || c.Worker.CrashLoopThreshold > 0 && c.Worker.CrashLoopWindow <= 0 {
+		return fmt.Errorf("invalid crash loop threshold %d or window %v", c.Worker.CrashLoopThreshold, c.Worker.CrashLoopWindow)
+	}
+
 	// Validate certificate paths
 	if c.Security.ServerCertPath == "" {
 		return fmt.Errorf("server certificate path required when TLS is enabled")
@@ -350,6 +1282,12 @@ func (c *Config) Validate() error {
 	if !filepath.IsAbs(c.Cgroup.BaseDir) {
 		return fmt.Errorf("cgroup base directory must be absolute path: %s", c.Cgroup.BaseDir)
 	}
+	if c.Cgroup.CleanupWorkers < 1 || c.Cgroup.CleanupQueueSize < 0 {
+		return fmt.Errorf("invalid cgroup cleanup pool: %d workers, queue size %d", c.Cgroup.CleanupWorkers, c.Cgroup.CleanupQueueSize)
+	}
+	if c.Cgroup.IODevice != "" && !validDevice(c.Cgroup.IODevice) {
+		return fmt.Errorf("invalid cgroup IO device %q, expected major:minor", c.Cgroup.IODevice)
+	}
 
 	// Validate logging level
 	validLevels := map[string]bool{
@@ -359,10 +1297,246 @@ func (c *Config) Validate() error {
 	if !validLevels[c.Logging.Level] {
 		return fmt.Errorf("invalid log level: %s", c.Logging.Level)
 	}
+	for component, level := range c.Logging.Components {
+		if !validLevels[level] {
+			return fmt.Errorf("invalid log level for component %s: %s", component, level)
+		}
+	}
+	switch c.Logging.Format {
+	case "", "text", "console", "json":
+	default:
+		return fmt.Errorf("invalid log format: %s", c.Logging.Format)
+	}
+	if c.Logging.MaxSize < 0 || c.Logging.MaxBackups < 0 {
+		return fmt.Errorf("invalid log rotation: maxSize %d, maxBackups %d", c.Logging.MaxSize, c.Logging.MaxBackups)
+	}
+
+	// Validate log shipping sinks
+	if c.LogShipping.Enabled {
+		for i, sink := range c.LogShipping.Sinks {
+			switch sink.Type {
+			case "syslog":
+			case "loki", "fluentd":
+				if sink.Address == "" {
+					return fmt.Errorf("log sink %d (%s) requires an address", i, sink.Type)
+				}
+			default:
+				return fmt.Errorf("invalid log sink type: %s", sink.Type)
+			}
+		}
+	}
+
+	if c.Offload.Enabled {
+		if c.Offload.Type != "s3" && c.Offload.Type != "gcs" {
+			return fmt.Errorf("invalid offload type: %s", c.Offload.Type)
+		}
+		if c.Offload.Bucket == "" {
+			return fmt.Errorf("offload requires a bucket")
+		}
+	}
+
+	for i, blackout := range c.Schedule.Blackouts {
+		if blackout.Window == "" {
+			return fmt.Errorf("schedule blackout %d has no window", i)
+		}
+	}
+
+	if c.Inputs.Enabled {
+		if c.Inputs.Type != "s3" && c.Inputs.Type != "gcs" {
+			return fmt.Errorf("invalid inputs type: %s", c.Inputs.Type)
+		}
+		if c.Inputs.MaxInputs < 1 {
+			return fmt.Errorf("inputs max inputs must be at least 1: %d", c.Inputs.MaxInputs)
+		}
+		if c.Inputs.FetchTimeout <= 0 {
+			return fmt.Errorf("invalid inputs fetch timeout: %v", c.Inputs.FetchTimeout)
+		}
+	}
+
+	if c.Sockets.Enabled && !filepath.IsAbs(c.Sockets.UnixDir) {
+		return fmt.Errorf("sockets unix directory must be absolute: %q", c.Sockets.UnixDir)
+	}
+
+	if c.Workspace.BaseDir == "" {
+		return fmt.Errorf("workspace base directory cannot be empty")
+	}
+
+	if c.Workspace.MountPath != "" && !filepath.IsAbs(c.Workspace.MountPath) {
+		return fmt.Errorf("workspace mount path must be absolute: %s", c.Workspace.MountPath)
+	}
+
+	if c.Workspace.Retention < 0 {
+		return fmt.Errorf("invalid workspace retention: %v", c.Workspace.Retention)
+	}
+
+	if c.Secrets.Enabled && (c.Secrets.Dir == "" || c.Secrets.KeyFile == "") {
+		return fmt.Errorf("secrets store requires both dir and keyFile")
+	}
+
+	if c.WarmPool.Enabled && (c.WarmPool.Size < 1 || c.WarmPool.TTL <= 0) {
+		return fmt.Errorf("invalid warm pool: size %d, ttl %v", c.WarmPool.Size, c.WarmPool.TTL)
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" {
+		return fmt.Errorf("tracing requires an endpoint")
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		return fmt.Errorf("invalid tracing sample ratio: %v", c.Tracing.SampleRatio)
+	}
+
+	if c.CloudEvents.Enabled {
+		if c.CloudEvents.Transport != "http" && c.CloudEvents.Transport != "nats" {
+			return fmt.Errorf("unsupported cloudevents transport: %s", c.CloudEvents.Transport)
+		}
+		if c.CloudEvents.URL == "" {
+			return fmt.Errorf("cloudevents requires a url")
+		}
+		if c.CloudEvents.Transport == "nats" && c.CloudEvents.Subject == "" {
+			return fmt.Errorf("cloudevents over nats requires a subject")
+		}
+		if c.CloudEvents.QueueSize < 1 {
+			return fmt.Errorf("invalid cloudevents queue size: %d", c.CloudEvents.QueueSize)
+		}
+	}
+
+	if c.Fleet.Enabled {
+		if c.Fleet.Coordinator == "" {
+			return fmt.Errorf("joining a fleet requires the coordinator address")
+		}
+		if strings.Contains(c.Fleet.Name, "/") {
+			return fmt.Errorf("invalid fleet worker name: %s", c.Fleet.Name)
+		}
+		if c.Fleet.Interval <= 0 {
+			return fmt.Errorf("invalid fleet heartbeat interval: %v", c.Fleet.Interval)
+		}
+	}
+
+	if c.Intake.Enabled {
+		if c.Intake.URL == "" {
+			return fmt.Errorf("intake requires a url")
+		}
+		switch c.Intake.Transport {
+		case "nats":
+			if c.Intake.Subject == "" {
+				return fmt.Errorf("intake over nats requires a subject")
+			}
+		case "jetstream":
+			if c.Intake.Stream == "" || c.Intake.Consumer == "" {
+				return fmt.Errorf("intake over jetstream requires a stream and a consumer")
+			}
+		default:
+			return fmt.Errorf("unsupported intake transport: %s", c.Intake.Transport)
+		}
+		if c.Intake.Role != "submitter" && c.Intake.Role != "admin" {
+			return fmt.Errorf("invalid intake role %q: must be submitter or admin", c.Intake.Role)
+		}
+		if c.Intake.Timeout <= 0 {
+			return fmt.Errorf("invalid intake timeout: %v", c.Intake.Timeout)
+		}
+	}
+
+	if c.Reconcile.Enabled {
+		if c.Reconcile.Dir == "" {
+			return fmt.Errorf("reconcile requires a spec directory")
+		}
+		if c.Reconcile.Interval <= 0 {
+			return fmt.Errorf("invalid reconcile interval: %v", c.Reconcile.Interval)
+		}
+	}
+
+	if c.Usage.Enabled {
+		if c.Usage.Dir == "" || c.Usage.TenantLabel == "" {
+			return fmt.Errorf("usage accounting requires a dir and a tenant label")
+		}
+		if c.Usage.Interval <= 0 {
+			return fmt.Errorf("invalid usage sampling interval: %v", c.Usage.Interval)
+		}
+	}
+
+	if c.Janitor.Enabled {
+		if c.Janitor.MaxBytes <= 0 {
+			return fmt.Errorf("invalid janitor disk budget: %d", c.Janitor.MaxBytes)
+		}
+		if c.Janitor.LowWatermark < 1 || c.Janitor.LowWatermark > 100 {
+			return fmt.Errorf("invalid janitor low watermark: %d, expected 1 to 100", c.Janitor.LowWatermark)
+		}
+		if c.Janitor.Interval <= 0 || c.Janitor.MinAge < 0 {
+			return fmt.Errorf("invalid janitor interval %v or min age %v", c.Janitor.Interval, c.Janitor.MinAge)
+		}
+	}
+
+	if c.CoreDumps.Enabled && c.CoreDumps.MaxBytes <= 0 {
+		return fmt.Errorf("invalid core dump size limit: %d", c.CoreDumps.MaxBytes)
+	}
+
+	if c.OutputBuffer.MaxBytes < 0 {
+		return fmt.Errorf("invalid output buffer budget: %d", c.OutputBuffer.MaxBytes)
+	}
+	if c.OutputBuffer.MaxBytes > 0 {
+		if c.OutputBuffer.WarnPercent < 1 || c.OutputBuffer.WarnPercent > 100 {
+			return fmt.Errorf("invalid output buffer warning threshold: %d, expected 1 to 100", c.OutputBuffer.WarnPercent)
+		}
+		if c.OutputBuffer.SpillDir == "" {
+			return fmt.Errorf("an output buffer budget requires a spill dir")
+		}
+	}
+	if c.OutputBuffer.FlushInterval < 0 || c.OutputBuffer.FlushInterval > 10*time.Second {
+		return fmt.Errorf("invalid output flush interval: %v, expected 0 to 10s", c.OutputBuffer.FlushInterval)
+	}
+	if c.OutputBuffer.FlushBytes < 1 {
+		return fmt.Errorf("invalid output flush size: %d, must be positive", c.OutputBuffer.FlushBytes)
+	}
+	if c.OutputBuffer.RateLimit != 0 && c.OutputBuffer.RateLimit < 1024 {
+		return fmt.Errorf("invalid output rate limit: %d, expected 0 or at least 1024 bytes per second", c.OutputBuffer.RateLimit)
+	}
+	switch c.OutputBuffer.RateLimitAction {
+	case "throttle", "truncate":
+	default:
+		return fmt.Errorf("invalid output rate limit action: %q, expected throttle or truncate", c.OutputBuffer.RateLimitAction)
+	}
+	if c.OutputBuffer.CompactInterval < 0 || c.OutputBuffer.CompressAfter < 0 || c.OutputBuffer.Retention < 0 {
+		return fmt.Errorf("invalid output compaction: interval %v, compress after %v, retention %v",
+			c.OutputBuffer.CompactInterval, c.OutputBuffer.CompressAfter, c.OutputBuffer.Retention)
+	}
+
+	if c.FaultInjection.Enabled {
+		for name, rate := range map[string]float64{
+			"cgroup write": c.FaultInjection.CgroupWriteFailRate,
+			"start":        c.FaultInjection.StartFailRate,
+			"kill":         c.FaultInjection.KillFailRate,
+		} {
+			if rate < 0 || rate > 1 {
+				return fmt.Errorf("invalid fault injection %s fail rate: %v, expected 0 to 1", name, rate)
+			}
+		}
+		if c.FaultInjection.StartDelay < 0 {
+			return fmt.Errorf("invalid fault injection start delay: %v", c.FaultInjection.StartDelay)
+		}
+	}
+
+	for _, hook := range slices.Concat(c.Hooks.PreStart, c.Hooks.PostStop) {
+		if (len(hook.Command) == 0) == (hook.URL == "") {
+			return fmt.Errorf("hook %q needs exactly one of command and url", hook.Name)
+		}
+		if hook.Timeout < 0 {
+			return fmt.Errorf("invalid timeout for hook %q: %v", hook.Name, hook.Timeout)
+		}
+	}
 
 	return nil
 }
 
+// validDevice reports whether device is a major:minor device number
+func validDevice(device string) bool {
+	major, minor, ok := strings.Cut(device, ":")
+	if !ok {
+		return false
+	}
+	_, errMajor := strconv.ParseUint(major, 10, 32)
+	_, errMinor := strconv.ParseUint(minor, 10, 32)
+	return errMajor == nil && errMinor == nil
+}
+
 func (c *Config) GetServerAddress() string {
 	return fmt.Sprintf("%s:%d", c.Server.Address, c.Server.Port)
 }
@@ -418,3 +1592,82 @@ func (c *Config) IsProductionMode() bool {
 func (c *Config) IsDevelopmentMode() bool {
 	return c.Logging.Level == "DEBUG"
 }
+
+func (c *CoordinatorConfig) validate() error {
+	names := make(map[string]bool, len(c.Workers))
+	for _, w := range c.Workers {
+		if w.Name == "" || strings.Contains(w.Name, "/") || w.Address == "" {
+			return fmt.Errorf("invalid coordinator worker %q at %q", w.Name, w.Address)
+		}
+		if names[w.Name] {
+			return fmt.Errorf("duplicate coordinator worker name: %s", w.Name)
+		}
+		names[w.Name] = true
+	}
+	if c.HeartbeatInterval <= 0 || c.HeartbeatTimeout < c.HeartbeatInterval || c.DispatchTimeout <= 0 {
+		return fmt.Errorf("invalid coordinator timing: heartbeat every %v, timeout %v, dispatch timeout %v",
+			c.HeartbeatInterval, c.HeartbeatTimeout, c.DispatchTimeout)
+	}
+	return nil
+}
+
+func (c *SecurityConfig) validate() error {
+	for _, cidr := range c.AllowedClientCIDRs {
+		if _, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err != nil {
+			return fmt.Errorf("invalid allowed client CIDR %q: %w", cidr, err)
+		}
+	}
+
+	clients := make(map[string]bool, len(c.SourceRestrictions))
+	for _, r := range c.SourceRestrictions {
+		if r.Client == "" || len(r.CIDRs) == 0 {
+			return fmt.Errorf("invalid source restriction for client %q: needs a client and its CIDRs", r.Client)
+		}
+		if clients[r.Client] {
+			return fmt.Errorf("duplicate source restriction for client %q", r.Client)
+		}
+		clients[r.Client] = true
+		for _, cidr := range r.CIDRs {
+			if _, err := netip.ParsePrefix(strings.TrimSpace(cidr)); err != nil {
+				return fmt.Errorf("invalid source restriction for client %q: CIDR %q: %w", r.Client, cidr, err)
+			}
+		}
+	}
+
+	if c.SpecSigning.Required && len(c.SpecSigning.Keys) == 0 {
+		return fmt.Errorf("signed job specs are required but no signing keys are configured")
+	}
+	keys := make(map[string]bool, len(c.SpecSigning.Keys))
+	for _, k := range c.SpecSigning.Keys {
+		if k.Name == "" || k.PublicKey == "" {
+			return fmt.Errorf("invalid spec signing key %q at %q: needs a name and a public key", k.Name, k.PublicKey)
+		}
+		if keys[k.Name] {
+			return fmt.Errorf("duplicate spec signing key name: %s", k.Name)
+		}
+		keys[k.Name] = true
+	}
+	return nil
+}
+
+func (c *GRPCConfig) validate() error {
+	if c.MaxRecvMsgSize < 1 || c.MaxSendMsgSize < 1 || c.MaxHeaderListSize < 1 {
+		return fmt.Errorf("invalid gRPC message sizes: receive %d, send %d, header list %d, must be positive",
+			c.MaxRecvMsgSize, c.MaxSendMsgSize, c.MaxHeaderListSize)
+	}
+	if c.MaxSubmitSize < 1 {
+		return fmt.Errorf("invalid gRPC max submit size: %d, must be positive", c.MaxSubmitSize)
+	}
+	if c.KeepAliveTime < 0 || c.KeepAliveTimeout < 0 || c.KeepAliveMinTime < 0 {
+		return fmt.Errorf("invalid gRPC keepalive: time %v, timeout %v, min time %v",
+			c.KeepAliveTime, c.KeepAliveTimeout, c.KeepAliveMinTime)
+	}
+	if c.MaxConnections < 0 {
+		return fmt.Errorf("invalid gRPC max connections: %d", c.MaxConnections)
+	}
+	if c.MaxConnectionIdle < 0 || c.MaxConnectionAge < 0 || c.MaxConnectionAgeGrace < 0 {
+		return fmt.Errorf("invalid gRPC connection limits: idle %v, age %v, age grace %v",
+			c.MaxConnectionIdle, c.MaxConnectionAge, c.MaxConnectionAgeGrace)
+	}
+	return nil
+}
@@ -1,11 +1,14 @@
 package logger
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
-	"log"
 	"os"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -34,10 +37,104 @@ func (l LogLevel) String() string {
 	}
 }
 
+// Format selects how log lines are rendered
+type Format string
+
+const (
+	FormatText Format = "text" // [time] [LEVEL] message | key=value ...
+	FormatJSON Format = "json" // one JSON object per line
+)
+
+// ParseFormat accepts "text", "console" (an alias of text) and "json"
+func ParseFormat(format string) (Format, error) {
+	switch strings.ToLower(format) {
+	case "", "text", "console":
+		return FormatText, nil
+	case "json":
+		return FormatJSON, nil
+	default:
+		return FormatText, fmt.Errorf("unknown log format: %s", format)
+	}
+}
+
+// Options configure where and how every logger writes, see Configure
+type Options struct {
+	Level      LogLevel
+	Components map[string]LogLevel // level per "component" field, overriding Level
+	Format     Format
+	Sink       Sink // stdout if nil
+}
+
+// core is the destination shared by loggers: a sink, a format and the levels.
+// It is replaced, never modified, so loggers read it without locking.
+type core struct {
+	level      LogLevel
+	components map[string]LogLevel
+	format     Format
+	sink       Sink
+	sinkMu     *sync.Mutex // serializes writes to the sink
+}
+
+func (c *core) levelFor(component string) LogLevel {
+	if level, exists := c.components[component]; exists {
+		return level
+	}
+	return c.level
+}
+
+var current atomic.Pointer[core]
+
+func init() {
+	current.Store(&core{level: INFO, format: FormatText, sink: NewWriterSink(os.Stdout), sinkMu: &sync.Mutex{}})
+}
+
+// Configure sets the destination, format and levels of every logger that was
+// not created with its own Config. The previous sink is closed.
+func Configure(opts Options) {
+	sink := opts.Sink
+	if sink == nil {
+		sink = NewWriterSink(os.Stdout)
+	}
+	components := make(map[string]LogLevel, len(opts.Components))
+	for name, level := range opts.Components {
+		components[name] = level
+	}
+
+	previous := current.Swap(&core{
+		level:      opts.Level,
+		components: components,
+		format:     opts.Format,
+		sink:       sink,
+		sinkMu:     &sync.Mutex{},
+	})
+	if previous.sink != sink {
+		previous.sinkMu.Lock()
+		_ = previous.sink.Close()
+		previous.sinkMu.Unlock()
+	}
+}
+
+// SetComponentLevel changes the level of the loggers of one component at runtime
+func SetComponentLevel(component string, level LogLevel) {
+	for {
+		old := current.Load()
+		next := *old
+		next.components = make(map[string]LogLevel, len(old.components)+1)
+		for name, l := range old.components {
+			next.components[name] = l
+		}
+		next.components[component] = level
+		if current.CompareAndSwap(old, &next) {
+			return
+		}
+	}
+}
+
 type Logger struct {
-	level  LogLevel
-	logger *log.Logger
-	fields map[string]interface{}
+	level   *LogLevel // set by SetLevel or Config, nil to follow the configured levels
+	own     *core     // private destination from NewWithConfig, nil for the shared one
+	sampler *sampler  // set by Sampled, nil to write every entry
+	fields  map[string]interface{}
 }
 
 type Config struct {
@@ -46,32 +143,33 @@ type Config struct {
 	Format string // "json" or "text" (default)
 }
 
+// New returns a logger that follows the configuration set with Configure
 func New() *Logger {
-	return NewWithConfig(Config{
-		Level:  INFO,
-		Output: os.Stdout,
-		Format: "text",
-	})
+	return &Logger{fields: make(map[string]interface{})}
 }
 
+// NewWithConfig returns a logger with its own output, format and level,
+// unaffected by Configure
 func NewWithConfig(config Config) *Logger {
 	if config.Output == nil {
 		config.Output = os.Stdout
 	}
+	format, _ := ParseFormat(config.Format)
+	level := config.Level
 
 	return &Logger{
-		level: config.Level,
-		// no default prefix/flags, we'll format ourselves
-		logger: log.New(config.Output, "", 0),
+		level:  &level,
+		own:    &core{level: level, format: format, sink: NewWriterSink(config.Output), sinkMu: &sync.Mutex{}},
 		fields: make(map[string]interface{}),
 	}
 }
 
 func (l *Logger) WithFields(keyVals ...interface{}) *Logger {
 	newLogger := &Logger{
-		level:  l.level,
-		logger: l.logger,
-		fields: make(map[string]interface{}),
+		level:   l.level,
+		own:     l.own,
+		sampler: l.sampler,
+		fields:  make(map[string]interface{}),
 	}
 
 	// copy existing fields
@@ -90,6 +188,17 @@ func (l *Logger) WithFields(keyVals ...interface{}) *Logger {
 	return newLogger
 }
 
+// Sampled returns a logger that writes at most perSecond entries with the same
+// level and message each second, for hot paths that would otherwise flood the
+// log. The loggers derived from it share the limit. The first entry written
+// in a new second carries the number of entries suppressed in the previous
+// one as the "suppressed" field.
+func (l *Logger) Sampled(perSecond int) *Logger {
+	newLogger := l.WithFields()
+	newLogger.sampler = newSampler(perSecond)
+	return newLogger
+}
+
 // WithField returns a new logger with a single additional context field
 func (l *Logger) WithField(key string, value interface{}) *Logger {
 	return l.WithFields(key, value)
@@ -122,12 +231,29 @@ func (l *Logger) Fatalf(format string, args ...interface{}) {
 	os.Exit(1)
 }
 
+func (l *Logger) destination() *core {
+	if l.own != nil {
+		return l.own
+	}
+	return current.Load()
+}
+
 func (l *Logger) log(level LogLevel, msg string, kv ...interface{}) {
-	if level < l.level {
+	c := l.destination()
+	if level < l.effectiveLevel(c) {
 		return
 	}
 
-	timestamp := time.Now().Format("2006-01-02T15:04:05.000Z07:00")
+	now := time.Now()
+	suppressed := 0
+	if l.sampler != nil {
+		var allowed bool
+		if allowed, suppressed = l.sampler.allow(level, msg, now); !allowed {
+			return
+		}
+	}
+
+	timestamp := now.Format("2006-01-02T15:04:05.000Z07:00")
 
 	allFields := make(map[string]interface{})
 	for k, v := range l.fields {
@@ -141,10 +267,28 @@ func (l *Logger) log(level LogLevel, msg string, kv ...interface{}) {
 			allFields[key] = kv[i+1]
 		}
 	}
+	if suppressed > 0 {
+		allFields["suppressed"] = suppressed
+	}
+
+	var logLine string
+	if c.format == FormatJSON {
+		logLine = formatJSONLine(timestamp, level, msg, allFields)
+	} else {
+		logLine = l.formatLogLine(timestamp, level, msg, allFields)
+	}
 
-	logLine := l.formatLogLine(timestamp, level, msg, allFields)
+	c.sinkMu.Lock()
+	_ = c.sink.Write(level, logLine)
+	c.sinkMu.Unlock()
+}
 
-	l.logger.Print(logLine)
+func (l *Logger) effectiveLevel(c *core) LogLevel {
+	if l.level != nil {
+		return *l.level
+	}
+	component, _ := l.fields["component"].(string)
+	return c.levelFor(component)
 }
 
 func (l *Logger) formatLogLine(timestamp string, level LogLevel, msg string, fields map[string]interface{}) string {
@@ -157,8 +301,8 @@ func (l *Logger) formatLogLine(timestamp string, level LogLevel, msg string, fie
 	// Add fields
 	if len(fields) > 0 {
 		var fieldParts []string
-		for key, value := range fields {
-			fieldParts = append(fieldParts, fmt.Sprintf("%s=%v", key, formatValue(value)))
+		for _, key := range sortedKeys(fields) {
+			fieldParts = append(fieldParts, fmt.Sprintf("%s=%v", key, formatValue(fields[key])))
 		}
 		if len(fieldParts) > 0 {
 			parts = append(parts, fmt.Sprintf("| %s", strings.Join(fieldParts, " ")))
@@ -168,6 +312,64 @@ func (l *Logger) formatLogLine(timestamp string, level LogLevel, msg string, fie
 	return strings.Join(parts, " ")
 }
 
+// formatJSONLine renders the entry as a JSON object with time, level and msg
+// first, then the fields in key order
+func formatJSONLine(timestamp string, level LogLevel, msg string, fields map[string]interface{}) string {
+	var b strings.Builder
+	b.WriteString(`{"time":`)
+	writeJSON(&b, timestamp)
+	b.WriteString(`,"level":`)
+	writeJSON(&b, level.String())
+	b.WriteString(`,"msg":`)
+	writeJSON(&b, msg)
+
+	for _, key := range sortedKeys(fields) {
+		if key == "time" || key == "level" || key == "msg" {
+			continue
+		}
+		b.WriteByte(',')
+		writeJSON(&b, key)
+		b.WriteByte(':')
+		writeJSON(&b, jsonValue(fields[key]))
+	}
+
+	b.WriteByte('}')
+	return b.String()
+}
+
+func writeJSON(b *strings.Builder, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprintf("%v", v))
+	}
+	b.Write(data)
+}
+
+// jsonValue converts values whose JSON encoding would lose what they say
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case error:
+		return v.Error()
+	case time.Duration:
+		return v.String()
+	case time.Time:
+		return v.Format("2006-01-02T15:04:05Z07:00")
+	case fmt.Stringer:
+		return v.String()
+	default:
+		return v
+	}
+}
+
+func sortedKeys(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for k := range fields {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 func formatValue(value interface{}) string {
 	switch v := value.(type) {
 	case string:
@@ -187,20 +389,22 @@ func formatValue(value interface{}) string {
 	}
 }
 
+// SetLevel fixes the level of this logger and the loggers derived from it
+// afterwards, ignoring the configured levels
 func (l *Logger) SetLevel(level LogLevel) {
-	l.level = level
+	l.level = &level
 }
 
 func (l *Logger) GetLevel() LogLevel {
-	return l.level
+	return l.effectiveLevel(l.destination())
 }
 
 func (l *Logger) IsDebugEnabled() bool {
-	return l.level <= DEBUG
+	return l.GetLevel() <= DEBUG
 }
 
 func (l *Logger) IsInfoEnabled() bool {
-	return l.level <= INFO
+	return l.GetLevel() <= INFO
 }
 
 // global logger instance for the convenience
@@ -238,8 +442,17 @@ func WithField(key string, value interface{}) *Logger {
 	return globalLogger.WithField(key, value)
 }
 
+// SetLevel changes the configured level of every logger at runtime. Component
+// levels still take precedence.
 func SetLevel(level LogLevel) {
-	globalLogger.SetLevel(level)
+	for {
+		old := current.Load()
+		next := *old
+		next.level = level
+		if current.CompareAndSwap(old, &next) {
+			return
+		}
+	}
 }
 
 func ParseLevel(level string) (LogLevel, error) {
@@ -0,0 +1,147 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// capture configures every logger to write to a buffer until the test ends
+func capture(t *testing.T, opts Options) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	opts.Sink = NewWriterSink(&buf)
+	Configure(opts)
+	t.Cleanup(func() { Configure(Options{Level: INFO}) })
+	return &buf
+}
+
+func TestJSONFormat(t *testing.T) {
+	buf := capture(t, Options{Level: INFO, Format: FormatJSON})
+
+	New().WithField("component", "store").Info("job stored", "jobId", "7", "error", errors.New("busy"), "took", time.Second)
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected one JSON object, got %q: %v", buf.String(), err)
+	}
+	want := map[string]interface{}{"level": "INFO", "msg": "job stored", "component": "store", "jobId": "7", "error": "busy", "took": "1s"}
+	for k, v := range want {
+		if entry[k] != v {
+			t.Errorf("%s = %v, want %v", k, entry[k], v)
+		}
+	}
+	if !strings.HasPrefix(buf.String(), `{"time":`) {
+		t.Errorf("expected time first, got %q", buf.String())
+	}
+}
+
+func TestComponentLevels(t *testing.T) {
+	buf := capture(t, Options{Level: WARN, Components: map[string]LogLevel{"store": DEBUG}})
+
+	New().WithField("component", "store").Debug("store detail")
+	New().WithField("component", "grpc-service").Info("rpc detail")
+	if out := buf.String(); !strings.Contains(out, "store detail") || strings.Contains(out, "rpc detail") {
+		t.Errorf("unexpected output %q", out)
+	}
+
+	SetComponentLevel("grpc-service", INFO)
+	New().WithField("component", "grpc-service").Info("rpc detail")
+	if !strings.Contains(buf.String(), "rpc detail") {
+		t.Error("expected the component level change to apply to existing loggers")
+	}
+}
+
+func TestNewWithConfigIgnoresConfigure(t *testing.T) {
+	capture(t, Options{Level: ERROR})
+
+	var own bytes.Buffer
+	NewWithConfig(Config{Level: DEBUG, Output: &own}).Debug("kept")
+	if !strings.Contains(own.String(), "kept") {
+		t.Errorf("expected own output and level, got %q", own.String())
+	}
+}
+
+func TestFileSinkRotation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "worker.log")
+	sink, err := NewFileSink(path, 20, 2)
+	if err != nil {
+		t.Fatalf("NewFileSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	for _, line := range []string{"first line 1", "second line", "third line", "fourth line"} {
+		if err := sink.Write(INFO, line); err != nil {
+			t.Fatalf("Write failed: %v", err)
+		}
+	}
+
+	for file, want := range map[string]string{path: "fourth line\n", path + ".1": "third line\n", path + ".2": "second line\n"} {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("ReadFile failed: %v", err)
+		}
+		if string(data) != want {
+			t.Errorf("%s = %q, want %q", filepath.Base(file), data, want)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected only two backups to be kept")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	if f, err := ParseFormat("console"); err != nil || f != FormatText {
+		t.Errorf("console = %v, %v", f, err)
+	}
+	if f, err := ParseFormat("JSON"); err != nil || f != FormatJSON {
+		t.Errorf("JSON = %v, %v", f, err)
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestSampledLogger(t *testing.T) {
+	buf := capture(t, Options{Level: DEBUG})
+
+	log := New().Sampled(2)
+	for i := 0; i < 5; i++ {
+		log.WithField("jobId", i).Debug("log chunk sent")
+	}
+	log.Debug("other message")
+
+	if n := strings.Count(buf.String(), "log chunk sent"); n != 2 {
+		t.Errorf("expected 2 sampled entries, got %d in %q", n, buf.String())
+	}
+	if !strings.Contains(buf.String(), "other message") {
+		t.Error("expected a different message to have its own limit")
+	}
+}
+
+func TestSamplerReportsSuppressed(t *testing.T) {
+	s := newSampler(1)
+	start := time.Now()
+
+	if ok, _ := s.allow(DEBUG, "chunk", start); !ok {
+		t.Fatal("expected the first entry to be written")
+	}
+	for i := 0; i < 3; i++ {
+		if ok, _ := s.allow(DEBUG, "chunk", start.Add(time.Millisecond)); ok {
+			t.Fatal("expected entries over the limit to be suppressed")
+		}
+	}
+	if ok, _ := s.allow(INFO, "chunk", start); !ok {
+		t.Error("expected another level to have its own limit")
+	}
+
+	ok, suppressed := s.allow(DEBUG, "chunk", start.Add(time.Second))
+	if !ok || suppressed != 3 {
+		t.Errorf("allow in the next second = %v, %d; want true, 3", ok, suppressed)
+	}
+}
@@ -0,0 +1,69 @@
+package logger
+
+import (
+	"sync"
+	"time"
+)
+
+// maxSampledMessages bounds the distinct messages a sampler tracks. Messages
+// are constant strings in practice; the bound only guards against callers
+// that format values into them.
+const maxSampledMessages = 1024
+
+// sampler limits how many entries with the same level and message are written
+// per second. It is shared by a sampled logger and the loggers derived from it,
+// so the limit holds across jobs, not per job.
+type sampler struct {
+	perSecond int
+
+	mutex   sync.Mutex
+	windows map[samplerKey]*sampleWindow
+}
+
+type samplerKey struct {
+	level LogLevel
+	msg   string
+}
+
+type sampleWindow struct {
+	start      time.Time
+	written    int
+	suppressed int
+}
+
+func newSampler(perSecond int) *sampler {
+	return &sampler{
+		perSecond: perSecond,
+		windows:   make(map[samplerKey]*sampleWindow),
+	}
+}
+
+// allow reports whether the entry is written and, for the first entry of a
+// new second, how many similar entries the previous second suppressed
+func (s *sampler) allow(level LogLevel, msg string, now time.Time) (bool, int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	key := samplerKey{level: level, msg: msg}
+	w, exists := s.windows[key]
+	if !exists {
+		if len(s.windows) >= maxSampledMessages {
+			s.windows = make(map[samplerKey]*sampleWindow)
+		}
+		w = &sampleWindow{start: now}
+		s.windows[key] = w
+	}
+
+	suppressed := 0
+	if now.Sub(w.start) >= time.Second {
+		suppressed = w.suppressed
+		*w = sampleWindow{start: now}
+	}
+
+	if w.written >= s.perSecond {
+		w.suppressed++
+		return false, 0
+	}
+	w.written++
+	return true, suppressed
+}
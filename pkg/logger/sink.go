@@ -0,0 +1,163 @@
+package logger
+
+import (
+	"fmt"
+	"io"
+	"log/syslog"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink receives formatted log lines, without the trailing newline. Writes are
+// serialized by the logger.
+type Sink interface {
+	Write(level LogLevel, line string) error
+	Close() error
+}
+
+type writerSink struct {
+	w io.Writer
+}
+
+// NewWriterSink writes each line to w
+func NewWriterSink(w io.Writer) Sink {
+	return &writerSink{w: w}
+}
+
+func (s *writerSink) Write(_ LogLevel, line string) error {
+	_, err := io.WriteString(s.w, line+"\n")
+	return err
+}
+
+// Close leaves the writer open, it belongs to the caller
+func (s *writerSink) Close() error {
+	return nil
+}
+
+// fileSink appends to a file, renaming it to path.1, path.2 and so on once it
+// reaches maxSize, keeping at most backups old files
+type fileSink struct {
+	path    string
+	maxSize int64
+	backups int
+
+	mutex sync.Mutex
+	file  *os.File
+	size  int64
+}
+
+// NewFileSink opens path for appending, creating its directory. A maxSize of
+// 0 never rotates.
+func NewFileSink(path string, maxSize int64, backups int) (Sink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	s := &fileSink{path: path, maxSize: maxSize, backups: backups}
+	if err := s.open(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *fileSink) open() error {
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open log file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat log file: %w", err)
+	}
+
+	s.file = file
+	s.size = info.Size()
+	return nil
+}
+
+func (s *fileSink) Write(_ LogLevel, line string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.maxSize > 0 && s.size > 0 && s.size+int64(len(line))+1 > s.maxSize {
+		if err := s.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := io.WriteString(s.file, line+"\n")
+	s.size += int64(n)
+	return err
+}
+
+// rotate shifts the backups up by one, dropping the oldest, and starts a new file
+func (s *fileSink) rotate() error {
+	if err := s.file.Close(); err != nil {
+		return err
+	}
+
+	if s.backups > 0 {
+		for i := s.backups - 1; i > 0; i-- {
+			_ = os.Rename(fmt.Sprintf("%s.%d", s.path, i), fmt.Sprintf("%s.%d", s.path, i+1))
+		}
+		_ = os.Rename(s.path, s.path+".1")
+	} else {
+		_ = os.Remove(s.path)
+	}
+
+	return s.open()
+}
+
+func (s *fileSink) Close() error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.file.Close()
+}
+
+type syslogSink struct {
+	writer *syslog.Writer
+}
+
+// NewSyslogSink sends lines to the local syslog daemon with the priority of
+// their level
+func NewSyslogSink(tag string) (Sink, error) {
+	w, err := syslog.New(syslog.LOG_INFO|syslog.LOG_DAEMON, tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to syslog: %w", err)
+	}
+	return &syslogSink{writer: w}, nil
+}
+
+func (s *syslogSink) Write(level LogLevel, line string) error {
+	switch level {
+	case DEBUG:
+		return s.writer.Debug(line)
+	case WARN:
+		return s.writer.Warning(line)
+	case ERROR:
+		return s.writer.Err(line)
+	default:
+		return s.writer.Info(line)
+	}
+}
+
+func (s *syslogSink) Close() error {
+	return s.writer.Close()
+}
+
+// OpenSink returns the sink named by a logging output setting: "stdout" (or
+// empty), "stderr", "syslog", or the path of a file rotated at maxSize bytes
+func OpenSink(output string, maxSize int64, backups int) (Sink, error) {
+	switch output {
+	case "", "stdout":
+		return NewWriterSink(os.Stdout), nil
+	case "stderr":
+		return NewWriterSink(os.Stderr), nil
+	case "syslog":
+		return NewSyslogSink("worker")
+	default:
+		return NewFileSink(output, maxSize, backups)
+	}
+}
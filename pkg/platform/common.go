@@ -29,6 +29,10 @@ func (bp *BasePlatform) ReadFile(path string) ([]byte, error) {
 	return os.ReadFile(path)
 }
 
+func (bp *BasePlatform) Open(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
 func (bp *BasePlatform) Remove(path string) error {
 	return os.Remove(path)
 }
@@ -114,6 +118,10 @@ func (e *ExecCommand) Process() Process {
 	return &ExecProcess{process: e.cmd.Process}
 }
 
+func (e *ExecCommand) SetStdin(r interface{}) {
+	e.cmd.Stdin = r.(io.Reader)
+}
+
 func (e *ExecCommand) SetStdout(w interface{}) {
 	e.cmd.Stdout = w.(io.Writer)
 }
@@ -130,6 +138,10 @@ func (e *ExecCommand) SetEnv(env []string) {
 	e.cmd.Env = env
 }
 
+func (e *ExecCommand) SetExtraFiles(files []*os.File) {
+	e.cmd.ExtraFiles = files
+}
+
 // ExecProcess wraps os.Process to implement Process interface
 type ExecProcess struct {
 	process *os.Process
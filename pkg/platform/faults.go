@@ -0,0 +1,144 @@
+package platform
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+	"syscall"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/logger"
+)
+
+// Faults fails and stalls operations at random, at the rates of the fault
+// injection config. A nil *Faults injects nothing, so callers need not check
+// whether fault injection is enabled.
+type Faults struct {
+	config config.FaultInjectionConfig
+	logger *logger.Logger
+
+	mutex sync.Mutex
+	rand  *rand.Rand
+}
+
+// NewFaults returns the faults of cfg, or nil when fault injection is disabled
+func NewFaults(cfg config.FaultInjectionConfig) *Faults {
+	if !cfg.Enabled {
+		return nil
+	}
+
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+
+	f := &Faults{
+		config: cfg,
+		logger: logger.New().WithField("component", "fault-injection"),
+		rand:   rand.New(rand.NewSource(seed)),
+	}
+	f.logger.Warn("fault injection is enabled, operations will fail at random",
+		"seed", seed,
+		"cgroupWriteFailRate", cfg.CgroupWriteFailRate,
+		"startFailRate", cfg.StartFailRate,
+		"startDelay", cfg.StartDelay,
+		"killFailRate", cfg.KillFailRate)
+	return f
+}
+
+// CgroupWrite fails a write to a cgroup file
+func (f *Faults) CgroupWrite(path string) error {
+	if f == nil {
+		return nil
+	}
+	return f.inject(f.config.CgroupWriteFailRate, syscall.EIO, "cgroup write", "path", path)
+}
+
+// Start stalls a process start and fails it
+func (f *Faults) Start(name string) error {
+	if f == nil {
+		return nil
+	}
+	if delay := f.delay(f.config.StartDelay); delay > 0 {
+		f.logger.Debug("injected process start delay", "command", name, "delay", delay)
+		time.Sleep(delay)
+	}
+	return f.inject(f.config.StartFailRate, syscall.EAGAIN, "process start", "command", name)
+}
+
+// Kill fails a signal to a process. Signal 0 only checks the process exists
+// and is never failed, as the worker would take the process for gone.
+func (f *Faults) Kill(pid int, sig syscall.Signal) error {
+	if f == nil || sig == 0 {
+		return nil
+	}
+	return f.inject(f.config.KillFailRate, syscall.EPERM, "kill", "pid", pid, "signal", sig)
+}
+
+// inject returns errno for a share rate of calls
+func (f *Faults) inject(rate float64, errno syscall.Errno, op string, keyvals ...any) error {
+	if rate <= 0 {
+		return nil
+	}
+
+	f.mutex.Lock()
+	fail := f.rand.Float64() < rate
+	f.mutex.Unlock()
+	if !fail {
+		return nil
+	}
+
+	f.logger.Info("injected fault", append([]any{"operation", op, "error", errno}, keyvals...)...)
+	return fmt.Errorf("injected %s fault: %w", op, errno)
+}
+
+// delay picks a delay up to most
+func (f *Faults) delay(most time.Duration) time.Duration {
+	if most <= 0 {
+		return 0
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return time.Duration(f.rand.Int63n(int64(most) + 1))
+}
+
+// WithFaults wraps p so the processes it starts and the signals it sends fail
+// and stall as f injects; it returns p itself when f is nil
+func WithFaults(p Platform, f *Faults) Platform {
+	if f == nil {
+		return p
+	}
+	return &faultyPlatform{Platform: p, faults: f}
+}
+
+// faultyPlatform is a Platform injecting faults into kills and process starts
+type faultyPlatform struct {
+	Platform
+	faults *Faults
+}
+
+func (fp *faultyPlatform) Kill(pid int, sig syscall.Signal) error {
+	if err := fp.faults.Kill(pid, sig); err != nil {
+		return err
+	}
+	return fp.Platform.Kill(pid, sig)
+}
+
+func (fp *faultyPlatform) CreateCommand(name string, args ...string) Command {
+	return &faultyCommand{Command: fp.Platform.CreateCommand(name, args...), name: name, faults: fp.faults}
+}
+
+// faultyCommand is a Command whose start stalls and fails as injected
+type faultyCommand struct {
+	Command
+	name   string
+	faults *Faults
+}
+
+func (fc *faultyCommand) Start() error {
+	if err := fc.faults.Start(fc.name); err != nil {
+		return err
+	}
+	return fc.Command.Start()
+}
@@ -0,0 +1,74 @@
+package platform_test
+
+import (
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+	"worker/pkg/config"
+	"worker/pkg/platform"
+	"worker/pkg/platform/platformfakes"
+)
+
+func TestFaults_Disabled(t *testing.T) {
+	faults := platform.NewFaults(config.FaultInjectionConfig{CgroupWriteFailRate: 1})
+	if faults != nil {
+		t.Fatal("NewFaults() of a disabled config is not nil")
+	}
+	if err := faults.CgroupWrite("/sys/fs/cgroup/job-1/cpu.max"); err != nil {
+		t.Errorf("nil Faults injected %v", err)
+	}
+
+	fake := &platformfakes.FakePlatform{}
+	if platform.WithFaults(fake, faults) != platform.Platform(fake) {
+		t.Error("WithFaults() of nil faults wrapped the platform")
+	}
+}
+
+func TestFaults_Rates(t *testing.T) {
+	faults := platform.NewFaults(config.FaultInjectionConfig{
+		Enabled:             true,
+		Seed:                1,
+		CgroupWriteFailRate: 1,
+		KillFailRate:        1,
+		StartDelay:          time.Millisecond,
+	})
+
+	if err := faults.CgroupWrite("cpu.max"); !errors.Is(err, syscall.EIO) {
+		t.Errorf("CgroupWrite() = %v, want EIO", err)
+	}
+	if err := faults.Kill(42, syscall.SIGTERM); !errors.Is(err, syscall.EPERM) {
+		t.Errorf("Kill() = %v, want EPERM", err)
+	}
+	if err := faults.Kill(42, 0); err != nil {
+		t.Errorf("Kill() of signal 0 = %v, want nil", err)
+	}
+	for i := 0; i < 100; i++ {
+		if err := faults.Start("init"); err != nil {
+			t.Fatalf("Start() with a 0 fail rate = %v", err)
+		}
+	}
+}
+
+func TestWithFaults(t *testing.T) {
+	faults := platform.NewFaults(config.FaultInjectionConfig{Enabled: true, Seed: 1, KillFailRate: 1, StartFailRate: 1})
+	fake := &platformfakes.FakePlatform{}
+	fakeCommand := &platformfakes.FakeCommand{}
+	fake.CreateCommandReturns(fakeCommand)
+	p := platform.WithFaults(fake, faults)
+
+	if err := p.Kill(42, syscall.SIGKILL); err == nil || fake.KillCallCount() != 0 {
+		t.Errorf("Kill() = %v with %d calls through, want an injected fault", err, fake.KillCallCount())
+	}
+	if err := p.Kill(42, 0); err != nil || fake.KillCallCount() != 1 {
+		t.Errorf("Kill() of signal 0 = %v with %d calls through, want it passed on", err, fake.KillCallCount())
+	}
+
+	cmd := p.CreateCommand("init", "--job")
+	if err := cmd.Start(); !errors.Is(err, syscall.EAGAIN) || fakeCommand.StartCallCount() != 0 {
+		t.Errorf("Start() = %v with %d calls through, want EAGAIN", err, fakeCommand.StartCallCount())
+	}
+	if name, args := fake.CreateCommandArgsForCall(0); name != "init" || len(args) != 1 {
+		t.Errorf("CreateCommand() passed %q %v", name, args)
+	}
+}
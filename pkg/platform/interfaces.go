@@ -1,6 +1,7 @@
 package platform
 
 import (
+	"io"
 	"os"
 	"syscall"
 )
@@ -24,6 +25,7 @@ type OSOperations interface {
 	// File operations
 	WriteFile(name string, data []byte, perm os.FileMode) error
 	ReadFile(path string) ([]byte, error)
+	Open(name string) (io.ReadCloser, error)
 	Remove(path string) error
 	Symlink(source string, path string) error
 	MkdirAll(dir string, perm os.FileMode) error
@@ -70,10 +72,13 @@ type Command interface {
 	Start() error
 	Wait() error
 	Process() Process
+	SetStdin(r interface{})
 	SetStdout(w interface{})
 	SetStderr(w interface{})
 	SetSysProcAttr(attr *syscall.SysProcAttr)
 	SetEnv(env []string)
+	// SetExtraFiles passes files to the process as descriptors 3 and up
+	SetExtraFiles(files []*os.File)
 }
 
 // Process represents a running process
@@ -2,6 +2,7 @@
 package platformfakes
 
 import (
+	"os"
 	"sync"
 	"syscall"
 	"worker/pkg/platform"
@@ -23,11 +24,21 @@ type FakeCommand struct {
 	setEnvArgsForCall []struct {
 		arg1 []string
 	}
+	SetExtraFilesStub        func([]*os.File)
+	setExtraFilesMutex       sync.RWMutex
+	setExtraFilesArgsForCall []struct {
+		arg1 []*os.File
+	}
 	SetStderrStub        func(interface{})
 	setStderrMutex       sync.RWMutex
 	setStderrArgsForCall []struct {
 		arg1 interface{}
 	}
+	SetStdinStub        func(interface{})
+	setStdinMutex       sync.RWMutex
+	setStdinArgsForCall []struct {
+		arg1 interface{}
+	}
 	SetStdoutStub        func(interface{})
 	setStdoutMutex       sync.RWMutex
 	setStdoutArgsForCall []struct {
@@ -152,6 +163,43 @@ func (fake *FakeCommand) SetEnvArgsForCall(i int) []string {
 	return argsForCall.arg1
 }
 
+func (fake *FakeCommand) SetExtraFiles(arg1 []*os.File) {
+	var arg1Copy []*os.File
+	if arg1 != nil {
+		arg1Copy = make([]*os.File, len(arg1))
+		copy(arg1Copy, arg1)
+	}
+	fake.setExtraFilesMutex.Lock()
+	fake.setExtraFilesArgsForCall = append(fake.setExtraFilesArgsForCall, struct {
+		arg1 []*os.File
+	}{arg1Copy})
+	stub := fake.SetExtraFilesStub
+	fake.recordInvocation("SetExtraFiles", []interface{}{arg1Copy})
+	fake.setExtraFilesMutex.Unlock()
+	if stub != nil {
+		fake.SetExtraFilesStub(arg1)
+	}
+}
+
+func (fake *FakeCommand) SetExtraFilesCallCount() int {
+	fake.setExtraFilesMutex.RLock()
+	defer fake.setExtraFilesMutex.RUnlock()
+	return len(fake.setExtraFilesArgsForCall)
+}
+
+func (fake *FakeCommand) SetExtraFilesCalls(stub func([]*os.File)) {
+	fake.setExtraFilesMutex.Lock()
+	defer fake.setExtraFilesMutex.Unlock()
+	fake.SetExtraFilesStub = stub
+}
+
+func (fake *FakeCommand) SetExtraFilesArgsForCall(i int) []*os.File {
+	fake.setExtraFilesMutex.RLock()
+	defer fake.setExtraFilesMutex.RUnlock()
+	argsForCall := fake.setExtraFilesArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeCommand) SetStderr(arg1 interface{}) {
 	fake.setStderrMutex.Lock()
 	fake.setStderrArgsForCall = append(fake.setStderrArgsForCall, struct {
@@ -184,6 +232,38 @@ func (fake *FakeCommand) SetStderrArgsForCall(i int) interface{} {
 	return argsForCall.arg1
 }
 
+func (fake *FakeCommand) SetStdin(arg1 interface{}) {
+	fake.setStdinMutex.Lock()
+	fake.setStdinArgsForCall = append(fake.setStdinArgsForCall, struct {
+		arg1 interface{}
+	}{arg1})
+	stub := fake.SetStdinStub
+	fake.recordInvocation("SetStdin", []interface{}{arg1})
+	fake.setStdinMutex.Unlock()
+	if stub != nil {
+		fake.SetStdinStub(arg1)
+	}
+}
+
+func (fake *FakeCommand) SetStdinCallCount() int {
+	fake.setStdinMutex.RLock()
+	defer fake.setStdinMutex.RUnlock()
+	return len(fake.setStdinArgsForCall)
+}
+
+func (fake *FakeCommand) SetStdinCalls(stub func(interface{})) {
+	fake.setStdinMutex.Lock()
+	defer fake.setStdinMutex.Unlock()
+	fake.SetStdinStub = stub
+}
+
+func (fake *FakeCommand) SetStdinArgsForCall(i int) interface{} {
+	fake.setStdinMutex.RLock()
+	defer fake.setStdinMutex.RUnlock()
+	argsForCall := fake.setStdinArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeCommand) SetStdout(arg1 interface{}) {
 	fake.setStdoutMutex.Lock()
 	fake.setStdoutArgsForCall = append(fake.setStdoutArgsForCall, struct {
@@ -361,8 +441,12 @@ func (fake *FakeCommand) Invocations() map[string][][]interface{} {
 	defer fake.processMutex.RUnlock()
 	fake.setEnvMutex.RLock()
 	defer fake.setEnvMutex.RUnlock()
+	fake.setExtraFilesMutex.RLock()
+	defer fake.setExtraFilesMutex.RUnlock()
 	fake.setStderrMutex.RLock()
 	defer fake.setStderrMutex.RUnlock()
+	fake.setStdinMutex.RLock()
+	defer fake.setStdinMutex.RUnlock()
 	fake.setStdoutMutex.RLock()
 	defer fake.setStdoutMutex.RUnlock()
 	fake.setSysProcAttrMutex.RLock()
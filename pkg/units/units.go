@@ -0,0 +1,153 @@
+// Package units converts human-friendly resource quantities such as "1.5"
+// CPUs, "512Mi" of memory or "50MB/s" of IO into the numeric limits used by
+// the worker: CPU in millicores, memory in bytes and IO in bytes per second.
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	kilo = 1000
+	kibi = 1024
+)
+
+// byte multipliers by suffix, matched case-insensitively. Plain K/M/G/T are
+// decimal, the "i" forms are binary.
+var byteSuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	// longest suffixes first so "mib" is not matched as "b"
+	{"kib", kibi}, {"mib", kibi * kibi}, {"gib", kibi * kibi * kibi}, {"tib", kibi * kibi * kibi * kibi},
+	{"ki", kibi}, {"mi", kibi * kibi}, {"gi", kibi * kibi * kibi}, {"ti", kibi * kibi * kibi * kibi},
+	{"kb", kilo}, {"mb", kilo * kilo}, {"gb", kilo * kilo * kilo}, {"tb", kilo * kilo * kilo * kilo},
+	{"k", kilo}, {"m", kilo * kilo}, {"g", kilo * kilo * kilo}, {"t", kilo * kilo * kilo * kilo},
+	{"b", 1},
+}
+
+// ParseCPU converts a number of CPUs ("1.5"), a percentage ("150%") or
+// millicores ("500m") to a CPU limit in millicores
+func ParseCPU(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+
+	var millis float64
+	switch {
+	case strings.HasSuffix(value, "%"):
+		n, err := parseNumber(strings.TrimSuffix(value, "%"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU %q: %v", s, err)
+		}
+		millis = n * 10
+	case strings.HasSuffix(value, "m"):
+		n, err := parseNumber(strings.TrimSuffix(value, "m"))
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU %q: %v", s, err)
+		}
+		millis = n
+	default:
+		n, err := parseNumber(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid CPU %q: expected CPUs (1.5), percent (150%%) or millicores (500m)", s)
+		}
+		millis = n * 1000
+	}
+
+	return toInt64("CPU", s, math.Round(millis))
+}
+
+// ParseMemory converts a size such as "512M", "2Gi" or "1.5GB" to bytes,
+// rounding up. A plain number is taken as MiB.
+func ParseMemory(s string) (int64, error) {
+	value := strings.TrimSpace(s)
+	if n, err := parseNumber(value); err == nil {
+		return toInt64("memory", s, math.Ceil(n*kibi*kibi))
+	}
+
+	bytes, err := parseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid memory %q: %v", s, err)
+	}
+	return toInt64("memory", s, math.Ceil(bytes))
+}
+
+// ParseIO converts a rate such as "50MB/s" or "10Mi" to bytes per second. The
+// "/s" suffix is optional and a plain number is taken as bytes per second.
+func ParseIO(s string) (int64, error) {
+	value := strings.TrimSuffix(strings.TrimSpace(s), "/s")
+
+	bytes, err := parseBytes(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid IO rate %q: %v", s, err)
+	}
+	return toInt64("IO rate", s, math.Ceil(bytes))
+}
+
+// parseBytes parses a number with an optional byte suffix
+func parseBytes(value string) (float64, error) {
+	lower := strings.ToLower(value)
+
+	multiplier := 1.0
+	for _, unit := range byteSuffixes {
+		if strings.HasSuffix(lower, unit.suffix) {
+			lower = strings.TrimSuffix(lower, unit.suffix)
+			multiplier = unit.multiplier
+			break
+		}
+	}
+
+	n, err := parseNumber(lower)
+	if err != nil {
+		return 0, fmt.Errorf("expected a size like 512M, 2Gi or 1.5GB")
+	}
+	return n * multiplier, nil
+}
+
+func parseNumber(value string) (float64, error) {
+	n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || math.IsNaN(n) || math.IsInf(n, 0) {
+		return 0, fmt.Errorf("not a number")
+	}
+	if n <= 0 {
+		return 0, fmt.Errorf("must be positive")
+	}
+	return n, nil
+}
+
+func toInt64(kind, s string, n float64) (int64, error) {
+	if n < 1 {
+		return 0, fmt.Errorf("invalid %s %q: too small", kind, s)
+	}
+	// float64 cannot represent MaxInt64 exactly, it rounds up to 2^63
+	if n >= math.MaxInt64 {
+		return 0, fmt.Errorf("invalid %s %q: too large", kind, s)
+	}
+	return int64(n), nil
+}
+
+// FormatCPU renders millicores as CPUs, e.g. "2", "1.5" or "250m"
+func FormatCPU(millis int64) string {
+	if millis < 1000 && millis%100 != 0 {
+		return fmt.Sprintf("%dm", millis)
+	}
+	return strconv.FormatFloat(float64(millis)/1000, 'f', -1, 64)
+}
+
+// FormatBytes renders a byte count with a binary suffix, e.g. "1.5Gi" or "512B"
+func FormatBytes(n int64) string {
+	if n < kibi {
+		return fmt.Sprintf("%dB", n)
+	}
+
+	suffixes := []string{"Ki", "Mi", "Gi", "Ti"}
+	value := float64(n) / kibi
+	i := 0
+	for value >= kibi && i < len(suffixes)-1 {
+		value /= kibi
+		i++
+	}
+	return fmt.Sprintf("%.1f%s", value, suffixes[i])
+}
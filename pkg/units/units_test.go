@@ -0,0 +1,131 @@
+package units
+
+import "testing"
+
+func TestParseCPU(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected int64
+	}{
+		{"1", 1000},
+		{"1.5", 1500},
+		{"0.25", 250},
+		{"150%", 1500},
+		{"500m", 500},
+		{"4m", 4},
+		{" 2 ", 2000},
+		{"0.001", 1},
+	}
+	for _, tt := range tests {
+		got, err := ParseCPU(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %d, got %d", tt.in, tt.expected, got)
+		}
+	}
+
+	for _, in := range []string{"", "abc", "-1", "0", "1.5x", "0.0001", "0.4m"} {
+		if _, err := ParseCPU(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestParseMemory(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected int64
+	}{
+		{"512", 512 << 20}, // plain numbers are MiB
+		{"512Mi", 512 << 20},
+		{"512MiB", 512 << 20},
+		{"2Gi", 2 << 30},
+		{"1.5Gi", 1536 << 20},
+		{"512M", 512000000},
+		{"1G", 1000000000},
+		{"100k", 100000},
+		{"1073741824b", 1 << 30},
+		{"64Ti", 64 << 40}, // larger than the old int32 MiB limit allowed
+	}
+	for _, tt := range tests {
+		got, err := ParseMemory(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %d, got %d", tt.in, tt.expected, got)
+		}
+	}
+
+	for _, in := range []string{"", "Gi", "two G", "-1G", "1X", "0b", "99999999Ti"} {
+		if _, err := ParseMemory(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestParseIO(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected int64
+	}{
+		{"50MB/s", 50000000},
+		{"5GB/s", 5000000000},
+		{"10Mi", 10485760},
+		{"1048576", 1048576},
+		{"512KiB/s", 524288},
+	}
+	for _, tt := range tests {
+		got, err := ParseIO(tt.in)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.expected {
+			t.Errorf("%q: expected %d, got %d", tt.in, tt.expected, got)
+		}
+	}
+
+	for _, in := range []string{"", "fast", "0/s", "1/m"} {
+		if _, err := ParseIO(in); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		in       int64
+		expected string
+	}{
+		{0, "0B"},
+		{512, "512B"},
+		{1536, "1.5Ki"},
+		{10 * 1024 * 1024, "10.0Mi"},
+		{3 * 1024 * 1024 * 1024 / 2, "1.5Gi"},
+		{2048 * 1024 * 1024 * 1024 * 1024, "2048.0Ti"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.in); got != tt.expected {
+			t.Errorf("%d: expected %q, got %q", tt.in, tt.expected, got)
+		}
+	}
+}
+
+func TestFormatCPU(t *testing.T) {
+	for millis, expected := range map[int64]string{
+		2000: "2",
+		1500: "1.5",
+		500:  "0.5",
+		250:  "250m",
+		4:    "4m",
+	} {
+		if got := FormatCPU(millis); got != expected {
+			t.Errorf("%d: expected %q, got %q", millis, expected, got)
+		}
+	}
+}
@@ -0,0 +1,479 @@
+package workertest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"syscall"
+	"time"
+	"worker/pkg/platform"
+)
+
+// Command is how a scripted command runs
+type Command struct {
+	Stdout   []byte        // written to the command's stdout when it is waited for
+	Stderr   []byte        // written to its stderr
+	Duration time.Duration // how long Wait blocks, unless the process is killed
+	StartErr error         // Start fails with it
+	WaitErr  error         // Wait returns it once the command ran
+}
+
+// Signal is a signal the platform was asked to send
+type Signal struct {
+	Pid    int // negative for a process group
+	Signal syscall.Signal
+}
+
+// Platform is an in-memory platform.Platform. Files live in memory, commands
+// run as scripted, and signals, mounts and execs are recorded instead of
+// performed. Only scripted commands and files that were set can be resolved.
+type Platform struct {
+	mutex     sync.Mutex
+	files     map[string][]byte
+	dirs      map[string]bool
+	env       map[string]string
+	commands  map[string]Command
+	killErrs  map[int]error
+	processes map[int]*process
+	signals   []Signal
+	mounts    []string
+	execs     [][]string
+	exitCode  *int
+	nextPid   int
+}
+
+// NewPlatform creates an empty platform
+func NewPlatform() *Platform {
+	return &Platform{
+		files:     make(map[string][]byte),
+		dirs:      map[string]bool{"/": true},
+		env:       make(map[string]string),
+		commands:  make(map[string]Command),
+		killErrs:  make(map[int]error),
+		processes: make(map[int]*process),
+		nextPid:   1000,
+	}
+}
+
+// SetFile creates or replaces a file
+func (p *Platform) SetFile(path string, data []byte) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.files[filepath.Clean(path)] = append([]byte(nil), data...)
+}
+
+// File is the content of a file, and whether it exists
+func (p *Platform) File(path string) ([]byte, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	data, ok := p.files[filepath.Clean(path)]
+	return append([]byte(nil), data...), ok
+}
+
+// SetEnv sets an environment variable
+func (p *Platform) SetEnv(key, value string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.env[key] = value
+}
+
+// Script sets how command runs, by name or path. Scripted commands are found
+// by LookPath.
+func (p *Platform) Script(command string, script Command) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.commands[command] = script
+}
+
+// FailKill makes signals to pid, or to its process group, fail with err
+func (p *Platform) FailKill(pid int, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.killErrs[pid] = err
+}
+
+// Signals lists the signals sent so far, in order
+func (p *Platform) Signals() []Signal {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]Signal(nil), p.signals...)
+}
+
+// Mounts lists the targets mounted so far and not unmounted since
+func (p *Platform) Mounts() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([]string(nil), p.mounts...)
+}
+
+// Execs lists the argv of each Exec so far
+func (p *Platform) Execs() [][]string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return append([][]string(nil), p.execs...)
+}
+
+// ExitCode is the code Exit was called with, and whether it was
+func (p *Platform) ExitCode() (int, bool) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.exitCode == nil {
+		return 0, false
+	}
+	return *p.exitCode, true
+}
+
+func notExist(op, path string) error {
+	return &fs.PathError{Op: op, Path: path, Err: fs.ErrNotExist}
+}
+
+func (p *Platform) WriteFile(name string, data []byte, perm os.FileMode) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	name = filepath.Clean(name)
+	if !p.dirs[filepath.Dir(name)] {
+		return notExist("open", name)
+	}
+	p.files[name] = append([]byte(nil), data...)
+	return nil
+}
+
+func (p *Platform) ReadFile(path string) ([]byte, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	data, ok := p.files[filepath.Clean(path)]
+	if !ok {
+		return nil, notExist("open", path)
+	}
+	return append([]byte(nil), data...), nil
+}
+
+func (p *Platform) Open(name string) (io.ReadCloser, error) {
+	data, err := p.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (p *Platform) Remove(path string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	path = filepath.Clean(path)
+	if _, ok := p.files[path]; ok {
+		delete(p.files, path)
+		return nil
+	}
+	if p.dirs[path] {
+		delete(p.dirs, path)
+		return nil
+	}
+	return notExist("remove", path)
+}
+
+// Symlink copies the content of source, links are not kept as such
+func (p *Platform) Symlink(source string, path string) error {
+	data, err := p.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	return p.WriteFile(path, data, 0777)
+}
+
+func (p *Platform) MkdirAll(dir string, perm os.FileMode) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for dir = filepath.Clean(dir); !p.dirs[dir]; dir = filepath.Dir(dir) {
+		p.dirs[dir] = true
+	}
+	return nil
+}
+
+func (p *Platform) Stat(name string) (os.FileInfo, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	name = filepath.Clean(name)
+	if data, ok := p.files[name]; ok {
+		return fileInfo{name: filepath.Base(name), size: int64(len(data)), mode: 0755}, nil
+	}
+	if p.dirs[name] {
+		return fileInfo{name: filepath.Base(name), mode: fs.ModeDir | 0755}, nil
+	}
+	return nil, notExist("stat", name)
+}
+
+func (p *Platform) IsNotExist(err error) bool {
+	return errors.Is(err, fs.ErrNotExist)
+}
+
+func (p *Platform) Executable() (string, error) {
+	return "/usr/local/bin/worker", nil
+}
+
+func (p *Platform) Getpid() int {
+	return 1
+}
+
+// Exit records the code instead of exiting, see ExitCode
+func (p *Platform) Exit(code int) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.exitCode = &code
+}
+
+func (p *Platform) Environ() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	env := make([]string, 0, len(p.env))
+	for key, value := range p.env {
+		env = append(env, key+"="+value)
+	}
+	sort.Strings(env)
+	return env
+}
+
+func (p *Platform) Getenv(key string) string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.env[key]
+}
+
+// Kill records the signal and ends the scripted process it is sent to.
+// Signal 0 fails with ESRCH once the process has ended.
+func (p *Platform) Kill(pid int, sig syscall.Signal) error {
+	p.mutex.Lock()
+	p.signals = append(p.signals, Signal{Pid: pid, Signal: sig})
+	target := max(pid, -pid)
+	err := p.killErrs[target]
+	proc := p.processes[target]
+	p.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+	if proc == nil || proc.ended() {
+		if sig == 0 {
+			return syscall.ESRCH
+		}
+		return nil
+	}
+	if sig != 0 {
+		proc.kill()
+	}
+	return nil
+}
+
+// Exec records the argv instead of replacing the process, see Execs
+func (p *Platform) Exec(argv0 string, argv []string, envv []string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.execs = append(p.execs, append([]string(nil), argv...))
+	return nil
+}
+
+func (p *Platform) CreateProcessGroup() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{Setpgid: true}
+}
+
+// Mount records the target, see Mounts
+func (p *Platform) Mount(source string, target string, fstype string, flags uintptr, data string) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.mounts = append(p.mounts, target)
+	return nil
+}
+
+func (p *Platform) Unmount(target string, flags int) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	for i, mounted := range p.mounts {
+		if mounted == target {
+			p.mounts = append(p.mounts[:i], p.mounts[i+1:]...)
+			return nil
+		}
+	}
+	return syscall.EINVAL
+}
+
+// LookPath finds scripted commands and files that were set, by name or path
+func (p *Platform) LookPath(file string) (string, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if _, ok := p.commands[file]; ok {
+		return file, nil
+	}
+	if _, ok := p.files[filepath.Clean(file)]; ok {
+		return file, nil
+	}
+	return "", &exec.Error{Name: file, Err: exec.ErrNotFound}
+}
+
+// CreateCommand creates a command running as scripted for name. Commands
+// without a script exit right away without output.
+func (p *Platform) CreateCommand(name string, args ...string) platform.Command {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return &command{platform: p, script: p.commands[name]}
+}
+
+// command is a scripted platform.Command
+type command struct {
+	platform *Platform
+	script   Command
+	stdout   io.Writer
+	stderr   io.Writer
+	process  *process
+}
+
+func (c *command) Start() error {
+	if c.process != nil {
+		return errors.New("workertest: command already started")
+	}
+	if c.script.StartErr != nil {
+		return c.script.StartErr
+	}
+
+	p := c.platform
+	p.mutex.Lock()
+	p.nextPid++
+	c.process = &process{pid: p.nextPid, killed: make(chan struct{})}
+	p.processes[c.process.pid] = c.process
+	p.mutex.Unlock()
+	return nil
+}
+
+func (c *command) Wait() error {
+	if c.process == nil {
+		return errors.New("workertest: command not started")
+	}
+
+	if c.stdout != nil {
+		_, _ = c.stdout.Write(c.script.Stdout)
+	}
+	if c.stderr != nil {
+		_, _ = c.stderr.Write(c.script.Stderr)
+	}
+
+	killed := false
+	select {
+	case <-time.After(c.script.Duration):
+	case <-c.process.killed:
+		killed = true
+	}
+	c.process.end()
+
+	if killed {
+		return fmt.Errorf("signal: killed")
+	}
+	return c.script.WaitErr
+}
+
+func (c *command) Process() platform.Process {
+	if c.process == nil {
+		return nil
+	}
+	return c.process
+}
+
+func (c *command) SetStdin(r interface{}) {}
+
+func (c *command) SetStdout(w interface{}) {
+	c.stdout, _ = w.(io.Writer)
+}
+
+func (c *command) SetStderr(w interface{}) {
+	c.stderr, _ = w.(io.Writer)
+}
+
+func (c *command) SetSysProcAttr(attr *syscall.SysProcAttr) {}
+
+func (c *command) SetEnv(env []string) {}
+
+func (c *command) SetExtraFiles(files []*os.File) {}
+
+// process is a started scripted command
+type process struct {
+	pid      int
+	mutex    sync.Mutex
+	killed   chan struct{}
+	isKilled bool
+	isEnded  bool
+}
+
+func (p *process) Pid() int {
+	return p.pid
+}
+
+func (p *process) Kill() error {
+	p.kill()
+	return nil
+}
+
+func (p *process) kill() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if !p.isKilled {
+		p.isKilled = true
+		close(p.killed)
+	}
+}
+
+func (p *process) end() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	p.isEnded = true
+}
+
+func (p *process) ended() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	return p.isEnded
+}
+
+// fileInfo describes an in-memory file
+type fileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (f fileInfo) Name() string       { return f.name }
+func (f fileInfo) Size() int64        { return f.size }
+func (f fileInfo) Mode() fs.FileMode  { return f.mode }
+func (f fileInfo) ModTime() time.Time { return time.Time{} }
+func (f fileInfo) IsDir() bool        { return f.mode.IsDir() }
+func (f fileInfo) Sys() any           { return nil }
+
+var _ platform.Platform = (*Platform)(nil)
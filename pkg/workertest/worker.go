@@ -0,0 +1,306 @@
+// Package workertest provides an in-memory job worker, store and platform for
+// testing code built on the worker without root, Linux or generated fakes.
+// Jobs do not run a process: each one ends as scripted for its command.
+package workertest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+	"worker/internal/worker/core/interfaces"
+	"worker/internal/worker/domain"
+	"worker/internal/worker/events"
+	"worker/internal/worker/state"
+	"worker/internal/worker/utils"
+)
+
+// NewStore creates an in-memory store kept up to date from the events
+// published on the returned bus, as the worker daemon wires them
+func NewStore() (state.Store, *events.Bus) {
+	bus := events.NewBus()
+	store := state.New()
+	bus.Handle(state.ApplyEvents(store))
+	return store, bus
+}
+
+// Result is how a scripted job ends
+type Result struct {
+	Output   []byte        // written to the job's output once it starts
+	ExitCode int32         // non-zero ends the job FAILED
+	Duration time.Duration // how long the job runs, negative until it is stopped
+	Err      error         // StartJob fails with it, the job is not created
+}
+
+// Worker is an in-memory interfaces.Worker. It publishes the same job events
+// as the Linux worker, so a store from NewStore sees jobs created, running
+// and finished, and writes the scripted output to the store.
+type Worker struct {
+	store state.Store
+	bus   *events.Bus
+
+	mutex   sync.Mutex
+	scripts map[string]Result
+	runs    map[string]*run
+	started []*domain.JobSpec
+	nextID  int
+}
+
+// run is a job the worker started
+type run struct {
+	job   *domain.Job
+	stop  chan struct{}
+	done  chan struct{}
+	stdin bytes.Buffer
+}
+
+// NewWorker creates a worker updating store, directly and through bus
+func NewWorker(store state.Store, bus *events.Bus) *Worker {
+	return &Worker{
+		store:   store,
+		bus:     bus,
+		scripts: make(map[string]Result),
+		runs:    make(map[string]*run),
+	}
+}
+
+// Script sets how jobs running command end. Commands without a script
+// complete right away with exit code 0 and no output.
+func (w *Worker) Script(command string, result Result) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.scripts[command] = result
+}
+
+// Started lists the specs of the jobs started so far, in order
+func (w *Worker) Started() []*domain.JobSpec {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	specs := make([]*domain.JobSpec, len(w.started))
+	for i, spec := range w.started {
+		specs[i] = spec.Copy()
+	}
+	return specs
+}
+
+// Stdin is what was written to the stdin of a job
+func (w *Worker) Stdin(jobID string) []byte {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if r, ok := w.runs[jobID]; ok {
+		return bytes.Clone(r.stdin.Bytes())
+	}
+	return nil
+}
+
+func (w *Worker) StartJob(ctx context.Context, spec *domain.JobSpec) (*domain.Job, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if spec.Command == "" {
+		return nil, errors.New("command is required")
+	}
+
+	w.mutex.Lock()
+	result := w.scripts[spec.Command]
+	if result.Err != nil {
+		w.mutex.Unlock()
+		return nil, result.Err
+	}
+	w.nextID++
+	pid := int32(1000 + w.nextID)
+	job := &domain.Job{
+		Id:         fmt.Sprintf("%d", w.nextID),
+		Command:    spec.Command,
+		Args:       utils.CopyStringSlice(spec.Args),
+		Limits:     spec.Limits,
+		Env:        utils.CopyStringMap(spec.Env),
+		SecretEnv:  utils.CopyStringMap(spec.SecretEnv),
+		Restart:    spec.Restart,
+		Probe:      spec.Probe.Copy(),
+		GroupId:    spec.GroupID,
+		Labels:     utils.CopyStringMap(spec.Labels),
+		Status:     domain.StatusInitializing,
+		Isolation:  spec.Isolation,
+		QoS:        spec.QoS,
+		Seccomp:    spec.Seccomp,
+		Runtime:    spec.Runtime,
+		TimeOffset: spec.TimeOffset,
+		Spec:       spec.Copy(),
+		StartTime:  time.Now(),
+	}
+	created := job.DeepCopy()
+	_ = job.MarkAsRunning(pid)
+	running := job.DeepCopy()
+
+	r := &run{job: job, stop: make(chan struct{}), done: make(chan struct{})}
+	w.runs[job.Id] = r
+	w.started = append(w.started, spec.Copy())
+	w.mutex.Unlock()
+
+	w.publish(events.JobCreated, created)
+	w.publish(events.JobUpdated, running)
+	if len(result.Output) > 0 {
+		w.store.WriteToBuffer(job.Id, result.Output)
+	}
+
+	go w.finish(r, result)
+	return running, nil
+}
+
+// ExpireJob records a job of the spec as EXPIRED without running it. It is
+// not listed by Started.
+func (w *Worker) ExpireJob(ctx context.Context, spec *domain.JobSpec, reason error) (*domain.Job, error) {
+	w.mutex.Lock()
+	w.nextID++
+	job := &domain.Job{
+		Id:        fmt.Sprintf("%d", w.nextID),
+		Command:   spec.Command,
+		Args:      utils.CopyStringSlice(spec.Args),
+		Limits:    spec.Limits,
+		GroupId:   spec.GroupID,
+		Labels:    utils.CopyStringMap(spec.Labels),
+		Status:    domain.StatusInitializing,
+		Spec:      spec.Copy(),
+		StartTime: time.Now(),
+	}
+	w.mutex.Unlock()
+
+	w.publish(events.JobCreated, job)
+	job.Expire(fmt.Sprintf("not started within its queue timeout: %v", reason))
+	w.publish(events.JobUpdated, job)
+	w.bus.Publish(events.Event{Type: events.JobExpired, JobID: job.Id, Err: reason, Time: time.Now()})
+	return job.DeepCopy(), nil
+}
+
+// finish ends a job as scripted, or stopped once asked to
+func (w *Worker) finish(r *run, result Result) {
+	var end <-chan time.Time
+	if result.Duration >= 0 {
+		end = time.After(result.Duration)
+	}
+
+	stopped := false
+	select {
+	case <-end:
+	case <-r.stop:
+		stopped = true
+	}
+
+	w.mutex.Lock()
+	switch {
+	case stopped:
+		r.job.Stop("stopped by a user")
+	case result.ExitCode != 0:
+		r.job.Fail(result.ExitCode, fmt.Sprintf("exited with code %d", result.ExitCode))
+	default:
+		r.job.Complete(0, "exited with code 0")
+	}
+	job := r.job.DeepCopy()
+	w.mutex.Unlock()
+
+	w.publish(events.JobUpdated, job)
+	w.bus.Publish(events.Event{Type: events.JobCleanedUp, JobID: job.Id, Time: time.Now()})
+	close(r.done)
+}
+
+func (w *Worker) publish(eventType events.Type, job *domain.Job) {
+	w.bus.Publish(events.Event{Type: eventType, JobID: job.Id, Job: job.DeepCopy(), Time: time.Now()})
+}
+
+func (w *Worker) StopJob(ctx context.Context, jobID string, opts domain.StopOptions) (*domain.StopResult, error) {
+	w.mutex.Lock()
+	r, ok := w.runs[jobID]
+	if !ok {
+		w.mutex.Unlock()
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+	if !r.job.IsRunning() {
+		status := r.job.Status
+		w.mutex.Unlock()
+		return nil, fmt.Errorf("job is not running: %s (status: %s)", jobID, status)
+	}
+	w.mutex.Unlock()
+
+	select {
+	case r.stop <- struct{}{}:
+		<-r.done
+		return &domain.StopResult{Method: domain.StopGraceful, ExitCode: -1}, nil
+	case <-r.done:
+		// it ended on its own meanwhile
+		return &domain.StopResult{Method: domain.StopExited, ExitCode: r.job.ExitCode}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// ResumeJob fails, as in-memory jobs never crash-loop
+func (w *Worker) ResumeJob(ctx context.Context, jobID string) error {
+	return fmt.Errorf("job is not in a crash loop: %s", jobID)
+}
+
+// ValidateJob accepts every spec with a command, unless its script fails it
+func (w *Worker) ValidateJob(ctx context.Context, spec *domain.JobSpec) *domain.JobValidation {
+	validation := &domain.JobValidation{ResolvedCommand: spec.Command, Limits: spec.Limits}
+	if spec.Command == "" {
+		validation.Add("command", errors.New("command is required"))
+		return validation
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	validation.Add("command", w.scripts[spec.Command].Err)
+	return validation
+}
+
+// OpenStdin returns a writer recording what is written, see Stdin
+func (w *Worker) OpenStdin(jobID string) (io.WriteCloser, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	r, ok := w.runs[jobID]
+	if !ok {
+		return nil, fmt.Errorf("job not found: %s", jobID)
+	}
+	if !r.job.Spec.Stdin {
+		return nil, fmt.Errorf("job %s was not started with stdin", jobID)
+	}
+	return &stdinWriter{worker: w, run: r}, nil
+}
+
+// Capabilities is empty, the in-memory worker has none of the optional ones
+func (w *Worker) Capabilities() []domain.Capability {
+	return nil
+}
+
+// Diagnostics reports a single passing check of the in-memory worker
+func (w *Worker) Diagnostics(ctx context.Context) domain.Diagnostics {
+	var d domain.Diagnostics
+	d.Add("workertest", domain.CheckOK, "in-memory worker, jobs run as scripted", "")
+	return d
+}
+
+// stdinWriter records the stdin of a job
+type stdinWriter struct {
+	worker *Worker
+	run    *run
+}
+
+func (s *stdinWriter) Write(p []byte) (int, error) {
+	s.worker.mutex.Lock()
+	defer s.worker.mutex.Unlock()
+
+	return s.run.stdin.Write(p)
+}
+
+func (s *stdinWriter) Close() error {
+	return nil
+}
+
+var _ interfaces.Worker = (*Worker)(nil)
@@ -0,0 +1,178 @@
+package workertest_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"syscall"
+	"testing"
+	"time"
+	"worker/internal/worker/domain"
+	"worker/pkg/workertest"
+)
+
+func TestWorker_Lifecycle(t *testing.T) {
+	store, bus := workertest.NewStore()
+	worker := workertest.NewWorker(store, bus)
+	worker.Script("make", workertest.Result{Output: []byte("built\n"), ExitCode: 2})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := worker.StartJob(ctx, &domain.JobSpec{Command: "make", Args: []string{"all"}})
+	if err != nil {
+		t.Fatalf("StartJob() error = %v", err)
+	}
+	if job.Status != domain.StatusRunning || job.Pid == 0 {
+		t.Errorf("StartJob() = %s with pid %d, want a running job", job.Status, job.Pid)
+	}
+
+	done, err := store.WaitForCompletion(ctx, job.Id)
+	if err != nil {
+		t.Fatalf("WaitForCompletion() error = %v", err)
+	}
+	if done.Status != domain.StatusFailed || done.ExitCode != 2 {
+		t.Errorf("job ended %s with exit code %d, want FAILED with 2", done.Status, done.ExitCode)
+	}
+	if output, _, _ := store.GetOutput(job.Id); string(output) != "built\n" {
+		t.Errorf("job output = %q", output)
+	}
+
+	if specs := worker.Started(); len(specs) != 1 || specs[0].Args[0] != "all" {
+		t.Errorf("Started() = %v", specs)
+	}
+}
+
+func TestWorker_ExpireJob(t *testing.T) {
+	store, bus := workertest.NewStore()
+	worker := workertest.NewWorker(store, bus)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := worker.ExpireJob(ctx, &domain.JobSpec{Command: "report"}, errors.New("not started within its queue timeout"))
+	if err != nil {
+		t.Fatalf("ExpireJob() error = %v", err)
+	}
+	done, err := store.WaitForCompletion(ctx, job.Id)
+	if err != nil {
+		t.Fatalf("WaitForCompletion() error = %v", err)
+	}
+	if done.Status != domain.StatusExpired {
+		t.Errorf("job ended %s, want EXPIRED", done.Status)
+	}
+	if specs := worker.Started(); len(specs) != 0 {
+		t.Errorf("Started() = %v, want no jobs", specs)
+	}
+}
+
+func TestWorker_StopAndStdin(t *testing.T) {
+	store, bus := workertest.NewStore()
+	worker := workertest.NewWorker(store, bus)
+	worker.Script("cat", workertest.Result{Duration: -1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	job, err := worker.StartJob(ctx, &domain.JobSpec{Command: "cat", Stdin: true})
+	if err != nil {
+		t.Fatalf("StartJob() error = %v", err)
+	}
+
+	stdin, err := worker.OpenStdin(job.Id)
+	if err != nil {
+		t.Fatalf("OpenStdin() error = %v", err)
+	}
+	_, _ = io.WriteString(stdin, "hello")
+	if got := string(worker.Stdin(job.Id)); got != "hello" {
+		t.Errorf("Stdin() = %q, want hello", got)
+	}
+
+	result, err := worker.StopJob(ctx, job.Id, domain.StopOptions{})
+	if err != nil || result.Method != domain.StopGraceful {
+		t.Fatalf("StopJob() = %+v, %v", result, err)
+	}
+	if stopped, _ := store.GetJob(job.Id); stopped.Status != domain.StatusStopped {
+		t.Errorf("stopped job is %s", stopped.Status)
+	}
+	if _, err := worker.StopJob(ctx, job.Id, domain.StopOptions{}); err == nil {
+		t.Error("StopJob() of a stopped job succeeded")
+	}
+}
+
+func TestWorker_ScriptedError(t *testing.T) {
+	store, bus := workertest.NewStore()
+	worker := workertest.NewWorker(store, bus)
+	refused := errors.New("no such command")
+	worker.Script("missing", workertest.Result{Err: refused})
+
+	spec := &domain.JobSpec{Command: "missing"}
+	if _, err := worker.StartJob(context.Background(), spec); !errors.Is(err, refused) {
+		t.Errorf("StartJob() error = %v, want %v", err, refused)
+	}
+	if validation := worker.ValidateJob(context.Background(), spec); len(validation.Errors) != 1 {
+		t.Errorf("ValidateJob() errors = %v", validation.Errors)
+	}
+	if jobs := store.ListJobs(); len(jobs) != 0 {
+		t.Errorf("failed start left %d jobs", len(jobs))
+	}
+}
+
+func TestPlatform(t *testing.T) {
+	p := workertest.NewPlatform()
+
+	if err := p.WriteFile("/sys/fs/cgroup/job-1/cpu.max", []byte("max"), 0644); !p.IsNotExist(err) {
+		t.Errorf("WriteFile() without its dir = %v, want not exist", err)
+	}
+	_ = p.MkdirAll("/sys/fs/cgroup/job-1", 0755)
+	_ = p.WriteFile("/sys/fs/cgroup/job-1/cpu.max", []byte("max"), 0644)
+	if data, ok := p.File("/sys/fs/cgroup/job-1/cpu.max"); !ok || string(data) != "max" {
+		t.Errorf("File() = %q, %v", data, ok)
+	}
+
+	p.Script("sleep", workertest.Command{Stdout: []byte("zzz"), Duration: time.Hour})
+	if _, err := p.LookPath("sleep"); err != nil {
+		t.Errorf("LookPath() of a scripted command = %v", err)
+	}
+	if _, err := p.LookPath("python3"); err == nil {
+		t.Error("LookPath() of an unknown command succeeded")
+	}
+
+	cmd := p.CreateCommand("sleep", "3600")
+	var stdout stringWriter
+	cmd.SetStdout(&stdout)
+	if err := cmd.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	pid := cmd.Process().Pid()
+	waited := make(chan error, 1)
+	go func() { waited <- cmd.Wait() }()
+
+	if err := p.Kill(-pid, syscall.SIGKILL); err != nil {
+		t.Fatalf("Kill() error = %v", err)
+	}
+	if err := <-waited; err == nil {
+		t.Error("Wait() of a killed command succeeded")
+	}
+	if stdout.s != "zzz" {
+		t.Errorf("stdout = %q", stdout.s)
+	}
+	if err := p.Kill(pid, 0); !errors.Is(err, syscall.ESRCH) {
+		t.Errorf("Kill() of an ended process = %v, want ESRCH", err)
+	}
+	if signals := p.Signals(); len(signals) != 2 || signals[0] != (workertest.Signal{Pid: -pid, Signal: syscall.SIGKILL}) {
+		t.Errorf("Signals() = %v", signals)
+	}
+
+	p.FailKill(pid, syscall.EPERM)
+	if err := p.Kill(pid, syscall.SIGTERM); !errors.Is(err, syscall.EPERM) {
+		t.Errorf("Kill() = %v, want EPERM", err)
+	}
+}
+
+type stringWriter struct{ s string }
+
+func (w *stringWriter) Write(p []byte) (int, error) {
+	w.s += string(p)
+	return len(p), nil
+}